@@ -0,0 +1,355 @@
+// Package main is a local webhook simulator for the sandbox billing provider.
+//
+// It signs provider-shaped events with the Standard Webhooks scheme and posts
+// them to the API's webhook endpoint, so the subscription lifecycle can be
+// exercised without a Polar account:
+//
+//	go run ./cmd/billing-sim checkout -customer organization-test-123
+//	go run ./cmd/billing-sim send created -customer organization-test-123
+//	go run ./cmd/billing-sim lifecycle -customer organization-test-123
+//	go run ./cmd/billing-sim replay -file events.jsonl
+//
+// The secret defaults to WEBHOOK_SECRET from the environment or app.env.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/infra/sandbox"
+	polarpkg "github.com/moasq/go-b2b-starter/internal/platform/polar"
+)
+
+const usage = `Usage: billing-sim <command> [event] [flags]
+
+Commands:
+  checkout   Print a sandbox checkout session ID for the verify-payment endpoint
+  send       Send one event: created, renewed, past-due, cancel-at-period-end,
+             canceled, customer-updated or meter-grant
+  lifecycle  Send created, renewed, cancel-at-period-end and canceled in order
+  replay     Send events read from a JSON lines file ({"type": ..., "data": {...}})
+
+Run "billing-sim <command> -h" for the command's flags.
+`
+
+// invoicesProcessedMeterSlug is the meter the billing module tracks quota with
+const invoicesProcessedMeterSlug = "invoice.processed"
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "checkout":
+		err = runCheckout(os.Args[2:])
+	case "send":
+		err = runSend(os.Args[2:])
+	case "lifecycle":
+		err = runLifecycle(os.Args[2:])
+	case "replay":
+		err = runReplay(os.Args[2:])
+	case "-h", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "billing-sim: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// target holds the flags shared by every command that posts events.
+type target struct {
+	url    string
+	secret string
+	dryRun bool
+}
+
+func (t *target) register(fs *flag.FlagSet) {
+	fs.StringVar(&t.url, "url", "http://localhost:8080/api/webhooks/polar", "webhook endpoint")
+	fs.StringVar(&t.secret, "secret", loadWebhookSecret(), "webhook secret (default WEBHOOK_SECRET)")
+	fs.BoolVar(&t.dryRun, "dry-run", false, "print events instead of sending them")
+}
+
+// subject holds the flags identifying the simulated customer.
+type subject struct {
+	customer string
+	product  string
+	at       string
+}
+
+func (s *subject) register(fs *flag.FlagSet) {
+	fs.StringVar(&s.customer, "customer", "", "external customer ID (the organization's Stytch org ID)")
+	fs.StringVar(&s.product, "product", sandbox.ProductStarter, "sandbox product ID")
+	fs.StringVar(&s.at, "at", "", "subscription start date, YYYY-MM-DD (default today)")
+}
+
+func (s *subject) subscription() (*domain.Subscription, error) {
+	if s.customer == "" {
+		return nil, fmt.Errorf("-customer is required")
+	}
+	start := time.Now()
+	if s.at != "" {
+		parsed, err := time.Parse(time.DateOnly, s.at)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -at: %w", err)
+		}
+		start = parsed
+	}
+	return sandbox.NewSubscription(s.customer, s.product, start)
+}
+
+func runCheckout(args []string) error {
+	fs := flag.NewFlagSet("checkout", flag.ExitOnError)
+	var subj subject
+	subj.register(fs)
+	outcome := fs.String("outcome", sandbox.OutcomeSucceeded, "succeeded, pending, expired or failed")
+	_ = fs.Parse(args)
+
+	if subj.customer == "" {
+		return fmt.Errorf("-customer is required")
+	}
+	sessionID, err := sandbox.CheckoutSessionID(*outcome, subj.product, subj.customer)
+	if err != nil {
+		return err
+	}
+	fmt.Println(sessionID)
+	return nil
+}
+
+func runSend(args []string) error {
+	fs := flag.NewFlagSet("send", flag.ExitOnError)
+	var tgt target
+	var subj subject
+	tgt.register(fs)
+	subj.register(fs)
+	invoices := fs.Int("invoices", 50, "remaining invoices for customer-updated")
+	credits := fs.Int("credits", 50, "available credits for meter-grant")
+
+	// The event name comes first: send <event> [flags]
+	if len(args) > 0 && (args[0] == "-h" || args[0] == "--help") {
+		_ = fs.Parse(args)
+	}
+	if len(args) == 0 || args[0] == "" || args[0][0] == '-' {
+		return fmt.Errorf("send takes an event name before its flags")
+	}
+	name := args[0]
+	_ = fs.Parse(args[1:])
+
+	sub, err := subj.subscription()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	var event *sandbox.Event
+	switch name {
+	case "created":
+		event, err = sandbox.SubscriptionEvent("subscription.created", sub, now)
+	case "renewed":
+		event, err = sandbox.SubscriptionEvent("subscription.updated", renewed(sub), now)
+	case "past-due":
+		sub.SubscriptionStatus = "past_due"
+		event, err = sandbox.SubscriptionEvent("subscription.updated", sub, now)
+	case "cancel-at-period-end":
+		sub.CancelAtPeriodEnd = true
+		event, err = sandbox.SubscriptionEvent("subscription.updated", sub, now)
+	case "canceled":
+		event, err = sandbox.SubscriptionEvent("subscription.canceled", canceled(sub, now), now)
+	case "customer-updated":
+		event = sandbox.CustomerUpdatedEvent(subj.customer, map[string]string{"invoice_count": strconv.Itoa(*invoices)}, now)
+	case "meter-grant":
+		event = sandbox.MeterGrantEvent(subj.customer, invoicesProcessedMeterSlug, int32(*credits), now)
+	default:
+		return fmt.Errorf("unknown event %q", name)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tgt.send(event)
+}
+
+func runLifecycle(args []string) error {
+	fs := flag.NewFlagSet("lifecycle", flag.ExitOnError)
+	var tgt target
+	var subj subject
+	tgt.register(fs)
+	subj.register(fs)
+	_ = fs.Parse(args)
+
+	sub, err := subj.subscription()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	next := renewed(sub)
+	ending := *next
+	ending.CancelAtPeriodEnd = true
+
+	steps := []struct {
+		eventType string
+		sub       *domain.Subscription
+	}{
+		{"subscription.created", sub},
+		{"subscription.updated", next},
+		{"subscription.updated", &ending},
+		{"subscription.canceled", canceled(&ending, ending.CurrentPeriodEnd)},
+	}
+
+	for _, step := range steps {
+		event, err := sandbox.SubscriptionEvent(step.eventType, step.sub, now)
+		if err != nil {
+			return err
+		}
+		if err := tgt.send(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runReplay(args []string) error {
+	fs := flag.NewFlagSet("replay", flag.ExitOnError)
+	var tgt target
+	tgt.register(fs)
+	file := fs.String("file", "", "JSON lines file with one event per line")
+	_ = fs.Parse(args)
+
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+	f, err := os.Open(*file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+		var event sandbox.Event
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if event.Type == "" {
+			return fmt.Errorf("line %d: event has no type", line)
+		}
+		// Replayed events are re-stamped so they pass the timestamp tolerance
+		event.Timestamp = time.Now().UTC()
+		if err := tgt.send(&event); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// send signs the event and posts it to the webhook endpoint.
+func (t *target) send(event *sandbox.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	if t.dryRun {
+		fmt.Println(string(body))
+		return nil
+	}
+	if t.secret == "" {
+		return fmt.Errorf("webhook secret is required (-secret or WEBHOOK_SECRET)")
+	}
+
+	webhookID, err := newWebhookID()
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	signature := polarpkg.ComputeWebhookSignature(t.secret, webhookID, timestamp, body)
+
+	req, err := http.NewRequest(http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("webhook-id", webhookID)
+	req.Header.Set("webhook-timestamp", timestamp)
+	req.Header.Set("webhook-signature", "v1,"+signature)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send %s: %w", event.Type, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	fmt.Printf("%s %s -> %d %s\n", webhookID, event.Type, resp.StatusCode, bytes.TrimSpace(respBody))
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("%s was rejected with status %d", event.Type, resp.StatusCode)
+	}
+	return nil
+}
+
+// renewed returns the subscription moved to its next billing period.
+func renewed(sub *domain.Subscription) *domain.Subscription {
+	next := *sub
+	next.SubscriptionStatus = "active"
+	next.CurrentPeriodStart = sub.CurrentPeriodEnd
+	next.CurrentPeriodEnd = sub.CurrentPeriodEnd.AddDate(0, 1, 0)
+	return &next
+}
+
+// canceled returns the subscription canceled at the given time.
+func canceled(sub *domain.Subscription, at time.Time) *domain.Subscription {
+	ended := *sub
+	ended.SubscriptionStatus = "canceled"
+	ended.CancelAtPeriodEnd = false
+	canceledAt := at.UTC()
+	ended.CanceledAt = &canceledAt
+	return &ended
+}
+
+func newWebhookID() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook ID: %w", err)
+	}
+	return "msg_sandbox_" + hex.EncodeToString(buf), nil
+}
+
+// loadWebhookSecret reads WEBHOOK_SECRET from the environment or app.env.
+func loadWebhookSecret() string {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+	_ = v.ReadInConfig()
+
+	return v.GetString("WEBHOOK_SECRET")
+}
@@ -0,0 +1,30 @@
+// Package main checks that the API is ready to run in this environment.
+//
+// It validates configuration, connects to Postgres and Redis, verifies that
+// the enabled signing keys can sign tokens, and makes lightweight calls to the
+// auth, OCR, LLM and billing providers, then prints a pass/fail report:
+//
+//	go run ./cmd/doctor
+//
+// Settings are read from the environment and app.env, like the API server.
+// The exit status is 1 if any check failed.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/joho/godotenv"
+
+	"github.com/moasq/go-b2b-starter/internal/bootstrap"
+)
+
+func main() {
+	if err := godotenv.Load("app.env"); err != nil {
+		log.Printf("Warning: Error loading app.env file: %v", err)
+	}
+
+	if !bootstrap.RunDoctor(os.Stdout) {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,338 @@
+// Package main moves an organization between instances through the
+// portability admin API.
+//
+// It downloads an organization's bundle from one instance and imports it into
+// an existing organization on another, printing the dry run plan before
+// anything is written and following the import until it finishes:
+//
+//	go run ./cmd/tenant-migrate export -url https://old.example.com/api/admin/portability -org 12 -out acme.json
+//	go run ./cmd/tenant-migrate import -org 3 -file acme.json -strategy skip -requested-by ops@example.com
+//	go run ./cmd/tenant-migrate import -org 3 -file acme.json -strategy overwrite -dry-run
+//	go run ./cmd/tenant-migrate status -id 7
+//
+// Document files are not part of the bundle: copy the objects to the target
+// instance's storage under the same keys before importing.
+//
+// The admin token defaults to ADMIN_API_TOKEN from the environment or app.env.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/portability/domain"
+)
+
+const usage = `Usage: tenant-migrate <command> [flags]
+
+Commands:
+  export  Download an organization's bundle to a file
+  import  Plan a bundle import into an existing organization, then run it
+          and wait for it to finish (only the plan with -dry-run)
+  status  Print an import's progress and results
+
+Run "tenant-migrate <command> -h" for the command's flags.
+`
+
+// pollInterval is how often import follows a running import
+const pollInterval = 2 * time.Second
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "export":
+		err = runExport(os.Args[2:])
+	case "import":
+		err = runImport(os.Args[2:])
+	case "status":
+		err = runStatus(os.Args[2:])
+	case "-h", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tenant-migrate: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// instance holds the flags shared by every command that calls the API.
+type instance struct {
+	url   string
+	token string
+}
+
+func (i *instance) register(fs *flag.FlagSet) {
+	fs.StringVar(&i.url, "url", "http://localhost:8080/api/admin/portability", "portability admin API")
+	fs.StringVar(&i.token, "token", loadAdminToken(), "admin token (default ADMIN_API_TOKEN)")
+}
+
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	var inst instance
+	inst.register(fs)
+	orgID := fs.Int("org", 0, "organization ID to export")
+	out := fs.String("out", "", "bundle file to write (default organization-<slug>-export.json)")
+	_ = fs.Parse(args)
+
+	if *orgID <= 0 {
+		return fmt.Errorf("-org is required")
+	}
+
+	body, err := inst.call(http.MethodGet, fmt.Sprintf("/organizations/%d/export", *orgID), nil)
+	if err != nil {
+		return err
+	}
+
+	var bundle domain.Bundle
+	if err := json.Unmarshal(body, &bundle); err != nil {
+		return fmt.Errorf("failed to decode bundle: %w", err)
+	}
+
+	path := *out
+	if path == "" {
+		path = fmt.Sprintf("organization-%s-export.json", bundle.Organization.Slug)
+	}
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		return fmt.Errorf("failed to write bundle: %w", err)
+	}
+
+	fmt.Printf("exported %s: %d users, %d documents -> %s\n",
+		bundle.Organization.Slug, len(bundle.Users), len(bundle.Documents), path)
+	return nil
+}
+
+func runImport(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	var inst instance
+	inst.register(fs)
+	orgID := fs.Int("org", 0, "target organization ID")
+	file := fs.String("file", "", "bundle file written by export")
+	strategy := fs.String("strategy", string(domain.ImportStrategySkip), "existing items: skip, overwrite or fail")
+	dryRun := fs.Bool("dry-run", false, "print the plan without importing")
+	requestedBy := fs.String("requested-by", "", "operator running the import (required unless -dry-run)")
+	_ = fs.Parse(args)
+
+	if *orgID <= 0 {
+		return fmt.Errorf("-org is required")
+	}
+	if *file == "" {
+		return fmt.Errorf("-file is required")
+	}
+	if !*dryRun && strings.TrimSpace(*requestedBy) == "" {
+		return fmt.Errorf("-requested-by is required")
+	}
+
+	raw, err := os.ReadFile(*file)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle: %w", err)
+	}
+
+	path := fmt.Sprintf("/organizations/%d/imports", *orgID)
+	request := map[string]any{
+		"bundle":       json.RawMessage(raw),
+		"strategy":     *strategy,
+		"requested_by": *requestedBy,
+	}
+
+	// Always plan first, so nothing is written if the bundle would be rejected
+	request["dry_run"] = true
+	body, err := inst.call(http.MethodPost, path, request)
+	if err != nil {
+		return err
+	}
+	var plan domain.ImportPlan
+	if err := json.Unmarshal(body, &plan); err != nil {
+		return fmt.Errorf("failed to decode plan: %w", err)
+	}
+	printPlan(&plan)
+
+	if !plan.Valid {
+		return fmt.Errorf("bundle would be rejected; fix the issues above or use another -strategy")
+	}
+	if *dryRun {
+		return nil
+	}
+
+	request["dry_run"] = false
+	body, err = inst.call(http.MethodPost, path, request)
+	if err != nil {
+		return err
+	}
+	var imp domain.OrganizationImport
+	if err := json.Unmarshal(body, &imp); err != nil {
+		return fmt.Errorf("failed to decode import: %w", err)
+	}
+	fmt.Printf("started import %d\n", imp.ID)
+
+	return inst.follow(imp.ID)
+}
+
+func runStatus(args []string) error {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	var inst instance
+	inst.register(fs)
+	id := fs.Int("id", 0, "import ID")
+	_ = fs.Parse(args)
+
+	if *id <= 0 {
+		return fmt.Errorf("-id is required")
+	}
+
+	imp, err := inst.getImport(int32(*id))
+	if err != nil {
+		return err
+	}
+	printImport(imp)
+	return nil
+}
+
+// follow polls the import until it is no longer running and prints its results.
+func (i *instance) follow(id int32) error {
+	for {
+		imp, err := i.getImport(id)
+		if err != nil {
+			return err
+		}
+		if imp.Status != domain.ImportStatusRunning {
+			printImport(imp)
+			if imp.Status == domain.ImportStatusFailed {
+				return fmt.Errorf("import %d failed: %s", imp.ID, imp.Error)
+			}
+			return nil
+		}
+
+		fmt.Printf("import %d: %d/%d items\n", imp.ID, imp.ProcessedItems, imp.TotalItems)
+		time.Sleep(pollInterval)
+	}
+}
+
+func (i *instance) getImport(id int32) (*domain.OrganizationImport, error) {
+	body, err := i.call(http.MethodGet, fmt.Sprintf("/imports/%d", id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var imp domain.OrganizationImport
+	if err := json.Unmarshal(body, &imp); err != nil {
+		return nil, fmt.Errorf("failed to decode import: %w", err)
+	}
+	return &imp, nil
+}
+
+// call sends the request with the admin token and returns the response data.
+// The bundle download is returned as is; other responses are unwrapped from
+// their {"success": true, "data": ...} envelope.
+func (i *instance) call(method, path string, payload any) ([]byte, error) {
+	if i.token == "" {
+		return nil, fmt.Errorf("admin token is required (-token or ADMIN_API_TOKEN)")
+	}
+
+	var reader io.Reader
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequest(method, strings.TrimSuffix(i.url, "/")+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Admin-Token", i.token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	client := &http.Client{Timeout: 5 * time.Minute}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%s %s failed: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 300 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(body, &apiErr) == nil && apiErr.Message != "" {
+			return nil, fmt.Errorf("%s %s: %d %s", method, path, resp.StatusCode, apiErr.Message)
+		}
+		return nil, fmt.Errorf("%s %s: %d %s", method, path, resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	if resp.Header.Get("Content-Disposition") != "" {
+		return body, nil
+	}
+
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return envelope.Data, nil
+}
+
+func printPlan(plan *domain.ImportPlan) {
+	fmt.Printf("plan for %s -> organization %d (strategy %s): %d items\n",
+		plan.SourceOrganization, plan.OrganizationID, plan.Strategy, plan.TotalItems)
+	for _, section := range plan.Sections {
+		fmt.Printf("  %-16s create %d, update %d, skip %d, conflict %d, invalid %d\n",
+			section.Section, section.Create, section.Update, section.Skip, section.Conflict, section.Invalid)
+	}
+	for _, issue := range plan.Issues {
+		fmt.Printf("  ! %s %s: %s\n", issue.Section, issue.Item, issue.Message)
+	}
+}
+
+func printImport(imp *domain.OrganizationImport) {
+	fmt.Printf("import %d of %s -> organization %d: %s, %d/%d items\n",
+		imp.ID, imp.SourceOrganization, imp.OrganizationID, imp.Status, imp.ProcessedItems, imp.TotalItems)
+	for _, section := range imp.Sections {
+		fmt.Printf("  %-16s created %d, updated %d, skipped %d, failed %d\n",
+			section.Section, section.Created, section.Updated, section.Skipped, section.Failed)
+	}
+	for _, failure := range imp.Failures {
+		fmt.Printf("  ! %s %s: %s\n", failure.Section, failure.Item, failure.Message)
+	}
+	if imp.Error != "" {
+		fmt.Printf("  error: %s\n", imp.Error)
+	}
+}
+
+// loadAdminToken reads ADMIN_API_TOKEN from the environment or app.env.
+func loadAdminToken() string {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+	_ = v.ReadInConfig()
+
+	return v.GetString("ADMIN_API_TOKEN")
+}
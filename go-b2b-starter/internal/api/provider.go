@@ -6,8 +6,15 @@ import (
 	"github.com/moasq/go-b2b-starter/internal/modules/auth"
 	"github.com/moasq/go-b2b-starter/internal/modules/billing"
 	"github.com/moasq/go-b2b-starter/internal/modules/cognitive"
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance"
 	"github.com/moasq/go-b2b-starter/internal/modules/documents"
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding"
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations"
+	"github.com/moasq/go-b2b-starter/internal/modules/portability"
+	"github.com/moasq/go-b2b-starter/internal/modules/search"
+	"github.com/moasq/go-b2b-starter/internal/modules/support"
+	"github.com/moasq/go-b2b-starter/internal/platform/jobs"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
 	server "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
 )
 
@@ -17,12 +24,26 @@ import (
 // 3. BillingHandler - Handles billing status and subscription routes (uses billing module)
 // 4. DocumentsRoutes - Handles PDF document upload and management routes
 // 5. CognitiveRoutes - Handles AI/RAG chat and document search routes
+// 6. SearchRoutes - Handles global search across users, documents and conversations
+// 7. SupportRoutes - Handles support tickets and the public contact form
+// 8. LogLevelHandler - Handles runtime log level changes for operators
+// 9. JobsHandler - Handles the background job run history for operators
+// 10. ComplianceRoutes - Handles tenant data purges and verification reports for operators
+// 11. OnboardingRoutes - Handles the organization onboarding checklist
+// 12. PortabilityRoutes - Handles organization exports and imports between instances for operators
 type moduleRoutes struct {
 	OrganizationRoutes  *organizations.Routes
 	RbacRoutes          *auth.Routes
 	SubscriptionHandler *billing.Handler
 	DocumentsRoutes     *documents.Routes
 	CognitiveRoutes     *cognitive.Routes
+	SearchRoutes        *search.Routes
+	SupportRoutes       *support.Routes
+	LogLevelHandler     *logger.LevelHandler
+	JobsHandler         *jobs.Handler
+	ComplianceRoutes    *compliance.Routes
+	OnboardingRoutes    *onboarding.Routes
+	PortabilityRoutes   *portability.Routes
 }
 
 // Init sets up all module dependencies and registers API routes
@@ -45,6 +66,13 @@ func registerAPI(container *dig.Container) error {
 		subscriptionHandler *billing.Handler,
 		documentsRoutes *documents.Routes,
 		cognitiveRoutes *cognitive.Routes,
+		searchRoutes *search.Routes,
+		supportRoutes *support.Routes,
+		logLevelHandler *logger.LevelHandler,
+		jobsHandler *jobs.Handler,
+		complianceRoutes *compliance.Routes,
+		onboardingRoutes *onboarding.Routes,
+		portabilityRoutes *portability.Routes,
 	) *moduleRoutes {
 		return &moduleRoutes{
 			OrganizationRoutes:  organizationRoutes,
@@ -52,6 +80,13 @@ func registerAPI(container *dig.Container) error {
 			SubscriptionHandler: subscriptionHandler,
 			DocumentsRoutes:     documentsRoutes,
 			CognitiveRoutes:     cognitiveRoutes,
+			SearchRoutes:        searchRoutes,
+			SupportRoutes:       supportRoutes,
+			LogLevelHandler:     logLevelHandler,
+			JobsHandler:         jobsHandler,
+			ComplianceRoutes:    complianceRoutes,
+			OnboardingRoutes:    onboardingRoutes,
+			PortabilityRoutes:   portabilityRoutes,
 		}
 	}); err != nil {
 		return err
@@ -67,6 +102,13 @@ func registerAPI(container *dig.Container) error {
 		srv.RegisterRoutes(modules.SubscriptionHandler.Routes, server.ApiPrefix)
 		srv.RegisterRoutes(modules.DocumentsRoutes.Routes, server.ApiPrefix)
 		srv.RegisterRoutes(modules.CognitiveRoutes.Routes, server.ApiPrefix)
+		srv.RegisterRoutes(modules.SearchRoutes.Routes, server.ApiPrefix)
+		srv.RegisterRoutes(modules.SupportRoutes.Routes, server.ApiPrefix)
+		srv.RegisterRoutes(modules.LogLevelHandler.Routes, server.ApiPrefix)
+		srv.RegisterRoutes(modules.JobsHandler.Routes, server.ApiPrefix)
+		srv.RegisterRoutes(modules.ComplianceRoutes.Routes, server.ApiPrefix)
+		srv.RegisterRoutes(modules.OnboardingRoutes.Routes, server.ApiPrefix)
+		srv.RegisterRoutes(modules.PortabilityRoutes.Routes, server.ApiPrefix)
 	})
 }
 
@@ -96,5 +138,30 @@ func setupDependencies(container *dig.Container) error {
 		return err
 	}
 
+	// Initialize search API (command-palette global search)
+	if err := search.NewProvider(container).RegisterDependencies(); err != nil {
+		return err
+	}
+
+	// Initialize support API (tickets and contact form)
+	if err := support.NewProvider(container).RegisterDependencies(); err != nil {
+		return err
+	}
+
+	// Initialize compliance API (tenant purges and verification reports)
+	if err := compliance.NewProvider(container).RegisterDependencies(); err != nil {
+		return err
+	}
+
+	// Initialize onboarding API (organization setup checklist)
+	if err := onboarding.NewProvider(container).RegisterDependencies(); err != nil {
+		return err
+	}
+
+	// Initialize portability API (organization exports and imports)
+	if err := portability.NewProvider(container).RegisterDependencies(); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,353 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/moasq/go-b2b-starter/internal/db/postgres"
+	"github.com/moasq/go-b2b-starter/internal/modules/auth/adapters/keycloak"
+	"github.com/moasq/go-b2b-starter/internal/modules/auth/adapters/stytch"
+	authCmd "github.com/moasq/go-b2b-starter/internal/modules/auth/cmd"
+	billingServices "github.com/moasq/go-b2b-starter/internal/modules/billing/app/services"
+	documentServices "github.com/moasq/go-b2b-starter/internal/modules/documents/app/services"
+	orgServices "github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	emailInfra "github.com/moasq/go-b2b-starter/internal/platform/email/infra"
+	llmInfra "github.com/moasq/go-b2b-starter/internal/platform/llm/infra"
+	ocrInfra "github.com/moasq/go-b2b-starter/internal/platform/ocr/infra"
+	polarpkg "github.com/moasq/go-b2b-starter/internal/platform/polar"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+	serverConfig "github.com/moasq/go-b2b-starter/internal/platform/server/config"
+)
+
+// doctorCheckTimeout bounds each connectivity and credential check
+const doctorCheckTimeout = 10 * time.Second
+
+// Doctor check outcomes
+const (
+	doctorPass = "PASS"
+	doctorFail = "FAIL"
+	doctorSkip = "SKIP"
+)
+
+// doctorCheck is one line of the doctor report. run returns the outcome and
+// a short detail (the error for failures, what was checked otherwise).
+type doctorCheck struct {
+	name string
+	run  func(ctx context.Context) (string, string)
+}
+
+// RunDoctor validates configuration, connects to Postgres and Redis, signs
+// and verifies a token with every enabled signing key, and makes lightweight
+// authenticated calls to the auth, OCR, LLM and billing providers. It writes
+// a pass/fail report to w and returns false if any check failed.
+//
+// Disabled features and offline providers (fake, sandbox) are skipped.
+func RunDoctor(w io.Writer) bool {
+	checks := []doctorCheck{
+		{"config: server", checkServerConfig},
+		{"config: email", checkEmailConfig},
+		{"postgres", checkPostgres},
+		{"redis", checkRedis},
+		{"signing key: guest sessions", checkGuestSessionKey},
+		{"signing key: impersonation", checkImpersonationKey},
+		{"signing key: oauth clients", checkOAuthClientKey},
+		{"signing key: oidc provider", checkOIDCProviderKey},
+		{"signing key: preview embeds", checkPreviewEmbedKey},
+		{"auth provider", checkAuthProvider},
+		{"ocr", checkOCR},
+		{"llm", checkLLM},
+		{"billing", checkBilling},
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	failed := 0
+	for _, check := range checks {
+		ctx, cancel := context.WithTimeout(context.Background(), doctorCheckTimeout)
+		status, detail := check.run(ctx)
+		cancel()
+
+		if status == doctorFail {
+			failed++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", status, check.name, detail)
+	}
+	tw.Flush()
+
+	if failed > 0 {
+		fmt.Fprintf(w, "\n%d of %d checks failed\n", failed, len(checks))
+		return false
+	}
+	fmt.Fprintf(w, "\nall %d checks passed\n", len(checks))
+	return true
+}
+
+// doctorOnStartup reports whether Execute runs the doctor before booting.
+// It is on by default when ENV=PROD; set DOCTOR_ON_STARTUP=false to boot
+// without it (e.g. while a third-party provider is down).
+func doctorOnStartup() bool {
+	if !strings.EqualFold(os.Getenv("ENV"), string(serverConfig.PROD)) {
+		return false
+	}
+	enabled, err := strconv.ParseBool(os.Getenv("DOCTOR_ON_STARTUP"))
+	return err != nil || enabled
+}
+
+func checkServerConfig(ctx context.Context) (string, string) {
+	cfg, err := serverConfig.LoadConfig()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	return doctorPass, fmt.Sprintf("ENV=%s", cfg.Env)
+}
+
+func checkEmailConfig(ctx context.Context) (string, string) {
+	cfg, err := emailInfra.LoadConfig()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	if cfg.SMTPHost == "" {
+		return doctorSkip, "EMAIL_SMTP_HOST is empty; emails are logged, not sent"
+	}
+	return doctorPass, fmt.Sprintf("smtp %s:%d from %s", cfg.SMTPHost, cfg.SMTPPort, cfg.From)
+}
+
+func checkPostgres(ctx context.Context) (string, string) {
+	cfg, err := postgres.LoadConfig()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+
+	conn, err := pgx.Connect(ctx, cfg.ConnectionString())
+	if err != nil {
+		return doctorFail, fmt.Sprintf("unable to connect: %v", err)
+	}
+	defer conn.Close(context.Background())
+
+	if err := conn.Ping(ctx); err != nil {
+		return doctorFail, fmt.Sprintf("unable to ping: %v", err)
+	}
+	return doctorPass, fmt.Sprintf("%s:%s/%s", cfg.Host, cfg.Port, cfg.DBName)
+}
+
+func checkRedis(ctx context.Context) (string, string) {
+	cfg, err := redis.LoadConfig()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+
+	addr := fmt.Sprintf("%s:%s", cfg.Host, cfg.Port)
+	rdb := goredis.NewClient(&goredis.Options{
+		Addr:     addr,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	})
+	defer rdb.Close()
+
+	if err := rdb.Ping(ctx).Err(); err != nil {
+		return doctorFail, fmt.Sprintf("unable to ping: %v", err)
+	}
+	return doctorPass, addr
+}
+
+func checkGuestSessionKey(ctx context.Context) (string, string) {
+	policy, err := orgServices.LoadGuestSessionPolicy()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	if !policy.Enabled {
+		return doctorSkip, "GUEST_SESSIONS_ENABLED=false"
+	}
+	return checkSigningKey(jwt.SigningMethodHS256, []byte(policy.Secret), []byte(policy.Secret))
+}
+
+func checkImpersonationKey(ctx context.Context) (string, string) {
+	policy, err := orgServices.LoadImpersonationPolicy()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	if !policy.Enabled {
+		return doctorSkip, "IMPERSONATION_ENABLED=false"
+	}
+	return checkSigningKey(jwt.SigningMethodHS256, []byte(policy.Secret), []byte(policy.Secret))
+}
+
+func checkOAuthClientKey(ctx context.Context) (string, string) {
+	policy, err := orgServices.LoadOAuthClientPolicy()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	if !policy.Enabled {
+		return doctorSkip, "OAUTH_CLIENTS_ENABLED=false"
+	}
+	return checkSigningKey(jwt.SigningMethodHS256, []byte(policy.Secret), []byte(policy.Secret))
+}
+
+func checkOIDCProviderKey(ctx context.Context) (string, string) {
+	policy, err := orgServices.LoadOIDCProviderPolicy()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	if !policy.Enabled {
+		return doctorSkip, "OIDC_PROVIDER_ENABLED=false"
+	}
+	status, detail := checkSigningKey(jwt.SigningMethodRS256, policy.SigningKey, &policy.SigningKey.PublicKey)
+	if status == doctorPass && policy.KeySource != orgServices.OIDCKeySourceFile {
+		detail = fmt.Sprintf("%s with a %s key; set OIDC_PROVIDER_SIGNING_KEY_PATH before production", detail, policy.KeySource)
+	}
+	return status, detail
+}
+
+func checkPreviewEmbedKey(ctx context.Context) (string, string) {
+	cfg, err := documentServices.LoadPreviewEmbedConfig()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	if !cfg.Enabled {
+		return doctorSkip, "PREVIEW_EMBED_ENABLED=false"
+	}
+	return checkSigningKey(jwt.SigningMethodHS256, []byte(cfg.Secret), []byte(cfg.Secret))
+}
+
+// checkSigningKey signs a short-lived token and verifies it with the matching key.
+func checkSigningKey(method jwt.SigningMethod, signKey, verifyKey interface{}) (string, string) {
+	claims := jwt.RegisteredClaims{
+		Subject:   "doctor",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+	}
+
+	signed, err := jwt.NewWithClaims(method, claims).SignedString(signKey)
+	if err != nil {
+		return doctorFail, fmt.Sprintf("unable to sign: %v", err)
+	}
+
+	_, err = jwt.ParseWithClaims(signed, &jwt.RegisteredClaims{}, func(*jwt.Token) (interface{}, error) {
+		return verifyKey, nil
+	}, jwt.WithValidMethods([]string{method.Alg()}))
+	if err != nil {
+		return doctorFail, fmt.Sprintf("unable to verify: %v", err)
+	}
+
+	return doctorPass, method.Alg()
+}
+
+// checkAuthProvider fetches the provider's signing keys, which confirms the
+// provider is reachable and the project or realms exist.
+func checkAuthProvider(ctx context.Context) (string, string) {
+	if authCmd.LoadProviderName() == authCmd.ProviderKeycloak {
+		cfg, err := keycloak.LoadConfig()
+		if err != nil {
+			return doctorFail, err.Error()
+		}
+		for realm := range cfg.RealmConfigs {
+			if err := doctorGet(ctx, cfg.JWKSURL(realm), ""); err != nil {
+				return doctorFail, fmt.Sprintf("keycloak realm %s: %v", realm, err)
+			}
+		}
+		return doctorPass, fmt.Sprintf("keycloak, %d realm(s)", len(cfg.RealmConfigs))
+	}
+
+	cfg, err := stytch.LoadConfig()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	if err := doctorGet(ctx, cfg.JWKSURL, ""); err != nil {
+		return doctorFail, fmt.Sprintf("stytch: %v", err)
+	}
+	return doctorPass, fmt.Sprintf("stytch project %s", cfg.ProjectID)
+}
+
+func checkOCR(ctx context.Context) (string, string) {
+	cfg := ocrInfra.NewOCRConfig()
+	if cfg.Provider == ocrInfra.ProviderFake {
+		return doctorSkip, "OCR_PROVIDER=fake"
+	}
+	if err := cfg.Validate(); err != nil {
+		return doctorFail, err.Error()
+	}
+
+	// Listing models is free and only needs a valid key
+	modelsURL := strings.TrimSuffix(cfg.APIEndpoint, "/ocr") + "/models"
+	if err := doctorGet(ctx, modelsURL, cfg.MistralAPIKey); err != nil {
+		return doctorFail, fmt.Sprintf("mistral: %v", err)
+	}
+	return doctorPass, "mistral"
+}
+
+func checkLLM(ctx context.Context) (string, string) {
+	cfg := llmInfra.NewLLMConfig()
+	if cfg.Provider == llmInfra.ProviderFake {
+		return doctorSkip, "LLM_PROVIDER=fake"
+	}
+	if err := cfg.Validate(); err != nil {
+		return doctorFail, err.Error()
+	}
+
+	if err := doctorGet(ctx, "https://api.openai.com/v1/models/"+cfg.Model, cfg.APIKey); err != nil {
+		return doctorFail, fmt.Sprintf("openai: %v", err)
+	}
+	return doctorPass, fmt.Sprintf("openai %s", cfg.Model)
+}
+
+func checkBilling(ctx context.Context) (string, string) {
+	providerCfg, err := billingServices.LoadProviderConfig()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	if providerCfg.IsSandbox() {
+		return doctorSkip, "BILLING_PROVIDER=sandbox"
+	}
+
+	cfg, err := polarpkg.LoadConfig()
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+	client, err := polarpkg.NewClient(&cfg)
+	if err != nil {
+		return doctorFail, err.Error()
+	}
+
+	resp, err := client.Get(ctx, "/v1/products/?limit=1")
+	if err != nil {
+		return doctorFail, fmt.Sprintf("polar: %v", err)
+	}
+	resp.Body.Close()
+
+	if providerCfg.WebhookSecret == "" {
+		return doctorFail, "polar credentials valid, but WEBHOOK_SECRET is empty so webhooks are rejected"
+	}
+	return doctorPass, fmt.Sprintf("polar %s", cfg.BaseURL)
+}
+
+// doctorGet performs a GET request, with a bearer token if one is given, and
+// fails on any non-2xx response.
+func doctorGet(ctx context.Context, url, bearerToken string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("GET %s returned HTTP %d", url, resp.StatusCode)
+	}
+	return nil
+}
@@ -10,21 +10,30 @@ import (
 	authCmd "github.com/moasq/go-b2b-starter/internal/modules/auth/cmd"
 	billing "github.com/moasq/go-b2b-starter/internal/modules/billing/cmd"
 	cognitive "github.com/moasq/go-b2b-starter/internal/modules/cognitive/cmd"
+	compliance "github.com/moasq/go-b2b-starter/internal/modules/compliance/cmd"
 	db "github.com/moasq/go-b2b-starter/internal/db/cmd"
 	docs "github.com/moasq/go-b2b-starter/internal/docs/cmd"
 	documents "github.com/moasq/go-b2b-starter/internal/modules/documents/cmd"
+	email "github.com/moasq/go-b2b-starter/internal/platform/email/cmd"
 	eventbus "github.com/moasq/go-b2b-starter/internal/platform/eventbus/cmd"
 	files "github.com/moasq/go-b2b-starter/internal/modules/files/cmd"
+	jobs "github.com/moasq/go-b2b-starter/internal/platform/jobs/cmd"
 	llm "github.com/moasq/go-b2b-starter/internal/platform/llm/cmd"
 	logger "github.com/moasq/go-b2b-starter/internal/platform/logger/cmd"
 	ocr "github.com/moasq/go-b2b-starter/internal/platform/ocr/cmd"
+	onboarding "github.com/moasq/go-b2b-starter/internal/modules/onboarding/cmd"
 	orgDomain "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
 	organizations "github.com/moasq/go-b2b-starter/internal/modules/organizations/cmd"
 	paywall "github.com/moasq/go-b2b-starter/internal/modules/paywall/cmd"
+	portability "github.com/moasq/go-b2b-starter/internal/modules/portability/cmd"
 	polar "github.com/moasq/go-b2b-starter/internal/platform/polar/cmd"
 	redisCmd "github.com/moasq/go-b2b-starter/internal/platform/redis/cmd"
+	search "github.com/moasq/go-b2b-starter/internal/modules/search/cmd"
 	server "github.com/moasq/go-b2b-starter/internal/platform/server/cmd"
 	stytchCmd "github.com/moasq/go-b2b-starter/internal/platform/stytch/cmd"
+	support "github.com/moasq/go-b2b-starter/internal/modules/support/cmd"
+	transcription "github.com/moasq/go-b2b-starter/internal/platform/transcription/cmd"
+	warehouse "github.com/moasq/go-b2b-starter/internal/modules/warehouse/cmd"
 )
 
 // orgLookupAdapter adapts orgDomain.OrganizationRepository to auth.OrganizationLookup
@@ -36,13 +45,21 @@ func (a *orgLookupAdapter) GetByStytchID(ctx context.Context, stytchOrgID string
 	return a.repo.GetByStytchID(ctx, stytchOrgID)
 }
 
-// accLookupAdapter adapts orgDomain.AccountRepository to auth.AccountLookup
+// accLookupAdapter adapts orgDomain.AccountRepository to auth.AccountLookup.
+// Suspended accounts resolve to auth.ErrAccountSuspended.
 type accLookupAdapter struct {
 	repo orgDomain.AccountRepository
 }
 
 func (a *accLookupAdapter) GetByEmail(ctx context.Context, orgID int32, email string) (auth.AccountEntity, error) {
-	return a.repo.GetByEmail(ctx, orgID, email)
+	account, err := a.repo.GetByEmail(ctx, orgID, email)
+	if err != nil {
+		return nil, err
+	}
+	if account.IsSuspended() {
+		return nil, auth.ErrAccountSuspended
+	}
+	return account, nil
 }
 
 func InitMods(container *dig.Container) {
@@ -51,6 +68,10 @@ func InitMods(container *dig.Container) {
 	server.Init(container)
 	logger.Init(container)
 	db.Init(container)
+	// Job run history must be initialized before modules that run background jobs
+	if err := jobs.Init(container); err != nil {
+		panic(err)
+	}
 	files.Init(container)
 	if err := eventbus.Init(container); err != nil {
 		panic(err)
@@ -58,6 +79,9 @@ func InitMods(container *dig.Container) {
 	if err := llm.Init(container); err != nil {
 		panic(err)
 	}
+	if err := email.Init(container); err != nil {
+		panic(err)
+	}
 
 	// Polar package must be initialized before payment module (payment depends on Polar client)
 	if err := polar.Init(container); err != nil {
@@ -130,6 +154,12 @@ func InitMods(container *dig.Container) {
 		panic(err)
 	}
 
+	// Transcription service (Whisper API for audio and video documents)
+	// Must be initialized before documents module (documents depends on transcription)
+	if err := transcription.Init(container); err != nil {
+		panic(err)
+	}
+
 	// Documents module (PDF upload and text extraction)
 	if err := documents.Init(container); err != nil {
 		panic(err)
@@ -141,6 +171,37 @@ func InitMods(container *dig.Container) {
 		panic(err)
 	}
 
+	// Search module (global search across users, documents and conversations)
+	if err := search.Init(container); err != nil {
+		panic(err)
+	}
+
+	// Support module (tickets and contact form, attachments via files module)
+	if err := support.Init(container); err != nil {
+		panic(err)
+	}
+
+	// Onboarding module (per-organization setup checklist)
+	// Note: This also wires the event listener for DocumentUploaded events
+	if err := onboarding.Init(container); err != nil {
+		panic(err)
+	}
+
+	// Warehouse module (scheduled anonymized exports to S3 for analytics)
+	if err := warehouse.Init(container); err != nil {
+		panic(err)
+	}
+
+	// Compliance module (tenant data purges with verification reports)
+	if err := compliance.Init(container); err != nil {
+		panic(err)
+	}
+
+	// Portability module (organization exports and imports between instances)
+	if err := portability.Init(container); err != nil {
+		panic(err)
+	}
+
 	// api
 	api.Init(container)
 }
@@ -2,6 +2,7 @@ package bootstrap
 
 import (
 	"log"
+	"os"
 
 	"github.com/joho/godotenv"
 	"go.uber.org/dig"
@@ -14,6 +15,11 @@ func Execute() {
 		log.Printf("Warning: Error loading app.env file: %v", err)
 	}
 
+	// Refuse to boot a production server that fails its startup checks
+	if doctorOnStartup() && !RunDoctor(os.Stdout) {
+		log.Fatal("startup checks failed; fix the configuration or set DOCTOR_ON_STARTUP=false")
+	}
+
 	container := dig.New()
 
 	InitMods(container)
@@ -6,6 +6,7 @@ package helpers
 import (
 	"encoding/json"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/pgvector/pgvector-go"
 )
@@ -47,6 +48,43 @@ func FromPgInt4(i pgtype.Int4) int32 {
 	return i.Int32
 }
 
+// FromPgInt4Ptr converts pgtype.Int4 to a pointer to int32, nil when NULL
+func FromPgInt4Ptr(i pgtype.Int4) *int32 {
+	if !i.Valid {
+		return nil
+	}
+	return &i.Int32
+}
+
+// ToPgUUID converts a uuid.UUID to pgtype.UUID
+func ToPgUUID(u uuid.UUID) pgtype.UUID {
+	return pgtype.UUID{Bytes: u, Valid: true}
+}
+
+// FromPgUUID converts pgtype.UUID to uuid.UUID, uuid.Nil when NULL
+func FromPgUUID(u pgtype.UUID) uuid.UUID {
+	if !u.Valid {
+		return uuid.Nil
+	}
+	return u.Bytes
+}
+
+// ToPgFloat4Ptr converts a pointer to float32 to pgtype.Float4
+func ToPgFloat4Ptr(f *float32) pgtype.Float4 {
+	if f == nil {
+		return pgtype.Float4{Valid: false}
+	}
+	return pgtype.Float4{Float32: *f, Valid: true}
+}
+
+// FromPgFloat4Ptr converts pgtype.Float4 to a pointer to float32, nil when NULL
+func FromPgFloat4Ptr(f pgtype.Float4) *float32 {
+	if !f.Valid {
+		return nil
+	}
+	return &f.Float32
+}
+
 // ToPgBool converts a bool to pgtype.Bool
 func ToPgBool(b bool) pgtype.Bool {
 	return pgtype.Bool{Bool: b, Valid: true}
@@ -10,18 +10,43 @@ import (
 	"go.uber.org/dig"
 
 	// Domain interfaces - these are the interfaces we provide
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
 	billingDomain "github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
 	cognitiveDomain "github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
+	complianceDomain "github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
 	documentDomain "github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
 	fileDomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	onboardingDomain "github.com/moasq/go-b2b-starter/internal/modules/onboarding/domain"
 	orgDomain "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	portabilityDomain "github.com/moasq/go-b2b-starter/internal/modules/portability/domain"
+	searchDomain "github.com/moasq/go-b2b-starter/internal/modules/search/domain"
+	supportDomain "github.com/moasq/go-b2b-starter/internal/modules/support/domain"
+	warehouseDomain "github.com/moasq/go-b2b-starter/internal/modules/warehouse/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+
+	// Module configuration needed by the repositories
+	orgServices "github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+
+	// Seat limits enforced by the organizations repositories
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+
+	// Cache behind the cached repositories
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
 
 	// Repository implementations from module infra layers
+	authRepos "github.com/moasq/go-b2b-starter/internal/modules/auth/adapters/postgres"
 	billingRepos "github.com/moasq/go-b2b-starter/internal/modules/billing/infra/repositories"
 	cognitiveRepos "github.com/moasq/go-b2b-starter/internal/modules/cognitive/infra/repositories"
+	complianceRepos "github.com/moasq/go-b2b-starter/internal/modules/compliance/infra/repositories"
 	documentRepos "github.com/moasq/go-b2b-starter/internal/modules/documents/infra/repositories"
 	fileInfra "github.com/moasq/go-b2b-starter/internal/modules/files/infra"
+	onboardingRepos "github.com/moasq/go-b2b-starter/internal/modules/onboarding/infra/repositories"
 	orgRepos "github.com/moasq/go-b2b-starter/internal/modules/organizations/infra/repositories"
+	portabilityRepos "github.com/moasq/go-b2b-starter/internal/modules/portability/infra/repositories"
+	searchRepos "github.com/moasq/go-b2b-starter/internal/modules/search/infra/repositories"
+	supportRepos "github.com/moasq/go-b2b-starter/internal/modules/support/infra/repositories"
+	warehouseRepos "github.com/moasq/go-b2b-starter/internal/modules/warehouse/infra/repositories"
+	jobsInfra "github.com/moasq/go-b2b-starter/internal/platform/jobs/infra"
 
 	// Legacy adapters - kept temporarily for backward compatibility
 	"github.com/moasq/go-b2b-starter/internal/db/adapters"
@@ -102,20 +127,209 @@ func registerDomainStores(container *dig.Container) error {
 		return fmt.Errorf("failed to provide document repository: %w", err)
 	}
 
+	// Register DocumentVersionRepository - implements documents/domain.DocumentVersionRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) documentDomain.DocumentVersionRepository {
+		return documentRepos.NewDocumentVersionRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide document version repository: %w", err)
+	}
+
+	// Register DocumentClassificationRepository - implements documents/domain.DocumentClassificationRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) documentDomain.DocumentClassificationRepository {
+		return documentRepos.NewDocumentClassificationRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide document classification repository: %w", err)
+	}
+
+	// Register DocumentCounterRepository - implements documents/domain.DocumentCounterRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) documentDomain.DocumentCounterRepository {
+		return documentRepos.NewDocumentCounterRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide document counter repository: %w", err)
+	}
+
+	// Register account email normalization, shared by the repositories that store or look up member emails.
+	// Stored emails must already be in normalized form, or lookups would miss them.
+	if err := container.Provide(func(sqlcStore sqlc.Store) (orgDomain.EmailNormalizer, error) {
+		policy, err := orgServices.LoadAccountEmailPolicy()
+		if err != nil {
+			return orgDomain.EmailNormalizer{}, err
+		}
+		normalizer := policy.Normalizer()
+		if err := orgRepos.CheckStoredEmails(context.Background(), sqlcStore, normalizer); err != nil {
+			return orgDomain.EmailNormalizer{}, err
+		}
+		return normalizer, nil
+	}); err != nil {
+		return fmt.Errorf("failed to provide account email normalizer: %w", err)
+	}
+
 	// Register OrganizationRepository - implements organizations/domain.OrganizationRepository
-	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.OrganizationRepository {
-		return orgRepos.NewOrganizationRepository(sqlcStore)
+	if err := container.Provide(func(sqlcStore sqlc.Store, normalizer orgDomain.EmailNormalizer) orgDomain.OrganizationRepository {
+		return orgRepos.NewOrganizationRepository(sqlcStore, normalizer)
 	}); err != nil {
 		return fmt.Errorf("failed to provide organization repository: %w", err)
 	}
 
-	// Register AccountRepository - implements organizations/domain.AccountRepository
-	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.AccountRepository {
-		return orgRepos.NewAccountRepository(sqlcStore)
+	// Register the account cache policy, shared by the repositories that read or change cached accounts
+	if err := container.Provide(orgServices.LoadAccountCachePolicy); err != nil {
+		return fmt.Errorf("failed to provide account cache policy: %w", err)
+	}
+
+	// Register AccountRepository - implements organizations/domain.AccountRepository, cached in Redis
+	if err := container.Provide(func(sqlcStore sqlc.Store, normalizer orgDomain.EmailNormalizer, seats paywall.SeatLock, redisClient redis.Client, cachePolicy *orgServices.AccountCachePolicy) orgDomain.AccountRepository {
+		return orgRepos.NewCachedAccountRepository(orgRepos.NewAccountRepository(sqlcStore, normalizer, seats), redisClient, normalizer, cachePolicy.TTL)
 	}); err != nil {
 		return fmt.Errorf("failed to provide account repository: %w", err)
 	}
 
+	// Register IPAllowlistRepository - implements organizations/domain.IPAllowlistRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.IPAllowlistRepository {
+		return orgRepos.NewIPAllowlistRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide ip allowlist repository: %w", err)
+	}
+
+	// Register AccessElevationRepository - implements organizations/domain.AccessElevationRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.AccessElevationRepository {
+		return orgRepos.NewAccessElevationRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide access elevation repository: %w", err)
+	}
+
+	// Register MFARecoveryRepository - implements organizations/domain.MFARecoveryRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.MFARecoveryRepository {
+		return orgRepos.NewMFARecoveryRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide mfa recovery repository: %w", err)
+	}
+
+	// Register EmailChangeRepository - implements organizations/domain.EmailChangeRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.EmailChangeRepository {
+		return orgRepos.NewEmailChangeRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide email change repository: %w", err)
+	}
+
+	// Register InviteRepository - implements organizations/domain.InviteRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.InviteRepository {
+		return orgRepos.NewInviteRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide invite repository: %w", err)
+	}
+
+	// Register AccountStatusRepository - implements organizations/domain.AccountStatusRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store, seats paywall.SeatLock, redisClient redis.Client, cachePolicy *orgServices.AccountCachePolicy) orgDomain.AccountStatusRepository {
+		return orgRepos.NewCacheClearingAccountStatusRepository(orgRepos.NewAccountStatusRepository(sqlcStore, seats), redisClient, cachePolicy.TTL)
+	}); err != nil {
+		return fmt.Errorf("failed to provide account status repository: %w", err)
+	}
+
+	// Register APIUsageRepository - implements organizations/domain.APIUsageRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.APIUsageRepository {
+		return orgRepos.NewAPIUsageRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide api usage repository: %w", err)
+	}
+
+	// Register ActivityRepository - implements organizations/domain.ActivityRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store, redisClient redis.Client, cachePolicy *orgServices.AccountCachePolicy) orgDomain.ActivityRepository {
+		return orgRepos.NewCacheClearingActivityRepository(orgRepos.NewActivityRepository(sqlcStore), redisClient, cachePolicy.TTL)
+	}); err != nil {
+		return fmt.Errorf("failed to provide activity repository: %w", err)
+	}
+
+	// Register CanaryCredentialRepository - implements organizations/domain.CanaryCredentialRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.CanaryCredentialRepository {
+		return orgRepos.NewCanaryCredentialRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide canary credential repository: %w", err)
+	}
+
+	// Register DebugCaptureRepository - implements organizations/domain.DebugCaptureRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.DebugCaptureRepository {
+		return orgRepos.NewDebugCaptureRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide debug capture repository: %w", err)
+	}
+
+	// Register OAuthClientRepository - implements organizations/domain.OAuthClientRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.OAuthClientRepository {
+		return orgRepos.NewOAuthClientRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide oauth client repository: %w", err)
+	}
+
+	// Register OIDCClientRepository - implements organizations/domain.OIDCClientRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.OIDCClientRepository {
+		return orgRepos.NewOIDCClientRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide oidc client repository: %w", err)
+	}
+
+	// Register OIDCGrantRepository - implements organizations/domain.OIDCGrantRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.OIDCGrantRepository {
+		return orgRepos.NewOIDCGrantRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide oidc grant repository: %w", err)
+	}
+
+	// Register AuthPolicyRepository - implements organizations/domain.AuthPolicyRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.AuthPolicyRepository {
+		return orgRepos.NewAuthPolicyRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide auth policy repository: %w", err)
+	}
+
+	// Register NotificationRepository - implements organizations/domain.NotificationRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.NotificationRepository {
+		return orgRepos.NewNotificationRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide notification repository: %w", err)
+	}
+
+	// Register TagRepository - implements organizations/domain.TagRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.TagRepository {
+		return orgRepos.NewTagRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide tag repository: %w", err)
+	}
+
+	// Register IdentityRepository - implements organizations/domain.IdentityRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.IdentityRepository {
+		return orgRepos.NewIdentityRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide identity repository: %w", err)
+	}
+
+	// Register UserStatsRepository - implements organizations/domain.UserStatsRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.UserStatsRepository {
+		return orgRepos.NewUserStatsRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide user stats repository: %w", err)
+	}
+
+	// Register SecondaryEmailRepository - implements organizations/domain.SecondaryEmailRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store, normalizer orgDomain.EmailNormalizer, redisClient redis.Client, cachePolicy *orgServices.AccountCachePolicy) orgDomain.SecondaryEmailRepository {
+		return orgRepos.NewCacheClearingSecondaryEmailRepository(orgRepos.NewSecondaryEmailRepository(sqlcStore, normalizer), redisClient, cachePolicy.TTL)
+	}); err != nil {
+		return fmt.Errorf("failed to provide secondary email repository: %w", err)
+	}
+
+	// Register RoleAssignmentRepository - implements organizations/domain.RoleAssignmentRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store, normalizer orgDomain.EmailNormalizer) orgDomain.RoleAssignmentRepository {
+		return orgRepos.NewRoleAssignmentRepository(sqlcStore, normalizer)
+	}); err != nil {
+		return fmt.Errorf("failed to provide role assignment repository: %w", err)
+	}
+
+	// Register ServiceAccountRepository - implements organizations/domain.ServiceAccountRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) orgDomain.ServiceAccountRepository {
+		return orgRepos.NewServiceAccountRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide service account repository: %w", err)
+	}
+
 	// Register SubscriptionRepository - implements billing/domain.SubscriptionRepository
 	if err := container.Provide(func(sqlcStore sqlc.Store) billingDomain.SubscriptionRepository {
 		return billingRepos.NewSubscriptionRepository(sqlcStore)
@@ -123,6 +337,48 @@ func registerDomainStores(container *dig.Container) error {
 		return fmt.Errorf("failed to provide subscription repository: %w", err)
 	}
 
+	// Register UserSubscriptionRepository - implements billing/domain.UserSubscriptionRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) billingDomain.UserSubscriptionRepository {
+		return billingRepos.NewUserSubscriptionRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide user subscription repository: %w", err)
+	}
+
+	// Register UsageRepository - implements billing/domain.UsageRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) billingDomain.UsageRepository {
+		return billingRepos.NewUsageRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide usage repository: %w", err)
+	}
+
+	// Register SeatRepository - implements billing/domain.SeatRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) billingDomain.SeatRepository {
+		return billingRepos.NewSeatRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide seat repository: %w", err)
+	}
+
+	// Register SeatLock - implements paywall.SeatLock for the organizations repositories
+	if err := container.Provide(func() paywall.SeatLock {
+		return billingRepos.NewSeatLock()
+	}); err != nil {
+		return fmt.Errorf("failed to provide seat lock: %w", err)
+	}
+
+	// Register TrialRepository - implements billing/domain.TrialRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) billingDomain.TrialRepository {
+		return billingRepos.NewTrialRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide trial repository: %w", err)
+	}
+
+	// Register BillingSettingsRepository - implements billing/domain.BillingSettingsRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) billingDomain.BillingSettingsRepository {
+		return billingRepos.NewBillingSettingsRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide billing settings repository: %w", err)
+	}
+
 	// Register EmbeddingRepository - implements cognitive/domain.EmbeddingRepository
 	if err := container.Provide(func(sqlcStore sqlc.Store) cognitiveDomain.EmbeddingRepository {
 		return cognitiveRepos.NewEmbeddingRepository(sqlcStore)
@@ -137,6 +393,20 @@ func registerDomainStores(container *dig.Container) error {
 		return fmt.Errorf("failed to provide chat repository: %w", err)
 	}
 
+	// Register PlanRepository - implements cognitive/domain.PlanRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) cognitiveDomain.PlanRepository {
+		return cognitiveRepos.NewPlanRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide plan repository: %w", err)
+	}
+
+	// Register PromptSettingsRepository - implements cognitive/domain.PromptSettingsRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) cognitiveDomain.PromptSettingsRepository {
+		return cognitiveRepos.NewPromptSettingsRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide prompt settings repository: %w", err)
+	}
+
 	// Register FileMetadataRepository - implements files/domain.FileMetadataRepository
 	if err := container.Provide(func(sqlcStore sqlc.Store) fileDomain.FileMetadataRepository {
 		return fileInfra.NewFileMetadataRepository(sqlcStore)
@@ -144,6 +414,97 @@ func registerDomainStores(container *dig.Container) error {
 		return fmt.Errorf("failed to provide file metadata repository: %w", err)
 	}
 
+	// Register SearchRepository - implements search/domain.SearchRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) searchDomain.SearchRepository {
+		return searchRepos.NewSearchRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide search repository: %w", err)
+	}
+
+	// Register TicketRepository - implements support/domain.TicketRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) supportDomain.TicketRepository {
+		return supportRepos.NewTicketRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide support ticket repository: %w", err)
+	}
+
+	// Register StepStateRepository - implements onboarding/domain.StepStateRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) onboardingDomain.StepStateRepository {
+		return onboardingRepos.NewStepStateRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide onboarding step state repository: %w", err)
+	}
+
+	// Register FactRepository - implements warehouse/domain.FactRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) warehouseDomain.FactRepository {
+		return warehouseRepos.NewFactRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide warehouse fact repository: %w", err)
+	}
+
+	// Register WatermarkRepository - implements warehouse/domain.WatermarkRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) warehouseDomain.WatermarkRepository {
+		return warehouseRepos.NewWatermarkRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide warehouse watermark repository: %w", err)
+	}
+
+	// Register RoleRepository - implements auth.RoleRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) auth.RoleRepository {
+		return authRepos.NewRoleRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide role repository: %w", err)
+	}
+
+	// Register AuditLogRepository - implements auth.AuditLogRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) auth.AuditLogRepository {
+		return authRepos.NewAuditLogRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide auth audit log repository: %w", err)
+	}
+
+	// Register RunRepository - implements jobs/domain.RunRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) jobsDomain.RunRepository {
+		return jobsInfra.NewRunRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide job run repository: %w", err)
+	}
+
+	// Register TenantDataRepository - implements compliance/domain.TenantDataRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) complianceDomain.TenantDataRepository {
+		return complianceRepos.NewTenantDataRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide tenant data repository: %w", err)
+	}
+
+	// Register PurgeReportRepository - implements compliance/domain.PurgeReportRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) complianceDomain.PurgeReportRepository {
+		return complianceRepos.NewPurgeReportRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide purge report repository: %w", err)
+	}
+
+	// Register DataExportRepository - implements compliance/domain.DataExportRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) complianceDomain.DataExportRepository {
+		return complianceRepos.NewDataExportRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide data export repository: %w", err)
+	}
+
+	// Register AccountDataRepository - implements compliance/domain.AccountDataRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) complianceDomain.AccountDataRepository {
+		return complianceRepos.NewAccountDataRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide account data repository: %w", err)
+	}
+
+	// Register ImportRepository - implements portability/domain.ImportRepository
+	if err := container.Provide(func(sqlcStore sqlc.Store) portabilityDomain.ImportRepository {
+		return portabilityRepos.NewImportRepository(sqlcStore)
+	}); err != nil {
+		return fmt.Errorf("failed to provide organization import repository: %w", err)
+	}
+
 	// ============================================
 	// LEGACY: Adapter stores (kept for backward compatibility)
 	// TODO: Migrate callers to use domain interfaces, then remove these
@@ -43,18 +43,26 @@ INSERT INTO cognitive.chat_messages (
     role,
     content,
     referenced_docs,
-    tokens_used
+    tokens_used,
+    model,
+    temperature,
+    max_tokens,
+    truncated
 ) VALUES (
-    $1, $2, $3, $4, $5
-) RETURNING id, session_id, role, content, referenced_docs, tokens_used, created_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+) RETURNING id, session_id, role, content, referenced_docs, tokens_used, created_at, model, temperature, max_tokens, truncated
 `
 
 type CreateChatMessageParams struct {
-	SessionID      int32       `json:"session_id"`
-	Role           string      `json:"role"`
-	Content        string      `json:"content"`
-	ReferencedDocs []int32     `json:"referenced_docs"`
-	TokensUsed     pgtype.Int4 `json:"tokens_used"`
+	SessionID      int32         `json:"session_id"`
+	Role           string        `json:"role"`
+	Content        string        `json:"content"`
+	ReferencedDocs []int32       `json:"referenced_docs"`
+	TokensUsed     pgtype.Int4   `json:"tokens_used"`
+	Model          pgtype.Text   `json:"model"`
+	Temperature    pgtype.Float4 `json:"temperature"`
+	MaxTokens      pgtype.Int4   `json:"max_tokens"`
+	Truncated      bool          `json:"truncated"`
 }
 
 // Chat Messages
@@ -65,6 +73,10 @@ func (q *Queries) CreateChatMessage(ctx context.Context, arg CreateChatMessagePa
 		arg.Content,
 		arg.ReferencedDocs,
 		arg.TokensUsed,
+		arg.Model,
+		arg.Temperature,
+		arg.MaxTokens,
+		arg.Truncated,
 	)
 	var i CognitiveChatMessage
 	err := row.Scan(
@@ -75,6 +87,10 @@ func (q *Queries) CreateChatMessage(ctx context.Context, arg CreateChatMessagePa
 		&i.ReferencedDocs,
 		&i.TokensUsed,
 		&i.CreatedAt,
+		&i.Model,
+		&i.Temperature,
+		&i.MaxTokens,
+		&i.Truncated,
 	)
 	return i, err
 }
@@ -84,21 +100,34 @@ const createChatSession = `-- name: CreateChatSession :one
 INSERT INTO cognitive.chat_sessions (
     organization_id,
     account_id,
-    title
+    title,
+    model,
+    temperature,
+    max_tokens
 ) VALUES (
-    $1, $2, $3
-) RETURNING id, organization_id, account_id, title, created_at, updated_at
+    $1, $2, $3, $4, $5, $6
+) RETURNING id, organization_id, account_id, title, created_at, updated_at, model, temperature, max_tokens, summary, summarized_through_message_id
 `
 
 type CreateChatSessionParams struct {
-	OrganizationID int32       `json:"organization_id"`
-	AccountID      int32       `json:"account_id"`
-	Title          pgtype.Text `json:"title"`
+	OrganizationID int32         `json:"organization_id"`
+	AccountID      int32         `json:"account_id"`
+	Title          pgtype.Text   `json:"title"`
+	Model          pgtype.Text   `json:"model"`
+	Temperature    pgtype.Float4 `json:"temperature"`
+	MaxTokens      pgtype.Int4   `json:"max_tokens"`
 }
 
 // Chat Sessions
 func (q *Queries) CreateChatSession(ctx context.Context, arg CreateChatSessionParams) (CognitiveChatSession, error) {
-	row := q.db.QueryRow(ctx, createChatSession, arg.OrganizationID, arg.AccountID, arg.Title)
+	row := q.db.QueryRow(ctx, createChatSession,
+		arg.OrganizationID,
+		arg.AccountID,
+		arg.Title,
+		arg.Model,
+		arg.Temperature,
+		arg.MaxTokens,
+	)
 	var i CognitiveChatSession
 	err := row.Scan(
 		&i.ID,
@@ -107,6 +136,11 @@ func (q *Queries) CreateChatSession(ctx context.Context, arg CreateChatSessionPa
 		&i.Title,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Model,
+		&i.Temperature,
+		&i.MaxTokens,
+		&i.Summary,
+		&i.SummarizedThroughMessageID,
 	)
 	return i, err
 }
@@ -120,10 +154,11 @@ INSERT INTO cognitive.document_embeddings (
     embedding,
     content_hash,
     content_preview,
-    chunk_index
+    chunk_index,
+    location
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
-) RETURNING id, document_id, organization_id, embedding, content_hash, content_preview, chunk_index, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7
+) RETURNING id, document_id, organization_id, embedding, content_hash, content_preview, chunk_index, created_at, updated_at, location
 `
 
 type CreateDocumentEmbeddingParams struct {
@@ -133,6 +168,7 @@ type CreateDocumentEmbeddingParams struct {
 	ContentHash    pgtype.Text        `json:"content_hash"`
 	ContentPreview pgtype.Text        `json:"content_preview"`
 	ChunkIndex     pgtype.Int4        `json:"chunk_index"`
+	Location       pgtype.Text        `json:"location"`
 }
 
 // Cognitive Agent queries
@@ -145,6 +181,7 @@ func (q *Queries) CreateDocumentEmbedding(ctx context.Context, arg CreateDocumen
 		arg.ContentHash,
 		arg.ContentPreview,
 		arg.ChunkIndex,
+		arg.Location,
 	)
 	var i CognitiveDocumentEmbedding
 	err := row.Scan(
@@ -157,6 +194,138 @@ func (q *Queries) CreateDocumentEmbedding(ctx context.Context, arg CreateDocumen
 		&i.ChunkIndex,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Location,
+	)
+	return i, err
+}
+
+const createDocumentEmbeddings = `-- name: CreateDocumentEmbeddings :many
+INSERT INTO cognitive.document_embeddings (
+    document_id,
+    organization_id,
+    embedding,
+    content_hash,
+    content_preview,
+    chunk_index,
+    location
+)
+SELECT
+    $1::int4,
+    $2::int4,
+    c.embedding::vector,
+    c.content_hash,
+    c.content_preview,
+    c.chunk_index,
+    NULLIF(c.location, '')
+FROM unnest(
+    $3::text[],
+    $4::text[],
+    $5::text[],
+    $6::int4[],
+    $7::text[]
+) AS c(embedding, content_hash, content_preview, chunk_index, location)
+ORDER BY c.chunk_index
+RETURNING id, document_id, organization_id, embedding, content_hash, content_preview, chunk_index, created_at, updated_at, location
+`
+
+type CreateDocumentEmbeddingsParams struct {
+	DocumentID      int32    `json:"document_id"`
+	OrganizationID  int32    `json:"organization_id"`
+	Embeddings      []string `json:"embeddings"`
+	ContentHashes   []string `json:"content_hashes"`
+	ContentPreviews []string `json:"content_previews"`
+	ChunkIndexes    []int32  `json:"chunk_indexes"`
+	Locations       []string `json:"locations"`
+}
+
+// Inserts a batch of chunk embeddings of one document in a single statement.
+// Vectors are passed in pgvector's text form; an empty location is stored as NULL.
+func (q *Queries) CreateDocumentEmbeddings(ctx context.Context, arg CreateDocumentEmbeddingsParams) ([]CognitiveDocumentEmbedding, error) {
+	rows, err := q.db.Query(ctx, createDocumentEmbeddings,
+		arg.DocumentID,
+		arg.OrganizationID,
+		arg.Embeddings,
+		arg.ContentHashes,
+		arg.ContentPreviews,
+		arg.ChunkIndexes,
+		arg.Locations,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CognitiveDocumentEmbedding{}
+	for rows.Next() {
+		var i CognitiveDocumentEmbedding
+		if err := rows.Scan(
+			&i.ID,
+			&i.DocumentID,
+			&i.OrganizationID,
+			&i.Embedding,
+			&i.ContentHash,
+			&i.ContentPreview,
+			&i.ChunkIndex,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Location,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createPromptSettingsVersion = `-- name: CreatePromptSettingsVersion :one
+
+INSERT INTO cognitive.prompt_settings (
+    organization_id,
+    version_number,
+    system_prompt,
+    glossary,
+    created_by_account_id,
+    language
+) VALUES (
+    $1,
+    (SELECT COALESCE(MAX(version_number), 0) + 1 FROM cognitive.prompt_settings WHERE organization_id = $1)::int,
+    $2,
+    $3,
+    $4,
+    $5
+) RETURNING id, organization_id, version_number, system_prompt, glossary, created_by_account_id, created_at, language
+`
+
+type CreatePromptSettingsVersionParams struct {
+	OrganizationID     int32       `json:"organization_id"`
+	SystemPrompt       pgtype.Text `json:"system_prompt"`
+	Glossary           []byte      `json:"glossary"`
+	CreatedByAccountID pgtype.Int4 `json:"created_by_account_id"`
+	Language           pgtype.Text `json:"language"`
+}
+
+// Prompt Settings
+// Versions are numbered per organization; a concurrent change fails on uq_prompt_settings_version
+func (q *Queries) CreatePromptSettingsVersion(ctx context.Context, arg CreatePromptSettingsVersionParams) (CognitivePromptSetting, error) {
+	row := q.db.QueryRow(ctx, createPromptSettingsVersion,
+		arg.OrganizationID,
+		arg.SystemPrompt,
+		arg.Glossary,
+		arg.CreatedByAccountID,
+		arg.Language,
+	)
+	var i CognitivePromptSetting
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.VersionNumber,
+		&i.SystemPrompt,
+		&i.Glossary,
+		&i.CreatedByAccountID,
+		&i.CreatedAt,
+		&i.Language,
 	)
 	return i, err
 }
@@ -186,6 +355,24 @@ func (q *Queries) DeleteChatSession(ctx context.Context, arg DeleteChatSessionPa
 	return err
 }
 
+const deleteChatSessionsByAccount = `-- name: DeleteChatSessionsByAccount :execrows
+DELETE FROM cognitive.chat_sessions
+WHERE organization_id = $1 AND account_id = $2
+`
+
+type DeleteChatSessionsByAccountParams struct {
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+}
+
+func (q *Queries) DeleteChatSessionsByAccount(ctx context.Context, arg DeleteChatSessionsByAccountParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteChatSessionsByAccount, arg.OrganizationID, arg.AccountID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteDocumentEmbeddings = `-- name: DeleteDocumentEmbeddings :exec
 DELETE FROM cognitive.document_embeddings
 WHERE document_id = $1 AND organization_id = $2
@@ -201,8 +388,51 @@ func (q *Queries) DeleteDocumentEmbeddings(ctx context.Context, arg DeleteDocume
 	return err
 }
 
+const getChatMessagesAfter = `-- name: GetChatMessagesAfter :many
+SELECT id, session_id, role, content, referenced_docs, tokens_used, created_at, model, temperature, max_tokens, truncated FROM cognitive.chat_messages
+WHERE session_id = $1 AND id > $2
+ORDER BY created_at ASC, id ASC
+`
+
+type GetChatMessagesAfterParams struct {
+	SessionID      int32 `json:"session_id"`
+	AfterMessageID int32 `json:"after_message_id"`
+}
+
+func (q *Queries) GetChatMessagesAfter(ctx context.Context, arg GetChatMessagesAfterParams) ([]CognitiveChatMessage, error) {
+	rows, err := q.db.Query(ctx, getChatMessagesAfter, arg.SessionID, arg.AfterMessageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CognitiveChatMessage{}
+	for rows.Next() {
+		var i CognitiveChatMessage
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.Role,
+			&i.Content,
+			&i.ReferencedDocs,
+			&i.TokensUsed,
+			&i.CreatedAt,
+			&i.Model,
+			&i.Temperature,
+			&i.MaxTokens,
+			&i.Truncated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getChatMessagesBySession = `-- name: GetChatMessagesBySession :many
-SELECT id, session_id, role, content, referenced_docs, tokens_used, created_at FROM cognitive.chat_messages
+SELECT id, session_id, role, content, referenced_docs, tokens_used, created_at, model, temperature, max_tokens, truncated FROM cognitive.chat_messages
 WHERE session_id = $1
 ORDER BY created_at ASC
 `
@@ -224,6 +454,10 @@ func (q *Queries) GetChatMessagesBySession(ctx context.Context, sessionID int32)
 			&i.ReferencedDocs,
 			&i.TokensUsed,
 			&i.CreatedAt,
+			&i.Model,
+			&i.Temperature,
+			&i.MaxTokens,
+			&i.Truncated,
 		); err != nil {
 			return nil, err
 		}
@@ -236,7 +470,7 @@ func (q *Queries) GetChatMessagesBySession(ctx context.Context, sessionID int32)
 }
 
 const getChatSessionByID = `-- name: GetChatSessionByID :one
-SELECT id, organization_id, account_id, title, created_at, updated_at FROM cognitive.chat_sessions
+SELECT id, organization_id, account_id, title, created_at, updated_at, model, temperature, max_tokens, summary, summarized_through_message_id FROM cognitive.chat_sessions
 WHERE id = $1 AND organization_id = $2
 `
 
@@ -255,12 +489,17 @@ func (q *Queries) GetChatSessionByID(ctx context.Context, arg GetChatSessionByID
 		&i.Title,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Model,
+		&i.Temperature,
+		&i.MaxTokens,
+		&i.Summary,
+		&i.SummarizedThroughMessageID,
 	)
 	return i, err
 }
 
 const getDocumentEmbeddingByID = `-- name: GetDocumentEmbeddingByID :one
-SELECT id, document_id, organization_id, embedding, content_hash, content_preview, chunk_index, created_at, updated_at FROM cognitive.document_embeddings
+SELECT id, document_id, organization_id, embedding, content_hash, content_preview, chunk_index, created_at, updated_at, location FROM cognitive.document_embeddings
 WHERE id = $1 AND organization_id = $2
 `
 
@@ -282,12 +521,13 @@ func (q *Queries) GetDocumentEmbeddingByID(ctx context.Context, arg GetDocumentE
 		&i.ChunkIndex,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Location,
 	)
 	return i, err
 }
 
 const getDocumentEmbeddingsByDocumentID = `-- name: GetDocumentEmbeddingsByDocumentID :many
-SELECT id, document_id, organization_id, embedding, content_hash, content_preview, chunk_index, created_at, updated_at FROM cognitive.document_embeddings
+SELECT id, document_id, organization_id, embedding, content_hash, content_preview, chunk_index, created_at, updated_at, location FROM cognitive.document_embeddings
 WHERE document_id = $1 AND organization_id = $2
 ORDER BY chunk_index
 `
@@ -316,6 +556,7 @@ func (q *Queries) GetDocumentEmbeddingsByDocumentID(ctx context.Context, arg Get
 			&i.ChunkIndex,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Location,
 		); err != nil {
 			return nil, err
 		}
@@ -327,8 +568,57 @@ func (q *Queries) GetDocumentEmbeddingsByDocumentID(ctx context.Context, arg Get
 	return items, nil
 }
 
+const getLatestPromptSettings = `-- name: GetLatestPromptSettings :one
+SELECT id, organization_id, version_number, system_prompt, glossary, created_by_account_id, created_at, language FROM cognitive.prompt_settings
+WHERE organization_id = $1
+ORDER BY version_number DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestPromptSettings(ctx context.Context, organizationID int32) (CognitivePromptSetting, error) {
+	row := q.db.QueryRow(ctx, getLatestPromptSettings, organizationID)
+	var i CognitivePromptSetting
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.VersionNumber,
+		&i.SystemPrompt,
+		&i.Glossary,
+		&i.CreatedByAccountID,
+		&i.CreatedAt,
+		&i.Language,
+	)
+	return i, err
+}
+
+const getPromptSettingsVersion = `-- name: GetPromptSettingsVersion :one
+SELECT id, organization_id, version_number, system_prompt, glossary, created_by_account_id, created_at, language FROM cognitive.prompt_settings
+WHERE organization_id = $1 AND version_number = $2
+`
+
+type GetPromptSettingsVersionParams struct {
+	OrganizationID int32 `json:"organization_id"`
+	VersionNumber  int32 `json:"version_number"`
+}
+
+func (q *Queries) GetPromptSettingsVersion(ctx context.Context, arg GetPromptSettingsVersionParams) (CognitivePromptSetting, error) {
+	row := q.db.QueryRow(ctx, getPromptSettingsVersion, arg.OrganizationID, arg.VersionNumber)
+	var i CognitivePromptSetting
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.VersionNumber,
+		&i.SystemPrompt,
+		&i.Glossary,
+		&i.CreatedByAccountID,
+		&i.CreatedAt,
+		&i.Language,
+	)
+	return i, err
+}
+
 const getRecentChatMessages = `-- name: GetRecentChatMessages :many
-SELECT id, session_id, role, content, referenced_docs, tokens_used, created_at FROM cognitive.chat_messages
+SELECT id, session_id, role, content, referenced_docs, tokens_used, created_at, model, temperature, max_tokens, truncated FROM cognitive.chat_messages
 WHERE session_id = $1
 ORDER BY created_at DESC
 LIMIT $2
@@ -356,6 +646,10 @@ func (q *Queries) GetRecentChatMessages(ctx context.Context, arg GetRecentChatMe
 			&i.ReferencedDocs,
 			&i.TokensUsed,
 			&i.CreatedAt,
+			&i.Model,
+			&i.Temperature,
+			&i.MaxTokens,
+			&i.Truncated,
 		); err != nil {
 			return nil, err
 		}
@@ -368,7 +662,7 @@ func (q *Queries) GetRecentChatMessages(ctx context.Context, arg GetRecentChatMe
 }
 
 const listChatSessionsByAccount = `-- name: ListChatSessionsByAccount :many
-SELECT id, organization_id, account_id, title, created_at, updated_at FROM cognitive.chat_sessions
+SELECT id, organization_id, account_id, title, created_at, updated_at, model, temperature, max_tokens, summary, summarized_through_message_id FROM cognitive.chat_sessions
 WHERE organization_id = $1 AND account_id = $2
 ORDER BY updated_at DESC
 LIMIT $3 OFFSET $4
@@ -402,6 +696,11 @@ func (q *Queries) ListChatSessionsByAccount(ctx context.Context, arg ListChatSes
 			&i.Title,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Model,
+			&i.Temperature,
+			&i.MaxTokens,
+			&i.Summary,
+			&i.SummarizedThroughMessageID,
 		); err != nil {
 			return nil, err
 		}
@@ -413,6 +712,74 @@ func (q *Queries) ListChatSessionsByAccount(ctx context.Context, arg ListChatSes
 	return items, nil
 }
 
+const listPromptSettingsVersions = `-- name: ListPromptSettingsVersions :many
+SELECT id, organization_id, version_number, system_prompt, glossary, created_by_account_id, created_at, language FROM cognitive.prompt_settings
+WHERE organization_id = $1
+ORDER BY version_number DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListPromptSettingsVersionsParams struct {
+	OrganizationID int32 `json:"organization_id"`
+	Limit          int32 `json:"limit"`
+	Offset         int32 `json:"offset"`
+}
+
+func (q *Queries) ListPromptSettingsVersions(ctx context.Context, arg ListPromptSettingsVersionsParams) ([]CognitivePromptSetting, error) {
+	rows, err := q.db.Query(ctx, listPromptSettingsVersions, arg.OrganizationID, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CognitivePromptSetting
+	for rows.Next() {
+		var i CognitivePromptSetting
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.VersionNumber,
+			&i.SystemPrompt,
+			&i.Glossary,
+			&i.CreatedByAccountID,
+			&i.CreatedAt,
+			&i.Language,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reassignChatSessions = `-- name: ReassignChatSessions :execrows
+UPDATE cognitive.chat_sessions
+SET organization_id = $3, account_id = $4, updated_at = NOW()
+WHERE organization_id = $1 AND account_id = $2
+`
+
+type ReassignChatSessionsParams struct {
+	OrganizationID   int32 `json:"organization_id"`
+	AccountID        int32 `json:"account_id"`
+	OrganizationID_2 int32 `json:"organization_id_2"`
+	AccountID_2      int32 `json:"account_id_2"`
+}
+
+func (q *Queries) ReassignChatSessions(ctx context.Context, arg ReassignChatSessionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reassignChatSessions,
+		arg.OrganizationID,
+		arg.AccountID,
+		arg.OrganizationID_2,
+		arg.AccountID_2,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const searchSimilarDocuments = `-- name: SearchSimilarDocuments :many
 SELECT
     de.id,
@@ -423,9 +790,11 @@ SELECT
     de.chunk_index,
     de.created_at,
     de.updated_at,
+    de.location,
     (1 - (de.embedding <=> $1::vector))::double precision as similarity_score
 FROM cognitive.document_embeddings de
 WHERE de.organization_id = $2
+  AND vector_dims(de.embedding) = vector_dims($1::vector)
 ORDER BY de.embedding <=> $1::vector
 LIMIT $3
 `
@@ -445,6 +814,7 @@ type SearchSimilarDocumentsRow struct {
 	ChunkIndex      pgtype.Int4      `json:"chunk_index"`
 	CreatedAt       pgtype.Timestamp `json:"created_at"`
 	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+	Location        pgtype.Text      `json:"location"`
 	SimilarityScore float64          `json:"similarity_score"`
 }
 
@@ -466,6 +836,7 @@ func (q *Queries) SearchSimilarDocuments(ctx context.Context, arg SearchSimilarD
 			&i.ChunkIndex,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Location,
 			&i.SimilarityScore,
 		); err != nil {
 			return nil, err
@@ -478,11 +849,127 @@ func (q *Queries) SearchSimilarDocuments(ctx context.Context, arg SearchSimilarD
 	return items, nil
 }
 
+const updateChatMessageContent = `-- name: UpdateChatMessageContent :one
+UPDATE cognitive.chat_messages
+SET content = $2, tokens_used = $3, truncated = $4
+WHERE id = $1
+RETURNING id, session_id, role, content, referenced_docs, tokens_used, created_at, model, temperature, max_tokens, truncated
+`
+
+type UpdateChatMessageContentParams struct {
+	ID         int32       `json:"id"`
+	Content    string      `json:"content"`
+	TokensUsed pgtype.Int4 `json:"tokens_used"`
+	Truncated  bool        `json:"truncated"`
+}
+
+func (q *Queries) UpdateChatMessageContent(ctx context.Context, arg UpdateChatMessageContentParams) (CognitiveChatMessage, error) {
+	row := q.db.QueryRow(ctx, updateChatMessageContent,
+		arg.ID,
+		arg.Content,
+		arg.TokensUsed,
+		arg.Truncated,
+	)
+	var i CognitiveChatMessage
+	err := row.Scan(
+		&i.ID,
+		&i.SessionID,
+		&i.Role,
+		&i.Content,
+		&i.ReferencedDocs,
+		&i.TokensUsed,
+		&i.CreatedAt,
+		&i.Model,
+		&i.Temperature,
+		&i.MaxTokens,
+		&i.Truncated,
+	)
+	return i, err
+}
+
+const updateChatSessionParameters = `-- name: UpdateChatSessionParameters :one
+UPDATE cognitive.chat_sessions
+SET model = $3, temperature = $4, max_tokens = $5, updated_at = NOW()
+WHERE id = $1 AND organization_id = $2
+RETURNING id, organization_id, account_id, title, created_at, updated_at, model, temperature, max_tokens, summary, summarized_through_message_id
+`
+
+type UpdateChatSessionParametersParams struct {
+	ID             int32         `json:"id"`
+	OrganizationID int32         `json:"organization_id"`
+	Model          pgtype.Text   `json:"model"`
+	Temperature    pgtype.Float4 `json:"temperature"`
+	MaxTokens      pgtype.Int4   `json:"max_tokens"`
+}
+
+func (q *Queries) UpdateChatSessionParameters(ctx context.Context, arg UpdateChatSessionParametersParams) (CognitiveChatSession, error) {
+	row := q.db.QueryRow(ctx, updateChatSessionParameters,
+		arg.ID,
+		arg.OrganizationID,
+		arg.Model,
+		arg.Temperature,
+		arg.MaxTokens,
+	)
+	var i CognitiveChatSession
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.AccountID,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Model,
+		&i.Temperature,
+		&i.MaxTokens,
+		&i.Summary,
+		&i.SummarizedThroughMessageID,
+	)
+	return i, err
+}
+
+const updateChatSessionSummary = `-- name: UpdateChatSessionSummary :one
+UPDATE cognitive.chat_sessions
+SET summary = $3, summarized_through_message_id = $4
+WHERE id = $1 AND organization_id = $2
+RETURNING id, organization_id, account_id, title, created_at, updated_at, model, temperature, max_tokens, summary, summarized_through_message_id
+`
+
+type UpdateChatSessionSummaryParams struct {
+	ID                         int32       `json:"id"`
+	OrganizationID             int32       `json:"organization_id"`
+	Summary                    pgtype.Text `json:"summary"`
+	SummarizedThroughMessageID int32       `json:"summarized_through_message_id"`
+}
+
+func (q *Queries) UpdateChatSessionSummary(ctx context.Context, arg UpdateChatSessionSummaryParams) (CognitiveChatSession, error) {
+	row := q.db.QueryRow(ctx, updateChatSessionSummary,
+		arg.ID,
+		arg.OrganizationID,
+		arg.Summary,
+		arg.SummarizedThroughMessageID,
+	)
+	var i CognitiveChatSession
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.AccountID,
+		&i.Title,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Model,
+		&i.Temperature,
+		&i.MaxTokens,
+		&i.Summary,
+		&i.SummarizedThroughMessageID,
+	)
+	return i, err
+}
+
 const updateChatSessionTitle = `-- name: UpdateChatSessionTitle :one
 UPDATE cognitive.chat_sessions
 SET title = $3, updated_at = NOW()
 WHERE id = $1 AND organization_id = $2
-RETURNING id, organization_id, account_id, title, created_at, updated_at
+RETURNING id, organization_id, account_id, title, created_at, updated_at, model, temperature, max_tokens, summary, summarized_through_message_id
 `
 
 type UpdateChatSessionTitleParams struct {
@@ -501,6 +988,11 @@ func (q *Queries) UpdateChatSessionTitle(ctx context.Context, arg UpdateChatSess
 		&i.Title,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Model,
+		&i.Temperature,
+		&i.MaxTokens,
+		&i.Summary,
+		&i.SummarizedThroughMessageID,
 	)
 	return i, err
 }
@@ -51,10 +51,11 @@ INSERT INTO documents.documents (
     file_size,
     extracted_text,
     status,
-    metadata
+    metadata,
+    uploaded_by
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
-) RETURNING id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at, uploaded_by
 `
 
 type CreateDocumentParams struct {
@@ -67,6 +68,7 @@ type CreateDocumentParams struct {
 	ExtractedText  pgtype.Text `json:"extracted_text"`
 	Status         string      `json:"status"`
 	Metadata       []byte      `json:"metadata"`
+	UploadedBy     pgtype.Int4 `json:"uploaded_by"`
 }
 
 // Documents queries
@@ -81,6 +83,7 @@ func (q *Queries) CreateDocument(ctx context.Context, arg CreateDocumentParams)
 		arg.ExtractedText,
 		arg.Status,
 		arg.Metadata,
+		arg.UploadedBy,
 	)
 	var i DocumentsDocument
 	err := row.Scan(
@@ -96,6 +99,74 @@ func (q *Queries) CreateDocument(ctx context.Context, arg CreateDocumentParams)
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UploadedBy,
+	)
+	return i, err
+}
+
+const createDocumentVersion = `-- name: CreateDocumentVersion :one
+
+INSERT INTO documents.document_versions (
+    document_id,
+    organization_id,
+    version_number,
+    file_asset_id,
+    file_name,
+    content_type,
+    file_size,
+    status,
+    uploaded_by
+) VALUES (
+    $1,
+    $2,
+    (SELECT COALESCE(MAX(version_number), 0) + 1 FROM documents.document_versions WHERE document_id = $1)::int,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+) RETURNING id, document_id, organization_id, version_number, file_asset_id, file_name, content_type, file_size, extracted_text, status, created_at, uploaded_by
+`
+
+type CreateDocumentVersionParams struct {
+	DocumentID     int32       `json:"document_id"`
+	OrganizationID int32       `json:"organization_id"`
+	FileAssetID    int32       `json:"file_asset_id"`
+	FileName       string      `json:"file_name"`
+	ContentType    string      `json:"content_type"`
+	FileSize       int64       `json:"file_size"`
+	Status         string      `json:"status"`
+	UploadedBy     pgtype.Int4 `json:"uploaded_by"`
+}
+
+// Document version queries
+// Versions are numbered per document; a concurrent upload fails on uq_document_versions_number
+func (q *Queries) CreateDocumentVersion(ctx context.Context, arg CreateDocumentVersionParams) (DocumentsDocumentVersion, error) {
+	row := q.db.QueryRow(ctx, createDocumentVersion,
+		arg.DocumentID,
+		arg.OrganizationID,
+		arg.FileAssetID,
+		arg.FileName,
+		arg.ContentType,
+		arg.FileSize,
+		arg.Status,
+		arg.UploadedBy,
+	)
+	var i DocumentsDocumentVersion
+	err := row.Scan(
+		&i.ID,
+		&i.DocumentID,
+		&i.OrganizationID,
+		&i.VersionNumber,
+		&i.FileAssetID,
+		&i.FileName,
+		&i.ContentType,
+		&i.FileSize,
+		&i.ExtractedText,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UploadedBy,
 	)
 	return i, err
 }
@@ -116,7 +187,7 @@ func (q *Queries) DeleteDocument(ctx context.Context, arg DeleteDocumentParams)
 }
 
 const getDocumentByFileAssetID = `-- name: GetDocumentByFileAssetID :one
-SELECT id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at FROM documents.documents
+SELECT id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at, uploaded_by FROM documents.documents
 WHERE file_asset_id = $1 AND organization_id = $2
 `
 
@@ -141,12 +212,13 @@ func (q *Queries) GetDocumentByFileAssetID(ctx context.Context, arg GetDocumentB
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UploadedBy,
 	)
 	return i, err
 }
 
 const getDocumentByID = `-- name: GetDocumentByID :one
-SELECT id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at FROM documents.documents
+SELECT id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at, uploaded_by FROM documents.documents
 WHERE id = $1 AND organization_id = $2
 `
 
@@ -171,12 +243,157 @@ func (q *Queries) GetDocumentByID(ctx context.Context, arg GetDocumentByIDParams
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UploadedBy,
 	)
 	return i, err
 }
 
+const getDocumentCounters = `-- name: GetDocumentCounters :one
+SELECT organization_id, document_count, pending_count, processing_count, processed_count, failed_count, storage_bytes, chunk_count, reconciled_at, updated_at FROM documents.document_counters
+WHERE organization_id = $1
+`
+
+func (q *Queries) GetDocumentCounters(ctx context.Context, organizationID int32) (DocumentsDocumentCounter, error) {
+	row := q.db.QueryRow(ctx, getDocumentCounters, organizationID)
+	var i DocumentsDocumentCounter
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.DocumentCount,
+		&i.PendingCount,
+		&i.ProcessingCount,
+		&i.ProcessedCount,
+		&i.FailedCount,
+		&i.StorageBytes,
+		&i.ChunkCount,
+		&i.ReconciledAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getDocumentVersion = `-- name: GetDocumentVersion :one
+SELECT id, document_id, organization_id, version_number, file_asset_id, file_name, content_type, file_size, extracted_text, status, created_at, uploaded_by FROM documents.document_versions
+WHERE document_id = $1 AND organization_id = $2 AND version_number = $3
+`
+
+type GetDocumentVersionParams struct {
+	DocumentID     int32 `json:"document_id"`
+	OrganizationID int32 `json:"organization_id"`
+	VersionNumber  int32 `json:"version_number"`
+}
+
+func (q *Queries) GetDocumentVersion(ctx context.Context, arg GetDocumentVersionParams) (DocumentsDocumentVersion, error) {
+	row := q.db.QueryRow(ctx, getDocumentVersion, arg.DocumentID, arg.OrganizationID, arg.VersionNumber)
+	var i DocumentsDocumentVersion
+	err := row.Scan(
+		&i.ID,
+		&i.DocumentID,
+		&i.OrganizationID,
+		&i.VersionNumber,
+		&i.FileAssetID,
+		&i.FileName,
+		&i.ContentType,
+		&i.FileSize,
+		&i.ExtractedText,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UploadedBy,
+	)
+	return i, err
+}
+
+const listCountedOrganizationIDs = `-- name: ListCountedOrganizationIDs :many
+SELECT id FROM organizations.organizations
+WHERE id > $1::int
+ORDER BY id
+LIMIT $2::int
+`
+
+type ListCountedOrganizationIDsParams struct {
+	AfterID  int32 `json:"after_id"`
+	RowLimit int32 `json:"row_limit"`
+}
+
+// Organizations in id order after a cursor, for reconciling counters in batches
+func (q *Queries) ListCountedOrganizationIDs(ctx context.Context, arg ListCountedOrganizationIDsParams) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listCountedOrganizationIDs, arg.AfterID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDocumentVersions = `-- name: ListDocumentVersions :many
+SELECT id, document_id, organization_id, version_number, file_asset_id, file_name, content_type, file_size, status, created_at
+FROM documents.document_versions
+WHERE document_id = $1 AND organization_id = $2
+ORDER BY version_number DESC
+`
+
+type ListDocumentVersionsParams struct {
+	DocumentID     int32 `json:"document_id"`
+	OrganizationID int32 `json:"organization_id"`
+}
+
+type ListDocumentVersionsRow struct {
+	ID             int32            `json:"id"`
+	DocumentID     int32            `json:"document_id"`
+	OrganizationID int32            `json:"organization_id"`
+	VersionNumber  int32            `json:"version_number"`
+	FileAssetID    int32            `json:"file_asset_id"`
+	FileName       string           `json:"file_name"`
+	ContentType    string           `json:"content_type"`
+	FileSize       int64            `json:"file_size"`
+	Status         string           `json:"status"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+}
+
+// Extracted text is left out; fetch a single version to read it
+func (q *Queries) ListDocumentVersions(ctx context.Context, arg ListDocumentVersionsParams) ([]ListDocumentVersionsRow, error) {
+	rows, err := q.db.Query(ctx, listDocumentVersions, arg.DocumentID, arg.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDocumentVersionsRow
+	for rows.Next() {
+		var i ListDocumentVersionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.DocumentID,
+			&i.OrganizationID,
+			&i.VersionNumber,
+			&i.FileAssetID,
+			&i.FileName,
+			&i.ContentType,
+			&i.FileSize,
+			&i.Status,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listDocumentsByOrganization = `-- name: ListDocumentsByOrganization :many
-SELECT id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at FROM documents.documents
+SELECT id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at, uploaded_by FROM documents.documents
 WHERE organization_id = $1
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
@@ -210,6 +427,7 @@ func (q *Queries) ListDocumentsByOrganization(ctx context.Context, arg ListDocum
 			&i.Metadata,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.UploadedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -222,7 +440,7 @@ func (q *Queries) ListDocumentsByOrganization(ctx context.Context, arg ListDocum
 }
 
 const listDocumentsByStatus = `-- name: ListDocumentsByStatus :many
-SELECT id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at FROM documents.documents
+SELECT id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at, uploaded_by FROM documents.documents
 WHERE organization_id = $1 AND status = $2
 ORDER BY created_at DESC
 LIMIT $3 OFFSET $4
@@ -262,6 +480,7 @@ func (q *Queries) ListDocumentsByStatus(ctx context.Context, arg ListDocumentsBy
 			&i.Metadata,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.UploadedBy,
 		); err != nil {
 			return nil, err
 		}
@@ -273,6 +492,18 @@ func (q *Queries) ListDocumentsByStatus(ctx context.Context, arg ListDocumentsBy
 	return items, nil
 }
 
+const reconcileDocumentCounters = `-- name: ReconcileDocumentCounters :one
+SELECT documents.reconcile_document_counters($1::int)::boolean AS drifted
+`
+
+// Recounts an organization's counters; true when they had drifted
+func (q *Queries) ReconcileDocumentCounters(ctx context.Context, organizationID int32) (bool, error) {
+	row := q.db.QueryRow(ctx, reconcileDocumentCounters, organizationID)
+	var drifted bool
+	err := row.Scan(&drifted)
+	return drifted, err
+}
+
 const updateDocument = `-- name: UpdateDocument :one
 UPDATE documents.documents
 SET
@@ -280,7 +511,7 @@ SET
     metadata = COALESCE($4, metadata),
     updated_at = NOW()
 WHERE id = $1 AND organization_id = $2
-RETURNING id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at
+RETURNING id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at, uploaded_by
 `
 
 type UpdateDocumentParams struct {
@@ -311,6 +542,7 @@ func (q *Queries) UpdateDocument(ctx context.Context, arg UpdateDocumentParams)
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UploadedBy,
 	)
 	return i, err
 }
@@ -319,7 +551,7 @@ const updateDocumentExtractedText = `-- name: UpdateDocumentExtractedText :one
 UPDATE documents.documents
 SET extracted_text = $3, status = 'processed', updated_at = NOW()
 WHERE id = $1 AND organization_id = $2
-RETURNING id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at
+RETURNING id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at, uploaded_by
 `
 
 type UpdateDocumentExtractedTextParams struct {
@@ -344,6 +576,52 @@ func (q *Queries) UpdateDocumentExtractedText(ctx context.Context, arg UpdateDoc
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UploadedBy,
+	)
+	return i, err
+}
+
+const updateDocumentFile = `-- name: UpdateDocumentFile :one
+UPDATE documents.documents
+SET file_asset_id = $3, file_name = $4, content_type = $5, file_size = $6, status = 'pending', updated_at = NOW()
+WHERE id = $1 AND organization_id = $2
+RETURNING id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at, uploaded_by
+`
+
+type UpdateDocumentFileParams struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	FileAssetID    int32  `json:"file_asset_id"`
+	FileName       string `json:"file_name"`
+	ContentType    string `json:"content_type"`
+	FileSize       int64  `json:"file_size"`
+}
+
+// Points a document at a new version's file; its text is extracted again
+func (q *Queries) UpdateDocumentFile(ctx context.Context, arg UpdateDocumentFileParams) (DocumentsDocument, error) {
+	row := q.db.QueryRow(ctx, updateDocumentFile,
+		arg.ID,
+		arg.OrganizationID,
+		arg.FileAssetID,
+		arg.FileName,
+		arg.ContentType,
+		arg.FileSize,
+	)
+	var i DocumentsDocument
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.FileAssetID,
+		&i.Title,
+		&i.FileName,
+		&i.ContentType,
+		&i.FileSize,
+		&i.ExtractedText,
+		&i.Status,
+		&i.Metadata,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.UploadedBy,
 	)
 	return i, err
 }
@@ -352,7 +630,7 @@ const updateDocumentStatus = `-- name: UpdateDocumentStatus :one
 UPDATE documents.documents
 SET status = $3, updated_at = NOW()
 WHERE id = $1 AND organization_id = $2
-RETURNING id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at
+RETURNING id, organization_id, file_asset_id, title, file_name, content_type, file_size, extracted_text, status, metadata, created_at, updated_at, uploaded_by
 `
 
 type UpdateDocumentStatusParams struct {
@@ -377,6 +655,30 @@ func (q *Queries) UpdateDocumentStatus(ctx context.Context, arg UpdateDocumentSt
 		&i.Metadata,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.UploadedBy,
 	)
 	return i, err
 }
+
+const updateDocumentVersionText = `-- name: UpdateDocumentVersionText :exec
+UPDATE documents.document_versions
+SET extracted_text = $3, status = $4
+WHERE document_id = $1 AND file_asset_id = $2
+`
+
+type UpdateDocumentVersionTextParams struct {
+	DocumentID    int32       `json:"document_id"`
+	FileAssetID   int32       `json:"file_asset_id"`
+	ExtractedText pgtype.Text `json:"extracted_text"`
+	Status        string      `json:"status"`
+}
+
+func (q *Queries) UpdateDocumentVersionText(ctx context.Context, arg UpdateDocumentVersionTextParams) error {
+	_, err := q.db.Exec(ctx, updateDocumentVersionText,
+		arg.DocumentID,
+		arg.FileAssetID,
+		arg.ExtractedText,
+		arg.Status,
+	)
+	return err
+}
@@ -25,3 +25,13 @@ func ErrorCode(err error) string {
 	}
 	return ""
 }
+
+// ConstraintName returns the constraint or unique index a Postgres error
+// reports, e.g. which of a table's unique keys a UniqueViolation hit
+func ConstraintName(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.ConstraintName
+	}
+	return ""
+}
@@ -9,6 +9,22 @@ import (
 	pgvector_go "github.com/pgvector/pgvector-go"
 )
 
+// Progress of incremental data warehouse exports per dataset
+type AnalyticsExportWatermark struct {
+	Dataset string `json:"dataset"`
+	// Schema version of the exported files; a new version restarts the export
+	SchemaVersion  int32            `json:"schema_version"`
+	WatermarkAt    pgtype.Timestamp `json:"watermark_at"`
+	WatermarkID    int32            `json:"watermark_id"`
+	RowsExported   int64            `json:"rows_exported"`
+	LastObjectKey  pgtype.Text      `json:"last_object_key"`
+	LastExportedAt pgtype.Timestamp `json:"last_exported_at"`
+	// Exporter lease so only one instance exports a dataset at a time
+	LeaseUntil pgtype.Timestamp `json:"lease_until"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
+}
+
 // Messages within chat sessions with role (user/assistant/system)
 type CognitiveChatMessage struct {
 	ID             int32            `json:"id"`
@@ -18,6 +34,14 @@ type CognitiveChatMessage struct {
 	ReferencedDocs []int32          `json:"referenced_docs"`
 	TokensUsed     pgtype.Int4      `json:"tokens_used"`
 	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	// Model the message was generated with, NULL for user messages
+	Model pgtype.Text `json:"model"`
+	// Temperature the message was generated with, NULL when the model takes none
+	Temperature pgtype.Float4 `json:"temperature"`
+	// Completion token limit the message was generated with
+	MaxTokens pgtype.Int4 `json:"max_tokens"`
+	// Whether generation stopped before the answer was complete
+	Truncated bool `json:"truncated"`
 }
 
 // Conversational AI sessions for RAG-based chat
@@ -28,14 +52,24 @@ type CognitiveChatSession struct {
 	Title          pgtype.Text      `json:"title"`
 	CreatedAt      pgtype.Timestamp `json:"created_at"`
 	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	// Model requested for the conversation, NULL for the server default
+	Model pgtype.Text `json:"model"`
+	// Temperature requested for the conversation, NULL for the server default
+	Temperature pgtype.Float4 `json:"temperature"`
+	// Completion token limit requested for the conversation, NULL for the server default
+	MaxTokens pgtype.Int4 `json:"max_tokens"`
+	// Rolling summary of the conversation up to summarized_through_message_id, NULL until the conversation is first summarized
+	Summary pgtype.Text `json:"summary"`
+	// Newest message covered by the summary; later messages are sent verbatim
+	SummarizedThroughMessageID int32 `json:"summarized_through_message_id"`
 }
 
-// Vector embeddings for documents using OpenAI text-embedding-3-small (1536 dimensions)
+// Vector embeddings for documents using OpenAI text-embedding-3-small, full size (1536 dimensions) or reduced
 type CognitiveDocumentEmbedding struct {
 	ID             int32 `json:"id"`
 	DocumentID     int32 `json:"document_id"`
 	OrganizationID int32 `json:"organization_id"`
-	// Vector embedding for semantic similarity search
+	// Vector embedding for semantic similarity search, with the dimensions configured for the document index
 	Embedding      pgvector_go.Vector `json:"embedding"`
 	ContentHash    pgtype.Text        `json:"content_hash"`
 	ContentPreview pgtype.Text        `json:"content_preview"`
@@ -43,6 +77,65 @@ type CognitiveDocumentEmbedding struct {
 	ChunkIndex pgtype.Int4      `json:"chunk_index"`
 	CreatedAt  pgtype.Timestamp `json:"created_at"`
 	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
+	// Cell range of a spreadsheet chunk or time range (HH:MM:SS-HH:MM:SS) of a transcript chunk, NULL for text documents
+	Location pgtype.Text `json:"location"`
+}
+
+// Versioned custom system prompt and glossary added to an organization's chat prompts
+type CognitivePromptSetting struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	// Starts at 1 and increases with every change; the highest version applies
+	VersionNumber int32 `json:"version_number"`
+	// Persona and instructions added after the built-in system prompt, NULL for none
+	SystemPrompt pgtype.Text `json:"system_prompt"`
+	// Array of {term, definition} objects explaining organization-specific terms
+	Glossary           []byte           `json:"glossary"`
+	CreatedByAccountID pgtype.Int4      `json:"created_by_account_id"`
+	CreatedAt          pgtype.Timestamp `json:"created_at"`
+	// BCP 47 tag of the default answer language, NULL to answer in the language of each question
+	Language pgtype.Text `json:"language"`
+}
+
+// Archives of a member's personal data requested through "download my data"
+type ComplianceDataExport struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+	// running, completed, failed or expired
+	Status string `json:"status"`
+	// The archive, set once completed and cleared when it expires
+	FileAssetID pgtype.Int4 `json:"file_asset_id"`
+	FileSize    pgtype.Int8 `json:"file_size"`
+	// SHA-256 of the token in the emailed download link
+	DownloadTokenHash pgtype.Text `json:"download_token_hash"`
+	DownloadCount     int32       `json:"download_count"`
+	// Per-section counts and the files left out of the archive
+	Summary     []byte           `json:"summary"`
+	Error       pgtype.Text      `json:"error"`
+	StartedAt   pgtype.Timestamp `json:"started_at"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	// When the download link stops working and the archive is deleted
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+// Verification reports for tenant data purges
+type CompliancePurgeReport struct {
+	ID               int32  `json:"id"`
+	OrganizationID   int32  `json:"organization_id"`
+	OrganizationName string `json:"organization_name"`
+	// gdpr_erasure, organization_deletion or staging_expiry
+	Reason string `json:"reason"`
+	// Operator or ticket reference that requested the purge
+	RequestedBy string `json:"requested_by"`
+	// verified when nothing remains, incomplete otherwise
+	Status string `json:"status"`
+	// Per-table, vector index, storage and auth provider results
+	Details     []byte           `json:"details"`
+	StartedAt   pgtype.Timestamp `json:"started_at"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
 }
 
 // Stores uploaded documents (PDFs) with extracted text for RAG
@@ -61,6 +154,59 @@ type DocumentsDocument struct {
 	Metadata  []byte           `json:"metadata"`
 	CreatedAt pgtype.Timestamp `json:"created_at"`
 	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+	// Member who uploaded the document, NULL for imported documents or when the account was deleted
+	UploadedBy pgtype.Int4 `json:"uploaded_by"`
+}
+
+// Uploaded revisions of a document with their extracted text
+// Document type and extracted named entities per document
+type DocumentsDocumentClassification struct {
+	DocumentID     int32  `json:"document_id"`
+	OrganizationID int32  `json:"organization_id"`
+	DocumentType   string `json:"document_type"`
+	// Model confidence in the document type from 0 to 1; 1 for manual overrides
+	Confidence float64 `json:"confidence"`
+	Entities   []byte  `json:"entities"`
+	// model when set by the classification stage, manual when overridden by a user
+	Source       string           `json:"source"`
+	Model        pgtype.Text      `json:"model"`
+	OverriddenBy pgtype.Int4      `json:"overridden_by"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+}
+
+// Per-organization document, storage and chunk counters maintained by triggers and reconciled by a scheduled job
+type DocumentsDocumentCounter struct {
+	OrganizationID  int32 `json:"organization_id"`
+	DocumentCount   int64 `json:"document_count"`
+	PendingCount    int64 `json:"pending_count"`
+	ProcessingCount int64 `json:"processing_count"`
+	ProcessedCount  int64 `json:"processed_count"`
+	FailedCount     int64 `json:"failed_count"`
+	// Total file size of all stored document versions
+	StorageBytes int64 `json:"storage_bytes"`
+	// Embedded document chunks (rows of cognitive.document_embeddings)
+	ChunkCount int64 `json:"chunk_count"`
+	// When the counters were last recounted from the counted tables, NULL if never
+	ReconciledAt pgtype.Timestamp `json:"reconciled_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+}
+
+type DocumentsDocumentVersion struct {
+	ID             int32 `json:"id"`
+	DocumentID     int32 `json:"document_id"`
+	OrganizationID int32 `json:"organization_id"`
+	// Starts at 1 and increases with every uploaded revision
+	VersionNumber int32            `json:"version_number"`
+	FileAssetID   int32            `json:"file_asset_id"`
+	FileName      string           `json:"file_name"`
+	ContentType   string           `json:"content_type"`
+	FileSize      int64            `json:"file_size"`
+	ExtractedText pgtype.Text      `json:"extracted_text"`
+	Status        string           `json:"status"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	// Member who uploaded the version, NULL for imported versions or when the account was deleted
+	UploadedBy pgtype.Int4 `json:"uploaded_by"`
 }
 
 // Stores potential duplicate resources found via vector similarity and LLM adjudication
@@ -139,6 +285,55 @@ type FileManagerFileContext struct {
 	Name string `json:"name"`
 }
 
+// History of background job executions
+type JobsJobRun struct {
+	ID      int64  `json:"id"`
+	JobName string `json:"job_name"`
+	// scheduled or queued
+	Kind string `json:"kind"`
+	// running, succeeded or failed
+	Status string `json:"status"`
+	// Host that ran the job
+	Instance   string           `json:"instance"`
+	Error      pgtype.Text      `json:"error"`
+	StartedAt  pgtype.Timestamp `json:"started_at"`
+	FinishedAt pgtype.Timestamp `json:"finished_at"`
+	// Run time in milliseconds, set when the run finishes
+	DurationMs pgtype.Int8 `json:"duration_ms"`
+}
+
+// Completed and skipped onboarding steps per organization
+type OnboardingOrganizationStep struct {
+	OrganizationID int32  `json:"organization_id"`
+	StepKey        string `json:"step_key"`
+	// completed or skipped
+	Status string `json:"status"`
+	// Member who completed or skipped the step; NULL when detected automatically
+	AccountID pgtype.Int4      `json:"account_id"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+// Time-boxed role elevation requests and grants
+type OrganizationsAccessElevation struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+	// Role granted while the elevation is active
+	RequestedRole   string `json:"requested_role"`
+	Reason          string `json:"reason"`
+	DurationMinutes int32  `json:"duration_minutes"`
+	Status          string `json:"status"`
+	// Approver; NULL when granted by policy
+	DecidedByAccountID pgtype.Int4      `json:"decided_by_account_id"`
+	DecisionNote       string           `json:"decision_note"`
+	DecidedAt          pgtype.Timestamp `json:"decided_at"`
+	// End of the elevation window, set on approval
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
 // User accounts within organizations
 type OrganizationsAccount struct {
 	ID             int32  `json:"id"`
@@ -159,6 +354,355 @@ type OrganizationsAccount struct {
 	LastLoginAt pgtype.Timestamp `json:"last_login_at"`
 	CreatedAt   pgtype.Timestamp `json:"created_at"`
 	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	// Application-defined attributes of the account as a JSON object
+	Metadata []byte `json:"metadata"`
+	// Stored file of the uploaded avatar, resized to AVATAR_SIZE
+	AvatarFileID pgtype.Int4 `json:"avatar_file_id"`
+	// Random key the avatar is served under without authentication
+	AvatarKey pgtype.Text `json:"avatar_key"`
+	// URL clients display as the account avatar
+	AvatarUrl pgtype.Text `json:"avatar_url"`
+	// UUIDv7 identifier exposed to clients instead of the sequential id
+	PublicID pgtype.UUID `json:"public_id"`
+	// human, service_account, oauth_client or guest; only human accounts take a seat
+	Kind string `json:"kind"`
+}
+
+// Last API activity of each account, used for dormancy detection
+type OrganizationsAccountActivity struct {
+	AccountID      int32 `json:"account_id"`
+	OrganizationID int32 `json:"organization_id"`
+	// Last authenticated request of the account, accurate to DORMANCY_ACTIVITY_INTERVAL
+	LastActiveAt pgtype.Timestamp `json:"last_active_at"`
+}
+
+// Recent activities of each account, recorded from the event bus
+type OrganizationsAccountActivityFeed struct {
+	ID             int64  `json:"id"`
+	EventID        string `json:"event_id"`
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	ActivityType   string `json:"activity_type"`
+	// What the activity was about, e.g. the document or setting
+	Details []byte `json:"details"`
+	// When the activity happened, as published
+	OccurredAt pgtype.Timestamp `json:"occurred_at"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+// Latest time of each kind of activity per account
+type OrganizationsAccountActivityTimestamp struct {
+	AccountID      int32            `json:"account_id"`
+	OrganizationID int32            `json:"organization_id"`
+	ActivityType   string           `json:"activity_type"`
+	LastOccurredAt pgtype.Timestamp `json:"last_occurred_at"`
+	// Activities of this kind recorded since the account was created
+	Occurrences int32 `json:"occurrences"`
+}
+
+// Suspensions and reactivations of accounts with their reason and actor
+type OrganizationsAccountStatusChange struct {
+	ID             int64  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	FromStatus     string `json:"from_status"`
+	ToStatus       string `json:"to_status"`
+	// Why the status was changed, shown to admins and emailed to the member
+	Reason         string           `json:"reason"`
+	ActorAccountID pgtype.Int4      `json:"actor_account_id"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+}
+
+// Tags attached to accounts
+type OrganizationsAccountTag struct {
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+	TagID          int32 `json:"tag_id"`
+	// Admin that attached the tag
+	CreatedByAccountID pgtype.Int4      `json:"created_by_account_id"`
+	CreatedAt          pgtype.Timestamp `json:"created_at"`
+}
+
+// API requests per account and quota window, flushed from Redis counters
+type OrganizationsApiUsage struct {
+	OrganizationID int32            `json:"organization_id"`
+	AccountID      int32            `json:"account_id"`
+	WindowStart    pgtype.Timestamp `json:"window_start"`
+	WindowEnd      pgtype.Timestamp `json:"window_end"`
+	// Requests counted in the window as of the last flush
+	RequestCount int64            `json:"request_count"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+}
+
+// Structured auth events recorded from the event bus
+type OrganizationsAuthAuditLog struct {
+	ID int64 `json:"id"`
+	// Organization of the identity, NULL when the event has none (e.g. rejected tokens, lockouts)
+	OrganizationID pgtype.Int4 `json:"organization_id"`
+	AccountID      pgtype.Int4 `json:"account_id"`
+	EventType      string      `json:"event_type"`
+	// Auth provider user ID
+	UserID    pgtype.Text `json:"user_id"`
+	Email     pgtype.Text `json:"email"`
+	SessionID pgtype.Text `json:"session_id"`
+	ClientIp  pgtype.Text `json:"client_ip"`
+	UserAgent pgtype.Text `json:"user_agent"`
+	// Why a login failed, or how a logout was initiated
+	Reason pgtype.Text `json:"reason"`
+	// When the event happened, as published
+	OccurredAt pgtype.Timestamp `json:"occurred_at"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+// Organization auth policy overrides enforced by the auth middleware
+type OrganizationsAuthPolicy struct {
+	OrganizationID int32 `json:"organization_id"`
+	// Reject sessions that were not authenticated with a second factor
+	MfaRequired bool `json:"mfa_required"`
+	// How long after signing in a session may be used; 0 leaves it to the auth provider
+	SessionMaxAgeSeconds int32 `json:"session_max_age_seconds"`
+	// Sign-in methods members may use; empty allows all
+	AllowedAuthMethods []string         `json:"allowed_auth_methods"`
+	UpdatedByAccountID pgtype.Int4      `json:"updated_by_account_id"`
+	CreatedAt          pgtype.Timestamp `json:"created_at"`
+	UpdatedAt          pgtype.Timestamp `json:"updated_at"`
+}
+
+// Decoy API keys and users whose use signals a credential leak
+type OrganizationsCanaryCredential struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	Kind           string `json:"kind"`
+	// Client ID (api_key) or lowercased email (user) the canary is detected by
+	Identifier string `json:"identifier"`
+	// Where the canary was planted
+	Name string `json:"name"`
+	// Revoke the organization's OAuth clients when the canary is used
+	RotateOnTrigger    bool             `json:"rotate_on_trigger"`
+	CreatedByAccountID pgtype.Int4      `json:"created_by_account_id"`
+	TriggerCount       int32            `json:"trigger_count"`
+	LastTriggeredAt    pgtype.Timestamp `json:"last_triggered_at"`
+	CreatedAt          pgtype.Timestamp `json:"created_at"`
+}
+
+// Time-boxed recordings of an organization's API traffic for debugging
+type OrganizationsDebugCapture struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	Reason         string `json:"reason"`
+	StartedBy      string `json:"started_by"`
+	// Field names redacted in addition to DEBUG_CAPTURE_REDACT_FIELDS
+	RedactFields []string         `json:"redact_fields"`
+	MaxRecords   int32            `json:"max_records"`
+	RecordCount  int32            `json:"record_count"`
+	ExpiresAt    pgtype.Timestamp `json:"expires_at"`
+	StoppedAt    pgtype.Timestamp `json:"stopped_at"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+}
+
+// Redacted request/response pairs recorded by a debug capture
+type OrganizationsDebugCaptureRecord struct {
+	ID              int64            `json:"id"`
+	CaptureID       int32            `json:"capture_id"`
+	OrganizationID  int32            `json:"organization_id"`
+	AccountID       pgtype.Int4      `json:"account_id"`
+	Method          string           `json:"method"`
+	Path            string           `json:"path"`
+	Route           string           `json:"route"`
+	Query           string           `json:"query"`
+	ClientIp        string           `json:"client_ip"`
+	RequestHeaders  []byte           `json:"request_headers"`
+	RequestBody     string           `json:"request_body"`
+	Status          int32            `json:"status"`
+	DurationMs      int32            `json:"duration_ms"`
+	ResponseHeaders []byte           `json:"response_headers"`
+	ResponseBody    string           `json:"response_body"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	// The record is deleted after this time (DEBUG_CAPTURE_RETENTION)
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+}
+
+// Member email address changes awaiting confirmation or within their rollback window
+type OrganizationsEmailChangeRequest struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	OldEmail       string `json:"old_email"`
+	NewEmail       string `json:"new_email"`
+	Status         string `json:"status"`
+	// SHA-256 of the token emailed to the new address
+	ConfirmTokenHash string `json:"confirm_token_hash"`
+	// SHA-256 of the token emailed to the old address
+	RevertTokenHash string `json:"revert_token_hash"`
+	// Latest time the new address can be confirmed
+	ExpiresAt   pgtype.Timestamp `json:"expires_at"`
+	ConfirmedAt pgtype.Timestamp `json:"confirmed_at"`
+	// Latest time the old address can roll back a confirmed change
+	RevertibleUntil pgtype.Timestamp `json:"revertible_until"`
+	RevertedAt      pgtype.Timestamp `json:"reverted_at"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+}
+
+// Social identities linked to accounts
+type OrganizationsIdentity struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+	// google or github
+	Provider string `json:"provider"`
+	// Stable user ID at the provider (Google sub, GitHub user ID)
+	ProviderUserID string `json:"provider_user_id"`
+	// Email the provider reported when the identity was linked; may differ from the account email
+	Email     pgtype.Text      `json:"email"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+// Single-use invitations to register as a member of an organization
+type OrganizationsInvite struct {
+	ID                 int32       `json:"id"`
+	OrganizationID     int32       `json:"organization_id"`
+	CreatedByAccountID pgtype.Int4 `json:"created_by_account_id"`
+	// Address the invite is pinned to; NULL lets whoever holds the link accept it
+	Email pgtype.Text `json:"email"`
+	// Role the invited member is given
+	RoleSlug string `json:"role_slug"`
+	Status   string `json:"status"`
+	// SHA-256 of the invite token
+	TokenHash string `json:"token_hash"`
+	// Latest time the invite can be accepted
+	ExpiresAt  pgtype.Timestamp `json:"expires_at"`
+	AcceptedAt pgtype.Timestamp `json:"accepted_at"`
+	// Address of the member who accepted the invite
+	AcceptedEmail pgtype.Text      `json:"accepted_email"`
+	RevokedAt     pgtype.Timestamp `json:"revoked_at"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	UpdatedAt     pgtype.Timestamp `json:"updated_at"`
+}
+
+// CIDR ranges allowed to access the API on behalf of an organization
+type OrganizationsIpAllowlistEntry struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	// Allowed network in canonical CIDR notation
+	Cidr        string `json:"cidr"`
+	Description string `json:"description"`
+	// Account that added the entry
+	CreatedByAccountID pgtype.Int4      `json:"created_by_account_id"`
+	CreatedAt          pgtype.Timestamp `json:"created_at"`
+}
+
+// Delayed MFA reset requests for members who lost their authenticator
+type OrganizationsMfaRecoveryRequest struct {
+	ID               int32  `json:"id"`
+	OrganizationID   int32  `json:"organization_id"`
+	AccountID        int32  `json:"account_id"`
+	Status           string `json:"status"`
+	RequiresApproval bool   `json:"requires_approval"`
+	// SHA-256 of the one-time completion token
+	TokenHash          string           `json:"token_hash"`
+	DecidedByAccountID pgtype.Int4      `json:"decided_by_account_id"`
+	DecidedAt          pgtype.Timestamp `json:"decided_at"`
+	// Earliest time the reset can be completed
+	EligibleAt pgtype.Timestamp `json:"eligible_at"`
+	// Latest time the reset can be completed
+	ExpiresAt   pgtype.Timestamp `json:"expires_at"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+}
+
+// In-app notifications of accounts
+type OrganizationsNotification struct {
+	ID               int64  `json:"id"`
+	OrganizationID   int32  `json:"organization_id"`
+	AccountID        int32  `json:"account_id"`
+	NotificationType string `json:"notification_type"`
+	Title            string `json:"title"`
+	Body             string `json:"body"`
+	// When the member marked the notification read, NULL while unread
+	ReadAt    pgtype.Timestamp `json:"read_at"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+}
+
+// Per-account opt-ins and opt-outs of notification types by channel
+type OrganizationsNotificationPreference struct {
+	OrganizationID   int32  `json:"organization_id"`
+	AccountID        int32  `json:"account_id"`
+	NotificationType string `json:"notification_type"`
+	// email or in_app
+	Channel   string           `json:"channel"`
+	Enabled   bool             `json:"enabled"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+// OAuth2 clients that obtain access tokens with the client credentials grant
+type OrganizationsOauthClient struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	// Service account the client acts as
+	AccountID int32  `json:"account_id"`
+	ClientID  string `json:"client_id"`
+	// SHA-256 of the client secret
+	SecretHash string `json:"secret_hash"`
+	Name       string `json:"name"`
+	// Permissions (resource:action) the client may request
+	Scopes             []string    `json:"scopes"`
+	CreatedByAccountID pgtype.Int4 `json:"created_by_account_id"`
+	// Last time the client obtained a token
+	LastUsedAt pgtype.Timestamp `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamp `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
+}
+
+// Single-use OpenID Connect authorization codes
+type OrganizationsOidcAuthorizationCode struct {
+	ID             int32 `json:"id"`
+	OidcClientID   int32 `json:"oidc_client_id"`
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+	// SHA-256 of the authorization code
+	CodeHash string `json:"code_hash"`
+	// Auth provider member ID used as the id_token subject
+	Subject       string      `json:"subject"`
+	EmailVerified bool        `json:"email_verified"`
+	RedirectUri   string      `json:"redirect_uri"`
+	Scopes        []string    `json:"scopes"`
+	Nonce         pgtype.Text `json:"nonce"`
+	// PKCE S256 code challenge
+	CodeChallenge pgtype.Text      `json:"code_challenge"`
+	AuthTime      pgtype.Timestamp `json:"auth_time"`
+	ExpiresAt     pgtype.Timestamp `json:"expires_at"`
+	UsedAt        pgtype.Timestamp `json:"used_at"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
+// Relying parties that sign members in with OpenID Connect
+type OrganizationsOidcClient struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	ClientID       string `json:"client_id"`
+	// SHA-256 of the client secret
+	SecretHash string `json:"secret_hash"`
+	Name       string `json:"name"`
+	// Exact redirect URIs the client may receive codes at
+	RedirectUris       []string         `json:"redirect_uris"`
+	CreatedByAccountID pgtype.Int4      `json:"created_by_account_id"`
+	RevokedAt          pgtype.Timestamp `json:"revoked_at"`
+	CreatedAt          pgtype.Timestamp `json:"created_at"`
+	UpdatedAt          pgtype.Timestamp `json:"updated_at"`
+}
+
+// Scopes a member approved for an OpenID Connect client
+type OrganizationsOidcConsent struct {
+	ID             int32            `json:"id"`
+	OidcClientID   int32            `json:"oidc_client_id"`
+	OrganizationID int32            `json:"organization_id"`
+	AccountID      int32            `json:"account_id"`
+	Scopes         []string         `json:"scopes"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
 }
 
 // Organizations (tenants) in the system
@@ -176,6 +720,142 @@ type OrganizationsOrganization struct {
 	StytchConnectionName pgtype.Text      `json:"stytch_connection_name"`
 	CreatedAt            pgtype.Timestamp `json:"created_at"`
 	UpdatedAt            pgtype.Timestamp `json:"updated_at"`
+	// production, staging or sandbox
+	Environment string `json:"environment"`
+	// Production organization owning a staging or sandbox organization
+	ParentOrganizationID pgtype.Int4 `json:"parent_organization_id"`
+	// When a staging or sandbox organization is purged
+	ExpiresAt pgtype.Timestamp `json:"expires_at"`
+}
+
+// Secondary email addresses of accounts; the primary address stays in accounts.email
+type OrganizationsSecondaryEmail struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+	// Normalized address, unique within the organization
+	Email string `json:"email"`
+	// SHA-256 of the pending verification link token; NULL once verified
+	VerificationTokenHash pgtype.Text `json:"verification_token_hash"`
+	// When the pending verification link stops working
+	VerificationExpiresAt pgtype.Timestamp `json:"verification_expires_at"`
+	// When the owner confirmed the address; unverified addresses cannot receive notifications
+	VerifiedAt pgtype.Timestamp `json:"verified_at"`
+	// Whether email notifications are also sent to the address
+	Notifications bool             `json:"notifications"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
+// Non-human users of an organization that authenticate with API keys only
+type OrganizationsServiceAccount struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	// Account the service account acts as
+	AccountID int32 `json:"account_id"`
+	// Generated machine name, also the local part of the account email
+	Username    string `json:"username"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Member responsible for the automation
+	OwnerAccountID     pgtype.Int4 `json:"owner_account_id"`
+	CreatedByAccountID pgtype.Int4 `json:"created_by_account_id"`
+	// When the service account was disabled; its keys are rejected while set
+	DisabledAt pgtype.Timestamp `json:"disabled_at"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+	UpdatedAt  pgtype.Timestamp `json:"updated_at"`
+}
+
+// API keys service accounts authenticate with
+type OrganizationsServiceAccountKey struct {
+	ID               int32  `json:"id"`
+	OrganizationID   int32  `json:"organization_id"`
+	ServiceAccountID int32  `json:"service_account_id"`
+	Name             string `json:"name"`
+	// First characters of the key, shown to tell keys apart
+	KeyPrefix string `json:"key_prefix"`
+	// SHA-256 of the key
+	KeyHash string `json:"key_hash"`
+	// When the key stops working; NULL for keys that do not expire
+	ExpiresAt          pgtype.Timestamp `json:"expires_at"`
+	CreatedByAccountID pgtype.Int4      `json:"created_by_account_id"`
+	// Last request made with the key, recorded at most once a minute
+	LastUsedAt pgtype.Timestamp `json:"last_used_at"`
+	RevokedAt  pgtype.Timestamp `json:"revoked_at"`
+	CreatedAt  pgtype.Timestamp `json:"created_at"`
+}
+
+// Tags organization admins attach to users
+type OrganizationsTag struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	// Lowercase letters, digits, hyphens and underscores
+	Name string `json:"name"`
+	// Account that first used the tag
+	CreatedByAccountID pgtype.Int4      `json:"created_by_account_id"`
+	CreatedAt          pgtype.Timestamp `json:"created_at"`
+}
+
+// Imports of organization bundles exported from another instance
+type PortabilityOrganizationImport struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+	// Slug of the organization the bundle was exported from
+	SourceOrganization string `json:"source_organization"`
+	// skip, overwrite or fail: what happens to items that already exist
+	Strategy string `json:"strategy"`
+	// Operator or ticket reference that requested the import
+	RequestedBy    string `json:"requested_by"`
+	Status         string `json:"status"`
+	TotalItems     int32  `json:"total_items"`
+	ProcessedItems int32  `json:"processed_items"`
+	// Per-section counts and item failures
+	Result      []byte           `json:"result"`
+	Error       pgtype.Text      `json:"error"`
+	StartedAt   pgtype.Timestamp `json:"started_at"`
+	UpdatedAt   pgtype.Timestamp `json:"updated_at"`
+	CompletedAt pgtype.Timestamp `json:"completed_at"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+// Permissions that can be granted to roles
+type RbacPermission struct {
+	// Permission in resource:action form
+	ID          string           `json:"id"`
+	Description string           `json:"description"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+// Roles and their display metadata; permissions are in role_permissions
+type RbacRole struct {
+	// Role ID as it appears in tokens
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Built-in role that cannot be deleted
+	IsSystem  bool             `json:"is_system"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+// Roles granted to users in one organization or globally, on top of the auth provider role
+type RbacRoleAssignment struct {
+	ID     int32  `json:"id"`
+	RoleID string `json:"role_id"`
+	// Organization of the assignment; NULL for global assignments
+	OrganizationID pgtype.Int4 `json:"organization_id"`
+	// Account holding the role; NULL for global assignments
+	AccountID pgtype.Int4 `json:"account_id"`
+	// Normalized email of the user holding a global assignment
+	Email pgtype.Text `json:"email"`
+	// Admin who made an organization assignment; NULL for operator assignments
+	AssignedByAccountID pgtype.Int4      `json:"assigned_by_account_id"`
+	CreatedAt           pgtype.Timestamp `json:"created_at"`
+}
+
+// Permissions granted to each role
+type RbacRolePermission struct {
+	RoleID       string `json:"role_id"`
+	PermissionID string `json:"permission_id"`
 }
 
 // Stores vector embeddings for resources using OpenAI text-embedding-3-small (1536 dimensions)
@@ -192,6 +872,17 @@ type ResourceEmbedding struct {
 	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
 }
 
+// Preferred currency and locale for displaying prices to an organization
+type SubscriptionBillingBillingSetting struct {
+	OrganizationID int32 `json:"organization_id"`
+	// ISO 4217 currency code; NULL negotiates from the request
+	Currency pgtype.Text `json:"currency"`
+	// BCP 47 locale used to format amounts; NULL negotiates from the request
+	Locale    pgtype.Text      `json:"locale"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
 // Tracks usage quotas per organization for fast quota checks
 type SubscriptionBillingQuotaTracking struct {
 	ID             int32            `json:"id"`
@@ -206,6 +897,23 @@ type SubscriptionBillingQuotaTracking struct {
 	InvoiceCount int32 `json:"invoice_count"`
 }
 
+// Purchased and used seats per organization, synced to the subscription seat quantity
+type SubscriptionBillingSeatAllocation struct {
+	OrganizationID int32 `json:"organization_id"`
+	// Seat quantity of the subscription; 0 when the plan is not seat-based
+	PurchasedSeats int32 `json:"purchased_seats"`
+	UsedSeats      int32 `json:"used_seats"`
+	// Grow and shrink the subscription with membership instead of blocking new members
+	AutoExpand bool `json:"auto_expand"`
+	// When the debounced sync runs; NULL when nothing changed since the last sync
+	SyncDueAt       pgtype.Timestamp `json:"sync_due_at"`
+	SyncRequestedAt pgtype.Timestamp `json:"sync_requested_at"`
+	SyncedAt        pgtype.Timestamp `json:"synced_at"`
+	SyncError       pgtype.Text      `json:"sync_error"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+}
+
 // Stores subscription details from Polar, synced via webhooks
 type SubscriptionBillingSubscription struct {
 	ID             int32 `json:"id"`
@@ -224,4 +932,86 @@ type SubscriptionBillingSubscription struct {
 	CreatedAt          pgtype.Timestamp `json:"created_at"`
 	UpdatedAt          pgtype.Timestamp `json:"updated_at"`
 	Metadata           []byte           `json:"metadata"`
+	// Start of the trial; kept after the subscription converts
+	TrialStart pgtype.Timestamp `json:"trial_start"`
+	// End of the trial; local trials expire at this time
+	TrialEnd pgtype.Timestamp `json:"trial_end"`
+	// When admins were emailed that the trial is ending
+	TrialReminderSentAt pgtype.Timestamp `json:"trial_reminder_sent_at"`
+}
+
+// Metered usage recorded by features, rolled up hourly into usage_rollups
+type SubscriptionBillingUsageEvent struct {
+	ID             int64            `json:"id"`
+	OrganizationID int32            `json:"organization_id"`
+	AccountID      pgtype.Int4      `json:"account_id"`
+	Metric         string           `json:"metric"`
+	Quantity       int64            `json:"quantity"`
+	Source         string           `json:"source"`
+	IdempotencyKey pgtype.Text      `json:"idempotency_key"`
+	OccurredAt     pgtype.Timestamp `json:"occurred_at"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+}
+
+// Hourly usage per organization and metric, reported to the billing provider once the hour closed
+type SubscriptionBillingUsageRollup struct {
+	ID             int32            `json:"id"`
+	OrganizationID int32            `json:"organization_id"`
+	Metric         string           `json:"metric"`
+	PeriodStart    pgtype.Timestamp `json:"period_start"`
+	PeriodEnd      pgtype.Timestamp `json:"period_end"`
+	// Sum of the events in the hour, or their maximum for gauge metrics
+	Quantity   int64 `json:"quantity"`
+	EventCount int32 `json:"event_count"`
+	// Quantity already reported to the billing provider; the difference is reported next
+	ReportedQuantity int64            `json:"reported_quantity"`
+	ReportedAt       pgtype.Timestamp `json:"reported_at"`
+	CreatedAt        pgtype.Timestamp `json:"created_at"`
+	UpdatedAt        pgtype.Timestamp `json:"updated_at"`
+}
+
+// Subscriptions paid by individual accounts (BILLING_SCOPE=user), synced via webhooks
+type SubscriptionBillingUserSubscription struct {
+	ID        int32 `json:"id"`
+	AccountID int32 `json:"account_id"`
+	// Organization of the account, so tenant purges remove the subscription
+	OrganizationID int32 `json:"organization_id"`
+	// Billing provider customer ID: user_ followed by the account public_id
+	ExternalCustomerID string           `json:"external_customer_id"`
+	SubscriptionID     string           `json:"subscription_id"`
+	SubscriptionStatus string           `json:"subscription_status"`
+	ProductID          string           `json:"product_id"`
+	ProductName        pgtype.Text      `json:"product_name"`
+	PlanName           pgtype.Text      `json:"plan_name"`
+	CurrentPeriodStart pgtype.Timestamp `json:"current_period_start"`
+	CurrentPeriodEnd   pgtype.Timestamp `json:"current_period_end"`
+	CancelAtPeriodEnd  bool             `json:"cancel_at_period_end"`
+	CanceledAt         pgtype.Timestamp `json:"canceled_at"`
+	Metadata           []byte           `json:"metadata"`
+	CreatedAt          pgtype.Timestamp `json:"created_at"`
+	UpdatedAt          pgtype.Timestamp `json:"updated_at"`
+}
+
+// Support and contact requests routed to the support inbox
+type SupportTicket struct {
+	ID int32 `json:"id"`
+	// Public ticket identifier
+	Reference      string      `json:"reference"`
+	OrganizationID pgtype.Int4 `json:"organization_id"`
+	AccountID      pgtype.Int4 `json:"account_id"`
+	Name           string      `json:"name"`
+	Email          string      `json:"email"`
+	Subject        string      `json:"subject"`
+	Message        string      `json:"message"`
+	Status         string      `json:"status"`
+	// SHA-256 of the requester status access token
+	AccessTokenHash string           `json:"access_token_hash"`
+	CreatedAt       pgtype.Timestamp `json:"created_at"`
+	UpdatedAt       pgtype.Timestamp `json:"updated_at"`
+}
+
+type SupportTicketAttachment struct {
+	TicketID    int32            `json:"ticket_id"`
+	FileAssetID int32            `json:"file_asset_id"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
 }
@@ -46,6 +46,90 @@ func (q *Queries) CheckAccountPermission(ctx context.Context, arg CheckAccountPe
 	return i, err
 }
 
+const countAccountsFiltered = `-- name: CountAccountsFiltered :one
+SELECT COUNT(*) FROM organizations.accounts
+WHERE organization_id = $1::int
+  AND ($2::text IS NULL OR email ILIKE '%' || $2::text || '%' OR full_name ILIKE '%' || $2::text || '%')
+  AND ($3::text IS NULL OR email ILIKE '%' || $3::text || '%')
+  AND ($4::text IS NULL OR status = $4::text)
+  AND ($5::text IS NULL OR role = $5::text)
+  AND ($6::boolean IS NULL OR stytch_email_verified = $6::boolean)
+  AND ($7::timestamp IS NULL OR created_at >= $7::timestamp)
+  AND ($8::timestamp IS NULL OR created_at < $8::timestamp)
+  AND ($9::text IS NULL OR EXISTS (
+      SELECT 1 FROM organizations.account_tags at
+      INNER JOIN organizations.tags t ON t.id = at.tag_id
+      WHERE at.account_id = accounts.id AND t.name = $9::text
+  ))
+  AND ($10::boolean IS NULL OR $10::boolean = (
+      EXISTS (SELECT 1 FROM organizations.service_accounts sa WHERE sa.account_id = accounts.id)
+      OR EXISTS (SELECT 1 FROM organizations.oauth_clients oc WHERE oc.account_id = accounts.id)
+  ))
+`
+
+type CountAccountsFilteredParams struct {
+	OrganizationID int32            `json:"organization_id"`
+	Pattern        pgtype.Text      `json:"pattern"`
+	EmailPattern   pgtype.Text      `json:"email_pattern"`
+	Status         pgtype.Text      `json:"status"`
+	Role           pgtype.Text      `json:"role"`
+	EmailVerified  pgtype.Bool      `json:"email_verified"`
+	CreatedAfter   pgtype.Timestamp `json:"created_after"`
+	CreatedBefore  pgtype.Timestamp `json:"created_before"`
+	Tag            pgtype.Text      `json:"tag"`
+	Service        pgtype.Bool      `json:"service"`
+}
+
+func (q *Queries) CountAccountsFiltered(ctx context.Context, arg CountAccountsFilteredParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countAccountsFiltered,
+		arg.OrganizationID,
+		arg.Pattern,
+		arg.EmailPattern,
+		arg.Status,
+		arg.Role,
+		arg.EmailVerified,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Tag,
+		arg.Service,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPlusAddressedEmails = `-- name: CountPlusAddressedEmails :one
+SELECT (
+    (SELECT COUNT(*) FROM organizations.accounts a
+     WHERE strpos(split_part(a.email, '@', 1), '+') > 1)
+  + (SELECT COUNT(*) FROM organizations.secondary_emails s
+     WHERE strpos(split_part(s.email, '@', 1), '+') > 1)
+  + (SELECT COUNT(*) FROM rbac.role_assignments r
+     WHERE r.email IS NOT NULL AND strpos(split_part(r.email, '@', 1), '+') > 1)
+)::bigint AS plus_addressed
+`
+
+// Stored member emails with a "+tag" in the local part, which lookups no
+// longer match once ACCOUNT_EMAIL_CANONICALIZE_PLUS strips the tag
+func (q *Queries) CountPlusAddressedEmails(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countPlusAddressedEmails)
+	var plusAddressed int64
+	err := row.Scan(&plusAddressed)
+	return plusAddressed, err
+}
+
+const countStagingOrganizations = `-- name: CountStagingOrganizations :one
+SELECT COUNT(*) FROM organizations.organizations
+WHERE parent_organization_id = $1::int
+`
+
+func (q *Queries) CountStagingOrganizations(ctx context.Context, parentOrganizationID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countStagingOrganizations, parentOrganizationID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccount = `-- name: CreateAccount :one
 
 INSERT INTO organizations.accounts (
@@ -57,7 +141,8 @@ INSERT INTO organizations.accounts (
     stytch_role_slug,
     stytch_email_verified,
     role,
-    status
+    status,
+    kind
 ) VALUES (
     $1,
     $2,
@@ -67,7 +152,8 @@ INSERT INTO organizations.accounts (
     $6,
     $7,
     $8,
-    $9
+    $9,
+    $10
 ) RETURNING
     id,
     organization_id,
@@ -81,7 +167,13 @@ INSERT INTO organizations.accounts (
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 `
 
 type CreateAccountParams struct {
@@ -94,6 +186,7 @@ type CreateAccountParams struct {
 	StytchEmailVerified bool        `json:"stytch_email_verified"`
 	Role                string      `json:"role"`
 	Status              string      `json:"status"`
+	Kind                string      `json:"kind"`
 }
 
 // Accounts queries
@@ -108,6 +201,7 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (O
 		arg.StytchEmailVerified,
 		arg.Role,
 		arg.Status,
+		arg.Kind,
 	)
 	var i OrganizationsAccount
 	err := row.Scan(
@@ -124,6 +218,12 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (O
 		&i.LastLoginAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Metadata,
+		&i.AvatarFileID,
+		&i.AvatarKey,
+		&i.AvatarUrl,
+		&i.PublicID,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -132,11 +232,17 @@ const createOrganization = `-- name: CreateOrganization :one
 INSERT INTO organizations.organizations (
     slug,
     name,
-    status
+    status,
+    environment,
+    parent_organization_id,
+    expires_at
 ) VALUES (
     $1,
     $2,
-    $3
+    $3,
+    $4,
+    $5,
+    $6
 ) RETURNING
     id,
     slug,
@@ -146,17 +252,30 @@ INSERT INTO organizations.organizations (
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 `
 
 type CreateOrganizationParams struct {
-	Slug   string `json:"slug"`
-	Name   string `json:"name"`
-	Status string `json:"status"`
+	Slug                 string           `json:"slug"`
+	Name                 string           `json:"name"`
+	Status               string           `json:"status"`
+	Environment          string           `json:"environment"`
+	ParentOrganizationID pgtype.Int4      `json:"parent_organization_id"`
+	ExpiresAt            pgtype.Timestamp `json:"expires_at"`
 }
 
 func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (OrganizationsOrganization, error) {
-	row := q.db.QueryRow(ctx, createOrganization, arg.Slug, arg.Name, arg.Status)
+	row := q.db.QueryRow(ctx, createOrganization,
+		arg.Slug,
+		arg.Name,
+		arg.Status,
+		arg.Environment,
+		arg.ParentOrganizationID,
+		arg.ExpiresAt,
+	)
 	var i OrganizationsOrganization
 	err := row.Scan(
 		&i.ID,
@@ -168,6 +287,9 @@ func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganization
 		&i.StytchConnectionName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Environment,
+		&i.ParentOrganizationID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -214,7 +336,13 @@ SELECT
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 FROM organizations.accounts
 WHERE email = $1 AND organization_id = $2
 `
@@ -241,6 +369,12 @@ func (q *Queries) GetAccountByEmail(ctx context.Context, arg GetAccountByEmailPa
 		&i.LastLoginAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Metadata,
+		&i.AvatarFileID,
+		&i.AvatarKey,
+		&i.AvatarUrl,
+		&i.PublicID,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -259,7 +393,13 @@ SELECT
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 FROM organizations.accounts
 WHERE id = $1 AND organization_id = $2
 `
@@ -286,10 +426,90 @@ func (q *Queries) GetAccountByID(ctx context.Context, arg GetAccountByIDParams)
 		&i.LastLoginAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Metadata,
+		&i.AvatarFileID,
+		&i.AvatarKey,
+		&i.AvatarUrl,
+		&i.PublicID,
+		&i.Kind,
+	)
+	return i, err
+}
+
+const getAccountByPublicID = `-- name: GetAccountByPublicID :one
+SELECT
+    id,
+    organization_id,
+    email,
+    full_name,
+    stytch_member_id,
+    stytch_role_id,
+    stytch_role_slug,
+    stytch_email_verified,
+    role,
+    status,
+    last_login_at,
+    created_at,
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
+FROM organizations.accounts
+WHERE public_id = $1 AND organization_id = $2
+`
+
+type GetAccountByPublicIDParams struct {
+	PublicID       pgtype.UUID `json:"public_id"`
+	OrganizationID int32       `json:"organization_id"`
+}
+
+func (q *Queries) GetAccountByPublicID(ctx context.Context, arg GetAccountByPublicIDParams) (OrganizationsAccount, error) {
+	row := q.db.QueryRow(ctx, getAccountByPublicID, arg.PublicID, arg.OrganizationID)
+	var i OrganizationsAccount
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Email,
+		&i.FullName,
+		&i.StytchMemberID,
+		&i.StytchRoleID,
+		&i.StytchRoleSlug,
+		&i.StytchEmailVerified,
+		&i.Role,
+		&i.Status,
+		&i.LastLoginAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Metadata,
+		&i.AvatarFileID,
+		&i.AvatarKey,
+		&i.AvatarUrl,
+		&i.PublicID,
+		&i.Kind,
 	)
 	return i, err
 }
 
+const getAccountMetadata = `-- name: GetAccountMetadata :one
+SELECT metadata FROM organizations.accounts
+WHERE id = $1 AND organization_id = $2
+`
+
+type GetAccountMetadataParams struct {
+	ID             int32 `json:"id"`
+	OrganizationID int32 `json:"organization_id"`
+}
+
+func (q *Queries) GetAccountMetadata(ctx context.Context, arg GetAccountMetadataParams) ([]byte, error) {
+	row := q.db.QueryRow(ctx, getAccountMetadata, arg.ID, arg.OrganizationID)
+	var metadata []byte
+	err := row.Scan(&metadata)
+	return metadata, err
+}
+
 const getAccountOrganization = `-- name: GetAccountOrganization :one
 SELECT
     o.id,
@@ -300,7 +520,10 @@ SELECT
     o.stytch_connection_id,
     o.stytch_connection_name,
     o.created_at,
-    o.updated_at
+    o.updated_at,
+    o.environment,
+    o.parent_organization_id,
+    o.expires_at
 FROM organizations.organizations o
 INNER JOIN organizations.accounts a ON o.id = a.organization_id
 WHERE a.id = $1
@@ -319,6 +542,9 @@ func (q *Queries) GetAccountOrganization(ctx context.Context, id int32) (Organiz
 		&i.StytchConnectionName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Environment,
+		&i.ParentOrganizationID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -386,6 +612,18 @@ func (q *Queries) GetAccountStats(ctx context.Context, id int32) (GetAccountStat
 	return i, err
 }
 
+const getAvatarFileIDByKey = `-- name: GetAvatarFileIDByKey :one
+SELECT avatar_file_id FROM organizations.accounts
+WHERE avatar_key = $1::text AND avatar_file_id IS NOT NULL
+`
+
+func (q *Queries) GetAvatarFileIDByKey(ctx context.Context, avatarKey string) (pgtype.Int4, error) {
+	row := q.db.QueryRow(ctx, getAvatarFileIDByKey, avatarKey)
+	var avatar_file_id pgtype.Int4
+	err := row.Scan(&avatar_file_id)
+	return avatar_file_id, err
+}
+
 const getOrganizationByID = `-- name: GetOrganizationByID :one
 SELECT
     id,
@@ -396,7 +634,10 @@ SELECT
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 FROM organizations.organizations
 WHERE id = $1
 `
@@ -414,6 +655,9 @@ func (q *Queries) GetOrganizationByID(ctx context.Context, id int32) (Organizati
 		&i.StytchConnectionName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Environment,
+		&i.ParentOrganizationID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -428,7 +672,10 @@ SELECT
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 FROM organizations.organizations
 WHERE slug = $1
 `
@@ -446,6 +693,9 @@ func (q *Queries) GetOrganizationBySlug(ctx context.Context, slug string) (Organ
 		&i.StytchConnectionName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Environment,
+		&i.ParentOrganizationID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -460,7 +710,10 @@ SELECT
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 FROM organizations.organizations
 WHERE stytch_org_id = $1
 `
@@ -478,6 +731,9 @@ func (q *Queries) GetOrganizationByStytchID(ctx context.Context, stytchOrgID pgt
 		&i.StytchConnectionName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Environment,
+		&i.ParentOrganizationID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -493,12 +749,16 @@ SELECT
     o.stytch_connection_id,
     o.stytch_connection_name,
     o.created_at,
-    o.updated_at
+    o.updated_at,
+    o.environment,
+    o.parent_organization_id,
+    o.expires_at
 FROM organizations.organizations o
 INNER JOIN organizations.accounts a ON o.id = a.organization_id
 WHERE a.email = $1
-  AND a.status = 'active'
-  AND o.status = 'active'
+  AND a.status IN ('active', 'dormant')
+  AND o.status IN ('active', 'dormant')
+ORDER BY (o.environment = 'production') DESC, o.id
 LIMIT 1
 `
 
@@ -516,6 +776,9 @@ func (q *Queries) GetOrganizationByUserEmail(ctx context.Context, email string)
 		&i.StytchConnectionName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Environment,
+		&i.ParentOrganizationID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -588,7 +851,13 @@ SELECT
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 FROM organizations.accounts
 WHERE organization_id = $1
 ORDER BY created_at DESC
@@ -617,6 +886,190 @@ func (q *Queries) ListAccountsByOrganization(ctx context.Context, organizationID
 			&i.LastLoginAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Metadata,
+			&i.AvatarFileID,
+			&i.AvatarKey,
+			&i.AvatarUrl,
+			&i.PublicID,
+			&i.Kind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAccountsFiltered = `-- name: ListAccountsFiltered :many
+SELECT
+    id,
+    organization_id,
+    email,
+    full_name,
+    stytch_member_id,
+    stytch_role_id,
+    stytch_role_slug,
+    stytch_email_verified,
+    role,
+    status,
+    last_login_at,
+    created_at,
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
+FROM organizations.accounts
+WHERE organization_id = $1::int
+  AND ($2::text IS NULL OR email ILIKE '%' || $2::text || '%' OR full_name ILIKE '%' || $2::text || '%')
+  AND ($3::text IS NULL OR email ILIKE '%' || $3::text || '%')
+  AND ($4::text IS NULL OR status = $4::text)
+  AND ($5::text IS NULL OR role = $5::text)
+  AND ($6::boolean IS NULL OR stytch_email_verified = $6::boolean)
+  AND ($7::timestamp IS NULL OR created_at >= $7::timestamp)
+  AND ($8::timestamp IS NULL OR created_at < $8::timestamp)
+  AND ($9::text IS NULL OR EXISTS (
+      SELECT 1 FROM organizations.account_tags at
+      INNER JOIN organizations.tags t ON t.id = at.tag_id
+      WHERE at.account_id = accounts.id AND t.name = $9::text
+  ))
+  AND ($10::boolean IS NULL OR $10::boolean = (
+      EXISTS (SELECT 1 FROM organizations.service_accounts sa WHERE sa.account_id = accounts.id)
+      OR EXISTS (SELECT 1 FROM organizations.oauth_clients oc WHERE oc.account_id = accounts.id)
+  ))
+ORDER BY
+    CASE WHEN $11::text = 'created_asc' THEN created_at END ASC,
+    CASE WHEN $11::text = 'email_asc' THEN email END ASC,
+    CASE WHEN $11::text = 'email_desc' THEN email END DESC,
+    CASE WHEN $11::text = 'name_asc' THEN full_name END ASC,
+    CASE WHEN $11::text = 'last_login_desc' THEN last_login_at END DESC NULLS LAST,
+    created_at DESC,
+    id DESC
+LIMIT $12 OFFSET $13
+`
+
+type ListAccountsFilteredParams struct {
+	OrganizationID int32            `json:"organization_id"`
+	Pattern        pgtype.Text      `json:"pattern"`
+	EmailPattern   pgtype.Text      `json:"email_pattern"`
+	Status         pgtype.Text      `json:"status"`
+	Role           pgtype.Text      `json:"role"`
+	EmailVerified  pgtype.Bool      `json:"email_verified"`
+	CreatedAfter   pgtype.Timestamp `json:"created_after"`
+	CreatedBefore  pgtype.Timestamp `json:"created_before"`
+	Tag            pgtype.Text      `json:"tag"`
+	Service        pgtype.Bool      `json:"service"`
+	Sort           string           `json:"sort"`
+	RowLimit       int32            `json:"row_limit"`
+	RowOffset      int32            `json:"row_offset"`
+}
+
+// Accounts of an organization matching the optional filters; pattern and email_pattern have LIKE wildcards (%, _) escaped.
+// sort is created_desc (default), created_asc, email_asc, email_desc, name_asc or last_login_desc; ties are newest first.
+func (q *Queries) ListAccountsFiltered(ctx context.Context, arg ListAccountsFilteredParams) ([]OrganizationsAccount, error) {
+	rows, err := q.db.Query(ctx, listAccountsFiltered,
+		arg.OrganizationID,
+		arg.Pattern,
+		arg.EmailPattern,
+		arg.Status,
+		arg.Role,
+		arg.EmailVerified,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.Tag,
+		arg.Service,
+		arg.Sort,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OrganizationsAccount{}
+	for rows.Next() {
+		var i OrganizationsAccount
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrganizationID,
+			&i.Email,
+			&i.FullName,
+			&i.StytchMemberID,
+			&i.StytchRoleID,
+			&i.StytchRoleSlug,
+			&i.StytchEmailVerified,
+			&i.Role,
+			&i.Status,
+			&i.LastLoginAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Metadata,
+			&i.AvatarFileID,
+			&i.AvatarKey,
+			&i.AvatarUrl,
+			&i.PublicID,
+			&i.Kind,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMembershipsByEmail = `-- name: ListMembershipsByEmail :many
+SELECT
+    o.id AS organization_id,
+    o.slug,
+    o.name,
+    o.environment,
+    o.stytch_org_id,
+    a.id AS account_id,
+    a.role
+FROM organizations.organizations o
+INNER JOIN organizations.accounts a ON o.id = a.organization_id
+WHERE a.email = $1
+  AND a.status IN ('active', 'dormant')
+  AND o.status IN ('active', 'dormant')
+ORDER BY (o.environment = 'production') DESC, o.name, o.id
+`
+
+type ListMembershipsByEmailRow struct {
+	OrganizationID int32       `json:"organization_id"`
+	Slug           string      `json:"slug"`
+	Name           string      `json:"name"`
+	Environment    string      `json:"environment"`
+	StytchOrgID    pgtype.Text `json:"stytch_org_id"`
+	AccountID      int32       `json:"account_id"`
+	Role           string      `json:"role"`
+}
+
+// Active organizations the user has an active account in, for switching between them
+func (q *Queries) ListMembershipsByEmail(ctx context.Context, email string) ([]ListMembershipsByEmailRow, error) {
+	rows, err := q.db.Query(ctx, listMembershipsByEmail, email)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListMembershipsByEmailRow{}
+	for rows.Next() {
+		var i ListMembershipsByEmailRow
+		if err := rows.Scan(
+			&i.OrganizationID,
+			&i.Slug,
+			&i.Name,
+			&i.Environment,
+			&i.StytchOrgID,
+			&i.AccountID,
+			&i.Role,
 		); err != nil {
 			return nil, err
 		}
@@ -638,7 +1091,10 @@ SELECT
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 FROM organizations.organizations
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
@@ -668,6 +1124,62 @@ func (q *Queries) ListOrganizations(ctx context.Context, arg ListOrganizationsPa
 			&i.StytchConnectionName,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Environment,
+			&i.ParentOrganizationID,
+			&i.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStagingOrganizations = `-- name: ListStagingOrganizations :many
+SELECT
+    id,
+    slug,
+    name,
+    status,
+    stytch_org_id,
+    stytch_connection_id,
+    stytch_connection_name,
+    created_at,
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
+FROM organizations.organizations
+WHERE parent_organization_id = $1::int
+ORDER BY created_at DESC
+`
+
+// Staging and sandbox organizations owned by a production organization
+func (q *Queries) ListStagingOrganizations(ctx context.Context, parentOrganizationID int32) ([]OrganizationsOrganization, error) {
+	rows, err := q.db.Query(ctx, listStagingOrganizations, parentOrganizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []OrganizationsOrganization{}
+	for rows.Next() {
+		var i OrganizationsOrganization
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.Name,
+			&i.Status,
+			&i.StytchOrgID,
+			&i.StytchConnectionID,
+			&i.StytchConnectionName,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Environment,
+			&i.ParentOrganizationID,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -679,6 +1191,67 @@ func (q *Queries) ListOrganizations(ctx context.Context, arg ListOrganizationsPa
 	return items, nil
 }
 
+const patchAccountMetadata = `-- name: PatchAccountMetadata :one
+UPDATE organizations.accounts
+SET
+    metadata = (metadata || $1::jsonb) - ARRAY(SELECT key FROM jsonb_each($1::jsonb) WHERE value = 'null'::jsonb),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $2::int AND organization_id = $3::int
+RETURNING metadata
+`
+
+type PatchAccountMetadataParams struct {
+	Patch          []byte `json:"patch"`
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+}
+
+// Merges the patch's top-level keys into the account metadata; keys set to null in the patch are removed
+func (q *Queries) PatchAccountMetadata(ctx context.Context, arg PatchAccountMetadataParams) ([]byte, error) {
+	row := q.db.QueryRow(ctx, patchAccountMetadata, arg.Patch, arg.ID, arg.OrganizationID)
+	var metadata []byte
+	err := row.Scan(&metadata)
+	return metadata, err
+}
+
+const setAccountAvatar = `-- name: SetAccountAvatar :one
+UPDATE organizations.accounts a
+SET
+    avatar_file_id = $1::int,
+    avatar_key = $2::text,
+    avatar_url = $3::text,
+    updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT id, avatar_file_id FROM organizations.accounts
+    WHERE id = $4::int AND organization_id = $5::int
+    FOR UPDATE
+) previous
+WHERE a.id = previous.id
+RETURNING previous.avatar_file_id AS previous_avatar_file_id
+`
+
+type SetAccountAvatarParams struct {
+	AvatarFileID   pgtype.Int4 `json:"avatar_file_id"`
+	AvatarKey      pgtype.Text `json:"avatar_key"`
+	AvatarUrl      pgtype.Text `json:"avatar_url"`
+	ID             int32       `json:"id"`
+	OrganizationID int32       `json:"organization_id"`
+}
+
+// Replaces the account avatar and returns the file of the previous one, which the caller deletes
+func (q *Queries) SetAccountAvatar(ctx context.Context, arg SetAccountAvatarParams) (pgtype.Int4, error) {
+	row := q.db.QueryRow(ctx, setAccountAvatar,
+		arg.AvatarFileID,
+		arg.AvatarKey,
+		arg.AvatarUrl,
+		arg.ID,
+		arg.OrganizationID,
+	)
+	var previous_avatar_file_id pgtype.Int4
+	err := row.Scan(&previous_avatar_file_id)
+	return previous_avatar_file_id, err
+}
+
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE organizations.accounts
 SET
@@ -703,7 +1276,13 @@ RETURNING
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 `
 
 type UpdateAccountParams struct {
@@ -743,6 +1322,73 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (O
 		&i.LastLoginAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Metadata,
+		&i.AvatarFileID,
+		&i.AvatarKey,
+		&i.AvatarUrl,
+		&i.PublicID,
+		&i.Kind,
+	)
+	return i, err
+}
+
+const updateAccountEmail = `-- name: UpdateAccountEmail :one
+UPDATE organizations.accounts
+SET
+    email = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND organization_id = $2
+RETURNING
+    id,
+    organization_id,
+    email,
+    full_name,
+    stytch_member_id,
+    stytch_role_id,
+    stytch_role_slug,
+    stytch_email_verified,
+    role,
+    status,
+    last_login_at,
+    created_at,
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
+`
+
+type UpdateAccountEmailParams struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	Email          string `json:"email"`
+}
+
+func (q *Queries) UpdateAccountEmail(ctx context.Context, arg UpdateAccountEmailParams) (OrganizationsAccount, error) {
+	row := q.db.QueryRow(ctx, updateAccountEmail, arg.ID, arg.OrganizationID, arg.Email)
+	var i OrganizationsAccount
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.Email,
+		&i.FullName,
+		&i.StytchMemberID,
+		&i.StytchRoleID,
+		&i.StytchRoleSlug,
+		&i.StytchEmailVerified,
+		&i.Role,
+		&i.Status,
+		&i.LastLoginAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Metadata,
+		&i.AvatarFileID,
+		&i.AvatarKey,
+		&i.AvatarUrl,
+		&i.PublicID,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -766,7 +1412,13 @@ RETURNING
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 `
 
 type UpdateAccountLastLoginParams struct {
@@ -791,6 +1443,12 @@ func (q *Queries) UpdateAccountLastLogin(ctx context.Context, arg UpdateAccountL
 		&i.LastLoginAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Metadata,
+		&i.AvatarFileID,
+		&i.AvatarKey,
+		&i.AvatarUrl,
+		&i.PublicID,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -817,7 +1475,13 @@ RETURNING
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 `
 
 type UpdateAccountStytchInfoParams struct {
@@ -853,6 +1517,12 @@ func (q *Queries) UpdateAccountStytchInfo(ctx context.Context, arg UpdateAccount
 		&i.LastLoginAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Metadata,
+		&i.AvatarFileID,
+		&i.AvatarKey,
+		&i.AvatarUrl,
+		&i.PublicID,
+		&i.Kind,
 	)
 	return i, err
 }
@@ -876,7 +1546,10 @@ RETURNING
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 `
 
 type UpdateOrganizationParams struct {
@@ -908,6 +1581,9 @@ func (q *Queries) UpdateOrganization(ctx context.Context, arg UpdateOrganization
 		&i.StytchConnectionName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Environment,
+		&i.ParentOrganizationID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
@@ -929,7 +1605,10 @@ RETURNING
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 `
 
 type UpdateOrganizationStytchInfoParams struct {
@@ -957,6 +1636,9 @@ func (q *Queries) UpdateOrganizationStytchInfo(ctx context.Context, arg UpdateOr
 		&i.StytchConnectionName,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Environment,
+		&i.ParentOrganizationID,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
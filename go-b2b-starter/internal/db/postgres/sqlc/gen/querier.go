@@ -11,61 +11,210 @@ import (
 )
 
 type Querier interface {
+	// Claims a pending, unexpired invite; concurrent accepts of the same invite get no row
+	AcceptInvite(ctx context.Context, arg AcceptInviteParams) (OrganizationsInvite, error)
+	// Attaches a tag to an account, creating the tag if the organization does not have it yet
+	AddAccountTag(ctx context.Context, arg AddAccountTagParams) error
+	AddSupportTicketAttachment(ctx context.Context, arg AddSupportTicketAttachmentParams) error
+	AdvanceExportWatermark(ctx context.Context, arg AdvanceExportWatermarkParams) error
 	// Assign resource to someone for approval
 	AssignResourceApproval(ctx context.Context, arg AssignResourceApprovalParams) error
 	// Attach a file to a resource
 	AttachFileToResource(ctx context.Context, arg AttachFileToResourceParams) error
+	CancelEmailChangeRequest(ctx context.Context, id int32) (OrganizationsEmailChangeRequest, error)
+	CancelMFARecoveryRequest(ctx context.Context, arg CancelMFARecoveryRequestParams) (OrganizationsMfaRecoveryRequest, error)
+	CancelPendingEmailChangeRequests(ctx context.Context, arg CancelPendingEmailChangeRequestsParams) (int64, error)
+	// Moves the account from one status to another and records the change; returns no row if the account is not in from_status
+	ChangeAccountStatus(ctx context.Context, arg ChangeAccountStatusParams) (OrganizationsAccountStatusChange, error)
 	CheckAccountPermission(ctx context.Context, arg CheckAccountPermissionParams) (CheckAccountPermissionRow, error)
+	// Creates the watermark on first use and takes the dataset lease unless another instance holds it
+	ClaimExportWatermark(ctx context.Context, arg ClaimExportWatermarkParams) (AnalyticsExportWatermark, error)
+	CompleteDataExport(ctx context.Context, arg CompleteDataExportParams) (ComplianceDataExport, error)
+	CompleteMFARecoveryRequest(ctx context.Context, arg CompleteMFARecoveryRequestParams) (OrganizationsMfaRecoveryRequest, error)
+	CompleteOrganizationImport(ctx context.Context, arg CompleteOrganizationImportParams) (PortabilityOrganizationImport, error)
+	// Marks a pending or skipped step completed. Returns no row when it already was.
+	CompleteOnboardingStep(ctx context.Context, arg CompleteOnboardingStepParams) (OnboardingOrganizationStep, error)
+	ConfirmEmailChangeRequest(ctx context.Context, arg ConfirmEmailChangeRequestParams) (OrganizationsEmailChangeRequest, error)
+	// Marks an unused, unexpired code used; no row means it was spent, expired or never issued
+	ConsumeOIDCAuthorizationCode(ctx context.Context, codeHash string) (OrganizationsOidcAuthorizationCode, error)
+	CountAccountsFiltered(ctx context.Context, arg CountAccountsFilteredParams) (int64, error)
+	CountActiveServiceAccountKeys(ctx context.Context, serviceAccountID int32) (int64, error)
+	CountAuthAuditEvents(ctx context.Context, arg CountAuthAuditEventsParams) (int64, error)
+	// Members that occupy a seat: active and dormant human accounts, so service
+	// accounts, OAuth clients and guests are not billed
+	CountBillableSeats(ctx context.Context, organizationID int32) (int32, error)
 	CountChatMessagesBySession(ctx context.Context, sessionID int32) (int64, error)
 	CountDocumentEmbeddingsByOrganization(ctx context.Context, organizationID int32) (int64, error)
 	CountDocumentsByOrganization(ctx context.Context, organizationID int32) (int64, error)
 	CountDocumentsByStatus(ctx context.Context, arg CountDocumentsByStatusParams) (int64, error)
+	CountFileAssetsByIDs(ctx context.Context, ids []int32) (int64, error)
+	CountJobRuns(ctx context.Context, arg CountJobRunsParams) (int64, error)
+	// Active and dormant accounts holding the admin role, from the auth provider
+	// (legacy owner included) or an assignment
+	CountOrganizationAdmins(ctx context.Context, organizationID int32) (int64, error)
+	CountOrganizationImports(ctx context.Context, organizationID pgtype.Int4) (int64, error)
+	// Rows each tenant table holds for one organization, used before and after a purge
+	CountOrganizationRows(ctx context.Context, organizationID int32) ([]CountOrganizationRowsRow, error)
+	// Stored member emails with a "+tag" in the local part, which lookups no
+	// longer match once ACCOUNT_EMAIL_CANONICALIZE_PLUS strips the tag
+	CountPlusAddressedEmails(ctx context.Context) (int64, error)
+	CountPurgeReports(ctx context.Context, organizationID pgtype.Int4) (int64, error)
 	// Count resources for pagination
 	CountResources(ctx context.Context, arg CountResourcesParams) (int64, error)
+	CountStagingOrganizations(ctx context.Context, parentOrganizationID int32) (int64, error)
+	CreateAccessElevation(ctx context.Context, arg CreateAccessElevationParams) (OrganizationsAccessElevation, error)
 	// Accounts queries
+	// Stores an activity of the account; an event already recorded for the account is ignored
+	CreateAccountActivityEvent(ctx context.Context, arg CreateAccountActivityEventParams) (int64, error)
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (OrganizationsAccount, error)
+	CreateAuthAuditEvent(ctx context.Context, arg CreateAuthAuditEventParams) (OrganizationsAuthAuditLog, error)
+	CreateCanaryCredential(ctx context.Context, arg CreateCanaryCredentialParams) (OrganizationsCanaryCredential, error)
 	// Chat Messages
 	CreateChatMessage(ctx context.Context, arg CreateChatMessageParams) (CognitiveChatMessage, error)
 	// Chat Sessions
 	CreateChatSession(ctx context.Context, arg CreateChatSessionParams) (CognitiveChatSession, error)
+	CreateDataExport(ctx context.Context, arg CreateDataExportParams) (ComplianceDataExport, error)
+	CreateDebugCapture(ctx context.Context, arg CreateDebugCaptureParams) (OrganizationsDebugCapture, error)
+	CreateDebugCaptureRecord(ctx context.Context, arg CreateDebugCaptureRecordParams) error
 	// Documents queries
 	CreateDocument(ctx context.Context, arg CreateDocumentParams) (DocumentsDocument, error)
 	// Cognitive Agent queries
 	// Document Embeddings
 	CreateDocumentEmbedding(ctx context.Context, arg CreateDocumentEmbeddingParams) (CognitiveDocumentEmbedding, error)
+	// Inserts a batch of chunk embeddings of one document in a single statement.
+	// Vectors are passed in pgvector's text form; an empty location is stored as NULL.
+	CreateDocumentEmbeddings(ctx context.Context, arg CreateDocumentEmbeddingsParams) ([]CognitiveDocumentEmbedding, error)
+	// Document version queries
+	// Versions are numbered per document; a concurrent upload fails on uq_document_versions_number
+	CreateDocumentVersion(ctx context.Context, arg CreateDocumentVersionParams) (DocumentsDocumentVersion, error)
+	CreateEmailChangeRequest(ctx context.Context, arg CreateEmailChangeRequestParams) (OrganizationsEmailChangeRequest, error)
 	CreateFileAsset(ctx context.Context, arg CreateFileAssetParams) (FileManagerFileAsset, error)
+	// Assigns a role to a user in every organization they have an account in
+	CreateGlobalRoleAssignment(ctx context.Context, arg CreateGlobalRoleAssignmentParams) (RbacRoleAssignment, error)
+	CreateIPAllowlistEntry(ctx context.Context, arg CreateIPAllowlistEntryParams) (OrganizationsIpAllowlistEntry, error)
+	// Links a provider identity to an account
+	CreateIdentity(ctx context.Context, arg CreateIdentityParams) (OrganizationsIdentity, error)
+	CreateJobRun(ctx context.Context, arg CreateJobRunParams) (JobsJobRun, error)
+	CreateInvite(ctx context.Context, arg CreateInviteParams) (OrganizationsInvite, error)
+	CreateMFARecoveryRequest(ctx context.Context, arg CreateMFARecoveryRequestParams) (OrganizationsMfaRecoveryRequest, error)
 	// Creates a minimal placeholder resource
 	CreateMinimalResource(ctx context.Context, arg CreateMinimalResourceParams) (ExampleResource, error)
+	// Adds an in-app notification to an account's inbox
+	CreateNotification(ctx context.Context, arg CreateNotificationParams) (OrganizationsNotification, error)
+	CreateOAuthClient(ctx context.Context, arg CreateOAuthClientParams) (OrganizationsOauthClient, error)
+	CreateOIDCAuthorizationCode(ctx context.Context, arg CreateOIDCAuthorizationCodeParams) (OrganizationsOidcAuthorizationCode, error)
+	CreateOIDCClient(ctx context.Context, arg CreateOIDCClientParams) (OrganizationsOidcClient, error)
 	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (OrganizationsOrganization, error)
+	CreateOrganizationImport(ctx context.Context, arg CreateOrganizationImportParams) (PortabilityOrganizationImport, error)
+	// Assigns a role to an account within its organization
+	CreateOrganizationRoleAssignment(ctx context.Context, arg CreateOrganizationRoleAssignmentParams) (RbacRoleAssignment, error)
+	// Prompt Settings
+	// Versions are numbered per organization; a concurrent change fails on uq_prompt_settings_version
+	CreatePromptSettingsVersion(ctx context.Context, arg CreatePromptSettingsVersionParams) (CognitivePromptSetting, error)
+	CreatePurgeReport(ctx context.Context, arg CreatePurgeReportParams) (CompliancePurgeReport, error)
 	// Example Resource Queries
 	// Demonstrates Clean Architecture patterns with CRUD operations,
 	// file attachments, OCR/LLM processing, and approval workflows
 	// CREATE operations
 	CreateResource(ctx context.Context, arg CreateResourceParams) (ExampleResource, error)
+	CreateRole(ctx context.Context, arg CreateRoleParams) (RbacRole, error)
+	// Adds an unverified secondary address to an account
+	CreateSecondaryEmail(ctx context.Context, arg CreateSecondaryEmailParams) (OrganizationsSecondaryEmail, error)
+	CreateServiceAccount(ctx context.Context, arg CreateServiceAccountParams) (OrganizationsServiceAccount, error)
+	CreateServiceAccountKey(ctx context.Context, arg CreateServiceAccountKeyParams) (OrganizationsServiceAccountKey, error)
+	CreateSupportTicket(ctx context.Context, arg CreateSupportTicketParams) (SupportTicket, error)
+	DecideAccessElevation(ctx context.Context, arg DecideAccessElevationParams) (OrganizationsAccessElevation, error)
+	DecideMFARecoveryRequest(ctx context.Context, arg DecideMFARecoveryRequestParams) (OrganizationsMfaRecoveryRequest, error)
 	// Decrement invoice count by 1 (called after successful invoice processing)
 	DecrementInvoiceCount(ctx context.Context, organizationID int32) (SubscriptionBillingQuotaTracking, error)
+	// Deletes usage windows that ended before the retention cutoff
+	DeleteAPIUsageBefore(ctx context.Context, cutoff pgtype.Timestamp) (int64, error)
 	DeleteAccount(ctx context.Context, arg DeleteAccountParams) error
+	DeleteAccountActivityEventsBefore(ctx context.Context, occurredAt pgtype.Timestamp) (int64, error)
+	DeleteAuthAuditEventsBefore(ctx context.Context, occurredAt pgtype.Timestamp) (int64, error)
+	DeleteCanaryCredential(ctx context.Context, arg DeleteCanaryCredentialParams) (int64, error)
 	DeleteChatMessage(ctx context.Context, id int32) error
 	DeleteChatSession(ctx context.Context, arg DeleteChatSessionParams) error
+	DeleteChatSessionsByAccount(ctx context.Context, arg DeleteChatSessionsByAccountParams) (int64, error)
 	DeleteDocument(ctx context.Context, arg DeleteDocumentParams) error
 	DeleteDocumentEmbeddings(ctx context.Context, arg DeleteDocumentEmbeddingsParams) error
+	// Captures that stopped recording before the cutoff, along with any records left
+	DeleteEndedDebugCaptures(ctx context.Context, endedBefore pgtype.Timestamp) (int64, error)
+	DeleteExpiredDebugCaptureRecords(ctx context.Context, expiredBefore pgtype.Timestamp) (int64, error)
+	// Codes only live for minutes; spent and expired ones are dropped as new ones are issued
+	DeleteExpiredOIDCAuthorizationCodes(ctx context.Context) (int64, error)
+	// Removes an address whose verification link expired, freeing it for
+	// another account
+	DeleteExpiredSecondaryEmail(ctx context.Context, id int32) (int64, error)
 	DeleteFileAsset(ctx context.Context, id int32) error
+	// Removes a global assignment
+	DeleteGlobalRoleAssignment(ctx context.Context, id int32) (RbacRoleAssignment, error)
+	DeleteIPAllowlistEntry(ctx context.Context, arg DeleteIPAllowlistEntryParams) (int64, error)
+	// Unlinks an account's identity at a provider
+	DeleteIdentity(ctx context.Context, arg DeleteIdentityParams) (int64, error)
+	// Deletes invites that can no longer be accepted: pending ones that expired and revoked ones, before the cutoff
+	DeleteInvitesBefore(ctx context.Context, expiresAt pgtype.Timestamp) (int64, error)
+	DeleteJobRunsBefore(ctx context.Context, startedAt pgtype.Timestamp) (int64, error)
 	DeleteOrganization(ctx context.Context, id int32) error
+	DeleteOrganizationAuthPolicy(ctx context.Context, organizationID int32) (int64, error)
+	// Removes a role from an account within its organization
+	DeleteOrganizationRoleAssignment(ctx context.Context, arg DeleteOrganizationRoleAssignmentParams) (RbacRoleAssignment, error)
 	// DELETE operations
 	// Soft delete a resource
 	DeleteResource(ctx context.Context, arg DeleteResourceParams) error
+	DeleteRole(ctx context.Context, id string) (int64, error)
+	// Removes one of an account's secondary addresses
+	DeleteSecondaryEmail(ctx context.Context, arg DeleteSecondaryEmailParams) (int64, error)
 	// Delete subscription (when subscription is permanently deleted)
 	DeleteSubscription(ctx context.Context, organizationID int32) error
+	// Deletes an organization's tag, removing it from every account
+	DeleteTag(ctx context.Context, arg DeleteTagParams) (int64, error)
+	// Deletes usage events past retention; their buckets are kept
+	DeleteUsageEventsBefore(ctx context.Context, cutoff pgtype.Timestamp) (int64, error)
+	ExpireDataExport(ctx context.Context, id int32) error
+	// Ends a local trial; returns no row when the organization subscribed meanwhile
+	ExpireTrial(ctx context.Context, arg ExpireTrialParams) (SubscriptionBillingSubscription, error)
+	FailDataExport(ctx context.Context, arg FailDataExportParams) error
+	// Marks an account's running exports that outlived the export timeout, for example because of a restart, as failed
+	FailStaleDataExports(ctx context.Context, arg FailStaleDataExportsParams) (int64, error)
+	// Marks an organization's running imports that stopped reporting progress as failed
+	FailStaleOrganizationImports(ctx context.Context, arg FailStaleOrganizationImportsParams) (int64, error)
+	FinishJobRun(ctx context.Context, arg FinishJobRunParams) (JobsJobRun, error)
+	GetAccessElevationByID(ctx context.Context, arg GetAccessElevationByIDParams) (OrganizationsAccessElevation, error)
 	GetAccountByEmail(ctx context.Context, arg GetAccountByEmailParams) (OrganizationsAccount, error)
 	GetAccountByID(ctx context.Context, arg GetAccountByIDParams) (OrganizationsAccount, error)
+	GetAccountByPublicID(ctx context.Context, arg GetAccountByPublicIDParams) (OrganizationsAccount, error)
+	GetAccountLastActive(ctx context.Context, arg GetAccountLastActiveParams) (pgtype.Timestamp, error)
+	GetAccountMetadata(ctx context.Context, arg GetAccountMetadataParams) ([]byte, error)
 	GetAccountOrganization(ctx context.Context, id int32) (OrganizationsOrganization, error)
 	GetAccountStats(ctx context.Context, id int32) (GetAccountStatsRow, error)
+	GetActiveAccessElevation(ctx context.Context, arg GetActiveAccessElevationParams) (OrganizationsAccessElevation, error)
+	// The organization's capture that is still recording, if any
+	GetActiveDebugCapture(ctx context.Context, organizationID int32) (OrganizationsDebugCapture, error)
+	GetAvatarFileIDByKey(ctx context.Context, avatarKey string) (pgtype.Int4, error)
+	// Get the identifiers an account is billed under
+	GetBillingCustomerByAccountID(ctx context.Context, id int32) (GetBillingCustomerByAccountIDRow, error)
+	// Resolve the account of a user-scoped billing customer
+	GetBillingCustomerByPublicID(ctx context.Context, publicID pgtype.UUID) (GetBillingCustomerByPublicIDRow, error)
+	// Get the display currency and locale of an organization
+	GetBillingSettings(ctx context.Context, organizationID int32) (SubscriptionBillingBillingSetting, error)
+	GetCanaryCredentialByIdentifier(ctx context.Context, identifier string) (OrganizationsCanaryCredential, error)
+	GetChatMessagesAfter(ctx context.Context, arg GetChatMessagesAfterParams) ([]CognitiveChatMessage, error)
 	GetChatMessagesBySession(ctx context.Context, sessionID int32) ([]CognitiveChatMessage, error)
 	GetChatSessionByID(ctx context.Context, arg GetChatSessionByIDParams) (CognitiveChatSession, error)
+	GetDataExport(ctx context.Context, arg GetDataExportParams) (ComplianceDataExport, error)
+	GetDataExportByTokenHash(ctx context.Context, downloadTokenHash pgtype.Text) (ComplianceDataExport, error)
+	GetDebugCapture(ctx context.Context, id int32) (OrganizationsDebugCapture, error)
 	GetDocumentByFileAssetID(ctx context.Context, arg GetDocumentByFileAssetIDParams) (DocumentsDocument, error)
 	GetDocumentByID(ctx context.Context, arg GetDocumentByIDParams) (DocumentsDocument, error)
+	// Document classification queries
+	GetDocumentClassification(ctx context.Context, arg GetDocumentClassificationParams) (DocumentsDocumentClassification, error)
+	GetDocumentCounters(ctx context.Context, organizationID int32) (DocumentsDocumentCounter, error)
+	GetDocumentVersion(ctx context.Context, arg GetDocumentVersionParams) (DocumentsDocumentVersion, error)
 	GetDocumentEmbeddingByID(ctx context.Context, arg GetDocumentEmbeddingByIDParams) (CognitiveDocumentEmbedding, error)
 	GetDocumentEmbeddingsByDocumentID(ctx context.Context, arg GetDocumentEmbeddingsByDocumentIDParams) ([]CognitiveDocumentEmbedding, error)
+	GetEmailChangeRequestByConfirmTokenHash(ctx context.Context, confirmTokenHash string) (OrganizationsEmailChangeRequest, error)
+	GetEmailChangeRequestByRevertTokenHash(ctx context.Context, revertTokenHash string) (OrganizationsEmailChangeRequest, error)
 	GetFileAssetByID(ctx context.Context, id int32) (FileManagerFileAsset, error)
 	GetFileAssetByStoragePath(ctx context.Context, storagePath string) (FileManagerFileAsset, error)
 	GetFileAssetsByCategory(ctx context.Context, name string) ([]GetFileAssetsByCategoryRow, error)
@@ -74,13 +223,27 @@ type Querier interface {
 	GetFileAssetsByEntityAndPurpose(ctx context.Context, arg GetFileAssetsByEntityAndPurposeParams) ([]FileManagerFileAsset, error)
 	GetFileCategories(ctx context.Context) ([]FileManagerFileCategory, error)
 	GetFileContexts(ctx context.Context) ([]FileManagerFileContext, error)
+	GetJobRun(ctx context.Context, id int64) (JobsJobRun, error)
+	GetInviteByTokenHash(ctx context.Context, tokenHash string) (OrganizationsInvite, error)
+	// The account's newest export that did not fail, used to limit how often exports can be requested
+	GetLatestDataExport(ctx context.Context, arg GetLatestDataExportParams) (ComplianceDataExport, error)
+	GetLatestPromptSettings(ctx context.Context, organizationID int32) (CognitivePromptSetting, error)
+	GetMFARecoveryRequestByID(ctx context.Context, id int32) (OrganizationsMfaRecoveryRequest, error)
+	GetOAuthClientByClientID(ctx context.Context, clientID string) (OrganizationsOauthClient, error)
+	GetOAuthClientByID(ctx context.Context, arg GetOAuthClientByIDParams) (OrganizationsOauthClient, error)
+	GetOIDCClientByClientID(ctx context.Context, clientID string) (OrganizationsOidcClient, error)
+	GetOIDCConsent(ctx context.Context, arg GetOIDCConsentParams) (OrganizationsOidcConsent, error)
+	GetOrganizationAuthPolicy(ctx context.Context, organizationID int32) (OrganizationsAuthPolicy, error)
 	GetOrganizationByID(ctx context.Context, id int32) (OrganizationsOrganization, error)
 	GetOrganizationBySlug(ctx context.Context, slug string) (OrganizationsOrganization, error)
 	GetOrganizationByStytchID(ctx context.Context, stytchOrgID pgtype.Text) (OrganizationsOrganization, error)
 	// Organization membership queries
 	GetOrganizationByUserEmail(ctx context.Context, email string) (OrganizationsOrganization, error)
+	GetOrganizationImport(ctx context.Context, id int32) (PortabilityOrganizationImport, error)
 	// Statistics queries (useful for admin panels)
 	GetOrganizationStats(ctx context.Context, id int32) (GetOrganizationStatsRow, error)
+	GetPromptSettingsVersion(ctx context.Context, arg GetPromptSettingsVersionParams) (CognitivePromptSetting, error)
+	GetPurgeReport(ctx context.Context, id int32) (CompliancePurgeReport, error)
 	// Get quota tracking for an organization
 	GetQuotaByOrgID(ctx context.Context, organizationID int32) (SubscriptionBillingQuotaTracking, error)
 	// Get combined subscription and quota status for fast quota checks
@@ -96,39 +259,267 @@ type Querier interface {
 	GetResourceStats(ctx context.Context, organizationID int32) (GetResourceStatsRow, error)
 	// Get resources created by a specific user
 	GetResourcesByCreator(ctx context.Context, arg GetResourcesByCreatorParams) ([]ExampleResource, error)
+	GetRole(ctx context.Context, id string) (GetRoleRow, error)
+	GetSeatAllocation(ctx context.Context, organizationID int32) (SubscriptionBillingSeatAllocation, error)
+	// One of an account's secondary addresses
+	GetSecondaryEmail(ctx context.Context, arg GetSecondaryEmailParams) (OrganizationsSecondaryEmail, error)
+	// The secondary address of any account of the organization, verified or not
+	GetSecondaryEmailByAddress(ctx context.Context, arg GetSecondaryEmailByAddressParams) (OrganizationsSecondaryEmail, error)
+	// The unverified address a verification link was sent to
+	GetSecondaryEmailByVerificationToken(ctx context.Context, verificationTokenHash string) (OrganizationsSecondaryEmail, error)
+	GetServiceAccountByID(ctx context.Context, arg GetServiceAccountByIDParams) (OrganizationsServiceAccount, error)
+	// Unrevoked key with what the auth middleware needs to build the identity of
+	// its service account
+	GetServiceAccountKeyCredential(ctx context.Context, keyHash string) (GetServiceAccountKeyCredentialRow, error)
 	// Get subscription details for an organization
 	GetSubscriptionByOrgID(ctx context.Context, organizationID int32) (SubscriptionBillingSubscription, error)
 	// Get subscription by Polar subscription ID
 	GetSubscriptionBySubscriptionID(ctx context.Context, subscriptionID string) (SubscriptionBillingSubscription, error)
+	GetSupportTicketByReference(ctx context.Context, reference string) (SupportTicket, error)
+	// Account counts of production organizations, or of one organization when
+	// given. Deleted (inactive) accounts are left out. Users are active within a
+	// window when they signed in or made an authenticated request in it.
+	GetUserStats(ctx context.Context, organizationID pgtype.Int4) (GetUserStatsRow, error)
+	// Get the subscription an account pays for itself
+	GetUserSubscriptionByAccountID(ctx context.Context, accountID int32) (SubscriptionBillingUserSubscription, error)
 	// Hard delete a resource (use with caution)
 	HardDeleteResource(ctx context.Context, arg HardDeleteResourceParams) error
+	IncrementDataExportDownloads(ctx context.Context, id int32) error
+	// Records a usage event. An event whose idempotency key was already recorded
+	// for the organization is ignored and affects no rows.
+	InsertUsageEvent(ctx context.Context, arg InsertUsageEventParams) (int64, error)
+	// Roles assigned to an account, within its organization and globally through its email
+	ListAccountRoleAssignments(ctx context.Context, arg ListAccountRoleAssignmentsParams) ([]RbacRoleAssignment, error)
+	// Secondary addresses of an account, oldest first
+	ListAccountSecondaryEmails(ctx context.Context, arg ListAccountSecondaryEmailsParams) ([]OrganizationsSecondaryEmail, error)
+	// Usage windows of an account, newest first
+	ListAPIUsage(ctx context.Context, arg ListAPIUsageParams) ([]OrganizationsApiUsage, error)
+	ListAccessElevationsByOrganization(ctx context.Context, arg ListAccessElevationsByOrganizationParams) ([]OrganizationsAccessElevation, error)
+	ListAccountActivityEvents(ctx context.Context, arg ListAccountActivityEventsParams) ([]OrganizationsAccountActivityFeed, error)
+	ListAccountActivityTimestamps(ctx context.Context, arg ListAccountActivityTimestampsParams) ([]ListAccountActivityTimestampsRow, error)
+	// Messages of the account's chat sessions, for its data export
+	ListAccountChatMessages(ctx context.Context, arg ListAccountChatMessagesParams) ([]ListAccountChatMessagesRow, error)
+	// Chat sessions of the account, for its data export
+	ListAccountChatSessions(ctx context.Context, arg ListAccountChatSessionsParams) ([]ListAccountChatSessionsRow, error)
+	// Identities linked to an account
+	ListAccountIdentities(ctx context.Context, arg ListAccountIdentitiesParams) ([]OrganizationsIdentity, error)
+	// Status changes of an account, newest first
+	ListAccountStatusChanges(ctx context.Context, arg ListAccountStatusChangesParams) ([]OrganizationsAccountStatusChange, error)
+	// Names of the tags attached to an account
+	ListAccountTags(ctx context.Context, arg ListAccountTagsParams) ([]string, error)
+	// Document versions the account uploaded with the title of their document, for its data export
+	ListAccountUploadedDocumentVersions(ctx context.Context, arg ListAccountUploadedDocumentVersionsParams) ([]ListAccountUploadedDocumentVersionsRow, error)
+	// Documents the account uploaded, for its data export
+	ListAccountUploadedDocuments(ctx context.Context, arg ListAccountUploadedDocumentsParams) ([]ListAccountUploadedDocumentsRow, error)
 	ListAccountsByOrganization(ctx context.Context, organizationID int32) ([]OrganizationsAccount, error)
+	// Accounts of an organization matching the optional filters; pattern and email_pattern have LIKE wildcards (%, _) escaped.
+	// sort is created_desc (default), created_asc, email_asc, email_desc, name_asc or last_login_desc; ties are newest first.
+	ListAccountsFiltered(ctx context.Context, arg ListAccountsFilteredParams) ([]OrganizationsAccount, error)
 	// List all active subscriptions for monitoring/admin purposes
 	ListActiveSubscriptions(ctx context.Context) ([]SubscriptionBillingSubscription, error)
+	ListAuthAuditEvents(ctx context.Context, arg ListAuthAuditEventsParams) ([]OrganizationsAuthAuditLog, error)
+	// Page of events newest first, starting after the (occurred_at, id) of the
+	// last event of the previous page; no cursor starts at the newest event
+	ListAuthAuditEventsKeyset(ctx context.Context, arg ListAuthAuditEventsKeysetParams) ([]OrganizationsAuthAuditLog, error)
+	ListCanaryCredentialsByOrganization(ctx context.Context, organizationID int32) ([]OrganizationsCanaryCredential, error)
+	// Chat usage facts without message content
+	ListChatMessageFacts(ctx context.Context, arg ListChatMessageFactsParams) ([]ListChatMessageFactsRow, error)
 	ListChatSessionsByAccount(ctx context.Context, arg ListChatSessionsByAccountParams) ([]CognitiveChatSession, error)
+	// Organizations in id order after a cursor, for reconciling counters in batches
+	ListCountedOrganizationIDs(ctx context.Context, arg ListCountedOrganizationIDsParams) ([]int32, error)
+	// Accounts created per day over the given number of days up to today, in
+	// production organizations or in one organization when given, with how many
+	// of them verified their email. Accounts deleted since still count. Days
+	// without signups are included.
+	ListDailySignups(ctx context.Context, arg ListDailySignupsParams) ([]ListDailySignupsRow, error)
+	ListDataExports(ctx context.Context, arg ListDataExportsParams) ([]ComplianceDataExport, error)
+	ListDebugCaptureRecords(ctx context.Context, arg ListDebugCaptureRecordsParams) ([]OrganizationsDebugCaptureRecord, error)
+	ListDebugCaptures(ctx context.Context, arg ListDebugCapturesParams) ([]OrganizationsDebugCapture, error)
+	// Document processing facts without titles, file names or text
+	ListDocumentFacts(ctx context.Context, arg ListDocumentFactsParams) ([]ListDocumentFactsRow, error)
+	// Extracted text is left out; fetch a single version to read it
+	ListDocumentVersions(ctx context.Context, arg ListDocumentVersionsParams) ([]ListDocumentVersionsRow, error)
 	ListDocumentsByOrganization(ctx context.Context, arg ListDocumentsByOrganizationParams) ([]DocumentsDocument, error)
 	ListDocumentsByStatus(ctx context.Context, arg ListDocumentsByStatusParams) ([]DocumentsDocument, error)
+	// Allocations whose sync is due, oldest first
+	ListDueSeatAllocations(ctx context.Context, arg ListDueSeatAllocationsParams) ([]SubscriptionBillingSeatAllocation, error)
+	// Completed exports whose download link expired, oldest first
+	ListExpiredDataExports(ctx context.Context, rowLimit int32) ([]ComplianceDataExport, error)
+	// Staging and sandbox organizations past their expiry, oldest first
+	ListExpiredStagingOrganizations(ctx context.Context, rowLimit int32) ([]int32, error)
+	// Local trials that ended before ended_before, oldest first
+	ListExpiredTrials(ctx context.Context, arg ListExpiredTrialsParams) ([]SubscriptionBillingSubscription, error)
 	ListFileAssets(ctx context.Context, arg ListFileAssetsParams) ([]ListFileAssetsRow, error)
+	// Global assignments, optionally of one email
+	ListGlobalRoleAssignments(ctx context.Context, email string) ([]RbacRoleAssignment, error)
+	ListIPAllowlistEntriesByOrganization(ctx context.Context, organizationID int32) ([]OrganizationsIpAllowlistEntry, error)
+	// Accounts a provider user is linked to, across organizations
+	ListIdentitiesByProviderUser(ctx context.Context, arg ListIdentitiesByProviderUserParams) ([]OrganizationsIdentity, error)
+	ListJobRunStats(ctx context.Context) ([]ListJobRunStatsRow, error)
+	ListJobRuns(ctx context.Context, arg ListJobRunsParams) ([]JobsJobRun, error)
+	// Most recent run of each job
+	ListLatestJobRuns(ctx context.Context) ([]JobsJobRun, error)
+	ListInvitesByOrganization(ctx context.Context, arg ListInvitesByOrganizationParams) ([]OrganizationsInvite, error)
+	// Active organizations the user has an active account in, for switching between them
+	ListMembershipsByEmail(ctx context.Context, email string) ([]ListMembershipsByEmailRow, error)
+	ListMFARecoveryRequestsByOrganization(ctx context.Context, arg ListMFARecoveryRequestsByOrganizationParams) ([]OrganizationsMfaRecoveryRequest, error)
+	// Stored notification choices of an account
+	ListNotificationPreferences(ctx context.Context, arg ListNotificationPreferencesParams) ([]OrganizationsNotificationPreference, error)
+	// In-app notifications of an account, newest first, optionally only unread ones
+	ListNotifications(ctx context.Context, arg ListNotificationsParams) ([]OrganizationsNotification, error)
+	// Verified secondary addresses of an account that receive email notifications
+	ListNotifiedSecondaryEmails(ctx context.Context, arg ListNotifiedSecondaryEmailsParams) ([]string, error)
+	ListOAuthClientsByOrganization(ctx context.Context, organizationID int32) ([]OrganizationsOauthClient, error)
+	ListOIDCClientsByOrganization(ctx context.Context, organizationID int32) ([]OrganizationsOidcClient, error)
+	ListOnboardingSteps(ctx context.Context, organizationID int32) ([]OnboardingOrganizationStep, error)
+	// Stored files referenced by an organization's documents (all versions), ticket attachments, resources, account avatars and data export archives
+	ListOrganizationFileAssets(ctx context.Context, organizationID int32) ([]ListOrganizationFileAssetsRow, error)
+	ListOrganizationImports(ctx context.Context, arg ListOrganizationImportsParams) ([]PortabilityOrganizationImport, error)
 	ListOrganizations(ctx context.Context, arg ListOrganizationsParams) ([]OrganizationsOrganization, error)
+	// Organizations the email has an active or dormant account in where no
+	// account holds the admin role
+	ListOrganizationsWithoutAdmins(ctx context.Context, email string) ([]int32, error)
+	ListPermissions(ctx context.Context) ([]RbacPermission, error)
+	ListPromptSettingsVersions(ctx context.Context, arg ListPromptSettingsVersionsParams) ([]CognitivePromptSetting, error)
+	ListPurgeReports(ctx context.Context, arg ListPurgeReportsParams) ([]CompliancePurgeReport, error)
 	// List organizations approaching their quota limit (for alerting)
 	ListQuotasNearLimit(ctx context.Context, invoiceCount int32) ([]ListQuotasNearLimitRow, error)
 	// List resources with filtering and pagination
 	ListResources(ctx context.Context, arg ListResourcesParams) ([]ListResourcesRow, error)
+	ListRoles(ctx context.Context) ([]ListRolesRow, error)
+	ListServiceAccountKeys(ctx context.Context, arg ListServiceAccountKeysParams) ([]OrganizationsServiceAccountKey, error)
+	ListServiceAccountsByOrganization(ctx context.Context, organizationID int32) ([]OrganizationsServiceAccount, error)
+	// Staging and sandbox organizations purged before their production parent
+	ListStagingOrganizationIDs(ctx context.Context, parentOrganizationID int32) ([]int32, error)
+	// Staging and sandbox organizations owned by a production organization
+	ListStagingOrganizations(ctx context.Context, parentOrganizationID int32) ([]OrganizationsOrganization, error)
+	// Billing facts without provider customer IDs or metadata
+	ListSubscriptionFacts(ctx context.Context, arg ListSubscriptionFactsParams) ([]ListSubscriptionFactsRow, error)
+	ListSupportTicketAttachments(ctx context.Context, ticketID int32) ([]ListSupportTicketAttachmentsRow, error)
+	ListSupportTicketsByAccount(ctx context.Context, arg ListSupportTicketsByAccountParams) ([]SupportTicket, error)
+	// Tags of an organization with how many accounts carry each
+	ListTags(ctx context.Context, organizationID int32) ([]ListTagsRow, error)
+	// Emails of the organization's active admins, from the auth provider role
+	// (legacy owner included) or an assignment
+	ListTrialReminderRecipients(ctx context.Context, organizationID int32) ([]string, error)
+	// Trialing subscriptions ending in (ends_after, ends_before] whose admins
+	// were not reminded yet, soonest first
+	ListTrialsEndingBetween(ctx context.Context, arg ListTrialsEndingBetweenParams) ([]SubscriptionBillingSubscription, error)
+	// Closed buckets of the given metrics with quantity not yet reported to the
+	// billing provider, after a cursor so failed reports are not listed again
+	ListUnreportedUsageRollups(ctx context.Context, arg ListUnreportedUsageRollupsParams) ([]SubscriptionBillingUsageRollup, error)
+	// Buckets of an organization starting in [period_from, period_to), by metric and oldest first
+	ListUsageRollups(ctx context.Context, arg ListUsageRollupsParams) ([]SubscriptionBillingUsageRollup, error)
+	// Serializes assignment removals so concurrent ones cannot remove every admin
+	LockRoleAssignments(ctx context.Context) error
+	// Locks an organization's allocation until the transaction ends, so members
+	// added at the same time are counted against the seats one after the other
+	LockSeatAllocation(ctx context.Context, organizationID int32) (SubscriptionBillingSeatAllocation, error)
+	// Locks one of an account's secondary addresses for a primary address switch
+	LockSecondaryEmail(ctx context.Context, arg LockSecondaryEmailParams) (OrganizationsSecondaryEmail, error)
+	// Moves active accounts of production organizations without activity since the cutoff to dormant, least recently active first
+	MarkDormantAccounts(ctx context.Context, arg MarkDormantAccountsParams) ([]MarkDormantAccountsRow, error)
+	// Moves active production organizations none of whose accounts were active since the cutoff to dormant, least recently active first
+	MarkDormantOrganizations(ctx context.Context, arg MarkDormantOrganizationsParams) ([]MarkDormantOrganizationsRow, error)
+	// Marks an account's in-app notification read; already read notifications keep their time
+	MarkNotificationRead(ctx context.Context, arg MarkNotificationReadParams) (int64, error)
+	// Records a failed sync and schedules the retry
+	MarkSeatAllocationFailed(ctx context.Context, arg MarkSeatAllocationFailedParams) error
+	// Records a sync whose seats were counted at counted_at. A change requested
+	// after the count keeps the sync due so it is picked up again.
+	MarkSeatAllocationSynced(ctx context.Context, arg MarkSeatAllocationSyncedParams) (SubscriptionBillingSeatAllocation, error)
+	// Records that the admins were emailed about the trial ending
+	MarkTrialReminderSent(ctx context.Context, arg MarkTrialReminderSentParams) error
+	// Records the quantity of a bucket reported to the billing provider
+	MarkUsageRollupReported(ctx context.Context, arg MarkUsageRollupReportedParams) error
+	OverrideDocumentClassification(ctx context.Context, arg OverrideDocumentClassificationParams) (DocumentsDocumentClassification, error)
+	// Merges the patch's top-level keys into the account metadata; keys set to null in the patch are removed
+	PatchAccountMetadata(ctx context.Context, arg PatchAccountMetadataParams) ([]byte, error)
+	ReactivateDormantAccount(ctx context.Context, arg ReactivateDormantAccountParams) (int64, error)
+	ReactivateDormantOrganization(ctx context.Context, id int32) (int64, error)
+	ReassignChatSessions(ctx context.Context, arg ReassignChatSessionsParams) (int64, error)
+	// Recounts an organization's counters; true when they had drifted
+	ReconcileDocumentCounters(ctx context.Context, organizationID int32) (bool, error)
+	// Stores the time of the account's latest authenticated request
+	RecordAccountActivity(ctx context.Context, arg RecordAccountActivityParams) error
+	ReleaseExportWatermark(ctx context.Context, dataset string) error
+	// Returns a claimed invite to pending when registering the member failed
+	ReleaseInvite(ctx context.Context, id int32) error
+	// Detaches a tag from an account; the tag stays in the organization
+	RemoveAccountTag(ctx context.Context, arg RemoveAccountTagParams) (int64, error)
+	// Stores the previous primary address in the secondary address that became
+	// primary. The previous primary address counts as verified and does not
+	// receive notifications until turned on.
+	ReplaceSecondaryEmailAddress(ctx context.Context, arg ReplaceSecondaryEmailAddressParams) (OrganizationsSecondaryEmail, error)
+	// Schedules a sync at due_at unless one is already scheduled, so a burst of
+	// membership changes is synced once
+	RequestSeatSync(ctx context.Context, arg RequestSeatSyncParams) error
+	// Schedules a sync at due_at for every organization with an active or
+	// trialing subscription, keeping syncs already scheduled
+	RequestSeatSyncForSubscribed(ctx context.Context, arg RequestSeatSyncForSubscribedParams) (int64, error)
+	// Counts a record against the capture's limit; no row means the capture stopped or is full
+	ReserveDebugCaptureRecord(ctx context.Context, id int32) (int64, error)
 	// Reset quota counters for a new billing period
 	ResetQuotaForPeriod(ctx context.Context, arg ResetQuotaForPeriodParams) (SubscriptionBillingQuotaTracking, error)
+	RevertEmailChangeRequest(ctx context.Context, id int32) (OrganizationsEmailChangeRequest, error)
+	RevokeAccessElevation(ctx context.Context, arg RevokeAccessElevationParams) (OrganizationsAccessElevation, error)
+	RevokeInvite(ctx context.Context, arg RevokeInviteParams) (OrganizationsInvite, error)
+	RevokeOAuthClient(ctx context.Context, arg RevokeOAuthClientParams) (OrganizationsOauthClient, error)
+	RevokeOIDCClient(ctx context.Context, arg RevokeOIDCClientParams) (OrganizationsOidcClient, error)
+	RevokeServiceAccountKey(ctx context.Context, arg RevokeServiceAccountKeyParams) (OrganizationsServiceAccountKey, error)
+	// Recomputes the hourly buckets of the events since the cutoff, which must
+	// be the start of an hour. Gauge metrics keep the bucket's maximum, the
+	// others its sum. Buckets whose totals did not change are left untouched.
+	RollupUsageEvents(ctx context.Context, arg RollupUsageEventsParams) (int64, error)
+	SearchAccounts(ctx context.Context, arg SearchAccountsParams) ([]SearchAccountsRow, error)
+	SearchChatSessions(ctx context.Context, arg SearchChatSessionsParams) ([]SearchChatSessionsRow, error)
+	SearchDocuments(ctx context.Context, arg SearchDocumentsParams) ([]SearchDocumentsRow, error)
 	// SEARCH operations
 	// Full-text search on title and description
 	SearchResourcesByText(ctx context.Context, arg SearchResourcesByTextParams) ([]SearchResourcesByTextRow, error)
 	SearchSimilarDocuments(ctx context.Context, arg SearchSimilarDocumentsParams) ([]SearchSimilarDocumentsRow, error)
+	// Replaces the account avatar and returns the file of the previous one, which the caller deletes
+	SetAccountAvatar(ctx context.Context, arg SetAccountAvatarParams) (pgtype.Int4, error)
+	SetRolePermissions(ctx context.Context, arg SetRolePermissionsParams) error
+	SetSeatAutoExpand(ctx context.Context, arg SetSeatAutoExpandParams) (SubscriptionBillingSeatAllocation, error)
+	// Turns email notifications to a secondary address on or off
+	SetSecondaryEmailNotifications(ctx context.Context, arg SetSecondaryEmailNotificationsParams) (OrganizationsSecondaryEmail, error)
+	// Replaces the verification link of an unverified address
+	SetSecondaryEmailVerification(ctx context.Context, arg SetSecondaryEmailVerificationParams) (OrganizationsSecondaryEmail, error)
+	// Disables or enables the service account; disabling keeps the original time
+	SetServiceAccountDisabled(ctx context.Context, arg SetServiceAccountDisabledParams) (OrganizationsServiceAccount, error)
+	// Marks a pending step skipped. Returns no row when it was already completed or skipped.
+	SkipOnboardingStep(ctx context.Context, arg SkipOnboardingStepParams) (OnboardingOrganizationStep, error)
+	// Starts a local trial for an organization without a subscription; returns
+	// no row when it already has one
+	StartTrial(ctx context.Context, arg StartTrialParams) (SubscriptionBillingSubscription, error)
+	StopDebugCapture(ctx context.Context, id int32) (OrganizationsDebugCapture, error)
+	// Counts an activity and keeps the latest time of its kind
+	TouchAccountActivityTimestamp(ctx context.Context, arg TouchAccountActivityTimestampParams) error
+	TouchOAuthClient(ctx context.Context, id int32) error
+	// Records a use of the key at most once a minute
+	TouchServiceAccountKey(ctx context.Context, id int32) error
+	// Counts a use of the canary
+	TriggerCanaryCredential(ctx context.Context, id int32) (OrganizationsCanaryCredential, error)
+	// Deletes all but the newest keep activities of the account
+	TrimAccountActivityFeed(ctx context.Context, arg TrimAccountActivityFeedParams) (int64, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (OrganizationsAccount, error)
+	UpdateAccountEmail(ctx context.Context, arg UpdateAccountEmailParams) (OrganizationsAccount, error)
 	UpdateAccountLastLogin(ctx context.Context, arg UpdateAccountLastLoginParams) (OrganizationsAccount, error)
 	UpdateAccountStytchInfo(ctx context.Context, arg UpdateAccountStytchInfoParams) (OrganizationsAccount, error)
+	UpdateChatMessageContent(ctx context.Context, arg UpdateChatMessageContentParams) (CognitiveChatMessage, error)
+	UpdateChatSessionParameters(ctx context.Context, arg UpdateChatSessionParametersParams) (CognitiveChatSession, error)
+	UpdateChatSessionSummary(ctx context.Context, arg UpdateChatSessionSummaryParams) (CognitiveChatSession, error)
 	UpdateChatSessionTitle(ctx context.Context, arg UpdateChatSessionTitleParams) (CognitiveChatSession, error)
 	UpdateDocument(ctx context.Context, arg UpdateDocumentParams) (DocumentsDocument, error)
 	UpdateDocumentExtractedText(ctx context.Context, arg UpdateDocumentExtractedTextParams) (DocumentsDocument, error)
+	// Points a document at a new version's file; its text is extracted again
+	UpdateDocumentFile(ctx context.Context, arg UpdateDocumentFileParams) (DocumentsDocument, error)
 	UpdateDocumentStatus(ctx context.Context, arg UpdateDocumentStatusParams) (DocumentsDocument, error)
+	UpdateDocumentVersionText(ctx context.Context, arg UpdateDocumentVersionTextParams) error
 	UpdateFileAsset(ctx context.Context, arg UpdateFileAssetParams) error
 	UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (OrganizationsOrganization, error)
+	UpdateOrganizationImportProgress(ctx context.Context, arg UpdateOrganizationImportProgressParams) error
 	UpdateOrganizationStytchInfo(ctx context.Context, arg UpdateOrganizationStytchInfoParams) (OrganizationsOrganization, error)
 	// UPDATE operations
 	UpdateResource(ctx context.Context, arg UpdateResourceParams) error
@@ -137,10 +528,30 @@ type Querier interface {
 	// Update OCR/LLM processing results
 	UpdateResourceProcessingData(ctx context.Context, arg UpdateResourceProcessingDataParams) error
 	UpdateResourceStatus(ctx context.Context, arg UpdateResourceStatusParams) error
+	UpdateRole(ctx context.Context, arg UpdateRoleParams) (RbacRole, error)
+	UpdateServiceAccount(ctx context.Context, arg UpdateServiceAccountParams) (OrganizationsServiceAccount, error)
+	// Stores the request count of an account's window. Counts only grow, so a
+	// stale flush from another instance never lowers a newer one.
+	UpsertAPIUsage(ctx context.Context, arg UpsertAPIUsageParams) error
+	// Set the display currency and locale of an organization
+	UpsertBillingSettings(ctx context.Context, arg UpsertBillingSettingsParams) (SubscriptionBillingBillingSetting, error)
+	// A manual override is only replaced when replace_manual is set; zero rows
+	// affected means an override was kept
+	UpsertModelDocumentClassification(ctx context.Context, arg UpsertModelDocumentClassificationParams) (int64, error)
+	// Turns a notification type on or off on a channel for an account
+	UpsertNotificationPreference(ctx context.Context, arg UpsertNotificationPreferenceParams) error
+	UpsertOIDCConsent(ctx context.Context, arg UpsertOIDCConsentParams) (OrganizationsOidcConsent, error)
+	UpsertOrganizationAuthPolicy(ctx context.Context, arg UpsertOrganizationAuthPolicyParams) (OrganizationsAuthPolicy, error)
+	// Records the seat quantity of an organization's subscription
+	UpsertPurchasedSeats(ctx context.Context, arg UpsertPurchasedSeatsParams) (SubscriptionBillingSeatAllocation, error)
 	// Create or update quota tracking
 	UpsertQuota(ctx context.Context, arg UpsertQuotaParams) (SubscriptionBillingQuotaTracking, error)
 	// Create or update subscription from Polar webhook
 	UpsertSubscription(ctx context.Context, arg UpsertSubscriptionParams) (SubscriptionBillingSubscription, error)
+	// Create or update an account's subscription from the billing provider
+	UpsertUserSubscription(ctx context.Context, arg UpsertUserSubscriptionParams) (SubscriptionBillingUserSubscription, error)
+	// Marks an address verified and retires its verification link
+	VerifySecondaryEmail(ctx context.Context, id int32) (OrganizationsSecondaryEmail, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -12,6 +12,9 @@ import (
 
 type Store interface {
 	Querier
+
+	// ExecTx runs fn with queries bound to a single database transaction
+	ExecTx(ctx context.Context, fn func(*Queries) error) error
 }
 
 type SQLStore struct {
@@ -32,7 +35,11 @@ func (store *SQLStore) WithTx(db DBTX) Store {
 	}
 }
 
-// ExecTx executes a function within a database transaction
+// ExecTx executes a function within a database transaction.
+// A store created with WithTx is already inside one, so fn runs on it directly.
 func (store *SQLStore) ExecTx(ctx context.Context, fn func(*Queries) error) error {
-	return fn(store.Queries)
+	if store.connPool == nil {
+		return fn(store.Queries)
+	}
+	return store.execTx(ctx, fn)
 }
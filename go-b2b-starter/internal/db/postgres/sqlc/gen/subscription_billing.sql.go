@@ -49,6 +49,25 @@ func (q *Queries) DeleteSubscription(ctx context.Context, organizationID int32)
 	return err
 }
 
+const getBillingSettings = `-- name: GetBillingSettings :one
+SELECT organization_id, currency, locale, created_at, updated_at FROM subscription_billing.billing_settings
+WHERE organization_id = $1
+`
+
+// Get the display currency and locale of an organization
+func (q *Queries) GetBillingSettings(ctx context.Context, organizationID int32) (SubscriptionBillingBillingSetting, error) {
+	row := q.db.QueryRow(ctx, getBillingSettings, organizationID)
+	var i SubscriptionBillingBillingSetting
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.Currency,
+		&i.Locale,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getQuotaByOrgID = `-- name: GetQuotaByOrgID :one
 SELECT id, organization_id, max_seats, period_start, period_end, last_synced_at, created_at, updated_at, invoice_count FROM subscription_billing.quota_tracking
 WHERE organization_id = $1
@@ -81,8 +100,9 @@ SELECT
     s.cancel_at_period_end,
     q.invoice_count,
     q.max_seats,
+    s.trial_end,
     CASE
-        WHEN s.subscription_status = 'active' AND q.invoice_count > 0
+        WHEN s.subscription_status IN ('active', 'trialing') AND q.invoice_count > 0
         THEN TRUE
         ELSE FALSE
     END AS can_process_invoice
@@ -99,6 +119,7 @@ type GetQuotaStatusRow struct {
 	CancelAtPeriodEnd  pgtype.Bool      `json:"cancel_at_period_end"`
 	InvoiceCount       int32            `json:"invoice_count"`
 	MaxSeats           pgtype.Int4      `json:"max_seats"`
+	TrialEnd           pgtype.Timestamp `json:"trial_end"`
 	CanProcessInvoice  bool             `json:"can_process_invoice"`
 }
 
@@ -113,13 +134,14 @@ func (q *Queries) GetQuotaStatus(ctx context.Context, organizationID int32) (Get
 		&i.CancelAtPeriodEnd,
 		&i.InvoiceCount,
 		&i.MaxSeats,
+		&i.TrialEnd,
 		&i.CanProcessInvoice,
 	)
 	return i, err
 }
 
 const getSubscriptionByOrgID = `-- name: GetSubscriptionByOrgID :one
-SELECT id, organization_id, external_customer_id, subscription_id, subscription_status, product_id, product_name, plan_name, current_period_start, current_period_end, cancel_at_period_end, canceled_at, created_at, updated_at, metadata FROM subscription_billing.subscriptions
+SELECT id, organization_id, external_customer_id, subscription_id, subscription_status, product_id, product_name, plan_name, current_period_start, current_period_end, cancel_at_period_end, canceled_at, created_at, updated_at, metadata, trial_start, trial_end, trial_reminder_sent_at FROM subscription_billing.subscriptions
 WHERE organization_id = $1
 LIMIT 1
 `
@@ -144,12 +166,15 @@ func (q *Queries) GetSubscriptionByOrgID(ctx context.Context, organizationID int
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Metadata,
+		&i.TrialStart,
+		&i.TrialEnd,
+		&i.TrialReminderSentAt,
 	)
 	return i, err
 }
 
 const getSubscriptionBySubscriptionID = `-- name: GetSubscriptionBySubscriptionID :one
-SELECT id, organization_id, external_customer_id, subscription_id, subscription_status, product_id, product_name, plan_name, current_period_start, current_period_end, cancel_at_period_end, canceled_at, created_at, updated_at, metadata FROM subscription_billing.subscriptions
+SELECT id, organization_id, external_customer_id, subscription_id, subscription_status, product_id, product_name, plan_name, current_period_start, current_period_end, cancel_at_period_end, canceled_at, created_at, updated_at, metadata, trial_start, trial_end, trial_reminder_sent_at FROM subscription_billing.subscriptions
 WHERE subscription_id = $1
 LIMIT 1
 `
@@ -174,12 +199,15 @@ func (q *Queries) GetSubscriptionBySubscriptionID(ctx context.Context, subscript
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Metadata,
+		&i.TrialStart,
+		&i.TrialEnd,
+		&i.TrialReminderSentAt,
 	)
 	return i, err
 }
 
 const listActiveSubscriptions = `-- name: ListActiveSubscriptions :many
-SELECT id, organization_id, external_customer_id, subscription_id, subscription_status, product_id, product_name, plan_name, current_period_start, current_period_end, cancel_at_period_end, canceled_at, created_at, updated_at, metadata FROM subscription_billing.subscriptions
+SELECT id, organization_id, external_customer_id, subscription_id, subscription_status, product_id, product_name, plan_name, current_period_start, current_period_end, cancel_at_period_end, canceled_at, created_at, updated_at, metadata, trial_start, trial_end, trial_reminder_sent_at FROM subscription_billing.subscriptions
 WHERE subscription_status = 'active'
 ORDER BY created_at DESC
 `
@@ -210,6 +238,9 @@ func (q *Queries) ListActiveSubscriptions(ctx context.Context) ([]SubscriptionBi
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.Metadata,
+			&i.TrialStart,
+			&i.TrialEnd,
+			&i.TrialReminderSentAt,
 		); err != nil {
 			return nil, err
 		}
@@ -322,6 +353,40 @@ func (q *Queries) ResetQuotaForPeriod(ctx context.Context, arg ResetQuotaForPeri
 	return i, err
 }
 
+const upsertBillingSettings = `-- name: UpsertBillingSettings :one
+INSERT INTO subscription_billing.billing_settings (
+    organization_id,
+    currency,
+    locale
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (organization_id) DO UPDATE SET
+    currency = EXCLUDED.currency,
+    locale = EXCLUDED.locale
+RETURNING organization_id, currency, locale, created_at, updated_at
+`
+
+type UpsertBillingSettingsParams struct {
+	OrganizationID int32       `json:"organization_id"`
+	Currency       pgtype.Text `json:"currency"`
+	Locale         pgtype.Text `json:"locale"`
+}
+
+// Set the display currency and locale of an organization
+func (q *Queries) UpsertBillingSettings(ctx context.Context, arg UpsertBillingSettingsParams) (SubscriptionBillingBillingSetting, error) {
+	row := q.db.QueryRow(ctx, upsertBillingSettings, arg.OrganizationID, arg.Currency, arg.Locale)
+	var i SubscriptionBillingBillingSetting
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.Currency,
+		&i.Locale,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const upsertQuota = `-- name: UpsertQuota :one
 INSERT INTO subscription_billing.quota_tracking (
     organization_id,
@@ -391,9 +456,11 @@ INSERT INTO subscription_billing.subscriptions (
     cancel_at_period_end,
     canceled_at,
     metadata,
+    trial_start,
+    trial_end,
     updated_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CURRENT_TIMESTAMP
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, CURRENT_TIMESTAMP
 )
 ON CONFLICT (organization_id)
 DO UPDATE SET
@@ -408,8 +475,11 @@ DO UPDATE SET
     cancel_at_period_end = EXCLUDED.cancel_at_period_end,
     canceled_at = EXCLUDED.canceled_at,
     metadata = EXCLUDED.metadata,
+    -- A trial period is kept after the subscription converts
+    trial_start = COALESCE(EXCLUDED.trial_start, subscription_billing.subscriptions.trial_start),
+    trial_end = COALESCE(EXCLUDED.trial_end, subscription_billing.subscriptions.trial_end),
     updated_at = CURRENT_TIMESTAMP
-RETURNING id, organization_id, external_customer_id, subscription_id, subscription_status, product_id, product_name, plan_name, current_period_start, current_period_end, cancel_at_period_end, canceled_at, created_at, updated_at, metadata
+RETURNING id, organization_id, external_customer_id, subscription_id, subscription_status, product_id, product_name, plan_name, current_period_start, current_period_end, cancel_at_period_end, canceled_at, created_at, updated_at, metadata, trial_start, trial_end, trial_reminder_sent_at
 `
 
 type UpsertSubscriptionParams struct {
@@ -425,6 +495,8 @@ type UpsertSubscriptionParams struct {
 	CancelAtPeriodEnd  pgtype.Bool      `json:"cancel_at_period_end"`
 	CanceledAt         pgtype.Timestamp `json:"canceled_at"`
 	Metadata           []byte           `json:"metadata"`
+	TrialStart         pgtype.Timestamp `json:"trial_start"`
+	TrialEnd           pgtype.Timestamp `json:"trial_end"`
 }
 
 // Create or update subscription from Polar webhook
@@ -442,6 +514,8 @@ func (q *Queries) UpsertSubscription(ctx context.Context, arg UpsertSubscription
 		arg.CancelAtPeriodEnd,
 		arg.CanceledAt,
 		arg.Metadata,
+		arg.TrialStart,
+		arg.TrialEnd,
 	)
 	var i SubscriptionBillingSubscription
 	err := row.Scan(
@@ -460,6 +534,9 @@ func (q *Queries) UpsertSubscription(ctx context.Context, arg UpsertSubscription
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Metadata,
+		&i.TrialStart,
+		&i.TrialEnd,
+		&i.TrialReminderSentAt,
 	)
 	return i, err
 }
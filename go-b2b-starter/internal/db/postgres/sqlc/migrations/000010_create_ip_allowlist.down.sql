@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS organizations.idx_ip_allowlist_entries_org_id;
+DROP TABLE IF EXISTS organizations.ip_allowlist_entries;
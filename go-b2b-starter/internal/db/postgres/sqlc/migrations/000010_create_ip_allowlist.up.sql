@@ -0,0 +1,23 @@
+-- Organization-level IP allowlist
+-- When an organization has at least one entry, API access for its members
+-- is only allowed from matching client addresses.
+CREATE TABLE organizations.ip_allowlist_entries (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    -- Allowed network in CIDR notation (single addresses are stored as /32 or /128)
+    cidr VARCHAR(64) NOT NULL,
+    description VARCHAR(255) DEFAULT '' NOT NULL,
+
+    -- Audit
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    UNIQUE(organization_id, cidr)
+);
+
+CREATE INDEX idx_ip_allowlist_entries_org_id ON organizations.ip_allowlist_entries(organization_id);
+
+COMMENT ON TABLE organizations.ip_allowlist_entries IS 'CIDR ranges allowed to access the API on behalf of an organization';
+COMMENT ON COLUMN organizations.ip_allowlist_entries.cidr IS 'Allowed network in canonical CIDR notation';
+COMMENT ON COLUMN organizations.ip_allowlist_entries.created_by_account_id IS 'Account that added the entry';
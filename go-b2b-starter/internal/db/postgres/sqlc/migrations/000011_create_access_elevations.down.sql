@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_access_elevations_updated_at ON organizations.access_elevations;
+DROP INDEX IF EXISTS organizations.idx_access_elevations_active;
+DROP INDEX IF EXISTS organizations.idx_access_elevations_org_id;
+DROP TABLE IF EXISTS organizations.access_elevations;
@@ -0,0 +1,40 @@
+-- Just-in-time privilege elevation
+-- A member requests a higher role for a limited time; once approved the
+-- role is layered on top of their provider roles until expires_at.
+CREATE TABLE organizations.access_elevations (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    -- Request
+    requested_role VARCHAR(50) NOT NULL,
+    reason TEXT NOT NULL,
+    duration_minutes INTEGER NOT NULL,
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL,
+
+    -- Decision
+    decided_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    decision_note TEXT DEFAULT '' NOT NULL,
+    decided_at TIMESTAMP,
+    expires_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_access_elevations_status CHECK (status IN ('pending', 'approved', 'denied', 'revoked')),
+    CONSTRAINT chk_access_elevations_duration CHECK (duration_minutes > 0)
+);
+
+CREATE INDEX idx_access_elevations_org_id ON organizations.access_elevations(organization_id);
+CREATE INDEX idx_access_elevations_active ON organizations.access_elevations(organization_id, account_id, expires_at)
+    WHERE status = 'approved';
+
+CREATE TRIGGER trigger_access_elevations_updated_at
+    BEFORE UPDATE ON organizations.access_elevations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE organizations.access_elevations IS 'Time-boxed role elevation requests and grants';
+COMMENT ON COLUMN organizations.access_elevations.requested_role IS 'Role granted while the elevation is active';
+COMMENT ON COLUMN organizations.access_elevations.decided_by_account_id IS 'Approver; NULL when granted by policy';
+COMMENT ON COLUMN organizations.access_elevations.expires_at IS 'End of the elevation window, set on approval';
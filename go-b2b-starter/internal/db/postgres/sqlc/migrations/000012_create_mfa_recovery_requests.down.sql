@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_mfa_recovery_requests_updated_at ON organizations.mfa_recovery_requests;
+DROP INDEX IF EXISTS organizations.idx_mfa_recovery_requests_org_id;
+DROP INDEX IF EXISTS organizations.idx_mfa_recovery_requests_open;
+DROP TABLE IF EXISTS organizations.mfa_recovery_requests;
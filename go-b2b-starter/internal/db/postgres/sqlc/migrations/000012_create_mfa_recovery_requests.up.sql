@@ -0,0 +1,44 @@
+-- MFA recovery for members who lost their authenticator
+-- A recovery is opened after email verification, waits out a mandatory delay
+-- (and optionally an admin approval), then resets the member's MFA factors.
+CREATE TABLE organizations.mfa_recovery_requests (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL,
+    requires_approval BOOLEAN DEFAULT FALSE NOT NULL,
+
+    -- SHA-256 of the one-time completion token handed to the requester
+    token_hash VARCHAR(64) NOT NULL,
+
+    -- Approval
+    decided_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    decided_at TIMESTAMP,
+
+    -- Recovery window
+    eligible_at TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_mfa_recovery_requests_status CHECK (status IN ('pending', 'approved', 'denied', 'cancelled', 'completed')),
+    CONSTRAINT chk_mfa_recovery_requests_window CHECK (expires_at > eligible_at)
+);
+
+-- At most one open recovery per account
+CREATE UNIQUE INDEX idx_mfa_recovery_requests_open ON organizations.mfa_recovery_requests(account_id)
+    WHERE status IN ('pending', 'approved');
+CREATE INDEX idx_mfa_recovery_requests_org_id ON organizations.mfa_recovery_requests(organization_id);
+
+CREATE TRIGGER trigger_mfa_recovery_requests_updated_at
+    BEFORE UPDATE ON organizations.mfa_recovery_requests
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE organizations.mfa_recovery_requests IS 'Delayed MFA reset requests for members who lost their authenticator';
+COMMENT ON COLUMN organizations.mfa_recovery_requests.token_hash IS 'SHA-256 of the one-time completion token';
+COMMENT ON COLUMN organizations.mfa_recovery_requests.eligible_at IS 'Earliest time the reset can be completed';
+COMMENT ON COLUMN organizations.mfa_recovery_requests.expires_at IS 'Latest time the reset can be completed';
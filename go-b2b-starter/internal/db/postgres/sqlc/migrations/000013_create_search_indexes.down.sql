@@ -0,0 +1 @@
+DROP INDEX IF EXISTS documents.idx_documents_search;
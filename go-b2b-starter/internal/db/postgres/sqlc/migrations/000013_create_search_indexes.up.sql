@@ -0,0 +1,3 @@
+-- Full-text index backing global document search
+CREATE INDEX idx_documents_search ON documents.documents
+    USING GIN (to_tsvector('english', title || ' ' || COALESCE(extracted_text, '')));
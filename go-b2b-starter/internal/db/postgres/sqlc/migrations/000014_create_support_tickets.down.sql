@@ -0,0 +1,7 @@
+DELETE FROM file_manager.file_contexts WHERE name = 'support';
+DROP TABLE IF EXISTS support.ticket_attachments;
+DROP TRIGGER IF EXISTS trigger_support_tickets_updated_at ON support.tickets;
+DROP INDEX IF EXISTS support.idx_support_tickets_status;
+DROP INDEX IF EXISTS support.idx_support_tickets_account;
+DROP TABLE IF EXISTS support.tickets;
+DROP SCHEMA IF EXISTS support;
@@ -0,0 +1,50 @@
+-- Support tickets submitted by signed-in members or anonymous visitors
+CREATE SCHEMA IF NOT EXISTS support;
+
+CREATE TABLE support.tickets (
+    id SERIAL PRIMARY KEY,
+    -- Public identifier shared with the requester and the support inbox
+    reference VARCHAR(36) NOT NULL UNIQUE,
+
+    -- Set for tickets from signed-in members, NULL for anonymous visitors
+    organization_id INTEGER REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+
+    name VARCHAR(255) DEFAULT '' NOT NULL,
+    email VARCHAR(255) NOT NULL,
+    subject VARCHAR(200) NOT NULL,
+    message TEXT NOT NULL,
+    status VARCHAR(20) DEFAULT 'open' NOT NULL,
+
+    -- SHA-256 of the access token anonymous requesters use to check status
+    access_token_hash VARCHAR(64) NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_support_tickets_status CHECK (status IN ('open', 'in_progress', 'resolved', 'closed'))
+);
+
+CREATE INDEX idx_support_tickets_account ON support.tickets(organization_id, account_id, created_at DESC);
+CREATE INDEX idx_support_tickets_status ON support.tickets(status);
+
+CREATE TRIGGER trigger_support_tickets_updated_at
+    BEFORE UPDATE ON support.tickets
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Files attached to a ticket (stored by the files module)
+CREATE TABLE support.ticket_attachments (
+    ticket_id INTEGER NOT NULL REFERENCES support.tickets(id) ON DELETE CASCADE,
+    file_asset_id INTEGER NOT NULL REFERENCES file_manager.file_assets(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    PRIMARY KEY (ticket_id, file_asset_id)
+);
+
+-- File context for support attachments
+INSERT INTO file_manager.file_contexts (id, name) VALUES (7, 'support')
+ON CONFLICT DO NOTHING;
+
+COMMENT ON TABLE support.tickets IS 'Support and contact requests routed to the support inbox';
+COMMENT ON COLUMN support.tickets.reference IS 'Public ticket identifier';
+COMMENT ON COLUMN support.tickets.access_token_hash IS 'SHA-256 of the requester status access token';
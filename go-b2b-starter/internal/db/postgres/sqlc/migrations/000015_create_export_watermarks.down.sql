@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS documents.idx_documents_export;
+DROP INDEX IF EXISTS cognitive.idx_chat_messages_export;
+DROP TRIGGER IF EXISTS trigger_export_watermarks_updated_at ON analytics.export_watermarks;
+DROP TABLE IF EXISTS analytics.export_watermarks;
+DROP SCHEMA IF EXISTS analytics;
@@ -0,0 +1,35 @@
+-- Incremental data warehouse exports
+-- One row per exported dataset tracks how far the export has progressed.
+CREATE SCHEMA IF NOT EXISTS analytics;
+
+CREATE TABLE analytics.export_watermarks (
+    dataset VARCHAR(100) PRIMARY KEY,
+    schema_version INTEGER NOT NULL,
+
+    -- Keyset position of the last exported row: (timestamp, id)
+    watermark_at TIMESTAMP DEFAULT 'epoch' NOT NULL,
+    watermark_id INTEGER DEFAULT 0 NOT NULL,
+
+    rows_exported BIGINT DEFAULT 0 NOT NULL,
+    last_object_key VARCHAR(1000),
+    last_exported_at TIMESTAMP,
+
+    -- Set while an exporter instance holds the dataset
+    lease_until TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TRIGGER trigger_export_watermarks_updated_at
+    BEFORE UPDATE ON analytics.export_watermarks
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Keyset scans for the exported facts
+CREATE INDEX IF NOT EXISTS idx_chat_messages_export ON cognitive.chat_messages(created_at, id);
+CREATE INDEX IF NOT EXISTS idx_documents_export ON documents.documents(updated_at, id);
+
+COMMENT ON TABLE analytics.export_watermarks IS 'Progress of incremental data warehouse exports per dataset';
+COMMENT ON COLUMN analytics.export_watermarks.schema_version IS 'Schema version of the exported files; a new version restarts the export';
+COMMENT ON COLUMN analytics.export_watermarks.lease_until IS 'Exporter lease so only one instance exports a dataset at a time';
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trigger_billing_settings_updated_at ON subscription_billing.billing_settings;
+DROP TABLE IF EXISTS subscription_billing.billing_settings;
@@ -0,0 +1,21 @@
+-- Per-organization currency and locale used to display prices and amounts
+CREATE TABLE subscription_billing.billing_settings (
+    organization_id INT PRIMARY KEY REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    -- ISO 4217 code, NULL = negotiate from the request
+    currency VARCHAR(3),
+    -- BCP 47 language tag, NULL = negotiate from the request
+    locale VARCHAR(35),
+
+    -- Audit timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TRIGGER trigger_billing_settings_updated_at
+    BEFORE UPDATE ON subscription_billing.billing_settings
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE subscription_billing.billing_settings IS 'Preferred currency and locale for displaying prices to an organization';
+COMMENT ON COLUMN subscription_billing.billing_settings.currency IS 'ISO 4217 currency code; NULL negotiates from the request';
+COMMENT ON COLUMN subscription_billing.billing_settings.locale IS 'BCP 47 locale used to format amounts; NULL negotiates from the request';
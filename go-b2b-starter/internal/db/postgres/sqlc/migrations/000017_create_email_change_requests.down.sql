@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS trigger_email_change_requests_updated_at ON organizations.email_change_requests;
+DROP INDEX IF EXISTS organizations.idx_email_change_requests_revert_token;
+DROP INDEX IF EXISTS organizations.idx_email_change_requests_confirm_token;
+DROP INDEX IF EXISTS organizations.idx_email_change_requests_pending;
+DROP TABLE IF EXISTS organizations.email_change_requests;
@@ -0,0 +1,46 @@
+-- Email address changes requested by members
+-- The new address must be confirmed before it replaces the old one, which stays
+-- active until then. The old address can cancel a pending change or roll back
+-- a confirmed one for a limited time.
+CREATE TABLE organizations.email_change_requests (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    old_email VARCHAR(255) NOT NULL,
+    new_email VARCHAR(255) NOT NULL,
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL,
+
+    -- SHA-256 of the token emailed to the new address
+    confirm_token_hash VARCHAR(64) NOT NULL,
+    -- SHA-256 of the token emailed to the old address, rotated on confirmation
+    revert_token_hash VARCHAR(64) NOT NULL,
+
+    -- Lifecycle
+    expires_at TIMESTAMP NOT NULL,
+    confirmed_at TIMESTAMP,
+    revertible_until TIMESTAMP,
+    reverted_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_email_change_requests_status CHECK (status IN ('pending', 'confirmed', 'cancelled', 'reverted'))
+);
+
+-- At most one pending change per account
+CREATE UNIQUE INDEX idx_email_change_requests_pending ON organizations.email_change_requests(account_id)
+    WHERE status = 'pending';
+CREATE UNIQUE INDEX idx_email_change_requests_confirm_token ON organizations.email_change_requests(confirm_token_hash);
+CREATE UNIQUE INDEX idx_email_change_requests_revert_token ON organizations.email_change_requests(revert_token_hash);
+
+CREATE TRIGGER trigger_email_change_requests_updated_at
+    BEFORE UPDATE ON organizations.email_change_requests
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE organizations.email_change_requests IS 'Member email address changes awaiting confirmation or within their rollback window';
+COMMENT ON COLUMN organizations.email_change_requests.confirm_token_hash IS 'SHA-256 of the token emailed to the new address';
+COMMENT ON COLUMN organizations.email_change_requests.revert_token_hash IS 'SHA-256 of the token emailed to the old address';
+COMMENT ON COLUMN organizations.email_change_requests.expires_at IS 'Latest time the new address can be confirmed';
+COMMENT ON COLUMN organizations.email_change_requests.revertible_until IS 'Latest time the old address can roll back a confirmed change';
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_oauth_clients_updated_at ON organizations.oauth_clients;
+DROP INDEX IF EXISTS organizations.idx_oauth_clients_organization;
+DROP INDEX IF EXISTS organizations.idx_oauth_clients_client_id;
+DROP TABLE IF EXISTS organizations.oauth_clients;
@@ -0,0 +1,37 @@
+-- OAuth2 clients for machine-to-machine access (client credentials grant)
+-- Each client acts through its own service account in the organization and
+-- is limited to the scopes (permissions) granted when it was registered.
+CREATE TABLE organizations.oauth_clients (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    -- Service account the client acts as
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    client_id VARCHAR(64) NOT NULL,
+    -- SHA-256 of the client secret, which is only shown once
+    secret_hash VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    scopes TEXT[] DEFAULT '{}' NOT NULL,
+
+    -- Lifecycle
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_oauth_clients_client_id ON organizations.oauth_clients(client_id);
+CREATE INDEX idx_oauth_clients_organization ON organizations.oauth_clients(organization_id, created_at DESC);
+
+CREATE TRIGGER trigger_oauth_clients_updated_at
+    BEFORE UPDATE ON organizations.oauth_clients
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE organizations.oauth_clients IS 'OAuth2 clients that obtain access tokens with the client credentials grant';
+COMMENT ON COLUMN organizations.oauth_clients.account_id IS 'Service account the client acts as';
+COMMENT ON COLUMN organizations.oauth_clients.secret_hash IS 'SHA-256 of the client secret';
+COMMENT ON COLUMN organizations.oauth_clients.scopes IS 'Permissions (resource:action) the client may request';
+COMMENT ON COLUMN organizations.oauth_clients.last_used_at IS 'Last time the client obtained a token';
@@ -0,0 +1,6 @@
+DROP TRIGGER IF EXISTS trigger_roles_updated_at ON rbac.roles;
+DROP INDEX IF EXISTS rbac.idx_role_permissions_permission;
+DROP TABLE IF EXISTS rbac.role_permissions;
+DROP TABLE IF EXISTS rbac.roles;
+DROP TABLE IF EXISTS rbac.permissions;
+DROP SCHEMA IF EXISTS rbac;
@@ -0,0 +1,76 @@
+-- Roles and permissions managed at runtime
+-- Tokens carry role IDs; each role's permissions are looked up here so
+-- operators can define custom roles without recompiling. System roles are
+-- seeded from the built-in definitions and cannot be deleted.
+CREATE SCHEMA IF NOT EXISTS rbac;
+
+CREATE TABLE rbac.permissions (
+    -- resource:action
+    id VARCHAR(100) PRIMARY KEY,
+    description TEXT DEFAULT '' NOT NULL,
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE rbac.roles (
+    -- Role ID as it appears in tokens (e.g. member, auditor)
+    id VARCHAR(50) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    description TEXT DEFAULT '' NOT NULL,
+    is_system BOOLEAN DEFAULT FALSE NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE TABLE rbac.role_permissions (
+    role_id VARCHAR(50) NOT NULL REFERENCES rbac.roles(id) ON DELETE CASCADE,
+    permission_id VARCHAR(100) NOT NULL REFERENCES rbac.permissions(id) ON DELETE RESTRICT,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE INDEX idx_role_permissions_permission ON rbac.role_permissions(permission_id);
+
+CREATE TRIGGER trigger_roles_updated_at
+    BEFORE UPDATE ON rbac.roles
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE rbac.permissions IS 'Permissions that can be granted to roles';
+COMMENT ON COLUMN rbac.permissions.id IS 'Permission in resource:action form';
+COMMENT ON TABLE rbac.roles IS 'Roles and their display metadata; permissions are in role_permissions';
+COMMENT ON COLUMN rbac.roles.id IS 'Role ID as it appears in tokens';
+COMMENT ON COLUMN rbac.roles.is_system IS 'Built-in role that cannot be deleted';
+COMMENT ON TABLE rbac.role_permissions IS 'Permissions granted to each role';
+
+-- Seed the built-in permissions and roles
+INSERT INTO rbac.permissions (id, description) VALUES
+    ('resource:view', 'Can view resource'),
+    ('resource:create', 'Can create resource'),
+    ('resource:edit', 'Can edit resource'),
+    ('resource:delete', 'Can delete resource'),
+    ('resource:approve', 'Can approve resource'),
+    ('org:view', 'Can view org'),
+    ('org:manage', 'Can manage org');
+
+INSERT INTO rbac.roles (id, name, description, is_system) VALUES
+    ('member', 'Member', 'Basic access. Can view and create resources.', TRUE),
+    ('manager', 'Manager', 'Elevated access. Can edit, delete, and approve resources.', TRUE),
+    ('admin', 'Admin', 'Full control. Can manage organization settings and users.', TRUE);
+
+INSERT INTO rbac.role_permissions (role_id, permission_id) VALUES
+    ('member', 'resource:view'),
+    ('member', 'resource:create'),
+    ('manager', 'resource:view'),
+    ('manager', 'resource:create'),
+    ('manager', 'resource:edit'),
+    ('manager', 'resource:delete'),
+    ('manager', 'resource:approve'),
+    ('manager', 'org:view'),
+    ('admin', 'resource:view'),
+    ('admin', 'resource:create'),
+    ('admin', 'resource:edit'),
+    ('admin', 'resource:delete'),
+    ('admin', 'resource:approve'),
+    ('admin', 'org:view'),
+    ('admin', 'org:manage');
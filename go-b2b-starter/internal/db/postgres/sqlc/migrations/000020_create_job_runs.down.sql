@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS jobs.idx_job_runs_started;
+DROP INDEX IF EXISTS jobs.idx_job_runs_job_started;
+DROP TABLE IF EXISTS jobs.job_runs;
+DROP SCHEMA IF EXISTS jobs;
@@ -0,0 +1,31 @@
+CREATE SCHEMA IF NOT EXISTS jobs;
+
+-- History of background job executions (scheduled loops and queued event handlers)
+-- Rows older than JOBS_HISTORY_RETENTION are purged by the jobs.history_cleanup job.
+CREATE TABLE jobs.job_runs (
+    id BIGSERIAL PRIMARY KEY,
+    job_name VARCHAR(100) NOT NULL,
+    -- scheduled or queued
+    kind VARCHAR(20) NOT NULL,
+    -- running, succeeded or failed
+    status VARCHAR(20) DEFAULT 'running' NOT NULL,
+    -- Host that ran the job
+    instance VARCHAR(255) NOT NULL,
+    error TEXT,
+
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    finished_at TIMESTAMP,
+    duration_ms BIGINT,
+
+    CONSTRAINT check_job_runs_kind CHECK (kind IN ('scheduled', 'queued')),
+    CONSTRAINT check_job_runs_status CHECK (status IN ('running', 'succeeded', 'failed'))
+);
+
+CREATE INDEX idx_job_runs_job_started ON jobs.job_runs(job_name, started_at DESC);
+CREATE INDEX idx_job_runs_started ON jobs.job_runs(started_at DESC);
+
+COMMENT ON TABLE jobs.job_runs IS 'History of background job executions';
+COMMENT ON COLUMN jobs.job_runs.kind IS 'scheduled or queued';
+COMMENT ON COLUMN jobs.job_runs.status IS 'running, succeeded or failed';
+COMMENT ON COLUMN jobs.job_runs.instance IS 'Host that ran the job';
+COMMENT ON COLUMN jobs.job_runs.duration_ms IS 'Run time in milliseconds, set when the run finishes';
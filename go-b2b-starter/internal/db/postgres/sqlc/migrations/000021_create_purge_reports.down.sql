@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS compliance.idx_purge_reports_created;
+DROP INDEX IF EXISTS compliance.idx_purge_reports_organization;
+DROP TABLE IF EXISTS compliance.purge_reports;
+DROP SCHEMA IF EXISTS compliance;
@@ -0,0 +1,34 @@
+CREATE SCHEMA IF NOT EXISTS compliance;
+
+-- Verification reports for tenant data purges (GDPR erasure or organization deletion).
+-- There is deliberately no foreign key to organizations: the report must outlive the
+-- organization it describes.
+CREATE TABLE compliance.purge_reports (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL,
+    organization_name VARCHAR(255) NOT NULL,
+    -- gdpr_erasure or organization_deletion
+    reason VARCHAR(30) NOT NULL,
+    -- Operator or ticket reference that requested the purge
+    requested_by VARCHAR(255) NOT NULL,
+    -- verified when nothing remains, incomplete otherwise
+    status VARCHAR(20) NOT NULL,
+    -- Per-table, vector index, storage and auth provider results
+    details JSONB NOT NULL,
+
+    started_at TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT check_purge_reports_reason CHECK (reason IN ('gdpr_erasure', 'organization_deletion')),
+    CONSTRAINT check_purge_reports_status CHECK (status IN ('verified', 'incomplete'))
+);
+
+CREATE INDEX idx_purge_reports_organization ON compliance.purge_reports(organization_id, created_at DESC);
+CREATE INDEX idx_purge_reports_created ON compliance.purge_reports(created_at DESC);
+
+COMMENT ON TABLE compliance.purge_reports IS 'Verification reports for tenant data purges';
+COMMENT ON COLUMN compliance.purge_reports.reason IS 'gdpr_erasure or organization_deletion';
+COMMENT ON COLUMN compliance.purge_reports.requested_by IS 'Operator or ticket reference that requested the purge';
+COMMENT ON COLUMN compliance.purge_reports.status IS 'verified when nothing remains, incomplete otherwise';
+COMMENT ON COLUMN compliance.purge_reports.details IS 'Per-table, vector index, storage and auth provider results';
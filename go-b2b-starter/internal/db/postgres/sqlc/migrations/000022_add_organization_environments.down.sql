@@ -0,0 +1,17 @@
+DELETE FROM compliance.purge_reports WHERE reason = 'staging_expiry';
+
+ALTER TABLE compliance.purge_reports
+    DROP CONSTRAINT check_purge_reports_reason,
+    ADD CONSTRAINT check_purge_reports_reason CHECK (reason IN ('gdpr_erasure', 'organization_deletion'));
+
+COMMENT ON COLUMN compliance.purge_reports.reason IS 'gdpr_erasure or organization_deletion';
+
+DROP INDEX IF EXISTS organizations.idx_organizations_expires_at;
+DROP INDEX IF EXISTS organizations.idx_organizations_parent;
+
+ALTER TABLE organizations.organizations
+    DROP CONSTRAINT chk_organizations_environment_parent,
+    DROP CONSTRAINT chk_organizations_environment,
+    DROP COLUMN expires_at,
+    DROP COLUMN parent_organization_id,
+    DROP COLUMN environment;
@@ -0,0 +1,25 @@
+-- Staging and sandbox organizations let customers test integrations against the
+-- production deployment. They belong to a production parent, are excluded from
+-- analytics and billing, and are purged once expires_at passes.
+ALTER TABLE organizations.organizations
+    ADD COLUMN environment VARCHAR(20) DEFAULT 'production' NOT NULL,
+    ADD COLUMN parent_organization_id INTEGER REFERENCES organizations.organizations(id),
+    ADD COLUMN expires_at TIMESTAMP,
+    ADD CONSTRAINT chk_organizations_environment CHECK (environment IN ('production', 'staging', 'sandbox')),
+    ADD CONSTRAINT chk_organizations_environment_parent CHECK ((environment = 'production') = (parent_organization_id IS NULL));
+
+CREATE INDEX idx_organizations_parent ON organizations.organizations(parent_organization_id)
+    WHERE parent_organization_id IS NOT NULL;
+CREATE INDEX idx_organizations_expires_at ON organizations.organizations(expires_at)
+    WHERE environment <> 'production';
+
+COMMENT ON COLUMN organizations.organizations.environment IS 'production, staging or sandbox';
+COMMENT ON COLUMN organizations.organizations.parent_organization_id IS 'Production organization owning a staging or sandbox organization';
+COMMENT ON COLUMN organizations.organizations.expires_at IS 'When a staging or sandbox organization is purged';
+
+-- Expired staging organizations are purged with their own reason
+ALTER TABLE compliance.purge_reports
+    DROP CONSTRAINT check_purge_reports_reason,
+    ADD CONSTRAINT check_purge_reports_reason CHECK (reason IN ('gdpr_erasure', 'organization_deletion', 'staging_expiry'));
+
+COMMENT ON COLUMN compliance.purge_reports.reason IS 'gdpr_erasure, organization_deletion or staging_expiry';
@@ -0,0 +1,32 @@
+-- Versions of a document: every upload of a new revision adds one with its own
+-- file and extracted text, so revisions can be compared.
+CREATE TABLE documents.document_versions (
+    id SERIAL PRIMARY KEY,
+    document_id INTEGER NOT NULL REFERENCES documents.documents(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    version_number INTEGER NOT NULL,
+    file_asset_id INTEGER NOT NULL REFERENCES file_manager.file_assets(id) ON DELETE CASCADE,
+    file_name VARCHAR(500) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    file_size BIGINT NOT NULL,
+    extracted_text TEXT,
+    status VARCHAR(50) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_document_versions_number UNIQUE (document_id, version_number),
+    CONSTRAINT uq_document_versions_file UNIQUE (document_id, file_asset_id),
+    CONSTRAINT valid_version_status CHECK (status IN ('pending', 'processing', 'processed', 'failed'))
+);
+
+CREATE INDEX idx_document_versions_organization ON documents.document_versions(organization_id);
+
+-- Existing documents become their own first version
+INSERT INTO documents.document_versions (
+    document_id, organization_id, version_number, file_asset_id,
+    file_name, content_type, file_size, extracted_text, status, created_at
+)
+SELECT id, organization_id, 1, file_asset_id,
+       file_name, content_type, file_size, extracted_text, status, created_at
+FROM documents.documents;
+
+COMMENT ON TABLE documents.document_versions IS 'Uploaded revisions of a document with their extracted text';
+COMMENT ON COLUMN documents.document_versions.version_number IS 'Starts at 1 and increases with every uploaded revision';
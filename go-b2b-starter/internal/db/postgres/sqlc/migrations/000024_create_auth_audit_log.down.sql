@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS organizations.idx_auth_audit_log_occurred;
+DROP INDEX IF EXISTS organizations.idx_auth_audit_log_user;
+DROP INDEX IF EXISTS organizations.idx_auth_audit_log_organization;
+DROP TABLE IF EXISTS organizations.auth_audit_log;
@@ -0,0 +1,42 @@
+-- Structured auth events (logins, failures, lockouts, token refreshes, logouts)
+-- recorded from the event bus. Events that cannot be tied to an organization,
+-- such as rejected tokens and lockouts, are stored without one.
+-- Rows older than AUTH_AUDIT_RETENTION are purged by the auth.audit_log_cleanup job.
+CREATE TABLE organizations.auth_audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id INTEGER REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+
+    event_type VARCHAR(50) NOT NULL,
+    -- Auth provider user ID and email, kept when the account is deleted
+    user_id VARCHAR(255),
+    email VARCHAR(255),
+    session_id VARCHAR(255),
+
+    -- Request the event was observed on
+    client_ip VARCHAR(45),
+    user_agent TEXT,
+    reason TEXT,
+
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT check_auth_audit_log_event_type CHECK (event_type IN (
+        'auth.login_succeeded',
+        'auth.login_failed',
+        'auth.lockout',
+        'auth.token_refreshed',
+        'auth.logout',
+        'auth.password_changed'
+    ))
+);
+
+CREATE INDEX idx_auth_audit_log_organization ON organizations.auth_audit_log(organization_id, occurred_at DESC);
+CREATE INDEX idx_auth_audit_log_user ON organizations.auth_audit_log(user_id, occurred_at DESC);
+CREATE INDEX idx_auth_audit_log_occurred ON organizations.auth_audit_log(occurred_at);
+
+COMMENT ON TABLE organizations.auth_audit_log IS 'Structured auth events recorded from the event bus';
+COMMENT ON COLUMN organizations.auth_audit_log.organization_id IS 'Organization of the identity, NULL when the event has none (e.g. rejected tokens, lockouts)';
+COMMENT ON COLUMN organizations.auth_audit_log.user_id IS 'Auth provider user ID';
+COMMENT ON COLUMN organizations.auth_audit_log.reason IS 'Why a login failed, or how a logout was initiated';
+COMMENT ON COLUMN organizations.auth_audit_log.occurred_at IS 'When the event happened, as published';
@@ -0,0 +1,29 @@
+-- Document type and named entities of a document, produced by the optional
+-- LLM classification stage after text extraction or set manually.
+-- Manual overrides (source = 'manual') are kept when the document is reprocessed.
+CREATE TABLE documents.document_classifications (
+    document_id INTEGER PRIMARY KEY REFERENCES documents.documents(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    document_type VARCHAR(100) NOT NULL,
+    confidence DOUBLE PRECISION NOT NULL,
+    -- [{"type": "...", "value": "...", "confidence": 0.0}]
+    entities JSONB NOT NULL DEFAULT '[]',
+
+    source VARCHAR(20) NOT NULL,
+    -- LLM model for source = 'model', NULL for manual overrides
+    model VARCHAR(100),
+    overridden_by INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+
+    CONSTRAINT valid_classification_source CHECK (source IN ('model', 'manual')),
+    CONSTRAINT valid_classification_confidence CHECK (confidence >= 0 AND confidence <= 1)
+);
+
+CREATE INDEX idx_document_classifications_org_type ON documents.document_classifications(organization_id, document_type);
+
+COMMENT ON TABLE documents.document_classifications IS 'Document type and extracted named entities per document';
+COMMENT ON COLUMN documents.document_classifications.confidence IS 'Model confidence in the document type from 0 to 1; 1 for manual overrides';
+COMMENT ON COLUMN documents.document_classifications.source IS 'model when set by the classification stage, manual when overridden by a user';
@@ -0,0 +1 @@
+ALTER TABLE cognitive.document_embeddings DROP COLUMN IF EXISTS location;
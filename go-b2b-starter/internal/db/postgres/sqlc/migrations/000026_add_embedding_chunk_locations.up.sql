@@ -0,0 +1,5 @@
+-- Spreadsheets are embedded one chunk of rows at a time; location is the
+-- cell range a chunk covers (e.g. 'Q3 Sales'!A2:D40) and is cited in RAG answers.
+ALTER TABLE cognitive.document_embeddings ADD COLUMN location VARCHAR(255);
+
+COMMENT ON COLUMN cognitive.document_embeddings.location IS 'Cell range of a spreadsheet chunk, NULL for text documents';
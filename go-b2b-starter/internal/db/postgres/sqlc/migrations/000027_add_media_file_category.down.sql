@@ -0,0 +1,2 @@
+COMMENT ON COLUMN cognitive.document_embeddings.location IS 'Cell range of a spreadsheet chunk, NULL for text documents';
+DELETE FROM file_manager.file_categories WHERE name = 'media';
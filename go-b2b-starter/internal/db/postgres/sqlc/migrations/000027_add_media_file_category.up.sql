@@ -0,0 +1,6 @@
+-- File category for audio and video documents, which are transcribed
+INSERT INTO file_manager.file_categories (id, name, max_size_bytes) VALUES (4, 'media', 26214400) -- 25MB
+ON CONFLICT DO NOTHING;
+
+-- Transcripts are embedded one chunk of segments at a time, located by time range
+COMMENT ON COLUMN cognitive.document_embeddings.location IS 'Cell range of a spreadsheet chunk or time range (HH:MM:SS-HH:MM:SS) of a transcript chunk, NULL for text documents';
@@ -0,0 +1,9 @@
+ALTER TABLE cognitive.chat_messages
+    DROP COLUMN IF EXISTS max_tokens,
+    DROP COLUMN IF EXISTS temperature,
+    DROP COLUMN IF EXISTS model;
+
+ALTER TABLE cognitive.chat_sessions
+    DROP COLUMN IF EXISTS max_tokens,
+    DROP COLUMN IF EXISTS temperature,
+    DROP COLUMN IF EXISTS model;
@@ -0,0 +1,19 @@
+-- Clients may pick the model, temperature and completion token limit of a
+-- conversation within the server's allowlist and their plan's ceiling. Each
+-- assistant message records the parameters it was actually generated with.
+ALTER TABLE cognitive.chat_sessions
+    ADD COLUMN model VARCHAR(100),
+    ADD COLUMN temperature REAL,
+    ADD COLUMN max_tokens INTEGER;
+
+ALTER TABLE cognitive.chat_messages
+    ADD COLUMN model VARCHAR(100),
+    ADD COLUMN temperature REAL,
+    ADD COLUMN max_tokens INTEGER;
+
+COMMENT ON COLUMN cognitive.chat_sessions.model IS 'Model requested for the conversation, NULL for the server default';
+COMMENT ON COLUMN cognitive.chat_sessions.temperature IS 'Temperature requested for the conversation, NULL for the server default';
+COMMENT ON COLUMN cognitive.chat_sessions.max_tokens IS 'Completion token limit requested for the conversation, NULL for the server default';
+COMMENT ON COLUMN cognitive.chat_messages.model IS 'Model the message was generated with, NULL for user messages';
+COMMENT ON COLUMN cognitive.chat_messages.temperature IS 'Temperature the message was generated with, NULL when the model takes none';
+COMMENT ON COLUMN cognitive.chat_messages.max_tokens IS 'Completion token limit the message was generated with';
@@ -0,0 +1,17 @@
+-- Versions of an organization's assistant prompt settings: every change adds
+-- one, so earlier prompts can be reviewed and restored. The newest applies.
+CREATE TABLE cognitive.prompt_settings (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    version_number INTEGER NOT NULL,
+    system_prompt TEXT,
+    glossary JSONB NOT NULL DEFAULT '[]',
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_prompt_settings_version UNIQUE (organization_id, version_number)
+);
+
+COMMENT ON TABLE cognitive.prompt_settings IS 'Versioned custom system prompt and glossary added to an organization''s chat prompts';
+COMMENT ON COLUMN cognitive.prompt_settings.version_number IS 'Starts at 1 and increases with every change; the highest version applies';
+COMMENT ON COLUMN cognitive.prompt_settings.system_prompt IS 'Persona and instructions added after the built-in system prompt, NULL for none';
+COMMENT ON COLUMN cognitive.prompt_settings.glossary IS 'Array of {term, definition} objects explaining organization-specific terms';
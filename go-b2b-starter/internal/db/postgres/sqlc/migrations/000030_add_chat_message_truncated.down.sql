@@ -0,0 +1,2 @@
+ALTER TABLE cognitive.chat_messages
+    DROP COLUMN IF EXISTS truncated;
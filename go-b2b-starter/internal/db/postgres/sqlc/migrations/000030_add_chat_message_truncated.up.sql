@@ -0,0 +1,7 @@
+-- A streamed answer whose client disconnected, or whose provider stream broke
+-- off, is kept with what was generated so far so it can be continued or
+-- regenerated later.
+ALTER TABLE cognitive.chat_messages
+    ADD COLUMN truncated BOOLEAN NOT NULL DEFAULT FALSE;
+
+COMMENT ON COLUMN cognitive.chat_messages.truncated IS 'Whether generation stopped before the answer was complete';
@@ -0,0 +1,3 @@
+ALTER TABLE cognitive.chat_sessions
+    DROP COLUMN IF EXISTS summarized_through_message_id,
+    DROP COLUMN IF EXISTS summary;
@@ -0,0 +1,8 @@
+-- Long conversations keep a rolling summary of their older turns, which is
+-- sent in place of those turns so prompts stay within the context budget.
+ALTER TABLE cognitive.chat_sessions
+    ADD COLUMN summary TEXT,
+    ADD COLUMN summarized_through_message_id INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN cognitive.chat_sessions.summary IS 'Rolling summary of the conversation up to summarized_through_message_id, NULL until the conversation is first summarized';
+COMMENT ON COLUMN cognitive.chat_sessions.summarized_through_message_id IS 'Newest message covered by the summary; later messages are sent verbatim';
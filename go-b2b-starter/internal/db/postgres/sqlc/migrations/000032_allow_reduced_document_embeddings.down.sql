@@ -0,0 +1,13 @@
+-- Reduced embeddings cannot be restored to full size; their documents need
+-- to be embedded again.
+DELETE FROM cognitive.document_embeddings
+WHERE vector_dims(embedding) <> 1536;
+
+ALTER TABLE cognitive.document_embeddings
+    ALTER COLUMN embedding TYPE vector(1536);
+
+CREATE INDEX idx_doc_embeddings_vector ON cognitive.document_embeddings
+USING ivfflat (embedding vector_cosine_ops) WITH (lists = 100);
+
+COMMENT ON TABLE cognitive.document_embeddings IS 'Vector embeddings for documents using OpenAI text-embedding-3-small (1536 dimensions)';
+COMMENT ON COLUMN cognitive.document_embeddings.embedding IS 'Vector embedding for semantic similarity search';
@@ -0,0 +1,11 @@
+-- Document embeddings are stored at the size configured for the document
+-- index (EMBEDDING_DOCUMENTS_DIMENSIONS), so the column no longer fixes one.
+-- The IVFFlat index requires a fixed size; searches are scoped to one
+-- organization through idx_doc_embeddings_organization instead.
+DROP INDEX IF EXISTS cognitive.idx_doc_embeddings_vector;
+
+ALTER TABLE cognitive.document_embeddings
+    ALTER COLUMN embedding TYPE vector;
+
+COMMENT ON TABLE cognitive.document_embeddings IS 'Vector embeddings for documents using OpenAI text-embedding-3-small, full size (1536 dimensions) or reduced';
+COMMENT ON COLUMN cognitive.document_embeddings.embedding IS 'Vector embedding for semantic similarity search, with the dimensions configured for the document index';
@@ -0,0 +1,2 @@
+ALTER TABLE cognitive.prompt_settings
+    DROP COLUMN IF EXISTS language;
@@ -0,0 +1,6 @@
+-- The organization's default language for generated answers and
+-- conversation summaries, versioned with the rest of its prompt settings.
+ALTER TABLE cognitive.prompt_settings
+    ADD COLUMN language VARCHAR(35);
+
+COMMENT ON COLUMN cognitive.prompt_settings.language IS 'BCP 47 tag of the default answer language, NULL to answer in the language of each question';
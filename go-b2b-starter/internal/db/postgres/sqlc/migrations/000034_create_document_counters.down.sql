@@ -0,0 +1,15 @@
+DROP TRIGGER IF EXISTS document_counters_chunk_delete ON cognitive.document_embeddings;
+DROP TRIGGER IF EXISTS document_counters_chunk_insert ON cognitive.document_embeddings;
+DROP TRIGGER IF EXISTS document_counters_version_delete ON documents.document_versions;
+DROP TRIGGER IF EXISTS document_counters_version_insert ON documents.document_versions;
+DROP TRIGGER IF EXISTS document_counters_delete ON documents.documents;
+DROP TRIGGER IF EXISTS document_counters_update ON documents.documents;
+DROP TRIGGER IF EXISTS document_counters_insert ON documents.documents;
+
+DROP FUNCTION IF EXISTS documents.reconcile_document_counters(INTEGER);
+DROP FUNCTION IF EXISTS documents.count_document_chunks();
+DROP FUNCTION IF EXISTS documents.count_document_storage();
+DROP FUNCTION IF EXISTS documents.count_documents();
+DROP FUNCTION IF EXISTS documents.add_document_counters(INTEGER, BIGINT, BIGINT, BIGINT, BIGINT, BIGINT, BIGINT, BIGINT);
+
+DROP TABLE IF EXISTS documents.document_counters;
@@ -0,0 +1,229 @@
+-- Per-organization document counters for quota checks and dashboards, so
+-- they don't count rows of the documents, versions and embeddings tables.
+-- Statement-level triggers keep them in the same transaction as the rows
+-- they count; the documents.counter_reconcile job corrects any drift.
+CREATE TABLE documents.document_counters (
+    organization_id INTEGER PRIMARY KEY REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    document_count BIGINT NOT NULL DEFAULT 0,
+    pending_count BIGINT NOT NULL DEFAULT 0,
+    processing_count BIGINT NOT NULL DEFAULT 0,
+    processed_count BIGINT NOT NULL DEFAULT 0,
+    failed_count BIGINT NOT NULL DEFAULT 0,
+    storage_bytes BIGINT NOT NULL DEFAULT 0,
+    chunk_count BIGINT NOT NULL DEFAULT 0,
+    reconciled_at TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Adds deltas to an organization's counters. Skipped while the organization
+-- itself is being deleted, whose cascade removes its counters anyway.
+CREATE OR REPLACE FUNCTION documents.add_document_counters(
+    org INTEGER,
+    docs BIGINT,
+    pending BIGINT,
+    processing BIGINT,
+    processed BIGINT,
+    failed BIGINT,
+    bytes BIGINT,
+    chunks BIGINT
+) RETURNS VOID AS $$
+BEGIN
+    INSERT INTO documents.document_counters AS c (
+        organization_id, document_count, pending_count, processing_count,
+        processed_count, failed_count, storage_bytes, chunk_count
+    )
+    SELECT org, docs, pending, processing, processed, failed, bytes, chunks
+    WHERE EXISTS (SELECT 1 FROM organizations.organizations WHERE id = org)
+    ON CONFLICT (organization_id) DO UPDATE SET
+        document_count = c.document_count + EXCLUDED.document_count,
+        pending_count = c.pending_count + EXCLUDED.pending_count,
+        processing_count = c.processing_count + EXCLUDED.processing_count,
+        processed_count = c.processed_count + EXCLUDED.processed_count,
+        failed_count = c.failed_count + EXCLUDED.failed_count,
+        storage_bytes = c.storage_bytes + EXCLUDED.storage_bytes,
+        chunk_count = c.chunk_count + EXCLUDED.chunk_count,
+        updated_at = NOW();
+END;
+$$ LANGUAGE plpgsql;
+
+-- Documents by status: inserted rows add, deleted rows subtract, and an
+-- update subtracts the old rows and adds the new ones
+CREATE OR REPLACE FUNCTION documents.count_documents()
+RETURNS TRIGGER AS $$
+DECLARE
+    r RECORD;
+BEGIN
+    IF TG_OP IN ('UPDATE', 'DELETE') THEN
+        FOR r IN
+            SELECT organization_id,
+                   COUNT(*) AS docs,
+                   COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+                   COUNT(*) FILTER (WHERE status = 'processing') AS processing,
+                   COUNT(*) FILTER (WHERE status = 'processed') AS processed,
+                   COUNT(*) FILTER (WHERE status = 'failed') AS failed
+            FROM old_rows GROUP BY organization_id
+        LOOP
+            PERFORM documents.add_document_counters(r.organization_id,
+                -r.docs, -r.pending, -r.processing, -r.processed, -r.failed, 0, 0);
+        END LOOP;
+    END IF;
+
+    IF TG_OP IN ('INSERT', 'UPDATE') THEN
+        FOR r IN
+            SELECT organization_id,
+                   COUNT(*) AS docs,
+                   COUNT(*) FILTER (WHERE status = 'pending') AS pending,
+                   COUNT(*) FILTER (WHERE status = 'processing') AS processing,
+                   COUNT(*) FILTER (WHERE status = 'processed') AS processed,
+                   COUNT(*) FILTER (WHERE status = 'failed') AS failed
+            FROM new_rows GROUP BY organization_id
+        LOOP
+            PERFORM documents.add_document_counters(r.organization_id,
+                r.docs, r.pending, r.processing, r.processed, r.failed, 0, 0);
+        END LOOP;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER document_counters_insert
+    AFTER INSERT ON documents.documents
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION documents.count_documents();
+
+CREATE TRIGGER document_counters_update
+    AFTER UPDATE ON documents.documents
+    REFERENCING OLD TABLE AS old_rows NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION documents.count_documents();
+
+CREATE TRIGGER document_counters_delete
+    AFTER DELETE ON documents.documents
+    REFERENCING OLD TABLE AS old_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION documents.count_documents();
+
+-- Storage is the size of every stored version's file
+CREATE OR REPLACE FUNCTION documents.count_document_storage()
+RETURNS TRIGGER AS $$
+DECLARE
+    r RECORD;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        FOR r IN SELECT organization_id, SUM(file_size) AS bytes FROM new_rows GROUP BY organization_id LOOP
+            PERFORM documents.add_document_counters(r.organization_id, 0, 0, 0, 0, 0, r.bytes, 0);
+        END LOOP;
+    ELSE
+        FOR r IN SELECT organization_id, SUM(file_size) AS bytes FROM old_rows GROUP BY organization_id LOOP
+            PERFORM documents.add_document_counters(r.organization_id, 0, 0, 0, 0, 0, -r.bytes, 0);
+        END LOOP;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER document_counters_version_insert
+    AFTER INSERT ON documents.document_versions
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION documents.count_document_storage();
+
+CREATE TRIGGER document_counters_version_delete
+    AFTER DELETE ON documents.document_versions
+    REFERENCING OLD TABLE AS old_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION documents.count_document_storage();
+
+-- Chunks are the embedded pieces of the organization's documents
+CREATE OR REPLACE FUNCTION documents.count_document_chunks()
+RETURNS TRIGGER AS $$
+DECLARE
+    r RECORD;
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        FOR r IN SELECT organization_id, COUNT(*) AS chunks FROM new_rows GROUP BY organization_id LOOP
+            PERFORM documents.add_document_counters(r.organization_id, 0, 0, 0, 0, 0, 0, r.chunks);
+        END LOOP;
+    ELSE
+        FOR r IN SELECT organization_id, COUNT(*) AS chunks FROM old_rows GROUP BY organization_id LOOP
+            PERFORM documents.add_document_counters(r.organization_id, 0, 0, 0, 0, 0, 0, -r.chunks);
+        END LOOP;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER document_counters_chunk_insert
+    AFTER INSERT ON cognitive.document_embeddings
+    REFERENCING NEW TABLE AS new_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION documents.count_document_chunks();
+
+CREATE TRIGGER document_counters_chunk_delete
+    AFTER DELETE ON cognitive.document_embeddings
+    REFERENCING OLD TABLE AS old_rows
+    FOR EACH STATEMENT
+    EXECUTE FUNCTION documents.count_document_chunks();
+
+-- Recounts one organization's counters from the counted tables and reports
+-- whether they had drifted. The counters row is locked first, and each
+-- statement of a function sees rows committed before it started, so the
+-- count includes every write whose trigger ran before the lock and none
+-- whose trigger runs after it.
+CREATE OR REPLACE FUNCTION documents.reconcile_document_counters(org INTEGER)
+RETURNS BOOLEAN AS $$
+DECLARE
+    drifted BOOLEAN;
+BEGIN
+    PERFORM documents.add_document_counters(org, 0, 0, 0, 0, 0, 0, 0);
+    PERFORM 1 FROM documents.document_counters WHERE organization_id = org FOR UPDATE;
+
+    WITH actual AS (
+        SELECT
+            (SELECT COUNT(*) FROM documents.documents WHERE organization_id = org) AS document_count,
+            (SELECT COUNT(*) FROM documents.documents WHERE organization_id = org AND status = 'pending') AS pending_count,
+            (SELECT COUNT(*) FROM documents.documents WHERE organization_id = org AND status = 'processing') AS processing_count,
+            (SELECT COUNT(*) FROM documents.documents WHERE organization_id = org AND status = 'processed') AS processed_count,
+            (SELECT COUNT(*) FROM documents.documents WHERE organization_id = org AND status = 'failed') AS failed_count,
+            (SELECT COALESCE(SUM(file_size), 0) FROM documents.document_versions WHERE organization_id = org)::BIGINT AS storage_bytes,
+            (SELECT COUNT(*) FROM cognitive.document_embeddings WHERE organization_id = org) AS chunk_count
+    ), previous AS (
+        SELECT * FROM documents.document_counters WHERE organization_id = org
+    )
+    UPDATE documents.document_counters c SET
+        document_count = a.document_count,
+        pending_count = a.pending_count,
+        processing_count = a.processing_count,
+        processed_count = a.processed_count,
+        failed_count = a.failed_count,
+        storage_bytes = a.storage_bytes,
+        chunk_count = a.chunk_count,
+        reconciled_at = NOW(),
+        updated_at = NOW()
+    FROM actual a, previous p
+    WHERE c.organization_id = org
+    RETURNING (p.document_count, p.pending_count, p.processing_count, p.processed_count,
+               p.failed_count, p.storage_bytes, p.chunk_count)
+        IS DISTINCT FROM
+              (a.document_count, a.pending_count, a.processing_count, a.processed_count,
+               a.failed_count, a.storage_bytes, a.chunk_count)
+    INTO drifted;
+
+    RETURN COALESCE(drifted, FALSE);
+END;
+$$ LANGUAGE plpgsql;
+
+-- Existing organizations start from their current counts
+INSERT INTO documents.document_counters (organization_id)
+SELECT id FROM organizations.organizations;
+
+SELECT documents.reconcile_document_counters(id) FROM organizations.organizations;
+
+COMMENT ON TABLE documents.document_counters IS 'Per-organization document, storage and chunk counters maintained by triggers and reconciled by a scheduled job';
+COMMENT ON COLUMN documents.document_counters.storage_bytes IS 'Total file size of all stored document versions';
+COMMENT ON COLUMN documents.document_counters.chunk_count IS 'Embedded document chunks (rows of cognitive.document_embeddings)';
+COMMENT ON COLUMN documents.document_counters.reconciled_at IS 'When the counters were last recounted from the counted tables, NULL if never';
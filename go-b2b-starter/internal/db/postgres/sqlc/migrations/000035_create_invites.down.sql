@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_invites_updated_at ON organizations.invites;
+DROP INDEX IF EXISTS organizations.idx_invites_organization;
+DROP INDEX IF EXISTS organizations.idx_invites_token;
+DROP TABLE IF EXISTS organizations.invites;
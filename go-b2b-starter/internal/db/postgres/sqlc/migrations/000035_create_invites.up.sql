@@ -0,0 +1,43 @@
+-- Invitations to join an organization, created by its admins
+-- An invite can be pinned to one email address; otherwise anyone holding the
+-- link can accept it once. With AUTH_INVITE_ONLY=true accepting an invite is
+-- the only way to register.
+CREATE TABLE organizations.invites (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+
+    -- NULL lets whoever holds the link accept the invite
+    email VARCHAR(255),
+    role_slug VARCHAR(100) DEFAULT 'member' NOT NULL,
+    status VARCHAR(20) DEFAULT 'pending' NOT NULL,
+
+    -- SHA-256 of the invite token
+    token_hash VARCHAR(64) NOT NULL,
+
+    -- Lifecycle
+    expires_at TIMESTAMP NOT NULL,
+    accepted_at TIMESTAMP,
+    accepted_email VARCHAR(255),
+    revoked_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_invites_status CHECK (status IN ('pending', 'accepted', 'revoked'))
+);
+
+CREATE UNIQUE INDEX idx_invites_token ON organizations.invites(token_hash);
+CREATE INDEX idx_invites_organization ON organizations.invites(organization_id, created_at DESC);
+
+CREATE TRIGGER trigger_invites_updated_at
+    BEFORE UPDATE ON organizations.invites
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE organizations.invites IS 'Single-use invitations to register as a member of an organization';
+COMMENT ON COLUMN organizations.invites.email IS 'Address the invite is pinned to; NULL lets whoever holds the link accept it';
+COMMENT ON COLUMN organizations.invites.role_slug IS 'Role the invited member is given';
+COMMENT ON COLUMN organizations.invites.token_hash IS 'SHA-256 of the invite token';
+COMMENT ON COLUMN organizations.invites.expires_at IS 'Latest time the invite can be accepted';
+COMMENT ON COLUMN organizations.invites.accepted_email IS 'Address of the member who accepted the invite';
@@ -0,0 +1,11 @@
+DROP TRIGGER IF EXISTS trigger_oidc_consents_updated_at ON organizations.oidc_consents;
+DROP INDEX IF EXISTS organizations.idx_oidc_consents_account;
+DROP TABLE IF EXISTS organizations.oidc_consents;
+
+DROP INDEX IF EXISTS organizations.idx_oidc_authorization_codes_code;
+DROP TABLE IF EXISTS organizations.oidc_authorization_codes;
+
+DROP TRIGGER IF EXISTS trigger_oidc_clients_updated_at ON organizations.oidc_clients;
+DROP INDEX IF EXISTS organizations.idx_oidc_clients_organization;
+DROP INDEX IF EXISTS organizations.idx_oidc_clients_client_id;
+DROP TABLE IF EXISTS organizations.oidc_clients;
@@ -0,0 +1,89 @@
+-- OpenID Connect provider mode: internal tools sign members in with the
+-- authorization code flow and receive an id_token issued by this API.
+-- Relying parties are registered per organization and only its members can
+-- sign in to them.
+CREATE TABLE organizations.oidc_clients (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    client_id VARCHAR(64) NOT NULL,
+    -- SHA-256 of the client secret, which is only shown once
+    secret_hash VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    -- Exact redirect URIs the client may receive codes at
+    redirect_uris TEXT[] DEFAULT '{}' NOT NULL,
+
+    -- Lifecycle
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    revoked_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_oidc_clients_client_id ON organizations.oidc_clients(client_id);
+CREATE INDEX idx_oidc_clients_organization ON organizations.oidc_clients(organization_id, created_at DESC);
+
+CREATE TRIGGER trigger_oidc_clients_updated_at
+    BEFORE UPDATE ON organizations.oidc_clients
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Single-use authorization codes handed to a client's redirect URI
+CREATE TABLE organizations.oidc_authorization_codes (
+    id SERIAL PRIMARY KEY,
+    oidc_client_id INTEGER NOT NULL REFERENCES organizations.oidc_clients(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    -- SHA-256 of the code
+    code_hash VARCHAR(64) NOT NULL,
+    -- Auth provider member ID, used as the id_token subject
+    subject VARCHAR(255) NOT NULL,
+    email_verified BOOLEAN DEFAULT FALSE NOT NULL,
+    redirect_uri TEXT NOT NULL,
+    scopes TEXT[] DEFAULT '{}' NOT NULL,
+    nonce VARCHAR(255),
+    -- PKCE (RFC 7636); only S256 is accepted
+    code_challenge VARCHAR(128),
+    auth_time TIMESTAMP,
+
+    -- Lifecycle
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_oidc_authorization_codes_code ON organizations.oidc_authorization_codes(code_hash);
+
+-- Scopes each member approved for a client, so the consent screen is skipped next time
+CREATE TABLE organizations.oidc_consents (
+    id SERIAL PRIMARY KEY,
+    oidc_client_id INTEGER NOT NULL REFERENCES organizations.oidc_clients(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+    scopes TEXT[] DEFAULT '{}' NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT uq_oidc_consents_client_account UNIQUE (oidc_client_id, account_id)
+);
+
+CREATE INDEX idx_oidc_consents_account ON organizations.oidc_consents(account_id);
+
+CREATE TRIGGER trigger_oidc_consents_updated_at
+    BEFORE UPDATE ON organizations.oidc_consents
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE organizations.oidc_clients IS 'Relying parties that sign members in with OpenID Connect';
+COMMENT ON COLUMN organizations.oidc_clients.secret_hash IS 'SHA-256 of the client secret';
+COMMENT ON COLUMN organizations.oidc_clients.redirect_uris IS 'Exact redirect URIs the client may receive codes at';
+COMMENT ON TABLE organizations.oidc_authorization_codes IS 'Single-use OpenID Connect authorization codes';
+COMMENT ON COLUMN organizations.oidc_authorization_codes.code_hash IS 'SHA-256 of the authorization code';
+COMMENT ON COLUMN organizations.oidc_authorization_codes.subject IS 'Auth provider member ID used as the id_token subject';
+COMMENT ON COLUMN organizations.oidc_authorization_codes.code_challenge IS 'PKCE S256 code challenge';
+COMMENT ON TABLE organizations.oidc_consents IS 'Scopes a member approved for an OpenID Connect client';
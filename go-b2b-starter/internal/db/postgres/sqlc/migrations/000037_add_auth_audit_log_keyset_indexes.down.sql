@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_auth_audit_log_organization ON organizations.auth_audit_log(organization_id, occurred_at DESC);
+DROP INDEX IF EXISTS organizations.idx_auth_audit_log_organization_user_keyset;
+DROP INDEX IF EXISTS organizations.idx_auth_audit_log_organization_keyset;
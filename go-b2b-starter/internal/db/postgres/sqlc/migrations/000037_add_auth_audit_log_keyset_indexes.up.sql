@@ -0,0 +1,10 @@
+-- Keyset pagination of the auth audit log walks (occurred_at, id) newest first
+-- within an organization, optionally narrowed to one user. id breaks ties
+-- between events published in the same instant, so it is part of the key.
+CREATE INDEX idx_auth_audit_log_organization_keyset
+    ON organizations.auth_audit_log(organization_id, occurred_at DESC, id DESC);
+CREATE INDEX idx_auth_audit_log_organization_user_keyset
+    ON organizations.auth_audit_log(organization_id, user_id, occurred_at DESC, id DESC);
+
+-- Superseded by idx_auth_audit_log_organization_keyset
+DROP INDEX IF EXISTS organizations.idx_auth_audit_log_organization;
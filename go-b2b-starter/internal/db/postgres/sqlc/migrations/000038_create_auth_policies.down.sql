@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS trigger_auth_policies_updated_at ON organizations.auth_policies;
+DROP TABLE IF EXISTS organizations.auth_policies;
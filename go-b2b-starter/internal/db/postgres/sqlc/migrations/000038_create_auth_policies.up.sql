@@ -0,0 +1,29 @@
+-- Per-organization auth policy overrides, enforced by the auth middleware on
+-- top of the auth provider's own settings. Organizations without a row use
+-- the provider defaults.
+CREATE TABLE organizations.auth_policies (
+    organization_id INTEGER PRIMARY KEY REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    mfa_required BOOLEAN DEFAULT FALSE NOT NULL,
+    -- 0 leaves session lifetime to the auth provider
+    session_max_age_seconds INTEGER DEFAULT 0 NOT NULL,
+    -- Empty allows every sign-in method
+    allowed_auth_methods TEXT[] DEFAULT '{}' NOT NULL,
+
+    updated_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_auth_policies_session_max_age CHECK (session_max_age_seconds >= 0)
+);
+
+CREATE TRIGGER trigger_auth_policies_updated_at
+    BEFORE UPDATE ON organizations.auth_policies
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE organizations.auth_policies IS 'Organization auth policy overrides enforced by the auth middleware';
+COMMENT ON COLUMN organizations.auth_policies.mfa_required IS 'Reject sessions that were not authenticated with a second factor';
+COMMENT ON COLUMN organizations.auth_policies.session_max_age_seconds IS 'How long after signing in a session may be used; 0 leaves it to the auth provider';
+COMMENT ON COLUMN organizations.auth_policies.allowed_auth_methods IS 'Sign-in methods members may use; empty allows all';
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trigger_onboarding_steps_updated_at ON onboarding.organization_steps;
+DROP TABLE IF EXISTS onboarding.organization_steps;
+DROP SCHEMA IF EXISTS onboarding;
@@ -0,0 +1,27 @@
+CREATE SCHEMA IF NOT EXISTS onboarding;
+
+-- Onboarding checklist state per organization. Steps without a row are pending;
+-- the steps themselves are defined in code by the onboarding module.
+CREATE TABLE onboarding.organization_steps (
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    step_key VARCHAR(100) NOT NULL,
+    -- completed or skipped
+    status VARCHAR(20) NOT NULL,
+    -- Member who completed or skipped the step; NULL when detected automatically
+    account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (organization_id, step_key),
+    CONSTRAINT check_onboarding_steps_status CHECK (status IN ('completed', 'skipped'))
+);
+
+CREATE TRIGGER trigger_onboarding_steps_updated_at
+    BEFORE UPDATE ON onboarding.organization_steps
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE onboarding.organization_steps IS 'Completed and skipped onboarding steps per organization';
+COMMENT ON COLUMN onboarding.organization_steps.status IS 'completed or skipped';
+COMMENT ON COLUMN onboarding.organization_steps.account_id IS 'Member who completed or skipped the step; NULL when detected automatically';
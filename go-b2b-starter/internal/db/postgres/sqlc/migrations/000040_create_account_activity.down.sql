@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS organizations.idx_accounts_dormant;
+
+UPDATE organizations.organizations SET status = 'active' WHERE status = 'dormant';
+UPDATE organizations.accounts SET status = 'active' WHERE status = 'dormant';
+
+ALTER TABLE organizations.organizations
+    DROP CONSTRAINT chk_organizations_status,
+    ADD CONSTRAINT chk_organizations_status CHECK (status IN ('active', 'suspended', 'cancelled'));
+
+ALTER TABLE organizations.accounts
+    DROP CONSTRAINT chk_accounts_status,
+    ADD CONSTRAINT chk_accounts_status CHECK (status IN ('active', 'inactive', 'suspended'));
+
+DROP TABLE IF EXISTS organizations.account_activity;
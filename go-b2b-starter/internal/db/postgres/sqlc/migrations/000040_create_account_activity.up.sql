@@ -0,0 +1,24 @@
+-- Last time each account used the API, recorded by the auth middleware at most
+-- once per DORMANCY_ACTIVITY_INTERVAL. Accounts and organizations without
+-- activity for DORMANCY_AFTER are moved to the dormant status, and back to
+-- active on their next request.
+CREATE TABLE organizations.account_activity (
+    account_id INTEGER PRIMARY KEY REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    last_active_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_account_activity_organization ON organizations.account_activity(organization_id, last_active_at DESC);
+
+COMMENT ON TABLE organizations.account_activity IS 'Last API activity of each account, used for dormancy detection';
+COMMENT ON COLUMN organizations.account_activity.last_active_at IS 'Last authenticated request of the account, accurate to DORMANCY_ACTIVITY_INTERVAL';
+
+ALTER TABLE organizations.accounts
+    DROP CONSTRAINT chk_accounts_status,
+    ADD CONSTRAINT chk_accounts_status CHECK (status IN ('active', 'inactive', 'suspended', 'dormant'));
+
+ALTER TABLE organizations.organizations
+    DROP CONSTRAINT chk_organizations_status,
+    ADD CONSTRAINT chk_organizations_status CHECK (status IN ('active', 'suspended', 'cancelled', 'dormant'));
+
+CREATE INDEX idx_accounts_dormant ON organizations.accounts(organization_id) WHERE status = 'dormant';
@@ -0,0 +1,16 @@
+DELETE FROM organizations.auth_audit_log WHERE event_type = 'auth.canary_triggered';
+
+ALTER TABLE organizations.auth_audit_log
+    DROP CONSTRAINT check_auth_audit_log_event_type,
+    ADD CONSTRAINT check_auth_audit_log_event_type CHECK (event_type IN (
+        'auth.login_succeeded',
+        'auth.login_failed',
+        'auth.lockout',
+        'auth.token_refreshed',
+        'auth.logout',
+        'auth.password_changed'
+    ));
+
+DROP INDEX IF EXISTS organizations.idx_canary_credentials_organization;
+DROP INDEX IF EXISTS organizations.idx_canary_credentials_identifier;
+DROP TABLE IF EXISTS organizations.canary_credentials;
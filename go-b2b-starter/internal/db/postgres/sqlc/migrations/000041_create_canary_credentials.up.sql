@@ -0,0 +1,47 @@
+-- Canary credentials (honeytokens): decoy API keys and users planted where a
+-- leak would expose them (repositories, CI variables, password managers). They
+-- are never used legitimately, so any use raises a high-severity alert.
+CREATE TABLE organizations.canary_credentials (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    -- 'api_key' canaries look like OAuth client credentials; 'user' canaries are an email
+    kind VARCHAR(20) NOT NULL,
+    -- Client ID or lowercased email the canary is detected by
+    identifier VARCHAR(255) NOT NULL,
+    -- Where the canary was planted
+    name VARCHAR(255) NOT NULL,
+    -- Revoke the organization's OAuth clients when the canary is used
+    rotate_on_trigger BOOLEAN DEFAULT false NOT NULL,
+
+    -- Lifecycle
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    trigger_count INTEGER DEFAULT 0 NOT NULL,
+    last_triggered_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_canary_credentials_kind CHECK (kind IN ('api_key', 'user'))
+);
+
+CREATE UNIQUE INDEX idx_canary_credentials_identifier ON organizations.canary_credentials(identifier);
+CREATE INDEX idx_canary_credentials_organization ON organizations.canary_credentials(organization_id, created_at DESC);
+
+COMMENT ON TABLE organizations.canary_credentials IS 'Decoy API keys and users whose use signals a credential leak';
+COMMENT ON COLUMN organizations.canary_credentials.identifier IS 'Client ID (api_key) or lowercased email (user) the canary is detected by';
+COMMENT ON COLUMN organizations.canary_credentials.name IS 'Where the canary was planted';
+COMMENT ON COLUMN organizations.canary_credentials.rotate_on_trigger IS 'Revoke the organization''s OAuth clients when the canary is used';
+
+-- Canary uses are recorded in the auth audit log of the canary's organization
+ALTER TABLE organizations.auth_audit_log
+    DROP CONSTRAINT check_auth_audit_log_event_type,
+    ADD CONSTRAINT check_auth_audit_log_event_type CHECK (event_type IN (
+        'auth.login_succeeded',
+        'auth.login_failed',
+        'auth.lockout',
+        'auth.token_refreshed',
+        'auth.logout',
+        'auth.password_changed',
+        'auth.canary_triggered'
+    ));
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS organizations.idx_debug_capture_records_expires_at;
+DROP INDEX IF EXISTS organizations.idx_debug_capture_records_organization;
+DROP INDEX IF EXISTS organizations.idx_debug_capture_records_capture;
+DROP TABLE IF EXISTS organizations.debug_capture_records;
+
+DROP INDEX IF EXISTS organizations.idx_debug_captures_active;
+DROP INDEX IF EXISTS organizations.idx_debug_captures_organization;
+DROP TABLE IF EXISTS organizations.debug_captures;
@@ -0,0 +1,67 @@
+-- Debug captures: time-boxed recording of an organization's API traffic that
+-- operators turn on to reproduce issues a customer reports. Bodies are
+-- redacted before they are stored and records expire on their own.
+CREATE TABLE organizations.debug_captures (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    -- Why the capture was started (support ticket, incident)
+    reason TEXT NOT NULL,
+    -- Operator who started the capture
+    started_by VARCHAR(255) NOT NULL,
+    -- Field names redacted in addition to DEBUG_CAPTURE_REDACT_FIELDS
+    redact_fields TEXT[] DEFAULT '{}' NOT NULL,
+
+    -- Limits
+    max_records INTEGER NOT NULL,
+    record_count INTEGER DEFAULT 0 NOT NULL,
+
+    -- Lifecycle: recording stops at expires_at or when stopped early
+    expires_at TIMESTAMP NOT NULL,
+    stopped_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_debug_captures_record_count CHECK (record_count <= max_records)
+);
+
+CREATE INDEX idx_debug_captures_organization ON organizations.debug_captures(organization_id, created_at DESC);
+CREATE INDEX idx_debug_captures_active ON organizations.debug_captures(organization_id, expires_at)
+    WHERE stopped_at IS NULL;
+
+COMMENT ON TABLE organizations.debug_captures IS 'Time-boxed recordings of an organization''s API traffic for debugging';
+COMMENT ON COLUMN organizations.debug_captures.redact_fields IS 'Field names redacted in addition to DEBUG_CAPTURE_REDACT_FIELDS';
+
+CREATE TABLE organizations.debug_capture_records (
+    id BIGSERIAL PRIMARY KEY,
+    capture_id INTEGER NOT NULL REFERENCES organizations.debug_captures(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER,
+
+    -- Request
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    route TEXT NOT NULL,
+    query TEXT NOT NULL,
+    client_ip VARCHAR(64) NOT NULL,
+    request_headers JSONB NOT NULL,
+    request_body TEXT NOT NULL,
+
+    -- Response
+    status INTEGER NOT NULL,
+    duration_ms INTEGER NOT NULL,
+    response_headers JSONB NOT NULL,
+    response_body TEXT NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    expires_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_debug_capture_records_capture ON organizations.debug_capture_records(capture_id, id);
+CREATE INDEX idx_debug_capture_records_organization ON organizations.debug_capture_records(organization_id);
+CREATE INDEX idx_debug_capture_records_expires_at ON organizations.debug_capture_records(expires_at);
+
+COMMENT ON TABLE organizations.debug_capture_records IS 'Redacted request/response pairs recorded by a debug capture';
+COMMENT ON COLUMN organizations.debug_capture_records.expires_at IS 'The record is deleted after this time (DEBUG_CAPTURE_RETENTION)';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS portability.idx_organization_imports_running;
+DROP INDEX IF EXISTS portability.idx_organization_imports_created;
+DROP INDEX IF EXISTS portability.idx_organization_imports_organization;
+DROP TABLE IF EXISTS portability.organization_imports;
+DROP SCHEMA IF EXISTS portability;
@@ -0,0 +1,49 @@
+CREATE SCHEMA IF NOT EXISTS portability;
+
+-- Imports of organization bundles exported from another instance of the
+-- starter (users, settings and a documents manifest). Each row tracks one
+-- import into an existing organization and its progress.
+CREATE TABLE portability.organization_imports (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    -- Slug of the organization the bundle was exported from
+    source_organization VARCHAR(255) NOT NULL,
+    -- skip, overwrite or fail: what happens to items that already exist
+    strategy VARCHAR(20) NOT NULL,
+    -- Operator or ticket reference that requested the import
+    requested_by VARCHAR(255) NOT NULL,
+    -- running, completed or failed
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+
+    -- Progress
+    total_items INTEGER NOT NULL,
+    processed_items INTEGER DEFAULT 0 NOT NULL,
+    -- Per-section counts and item failures
+    result JSONB DEFAULT '{}' NOT NULL,
+    -- Why a failed import stopped
+    error TEXT,
+
+    -- Timestamps
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    -- Bumped on every progress update; a running import that stops updating was interrupted
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT check_organization_imports_strategy CHECK (strategy IN ('skip', 'overwrite', 'fail')),
+    CONSTRAINT check_organization_imports_status CHECK (status IN ('running', 'completed', 'failed')),
+    CONSTRAINT check_organization_imports_progress CHECK (processed_items <= total_items)
+);
+
+CREATE INDEX idx_organization_imports_organization ON portability.organization_imports(organization_id, created_at DESC);
+CREATE INDEX idx_organization_imports_created ON portability.organization_imports(created_at DESC);
+-- One import at a time per organization
+CREATE UNIQUE INDEX idx_organization_imports_running ON portability.organization_imports(organization_id)
+    WHERE status = 'running';
+
+COMMENT ON TABLE portability.organization_imports IS 'Imports of organization bundles exported from another instance';
+COMMENT ON COLUMN portability.organization_imports.source_organization IS 'Slug of the organization the bundle was exported from';
+COMMENT ON COLUMN portability.organization_imports.strategy IS 'skip, overwrite or fail: what happens to items that already exist';
+COMMENT ON COLUMN portability.organization_imports.requested_by IS 'Operator or ticket reference that requested the import';
+COMMENT ON COLUMN portability.organization_imports.result IS 'Per-section counts and item failures';
@@ -0,0 +1,3 @@
+ALTER TABLE organizations.accounts
+    DROP CONSTRAINT IF EXISTS chk_accounts_metadata_object,
+    DROP COLUMN IF EXISTS metadata;
@@ -0,0 +1,7 @@
+-- Custom attributes applications attach to accounts without schema changes.
+-- Patches merge top-level keys; a null value removes the key.
+ALTER TABLE organizations.accounts
+    ADD COLUMN metadata JSONB NOT NULL DEFAULT '{}'::jsonb,
+    ADD CONSTRAINT chk_accounts_metadata_object CHECK (jsonb_typeof(metadata) = 'object');
+
+COMMENT ON COLUMN organizations.accounts.metadata IS 'Application-defined attributes of the account as a JSON object';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS organizations.idx_accounts_avatar_key;
+
+ALTER TABLE organizations.accounts
+    DROP COLUMN IF EXISTS avatar_url,
+    DROP COLUMN IF EXISTS avatar_key,
+    DROP COLUMN IF EXISTS avatar_file_id;
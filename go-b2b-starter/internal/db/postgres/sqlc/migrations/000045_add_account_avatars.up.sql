@@ -0,0 +1,13 @@
+-- Profile pictures of accounts. Uploaded avatars are stored by the files
+-- module and served publicly under their random key; avatar_url is what
+-- clients display.
+ALTER TABLE organizations.accounts
+    ADD COLUMN avatar_file_id INTEGER REFERENCES file_manager.file_assets(id) ON DELETE SET NULL,
+    ADD COLUMN avatar_key VARCHAR(64),
+    ADD COLUMN avatar_url TEXT;
+
+CREATE UNIQUE INDEX idx_accounts_avatar_key ON organizations.accounts(avatar_key) WHERE avatar_key IS NOT NULL;
+
+COMMENT ON COLUMN organizations.accounts.avatar_file_id IS 'Stored file of the uploaded avatar, resized to AVATAR_SIZE';
+COMMENT ON COLUMN organizations.accounts.avatar_key IS 'Random key the avatar is served under without authentication';
+COMMENT ON COLUMN organizations.accounts.avatar_url IS 'URL clients display as the account avatar';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS organizations.account_activity_timestamps;
+DROP TABLE IF EXISTS organizations.account_activity_feed;
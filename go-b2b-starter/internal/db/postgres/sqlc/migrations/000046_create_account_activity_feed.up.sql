@@ -0,0 +1,49 @@
+-- Rolling feed of what each account did (signed in, uploaded a document,
+-- changed a setting), recorded from the event bus for support and debugging.
+-- Each account keeps its newest ACTIVITY_FEED_MAX_PER_ACCOUNT activities, and
+-- rows older than ACTIVITY_FEED_RETENTION are purged.
+CREATE TABLE organizations.account_activity_feed (
+    id BIGSERIAL PRIMARY KEY,
+    -- Event the activity was recorded from, so a redelivered event is stored once
+    event_id VARCHAR(64) NOT NULL,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    activity_type VARCHAR(50) NOT NULL,
+    details JSONB NOT NULL DEFAULT '{}',
+
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT uq_account_activity_feed_event UNIQUE (event_id, account_id),
+    CONSTRAINT chk_account_activity_feed_type CHECK (activity_type IN (
+        'login',
+        'logout',
+        'password_changed',
+        'document_uploaded',
+        'settings_changed'
+    ))
+);
+
+CREATE INDEX idx_account_activity_feed_account ON organizations.account_activity_feed(account_id, occurred_at DESC, id DESC);
+CREATE INDEX idx_account_activity_feed_occurred ON organizations.account_activity_feed(occurred_at);
+
+COMMENT ON TABLE organizations.account_activity_feed IS 'Recent activities of each account, recorded from the event bus';
+COMMENT ON COLUMN organizations.account_activity_feed.details IS 'What the activity was about, e.g. the document or setting';
+COMMENT ON COLUMN organizations.account_activity_feed.occurred_at IS 'When the activity happened, as published';
+
+-- Latest time and number of occurrences of each kind of activity per account.
+-- Unlike the feed it is not trimmed, so it answers "when did this member last
+-- upload a document" long after the activity left the feed.
+CREATE TABLE organizations.account_activity_timestamps (
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    activity_type VARCHAR(50) NOT NULL,
+    last_occurred_at TIMESTAMP NOT NULL,
+    occurrences INTEGER DEFAULT 0 NOT NULL,
+
+    PRIMARY KEY (account_id, activity_type)
+);
+
+COMMENT ON TABLE organizations.account_activity_timestamps IS 'Latest time of each kind of activity per account';
+COMMENT ON COLUMN organizations.account_activity_timestamps.occurrences IS 'Activities of this kind recorded since the account was created';
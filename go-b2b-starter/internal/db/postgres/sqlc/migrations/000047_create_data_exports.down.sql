@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS compliance.idx_data_exports_running;
+DROP INDEX IF EXISTS compliance.idx_data_exports_token;
+DROP INDEX IF EXISTS compliance.idx_data_exports_expiry;
+DROP INDEX IF EXISTS compliance.idx_data_exports_account;
+DROP TABLE IF EXISTS compliance.data_exports;
+
+DELETE FROM file_manager.file_contexts WHERE name = 'data_export';
+
+DROP INDEX IF EXISTS documents.idx_document_versions_uploaded_by;
+DROP INDEX IF EXISTS documents.idx_documents_uploaded_by;
+
+ALTER TABLE documents.document_versions DROP COLUMN IF EXISTS uploaded_by;
+ALTER TABLE documents.documents DROP COLUMN IF EXISTS uploaded_by;
@@ -0,0 +1,63 @@
+-- The member who uploaded each document and version, so a member's data
+-- export can include what they uploaded. Documents and versions created
+-- before this column, or by an import, have no uploader.
+ALTER TABLE documents.documents
+    ADD COLUMN uploaded_by INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL;
+ALTER TABLE documents.document_versions
+    ADD COLUMN uploaded_by INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_documents_uploaded_by ON documents.documents(uploaded_by) WHERE uploaded_by IS NOT NULL;
+CREATE INDEX idx_document_versions_uploaded_by ON documents.document_versions(uploaded_by) WHERE uploaded_by IS NOT NULL;
+
+COMMENT ON COLUMN documents.documents.uploaded_by IS 'Member who uploaded the document, NULL for imported documents or when the account was deleted';
+COMMENT ON COLUMN documents.document_versions.uploaded_by IS 'Member who uploaded the version, NULL for imported versions or when the account was deleted';
+
+-- Export archives are stored by the files module
+INSERT INTO file_manager.file_contexts (id, name) VALUES (8, 'data_export')
+ON CONFLICT DO NOTHING;
+
+-- "Download my data" requests of members. A background job builds a ZIP
+-- archive of the member's personal data and emails a download link; the
+-- archive is deleted when the link expires.
+CREATE TABLE compliance.data_exports (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    -- running, completed, failed or expired
+    status VARCHAR(20) NOT NULL DEFAULT 'running',
+    -- The archive, set once completed and cleared when it expires
+    file_asset_id INTEGER REFERENCES file_manager.file_assets(id) ON DELETE SET NULL,
+    file_size BIGINT,
+    -- SHA-256 of the token in the emailed download link
+    download_token_hash VARCHAR(64),
+    download_count INTEGER DEFAULT 0 NOT NULL,
+    -- Per-section counts and the files left out of the archive
+    summary JSONB DEFAULT '{}' NOT NULL,
+    -- Why a failed export stopped
+    error TEXT,
+
+    -- Timestamps
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP,
+    -- When the download link stops working and the archive is deleted
+    expires_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT check_data_exports_status CHECK (status IN ('running', 'completed', 'failed', 'expired'))
+);
+
+CREATE INDEX idx_data_exports_account ON compliance.data_exports(account_id, created_at DESC);
+CREATE INDEX idx_data_exports_expiry ON compliance.data_exports(expires_at) WHERE status = 'completed';
+CREATE UNIQUE INDEX idx_data_exports_token ON compliance.data_exports(download_token_hash)
+    WHERE download_token_hash IS NOT NULL;
+-- One export at a time per account
+CREATE UNIQUE INDEX idx_data_exports_running ON compliance.data_exports(account_id)
+    WHERE status = 'running';
+
+COMMENT ON TABLE compliance.data_exports IS 'Archives of a member''s personal data requested through "download my data"';
+COMMENT ON COLUMN compliance.data_exports.status IS 'running, completed, failed or expired';
+COMMENT ON COLUMN compliance.data_exports.file_asset_id IS 'The archive, set once completed and cleared when it expires';
+COMMENT ON COLUMN compliance.data_exports.download_token_hash IS 'SHA-256 of the token in the emailed download link';
+COMMENT ON COLUMN compliance.data_exports.summary IS 'Per-section counts and the files left out of the archive';
+COMMENT ON COLUMN compliance.data_exports.expires_at IS 'When the download link stops working and the archive is deleted';
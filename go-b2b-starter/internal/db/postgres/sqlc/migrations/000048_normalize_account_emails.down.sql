@@ -0,0 +1,5 @@
+COMMENT ON COLUMN organizations.accounts.email IS NULL;
+
+DROP INDEX IF EXISTS organizations.idx_accounts_org_email_lower;
+
+ALTER TABLE organizations.accounts ADD CONSTRAINT accounts_organization_id_email_key UNIQUE (organization_id, email);
@@ -0,0 +1,36 @@
+-- Account emails are compared case-insensitively: the repository stores them
+-- trimmed and lowercased, and uniqueness per organization is enforced on
+-- lower(email) so "Foo@x.com" and "foo@x.com" cannot both register even when
+-- a row is written outside the repository.
+
+-- Accounts that only differ by case or surrounding whitespace cannot be
+-- merged automatically. Stop with the offending rows so they can be resolved
+-- by hand (deactivate or delete one of each pair) before migrating again.
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(format('organization %s: %s', organization_id, emails), '; ')
+    INTO duplicates
+    FROM (
+        SELECT organization_id, string_agg(email, ', ' ORDER BY id) AS emails
+        FROM organizations.accounts
+        GROUP BY organization_id, lower(btrim(email))
+        HAVING COUNT(*) > 1
+    ) d;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'accounts with case-insensitive duplicate emails must be resolved first: %', duplicates;
+    END IF;
+END $$;
+
+UPDATE organizations.accounts
+SET email = lower(btrim(email)),
+    updated_at = CURRENT_TIMESTAMP
+WHERE email <> lower(btrim(email));
+
+ALTER TABLE organizations.accounts DROP CONSTRAINT IF EXISTS accounts_organization_id_email_key;
+
+CREATE UNIQUE INDEX idx_accounts_org_email_lower ON organizations.accounts(organization_id, lower(email));
+
+COMMENT ON COLUMN organizations.accounts.email IS 'Trimmed and lowercased; unique per organization regardless of case';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS organizations.idx_accounts_public_id;
+
+ALTER TABLE organizations.accounts DROP COLUMN IF EXISTS public_id;
+
+DROP FUNCTION IF EXISTS organizations.uuid_generate_v7();
@@ -0,0 +1,28 @@
+-- Accounts get a UUIDv7 public identifier for APIs and cross-region data.
+-- Sequential integer IDs reveal how many accounts exist and collide when
+-- databases of different regions are merged; they remain the primary and
+-- foreign keys inside the database.
+
+-- UUIDv7 (RFC 9562): a 48-bit Unix millisecond timestamp followed by random
+-- bits, so identifiers sort by creation time and index well. Postgres only
+-- ships a uuidv7() function from version 18.
+CREATE OR REPLACE FUNCTION organizations.uuid_generate_v7()
+RETURNS UUID AS $$
+    SELECT encode(
+        set_bit(
+            set_bit(
+                overlay(uuid_send(gen_random_uuid())
+                    PLACING substring(int8send(floor(extract(epoch FROM clock_timestamp()) * 1000)::BIGINT) FROM 3)
+                    FROM 1 FOR 6),
+                52, 1),
+            53, 1),
+        'hex')::UUID;
+$$ LANGUAGE SQL VOLATILE;
+
+-- Existing accounts are backfilled with identifiers of the migration time
+ALTER TABLE organizations.accounts
+    ADD COLUMN public_id UUID NOT NULL DEFAULT organizations.uuid_generate_v7();
+
+CREATE UNIQUE INDEX idx_accounts_public_id ON organizations.accounts(public_id);
+
+COMMENT ON COLUMN organizations.accounts.public_id IS 'UUIDv7 identifier exposed to clients instead of the sequential id';
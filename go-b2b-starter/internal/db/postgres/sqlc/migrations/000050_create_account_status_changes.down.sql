@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS organizations.idx_account_status_changes_account;
+DROP TABLE IF EXISTS organizations.account_status_changes;
@@ -0,0 +1,22 @@
+-- History of account suspensions and reactivations: who changed the status,
+-- from what, to what and why. The status itself stays on
+-- organizations.accounts; each change is written in the same statement as
+-- the status update.
+CREATE TABLE organizations.account_status_changes (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    -- Admin who made the change; kept as NULL once their account is deleted
+    actor_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_account_status_changes_account ON organizations.account_status_changes(account_id, created_at DESC, id DESC);
+
+COMMENT ON TABLE organizations.account_status_changes IS 'Suspensions and reactivations of accounts with their reason and actor';
+COMMENT ON COLUMN organizations.account_status_changes.reason IS 'Why the status was changed, shown to admins and emailed to the member';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS organizations.idx_api_usage_window_end;
+DROP TABLE IF EXISTS organizations.api_usage;
@@ -0,0 +1,20 @@
+-- Requests made by each account per quota window. Requests are counted in
+-- Redis on the hot path and the counts are flushed here periodically, so the
+-- current window may lag behind by up to API_USAGE_FLUSH_INTERVAL.
+CREATE TABLE organizations.api_usage (
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    window_start TIMESTAMP NOT NULL,
+    window_end TIMESTAMP NOT NULL,
+    request_count BIGINT NOT NULL DEFAULT 0,
+
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (account_id, window_start)
+);
+
+CREATE INDEX idx_api_usage_window_end ON organizations.api_usage(window_end);
+
+COMMENT ON TABLE organizations.api_usage IS 'API requests per account and quota window, flushed from Redis counters';
+COMMENT ON COLUMN organizations.api_usage.request_count IS 'Requests counted in the window as of the last flush';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS organizations.idx_notifications_account;
+DROP TABLE IF EXISTS organizations.notifications;
+DROP TABLE IF EXISTS organizations.notification_preferences;
@@ -0,0 +1,37 @@
+-- Which notification types an account wants on which channels. Only choices
+-- that differ from the default are stored: a missing row means the type is
+-- delivered on the channel.
+CREATE TABLE organizations.notification_preferences (
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    notification_type VARCHAR(100) NOT NULL,
+    channel VARCHAR(20) NOT NULL,
+    enabled BOOLEAN NOT NULL,
+
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (account_id, notification_type, channel)
+);
+
+COMMENT ON TABLE organizations.notification_preferences IS 'Per-account opt-ins and opt-outs of notification types by channel';
+COMMENT ON COLUMN organizations.notification_preferences.channel IS 'email or in_app';
+
+-- Notifications delivered on the in_app channel, shown in the member's inbox
+CREATE TABLE organizations.notifications (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    notification_type VARCHAR(100) NOT NULL,
+    title TEXT NOT NULL,
+    body TEXT NOT NULL DEFAULT '',
+    read_at TIMESTAMP,
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_notifications_account ON organizations.notifications(account_id, created_at DESC, id DESC);
+
+COMMENT ON TABLE organizations.notifications IS 'In-app notifications of accounts';
+COMMENT ON COLUMN organizations.notifications.read_at IS 'When the member marked the notification read, NULL while unread';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS organizations.idx_account_tags_tag;
+DROP TABLE IF EXISTS organizations.account_tags;
+DROP TABLE IF EXISTS organizations.tags;
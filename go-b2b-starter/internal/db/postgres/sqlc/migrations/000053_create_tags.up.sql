@@ -0,0 +1,36 @@
+-- Admin-defined tags (segments) such as "beta" or "vip" that organization
+-- admins attach to users for support and feature rollouts
+CREATE TABLE organizations.tags (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    name VARCHAR(50) NOT NULL,
+
+    -- Audit
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    UNIQUE(organization_id, name)
+);
+
+COMMENT ON TABLE organizations.tags IS 'Tags organization admins attach to users';
+COMMENT ON COLUMN organizations.tags.name IS 'Lowercase letters, digits, hyphens and underscores';
+COMMENT ON COLUMN organizations.tags.created_by_account_id IS 'Account that first used the tag';
+
+-- Which accounts carry which tags
+CREATE TABLE organizations.account_tags (
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES organizations.tags(id) ON DELETE CASCADE,
+
+    -- Audit
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    PRIMARY KEY (account_id, tag_id)
+);
+
+CREATE INDEX idx_account_tags_tag ON organizations.account_tags(tag_id);
+
+COMMENT ON TABLE organizations.account_tags IS 'Tags attached to accounts';
+COMMENT ON COLUMN organizations.account_tags.created_by_account_id IS 'Admin that attached the tag';
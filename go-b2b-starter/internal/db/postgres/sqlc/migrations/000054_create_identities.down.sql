@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS organizations.idx_identities_provider_user;
+DROP TABLE IF EXISTS organizations.identities;
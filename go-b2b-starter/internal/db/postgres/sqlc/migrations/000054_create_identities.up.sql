@@ -0,0 +1,23 @@
+-- Google and GitHub identities linked to existing accounts. Signing in with
+-- them stays with the auth provider's own OAuth flow
+CREATE TABLE organizations.identities (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    provider VARCHAR(20) NOT NULL,
+    provider_user_id VARCHAR(255) NOT NULL,
+    email VARCHAR(255),
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    UNIQUE(organization_id, provider, provider_user_id),
+    UNIQUE(account_id, provider)
+);
+
+CREATE INDEX idx_identities_provider_user ON organizations.identities(provider, provider_user_id);
+
+COMMENT ON TABLE organizations.identities IS 'Social identities linked to accounts';
+COMMENT ON COLUMN organizations.identities.provider IS 'google or github';
+COMMENT ON COLUMN organizations.identities.provider_user_id IS 'Stable user ID at the provider (Google sub, GitHub user ID)';
+COMMENT ON COLUMN organizations.identities.email IS 'Email the provider reported when the identity was linked; may differ from the account email';
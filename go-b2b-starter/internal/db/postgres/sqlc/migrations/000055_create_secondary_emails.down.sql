@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS organizations.idx_secondary_emails_verification_token;
+DROP INDEX IF EXISTS organizations.idx_secondary_emails_account;
+DROP TABLE IF EXISTS organizations.secondary_emails;
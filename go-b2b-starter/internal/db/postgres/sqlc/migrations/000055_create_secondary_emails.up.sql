@@ -0,0 +1,29 @@
+-- Additional addresses of an account besides its primary email. A verified
+-- secondary address can receive notifications and can be made the primary
+-- address, which moves the old primary address here.
+CREATE TABLE organizations.secondary_emails (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    email VARCHAR(255) NOT NULL,
+    verification_token_hash VARCHAR(64),
+    verification_expires_at TIMESTAMP,
+    verified_at TIMESTAMP,
+    notifications BOOLEAN NOT NULL DEFAULT FALSE,
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    UNIQUE(organization_id, email)
+);
+
+CREATE INDEX idx_secondary_emails_account ON organizations.secondary_emails(account_id);
+CREATE UNIQUE INDEX idx_secondary_emails_verification_token ON organizations.secondary_emails(verification_token_hash)
+    WHERE verification_token_hash IS NOT NULL;
+
+COMMENT ON TABLE organizations.secondary_emails IS 'Secondary email addresses of accounts; the primary address stays in accounts.email';
+COMMENT ON COLUMN organizations.secondary_emails.email IS 'Normalized address, unique within the organization';
+COMMENT ON COLUMN organizations.secondary_emails.verification_token_hash IS 'SHA-256 of the pending verification link token; NULL once verified';
+COMMENT ON COLUMN organizations.secondary_emails.verification_expires_at IS 'When the pending verification link stops working';
+COMMENT ON COLUMN organizations.secondary_emails.verified_at IS 'When the owner confirmed the address; unverified addresses cannot receive notifications';
+COMMENT ON COLUMN organizations.secondary_emails.notifications IS 'Whether email notifications are also sent to the address';
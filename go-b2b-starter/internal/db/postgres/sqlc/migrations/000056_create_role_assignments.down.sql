@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS rbac.idx_role_assignments_role;
+DROP INDEX IF EXISTS rbac.idx_role_assignments_email;
+DROP INDEX IF EXISTS rbac.idx_role_assignments_account;
+DROP TABLE IF EXISTS rbac.role_assignments;
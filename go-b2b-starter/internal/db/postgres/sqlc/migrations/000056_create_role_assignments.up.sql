@@ -0,0 +1,35 @@
+-- Roles assigned to users on top of the role the auth provider assigns.
+-- Assignments within an organization belong to an account; global
+-- assignments belong to an email and apply in every organization the user
+-- has an account in.
+CREATE TABLE rbac.role_assignments (
+    id SERIAL PRIMARY KEY,
+    role_id VARCHAR(50) NOT NULL REFERENCES rbac.roles(id) ON DELETE CASCADE,
+
+    -- Assignment within one organization
+    organization_id INTEGER REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    -- Global assignment
+    email VARCHAR(255),
+
+    assigned_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    CONSTRAINT chk_role_assignments_scope CHECK (
+        (organization_id IS NOT NULL AND account_id IS NOT NULL AND email IS NULL)
+        OR (organization_id IS NULL AND account_id IS NULL AND email IS NOT NULL)
+    )
+);
+
+CREATE UNIQUE INDEX idx_role_assignments_account ON rbac.role_assignments(organization_id, account_id, role_id)
+    WHERE organization_id IS NOT NULL;
+CREATE UNIQUE INDEX idx_role_assignments_email ON rbac.role_assignments(email, role_id)
+    WHERE organization_id IS NULL;
+CREATE INDEX idx_role_assignments_role ON rbac.role_assignments(role_id);
+
+COMMENT ON TABLE rbac.role_assignments IS 'Roles granted to users in one organization or globally, on top of the auth provider role';
+COMMENT ON COLUMN rbac.role_assignments.organization_id IS 'Organization of the assignment; NULL for global assignments';
+COMMENT ON COLUMN rbac.role_assignments.account_id IS 'Account holding the role; NULL for global assignments';
+COMMENT ON COLUMN rbac.role_assignments.email IS 'Normalized email of the user holding a global assignment';
+COMMENT ON COLUMN rbac.role_assignments.assigned_by_account_id IS 'Admin who made an organization assignment; NULL for operator assignments';
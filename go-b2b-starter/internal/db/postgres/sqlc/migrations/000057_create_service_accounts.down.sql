@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS organizations.idx_service_account_keys_service_account;
+DROP INDEX IF EXISTS organizations.idx_service_account_keys_hash;
+DROP TABLE IF EXISTS organizations.service_account_keys;
+
+DROP TRIGGER IF EXISTS trigger_service_accounts_updated_at ON organizations.service_accounts;
+DROP INDEX IF EXISTS organizations.idx_service_accounts_name;
+DROP INDEX IF EXISTS organizations.idx_service_accounts_account;
+DROP INDEX IF EXISTS organizations.idx_service_accounts_username;
+DROP TABLE IF EXISTS organizations.service_accounts;
@@ -0,0 +1,66 @@
+-- Service accounts: non-human users owned by an organization, for automations
+-- that should not share a member's account. A service account has no password
+-- and no auth provider member; it authenticates with API keys only. It acts
+-- through its own account in the organization, so its role, role assignments
+-- and account status apply like a member's.
+CREATE TABLE organizations.service_accounts (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    -- Account the service account acts as
+    account_id INTEGER NOT NULL REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+
+    username VARCHAR(64) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    description TEXT DEFAULT '' NOT NULL,
+    -- Member responsible for the automation
+    owner_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+
+    -- Lifecycle
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    disabled_at TIMESTAMP,
+
+    -- Timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_service_accounts_username ON organizations.service_accounts(username);
+CREATE UNIQUE INDEX idx_service_accounts_account ON organizations.service_accounts(account_id);
+CREATE UNIQUE INDEX idx_service_accounts_name ON organizations.service_accounts(organization_id, name);
+
+CREATE TRIGGER trigger_service_accounts_updated_at
+    BEFORE UPDATE ON organizations.service_accounts
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- API keys of service accounts. Keys are only shown once and stored hashed.
+CREATE TABLE organizations.service_account_keys (
+    id SERIAL PRIMARY KEY,
+    organization_id INTEGER NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    service_account_id INTEGER NOT NULL REFERENCES organizations.service_accounts(id) ON DELETE CASCADE,
+
+    name VARCHAR(255) NOT NULL,
+    key_prefix VARCHAR(16) NOT NULL,
+    key_hash VARCHAR(64) NOT NULL,
+    expires_at TIMESTAMP,
+
+    -- Lifecycle
+    created_by_account_id INTEGER REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMP,
+    revoked_at TIMESTAMP,
+
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_service_account_keys_hash ON organizations.service_account_keys(key_hash);
+CREATE INDEX idx_service_account_keys_service_account ON organizations.service_account_keys(service_account_id, created_at DESC);
+
+COMMENT ON TABLE organizations.service_accounts IS 'Non-human users of an organization that authenticate with API keys only';
+COMMENT ON COLUMN organizations.service_accounts.account_id IS 'Account the service account acts as';
+COMMENT ON COLUMN organizations.service_accounts.username IS 'Generated machine name, also the local part of the account email';
+COMMENT ON COLUMN organizations.service_accounts.owner_account_id IS 'Member responsible for the automation';
+COMMENT ON COLUMN organizations.service_accounts.disabled_at IS 'When the service account was disabled; its keys are rejected while set';
+COMMENT ON TABLE organizations.service_account_keys IS 'API keys service accounts authenticate with';
+COMMENT ON COLUMN organizations.service_account_keys.key_prefix IS 'First characters of the key, shown to tell keys apart';
+COMMENT ON COLUMN organizations.service_account_keys.key_hash IS 'SHA-256 of the key';
+COMMENT ON COLUMN organizations.service_account_keys.expires_at IS 'When the key stops working; NULL for keys that do not expire';
+COMMENT ON COLUMN organizations.service_account_keys.last_used_at IS 'Last request made with the key, recorded at most once a minute';
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS trigger_user_subscriptions_updated_at ON subscription_billing.user_subscriptions;
+DROP INDEX IF EXISTS subscription_billing.idx_user_subscriptions_external_customer_id;
+DROP INDEX IF EXISTS subscription_billing.idx_user_subscriptions_organization_id;
+DROP TABLE IF EXISTS subscription_billing.user_subscriptions;
@@ -0,0 +1,45 @@
+-- User-scoped subscriptions for B2C billing: an individual account pays for
+-- itself instead of its organization. The billing provider knows the account
+-- by external_customer_id ('user_' followed by the account's public_id), so
+-- webhooks and checkouts for users never resolve to an organization.
+CREATE TABLE subscription_billing.user_subscriptions (
+    id SERIAL PRIMARY KEY,
+    account_id INT NOT NULL UNIQUE REFERENCES organizations.accounts(id) ON DELETE CASCADE,
+    -- Organization of the account, so tenant purges remove the subscription
+    organization_id INT NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+
+    -- Provider identifiers
+    external_customer_id VARCHAR(100) NOT NULL,
+    subscription_id VARCHAR(100) NOT NULL UNIQUE,
+
+    -- Subscription details
+    subscription_status VARCHAR(50) NOT NULL,
+    product_id VARCHAR(100) NOT NULL,
+    product_name VARCHAR(255),
+    plan_name VARCHAR(100),
+
+    -- Billing period
+    current_period_start TIMESTAMP NOT NULL,
+    current_period_end TIMESTAMP NOT NULL,
+
+    -- Cancellation details
+    cancel_at_period_end BOOLEAN DEFAULT FALSE NOT NULL,
+    canceled_at TIMESTAMP,
+
+    metadata JSONB DEFAULT '{}'::jsonb NOT NULL,
+
+    -- Audit timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_user_subscriptions_organization_id ON subscription_billing.user_subscriptions(organization_id);
+CREATE INDEX idx_user_subscriptions_external_customer_id ON subscription_billing.user_subscriptions(external_customer_id);
+
+CREATE TRIGGER trigger_user_subscriptions_updated_at
+    BEFORE UPDATE ON subscription_billing.user_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE subscription_billing.user_subscriptions IS 'Subscriptions paid by individual accounts (BILLING_SCOPE=user), synced via webhooks';
+COMMENT ON COLUMN subscription_billing.user_subscriptions.external_customer_id IS 'Billing provider customer ID: user_ followed by the account public_id';
+COMMENT ON COLUMN subscription_billing.user_subscriptions.organization_id IS 'Organization of the account, so tenant purges remove the subscription';
@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS trigger_usage_rollups_updated_at ON subscription_billing.usage_rollups;
+DROP INDEX IF EXISTS subscription_billing.idx_usage_rollups_unreported;
+DROP TABLE IF EXISTS subscription_billing.usage_rollups;
+
+DROP INDEX IF EXISTS subscription_billing.idx_usage_events_organization_id;
+DROP INDEX IF EXISTS subscription_billing.idx_usage_events_occurred_at;
+DROP INDEX IF EXISTS subscription_billing.idx_usage_events_idempotency_key;
+DROP TABLE IF EXISTS subscription_billing.usage_events;
@@ -0,0 +1,58 @@
+-- Usage metering: raw usage events (LLM tokens, OCR pages, storage) and
+-- their hourly rollups per organization and metric. The rollup job
+-- recomputes recent buckets from the events and reports closed buckets to
+-- the billing provider for metered prices.
+CREATE TABLE subscription_billing.usage_events (
+    id BIGSERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    -- Account that caused the usage, if any; kept after the account is deleted
+    account_id INT REFERENCES organizations.accounts(id) ON DELETE SET NULL,
+    metric VARCHAR(50) NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity >= 0),
+    -- Feature that recorded the event, e.g. documents.ocr
+    source VARCHAR(100) DEFAULT '' NOT NULL,
+    -- Set by callers that may retry; a repeated key is ignored
+    idempotency_key VARCHAR(255),
+    occurred_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_usage_events_idempotency_key
+    ON subscription_billing.usage_events(organization_id, idempotency_key)
+    WHERE idempotency_key IS NOT NULL;
+CREATE INDEX idx_usage_events_occurred_at ON subscription_billing.usage_events(occurred_at);
+CREATE INDEX idx_usage_events_organization_id ON subscription_billing.usage_events(organization_id);
+
+CREATE TABLE subscription_billing.usage_rollups (
+    id SERIAL PRIMARY KEY,
+    organization_id INT NOT NULL REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    metric VARCHAR(50) NOT NULL,
+    period_start TIMESTAMP NOT NULL,
+    period_end TIMESTAMP NOT NULL,
+    -- Sum of the bucket's events, or their maximum for gauge metrics such as storage
+    quantity BIGINT DEFAULT 0 NOT NULL,
+    event_count INT DEFAULT 0 NOT NULL,
+
+    -- Quantity already reported to the billing provider
+    reported_quantity BIGINT DEFAULT 0 NOT NULL,
+    reported_at TIMESTAMP,
+
+    -- Audit timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+
+    UNIQUE (organization_id, metric, period_start)
+);
+
+CREATE INDEX idx_usage_rollups_unreported
+    ON subscription_billing.usage_rollups(id)
+    WHERE reported_at IS NULL OR reported_quantity <> quantity;
+
+CREATE TRIGGER trigger_usage_rollups_updated_at
+    BEFORE UPDATE ON subscription_billing.usage_rollups
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE subscription_billing.usage_events IS 'Metered usage recorded by features, rolled up hourly into usage_rollups';
+COMMENT ON TABLE subscription_billing.usage_rollups IS 'Hourly usage per organization and metric, reported to the billing provider once the hour closed';
+COMMENT ON COLUMN subscription_billing.usage_rollups.quantity IS 'Sum of the events in the hour, or their maximum for gauge metrics';
+COMMENT ON COLUMN subscription_billing.usage_rollups.reported_quantity IS 'Quantity already reported to the billing provider; the difference is reported next';
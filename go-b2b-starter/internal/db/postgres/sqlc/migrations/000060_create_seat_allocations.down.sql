@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS trigger_seat_allocations_updated_at ON subscription_billing.seat_allocations;
+DROP INDEX IF EXISTS subscription_billing.idx_seat_allocations_sync_due_at;
+DROP TABLE IF EXISTS subscription_billing.seat_allocations;
@@ -0,0 +1,37 @@
+-- Seat-based billing: the seats an organization purchased on its
+-- subscription and the seats its members use. Membership changes schedule a
+-- debounced sync that updates the subscription's seat quantity on the
+-- billing provider; a periodic reconciliation schedules every subscribed
+-- organization so missed changes are corrected.
+CREATE TABLE subscription_billing.seat_allocations (
+    organization_id INT PRIMARY KEY REFERENCES organizations.organizations(id) ON DELETE CASCADE,
+    -- Seat quantity of the subscription; 0 when the plan is not seat-based
+    purchased_seats INT DEFAULT 0 NOT NULL CHECK (purchased_seats >= 0),
+    -- Billable members counted at the last sync
+    used_seats INT DEFAULT 0 NOT NULL CHECK (used_seats >= 0),
+    -- Grow and shrink the subscription with membership instead of blocking new members
+    auto_expand BOOLEAN DEFAULT FALSE NOT NULL,
+
+    -- Debounced sync: set by the first membership change, cleared once synced
+    sync_due_at TIMESTAMP,
+    sync_requested_at TIMESTAMP,
+    synced_at TIMESTAMP,
+    sync_error TEXT,
+
+    -- Audit timestamps
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_seat_allocations_sync_due_at
+    ON subscription_billing.seat_allocations(sync_due_at)
+    WHERE sync_due_at IS NOT NULL;
+
+CREATE TRIGGER trigger_seat_allocations_updated_at
+    BEFORE UPDATE ON subscription_billing.seat_allocations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE subscription_billing.seat_allocations IS 'Purchased and used seats per organization, synced to the subscription seat quantity';
+COMMENT ON COLUMN subscription_billing.seat_allocations.purchased_seats IS 'Seat quantity of the subscription; 0 when the plan is not seat-based';
+COMMENT ON COLUMN subscription_billing.seat_allocations.auto_expand IS 'Grow and shrink the subscription with membership instead of blocking new members';
+COMMENT ON COLUMN subscription_billing.seat_allocations.sync_due_at IS 'When the debounced sync runs; NULL when nothing changed since the last sync';
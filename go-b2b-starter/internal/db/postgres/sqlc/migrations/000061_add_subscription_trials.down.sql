@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS subscription_billing.idx_subscriptions_trial_end;
+
+ALTER TABLE subscription_billing.subscriptions
+    DROP COLUMN IF EXISTS trial_reminder_sent_at,
+    DROP COLUMN IF EXISTS trial_end,
+    DROP COLUMN IF EXISTS trial_start;
@@ -0,0 +1,17 @@
+-- Free trials. Signing up starts a trial held locally as a 'trialing'
+-- subscription without a provider subscription; checking out replaces it with
+-- the paid subscription. Trials of provider subscriptions record the provider's
+-- trial period. A scheduled job emails admins before the trial ends and
+-- expires local trials once it has ended, locking paid features.
+ALTER TABLE subscription_billing.subscriptions
+    ADD COLUMN trial_start TIMESTAMP,
+    ADD COLUMN trial_end TIMESTAMP,
+    ADD COLUMN trial_reminder_sent_at TIMESTAMP;
+
+CREATE INDEX idx_subscriptions_trial_end
+    ON subscription_billing.subscriptions(trial_end)
+    WHERE subscription_status = 'trialing';
+
+COMMENT ON COLUMN subscription_billing.subscriptions.trial_start IS 'Start of the trial; kept after the subscription converts';
+COMMENT ON COLUMN subscription_billing.subscriptions.trial_end IS 'End of the trial; local trials expire at this time';
+COMMENT ON COLUMN subscription_billing.subscriptions.trial_reminder_sent_at IS 'When admins were emailed that the trial is ending';
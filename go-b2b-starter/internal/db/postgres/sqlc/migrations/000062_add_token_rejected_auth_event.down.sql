@@ -0,0 +1,13 @@
+DELETE FROM organizations.auth_audit_log WHERE event_type = 'auth.token_rejected';
+
+ALTER TABLE organizations.auth_audit_log
+    DROP CONSTRAINT check_auth_audit_log_event_type,
+    ADD CONSTRAINT check_auth_audit_log_event_type CHECK (event_type IN (
+        'auth.login_succeeded',
+        'auth.login_failed',
+        'auth.lockout',
+        'auth.token_refreshed',
+        'auth.logout',
+        'auth.password_changed',
+        'auth.canary_triggered'
+    ));
@@ -0,0 +1,15 @@
+-- Rejected bearer tokens are recorded as auth.token_rejected rather than as
+-- failed sign-ins, which only the auth provider can report. Rows recorded
+-- before keep their login_failed event type.
+ALTER TABLE organizations.auth_audit_log
+    DROP CONSTRAINT check_auth_audit_log_event_type,
+    ADD CONSTRAINT check_auth_audit_log_event_type CHECK (event_type IN (
+        'auth.login_succeeded',
+        'auth.login_failed',
+        'auth.token_rejected',
+        'auth.lockout',
+        'auth.token_refreshed',
+        'auth.logout',
+        'auth.password_changed',
+        'auth.canary_triggered'
+    ));
@@ -0,0 +1,3 @@
+ALTER TABLE organizations.accounts
+    DROP CONSTRAINT IF EXISTS chk_accounts_kind,
+    DROP COLUMN IF EXISTS kind;
@@ -0,0 +1,24 @@
+-- What an account stands for. Only people take a seat; service accounts,
+-- OAuth clients and guests are accounts so org_context resolves them like
+-- members, but they are not billed.
+ALTER TABLE organizations.accounts
+    ADD COLUMN kind VARCHAR(20) DEFAULT 'human' NOT NULL,
+    ADD CONSTRAINT chk_accounts_kind CHECK (kind IN ('human', 'service_account', 'oauth_client', 'guest'));
+
+-- Existing accounts are classified by the tables that own them; guests have
+-- no table of their own and are recognized by their email domain
+UPDATE organizations.accounts a
+SET kind = 'service_account'
+FROM organizations.service_accounts sa
+WHERE sa.account_id = a.id;
+
+UPDATE organizations.accounts a
+SET kind = 'oauth_client'
+FROM organizations.oauth_clients c
+WHERE c.account_id = a.id;
+
+UPDATE organizations.accounts
+SET kind = 'guest'
+WHERE email LIKE '%@guest.invalid';
+
+COMMENT ON COLUMN organizations.accounts.kind IS 'human, service_account, oauth_client or guest; only human accounts take a seat';
@@ -0,0 +1,56 @@
+-- name: CreateAccessElevation :one
+INSERT INTO organizations.access_elevations (
+    organization_id,
+    account_id,
+    requested_role,
+    reason,
+    duration_minutes
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+) RETURNING *;
+
+-- name: GetAccessElevationByID :one
+SELECT * FROM organizations.access_elevations
+WHERE id = $1 AND organization_id = $2;
+
+-- name: ListAccessElevationsByOrganization :many
+SELECT * FROM organizations.access_elevations
+WHERE organization_id = sqlc.arg(organization_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC;
+
+-- name: GetActiveAccessElevation :one
+SELECT * FROM organizations.access_elevations
+WHERE organization_id = $1
+  AND account_id = $2
+  AND status = 'approved'
+  AND expires_at > NOW()
+ORDER BY expires_at DESC
+LIMIT 1;
+
+-- name: DecideAccessElevation :one
+UPDATE organizations.access_elevations
+SET status = sqlc.arg(status),
+    decided_by_account_id = sqlc.narg(decided_by_account_id),
+    decision_note = sqlc.arg(decision_note),
+    decided_at = NOW(),
+    expires_at = CASE WHEN sqlc.arg(status) = 'approved'
+        THEN NOW() + duration_minutes * INTERVAL '1 minute'
+    END
+WHERE id = sqlc.arg(id)
+  AND organization_id = sqlc.arg(organization_id)
+  AND status = 'pending'
+RETURNING *;
+
+-- name: RevokeAccessElevation :one
+UPDATE organizations.access_elevations
+SET status = 'revoked',
+    expires_at = LEAST(expires_at, NOW())
+WHERE id = $1
+  AND organization_id = $2
+  AND status = 'approved'
+RETURNING *;
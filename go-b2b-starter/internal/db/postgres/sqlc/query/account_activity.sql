@@ -0,0 +1,112 @@
+-- name: RecordAccountActivity :exec
+-- Stores the time of the account's latest authenticated request
+INSERT INTO organizations.account_activity (account_id, organization_id, last_active_at)
+VALUES ($1, $2, NOW())
+ON CONFLICT (account_id) DO UPDATE
+SET last_active_at = EXCLUDED.last_active_at;
+
+-- name: ReactivateDormantAccount :execrows
+UPDATE organizations.accounts
+SET status = 'active'
+WHERE id = $1
+  AND organization_id = $2
+  AND status = 'dormant';
+
+-- name: ReactivateDormantOrganization :execrows
+UPDATE organizations.organizations
+SET status = 'active'
+WHERE id = $1
+  AND status = 'dormant';
+
+-- name: MarkDormantAccounts :many
+-- Moves active accounts of production organizations without activity since the cutoff to dormant, least recently active first
+WITH candidates AS (
+    SELECT a.id, GREATEST(a.created_at, a.last_login_at, act.last_active_at)::timestamp AS last_active_at
+    FROM organizations.accounts a
+    INNER JOIN organizations.organizations o ON o.id = a.organization_id
+    LEFT JOIN organizations.account_activity act ON act.account_id = a.id
+    WHERE a.status = 'active'
+      AND o.status IN ('active', 'dormant')
+      AND o.environment = 'production'
+      AND GREATEST(a.created_at, a.last_login_at, act.last_active_at) < @cutoff::timestamp
+    ORDER BY 2
+    LIMIT @row_limit::int
+)
+UPDATE organizations.accounts a
+SET status = 'dormant'
+FROM candidates c
+WHERE a.id = c.id
+RETURNING a.id, a.organization_id, a.email, a.full_name, c.last_active_at;
+
+-- name: MarkDormantOrganizations :many
+-- Moves active production organizations none of whose accounts were active since the cutoff to dormant, least recently active first
+WITH candidates AS (
+    SELECT o.id, GREATEST(o.created_at, MAX(a.last_login_at), MAX(act.last_active_at))::timestamp AS last_active_at
+    FROM organizations.organizations o
+    LEFT JOIN organizations.accounts a ON a.organization_id = o.id
+    LEFT JOIN organizations.account_activity act ON act.account_id = a.id
+    WHERE o.status = 'active'
+      AND o.environment = 'production'
+    GROUP BY o.id
+    HAVING GREATEST(o.created_at, MAX(a.last_login_at), MAX(act.last_active_at)) < @cutoff::timestamp
+    ORDER BY 2
+    LIMIT @row_limit::int
+)
+UPDATE organizations.organizations o
+SET status = 'dormant'
+FROM candidates c
+WHERE o.id = c.id
+RETURNING o.id, o.slug, o.name, c.last_active_at;
+
+-- name: GetAccountLastActive :one
+SELECT last_active_at FROM organizations.account_activity
+WHERE account_id = $1 AND organization_id = $2;
+
+-- name: CreateAccountActivityEvent :execrows
+-- Stores an activity of the account; an event already recorded for the account is ignored
+INSERT INTO organizations.account_activity_feed (
+    event_id,
+    organization_id,
+    account_id,
+    activity_type,
+    details,
+    occurred_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+)
+ON CONFLICT (event_id, account_id) DO NOTHING;
+
+-- name: TouchAccountActivityTimestamp :exec
+-- Counts an activity and keeps the latest time of its kind
+INSERT INTO organizations.account_activity_timestamps (account_id, organization_id, activity_type, last_occurred_at, occurrences)
+VALUES ($1, $2, $3, $4, 1)
+ON CONFLICT (account_id, activity_type) DO UPDATE
+SET last_occurred_at = GREATEST(organizations.account_activity_timestamps.last_occurred_at, EXCLUDED.last_occurred_at),
+    occurrences = organizations.account_activity_timestamps.occurrences + 1;
+
+-- name: TrimAccountActivityFeed :execrows
+-- Deletes all but the newest keep activities of the account
+DELETE FROM organizations.account_activity_feed
+WHERE id IN (
+    SELECT id FROM organizations.account_activity_feed
+    WHERE account_id = @account_id::int
+    ORDER BY occurred_at DESC, id DESC
+    OFFSET @keep::int
+);
+
+-- name: ListAccountActivityEvents :many
+SELECT * FROM organizations.account_activity_feed
+WHERE organization_id = sqlc.arg(organization_id)::int
+  AND account_id = sqlc.arg(account_id)::int
+  AND (sqlc.narg(activity_type)::text IS NULL OR activity_type = sqlc.narg(activity_type)::text)
+ORDER BY occurred_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListAccountActivityTimestamps :many
+SELECT activity_type, last_occurred_at, occurrences FROM organizations.account_activity_timestamps
+WHERE organization_id = $1 AND account_id = $2
+ORDER BY activity_type;
+
+-- name: DeleteAccountActivityEventsBefore :execrows
+DELETE FROM organizations.account_activity_feed
+WHERE occurred_at < $1;
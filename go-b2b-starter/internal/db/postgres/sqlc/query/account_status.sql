@@ -0,0 +1,53 @@
+-- name: ChangeAccountStatus :one
+-- Moves the account from one status to another and records the change; returns no row if the account is not in from_status
+WITH changed AS (
+    UPDATE organizations.accounts
+    SET status = @to_status::text,
+        updated_at = CURRENT_TIMESTAMP
+    WHERE id = @account_id::int
+      AND organization_id = @organization_id::int
+      AND status = @from_status::text
+    RETURNING id, organization_id
+)
+INSERT INTO organizations.account_status_changes (
+    organization_id,
+    account_id,
+    from_status,
+    to_status,
+    reason,
+    actor_account_id
+)
+SELECT
+    changed.organization_id,
+    changed.id,
+    @from_status::text,
+    @to_status::text,
+    @reason::text,
+    sqlc.narg(actor_account_id)::int
+FROM changed
+RETURNING
+    id,
+    organization_id,
+    account_id,
+    from_status,
+    to_status,
+    reason,
+    actor_account_id,
+    created_at;
+
+-- name: ListAccountStatusChanges :many
+-- Status changes of an account, newest first
+SELECT
+    id,
+    organization_id,
+    account_id,
+    from_status,
+    to_status,
+    reason,
+    actor_account_id,
+    created_at
+FROM organizations.account_status_changes
+WHERE account_id = @account_id::int
+  AND organization_id = @organization_id::int
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit::int;
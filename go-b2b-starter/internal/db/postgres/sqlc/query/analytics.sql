@@ -0,0 +1,76 @@
+-- name: ClaimExportWatermark :one
+-- Creates the watermark on first use and takes the dataset lease unless another instance holds it
+INSERT INTO analytics.export_watermarks (
+    dataset,
+    schema_version,
+    lease_until
+) VALUES (
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (dataset) DO UPDATE
+SET lease_until = EXCLUDED.lease_until
+WHERE analytics.export_watermarks.lease_until IS NULL
+   OR analytics.export_watermarks.lease_until < NOW()
+RETURNING *;
+
+-- name: AdvanceExportWatermark :exec
+UPDATE analytics.export_watermarks
+SET schema_version = sqlc.arg(schema_version),
+    watermark_at = sqlc.arg(watermark_at),
+    watermark_id = sqlc.arg(watermark_id),
+    rows_exported = rows_exported + sqlc.arg(exported_rows)::bigint,
+    last_object_key = sqlc.arg(last_object_key),
+    last_exported_at = NOW()
+WHERE dataset = sqlc.arg(dataset);
+
+-- name: ReleaseExportWatermark :exec
+UPDATE analytics.export_watermarks
+SET lease_until = NULL
+WHERE dataset = $1;
+
+-- name: ListChatMessageFacts :many
+-- Chat usage facts without message content; staging and sandbox organizations are left out
+SELECT m.id, m.session_id, s.organization_id, s.account_id, m.role,
+       COALESCE(m.tokens_used, 0)::int AS tokens_used,
+       COALESCE(cardinality(m.referenced_docs), 0)::int AS referenced_doc_count,
+       m.created_at
+FROM cognitive.chat_messages m
+JOIN cognitive.chat_sessions s ON s.id = m.session_id
+WHERE NOT EXISTS (
+    SELECT 1 FROM organizations.organizations o
+    WHERE o.id = s.organization_id AND o.environment <> 'production'
+  )
+  AND (m.created_at, m.id) > (sqlc.arg(after_at)::timestamp, sqlc.arg(after_id)::int)
+  AND m.created_at <= sqlc.arg(until_at)::timestamp
+ORDER BY m.created_at, m.id
+LIMIT sqlc.arg(batch_limit);
+
+-- name: ListDocumentFacts :many
+-- Document processing facts without titles, file names or text; staging and sandbox organizations are left out
+SELECT id, organization_id, status, content_type, file_size, created_at, updated_at
+FROM documents.documents d
+WHERE NOT EXISTS (
+    SELECT 1 FROM organizations.organizations o
+    WHERE o.id = d.organization_id AND o.environment <> 'production'
+  )
+  AND (updated_at, id) > (sqlc.arg(after_at)::timestamp, sqlc.arg(after_id)::int)
+  AND updated_at <= sqlc.arg(until_at)::timestamp
+ORDER BY updated_at, id
+LIMIT sqlc.arg(batch_limit);
+
+-- name: ListSubscriptionFacts :many
+-- Billing facts without provider customer IDs or metadata; staging and sandbox organizations are left out
+SELECT id, organization_id, subscription_status, product_id, plan_name,
+       current_period_start, current_period_end, cancel_at_period_end, canceled_at,
+       COALESCE(updated_at, created_at, 'epoch')::timestamp AS changed_at
+FROM subscription_billing.subscriptions sub
+WHERE NOT EXISTS (
+    SELECT 1 FROM organizations.organizations o
+    WHERE o.id = sub.organization_id AND o.environment <> 'production'
+  )
+  AND (COALESCE(updated_at, created_at, 'epoch')::timestamp, id) > (sqlc.arg(after_at)::timestamp, sqlc.arg(after_id)::int)
+  AND COALESCE(updated_at, created_at, 'epoch')::timestamp <= sqlc.arg(until_at)::timestamp
+ORDER BY changed_at, id
+LIMIT sqlc.arg(batch_limit);
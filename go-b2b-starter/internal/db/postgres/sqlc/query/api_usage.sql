@@ -0,0 +1,40 @@
+-- name: UpsertAPIUsage :exec
+-- Stores the request count of an account's window. Counts only grow, so a
+-- stale flush from another instance never lowers a newer one.
+INSERT INTO organizations.api_usage (
+    organization_id,
+    account_id,
+    window_start,
+    window_end,
+    request_count
+) VALUES (
+    @organization_id::int,
+    @account_id::int,
+    @window_start::timestamp,
+    @window_end::timestamp,
+    @request_count::bigint
+)
+ON CONFLICT (account_id, window_start) DO UPDATE
+SET request_count = GREATEST(organizations.api_usage.request_count, EXCLUDED.request_count),
+    window_end = EXCLUDED.window_end,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: ListAPIUsage :many
+-- Usage windows of an account, newest first
+SELECT
+    organization_id,
+    account_id,
+    window_start,
+    window_end,
+    request_count,
+    updated_at
+FROM organizations.api_usage
+WHERE account_id = @account_id::int
+  AND organization_id = @organization_id::int
+ORDER BY window_start DESC
+LIMIT @row_limit::int;
+
+-- name: DeleteAPIUsageBefore :execrows
+-- Deletes usage windows that ended before the retention cutoff
+DELETE FROM organizations.api_usage
+WHERE window_end < @cutoff::timestamp;
@@ -0,0 +1,51 @@
+-- name: CreateAuthAuditEvent :one
+INSERT INTO organizations.auth_audit_log (
+    organization_id,
+    account_id,
+    event_type,
+    user_id,
+    email,
+    session_id,
+    client_ip,
+    user_agent,
+    reason,
+    occurred_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
+) RETURNING *;
+
+-- name: ListAuthAuditEvents :many
+SELECT * FROM organizations.auth_audit_log
+WHERE organization_id = sqlc.arg(organization_id)::int
+  AND (sqlc.narg(user_id)::text IS NULL OR user_id = sqlc.narg(user_id)::text)
+  AND (sqlc.narg(event_type)::text IS NULL OR event_type = sqlc.narg(event_type)::text)
+  AND (sqlc.narg(occurred_after)::timestamp IS NULL OR occurred_at >= sqlc.narg(occurred_after)::timestamp)
+  AND (sqlc.narg(occurred_before)::timestamp IS NULL OR occurred_at < sqlc.narg(occurred_before)::timestamp)
+ORDER BY occurred_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: ListAuthAuditEventsKeyset :many
+-- Page of events newest first, starting after the (occurred_at, id) of the
+-- last event of the previous page; no cursor starts at the newest event
+SELECT * FROM organizations.auth_audit_log
+WHERE organization_id = sqlc.arg(organization_id)::int
+  AND (sqlc.narg(user_id)::text IS NULL OR user_id = sqlc.narg(user_id)::text)
+  AND (sqlc.narg(event_type)::text IS NULL OR event_type = sqlc.narg(event_type)::text)
+  AND (sqlc.narg(occurred_after)::timestamp IS NULL OR occurred_at >= sqlc.narg(occurred_after)::timestamp)
+  AND (sqlc.narg(occurred_before)::timestamp IS NULL OR occurred_at < sqlc.narg(occurred_before)::timestamp)
+  AND (sqlc.narg(cursor_at)::timestamp IS NULL
+       OR (occurred_at, id) < (sqlc.narg(cursor_at)::timestamp, sqlc.narg(cursor_id)::bigint))
+ORDER BY occurred_at DESC, id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountAuthAuditEvents :one
+SELECT COUNT(*) FROM organizations.auth_audit_log
+WHERE organization_id = sqlc.arg(organization_id)::int
+  AND (sqlc.narg(user_id)::text IS NULL OR user_id = sqlc.narg(user_id)::text)
+  AND (sqlc.narg(event_type)::text IS NULL OR event_type = sqlc.narg(event_type)::text)
+  AND (sqlc.narg(occurred_after)::timestamp IS NULL OR occurred_at >= sqlc.narg(occurred_after)::timestamp)
+  AND (sqlc.narg(occurred_before)::timestamp IS NULL OR occurred_at < sqlc.narg(occurred_before)::timestamp);
+
+-- name: DeleteAuthAuditEventsBefore :execrows
+DELETE FROM organizations.auth_audit_log
+WHERE occurred_at < $1;
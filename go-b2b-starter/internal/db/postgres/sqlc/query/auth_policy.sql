@@ -0,0 +1,24 @@
+-- name: GetOrganizationAuthPolicy :one
+SELECT * FROM organizations.auth_policies
+WHERE organization_id = $1;
+
+-- name: UpsertOrganizationAuthPolicy :one
+INSERT INTO organizations.auth_policies (
+    organization_id,
+    mfa_required,
+    session_max_age_seconds,
+    allowed_auth_methods,
+    updated_by_account_id
+) VALUES (
+    $1, $2, $3, $4, $5
+)
+ON CONFLICT (organization_id) DO UPDATE
+SET mfa_required = EXCLUDED.mfa_required,
+    session_max_age_seconds = EXCLUDED.session_max_age_seconds,
+    allowed_auth_methods = EXCLUDED.allowed_auth_methods,
+    updated_by_account_id = EXCLUDED.updated_by_account_id
+RETURNING *;
+
+-- name: DeleteOrganizationAuthPolicy :execrows
+DELETE FROM organizations.auth_policies
+WHERE organization_id = $1;
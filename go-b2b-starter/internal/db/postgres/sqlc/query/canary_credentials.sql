@@ -0,0 +1,38 @@
+-- name: CreateCanaryCredential :one
+INSERT INTO organizations.canary_credentials (
+    organization_id,
+    kind,
+    identifier,
+    name,
+    rotate_on_trigger,
+    created_by_account_id
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING *;
+
+-- name: GetCanaryCredentialByIdentifier :one
+SELECT * FROM organizations.canary_credentials
+WHERE identifier = $1;
+
+-- name: ListCanaryCredentialsByOrganization :many
+SELECT * FROM organizations.canary_credentials
+WHERE organization_id = $1
+ORDER BY created_at DESC;
+
+-- name: DeleteCanaryCredential :execrows
+DELETE FROM organizations.canary_credentials
+WHERE organization_id = $1
+  AND id = $2;
+
+-- name: TriggerCanaryCredential :one
+-- Counts a use of the canary
+UPDATE organizations.canary_credentials
+SET trigger_count = trigger_count + 1,
+    last_triggered_at = NOW()
+WHERE id = $1
+RETURNING *;
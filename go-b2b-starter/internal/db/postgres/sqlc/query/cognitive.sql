@@ -9,11 +9,42 @@ INSERT INTO cognitive.document_embeddings (
     embedding,
     content_hash,
     content_preview,
-    chunk_index
+    chunk_index,
+    location
 ) VALUES (
-    $1, $2, $3, $4, $5, $6
+    $1, $2, $3, $4, $5, $6, $7
 ) RETURNING *;
 
+-- name: CreateDocumentEmbeddings :many
+-- Inserts a batch of chunk embeddings of one document in a single statement.
+-- Vectors are passed in pgvector's text form; an empty location is stored as NULL.
+INSERT INTO cognitive.document_embeddings (
+    document_id,
+    organization_id,
+    embedding,
+    content_hash,
+    content_preview,
+    chunk_index,
+    location
+)
+SELECT
+    @document_id::int4,
+    @organization_id::int4,
+    c.embedding::vector,
+    c.content_hash,
+    c.content_preview,
+    c.chunk_index,
+    NULLIF(c.location, '')
+FROM unnest(
+    @embeddings::text[],
+    @content_hashes::text[],
+    @content_previews::text[],
+    @chunk_indexes::int4[],
+    @locations::text[]
+) AS c(embedding, content_hash, content_preview, chunk_index, location)
+ORDER BY c.chunk_index
+RETURNING *;
+
 -- name: GetDocumentEmbeddingByID :one
 SELECT * FROM cognitive.document_embeddings
 WHERE id = $1 AND organization_id = $2;
@@ -33,9 +64,11 @@ SELECT
     de.chunk_index,
     de.created_at,
     de.updated_at,
+    de.location,
     (1 - (de.embedding <=> $1::vector))::double precision as similarity_score
 FROM cognitive.document_embeddings de
 WHERE de.organization_id = $2
+  AND vector_dims(de.embedding) = vector_dims($1::vector)
 ORDER BY de.embedding <=> $1::vector
 LIMIT $3;
 
@@ -53,9 +86,12 @@ WHERE organization_id = $1;
 INSERT INTO cognitive.chat_sessions (
     organization_id,
     account_id,
-    title
+    title,
+    model,
+    temperature,
+    max_tokens
 ) VALUES (
-    $1, $2, $3
+    $1, $2, $3, $4, $5, $6
 ) RETURNING *;
 
 -- name: GetChatSessionByID :one
@@ -74,10 +110,31 @@ SET title = $3, updated_at = NOW()
 WHERE id = $1 AND organization_id = $2
 RETURNING *;
 
+-- name: UpdateChatSessionParameters :one
+UPDATE cognitive.chat_sessions
+SET model = $3, temperature = $4, max_tokens = $5, updated_at = NOW()
+WHERE id = $1 AND organization_id = $2
+RETURNING *;
+
+-- name: UpdateChatSessionSummary :one
+UPDATE cognitive.chat_sessions
+SET summary = $3, summarized_through_message_id = $4
+WHERE id = $1 AND organization_id = $2
+RETURNING *;
+
 -- name: DeleteChatSession :exec
 DELETE FROM cognitive.chat_sessions
 WHERE id = $1 AND organization_id = $2;
 
+-- name: DeleteChatSessionsByAccount :execrows
+DELETE FROM cognitive.chat_sessions
+WHERE organization_id = $1 AND account_id = $2;
+
+-- name: ReassignChatSessions :execrows
+UPDATE cognitive.chat_sessions
+SET organization_id = $3, account_id = $4, updated_at = NOW()
+WHERE organization_id = $1 AND account_id = $2;
+
 -- Chat Messages
 
 -- name: CreateChatMessage :one
@@ -86,9 +143,13 @@ INSERT INTO cognitive.chat_messages (
     role,
     content,
     referenced_docs,
-    tokens_used
+    tokens_used,
+    model,
+    temperature,
+    max_tokens,
+    truncated
 ) VALUES (
-    $1, $2, $3, $4, $5
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
 ) RETURNING *;
 
 -- name: GetChatMessagesBySession :many
@@ -96,6 +157,11 @@ SELECT * FROM cognitive.chat_messages
 WHERE session_id = $1
 ORDER BY created_at ASC;
 
+-- name: GetChatMessagesAfter :many
+SELECT * FROM cognitive.chat_messages
+WHERE session_id = $1 AND id > @after_message_id
+ORDER BY created_at ASC, id ASC;
+
 -- name: GetRecentChatMessages :many
 SELECT * FROM cognitive.chat_messages
 WHERE session_id = $1
@@ -106,6 +172,48 @@ LIMIT $2;
 SELECT COUNT(*) FROM cognitive.chat_messages
 WHERE session_id = $1;
 
+-- name: UpdateChatMessageContent :one
+UPDATE cognitive.chat_messages
+SET content = $2, tokens_used = $3, truncated = $4
+WHERE id = $1
+RETURNING *;
+
 -- name: DeleteChatMessage :exec
 DELETE FROM cognitive.chat_messages
 WHERE id = $1;
+
+-- Prompt Settings
+
+-- name: CreatePromptSettingsVersion :one
+-- Versions are numbered per organization; a concurrent change fails on uq_prompt_settings_version
+INSERT INTO cognitive.prompt_settings (
+    organization_id,
+    version_number,
+    system_prompt,
+    glossary,
+    created_by_account_id,
+    language
+) VALUES (
+    @organization_id,
+    (SELECT COALESCE(MAX(version_number), 0) + 1 FROM cognitive.prompt_settings WHERE organization_id = @organization_id)::int,
+    @system_prompt,
+    @glossary,
+    @created_by_account_id,
+    @language
+) RETURNING *;
+
+-- name: GetLatestPromptSettings :one
+SELECT * FROM cognitive.prompt_settings
+WHERE organization_id = $1
+ORDER BY version_number DESC
+LIMIT 1;
+
+-- name: GetPromptSettingsVersion :one
+SELECT * FROM cognitive.prompt_settings
+WHERE organization_id = $1 AND version_number = $2;
+
+-- name: ListPromptSettingsVersions :many
+SELECT * FROM cognitive.prompt_settings
+WHERE organization_id = $1
+ORDER BY version_number DESC
+LIMIT $2 OFFSET $3;
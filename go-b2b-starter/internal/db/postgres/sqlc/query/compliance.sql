@@ -0,0 +1,242 @@
+-- name: CountOrganizationRows :many
+-- Rows each tenant table holds for one organization, used before and after a purge
+SELECT 'organizations.organizations'::text AS table_name, COUNT(*) AS row_count
+FROM organizations.organizations WHERE id = @organization_id::int
+UNION ALL
+SELECT 'organizations.accounts', COUNT(*)
+FROM organizations.accounts WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.ip_allowlist_entries', COUNT(*)
+FROM organizations.ip_allowlist_entries WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.access_elevations', COUNT(*)
+FROM organizations.access_elevations WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.mfa_recovery_requests', COUNT(*)
+FROM organizations.mfa_recovery_requests WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.email_change_requests', COUNT(*)
+FROM organizations.email_change_requests WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.oauth_clients', COUNT(*)
+FROM organizations.oauth_clients WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.invites', COUNT(*)
+FROM organizations.invites WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.account_activity', COUNT(*)
+FROM organizations.account_activity WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.account_activity_feed', COUNT(*)
+FROM organizations.account_activity_feed WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.account_activity_timestamps', COUNT(*)
+FROM organizations.account_activity_timestamps WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.account_status_changes', COUNT(*)
+FROM organizations.account_status_changes WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.api_usage', COUNT(*)
+FROM organizations.api_usage WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.notification_preferences', COUNT(*)
+FROM organizations.notification_preferences WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.notifications', COUNT(*)
+FROM organizations.notifications WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.tags', COUNT(*)
+FROM organizations.tags WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.account_tags', COUNT(*)
+FROM organizations.account_tags WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.identities', COUNT(*)
+FROM organizations.identities WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.secondary_emails', COUNT(*)
+FROM organizations.secondary_emails WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.canary_credentials', COUNT(*)
+FROM organizations.canary_credentials WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.debug_captures', COUNT(*)
+FROM organizations.debug_captures WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.debug_capture_records', COUNT(*)
+FROM organizations.debug_capture_records WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.auth_policies', COUNT(*)
+FROM organizations.auth_policies WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.oidc_clients', COUNT(*)
+FROM organizations.oidc_clients WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.oidc_authorization_codes', COUNT(*)
+FROM organizations.oidc_authorization_codes WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.oidc_consents', COUNT(*)
+FROM organizations.oidc_consents WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.auth_audit_log', COUNT(*)
+FROM organizations.auth_audit_log WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.service_accounts', COUNT(*)
+FROM organizations.service_accounts WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'organizations.service_account_keys', COUNT(*)
+FROM organizations.service_account_keys WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'rbac.role_assignments', COUNT(*)
+FROM rbac.role_assignments WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'subscription_billing.subscriptions', COUNT(*)
+FROM subscription_billing.subscriptions WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'subscription_billing.quota_tracking', COUNT(*)
+FROM subscription_billing.quota_tracking WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'subscription_billing.billing_settings', COUNT(*)
+FROM subscription_billing.billing_settings WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'subscription_billing.user_subscriptions', COUNT(*)
+FROM subscription_billing.user_subscriptions WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'subscription_billing.usage_events', COUNT(*)
+FROM subscription_billing.usage_events WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'subscription_billing.usage_rollups', COUNT(*)
+FROM subscription_billing.usage_rollups WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'subscription_billing.seat_allocations', COUNT(*)
+FROM subscription_billing.seat_allocations WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'documents.documents', COUNT(*)
+FROM documents.documents WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'documents.document_versions', COUNT(*)
+FROM documents.document_versions WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'documents.document_classifications', COUNT(*)
+FROM documents.document_classifications WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'documents.document_counters', COUNT(*)
+FROM documents.document_counters WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'cognitive.document_embeddings', COUNT(*)
+FROM cognitive.document_embeddings WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'cognitive.chat_sessions', COUNT(*)
+FROM cognitive.chat_sessions WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'cognitive.chat_messages', COUNT(*)
+FROM cognitive.chat_messages m
+JOIN cognitive.chat_sessions s ON s.id = m.session_id
+WHERE s.organization_id = @organization_id::int
+UNION ALL
+SELECT 'cognitive.prompt_settings', COUNT(*)
+FROM cognitive.prompt_settings WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'public.example_resources', COUNT(*)
+FROM example_resources WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'public.resource_embeddings', COUNT(*)
+FROM resource_embeddings WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'public.duplicate_candidates', COUNT(*)
+FROM duplicate_candidates WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'onboarding.organization_steps', COUNT(*)
+FROM onboarding.organization_steps WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'portability.organization_imports', COUNT(*)
+FROM portability.organization_imports WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'support.tickets', COUNT(*)
+FROM support.tickets WHERE organization_id = @organization_id::int
+UNION ALL
+SELECT 'support.ticket_attachments', COUNT(*)
+FROM support.ticket_attachments a
+JOIN support.tickets t ON t.id = a.ticket_id
+WHERE t.organization_id = @organization_id::int
+UNION ALL
+SELECT 'compliance.data_exports', COUNT(*)
+FROM compliance.data_exports WHERE organization_id = @organization_id::int;
+
+-- name: ListOrganizationFileAssets :many
+-- Stored files referenced by an organization's documents (all versions), ticket attachments, resources, account avatars and data export archives
+SELECT id, storage_path, bucket_name FROM file_manager.file_assets
+WHERE id IN (
+    SELECT file_asset_id FROM documents.documents
+    WHERE organization_id = @organization_id::int
+    UNION
+    SELECT file_asset_id FROM documents.document_versions
+    WHERE organization_id = @organization_id::int
+    UNION
+    SELECT a.file_asset_id FROM support.ticket_attachments a
+    JOIN support.tickets t ON t.id = a.ticket_id
+    WHERE t.organization_id = @organization_id::int
+    UNION
+    SELECT file_id FROM example_resources
+    WHERE organization_id = @organization_id::int AND file_id IS NOT NULL
+    UNION
+    SELECT avatar_file_id FROM organizations.accounts
+    WHERE organization_id = @organization_id::int AND avatar_file_id IS NOT NULL
+    UNION
+    SELECT file_asset_id FROM compliance.data_exports
+    WHERE organization_id = @organization_id::int AND file_asset_id IS NOT NULL
+)
+ORDER BY id;
+
+-- name: CountFileAssetsByIDs :one
+SELECT COUNT(*) FROM file_manager.file_assets
+WHERE id = ANY(@ids::int[]);
+
+-- name: CreatePurgeReport :one
+INSERT INTO compliance.purge_reports (
+    organization_id,
+    organization_name,
+    reason,
+    requested_by,
+    status,
+    details,
+    started_at,
+    completed_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+) RETURNING *;
+
+-- name: GetPurgeReport :one
+SELECT * FROM compliance.purge_reports
+WHERE id = $1;
+
+-- name: ListPurgeReports :many
+SELECT * FROM compliance.purge_reports
+WHERE (sqlc.narg(organization_id)::int IS NULL OR organization_id = sqlc.narg(organization_id)::int)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountPurgeReports :one
+SELECT COUNT(*) FROM compliance.purge_reports
+WHERE (sqlc.narg(organization_id)::int IS NULL OR organization_id = sqlc.narg(organization_id)::int);
+
+-- name: ListExpiredStagingOrganizations :many
+-- Staging and sandbox organizations past their expiry, oldest first
+SELECT id FROM organizations.organizations
+WHERE environment <> 'production'
+  AND expires_at <= NOW()
+ORDER BY expires_at
+LIMIT @row_limit::int;
+
+-- name: ListStagingOrganizationIDs :many
+-- Staging and sandbox organizations purged before their production parent
+SELECT id FROM organizations.organizations
+WHERE parent_organization_id = @parent_organization_id::int
+ORDER BY id;
@@ -0,0 +1,115 @@
+-- name: CreateDataExport :one
+INSERT INTO compliance.data_exports (
+    organization_id,
+    account_id
+) VALUES (
+    $1,
+    $2
+) RETURNING *;
+
+-- name: GetDataExport :one
+SELECT * FROM compliance.data_exports
+WHERE id = $1 AND organization_id = $2 AND account_id = $3;
+
+-- name: GetDataExportByTokenHash :one
+SELECT * FROM compliance.data_exports
+WHERE download_token_hash = $1;
+
+-- name: GetLatestDataExport :one
+-- The account's newest export that did not fail, used to limit how often exports can be requested
+SELECT * FROM compliance.data_exports
+WHERE organization_id = $1 AND account_id = $2
+  AND status <> 'failed'
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- name: ListDataExports :many
+SELECT * FROM compliance.data_exports
+WHERE organization_id = $1 AND account_id = $2
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit::int;
+
+-- name: CompleteDataExport :one
+UPDATE compliance.data_exports
+SET status = 'completed',
+    file_asset_id = $2,
+    file_size = $3,
+    download_token_hash = $4,
+    summary = $5,
+    expires_at = $6,
+    completed_at = NOW()
+WHERE id = $1
+  AND status = 'running'
+RETURNING *;
+
+-- name: FailDataExport :exec
+UPDATE compliance.data_exports
+SET status = 'failed',
+    error = $2,
+    completed_at = NOW()
+WHERE id = $1
+  AND status = 'running';
+
+-- name: FailStaleDataExports :execrows
+-- Marks an account's running exports that outlived the export timeout, for example because of a restart, as failed
+UPDATE compliance.data_exports
+SET status = 'failed',
+    error = 'export was interrupted',
+    completed_at = NOW()
+WHERE account_id = @account_id::int
+  AND status = 'running'
+  AND started_at < @stale_before::timestamp;
+
+-- name: ListExpiredDataExports :many
+-- Completed exports whose download link expired, oldest first
+SELECT * FROM compliance.data_exports
+WHERE status = 'completed'
+  AND expires_at <= NOW()
+ORDER BY expires_at
+LIMIT @row_limit::int;
+
+-- name: ExpireDataExport :exec
+UPDATE compliance.data_exports
+SET status = 'expired',
+    file_asset_id = NULL,
+    download_token_hash = NULL
+WHERE id = $1;
+
+-- name: IncrementDataExportDownloads :exec
+UPDATE compliance.data_exports
+SET download_count = download_count + 1
+WHERE id = $1;
+
+-- name: ListAccountUploadedDocuments :many
+-- Documents the account uploaded, for its data export
+SELECT id, title, file_name, content_type, file_size, status, metadata, created_at, updated_at
+FROM documents.documents
+WHERE organization_id = @organization_id::int
+  AND uploaded_by = @account_id::int
+ORDER BY id;
+
+-- name: ListAccountUploadedDocumentVersions :many
+-- Document versions the account uploaded with the title of their document, for its data export
+SELECT v.id, v.document_id, d.title, v.version_number, v.file_asset_id, v.file_name, v.content_type, v.file_size, v.created_at
+FROM documents.document_versions v
+JOIN documents.documents d ON d.id = v.document_id
+WHERE v.organization_id = @organization_id::int
+  AND v.uploaded_by = @account_id::int
+ORDER BY v.document_id, v.version_number;
+
+-- name: ListAccountChatSessions :many
+-- Chat sessions of the account, for its data export
+SELECT id, title, created_at, updated_at
+FROM cognitive.chat_sessions
+WHERE organization_id = @organization_id::int
+  AND account_id = @account_id::int
+ORDER BY created_at, id;
+
+-- name: ListAccountChatMessages :many
+-- Messages of the account's chat sessions, for its data export
+SELECT m.id, m.session_id, m.role, m.content, m.referenced_docs, m.created_at
+FROM cognitive.chat_messages m
+JOIN cognitive.chat_sessions s ON s.id = m.session_id
+WHERE s.organization_id = @organization_id::int
+  AND s.account_id = @account_id::int
+ORDER BY m.session_id, m.created_at, m.id;
@@ -0,0 +1,102 @@
+-- name: CreateDebugCapture :one
+INSERT INTO organizations.debug_captures (
+    organization_id,
+    reason,
+    started_by,
+    redact_fields,
+    max_records,
+    expires_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING *;
+
+-- name: GetDebugCapture :one
+SELECT * FROM organizations.debug_captures
+WHERE id = $1;
+
+-- name: GetActiveDebugCapture :one
+-- The organization's capture that is still recording, if any
+SELECT * FROM organizations.debug_captures
+WHERE organization_id = $1
+  AND stopped_at IS NULL
+  AND expires_at > NOW()
+ORDER BY created_at DESC
+LIMIT 1;
+
+-- name: ListDebugCaptures :many
+SELECT * FROM organizations.debug_captures
+WHERE (sqlc.narg(organization_id)::int IS NULL OR organization_id = sqlc.narg(organization_id)::int)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: StopDebugCapture :one
+UPDATE organizations.debug_captures
+SET stopped_at = NOW()
+WHERE id = $1
+  AND stopped_at IS NULL
+  AND expires_at > NOW()
+RETURNING *;
+
+-- name: ReserveDebugCaptureRecord :execrows
+-- Counts a record against the capture's limit; no row means the capture stopped or is full
+UPDATE organizations.debug_captures
+SET record_count = record_count + 1
+WHERE id = $1
+  AND stopped_at IS NULL
+  AND expires_at > NOW()
+  AND record_count < max_records;
+
+-- name: CreateDebugCaptureRecord :exec
+INSERT INTO organizations.debug_capture_records (
+    capture_id,
+    organization_id,
+    account_id,
+    method,
+    path,
+    route,
+    query,
+    client_ip,
+    request_headers,
+    request_body,
+    status,
+    duration_ms,
+    response_headers,
+    response_body,
+    expires_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8,
+    $9,
+    $10,
+    $11,
+    $12,
+    $13,
+    $14,
+    $15
+);
+
+-- name: ListDebugCaptureRecords :many
+SELECT * FROM organizations.debug_capture_records
+WHERE capture_id = $1
+ORDER BY id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: DeleteExpiredDebugCaptureRecords :execrows
+DELETE FROM organizations.debug_capture_records
+WHERE expires_at <= @expired_before::timestamp;
+
+-- name: DeleteEndedDebugCaptures :execrows
+-- Captures that stopped recording before the cutoff, along with any records left
+DELETE FROM organizations.debug_captures
+WHERE COALESCE(stopped_at, expires_at) <= @ended_before::timestamp;
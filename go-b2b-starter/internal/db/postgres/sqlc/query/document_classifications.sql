@@ -0,0 +1,63 @@
+-- Document classification queries
+
+-- name: GetDocumentClassification :one
+SELECT * FROM documents.document_classifications
+WHERE document_id = $1 AND organization_id = $2;
+
+-- name: UpsertModelDocumentClassification :execrows
+-- A manual override is only replaced when replace_manual is set; zero rows
+-- affected means an override was kept
+INSERT INTO documents.document_classifications (
+    document_id,
+    organization_id,
+    document_type,
+    confidence,
+    entities,
+    source,
+    model
+) VALUES (
+    @document_id,
+    @organization_id,
+    @document_type,
+    @confidence,
+    @entities,
+    'model',
+    @model
+)
+ON CONFLICT (document_id) DO UPDATE
+SET document_type = EXCLUDED.document_type,
+    confidence = EXCLUDED.confidence,
+    entities = EXCLUDED.entities,
+    source = EXCLUDED.source,
+    model = EXCLUDED.model,
+    overridden_by = NULL,
+    updated_at = NOW()
+WHERE documents.document_classifications.source = 'model' OR @replace_manual::boolean;
+
+-- name: OverrideDocumentClassification :one
+INSERT INTO documents.document_classifications (
+    document_id,
+    organization_id,
+    document_type,
+    confidence,
+    entities,
+    source,
+    overridden_by
+) VALUES (
+    @document_id,
+    @organization_id,
+    @document_type,
+    1,
+    @entities,
+    'manual',
+    @overridden_by
+)
+ON CONFLICT (document_id) DO UPDATE
+SET document_type = EXCLUDED.document_type,
+    confidence = EXCLUDED.confidence,
+    entities = EXCLUDED.entities,
+    source = EXCLUDED.source,
+    model = NULL,
+    overridden_by = EXCLUDED.overridden_by,
+    updated_at = NOW()
+RETURNING *;
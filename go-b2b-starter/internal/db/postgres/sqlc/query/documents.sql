@@ -10,9 +10,10 @@ INSERT INTO documents.documents (
     file_size,
     extracted_text,
     status,
-    metadata
+    metadata,
+    uploaded_by
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10
 ) RETURNING *;
 
 -- name: GetDocumentByID :one
@@ -67,3 +68,69 @@ WHERE organization_id = $1;
 -- name: CountDocumentsByStatus :one
 SELECT COUNT(*) FROM documents.documents
 WHERE organization_id = $1 AND status = $2;
+
+-- name: UpdateDocumentFile :one
+-- Points a document at a new version's file; its text is extracted again
+UPDATE documents.documents
+SET file_asset_id = $3, file_name = $4, content_type = $5, file_size = $6, status = 'pending', updated_at = NOW()
+WHERE id = $1 AND organization_id = $2
+RETURNING *;
+
+-- Document version queries
+
+-- name: CreateDocumentVersion :one
+-- Versions are numbered per document; a concurrent upload fails on uq_document_versions_number
+INSERT INTO documents.document_versions (
+    document_id,
+    organization_id,
+    version_number,
+    file_asset_id,
+    file_name,
+    content_type,
+    file_size,
+    status,
+    uploaded_by
+) VALUES (
+    @document_id,
+    @organization_id,
+    (SELECT COALESCE(MAX(version_number), 0) + 1 FROM documents.document_versions WHERE document_id = @document_id)::int,
+    @file_asset_id,
+    @file_name,
+    @content_type,
+    @file_size,
+    @status,
+    sqlc.narg(uploaded_by)
+) RETURNING *;
+
+-- name: GetDocumentVersion :one
+SELECT * FROM documents.document_versions
+WHERE document_id = $1 AND organization_id = $2 AND version_number = $3;
+
+-- name: ListDocumentVersions :many
+-- Extracted text is left out; fetch a single version to read it
+SELECT id, document_id, organization_id, version_number, file_asset_id, file_name, content_type, file_size, status, created_at
+FROM documents.document_versions
+WHERE document_id = $1 AND organization_id = $2
+ORDER BY version_number DESC;
+
+-- name: UpdateDocumentVersionText :exec
+UPDATE documents.document_versions
+SET extracted_text = $3, status = $4
+WHERE document_id = $1 AND file_asset_id = $2;
+
+-- Document counter queries
+
+-- name: GetDocumentCounters :one
+SELECT * FROM documents.document_counters
+WHERE organization_id = $1;
+
+-- name: ListCountedOrganizationIDs :many
+-- Organizations in id order after a cursor, for reconciling counters in batches
+SELECT id FROM organizations.organizations
+WHERE id > @after_id::int
+ORDER BY id
+LIMIT @row_limit::int;
+
+-- name: ReconcileDocumentCounters :one
+-- Recounts an organization's counters; true when they had drifted
+SELECT documents.reconcile_document_counters(@organization_id::int)::boolean AS drifted;
@@ -0,0 +1,58 @@
+-- name: CreateEmailChangeRequest :one
+INSERT INTO organizations.email_change_requests (
+    organization_id,
+    account_id,
+    old_email,
+    new_email,
+    confirm_token_hash,
+    revert_token_hash,
+    expires_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+) RETURNING *;
+
+-- name: GetEmailChangeRequestByConfirmTokenHash :one
+SELECT * FROM organizations.email_change_requests
+WHERE confirm_token_hash = $1;
+
+-- name: GetEmailChangeRequestByRevertTokenHash :one
+SELECT * FROM organizations.email_change_requests
+WHERE revert_token_hash = $1;
+
+-- name: CancelPendingEmailChangeRequests :execrows
+UPDATE organizations.email_change_requests
+SET status = 'cancelled'
+WHERE organization_id = $1
+  AND account_id = $2
+  AND status = 'pending';
+
+-- name: CancelEmailChangeRequest :one
+UPDATE organizations.email_change_requests
+SET status = 'cancelled'
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: ConfirmEmailChangeRequest :one
+UPDATE organizations.email_change_requests
+SET status = 'confirmed',
+    revert_token_hash = $2,
+    revertible_until = $3,
+    confirmed_at = NOW()
+WHERE id = $1
+  AND status = 'pending'
+RETURNING *;
+
+-- name: RevertEmailChangeRequest :one
+UPDATE organizations.email_change_requests
+SET status = 'reverted',
+    reverted_at = NOW()
+WHERE id = $1
+  AND status = 'confirmed'
+RETURNING *;
@@ -0,0 +1,59 @@
+-- name: CreateIdentity :one
+-- Links a provider identity to an account
+INSERT INTO organizations.identities (
+    organization_id,
+    account_id,
+    provider,
+    provider_user_id,
+    email
+) VALUES (
+    @organization_id::int,
+    @account_id::int,
+    @provider::text,
+    @provider_user_id::text,
+    sqlc.narg(email)::text
+) RETURNING
+    id,
+    organization_id,
+    account_id,
+    provider,
+    provider_user_id,
+    email,
+    created_at;
+
+-- name: ListAccountIdentities :many
+-- Identities linked to an account
+SELECT
+    id,
+    organization_id,
+    account_id,
+    provider,
+    provider_user_id,
+    email,
+    created_at
+FROM organizations.identities
+WHERE account_id = @account_id::int
+  AND organization_id = @organization_id::int
+ORDER BY provider;
+
+-- name: ListIdentitiesByProviderUser :many
+-- Accounts a provider user is linked to, across organizations
+SELECT
+    id,
+    organization_id,
+    account_id,
+    provider,
+    provider_user_id,
+    email,
+    created_at
+FROM organizations.identities
+WHERE provider = @provider::text
+  AND provider_user_id = @provider_user_id::text
+ORDER BY organization_id;
+
+-- name: DeleteIdentity :execrows
+-- Unlinks an account's identity at a provider
+DELETE FROM organizations.identities
+WHERE account_id = @account_id::int
+  AND organization_id = @organization_id::int
+  AND provider = @provider::text;
@@ -0,0 +1,61 @@
+-- name: CreateInvite :one
+INSERT INTO organizations.invites (
+    organization_id,
+    created_by_account_id,
+    email,
+    role_slug,
+    token_hash,
+    expires_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING *;
+
+-- name: GetInviteByTokenHash :one
+SELECT * FROM organizations.invites
+WHERE token_hash = $1;
+
+-- name: ListInvitesByOrganization :many
+SELECT * FROM organizations.invites
+WHERE organization_id = $1
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: AcceptInvite :one
+-- Claims a pending, unexpired invite; concurrent accepts of the same invite get no row
+UPDATE organizations.invites
+SET status = 'accepted',
+    accepted_at = NOW(),
+    accepted_email = $2
+WHERE id = $1
+  AND status = 'pending'
+  AND expires_at > NOW()
+RETURNING *;
+
+-- name: ReleaseInvite :exec
+-- Returns a claimed invite to pending when registering the member failed
+UPDATE organizations.invites
+SET status = 'pending',
+    accepted_at = NULL,
+    accepted_email = NULL
+WHERE id = $1
+  AND status = 'accepted';
+
+-- name: DeleteInvitesBefore :execrows
+-- Deletes invites that can no longer be accepted: pending ones that expired and revoked ones, before the cutoff
+DELETE FROM organizations.invites
+WHERE (status = 'pending' AND expires_at < $1)
+   OR (status = 'revoked' AND revoked_at < $1);
+
+-- name: RevokeInvite :one
+UPDATE organizations.invites
+SET status = 'revoked',
+    revoked_at = NOW()
+WHERE id = $1
+  AND organization_id = $2
+  AND status = 'pending'
+RETURNING *;
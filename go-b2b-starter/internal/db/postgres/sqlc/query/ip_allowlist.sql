@@ -0,0 +1,34 @@
+-- name: CreateIPAllowlistEntry :one
+INSERT INTO organizations.ip_allowlist_entries (
+    organization_id,
+    cidr,
+    description,
+    created_by_account_id
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4
+) RETURNING
+    id,
+    organization_id,
+    cidr,
+    description,
+    created_by_account_id,
+    created_at;
+
+-- name: ListIPAllowlistEntriesByOrganization :many
+SELECT
+    id,
+    organization_id,
+    cidr,
+    description,
+    created_by_account_id,
+    created_at
+FROM organizations.ip_allowlist_entries
+WHERE organization_id = $1
+ORDER BY created_at ASC;
+
+-- name: DeleteIPAllowlistEntry :execrows
+DELETE FROM organizations.ip_allowlist_entries
+WHERE id = $1 AND organization_id = $2;
@@ -0,0 +1,55 @@
+-- name: CreateJobRun :one
+INSERT INTO jobs.job_runs (
+    job_name,
+    kind,
+    instance
+) VALUES (
+    $1,
+    $2,
+    $3
+) RETURNING *;
+
+-- name: FinishJobRun :one
+UPDATE jobs.job_runs
+SET status = $2,
+    error = $3,
+    finished_at = CURRENT_TIMESTAMP,
+    duration_ms = $4
+WHERE id = $1
+RETURNING *;
+
+-- name: GetJobRun :one
+SELECT * FROM jobs.job_runs
+WHERE id = $1;
+
+-- name: ListJobRuns :many
+SELECT * FROM jobs.job_runs
+WHERE (sqlc.narg(job_name)::text IS NULL OR job_name = sqlc.narg(job_name)::text)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY started_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountJobRuns :one
+SELECT COUNT(*) FROM jobs.job_runs
+WHERE (sqlc.narg(job_name)::text IS NULL OR job_name = sqlc.narg(job_name)::text)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text);
+
+-- name: ListLatestJobRuns :many
+-- Most recent run of each job
+SELECT DISTINCT ON (job_name) * FROM jobs.job_runs
+ORDER BY job_name, started_at DESC, id DESC;
+
+-- name: ListJobRunStats :many
+SELECT
+    job_name,
+    (MAX(finished_at) FILTER (WHERE status = 'succeeded'))::timestamp AS last_succeeded_at,
+    (MAX(finished_at) FILTER (WHERE status = 'failed'))::timestamp AS last_failed_at,
+    COUNT(*) AS total_runs,
+    COUNT(*) FILTER (WHERE status = 'failed') AS failed_runs
+FROM jobs.job_runs
+GROUP BY job_name
+ORDER BY job_name;
+
+-- name: DeleteJobRunsBefore :execrows
+DELETE FROM jobs.job_runs
+WHERE started_at < $1;
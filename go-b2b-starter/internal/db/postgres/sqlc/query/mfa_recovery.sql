@@ -0,0 +1,54 @@
+-- name: CreateMFARecoveryRequest :one
+INSERT INTO organizations.mfa_recovery_requests (
+    organization_id,
+    account_id,
+    requires_approval,
+    token_hash,
+    eligible_at,
+    expires_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING *;
+
+-- name: GetMFARecoveryRequestByID :one
+SELECT * FROM organizations.mfa_recovery_requests
+WHERE id = $1;
+
+-- name: ListMFARecoveryRequestsByOrganization :many
+SELECT * FROM organizations.mfa_recovery_requests
+WHERE organization_id = sqlc.arg(organization_id)
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+ORDER BY created_at DESC;
+
+-- name: DecideMFARecoveryRequest :one
+UPDATE organizations.mfa_recovery_requests
+SET status = $3,
+    decided_by_account_id = $4,
+    decided_at = NOW()
+WHERE id = $1
+  AND organization_id = $2
+  AND status = 'pending'
+  AND requires_approval = TRUE
+RETURNING *;
+
+-- name: CancelMFARecoveryRequest :one
+UPDATE organizations.mfa_recovery_requests
+SET status = 'cancelled'
+WHERE id = $1
+  AND organization_id = $2
+  AND account_id = $3
+  AND status IN ('pending', 'approved')
+RETURNING *;
+
+-- name: CompleteMFARecoveryRequest :one
+UPDATE organizations.mfa_recovery_requests
+SET status = 'completed',
+    completed_at = NOW()
+WHERE id = $1
+  AND status = $2
+RETURNING *;
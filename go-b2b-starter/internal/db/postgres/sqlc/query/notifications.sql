@@ -0,0 +1,83 @@
+-- name: ListNotificationPreferences :many
+-- Stored notification choices of an account
+SELECT
+    organization_id,
+    account_id,
+    notification_type,
+    channel,
+    enabled,
+    updated_at
+FROM organizations.notification_preferences
+WHERE account_id = @account_id::int
+  AND organization_id = @organization_id::int
+ORDER BY notification_type, channel;
+
+-- name: UpsertNotificationPreference :exec
+-- Turns a notification type on or off on a channel for an account
+INSERT INTO organizations.notification_preferences (
+    organization_id,
+    account_id,
+    notification_type,
+    channel,
+    enabled
+) VALUES (
+    @organization_id::int,
+    @account_id::int,
+    @notification_type::text,
+    @channel::text,
+    @enabled::boolean
+)
+ON CONFLICT (account_id, notification_type, channel) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+    updated_at = CURRENT_TIMESTAMP;
+
+-- name: CreateNotification :one
+-- Adds an in-app notification to an account's inbox
+INSERT INTO organizations.notifications (
+    organization_id,
+    account_id,
+    notification_type,
+    title,
+    body
+) VALUES (
+    @organization_id::int,
+    @account_id::int,
+    @notification_type::text,
+    @title::text,
+    @body::text
+)
+RETURNING
+    id,
+    organization_id,
+    account_id,
+    notification_type,
+    title,
+    body,
+    read_at,
+    created_at;
+
+-- name: ListNotifications :many
+-- In-app notifications of an account, newest first, optionally only unread ones
+SELECT
+    id,
+    organization_id,
+    account_id,
+    notification_type,
+    title,
+    body,
+    read_at,
+    created_at
+FROM organizations.notifications
+WHERE account_id = @account_id::int
+  AND organization_id = @organization_id::int
+  AND (NOT @unread_only::boolean OR read_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit::int;
+
+-- name: MarkNotificationRead :execrows
+-- Marks an account's in-app notification read; already read notifications keep their time
+UPDATE organizations.notifications
+SET read_at = COALESCE(read_at, CURRENT_TIMESTAMP)
+WHERE id = @id::bigint
+  AND account_id = @account_id::int
+  AND organization_id = @organization_id::int;
@@ -0,0 +1,44 @@
+-- name: CreateOAuthClient :one
+INSERT INTO organizations.oauth_clients (
+    organization_id,
+    account_id,
+    client_id,
+    secret_hash,
+    name,
+    scopes,
+    created_by_account_id
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+) RETURNING *;
+
+-- name: GetOAuthClientByClientID :one
+SELECT * FROM organizations.oauth_clients
+WHERE client_id = $1;
+
+-- name: GetOAuthClientByID :one
+SELECT * FROM organizations.oauth_clients
+WHERE organization_id = $1 AND id = $2;
+
+-- name: ListOAuthClientsByOrganization :many
+SELECT * FROM organizations.oauth_clients
+WHERE organization_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeOAuthClient :one
+UPDATE organizations.oauth_clients
+SET revoked_at = NOW()
+WHERE organization_id = $1
+  AND id = $2
+  AND revoked_at IS NULL
+RETURNING *;
+
+-- name: TouchOAuthClient :exec
+UPDATE organizations.oauth_clients
+SET last_used_at = NOW()
+WHERE id = $1;
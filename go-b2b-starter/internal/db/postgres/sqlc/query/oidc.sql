@@ -0,0 +1,77 @@
+-- name: ConsumeOIDCAuthorizationCode :one
+-- Marks an unused, unexpired code used; no row means it was spent, expired or never issued
+UPDATE organizations.oidc_authorization_codes
+SET used_at = NOW()
+WHERE code_hash = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+RETURNING *;
+
+-- name: CreateOIDCAuthorizationCode :one
+INSERT INTO organizations.oidc_authorization_codes (
+    oidc_client_id,
+    organization_id,
+    account_id,
+    code_hash,
+    subject,
+    email_verified,
+    redirect_uri,
+    scopes,
+    nonce,
+    code_challenge,
+    auth_time,
+    expires_at
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12
+) RETURNING *;
+
+-- name: CreateOIDCClient :one
+INSERT INTO organizations.oidc_clients (
+    organization_id,
+    client_id,
+    secret_hash,
+    name,
+    redirect_uris,
+    created_by_account_id
+) VALUES (
+    $1, $2, $3, $4, $5, $6
+) RETURNING *;
+
+-- name: DeleteExpiredOIDCAuthorizationCodes :execrows
+-- Codes only live for minutes; spent and expired ones are dropped as new ones are issued
+DELETE FROM organizations.oidc_authorization_codes
+WHERE expires_at < NOW() - INTERVAL '1 hour';
+
+-- name: GetOIDCClientByClientID :one
+SELECT * FROM organizations.oidc_clients
+WHERE client_id = $1;
+
+-- name: GetOIDCConsent :one
+SELECT * FROM organizations.oidc_consents
+WHERE oidc_client_id = $1 AND account_id = $2;
+
+-- name: ListOIDCClientsByOrganization :many
+SELECT * FROM organizations.oidc_clients
+WHERE organization_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeOIDCClient :one
+UPDATE organizations.oidc_clients
+SET revoked_at = NOW()
+WHERE organization_id = $1
+  AND id = $2
+  AND revoked_at IS NULL
+RETURNING *;
+
+-- name: UpsertOIDCConsent :one
+INSERT INTO organizations.oidc_consents (
+    oidc_client_id,
+    organization_id,
+    account_id,
+    scopes
+) VALUES (
+    $1, $2, $3, $4
+)
+ON CONFLICT (oidc_client_id, account_id) DO UPDATE
+SET scopes = EXCLUDED.scopes
+RETURNING *;
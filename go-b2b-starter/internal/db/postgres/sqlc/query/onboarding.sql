@@ -0,0 +1,33 @@
+-- name: ListOnboardingSteps :many
+SELECT * FROM onboarding.organization_steps
+WHERE organization_id = $1
+ORDER BY created_at;
+
+-- name: CompleteOnboardingStep :one
+-- Marks a pending or skipped step completed. Returns no row when it already was.
+INSERT INTO onboarding.organization_steps (
+    organization_id,
+    step_key,
+    status,
+    account_id
+) VALUES (
+    $1, $2, 'completed', $3
+)
+ON CONFLICT (organization_id, step_key) DO UPDATE
+SET status = 'completed',
+    account_id = EXCLUDED.account_id
+WHERE onboarding.organization_steps.status <> 'completed'
+RETURNING *;
+
+-- name: SkipOnboardingStep :one
+-- Marks a pending step skipped. Returns no row when it was already completed or skipped.
+INSERT INTO onboarding.organization_steps (
+    organization_id,
+    step_key,
+    status,
+    account_id
+) VALUES (
+    $1, $2, 'skipped', $3
+)
+ON CONFLICT (organization_id, step_key) DO NOTHING
+RETURNING *;
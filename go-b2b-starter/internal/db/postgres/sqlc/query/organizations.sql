@@ -2,11 +2,17 @@
 INSERT INTO organizations.organizations (
     slug,
     name,
-    status
+    status,
+    environment,
+    parent_organization_id,
+    expires_at
 ) VALUES (
     $1,
     $2,
-    $3
+    $3,
+    $4,
+    $5,
+    $6
 ) RETURNING
     id,
     slug,
@@ -16,7 +22,10 @@ INSERT INTO organizations.organizations (
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at;
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at;
 
 -- name: GetOrganizationByID :one
 SELECT
@@ -28,7 +37,10 @@ SELECT
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 FROM organizations.organizations
 WHERE id = $1;
 
@@ -42,7 +54,10 @@ SELECT
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 FROM organizations.organizations
 WHERE slug = $1;
 
@@ -56,7 +71,10 @@ SELECT
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 FROM organizations.organizations
 WHERE stytch_org_id = $1;
 
@@ -79,7 +97,10 @@ RETURNING
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at;
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at;
 
 -- name: UpdateOrganizationStytchInfo :one
 UPDATE organizations.organizations
@@ -98,7 +119,10 @@ RETURNING
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at;
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at;
 
 -- name: ListOrganizations :many
 SELECT
@@ -110,7 +134,10 @@ SELECT
     stytch_connection_id,
     stytch_connection_name,
     created_at,
-    updated_at
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
 FROM organizations.organizations
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
@@ -119,6 +146,29 @@ LIMIT $1 OFFSET $2;
 DELETE FROM organizations.organizations
 WHERE id = $1;
 
+-- name: ListStagingOrganizations :many
+-- Staging and sandbox organizations owned by a production organization
+SELECT
+    id,
+    slug,
+    name,
+    status,
+    stytch_org_id,
+    stytch_connection_id,
+    stytch_connection_name,
+    created_at,
+    updated_at,
+    environment,
+    parent_organization_id,
+    expires_at
+FROM organizations.organizations
+WHERE parent_organization_id = @parent_organization_id::int
+ORDER BY created_at DESC;
+
+-- name: CountStagingOrganizations :one
+SELECT COUNT(*) FROM organizations.organizations
+WHERE parent_organization_id = @parent_organization_id::int;
+
 -- Accounts queries
 
 -- name: CreateAccount :one
@@ -131,7 +181,8 @@ INSERT INTO organizations.accounts (
     stytch_role_slug,
     stytch_email_verified,
     role,
-    status
+    status,
+    kind
 ) VALUES (
     $1,
     $2,
@@ -141,7 +192,8 @@ INSERT INTO organizations.accounts (
     $6,
     $7,
     $8,
-    $9
+    $9,
+    $10
 ) RETURNING
     id,
     organization_id,
@@ -155,7 +207,13 @@ INSERT INTO organizations.accounts (
     status,
     last_login_at,
     created_at,
-    updated_at;
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind;
 
 -- name: GetAccountByID :one
 SELECT
@@ -171,7 +229,13 @@ SELECT
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 FROM organizations.accounts
 WHERE id = $1 AND organization_id = $2;
 
@@ -189,10 +253,40 @@ SELECT
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 FROM organizations.accounts
 WHERE email = $1 AND organization_id = $2;
 
+-- name: GetAccountByPublicID :one
+SELECT
+    id,
+    organization_id,
+    email,
+    full_name,
+    stytch_member_id,
+    stytch_role_id,
+    stytch_role_slug,
+    stytch_email_verified,
+    role,
+    status,
+    last_login_at,
+    created_at,
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
+FROM organizations.accounts
+WHERE public_id = $1 AND organization_id = $2;
+
 -- name: ListAccountsByOrganization :many
 SELECT
     id,
@@ -207,11 +301,100 @@ SELECT
     status,
     last_login_at,
     created_at,
-    updated_at
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
 FROM organizations.accounts
 WHERE organization_id = $1
 ORDER BY created_at DESC;
 
+-- name: ListAccountsFiltered :many
+-- Accounts of an organization matching the optional filters; pattern and email_pattern have LIKE wildcards (%, _) escaped.
+-- sort is created_desc (default), created_asc, email_asc, email_desc, name_asc or last_login_desc; ties are newest first.
+SELECT
+    id,
+    organization_id,
+    email,
+    full_name,
+    stytch_member_id,
+    stytch_role_id,
+    stytch_role_slug,
+    stytch_email_verified,
+    role,
+    status,
+    last_login_at,
+    created_at,
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind
+FROM organizations.accounts
+WHERE organization_id = @organization_id::int
+  AND (sqlc.narg(pattern)::text IS NULL OR email ILIKE '%' || sqlc.narg(pattern)::text || '%' OR full_name ILIKE '%' || sqlc.narg(pattern)::text || '%')
+  AND (sqlc.narg(email_pattern)::text IS NULL OR email ILIKE '%' || sqlc.narg(email_pattern)::text || '%')
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role)::text)
+  AND (sqlc.narg(email_verified)::boolean IS NULL OR stytch_email_verified = sqlc.narg(email_verified)::boolean)
+  AND (sqlc.narg(created_after)::timestamp IS NULL OR created_at >= sqlc.narg(created_after)::timestamp)
+  AND (sqlc.narg(created_before)::timestamp IS NULL OR created_at < sqlc.narg(created_before)::timestamp)
+  AND (sqlc.narg(tag)::text IS NULL OR EXISTS (
+      SELECT 1 FROM organizations.account_tags at
+      INNER JOIN organizations.tags t ON t.id = at.tag_id
+      WHERE at.account_id = accounts.id AND t.name = sqlc.narg(tag)::text
+  ))
+  AND (sqlc.narg(service)::boolean IS NULL OR sqlc.narg(service)::boolean = (
+      EXISTS (SELECT 1 FROM organizations.service_accounts sa WHERE sa.account_id = accounts.id)
+      OR EXISTS (SELECT 1 FROM organizations.oauth_clients oc WHERE oc.account_id = accounts.id)
+  ))
+ORDER BY
+    CASE WHEN @sort::text = 'created_asc' THEN created_at END ASC,
+    CASE WHEN @sort::text = 'email_asc' THEN email END ASC,
+    CASE WHEN @sort::text = 'email_desc' THEN email END DESC,
+    CASE WHEN @sort::text = 'name_asc' THEN full_name END ASC,
+    CASE WHEN @sort::text = 'last_login_desc' THEN last_login_at END DESC NULLS LAST,
+    created_at DESC,
+    id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountAccountsFiltered :one
+SELECT COUNT(*) FROM organizations.accounts
+WHERE organization_id = @organization_id::int
+  AND (sqlc.narg(pattern)::text IS NULL OR email ILIKE '%' || sqlc.narg(pattern)::text || '%' OR full_name ILIKE '%' || sqlc.narg(pattern)::text || '%')
+  AND (sqlc.narg(email_pattern)::text IS NULL OR email ILIKE '%' || sqlc.narg(email_pattern)::text || '%')
+  AND (sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text)
+  AND (sqlc.narg(role)::text IS NULL OR role = sqlc.narg(role)::text)
+  AND (sqlc.narg(email_verified)::boolean IS NULL OR stytch_email_verified = sqlc.narg(email_verified)::boolean)
+  AND (sqlc.narg(created_after)::timestamp IS NULL OR created_at >= sqlc.narg(created_after)::timestamp)
+  AND (sqlc.narg(created_before)::timestamp IS NULL OR created_at < sqlc.narg(created_before)::timestamp)
+  AND (sqlc.narg(tag)::text IS NULL OR EXISTS (
+      SELECT 1 FROM organizations.account_tags at
+      INNER JOIN organizations.tags t ON t.id = at.tag_id
+      WHERE at.account_id = accounts.id AND t.name = sqlc.narg(tag)::text
+  ))
+  AND (sqlc.narg(service)::boolean IS NULL OR sqlc.narg(service)::boolean = (
+      EXISTS (SELECT 1 FROM organizations.service_accounts sa WHERE sa.account_id = accounts.id)
+      OR EXISTS (SELECT 1 FROM organizations.oauth_clients oc WHERE oc.account_id = accounts.id)
+  ));
+
+-- name: CountPlusAddressedEmails :one
+-- Stored member emails with a "+tag" in the local part, which lookups no
+-- longer match once ACCOUNT_EMAIL_CANONICALIZE_PLUS strips the tag
+SELECT (
+    (SELECT COUNT(*) FROM organizations.accounts a
+     WHERE strpos(split_part(a.email, '@', 1), '+') > 1)
+  + (SELECT COUNT(*) FROM organizations.secondary_emails s
+     WHERE strpos(split_part(s.email, '@', 1), '+') > 1)
+  + (SELECT COUNT(*) FROM rbac.role_assignments r
+     WHERE r.email IS NOT NULL AND strpos(split_part(r.email, '@', 1), '+') > 1)
+)::bigint AS plus_addressed;
+
 -- name: UpdateAccount :one
 UPDATE organizations.accounts
 SET
@@ -236,7 +419,40 @@ RETURNING
     status,
     last_login_at,
     created_at,
-    updated_at;
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind;
+
+-- name: UpdateAccountEmail :one
+UPDATE organizations.accounts
+SET
+    email = $3,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND organization_id = $2
+RETURNING
+    id,
+    organization_id,
+    email,
+    full_name,
+    stytch_member_id,
+    stytch_role_id,
+    stytch_role_slug,
+    stytch_email_verified,
+    role,
+    status,
+    last_login_at,
+    created_at,
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind;
 
 -- name: UpdateAccountStytchInfo :one
 UPDATE organizations.accounts
@@ -260,7 +476,13 @@ RETURNING
     status,
     last_login_at,
     created_at,
-    updated_at;
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind;
 
 -- name: UpdateAccountLastLogin :one
 UPDATE organizations.accounts
@@ -281,7 +503,46 @@ RETURNING
     status,
     last_login_at,
     created_at,
-    updated_at;
+    updated_at,
+    metadata,
+    avatar_file_id,
+    avatar_key,
+    avatar_url,
+    public_id,
+    kind;
+
+-- name: GetAccountMetadata :one
+SELECT metadata FROM organizations.accounts
+WHERE id = $1 AND organization_id = $2;
+
+-- name: PatchAccountMetadata :one
+-- Merges the patch's top-level keys into the account metadata; keys set to null in the patch are removed
+UPDATE organizations.accounts
+SET
+    metadata = (metadata || @patch::jsonb) - ARRAY(SELECT key FROM jsonb_each(@patch::jsonb) WHERE value = 'null'::jsonb),
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id::int AND organization_id = @organization_id::int
+RETURNING metadata;
+
+-- name: SetAccountAvatar :one
+-- Replaces the account avatar and returns the file of the previous one, which the caller deletes
+UPDATE organizations.accounts a
+SET
+    avatar_file_id = sqlc.narg(avatar_file_id)::int,
+    avatar_key = sqlc.narg(avatar_key)::text,
+    avatar_url = sqlc.narg(avatar_url)::text,
+    updated_at = CURRENT_TIMESTAMP
+FROM (
+    SELECT id, avatar_file_id FROM organizations.accounts
+    WHERE id = @id::int AND organization_id = @organization_id::int
+    FOR UPDATE
+) previous
+WHERE a.id = previous.id
+RETURNING previous.avatar_file_id AS previous_avatar_file_id;
+
+-- name: GetAvatarFileIDByKey :one
+SELECT avatar_file_id FROM organizations.accounts
+WHERE avatar_key = @avatar_key::text AND avatar_file_id IS NOT NULL;
 
 -- name: DeleteAccount :exec
 UPDATE organizations.accounts
@@ -302,14 +563,35 @@ SELECT
     o.stytch_connection_id,
     o.stytch_connection_name,
     o.created_at,
-    o.updated_at
+    o.updated_at,
+    o.environment,
+    o.parent_organization_id,
+    o.expires_at
 FROM organizations.organizations o
 INNER JOIN organizations.accounts a ON o.id = a.organization_id
 WHERE a.email = $1
-  AND a.status = 'active'
-  AND o.status = 'active'
+  AND a.status IN ('active', 'dormant')
+  AND o.status IN ('active', 'dormant')
+ORDER BY (o.environment = 'production') DESC, o.id
 LIMIT 1;
 
+-- Active organizations the user has an active account in, for switching between them
+-- name: ListMembershipsByEmail :many
+SELECT
+    o.id AS organization_id,
+    o.slug,
+    o.name,
+    o.environment,
+    o.stytch_org_id,
+    a.id AS account_id,
+    a.role
+FROM organizations.organizations o
+INNER JOIN organizations.accounts a ON o.id = a.organization_id
+WHERE a.email = $1
+  AND a.status IN ('active', 'dormant')
+  AND o.status IN ('active', 'dormant')
+ORDER BY (o.environment = 'production') DESC, o.name, o.id;
+
 -- name: GetAccountOrganization :one
 SELECT
     o.id,
@@ -320,7 +602,10 @@ SELECT
     o.stytch_connection_id,
     o.stytch_connection_name,
     o.created_at,
-    o.updated_at
+    o.updated_at,
+    o.environment,
+    o.parent_organization_id,
+    o.expires_at
 FROM organizations.organizations o
 INNER JOIN organizations.accounts a ON o.id = a.organization_id
 WHERE a.id = $1;
@@ -0,0 +1,59 @@
+-- name: CreateOrganizationImport :one
+INSERT INTO portability.organization_imports (
+    organization_id,
+    source_organization,
+    strategy,
+    requested_by,
+    total_items,
+    result
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6
+) RETURNING *;
+
+-- name: GetOrganizationImport :one
+SELECT * FROM portability.organization_imports
+WHERE id = $1;
+
+-- name: ListOrganizationImports :many
+SELECT * FROM portability.organization_imports
+WHERE (sqlc.narg(organization_id)::int IS NULL OR organization_id = sqlc.narg(organization_id)::int)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
+-- name: CountOrganizationImports :one
+SELECT COUNT(*) FROM portability.organization_imports
+WHERE (sqlc.narg(organization_id)::int IS NULL OR organization_id = sqlc.narg(organization_id)::int);
+
+-- name: UpdateOrganizationImportProgress :exec
+UPDATE portability.organization_imports
+SET processed_items = $2,
+    result = $3,
+    updated_at = NOW()
+WHERE id = $1
+  AND status = 'running';
+
+-- name: CompleteOrganizationImport :one
+UPDATE portability.organization_imports
+SET status = $2,
+    processed_items = $3,
+    result = $4,
+    error = $5,
+    updated_at = NOW(),
+    completed_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: FailStaleOrganizationImports :execrows
+-- Marks an organization's running imports that stopped reporting progress as failed
+UPDATE portability.organization_imports
+SET status = 'failed',
+    error = 'import was interrupted',
+    completed_at = NOW()
+WHERE organization_id = @organization_id::int
+  AND status = 'running'
+  AND updated_at < @stale_before::timestamp;
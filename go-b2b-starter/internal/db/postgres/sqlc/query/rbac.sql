@@ -0,0 +1,70 @@
+-- name: ListRoles :many
+SELECT
+    r.id,
+    r.name,
+    r.description,
+    r.is_system,
+    r.created_at,
+    r.updated_at,
+    COALESCE(
+        array_agg(rp.permission_id ORDER BY rp.permission_id) FILTER (WHERE rp.permission_id IS NOT NULL),
+        '{}'
+    )::text[] AS permissions
+FROM rbac.roles r
+LEFT JOIN rbac.role_permissions rp ON rp.role_id = r.id
+GROUP BY r.id
+ORDER BY r.is_system DESC, r.created_at, r.id;
+
+-- name: GetRole :one
+SELECT
+    r.id,
+    r.name,
+    r.description,
+    r.is_system,
+    r.created_at,
+    r.updated_at,
+    COALESCE(
+        array_agg(rp.permission_id ORDER BY rp.permission_id) FILTER (WHERE rp.permission_id IS NOT NULL),
+        '{}'
+    )::text[] AS permissions
+FROM rbac.roles r
+LEFT JOIN rbac.role_permissions rp ON rp.role_id = r.id
+WHERE r.id = $1
+GROUP BY r.id;
+
+-- name: CreateRole :one
+INSERT INTO rbac.roles (
+    id,
+    name,
+    description
+) VALUES (
+    $1,
+    $2,
+    $3
+) RETURNING *;
+
+-- name: UpdateRole :one
+UPDATE rbac.roles
+SET name = $2,
+    description = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteRole :execrows
+DELETE FROM rbac.roles
+WHERE id = $1
+  AND is_system = FALSE;
+
+-- name: SetRolePermissions :exec
+WITH removed AS (
+    DELETE FROM rbac.role_permissions
+    WHERE role_id = @role_id
+      AND permission_id <> ALL(@permission_ids::text[])
+)
+INSERT INTO rbac.role_permissions (role_id, permission_id)
+SELECT @role_id, unnest(@permission_ids::text[])
+ON CONFLICT DO NOTHING;
+
+-- name: ListPermissions :many
+SELECT * FROM rbac.permissions
+ORDER BY id;
@@ -0,0 +1,141 @@
+-- name: CreateOrganizationRoleAssignment :one
+-- Assigns a role to an account within its organization
+INSERT INTO rbac.role_assignments (
+    role_id,
+    organization_id,
+    account_id,
+    assigned_by_account_id
+) VALUES (
+    @role_id::text,
+    @organization_id::int,
+    @account_id::int,
+    sqlc.narg(assigned_by_account_id)::int
+) RETURNING
+    id,
+    role_id,
+    organization_id,
+    account_id,
+    email,
+    assigned_by_account_id,
+    created_at;
+
+-- name: CreateGlobalRoleAssignment :one
+-- Assigns a role to a user in every organization they have an account in
+INSERT INTO rbac.role_assignments (
+    role_id,
+    email
+) VALUES (
+    @role_id::text,
+    @email::text
+) RETURNING
+    id,
+    role_id,
+    organization_id,
+    account_id,
+    email,
+    assigned_by_account_id,
+    created_at;
+
+-- name: ListAccountRoleAssignments :many
+-- Roles assigned to an account, within its organization and globally through its email
+SELECT
+    ra.id,
+    ra.role_id,
+    ra.organization_id,
+    ra.account_id,
+    ra.email,
+    ra.assigned_by_account_id,
+    ra.created_at
+FROM rbac.role_assignments ra
+WHERE (ra.organization_id = @organization_id::int AND ra.account_id = @account_id::int)
+   OR (ra.organization_id IS NULL AND ra.email = (
+        SELECT a.email FROM organizations.accounts a
+        WHERE a.id = @account_id::int AND a.organization_id = @organization_id::int
+   ))
+ORDER BY ra.organization_id NULLS FIRST, ra.role_id;
+
+-- name: ListGlobalRoleAssignments :many
+-- Global assignments, optionally of one email
+SELECT
+    id,
+    role_id,
+    organization_id,
+    account_id,
+    email,
+    assigned_by_account_id,
+    created_at
+FROM rbac.role_assignments
+WHERE organization_id IS NULL
+  AND (@email::text = '' OR email = @email::text)
+ORDER BY email, role_id;
+
+-- name: DeleteOrganizationRoleAssignment :one
+-- Removes a role from an account within its organization
+DELETE FROM rbac.role_assignments
+WHERE organization_id = @organization_id::int
+  AND account_id = @account_id::int
+  AND role_id = @role_id::text
+RETURNING
+    id,
+    role_id,
+    organization_id,
+    account_id,
+    email,
+    assigned_by_account_id,
+    created_at;
+
+-- name: DeleteGlobalRoleAssignment :one
+-- Removes a global assignment
+DELETE FROM rbac.role_assignments
+WHERE id = @id::int
+  AND organization_id IS NULL
+RETURNING
+    id,
+    role_id,
+    organization_id,
+    account_id,
+    email,
+    assigned_by_account_id,
+    created_at;
+
+-- name: LockRoleAssignments :exec
+-- Serializes assignment removals so concurrent ones cannot remove every admin
+SELECT pg_advisory_xact_lock(hashtext('rbac.role_assignments'));
+
+-- name: CountOrganizationAdmins :one
+-- Active and dormant accounts holding the admin role, from the auth provider
+-- (legacy owner included) or an assignment
+SELECT COUNT(*) FROM organizations.accounts a
+WHERE a.organization_id = @organization_id::int
+  AND a.status IN ('active', 'dormant')
+  AND (
+    a.role IN ('admin', 'owner')
+    OR EXISTS (
+        SELECT 1 FROM rbac.role_assignments ra
+        WHERE ra.role_id = 'admin'
+          AND ((ra.organization_id = a.organization_id AND ra.account_id = a.id)
+            OR (ra.organization_id IS NULL AND ra.email = a.email))
+    )
+  );
+
+-- name: ListOrganizationsWithoutAdmins :many
+-- Organizations the email has an active or dormant account in where no
+-- account holds the admin role
+SELECT a.organization_id FROM organizations.accounts a
+WHERE a.email = @email::text
+  AND a.status IN ('active', 'dormant')
+  AND NOT EXISTS (
+    SELECT 1 FROM organizations.accounts b
+    WHERE b.organization_id = a.organization_id
+      AND b.status IN ('active', 'dormant')
+      AND (
+        b.role IN ('admin', 'owner')
+        OR EXISTS (
+            SELECT 1 FROM rbac.role_assignments ra
+            WHERE ra.role_id = 'admin'
+              AND ((ra.organization_id = b.organization_id AND ra.account_id = b.id)
+                OR (ra.organization_id IS NULL AND ra.email = b.email))
+        )
+      )
+  )
+ORDER BY a.organization_id;
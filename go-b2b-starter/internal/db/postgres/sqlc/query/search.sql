@@ -0,0 +1,55 @@
+-- Global search queries
+-- query is matched as entered; pattern is the same text with LIKE wildcards (%, _) escaped
+
+-- name: SearchAccounts :many
+SELECT
+    a.id,
+    a.email,
+    a.full_name,
+    a.role,
+    (CASE
+        WHEN lower(a.email) = lower(sqlc.arg(query)::text) OR lower(a.full_name) = lower(sqlc.arg(query)::text) THEN 1.0
+        WHEN a.email ILIKE sqlc.arg(pattern)::text || '%' OR a.full_name ILIKE sqlc.arg(pattern)::text || '%' THEN 0.8
+        ELSE 0.5
+    END)::double precision AS rank
+FROM organizations.accounts a
+WHERE a.organization_id = sqlc.arg(organization_id)
+  AND a.status IN ('active', 'dormant')
+  AND (a.email ILIKE '%' || sqlc.arg(pattern)::text || '%' OR a.full_name ILIKE '%' || sqlc.arg(pattern)::text || '%')
+ORDER BY rank DESC, a.full_name
+LIMIT sqlc.arg(result_limit);
+
+-- name: SearchDocuments :many
+SELECT
+    d.id,
+    d.title,
+    d.file_name,
+    d.status,
+    d.created_at,
+    ts_headline('english', COALESCE(d.extracted_text, ''), plainto_tsquery('english', sqlc.arg(query)::text),
+        'MaxFragments=1, MaxWords=20, MinWords=5')::text AS snippet,
+    (ts_rank(to_tsvector('english', d.title || ' ' || COALESCE(d.extracted_text, '')), plainto_tsquery('english', sqlc.arg(query)::text))
+        + CASE WHEN d.title ILIKE '%' || sqlc.arg(pattern)::text || '%' THEN 0.5 ELSE 0 END)::double precision AS rank
+FROM documents.documents d
+WHERE d.organization_id = sqlc.arg(organization_id)
+  AND (to_tsvector('english', d.title || ' ' || COALESCE(d.extracted_text, '')) @@ plainto_tsquery('english', sqlc.arg(query)::text)
+       OR d.title ILIKE '%' || sqlc.arg(pattern)::text || '%')
+ORDER BY rank DESC, d.created_at DESC
+LIMIT sqlc.arg(result_limit);
+
+-- name: SearchChatSessions :many
+SELECT
+    s.id,
+    s.title,
+    s.updated_at,
+    (CASE
+        WHEN lower(s.title) = lower(sqlc.arg(query)::text) THEN 1.0
+        WHEN s.title ILIKE sqlc.arg(pattern)::text || '%' THEN 0.8
+        ELSE 0.5
+    END)::double precision AS rank
+FROM cognitive.chat_sessions s
+WHERE s.organization_id = sqlc.arg(organization_id)
+  AND s.account_id = sqlc.arg(account_id)
+  AND s.title ILIKE '%' || sqlc.arg(pattern)::text || '%'
+ORDER BY rank DESC, s.updated_at DESC
+LIMIT sqlc.arg(result_limit);
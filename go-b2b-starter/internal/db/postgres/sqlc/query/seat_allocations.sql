@@ -0,0 +1,103 @@
+-- name: GetSeatAllocation :one
+SELECT * FROM subscription_billing.seat_allocations
+WHERE organization_id = @organization_id::int;
+
+-- name: LockSeatAllocation :one
+-- Locks an organization's allocation until the transaction ends, so members
+-- added at the same time are counted against the seats one after the other
+SELECT * FROM subscription_billing.seat_allocations
+WHERE organization_id = @organization_id::int
+FOR UPDATE;
+
+-- name: UpsertPurchasedSeats :one
+-- Records the seat quantity of an organization's subscription
+INSERT INTO subscription_billing.seat_allocations (
+    organization_id,
+    purchased_seats
+) VALUES (
+    @organization_id::int,
+    @purchased_seats::int
+)
+ON CONFLICT (organization_id) DO UPDATE
+SET purchased_seats = EXCLUDED.purchased_seats
+RETURNING *;
+
+-- name: SetSeatAutoExpand :one
+INSERT INTO subscription_billing.seat_allocations (
+    organization_id,
+    auto_expand
+) VALUES (
+    @organization_id::int,
+    @auto_expand::boolean
+)
+ON CONFLICT (organization_id) DO UPDATE
+SET auto_expand = EXCLUDED.auto_expand
+RETURNING *;
+
+-- name: CountBillableSeats :one
+-- Members that occupy a seat: active and dormant human accounts, so service
+-- accounts, OAuth clients and guests are not billed
+SELECT COUNT(*)::int AS used_seats FROM organizations.accounts
+WHERE organization_id = @organization_id::int
+  AND status IN ('active', 'dormant')
+  AND kind = 'human';
+
+-- name: RequestSeatSync :exec
+-- Schedules a sync at due_at unless one is already scheduled, so a burst of
+-- membership changes is synced once
+INSERT INTO subscription_billing.seat_allocations (
+    organization_id,
+    sync_due_at,
+    sync_requested_at
+) VALUES (
+    @organization_id::int,
+    @due_at::timestamp,
+    @requested_at::timestamp
+)
+ON CONFLICT (organization_id) DO UPDATE
+SET sync_due_at = COALESCE(subscription_billing.seat_allocations.sync_due_at, EXCLUDED.sync_due_at),
+    sync_requested_at = EXCLUDED.sync_requested_at;
+
+-- name: RequestSeatSyncForSubscribed :execrows
+-- Schedules a sync at due_at for every organization with an active or
+-- trialing subscription, keeping syncs already scheduled
+INSERT INTO subscription_billing.seat_allocations (
+    organization_id,
+    sync_due_at,
+    sync_requested_at
+)
+SELECT organization_id, @due_at::timestamp, @requested_at::timestamp
+FROM subscription_billing.subscriptions
+WHERE subscription_status IN ('active', 'trialing')
+ON CONFLICT (organization_id) DO UPDATE
+SET sync_due_at = COALESCE(subscription_billing.seat_allocations.sync_due_at, EXCLUDED.sync_due_at),
+    sync_requested_at = EXCLUDED.sync_requested_at;
+
+-- name: ListDueSeatAllocations :many
+-- Allocations whose sync is due, oldest first
+SELECT * FROM subscription_billing.seat_allocations
+WHERE sync_due_at <= @due_before::timestamp
+ORDER BY sync_due_at
+LIMIT @row_limit::int;
+
+-- name: MarkSeatAllocationSynced :one
+-- Records a sync whose seats were counted at counted_at. A change requested
+-- after the count keeps the sync due so it is picked up again.
+UPDATE subscription_billing.seat_allocations
+SET used_seats = @used_seats::int,
+    purchased_seats = @purchased_seats::int,
+    synced_at = CURRENT_TIMESTAMP,
+    sync_error = NULL,
+    sync_due_at = CASE
+        WHEN sync_requested_at > @counted_at::timestamp THEN sync_due_at
+        ELSE NULL
+    END
+WHERE organization_id = @organization_id::int
+RETURNING *;
+
+-- name: MarkSeatAllocationFailed :exec
+-- Records a failed sync and schedules the retry
+UPDATE subscription_billing.seat_allocations
+SET sync_error = @sync_error::text,
+    sync_due_at = @retry_at::timestamp
+WHERE organization_id = @organization_id::int;
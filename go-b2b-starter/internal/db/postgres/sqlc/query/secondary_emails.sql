@@ -0,0 +1,210 @@
+-- name: CreateSecondaryEmail :one
+-- Adds an unverified secondary address to an account
+INSERT INTO organizations.secondary_emails (
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at
+) VALUES (
+    @organization_id::int,
+    @account_id::int,
+    @email::text,
+    @verification_token_hash::text,
+    @verification_expires_at::timestamp
+) RETURNING
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at;
+
+-- name: ListAccountSecondaryEmails :many
+-- Secondary addresses of an account, oldest first
+SELECT
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at
+FROM organizations.secondary_emails
+WHERE account_id = @account_id::int
+  AND organization_id = @organization_id::int
+ORDER BY created_at, id;
+
+-- name: GetSecondaryEmail :one
+-- One of an account's secondary addresses
+SELECT
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at
+FROM organizations.secondary_emails
+WHERE id = @id::int
+  AND account_id = @account_id::int
+  AND organization_id = @organization_id::int;
+
+-- name: GetSecondaryEmailByAddress :one
+-- The secondary address of any account of the organization, verified or not
+SELECT
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at
+FROM organizations.secondary_emails
+WHERE organization_id = @organization_id::int
+  AND email = @email::text;
+
+-- name: GetSecondaryEmailByVerificationToken :one
+-- The unverified address a verification link was sent to
+SELECT
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at
+FROM organizations.secondary_emails
+WHERE verification_token_hash = @verification_token_hash::text;
+
+-- name: ListNotifiedSecondaryEmails :many
+-- Verified secondary addresses of an account that receive email notifications
+SELECT email
+FROM organizations.secondary_emails
+WHERE account_id = @account_id::int
+  AND organization_id = @organization_id::int
+  AND verified_at IS NOT NULL
+  AND notifications
+ORDER BY created_at, id;
+
+-- name: SetSecondaryEmailVerification :one
+-- Replaces the verification link of an unverified address
+UPDATE organizations.secondary_emails
+SET
+    verification_token_hash = @verification_token_hash::text,
+    verification_expires_at = @verification_expires_at::timestamp
+WHERE id = @id::int
+  AND verified_at IS NULL
+RETURNING
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at;
+
+-- name: VerifySecondaryEmail :one
+-- Marks an address verified and retires its verification link
+UPDATE organizations.secondary_emails
+SET
+    verified_at = NOW(),
+    verification_token_hash = NULL,
+    verification_expires_at = NULL
+WHERE id = @id::int
+  AND verified_at IS NULL
+RETURNING
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at;
+
+-- name: SetSecondaryEmailNotifications :one
+-- Turns email notifications to a secondary address on or off
+UPDATE organizations.secondary_emails
+SET notifications = @notifications::boolean
+WHERE id = @id::int
+  AND account_id = @account_id::int
+  AND organization_id = @organization_id::int
+RETURNING
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at;
+
+-- name: LockSecondaryEmail :one
+-- Locks one of an account's secondary addresses for a primary address switch
+SELECT
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at
+FROM organizations.secondary_emails
+WHERE id = @id::int
+  AND account_id = @account_id::int
+  AND organization_id = @organization_id::int
+FOR UPDATE;
+
+-- name: ReplaceSecondaryEmailAddress :one
+-- Stores the previous primary address in the secondary address that became
+-- primary. The previous primary address counts as verified and does not
+-- receive notifications until turned on.
+UPDATE organizations.secondary_emails
+SET
+    email = @email::text,
+    verified_at = NOW(),
+    notifications = FALSE = NULL
+WHERE id = @id::int
+RETURNING
+    id,
+    organization_id,
+    account_id,
+    email,
+    verification_token_hash,
+    verification_expires_at,
+    verified_at,
+    notifications,
+    created_at;
+
+-- name: DeleteSecondaryEmail :execrows
+-- Removes one of an account's secondary addresses
+DELETE FROM organizations.secondary_emails
+WHERE id = @id::int
+  AND account_id = @account_id::int
+  AND organization_id = @organization_id::int;
+
+-- name: DeleteExpiredSecondaryEmail :execrows
+-- Removes an address whose verification link expired, freeing it for
+-- another account
+DELETE FROM organizations.secondary_emails
+WHERE id = @id::int
+  AND verified_at IS NULL
+  AND verification_expires_at <= NOW();
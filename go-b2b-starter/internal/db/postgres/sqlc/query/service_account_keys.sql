@@ -0,0 +1,64 @@
+-- name: CreateServiceAccountKey :one
+INSERT INTO organizations.service_account_keys (
+    organization_id,
+    service_account_id,
+    name,
+    key_prefix,
+    key_hash,
+    expires_at,
+    created_by_account_id
+) VALUES (
+    @organization_id,
+    @service_account_id,
+    @name,
+    @key_prefix,
+    @key_hash,
+    sqlc.narg(expires_at),
+    sqlc.narg(created_by_account_id)
+) RETURNING *;
+
+-- name: ListServiceAccountKeys :many
+SELECT * FROM organizations.service_account_keys
+WHERE organization_id = @organization_id AND service_account_id = @service_account_id
+ORDER BY created_at DESC;
+
+-- name: CountActiveServiceAccountKeys :one
+SELECT COUNT(*) FROM organizations.service_account_keys
+WHERE service_account_id = @service_account_id
+  AND revoked_at IS NULL
+  AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: RevokeServiceAccountKey :one
+UPDATE organizations.service_account_keys
+SET revoked_at = NOW()
+WHERE organization_id = @organization_id
+  AND service_account_id = @service_account_id
+  AND id = @id
+  AND revoked_at IS NULL
+RETURNING *;
+
+-- name: GetServiceAccountKeyCredential :one
+-- Unrevoked key with what the auth middleware needs to build the identity of
+-- its service account
+SELECT
+    k.id,
+    k.organization_id,
+    k.service_account_id,
+    k.expires_at,
+    sa.username,
+    sa.disabled_at,
+    a.email,
+    a.role,
+    o.stytch_org_id
+FROM organizations.service_account_keys k
+INNER JOIN organizations.service_accounts sa ON sa.id = k.service_account_id
+INNER JOIN organizations.accounts a ON a.id = sa.account_id
+INNER JOIN organizations.organizations o ON o.id = k.organization_id
+WHERE k.key_hash = @key_hash AND k.revoked_at IS NULL;
+
+-- name: TouchServiceAccountKey :exec
+-- Records a use of the key at most once a minute
+UPDATE organizations.service_account_keys
+SET last_used_at = NOW()
+WHERE id = @id
+  AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
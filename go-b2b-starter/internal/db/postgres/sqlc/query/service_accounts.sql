@@ -0,0 +1,42 @@
+-- name: CreateServiceAccount :one
+INSERT INTO organizations.service_accounts (
+    organization_id,
+    account_id,
+    username,
+    name,
+    description,
+    owner_account_id,
+    created_by_account_id
+) VALUES (
+    @organization_id,
+    @account_id,
+    @username,
+    @name,
+    @description,
+    sqlc.narg(owner_account_id),
+    sqlc.narg(created_by_account_id)
+) RETURNING *;
+
+-- name: GetServiceAccountByID :one
+SELECT * FROM organizations.service_accounts
+WHERE organization_id = @organization_id AND id = @id;
+
+-- name: ListServiceAccountsByOrganization :many
+SELECT * FROM organizations.service_accounts
+WHERE organization_id = @organization_id
+ORDER BY created_at DESC;
+
+-- name: UpdateServiceAccount :one
+UPDATE organizations.service_accounts
+SET name = @name,
+    description = @description,
+    owner_account_id = sqlc.narg(owner_account_id)
+WHERE organization_id = @organization_id AND id = @id
+RETURNING *;
+
+-- name: SetServiceAccountDisabled :one
+-- Disables or enables the service account; disabling keeps the original time
+UPDATE organizations.service_accounts
+SET disabled_at = CASE WHEN @disabled::boolean THEN COALESCE(disabled_at, NOW()) END
+WHERE organization_id = @organization_id AND id = @id
+RETURNING *;
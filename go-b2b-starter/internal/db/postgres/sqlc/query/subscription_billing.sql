@@ -25,9 +25,11 @@ INSERT INTO subscription_billing.subscriptions (
     cancel_at_period_end,
     canceled_at,
     metadata,
+    trial_start,
+    trial_end,
     updated_at
 ) VALUES (
-    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CURRENT_TIMESTAMP
+    $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, CURRENT_TIMESTAMP
 )
 ON CONFLICT (organization_id)
 DO UPDATE SET
@@ -42,6 +44,9 @@ DO UPDATE SET
     cancel_at_period_end = EXCLUDED.cancel_at_period_end,
     canceled_at = EXCLUDED.canceled_at,
     metadata = EXCLUDED.metadata,
+    -- A trial period is kept after the subscription converts
+    trial_start = COALESCE(EXCLUDED.trial_start, subscription_billing.subscriptions.trial_start),
+    trial_end = COALESCE(EXCLUDED.trial_end, subscription_billing.subscriptions.trial_end),
     updated_at = CURRENT_TIMESTAMP
 RETURNING *;
 
@@ -108,8 +113,9 @@ SELECT
     s.cancel_at_period_end,
     q.invoice_count,
     q.max_seats,
+    s.trial_end,
     CASE
-        WHEN s.subscription_status = 'active' AND q.invoice_count > 0
+        WHEN s.subscription_status IN ('active', 'trialing') AND q.invoice_count > 0
         THEN TRUE
         ELSE FALSE
     END AS can_process_invoice
@@ -136,3 +142,22 @@ WHERE
     s.subscription_status = 'active'
     AND q.invoice_count <= $1
 ORDER BY q.invoice_count ASC;
+
+-- name: GetBillingSettings :one
+-- Get the display currency and locale of an organization
+SELECT * FROM subscription_billing.billing_settings
+WHERE organization_id = $1;
+
+-- name: UpsertBillingSettings :one
+-- Set the display currency and locale of an organization
+INSERT INTO subscription_billing.billing_settings (
+    organization_id,
+    currency,
+    locale
+) VALUES (
+    $1, $2, $3
+)
+ON CONFLICT (organization_id) DO UPDATE SET
+    currency = EXCLUDED.currency,
+    locale = EXCLUDED.locale
+RETURNING *;
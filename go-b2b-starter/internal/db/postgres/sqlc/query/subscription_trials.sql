@@ -0,0 +1,80 @@
+-- name: StartTrial :one
+-- Starts a local trial for an organization without a subscription; returns
+-- no row when it already has one
+INSERT INTO subscription_billing.subscriptions (
+    organization_id,
+    external_customer_id,
+    subscription_id,
+    subscription_status,
+    product_id,
+    product_name,
+    plan_name,
+    current_period_start,
+    current_period_end,
+    trial_start,
+    trial_end,
+    metadata
+) VALUES (
+    @organization_id, @external_customer_id, @subscription_id, 'trialing',
+    @product_id, @product_name, @plan_name, @trial_start, @trial_end,
+    @trial_start, @trial_end, '{}'
+)
+ON CONFLICT (organization_id) DO NOTHING
+RETURNING *;
+
+-- name: ListTrialsEndingBetween :many
+-- Trialing subscriptions ending in (ends_after, ends_before] whose admins
+-- were not reminded yet, soonest first
+SELECT * FROM subscription_billing.subscriptions
+WHERE subscription_status = 'trialing'
+  AND trial_end > @ends_after
+  AND trial_end <= @ends_before
+  AND trial_reminder_sent_at IS NULL
+ORDER BY trial_end
+LIMIT @row_limit;
+
+-- name: MarkTrialReminderSent :exec
+-- Records that the admins were emailed about the trial ending
+UPDATE subscription_billing.subscriptions
+SET
+    trial_reminder_sent_at = @sent_at,
+    updated_at = CURRENT_TIMESTAMP
+WHERE organization_id = @organization_id;
+
+-- name: ListExpiredTrials :many
+-- Local trials that ended before ended_before, oldest first
+SELECT * FROM subscription_billing.subscriptions
+WHERE subscription_status = 'trialing'
+  AND product_id = @product_id
+  AND trial_end <= @ended_before
+ORDER BY trial_end
+LIMIT @row_limit;
+
+-- name: ExpireTrial :one
+-- Ends a local trial; returns no row when the organization subscribed meanwhile
+UPDATE subscription_billing.subscriptions
+SET
+    subscription_status = 'expired',
+    updated_at = CURRENT_TIMESTAMP
+WHERE organization_id = @organization_id
+  AND subscription_status = 'trialing'
+  AND product_id = @product_id
+RETURNING *;
+
+-- name: ListTrialReminderRecipients :many
+-- Emails of the organization's active admins, from the auth provider role
+-- (legacy owner included) or an assignment
+SELECT a.email FROM organizations.accounts a
+WHERE a.organization_id = @organization_id::int
+  AND a.status = 'active'
+  AND a.email NOT LIKE '%.invalid'
+  AND (
+    a.role IN ('admin', 'owner')
+    OR EXISTS (
+        SELECT 1 FROM rbac.role_assignments ra
+        WHERE ra.role_id = 'admin'
+          AND ((ra.organization_id = a.organization_id AND ra.account_id = a.id)
+            OR (ra.organization_id IS NULL AND ra.email = a.email))
+    )
+  )
+ORDER BY a.email;
@@ -0,0 +1,47 @@
+-- name: CreateSupportTicket :one
+INSERT INTO support.tickets (
+    reference,
+    organization_id,
+    account_id,
+    name,
+    email,
+    subject,
+    message,
+    access_token_hash
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7,
+    $8
+) RETURNING *;
+
+-- name: GetSupportTicketByReference :one
+SELECT * FROM support.tickets
+WHERE reference = $1;
+
+-- name: ListSupportTicketsByAccount :many
+SELECT * FROM support.tickets
+WHERE organization_id = $1
+  AND account_id = $2
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: AddSupportTicketAttachment :exec
+INSERT INTO support.ticket_attachments (
+    ticket_id,
+    file_asset_id
+) VALUES (
+    $1,
+    $2
+);
+
+-- name: ListSupportTicketAttachments :many
+SELECT fa.id, fa.original_file_name, fa.file_size, fa.mime_type
+FROM support.ticket_attachments ta
+JOIN file_manager.file_assets fa ON fa.id = ta.file_asset_id
+WHERE ta.ticket_id = $1
+ORDER BY ta.created_at, fa.id;
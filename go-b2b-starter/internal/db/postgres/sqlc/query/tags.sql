@@ -0,0 +1,64 @@
+-- name: ListTags :many
+-- Tags of an organization with how many accounts carry each
+SELECT
+    t.id,
+    t.organization_id,
+    t.name,
+    t.created_by_account_id,
+    t.created_at,
+    COUNT(at.account_id) AS account_count
+FROM organizations.tags t
+LEFT JOIN organizations.account_tags at ON at.tag_id = t.id
+WHERE t.organization_id = @organization_id::int
+GROUP BY t.id
+ORDER BY t.name;
+
+-- name: DeleteTag :execrows
+-- Deletes an organization's tag, removing it from every account
+DELETE FROM organizations.tags
+WHERE organization_id = @organization_id::int
+  AND name = @name::text;
+
+-- name: AddAccountTag :exec
+-- Attaches a tag to an account, creating the tag if the organization does not have it yet
+WITH tag AS (
+    INSERT INTO organizations.tags (
+        organization_id,
+        name,
+        created_by_account_id
+    ) VALUES (
+        @organization_id::int,
+        @name::text,
+        @actor_account_id::int
+    )
+    ON CONFLICT (organization_id, name) DO UPDATE
+    SET name = EXCLUDED.name
+    RETURNING id
+)
+INSERT INTO organizations.account_tags (
+    organization_id,
+    account_id,
+    tag_id,
+    created_by_account_id
+)
+SELECT @organization_id::int, @account_id::int, tag.id, @actor_account_id::int
+FROM tag
+ON CONFLICT (account_id, tag_id) DO NOTHING;
+
+-- name: RemoveAccountTag :execrows
+-- Detaches a tag from an account; the tag stays in the organization
+DELETE FROM organizations.account_tags at
+USING organizations.tags t
+WHERE at.tag_id = t.id
+  AND at.account_id = @account_id::int
+  AND at.organization_id = @organization_id::int
+  AND t.name = @name::text;
+
+-- name: ListAccountTags :many
+-- Names of the tags attached to an account
+SELECT t.name
+FROM organizations.account_tags at
+INNER JOIN organizations.tags t ON t.id = at.tag_id
+WHERE at.account_id = @account_id::int
+  AND at.organization_id = @organization_id::int
+ORDER BY t.name;
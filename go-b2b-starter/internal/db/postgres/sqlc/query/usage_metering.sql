@@ -0,0 +1,80 @@
+-- name: InsertUsageEvent :execrows
+-- Records a usage event. An event whose idempotency key was already recorded
+-- for the organization is ignored and affects no rows.
+INSERT INTO subscription_billing.usage_events (
+    organization_id,
+    account_id,
+    metric,
+    quantity,
+    source,
+    idempotency_key,
+    occurred_at
+) VALUES (
+    @organization_id::int,
+    sqlc.narg(account_id)::int,
+    @metric::text,
+    @quantity::bigint,
+    @source::text,
+    sqlc.narg(idempotency_key)::text,
+    @occurred_at::timestamp
+)
+ON CONFLICT (organization_id, idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING;
+
+-- name: RollupUsageEvents :execrows
+-- Recomputes the hourly buckets of the events since the cutoff, which must
+-- be the start of an hour. Gauge metrics keep the bucket's maximum, the
+-- others its sum. Buckets whose totals did not change are left untouched.
+INSERT INTO subscription_billing.usage_rollups (
+    organization_id,
+    metric,
+    period_start,
+    period_end,
+    quantity,
+    event_count
+)
+SELECT
+    organization_id,
+    metric,
+    date_trunc('hour', occurred_at),
+    date_trunc('hour', occurred_at) + INTERVAL '1 hour',
+    (CASE WHEN metric = ANY(@gauge_metrics::text[]) THEN MAX(quantity) ELSE SUM(quantity) END)::bigint,
+    COUNT(*)::int
+FROM subscription_billing.usage_events
+WHERE occurred_at >= @since::timestamp
+GROUP BY organization_id, metric, date_trunc('hour', occurred_at)
+ON CONFLICT (organization_id, metric, period_start) DO UPDATE
+SET quantity = EXCLUDED.quantity,
+    event_count = EXCLUDED.event_count
+WHERE subscription_billing.usage_rollups.quantity <> EXCLUDED.quantity
+   OR subscription_billing.usage_rollups.event_count <> EXCLUDED.event_count;
+
+-- name: ListUnreportedUsageRollups :many
+-- Closed buckets of the given metrics with quantity not yet reported to the
+-- billing provider, after a cursor so failed reports are not listed again
+SELECT * FROM subscription_billing.usage_rollups
+WHERE metric = ANY(@metrics::text[])
+  AND period_end <= @closed_before::timestamp
+  AND (reported_at IS NULL OR reported_quantity <> quantity)
+  AND id > @after_id::int
+ORDER BY id
+LIMIT @row_limit::int;
+
+-- name: MarkUsageRollupReported :exec
+-- Records the quantity of a bucket reported to the billing provider
+UPDATE subscription_billing.usage_rollups
+SET reported_quantity = @reported_quantity::bigint,
+    reported_at = CURRENT_TIMESTAMP
+WHERE id = @id::int;
+
+-- name: ListUsageRollups :many
+-- Buckets of an organization starting in [period_from, period_to), by metric and oldest first
+SELECT * FROM subscription_billing.usage_rollups
+WHERE organization_id = @organization_id::int
+  AND period_start >= @period_from::timestamp
+  AND period_start < @period_to::timestamp
+ORDER BY metric, period_start;
+
+-- name: DeleteUsageEventsBefore :execrows
+-- Deletes usage events past retention; their buckets are kept
+DELETE FROM subscription_billing.usage_events
+WHERE occurred_at < @cutoff::timestamp;
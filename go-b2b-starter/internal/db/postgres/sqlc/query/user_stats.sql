@@ -0,0 +1,46 @@
+-- name: GetUserStats :one
+-- Account counts of production organizations, or of one organization when
+-- given. Deleted (inactive) accounts are left out. Users are active within a
+-- window when they signed in or made an authenticated request in it.
+SELECT
+    COUNT(*)::bigint AS total_accounts,
+    COUNT(*) FILTER (WHERE a.status = 'active')::bigint AS active_accounts,
+    COUNT(*) FILTER (WHERE a.status = 'dormant')::bigint AS dormant_accounts,
+    COUNT(*) FILTER (WHERE a.status = 'suspended')::bigint AS suspended_accounts,
+    COUNT(*) FILTER (WHERE a.stytch_email_verified)::bigint AS verified_accounts,
+    COUNT(*) FILTER (WHERE GREATEST(a.last_login_at, act.last_active_at) >= NOW() - INTERVAL '1 day')::bigint AS daily_active_users,
+    COUNT(*) FILTER (WHERE GREATEST(a.last_login_at, act.last_active_at) >= NOW() - INTERVAL '7 days')::bigint AS weekly_active_users,
+    COUNT(*) FILTER (WHERE GREATEST(a.last_login_at, act.last_active_at) >= NOW() - INTERVAL '30 days')::bigint AS monthly_active_users
+FROM organizations.accounts a
+LEFT JOIN organizations.account_activity act ON act.account_id = a.id
+WHERE a.status <> 'inactive'
+  AND (
+    (sqlc.narg(organization_id)::int IS NULL AND NOT EXISTS (
+        SELECT 1 FROM organizations.organizations o
+        WHERE o.id = a.organization_id AND o.environment <> 'production'
+    ))
+    OR a.organization_id = sqlc.narg(organization_id)::int
+  );
+
+-- name: ListDailySignups :many
+-- Accounts created per day over the given number of days up to today, in
+-- production organizations or in one organization when given, with how many
+-- of them verified their email. Accounts deleted since still count. Days
+-- without signups are included.
+SELECT
+    d.day::timestamp AS day,
+    COUNT(a.id)::bigint AS signups,
+    COUNT(a.id) FILTER (WHERE a.stytch_email_verified)::bigint AS verified
+FROM generate_series(CURRENT_DATE - (@days::int - 1), CURRENT_DATE, INTERVAL '1 day') AS d(day)
+LEFT JOIN organizations.accounts a
+    ON a.created_at >= d.day
+   AND a.created_at < d.day + INTERVAL '1 day'
+   AND (
+    (sqlc.narg(organization_id)::int IS NULL AND NOT EXISTS (
+        SELECT 1 FROM organizations.organizations o
+        WHERE o.id = a.organization_id AND o.environment <> 'production'
+    ))
+    OR a.organization_id = sqlc.narg(organization_id)::int
+   )
+GROUP BY d.day
+ORDER BY d.day;
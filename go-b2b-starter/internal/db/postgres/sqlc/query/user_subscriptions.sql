@@ -0,0 +1,61 @@
+-- name: GetUserSubscriptionByAccountID :one
+-- Get the subscription an account pays for itself
+SELECT * FROM subscription_billing.user_subscriptions
+WHERE account_id = @account_id;
+
+-- name: UpsertUserSubscription :one
+-- Create or update an account's subscription from the billing provider
+INSERT INTO subscription_billing.user_subscriptions (
+    account_id,
+    organization_id,
+    external_customer_id,
+    subscription_id,
+    subscription_status,
+    product_id,
+    product_name,
+    plan_name,
+    current_period_start,
+    current_period_end,
+    cancel_at_period_end,
+    canceled_at,
+    metadata
+) VALUES (
+    @account_id,
+    @organization_id,
+    @external_customer_id,
+    @subscription_id,
+    @subscription_status,
+    @product_id,
+    sqlc.narg(product_name),
+    sqlc.narg(plan_name),
+    @current_period_start,
+    @current_period_end,
+    @cancel_at_period_end,
+    sqlc.narg(canceled_at),
+    @metadata
+)
+ON CONFLICT (account_id) DO UPDATE SET
+    external_customer_id = EXCLUDED.external_customer_id,
+    subscription_id = EXCLUDED.subscription_id,
+    subscription_status = EXCLUDED.subscription_status,
+    product_id = EXCLUDED.product_id,
+    product_name = EXCLUDED.product_name,
+    plan_name = EXCLUDED.plan_name,
+    current_period_start = EXCLUDED.current_period_start,
+    current_period_end = EXCLUDED.current_period_end,
+    cancel_at_period_end = EXCLUDED.cancel_at_period_end,
+    canceled_at = EXCLUDED.canceled_at,
+    metadata = EXCLUDED.metadata
+RETURNING *;
+
+-- name: GetBillingCustomerByAccountID :one
+-- Get the identifiers an account is billed under
+SELECT id, organization_id, public_id
+FROM organizations.accounts
+WHERE id = @id;
+
+-- name: GetBillingCustomerByPublicID :one
+-- Resolve the account of a user-scoped billing customer
+SELECT id, organization_id, public_id
+FROM organizations.accounts
+WHERE public_id = @public_id;
@@ -0,0 +1,15 @@
+package auth
+
+import "context"
+
+// ActivityRecorder records that an account made an authenticated request.
+//
+// This interface decouples auth middleware from the organizations domain.
+// Implement this interface to track last activity, e.g. for detecting and
+// reactivating dormant accounts.
+type ActivityRecorder interface {
+	// RecordActivity records activity for the account. Implementations must be
+	// cheap on the hot path and must not fail the request: errors are handled
+	// internally.
+	RecordActivity(ctx context.Context, orgID, accountID int32)
+}
@@ -0,0 +1,264 @@
+// Package keycloak provides Keycloak authentication integration.
+//
+// This package implements the auth.AuthProvider interface using Keycloak
+// as the identity provider. It verifies realm-signed access tokens locally
+// and maps realm and client roles to auth.Role and auth.Permission.
+//
+// # Multi-Tenancy
+//
+// A single Keycloak instance can serve several tenants, one realm each.
+// The realm is taken from the token issuer and must be listed in
+// KEYCLOAK_REALMS; every realm has its own signing keys, client and
+// organization mapping.
+//
+// # Components
+//
+//   - KeycloakAuthAdapter: Main entry point implementing auth.AuthProvider
+//   - Config / RealmConfig: Per-realm settings and role mapping
+//
+// # Usage
+//
+//	cfg, err := keycloak.LoadConfig()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	adapter := keycloak.NewKeycloakAuthAdapter(cfg, logger)
+//
+//	// Use as auth.AuthProvider
+//	identity, err := adapter.VerifyToken(ctx, token)
+package keycloak
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+)
+
+// KeycloakAuthAdapter implements auth.AuthProvider using Keycloak realms.
+type KeycloakAuthAdapter struct {
+	cfg    *Config
+	logger logger.Logger
+
+	mu       sync.Mutex
+	realmKey map[string]*keyfunc.JWKS
+}
+
+// Ensure KeycloakAuthAdapter implements auth.AuthProvider.
+var _ auth.AuthProvider = (*KeycloakAuthAdapter)(nil)
+
+func NewKeycloakAuthAdapter(cfg *Config, log logger.Logger) *KeycloakAuthAdapter {
+	return &KeycloakAuthAdapter{
+		cfg:      cfg,
+		logger:   log,
+		realmKey: make(map[string]*keyfunc.JWKS),
+	}
+}
+
+// VerifyToken validates a Keycloak access token and returns an Identity.
+//
+// This implements auth.AuthProvider.VerifyToken.
+//
+// Returns auth.ErrInvalidToken if the token is invalid.
+// Returns auth.ErrTokenExpired if the token has expired.
+// Returns auth.ErrIssuerMismatch if the realm is not trusted.
+// Returns auth.ErrAudienceMismatch if the token was not issued for the realm's client.
+func (a *KeycloakAuthAdapter) VerifyToken(ctx context.Context, token string) (*auth.Identity, error) {
+	if token == "" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	claims, realm, err := a.parseAndVerify(token)
+	if err != nil {
+		a.logger.Debug("keycloak token verification failed", logger.Fields{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+
+	if !claims.hasAudience(realm.ClientID) {
+		return nil, auth.ErrAudienceMismatch
+	}
+
+	roles, permissions := a.mapRoles(claims, realm)
+
+	identity := &auth.Identity{
+		UserID:          claims.Subject,
+		Email:           claims.Email,
+		EmailVerified:   claims.EmailVerified,
+		OrganizationID:  realm.OrganizationID,
+		Roles:           roles,
+		Permissions:     permissions,
+		SessionID:       claims.SessionID,
+		TokenID:         claims.ID,
+		IssuedAt:        timeOf(claims.IssuedAt),
+		AuthenticatedAt: timeOf(claims.AuthTime),
+		AuthMethods:     authMethods(claims.AMR),
+		ExpiresAt:       timeOf(claims.ExpiresAt),
+		Raw: map[string]any{
+			"realm":              realm.Name,
+			"azp":                claims.AuthorizedParty,
+			"preferred_username": claims.PreferredUsername,
+		},
+	}
+
+	a.logger.Debug("keycloak token verified", logger.Fields{
+		"user_id":           identity.UserID,
+		"realm":             realm.Name,
+		"roles_count":       len(identity.Roles),
+		"permissions_count": len(identity.Permissions),
+	})
+
+	return identity, nil
+}
+
+// parseAndVerify resolves the realm from the issuer and verifies the token
+// signature against that realm's keys.
+func (a *KeycloakAuthAdapter) parseAndVerify(token string) (*tokenClaims, RealmConfig, error) {
+	// Read the issuer before verification to pick the realm's keys
+	var unverified tokenClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &unverified); err != nil {
+		return nil, RealmConfig{}, auth.ErrInvalidToken
+	}
+
+	realm, err := a.realmForIssuer(unverified.Issuer)
+	if err != nil {
+		return nil, RealmConfig{}, err
+	}
+
+	jwks, err := a.realmKeys(realm.Name)
+	if err != nil {
+		return nil, RealmConfig{}, fmt.Errorf("failed to load keys for realm %s: %w", realm.Name, err)
+	}
+
+	var claims tokenClaims
+	parsed, err := jwt.ParseWithClaims(token, &claims, jwks.Keyfunc,
+		jwt.WithValidMethods(a.cfg.ValidMethods),
+		jwt.WithIssuer(a.cfg.IssuerURL(realm.Name)),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, RealmConfig{}, auth.ErrTokenExpired
+		}
+		if errors.Is(err, jwt.ErrTokenInvalidIssuer) {
+			return nil, RealmConfig{}, auth.ErrIssuerMismatch
+		}
+		return nil, RealmConfig{}, auth.ErrInvalidToken
+	}
+	if !parsed.Valid {
+		return nil, RealmConfig{}, auth.ErrInvalidToken
+	}
+
+	return &claims, realm, nil
+}
+
+// realmForIssuer returns the configured realm that issued the token.
+func (a *KeycloakAuthAdapter) realmForIssuer(issuer string) (RealmConfig, error) {
+	prefix := a.cfg.BaseURL + "/realms/"
+	if !strings.HasPrefix(issuer, prefix) {
+		return RealmConfig{}, auth.ErrIssuerMismatch
+	}
+
+	realm, ok := a.cfg.RealmConfigs[strings.TrimPrefix(issuer, prefix)]
+	if !ok {
+		return RealmConfig{}, auth.ErrIssuerMismatch
+	}
+	return realm, nil
+}
+
+// realmKeys returns the cached JWKS for a realm, fetching it on first use.
+func (a *KeycloakAuthAdapter) realmKeys(realm string) (*keyfunc.JWKS, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if jwks, ok := a.realmKey[realm]; ok {
+		return jwks, nil
+	}
+
+	jwks, err := keyfunc.Get(a.cfg.JWKSURL(realm), keyfunc.Options{
+		RefreshInterval:   a.cfg.JWKSRefreshInterval,
+		RefreshRateLimit:  time.Minute,
+		RefreshTimeout:    10 * time.Second,
+		RefreshUnknownKID: true,
+		RefreshErrorHandler: func(err error) {
+			a.logger.Warn("failed to refresh keycloak JWKS", logger.Fields{
+				"realm": realm,
+				"error": err.Error(),
+			})
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	a.realmKey[realm] = jwks
+	return jwks, nil
+}
+
+// tokenClaims holds the Keycloak access token claims used by the adapter.
+type tokenClaims struct {
+	jwt.RegisteredClaims
+
+	Email             string               `json:"email"`
+	EmailVerified     bool                 `json:"email_verified"`
+	PreferredUsername string               `json:"preferred_username"`
+	AuthorizedParty   string               `json:"azp"`
+	SessionID         string               `json:"sid"`
+	AuthTime          *jwt.NumericDate     `json:"auth_time"`
+	AMR               []string             `json:"amr"`
+	RealmAccess       roleClaim            `json:"realm_access"`
+	ResourceAccess    map[string]roleClaim `json:"resource_access"`
+}
+
+// roleClaim is the shape of realm_access and resource_access entries.
+type roleClaim struct {
+	Roles []string `json:"roles"`
+}
+
+// hasAudience reports whether the token was issued for the client.
+// Keycloak puts the requesting client in azp; aud lists resource servers.
+func (c *tokenClaims) hasAudience(clientID string) bool {
+	return c.AuthorizedParty == clientID || slices.Contains(c.Audience, clientID)
+}
+
+// authMethods maps the amr claim (RFC 8176 values, added by Keycloak's
+// "authentication method reference" mapper) to auth.AuthMethod constants.
+func authMethods(amr []string) []string {
+	var methods []string
+	for _, value := range amr {
+		var method string
+		switch value {
+		case "pwd":
+			method = auth.AuthMethodPassword
+		case "otp":
+			method = auth.AuthMethodTOTP
+		case "sms":
+			method = auth.AuthMethodSMSOTP
+		case "fed":
+			method = auth.AuthMethodSSO
+		default:
+			continue
+		}
+		if !slices.Contains(methods, method) {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+func timeOf(t *jwt.NumericDate) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.Time
+}
@@ -0,0 +1,225 @@
+package keycloak
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+)
+
+// Config captures the runtime configuration for Keycloak authentication.
+//
+// All configuration values can be set via environment variables with the
+// KEYCLOAK_ prefix (e.g., KEYCLOAK_BASE_URL, KEYCLOAK_REALMS).
+type Config struct {
+	// BaseURL is the Keycloak server URL, without the /realms suffix (required)
+	BaseURL string `mapstructure:"KEYCLOAK_BASE_URL"`
+
+	// Realms lists the trusted realms as comma-separated "realm[:client_id[:organization_id]]"
+	// entries (required). Each realm is one tenant of a shared Keycloak instance.
+	Realms string `mapstructure:"KEYCLOAK_REALMS"`
+
+	// ClientID is the default client (audience) for realms that don't set one
+	ClientID string `mapstructure:"KEYCLOAK_CLIENT_ID"`
+
+	// RoleMapping maps Keycloak roles to application roles as comma-separated
+	// "keycloak_role=app_role" entries (e.g., "realm-admin=admin,editor=manager")
+	RoleMapping string `mapstructure:"KEYCLOAK_ROLE_MAPPING"`
+
+	// JWKSRefreshInterval is how often realm signing keys are refreshed
+	JWKSRefreshInterval time.Duration `mapstructure:"KEYCLOAK_JWKS_REFRESH_INTERVAL"`
+
+	// SigningAlgorithms lists the comma-separated JWS algorithms accepted on tokens
+	// (e.g., "ES256,EdDSA"). Set it to the realm's key type to reject everything else.
+	SigningAlgorithms string `mapstructure:"KEYCLOAK_SIGNING_ALGORITHMS"`
+
+	// RealmConfigs is the parsed form of Realms, keyed by realm name
+	RealmConfigs map[string]RealmConfig `mapstructure:"-"`
+
+	// RoleMap is the parsed form of RoleMapping
+	RoleMap map[string]auth.Role `mapstructure:"-"`
+
+	// ValidMethods is the parsed form of SigningAlgorithms
+	ValidMethods []string `mapstructure:"-"`
+}
+
+// supportedAlgorithms are the asymmetric algorithms Keycloak realms can sign with.
+// HMAC algorithms are excluded since realm keys are published through JWKS.
+var supportedAlgorithms = map[string]bool{
+	"RS256": true, "RS384": true, "RS512": true,
+	"PS256": true, "PS384": true, "PS512": true,
+	"ES256": true, "ES384": true, "ES512": true,
+	"EdDSA": true,
+}
+
+// RealmConfig holds the per-realm settings.
+type RealmConfig struct {
+	// Name is the Keycloak realm name
+	Name string
+
+	// ClientID is the client whose roles are mapped and which must be the token audience
+	ClientID string
+
+	// OrganizationID is reported as Identity.OrganizationID (defaults to the realm name)
+	OrganizationID string
+}
+
+// LoadConfig loads the Keycloak configuration from environment variables and app.env file.
+//
+// Configuration priority:
+//  1. Environment variables (highest)
+//  2. app.env file
+//  3. Default values (lowest)
+func LoadConfig() (*Config, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("KEYCLOAK_JWKS_REFRESH_INTERVAL", "1h")
+	v.SetDefault("KEYCLOAK_SIGNING_ALGORITHMS", "RS256,RS384,RS512,ES256,ES384,ES512,EdDSA")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode keycloak config: %w", err)
+	}
+
+	cfg.BaseURL = strings.TrimSuffix(strings.TrimSpace(cfg.BaseURL), "/")
+	if cfg.JWKSRefreshInterval <= 0 {
+		cfg.JWKSRefreshInterval = time.Hour
+	}
+
+	realms, err := parseRealms(cfg.Realms, cfg.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	cfg.RealmConfigs = realms
+
+	roleMap, err := parseRoleMapping(cfg.RoleMapping)
+	if err != nil {
+		return nil, err
+	}
+	cfg.RoleMap = roleMap
+
+	methods, err := parseSigningAlgorithms(cfg.SigningAlgorithms)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ValidMethods = methods
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that the configuration has all required fields.
+func (c *Config) Validate() error {
+	if c.BaseURL == "" {
+		return fmt.Errorf("keycloak configuration invalid: KEYCLOAK_BASE_URL is required")
+	}
+	if len(c.RealmConfigs) == 0 {
+		return fmt.Errorf("keycloak configuration invalid: KEYCLOAK_REALMS is required")
+	}
+	if len(c.ValidMethods) == 0 {
+		return fmt.Errorf("keycloak configuration invalid: KEYCLOAK_SIGNING_ALGORITHMS is required")
+	}
+	for name, realm := range c.RealmConfigs {
+		if realm.ClientID == "" {
+			return fmt.Errorf("keycloak configuration invalid: no client ID for realm %q", name)
+		}
+	}
+	return nil
+}
+
+// IssuerURL returns the token issuer for a realm.
+func (c *Config) IssuerURL(realm string) string {
+	return fmt.Sprintf("%s/realms/%s", c.BaseURL, realm)
+}
+
+// JWKSURL returns the signing keys endpoint for a realm.
+func (c *Config) JWKSURL(realm string) string {
+	return fmt.Sprintf("%s/protocol/openid-connect/certs", c.IssuerURL(realm))
+}
+
+// parseRealms parses "realm[:client_id[:organization_id]]" entries.
+func parseRealms(raw, defaultClientID string) (map[string]RealmConfig, error) {
+	realms := make(map[string]RealmConfig)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		parts := strings.Split(entry, ":")
+		if len(parts) > 3 || parts[0] == "" {
+			return nil, fmt.Errorf("keycloak configuration invalid: bad realm entry %q", entry)
+		}
+
+		realm := RealmConfig{
+			Name:           parts[0],
+			ClientID:       defaultClientID,
+			OrganizationID: parts[0],
+		}
+		if len(parts) > 1 && parts[1] != "" {
+			realm.ClientID = parts[1]
+		}
+		if len(parts) > 2 && parts[2] != "" {
+			realm.OrganizationID = parts[2]
+		}
+		realms[realm.Name] = realm
+	}
+	return realms, nil
+}
+
+// parseRoleMapping parses "keycloak_role=app_role" entries.
+func parseRoleMapping(raw string) (map[string]auth.Role, error) {
+	roleMap := make(map[string]auth.Role)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		from, to, ok := strings.Cut(entry, "=")
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("keycloak configuration invalid: bad role mapping %q", entry)
+		}
+
+		role := auth.NormalizeRole(strings.TrimSpace(to))
+		if !role.IsValid() {
+			return nil, fmt.Errorf("keycloak configuration invalid: unknown role %q", to)
+		}
+		roleMap[strings.TrimSpace(from)] = role
+	}
+	return roleMap, nil
+}
+
+// parseSigningAlgorithms parses comma-separated JWS algorithm names.
+func parseSigningAlgorithms(raw string) ([]string, error) {
+	var methods []string
+	for _, alg := range strings.Split(raw, ",") {
+		alg = strings.TrimSpace(alg)
+		if alg == "" {
+			continue
+		}
+		if !supportedAlgorithms[alg] {
+			return nil, fmt.Errorf("keycloak configuration invalid: unsupported signing algorithm %q", alg)
+		}
+		methods = append(methods, alg)
+	}
+	return methods, nil
+}
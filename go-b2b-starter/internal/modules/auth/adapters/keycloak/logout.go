@@ -0,0 +1,131 @@
+package keycloak
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+)
+
+// Ensure KeycloakAuthAdapter supports OIDC logout.
+var (
+	_ auth.LogoutTokenVerifier  = (*KeycloakAuthAdapter)(nil)
+	_ auth.SessionRevoker       = (*KeycloakAuthAdapter)(nil)
+	_ auth.SessionLister        = (*KeycloakAuthAdapter)(nil)
+	_ auth.OrganizationSwitcher = (*KeycloakAuthAdapter)(nil)
+)
+
+// logoutTokenClaims holds the claims of a Keycloak back-channel logout token.
+type logoutTokenClaims struct {
+	jwt.RegisteredClaims
+
+	SessionID string         `json:"sid"`
+	Events    map[string]any `json:"events"`
+	Nonce     string         `json:"nonce"`
+}
+
+// VerifyLogoutToken validates a back-channel logout token signed by a trusted realm.
+//
+// This implements auth.LogoutTokenVerifier.VerifyLogoutToken.
+func (a *KeycloakAuthAdapter) VerifyLogoutToken(ctx context.Context, token string) (*auth.LogoutClaims, error) {
+	var unverified logoutTokenClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &unverified); err != nil {
+		return nil, auth.ErrInvalidLogoutToken
+	}
+
+	realm, err := a.realmForIssuer(unverified.Issuer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: untrusted issuer", auth.ErrInvalidLogoutToken)
+	}
+
+	jwks, err := a.realmKeys(realm.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load keys for realm %s: %w", realm.Name, err)
+	}
+
+	var claims logoutTokenClaims
+	parsed, err := jwt.ParseWithClaims(token, &claims, jwks.Keyfunc,
+		jwt.WithValidMethods(a.cfg.ValidMethods),
+		jwt.WithIssuer(a.cfg.IssuerURL(realm.Name)),
+		jwt.WithAudience(realm.ClientID),
+		jwt.WithIssuedAt(),
+	)
+	if err != nil || !parsed.Valid {
+		return nil, auth.ErrInvalidLogoutToken
+	}
+
+	if _, ok := claims.Events[auth.BackChannelLogoutEvent]; !ok {
+		return nil, fmt.Errorf("%w: missing back-channel logout event", auth.ErrInvalidLogoutToken)
+	}
+	if claims.Nonce != "" {
+		return nil, fmt.Errorf("%w: nonce is not allowed", auth.ErrInvalidLogoutToken)
+	}
+	if claims.ID == "" || claims.IssuedAt == nil {
+		return nil, fmt.Errorf("%w: iat and jti are required", auth.ErrInvalidLogoutToken)
+	}
+	if claims.Subject == "" && claims.SessionID == "" {
+		return nil, fmt.Errorf("%w: sub or sid is required", auth.ErrInvalidLogoutToken)
+	}
+
+	logoutClaims := &auth.LogoutClaims{
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		SessionID: claims.SessionID,
+		TokenID:   claims.ID,
+		IssuedAt:  claims.IssuedAt.Time,
+	}
+	if claims.ExpiresAt != nil {
+		logoutClaims.ExpiresAt = claims.ExpiresAt.Time
+	}
+	return logoutClaims, nil
+}
+
+// ValidateIssuer checks that a front-channel logout issuer is a trusted realm.
+//
+// This implements auth.LogoutTokenVerifier.ValidateIssuer.
+func (a *KeycloakAuthAdapter) ValidateIssuer(issuer string) error {
+	_, err := a.realmForIssuer(issuer)
+	return err
+}
+
+// RevokeSession is a no-op: Keycloak already terminated the session when it
+// initiated the logout. Local access tokens are handled by the denylist.
+//
+// This implements auth.SessionRevoker.RevokeSession.
+func (a *KeycloakAuthAdapter) RevokeSession(ctx context.Context, sessionID string) error {
+	a.logger.Debug("keycloak session ended by identity provider", logger.Fields{
+		"session_id": sessionID,
+	})
+	return nil
+}
+
+// RevokeUserSessions is a no-op for the same reason as RevokeSession.
+//
+// This implements auth.SessionRevoker.RevokeUserSessions.
+func (a *KeycloakAuthAdapter) RevokeUserSessions(ctx context.Context, userID string) error {
+	a.logger.Debug("keycloak user sessions ended by identity provider", logger.Fields{
+		"user_id": userID,
+	})
+	return nil
+}
+
+// ListUserSessions is not supported: listing Keycloak sessions requires
+// admin API credentials, which the adapter does not hold. Users manage
+// their devices in the Keycloak account console instead.
+//
+// This implements auth.SessionLister.ListUserSessions.
+func (a *KeycloakAuthAdapter) ListUserSessions(ctx context.Context, organizationID, userID string) ([]auth.Session, error) {
+	return nil, auth.ErrSessionListingUnsupported
+}
+
+// SwitchOrganization is not supported: Keycloak tokens are issued by the
+// client's own token endpoint, so the frontend signs in to the other
+// organization's realm instead.
+//
+// This implements auth.OrganizationSwitcher.SwitchOrganization.
+func (a *KeycloakAuthAdapter) SwitchOrganization(ctx context.Context, token, organizationID string) (*auth.SessionTokens, error) {
+	return nil, auth.ErrOrganizationSwitchUnsupported
+}
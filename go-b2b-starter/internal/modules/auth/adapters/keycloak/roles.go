@@ -0,0 +1,71 @@
+package keycloak
+
+import (
+	"strings"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+)
+
+// mapRoles converts realm and client roles to application roles and permissions.
+//
+// Mapping rules, applied to realm roles and the realm client's roles:
+//   - Roles listed in KEYCLOAK_ROLE_MAPPING map to the configured app role
+//   - Roles in "resource:action" form are granted as permissions directly
+//   - Other roles are kept if they name a known app role (after normalization)
+//
+// Permissions of every mapped role are added from rbac.go.
+func (a *KeycloakAuthAdapter) mapRoles(claims *tokenClaims, realm RealmConfig) ([]auth.Role, []auth.Permission) {
+	keycloakRoles := append([]string{}, claims.RealmAccess.Roles...)
+	if client, ok := claims.ResourceAccess[realm.ClientID]; ok {
+		keycloakRoles = append(keycloakRoles, client.Roles...)
+	}
+
+	roleSet := make(map[auth.Role]struct{})
+	permSet := make(map[auth.Permission]struct{})
+	var roles []auth.Role
+
+	for _, kcRole := range keycloakRoles {
+		role, isRole := a.mapRole(kcRole)
+		if !isRole {
+			if strings.Contains(kcRole, ":") {
+				if perm := auth.Permission(kcRole); perm.IsValid() {
+					permSet[perm] = struct{}{}
+				}
+			}
+			continue
+		}
+
+		if _, seen := roleSet[role]; seen {
+			continue
+		}
+		roleSet[role] = struct{}{}
+		roles = append(roles, role)
+
+		for _, p := range auth.GetRolePermissions(role) {
+			permSet[p] = struct{}{}
+		}
+	}
+
+	if len(permSet) == 0 {
+		return roles, nil
+	}
+
+	permissions := make([]auth.Permission, 0, len(permSet))
+	for p := range permSet {
+		permissions = append(permissions, p)
+	}
+	return roles, permissions
+}
+
+// mapRole maps a single Keycloak role to an app role.
+func (a *KeycloakAuthAdapter) mapRole(kcRole string) (auth.Role, bool) {
+	if role, ok := a.cfg.RoleMap[kcRole]; ok {
+		return role, true
+	}
+
+	role := auth.NormalizeRole(kcRole)
+	if role.IsValid() {
+		return role, true
+	}
+	return "", false
+}
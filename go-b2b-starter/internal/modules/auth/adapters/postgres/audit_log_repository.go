@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+)
+
+// auditLogRepository implements auth.AuditLogRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type auditLogRepository struct {
+	store sqlc.Store
+}
+
+// NewAuditLogRepository creates a new auth.AuditLogRepository implementation.
+func NewAuditLogRepository(store sqlc.Store) auth.AuditLogRepository {
+	return &auditLogRepository{store: store}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, entry *auth.AuditLogEntry) (*auth.AuditLogEntry, error) {
+	row, err := r.store.CreateAuthAuditEvent(ctx, sqlc.CreateAuthAuditEventParams{
+		OrganizationID: helpers.ToPgInt4Ptr(entry.OrganizationID),
+		AccountID:      helpers.ToPgInt4Ptr(entry.AccountID),
+		EventType:      entry.EventType,
+		UserID:         helpers.ToPgText(entry.UserID),
+		Email:          helpers.ToPgText(entry.Email),
+		SessionID:      helpers.ToPgText(entry.SessionID),
+		ClientIp:       helpers.ToPgText(entry.ClientIP),
+		UserAgent:      helpers.ToPgText(entry.UserAgent),
+		Reason:         helpers.ToPgText(entry.Reason),
+		OccurredAt:     pgtype.Timestamp{Time: entry.OccurredAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create auth audit event: %w", err)
+	}
+
+	return toAuditLogEntry(&row), nil
+}
+
+func (r *auditLogRepository) List(ctx context.Context, filter auth.AuditLogFilter) ([]*auth.AuditLogEntry, int64, error) {
+	rows, err := r.store.ListAuthAuditEvents(ctx, sqlc.ListAuthAuditEventsParams{
+		OrganizationID: filter.OrganizationID,
+		UserID:         helpers.ToPgText(filter.UserID),
+		EventType:      helpers.ToPgText(filter.EventType),
+		OccurredAfter:  toPgTimestamp(filter.From),
+		OccurredBefore: toPgTimestamp(filter.To),
+		RowLimit:       filter.Limit,
+		RowOffset:      filter.Offset,
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list auth audit events: %w", err)
+	}
+
+	total, err := r.store.CountAuthAuditEvents(ctx, sqlc.CountAuthAuditEventsParams{
+		OrganizationID: filter.OrganizationID,
+		UserID:         helpers.ToPgText(filter.UserID),
+		EventType:      helpers.ToPgText(filter.EventType),
+		OccurredAfter:  toPgTimestamp(filter.From),
+		OccurredBefore: toPgTimestamp(filter.To),
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count auth audit events: %w", err)
+	}
+
+	entries := make([]*auth.AuditLogEntry, len(rows))
+	for i := range rows {
+		entries[i] = toAuditLogEntry(&rows[i])
+	}
+	return entries, total, nil
+}
+
+func (r *auditLogRepository) ListAfter(ctx context.Context, filter auth.AuditLogFilter, after *auth.AuditLogCursor) (*auth.AuditLogPage, error) {
+	params := sqlc.ListAuthAuditEventsKeysetParams{
+		OrganizationID: filter.OrganizationID,
+		UserID:         helpers.ToPgText(filter.UserID),
+		EventType:      helpers.ToPgText(filter.EventType),
+		OccurredAfter:  toPgTimestamp(filter.From),
+		OccurredBefore: toPgTimestamp(filter.To),
+		// Fetch one extra row to learn whether another page follows
+		RowLimit: filter.Limit + 1,
+	}
+	if after != nil {
+		params.CursorAt = pgtype.Timestamp{Time: after.OccurredAt, Valid: true}
+		params.CursorID = pgtype.Int8{Int64: after.ID, Valid: true}
+	}
+
+	rows, err := r.store.ListAuthAuditEventsKeyset(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list auth audit events: %w", err)
+	}
+
+	page := &auth.AuditLogPage{}
+	if filter.Limit > 0 && int32(len(rows)) > filter.Limit {
+		rows = rows[:filter.Limit]
+		last := rows[len(rows)-1]
+		page.NextCursor = &auth.AuditLogCursor{OccurredAt: last.OccurredAt.Time, ID: last.ID}
+	}
+
+	page.Items = make([]*auth.AuditLogEntry, len(rows))
+	for i := range rows {
+		page.Items[i] = toAuditLogEntry(&rows[i])
+	}
+	return page, nil
+}
+
+func (r *auditLogRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	deleted, err := r.store.DeleteAuthAuditEventsBefore(ctx, pgtype.Timestamp{Time: cutoff, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete auth audit events: %w", err)
+	}
+	return deleted, nil
+}
+
+func toAuditLogEntry(row *sqlc.OrganizationsAuthAuditLog) *auth.AuditLogEntry {
+	return &auth.AuditLogEntry{
+		ID:             row.ID,
+		OrganizationID: int4Ptr(row.OrganizationID),
+		AccountID:      int4Ptr(row.AccountID),
+		EventType:      row.EventType,
+		UserID:         helpers.FromPgText(row.UserID),
+		Email:          helpers.FromPgText(row.Email),
+		SessionID:      helpers.FromPgText(row.SessionID),
+		ClientIP:       helpers.FromPgText(row.ClientIp),
+		UserAgent:      helpers.FromPgText(row.UserAgent),
+		Reason:         helpers.FromPgText(row.Reason),
+		OccurredAt:     row.OccurredAt.Time,
+	}
+}
+
+// toPgTimestamp maps the zero time to NULL, which the queries treat as "no bound".
+func toPgTimestamp(t time.Time) pgtype.Timestamp {
+	if t.IsZero() {
+		return pgtype.Timestamp{}
+	}
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+func int4Ptr(i pgtype.Int4) *int32 {
+	if !i.Valid {
+		return nil
+	}
+	value := i.Int32
+	return &value
+}
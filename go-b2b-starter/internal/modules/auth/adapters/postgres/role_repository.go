@@ -0,0 +1,156 @@
+// Package postgres stores auth roles and permissions in PostgreSQL.
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+)
+
+// roleRepository implements auth.RoleRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type roleRepository struct {
+	store sqlc.Store
+}
+
+// NewRoleRepository creates a new auth.RoleRepository implementation.
+func NewRoleRepository(store sqlc.Store) auth.RoleRepository {
+	return &roleRepository{store: store}
+}
+
+func (r *roleRepository) ListRoles(ctx context.Context) ([]auth.RoleInfo, error) {
+	rows, err := r.store.ListRoles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	roles := make([]auth.RoleInfo, len(rows))
+	for i, row := range rows {
+		roles[i] = auth.RoleInfo{
+			ID:          row.ID,
+			Name:        row.Name,
+			Description: row.Description,
+			Permissions: toPermissions(row.Permissions),
+			System:      row.IsSystem,
+		}
+	}
+	return roles, nil
+}
+
+func (r *roleRepository) GetRole(ctx context.Context, roleID string) (*auth.RoleInfo, error) {
+	row, err := r.store.GetRole(ctx, roleID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, auth.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return &auth.RoleInfo{
+		ID:          row.ID,
+		Name:        row.Name,
+		Description: row.Description,
+		Permissions: toPermissions(row.Permissions),
+		System:      row.IsSystem,
+	}, nil
+}
+
+func (r *roleRepository) CreateRole(ctx context.Context, role *auth.RoleInfo) (*auth.RoleInfo, error) {
+	_, err := r.store.CreateRole(ctx, sqlc.CreateRoleParams{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+	})
+	if err != nil {
+		if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+			return nil, auth.ErrRoleExists
+		}
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	if err := r.setPermissions(ctx, role.ID, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	return r.GetRole(ctx, role.ID)
+}
+
+func (r *roleRepository) UpdateRole(ctx context.Context, role *auth.RoleInfo) (*auth.RoleInfo, error) {
+	_, err := r.store.UpdateRole(ctx, sqlc.UpdateRoleParams{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, auth.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	if err := r.setPermissions(ctx, role.ID, role.Permissions); err != nil {
+		return nil, err
+	}
+
+	return r.GetRole(ctx, role.ID)
+}
+
+func (r *roleRepository) DeleteRole(ctx context.Context, roleID string) error {
+	deleted, err := r.store.DeleteRole(ctx, roleID)
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+	if deleted > 0 {
+		return nil
+	}
+
+	// Nothing deleted: the role is missing or built-in
+	if _, err := r.GetRole(ctx, roleID); err != nil {
+		return err
+	}
+	return auth.ErrSystemRole
+}
+
+func (r *roleRepository) ListPermissions(ctx context.Context) ([]auth.Permission, error) {
+	rows, err := r.store.ListPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	permissions := make([]auth.Permission, len(rows))
+	for i, row := range rows {
+		permissions[i] = auth.Permission(row.ID)
+	}
+	return permissions, nil
+}
+
+// setPermissions replaces a role's permissions with the given set.
+func (r *roleRepository) setPermissions(ctx context.Context, roleID string, permissions []auth.Permission) error {
+	ids := make([]string, len(permissions))
+	for i, permission := range permissions {
+		ids[i] = permission.String()
+	}
+
+	err := r.store.SetRolePermissions(ctx, sqlc.SetRolePermissionsParams{
+		RoleID:        roleID,
+		PermissionIds: ids,
+	})
+	if err != nil {
+		if sqlc.ErrorCode(err) == sqlc.ForeignKeyViolation {
+			return auth.ErrUnknownPermission
+		}
+		return fmt.Errorf("failed to set role permissions: %w", err)
+	}
+	return nil
+}
+
+func toPermissions(ids []string) []auth.Permission {
+	permissions := make([]auth.Permission, len(ids))
+	for i, id := range ids {
+		permissions[i] = auth.Permission(id)
+	}
+	return permissions
+}
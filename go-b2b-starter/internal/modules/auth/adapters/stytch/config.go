@@ -133,6 +133,16 @@ func LoadConfig() (*Config, error) {
 	return &cfg, nil
 }
 
+// Issuers returns the iss values of tokens Stytch issues for the project:
+// stytch.com/<project ID>, and https://<custom domain> when one is set.
+func (c *Config) Issuers() []string {
+	issuers := []string{"stytch.com/" + c.ProjectID}
+	if c.CustomDomain != "" {
+		issuers = append(issuers, "https://"+strings.TrimSuffix(c.CustomDomain, "/"))
+	}
+	return issuers
+}
+
 // Validate checks that the configuration has all required fields.
 func (c *Config) Validate() error {
 	if c.ProjectID == "" {
@@ -0,0 +1,242 @@
+package stytch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/stytchauth/stytch-go/v16/stytch/b2b/sessions"
+)
+
+// Ensure StytchAuthAdapter supports OIDC logout and session management.
+var (
+	_ auth.LogoutTokenVerifier  = (*StytchAuthAdapter)(nil)
+	_ auth.SessionRevoker       = (*StytchAuthAdapter)(nil)
+	_ auth.SessionLister        = (*StytchAuthAdapter)(nil)
+	_ auth.OrganizationSwitcher = (*StytchAuthAdapter)(nil)
+)
+
+// VerifyLogoutToken validates an OIDC back-channel logout token signed by Stytch.
+//
+// This implements auth.LogoutTokenVerifier.VerifyLogoutToken.
+func (a *StytchAuthAdapter) VerifyLogoutToken(ctx context.Context, token string) (*auth.LogoutClaims, error) {
+	claims, err := a.tokenVerifier.VerifyLogoutToken(ctx, token)
+	if err != nil {
+		a.logger.Warn("logout token verification failed", logger.Fields{
+			"error": err.Error(),
+		})
+		return nil, err
+	}
+	return claims, nil
+}
+
+// ValidateIssuer checks that a front-channel logout issuer is this Stytch project.
+//
+// This implements auth.LogoutTokenVerifier.ValidateIssuer.
+func (a *StytchAuthAdapter) ValidateIssuer(issuer string) error {
+	if !a.tokenVerifier.isTrustedIssuer(issuer) {
+		return auth.ErrIssuerMismatch
+	}
+	return nil
+}
+
+// RevokeSession terminates a single Stytch member session.
+//
+// This implements auth.SessionRevoker.RevokeSession.
+func (a *StytchAuthAdapter) RevokeSession(ctx context.Context, sessionID string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.cfg.APITimeout)
+	defer cancel()
+
+	if _, err := a.client.Sessions.Revoke(ctx, &sessions.RevokeParams{
+		MemberSessionID: sessionID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke stytch session: %w", err)
+	}
+
+	a.logger.Info("stytch session revoked", logger.Fields{
+		"session_id": sessionID,
+	})
+	return nil
+}
+
+// RevokeUserSessions terminates every Stytch session of a member.
+//
+// This implements auth.SessionRevoker.RevokeUserSessions.
+func (a *StytchAuthAdapter) RevokeUserSessions(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.cfg.APITimeout)
+	defer cancel()
+
+	if _, err := a.client.Sessions.Revoke(ctx, &sessions.RevokeParams{
+		MemberID: userID,
+	}); err != nil {
+		return fmt.Errorf("failed to revoke stytch member sessions: %w", err)
+	}
+
+	a.logger.Info("stytch member sessions revoked", logger.Fields{
+		"member_id": userID,
+	})
+	return nil
+}
+
+// ListUserSessions returns the active Stytch sessions of a member.
+//
+// This implements auth.SessionLister.ListUserSessions.
+func (a *StytchAuthAdapter) ListUserSessions(ctx context.Context, organizationID, userID string) ([]auth.Session, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.cfg.APITimeout)
+	defer cancel()
+
+	resp, err := a.client.Sessions.Get(ctx, &sessions.GetParams{
+		OrganizationID: organizationID,
+		MemberID:       userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stytch member sessions: %w", err)
+	}
+
+	result := make([]auth.Session, 0, len(resp.MemberSessions))
+	for _, ms := range resp.MemberSessions {
+		session := auth.Session{
+			ID: ms.MemberSessionID,
+		}
+		if ms.StartedAt != nil {
+			session.StartedAt = *ms.StartedAt
+		}
+		if ms.LastAccessedAt != nil {
+			session.LastAccessedAt = *ms.LastAccessedAt
+		}
+		if ms.ExpiresAt != nil {
+			session.ExpiresAt = *ms.ExpiresAt
+		}
+		for _, factor := range ms.AuthenticationFactors {
+			method := string(factor.DeliveryMethod)
+			if method != "" && !slices.Contains(session.AuthenticationMethods, method) {
+				session.AuthenticationMethods = append(session.AuthenticationMethods, method)
+			}
+		}
+		result = append(result, session)
+	}
+	return result, nil
+}
+
+// SwitchOrganization exchanges the member's session for a session in another
+// Stytch organization the same email belongs to. Factors that don't satisfy the
+// target organization's MFA policy yield an intermediate session token instead.
+//
+// This implements auth.OrganizationSwitcher.SwitchOrganization.
+func (a *StytchAuthAdapter) SwitchOrganization(ctx context.Context, token, organizationID string) (*auth.SessionTokens, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.cfg.APITimeout)
+	defer cancel()
+
+	resp, err := a.client.Sessions.Exchange(ctx, &sessions.ExchangeParams{
+		OrganizationID:         organizationID,
+		SessionJWT:             token,
+		SessionDurationMinutes: a.cfg.SessionDurationMinutes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange stytch session: %w", err)
+	}
+
+	tokens := &auth.SessionTokens{
+		AccessToken:              resp.SessionJWT,
+		SessionToken:             resp.SessionToken,
+		MFARequired:              !resp.MemberAuthenticated,
+		IntermediateSessionToken: resp.IntermediateSessionToken,
+	}
+	if resp.MemberSession != nil && resp.MemberSession.ExpiresAt != nil {
+		tokens.ExpiresAt = *resp.MemberSession.ExpiresAt
+	}
+
+	a.logger.Info("stytch session exchanged", logger.Fields{
+		"member_id":       resp.MemberID,
+		"organization_id": organizationID,
+		"mfa_required":    tokens.MFARequired,
+	})
+	return tokens, nil
+}
+
+// VerifyLogoutToken validates the signature and claims of a logout token.
+//
+// Per OpenID Connect Back-Channel Logout 1.0 the token must:
+//   - be signed by the provider (verified against the cached JWKS)
+//   - be issued by the provider and addressed to this project
+//   - contain the back-channel logout event
+//   - contain sub and/or sid, and no nonce
+func (v *TokenVerifier) VerifyLogoutToken(ctx context.Context, token string) (*auth.LogoutClaims, error) {
+	kid, err := v.jwtParser.ExtractKeyID(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", auth.ErrInvalidLogoutToken, err)
+	}
+
+	publicKey, err := v.jwksCache.GetPublicKey(ctx, kid)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", auth.ErrInvalidLogoutToken, err)
+	}
+
+	jwtToken, err := jwt.Parse(token, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return publicKey, nil
+	})
+	if err != nil || !jwtToken.Valid {
+		return nil, auth.ErrInvalidLogoutToken
+	}
+
+	_, claimsMap, err := v.jwtParser.ParseWithoutVerification(token)
+	if err != nil {
+		return nil, auth.ErrInvalidLogoutToken
+	}
+
+	return v.parseLogoutClaims(claimsMap)
+}
+
+// parseLogoutClaims validates the logout-specific claims.
+func (v *TokenVerifier) parseLogoutClaims(claimsMap map[string]any) (*auth.LogoutClaims, error) {
+	claims := &auth.LogoutClaims{
+		IssuedAt:  parseNumericTime(claimsMap["iat"]),
+		ExpiresAt: parseNumericTime(claimsMap["exp"]),
+	}
+	claims.Issuer, _ = claimsMap["iss"].(string)
+	claims.Subject, _ = claimsMap["sub"].(string)
+	claims.SessionID, _ = claimsMap["sid"].(string)
+	claims.TokenID, _ = claimsMap["jti"].(string)
+
+	if !v.isTrustedIssuer(claims.Issuer) {
+		return nil, fmt.Errorf("%w: untrusted issuer", auth.ErrInvalidLogoutToken)
+	}
+
+	if !slices.Contains(parseStringSlice(claimsMap["aud"]), v.cfg.ProjectID) {
+		return nil, fmt.Errorf("%w: audience mismatch", auth.ErrInvalidLogoutToken)
+	}
+
+	if claims.IssuedAt.IsZero() || claims.TokenID == "" {
+		return nil, fmt.Errorf("%w: iat and jti are required", auth.ErrInvalidLogoutToken)
+	}
+
+	events, ok := claimsMap["events"].(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing events claim", auth.ErrInvalidLogoutToken)
+	}
+	if _, ok := events[auth.BackChannelLogoutEvent]; !ok {
+		return nil, fmt.Errorf("%w: missing back-channel logout event", auth.ErrInvalidLogoutToken)
+	}
+
+	if _, hasNonce := claimsMap["nonce"]; hasNonce {
+		return nil, fmt.Errorf("%w: nonce is not allowed", auth.ErrInvalidLogoutToken)
+	}
+
+	if claims.Subject == "" && claims.SessionID == "" {
+		return nil, fmt.Errorf("%w: sub or sid is required", auth.ErrInvalidLogoutToken)
+	}
+
+	return claims, nil
+}
+
+// isTrustedIssuer reports whether the issuer is exactly one of the project's
+// issuers. Issuers naming another project or another domain are rejected.
+func (v *TokenVerifier) isTrustedIssuer(issuer string) bool {
+	return issuer != "" && slices.Contains(v.cfg.Issuers(), issuer)
+}
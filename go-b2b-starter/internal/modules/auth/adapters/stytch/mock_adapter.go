@@ -18,8 +18,13 @@ type MockAuthAdapter struct {
 	logger logger.Logger
 }
 
-// Ensure MockAuthAdapter implements auth.AuthProvider.
-var _ auth.AuthProvider = (*MockAuthAdapter)(nil)
+// Ensure MockAuthAdapter implements auth.AuthProvider and OIDC logout support.
+var (
+	_ auth.AuthProvider        = (*MockAuthAdapter)(nil)
+	_ auth.LogoutTokenVerifier = (*MockAuthAdapter)(nil)
+	_ auth.SessionRevoker      = (*MockAuthAdapter)(nil)
+	_ auth.SessionLister       = (*MockAuthAdapter)(nil)
+)
 
 func NewMockAuthAdapter(log logger.Logger) *MockAuthAdapter {
 	return &MockAuthAdapter{
@@ -47,7 +52,9 @@ func (m *MockAuthAdapter) VerifyToken(ctx context.Context, token string) (*auth.
 		Permissions: []auth.Permission{
 			auth.NewPermission("*", "*"), // Wildcard permission for development
 		},
-		ExpiresAt: time.Now().Add(24 * time.Hour),
+		SessionID:       "mock-session-123",
+		AuthenticatedAt: time.Now(),
+		ExpiresAt:       time.Now().Add(24 * time.Hour),
 		Raw: map[string]any{
 			"mock":       true,
 			"session_id": "mock-session-123",
@@ -82,3 +89,73 @@ func (m *MockAuthAdapter) ValidatePermission(ctx context.Context, identity *auth
 func (m *MockAuthAdapter) RefreshSession(ctx context.Context, sessionToken string) (*auth.Identity, error) {
 	return nil, fmt.Errorf("mock adapter: RefreshSession not implemented")
 }
+
+// VerifyLogoutToken parses the logout token without verifying its signature.
+// This is for development only and should never be used in production.
+func (m *MockAuthAdapter) VerifyLogoutToken(ctx context.Context, token string) (*auth.LogoutClaims, error) {
+	_, claimsMap, err := NewJWTParser().ParseWithoutVerification(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", auth.ErrInvalidLogoutToken, err)
+	}
+
+	claims := &auth.LogoutClaims{
+		IssuedAt:  parseNumericTime(claimsMap["iat"]),
+		ExpiresAt: parseNumericTime(claimsMap["exp"]),
+	}
+	claims.Issuer, _ = claimsMap["iss"].(string)
+	claims.Subject, _ = claimsMap["sub"].(string)
+	claims.SessionID, _ = claimsMap["sid"].(string)
+	claims.TokenID, _ = claimsMap["jti"].(string)
+
+	if claims.Subject == "" && claims.SessionID == "" {
+		return nil, fmt.Errorf("%w: sub or sid is required", auth.ErrInvalidLogoutToken)
+	}
+	return claims, nil
+}
+
+// ValidateIssuer accepts any issuer in mock mode.
+func (m *MockAuthAdapter) ValidateIssuer(issuer string) error {
+	return nil
+}
+
+// RevokeSession is a no-op in mock mode.
+func (m *MockAuthAdapter) RevokeSession(ctx context.Context, sessionID string) error {
+	m.logger.Debug("Mock adapter ignoring session revocation", map[string]any{
+		"session_id": sessionID,
+	})
+	return nil
+}
+
+// RevokeUserSessions is a no-op in mock mode.
+func (m *MockAuthAdapter) RevokeUserSessions(ctx context.Context, userID string) error {
+	m.logger.Debug("Mock adapter ignoring member session revocation", map[string]any{
+		"member_id": userID,
+	})
+	return nil
+}
+
+// ListUserSessions returns the single mock session in mock mode.
+func (m *MockAuthAdapter) ListUserSessions(ctx context.Context, organizationID, userID string) ([]auth.Session, error) {
+	now := time.Now()
+	return []auth.Session{
+		{
+			ID:                    "mock-session-123",
+			StartedAt:             now.Add(-time.Hour),
+			LastAccessedAt:        now,
+			ExpiresAt:             now.Add(24 * time.Hour),
+			AuthenticationMethods: []string{"mock"},
+		},
+	}, nil
+}
+
+// SwitchOrganization returns mock tokens in mock mode.
+func (m *MockAuthAdapter) SwitchOrganization(ctx context.Context, token, organizationID string) (*auth.SessionTokens, error) {
+	m.logger.Debug("Mock adapter switching organization", map[string]any{
+		"organization_id": organizationID,
+	})
+	return &auth.SessionTokens{
+		AccessToken:  "mock-session-jwt",
+		SessionToken: "mock-session-token",
+		ExpiresAt:    time.Now().Add(24 * time.Hour),
+	}, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/moasq/go-b2b-starter/internal/platform/logger"
 	"github.com/stytchauth/stytch-go/v16/stytch/b2b/b2bstytchapi"
 	"github.com/stytchauth/stytch-go/v16/stytch/b2b/sessions"
+	consumersessions "github.com/stytchauth/stytch-go/v16/stytch/consumer/sessions"
 	"github.com/stytchauth/stytch-go/v16/stytch/stytcherror"
 )
 
@@ -52,11 +54,15 @@ type internalClaims struct {
 	Email          string
 	EmailVerified  bool
 	OrganizationID string
+	SessionID      string
+	TokenID        string
 	Roles          []string
 	Permissions    []auth.Permission
 	IssuedAt       time.Time
 	ExpiresAt      time.Time
 	NotBefore      time.Time
+	AuthTime       time.Time
+	AuthMethods    []string
 	Issuer         string
 	Audience       []string
 	Raw            map[string]any
@@ -142,14 +148,19 @@ func (v *TokenVerifier) verifyLocally(ctx context.Context, token string) (*auth.
 
 	// 7. Convert to Identity
 	return &auth.Identity{
-		UserID:         claims.Subject,
-		Email:          claims.Email,
-		EmailVerified:  claims.EmailVerified,
-		OrganizationID: claims.OrganizationID,
-		Roles:          v.convertRoles(claims.Roles),
-		Permissions:    permissions,
-		ExpiresAt:      claims.ExpiresAt,
-		Raw:            claims.Raw,
+		UserID:          claims.Subject,
+		Email:           claims.Email,
+		EmailVerified:   claims.EmailVerified,
+		OrganizationID:  claims.OrganizationID,
+		Roles:           v.convertRoles(claims.Roles),
+		Permissions:     permissions,
+		SessionID:       claims.SessionID,
+		TokenID:         claims.TokenID,
+		IssuedAt:        claims.IssuedAt,
+		AuthenticatedAt: claims.AuthTime,
+		AuthMethods:     claims.AuthMethods,
+		ExpiresAt:       claims.ExpiresAt,
+		Raw:             claims.Raw,
 	}, nil
 }
 
@@ -184,13 +195,16 @@ func (v *TokenVerifier) verifyViaAPI(ctx context.Context, token string) (*auth.I
 
 	// Build identity
 	identity := &auth.Identity{
-		UserID:         session.MemberID,
-		Email:          member.EmailAddress,
-		EmailVerified:  member.EmailAddressVerified,
-		OrganizationID: session.OrganizationID,
-		Roles:          v.convertRoles(session.Roles),
-		Permissions:    permissions,
-		ExpiresAt:      timeValue(session.ExpiresAt),
+		UserID:          session.MemberID,
+		Email:           member.EmailAddress,
+		EmailVerified:   member.EmailAddressVerified,
+		OrganizationID:  session.OrganizationID,
+		Roles:           v.convertRoles(session.Roles),
+		Permissions:     permissions,
+		SessionID:       session.MemberSessionID,
+		AuthenticatedAt: lastAuthenticatedAt(session.AuthenticationFactors),
+		AuthMethods:     sessionAuthMethods(session.AuthenticationFactors),
+		ExpiresAt:       timeValue(session.ExpiresAt),
 		Raw: map[string]any{
 			"member_session": session,
 			"member":         member,
@@ -217,14 +231,19 @@ func (v *TokenVerifier) verifyWithoutSignature(ctx context.Context, token string
 	permissions := v.derivePermissions(ctx, claims.Roles)
 
 	return &auth.Identity{
-		UserID:         claims.Subject,
-		Email:          claims.Email,
-		EmailVerified:  claims.EmailVerified,
-		OrganizationID: claims.OrganizationID,
-		Roles:          v.convertRoles(claims.Roles),
-		Permissions:    permissions,
-		ExpiresAt:      claims.ExpiresAt,
-		Raw:            claims.Raw,
+		UserID:          claims.Subject,
+		Email:           claims.Email,
+		EmailVerified:   claims.EmailVerified,
+		OrganizationID:  claims.OrganizationID,
+		Roles:           v.convertRoles(claims.Roles),
+		Permissions:     permissions,
+		SessionID:       claims.SessionID,
+		TokenID:         claims.TokenID,
+		IssuedAt:        claims.IssuedAt,
+		AuthenticatedAt: claims.AuthTime,
+		AuthMethods:     claims.AuthMethods,
+		ExpiresAt:       claims.ExpiresAt,
+		Raw:             claims.Raw,
 	}, nil
 }
 
@@ -239,16 +258,36 @@ func (v *TokenVerifier) parseClaimsFromMap(claimsMap map[string]any) *internalCl
 		claims.Subject = sub
 	}
 
+	// Extract token ID (used for per-token revocation)
+	if jti, ok := claimsMap["jti"].(string); ok {
+		claims.TokenID = jti
+	}
+
 	// Extract email from Stytch session authentication factors
 	// Format: https://stytch.com/session.authentication_factors[].email_factor.email_address
 	if sessionObj, ok := claimsMap["https://stytch.com/session"].(map[string]any); ok {
+		if sessionID, ok := sessionObj["id"].(string); ok {
+			claims.SessionID = sessionID
+		}
+
+		// The most recent factor authentication is when the member last
+		// proved who they are (used for step-up authentication)
 		if factors, ok := sessionObj["authentication_factors"].([]any); ok {
 			for _, factor := range factors {
 				if factorMap, ok := factor.(map[string]any); ok {
-					if emailFactor, ok := factorMap["email_factor"].(map[string]any); ok {
+					if emailFactor, ok := factorMap["email_factor"].(map[string]any); ok && claims.Email == "" {
 						if emailAddr, ok := emailFactor["email_address"].(string); ok {
 							claims.Email = emailAddr
-							break
+						}
+					}
+					if at, ok := factorMap["last_authenticated_at"].(string); ok {
+						if t, err := time.Parse(time.RFC3339, at); err == nil && t.After(claims.AuthTime) {
+							claims.AuthTime = t.UTC()
+						}
+					}
+					if factorType, ok := factorMap["type"].(string); ok {
+						if method := authMethod(factorType); method != "" && !slices.Contains(claims.AuthMethods, method) {
+							claims.AuthMethods = append(claims.AuthMethods, method)
 						}
 					}
 				}
@@ -297,6 +336,13 @@ func (v *TokenVerifier) parseClaimsFromMap(claimsMap map[string]any) *internalCl
 		}
 	}
 
+	// Fallback to standard OIDC session claim
+	if claims.SessionID == "" {
+		if sid, ok := claimsMap["sid"].(string); ok {
+			claims.SessionID = sid
+		}
+	}
+
 	// Parse timestamps
 	claims.IssuedAt = parseNumericTime(claimsMap["iat"])
 	claims.ExpiresAt = parseNumericTime(claimsMap["exp"])
@@ -477,6 +523,55 @@ func parseNumericTime(value any) time.Time {
 	}
 }
 
+// lastAuthenticatedAt returns the most recent authentication across the
+// session's factors, or zero if none reports one.
+func lastAuthenticatedAt(factors []consumersessions.AuthenticationFactor) time.Time {
+	var latest time.Time
+	for _, factor := range factors {
+		if at := timeValue(factor.LastAuthenticatedAt); at.After(latest) {
+			latest = at
+		}
+	}
+	return latest
+}
+
+// sessionAuthMethods returns the distinct methods the session's factors were
+// authenticated with.
+func sessionAuthMethods(factors []consumersessions.AuthenticationFactor) []string {
+	var methods []string
+	for _, factor := range factors {
+		if method := authMethod(string(factor.Type)); method != "" && !slices.Contains(methods, method) {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// authMethod maps a Stytch authentication factor type to an auth.AuthMethod
+// constant, or "" for factors that are not a sign-in method (e.g. impersonated).
+func authMethod(factorType string) string {
+	switch factorType {
+	case "password":
+		return auth.AuthMethodPassword
+	case "magic_link":
+		return auth.AuthMethodMagicLink
+	case "email_otp":
+		return auth.AuthMethodEmailOTP
+	case "oauth":
+		return auth.AuthMethodOAuth
+	case "sso":
+		return auth.AuthMethodSSO
+	case "otp":
+		return auth.AuthMethodSMSOTP
+	case "totp":
+		return auth.AuthMethodTOTP
+	case "recovery_codes":
+		return auth.AuthMethodRecoveryCode
+	default:
+		return ""
+	}
+}
+
 func timeValue(ts *time.Time) time.Time {
 	if ts == nil {
 		return time.Time{}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// APIUsage is an account's request count in the current quota window.
+type APIUsage struct {
+	// Count is the number of requests in the window, including this one
+	Count int64
+
+	// Limit is the quota for the window. 0 means requests are only counted.
+	Limit int64
+
+	// ResetAt is when the window ends and the count starts over
+	ResetAt time.Time
+}
+
+// Exceeded reports whether the request that was counted is over the quota.
+func (u *APIUsage) Exceeded() bool {
+	return u.Limit > 0 && u.Count > u.Limit
+}
+
+// Remaining is how many more requests fit in the window.
+func (u *APIUsage) Remaining() int64 {
+	if u.Limit <= 0 || u.Count >= u.Limit {
+		return 0
+	}
+	return u.Limit - u.Count
+}
+
+// UsageMeter counts the API requests of accounts against their quota.
+//
+// This interface decouples auth middleware from the organizations domain.
+// Implement this interface to track per-account usage and enforce request
+// quotas, e.g. limits that depend on the organization's plan.
+type UsageMeter interface {
+	// CountRequest counts a request by the account and returns its usage in
+	// the current window. It returns nil when the request was not counted
+	// (usage tracking is off, or the counter store is down and tracking fails
+	// open), and an error when the request must be rejected because it could
+	// not be counted.
+	CountRequest(ctx context.Context, orgID, accountID int32) (*APIUsage, error)
+}
+
+// enforceUsage counts the request and reports whether it may proceed,
+// responding with 429 once the account is over its quota. Responses carry
+// X-RateLimit-* headers while a quota is set.
+func (m *Middleware) enforceUsage(c *gin.Context, orgID, accountID int32) bool {
+	usage, err := m.config.Usage.CountRequest(c.Request.Context(), orgID, accountID)
+	if err != nil {
+		m.config.ErrorHandler(c, http.StatusServiceUnavailable, "failed to count API usage", err)
+		c.Abort()
+		return false
+	}
+	if usage == nil || usage.Limit <= 0 {
+		return true
+	}
+
+	resetIn := time.Until(usage.ResetAt)
+	c.Header("X-RateLimit-Limit", strconv.FormatInt(usage.Limit, 10))
+	c.Header("X-RateLimit-Remaining", strconv.FormatInt(usage.Remaining(), 10))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(usage.ResetAt.Unix(), 10))
+
+	if usage.Exceeded() {
+		apiQuotaExceeded.Inc()
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(resetIn.Seconds()))))
+		c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+			"error":   "quota_exceeded",
+			"message": "API request quota exceeded, please try again later",
+		})
+		return false
+	}
+	return true
+}
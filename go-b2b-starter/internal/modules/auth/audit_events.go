@@ -0,0 +1,208 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+// Auth event types, published on the event bus and stored in the auth audit log.
+const (
+	AuthEventLoginSucceeded  = "auth.login_succeeded"
+	AuthEventLoginFailed     = "auth.login_failed"
+	AuthEventTokenRejected   = "auth.token_rejected"
+	AuthEventLockout         = "auth.lockout"
+	AuthEventTokenRefreshed  = "auth.token_refreshed"
+	AuthEventLogout          = "auth.logout"
+	AuthEventPasswordChanged = "auth.password_changed"
+	AuthEventCanaryTriggered = "auth.canary_triggered"
+)
+
+// AuthEventTypes lists every auth event type.
+var AuthEventTypes = []string{
+	AuthEventLoginSucceeded,
+	AuthEventLoginFailed,
+	AuthEventTokenRejected,
+	AuthEventLockout,
+	AuthEventTokenRefreshed,
+	AuthEventLogout,
+	AuthEventPasswordChanged,
+	AuthEventCanaryTriggered,
+}
+
+const (
+	// Redis keys marking tokens and lockouts that were already recorded
+	authEventTokenKeyPattern   = "auth:events:token:%s"
+	authEventLockoutKeyPattern = "auth:events:lockout:%s"
+
+	// authEventTokenTTL is how long a token without an expiry is remembered
+	authEventTokenTTL = time.Hour
+)
+
+// AuthEvent is published for every auth event. Its name is the event type.
+//
+// OrganizationID is the auth provider's organization ID; subscribers resolve
+// database IDs themselves. Fields the event does not know are empty.
+type AuthEvent struct {
+	eventbus.BaseEvent
+	UserID         string `json:"user_id,omitempty"`
+	Email          string `json:"email,omitempty"`
+	OrganizationID string `json:"organization_id,omitempty"`
+	SessionID      string `json:"session_id,omitempty"`
+	ClientIP       string `json:"client_ip,omitempty"`
+	UserAgent      string `json:"user_agent,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// NewAuthEvent creates an auth event for the given identity, which may be nil.
+func NewAuthEvent(eventType string, identity *Identity) *AuthEvent {
+	event := &AuthEvent{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      eventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+	}
+	if identity != nil {
+		event.UserID = identity.UserID
+		event.Email = identity.Email
+		event.OrganizationID = identity.OrganizationID
+		event.SessionID = identity.SessionID
+	}
+	return event
+}
+
+// WithRequest records the client the event was observed from.
+func (e *AuthEvent) WithRequest(clientIP, userAgent string) *AuthEvent {
+	e.ClientIP = clientIP
+	e.UserAgent = userAgent
+	return e
+}
+
+// WithReason records why a login failed or how a logout was initiated.
+func (e *AuthEvent) WithReason(reason string) *AuthEvent {
+	e.Reason = reason
+	return e
+}
+
+// AuthEventPublisher publishes auth events on the event bus.
+//
+// Publishing never blocks or fails the request it happens on; delivery
+// problems are logged.
+type AuthEventPublisher interface {
+	// Publish publishes an event.
+	Publish(ctx context.Context, event *AuthEvent)
+
+	// ObserveToken publishes a login or token refresh the first time a
+	// provider token is seen. A token issued within the login window of the
+	// user's last sign-in (Identity.AuthenticatedAt) is a login; any other
+	// new token, including one without an authentication time, is a refresh.
+	ObserveToken(ctx context.Context, token string, identity *Identity, clientIP, userAgent string)
+
+	// ObserveLockout publishes a lockout the first time an IP and email pair
+	// is throttled within a window.
+	ObserveLockout(ctx context.Context, email, clientIP, userAgent string, retryAfter time.Duration)
+}
+
+type authEventPublisher struct {
+	bus    eventbus.EventBus
+	redis  redis.Client
+	cfg    *AuditLogConfig
+	logger logger.Logger
+}
+
+// NewAuthEventPublisher creates an AuthEventPublisher. When the audit log is
+// disabled, events are dropped.
+func NewAuthEventPublisher(bus eventbus.EventBus, redisClient redis.Client, cfg *AuditLogConfig, log logger.Logger) AuthEventPublisher {
+	return &authEventPublisher{
+		bus:    bus,
+		redis:  redisClient,
+		cfg:    cfg,
+		logger: log.Named("auth"),
+	}
+}
+
+func (p *authEventPublisher) Publish(ctx context.Context, event *AuthEvent) {
+	authEvents.WithLabelValues(event.EventName()).Inc()
+
+	if !p.cfg.Enabled {
+		return
+	}
+
+	// Handlers write to the database; keep them off the request path
+	go func() {
+		if err := p.bus.Publish(context.WithoutCancel(ctx), event); err != nil {
+			p.logger.Warn("failed to publish auth event", logger.Fields{
+				"event_type": event.EventName(),
+				"error":      err.Error(),
+			})
+		}
+	}()
+}
+
+func (p *authEventPublisher) ObserveToken(ctx context.Context, token string, identity *Identity, clientIP, userAgent string) {
+	if !p.cfg.Enabled {
+		return
+	}
+
+	ttl := time.Until(identity.ExpiresAt)
+	if identity.ExpiresAt.IsZero() {
+		ttl = authEventTokenTTL
+	}
+	if !p.firstSeen(ctx, fmt.Sprintf(authEventTokenKeyPattern, hashAuthEventKey(token)), ttl) {
+		return
+	}
+
+	eventType := AuthEventTokenRefreshed
+	if !identity.AuthenticatedAt.IsZero() && !identity.IssuedAt.IsZero() &&
+		identity.IssuedAt.Sub(identity.AuthenticatedAt) <= p.cfg.LoginWindow {
+		eventType = AuthEventLoginSucceeded
+	}
+
+	p.Publish(ctx, NewAuthEvent(eventType, identity).WithRequest(clientIP, userAgent))
+}
+
+func (p *authEventPublisher) ObserveLockout(ctx context.Context, email, clientIP, userAgent string, retryAfter time.Duration) {
+	if !p.cfg.Enabled {
+		return
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !p.firstSeen(ctx, fmt.Sprintf(authEventLockoutKeyPattern, hashAuthEventKey(clientIP+"|"+email)), retryAfter) {
+		return
+	}
+
+	event := NewAuthEvent(AuthEventLockout, nil).WithRequest(clientIP, userAgent)
+	event.Email = email
+	p.Publish(ctx, event)
+}
+
+// firstSeen marks key for ttl and reports whether it was unmarked. Redis
+// errors report false, so an outage skips events rather than repeating them.
+func (p *authEventPublisher) firstSeen(ctx context.Context, key string, ttl time.Duration) bool {
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+
+	seen, err := p.redis.Exists(ctx, key)
+	if err != nil || seen {
+		return false
+	}
+	return p.redis.Set(ctx, key, "1", ttl) == nil
+}
+
+// hashAuthEventKey hashes tokens and emails so they are not stored in Redis keys.
+func hashAuthEventKey(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
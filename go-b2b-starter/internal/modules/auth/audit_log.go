@@ -0,0 +1,295 @@
+package auth
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+)
+
+// auditLogCleanupJob deletes auth events older than AUTH_AUDIT_RETENTION
+var auditLogCleanupJob = jobsDomain.Definition{
+	Name:        "auth.audit_log_cleanup",
+	Kind:        jobsDomain.KindScheduled,
+	Description: "Deletes auth audit events older than AUTH_AUDIT_RETENTION",
+}
+
+// AuditLogConfig configures the auth audit log.
+//
+// All values can be set via environment variables with the AUTH_AUDIT_ prefix.
+type AuditLogConfig struct {
+	// Enabled turns publishing and recording of auth events on
+	Enabled bool `mapstructure:"AUTH_AUDIT_ENABLED"`
+
+	// LoginWindow is how soon after the user's sign-in a token must be issued
+	// to count as a login rather than a token refresh
+	LoginWindow time.Duration `mapstructure:"AUTH_AUDIT_LOGIN_WINDOW"`
+
+	// Retention is how long events are kept
+	Retention time.Duration `mapstructure:"AUTH_AUDIT_RETENTION"`
+
+	// CleanupInterval is how often expired events are deleted
+	CleanupInterval time.Duration `mapstructure:"AUTH_AUDIT_CLEANUP_INTERVAL"`
+}
+
+// LoadAuditLogConfig loads the auth audit log configuration from environment variables and app.env file.
+func LoadAuditLogConfig() (*AuditLogConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("AUTH_AUDIT_ENABLED", true)
+	v.SetDefault("AUTH_AUDIT_LOGIN_WINDOW", "1m")
+	v.SetDefault("AUTH_AUDIT_RETENTION", "2160h")
+	v.SetDefault("AUTH_AUDIT_CLEANUP_INTERVAL", "1h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg AuditLogConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode auth audit config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that an enabled audit log has usable durations.
+func (c *AuditLogConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.LoginWindow <= 0 {
+		return fmt.Errorf("auth audit config invalid: AUTH_AUDIT_LOGIN_WINDOW must be positive")
+	}
+	if c.Retention <= 0 || c.CleanupInterval <= 0 {
+		return fmt.Errorf("auth audit config invalid: AUTH_AUDIT_RETENTION and AUTH_AUDIT_CLEANUP_INTERVAL must be positive")
+	}
+	return nil
+}
+
+// AuditLogEntry is a stored auth event.
+type AuditLogEntry struct {
+	ID int64 `json:"id"`
+
+	// OrganizationID and AccountID are database IDs; nil when the event could
+	// not be tied to an organization or account.
+	OrganizationID *int32 `json:"organization_id,omitempty"`
+	AccountID      *int32 `json:"account_id,omitempty"`
+
+	EventType  string    `json:"event_type"`
+	UserID     string    `json:"user_id,omitempty"`
+	Email      string    `json:"email,omitempty"`
+	SessionID  string    `json:"session_id,omitempty"`
+	ClientIP   string    `json:"client_ip,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// AuditLogFilter selects auth events of one organization. Empty fields match everything.
+type AuditLogFilter struct {
+	OrganizationID int32
+	UserID         string
+	EventType      string
+	From           time.Time // inclusive
+	To             time.Time // exclusive
+	Limit          int32
+	Offset         int32
+}
+
+// AuditLogCursor marks the last event of a keyset page. Events are ordered
+// by (OccurredAt, ID) newest first, so the next page starts strictly after it.
+type AuditLogCursor struct {
+	OccurredAt time.Time
+	ID         int64
+}
+
+// Encode returns the cursor as an opaque URL-safe string.
+func (c AuditLogCursor) Encode() string {
+	raw := strconv.FormatInt(c.OccurredAt.UnixNano(), 10) + "." + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// ParseAuditLogCursor decodes a cursor returned by Encode.
+func ParseAuditLogCursor(s string) (*AuditLogCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidAuditCursor
+	}
+	at, id, ok := strings.Cut(string(raw), ".")
+	if !ok {
+		return nil, ErrInvalidAuditCursor
+	}
+	nanos, err := strconv.ParseInt(at, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidAuditCursor
+	}
+	eventID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil || eventID <= 0 {
+		return nil, ErrInvalidAuditCursor
+	}
+	return &AuditLogCursor{OccurredAt: time.Unix(0, nanos).UTC(), ID: eventID}, nil
+}
+
+// AuditLogPage is a keyset page of auth events. NextCursor is nil on the last page.
+type AuditLogPage struct {
+	Items      []*AuditLogEntry
+	NextCursor *AuditLogCursor
+}
+
+// AuditLogRepository stores auth events.
+type AuditLogRepository interface {
+	Create(ctx context.Context, entry *AuditLogEntry) (*AuditLogEntry, error)
+
+	// List returns a page of events, newest first, and the total match count
+	List(ctx context.Context, filter AuditLogFilter) ([]*AuditLogEntry, int64, error)
+
+	// ListAfter returns up to filter.Limit events, newest first, that come
+	// after the cursor (from the newest event if nil). It ignores
+	// filter.Offset and skips the total count, so deep pages stay cheap.
+	ListAfter(ctx context.Context, filter AuditLogFilter, after *AuditLogCursor) (*AuditLogPage, error)
+
+	// DeleteBefore deletes events that occurred before cutoff
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// AuditLogService records auth events from the event bus and answers queries.
+type AuditLogService interface {
+	// Record stores an event, resolving its organization and account from
+	// the provider organization ID and email when they exist.
+	Record(ctx context.Context, event *AuthEvent) error
+
+	// List returns a page of an organization's events and the total match count
+	List(ctx context.Context, filter AuditLogFilter) ([]*AuditLogEntry, int64, error)
+
+	// ListAfter returns a keyset page of an organization's events after the cursor
+	ListAfter(ctx context.Context, filter AuditLogFilter, after *AuditLogCursor) (*AuditLogPage, error)
+
+	// Run deletes expired events every cleanup interval until ctx is done
+	Run(ctx context.Context)
+}
+
+type auditLogService struct {
+	repo        AuditLogRepository
+	orgResolver OrganizationResolver
+	accResolver AccountResolver
+	tracker     jobsDomain.Tracker
+	cfg         *AuditLogConfig
+	logger      logger.Logger
+}
+
+// NewAuditLogService creates an AuditLogService.
+func NewAuditLogService(
+	repo AuditLogRepository,
+	orgResolver OrganizationResolver,
+	accResolver AccountResolver,
+	tracker jobsDomain.Tracker,
+	cfg *AuditLogConfig,
+	log logger.Logger,
+) AuditLogService {
+	def := auditLogCleanupJob
+	def.Schedule = "every " + cfg.CleanupInterval.String()
+	tracker.Register(def)
+
+	return &auditLogService{
+		repo:        repo,
+		orgResolver: orgResolver,
+		accResolver: accResolver,
+		tracker:     tracker,
+		cfg:         cfg,
+		logger:      log.Named("auth"),
+	}
+}
+
+func (s *auditLogService) Record(ctx context.Context, event *AuthEvent) error {
+	entry := &AuditLogEntry{
+		EventType:  event.EventName(),
+		UserID:     event.UserID,
+		Email:      event.Email,
+		SessionID:  event.SessionID,
+		ClientIP:   event.ClientIP,
+		UserAgent:  event.UserAgent,
+		Reason:     event.Reason,
+		OccurredAt: event.Timestamp(),
+	}
+
+	// Events of deleted or not yet provisioned organizations are kept without one
+	if event.OrganizationID != "" {
+		if orgID, err := s.orgResolver.ResolveByProviderID(ctx, event.OrganizationID); err == nil {
+			entry.OrganizationID = &orgID
+			if event.Email != "" {
+				if accountID, err := s.accResolver.ResolveByEmail(ctx, orgID, event.Email); err == nil {
+					entry.AccountID = &accountID
+				}
+			}
+		}
+	}
+
+	if _, err := s.repo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to record auth event: %w", err)
+	}
+	return nil
+}
+
+func (s *auditLogService) List(ctx context.Context, filter AuditLogFilter) ([]*AuditLogEntry, int64, error) {
+	if filter.EventType != "" && !slices.Contains(AuthEventTypes, filter.EventType) {
+		return nil, 0, ErrInvalidAuthEventType
+	}
+	return s.repo.List(ctx, filter)
+}
+
+func (s *auditLogService) ListAfter(ctx context.Context, filter AuditLogFilter, after *AuditLogCursor) (*AuditLogPage, error) {
+	if filter.EventType != "" && !slices.Contains(AuthEventTypes, filter.EventType) {
+		return nil, ErrInvalidAuthEventType
+	}
+	return s.repo.ListAfter(ctx, filter, after)
+}
+
+func (s *auditLogService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.CleanupInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("auth audit log cleanup started", logger.Fields{
+		"interval":  s.cfg.CleanupInterval.String(),
+		"retention": s.cfg.Retention.String(),
+	})
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		err := s.tracker.Track(ctx, auditLogCleanupJob, func(ctx context.Context) error {
+			deleted, err := s.repo.DeleteBefore(ctx, time.Now().Add(-s.cfg.Retention))
+			if err == nil && deleted > 0 {
+				s.logger.Info("deleted expired auth events", logger.Fields{"deleted": deleted})
+			}
+			return err
+		})
+		if err != nil {
+			s.logger.Error("auth audit log cleanup failed", logger.Fields{"error": err.Error()})
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	listingshared "github.com/moasq/go-b2b-starter/pkg/pagination"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// ListAuditEventsParams filters and pages the auth audit log
+type ListAuditEventsParams struct {
+	UserID    string    `form:"user_id"`
+	EventType string    `form:"event_type"`
+	From      time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To        time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	listingshared.ListableParams
+}
+
+// AuditEventsCursorPage is a keyset page of the auth audit log
+type AuditEventsCursorPage struct {
+	Items []*AuditLogEntry `json:"items"`
+
+	// NextCursor fetches the following page; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// AuditLogHandler serves an organization's auth audit log.
+type AuditLogHandler struct {
+	service AuditLogService
+}
+
+func NewAuditLogHandler(service AuditLogService) *AuditLogHandler {
+	return &AuditLogHandler{
+		service: service,
+	}
+}
+
+// ListEvents godoc
+// @Summary List auth events
+// @Description Returns the organization's auth events newest first: logins, token refreshes, logouts and password changes. Rejected tokens and lockouts cannot be tied to an organization and are not included.
+// @Description Pass cursor (empty for the first page) to page by keyset instead of page number: the response is then {items, next_cursor} without a total count, and stays fast however deep you page.
+// @Tags Auth
+// @Produce json
+// @Param user_id query string false "Auth provider user ID"
+// @Param event_type query string false "auth.login_succeeded, auth.login_failed, auth.token_rejected, auth.lockout, auth.token_refreshed, auth.logout or auth.password_changed"
+// @Param from query string false "Earliest occurrence (RFC 3339, inclusive)"
+// @Param to query string false "Latest occurrence (RFC 3339, exclusive)"
+// @Param cursor query string false "next_cursor of the previous page; switches to keyset paging"
+// @Param page query int false "Page number (default 1); ignored with cursor"
+// @Param limit query int false "Page size (default 10, max 100)"
+// @Success 200 {object} listingshared.PagePagination[AuditLogEntry] "Events"
+// @Success 200 {object} AuditEventsCursorPage "Events, when paging by cursor"
+// @Failure 400 {object} map[string]string "Invalid filter or cursor"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/audit-log [get]
+func (h *AuditLogHandler) ListEvents(c *gin.Context) {
+	reqCtx := GetRequestContext(c)
+	if reqCtx == nil {
+		response.Error(c, http.StatusUnauthorized, "authentication required", nil)
+		return
+	}
+
+	var params ListAuditEventsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+	if err := params.Validate(); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+	if !params.From.IsZero() && !params.To.IsZero() && !params.From.Before(params.To) {
+		response.Error(c, http.StatusBadRequest, "from must be before to", nil)
+		return
+	}
+
+	filter := AuditLogFilter{
+		OrganizationID: reqCtx.OrganizationID,
+		UserID:         params.UserID,
+		EventType:      params.EventType,
+		From:           params.From,
+		To:             params.To,
+		Limit:          int32(params.Limit),
+	}
+
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		h.listEventsAfter(c, filter, cursor)
+		return
+	}
+
+	offset, err := listingshared.PageToOffset(params.Page, params.Limit)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	filter.Offset = int32(offset)
+	events, total, err := h.service.List(c.Request.Context(), filter)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAuthEventType) {
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to list auth events", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, listingshared.NewPagePagination(int(total), params.Page, params.Limit, events))
+}
+
+// listEventsAfter serves a keyset page starting after cursor ("" for the first page)
+func (h *AuditLogHandler) listEventsAfter(c *gin.Context, filter AuditLogFilter, cursor string) {
+	var after *AuditLogCursor
+	if cursor != "" {
+		parsed, err := ParseAuditLogCursor(cursor)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		after = parsed
+	}
+
+	page, err := h.service.ListAfter(c.Request.Context(), filter, after)
+	if err != nil {
+		if errors.Is(err, ErrInvalidAuthEventType) {
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to list auth events", err)
+		return
+	}
+
+	result := AuditEventsCursorPage{Items: page.Items}
+	if page.NextCursor != nil {
+		result.NextCursor = page.NextCursor.Encode()
+	}
+	response.Success(c, http.StatusOK, result)
+}
@@ -109,6 +109,37 @@ type Identity struct {
 	// These are derived from roles by the auth provider or adapter.
 	Permissions []Permission `json:"permissions"`
 
+	// SessionID is the auth provider's session identifier (the OIDC "sid").
+	// It is used to match front-channel and back-channel logout requests.
+	SessionID string `json:"session_id,omitempty"`
+
+	// TokenID is the token's unique identifier (the JWT "jti"), used to revoke
+	// a single access token. Empty if the provider did not issue one.
+	TokenID string `json:"token_id,omitempty"`
+
+	// Guest is true for anonymous guest identities (see GuestVerifier).
+	Guest bool `json:"guest,omitempty"`
+
+	// ClientID is set for machine identities from the client credentials
+	// grant (see ClientTokenVerifier). UserID is then the client ID too.
+	ClientID string `json:"client_id,omitempty"`
+
+	// ServiceAccountID is set for service accounts authenticated with an API
+	// key (see ServiceAccountKeyVerifier). UserID is then the same username.
+	ServiceAccountID string `json:"service_account_id,omitempty"`
+
+	// IssuedAt is when the token was issued. Zero if the provider did not say.
+	IssuedAt time.Time `json:"issued_at,omitempty"`
+
+	// AuthenticatedAt is when the user last actively authenticated (password,
+	// SSO or MFA), as opposed to when the token was refreshed. Zero if the
+	// provider did not say; RequireRecentAuth then always asks to step up.
+	AuthenticatedAt time.Time `json:"authenticated_at,omitempty"`
+
+	// AuthMethods are the factors the user authenticated with in this session
+	// (see the AuthMethod constants). Empty if the provider did not say.
+	AuthMethods []string `json:"auth_methods,omitempty"`
+
 	// ExpiresAt is when the token/session expires.
 	ExpiresAt time.Time `json:"expires_at"`
 
@@ -164,6 +195,10 @@ type RequestContext struct {
 	// ProviderOrgID preserves the original provider organization ID for reference.
 	// Use this when making calls back to the auth provider.
 	ProviderOrgID string `json:"provider_org_id,omitempty"`
+
+	// Elevation is the active just-in-time role grant, if any.
+	// Its role is already included in Identity.Roles and Identity.Permissions.
+	Elevation *Elevation `json:"elevation,omitempty"`
 }
 
 // OrganizationRepository defines the interface for looking up organizations.
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CanaryUse describes the request a canary credential was used in.
+type CanaryUse struct {
+	// OrganizationID is the database ID of the organization the request is
+	// made in, or 0 before one is resolved. Canaries of other organizations
+	// are ignored when it is set.
+	OrganizationID int32 `json:"organization_id,omitempty"`
+
+	ClientIP  string `json:"client_ip"`
+	UserAgent string `json:"user_agent"`
+	Method    string `json:"method"`
+	Path      string `json:"path"`
+}
+
+// NewCanaryUse describes the request in c.
+func NewCanaryUse(c *gin.Context) *CanaryUse {
+	return &CanaryUse{
+		ClientIP:  c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Method:    c.Request.Method,
+		Path:      c.FullPath(),
+	}
+}
+
+// CanaryDetector recognizes canary credentials (honeytokens): decoy API keys
+// and users that are never used legitimately, so any use means a leak.
+//
+// This interface decouples auth middleware from the organizations domain.
+// Implement this interface to raise alerts when a canary is used.
+type CanaryDetector interface {
+	// DetectCanary reports whether identifier (an email or OAuth client ID)
+	// is a canary credential, raising the alarm when it is. Lookup errors
+	// report false so an outage does not block sign-ins.
+	DetectCanary(ctx context.Context, identifier string, use *CanaryUse) bool
+}
+
+// CanaryTrap returns middleware that reports uses of canary credentials on
+// login-flow and token endpoints.
+//
+// The email is taken like LoginRateLimit does, the client ID from HTTP Basic
+// credentials or the "client_id" form field. The request continues either
+// way: canary client IDs and emails fail like unknown ones, so the response
+// does not tell an attacker the credential was a decoy.
+func CanaryTrap(detector CanaryDetector) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var use *CanaryUse
+		for _, identifier := range canaryIdentifiers(c) {
+			if use == nil {
+				use = NewCanaryUse(c)
+			}
+			detector.DetectCanary(c.Request.Context(), identifier, use)
+		}
+
+		c.Next()
+	}
+}
+
+// canaryIdentifiers returns the emails and client IDs a request presents.
+func canaryIdentifiers(c *gin.Context) []string {
+	var identifiers []string
+	if email := attemptEmail(c); email != "" {
+		identifiers = append(identifiers, email)
+	}
+	if clientID, _, ok := c.Request.BasicAuth(); ok {
+		if clientID, err := url.QueryUnescape(clientID); err == nil && clientID != "" {
+			identifiers = append(identifiers, clientID)
+		}
+	}
+	if c.ContentType() == gin.MIMEPOSTForm {
+		if clientID := c.PostForm("client_id"); clientID != "" {
+			identifiers = append(identifiers, clientID)
+		}
+	}
+	return identifiers
+}
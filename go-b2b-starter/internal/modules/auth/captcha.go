@@ -0,0 +1,364 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+// Supported values for CAPTCHA_PROVIDER.
+const (
+	CaptchaProviderNone      = "none"
+	CaptchaProviderTurnstile = "turnstile"
+	CaptchaProviderHCaptcha  = "hcaptcha"
+	CaptchaProviderReCaptcha = "recaptcha"
+)
+
+const (
+	// Siteverify endpoints of the supported providers
+	turnstileVerifyURL = "https://challenges.cloudflare.com/turnstile/v0/siteverify"
+	hcaptchaVerifyURL  = "https://api.hcaptcha.com/siteverify"
+	recaptchaVerifyURL = "https://www.google.com/recaptcha/api/siteverify"
+
+	// CaptchaTokenHeader carries the widget response token; the JSON field
+	// "captcha_token" is accepted as well
+	CaptchaTokenHeader = "X-Captcha-Token"
+
+	// Redis keys for failed login-flow attempt counters
+	captchaFailuresIPKeyPattern    = "auth:captcha:failures:ip:%s"
+	captchaFailuresEmailKeyPattern = "auth:captcha:failures:email:%s"
+)
+
+// CaptchaConfig configures CAPTCHA challenges on login-flow endpoints.
+//
+// All values can be set via environment variables with the CAPTCHA_ prefix.
+type CaptchaConfig struct {
+	// Provider is "none", "turnstile", "hcaptcha" or "recaptcha"
+	Provider string `mapstructure:"CAPTCHA_PROVIDER"`
+
+	// SecretKey is the provider's server-side secret
+	SecretKey string `mapstructure:"CAPTCHA_SECRET_KEY"`
+
+	// AlwaysOnSignup requires a CAPTCHA on every registration
+	AlwaysOnSignup bool `mapstructure:"CAPTCHA_ALWAYS_ON_SIGNUP"`
+
+	// FailedAttemptsThreshold is how many failed attempts from one IP or for
+	// one email are allowed per FailedAttemptsWindow before a CAPTCHA is required
+	FailedAttemptsThreshold int64 `mapstructure:"CAPTCHA_FAILED_ATTEMPTS_THRESHOLD"`
+
+	// FailedAttemptsWindow is the counting window for FailedAttemptsThreshold
+	FailedAttemptsWindow time.Duration `mapstructure:"CAPTCHA_FAILED_ATTEMPTS_WINDOW"`
+
+	// MinScore is the lowest accepted reCAPTCHA v3 score (ignored by other providers)
+	MinScore float64 `mapstructure:"CAPTCHA_MIN_SCORE"`
+
+	// Timeout bounds each call to the provider
+	Timeout time.Duration `mapstructure:"CAPTCHA_TIMEOUT"`
+}
+
+// LoadCaptchaConfig loads the CAPTCHA configuration from environment variables and app.env file.
+func LoadCaptchaConfig() (*CaptchaConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("CAPTCHA_PROVIDER", CaptchaProviderNone)
+	v.SetDefault("CAPTCHA_SECRET_KEY", "")
+	v.SetDefault("CAPTCHA_ALWAYS_ON_SIGNUP", true)
+	v.SetDefault("CAPTCHA_FAILED_ATTEMPTS_THRESHOLD", 3)
+	v.SetDefault("CAPTCHA_FAILED_ATTEMPTS_WINDOW", "15m")
+	v.SetDefault("CAPTCHA_MIN_SCORE", 0.5)
+	v.SetDefault("CAPTCHA_TIMEOUT", "5s")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg CaptchaConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode captcha config: %w", err)
+	}
+
+	cfg.Provider = strings.ToLower(strings.TrimSpace(cfg.Provider))
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Enabled reports whether a CAPTCHA provider is configured.
+func (c *CaptchaConfig) Enabled() bool {
+	return c.Provider != "" && c.Provider != CaptchaProviderNone
+}
+
+// Validate checks that a configured provider has a secret and usable thresholds.
+func (c *CaptchaConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	switch c.Provider {
+	case CaptchaProviderTurnstile, CaptchaProviderHCaptcha, CaptchaProviderReCaptcha:
+	default:
+		return fmt.Errorf("captcha config invalid: unsupported CAPTCHA_PROVIDER %q", c.Provider)
+	}
+	if c.SecretKey == "" {
+		return fmt.Errorf("captcha config invalid: CAPTCHA_SECRET_KEY is required")
+	}
+	if c.FailedAttemptsThreshold < 0 || c.FailedAttemptsWindow <= 0 {
+		return fmt.Errorf("captcha config invalid: CAPTCHA_FAILED_ATTEMPTS_THRESHOLD must not be negative and CAPTCHA_FAILED_ATTEMPTS_WINDOW must be positive")
+	}
+	if c.MinScore < 0 || c.MinScore > 1 {
+		return fmt.Errorf("captcha config invalid: CAPTCHA_MIN_SCORE must be between 0 and 1")
+	}
+	if c.Timeout <= 0 {
+		return fmt.Errorf("captcha config invalid: CAPTCHA_TIMEOUT must be positive")
+	}
+	return nil
+}
+
+// CaptchaVerifier checks CAPTCHA widget response tokens with the provider.
+//
+// Implementations exist for Cloudflare Turnstile, hCaptcha and Google reCAPTCHA.
+type CaptchaVerifier interface {
+	// Verify returns ErrCaptchaInvalid when the provider rejects the token.
+	Verify(ctx context.Context, token, remoteIP string) error
+}
+
+// NewCaptchaVerifier creates the verifier for the configured provider.
+// It returns nil when no provider is configured.
+func NewCaptchaVerifier(cfg *CaptchaConfig) CaptchaVerifier {
+	httpClient := &http.Client{Timeout: cfg.Timeout}
+
+	switch cfg.Provider {
+	case CaptchaProviderTurnstile:
+		return NewTurnstileVerifier(cfg.SecretKey, httpClient)
+	case CaptchaProviderHCaptcha:
+		return NewHCaptchaVerifier(cfg.SecretKey, httpClient)
+	case CaptchaProviderReCaptcha:
+		return NewReCaptchaVerifier(cfg.SecretKey, cfg.MinScore, httpClient)
+	default:
+		return nil
+	}
+}
+
+// NewTurnstileVerifier creates a CaptchaVerifier for Cloudflare Turnstile.
+func NewTurnstileVerifier(secret string, httpClient *http.Client) CaptchaVerifier {
+	return &siteVerifyCaptcha{verifyURL: turnstileVerifyURL, secret: secret, httpClient: httpClient}
+}
+
+// NewHCaptchaVerifier creates a CaptchaVerifier for hCaptcha.
+func NewHCaptchaVerifier(secret string, httpClient *http.Client) CaptchaVerifier {
+	return &siteVerifyCaptcha{verifyURL: hcaptchaVerifyURL, secret: secret, httpClient: httpClient}
+}
+
+// NewReCaptchaVerifier creates a CaptchaVerifier for Google reCAPTCHA.
+// minScore applies to v3 tokens; v2 responses carry no score and only need to succeed.
+func NewReCaptchaVerifier(secret string, minScore float64, httpClient *http.Client) CaptchaVerifier {
+	return &siteVerifyCaptcha{verifyURL: recaptchaVerifyURL, secret: secret, minScore: minScore, httpClient: httpClient}
+}
+
+// siteVerifyCaptcha implements CaptchaVerifier for providers that share the
+// siteverify protocol: a form POST of secret, response and remoteip.
+type siteVerifyCaptcha struct {
+	verifyURL  string
+	secret     string
+	minScore   float64
+	httpClient *http.Client
+}
+
+type siteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	Score      *float64 `json:"score,omitempty"`
+	ErrorCodes []string `json:"error-codes,omitempty"`
+}
+
+func (v *siteVerifyCaptcha) Verify(ctx context.Context, token, remoteIP string) error {
+	if token == "" {
+		return ErrCaptchaRequired
+	}
+
+	form := url.Values{}
+	form.Set("secret", v.secret)
+	form.Set("response", token)
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create captcha request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to verify captcha: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("captcha provider returned status %d", resp.StatusCode)
+	}
+
+	var result siteVerifyResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("failed to decode captcha response: %w", err)
+	}
+
+	if !result.Success {
+		return ErrCaptchaInvalid
+	}
+	if result.Score != nil && *result.Score < v.minScore {
+		return ErrCaptchaInvalid
+	}
+	return nil
+}
+
+// CaptchaFailureTracker counts failed login-flow attempts per client IP and per email.
+type CaptchaFailureTracker interface {
+	// Failures returns the highest failed-attempt count of the IP and the email
+	// in the current window. An empty email is ignored.
+	Failures(ctx context.Context, clientIP, email string) (int64, error)
+
+	// RecordFailure counts a failed attempt for the IP and the email.
+	RecordFailure(ctx context.Context, clientIP, email string) error
+}
+
+// redisCaptchaFailureTracker implements CaptchaFailureTracker with fixed-window Redis counters.
+type redisCaptchaFailureTracker struct {
+	redis  redis.Client
+	window time.Duration
+}
+
+// NewRedisCaptchaFailureTracker creates a Redis-backed CaptchaFailureTracker.
+func NewRedisCaptchaFailureTracker(redisClient redis.Client, cfg *CaptchaConfig) CaptchaFailureTracker {
+	return &redisCaptchaFailureTracker{
+		redis:  redisClient,
+		window: cfg.FailedAttemptsWindow,
+	}
+}
+
+func (t *redisCaptchaFailureTracker) Failures(ctx context.Context, clientIP, email string) (int64, error) {
+	var highest int64
+	for _, key := range t.keys(clientIP, email) {
+		exists, err := t.redis.Exists(ctx, key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to check failed attempts: %w", err)
+		}
+		if !exists {
+			continue
+		}
+
+		value, err := t.redis.Get(ctx, key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read failed attempts: %w", err)
+		}
+		count, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid failed attempts entry: %w", err)
+		}
+		if count > highest {
+			highest = count
+		}
+	}
+	return highest, nil
+}
+
+func (t *redisCaptchaFailureTracker) RecordFailure(ctx context.Context, clientIP, email string) error {
+	for _, key := range t.keys(clientIP, email) {
+		if _, _, err := t.redis.Incr(ctx, key, t.window); err != nil {
+			return fmt.Errorf("failed to record failed attempt: %w", err)
+		}
+	}
+	return nil
+}
+
+func (t *redisCaptchaFailureTracker) keys(clientIP, email string) []string {
+	keys := []string{fmt.Sprintf(captchaFailuresIPKeyPattern, clientIP)}
+	if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+		keys = append(keys, fmt.Sprintf(captchaFailuresEmailKeyPattern, hashEmail(email)))
+	}
+	return keys
+}
+
+// Captcha returns middleware that requires a valid CAPTCHA on login-flow endpoints.
+//
+// A CAPTCHA is required once the client IP or the targeted email exceeds the
+// failed-attempt threshold, or on every request when always is set.
+// Responses with 401, 403 or 404 are counted as failed attempts.
+func Captcha(verifier CaptchaVerifier, failures CaptchaFailureTracker, cfg *CaptchaConfig, always bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.Enabled() {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		clientIP := c.ClientIP()
+		email := attemptEmail(c)
+
+		required := always
+		if !required {
+			count, err := failures.Failures(ctx, clientIP, email)
+			// Without the counters, fall back to challenging the request
+			required = err != nil || count >= cfg.FailedAttemptsThreshold
+		}
+
+		if required {
+			token := c.GetHeader(CaptchaTokenHeader)
+			if token == "" {
+				token = jsonBodyField(c, "captcha_token")
+			}
+
+			if err := verifier.Verify(ctx, token, clientIP); err != nil {
+				switch err {
+				case ErrCaptchaRequired:
+					captchaVerifications.WithLabelValues("missing").Inc()
+					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+						"error":   "captcha_required",
+						"message": err.Error(),
+					})
+				case ErrCaptchaInvalid:
+					captchaVerifications.WithLabelValues("invalid").Inc()
+					c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+						"error":   "captcha_invalid",
+						"message": err.Error(),
+					})
+				default:
+					captchaVerifications.WithLabelValues("error").Inc()
+					c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+						"error":   "service_unavailable",
+						"message": "captcha verification is temporarily unavailable",
+					})
+				}
+				return
+			}
+			captchaVerifications.WithLabelValues("passed").Inc()
+		}
+
+		c.Next()
+
+		switch c.Writer.Status() {
+		case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+			// Best effort: a lost count only delays the next challenge
+			_ = failures.RecordFailure(ctx, clientIP, email)
+		}
+	}
+}
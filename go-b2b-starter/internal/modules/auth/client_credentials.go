@@ -0,0 +1,19 @@
+package auth
+
+import "context"
+
+// ClientTokenVerifier validates access tokens issued to OAuth2 clients with
+// the client credentials grant.
+//
+// Client tokens are issued by the application (not the auth provider) so that
+// backend services can call the API without a user. The verified Identity
+// has ClientID set, the client's service account email, and the client's
+// scopes as Permissions (no roles).
+type ClientTokenVerifier interface {
+	// IsClientToken reports whether the token was issued to an OAuth2 client.
+	IsClientToken(token string) bool
+
+	// VerifyClientToken validates a client access token.
+	// Returns ErrInvalidToken or ErrTokenExpired on failure.
+	VerifyClientToken(ctx context.Context, token string) (*Identity, error)
+}
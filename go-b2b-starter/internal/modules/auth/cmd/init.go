@@ -2,20 +2,39 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
 	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/auth/adapters/keycloak"
 	"github.com/moasq/go-b2b-starter/internal/modules/auth/adapters/stytch"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
 	"github.com/moasq/go-b2b-starter/internal/platform/logger"
 	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+	"github.com/spf13/viper"
 	"go.uber.org/dig"
 )
 
+// Supported values for AUTH_PROVIDER.
+const (
+	ProviderStytch   = "stytch"
+	ProviderKeycloak = "keycloak"
+)
+
 //
 // This sets up:
 //   - stytch.Config
-//   - auth.AuthProvider (Stytch adapter)
+//   - auth.AuthProvider (Stytch adapter, or Keycloak when AUTH_PROVIDER=keycloak)
+//   - auth.LogoutTokenVerifier, auth.SessionRevoker, auth.SessionLister and auth.OrganizationSwitcher (same adapter)
+//   - auth.SessionDenylist (Redis)
+//   - auth.TokenClaimsValidator (issuer and audience of app-issued tokens)
+//   - auth.LoginRateLimiter and auth.LoginLockoutService (Redis; unlock links by email)
+//   - auth.EmailThrottle (Redis; cooldowns for endpoints that send email)
+//   - auth.CaptchaVerifier (Turnstile, hCaptcha or reCAPTCHA) and auth.CaptchaFailureTracker (Redis)
+//   - auth.RoleConfig and auth.RoleService (database roles cached in Redis)
+//   - auth.PolicyConfig and auth.PolicyEvaluator (rules, OPA or Cedar; nil when disabled)
+//   - auth.AuditLogConfig, auth.AuthEventPublisher (event bus) and auth.AuditLogService
 //
 // Note: The auth middleware is NOT initialized here because it requires
 // organization/account resolvers from the organizations module.
@@ -26,6 +45,9 @@ import (
 // The following modules must be initialized first:
 //   - redis (for caching)
 //   - logger
+//   - db (for auth.RoleRepository and auth.AuditLogRepository)
+//   - eventbus and jobs (for the audit log)
+//   - email (for lockout unlock links)
 //
 // # Usage
 //
@@ -41,12 +63,20 @@ func Init(container *dig.Container) error {
 		return fmt.Errorf("failed to provide stytch config: %w", err)
 	}
 
-	// Stytch Auth Adapter (implements auth.AuthProvider)
+	// Auth Adapter (implements auth.AuthProvider)
 	if err := container.Provide(func(
 		cfg *stytch.Config,
 		redisClient redis.Client,
 		log logger.Logger,
 	) (auth.AuthProvider, error) {
+		if LoadProviderName() == ProviderKeycloak {
+			kcCfg, err := keycloak.LoadConfig()
+			if err != nil {
+				return nil, fmt.Errorf("failed to load keycloak config: %w", err)
+			}
+			return keycloak.NewKeycloakAuthAdapter(kcCfg, log), nil
+		}
+
 		// Check for placeholder credentials
 		if isPlaceholderCredentials(cfg) {
 			log.Warn("Stytch credentials are placeholders - using development mode", map[string]any{
@@ -65,10 +95,156 @@ func Init(container *dig.Container) error {
 		return fmt.Errorf("failed to provide auth provider: %w", err)
 	}
 
+	// OIDC logout support, implemented by the same adapter
+	if err := container.Provide(func(provider auth.AuthProvider) (auth.LogoutTokenVerifier, auth.SessionRevoker, error) {
+		verifier, ok := provider.(auth.LogoutTokenVerifier)
+		if !ok {
+			return nil, nil, fmt.Errorf("auth provider does not support logout token verification")
+		}
+		revoker, ok := provider.(auth.SessionRevoker)
+		if !ok {
+			return nil, nil, fmt.Errorf("auth provider does not support session revocation")
+		}
+		return verifier, revoker, nil
+	}); err != nil {
+		return fmt.Errorf("failed to provide logout support: %w", err)
+	}
+
+	// Device session listing, implemented by the same adapter
+	if err := container.Provide(func(provider auth.AuthProvider) (auth.SessionLister, error) {
+		lister, ok := provider.(auth.SessionLister)
+		if !ok {
+			return nil, fmt.Errorf("auth provider does not support session listing")
+		}
+		return lister, nil
+	}); err != nil {
+		return fmt.Errorf("failed to provide session lister: %w", err)
+	}
+
+	// Organization switching, implemented by the same adapter
+	if err := container.Provide(func(provider auth.AuthProvider) (auth.OrganizationSwitcher, error) {
+		switcher, ok := provider.(auth.OrganizationSwitcher)
+		if !ok {
+			return nil, fmt.Errorf("auth provider does not support organization switching")
+		}
+		return switcher, nil
+	}); err != nil {
+		return fmt.Errorf("failed to provide organization switcher: %w", err)
+	}
+
+	// Session denylist for logged-out access tokens
+	if err := container.Provide(func(redisClient redis.Client) auth.SessionDenylist {
+		return auth.NewRedisDenylist(redisClient, auth.DefaultDenylistTTL)
+	}); err != nil {
+		return fmt.Errorf("failed to provide session denylist: %w", err)
+	}
+
+	// Issuer and audience of app-issued tokens, with legacy values accepted during a rename
+	if err := container.Provide(auth.LoadTokenClaimsConfig); err != nil {
+		return fmt.Errorf("failed to provide token claims config: %w", err)
+	}
+
+	if err := container.Provide(func(cfg *auth.TokenClaimsConfig, log logger.Logger) auth.TokenClaimsValidator {
+		return auth.NewTokenClaimsValidator(cfg, log)
+	}); err != nil {
+		return fmt.Errorf("failed to provide token claims validator: %w", err)
+	}
+
+	// Brute-force protection for login-flow endpoints
+	if err := container.Provide(auth.LoadLoginRateLimitConfig); err != nil {
+		return fmt.Errorf("failed to provide login rate limit config: %w", err)
+	}
+
+	if err := container.Provide(auth.NewLoginLockoutService); err != nil {
+		return fmt.Errorf("failed to provide login lockout service: %w", err)
+	}
+
+	if err := container.Provide(func(redisClient redis.Client, cfg *auth.LoginRateLimitConfig, lockouts auth.LoginLockoutService) auth.LoginRateLimiter {
+		return auth.NewRedisLoginRateLimiter(redisClient, cfg, lockouts)
+	}); err != nil {
+		return fmt.Errorf("failed to provide login rate limiter: %w", err)
+	}
+
+	// Email-bombing protection for endpoints that send email on request
+	if err := container.Provide(auth.LoadEmailThrottleConfig); err != nil {
+		return fmt.Errorf("failed to provide email throttle config: %w", err)
+	}
+
+	if err := container.Provide(func(redisClient redis.Client, cfg *auth.EmailThrottleConfig) auth.EmailThrottle {
+		return auth.NewRedisEmailThrottle(redisClient, cfg)
+	}); err != nil {
+		return fmt.Errorf("failed to provide email throttle: %w", err)
+	}
+
+	// CAPTCHA challenges for login-flow endpoints
+	if err := container.Provide(auth.LoadCaptchaConfig); err != nil {
+		return fmt.Errorf("failed to provide captcha config: %w", err)
+	}
+
+	if err := container.Provide(auth.NewCaptchaVerifier); err != nil {
+		return fmt.Errorf("failed to provide captcha verifier: %w", err)
+	}
+
+	if err := container.Provide(func(redisClient redis.Client, cfg *auth.CaptchaConfig) auth.CaptchaFailureTracker {
+		return auth.NewRedisCaptchaFailureTracker(redisClient, cfg)
+	}); err != nil {
+		return fmt.Errorf("failed to provide captcha failure tracker: %w", err)
+	}
+
+	// Step-up authentication window for sensitive endpoints
+	if err := container.Provide(auth.LoadRecentAuthConfig); err != nil {
+		return fmt.Errorf("failed to provide recent auth config: %w", err)
+	}
+
+	// Operator token for the /admin endpoints
+	if err := container.Provide(auth.LoadOperatorAuthConfig); err != nil {
+		return fmt.Errorf("failed to provide operator auth config: %w", err)
+	}
+
+	// Auth audit log: events published on the event bus and stored in the database
+	if err := container.Provide(auth.LoadAuditLogConfig); err != nil {
+		return fmt.Errorf("failed to provide auth audit config: %w", err)
+	}
+
+	if err := container.Provide(auth.NewAuthEventPublisher); err != nil {
+		return fmt.Errorf("failed to provide auth event publisher: %w", err)
+	}
+
+	if err := container.Provide(auth.NewAuditLogService); err != nil {
+		return fmt.Errorf("failed to provide auth audit log service: %w", err)
+	}
+
+	// Roles and permissions from the database, cached in Redis
+	if err := container.Provide(auth.LoadRoleConfig); err != nil {
+		return fmt.Errorf("failed to provide rbac config: %w", err)
+	}
+
+	if err := container.Provide(func(
+		repo auth.RoleRepository,
+		redisClient redis.Client,
+		cfg *auth.RoleConfig,
+		log logger.Logger,
+	) auth.RoleService {
+		cached := auth.NewCachedRoleRepository(repo, redisClient, cfg.CacheTTL)
+		return auth.NewRoleService(cached, cfg, log)
+	}); err != nil {
+		return fmt.Errorf("failed to provide role service: %w", err)
+	}
+
+	// Attribute-based policy checked after role permissions
+	if err := container.Provide(auth.LoadPolicyConfig); err != nil {
+		return fmt.Errorf("failed to provide policy config: %w", err)
+	}
+
+	if err := container.Provide(auth.NewPolicyEvaluator); err != nil {
+		return fmt.Errorf("failed to provide policy evaluator: %w", err)
+	}
+
 	return nil
 }
 
-// InitMiddleware initializes the auth middleware with resolvers.
+// InitMiddleware initializes the auth middleware with resolvers, and starts
+// recording auth events in the audit log.
 //
 // This must be called after the organizations module is initialized,
 // as it depends on organization and account repositories.
@@ -79,6 +255,7 @@ func Init(container *dig.Container) error {
 //   - auth.AuthProvider (from Init)
 //   - auth.OrganizationResolver
 //   - auth.AccountResolver
+//   - eventbus.EventBus
 //   - serverDomain.Server (for registering named middlewares)
 //
 // # Usage
@@ -91,9 +268,51 @@ func InitMiddleware(container *dig.Container) error {
 	if err := auth.SetupMiddleware(container); err != nil {
 		return fmt.Errorf("failed to setup auth middleware: %w", err)
 	}
+
+	// Store published auth events and purge expired ones
+	if err := container.Invoke(func(
+		bus eventbus.EventBus,
+		cfg *auth.AuditLogConfig,
+		service auth.AuditLogService,
+	) error {
+		if !cfg.Enabled {
+			return nil
+		}
+
+		for _, eventType := range auth.AuthEventTypes {
+			if err := bus.Subscribe(eventType, func(ctx context.Context, event eventbus.Event) error {
+				authEvent, ok := event.(*auth.AuthEvent)
+				if !ok {
+					return fmt.Errorf("unexpected event type: %T", event)
+				}
+				return service.Record(ctx, authEvent)
+			}); err != nil {
+				return err
+			}
+		}
+
+		go service.Run(context.Background())
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to wire auth audit log: %w", err)
+	}
+
 	return nil
 }
 
+// LoadProviderName reads AUTH_PROVIDER from the environment or app.env (default: stytch).
+func LoadProviderName() string {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+	v.SetDefault("AUTH_PROVIDER", ProviderStytch)
+	_ = v.ReadInConfig()
+
+	return strings.ToLower(strings.TrimSpace(v.GetString("AUTH_PROVIDER")))
+}
+
 // isPlaceholderCredentials checks if the Stytch credentials are placeholder values.
 func isPlaceholderCredentials(cfg *stytch.Config) bool {
 	return strings.Contains(cfg.ProjectID, "REPLACE") ||
@@ -16,6 +16,9 @@ const (
 
 	// requestContextKey is the context key for storing the RequestContext.
 	requestContextKey contextKey = "auth_request_context"
+
+	// resourceKey is the context key for storing the targeted ResourceAttributes.
+	resourceKey contextKey = "auth_resource"
 )
 
 // SetIdentity stores the Identity in the Gin context.
@@ -105,6 +108,25 @@ func MustGetRequestContext(c *gin.Context) *RequestContext {
 	return reqCtx
 }
 
+// SetResource stores the targeted resource for the policy check.
+//
+// This is called by the LoadResource middleware.
+func SetResource(c *gin.Context, resource *ResourceAttributes) {
+	c.Set(string(resourceKey), resource)
+}
+
+// GetResource retrieves the targeted resource from the Gin context.
+//
+// Returns nil if the route did not load one.
+func GetResource(c *gin.Context) *ResourceAttributes {
+	if val, exists := c.Get(string(resourceKey)); exists {
+		if resource, ok := val.(*ResourceAttributes); ok {
+			return resource
+		}
+	}
+	return nil
+}
+
 // GetOrganizationID is a convenience function to get the database organization ID.
 //
 // Returns 0 if no request context is set.
@@ -127,6 +149,14 @@ func GetAccountID(c *gin.Context) int32 {
 	return 0
 }
 
+// GetBearerToken returns the token the request was authenticated with.
+//
+// Handlers that exchange the caller's session at the auth provider (e.g.,
+// switching organizations) need the raw token, which Identity does not keep.
+func GetBearerToken(c *gin.Context) (string, error) {
+	return extractBearerToken(c)
+}
+
 // WithIdentity adds the Identity to a context.Context.
 //
 // This is useful for passing auth context through service layers
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DebugCapture is a debug capture recording an organization's requests.
+type DebugCapture struct {
+	ID int32
+
+	// MaxBodyBytes caps how much of each request and response body is kept.
+	MaxBodyBytes int
+}
+
+// CapturedExchange is a request and the response it got, as seen by
+// RequireOrganization. Nothing in it is redacted yet.
+type CapturedExchange struct {
+	CaptureID int32
+
+	// RequestContext is the organization and account the request was made in.
+	RequestContext *RequestContext
+
+	Method   string
+	Path     string
+	Route    string
+	Query    string
+	ClientIP string
+
+	RequestHeader http.Header
+	RequestBody   []byte
+	// RequestBodyTruncated is set when the body was longer than MaxBodyBytes.
+	RequestBodyTruncated bool
+
+	Status         int
+	Duration       time.Duration
+	ResponseHeader http.Header
+	ResponseBody   []byte
+	// ResponseBodyTruncated is set when the body was longer than MaxBodyBytes.
+	ResponseBodyTruncated bool
+}
+
+// DebugCapturer records the requests of organizations an operator is
+// debugging, so hard-to-reproduce customer issues can be replayed.
+//
+// This interface decouples auth middleware from the organizations domain.
+// Implement this interface to store request/response pairs with sensitive
+// values redacted.
+type DebugCapturer interface {
+	// ActiveDebugCapture returns the organization's recording capture, or nil
+	// if there is none. Lookup errors return nil so an outage does not fail
+	// requests.
+	ActiveDebugCapture(ctx context.Context, orgID int32) *DebugCapture
+
+	// RecordExchange redacts and stores the exchange. It must not fail or
+	// hold up the request: errors are handled internally.
+	RecordExchange(ctx context.Context, exchange *CapturedExchange)
+}
+
+// captureExchange runs the rest of the chain and records the request and its
+// response in capture. Handlers still read the whole request body.
+func captureExchange(c *gin.Context, capturer DebugCapturer, capture *DebugCapture) {
+	start := time.Now()
+
+	exchange := &CapturedExchange{
+		CaptureID:     capture.ID,
+		Method:        c.Request.Method,
+		Path:          c.Request.URL.Path,
+		Route:         c.FullPath(),
+		Query:         c.Request.URL.RawQuery,
+		ClientIP:      c.ClientIP(),
+		RequestHeader: c.Request.Header.Clone(),
+	}
+	exchange.RequestBody, exchange.RequestBodyTruncated = peekRequestBody(c.Request, capture.MaxBodyBytes)
+
+	writer := &captureWriter{ResponseWriter: c.Writer, limit: capture.MaxBodyBytes}
+	c.Writer = writer
+	c.Next()
+	c.Writer = writer.ResponseWriter
+
+	exchange.RequestContext = GetRequestContext(c)
+	exchange.Status = writer.Status()
+	exchange.Duration = time.Since(start)
+	exchange.ResponseHeader = writer.Header().Clone()
+	exchange.ResponseBody = writer.body.Bytes()
+	exchange.ResponseBodyTruncated = writer.truncated
+
+	capturer.RecordExchange(c.Request.Context(), exchange)
+}
+
+// peekRequestBody reads up to limit bytes of the request body and puts them
+// back in front of the rest of it.
+func peekRequestBody(r *http.Request, limit int) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, false
+	}
+
+	peeked, err := io.ReadAll(io.LimitReader(r.Body, int64(limit)+1))
+	r.Body = &peekedBody{Reader: io.MultiReader(bytes.NewReader(peeked), r.Body), Closer: r.Body}
+	if err != nil {
+		return nil, false
+	}
+
+	if len(peeked) > limit {
+		return peeked[:limit], true
+	}
+	return peeked, false
+}
+
+// peekedBody is a request body whose first bytes were already read.
+type peekedBody struct {
+	io.Reader
+	io.Closer
+}
+
+// captureWriter keeps a copy of the first limit bytes written to the response.
+type captureWriter struct {
+	gin.ResponseWriter
+	limit     int
+	body      bytes.Buffer
+	truncated bool
+}
+
+func (w *captureWriter) Write(data []byte) (int, error) {
+	w.keep(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *captureWriter) WriteString(s string) (int, error) {
+	w.keep([]byte(s))
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *captureWriter) keep(data []byte) {
+	room := w.limit - w.body.Len()
+	if len(data) > room {
+		data = data[:max(room, 0)]
+		w.truncated = true
+	}
+	w.body.Write(data)
+}
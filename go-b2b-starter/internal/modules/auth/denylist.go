@@ -0,0 +1,136 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+const (
+	// Redis keys for revoked tokens, sessions and subjects
+	denylistTokenKeyPattern   = "auth:denylist:jti:%s"
+	denylistSessionKeyPattern = "auth:denylist:sid:%s"
+	denylistSubjectKeyPattern = "auth:denylist:sub:%s"
+
+	// Redis key for back-channel logout tokens already used
+	denylistLogoutTokenKeyPattern = "auth:denylist:logout_jti:%s"
+
+	// DefaultDenylistTTL bounds how long revocations are remembered.
+	// It must be at least as long as the longest access token lifetime.
+	DefaultDenylistTTL = 24 * time.Hour
+)
+
+// redisDenylist implements SessionDenylist using Redis.
+type redisDenylist struct {
+	redis redis.Client
+	ttl   time.Duration
+}
+
+// NewRedisDenylist creates a Redis-backed SessionDenylist.
+//
+// Entries expire after ttl; pass 0 to use DefaultDenylistTTL.
+func NewRedisDenylist(redisClient redis.Client, ttl time.Duration) SessionDenylist {
+	if ttl <= 0 {
+		ttl = DefaultDenylistTTL
+	}
+	return &redisDenylist{
+		redis: redisClient,
+		ttl:   ttl,
+	}
+}
+
+func (d *redisDenylist) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	// The entry only needs to outlive the token itself
+	ttl := time.Until(expiresAt)
+	if expiresAt.IsZero() || ttl > d.ttl {
+		ttl = d.ttl
+	}
+	if ttl <= 0 {
+		return nil
+	}
+	return d.redis.Set(ctx, fmt.Sprintf(denylistTokenKeyPattern, tokenID), "1", ttl)
+}
+
+func (d *redisDenylist) RevokeSession(ctx context.Context, sessionID string) error {
+	return d.redis.Set(ctx, fmt.Sprintf(denylistSessionKeyPattern, sessionID), "1", d.ttl)
+}
+
+func (d *redisDenylist) RevokeSubject(ctx context.Context, subject string, before time.Time) error {
+	value := strconv.FormatInt(before.Unix(), 10)
+	return d.redis.Set(ctx, fmt.Sprintf(denylistSubjectKeyPattern, subject), value, d.ttl)
+}
+
+func (d *redisDenylist) IsRevoked(ctx context.Context, identity *Identity) (bool, error) {
+	if identity.TokenID != "" {
+		revoked, err := d.redis.Exists(ctx, fmt.Sprintf(denylistTokenKeyPattern, identity.TokenID))
+		if err != nil {
+			return false, fmt.Errorf("failed to check token denylist: %w", err)
+		}
+		if revoked {
+			return true, nil
+		}
+	}
+
+	if identity.SessionID != "" {
+		revoked, err := d.redis.Exists(ctx, fmt.Sprintf(denylistSessionKeyPattern, identity.SessionID))
+		if err != nil {
+			return false, fmt.Errorf("failed to check session denylist: %w", err)
+		}
+		if revoked {
+			return true, nil
+		}
+	}
+
+	// Subject revocation only applies to tokens with a known issue time
+	if identity.UserID == "" || identity.IssuedAt.IsZero() {
+		return false, nil
+	}
+
+	subjectKey := fmt.Sprintf(denylistSubjectKeyPattern, identity.UserID)
+	exists, err := d.redis.Exists(ctx, subjectKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to check subject denylist: %w", err)
+	}
+	if !exists {
+		return false, nil
+	}
+
+	value, err := d.redis.Get(ctx, subjectKey)
+	if err != nil {
+		return false, fmt.Errorf("failed to check subject denylist: %w", err)
+	}
+
+	revokedAt, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("invalid subject denylist entry: %w", err)
+	}
+
+	return identity.IssuedAt.Unix() <= revokedAt, nil
+}
+
+func (d *redisDenylist) UseLogoutToken(ctx context.Context, tokenID string, expiresAt time.Time) (bool, error) {
+	// The entry lives as long as the token; tokens without exp are
+	// remembered for the denylist ttl
+	ttl := d.ttl
+	if !expiresAt.IsZero() {
+		ttl = time.Until(expiresAt)
+	}
+	// Expired tokens cannot be used anymore
+	if ttl <= 0 {
+		return false, nil
+	}
+
+	// Incr is atomic, so only the first of concurrent deliveries counts 1
+	count, _, err := d.redis.Incr(ctx, fmt.Sprintf(denylistLogoutTokenKeyPattern, tokenID), ttl)
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+func (d *redisDenylist) ReleaseLogoutToken(ctx context.Context, tokenID string) error {
+	return d.redis.Delete(ctx, fmt.Sprintf(denylistLogoutTokenKeyPattern, tokenID))
+}
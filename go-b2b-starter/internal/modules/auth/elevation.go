@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// Elevation is a time-boxed role granted on top of the provider's roles.
+//
+// Provider tokens cannot carry the elevated role (they are issued before the
+// grant), so RequireOrganization layers it onto the Identity on every request
+// while the elevation is active. Once ExpiresAt passes the resolver stops
+// returning it and the user is back to their normal roles.
+type Elevation struct {
+	// ID is the database ID of the elevation grant.
+	ID int32 `json:"id"`
+
+	// Role is the elevated role.
+	Role Role `json:"role"`
+
+	// ExpiresAt is when the elevation ends.
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// ElevationResolver looks up active privilege elevations.
+//
+// This interface decouples auth middleware from the organizations domain.
+// Implement this interface to support just-in-time admin access.
+type ElevationResolver interface {
+	// ActiveElevation returns the account's active elevation, or nil if none.
+	ActiveElevation(ctx context.Context, orgID, accountID int32) (*Elevation, error)
+}
+
+// WithElevation returns a copy of the identity that also holds the elevated
+// role and its permissions. The original identity is not modified.
+func (i *Identity) WithElevation(elevation *Elevation) *Identity {
+	return i.WithRoles(elevation.Role)
+}
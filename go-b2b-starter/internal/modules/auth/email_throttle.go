@@ -0,0 +1,212 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+const (
+	// Redis keys for email send counters
+	emailThrottleIPKeyPattern        = "auth:email_throttle:ip:%s"
+	emailThrottleAccountKeyPattern   = "auth:email_throttle:account:%s"
+	emailThrottleRecipientKeyPattern = "auth:email_throttle:recipient:%s"
+)
+
+// EmailThrottleConfig configures throttling of endpoints that send an email
+// on request (MFA recovery codes, email change verification links), which
+// are a common email-bombing vector.
+//
+// Each recipient address gets at most one email per Cooldown, and requests
+// are also counted per account and per client IP in fixed windows. All
+// values can be set via environment variables with the EMAIL_THROTTLE_ prefix.
+type EmailThrottleConfig struct {
+	// Enabled turns the throttle on
+	Enabled bool `mapstructure:"EMAIL_THROTTLE_ENABLED"`
+
+	// Cooldown is the minimum time between emails to the same address
+	Cooldown time.Duration `mapstructure:"EMAIL_THROTTLE_COOLDOWN"`
+
+	// AccountLimit is how many emails one account may request per AccountWindow
+	AccountLimit int64 `mapstructure:"EMAIL_THROTTLE_ACCOUNT_LIMIT"`
+
+	// AccountWindow is the counting window for AccountLimit
+	AccountWindow time.Duration `mapstructure:"EMAIL_THROTTLE_ACCOUNT_WINDOW"`
+
+	// IPLimit is how many emails one client IP may request per IPWindow
+	IPLimit int64 `mapstructure:"EMAIL_THROTTLE_IP_LIMIT"`
+
+	// IPWindow is the counting window for IPLimit
+	IPWindow time.Duration `mapstructure:"EMAIL_THROTTLE_IP_WINDOW"`
+
+	// FailOpen lets requests through when Redis is unavailable
+	FailOpen bool `mapstructure:"EMAIL_THROTTLE_FAIL_OPEN"`
+}
+
+// LoadEmailThrottleConfig loads the email throttle configuration from environment variables and app.env file.
+func LoadEmailThrottleConfig() (*EmailThrottleConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("EMAIL_THROTTLE_ENABLED", true)
+	v.SetDefault("EMAIL_THROTTLE_COOLDOWN", "1m")
+	v.SetDefault("EMAIL_THROTTLE_ACCOUNT_LIMIT", 5)
+	v.SetDefault("EMAIL_THROTTLE_ACCOUNT_WINDOW", "1h")
+	v.SetDefault("EMAIL_THROTTLE_IP_LIMIT", 20)
+	v.SetDefault("EMAIL_THROTTLE_IP_WINDOW", "1h")
+	v.SetDefault("EMAIL_THROTTLE_FAIL_OPEN", true)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg EmailThrottleConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode email throttle config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that an enabled throttle has usable thresholds.
+func (c *EmailThrottleConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Cooldown <= 0 {
+		return fmt.Errorf("email throttle invalid: EMAIL_THROTTLE_COOLDOWN must be positive")
+	}
+	if c.AccountLimit <= 0 || c.AccountWindow <= 0 {
+		return fmt.Errorf("email throttle invalid: EMAIL_THROTTLE_ACCOUNT_LIMIT and EMAIL_THROTTLE_ACCOUNT_WINDOW must be positive")
+	}
+	if c.IPLimit <= 0 || c.IPWindow <= 0 {
+		return fmt.Errorf("email throttle invalid: EMAIL_THROTTLE_IP_LIMIT and EMAIL_THROTTLE_IP_WINDOW must be positive")
+	}
+	return nil
+}
+
+// EmailThrottle limits how often emails may be requested.
+type EmailThrottle interface {
+	// Allow records a request to email recipient on behalf of account from
+	// clientIP, and reports whether it may proceed. When it may not, the
+	// returned duration is how long until it may. Empty account or recipient
+	// values are not counted.
+	Allow(ctx context.Context, clientIP, account, recipient string) (bool, time.Duration, error)
+}
+
+// redisEmailThrottle implements EmailThrottle with Redis counters. The
+// recipient cooldown is a counter whose window is the cooldown itself.
+type redisEmailThrottle struct {
+	redis redis.Client
+	cfg   *EmailThrottleConfig
+}
+
+// NewRedisEmailThrottle creates a Redis-backed EmailThrottle.
+func NewRedisEmailThrottle(redisClient redis.Client, cfg *EmailThrottleConfig) EmailThrottle {
+	return &redisEmailThrottle{
+		redis: redisClient,
+		cfg:   cfg,
+	}
+}
+
+func (t *redisEmailThrottle) Allow(ctx context.Context, clientIP, account, recipient string) (bool, time.Duration, error) {
+	count, resetIn, err := t.redis.Incr(ctx, fmt.Sprintf(emailThrottleIPKeyPattern, clientIP), t.cfg.IPWindow)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to count email requests for ip: %w", err)
+	}
+	if count > t.cfg.IPLimit {
+		return false, resetIn, nil
+	}
+
+	if recipient = strings.ToLower(strings.TrimSpace(recipient)); recipient != "" {
+		count, resetIn, err = t.redis.Incr(ctx, fmt.Sprintf(emailThrottleRecipientKeyPattern, hashEmail(recipient)), t.cfg.Cooldown)
+		if err != nil {
+			return false, 0, fmt.Errorf("failed to count email requests for recipient: %w", err)
+		}
+		if count > 1 {
+			return false, resetIn, nil
+		}
+	}
+
+	if account = strings.TrimSpace(account); account != "" {
+		count, resetIn, err = t.redis.Incr(ctx, fmt.Sprintf(emailThrottleAccountKeyPattern, hashEmail(account)), t.cfg.AccountWindow)
+		if err != nil {
+			return false, 0, fmt.Errorf("failed to count email requests for account: %w", err)
+		}
+		if count > t.cfg.AccountLimit {
+			return false, resetIn, nil
+		}
+	}
+
+	return true, 0, nil
+}
+
+// EmailSendThrottle returns middleware that throttles endpoints sending an
+// email on request.
+//
+// The recipient is taken from the "new_email" or "email" JSON field (the body
+// is restored for the handler). The account is the signed-in user when the
+// route runs after "auth", and otherwise the recipient, so unauthenticated
+// requests for unknown addresses are throttled the same as for real ones.
+func EmailSendThrottle(throttle EmailThrottle, cfg *EmailThrottleConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.Enabled {
+			c.Next()
+			return
+		}
+
+		recipient := jsonBodyField(c, "new_email")
+		if recipient == "" {
+			recipient = attemptEmail(c)
+		}
+		account := strings.ToLower(strings.TrimSpace(recipient))
+		if identity := GetIdentity(c); identity != nil {
+			account = identity.UserID
+		}
+
+		allowed, retryAfter, err := throttle.Allow(c.Request.Context(), c.ClientIP(), account, recipient)
+		if err != nil {
+			if cfg.FailOpen {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "service_unavailable",
+				"message": "email delivery is temporarily unavailable",
+			})
+			return
+		}
+
+		if !allowed {
+			emailThrottled.Inc()
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":   "too_many_emails",
+				"message": "too many emails requested, please try again later",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -37,6 +37,10 @@ var (
 	// HTTP status: 403 Forbidden
 	ErrAccountNotFound = errors.New("account not found")
 
+	// ErrAccountSuspended is returned when an organization admin suspended the account.
+	// HTTP status: 403 Forbidden
+	ErrAccountSuspended = errors.New("account suspended")
+
 	// ErrMissingOrganization is returned when the token doesn't contain an organization ID.
 	// HTTP status: 403 Forbidden
 	ErrMissingOrganization = errors.New("no organization in token")
@@ -45,6 +49,22 @@ var (
 	// HTTP status: 403 Forbidden
 	ErrMissingEmail = errors.New("no email in token")
 
+	// ErrIPNotAllowed is returned when the client address is outside the organization's IP allowlist.
+	// HTTP status: 403 Forbidden
+	ErrIPNotAllowed = errors.New("client address not allowed")
+
+	// ErrSessionTooOld is returned when the user signed in longer ago than the organization's session lifetime.
+	// HTTP status: 401 Unauthorized
+	ErrSessionTooOld = errors.New("session exceeds the organization's maximum lifetime")
+
+	// ErrMFARequired is returned when the organization requires multi-factor authentication and the session has none.
+	// HTTP status: 401 Unauthorized
+	ErrMFARequired = errors.New("organization requires multi-factor authentication")
+
+	// ErrAuthMethodNotAllowed is returned when the session was signed in with a method the organization does not allow.
+	// HTTP status: 403 Forbidden
+	ErrAuthMethodNotAllowed = errors.New("sign-in method not allowed by the organization")
+
 	// ErrAudienceMismatch is returned when the token audience doesn't match.
 	// HTTP status: 401 Unauthorized
 	ErrAudienceMismatch = errors.New("token audience mismatch")
@@ -52,6 +72,76 @@ var (
 	// ErrIssuerMismatch is returned when the token issuer doesn't match.
 	// HTTP status: 401 Unauthorized
 	ErrIssuerMismatch = errors.New("token issuer mismatch")
+
+	// ErrAudienceNotAllowed is returned when a token is requested for an audience
+	// that is neither this application nor a configured downstream service.
+	// HTTP status: 400 Bad Request
+	ErrAudienceNotAllowed = errors.New("token audience not allowed")
+
+	// ErrSessionRevoked is returned when the token belongs to a session that
+	// was terminated by a logout (e.g., an OIDC back-channel logout).
+	// HTTP status: 401 Unauthorized
+	ErrSessionRevoked = errors.New("session revoked")
+
+	// ErrInvalidLogoutToken is returned when an OIDC logout token fails validation.
+	// HTTP status: 400 Bad Request
+	ErrInvalidLogoutToken = errors.New("invalid logout token")
+
+	// ErrSessionNotFound is returned when a session does not exist or belongs to another user.
+	// HTTP status: 404 Not Found
+	ErrSessionNotFound = errors.New("session not found")
+
+	// ErrSessionListingUnsupported is returned when the auth provider cannot list user sessions.
+	// HTTP status: 501 Not Implemented
+	ErrSessionListingUnsupported = errors.New("session listing is not supported by the auth provider")
+
+	// ErrOrganizationSwitchUnsupported is returned when the auth provider cannot issue tokens for another organization.
+	// HTTP status: 501 Not Implemented
+	ErrOrganizationSwitchUnsupported = errors.New("organization switching is not supported by the auth provider")
+
+	// ErrCaptchaRequired is returned when a CAPTCHA is required but no token was sent.
+	// HTTP status: 400 Bad Request
+	ErrCaptchaRequired = errors.New("captcha required")
+
+	// ErrCaptchaInvalid is returned when the CAPTCHA provider rejects the token.
+	// HTTP status: 400 Bad Request
+	ErrCaptchaInvalid = errors.New("captcha verification failed")
+
+	// ErrRoleNotFound is returned when a role does not exist.
+	// HTTP status: 404 Not Found
+	ErrRoleNotFound = errors.New("role not found")
+
+	// ErrRoleExists is returned when creating a role whose ID is taken.
+	// HTTP status: 409 Conflict
+	ErrRoleExists = errors.New("role already exists")
+
+	// ErrInvalidRoleID is returned when a role ID is malformed or is a legacy alias.
+	// HTTP status: 400 Bad Request
+	ErrInvalidRoleID = errors.New("role id must be 2-50 lowercase letters, digits, '_' or '-' and not a legacy alias")
+
+	// ErrUnknownPermission is returned when a role is granted a permission that does not exist.
+	// HTTP status: 400 Bad Request
+	ErrUnknownPermission = errors.New("unknown permission")
+
+	// ErrSystemRole is returned when deleting a built-in role.
+	// HTTP status: 409 Conflict
+	ErrSystemRole = errors.New("system roles cannot be deleted")
+
+	// ErrAdminRoleLockout is returned when an update would remove org:manage from the admin role.
+	// HTTP status: 409 Conflict
+	ErrAdminRoleLockout = errors.New("the admin role must keep org:manage")
+
+	// ErrInvalidAuthEventType is returned when filtering the audit log by an unknown event type.
+	// HTTP status: 400 Bad Request
+	ErrInvalidAuthEventType = errors.New("unknown auth event type")
+
+	// ErrInvalidAuditCursor is returned when an audit log page cursor cannot be decoded.
+	// HTTP status: 400 Bad Request
+	ErrInvalidAuditCursor = errors.New("invalid audit log cursor")
+
+	// ErrInvalidUnlockToken is returned when a lockout unlock link is unknown or expired.
+	// HTTP status: 400 Bad Request
+	ErrInvalidUnlockToken = errors.New("invalid or expired unlock token")
 )
 
 // IsAuthError returns true if the error is an authentication error (401).
@@ -60,7 +150,10 @@ func IsAuthError(err error) bool {
 		errors.Is(err, ErrInvalidToken) ||
 		errors.Is(err, ErrTokenExpired) ||
 		errors.Is(err, ErrAudienceMismatch) ||
-		errors.Is(err, ErrIssuerMismatch)
+		errors.Is(err, ErrIssuerMismatch) ||
+		errors.Is(err, ErrSessionRevoked) ||
+		errors.Is(err, ErrSessionTooOld) ||
+		errors.Is(err, ErrMFARequired)
 }
 
 // IsForbiddenError returns true if the error is an authorization error (403).
@@ -70,7 +163,9 @@ func IsForbiddenError(err error) bool {
 		errors.Is(err, ErrOrganizationNotFound) ||
 		errors.Is(err, ErrAccountNotFound) ||
 		errors.Is(err, ErrMissingOrganization) ||
-		errors.Is(err, ErrMissingEmail)
+		errors.Is(err, ErrMissingEmail) ||
+		errors.Is(err, ErrIPNotAllowed) ||
+		errors.Is(err, ErrAuthMethodNotAllowed)
 }
 
 // HTTPStatusCode returns the appropriate HTTP status code for an auth error.
@@ -0,0 +1,20 @@
+package auth
+
+import "context"
+
+// GuestDeviceHeader carries the device identifier that guest tokens are bound to.
+const GuestDeviceHeader = "X-Device-ID"
+
+// GuestVerifier validates short-lived anonymous guest tokens.
+//
+// Guest tokens are issued by the application (not the auth provider) so that
+// prospects can try selected features without registering. They are only
+// accepted on routes using RequireAuthOrGuest.
+type GuestVerifier interface {
+	// IsGuestToken reports whether the token was issued to a guest.
+	IsGuestToken(token string) bool
+
+	// VerifyGuestToken validates a guest token presented from the given device.
+	// Returns ErrInvalidToken or ErrTokenExpired on failure.
+	VerifyGuestToken(ctx context.Context, token, deviceID string) (*Identity, error)
+}
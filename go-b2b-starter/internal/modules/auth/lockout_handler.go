@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// UnlockEmailRequest names the email an operator unlocks.
+type UnlockEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// UnlockTokenRequest carries the token from an unlock email.
+type UnlockTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// LockoutHandler lifts login lockouts, for operators and through unlock emails.
+type LockoutHandler struct {
+	service LoginLockoutService
+}
+
+func NewLockoutHandler(service LoginLockoutService) *LockoutHandler {
+	return &LockoutHandler{service: service}
+}
+
+// AdminUnlock godoc
+// @Summary Unlock an email
+// @Description Lifts the login lockout of an email, clears its failed attempts and restarts its progressive lockout sequence.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param request body UnlockEmailRequest true "Email to unlock"
+// @Success 204 "Unlocked"
+// @Failure 400 {object} map[string]string "Invalid email"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/auth/lockouts/unlock [post]
+func (h *LockoutHandler) AdminUnlock(c *gin.Context) {
+	var req UnlockEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	if err := h.service.Unlock(c.Request.Context(), req.Email); err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to unlock email", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Unlock godoc
+// @Summary Unlock an email from its unlock link
+// @Description Lifts the login lockout an unlock email was sent for. Links are single-use and expire with the lockout.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body UnlockTokenRequest true "Token from the unlock link"
+// @Success 204 "Unlocked"
+// @Failure 400 {object} map[string]string "Invalid or expired token"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/lockouts/unlock [post]
+func (h *LockoutHandler) Unlock(c *gin.Context) {
+	var req UnlockTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	if err := h.service.UnlockWithToken(c.Request.Context(), req.Token); err != nil {
+		if errors.Is(err, ErrInvalidUnlockToken) {
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to unlock email", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
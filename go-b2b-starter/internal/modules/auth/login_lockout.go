@@ -0,0 +1,256 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+const (
+	// Redis keys for progressive lockouts, by email hash: the active lockout
+	// (holding its end as a Unix time) and the number of consecutive lockouts
+	loginLockoutKeyPattern      = "auth:lockout:email:%s"
+	loginLockoutCountKeyPattern = "auth:lockout:count:%s"
+
+	// loginUnlockKeyPattern maps an unlock token hash to the email hash it unlocks
+	loginUnlockKeyPattern = "auth:lockout:unlock:%s"
+
+	// loginUnlockTokenBytes is the entropy of unlock link tokens
+	loginUnlockTokenBytes = 32
+
+	// loginUnlockEmailTimeout bounds sending an unlock email
+	loginUnlockEmailTimeout = 30 * time.Second
+)
+
+// LoginLockoutService locks emails out of login-flow endpoints for
+// progressively longer durations and lifts lockouts early.
+type LoginLockoutService interface {
+	// LockedFor reports how much longer an email is locked out, or zero.
+	LockedFor(ctx context.Context, email string) (time.Duration, error)
+
+	// Lock locks an email out for the next duration of its sequence and
+	// returns that duration. When LOGIN_RATE_LIMIT_UNLOCK_URL is set, the
+	// address is emailed a link that lifts the lockout.
+	Lock(ctx context.Context, email string) (time.Duration, error)
+
+	// Unlock lifts an email's lockout, clears its attempts and starts its
+	// sequence over. It is used by operators.
+	Unlock(ctx context.Context, email string) error
+
+	// UnlockWithToken lifts the lockout an unlock email was sent for and
+	// clears the email's attempts. The sequence is kept, so a lockout that
+	// follows is longer. Unknown or expired tokens return ErrInvalidUnlockToken.
+	UnlockWithToken(ctx context.Context, token string) error
+}
+
+type loginLockoutService struct {
+	redis  redis.Client
+	cfg    *LoginRateLimitConfig
+	sender emailDomain.Sender
+	logger logger.Logger
+}
+
+// NewLoginLockoutService creates a Redis-backed LoginLockoutService.
+func NewLoginLockoutService(redisClient redis.Client, cfg *LoginRateLimitConfig, sender emailDomain.Sender, log logger.Logger) LoginLockoutService {
+	return &loginLockoutService{
+		redis:  redisClient,
+		cfg:    cfg,
+		sender: sender,
+		logger: log.Named("auth"),
+	}
+}
+
+func (s *loginLockoutService) LockedFor(ctx context.Context, email string) (time.Duration, error) {
+	key := fmt.Sprintf(loginLockoutKeyPattern, hashEmail(normalizeLockoutEmail(email)))
+
+	locked, err := s.redis.Exists(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check lockout: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	value, err := s.redis.Get(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read lockout: %w", err)
+	}
+	lockedUntil, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid lockout entry: %w", err)
+	}
+	return max(time.Until(time.Unix(lockedUntil, 0)), 0), nil
+}
+
+func (s *loginLockoutService) Lock(ctx context.Context, email string) (time.Duration, error) {
+	email = normalizeLockoutEmail(email)
+	emailHash := hashEmail(email)
+	countKey := fmt.Sprintf(loginLockoutCountKeyPattern, emailHash)
+
+	var previous int64
+	counted, err := s.redis.Exists(ctx, countKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read lockout count: %w", err)
+	}
+	if counted {
+		value, err := s.redis.Get(ctx, countKey)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read lockout count: %w", err)
+		}
+		if previous, err = strconv.ParseInt(value, 10, 64); err != nil {
+			return 0, fmt.Errorf("invalid lockout count: %w", err)
+		}
+	}
+
+	durations := s.cfg.LockoutDurationList
+	duration := durations[min(int(previous), len(durations)-1)]
+
+	// The count outlives the lockout by LockoutReset, so the sequence only
+	// starts over once the email has been left alone for that long
+	if err := s.redis.Set(ctx, countKey, previous+1, duration+s.cfg.LockoutReset); err != nil {
+		return 0, fmt.Errorf("failed to store lockout count: %w", err)
+	}
+	lockedUntil := time.Now().Add(duration).Unix()
+	if err := s.redis.Set(ctx, fmt.Sprintf(loginLockoutKeyPattern, emailHash), lockedUntil, duration); err != nil {
+		return 0, fmt.Errorf("failed to store lockout: %w", err)
+	}
+	// Attempts start from zero once the lockout ends
+	if err := s.redis.Delete(ctx, fmt.Sprintf(loginRateLimitEmailKeyPattern, emailHash)); err != nil {
+		return 0, fmt.Errorf("failed to reset attempts: %w", err)
+	}
+
+	loginLockouts.Inc()
+	s.audit("locked", emailHash, logger.Fields{
+		"lockout":  previous + 1,
+		"duration": duration.String(),
+	})
+
+	if s.cfg.UnlockURL != "" {
+		if err := s.sendUnlockEmail(ctx, email, emailHash, duration); err != nil {
+			s.logger.Warn("failed to create unlock link", logger.Fields{
+				"email_hash": emailHash,
+				"error":      err.Error(),
+			})
+		}
+	}
+
+	return duration, nil
+}
+
+func (s *loginLockoutService) Unlock(ctx context.Context, email string) error {
+	emailHash := hashEmail(normalizeLockoutEmail(email))
+
+	for _, pattern := range []string{loginLockoutKeyPattern, loginLockoutCountKeyPattern, loginRateLimitEmailKeyPattern} {
+		if err := s.redis.Delete(ctx, fmt.Sprintf(pattern, emailHash)); err != nil {
+			return fmt.Errorf("failed to unlock email: %w", err)
+		}
+	}
+
+	s.audit("unlocked", emailHash, logger.Fields{"via": "admin"})
+	return nil
+}
+
+func (s *loginLockoutService) UnlockWithToken(ctx context.Context, token string) error {
+	tokenKey := fmt.Sprintf(loginUnlockKeyPattern, hashUnlockToken(token))
+
+	exists, err := s.redis.Exists(ctx, tokenKey)
+	if err != nil {
+		return fmt.Errorf("failed to check unlock token: %w", err)
+	}
+	if !exists {
+		return ErrInvalidUnlockToken
+	}
+	emailHash, err := s.redis.Get(ctx, tokenKey)
+	if err != nil {
+		return fmt.Errorf("failed to read unlock token: %w", err)
+	}
+
+	for _, key := range []string{
+		fmt.Sprintf(loginLockoutKeyPattern, emailHash),
+		fmt.Sprintf(loginRateLimitEmailKeyPattern, emailHash),
+		tokenKey,
+	} {
+		if err := s.redis.Delete(ctx, key); err != nil {
+			return fmt.Errorf("failed to unlock email: %w", err)
+		}
+	}
+
+	s.audit("unlocked", emailHash, logger.Fields{"via": "email"})
+	return nil
+}
+
+// sendUnlockEmail stores a single-use unlock token for the lockout and emails
+// its link in the background. A failed send is logged; the lockout stands.
+func (s *loginLockoutService) sendUnlockEmail(ctx context.Context, email, emailHash string, duration time.Duration) error {
+	buf := make([]byte, loginUnlockTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Errorf("failed to generate unlock token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+
+	if err := s.redis.Set(ctx, fmt.Sprintf(loginUnlockKeyPattern, hashUnlockToken(token)), emailHash, duration); err != nil {
+		return fmt.Errorf("failed to store unlock token: %w", err)
+	}
+
+	msg := &emailDomain.Message{
+		To:      []string{email},
+		Subject: "Sign-in temporarily locked",
+		Body: fmt.Sprintf("There were too many sign-in attempts for this email address, so it is locked for %s.\n\n"+
+			"If that was you, you can unlock it now:\n%s\n\n"+
+			"If it wasn't you, ignore this email. The lock lifts on its own.",
+			duration, unlockLink(s.cfg.UnlockURL, token)),
+	}
+
+	go func() {
+		// Don't use request context as it will be cancelled when request completes
+		sendCtx, cancel := context.WithTimeout(context.Background(), loginUnlockEmailTimeout)
+		defer cancel()
+
+		if err := s.sender.Send(sendCtx, msg); err != nil {
+			s.logger.Error("failed to send unlock email", logger.Fields{
+				"email_hash": emailHash,
+				"error":      err.Error(),
+			})
+		}
+	}()
+
+	return nil
+}
+
+// audit writes an audit log entry for a lockout change.
+func (s *loginLockoutService) audit(event, emailHash string, fields logger.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["email_hash"] = emailHash
+	s.logger.Info("login lockout audit", fields)
+}
+
+// normalizeLockoutEmail normalizes an email the way attempts are counted.
+func normalizeLockoutEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// unlockLink appends the token to the unlock page URL.
+func unlockLink(base, token string) string {
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + "token=" + url.QueryEscape(token)
+}
+
+func hashUnlockToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
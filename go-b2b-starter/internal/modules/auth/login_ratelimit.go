@@ -0,0 +1,337 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+// Supported values for LOGIN_RATE_LIMIT_LOCKOUT_STRATEGY.
+const (
+	LockoutStrategyWindow      = "window"
+	LockoutStrategyProgressive = "progressive"
+)
+
+const (
+	// Redis keys for login attempt counters
+	loginRateLimitIPKeyPattern    = "auth:ratelimit:ip:%s"
+	loginRateLimitEmailKeyPattern = "auth:ratelimit:email:%s"
+
+	// loginRateLimitMaxBody bounds how much of a request body is read to find the email
+	loginRateLimitMaxBody = 64 << 10
+)
+
+// LoginRateLimitConfig configures brute-force protection for login-flow endpoints.
+//
+// Attempts are counted per client IP and per email in fixed windows. With the
+// progressive lockout strategy, an email that runs out of attempts is locked
+// for longer each consecutive time instead of waiting for its window to reset.
+// All values can be set via environment variables with the LOGIN_RATE_LIMIT_ prefix.
+type LoginRateLimitConfig struct {
+	// Enabled turns the limiter on
+	Enabled bool `mapstructure:"LOGIN_RATE_LIMIT_ENABLED"`
+
+	// IPAttempts is how many attempts one client IP may make per IPWindow
+	IPAttempts int64 `mapstructure:"LOGIN_RATE_LIMIT_IP_ATTEMPTS"`
+
+	// IPWindow is the counting window for IPAttempts
+	IPWindow time.Duration `mapstructure:"LOGIN_RATE_LIMIT_IP_WINDOW"`
+
+	// EmailAttempts is how many attempts may target one email per EmailWindow
+	EmailAttempts int64 `mapstructure:"LOGIN_RATE_LIMIT_EMAIL_ATTEMPTS"`
+
+	// EmailWindow is the counting window for EmailAttempts
+	EmailWindow time.Duration `mapstructure:"LOGIN_RATE_LIMIT_EMAIL_WINDOW"`
+
+	// ResponseStatus is the HTTP status returned to throttled requests (429 or 503)
+	ResponseStatus int `mapstructure:"LOGIN_RATE_LIMIT_RESPONSE_STATUS"`
+
+	// ResponseMessage is the error message returned to throttled requests
+	ResponseMessage string `mapstructure:"LOGIN_RATE_LIMIT_RESPONSE_MESSAGE"`
+
+	// FailOpen lets requests through when Redis is unavailable
+	FailOpen bool `mapstructure:"LOGIN_RATE_LIMIT_FAIL_OPEN"`
+
+	// LockoutStrategy is "window" (an email waits for EmailWindow to reset)
+	// or "progressive" (an email is locked for the next of LockoutDurations)
+	LockoutStrategy string `mapstructure:"LOGIN_RATE_LIMIT_LOCKOUT_STRATEGY"`
+
+	// LockoutDurations lists comma-separated lockout lengths, one per
+	// consecutive lockout of an email; the last one repeats
+	LockoutDurations string `mapstructure:"LOGIN_RATE_LIMIT_LOCKOUT_DURATIONS"`
+
+	// LockoutReset is how long an email must stay clear of lockouts, after
+	// the last one ends, to start again from the first duration
+	LockoutReset time.Duration `mapstructure:"LOGIN_RATE_LIMIT_LOCKOUT_RESET"`
+
+	// UnlockURL is the frontend page that receives the token of an unlock
+	// email; when empty, locked-out addresses are not emailed
+	UnlockURL string `mapstructure:"LOGIN_RATE_LIMIT_UNLOCK_URL"`
+
+	// LockoutDurationList is the parsed form of LockoutDurations
+	LockoutDurationList []time.Duration `mapstructure:"-"`
+}
+
+// Progressive reports whether emails are locked out progressively.
+func (c *LoginRateLimitConfig) Progressive() bool {
+	return c.Enabled && c.LockoutStrategy == LockoutStrategyProgressive
+}
+
+// LoadLoginRateLimitConfig loads the login rate limit configuration from environment variables and app.env file.
+func LoadLoginRateLimitConfig() (*LoginRateLimitConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("LOGIN_RATE_LIMIT_ENABLED", true)
+	v.SetDefault("LOGIN_RATE_LIMIT_IP_ATTEMPTS", 30)
+	v.SetDefault("LOGIN_RATE_LIMIT_IP_WINDOW", "5m")
+	v.SetDefault("LOGIN_RATE_LIMIT_EMAIL_ATTEMPTS", 10)
+	v.SetDefault("LOGIN_RATE_LIMIT_EMAIL_WINDOW", "15m")
+	v.SetDefault("LOGIN_RATE_LIMIT_RESPONSE_STATUS", http.StatusTooManyRequests)
+	v.SetDefault("LOGIN_RATE_LIMIT_RESPONSE_MESSAGE", "too many attempts, please try again later")
+	v.SetDefault("LOGIN_RATE_LIMIT_FAIL_OPEN", true)
+	v.SetDefault("LOGIN_RATE_LIMIT_LOCKOUT_STRATEGY", LockoutStrategyWindow)
+	v.SetDefault("LOGIN_RATE_LIMIT_LOCKOUT_DURATIONS", "1m,5m,30m,24h")
+	v.SetDefault("LOGIN_RATE_LIMIT_LOCKOUT_RESET", "24h")
+	v.SetDefault("LOGIN_RATE_LIMIT_UNLOCK_URL", "http://localhost:3000/auth/unlock")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg LoginRateLimitConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode login rate limit config: %w", err)
+	}
+
+	cfg.LockoutStrategy = strings.ToLower(strings.TrimSpace(cfg.LockoutStrategy))
+	cfg.UnlockURL = strings.TrimSpace(cfg.UnlockURL)
+	durations, err := parseLockoutDurations(cfg.LockoutDurations)
+	if err != nil {
+		return nil, err
+	}
+	cfg.LockoutDurationList = durations
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that an enabled limiter has usable thresholds.
+func (c *LoginRateLimitConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.IPAttempts <= 0 || c.IPWindow <= 0 {
+		return fmt.Errorf("login rate limit invalid: LOGIN_RATE_LIMIT_IP_ATTEMPTS and LOGIN_RATE_LIMIT_IP_WINDOW must be positive")
+	}
+	if c.EmailAttempts <= 0 || c.EmailWindow <= 0 {
+		return fmt.Errorf("login rate limit invalid: LOGIN_RATE_LIMIT_EMAIL_ATTEMPTS and LOGIN_RATE_LIMIT_EMAIL_WINDOW must be positive")
+	}
+	if c.ResponseStatus != http.StatusTooManyRequests && c.ResponseStatus != http.StatusServiceUnavailable {
+		return fmt.Errorf("login rate limit invalid: LOGIN_RATE_LIMIT_RESPONSE_STATUS must be 429 or 503")
+	}
+	switch c.LockoutStrategy {
+	case LockoutStrategyWindow:
+	case LockoutStrategyProgressive:
+		if len(c.LockoutDurationList) == 0 {
+			return fmt.Errorf("login rate limit invalid: LOGIN_RATE_LIMIT_LOCKOUT_DURATIONS is required for the progressive strategy")
+		}
+		if c.LockoutReset <= 0 {
+			return fmt.Errorf("login rate limit invalid: LOGIN_RATE_LIMIT_LOCKOUT_RESET must be positive")
+		}
+	default:
+		return fmt.Errorf("login rate limit invalid: LOGIN_RATE_LIMIT_LOCKOUT_STRATEGY must be %q or %q", LockoutStrategyWindow, LockoutStrategyProgressive)
+	}
+	return nil
+}
+
+// parseLockoutDurations parses a comma-separated list of positive durations.
+func parseLockoutDurations(value string) ([]time.Duration, error) {
+	var durations []time.Duration
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		duration, err := time.ParseDuration(part)
+		if err != nil || duration <= 0 {
+			return nil, fmt.Errorf("login rate limit invalid: LOGIN_RATE_LIMIT_LOCKOUT_DURATIONS has invalid duration %q", part)
+		}
+		durations = append(durations, duration)
+	}
+	return durations, nil
+}
+
+// LoginRateLimiter throttles login-flow attempts by client IP and by email.
+type LoginRateLimiter interface {
+	// Allow records an attempt and reports whether it may proceed.
+	// When it may not, the returned duration is how long until it may.
+	// An empty email is only counted against the IP.
+	Allow(ctx context.Context, clientIP, email string) (bool, time.Duration, error)
+}
+
+// redisLoginRateLimiter implements LoginRateLimiter with fixed-window Redis
+// counters, handing emails that run out of attempts to lockouts when the
+// progressive strategy is configured.
+type redisLoginRateLimiter struct {
+	redis    redis.Client
+	cfg      *LoginRateLimitConfig
+	lockouts LoginLockoutService
+}
+
+// NewRedisLoginRateLimiter creates a Redis-backed LoginRateLimiter.
+func NewRedisLoginRateLimiter(redisClient redis.Client, cfg *LoginRateLimitConfig, lockouts LoginLockoutService) LoginRateLimiter {
+	return &redisLoginRateLimiter{
+		redis:    redisClient,
+		cfg:      cfg,
+		lockouts: lockouts,
+	}
+}
+
+func (l *redisLoginRateLimiter) Allow(ctx context.Context, clientIP, email string) (bool, time.Duration, error) {
+	count, resetIn, err := l.redis.Incr(ctx, fmt.Sprintf(loginRateLimitIPKeyPattern, clientIP), l.cfg.IPWindow)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to count attempts for ip: %w", err)
+	}
+	if count > l.cfg.IPAttempts {
+		return false, resetIn, nil
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return true, 0, nil
+	}
+
+	progressive := l.cfg.Progressive()
+	if progressive {
+		lockedFor, err := l.lockouts.LockedFor(ctx, email)
+		if err != nil {
+			return false, 0, err
+		}
+		if lockedFor > 0 {
+			return false, lockedFor, nil
+		}
+	}
+
+	count, resetIn, err = l.redis.Incr(ctx, fmt.Sprintf(loginRateLimitEmailKeyPattern, hashEmail(email)), l.cfg.EmailWindow)
+	if err != nil {
+		return false, 0, fmt.Errorf("failed to count attempts for email: %w", err)
+	}
+	if count > l.cfg.EmailAttempts {
+		if progressive {
+			lockedFor, err := l.lockouts.Lock(ctx, email)
+			if err != nil {
+				return false, 0, err
+			}
+			return false, lockedFor, nil
+		}
+		return false, resetIn, nil
+	}
+
+	return true, 0, nil
+}
+
+// LoginRateLimit returns middleware that throttles login-flow endpoints.
+//
+// The email is taken from the "email" query parameter or the "email" field
+// of a JSON body; the body is restored for the handler. The first throttled
+// attempt of a window is published as a lockout (if events is not nil).
+func LoginRateLimit(limiter LoginRateLimiter, cfg *LoginRateLimitConfig, events AuthEventPublisher) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !cfg.Enabled {
+			c.Next()
+			return
+		}
+
+		email := attemptEmail(c)
+		allowed, retryAfter, err := limiter.Allow(c.Request.Context(), c.ClientIP(), email)
+		if err != nil {
+			if cfg.FailOpen {
+				c.Next()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error":   "service_unavailable",
+				"message": "login is temporarily unavailable",
+			})
+			return
+		}
+
+		if !allowed {
+			loginThrottled.Inc()
+			if events != nil {
+				events.ObserveLockout(c.Request.Context(), email, c.ClientIP(), c.Request.UserAgent(), retryAfter)
+			}
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(cfg.ResponseStatus, gin.H{
+				"error":   "too_many_attempts",
+				"message": cfg.ResponseMessage,
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// attemptEmail extracts the email a login-flow request targets, if any.
+func attemptEmail(c *gin.Context) string {
+	if email := c.Query("email"); email != "" {
+		return email
+	}
+	return jsonBodyField(c, "email")
+}
+
+// jsonBodyField reads a top-level string field from a JSON request body
+// and restores the body for the handler.
+func jsonBodyField(c *gin.Context, field string) string {
+	if c.Request.Body == nil || c.ContentType() != gin.MIMEJSON {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, loginRateLimitMaxBody))
+	if err != nil {
+		return ""
+	}
+	c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), c.Request.Body))
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	var value string
+	if err := json.Unmarshal(payload[field], &value); err != nil {
+		return ""
+	}
+	return value
+}
+
+// hashEmail hashes a normalized email so addresses are not stored in Redis keys.
+func hashEmail(email string) string {
+	sum := sha256.Sum256([]byte(email))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,230 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BackChannelLogoutEvent is the event type that must be present in the
+// "events" claim of an OIDC back-channel logout token.
+const BackChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+
+// LogoutClaims holds the validated claims of an OIDC logout token.
+//
+// At least one of Subject or SessionID is always set.
+type LogoutClaims struct {
+	// Issuer is the identity provider that issued the logout token.
+	Issuer string `json:"iss"`
+
+	// Subject is the provider user ID whose sessions should be terminated.
+	Subject string `json:"sub,omitempty"`
+
+	// SessionID is the provider session that should be terminated.
+	SessionID string `json:"sid,omitempty"`
+
+	// TokenID is the unique identifier (jti) of the logout token.
+	TokenID string `json:"jti"`
+
+	// IssuedAt is when the logout token was issued.
+	IssuedAt time.Time `json:"iat"`
+
+	// ExpiresAt is when the logout token expires; zero if it has no exp claim.
+	ExpiresAt time.Time `json:"exp,omitempty"`
+}
+
+// LogoutTokenVerifier validates OIDC logout requests coming from the identity provider.
+//
+// Implemented by auth provider adapters, since only they know the
+// provider's signing keys and issuer.
+type LogoutTokenVerifier interface {
+	// VerifyLogoutToken validates a back-channel logout token and returns its claims.
+	// Returns ErrInvalidLogoutToken if the token is malformed, unsigned, or not a logout token.
+	VerifyLogoutToken(ctx context.Context, token string) (*LogoutClaims, error)
+
+	// ValidateIssuer checks that the issuer of a front-channel logout request
+	// belongs to the configured provider.
+	ValidateIssuer(issuer string) error
+}
+
+// SessionRevoker terminates sessions at the auth provider.
+//
+// Revoking provider sessions invalidates the refresh credentials held by
+// clients, so they cannot obtain new access tokens after logout.
+type SessionRevoker interface {
+	// RevokeSession terminates a single provider session.
+	RevokeSession(ctx context.Context, sessionID string) error
+
+	// RevokeUserSessions terminates every session of a provider user.
+	RevokeUserSessions(ctx context.Context, userID string) error
+}
+
+// SessionDenylist tracks sessions that were logged out so that access
+// tokens which are still within their lifetime are rejected.
+type SessionDenylist interface {
+	// RevokeToken denylists a single access token by its ID until it expires.
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+
+	// RevokeSession denylists all tokens of the given session.
+	RevokeSession(ctx context.Context, sessionID string) error
+
+	// RevokeSubject denylists all tokens of the given user issued at or before the given time.
+	RevokeSubject(ctx context.Context, subject string, before time.Time) error
+
+	// IsRevoked reports whether the identity's token was revoked by a logout.
+	IsRevoked(ctx context.Context, identity *Identity) (bool, error)
+
+	// UseLogoutToken records the ID of a back-channel logout token until it
+	// expires and reports false if it was already recorded, so a replayed
+	// logout token is rejected.
+	UseLogoutToken(ctx context.Context, tokenID string, expiresAt time.Time) (bool, error)
+
+	// ReleaseLogoutToken forgets a logout token recorded by UseLogoutToken,
+	// so the provider can retry a logout that failed.
+	ReleaseLogoutToken(ctx context.Context, tokenID string) error
+}
+
+// LogoutService handles user logout and OIDC front-channel and back-channel logout requests.
+type LogoutService interface {
+	// Logout revokes the caller's access token and session. With everywhere set,
+	// every token and session of the user is revoked as well.
+	Logout(ctx context.Context, identity *Identity, everywhere bool) error
+
+	// BackChannelLogout processes a logout token sent server-to-server by the provider.
+	BackChannelLogout(ctx context.Context, logoutToken string) error
+
+	// FrontChannelLogout processes a logout request rendered in the user's browser by the provider.
+	FrontChannelLogout(ctx context.Context, issuer, sessionID string) error
+}
+
+type logoutService struct {
+	verifier LogoutTokenVerifier
+	revoker  SessionRevoker
+	denylist SessionDenylist
+	events   AuthEventPublisher
+}
+
+// NewLogoutService creates a LogoutService. Logouts initiated by the provider
+// are published on events; user logouts are published by the LogoutHandler,
+// which knows the client.
+func NewLogoutService(verifier LogoutTokenVerifier, revoker SessionRevoker, denylist SessionDenylist, events AuthEventPublisher) LogoutService {
+	return &logoutService{
+		verifier: verifier,
+		revoker:  revoker,
+		denylist: denylist,
+		events:   events,
+	}
+}
+
+func (s *logoutService) Logout(ctx context.Context, identity *Identity, everywhere bool) error {
+	if identity.TokenID != "" {
+		if err := s.denylist.RevokeToken(ctx, identity.TokenID, identity.ExpiresAt); err != nil {
+			return fmt.Errorf("failed to denylist token: %w", err)
+		}
+	}
+
+	if identity.SessionID != "" {
+		if err := s.revokeSession(ctx, identity.SessionID); err != nil {
+			return err
+		}
+	}
+
+	if everywhere && identity.UserID != "" {
+		if err := s.denylist.RevokeSubject(ctx, identity.UserID, time.Now()); err != nil {
+			return fmt.Errorf("failed to denylist subject: %w", err)
+		}
+		if err := s.revoker.RevokeUserSessions(ctx, identity.UserID); err != nil {
+			return fmt.Errorf("failed to revoke user sessions: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *logoutService) BackChannelLogout(ctx context.Context, logoutToken string) error {
+	claims, err := s.verifier.VerifyLogoutToken(ctx, logoutToken)
+	if err != nil {
+		return err
+	}
+
+	// A replayed token would sign the user out again after they signed back in
+	if claims.TokenID == "" {
+		return fmt.Errorf("%w: jti is required", ErrInvalidLogoutToken)
+	}
+	first, err := s.denylist.UseLogoutToken(ctx, claims.TokenID, claims.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("failed to record logout token: %w", err)
+	}
+	if !first {
+		return fmt.Errorf("%w: token was already used", ErrInvalidLogoutToken)
+	}
+
+	if err := s.revokeBackChannel(ctx, claims); err != nil {
+		// The provider retries failed deliveries with the same token
+		_ = s.denylist.ReleaseLogoutToken(ctx, claims.TokenID)
+		return err
+	}
+
+	event := NewAuthEvent(AuthEventLogout, nil).WithReason("backchannel")
+	event.UserID = claims.Subject
+	event.SessionID = claims.SessionID
+	s.events.Publish(ctx, event)
+
+	return nil
+}
+
+// revokeBackChannel revokes the session or user named by a logout token.
+func (s *logoutService) revokeBackChannel(ctx context.Context, claims *LogoutClaims) error {
+	if claims.SessionID != "" {
+		if err := s.revokeSession(ctx, claims.SessionID); err != nil {
+			return err
+		}
+	}
+
+	if claims.Subject != "" {
+		if err := s.denylist.RevokeSubject(ctx, claims.Subject, time.Now()); err != nil {
+			return fmt.Errorf("failed to denylist subject: %w", err)
+		}
+		// A logout token without sid terminates every session of the user
+		if claims.SessionID == "" {
+			if err := s.revoker.RevokeUserSessions(ctx, claims.Subject); err != nil {
+				return fmt.Errorf("failed to revoke user sessions: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *logoutService) FrontChannelLogout(ctx context.Context, issuer, sessionID string) error {
+	// The request is unauthenticated; iss and sid must come together
+	// (Front-Channel Logout 1.0 section 2) and iss must be the provider's
+	if sessionID == "" || issuer == "" {
+		return fmt.Errorf("%w: iss and sid are required", ErrInvalidLogoutToken)
+	}
+
+	if err := s.verifier.ValidateIssuer(issuer); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidLogoutToken, err)
+	}
+
+	if err := s.revokeSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	event := NewAuthEvent(AuthEventLogout, nil).WithReason("frontchannel")
+	event.SessionID = sessionID
+	s.events.Publish(ctx, event)
+
+	return nil
+}
+
+// revokeSession denylists the session locally and terminates it at the provider.
+func (s *logoutService) revokeSession(ctx context.Context, sessionID string) error {
+	if err := s.denylist.RevokeSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to denylist session: %w", err)
+	}
+	if err := s.revoker.RevokeSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
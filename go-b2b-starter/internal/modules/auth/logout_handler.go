@@ -0,0 +1,131 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// LogoutHandler handles user logout and the OIDC logout endpoints called by the identity provider.
+type LogoutHandler struct {
+	service LogoutService
+	events  AuthEventPublisher
+}
+
+func NewLogoutHandler(service LogoutService, events AuthEventPublisher) *LogoutHandler {
+	return &LogoutHandler{
+		service: service,
+		events:  events,
+	}
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revokes the caller's access token immediately, along with its provider session. With all=true, every session and access token of the user is revoked ("log out everywhere").
+// @Tags Auth
+// @Produce json
+// @Param all query bool false "Log out of every session"
+// @Success 204 "Logged out"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/logout [post]
+func (h *LogoutHandler) Logout(c *gin.Context) {
+	identity := GetIdentity(c)
+	if identity == nil {
+		response.Error(c, http.StatusUnauthorized, "authentication required", nil)
+		return
+	}
+
+	everywhere := c.Query("all") == "true"
+	if err := h.service.Logout(c.Request.Context(), identity, everywhere); err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to log out", err)
+		return
+	}
+
+	reason := "user"
+	if everywhere {
+		reason = "everywhere"
+	}
+	h.events.Publish(c.Request.Context(), NewAuthEvent(AuthEventLogout, identity).
+		WithRequest(c.ClientIP(), c.Request.UserAgent()).
+		WithReason(reason))
+
+	c.Status(http.StatusNoContent)
+}
+
+// BackChannelLogout godoc
+// @Summary OIDC back-channel logout
+// @Description Receives a logout token from the identity provider, revokes the referenced provider sessions and denylists their access tokens (OpenID Connect Back-Channel Logout 1.0).
+// @Tags Auth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param logout_token formData string true "Signed logout token"
+// @Success 200 "Logout processed"
+// @Failure 400 {object} map[string]string "Invalid logout token"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/oidc/backchannel-logout [post]
+func (h *LogoutHandler) BackChannelLogout(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+
+	logoutToken := c.PostForm("logout_token")
+	if logoutToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":             "invalid_request",
+			"error_description": "logout_token is required",
+		})
+		return
+	}
+
+	if err := h.service.BackChannelLogout(c.Request.Context(), logoutToken); err != nil {
+		if errors.Is(err, ErrInvalidLogoutToken) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":             "server_error",
+			"error_description": "failed to process logout",
+		})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// FrontChannelLogout godoc
+// @Summary OIDC front-channel logout
+// @Description Rendered by the identity provider in the user's browser; revokes the given session and denylists its access tokens (OpenID Connect Front-Channel Logout 1.0).
+// @Tags Auth
+// @Produce json
+// @Param iss query string true "Issuer of the logout request"
+// @Param sid query string true "Session ID to log out"
+// @Success 200 "Logout processed"
+// @Failure 400 {object} map[string]string "Invalid logout request"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/oidc/frontchannel-logout [get]
+func (h *LogoutHandler) FrontChannelLogout(c *gin.Context) {
+	c.Header("Cache-Control", "no-cache, no-store")
+	c.Header("Pragma", "no-cache")
+
+	if err := h.service.FrontChannelLogout(c.Request.Context(), c.Query("iss"), c.Query("sid")); err != nil {
+		if errors.Is(err, ErrInvalidLogoutToken) {
+			c.JSON(http.StatusBadRequest, gin.H{
+				"error":             "invalid_request",
+				"error_description": err.Error(),
+			})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":             "server_error",
+			"error_description": "failed to process logout",
+		})
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
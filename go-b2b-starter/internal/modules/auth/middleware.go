@@ -2,10 +2,16 @@ package auth
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
 )
 
 // OrganizationResolver looks up organization by provider org ID.
@@ -28,10 +34,84 @@ type AccountResolver interface {
 	ResolveByEmail(ctx context.Context, orgID int32, email string) (int32, error)
 }
 
+// NetworkPolicy restricts which client addresses may act within an organization.
+//
+// This interface decouples auth middleware from the organizations domain.
+// Implement this interface to enforce per-organization IP allowlists.
+type NetworkPolicy interface {
+	// CheckAccess returns ErrIPNotAllowed if the account may not access the
+	// organization from the given client IP.
+	CheckAccess(ctx context.Context, orgID, accountID int32, clientIP string) error
+}
+
 // MiddlewareConfig configures the auth middleware behavior.
 type MiddlewareConfig struct {
 	// ErrorHandler is called when an error occurs. If nil, default JSON responses are used.
 	ErrorHandler func(c *gin.Context, statusCode int, message string, err error)
+
+	// Denylist rejects tokens whose session was terminated by a logout.
+	// If nil, revocation is not checked.
+	Denylist SessionDenylist
+
+	// NetworkPolicy enforces per-organization IP allowlists in RequireOrganization.
+	// If nil, all client addresses are allowed.
+	NetworkPolicy NetworkPolicy
+
+	// Elevations layers active just-in-time role grants onto the identity in
+	// RequireOrganization. If nil, elevations are not applied.
+	Elevations ElevationResolver
+
+	// RoleAssignments layers roles assigned in the database onto the identity
+	// in RequireOrganization. If nil, only the provider's roles apply.
+	RoleAssignments RoleAssignmentResolver
+
+	// OrgAuthPolicies enforces per-organization MFA, session lifetime and
+	// sign-in method requirements in RequireOrganization. If nil, only the
+	// provider's requirements apply.
+	OrgAuthPolicies OrganizationAuthPolicyResolver
+
+	// Canaries rejects canary users in RequireOrganization and raises the
+	// alarm. If nil, canary users are not detected.
+	Canaries CanaryDetector
+
+	// Activity records member activity in RequireOrganization.
+	// If nil, activity is not tracked.
+	Activity ActivityRecorder
+
+	// Usage counts each account's requests in RequireOrganization and
+	// rejects those over the account's quota. If nil, usage is not tracked.
+	Usage UsageMeter
+
+	// DebugCaptures records the requests of organizations an operator is
+	// debugging in RequireOrganization. If nil, nothing is recorded.
+	DebugCaptures DebugCapturer
+
+	// Guests verifies guest tokens in RequireAuthOrGuest.
+	// If nil, guest tokens are rejected everywhere.
+	Guests GuestVerifier
+
+	// Impersonations verifies admin impersonation tokens and audits every
+	// request made with them. If nil, impersonation tokens are rejected.
+	Impersonations ImpersonationVerifier
+
+	// Clients verifies machine-to-machine tokens from the client credentials
+	// grant. If nil, client tokens are rejected.
+	Clients ClientTokenVerifier
+
+	// ServiceAccounts verifies API keys of service accounts. If nil, API keys
+	// are rejected.
+	ServiceAccounts ServiceAccountKeyVerifier
+
+	// Logger records permission denials. If nil, denials are not logged.
+	Logger logger.Logger
+
+	// Policy makes attribute-based decisions after the role permission check.
+	// If nil, the role permission alone decides.
+	Policy PolicyEvaluator
+
+	// Events publishes logins, token refreshes and rejected tokens for the
+	// auth audit log. If nil, no events are published.
+	Events AuthEventPublisher
 }
 
 // DefaultMiddlewareConfig returns the default middleware configuration.
@@ -90,7 +170,10 @@ func NewMiddleware(
 // This middleware:
 //  1. Extracts Bearer token from Authorization header
 //  2. Verifies token using the AuthProvider
-//  3. Sets Identity in Gin context (accessible via GetIdentity)
+//  3. Rejects tokens revoked by logout (if a Denylist is configured)
+//  4. Publishes login, token refresh and rejected token events (if Events is configured)
+//  5. Sets Identity in Gin context (accessible via GetIdentity)
+//  6. Audits the request if it was made with an impersonation token
 //
 // Must be called before any middleware that requires authentication.
 //
@@ -98,6 +181,19 @@ func NewMiddleware(
 //
 //	router.Use(authMiddleware.RequireAuth())
 func (m *Middleware) RequireAuth() gin.HandlerFunc {
+	return m.authenticate(false)
+}
+
+// RequireAuthOrGuest returns middleware like RequireAuth that also accepts
+// guest tokens (if a GuestVerifier is configured).
+//
+// Guest tokens must be sent with the device ID they were issued for in the
+// X-Device-ID header. Use it only on routes meant for anonymous trials.
+func (m *Middleware) RequireAuthOrGuest() gin.HandlerFunc {
+	return m.authenticate(true)
+}
+
+func (m *Middleware) authenticate(allowGuests bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Skip OPTIONS requests (CORS preflight)
 		if c.Request.Method == "OPTIONS" {
@@ -113,9 +209,38 @@ func (m *Middleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
-		// Verify token
-		identity, err := m.provider.VerifyToken(c.Request.Context(), token)
+		// Verify token; guest tokens only where allowed
+		var identity *Identity
+		providerToken := false
+		tokenType := metricTokenProvider
+		verifyStart := time.Now()
+		if allowGuests && m.config.Guests != nil && m.config.Guests.IsGuestToken(token) {
+			tokenType = metricTokenGuest
+			identity, err = m.config.Guests.VerifyGuestToken(c.Request.Context(), token, c.GetHeader(GuestDeviceHeader))
+		} else if m.config.Impersonations != nil && m.config.Impersonations.IsImpersonationToken(token) {
+			tokenType = metricTokenImpersonation
+			identity, err = m.config.Impersonations.VerifyImpersonationToken(c.Request.Context(), token)
+		} else if m.config.Clients != nil && m.config.Clients.IsClientToken(token) {
+			tokenType = metricTokenClient
+			identity, err = m.config.Clients.VerifyClientToken(c.Request.Context(), token)
+		} else if m.config.ServiceAccounts != nil && m.config.ServiceAccounts.IsServiceAccountKey(token) {
+			tokenType = metricTokenServiceAccount
+			identity, err = m.config.ServiceAccounts.VerifyServiceAccountKey(c.Request.Context(), token)
+		} else {
+			identity, err = m.provider.VerifyToken(c.Request.Context(), token)
+			providerToken = true
+		}
+		tokenVerificationDuration.WithLabelValues(tokenType).Observe(time.Since(verifyStart).Seconds())
 		if err != nil {
+			countTokenVerification(tokenType, err)
+
+			// Expired tokens are routine; clients refresh them
+			if m.config.Events != nil && !errors.Is(err, ErrTokenExpired) {
+				m.config.Events.Publish(c.Request.Context(), NewAuthEvent(AuthEventTokenRejected, nil).
+					WithRequest(c.ClientIP(), c.Request.UserAgent()).
+					WithReason(errorMessage(err)))
+			}
+
 			statusCode := HTTPStatusCode(err)
 			message := errorMessage(err)
 			m.config.ErrorHandler(c, statusCode, message, err)
@@ -123,10 +248,49 @@ func (m *Middleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Reject sessions terminated by front-channel or back-channel logout
+		if m.config.Denylist != nil {
+			revoked, err := m.config.Denylist.IsRevoked(c.Request.Context(), identity)
+			if err != nil {
+				m.config.ErrorHandler(c, http.StatusInternalServerError, "failed to check session status", err)
+				c.Abort()
+				return
+			}
+			if revoked {
+				countTokenVerification(tokenType, ErrSessionRevoked)
+				m.config.ErrorHandler(c, http.StatusUnauthorized, errorMessage(ErrSessionRevoked), ErrSessionRevoked)
+				c.Abort()
+				return
+			}
+		}
+
+		countTokenVerification(tokenType, nil)
+
+		// Record logins and token refreshes the first time a token is seen
+		if providerToken && m.config.Events != nil {
+			m.config.Events.ObserveToken(c.Request.Context(), token, identity, c.ClientIP(), c.Request.UserAgent())
+		}
+
 		// Set identity in context
 		SetIdentity(c, identity)
 
+		if !identity.IsImpersonated() || m.config.Impersonations == nil {
+			c.Next()
+			return
+		}
+
+		// Every impersonated request is audited, whatever its outcome
+		start := time.Now()
 		c.Next()
+		m.config.Impersonations.AuditImpersonatedRequest(c.Request.Context(), GetIdentity(c), &ImpersonatedRequest{
+			Method:         c.Request.Method,
+			Path:           c.Request.URL.Path,
+			Route:          c.FullPath(),
+			ClientIP:       c.ClientIP(),
+			Status:         c.Writer.Status(),
+			Duration:       time.Since(start),
+			RequestContext: GetRequestContext(c),
+		})
 	}
 }
 
@@ -136,7 +300,11 @@ func (m *Middleware) RequireAuth() gin.HandlerFunc {
 //  1. Gets Identity from context (requires RequireAuth to run first)
 //  2. Looks up organization by provider org ID
 //  3. Looks up account by email within organization
-//  4. Sets RequestContext in Gin context (accessible via GetRequestContext)
+//  4. Enforces the organization's network policy (if configured)
+//  5. Enforces the organization's auth policy (if configured)
+//  6. Sets RequestContext in Gin context (accessible via GetRequestContext)
+//  7. Records the request and response while the organization is under a
+//     debug capture (if configured)
 //
 // Must be called after RequireAuth middleware.
 //
@@ -175,38 +343,154 @@ func (m *Middleware) RequireOrganization() gin.HandlerFunc {
 			return
 		}
 
+		// A canary user's token means its credentials leaked. It fails like an
+		// unknown account so the caller cannot tell it was a decoy.
+		if m.config.Canaries != nil {
+			use := NewCanaryUse(c)
+			use.OrganizationID = orgID
+			if m.config.Canaries.DetectCanary(c.Request.Context(), identity.Email, use) {
+				m.config.ErrorHandler(c, http.StatusForbidden, "account not found", ErrAccountNotFound)
+				c.Abort()
+				return
+			}
+		}
+
 		// Resolve account
 		accountID, err := m.accResolver.ResolveByEmail(c.Request.Context(), orgID, identity.Email)
 		if err != nil {
-			m.config.ErrorHandler(c, http.StatusForbidden, "account not found", err)
+			if errors.Is(err, ErrAccountSuspended) {
+				m.config.ErrorHandler(c, http.StatusForbidden, "account suspended", err)
+			} else {
+				m.config.ErrorHandler(c, http.StatusForbidden, "account not found", err)
+			}
 			c.Abort()
 			return
 		}
 
+		// Enforce organization IP allowlist
+		if m.config.NetworkPolicy != nil {
+			if err := m.config.NetworkPolicy.CheckAccess(c.Request.Context(), orgID, accountID, c.ClientIP()); err != nil {
+				if errors.Is(err, ErrIPNotAllowed) {
+					m.config.ErrorHandler(c, http.StatusForbidden, "access from this network is not allowed", err)
+				} else {
+					m.config.ErrorHandler(c, http.StatusInternalServerError, "failed to check network policy", err)
+				}
+				c.Abort()
+				return
+			}
+		}
+
+		// Enforce the organization's session requirements. Client credentials,
+		// service account keys and impersonation tokens are not a member signing in.
+		if m.config.OrgAuthPolicies != nil && !identity.IsMachine() && !identity.IsImpersonated() {
+			policy, err := m.config.OrgAuthPolicies.AuthPolicy(c.Request.Context(), orgID)
+			if err != nil {
+				m.config.ErrorHandler(c, http.StatusInternalServerError, "failed to load organization auth policy", err)
+				c.Abort()
+				return
+			}
+			if policy != nil {
+				if err := policy.Check(identity, time.Now()); err != nil {
+					m.rejectOrgAuthPolicy(c, policy, err)
+					c.Abort()
+					return
+				}
+			}
+		}
+
+		// Apply roles assigned per user and per organization
+		if m.config.RoleAssignments != nil {
+			roles, err := m.config.RoleAssignments.AssignedRoles(c.Request.Context(), orgID, accountID)
+			if err != nil {
+				m.config.ErrorHandler(c, http.StatusInternalServerError, "failed to resolve role assignments", err)
+				c.Abort()
+				return
+			}
+			if len(roles) > 0 {
+				identity = identity.WithRoles(roles...)
+				SetIdentity(c, identity)
+			}
+		}
+
+		// Apply active just-in-time elevation
+		var elevation *Elevation
+		if m.config.Elevations != nil {
+			elevation, err = m.config.Elevations.ActiveElevation(c.Request.Context(), orgID, accountID)
+			if err != nil {
+				m.config.ErrorHandler(c, http.StatusInternalServerError, "failed to resolve privilege elevation", err)
+				c.Abort()
+				return
+			}
+			if elevation != nil {
+				identity = identity.WithElevation(elevation)
+				SetIdentity(c, identity)
+			}
+		}
+
 		// Set request context
 		reqCtx := &RequestContext{
 			Identity:       identity,
 			OrganizationID: orgID,
 			AccountID:      accountID,
 			ProviderOrgID:  identity.OrganizationID,
+			Elevation:      elevation,
 		}
 		SetRequestContext(c, reqCtx)
 
+		// Record member activity. Impersonations, client tokens and service
+		// accounts are not a member using the product.
+		if m.config.Activity != nil && !identity.IsMachine() && !identity.IsImpersonated() {
+			m.config.Activity.RecordActivity(c.Request.Context(), orgID, accountID)
+		}
+
+		// Count the request against the account's API quota. Impersonations
+		// are support staff acting for the member and are not counted.
+		if m.config.Usage != nil && !identity.IsImpersonated() && !m.enforceUsage(c, orgID, accountID) {
+			return
+		}
+
 		// Also set individual values for backward compatibility
 		c.Set("organization_id", orgID)
 		c.Set("account_id", accountID)
 		c.Set("stytch_org_id", identity.OrganizationID)
 
+		if m.config.DebugCaptures != nil {
+			if capture := m.config.DebugCaptures.ActiveDebugCapture(c.Request.Context(), orgID); capture != nil {
+				captureExchange(c, m.config.DebugCaptures, capture)
+				return
+			}
+		}
+
 		c.Next()
 	}
 }
 
+// rejectOrgAuthPolicy responds to a session that fails its organization's
+// auth policy. Sessions that are too old or lack MFA get the RFC 9470 step-up
+// challenge so clients know to send the user through sign-in again.
+func (m *Middleware) rejectOrgAuthPolicy(c *gin.Context, policy *OrganizationAuthPolicy, err error) {
+	switch {
+	case errors.Is(err, ErrSessionTooOld):
+		c.Header("WWW-Authenticate", `Bearer error="insufficient_user_authentication", `+
+			`error_description="organization session lifetime exceeded", max_age="`+strconv.Itoa(int(policy.SessionMaxAge.Seconds()))+`"`)
+		m.config.ErrorHandler(c, http.StatusUnauthorized, "please sign in again to continue", err)
+	case errors.Is(err, ErrMFARequired):
+		c.Header("WWW-Authenticate", `Bearer error="insufficient_user_authentication", `+
+			`error_description="multi-factor authentication required"`)
+		m.config.ErrorHandler(c, http.StatusUnauthorized, "your organization requires multi-factor authentication", err)
+	default:
+		m.config.ErrorHandler(c, http.StatusForbidden, "your organization does not allow this sign-in method", err)
+	}
+}
+
 // RequirePermission returns middleware that checks for a specific permission.
 //
 // This middleware:
 //  1. Gets Identity from context (requires RequireAuth to run first)
 //  2. Checks if user has the required permission
 //  3. Falls back to role-based permissions if not found in Identity
+//  4. Asks the configured PolicyEvaluator, if any (see LoadResource for
+//     making the targeted resource available to it)
 //
 // Must be called after RequireAuth middleware.
 //
@@ -223,7 +507,15 @@ func (m *Middleware) RequirePermission(resource, action string) gin.HandlerFunc
 			return
 		}
 
-		if !hasPermission(identity, resource, action) {
+		perm := NewPermission(resource, action)
+		decision, err := m.authorize(c, identity, perm)
+		if err != nil {
+			m.config.ErrorHandler(c, http.StatusServiceUnavailable, "authorization unavailable", err)
+			c.Abort()
+			return
+		}
+		if !decision.Allowed {
+			m.logDenied(c, identity, decision.Reason, perm)
 			m.config.ErrorHandler(c, http.StatusForbidden, "insufficient permissions", nil)
 			c.Abort()
 			return
@@ -233,6 +525,60 @@ func (m *Middleware) RequirePermission(resource, action string) gin.HandlerFunc
 	}
 }
 
+// authorize decides whether identity may use perm on the current request:
+// the role permission must be granted, then the policy (if any) must allow it.
+func (m *Middleware) authorize(c *gin.Context, identity *Identity, perm Permission) (PolicyDecision, error) {
+	if !hasPermission(identity, perm.Resource(), perm.Action()) {
+		return PolicyDecision{Reason: "role permission missing"}, nil
+	}
+	if m.config.Policy == nil {
+		return PolicyDecision{Allowed: true}, nil
+	}
+
+	decision, err := m.config.Policy.Evaluate(c.Request.Context(), NewPolicyInput(c, identity, perm))
+	if err != nil {
+		return PolicyDecision{}, fmt.Errorf("policy evaluation failed: %w", err)
+	}
+	if !decision.Allowed && decision.Reason == "" {
+		decision.Reason = "denied by policy"
+	}
+	return decision, nil
+}
+
+// logDenied records a permission denial with who was denied what, and where.
+// For RequireAnyPermission every accepted permission is listed.
+func (m *Middleware) logDenied(c *gin.Context, identity *Identity, reason string, permissions ...Permission) {
+	if m.config.Logger == nil {
+		return
+	}
+
+	required := make([]string, len(permissions))
+	for i, perm := range permissions {
+		required[i] = perm.String()
+	}
+
+	fields := logger.Fields{
+		"event":      "permission.denied",
+		"permission": strings.Join(required, ","),
+		"reason":     reason,
+		"user_id":    identity.UserID,
+		"roles":      identity.Roles,
+		"method":     c.Request.Method,
+		"route":      c.FullPath(),
+		"client_ip":  c.ClientIP(),
+	}
+	if reqCtx := GetRequestContext(c); reqCtx != nil {
+		fields["organization_id"] = reqCtx.OrganizationID
+		fields["account_id"] = reqCtx.AccountID
+	} else {
+		fields["provider_org_id"] = identity.OrganizationID
+	}
+	if identity.IsImpersonated() {
+		fields["impersonated_by"] = identity.ImpersonatedBy()
+	}
+	m.config.Logger.Warn("permission denied", fields)
+}
+
 // RequireAnyPermission returns middleware that checks for any of the given permissions.
 //
 // This middleware succeeds if the user has at least one of the specified permissions.
@@ -255,13 +601,22 @@ func (m *Middleware) RequireAnyPermission(permissions ...Permission) gin.Handler
 			return
 		}
 
+		var reasons []string
 		for _, perm := range permissions {
-			if hasPermission(identity, perm.Resource(), perm.Action()) {
+			decision, err := m.authorize(c, identity, perm)
+			if err != nil {
+				m.config.ErrorHandler(c, http.StatusServiceUnavailable, "authorization unavailable", err)
+				c.Abort()
+				return
+			}
+			if decision.Allowed {
 				c.Next()
 				return
 			}
+			reasons = append(reasons, decision.Reason)
 		}
 
+		m.logDenied(c, identity, strings.Join(reasons, "; "), permissions...)
 		m.config.ErrorHandler(c, http.StatusForbidden, "insufficient permissions", nil)
 		c.Abort()
 	}
@@ -289,7 +644,14 @@ func (m *Middleware) RequireAllPermissions(permissions ...Permission) gin.Handle
 		}
 
 		for _, perm := range permissions {
-			if !hasPermission(identity, perm.Resource(), perm.Action()) {
+			decision, err := m.authorize(c, identity, perm)
+			if err != nil {
+				m.config.ErrorHandler(c, http.StatusServiceUnavailable, "authorization unavailable", err)
+				c.Abort()
+				return
+			}
+			if !decision.Allowed {
+				m.logDenied(c, identity, decision.Reason, perm)
 				m.config.ErrorHandler(c, http.StatusForbidden, "insufficient permissions", nil)
 				c.Abort()
 				return
@@ -392,6 +754,19 @@ func hasPermission(identity *Identity, resource, action string) bool {
 	return false
 }
 
+// EffectivePermissions returns the catalog permissions the identity holds,
+// resolving wildcards and role fallbacks the same way as RequirePermission.
+// Authorization policies are not evaluated, as they depend on the request.
+func EffectivePermissions(identity *Identity) []Permission {
+	var permissions []Permission
+	for _, perm := range ActivePermissions() {
+		if hasPermission(identity, perm.Resource(), perm.Action()) {
+			permissions = append(permissions, perm)
+		}
+	}
+	return permissions
+}
+
 // hasRole checks if identity has the required role.
 func hasRole(identity *Identity, role Role) bool {
 	normalized := NormalizeRole(string(role))
@@ -416,6 +791,8 @@ func errorMessage(err error) string {
 		return "invalid token audience"
 	case ErrIssuerMismatch:
 		return "invalid token issuer"
+	case ErrSessionRevoked:
+		return "session revoked"
 	default:
 		return "authentication failed"
 	}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// OperatorTokenHeader carries ADMIN_API_TOKEN on operator requests
+const OperatorTokenHeader = "X-Admin-Token"
+
+// OperatorAuthConfig protects the operator endpoints under /api/admin.
+// Those endpoints act on the whole deployment rather than one organization,
+// so they take a shared operator token instead of a member token.
+type OperatorAuthConfig struct {
+	// Token protects every operator endpoint; empty disables them
+	Token string `mapstructure:"ADMIN_API_TOKEN"`
+}
+
+// LoadOperatorAuthConfig loads the operator token from environment variables and app.env file.
+func LoadOperatorAuthConfig() (*OperatorAuthConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("ADMIN_API_TOKEN", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg OperatorAuthConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode operator auth config: %w", err)
+	}
+
+	return &cfg, nil
+}
+
+// RequireOperator returns middleware that only lets through requests carrying
+// the operator token in the X-Admin-Token header. The endpoints are hidden
+// (404) while no token is configured.
+//
+// Usage:
+//
+//	group := router.Group("/admin/jobs")
+//	group.Use(resolver.Get("operator"))
+func RequireOperator(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			response.Error(c, http.StatusNotFound, "not found", nil)
+			c.Abort()
+			return
+		}
+		if subtle.ConstantTimeCompare([]byte(c.GetHeader(OperatorTokenHeader)), []byte(token)) != 1 {
+			response.Error(c, http.StatusUnauthorized, "invalid admin token", nil)
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
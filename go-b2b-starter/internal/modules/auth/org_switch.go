@@ -0,0 +1,38 @@
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// SessionTokens is a token pair issued by the auth provider.
+type SessionTokens struct {
+	// AccessToken is the short-lived token sent as the Bearer token (e.g., the Stytch session JWT).
+	AccessToken string `json:"access_token,omitempty"`
+
+	// SessionToken is the long-lived token used to refresh the access token.
+	SessionToken string `json:"session_token,omitempty"`
+
+	// ExpiresAt is when the session ends unless it is refreshed.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+
+	// MFARequired is true when the target organization requires a second factor
+	// the session does not have. No tokens are issued; the client completes MFA
+	// with IntermediateSessionToken instead.
+	MFARequired bool `json:"mfa_required"`
+
+	// IntermediateSessionToken is set when MFARequired is true.
+	IntermediateSessionToken string `json:"intermediate_session_token,omitempty"`
+}
+
+// OrganizationSwitcher moves a signed-in user to another organization they belong to.
+//
+// Implemented by auth provider adapters alongside SessionLister. Access tokens
+// are scoped to one organization (the org claim read into Identity.OrganizationID),
+// so switching issues a new token pair for the target organization.
+type OrganizationSwitcher interface {
+	// SwitchOrganization exchanges the token of the current session for a token
+	// pair scoped to the provider organization.
+	// Returns ErrOrganizationSwitchUnsupported if the provider cannot switch organizations.
+	SwitchOrganization(ctx context.Context, token, organizationID string) (*SessionTokens, error)
+}
@@ -20,9 +20,11 @@ import (
 // # Adding New Permissions
 //
 // To add a new permission:
-//  1. Add it to the appropriate role in DefaultRolePermissions (roles.go)
-//  2. Configure it in your auth provider (e.g., Stytch RBAC policy)
-//  3. Use RequirePermission("resource", "action") in your routes
+//  1. Add it to AllPermissions (rbac.go)
+//  2. Add a migration inserting it into rbac.permissions
+//  3. Grant it to roles through the role admin API (see RoleService)
+//  4. Configure it in your auth provider (e.g., Stytch RBAC policy)
+//  5. Use RequirePermission("resource", "action") in your routes
 type Permission string
 
 // NewPermission creates a permission from resource and action.
@@ -0,0 +1,316 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+)
+
+// Supported values for AUTH_POLICY_ENGINE.
+const (
+	PolicyEngineNone  = "none"
+	PolicyEngineRules = "rules"
+	PolicyEngineOPA   = "opa"
+	PolicyEngineCedar = "cedar"
+)
+
+// PolicyConfig configures the attribute-based policy evaluated after the role
+// permission check.
+//
+// All values can be set via environment variables with the AUTH_POLICY_ prefix.
+type PolicyConfig struct {
+	// Engine is "none", "rules", "opa" or "cedar"
+	Engine string `mapstructure:"AUTH_POLICY_ENGINE"`
+
+	// RulesFile is the JSON rules file for the built-in engine
+	RulesFile string `mapstructure:"AUTH_POLICY_RULES_FILE"`
+
+	// URL is the OPA server or cedar-agent base URL
+	URL string `mapstructure:"AUTH_POLICY_URL"`
+
+	// OPAPath is the OPA data document queried for decisions, e.g. "authz/allow"
+	OPAPath string `mapstructure:"AUTH_POLICY_OPA_PATH"`
+
+	// Timeout bounds each call to a remote engine
+	Timeout time.Duration `mapstructure:"AUTH_POLICY_TIMEOUT"`
+
+	// FailOpen falls back to the role permission alone when the engine errors
+	FailOpen bool `mapstructure:"AUTH_POLICY_FAIL_OPEN"`
+}
+
+// LoadPolicyConfig loads the policy configuration from environment variables and app.env file.
+func LoadPolicyConfig() (*PolicyConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("AUTH_POLICY_ENGINE", PolicyEngineNone)
+	v.SetDefault("AUTH_POLICY_RULES_FILE", "")
+	v.SetDefault("AUTH_POLICY_URL", "")
+	v.SetDefault("AUTH_POLICY_OPA_PATH", "authz/allow")
+	v.SetDefault("AUTH_POLICY_TIMEOUT", "2s")
+	v.SetDefault("AUTH_POLICY_FAIL_OPEN", false)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg PolicyConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode policy config: %w", err)
+	}
+
+	cfg.Engine = strings.ToLower(strings.TrimSpace(cfg.Engine))
+	cfg.OPAPath = strings.Trim(cfg.OPAPath, "/")
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Enabled reports whether a policy engine is configured.
+func (c *PolicyConfig) Enabled() bool {
+	return c.Engine != "" && c.Engine != PolicyEngineNone
+}
+
+// Validate checks that the configured engine has what it needs.
+func (c *PolicyConfig) Validate() error {
+	if !c.Enabled() {
+		return nil
+	}
+	switch c.Engine {
+	case PolicyEngineRules:
+		if c.RulesFile == "" {
+			return fmt.Errorf("policy config invalid: AUTH_POLICY_RULES_FILE is required for the rules engine")
+		}
+	case PolicyEngineOPA, PolicyEngineCedar:
+		if c.URL == "" {
+			return fmt.Errorf("policy config invalid: AUTH_POLICY_URL is required for %s", c.Engine)
+		}
+		if c.Engine == PolicyEngineOPA && c.OPAPath == "" {
+			return fmt.Errorf("policy config invalid: AUTH_POLICY_OPA_PATH is required for opa")
+		}
+		if c.Timeout <= 0 {
+			return fmt.Errorf("policy config invalid: AUTH_POLICY_TIMEOUT must be positive")
+		}
+	default:
+		return fmt.Errorf("policy config invalid: unsupported AUTH_POLICY_ENGINE %q", c.Engine)
+	}
+	return nil
+}
+
+// PolicyEvaluator makes attribute-based authorization decisions.
+//
+// The auth middleware calls it only after the role permission check passed,
+// so a policy can narrow access (ownership, tenant, network, time) but never
+// grant a permission the caller's roles lack.
+type PolicyEvaluator interface {
+	// Evaluate decides whether the request in input may proceed. An error
+	// means no decision could be made.
+	Evaluate(ctx context.Context, input *PolicyInput) (PolicyDecision, error)
+}
+
+// PolicyDecision is the outcome of a policy evaluation.
+type PolicyDecision struct {
+	// Allowed is true when the request may proceed
+	Allowed bool `json:"allowed"`
+
+	// Reason explains the decision (rule name or engine diagnostics)
+	Reason string `json:"reason,omitempty"`
+}
+
+// PolicyInput carries everything a policy can decide on.
+type PolicyInput struct {
+	// Permission is the permission the route requires, e.g. "resource:delete"
+	Permission Permission `json:"permission"`
+
+	// Subject is the caller
+	Subject PolicySubject `json:"subject"`
+
+	// Resource is the targeted resource, or nil when the route did not load
+	// one (see LoadResource)
+	Resource *ResourceAttributes `json:"resource,omitempty"`
+
+	// Request describes the HTTP request
+	Request PolicyRequest `json:"request"`
+}
+
+// PolicySubject describes the caller.
+type PolicySubject struct {
+	UserID                 string   `json:"user_id"`
+	Email                  string   `json:"email,omitempty"`
+	Roles                  []string `json:"roles"`
+	ProviderOrganizationID string   `json:"provider_organization_id,omitempty"`
+
+	// OrganizationID and AccountID are the database IDs; zero outside
+	// organization routes
+	OrganizationID int32 `json:"organization_id,omitempty"`
+	AccountID      int32 `json:"account_id,omitempty"`
+
+	Guest            bool   `json:"guest,omitempty"`
+	ClientID         string `json:"client_id,omitempty"`
+	ServiceAccountID string `json:"service_account_id,omitempty"`
+	ImpersonatedBy   string `json:"impersonated_by,omitempty"`
+}
+
+// PolicyRequest describes the HTTP request being authorized.
+type PolicyRequest struct {
+	Method   string    `json:"method"`
+	Route    string    `json:"route"`
+	Path     string    `json:"path"`
+	ClientIP string    `json:"client_ip"`
+	Time     time.Time `json:"time"`
+}
+
+// ResourceAttributes describes the resource a request targets.
+type ResourceAttributes struct {
+	// Type names the entity, e.g. "document"
+	Type string `json:"type"`
+
+	// ID is the entity's identifier
+	ID string `json:"id"`
+
+	// OrganizationID is the owning organization's database ID (0 if unknown)
+	OrganizationID int32 `json:"organization_id,omitempty"`
+
+	// OwnerAccountID is the creating account's database ID (0 if none)
+	OwnerAccountID int32 `json:"owner_account_id,omitempty"`
+
+	// Attributes holds any further properties policies may test, e.g. "status"
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// ResourceLoader looks up the resource a request targets.
+// It returns nil when the request does not target a single resource.
+type ResourceLoader func(c *gin.Context) (*ResourceAttributes, error)
+
+// ErrResourceNotFound is returned by a ResourceLoader when the targeted
+// resource does not exist; LoadResource answers 404.
+var ErrResourceNotFound = errors.New("resource not found")
+
+// LoadResource returns middleware that stores the targeted resource for the
+// policy check. Place it before the permission middleware.
+//
+// Usage:
+//
+//	docsGroup.DELETE("/:id",
+//	    auth.LoadResource(loadDocument),
+//	    resolver.Get("perm:resource:delete"),
+//	    handler.DeleteDocument)
+func LoadResource(loader ResourceLoader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resource, err := loader(c)
+		if errors.Is(err, ErrResourceNotFound) {
+			defaultErrorHandler(c, http.StatusNotFound, "resource not found", err)
+			c.Abort()
+			return
+		}
+		if err != nil {
+			defaultErrorHandler(c, http.StatusInternalServerError, "failed to load resource", err)
+			c.Abort()
+			return
+		}
+
+		if resource != nil {
+			SetResource(c, resource)
+		}
+		c.Next()
+	}
+}
+
+// NewPolicyInput builds the policy input for identity requesting permission
+// on the current request.
+func NewPolicyInput(c *gin.Context, identity *Identity, permission Permission) *PolicyInput {
+	roles := make([]string, len(identity.Roles))
+	for i, role := range identity.Roles {
+		roles[i] = string(role)
+	}
+
+	input := &PolicyInput{
+		Permission: permission,
+		Subject: PolicySubject{
+			UserID:                 identity.UserID,
+			Email:                  identity.Email,
+			Roles:                  roles,
+			ProviderOrganizationID: identity.OrganizationID,
+			Guest:                  identity.Guest,
+			ClientID:               identity.ClientID,
+			ServiceAccountID:       identity.ServiceAccountID,
+			ImpersonatedBy:         identity.ImpersonatedBy(),
+		},
+		Resource: GetResource(c),
+		Request: PolicyRequest{
+			Method:   c.Request.Method,
+			Route:    c.FullPath(),
+			Path:     c.Request.URL.Path,
+			ClientIP: c.ClientIP(),
+			Time:     time.Now().UTC(),
+		},
+	}
+	if reqCtx := GetRequestContext(c); reqCtx != nil {
+		input.Subject.OrganizationID = reqCtx.OrganizationID
+		input.Subject.AccountID = reqCtx.AccountID
+	}
+	return input
+}
+
+// NewPolicyEvaluator creates the evaluator for the configured engine.
+// It returns nil when no engine is configured.
+func NewPolicyEvaluator(cfg *PolicyConfig, log logger.Logger) (PolicyEvaluator, error) {
+	var evaluator PolicyEvaluator
+	httpClient := &http.Client{Timeout: cfg.Timeout}
+
+	switch cfg.Engine {
+	case PolicyEngineRules:
+		rules, err := LoadPolicyRules(cfg.RulesFile)
+		if err != nil {
+			return nil, err
+		}
+		evaluator = rules
+	case PolicyEngineOPA:
+		evaluator = NewOPAEvaluator(cfg.URL, cfg.OPAPath, httpClient)
+	case PolicyEngineCedar:
+		evaluator = NewCedarEvaluator(cfg.URL, httpClient)
+	default:
+		return nil, nil
+	}
+
+	if cfg.FailOpen {
+		evaluator = &failOpenEvaluator{next: evaluator, logger: log.Named("auth.policy")}
+	}
+	return evaluator, nil
+}
+
+// failOpenEvaluator allows requests when the wrapped engine cannot decide,
+// leaving the role permission check as the only gate.
+type failOpenEvaluator struct {
+	next   PolicyEvaluator
+	logger logger.Logger
+}
+
+func (e *failOpenEvaluator) Evaluate(ctx context.Context, input *PolicyInput) (PolicyDecision, error) {
+	decision, err := e.next.Evaluate(ctx, input)
+	if err != nil {
+		e.logger.Warn("policy engine unavailable, allowing on role permission", logger.Fields{
+			"permission": input.Permission.String(),
+			"error":      err.Error(),
+		})
+		return PolicyDecision{Allowed: true, Reason: "policy engine unavailable"}, nil
+	}
+	return decision, nil
+}
@@ -0,0 +1,154 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// opaEvaluator implements PolicyEvaluator with Open Policy Agent's data API.
+//
+// The PolicyInput is sent as "input". The queried document must be a boolean,
+// or an object with a boolean "allow" and an optional "reason".
+type opaEvaluator struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewOPAEvaluator creates a PolicyEvaluator that queries the document at path
+// (e.g. "authz/allow") on the OPA server at baseURL.
+func NewOPAEvaluator(baseURL, path string, httpClient *http.Client) PolicyEvaluator {
+	return &opaEvaluator{
+		url:        strings.TrimRight(baseURL, "/") + "/v1/data/" + strings.Trim(path, "/"),
+		httpClient: httpClient,
+	}
+}
+
+type opaResponse struct {
+	Result json.RawMessage `json:"result"`
+}
+
+type opaResult struct {
+	Allow  bool   `json:"allow"`
+	Reason string `json:"reason"`
+}
+
+func (e *opaEvaluator) Evaluate(ctx context.Context, input *PolicyInput) (PolicyDecision, error) {
+	var resp opaResponse
+	if err := postPolicyJSON(ctx, e.httpClient, e.url, map[string]any{"input": input}, &resp); err != nil {
+		return PolicyDecision{}, fmt.Errorf("opa: %w", err)
+	}
+
+	// An undefined document means no rule produced a decision
+	if len(resp.Result) == 0 {
+		return PolicyDecision{Reason: "undefined decision"}, nil
+	}
+
+	var allowed bool
+	if err := json.Unmarshal(resp.Result, &allowed); err == nil {
+		return PolicyDecision{Allowed: allowed}, nil
+	}
+
+	var result opaResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return PolicyDecision{}, fmt.Errorf("opa: unexpected result: %s", resp.Result)
+	}
+	return PolicyDecision{Allowed: result.Allow, Reason: result.Reason}, nil
+}
+
+// cedarEvaluator implements PolicyEvaluator with cedar-agent's authorization API.
+//
+// The principal is User::"<user_id>", the action Action::"<resource:action>"
+// and the resource <type>::"<id>" (the permission's resource when the route
+// loaded none). The full PolicyInput is passed as context.
+type cedarEvaluator struct {
+	url        string
+	httpClient *http.Client
+}
+
+// NewCedarEvaluator creates a PolicyEvaluator backed by the cedar-agent at baseURL.
+func NewCedarEvaluator(baseURL string, httpClient *http.Client) PolicyEvaluator {
+	return &cedarEvaluator{
+		url:        strings.TrimRight(baseURL, "/") + "/v1/is_authorized",
+		httpClient: httpClient,
+	}
+}
+
+type cedarRequest struct {
+	Principal string       `json:"principal"`
+	Action    string       `json:"action"`
+	Resource  string       `json:"resource"`
+	Context   *PolicyInput `json:"context"`
+}
+
+type cedarResponse struct {
+	Decision    string `json:"decision"`
+	Diagnostics struct {
+		Reason []string `json:"reason"`
+		Errors []string `json:"errors"`
+	} `json:"diagnostics"`
+}
+
+func (e *cedarEvaluator) Evaluate(ctx context.Context, input *PolicyInput) (PolicyDecision, error) {
+	resource := cedarEntity(input.Permission.Resource(), "")
+	if input.Resource != nil {
+		resource = cedarEntity(input.Resource.Type, input.Resource.ID)
+	}
+
+	req := cedarRequest{
+		Principal: cedarEntity("User", input.Subject.UserID),
+		Action:    cedarEntity("Action", input.Permission.String()),
+		Resource:  resource,
+		Context:   input,
+	}
+
+	var resp cedarResponse
+	if err := postPolicyJSON(ctx, e.httpClient, e.url, req, &resp); err != nil {
+		return PolicyDecision{}, fmt.Errorf("cedar: %w", err)
+	}
+	if len(resp.Diagnostics.Errors) > 0 {
+		return PolicyDecision{}, fmt.Errorf("cedar: %s", strings.Join(resp.Diagnostics.Errors, "; "))
+	}
+
+	return PolicyDecision{
+		Allowed: resp.Decision == "Allow",
+		Reason:  strings.Join(resp.Diagnostics.Reason, ","),
+	}, nil
+}
+
+// cedarEntity formats a Cedar entity UID such as User::"member-123".
+func cedarEntity(entityType, id string) string {
+	return entityType + "::" + strconv.Quote(id)
+}
+
+// postPolicyJSON posts body as JSON to url and decodes the response into out.
+func postPolicyJSON(ctx context.Context, httpClient *http.Client, url string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to query policy engine: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("policy engine returned status %d", resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
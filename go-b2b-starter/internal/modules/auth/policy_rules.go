@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"slices"
+	"strings"
+)
+
+// Rule effects for the built-in policy engine.
+const (
+	PolicyEffectAllow = "allow"
+	PolicyEffectDeny  = "deny"
+)
+
+// PolicyRuleSet is the built-in rules engine.
+//
+// Rules are checked in order and the first rule whose conditions all hold
+// decides. When no rule matches, DefaultEffect applies. Example file:
+//
+//	{
+//	  "default_effect": "allow",
+//	  "rules": [
+//	    {"name": "no cross-tenant access", "effect": "deny", "permissions": ["*:*"], "same_organization": false},
+//	    {"name": "admins delete anything", "effect": "allow", "permissions": ["resource:delete"], "roles": ["admin"]},
+//	    {"name": "others delete their own", "effect": "allow", "permissions": ["resource:delete"], "owner": true},
+//	    {"name": "deny other deletes", "effect": "deny", "permissions": ["resource:delete"]}
+//	  ]
+//	}
+type PolicyRuleSet struct {
+	// DefaultEffect is "allow" or "deny"
+	DefaultEffect string `json:"default_effect"`
+
+	Rules []PolicyRule `json:"rules"`
+}
+
+// PolicyRule is one rule of a PolicyRuleSet. Unset conditions always hold.
+//
+// Conditions on the resource (owner, same_organization, resource_attributes)
+// never hold when the route did not load a resource.
+type PolicyRule struct {
+	Name   string `json:"name"`
+	Effect string `json:"effect"`
+
+	// Permissions the rule applies to; wildcards as in MatchesWithWildcard
+	Permissions []Permission `json:"permissions"`
+
+	// Roles holds if the caller has any of them
+	Roles []string `json:"roles,omitempty"`
+
+	// Methods holds if the HTTP method is one of them
+	Methods []string `json:"methods,omitempty"`
+
+	// ClientCIDRs holds if the client IP is in one of the networks
+	ClientCIDRs []string `json:"client_cidrs,omitempty"`
+
+	// Guest and Client test for guest and machine identities (OAuth clients
+	// and service accounts)
+	Guest  *bool `json:"guest,omitempty"`
+	Client *bool `json:"client,omitempty"`
+
+	// Owner tests whether the caller's account created the resource
+	Owner *bool `json:"owner,omitempty"`
+
+	// SameOrganization tests whether the resource belongs to the caller's organization
+	SameOrganization *bool `json:"same_organization,omitempty"`
+
+	// ResourceAttributes holds if every listed attribute equals the resource's
+	ResourceAttributes map[string]any `json:"resource_attributes,omitempty"`
+
+	networks []*net.IPNet
+}
+
+// LoadPolicyRules reads and validates a JSON rules file.
+func LoadPolicyRules(path string) (*PolicyRuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy rules: %w", err)
+	}
+
+	var rules PolicyRuleSet
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to decode policy rules: %w", err)
+	}
+	if err := rules.compile(); err != nil {
+		return nil, err
+	}
+	return &rules, nil
+}
+
+// compile validates the rules and parses their networks.
+func (s *PolicyRuleSet) compile() error {
+	if s.DefaultEffect == "" {
+		s.DefaultEffect = PolicyEffectAllow
+	}
+	if s.DefaultEffect != PolicyEffectAllow && s.DefaultEffect != PolicyEffectDeny {
+		return fmt.Errorf("policy rules invalid: default_effect must be %q or %q", PolicyEffectAllow, PolicyEffectDeny)
+	}
+
+	for i := range s.Rules {
+		rule := &s.Rules[i]
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+		if rule.Effect != PolicyEffectAllow && rule.Effect != PolicyEffectDeny {
+			return fmt.Errorf("policy rules invalid: %s: effect must be %q or %q", rule.Name, PolicyEffectAllow, PolicyEffectDeny)
+		}
+		if len(rule.Permissions) == 0 {
+			return fmt.Errorf("policy rules invalid: %s: permissions are required", rule.Name)
+		}
+		for _, perm := range rule.Permissions {
+			if !perm.IsValid() {
+				return fmt.Errorf("policy rules invalid: %s: permission %q is not resource:action", rule.Name, perm)
+			}
+		}
+		for _, cidr := range rule.ClientCIDRs {
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return fmt.Errorf("policy rules invalid: %s: %w", rule.Name, err)
+			}
+			rule.networks = append(rule.networks, network)
+		}
+	}
+	return nil
+}
+
+// Evaluate implements PolicyEvaluator.
+func (s *PolicyRuleSet) Evaluate(_ context.Context, input *PolicyInput) (PolicyDecision, error) {
+	for i := range s.Rules {
+		rule := &s.Rules[i]
+		if rule.matches(input) {
+			return PolicyDecision{Allowed: rule.Effect == PolicyEffectAllow, Reason: rule.Name}, nil
+		}
+	}
+	return PolicyDecision{Allowed: s.DefaultEffect == PolicyEffectAllow, Reason: "default"}, nil
+}
+
+func (r *PolicyRule) matches(input *PolicyInput) bool {
+	if !slices.ContainsFunc(r.Permissions, func(p Permission) bool {
+		return p.MatchesWithWildcard(input.Permission)
+	}) {
+		return false
+	}
+
+	subject := input.Subject
+	if len(r.Roles) > 0 && !slices.ContainsFunc(subject.Roles, func(role string) bool {
+		return slices.ContainsFunc(r.Roles, func(want string) bool {
+			return NormalizeRole(want) == NormalizeRole(role)
+		})
+	}) {
+		return false
+	}
+	if len(r.Methods) > 0 && !slices.ContainsFunc(r.Methods, func(m string) bool {
+		return strings.EqualFold(m, input.Request.Method)
+	}) {
+		return false
+	}
+	if len(r.networks) > 0 {
+		ip := net.ParseIP(input.Request.ClientIP)
+		if ip == nil || !slices.ContainsFunc(r.networks, func(n *net.IPNet) bool { return n.Contains(ip) }) {
+			return false
+		}
+	}
+	if r.Guest != nil && *r.Guest != subject.Guest {
+		return false
+	}
+	if r.Client != nil && *r.Client != (subject.ClientID != "" || subject.ServiceAccountID != "") {
+		return false
+	}
+
+	if r.Owner == nil && r.SameOrganization == nil && len(r.ResourceAttributes) == 0 {
+		return true
+	}
+	resource := input.Resource
+	if resource == nil {
+		return false
+	}
+	if r.Owner != nil {
+		owner := resource.OwnerAccountID != 0 && resource.OwnerAccountID == subject.AccountID
+		if *r.Owner != owner {
+			return false
+		}
+	}
+	if r.SameOrganization != nil {
+		same := resource.OrganizationID != 0 && resource.OrganizationID == subject.OrganizationID
+		if *r.SameOrganization != same {
+			return false
+		}
+	}
+	for key, want := range r.ResourceAttributes {
+		got, ok := resource.Attributes[key]
+		if !ok || fmt.Sprint(got) != fmt.Sprint(want) {
+			return false
+		}
+	}
+	return true
+}
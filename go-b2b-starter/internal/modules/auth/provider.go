@@ -2,11 +2,18 @@ package auth
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
 )
 
+// PermissionMiddlewarePrefix prefixes the named permission middlewares, e.g.
+// "perm:org:manage" for RequirePermission("org", "manage").
+const PermissionMiddlewarePrefix = "perm:"
+
 // ServerMiddlewareRegistrar is the interface for registering named middleware.
 // This matches the server.Server interface's RegisterNamedMiddleware method.
 type ServerMiddlewareRegistrar interface {
@@ -26,9 +33,9 @@ func NewProvider(container *dig.Container) *Provider {
 
 // RegisterDependencies registers all RBAC dependencies in the container
 func (p *Provider) RegisterDependencies() error {
-	// Provide RBAC Service
-	if err := p.container.Provide(func() RBACService {
-		return NewRBACService()
+	// Provide RBAC Service, backed by the database role catalog
+	if err := p.container.Provide(func(roles RoleService) RBACService {
+		return roles
 	}); err != nil {
 		return fmt.Errorf("failed to provide rbac service: %w", err)
 	}
@@ -40,9 +47,74 @@ func (p *Provider) RegisterDependencies() error {
 		return fmt.Errorf("failed to provide rbac handler: %w", err)
 	}
 
+	// Provide Role Admin Handler
+	if err := p.container.Provide(func(service RoleService) *RoleAdminHandler {
+		return NewRoleAdminHandler(service)
+	}); err != nil {
+		return fmt.Errorf("failed to provide role admin handler: %w", err)
+	}
+
+	// Provide Lockout Handler
+	if err := p.container.Provide(func(service LoginLockoutService) *LockoutHandler {
+		return NewLockoutHandler(service)
+	}); err != nil {
+		return fmt.Errorf("failed to provide lockout handler: %w", err)
+	}
+
+	// Provide OIDC Logout Service
+	if err := p.container.Provide(func(
+		verifier LogoutTokenVerifier,
+		revoker SessionRevoker,
+		denylist SessionDenylist,
+		events AuthEventPublisher,
+	) LogoutService {
+		return NewLogoutService(verifier, revoker, denylist, events)
+	}); err != nil {
+		return fmt.Errorf("failed to provide logout service: %w", err)
+	}
+
+	// Provide OIDC Logout Handler
+	if err := p.container.Provide(func(service LogoutService, events AuthEventPublisher) *LogoutHandler {
+		return NewLogoutHandler(service, events)
+	}); err != nil {
+		return fmt.Errorf("failed to provide logout handler: %w", err)
+	}
+
+	// Provide Device Session Service
+	if err := p.container.Provide(func(
+		lister SessionLister,
+		revoker SessionRevoker,
+		denylist SessionDenylist,
+	) SessionService {
+		return NewSessionService(lister, revoker, denylist)
+	}); err != nil {
+		return fmt.Errorf("failed to provide session service: %w", err)
+	}
+
+	// Provide Device Session Handler
+	if err := p.container.Provide(func(service SessionService) *SessionHandler {
+		return NewSessionHandler(service)
+	}); err != nil {
+		return fmt.Errorf("failed to provide session handler: %w", err)
+	}
+
+	// Provide Auth Audit Log Handler
+	if err := p.container.Provide(func(service AuditLogService) *AuditLogHandler {
+		return NewAuditLogHandler(service)
+	}); err != nil {
+		return fmt.Errorf("failed to provide auth audit log handler: %w", err)
+	}
+
 	// Provide RBAC Routes
-	if err := p.container.Provide(func(handler *Handler) *Routes {
-		return NewRoutes(handler)
+	if err := p.container.Provide(func(
+		handler *Handler,
+		roleAdminHandler *RoleAdminHandler,
+		lockoutHandler *LockoutHandler,
+		logoutHandler *LogoutHandler,
+		sessionHandler *SessionHandler,
+		auditLogHandler *AuditLogHandler,
+	) *Routes {
+		return NewRoutes(handler, roleAdminHandler, lockoutHandler, logoutHandler, sessionHandler, auditLogHandler)
 	}); err != nil {
 		return fmt.Errorf("failed to provide rbac routes: %w", err)
 	}
@@ -60,6 +132,22 @@ func (p *Provider) RegisterDependencies() error {
 //   - auth.AuthProvider
 //   - auth.OrganizationResolver
 //   - auth.AccountResolver
+//   - auth.SessionDenylist
+//   - auth.NetworkPolicy
+//   - auth.ElevationResolver
+//   - auth.RoleAssignmentResolver
+//   - auth.OrganizationAuthPolicyResolver
+//   - auth.ActivityRecorder
+//   - auth.UsageMeter
+//   - auth.CanaryDetector
+//   - auth.DebugCapturer
+//   - auth.GuestVerifier
+//   - auth.ImpersonationVerifier
+//   - auth.ClientTokenVerifier
+//   - auth.ServiceAccountKeyVerifier
+//   - auth.PolicyEvaluator
+//   - auth.AuthEventPublisher
+//   - logger.Logger
 //
 // # Usage
 //
@@ -71,8 +159,41 @@ func SetupMiddleware(container *dig.Container) error {
 		provider AuthProvider,
 		orgResolver OrganizationResolver,
 		accResolver AccountResolver,
+		denylist SessionDenylist,
+		networkPolicy NetworkPolicy,
+		elevations ElevationResolver,
+		roleAssignments RoleAssignmentResolver,
+		orgAuthPolicies OrganizationAuthPolicyResolver,
+		activity ActivityRecorder,
+		usage UsageMeter,
+		canaries CanaryDetector,
+		debugCaptures DebugCapturer,
+		guests GuestVerifier,
+		impersonations ImpersonationVerifier,
+		clients ClientTokenVerifier,
+		serviceAccounts ServiceAccountKeyVerifier,
+		policy PolicyEvaluator,
+		events AuthEventPublisher,
+		log logger.Logger,
 	) *Middleware {
-		return NewMiddleware(provider, orgResolver, accResolver, nil)
+		config := DefaultMiddlewareConfig()
+		config.Denylist = denylist
+		config.NetworkPolicy = networkPolicy
+		config.Elevations = elevations
+		config.RoleAssignments = roleAssignments
+		config.OrgAuthPolicies = orgAuthPolicies
+		config.Activity = activity
+		config.Usage = usage
+		config.Canaries = canaries
+		config.DebugCaptures = debugCaptures
+		config.Guests = guests
+		config.Impersonations = impersonations
+		config.Clients = clients
+		config.ServiceAccounts = serviceAccounts
+		config.Logger = log.Named("auth")
+		config.Policy = policy
+		config.Events = events
+		return NewMiddleware(provider, orgResolver, accResolver, config)
 	}); err != nil {
 		return fmt.Errorf("failed to provide auth middleware: %w", err)
 	}
@@ -85,7 +206,17 @@ func SetupMiddleware(container *dig.Container) error {
 // This should be called after SetupMiddleware and the server is available.
 // It registers the following named middlewares:
 //   - "auth": RequireAuth middleware (verifies JWT token)
+//   - "guest_auth": RequireAuthOrGuest middleware (also accepts guest tokens)
 //   - "org_context": RequireOrganization middleware (resolves org/account IDs)
+//   - "login_rate_limit": LoginRateLimit middleware (throttles login-flow endpoints, publishing lockouts)
+//   - "email_throttle": EmailSendThrottle middleware (cooldowns for endpoints that send email)
+//   - "captcha": Captcha middleware (challenges after repeated failed attempts)
+//   - "captcha_signup": Captcha middleware for registration (always challenges when CAPTCHA_ALWAYS_ON_SIGNUP)
+//   - "canary": CanaryTrap middleware (alerts on canary emails and client IDs in login-flow and token requests)
+//   - "recent_auth": RequireRecentAuth middleware (step-up for sensitive operations, run after "auth")
+//   - "operator": RequireOperator middleware (X-Admin-Token for the /admin operator endpoints)
+//   - "perm:<resource>:<action>": RequirePermission middleware for every permission
+//     in the role catalog (e.g. "perm:org:manage"), logging each denial
 //
 // # Usage
 //
@@ -95,6 +226,18 @@ func SetupMiddleware(container *dig.Container) error {
 func RegisterNamedMiddlewares(container *dig.Container) error {
 	return container.Invoke(func(
 		middleware *Middleware,
+		limiter LoginRateLimiter,
+		limitConfig *LoginRateLimitConfig,
+		events AuthEventPublisher,
+		emailThrottle EmailThrottle,
+		emailThrottleConfig *EmailThrottleConfig,
+		captchaVerifier CaptchaVerifier,
+		captchaFailures CaptchaFailureTracker,
+		captchaConfig *CaptchaConfig,
+		recentAuthConfig *RecentAuthConfig,
+		operatorConfig *OperatorAuthConfig,
+		canaries CanaryDetector,
+		roles RoleService,
 		server ServerMiddlewareRegistrar,
 	) {
 		// Register auth middleware (verifies JWT and sets Identity)
@@ -102,10 +245,66 @@ func RegisterNamedMiddlewares(container *dig.Container) error {
 			return middleware.RequireAuth()
 		})
 
+		// Register guest-tolerant auth middleware (for anonymous trial routes)
+		server.RegisterNamedMiddleware("guest_auth", func() gin.HandlerFunc {
+			return middleware.RequireAuthOrGuest()
+		})
+
 		// Register organization context middleware (resolves database IDs)
 		server.RegisterNamedMiddleware("org_context", func() gin.HandlerFunc {
 			return middleware.RequireOrganization()
 		})
+
+		// Register login rate limit middleware (per-IP and per-email attempt throttling)
+		server.RegisterNamedMiddleware("login_rate_limit", func() gin.HandlerFunc {
+			return LoginRateLimit(limiter, limitConfig, events)
+		})
+
+		// Register email throttle middleware (per-recipient cooldown, per-account and per-IP limits)
+		server.RegisterNamedMiddleware("email_throttle", func() gin.HandlerFunc {
+			return EmailSendThrottle(emailThrottle, emailThrottleConfig)
+		})
+
+		// Register CAPTCHA middlewares (challenge after failed attempts, or always on signup)
+		server.RegisterNamedMiddleware("captcha", func() gin.HandlerFunc {
+			return Captcha(captchaVerifier, captchaFailures, captchaConfig, false)
+		})
+		server.RegisterNamedMiddleware("captcha_signup", func() gin.HandlerFunc {
+			return Captcha(captchaVerifier, captchaFailures, captchaConfig, captchaConfig.AlwaysOnSignup)
+		})
+
+		// Register canary trap middleware (alerts on leaked decoy credentials)
+		server.RegisterNamedMiddleware("canary", func() gin.HandlerFunc {
+			return CanaryTrap(canaries)
+		})
+
+		// Register step-up middleware (requires a recent sign-in for sensitive operations)
+		server.RegisterNamedMiddleware("recent_auth", func() gin.HandlerFunc {
+			return RequireRecentAuth(recentAuthConfig.MaxAge)
+		})
+
+		// Register operator middleware (shared operator token, hidden when unset)
+		server.RegisterNamedMiddleware("operator", func() gin.HandlerFunc {
+			return RequireOperator(operatorConfig.Token)
+		})
+
+		// Register permission middlewares (one per catalog permission, run after "auth").
+		// roles is requested so the catalog is loaded from the database first; the
+		// built-in permissions are always registered so routes keep resolving even
+		// if an admin removes one from the catalog. Permissions created later need
+		// a restart before routes can name them.
+		registered := make(map[Permission]bool)
+		for _, perm := range slices.Concat(AllPermissions, roles.GetAllPermissions()) {
+			if registered[perm] {
+				continue
+			}
+			registered[perm] = true
+
+			resource, action := perm.Resource(), perm.Action()
+			server.RegisterNamedMiddleware(PermissionMiddlewarePrefix+perm.String(), func() gin.HandlerFunc {
+				return middleware.RequirePermission(resource, action)
+			})
+		}
 	})
 }
 
@@ -1,5 +1,7 @@
 package auth
 
+import "fmt"
+
 // =============================================================================
 // RBAC DEFINITIONS - Roles and Permissions
 // =============================================================================
@@ -62,8 +64,8 @@ var AllPermissions = []Permission{
 //
 // To customize:
 //   1. Change role IDs if needed (must match Stytch configuration)
-//   2. Adjust permissions for each role
-//   3. Add new roles if needed
+//   2. Adjust permissions for each role (and the seed migration)
+//   3. Add custom roles at runtime through the role admin API (see RoleService)
 //
 // =============================================================================
 
@@ -78,6 +80,8 @@ type RoleInfo struct {
 	Description string
 	// Permissions is the list of permissions granted to this role
 	Permissions []Permission
+	// System marks built-in roles, which cannot be deleted
+	System bool
 }
 
 var (
@@ -85,6 +89,7 @@ var (
 	// Typical users: Employees, staff, basic users
 	RoleMemberInfo = RoleInfo{
 		ID:          "member",
+		System:      true,
 		Name:        "Member",
 		Description: "Basic access. Can view and create resources.",
 		Permissions: []Permission{
@@ -97,6 +102,7 @@ var (
 	// Typical users: Team leads, supervisors, managers
 	RoleManagerInfo = RoleInfo{
 		ID:          "manager",
+		System:      true,
 		Name:        "Manager",
 		Description: "Elevated access. Can edit, delete, and approve resources.",
 		Permissions: []Permission{
@@ -113,6 +119,7 @@ var (
 	// Typical users: Business owners, administrators
 	RoleAdminInfo = RoleInfo{
 		ID:          "admin",
+		System:      true,
 		Name:        "Admin",
 		Description: "Full control. Can manage organization settings and users.",
 		Permissions: []Permission{
@@ -127,8 +134,8 @@ var (
 	}
 )
 
-// AllRoles is the complete list of all roles in the RBAC system.
-// Update this when you add or remove roles.
+// AllRoles is the list of built-in roles. They seed the rbac.roles table and
+// are used until roles are loaded from the database (see RoleService).
 var AllRoles = []RoleInfo{
 	RoleMemberInfo,
 	RoleManagerInfo,
@@ -139,12 +146,13 @@ var AllRoles = []RoleInfo{
 // HELPER FUNCTIONS
 // =============================================================================
 
-// GetRoleInfo retrieves role information by role ID.
+// GetRoleInfo retrieves role information by role ID from the active roles.
 // Returns nil if the role is not found.
 func GetRoleInfo(roleID string) *RoleInfo {
-	for i := range AllRoles {
-		if AllRoles[i].ID == roleID {
-			return &AllRoles[i]
+	roles := ActiveRoles()
+	for i := range roles {
+		if roles[i].ID == roleID {
+			return &roles[i]
 		}
 	}
 	return nil
@@ -233,6 +241,7 @@ type RoleDTO struct {
 	ID          string          `json:"id"`
 	Name        string          `json:"name"`
 	Description string          `json:"description"`
+	IsSystem    bool            `json:"is_system"`
 	Permissions []PermissionDTO `json:"permissions"`
 }
 
@@ -247,6 +256,7 @@ func NewRoleDTO(role RoleInfo) RoleDTO {
 		ID:          role.ID,
 		Name:        role.Name,
 		Description: role.Description,
+		IsSystem:    role.System,
 		Permissions: permDTOs,
 	}
 }
@@ -361,16 +371,19 @@ type RBACMetadata struct {
 
 // NewRBACMetadata creates metadata about the RBAC system
 func NewRBACMetadata() RBACMetadata {
+	roles := ActiveRoles()
+	permissions := ActivePermissions()
+
 	permsByRole := make(map[string]int)
-	for _, role := range AllRoles {
+	for _, role := range roles {
 		permsByRole[role.ID] = len(role.Permissions)
 	}
 
 	return RBACMetadata{
-		TotalRoles:        len(AllRoles),
-		TotalPermissions:  len(AllPermissions),
+		TotalRoles:        len(roles),
+		TotalPermissions:  len(permissions),
 		PermissionsByRole: permsByRole,
-		Description:       "Simple RBAC system with 3 roles (Member, Manager, Admin) and 7 generic permissions",
+		Description:       fmt.Sprintf("RBAC system with %d roles and %d permissions", len(roles), len(permissions)),
 	}
 }
 
@@ -398,7 +411,7 @@ func NewRBACService() RBACService {
 }
 
 func (s *defaultRBACService) GetAllRoles() []RoleInfo {
-	return AllRoles
+	return ActiveRoles()
 }
 
 func (s *defaultRBACService) GetRoleInfo(roleID string) *RoleInfo {
@@ -406,7 +419,7 @@ func (s *defaultRBACService) GetRoleInfo(roleID string) *RoleInfo {
 }
 
 func (s *defaultRBACService) GetAllPermissions() []Permission {
-	return AllPermissions
+	return ActivePermissions()
 }
 
 func (s *defaultRBACService) GetRolePermissions(roleID string) []Permission {
@@ -420,7 +433,7 @@ func (s *defaultRBACService) GetRolePermissions(roleID string) []Permission {
 func (s *defaultRBACService) GetPermissionsByCategory() map[string][]Permission {
 	// For simplicity, return all permissions in one "General" category
 	return map[string][]Permission{
-		"General": AllPermissions,
+		"General": ActivePermissions(),
 	}
 }
 
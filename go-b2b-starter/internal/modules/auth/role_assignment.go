@@ -0,0 +1,41 @@
+package auth
+
+import "context"
+
+// RoleAssignmentResolver looks up roles assigned to accounts in the database.
+//
+// Provider tokens only carry the roles the auth provider assigns, so
+// RequireOrganization layers the assigned roles onto the Identity on every
+// request. Removing an assignment takes effect on the next request.
+//
+// This interface decouples auth middleware from the organizations domain.
+// Implement this interface to support role assignments per user and per
+// organization.
+type RoleAssignmentResolver interface {
+	// AssignedRoles returns the roles assigned to the account within its
+	// organization and globally to its user, or none.
+	AssignedRoles(ctx context.Context, orgID, accountID int32) ([]Role, error)
+}
+
+// WithRoles returns a copy of the identity that also holds the given roles
+// and their permissions. The original identity is not modified.
+func (i *Identity) WithRoles(roles ...Role) *Identity {
+	extended := *i
+
+	extended.Roles = append(make([]Role, 0, len(i.Roles)+len(roles)), i.Roles...)
+	perms := NewPermissionSet(i.Permissions)
+	extended.Permissions = append(make([]Permission, 0, len(i.Permissions)), i.Permissions...)
+	for _, role := range roles {
+		if !extended.HasRole(role) {
+			extended.Roles = append(extended.Roles, role)
+		}
+		for _, perm := range GetRolePermissions(role) {
+			if !perms.Contains(perm) {
+				perms[perm] = struct{}{}
+				extended.Permissions = append(extended.Permissions, perm)
+			}
+		}
+	}
+
+	return &extended
+}
@@ -0,0 +1,44 @@
+package auth
+
+import "sync/atomic"
+
+// roleCatalog is a snapshot of the roles and permissions in effect.
+type roleCatalog struct {
+	roles       []RoleInfo
+	permissions []Permission
+}
+
+// activeCatalog holds the roles loaded from the database. It is nil until
+// the first load, in which case the built-in AllRoles and AllPermissions apply.
+var activeCatalog atomic.Pointer[roleCatalog]
+
+// ActiveRoles returns the roles in effect: the roles loaded by RoleService,
+// or AllRoles before they are loaded.
+//
+// The returned slice is shared and must not be modified.
+func ActiveRoles() []RoleInfo {
+	if catalog := activeCatalog.Load(); catalog != nil {
+		return catalog.roles
+	}
+	return AllRoles
+}
+
+// ActivePermissions returns the permissions in effect: the permissions loaded
+// by RoleService, or AllPermissions before they are loaded.
+//
+// The returned slice is shared and must not be modified.
+func ActivePermissions() []Permission {
+	if catalog := activeCatalog.Load(); catalog != nil {
+		return catalog.permissions
+	}
+	return AllPermissions
+}
+
+// SetActiveRoles replaces the roles and permissions used by permission checks.
+// It is called by RoleService after loading from the database.
+func SetActiveRoles(roles []RoleInfo, permissions []Permission) {
+	activeCatalog.Store(&roleCatalog{
+		roles:       roles,
+		permissions: permissions,
+	})
+}
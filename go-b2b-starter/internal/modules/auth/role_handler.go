@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// RoleAdminHandler manages roles at runtime. Roles are shared by every
+// organization, so it is an operator endpoint behind the "operator"
+// middleware rather than organization permissions.
+type RoleAdminHandler struct {
+	service RoleService
+}
+
+func NewRoleAdminHandler(service RoleService) *RoleAdminHandler {
+	return &RoleAdminHandler{
+		service: service,
+	}
+}
+
+// CreateRole godoc
+// @Summary Create a role
+// @Description Adds a custom role. The role ID must also be configured in the auth provider so it appears in tokens.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param request body CreateRoleRequest true "Role definition"
+// @Success 201 {object} RoleDTO "Created role"
+// @Failure 400 {object} map[string]string "Invalid role ID or unknown permission"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Failure 409 {object} map[string]string "Role already exists"
+// @Router /admin/rbac/roles [post]
+func (h *RoleAdminHandler) CreateRole(c *gin.Context) {
+	var req CreateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	role, err := h.service.CreateRole(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, NewRoleDTO(*role))
+}
+
+// UpdateRole godoc
+// @Summary Update a role
+// @Description Replaces a role's name, description and permissions. Built-in roles can be edited, but admin must keep org:manage.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param role_id path string true "Role ID"
+// @Param request body UpdateRoleRequest true "Role definition"
+// @Success 200 {object} RoleDTO "Updated role"
+// @Failure 400 {object} map[string]string "Unknown permission"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Role not found"
+// @Failure 409 {object} map[string]string "Admin would lose org:manage"
+// @Router /admin/rbac/roles/{role_id} [put]
+func (h *RoleAdminHandler) UpdateRole(c *gin.Context) {
+	var req UpdateRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	role, err := h.service.UpdateRole(c.Request.Context(), c.Param("role_id"), &req)
+	if err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, NewRoleDTO(*role))
+}
+
+// DeleteRole godoc
+// @Summary Delete a role
+// @Description Removes a custom role. Users holding it lose its permissions on their next request.
+// @Tags admin
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param role_id path string true "Role ID"
+// @Success 204 "Role deleted"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Role not found"
+// @Failure 409 {object} map[string]string "Built-in role"
+// @Router /admin/rbac/roles/{role_id} [delete]
+func (h *RoleAdminHandler) DeleteRole(c *gin.Context) {
+	if err := h.service.DeleteRole(c.Request.Context(), c.Param("role_id")); err != nil {
+		h.handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RoleAdminHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, ErrInvalidRoleID), errors.Is(err, ErrUnknownPermission):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, ErrRoleNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, ErrRoleExists), errors.Is(err, ErrSystemRole), errors.Is(err, ErrAdminRoleLockout):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	default:
+		response.Error(c, http.StatusInternalServerError, "failed to manage role", err)
+	}
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+const (
+	// Redis keys for the cached role catalog
+	rolesCacheKey       = "auth:rbac:roles"
+	permissionsCacheKey = "auth:rbac:permissions"
+
+	// DefaultRoleCacheTTL bounds how long other instances may serve stale roles
+	DefaultRoleCacheTTL = 5 * time.Minute
+)
+
+// RoleRepository stores roles and their permissions.
+//
+// Permissions themselves are defined in code (see AllPermissions) and seeded
+// by migrations; roles are created and edited at runtime.
+type RoleRepository interface {
+	// ListRoles returns all roles with their permissions, built-in roles first.
+	ListRoles(ctx context.Context) ([]RoleInfo, error)
+
+	// GetRole returns a role, or ErrRoleNotFound.
+	GetRole(ctx context.Context, roleID string) (*RoleInfo, error)
+
+	// CreateRole stores a new role, or returns ErrRoleExists.
+	CreateRole(ctx context.Context, role *RoleInfo) (*RoleInfo, error)
+
+	// UpdateRole replaces a role's name, description and permissions.
+	UpdateRole(ctx context.Context, role *RoleInfo) (*RoleInfo, error)
+
+	// DeleteRole removes a custom role. Returns ErrRoleNotFound, or
+	// ErrSystemRole for built-in roles.
+	DeleteRole(ctx context.Context, roleID string) error
+
+	// ListPermissions returns all known permissions.
+	ListPermissions(ctx context.Context) ([]Permission, error)
+}
+
+// cachedRoleRepository caches role and permission lists in Redis so instances
+// don't query the database on every refresh. Writes clear the cache.
+type cachedRoleRepository struct {
+	RoleRepository
+	redis redis.Client
+	ttl   time.Duration
+}
+
+// NewCachedRoleRepository wraps repo with a Redis cache for ListRoles and
+// ListPermissions. Pass 0 to use DefaultRoleCacheTTL.
+func NewCachedRoleRepository(repo RoleRepository, redisClient redis.Client, ttl time.Duration) RoleRepository {
+	if ttl <= 0 {
+		ttl = DefaultRoleCacheTTL
+	}
+	return &cachedRoleRepository{
+		RoleRepository: repo,
+		redis:          redisClient,
+		ttl:            ttl,
+	}
+}
+
+func (r *cachedRoleRepository) ListRoles(ctx context.Context) ([]RoleInfo, error) {
+	var roles []RoleInfo
+	if r.readCache(ctx, rolesCacheKey, &roles) {
+		return roles, nil
+	}
+
+	roles, err := r.RoleRepository.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.writeCache(ctx, rolesCacheKey, roles)
+	return roles, nil
+}
+
+func (r *cachedRoleRepository) ListPermissions(ctx context.Context) ([]Permission, error) {
+	var permissions []Permission
+	if r.readCache(ctx, permissionsCacheKey, &permissions) {
+		return permissions, nil
+	}
+
+	permissions, err := r.RoleRepository.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	r.writeCache(ctx, permissionsCacheKey, permissions)
+	return permissions, nil
+}
+
+func (r *cachedRoleRepository) CreateRole(ctx context.Context, role *RoleInfo) (*RoleInfo, error) {
+	created, err := r.RoleRepository.CreateRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx)
+	return created, nil
+}
+
+func (r *cachedRoleRepository) UpdateRole(ctx context.Context, role *RoleInfo) (*RoleInfo, error) {
+	updated, err := r.RoleRepository.UpdateRole(ctx, role)
+	if err != nil {
+		return nil, err
+	}
+	r.invalidate(ctx)
+	return updated, nil
+}
+
+func (r *cachedRoleRepository) DeleteRole(ctx context.Context, roleID string) error {
+	if err := r.RoleRepository.DeleteRole(ctx, roleID); err != nil {
+		return err
+	}
+	r.invalidate(ctx)
+	return nil
+}
+
+// readCache decodes a cached value. Redis failures count as a miss so the
+// database stays the fallback.
+func (r *cachedRoleRepository) readCache(ctx context.Context, key string, target any) bool {
+	cached, err := r.redis.Get(ctx, key)
+	if err != nil || cached == "" {
+		return false
+	}
+	return json.Unmarshal([]byte(cached), target) == nil
+}
+
+func (r *cachedRoleRepository) writeCache(ctx context.Context, key string, value any) {
+	if data, err := json.Marshal(value); err == nil {
+		_ = r.redis.Set(ctx, key, string(data), r.ttl)
+	}
+}
+
+func (r *cachedRoleRepository) invalidate(ctx context.Context) {
+	_ = r.redis.Delete(ctx, rolesCacheKey)
+	_ = r.redis.Delete(ctx, permissionsCacheKey)
+}
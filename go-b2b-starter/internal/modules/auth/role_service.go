@@ -0,0 +1,284 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+)
+
+// roleIDPattern restricts role IDs to values that are safe in tokens and URLs.
+var roleIDPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+
+// RoleConfig controls the database-backed role catalog.
+type RoleConfig struct {
+	// CacheTTL is how long role lists stay cached in Redis
+	CacheTTL time.Duration `mapstructure:"RBAC_CACHE_TTL"`
+
+	// RefreshInterval is how often each instance reloads roles; 0 disables
+	// periodic reloads
+	RefreshInterval time.Duration `mapstructure:"RBAC_REFRESH_INTERVAL"`
+}
+
+// LoadRoleConfig loads the role catalog configuration from environment variables and app.env file.
+func LoadRoleConfig() (*RoleConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("RBAC_CACHE_TTL", DefaultRoleCacheTTL.String())
+	v.SetDefault("RBAC_REFRESH_INTERVAL", "30s")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg RoleConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode rbac config: %w", err)
+	}
+
+	if cfg.CacheTTL <= 0 {
+		return nil, fmt.Errorf("rbac config invalid: RBAC_CACHE_TTL must be positive")
+	}
+	if cfg.RefreshInterval < 0 {
+		return nil, fmt.Errorf("rbac config invalid: RBAC_REFRESH_INTERVAL must not be negative")
+	}
+
+	return &cfg, nil
+}
+
+// CreateRoleRequest defines a custom role.
+type CreateRoleRequest struct {
+	ID          string   `json:"id" binding:"required"`
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateRoleRequest replaces a role's display fields and permissions.
+type UpdateRoleRequest struct {
+	Name        string   `json:"name" binding:"required"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// RoleService serves RBAC lookups from the database-backed role catalog and
+// lets operators manage roles at runtime.
+//
+// Lookups read the in-memory catalog (see ActiveRoles), which is reloaded
+// after every change and every RBAC_REFRESH_INTERVAL so other instances pick
+// up changes made elsewhere.
+type RoleService interface {
+	RBACService
+
+	// CreateRole adds a custom role.
+	CreateRole(ctx context.Context, req *CreateRoleRequest) (*RoleInfo, error)
+
+	// UpdateRole changes a role's name, description and permissions.
+	// Built-in roles can be edited, but admin must keep org:manage.
+	UpdateRole(ctx context.Context, roleID string, req *UpdateRoleRequest) (*RoleInfo, error)
+
+	// DeleteRole removes a custom role. Built-in roles cannot be deleted.
+	DeleteRole(ctx context.Context, roleID string) error
+
+	// Refresh reloads the catalog from the repository.
+	Refresh(ctx context.Context) error
+
+	// Stop ends the periodic refresh.
+	Stop()
+}
+
+type roleService struct {
+	defaultRBACService
+	repo   RoleRepository
+	config *RoleConfig
+	logger logger.Logger
+
+	ticker   *time.Ticker
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewRoleService loads the role catalog and starts refreshing it periodically.
+//
+// When the catalog cannot be loaded, the built-in roles stay in effect and
+// loading is retried on the next refresh.
+func NewRoleService(repo RoleRepository, cfg *RoleConfig, log logger.Logger) RoleService {
+	s := &roleService{
+		repo:   repo,
+		config: cfg,
+		logger: log,
+		done:   make(chan struct{}),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := s.Refresh(ctx); err != nil {
+		s.logger.Warn("failed to load roles, using built-in roles", logger.Fields{"error": err.Error()})
+	}
+
+	if cfg.RefreshInterval > 0 {
+		s.ticker = time.NewTicker(cfg.RefreshInterval)
+		go s.periodicRefresh()
+	}
+
+	return s
+}
+
+// Stop should be called when the server is shutting down
+func (s *roleService) Stop() {
+	s.stopOnce.Do(func() {
+		if s.ticker != nil {
+			s.ticker.Stop()
+		}
+		close(s.done)
+	})
+}
+
+// periodicRefresh reloads the catalog so changes made on other instances apply here
+func (s *roleService) periodicRefresh() {
+	for {
+		select {
+		case <-s.ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := s.Refresh(ctx); err != nil {
+				s.logger.Warn("failed to refresh roles", logger.Fields{"error": err.Error()})
+			}
+			cancel()
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *roleService) Refresh(ctx context.Context) error {
+	roles, err := s.repo.ListRoles(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list roles: %w", err)
+	}
+	permissions, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list permissions: %w", err)
+	}
+	if len(roles) == 0 {
+		return fmt.Errorf("no roles defined; run the rbac migration")
+	}
+
+	SetActiveRoles(roles, permissions)
+	return nil
+}
+
+func (s *roleService) CreateRole(ctx context.Context, req *CreateRoleRequest) (*RoleInfo, error) {
+	roleID := strings.TrimSpace(req.ID)
+	if !roleIDPattern.MatchString(roleID) || string(NormalizeRole(roleID)) != roleID {
+		return nil, ErrInvalidRoleID
+	}
+
+	permissions, err := s.validatePermissions(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role, err := s.repo.CreateRole(ctx, &RoleInfo{
+		ID:          roleID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.afterChange(ctx, "role created", role.ID, role.Permissions)
+	return role, nil
+}
+
+func (s *roleService) UpdateRole(ctx context.Context, roleID string, req *UpdateRoleRequest) (*RoleInfo, error) {
+	permissions, err := s.validatePermissions(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if roleID == string(RoleAdmin) && !containsPermission(permissions, PermOrgManage) {
+		return nil, ErrAdminRoleLockout
+	}
+
+	role, err := s.repo.UpdateRole(ctx, &RoleInfo{
+		ID:          roleID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Permissions: permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.afterChange(ctx, "role updated", role.ID, role.Permissions)
+	return role, nil
+}
+
+func (s *roleService) DeleteRole(ctx context.Context, roleID string) error {
+	if err := s.repo.DeleteRole(ctx, roleID); err != nil {
+		return err
+	}
+
+	s.afterChange(ctx, "role deleted", roleID, nil)
+	return nil
+}
+
+// validatePermissions checks every permission exists and removes duplicates.
+func (s *roleService) validatePermissions(ctx context.Context, requested []string) ([]Permission, error) {
+	known, err := s.repo.ListPermissions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	permissions := make([]Permission, 0, len(requested))
+	for _, id := range requested {
+		permission := Permission(strings.TrimSpace(id))
+		if !containsPermission(known, permission) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, id)
+		}
+		if !containsPermission(permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions, nil
+}
+
+// afterChange records the change and reloads the catalog so it applies immediately.
+func (s *roleService) afterChange(ctx context.Context, event, roleID string, permissions []Permission) {
+	s.logger.Warn(event, logger.Fields{
+		"role_id":     roleID,
+		"permissions": permissions,
+	})
+
+	if err := s.Refresh(ctx); err != nil {
+		s.logger.Warn("failed to reload roles after change", logger.Fields{
+			"role_id": roleID,
+			"error":   err.Error(),
+		})
+	}
+}
+
+func containsPermission(permissions []Permission, target Permission) bool {
+	for _, p := range permissions {
+		if p == target {
+			return true
+		}
+	}
+	return false
+}
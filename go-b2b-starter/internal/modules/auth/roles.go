@@ -11,16 +11,16 @@ package auth
 //
 // # Adding New Roles
 //
-// To add a new role:
-//  1. Add the role constant below
-//  2. Add role info in rbac.go (AllRoles)
-//  3. Configure the role in your auth provider (e.g., Stytch dashboard)
+// Custom roles are created at runtime through the role admin API (see
+// RoleService) and stored in the rbac.roles table; no code change is needed.
+// Configure the same role ID in your auth provider (e.g., Stytch dashboard)
+// so it appears in tokens.
 //
 // # Role Source of Truth
 //
-// The auth provider (e.g., Stytch) is the source of truth for roles at runtime.
-// The definitions in rbac.go are used as a fallback when the auth provider
-// doesn't provide explicit permissions.
+// The auth provider (e.g., Stytch) decides which roles a user has. The
+// permissions of each role come from the database, falling back to the
+// built-in definitions in rbac.go until roles are loaded.
 type Role string
 
 // Core RBAC roles.
@@ -109,17 +109,14 @@ func NormalizeRole(roleStr string) Role {
 	return role
 }
 
-// GetRolePermissions returns the default permissions for a role.
+// GetRolePermissions returns the permissions of a role from the active roles.
 //
 // Returns nil if the role is not recognized.
-// This is used as a fallback when the auth provider doesn't provide permissions.
-//
-// Permissions are defined in rbac.go which is the single source of truth.
+// This is used when the auth provider doesn't provide permissions.
 func GetRolePermissions(role Role) []Permission {
 	// Normalize the role to handle legacy names
 	normalized := NormalizeRole(string(role))
 
-	// Get role info from rbac.go (single source of truth)
 	roleInfo := GetRoleInfo(string(normalized))
 	if roleInfo == nil {
 		return nil
@@ -130,7 +127,7 @@ func GetRolePermissions(role Role) []Permission {
 
 // HasRolePermission checks if a role has a specific permission.
 //
-// This uses the role definitions from rbac.go and is used as a fallback
+// This uses the active role definitions and is used as a fallback
 // when the auth provider doesn't include explicit permissions.
 func HasRolePermission(role Role, resource, action string) bool {
 	perms := GetRolePermissions(role)
@@ -6,14 +6,24 @@ import (
 	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
 )
 
-// Routes handles RBAC API routes registration
+// Routes handles RBAC, role admin, lockout, logout, session, audit log and OIDC logout API routes registration
 type Routes struct {
-	handler *Handler
+	handler          *Handler
+	roleAdminHandler *RoleAdminHandler
+	lockoutHandler   *LockoutHandler
+	logoutHandler    *LogoutHandler
+	sessionHandler   *SessionHandler
+	auditLogHandler  *AuditLogHandler
 }
 
-func NewRoutes(handler *Handler) *Routes {
+func NewRoutes(handler *Handler, roleAdminHandler *RoleAdminHandler, lockoutHandler *LockoutHandler, logoutHandler *LogoutHandler, sessionHandler *SessionHandler, auditLogHandler *AuditLogHandler) *Routes {
 	return &Routes{
-		handler: handler,
+		handler:          handler,
+		roleAdminHandler: roleAdminHandler,
+		lockoutHandler:   lockoutHandler,
+		logoutHandler:    logoutHandler,
+		sessionHandler:   sessionHandler,
+		auditLogHandler:  auditLogHandler,
 	}
 }
 
@@ -54,6 +64,79 @@ func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.M
 		rbacGroup.GET("/metadata",
 			r.handler.GetMetadata)
 	}
+
+	// Role admin endpoints - operator access with ADMIN_API_TOKEN, hidden when unset
+	rolesAdminGroup := router.Group("/admin/rbac/roles")
+	rolesAdminGroup.Use(resolver.Get("operator"))
+	{
+		// POST /api/admin/rbac/roles
+		rolesAdminGroup.POST("",
+			r.roleAdminHandler.CreateRole)
+
+		// PUT /api/admin/rbac/roles/{role_id}
+		rolesAdminGroup.PUT("/:role_id",
+			r.roleAdminHandler.UpdateRole)
+
+		// DELETE /api/admin/rbac/roles/{role_id}
+		rolesAdminGroup.DELETE("/:role_id",
+			r.roleAdminHandler.DeleteRole)
+	}
+
+	// Lockout admin endpoints - operator access with ADMIN_API_TOKEN, hidden when unset
+	lockoutsAdminGroup := router.Group("/admin/auth/lockouts")
+	lockoutsAdminGroup.Use(resolver.Get("operator"))
+	{
+		// POST /api/admin/auth/lockouts/unlock
+		lockoutsAdminGroup.POST("/unlock",
+			r.lockoutHandler.AdminUnlock)
+	}
+
+	// Unlock link - posted by the page an unlock email links to
+	// POST /api/auth/lockouts/unlock
+	router.POST("/auth/lockouts/unlock",
+		resolver.Get("login_rate_limit"),
+		r.lockoutHandler.Unlock)
+
+	// User logout - revokes the caller's access token and session
+	// POST /api/auth/logout[?all=true]
+	router.POST("/auth/logout",
+		resolver.Get("auth"),
+		r.logoutHandler.Logout)
+
+	// Device sessions - users review and sign out their own devices
+	sessionsGroup := router.Group("/auth/sessions")
+	sessionsGroup.Use(resolver.Get("auth"))
+	{
+		// GET /api/auth/sessions
+		sessionsGroup.GET("",
+			r.sessionHandler.ListSessions)
+
+		// DELETE /api/auth/sessions/{session_id}
+		sessionsGroup.DELETE("/:session_id",
+			r.sessionHandler.RevokeSession)
+	}
+
+	// Auth audit log - organization admins review sign-in activity
+	// GET /api/auth/audit-log?user_id=&event_type=&from=&to=[&cursor=]
+	router.GET("/auth/audit-log",
+		resolver.Get("auth"),
+		resolver.Get("org_context"),
+		resolver.Get("perm:org:manage"),
+		r.auditLogHandler.ListEvents)
+
+	// OIDC logout endpoints - called by the identity provider, NOT by authenticated users
+	oidcGroup := router.Group("/auth/oidc")
+	{
+		// Back-channel logout: server-to-server logout token delivery
+		// POST /api/auth/oidc/backchannel-logout
+		oidcGroup.POST("/backchannel-logout",
+			r.logoutHandler.BackChannelLogout)
+
+		// Front-channel logout: loaded by the provider in the user's browser
+		// GET /api/auth/oidc/frontchannel-logout
+		oidcGroup.GET("/frontchannel-logout",
+			r.logoutHandler.FrontChannelLogout)
+	}
 }
 
 // Routes satisfies the RouteRegistrar interface
@@ -0,0 +1,29 @@
+package auth
+
+import "context"
+
+// ServiceAccountKeyPrefix starts every service account API key, so keys are
+// told apart from JWTs without a lookup.
+const ServiceAccountKeyPrefix = "sak_"
+
+// ServiceAccountKeyVerifier validates API keys of service accounts.
+//
+// Service accounts are non-human users owned by an organization. They have
+// no password and no auth provider member, and send an API key as the bearer
+// token instead. The verified Identity has ServiceAccountID set, the service
+// account's email, and its role with the role's permissions, so
+// RequireOrganization resolves it like a member.
+type ServiceAccountKeyVerifier interface {
+	// IsServiceAccountKey reports whether the token is a service account API key.
+	IsServiceAccountKey(token string) bool
+
+	// VerifyServiceAccountKey validates an API key.
+	// Returns ErrInvalidToken or ErrTokenExpired on failure.
+	VerifyServiceAccountKey(ctx context.Context, key string) (*Identity, error)
+}
+
+// IsMachine reports whether the identity is an OAuth client or a service
+// account rather than a person.
+func (i *Identity) IsMachine() bool {
+	return i.ClientID != "" || i.ServiceAccountID != ""
+}
@@ -0,0 +1,85 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// SessionHandler lets users list and revoke their active sessions.
+type SessionHandler struct {
+	service SessionService
+}
+
+func NewSessionHandler(service SessionService) *SessionHandler {
+	return &SessionHandler{
+		service: service,
+	}
+}
+
+// ListSessions godoc
+// @Summary List active sessions
+// @Description Lists the caller's active sessions (one per signed-in device) and marks the one used for this request.
+// @Tags Auth
+// @Produce json
+// @Success 200 {array} Session "Active sessions"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 501 {object} map[string]string "Not supported by the auth provider"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/sessions [get]
+func (h *SessionHandler) ListSessions(c *gin.Context) {
+	identity := GetIdentity(c)
+	if identity == nil {
+		response.Error(c, http.StatusUnauthorized, "authentication required", nil)
+		return
+	}
+
+	sessions, err := h.service.ListSessions(c.Request.Context(), identity)
+	if err != nil {
+		if errors.Is(err, ErrSessionListingUnsupported) {
+			response.Error(c, http.StatusNotImplemented, err.Error(), err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to list sessions", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, sessions)
+}
+
+// RevokeSession godoc
+// @Summary Revoke a session
+// @Description Signs out one of the caller's devices. Its access tokens are rejected immediately and it can no longer be refreshed.
+// @Tags Auth
+// @Produce json
+// @Param session_id path string true "Session ID"
+// @Success 204 "Session revoked"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Session not found"
+// @Failure 501 {object} map[string]string "Not supported by the auth provider"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/sessions/{session_id} [delete]
+func (h *SessionHandler) RevokeSession(c *gin.Context) {
+	identity := GetIdentity(c)
+	if identity == nil {
+		response.Error(c, http.StatusUnauthorized, "authentication required", nil)
+		return
+	}
+
+	if err := h.service.RevokeSession(c.Request.Context(), identity, c.Param("session_id")); err != nil {
+		switch {
+		case errors.Is(err, ErrSessionNotFound):
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case errors.Is(err, ErrSessionListingUnsupported):
+			response.Error(c, http.StatusNotImplemented, err.Error(), err)
+		default:
+			response.Error(c, http.StatusInternalServerError, "failed to revoke session", err)
+		}
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
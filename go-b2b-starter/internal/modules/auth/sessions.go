@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Session is an active provider session of a user, one per signed-in device.
+type Session struct {
+	// ID is the provider session ID (the "sid" of tokens issued for it).
+	ID string `json:"id"`
+
+	// StartedAt is when the user signed in.
+	StartedAt time.Time `json:"started_at"`
+
+	// LastAccessedAt is when the session was last used.
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+
+	// ExpiresAt is when the session ends unless it is refreshed.
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// AuthenticationMethods lists how the user signed in (e.g., "email", "sso").
+	AuthenticationMethods []string `json:"authentication_methods,omitempty"`
+
+	// Current is true for the session the request was made with.
+	Current bool `json:"current"`
+}
+
+// SessionLister lists the active sessions of a user at the auth provider.
+//
+// Implemented by auth provider adapters alongside SessionRevoker.
+type SessionLister interface {
+	// ListUserSessions returns the active sessions of a provider user in an organization.
+	// Returns ErrSessionListingUnsupported if the provider cannot list sessions.
+	ListUserSessions(ctx context.Context, organizationID, userID string) ([]Session, error)
+}
+
+// SessionService lets users review and sign out their own devices.
+type SessionService interface {
+	// ListSessions returns the caller's active sessions, marking the current one.
+	ListSessions(ctx context.Context, identity *Identity) ([]Session, error)
+
+	// RevokeSession signs out one of the caller's sessions.
+	// Returns ErrSessionNotFound if the session does not belong to the caller.
+	RevokeSession(ctx context.Context, identity *Identity, sessionID string) error
+}
+
+type sessionService struct {
+	lister   SessionLister
+	revoker  SessionRevoker
+	denylist SessionDenylist
+}
+
+func NewSessionService(lister SessionLister, revoker SessionRevoker, denylist SessionDenylist) SessionService {
+	return &sessionService{
+		lister:   lister,
+		revoker:  revoker,
+		denylist: denylist,
+	}
+}
+
+func (s *sessionService) ListSessions(ctx context.Context, identity *Identity) ([]Session, error) {
+	sessions, err := s.lister.ListUserSessions(ctx, identity.OrganizationID, identity.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = identity.SessionID != "" && sessions[i].ID == identity.SessionID
+	}
+	return sessions, nil
+}
+
+func (s *sessionService) RevokeSession(ctx context.Context, identity *Identity, sessionID string) error {
+	sessions, err := s.lister.ListUserSessions(ctx, identity.OrganizationID, identity.UserID)
+	if err != nil {
+		return err
+	}
+
+	// Only the caller's own sessions may be revoked
+	owned := false
+	for _, session := range sessions {
+		if session.ID == sessionID {
+			owned = true
+			break
+		}
+	}
+	if !owned {
+		return ErrSessionNotFound
+	}
+
+	if err := s.denylist.RevokeSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to denylist session: %w", err)
+	}
+	if err := s.revoker.RevokeSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to revoke session: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+)
+
+// legacyClaimWarningInterval bounds how often the same legacy value is logged
+const legacyClaimWarningInterval = 15 * time.Minute
+
+// legacyTokenClaims counts tokens accepted only because of a legacy issuer or
+// audience. Once it stays at zero the legacy values can be removed.
+var legacyTokenClaims = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "auth_legacy_token_claims_total",
+	Help: "Tokens accepted with a deprecated issuer or audience.",
+}, []string{"token_type", "claim", "value"})
+
+// TokenClaimsConfig names the issuer and audience of tokens signed by this
+// application (guest, impersonation and client tokens).
+//
+// To rename the service without logging everyone out, set the new values and
+// move the old ones to the legacy lists. New tokens carry the new values,
+// tokens issued before the rename keep verifying until they expire, and each
+// use of a legacy value is logged and counted.
+//
+// Client tokens can also be issued for downstream services listed in
+// ServiceAudiences. A service running this starter sets JWT_AUDIENCE to its
+// own name and only accepts tokens that carry it. All values can be set via
+// environment variables with the JWT_ prefix.
+type TokenClaimsConfig struct {
+	// Issuer is the iss claim of new tokens
+	Issuer string `mapstructure:"JWT_ISSUER"`
+
+	// Audience is the aud claim of new tokens
+	Audience string `mapstructure:"JWT_AUDIENCE"`
+
+	// LegacyIssuers lists comma-separated issuers still accepted during a migration
+	LegacyIssuers string `mapstructure:"JWT_LEGACY_ISSUERS"`
+
+	// LegacyAudiences lists comma-separated audiences still accepted during a migration
+	LegacyAudiences string `mapstructure:"JWT_LEGACY_AUDIENCES"`
+
+	// ServiceAudiences lists comma-separated downstream services tokens may be issued for
+	ServiceAudiences string `mapstructure:"JWT_SERVICE_AUDIENCES"`
+
+	// LegacyIssuerList is the parsed form of LegacyIssuers
+	LegacyIssuerList []string `mapstructure:"-"`
+
+	// LegacyAudienceList is the parsed form of LegacyAudiences
+	LegacyAudienceList []string `mapstructure:"-"`
+
+	// ServiceAudienceList is the parsed form of ServiceAudiences
+	ServiceAudienceList []string `mapstructure:"-"`
+}
+
+// LoadTokenClaimsConfig loads the token claims configuration from environment variables and app.env file.
+func LoadTokenClaimsConfig() (*TokenClaimsConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("JWT_ISSUER", "go-b2b-starter")
+	v.SetDefault("JWT_AUDIENCE", "go-b2b-starter-api")
+	v.SetDefault("JWT_LEGACY_ISSUERS", "")
+	v.SetDefault("JWT_LEGACY_AUDIENCES", "")
+	v.SetDefault("JWT_SERVICE_AUDIENCES", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg TokenClaimsConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode token claims config: %w", err)
+	}
+
+	cfg.Issuer = strings.TrimSpace(cfg.Issuer)
+	cfg.Audience = strings.TrimSpace(cfg.Audience)
+	cfg.LegacyIssuerList = splitClaimList(cfg.LegacyIssuers)
+	cfg.LegacyAudienceList = splitClaimList(cfg.LegacyAudiences)
+	cfg.ServiceAudienceList = splitClaimList(cfg.ServiceAudiences)
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that new tokens have an issuer and audience that are not also
+// listed as legacy, and that service audiences are distinct from both.
+func (c *TokenClaimsConfig) Validate() error {
+	if c.Issuer == "" || c.Audience == "" {
+		return fmt.Errorf("token claims config invalid: JWT_ISSUER and JWT_AUDIENCE are required")
+	}
+	if slices.Contains(c.LegacyIssuerList, c.Issuer) {
+		return fmt.Errorf("token claims config invalid: JWT_LEGACY_ISSUERS must not contain JWT_ISSUER")
+	}
+	if slices.Contains(c.LegacyAudienceList, c.Audience) {
+		return fmt.Errorf("token claims config invalid: JWT_LEGACY_AUDIENCES must not contain JWT_AUDIENCE")
+	}
+	for _, aud := range c.ServiceAudienceList {
+		if aud == c.Audience || slices.Contains(c.LegacyAudienceList, aud) {
+			return fmt.Errorf("token claims config invalid: JWT_SERVICE_AUDIENCES must not contain JWT_AUDIENCE or a legacy audience")
+		}
+	}
+	return nil
+}
+
+// TokenClaimsValidator issues and checks the issuer and audience of tokens
+// signed by this application.
+type TokenClaimsValidator interface {
+	// Issuer returns the issuer to put on new tokens.
+	Issuer() string
+
+	// Audience returns the audience to put on new tokens.
+	Audience() string
+
+	// Audiences returns the aud claim for a new token targeted at the requested
+	// audiences, or this application's audience if none are requested. Each
+	// must be this application's audience or a configured service audience.
+	// Returns ErrAudienceNotAllowed otherwise.
+	Audiences(requested []string) ([]string, error)
+
+	// Validate checks the issuer and audience of a verified token of the given
+	// type. Legacy values are accepted with a deprecation warning.
+	// Returns ErrIssuerMismatch or ErrAudienceMismatch on failure.
+	Validate(tokenType, issuer string, audience []string) error
+
+	// ValidateIssued is like Validate but also accepts tokens issued only for
+	// a service audience. It is for endpoints that vouch for tokens on behalf
+	// of downstream services, such as token introspection.
+	ValidateIssued(tokenType, issuer string, audience []string) error
+}
+
+type tokenClaimsValidator struct {
+	cfg    *TokenClaimsConfig
+	logger logger.Logger
+
+	// warned holds the last warning time per legacy claim value
+	warned sync.Map
+}
+
+// NewTokenClaimsValidator creates a TokenClaimsValidator for the configuration.
+func NewTokenClaimsValidator(cfg *TokenClaimsConfig, log logger.Logger) TokenClaimsValidator {
+	return &tokenClaimsValidator{
+		cfg:    cfg,
+		logger: log,
+	}
+}
+
+func (v *tokenClaimsValidator) Issuer() string {
+	return v.cfg.Issuer
+}
+
+func (v *tokenClaimsValidator) Audience() string {
+	return v.cfg.Audience
+}
+
+func (v *tokenClaimsValidator) Audiences(requested []string) ([]string, error) {
+	if len(requested) == 0 {
+		return []string{v.cfg.Audience}, nil
+	}
+
+	audiences := make([]string, 0, len(requested))
+	for _, aud := range requested {
+		if aud != v.cfg.Audience && !slices.Contains(v.cfg.ServiceAudienceList, aud) {
+			return nil, ErrAudienceNotAllowed
+		}
+		if !slices.Contains(audiences, aud) {
+			audiences = append(audiences, aud)
+		}
+	}
+	return audiences, nil
+}
+
+func (v *tokenClaimsValidator) Validate(tokenType, issuer string, audience []string) error {
+	if err := v.validateIssuer(tokenType, issuer); err != nil {
+		return err
+	}
+	return v.validateAudience(tokenType, audience)
+}
+
+func (v *tokenClaimsValidator) ValidateIssued(tokenType, issuer string, audience []string) error {
+	if err := v.validateIssuer(tokenType, issuer); err != nil {
+		return err
+	}
+	for _, aud := range audience {
+		if slices.Contains(v.cfg.ServiceAudienceList, aud) {
+			return nil
+		}
+	}
+	return v.validateAudience(tokenType, audience)
+}
+
+func (v *tokenClaimsValidator) validateIssuer(tokenType, issuer string) error {
+	switch {
+	case issuer == v.cfg.Issuer:
+	case slices.Contains(v.cfg.LegacyIssuerList, issuer):
+		v.deprecated(tokenType, "iss", issuer)
+	default:
+		return ErrIssuerMismatch
+	}
+	return nil
+}
+
+func (v *tokenClaimsValidator) validateAudience(tokenType string, audience []string) error {
+	if slices.Contains(audience, v.cfg.Audience) {
+		return nil
+	}
+	for _, aud := range audience {
+		if slices.Contains(v.cfg.LegacyAudienceList, aud) {
+			v.deprecated(tokenType, "aud", aud)
+			return nil
+		}
+	}
+	return ErrAudienceMismatch
+}
+
+// deprecated records a token accepted with a legacy claim value.
+func (v *tokenClaimsValidator) deprecated(tokenType, claim, value string) {
+	legacyTokenClaims.WithLabelValues(tokenType, claim, value).Inc()
+
+	key := tokenType + ":" + claim + ":" + value
+	now := time.Now()
+	if last, ok := v.warned.Load(key); ok && now.Sub(last.(time.Time)) < legacyClaimWarningInterval {
+		return
+	}
+	v.warned.Store(key, now)
+
+	v.logger.Warn("token accepted with deprecated claim; it stops verifying once removed from the legacy list", logger.Fields{
+		"token_type": tokenType,
+		"claim":      claim,
+		"value":      value,
+		"current":    v.current(claim),
+	})
+}
+
+func (v *tokenClaimsValidator) current(claim string) string {
+	if claim == "iss" {
+		return v.cfg.Issuer
+	}
+	return v.cfg.Audience
+}
+
+// splitClaimList parses a comma-separated list, dropping empty entries.
+func splitClaimList(value string) []string {
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/pkg/money"
+)
+
+// CatalogConfig configures the plan catalog and price display.
+//
+// All values can be set via environment variables with the BILLING_ prefix.
+type CatalogConfig struct {
+	// DefaultCurrency is used when no requested currency is offered
+	DefaultCurrency string `mapstructure:"BILLING_DEFAULT_CURRENCY"`
+
+	// DefaultLocale formats amounts when neither the organization nor the request specify one
+	DefaultLocale string `mapstructure:"BILLING_DEFAULT_LOCALE"`
+
+	// PlanCacheTTL is how long plans fetched from the billing provider are cached
+	PlanCacheTTL time.Duration `mapstructure:"BILLING_PLAN_CACHE_TTL"`
+}
+
+// LoadCatalogConfig loads the catalog configuration from environment variables and app.env file.
+func LoadCatalogConfig() (*CatalogConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("BILLING_DEFAULT_CURRENCY", "USD")
+	v.SetDefault("BILLING_DEFAULT_LOCALE", "en-US")
+	v.SetDefault("BILLING_PLAN_CACHE_TTL", "15m")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg CatalogConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode billing catalog config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks and normalizes the default currency and locale.
+func (c *CatalogConfig) Validate() error {
+	currency, err := money.NormalizeCurrency(c.DefaultCurrency)
+	if err != nil {
+		return fmt.Errorf("billing catalog config invalid: BILLING_DEFAULT_CURRENCY: %w", err)
+	}
+	locale, err := money.NormalizeLocale(c.DefaultLocale)
+	if err != nil {
+		return fmt.Errorf("billing catalog config invalid: BILLING_DEFAULT_LOCALE: %w", err)
+	}
+	if c.PlanCacheTTL <= 0 {
+		return fmt.Errorf("billing catalog config invalid: BILLING_PLAN_CACHE_TTL must be positive")
+	}
+
+	c.DefaultCurrency = currency
+	c.DefaultLocale = locale
+	return nil
+}
@@ -12,8 +12,14 @@ import (
 // This method does NOT consume quota - it only checks if processing is allowed
 // Use ConsumeInvoiceQuota after successful invoice processing to actually decrement the quota
 func (s *billingService) CheckQuotaAvailability(ctx context.Context, organizationID int32) (*domain.BillingStatus, error) {
+	// Staging organizations are covered by their production parent's quota
+	billingOrgID, _, err := s.orgAdapter.GetBillingOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve billing organization: %w", err)
+	}
+
 	// Step 1: Check database quota status (read-only)
-	quotaStatus, err := s.repo.GetQuotaStatus(ctx, organizationID)
+	quotaStatus, err := s.repo.GetQuotaStatus(ctx, billingOrgID)
 	if err != nil {
 		return &domain.BillingStatus{
 			OrganizationID:        organizationID,
@@ -28,19 +34,19 @@ func (s *billingService) CheckQuotaAvailability(ctx context.Context, organizatio
 	needsFallback := s.needsFallbackVerification(quotaStatus)
 	if needsFallback {
 		s.logger.Info("Quota near limit or stale, performing fallback API verification", map[string]any{
-			"organization_id": organizationID,
+			"organization_id": billingOrgID,
 			"invoice_count":   quotaStatus.InvoiceCount,
 		})
 
 		// Sync from Polar and re-check
-		if err := s.SyncSubscriptionFromPolar(ctx, organizationID); err != nil {
+		if err := s.SyncSubscriptionFromPolar(ctx, billingOrgID); err != nil {
 			s.logger.Error("Fallback sync failed, using database data", map[string]any{
-				"organization_id": organizationID,
+				"organization_id": billingOrgID,
 				"error":           err.Error(),
 			})
 		} else {
 			// Re-fetch quota status after sync
-			quotaStatus, err = s.repo.GetQuotaStatus(ctx, organizationID)
+			quotaStatus, err = s.repo.GetQuotaStatus(ctx, billingOrgID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get quota after sync: %w", err)
 			}
@@ -51,7 +57,7 @@ func (s *billingService) CheckQuotaAvailability(ctx context.Context, organizatio
 	if !quotaStatus.CanProcessInvoice {
 		return &domain.BillingStatus{
 			OrganizationID:        organizationID,
-			HasActiveSubscription: quotaStatus.SubscriptionStatus == "active",
+			HasActiveSubscription: quotaStatus.IsActive(),
 			CanProcessInvoices:    false,
 			InvoiceCount:          quotaStatus.InvoiceCount,
 			Reason:                "quota exceeded or subscription inactive",
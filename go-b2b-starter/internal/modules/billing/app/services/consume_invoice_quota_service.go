@@ -12,6 +12,21 @@ import (
 // This should be called after the invoice has been successfully processed
 // Can be safely called in a background goroutine for better performance
 func (s *billingService) ConsumeInvoiceQuota(ctx context.Context, organizationID int32) (*domain.BillingStatus, error) {
+	// Staging organizations neither consume the parent's quota nor report
+	// usage to Polar
+	_, staging, err := s.orgAdapter.GetBillingOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve billing organization: %w", err)
+	}
+	if staging {
+		status, err := s.GetBillingStatus(ctx, organizationID)
+		if err != nil {
+			return nil, err
+		}
+		status.Reason = "staging organization: quota not consumed"
+		return status, nil
+	}
+
 	s.logger.Info("Consuming invoice quota for organization", map[string]any{
 		"organization_id": organizationID,
 	})
@@ -51,7 +66,7 @@ func (s *billingService) ConsumeInvoiceQuota(ctx context.Context, organizationID
 	// Step 4: Return updated billing status
 	return &domain.BillingStatus{
 		OrganizationID:        organizationID,
-		HasActiveSubscription: quotaStatus.SubscriptionStatus == "active",
+		HasActiveSubscription: quotaStatus.IsActive(),
 		CanProcessInvoices:    updatedQuota.InvoiceCount > 0,
 		InvoiceCount:          updatedQuota.InvoiceCount,
 		Reason:                "quota consumed successfully",
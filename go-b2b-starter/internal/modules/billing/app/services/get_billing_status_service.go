@@ -9,8 +9,14 @@ import (
 )
 
 func (s *billingService) GetBillingStatus(ctx context.Context, organizationID int32) (*domain.BillingStatus, error) {
+	// Staging organizations report their production parent's subscription
+	billingOrgID, _, err := s.orgAdapter.GetBillingOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve billing organization: %w", err)
+	}
+
 	// Get quota status from database
-	quotaStatus, err := s.repo.GetQuotaStatus(ctx, organizationID)
+	quotaStatus, err := s.repo.GetQuotaStatus(ctx, billingOrgID)
 	if err != nil {
 		// No subscription found
 		return &domain.BillingStatus{
@@ -24,19 +30,27 @@ func (s *billingService) GetBillingStatus(ctx context.Context, organizationID in
 	}
 
 	// Build billing status from quota status
-	return &domain.BillingStatus{
+	status := &domain.BillingStatus{
 		OrganizationID:        organizationID,
-		HasActiveSubscription: quotaStatus.SubscriptionStatus == "active",
+		HasActiveSubscription: quotaStatus.IsActive(),
+		SubscriptionStatus:    quotaStatus.SubscriptionStatus,
 		CanProcessInvoices:    quotaStatus.CanProcessInvoice,
 		InvoiceCount:          quotaStatus.InvoiceCount,
 		Reason:                s.buildStatusReason(quotaStatus),
 		CheckedAt:             time.Now(),
-	}, nil
+	}
+
+	// Trials report when they end so clients can prompt an upgrade
+	if quotaStatus.SubscriptionStatus == "trialing" {
+		status.TrialEndsAt = quotaStatus.TrialEnd
+	}
+
+	return status, nil
 }
 
 func (s *billingService) buildStatusReason(status *domain.QuotaStatus) string {
 	if !status.CanProcessInvoice {
-		if status.SubscriptionStatus != "active" {
+		if !status.IsActive() {
 			return fmt.Sprintf("subscription status: %s", status.SubscriptionStatus)
 		}
 		return "invoice quota exceeded"
@@ -6,13 +6,18 @@ import (
 	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
 	"github.com/moasq/go-b2b-starter/internal/modules/billing/infra/polar"
 	"github.com/moasq/go-b2b-starter/internal/modules/billing/infra/repositories"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/infra/sandbox"
 	"github.com/moasq/go-b2b-starter/internal/db/adapters"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
 	logger "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
 	polarpkg "github.com/moasq/go-b2b-starter/internal/platform/polar"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
 )
 
 // Module handles dependency injection for billing services
-// Note: SubscriptionRepository is registered in internal/db/inject.go
+// Note: SubscriptionRepository, UserSubscriptionRepository, UsageRepository, SeatRepository and TrialRepository are registered in internal/db/inject.go
 type Module struct{}
 
 func NewModule() *Module {
@@ -28,9 +33,85 @@ func (m *Module) Configure(container *dig.Container) error {
 		return err
 	}
 
-	// Register BillingProvider (Polar implementation)
-	if err := container.Provide(func(client *polarpkg.Client, log logger.Logger) domain.BillingProvider {
-		return polar.NewPolarAdapter(client, log)
+	// Register UserAdapter for user-scoped (B2C) billing
+	if err := container.Provide(func(store sqlc.Store) domain.UserAdapter {
+		return repositories.NewUserAdapter(store)
+	}); err != nil {
+		return err
+	}
+
+	// Provider selection is read up front so the sandbox never builds the Polar client
+	providerConfig, err := LoadProviderConfig()
+	if err != nil {
+		return err
+	}
+	if err := container.Provide(func() *ProviderConfig { return providerConfig }); err != nil {
+		return err
+	}
+
+	// Register BillingProvider (Polar, or the offline sandbox when BILLING_PROVIDER=sandbox)
+	if providerConfig.IsSandbox() {
+		if err := container.Provide(func(log logger.Logger) domain.BillingProvider {
+			log.Warn("Using sandbox billing provider - checkouts and subscriptions are simulated", nil)
+			return sandbox.NewSandboxAdapter(log)
+		}); err != nil {
+			return err
+		}
+	} else {
+		if err := container.Provide(func(client *polarpkg.Client, log logger.Logger) domain.BillingProvider {
+			return polar.NewPolarAdapter(client, log)
+		}); err != nil {
+			return err
+		}
+	}
+
+	// Register UserBillingService
+	if err := container.Provide(func(
+		repo domain.UserSubscriptionRepository,
+		userAdapter domain.UserAdapter,
+		billingProvider domain.BillingProvider,
+		config *ProviderConfig,
+		logger logger.Logger,
+	) UserBillingService {
+		return NewUserBillingService(repo, userAdapter, billingProvider, config, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register seat-based billing (seat limits and seat quantity sync)
+	if err := container.Provide(LoadSeatConfig); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(
+		repo domain.SeatRepository,
+		subscriptions domain.SubscriptionRepository,
+		orgAdapter domain.OrganizationAdapter,
+		billingProvider domain.BillingProvider,
+		config *SeatConfig,
+		tracker jobsDomain.Tracker,
+		logger logger.Logger,
+	) SeatService {
+		return NewSeatService(repo, subscriptions, orgAdapter, billingProvider, config, tracker, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register free trials (trial on signup, reminders and expiry)
+	if err := container.Provide(LoadTrialConfig); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(
+		trials domain.TrialRepository,
+		subscriptions domain.SubscriptionRepository,
+		orgAdapter domain.OrganizationAdapter,
+		sender emailDomain.Sender,
+		config *TrialConfig,
+		tracker jobsDomain.Tracker,
+		logger logger.Logger,
+	) TrialService {
+		return NewTrialService(trials, subscriptions, orgAdapter, sender, config, tracker, logger)
 	}); err != nil {
 		return err
 	}
@@ -40,9 +121,47 @@ func (m *Module) Configure(container *dig.Container) error {
 		repo domain.SubscriptionRepository,
 		orgAdapter domain.OrganizationAdapter,
 		billingProvider domain.BillingProvider,
+		userBilling UserBillingService,
+		seats SeatService,
 		logger logger.Logger,
 	) BillingService {
-		return NewBillingService(repo, orgAdapter, billingProvider, logger)
+		return NewBillingService(repo, orgAdapter, billingProvider, userBilling, seats, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register usage metering (recording, hourly rollups and usage reports)
+	if err := container.Provide(LoadUsageConfig); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(
+		repo domain.UsageRepository,
+		orgAdapter domain.OrganizationAdapter,
+		billingProvider domain.BillingProvider,
+		config *UsageConfig,
+		tracker jobsDomain.Tracker,
+		logger logger.Logger,
+	) UsageMeteringService {
+		return NewUsageMeteringService(repo, orgAdapter, billingProvider, config, tracker, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register catalog config
+	if err := container.Provide(LoadCatalogConfig); err != nil {
+		return err
+	}
+
+	// Register PlanCatalogService
+	if err := container.Provide(func(
+		billingProvider domain.BillingProvider,
+		settingsRepo domain.BillingSettingsRepository,
+		redisClient redis.Client,
+		config *CatalogConfig,
+		logger logger.Logger,
+	) PlanCatalogService {
+		return NewPlanCatalogService(billingProvider, settingsRepo, redisClient, config, logger)
 	}); err != nil {
 		return err
 	}
@@ -0,0 +1,327 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"golang.org/x/text/language"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	logger "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+	"github.com/moasq/go-b2b-starter/pkg/money"
+)
+
+const (
+	// planCatalogCacheKey caches the plans fetched from the billing provider
+	planCatalogCacheKey = "billing:plans:catalog"
+
+	// meteredAmountType is the amount type of usage-based prices
+	meteredAmountType = "metered_unit"
+)
+
+// PlanCatalogService serves the plan catalog with prices in the customer's currency
+// and formats amounts consistently for the API, invoices and emails.
+//
+// The currency is negotiated in this order: explicit request, organization
+// setting, region of the negotiated locale, BILLING_DEFAULT_CURRENCY. Only
+// currencies the billing provider actually offers are selected.
+type PlanCatalogService interface {
+	// ListPlans returns the active plans priced in the negotiated currency
+	ListPlans(ctx context.Context, req *PlanCatalogRequest) (*PlanCatalog, error)
+
+	// GetSettings returns the organization's currency and locale preferences
+	GetSettings(ctx context.Context, organizationID int32) (*domain.BillingSettings, error)
+
+	// UpdateSettings sets the organization's currency and locale preferences
+	UpdateSettings(ctx context.Context, organizationID int32, req *UpdateBillingSettingsRequest) (*domain.BillingSettings, error)
+
+	// FormatAmount formats an amount in minor units in the organization's locale
+	FormatAmount(ctx context.Context, organizationID int32, amount int64, currency string) string
+}
+
+// PlanCatalogRequest carries the inputs for currency and locale negotiation.
+// OrganizationID is zero for visitors who are not signed in.
+type PlanCatalogRequest struct {
+	OrganizationID int32
+	Currency       string
+	Locale         string
+	AcceptLanguage string
+}
+
+// UpdateBillingSettingsRequest sets the display preferences. Empty values
+// clear the preference so it is negotiated from each request.
+type UpdateBillingSettingsRequest struct {
+	Currency string `json:"currency" binding:"omitempty,len=3"`
+	Locale   string `json:"locale" binding:"max=35"`
+}
+
+// PlanCatalog is the plan catalog for one currency and locale
+type PlanCatalog struct {
+	Currency            string         `json:"currency"`
+	Locale              string         `json:"locale"`
+	AvailableCurrencies []string       `json:"available_currencies"`
+	Plans               []*CatalogPlan `json:"plans"`
+}
+
+// CatalogPlan is a plan with its price in the catalog currency.
+// Price is nil when the plan has no price.
+type CatalogPlan struct {
+	ProductID   string        `json:"product_id"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	IsRecurring bool          `json:"is_recurring"`
+	Interval    string        `json:"interval,omitempty"`
+	Price       *DisplayPrice `json:"price,omitempty"`
+}
+
+// DisplayPrice is a price with its locale-formatted amount. Currency differs
+// from the catalog currency when the plan is not offered in that currency.
+// Formatted is empty for metered prices.
+type DisplayPrice struct {
+	PriceID    string `json:"price_id"`
+	AmountType string `json:"amount_type"`
+	Amount     int64  `json:"amount"`
+	Currency   string `json:"currency"`
+	Formatted  string `json:"formatted"`
+}
+
+type planCatalogService struct {
+	billingProvider domain.BillingProvider
+	settingsRepo    domain.BillingSettingsRepository
+	redis           redis.Client
+	config          *CatalogConfig
+	logger          logger.Logger
+}
+
+func NewPlanCatalogService(
+	billingProvider domain.BillingProvider,
+	settingsRepo domain.BillingSettingsRepository,
+	redisClient redis.Client,
+	config *CatalogConfig,
+	logger logger.Logger,
+) PlanCatalogService {
+	return &planCatalogService{
+		billingProvider: billingProvider,
+		settingsRepo:    settingsRepo,
+		redis:           redisClient,
+		config:          config,
+		logger:          logger,
+	}
+}
+
+func (s *planCatalogService) ListPlans(ctx context.Context, req *PlanCatalogRequest) (*PlanCatalog, error) {
+	plans, err := s.loadPlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	settings := s.settingsFor(ctx, req.OrganizationID)
+	locale := s.negotiateLocale(req.Locale, settings.Locale, req.AcceptLanguage)
+
+	available := availableCurrencies(plans)
+	currency := s.negotiateCurrency(available, req.Currency, settings.Currency, locale)
+
+	catalog := &PlanCatalog{
+		Currency:            currency,
+		Locale:              locale,
+		AvailableCurrencies: available,
+		Plans:               make([]*CatalogPlan, 0, len(plans)),
+	}
+	for _, plan := range plans {
+		entry := &CatalogPlan{
+			ProductID:   plan.ProductID,
+			Name:        plan.Name,
+			Description: plan.Description,
+			IsRecurring: plan.IsRecurring,
+			Interval:    plan.Interval,
+		}
+		if price := s.selectPrice(plan, currency); price != nil {
+			entry.Price = &DisplayPrice{
+				PriceID:    price.PriceID,
+				AmountType: price.AmountType,
+				Amount:     price.Amount,
+				Currency:   price.Currency,
+			}
+			// Metered prices are billed per unit and have no single amount
+			if price.AmountType != meteredAmountType {
+				entry.Price.Formatted = money.Format(price.Amount, price.Currency, locale)
+			}
+		}
+		catalog.Plans = append(catalog.Plans, entry)
+	}
+
+	return catalog, nil
+}
+
+func (s *planCatalogService) GetSettings(ctx context.Context, organizationID int32) (*domain.BillingSettings, error) {
+	settings, err := s.settingsRepo.GetSettings(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrBillingSettingsNotFound) {
+			return &domain.BillingSettings{OrganizationID: organizationID}, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+func (s *planCatalogService) UpdateSettings(ctx context.Context, organizationID int32, req *UpdateBillingSettingsRequest) (*domain.BillingSettings, error) {
+	settings := &domain.BillingSettings{OrganizationID: organizationID}
+
+	if req.Currency != "" {
+		currency, err := money.NormalizeCurrency(req.Currency)
+		if err != nil {
+			return nil, domain.ErrInvalidCurrency
+		}
+		settings.Currency = currency
+	}
+	if req.Locale != "" {
+		locale, err := money.NormalizeLocale(req.Locale)
+		if err != nil {
+			return nil, domain.ErrInvalidLocale
+		}
+		settings.Locale = locale
+	}
+
+	updated, err := s.settingsRepo.UpsertSettings(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("billing settings updated", logger.Fields{
+		"organization_id": organizationID,
+		"currency":        updated.Currency,
+		"locale":          updated.Locale,
+	})
+
+	return updated, nil
+}
+
+func (s *planCatalogService) FormatAmount(ctx context.Context, organizationID int32, amount int64, currency string) string {
+	settings := s.settingsFor(ctx, organizationID)
+	return money.Format(amount, currency, s.negotiateLocale("", settings.Locale, ""))
+}
+
+// loadPlans returns the provider's plans, cached in Redis. A Redis failure
+// falls back to the provider so the catalog stays available.
+func (s *planCatalogService) loadPlans(ctx context.Context) ([]*domain.Plan, error) {
+	if exists, err := s.redis.Exists(ctx, planCatalogCacheKey); err == nil && exists {
+		if cached, err := s.redis.Get(ctx, planCatalogCacheKey); err == nil {
+			var plans []*domain.Plan
+			if err := json.Unmarshal([]byte(cached), &plans); err == nil {
+				return plans, nil
+			}
+		}
+	}
+
+	plans, err := s.billingProvider.ListPlans(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err := json.Marshal(plans); err == nil {
+		if err := s.redis.Set(ctx, planCatalogCacheKey, string(data), s.config.PlanCacheTTL); err != nil {
+			s.logger.Warn("failed to cache billing plans", logger.Fields{"error": err.Error()})
+		}
+	}
+
+	return plans, nil
+}
+
+// settingsFor returns the organization's preferences, or none for visitors
+// and when the settings cannot be read.
+func (s *planCatalogService) settingsFor(ctx context.Context, organizationID int32) *domain.BillingSettings {
+	if organizationID == 0 {
+		return &domain.BillingSettings{}
+	}
+
+	settings, err := s.GetSettings(ctx, organizationID)
+	if err != nil {
+		s.logger.Warn("failed to load billing settings, using request preferences", logger.Fields{
+			"organization_id": organizationID,
+			"error":           err.Error(),
+		})
+		return &domain.BillingSettings{OrganizationID: organizationID}
+	}
+	return settings
+}
+
+// negotiateLocale picks the explicit locale, then the organization's, then the
+// preferred Accept-Language entry, then BILLING_DEFAULT_LOCALE.
+func (s *planCatalogService) negotiateLocale(requested, preferred, acceptLanguage string) string {
+	for _, candidate := range []string{requested, preferred} {
+		if candidate == "" {
+			continue
+		}
+		if locale, err := money.NormalizeLocale(candidate); err == nil {
+			return locale
+		}
+	}
+
+	if acceptLanguage != "" {
+		if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil && len(tags) > 0 {
+			return tags[0].String()
+		}
+	}
+
+	return s.config.DefaultLocale
+}
+
+// negotiateCurrency picks the first preferred currency the provider offers.
+func (s *planCatalogService) negotiateCurrency(available []string, requested, preferred, locale string) string {
+	candidates := []string{requested, preferred}
+	if regional, ok := money.CurrencyForLocale(locale); ok {
+		candidates = append(candidates, regional)
+	}
+
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		currency, err := money.NormalizeCurrency(candidate)
+		if err != nil {
+			continue
+		}
+		for _, offered := range available {
+			if offered == currency {
+				return currency
+			}
+		}
+	}
+
+	return s.config.DefaultCurrency
+}
+
+// selectPrice returns the plan's price in the currency, falling back to the
+// default currency and then to the plan's first price.
+func (s *planCatalogService) selectPrice(plan *domain.Plan, currency string) *domain.Price {
+	if len(plan.Prices) == 0 {
+		return nil
+	}
+	for _, want := range []string{currency, s.config.DefaultCurrency} {
+		for _, price := range plan.Prices {
+			if price.Currency == want {
+				return price
+			}
+		}
+	}
+	return plan.Prices[0]
+}
+
+// availableCurrencies lists the currencies any plan is priced in, sorted.
+func availableCurrencies(plans []*domain.Plan) []string {
+	seen := make(map[string]bool)
+	currencies := []string{}
+	for _, plan := range plans {
+		for _, price := range plan.Prices {
+			if price.Currency != "" && !seen[price.Currency] {
+				seen[price.Currency] = true
+				currencies = append(currencies, price.Currency)
+			}
+		}
+	}
+	sort.Strings(currencies)
+	return currencies
+}
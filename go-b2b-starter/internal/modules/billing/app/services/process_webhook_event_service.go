@@ -27,18 +27,31 @@ func (s *billingService) ProcessWebhookEvent(ctx context.Context, eventType stri
 		if err != nil {
 			return fmt.Errorf("failed to parse subscription webhook payload: %w", err)
 		}
+		if domain.IsUserCustomerID(eventData.ExternalCustomerID) {
+			return s.userBilling.ApplySubscriptionEvent(ctx, eventType, eventData)
+		}
 		return s.handleSubscriptionUpsert(ctx, eventData)
 	case "subscription.canceled":
 		eventData, err := s.parseSubscriptionWebhookPayload(payload)
 		if err != nil {
 			return fmt.Errorf("failed to parse subscription webhook payload: %w", err)
 		}
+		if domain.IsUserCustomerID(eventData.ExternalCustomerID) {
+			return s.userBilling.ApplySubscriptionEvent(ctx, eventType, eventData)
+		}
 		return s.handleSubscriptionCanceled(ctx, eventData)
 	case "customer.updated":
 		eventData, err := s.parseSubscriptionWebhookPayload(payload)
 		if err != nil {
 			return fmt.Errorf("failed to parse subscription webhook payload: %w", err)
 		}
+		if domain.IsUserCustomerID(eventData.ExternalCustomerID) {
+			// Invoice quotas are organization-scoped; users have none to update
+			s.logger.Info("Ignoring customer update for user customer", map[string]any{
+				"external_customer_id": eventData.ExternalCustomerID,
+			})
+			return nil
+		}
 		return s.handleCustomerUpdated(ctx, eventData)
 	case "meter.grant.updated", "meter.grant.created", "entitlement.grant.updated":
 		if err := s.handleMeterGrantEvent(ctx, payload); err != nil {
@@ -95,6 +108,19 @@ func (s *billingService) parseSubscriptionWebhookPayload(payload map[string]any)
 		}
 	}
 
+	// Seat-based subscriptions carry their seat quantity
+	if seats, ok := toInt32(normalized["seats"]); ok {
+		data.Seats = seats
+	}
+
+	// Subscriptions with a trial carry its period
+	if t, ok := parseISOTime(normalized["trial_start"]); ok {
+		data.TrialStart = &t
+	}
+	if t, ok := parseISOTime(normalized["trial_end"]); ok {
+		data.TrialEnd = &t
+	}
+
 	product := extractProductMap(normalized)
 	if product == nil {
 		product = extractProductMap(payload)
@@ -201,6 +227,7 @@ func (s *billingService) parseSubscriptionWebhookPayload(payload map[string]any)
 		"external_customer_id":   data.ExternalCustomerID,
 		"status":                 data.Status,
 		"product_id":             data.ProductID,
+		"seats":                  data.Seats,
 		"product_metadata_keys":  len(data.ProductMetadata),
 		"customer_metadata_keys": len(data.CustomerMetadata),
 	})
@@ -261,6 +288,8 @@ func (s *billingService) handleSubscriptionUpsert(ctx context.Context, eventData
 		CurrentPeriodEnd:   eventData.CurrentPeriodEnd,
 		CancelAtPeriodEnd:  eventData.CancelAtPeriodEnd,
 		CanceledAt:         eventData.CanceledAt,
+		TrialStart:         eventData.TrialStart,
+		TrialEnd:           eventData.TrialEnd,
 	}
 
 	// Step 5: Upsert subscription to database
@@ -298,6 +327,11 @@ func (s *billingService) handleSubscriptionUpsert(ctx context.Context, eventData
 		"max_seats":       maxSeats,
 	})
 
+	// Step 8: Record the purchased seats of seat-based plans
+	if err := s.seats.ApplySubscriptionSeats(ctx, organizationID, eventData.Seats); err != nil {
+		return fmt.Errorf("failed to record subscription seats: %w", err)
+	}
+
 	return nil
 }
 
@@ -345,6 +379,11 @@ func (s *billingService) handleSubscriptionCanceled(ctx context.Context, eventDa
 		"canceled_at":     subscription.CanceledAt,
 	})
 
+	// Step 4: A canceled subscription holds no seats, so members are no longer limited
+	if err := s.seats.ApplySubscriptionSeats(ctx, organizationID, 0); err != nil {
+		return fmt.Errorf("failed to release subscription seats: %w", err)
+	}
+
 	return nil
 }
 
@@ -431,6 +470,13 @@ func (s *billingService) handleMeterGrantEvent(ctx context.Context, payload map[
 		return nil
 	}
 
+	if domain.IsUserCustomerID(eventData.ExternalCustomerID) {
+		s.logger.Info("Ignoring meter grant event for user customer", map[string]any{
+			"external_customer_id": eventData.ExternalCustomerID,
+		})
+		return nil
+	}
+
 	organizationID, err := s.orgAdapter.GetOrganizationIDByStytchOrgID(ctx, eventData.ExternalCustomerID)
 	if err != nil {
 		return fmt.Errorf("failed to map organization for meter grant: %w", err)
@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Supported values for BILLING_PROVIDER.
+const (
+	ProviderPolar   = "polar"
+	ProviderSandbox = "sandbox"
+)
+
+// Supported values for BILLING_SCOPE.
+const (
+	ScopeOrganization = "organization"
+	ScopeUser         = "user"
+)
+
+// ProviderConfig selects the billing provider and configures incoming webhooks.
+type ProviderConfig struct {
+	// Provider is "polar" or "sandbox" (offline fake for local development)
+	Provider string `mapstructure:"BILLING_PROVIDER"`
+
+	// WebhookSecret verifies Standard Webhooks signatures on incoming events.
+	// Webhooks are rejected when it is empty.
+	WebhookSecret string `mapstructure:"WEBHOOK_SECRET"`
+
+	// WebhookTolerance is how far the webhook timestamp may be from now
+	WebhookTolerance time.Duration `mapstructure:"BILLING_WEBHOOK_TOLERANCE"`
+
+	// Scope is "organization" (B2B, organizations pay) or "user" (B2C,
+	// each account pays for itself through the /subscriptions/me endpoints)
+	Scope string `mapstructure:"BILLING_SCOPE"`
+}
+
+// IsSandbox reports whether the offline sandbox provider is selected.
+func (c *ProviderConfig) IsSandbox() bool {
+	return c.Provider == ProviderSandbox
+}
+
+// IsUserScope reports whether individual accounts are billed.
+func (c *ProviderConfig) IsUserScope() bool {
+	return c.Scope == ScopeUser
+}
+
+// LoadProviderConfig loads the provider configuration from environment variables and app.env file.
+func LoadProviderConfig() (*ProviderConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("BILLING_PROVIDER", ProviderPolar)
+	v.SetDefault("WEBHOOK_SECRET", "")
+	v.SetDefault("BILLING_WEBHOOK_TOLERANCE", "5m")
+	v.SetDefault("BILLING_SCOPE", ScopeOrganization)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg ProviderConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode billing provider config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the provider name, webhook tolerance and billing scope.
+func (c *ProviderConfig) Validate() error {
+	c.Provider = strings.ToLower(strings.TrimSpace(c.Provider))
+	switch c.Provider {
+	case ProviderPolar, ProviderSandbox:
+	default:
+		return fmt.Errorf("billing provider config invalid: BILLING_PROVIDER must be %q or %q", ProviderPolar, ProviderSandbox)
+	}
+	if c.WebhookTolerance <= 0 {
+		return fmt.Errorf("billing provider config invalid: BILLING_WEBHOOK_TOLERANCE must be positive")
+	}
+	c.Scope = strings.ToLower(strings.TrimSpace(c.Scope))
+	switch c.Scope {
+	case ScopeOrganization, ScopeUser:
+	default:
+		return fmt.Errorf("billing provider config invalid: BILLING_SCOPE must be %q or %q", ScopeOrganization, ScopeUser)
+	}
+	return nil
+}
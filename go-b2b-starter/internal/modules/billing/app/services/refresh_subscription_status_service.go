@@ -12,8 +12,14 @@ import (
 // This is the lazy guarding mechanism - used when DB says expired but we want
 // to double-check with the provider in case we missed a webhook.
 func (s *billingService) RefreshSubscriptionStatus(ctx context.Context, organizationID int32) (*domain.BillingStatus, error) {
+	// Staging organizations refresh their production parent's subscription
+	billingOrgID, _, err := s.orgAdapter.GetBillingOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve billing organization: %w", err)
+	}
+
 	// Step 1: Check if subscription exists in database
-	_, err := s.repo.GetSubscriptionByOrgID(ctx, organizationID)
+	_, err = s.repo.GetSubscriptionByOrgID(ctx, billingOrgID)
 	if err != nil {
 		// No subscription exists - don't call Polar API
 		s.logger.Info("No subscription found for refresh", map[string]any{
@@ -31,7 +37,7 @@ func (s *billingService) RefreshSubscriptionStatus(ctx context.Context, organiza
 	}
 
 	// Step 2: Sync subscription from Polar API
-	if err := s.SyncSubscriptionFromPolar(ctx, organizationID); err != nil {
+	if err := s.SyncSubscriptionFromPolar(ctx, billingOrgID); err != nil {
 		// Sync failed - return error
 		return nil, fmt.Errorf("failed to refresh subscription from Polar: %w", err)
 	}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// SeatConfig controls seat-based billing: the seat limit on new members and
+// the sync of membership changes to the subscription's seat quantity.
+//
+// All values can be set via environment variables with the BILLING_SEATS_ prefix.
+type SeatConfig struct {
+	// Enabled enforces seat limits and syncs seats; when false members are
+	// never blocked and no job runs
+	Enabled bool `mapstructure:"BILLING_SEATS_ENABLED"`
+
+	// SyncDebounce is how long after the first membership change the seats
+	// are synced, so a burst of changes updates the subscription once
+	SyncDebounce time.Duration `mapstructure:"BILLING_SEATS_SYNC_DEBOUNCE"`
+
+	// SyncInterval is the time between runs of the sync job. Zero disables
+	// the scheduled sync.
+	SyncInterval time.Duration `mapstructure:"BILLING_SEATS_SYNC_INTERVAL"`
+
+	// ReconcileInterval is the time between reconciliations, which sync
+	// every subscribed organization to correct missed changes. Zero
+	// disables reconciliation.
+	ReconcileInterval time.Duration `mapstructure:"BILLING_SEATS_RECONCILE_INTERVAL"`
+
+	// RetryDelay is how long a failed sync waits before it is retried
+	RetryDelay time.Duration `mapstructure:"BILLING_SEATS_RETRY_DELAY"`
+
+	// SyncBatchSize is how many organizations are synced at a time
+	SyncBatchSize int `mapstructure:"BILLING_SEATS_SYNC_BATCH_SIZE"`
+}
+
+// LoadSeatConfig loads the seat configuration from environment variables and app.env file.
+func LoadSeatConfig() (*SeatConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("BILLING_SEATS_ENABLED", true)
+	v.SetDefault("BILLING_SEATS_SYNC_DEBOUNCE", "1m")
+	v.SetDefault("BILLING_SEATS_SYNC_INTERVAL", "30s")
+	v.SetDefault("BILLING_SEATS_RECONCILE_INTERVAL", "6h")
+	v.SetDefault("BILLING_SEATS_RETRY_DELAY", "5m")
+	v.SetDefault("BILLING_SEATS_SYNC_BATCH_SIZE", 100)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg SeatConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode billing seats config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the sync schedule and batch size.
+func (c *SeatConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.SyncDebounce < 0 {
+		return fmt.Errorf("billing seats config invalid: BILLING_SEATS_SYNC_DEBOUNCE must not be negative")
+	}
+	if c.SyncInterval < 0 {
+		return fmt.Errorf("billing seats config invalid: BILLING_SEATS_SYNC_INTERVAL must not be negative")
+	}
+	if c.ReconcileInterval < 0 {
+		return fmt.Errorf("billing seats config invalid: BILLING_SEATS_RECONCILE_INTERVAL must not be negative")
+	}
+	if c.RetryDelay <= 0 {
+		return fmt.Errorf("billing seats config invalid: BILLING_SEATS_RETRY_DELAY must be positive")
+	}
+	if c.SyncBatchSize < 1 {
+		return fmt.Errorf("billing seats config invalid: BILLING_SEATS_SYNC_BATCH_SIZE must be at least 1")
+	}
+	return nil
+}
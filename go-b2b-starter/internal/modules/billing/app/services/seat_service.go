@@ -0,0 +1,410 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	logger "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// SeatService keeps the seat quantity of seat-based subscriptions in line
+// with organization membership.
+//
+// Members occupy a seat while active or dormant; service accounts, OAuth
+// clients and guests do not. Adding a member beyond the purchased seats is
+// blocked unless the organization enabled auto-expand, in which case the
+// subscription grows and shrinks with membership.
+//
+// Membership changes request a sync that runs after BILLING_SEATS_SYNC_DEBOUNCE,
+// so a burst of changes updates the provider once. Each sync reads the seat
+// quantity from the provider, which wins over the local copy, and
+// reconciliation periodically syncs every subscribed organization to correct
+// missed events and webhooks.
+type SeatService interface {
+	// CheckSeatAvailable returns ErrSeatLimitReached when a new member would
+	// exceed the purchased seats, and reports whether membership is limited
+	// by seats. Plans that are not seat-based, staging organizations and
+	// auto-expanding organizations are never limited.
+	CheckSeatAvailable(ctx context.Context, organizationID int32) (limited bool, err error)
+
+	// RequestSync schedules a debounced sync after a membership change
+	RequestSync(ctx context.Context, organizationID int32) error
+
+	// ApplySubscriptionSeats records the seat quantity reported by the
+	// billing provider; 0 when the plan is not seat-based
+	ApplySubscriptionSeats(ctx context.Context, organizationID, seats int32) error
+
+	// GetSeats returns an organization's purchased and used seats
+	GetSeats(ctx context.Context, organizationID int32) (*domain.SeatStatus, error)
+
+	// UpdateSeats changes the purchased seats or auto-expand of a seat-based subscription
+	UpdateSeats(ctx context.Context, organizationID int32, req *UpdateSeatsRequest) (*domain.SeatStatus, error)
+
+	// Run syncs due seats every BILLING_SEATS_SYNC_INTERVAL and reconciles
+	// every BILLING_SEATS_RECONCILE_INTERVAL until ctx is cancelled
+	Run(ctx context.Context)
+
+	// SyncDue runs the sync job once
+	SyncDue(ctx context.Context) error
+
+	// Reconcile schedules a sync for every subscribed organization and runs it
+	Reconcile(ctx context.Context) error
+}
+
+// UpdateSeatsRequest changes seat settings; nil fields are left unchanged.
+// Seats can only be set while auto-expand is off.
+type UpdateSeatsRequest struct {
+	Seats      *int32 `json:"seats,omitempty"`
+	AutoExpand *bool  `json:"auto_expand,omitempty"`
+}
+
+type seatService struct {
+	repo            domain.SeatRepository
+	subscriptions   domain.SubscriptionRepository
+	orgAdapter      domain.OrganizationAdapter
+	billingProvider domain.BillingProvider
+	config          *SeatConfig
+	tracker         jobsDomain.Tracker
+	syncJob         jobsDomain.Definition
+	reconcileJob    jobsDomain.Definition
+	logger          logger.Logger
+}
+
+func NewSeatService(
+	repo domain.SeatRepository,
+	subscriptions domain.SubscriptionRepository,
+	orgAdapter domain.OrganizationAdapter,
+	billingProvider domain.BillingProvider,
+	config *SeatConfig,
+	tracker jobsDomain.Tracker,
+	logger logger.Logger,
+) SeatService {
+	s := &seatService{
+		repo:            repo,
+		subscriptions:   subscriptions,
+		orgAdapter:      orgAdapter,
+		billingProvider: billingProvider,
+		config:          config,
+		tracker:         tracker,
+		syncJob: jobsDomain.Definition{
+			Name:        "billing.seat_sync",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Syncs changed organization membership to the subscription seat quantity",
+			Schedule:    "every " + config.SyncInterval.String(),
+		},
+		reconcileJob: jobsDomain.Definition{
+			Name:        "billing.seat_reconcile",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Syncs the seats of every subscribed organization with the billing provider",
+			Schedule:    "every " + config.ReconcileInterval.String(),
+		},
+		logger: logger.Named("billing"),
+	}
+	tracker.Register(s.syncJob)
+	if config.ReconcileInterval > 0 {
+		tracker.Register(s.reconcileJob)
+	}
+	return s
+}
+
+func (s *seatService) CheckSeatAvailable(ctx context.Context, organizationID int32) (bool, error) {
+	if !s.config.Enabled {
+		return false, nil
+	}
+
+	// Staging organizations are covered by their parent and not billed
+	_, staging, err := s.orgAdapter.GetBillingOrganizationID(ctx, organizationID)
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve billing organization: %w", err)
+	}
+	if staging {
+		return false, nil
+	}
+
+	allocation, err := s.repo.GetAllocation(ctx, organizationID)
+	if err != nil {
+		return false, err
+	}
+	if !allocation.IsSeatBased() || allocation.AutoExpand {
+		return false, nil
+	}
+
+	used, err := s.repo.CountUsedSeats(ctx, organizationID)
+	if err != nil {
+		return true, err
+	}
+	if used >= allocation.PurchasedSeats {
+		return true, fmt.Errorf("%w: %d of %d seats used", domain.ErrSeatLimitReached, used, allocation.PurchasedSeats)
+	}
+	return true, nil
+}
+
+func (s *seatService) RequestSync(ctx context.Context, organizationID int32) error {
+	if !s.config.Enabled {
+		return nil
+	}
+	return s.repo.RequestSync(ctx, organizationID, time.Now().Add(s.config.SyncDebounce))
+}
+
+func (s *seatService) ApplySubscriptionSeats(ctx context.Context, organizationID, seats int32) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	allocation, err := s.repo.SetPurchasedSeats(ctx, organizationID, max(seats, 0))
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("subscription seats recorded", logger.Fields{
+		"organization_id": organizationID,
+		"purchased_seats": allocation.PurchasedSeats,
+		"auto_expand":     allocation.AutoExpand,
+	})
+	return nil
+}
+
+func (s *seatService) GetSeats(ctx context.Context, organizationID int32) (*domain.SeatStatus, error) {
+	if !s.config.Enabled {
+		return nil, domain.ErrSeatBillingDisabled
+	}
+
+	allocation, err := s.repo.GetAllocation(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.repo.CountUsedSeats(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &domain.SeatStatus{
+		OrganizationID: organizationID,
+		SeatBased:      allocation.IsSeatBased(),
+		PurchasedSeats: allocation.PurchasedSeats,
+		UsedSeats:      used,
+		AutoExpand:     allocation.AutoExpand,
+		SyncPending:    allocation.SyncDueAt != nil,
+		SyncedAt:       allocation.SyncedAt,
+		SyncError:      allocation.SyncError,
+	}
+	if allocation.IsSeatBased() && used < allocation.PurchasedSeats {
+		status.AvailableSeats = allocation.PurchasedSeats - used
+	}
+	return status, nil
+}
+
+func (s *seatService) UpdateSeats(ctx context.Context, organizationID int32, req *UpdateSeatsRequest) (*domain.SeatStatus, error) {
+	if !s.config.Enabled {
+		return nil, domain.ErrSeatBillingDisabled
+	}
+
+	allocation, err := s.repo.GetAllocation(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if !allocation.IsSeatBased() {
+		return nil, domain.ErrNotSeatBased
+	}
+
+	autoExpand := allocation.AutoExpand
+	if req.AutoExpand != nil {
+		autoExpand = *req.AutoExpand
+	}
+
+	if req.Seats != nil {
+		if autoExpand {
+			return nil, fmt.Errorf("%w: seats follow membership while auto-expand is on", domain.ErrInvalidSeatCount)
+		}
+		if err := s.setPurchasedSeats(ctx, organizationID, *req.Seats); err != nil {
+			return nil, err
+		}
+	}
+
+	if autoExpand != allocation.AutoExpand {
+		if _, err := s.repo.SetAutoExpand(ctx, organizationID, autoExpand); err != nil {
+			return nil, err
+		}
+		// Turning auto-expand on sizes the subscription to membership now
+		if autoExpand {
+			if err := s.repo.RequestSync(ctx, organizationID, time.Now()); err != nil {
+				return nil, err
+			}
+		}
+
+		s.logger.Info("seat auto-expand changed", logger.Fields{
+			"organization_id": organizationID,
+			"auto_expand":     autoExpand,
+		})
+	}
+
+	return s.GetSeats(ctx, organizationID)
+}
+
+// setPurchasedSeats changes the subscription's seat quantity on the provider
+// and records it. Seats below the members already using one are rejected.
+func (s *seatService) setPurchasedSeats(ctx context.Context, organizationID, seats int32) error {
+	used, err := s.repo.CountUsedSeats(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if seats < 1 || seats < used {
+		return fmt.Errorf("%w: %d seats requested, %d in use", domain.ErrInvalidSeatCount, seats, used)
+	}
+
+	subscription, err := s.subscriptions.GetSubscriptionByOrgID(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if err := s.billingProvider.UpdateSubscriptionSeats(ctx, subscription.SubscriptionID, seats); err != nil {
+		return fmt.Errorf("failed to update subscription seats: %w", err)
+	}
+
+	if _, err := s.repo.SetPurchasedSeats(ctx, organizationID, seats); err != nil {
+		return err
+	}
+
+	s.logger.Info("purchased seats changed", logger.Fields{
+		"organization_id": organizationID,
+		"subscription_id": subscription.SubscriptionID,
+		"seats":           seats,
+		"used_seats":      used,
+	})
+	return nil
+}
+
+func (s *seatService) Run(ctx context.Context) {
+	syncTicker := time.NewTicker(s.config.SyncInterval)
+	defer syncTicker.Stop()
+
+	// A nil channel never fires, leaving reconciliation off
+	var reconcile <-chan time.Time
+	if s.config.ReconcileInterval > 0 {
+		reconcileTicker := time.NewTicker(s.config.ReconcileInterval)
+		defer reconcileTicker.Stop()
+		reconcile = reconcileTicker.C
+	}
+
+	s.logger.Info("seat sync started", logger.Fields{
+		"sync_interval":      s.config.SyncInterval.String(),
+		"reconcile_interval": s.config.ReconcileInterval.String(),
+		"debounce":           s.config.SyncDebounce.String(),
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.syncJob, s.SyncDue); err != nil {
+			s.logger.Error("seat sync failed", logger.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-syncTicker.C:
+		case <-reconcile:
+			if err := s.tracker.Track(ctx, s.reconcileJob, s.Reconcile); err != nil {
+				s.logger.Error("seat reconciliation failed", logger.Fields{"error": err.Error()})
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *seatService) SyncDue(ctx context.Context) error {
+	var errs []error
+	var synced int
+	seen := make(map[int32]bool)
+	for {
+		allocations, err := s.repo.ListDue(ctx, time.Now(), int32(s.config.SyncBatchSize))
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		fresh := 0
+		for _, allocation := range allocations {
+			// A change requested during the sync keeps it due; leave it for the next run
+			if seen[allocation.OrganizationID] {
+				continue
+			}
+			seen[allocation.OrganizationID] = true
+			fresh++
+
+			if err := s.sync(ctx, allocation); err != nil {
+				errs = append(errs, fmt.Errorf("organization %d: %w", allocation.OrganizationID, err))
+				if markErr := s.repo.MarkFailed(ctx, allocation.OrganizationID, err.Error(), time.Now().Add(s.config.RetryDelay)); markErr != nil {
+					errs = append(errs, markErr)
+				}
+				continue
+			}
+			synced++
+		}
+
+		if len(allocations) < s.config.SyncBatchSize || fresh == 0 {
+			break
+		}
+	}
+
+	if synced > 0 {
+		s.logger.Debug("seats synced", logger.Fields{"organizations": synced})
+	}
+	return errors.Join(errs...)
+}
+
+func (s *seatService) Reconcile(ctx context.Context) error {
+	scheduled, err := s.repo.RequestSyncForSubscribed(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	s.logger.Info("seat reconciliation scheduled", logger.Fields{"organizations": scheduled})
+	return s.SyncDue(ctx)
+}
+
+// sync counts the members, refreshes the purchased seats from the provider
+// and, under auto-expand, sizes the subscription to the members
+func (s *seatService) sync(ctx context.Context, allocation *domain.SeatAllocation) error {
+	countedAt := time.Now()
+	used, err := s.repo.CountUsedSeats(ctx, allocation.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	// Inactive subscriptions keep their seats until they are canceled
+	purchased := allocation.PurchasedSeats
+	subscription, err := s.subscriptions.GetSubscriptionByOrgID(ctx, allocation.OrganizationID)
+	switch {
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		purchased = 0
+	case err != nil:
+		return err
+	case subscription.IsLocalTrial():
+		// Local trials have no provider subscription to size
+		purchased = 0
+	case subscription.SubscriptionStatus == "active" || subscription.SubscriptionStatus == "trialing":
+		current, err := s.billingProvider.GetSubscription(ctx, subscription.ExternalCustomerID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch subscription: %w", err)
+		}
+		purchased = current.Seats
+
+		allocation.PurchasedSeats = purchased
+		if target := allocation.TargetSeats(used); allocation.IsSeatBased() && target != purchased {
+			if err := s.billingProvider.UpdateSubscriptionSeats(ctx, current.SubscriptionID, target); err != nil {
+				return fmt.Errorf("failed to update subscription seats: %w", err)
+			}
+
+			s.logger.Info("subscription seats sized to membership", logger.Fields{
+				"organization_id": allocation.OrganizationID,
+				"subscription_id": current.SubscriptionID,
+				"previous_seats":  purchased,
+				"seats":           target,
+			})
+			purchased = target
+		}
+	}
+
+	_, err = s.repo.MarkSynced(ctx, allocation.OrganizationID, used, purchased, countedAt)
+	return err
+}
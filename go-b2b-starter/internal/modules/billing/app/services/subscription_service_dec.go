@@ -74,6 +74,8 @@ type billingService struct {
 	repo            domain.SubscriptionRepository
 	orgAdapter      domain.OrganizationAdapter
 	billingProvider domain.BillingProvider
+	userBilling     UserBillingService
+	seats           SeatService
 	logger          logger.Logger
 }
 
@@ -81,12 +83,16 @@ func NewBillingService(
 	repo domain.SubscriptionRepository,
 	orgAdapter domain.OrganizationAdapter,
 	billingProvider domain.BillingProvider,
+	userBilling UserBillingService,
+	seats SeatService,
 	logger logger.Logger,
 ) BillingService {
 	return &billingService{
 		repo:            repo,
 		orgAdapter:      orgAdapter,
 		billingProvider: billingProvider,
+		userBilling:     userBilling,
+		seats:           seats,
 		logger:          logger,
 	}
 }
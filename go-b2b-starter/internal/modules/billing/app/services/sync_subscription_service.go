@@ -54,6 +54,10 @@ func (s *billingService) SyncSubscriptionFromPolar(ctx context.Context, organiza
 		return fmt.Errorf("failed to save quota: %w", err)
 	}
 
+	if err := s.seats.ApplySubscriptionSeats(ctx, organizationID, subscription.Seats); err != nil {
+		return fmt.Errorf("failed to save subscription seats: %w", err)
+	}
+
 	s.logger.Info("Synced subscription and quota from Polar", map[string]any{
 		"organization_id": organizationID,
 		"subscription_id": subscription.SubscriptionID,
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// TrialConfig controls free trials: the trial started for organizations that
+// sign up, the reminder sent before it ends and the job that expires it.
+//
+// All values can be set via environment variables with the BILLING_TRIAL_ prefix.
+type TrialConfig struct {
+	// Enabled starts a trial on signup; when false no trial starts and no
+	// job runs
+	Enabled bool `mapstructure:"BILLING_TRIAL_ENABLED"`
+
+	// Days is the length of a trial
+	Days int `mapstructure:"BILLING_TRIAL_DAYS"`
+
+	// InvoiceQuota is the number of invoices an organization can process
+	// during its trial
+	InvoiceQuota int32 `mapstructure:"BILLING_TRIAL_INVOICE_QUOTA"`
+
+	// ReminderDays is how many days before the trial ends the admins are
+	// reminded to subscribe. Zero disables reminders.
+	ReminderDays int `mapstructure:"BILLING_TRIAL_REMINDER_DAYS"`
+
+	// CheckInterval is the time between runs of the job that sends reminders
+	// and expires trials. Zero disables the job.
+	CheckInterval time.Duration `mapstructure:"BILLING_TRIAL_CHECK_INTERVAL"`
+
+	// BatchSize is how many trials are handled at a time
+	BatchSize int `mapstructure:"BILLING_TRIAL_BATCH_SIZE"`
+
+	// UpgradeURL is the page linked from reminder emails to subscribe
+	UpgradeURL string `mapstructure:"BILLING_TRIAL_UPGRADE_URL"`
+}
+
+// Length returns the duration of a trial.
+func (c *TrialConfig) Length() time.Duration {
+	return time.Duration(c.Days) * 24 * time.Hour
+}
+
+// ReminderLead returns how long before the trial ends the reminder is sent.
+func (c *TrialConfig) ReminderLead() time.Duration {
+	return time.Duration(c.ReminderDays) * 24 * time.Hour
+}
+
+// LoadTrialConfig loads the trial configuration from environment variables and app.env file.
+func LoadTrialConfig() (*TrialConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("BILLING_TRIAL_ENABLED", true)
+	v.SetDefault("BILLING_TRIAL_DAYS", 14)
+	v.SetDefault("BILLING_TRIAL_INVOICE_QUOTA", 25)
+	v.SetDefault("BILLING_TRIAL_REMINDER_DAYS", 3)
+	v.SetDefault("BILLING_TRIAL_CHECK_INTERVAL", "1h")
+	v.SetDefault("BILLING_TRIAL_BATCH_SIZE", 100)
+	v.SetDefault("BILLING_TRIAL_UPGRADE_URL", "http://localhost:3000/billing")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg TrialConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode billing trial config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the trial length, reminder and job schedule.
+func (c *TrialConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Days < 1 {
+		return fmt.Errorf("billing trial config invalid: BILLING_TRIAL_DAYS must be at least 1")
+	}
+	if c.InvoiceQuota < 0 {
+		return fmt.Errorf("billing trial config invalid: BILLING_TRIAL_INVOICE_QUOTA must not be negative")
+	}
+	if c.ReminderDays < 0 || c.ReminderDays >= c.Days {
+		return fmt.Errorf("billing trial config invalid: BILLING_TRIAL_REMINDER_DAYS must be between 0 and BILLING_TRIAL_DAYS")
+	}
+	if c.CheckInterval < 0 {
+		return fmt.Errorf("billing trial config invalid: BILLING_TRIAL_CHECK_INTERVAL must not be negative")
+	}
+	if c.BatchSize < 1 {
+		return fmt.Errorf("billing trial config invalid: BILLING_TRIAL_BATCH_SIZE must be at least 1")
+	}
+	return nil
+}
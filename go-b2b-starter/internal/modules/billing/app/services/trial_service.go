@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	logger "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+const trialReminderEmailTimeout = 10 * time.Second
+
+// TrialService runs free trials.
+//
+// Organizations that sign up get a local trial: a trialing subscription with
+// no provider subscription behind it, ending after BILLING_TRIAL_DAYS. The
+// paywall lets trialing organizations through, so paid features are
+// available until the trial ends. Subscribing through checkout replaces the
+// trial with the provider subscription.
+//
+// The trial job reminds the admins BILLING_TRIAL_REMINDER_DAYS before a trial
+// ends, including trials run by the billing provider, and expires local
+// trials that ended, which locks paid features until the organization
+// subscribes.
+type TrialService interface {
+	// StartTrial starts a trial for a new organization. Organizations that
+	// already have a subscription and staging organizations are skipped.
+	StartTrial(ctx context.Context, organizationID int32) error
+
+	// Run checks trials every BILLING_TRIAL_CHECK_INTERVAL until ctx is cancelled
+	Run(ctx context.Context)
+
+	// CheckTrials runs the trial job once
+	CheckTrials(ctx context.Context) error
+}
+
+type trialService struct {
+	trials        domain.TrialRepository
+	subscriptions domain.SubscriptionRepository
+	orgAdapter    domain.OrganizationAdapter
+	sender        emailDomain.Sender
+	config        *TrialConfig
+	tracker       jobsDomain.Tracker
+	job           jobsDomain.Definition
+	logger        logger.Logger
+}
+
+func NewTrialService(
+	trials domain.TrialRepository,
+	subscriptions domain.SubscriptionRepository,
+	orgAdapter domain.OrganizationAdapter,
+	sender emailDomain.Sender,
+	config *TrialConfig,
+	tracker jobsDomain.Tracker,
+	logger logger.Logger,
+) TrialService {
+	s := &trialService{
+		trials:        trials,
+		subscriptions: subscriptions,
+		orgAdapter:    orgAdapter,
+		sender:        sender,
+		config:        config,
+		tracker:       tracker,
+		job: jobsDomain.Definition{
+			Name:        "billing.trial_expiry",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Sends trial-ending reminders and locks paid features when a free trial ends",
+			Schedule:    "every " + config.CheckInterval.String(),
+		},
+		logger: logger.Named("billing"),
+	}
+	if config.Enabled && config.CheckInterval > 0 {
+		tracker.Register(s.job)
+	}
+	return s
+}
+
+func (s *trialService) StartTrial(ctx context.Context, organizationID int32) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	_, staging, err := s.orgAdapter.GetBillingOrganizationID(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve billing organization: %w", err)
+	}
+	if staging {
+		return nil
+	}
+
+	externalCustomerID, err := s.orgAdapter.GetStytchOrgID(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to get external customer ID: %w", err)
+	}
+
+	now := time.Now().UTC()
+	end := now.Add(s.config.Length())
+	trial, err := s.trials.StartTrial(ctx, &domain.Subscription{
+		OrganizationID:     organizationID,
+		ExternalCustomerID: externalCustomerID,
+		SubscriptionID:     fmt.Sprintf("trial_%d", organizationID),
+		ProductID:          domain.TrialProductID,
+		ProductName:        "Free trial",
+		PlanName:           "trial",
+		TrialStart:         &now,
+		TrialEnd:           &end,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrSubscriptionExists) {
+			s.logger.Debug("trial not started, organization already has a subscription", logger.Fields{
+				"organization_id": organizationID,
+			})
+			return nil
+		}
+		return err
+	}
+
+	if _, err := s.subscriptions.UpsertQuota(ctx, &domain.QuotaTracking{
+		OrganizationID: organizationID,
+		InvoiceCount:   s.config.InvoiceQuota,
+		PeriodStart:    now,
+		PeriodEnd:      end,
+		LastSyncedAt:   &now,
+	}); err != nil {
+		return fmt.Errorf("failed to set trial quota: %w", err)
+	}
+
+	s.logger.Info("trial started", logger.Fields{
+		"organization_id": organizationID,
+		"trial_end":       trial.TrialEnd,
+	})
+	return nil
+}
+
+func (s *trialService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.CheckInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("trial expiry started", logger.Fields{
+		"interval":      s.config.CheckInterval.String(),
+		"reminder_days": s.config.ReminderDays,
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.CheckTrials); err != nil {
+			s.logger.Error("trial check failed", logger.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *trialService) CheckTrials(ctx context.Context) error {
+	var errs []error
+	if s.config.ReminderDays > 0 {
+		if err := s.sendReminders(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := s.expireTrials(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// sendReminders emails the admins of trials ending within the reminder lead.
+// A trial whose email failed is not marked and is retried on the next run.
+func (s *trialService) sendReminders(ctx context.Context) error {
+	var errs []error
+	var reminded int
+	seen := make(map[int32]bool)
+	for {
+		now := time.Now()
+		trials, err := s.trials.ListEndingBetween(ctx, now, now.Add(s.config.ReminderLead()), int32(s.config.BatchSize))
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		fresh := 0
+		for _, trial := range trials {
+			// A failed reminder stays listed; leave it for the next run
+			if seen[trial.OrganizationID] {
+				continue
+			}
+			seen[trial.OrganizationID] = true
+			fresh++
+
+			if err := s.remind(ctx, trial, now); err != nil {
+				errs = append(errs, fmt.Errorf("organization %d: %w", trial.OrganizationID, err))
+				continue
+			}
+			reminded++
+		}
+
+		if len(trials) < s.config.BatchSize || fresh == 0 {
+			break
+		}
+	}
+
+	if reminded > 0 {
+		s.logger.Info("trial reminders sent", logger.Fields{"organizations": reminded})
+	}
+	return errors.Join(errs...)
+}
+
+func (s *trialService) remind(ctx context.Context, trial *domain.Subscription, now time.Time) error {
+	recipients, err := s.trials.ListReminderRecipients(ctx, trial.OrganizationID)
+	if err != nil {
+		return err
+	}
+
+	if len(recipients) > 0 {
+		sendCtx, cancel := context.WithTimeout(ctx, trialReminderEmailTimeout)
+		defer cancel()
+
+		if err := s.sender.Send(sendCtx, s.reminderMessage(trial, recipients, now)); err != nil {
+			return fmt.Errorf("failed to send trial reminder: %w", err)
+		}
+	} else {
+		s.logger.Warn("trial ending but no admin to remind", logger.Fields{
+			"organization_id": trial.OrganizationID,
+		})
+	}
+
+	return s.trials.MarkReminderSent(ctx, trial.OrganizationID, now)
+}
+
+func (s *trialService) reminderMessage(trial *domain.Subscription, recipients []string, now time.Time) *emailDomain.Message {
+	days := int(math.Ceil(trial.TrialEnd.Sub(now).Hours() / 24))
+	subject := fmt.Sprintf("Your free trial ends in %d days", days)
+	if days <= 1 {
+		subject = "Your free trial ends within a day"
+	}
+
+	ends := trial.TrialEnd.UTC().Format("January 2, 2006 15:04 UTC")
+	var body string
+	if trial.IsLocalTrial() {
+		body = fmt.Sprintf("Your organization's free trial ends on %s.\n\n"+
+			"Subscribe before then to keep using paid features:\n%s\n\n"+
+			"When the trial ends, paid features are locked until you subscribe. Your data is kept.",
+			ends, s.config.UpgradeURL)
+	} else {
+		body = fmt.Sprintf("Your organization's free trial of %s ends on %s.\n\n"+
+			"Your subscription starts automatically when the trial ends. To review or change it:\n%s",
+			trial.ProductName, ends, s.config.UpgradeURL)
+	}
+
+	return &emailDomain.Message{
+		To:      recipients,
+		Subject: subject,
+		Body:    body,
+	}
+}
+
+// expireTrials expires local trials that ended. Provider trials are ended by
+// the provider's webhooks.
+func (s *trialService) expireTrials(ctx context.Context) error {
+	var errs []error
+	var expired int
+	seen := make(map[int32]bool)
+	for {
+		trials, err := s.trials.ListExpired(ctx, time.Now(), int32(s.config.BatchSize))
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+
+		fresh := 0
+		for _, trial := range trials {
+			// A trial that failed to expire stays listed; leave it for the next run
+			if seen[trial.OrganizationID] {
+				continue
+			}
+			seen[trial.OrganizationID] = true
+			fresh++
+
+			if _, err := s.trials.Expire(ctx, trial.OrganizationID); err != nil {
+				// The organization subscribed since the trial was listed
+				if errors.Is(err, domain.ErrTrialNotActive) {
+					continue
+				}
+				errs = append(errs, fmt.Errorf("organization %d: %w", trial.OrganizationID, err))
+				continue
+			}
+			expired++
+
+			s.logger.Info("trial expired", logger.Fields{
+				"organization_id": trial.OrganizationID,
+				"trial_end":       trial.TrialEnd,
+			})
+		}
+
+		if len(trials) < s.config.BatchSize || fresh == 0 {
+			break
+		}
+	}
+
+	if expired > 0 {
+		s.logger.Debug("trials expired", logger.Fields{"organizations": expired})
+	}
+	return errors.Join(errs...)
+}
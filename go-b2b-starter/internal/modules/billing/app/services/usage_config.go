@@ -0,0 +1,115 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+)
+
+// UsageConfig controls usage metering: recording, hourly rollups and
+// reporting to the billing provider.
+//
+// All values can be set via environment variables with the BILLING_USAGE_ prefix.
+type UsageConfig struct {
+	// Enabled records usage; when false Record is a no-op and no job runs
+	Enabled bool `mapstructure:"BILLING_USAGE_ENABLED"`
+
+	// RollupInterval is the time between rollup runs. Zero disables the
+	// scheduled rollup.
+	RollupInterval time.Duration `mapstructure:"BILLING_USAGE_ROLLUP_INTERVAL"`
+
+	// RollupLookback is how far back buckets are recomputed, so events that
+	// arrive late are still counted. Older events are rejected.
+	RollupLookback time.Duration `mapstructure:"BILLING_USAGE_ROLLUP_LOOKBACK"`
+
+	// EventRetention is how long raw events are kept; rollups are kept
+	EventRetention time.Duration `mapstructure:"BILLING_USAGE_EVENT_RETENTION"`
+
+	// ReportBatchSize is how many buckets are reported at a time
+	ReportBatchSize int `mapstructure:"BILLING_USAGE_REPORT_BATCH_SIZE"`
+
+	// Meter slugs of the metered prices per metric; empty leaves the metric
+	// unreported
+	LLMTokensMeter    string `mapstructure:"BILLING_USAGE_METER_LLM_TOKENS"`
+	OCRPagesMeter     string `mapstructure:"BILLING_USAGE_METER_OCR_PAGES"`
+	StorageBytesMeter string `mapstructure:"BILLING_USAGE_METER_STORAGE"`
+}
+
+// LoadUsageConfig loads the usage configuration from environment variables and app.env file.
+func LoadUsageConfig() (*UsageConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("BILLING_USAGE_ENABLED", true)
+	v.SetDefault("BILLING_USAGE_ROLLUP_INTERVAL", "5m")
+	v.SetDefault("BILLING_USAGE_ROLLUP_LOOKBACK", "48h")
+	v.SetDefault("BILLING_USAGE_EVENT_RETENTION", "2160h")
+	v.SetDefault("BILLING_USAGE_REPORT_BATCH_SIZE", 500)
+	v.SetDefault("BILLING_USAGE_METER_LLM_TOKENS", "")
+	v.SetDefault("BILLING_USAGE_METER_OCR_PAGES", "")
+	v.SetDefault("BILLING_USAGE_METER_STORAGE", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg UsageConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode billing usage config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the rollup schedule, retention and batch size.
+func (c *UsageConfig) Validate() error {
+	c.LLMTokensMeter = strings.TrimSpace(c.LLMTokensMeter)
+	c.OCRPagesMeter = strings.TrimSpace(c.OCRPagesMeter)
+	c.StorageBytesMeter = strings.TrimSpace(c.StorageBytesMeter)
+
+	if !c.Enabled {
+		return nil
+	}
+	if c.RollupInterval < 0 {
+		return fmt.Errorf("billing usage config invalid: BILLING_USAGE_ROLLUP_INTERVAL must not be negative")
+	}
+	if c.RollupLookback < time.Hour {
+		return fmt.Errorf("billing usage config invalid: BILLING_USAGE_ROLLUP_LOOKBACK must be at least 1h")
+	}
+	if c.EventRetention <= c.RollupLookback {
+		return fmt.Errorf("billing usage config invalid: BILLING_USAGE_EVENT_RETENTION must be longer than BILLING_USAGE_ROLLUP_LOOKBACK")
+	}
+	if c.ReportBatchSize < 1 {
+		return fmt.Errorf("billing usage config invalid: BILLING_USAGE_REPORT_BATCH_SIZE must be at least 1")
+	}
+	return nil
+}
+
+// MeterSlug returns the meter a metric is reported to, or "" when it is not reported.
+func (c *UsageConfig) MeterSlug(metric domain.UsageMetric) string {
+	switch metric {
+	case domain.UsageMetricLLMTokens:
+		return c.LLMTokensMeter
+	case domain.UsageMetricOCRPages:
+		return c.OCRPagesMeter
+	case domain.UsageMetricStorageBytes:
+		return c.StorageBytesMeter
+	default:
+		return ""
+	}
+}
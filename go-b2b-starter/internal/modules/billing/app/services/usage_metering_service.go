@@ -0,0 +1,322 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	logger "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// UsageMeteringService records metered usage (LLM tokens, OCR pages, storage)
+// and feeds it to the billing provider for metered prices.
+//
+// Features call Record as usage happens. A scheduled job rolls the events up
+// into hourly buckets per organization and metric, reports the closed buckets
+// to the provider and deletes events past retention. Buckets are recomputed
+// from the events, so a run that failed halfway is repaired by the next one.
+type UsageMeteringService interface {
+	// Record stores a usage event. Events repeating an idempotency key are
+	// ignored, so callers may retry.
+	Record(ctx context.Context, event *domain.UsageEvent) error
+
+	// GetUsage returns an organization's rolled up usage per metric in [from, to)
+	GetUsage(ctx context.Context, organizationID int32, from, to time.Time) (*domain.UsageSummary, error)
+
+	// Run rolls up and reports usage every BILLING_USAGE_ROLLUP_INTERVAL
+	// until ctx is cancelled
+	Run(ctx context.Context)
+
+	// RollupAndReport runs the rollup job once
+	RollupAndReport(ctx context.Context) error
+}
+
+// maxUsageClockSkew is how far in the future a usage event may occur
+const maxUsageClockSkew = 5 * time.Minute
+
+type usageMeteringService struct {
+	repo            domain.UsageRepository
+	orgAdapter      domain.OrganizationAdapter
+	billingProvider domain.BillingProvider
+	config          *UsageConfig
+	tracker         jobsDomain.Tracker
+	job             jobsDomain.Definition
+	logger          logger.Logger
+}
+
+func NewUsageMeteringService(
+	repo domain.UsageRepository,
+	orgAdapter domain.OrganizationAdapter,
+	billingProvider domain.BillingProvider,
+	config *UsageConfig,
+	tracker jobsDomain.Tracker,
+	logger logger.Logger,
+) UsageMeteringService {
+	s := &usageMeteringService{
+		repo:            repo,
+		orgAdapter:      orgAdapter,
+		billingProvider: billingProvider,
+		config:          config,
+		tracker:         tracker,
+		job: jobsDomain.Definition{
+			Name:        "billing.usage_rollup",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Rolls up usage events hourly and reports closed hours to the billing provider",
+			Schedule:    "every " + config.RollupInterval.String(),
+		},
+		logger: logger.Named("billing"),
+	}
+	tracker.Register(s.job)
+	return s
+}
+
+func (s *usageMeteringService) Record(ctx context.Context, event *domain.UsageEvent) error {
+	if !s.config.Enabled {
+		return nil
+	}
+
+	if _, ok := domain.LookupUsageMetric(event.Metric); !ok {
+		return fmt.Errorf("%w: %s", domain.ErrUnknownUsageMetric, event.Metric)
+	}
+	if event.OrganizationID <= 0 {
+		return fmt.Errorf("%w: organization is required", domain.ErrInvalidUsageEvent)
+	}
+	if event.Quantity < 0 {
+		return fmt.Errorf("%w: quantity must not be negative", domain.ErrInvalidUsageEvent)
+	}
+
+	now := time.Now()
+	recorded := *event
+	if recorded.OccurredAt.IsZero() {
+		recorded.OccurredAt = now
+	}
+	// Events outside the lookback would never be rolled up
+	if recorded.OccurredAt.Before(now.Add(-s.config.RollupLookback)) {
+		return fmt.Errorf("%w: occurred before the rollup lookback of %s", domain.ErrInvalidUsageEvent, s.config.RollupLookback)
+	}
+	if recorded.OccurredAt.After(now.Add(maxUsageClockSkew)) {
+		return fmt.Errorf("%w: occurred in the future", domain.ErrInvalidUsageEvent)
+	}
+
+	inserted, err := s.repo.InsertEvent(ctx, &recorded)
+	if err != nil {
+		return err
+	}
+	if !inserted {
+		s.logger.Debug("duplicate usage event ignored", logger.Fields{
+			"organization_id": recorded.OrganizationID,
+			"metric":          recorded.Metric,
+			"idempotency_key": recorded.IdempotencyKey,
+		})
+	}
+	return nil
+}
+
+func (s *usageMeteringService) GetUsage(ctx context.Context, organizationID int32, from, to time.Time) (*domain.UsageSummary, error) {
+	rollups, err := s.repo.ListRollups(ctx, organizationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions := domain.UsageMetrics()
+	totals := make(map[domain.UsageMetric]*domain.UsageTotal, len(definitions))
+	summary := &domain.UsageSummary{
+		OrganizationID: organizationID,
+		From:           from,
+		To:             to,
+		Metrics:        make([]*domain.UsageTotal, 0, len(definitions)),
+	}
+	for _, definition := range definitions {
+		total := &domain.UsageTotal{
+			Metric:      definition.Metric,
+			Unit:        definition.Unit,
+			Aggregation: definition.Aggregation,
+		}
+		totals[definition.Metric] = total
+		summary.Metrics = append(summary.Metrics, total)
+	}
+
+	for _, rollup := range rollups {
+		total, ok := totals[rollup.Metric]
+		if !ok {
+			continue
+		}
+		total.EventCount += int64(rollup.EventCount)
+		if total.Aggregation == domain.UsageAggregationMax {
+			total.Quantity = max(total.Quantity, rollup.Quantity)
+		} else {
+			total.Quantity += rollup.Quantity
+		}
+	}
+
+	return summary, nil
+}
+
+func (s *usageMeteringService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.RollupInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("usage rollup started", logger.Fields{
+		"interval": s.config.RollupInterval.String(),
+		"lookback": s.config.RollupLookback.String(),
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.RollupAndReport); err != nil {
+			s.logger.Error("usage rollup failed", logger.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *usageMeteringService) RollupAndReport(ctx context.Context) error {
+	now := time.Now()
+
+	var gauges []domain.UsageMetric
+	for _, definition := range domain.UsageMetrics() {
+		if definition.Aggregation == domain.UsageAggregationMax {
+			gauges = append(gauges, definition.Metric)
+		}
+	}
+
+	// Whole hours only, so no bucket is recomputed from part of its events
+	changed, err := s.repo.Rollup(ctx, now.Add(-s.config.RollupLookback).Truncate(time.Hour), gauges)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	reported, err := s.report(ctx, now)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	deleted, err := s.repo.DeleteEventsBefore(ctx, now.Add(-s.config.EventRetention))
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if changed > 0 || reported > 0 || deleted > 0 {
+		s.logger.Debug("usage rolled up", logger.Fields{
+			"buckets":  changed,
+			"reported": reported,
+			"deleted":  deleted,
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// report sends the closed buckets of the metrics with a meter slug to the
+// billing provider. A bucket that fails stays unreported for the next run.
+func (s *usageMeteringService) report(ctx context.Context, now time.Time) (int, error) {
+	var metrics []domain.UsageMetric
+	for _, definition := range domain.UsageMetrics() {
+		if s.config.MeterSlug(definition.Metric) != "" {
+			metrics = append(metrics, definition.Metric)
+		}
+	}
+	if len(metrics) == 0 {
+		return 0, nil
+	}
+
+	customers := make(map[int32]string)
+	var errs []error
+	var reported int
+	var afterID int32
+	for {
+		rollups, err := s.repo.ListUnreported(ctx, metrics, now, afterID, int32(s.config.ReportBatchSize))
+		if err != nil {
+			return reported, errors.Join(append(errs, err)...)
+		}
+
+		for _, rollup := range rollups {
+			afterID = rollup.ID
+			sent, err := s.reportRollup(ctx, rollup, customers)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("organization %d %s at %s: %w",
+					rollup.OrganizationID, rollup.Metric, rollup.PeriodStart.Format(time.RFC3339), err))
+				continue
+			}
+			if sent {
+				reported++
+			}
+		}
+
+		if len(rollups) < s.config.ReportBatchSize {
+			return reported, errors.Join(errs...)
+		}
+	}
+}
+
+// reportRollup reports what a bucket added since its last report: the
+// difference for summed metrics, the bucket's maximum for gauges.
+// customers caches the customer ID per organization; "" means not billed.
+func (s *usageMeteringService) reportRollup(ctx context.Context, rollup *domain.UsageRollup, customers map[int32]string) (bool, error) {
+	definition, ok := domain.LookupUsageMetric(rollup.Metric)
+	if !ok {
+		return false, domain.ErrUnknownUsageMetric
+	}
+
+	customerID, ok := customers[rollup.OrganizationID]
+	if !ok {
+		var err error
+		if customerID, err = s.customerID(ctx, rollup.OrganizationID); err != nil {
+			return false, err
+		}
+		customers[rollup.OrganizationID] = customerID
+	}
+
+	quantity := rollup.Quantity
+	if definition.Aggregation == domain.UsageAggregationSum {
+		quantity -= rollup.ReportedQuantity
+	}
+
+	// Staging organizations are not billed, like their invoice quota, and
+	// nothing is sent when the bucket added nothing
+	if customerID == "" || quantity <= 0 {
+		return false, s.repo.MarkReported(ctx, rollup.ID, rollup.Quantity)
+	}
+
+	err := s.billingProvider.ReportUsage(ctx, &domain.UsageReport{
+		ExternalCustomerID: customerID,
+		MeterSlug:          s.config.MeterSlug(rollup.Metric),
+		Metric:             rollup.Metric,
+		Quantity:           definition.ReportQuantity(quantity),
+		PeriodStart:        rollup.PeriodStart,
+		PeriodEnd:          rollup.PeriodEnd,
+		// Unique per reported range, so a report resent after a failed mark is dropped
+		IdempotencyKey: fmt.Sprintf("usage:%d:%s:%d:%d-%d",
+			rollup.OrganizationID, rollup.Metric, rollup.PeriodStart.Unix(), rollup.ReportedQuantity, rollup.Quantity),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to report usage: %w", err)
+	}
+
+	return true, s.repo.MarkReported(ctx, rollup.ID, rollup.Quantity)
+}
+
+// customerID returns the billing customer of an organization, or "" for
+// staging organizations
+func (s *usageMeteringService) customerID(ctx context.Context, organizationID int32) (string, error) {
+	_, staging, err := s.orgAdapter.GetBillingOrganizationID(ctx, organizationID)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve billing organization: %w", err)
+	}
+	if staging {
+		return "", nil
+	}
+
+	customerID, err := s.orgAdapter.GetStytchOrgID(ctx, organizationID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get billing customer: %w", err)
+	}
+	return customerID, nil
+}
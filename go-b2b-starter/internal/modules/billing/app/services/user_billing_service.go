@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	logger "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// UserBillingService bills individual accounts instead of organizations, for
+// B2C products (BILLING_SCOPE=user).
+//
+// The billing provider knows each account as the customer "user_<public id>",
+// which the frontend passes as the customer external ID when it opens a
+// checkout. Subscriptions are kept in sync the same way as organization
+// subscriptions: verification on redirect, webhooks and refresh on demand.
+type UserBillingService interface {
+	// GetStatus returns the account's subscription status and its customer ID for checkouts
+	GetStatus(ctx context.Context, accountID int32) (*domain.UserBillingStatus, error)
+
+	// RefreshStatus syncs the account's subscription from the provider in case
+	// a webhook was missed and returns the updated status
+	RefreshStatus(ctx context.Context, accountID int32) (*domain.UserBillingStatus, error)
+
+	// VerifyPaymentFromCheckout stores the subscription of a succeeded checkout.
+	// The checkout must have been paid for the account's own customer ID.
+	VerifyPaymentFromCheckout(ctx context.Context, accountID int32, sessionID string) (*domain.UserBillingStatus, error)
+
+	// ApplySubscriptionEvent stores a subscription webhook of a user customer.
+	// Webhooks are applied in every scope so switching scopes loses no state.
+	ApplySubscriptionEvent(ctx context.Context, eventType string, eventData *domain.SubscriptionEventData) error
+}
+
+type userBillingService struct {
+	repo            domain.UserSubscriptionRepository
+	userAdapter     domain.UserAdapter
+	billingProvider domain.BillingProvider
+	config          *ProviderConfig
+	logger          logger.Logger
+}
+
+func NewUserBillingService(
+	repo domain.UserSubscriptionRepository,
+	userAdapter domain.UserAdapter,
+	billingProvider domain.BillingProvider,
+	config *ProviderConfig,
+	logger logger.Logger,
+) UserBillingService {
+	return &userBillingService{
+		repo:            repo,
+		userAdapter:     userAdapter,
+		billingProvider: billingProvider,
+		config:          config,
+		logger:          logger,
+	}
+}
+
+func (s *userBillingService) GetStatus(ctx context.Context, accountID int32) (*domain.UserBillingStatus, error) {
+	if !s.config.IsUserScope() {
+		return nil, domain.ErrUserBillingDisabled
+	}
+
+	externalID, err := s.userAdapter.GetExternalCustomerID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve billing customer: %w", err)
+	}
+
+	subscription, err := s.repo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSubscriptionNotFound) {
+			return &domain.UserBillingStatus{
+				AccountID:  accountID,
+				ExternalID: externalID,
+				Reason:     "no active subscription found",
+				CheckedAt:  time.Now(),
+			}, nil
+		}
+		return nil, err
+	}
+
+	return userBillingStatus(subscription, externalID), nil
+}
+
+func (s *userBillingService) RefreshStatus(ctx context.Context, accountID int32) (*domain.UserBillingStatus, error) {
+	if !s.config.IsUserScope() {
+		return nil, domain.ErrUserBillingDisabled
+	}
+
+	// Without a subscription there is nothing to refresh; a first payment is
+	// picked up by verification on redirect or its webhook
+	subscription, err := s.repo.GetByAccountID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrSubscriptionNotFound) {
+			return s.GetStatus(ctx, accountID)
+		}
+		return nil, err
+	}
+
+	if _, err := s.syncFromProvider(ctx, accountID, subscription.OrganizationID, subscription.ExternalCustomerID); err != nil {
+		return nil, fmt.Errorf("failed to refresh subscription from provider: %w", err)
+	}
+
+	return s.GetStatus(ctx, accountID)
+}
+
+func (s *userBillingService) VerifyPaymentFromCheckout(ctx context.Context, accountID int32, sessionID string) (*domain.UserBillingStatus, error) {
+	if !s.config.IsUserScope() {
+		return nil, domain.ErrUserBillingDisabled
+	}
+
+	externalID, err := s.userAdapter.GetExternalCustomerID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve billing customer: %w", err)
+	}
+
+	checkoutSession, err := s.billingProvider.GetCheckoutSessionWithPolling(ctx, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkout session: %w", err)
+	}
+	if checkoutSession.Status != "succeeded" {
+		return nil, fmt.Errorf("%w: status is %s", domain.ErrCheckoutNotSucceeded, checkoutSession.Status)
+	}
+
+	// A session ID alone must not let an account claim another customer's payment
+	if checkoutSession.CustomerID != externalID {
+		s.logger.Warn("Checkout session verified by another account", map[string]any{
+			"session_id": sessionID,
+			"account_id": accountID,
+		})
+		return nil, domain.ErrCheckoutCustomerMismatch
+	}
+
+	_, organizationID, err := s.userAdapter.GetAccountByExternalCustomerID(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve billing customer: %w", err)
+	}
+
+	subscription, err := s.syncFromProvider(ctx, accountID, organizationID, externalID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("User payment verified from checkout session", map[string]any{
+		"session_id":      sessionID,
+		"account_id":      accountID,
+		"subscription_id": subscription.SubscriptionID,
+		"status":          subscription.SubscriptionStatus,
+	})
+
+	return userBillingStatus(subscription, externalID), nil
+}
+
+func (s *userBillingService) ApplySubscriptionEvent(ctx context.Context, eventType string, eventData *domain.SubscriptionEventData) error {
+	accountID, organizationID, err := s.userAdapter.GetAccountByExternalCustomerID(ctx, eventData.ExternalCustomerID)
+	if err != nil {
+		return fmt.Errorf("failed to map account: %w", err)
+	}
+
+	subscription := &domain.UserSubscription{
+		AccountID:          accountID,
+		OrganizationID:     organizationID,
+		ExternalCustomerID: eventData.ExternalCustomerID,
+		SubscriptionID:     eventData.SubscriptionID,
+		SubscriptionStatus: eventData.Status,
+		ProductID:          eventData.ProductID,
+		ProductName:        eventData.ProductName,
+		CurrentPeriodStart: eventData.CurrentPeriodStart,
+		CurrentPeriodEnd:   eventData.CurrentPeriodEnd,
+		CancelAtPeriodEnd:  eventData.CancelAtPeriodEnd,
+		CanceledAt:         eventData.CanceledAt,
+	}
+
+	if eventType == "subscription.canceled" {
+		subscription.SubscriptionStatus = "canceled"
+		subscription.CancelAtPeriodEnd = false // Already canceled
+		if subscription.CanceledAt == nil {
+			now := time.Now()
+			subscription.CanceledAt = &now
+		}
+	}
+
+	if _, err := s.repo.Upsert(ctx, subscription); err != nil {
+		return fmt.Errorf("failed to upsert user subscription: %w", err)
+	}
+
+	s.logger.Info("Upserted user subscription", map[string]any{
+		"event_type":      eventType,
+		"account_id":      accountID,
+		"subscription_id": eventData.SubscriptionID,
+		"status":          subscription.SubscriptionStatus,
+	})
+
+	return nil
+}
+
+// syncFromProvider stores the account's subscription as the provider reports it.
+func (s *userBillingService) syncFromProvider(ctx context.Context, accountID, organizationID int32, externalID string) (*domain.UserSubscription, error) {
+	providerSubscription, err := s.billingProvider.GetSubscription(ctx, externalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch subscription from provider: %w", err)
+	}
+
+	subscription, err := s.repo.Upsert(ctx, &domain.UserSubscription{
+		AccountID:          accountID,
+		OrganizationID:     organizationID,
+		ExternalCustomerID: externalID,
+		SubscriptionID:     providerSubscription.SubscriptionID,
+		SubscriptionStatus: providerSubscription.SubscriptionStatus,
+		ProductID:          providerSubscription.ProductID,
+		ProductName:        providerSubscription.ProductName,
+		PlanName:           providerSubscription.PlanName,
+		CurrentPeriodStart: providerSubscription.CurrentPeriodStart,
+		CurrentPeriodEnd:   providerSubscription.CurrentPeriodEnd,
+		CancelAtPeriodEnd:  providerSubscription.CancelAtPeriodEnd,
+		CanceledAt:         providerSubscription.CanceledAt,
+		Metadata:           providerSubscription.Metadata,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save user subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+func userBillingStatus(subscription *domain.UserSubscription, externalID string) *domain.UserBillingStatus {
+	status := &domain.UserBillingStatus{
+		AccountID:             subscription.AccountID,
+		ExternalID:            externalID,
+		HasActiveSubscription: subscription.IsActive(),
+		SubscriptionStatus:    subscription.SubscriptionStatus,
+		ProductID:             subscription.ProductID,
+		ProductName:           subscription.ProductName,
+		CancelAtPeriodEnd:     subscription.CancelAtPeriodEnd,
+		Reason:                "ok",
+		CheckedAt:             time.Now(),
+	}
+	if !subscription.CurrentPeriodEnd.IsZero() {
+		periodEnd := subscription.CurrentPeriodEnd
+		status.CurrentPeriodEnd = &periodEnd
+	}
+	if !status.HasActiveSubscription {
+		status.Reason = fmt.Sprintf("subscription status: %s", subscription.SubscriptionStatus)
+	}
+	return status
+}
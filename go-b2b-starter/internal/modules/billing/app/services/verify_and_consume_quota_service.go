@@ -9,8 +9,14 @@ import (
 )
 
 func (s *billingService) VerifyAndConsumeQuota(ctx context.Context, organizationID int32) (*domain.BillingStatus, error) {
+	// Staging organizations are covered by their production parent's quota
+	billingOrgID, staging, err := s.orgAdapter.GetBillingOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve billing organization: %w", err)
+	}
+
 	// Step 1: Check database quota status
-	quotaStatus, err := s.repo.GetQuotaStatus(ctx, organizationID)
+	quotaStatus, err := s.repo.GetQuotaStatus(ctx, billingOrgID)
 	if err != nil {
 		return &domain.BillingStatus{
 			OrganizationID:        organizationID,
@@ -26,19 +32,19 @@ func (s *billingService) VerifyAndConsumeQuota(ctx context.Context, organization
 
 	if needsFallback {
 		s.logger.Info("Quota near limit or stale, performing fallback API verification", map[string]any{
-			"organization_id": organizationID,
+			"organization_id": billingOrgID,
 			"invoice_count":   quotaStatus.InvoiceCount,
 		})
 
 		// Sync from Polar and re-check
-		if err := s.SyncSubscriptionFromPolar(ctx, organizationID); err != nil {
+		if err := s.SyncSubscriptionFromPolar(ctx, billingOrgID); err != nil {
 			s.logger.Error("Fallback sync failed, using database data", map[string]any{
-				"organization_id": organizationID,
+				"organization_id": billingOrgID,
 				"error":           err.Error(),
 			})
 		} else {
 			// Re-fetch quota status after sync
-			quotaStatus, err = s.repo.GetQuotaStatus(ctx, organizationID)
+			quotaStatus, err = s.repo.GetQuotaStatus(ctx, billingOrgID)
 			if err != nil {
 				return nil, fmt.Errorf("failed to get quota after sync: %w", err)
 			}
@@ -49,7 +55,7 @@ func (s *billingService) VerifyAndConsumeQuota(ctx context.Context, organization
 	if !quotaStatus.CanProcessInvoice {
 		return &domain.BillingStatus{
 			OrganizationID:        organizationID,
-			HasActiveSubscription: quotaStatus.SubscriptionStatus == "active",
+			HasActiveSubscription: quotaStatus.IsActive(),
 			CanProcessInvoices:    false,
 			InvoiceCount:          quotaStatus.InvoiceCount,
 			Reason:                "quota exceeded or subscription inactive",
@@ -57,6 +63,18 @@ func (s *billingService) VerifyAndConsumeQuota(ctx context.Context, organization
 		}, domain.ErrQuotaExceeded
 	}
 
+	// Staging usage is free: verified against the parent but never consumed
+	if staging {
+		return &domain.BillingStatus{
+			OrganizationID:        organizationID,
+			HasActiveSubscription: true,
+			CanProcessInvoices:    true,
+			InvoiceCount:          quotaStatus.InvoiceCount,
+			Reason:                "staging organization: quota not consumed",
+			CheckedAt:             time.Now(),
+		}, nil
+	}
+
 	// Step 4: Decrement quota count (consume one invoice)
 	_, err = s.repo.DecrementInvoiceCount(ctx, organizationID)
 	if err != nil {
@@ -79,5 +97,5 @@ func (s *billingService) needsFallbackVerification(status *domain.QuotaStatus) b
 	// 1. Very few invoices remaining (< 10)
 	// 2. Subscription is inactive but we're checking
 
-	return status.InvoiceCount < 10 || status.SubscriptionStatus != "active"
+	return status.InvoiceCount < 10 || !status.IsActive()
 }
@@ -1,7 +1,14 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+
 	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/app/services"
+	orgEvents "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
 )
 
 //
@@ -9,15 +16,148 @@ import (
 //   - Webhook processing for subscription events
 //   - Quota tracking and consumption
 //   - Billing status queries
+//   - Usage metering rolled up hourly and reported for metered prices
+//   - Seat-based billing synced with organization membership
+//   - Free trials started on signup and expired by a scheduled job
 //
 // Communication is event-driven:
 //   - Polar sends webhook → billing processes event → updates local DB
 //   - Paywall middleware reads from local DB (no external API calls)
+//   - Members added, suspended or deleted → billing syncs the seat quantity
+//   - Organization signs up → billing starts its free trial
 func Init(container *dig.Container) error {
 	// Register all dependencies
 	if err := ProvideDependencies(container); err != nil {
 		return err
 	}
 
-	return nil
+	if err := wireSeatSync(container); err != nil {
+		return err
+	}
+
+	if err := wireTrials(container); err != nil {
+		return err
+	}
+
+	return startUsageRollup(container)
+}
+
+// wireSeatSync requests a seat sync whenever a member starts or stops
+// occupying a seat, and starts the seat sync job unless seat-based billing is
+// disabled or BILLING_SEATS_SYNC_INTERVAL is zero.
+func wireSeatSync(container *dig.Container) error {
+	if err := container.Invoke(func(
+		bus eventbus.EventBus,
+		service services.SeatService,
+	) error {
+		if err := bus.Subscribe(orgEvents.UserRegisteredEventType, func(ctx context.Context, event eventbus.Event) error {
+			userEvent, ok := event.(*orgEvents.UserRegistered)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+			return service.RequestSync(ctx, userEvent.OrganizationID)
+		}); err != nil {
+			return err
+		}
+
+		if err := bus.Subscribe(orgEvents.UserSuspendedEventType, func(ctx context.Context, event eventbus.Event) error {
+			userEvent, ok := event.(*orgEvents.UserSuspended)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+			return service.RequestSync(ctx, userEvent.OrganizationID)
+		}); err != nil {
+			return err
+		}
+
+		if err := bus.Subscribe(orgEvents.UserReactivatedEventType, func(ctx context.Context, event eventbus.Event) error {
+			userEvent, ok := event.(*orgEvents.UserReactivated)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+			return service.RequestSync(ctx, userEvent.OrganizationID)
+		}); err != nil {
+			return err
+		}
+
+		return bus.Subscribe(orgEvents.UserDeletedEventType, func(ctx context.Context, event eventbus.Event) error {
+			userEvent, ok := event.(*orgEvents.UserDeleted)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+			return service.RequestSync(ctx, userEvent.OrganizationID)
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to wire seat sync listener: %w", err)
+	}
+
+	var enabled bool
+	if err := container.Invoke(func(cfg *services.SeatConfig) {
+		enabled = cfg.Enabled && cfg.SyncInterval > 0
+	}); err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	return container.Invoke(func(service services.SeatService) {
+		go service.Run(context.Background())
+	})
+}
+
+// wireTrials starts a free trial for organizations created by signup, and
+// starts the trial job unless trials are disabled or
+// BILLING_TRIAL_CHECK_INTERVAL is zero.
+func wireTrials(container *dig.Container) error {
+	if err := container.Invoke(func(
+		bus eventbus.EventBus,
+		service services.TrialService,
+	) error {
+		return bus.Subscribe(orgEvents.UserRegisteredEventType, func(ctx context.Context, event eventbus.Event) error {
+			userEvent, ok := event.(*orgEvents.UserRegistered)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+			// Only the owner's signup creates an organization; invited members join one
+			if userEvent.Source != orgEvents.UserSourceSignup {
+				return nil
+			}
+			return service.StartTrial(ctx, userEvent.OrganizationID)
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to wire trial listener: %w", err)
+	}
+
+	var enabled bool
+	if err := container.Invoke(func(cfg *services.TrialConfig) {
+		enabled = cfg.Enabled && cfg.CheckInterval > 0
+	}); err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	return container.Invoke(func(service services.TrialService) {
+		go service.Run(context.Background())
+	})
+}
+
+// startUsageRollup starts the usage rollup job unless usage metering is
+// disabled or BILLING_USAGE_ROLLUP_INTERVAL is zero.
+func startUsageRollup(container *dig.Container) error {
+	var enabled bool
+	if err := container.Invoke(func(cfg *services.UsageConfig) {
+		enabled = cfg.Enabled && cfg.RollupInterval > 0
+	}); err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	return container.Invoke(func(service services.UsageMeteringService) {
+		go service.Run(context.Background())
+	})
 }
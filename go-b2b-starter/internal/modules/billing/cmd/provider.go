@@ -27,5 +27,13 @@ func ProvideDependencies(container *dig.Container) error {
 		return fmt.Errorf("failed to provide subscription status provider: %w", err)
 	}
 
+	// Register SeatGuard so the organizations module can enforce seat limits
+	// when members are added
+	if err := container.Provide(func(svc services.SeatService) paywall.SeatGuard {
+		return adapters.NewSeatGuardAdapter(svc)
+	}); err != nil {
+		return fmt.Errorf("failed to provide seat guard: %w", err)
+	}
+
 	return nil
 }
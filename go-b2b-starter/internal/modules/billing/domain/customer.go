@@ -0,0 +1,18 @@
+package domain
+
+import "strings"
+
+// UserCustomerPrefix starts the billing provider customer ID of an individual
+// account. Organizations are billed under their auth provider organization ID,
+// which never has this prefix, so webhooks can tell the two apart.
+const UserCustomerPrefix = "user_"
+
+// UserCustomerID returns the billing provider customer ID of an account
+func UserCustomerID(accountPublicID string) string {
+	return UserCustomerPrefix + accountPublicID
+}
+
+// IsUserCustomerID reports whether a customer ID belongs to an individual account
+func IsUserCustomerID(externalCustomerID string) bool {
+	return strings.HasPrefix(externalCustomerID, UserCustomerPrefix)
+}
@@ -26,4 +26,46 @@ var (
 
 	// ErrCheckoutSessionNotFound is returned when a checkout session cannot be found
 	ErrCheckoutSessionNotFound = errors.New("checkout session not found")
+
+	// ErrUserBillingDisabled is returned by user-scoped billing when BILLING_SCOPE is not "user"
+	ErrUserBillingDisabled = errors.New("user-scoped billing is disabled")
+
+	// ErrCheckoutNotSucceeded is returned when a checkout session was not paid
+	ErrCheckoutNotSucceeded = errors.New("checkout session has not succeeded")
+
+	// ErrCheckoutCustomerMismatch is returned when a checkout was paid for another customer
+	ErrCheckoutCustomerMismatch = errors.New("checkout session belongs to another customer")
+
+	// ErrUnknownUsageMetric is returned when usage is recorded for a metric that is not metered
+	ErrUnknownUsageMetric = errors.New("unknown usage metric")
+
+	// ErrInvalidUsageEvent is returned when a usage event cannot be recorded
+	ErrInvalidUsageEvent = errors.New("invalid usage event")
+
+	// ErrSeatBillingDisabled is returned by seat management when BILLING_SEATS_ENABLED is false
+	ErrSeatBillingDisabled = errors.New("seat-based billing is disabled")
+
+	// ErrSeatLimitReached is returned when every purchased seat is used and auto-expand is off
+	ErrSeatLimitReached = errors.New("all purchased seats are in use")
+
+	// ErrNotSeatBased is returned when seats are managed for a plan that is not billed per seat
+	ErrNotSeatBased = errors.New("subscription is not seat-based")
+
+	// ErrInvalidSeatCount is returned for a seat quantity below one or below the seats in use
+	ErrInvalidSeatCount = errors.New("invalid seat count")
+
+	// ErrSubscriptionExists is returned when a trial is started for an organization that has a subscription
+	ErrSubscriptionExists = errors.New("organization already has a subscription")
+
+	// ErrTrialNotActive is returned when a trial to expire converted or ended already
+	ErrTrialNotActive = errors.New("trial is not active")
+
+	// ErrBillingSettingsNotFound is returned when an organization has no billing settings
+	ErrBillingSettingsNotFound = errors.New("billing settings not found")
+
+	// ErrInvalidCurrency is returned for a currency that is not an ISO 4217 code
+	ErrInvalidCurrency = errors.New("invalid currency code")
+
+	// ErrInvalidLocale is returned for a locale that is not a BCP 47 language tag
+	ErrInvalidLocale = errors.New("invalid locale")
 )
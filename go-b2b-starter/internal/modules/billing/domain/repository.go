@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // SubscriptionRepository provides database operations for subscriptions and quotas
 type SubscriptionRepository interface {
@@ -18,10 +21,106 @@ type SubscriptionRepository interface {
 	GetQuotaStatus(ctx context.Context, organizationID int32) (*QuotaStatus, error)
 }
 
+// UserSubscriptionRepository provides database operations for subscriptions
+// paid by individual accounts
+type UserSubscriptionRepository interface {
+	// GetByAccountID returns ErrSubscriptionNotFound when the account has none
+	GetByAccountID(ctx context.Context, accountID int32) (*UserSubscription, error)
+	Upsert(ctx context.Context, subscription *UserSubscription) (*UserSubscription, error)
+}
+
+// BillingSettingsRepository persists an organization's currency and locale preferences
+type BillingSettingsRepository interface {
+	// GetSettings returns ErrBillingSettingsNotFound when the organization has none
+	GetSettings(ctx context.Context, organizationID int32) (*BillingSettings, error)
+	UpsertSettings(ctx context.Context, settings *BillingSettings) (*BillingSettings, error)
+}
+
+// UsageRepository records usage events and maintains their hourly rollups
+type UsageRepository interface {
+	// InsertEvent returns false when an event with the same idempotency key
+	// was already recorded for the organization
+	InsertEvent(ctx context.Context, event *UsageEvent) (bool, error)
+
+	// Rollup recomputes the buckets of the events since the start of an hour
+	// and returns how many buckets changed
+	Rollup(ctx context.Context, since time.Time, gaugeMetrics []UsageMetric) (int64, error)
+
+	// ListUnreported lists buckets of the metrics that ended by closedBefore
+	// and have quantity not reported yet, with IDs after afterID
+	ListUnreported(ctx context.Context, metrics []UsageMetric, closedBefore time.Time, afterID, limit int32) ([]*UsageRollup, error)
+	MarkReported(ctx context.Context, rollupID int32, reportedQuantity int64) error
+
+	// ListRollups lists an organization's buckets starting in [from, to)
+	ListRollups(ctx context.Context, organizationID int32, from, to time.Time) ([]*UsageRollup, error)
+
+	// DeleteEventsBefore deletes events that occurred before cutoff; their buckets are kept
+	DeleteEventsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// SeatRepository tracks purchased and used seats and schedules their sync
+// with the billing provider
+type SeatRepository interface {
+	// GetAllocation returns an empty allocation when the organization has none
+	GetAllocation(ctx context.Context, organizationID int32) (*SeatAllocation, error)
+	SetPurchasedSeats(ctx context.Context, organizationID, seats int32) (*SeatAllocation, error)
+	SetAutoExpand(ctx context.Context, organizationID int32, autoExpand bool) (*SeatAllocation, error)
+
+	// CountUsedSeats counts the active and dormant members; service accounts,
+	// OAuth clients and guests do not occupy a seat
+	CountUsedSeats(ctx context.Context, organizationID int32) (int32, error)
+
+	// RequestSync schedules a sync at dueAt unless one is already scheduled
+	RequestSync(ctx context.Context, organizationID int32, dueAt time.Time) error
+	// RequestSyncForSubscribed schedules a sync for every organization with
+	// an active or trialing subscription and returns how many were scheduled
+	RequestSyncForSubscribed(ctx context.Context, dueAt time.Time) (int64, error)
+	ListDue(ctx context.Context, dueBefore time.Time, limit int32) ([]*SeatAllocation, error)
+
+	// MarkSynced records the seats counted at countedAt; a sync requested
+	// after countedAt stays due
+	MarkSynced(ctx context.Context, organizationID, usedSeats, purchasedSeats int32, countedAt time.Time) (*SeatAllocation, error)
+	MarkFailed(ctx context.Context, organizationID int32, syncErr string, retryAt time.Time) error
+}
+
+// TrialRepository starts and ends free trials, held as trialing subscriptions
+type TrialRepository interface {
+	// StartTrial stores a local trial; ErrSubscriptionExists when the
+	// organization already has a subscription
+	StartTrial(ctx context.Context, trial *Subscription) (*Subscription, error)
+
+	// ListEndingBetween returns trials ending in (after, before] whose admins
+	// were not reminded yet
+	ListEndingBetween(ctx context.Context, after, before time.Time, limit int32) ([]*Subscription, error)
+	MarkReminderSent(ctx context.Context, organizationID int32, sentAt time.Time) error
+	// ListReminderRecipients returns the emails of the organization's active admins
+	ListReminderRecipients(ctx context.Context, organizationID int32) ([]string, error)
+
+	// ListExpired returns local trials that ended before endedBefore
+	ListExpired(ctx context.Context, endedBefore time.Time, limit int32) ([]*Subscription, error)
+	// Expire ends a local trial; ErrTrialNotActive when it converted meanwhile
+	Expire(ctx context.Context, organizationID int32) (*Subscription, error)
+}
+
 // OrganizationAdapter provides access to organization data
 type OrganizationAdapter interface {
 	GetStytchOrgID(ctx context.Context, organizationID int32) (string, error)
 	GetOrganizationIDByStytchOrgID(ctx context.Context, stytchOrgID string) (int32, error)
+
+	// GetBillingOrganizationID returns the organization whose subscription
+	// covers organizationID: its production parent for staging and sandbox
+	// organizations (staging is true), otherwise organizationID itself
+	GetBillingOrganizationID(ctx context.Context, organizationID int32) (billingOrgID int32, staging bool, err error)
+}
+
+// UserAdapter provides access to the accounts billed in user scope
+type UserAdapter interface {
+	// GetExternalCustomerID returns the billing provider customer ID of an account
+	GetExternalCustomerID(ctx context.Context, accountID int32) (string, error)
+
+	// GetAccountByExternalCustomerID returns the account of a user customer ID
+	// and the organization the account belongs to
+	GetAccountByExternalCustomerID(ctx context.Context, externalCustomerID string) (accountID, organizationID int32, err error)
 }
 
 // BillingProvider defines operations for external billing providers
@@ -31,4 +130,9 @@ type BillingProvider interface {
 	GetCheckoutSession(ctx context.Context, sessionID string) (*CheckoutSessionResponse, error)
 	GetCheckoutSessionWithPolling(ctx context.Context, sessionID string) (*CheckoutSessionResponse, error)
 	IngestMeterEvent(ctx context.Context, externalCustomerID string, meterSlug string, amount int32) error
+	// ReportUsage reports rolled up usage for a metered price
+	ReportUsage(ctx context.Context, report *UsageReport) error
+	// UpdateSubscriptionSeats changes the seat quantity of a seat-based subscription
+	UpdateSubscriptionSeats(ctx context.Context, subscriptionID string, seats int32) error
+	ListPlans(ctx context.Context) ([]*Plan, error)
 }
@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// SeatAllocation holds the seats an organization purchased and the seats its
+// members use. PurchasedSeats is 0 when the plan is not seat-based.
+type SeatAllocation struct {
+	OrganizationID int32
+	PurchasedSeats int32
+	UsedSeats      int32
+	// AutoExpand grows and shrinks the subscription with membership instead
+	// of blocking new members once every seat is used
+	AutoExpand bool
+	// SyncDueAt is when the debounced sync runs; nil when nothing changed
+	SyncDueAt *time.Time
+	SyncedAt  *time.Time
+	SyncError string
+}
+
+// IsSeatBased reports whether the organization's plan is billed per seat
+func (a *SeatAllocation) IsSeatBased() bool {
+	return a.PurchasedSeats > 0
+}
+
+// TargetSeats is the seat quantity the subscription should have for
+// usedSeats members: their count under auto-expand, at least one, otherwise
+// the purchased quantity, which only an admin changes
+func (a *SeatAllocation) TargetSeats(usedSeats int32) int32 {
+	if !a.AutoExpand {
+		return a.PurchasedSeats
+	}
+	if usedSeats < 1 {
+		return 1
+	}
+	return usedSeats
+}
+
+// SeatStatus is an organization's seat usage as shown to admins
+type SeatStatus struct {
+	OrganizationID int32      `json:"organization_id"`
+	SeatBased      bool       `json:"seat_based"`
+	PurchasedSeats int32      `json:"purchased_seats"`
+	UsedSeats      int32      `json:"used_seats"`
+	AvailableSeats int32      `json:"available_seats"`
+	AutoExpand     bool       `json:"auto_expand"`
+	SyncPending    bool       `json:"sync_pending"`
+	SyncedAt       *time.Time `json:"synced_at,omitempty"`
+	SyncError      string     `json:"sync_error,omitempty"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+// TrialProductID is the product of local trials, which signup starts without
+// a provider subscription
+const TrialProductID = "trial"
+
+// TrialExpiredStatus is the status of a local trial that ended without
+// converting; it grants no access
+const TrialExpiredStatus = "expired"
+
+// IsTrialing reports whether the subscription is in its trial period
+func (s *Subscription) IsTrialing() bool {
+	return s.SubscriptionStatus == "trialing"
+}
+
+// IsLocalTrial reports whether the subscription is a local trial rather than
+// a subscription of the billing provider
+func (s *Subscription) IsLocalTrial() bool {
+	return s.ProductID == TrialProductID
+}
@@ -16,11 +16,44 @@ type Subscription struct {
 	CurrentPeriodEnd   time.Time
 	CancelAtPeriodEnd  bool
 	CanceledAt         *time.Time
+	// Seats is the seat quantity of a seat-based subscription, otherwise 0.
+	// It is read from the billing provider and kept in seat allocations.
+	Seats int32
+	// TrialStart and TrialEnd are the trial period, kept after the
+	// subscription converts; nil when it never had a trial
+	TrialStart *time.Time
+	TrialEnd   *time.Time
+	Metadata   map[string]any
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// UserSubscription is a subscription paid by an individual account instead of
+// its organization (user-scoped billing)
+type UserSubscription struct {
+	ID                 int32
+	AccountID          int32
+	OrganizationID     int32
+	ExternalCustomerID string
+	SubscriptionID     string
+	SubscriptionStatus string
+	ProductID          string
+	ProductName        string
+	PlanName           string
+	CurrentPeriodStart time.Time
+	CurrentPeriodEnd   time.Time
+	CancelAtPeriodEnd  bool
+	CanceledAt         *time.Time
 	Metadata           map[string]any
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
 }
 
+// IsActive reports whether the subscription grants access
+func (s *UserSubscription) IsActive() bool {
+	return s.SubscriptionStatus == "active" || s.SubscriptionStatus == "trialing"
+}
+
 // QuotaTracking represents usage quota tracking for an organization
 type QuotaTracking struct {
 	ID             int32
@@ -43,20 +76,70 @@ type QuotaStatus struct {
 	CancelAtPeriodEnd  bool
 	InvoiceCount       int32 // Remaining invoices
 	MaxSeats           int32
+	TrialEnd           *time.Time
 	CanProcessInvoice  bool
 }
 
+// IsActive reports whether the subscription grants access
+func (s *QuotaStatus) IsActive() bool {
+	return s.SubscriptionStatus == "active" || s.SubscriptionStatus == "trialing"
+}
+
 // BillingStatus represents the overall billing status for quota verification
 type BillingStatus struct {
 	OrganizationID        int32
 	ExternalID            string
 	HasActiveSubscription bool
+	SubscriptionStatus    string
+	TrialEndsAt           *time.Time // Set while the subscription is trialing
 	CanProcessInvoices    bool
 	InvoiceCount          int32 // Remaining invoices
 	Reason                string
 	CheckedAt             time.Time
 }
 
+// UserBillingStatus is the subscription status of an individual account.
+// ExternalID is the customer ID to pass to the provider's checkout.
+type UserBillingStatus struct {
+	AccountID             int32      `json:"account_id"`
+	ExternalID            string     `json:"external_id"`
+	HasActiveSubscription bool       `json:"has_active_subscription"`
+	SubscriptionStatus    string     `json:"subscription_status,omitempty"`
+	ProductID             string     `json:"product_id,omitempty"`
+	ProductName           string     `json:"product_name,omitempty"`
+	CurrentPeriodEnd      *time.Time `json:"current_period_end,omitempty"`
+	CancelAtPeriodEnd     bool       `json:"cancel_at_period_end"`
+	Reason                string     `json:"reason"`
+	CheckedAt             time.Time  `json:"checked_at"`
+}
+
+// Plan represents a product offered by the billing provider
+type Plan struct {
+	ProductID   string            `json:"product_id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	IsRecurring bool              `json:"is_recurring"`
+	Interval    string            `json:"interval,omitempty"` // "month" or "year"
+	Prices      []*Price          `json:"prices"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// Price is the price of a plan in one currency
+type Price struct {
+	PriceID    string `json:"price_id"`
+	AmountType string `json:"amount_type"` // "fixed", "free", "custom", "metered_unit", ...
+	Amount     int64  `json:"amount"`      // In minor units (cents for USD)
+	Currency   string `json:"currency"`    // ISO 4217, upper case
+}
+
+// BillingSettings holds an organization's display preferences for prices and amounts.
+// Empty values are negotiated from the request.
+type BillingSettings struct {
+	OrganizationID int32  `json:"organization_id"`
+	Currency       string `json:"currency"`
+	Locale         string `json:"locale"`
+}
+
 // WebhookEvent represents a Polar webhook event
 type WebhookEvent struct {
 	EventType string
@@ -74,8 +157,13 @@ type SubscriptionEventData struct {
 	CurrentPeriodEnd   time.Time
 	CancelAtPeriodEnd  bool
 	CanceledAt         *time.Time
-	ProductMetadata    map[string]string
-	CustomerMetadata   map[string]string
+	// Seats is the seat quantity of a seat-based subscription, otherwise 0
+	Seats int32
+	// TrialStart and TrialEnd are set when the subscription has a trial
+	TrialStart       *time.Time
+	TrialEnd         *time.Time
+	ProductMetadata  map[string]string
+	CustomerMetadata map[string]string
 }
 
 // MeterGrantEventData represents meter grant payload details from Polar webhooks
@@ -0,0 +1,121 @@
+package domain
+
+import "time"
+
+// UsageMetric identifies a kind of metered usage
+type UsageMetric string
+
+const (
+	UsageMetricLLMTokens    UsageMetric = "llm_tokens"
+	UsageMetricOCRPages     UsageMetric = "ocr_pages"
+	UsageMetricStorageBytes UsageMetric = "storage_bytes"
+)
+
+// UsageAggregation is how the events of a rollup bucket are combined
+type UsageAggregation string
+
+const (
+	// UsageAggregationSum adds up consumption such as tokens and pages
+	UsageAggregationSum UsageAggregation = "sum"
+	// UsageAggregationMax keeps the peak of a gauge such as storage, which is
+	// recorded as samples of the current total
+	UsageAggregationMax UsageAggregation = "max"
+)
+
+// UsageMetricDefinition describes how a metric is recorded, rolled up and
+// reported to the billing provider
+type UsageMetricDefinition struct {
+	Metric      UsageMetric
+	Unit        string
+	Aggregation UsageAggregation
+	// ReportUnit is the unit metered prices are billed in; quantities are
+	// divided by ReportDivisor when reported
+	ReportUnit    string
+	ReportDivisor float64
+}
+
+// ReportQuantity converts a quantity to the unit billed by the provider
+func (d UsageMetricDefinition) ReportQuantity(quantity int64) float64 {
+	return float64(quantity) / d.ReportDivisor
+}
+
+var usageMetrics = []UsageMetricDefinition{
+	{Metric: UsageMetricLLMTokens, Unit: "tokens", Aggregation: UsageAggregationSum, ReportUnit: "tokens", ReportDivisor: 1},
+	{Metric: UsageMetricOCRPages, Unit: "pages", Aggregation: UsageAggregationSum, ReportUnit: "pages", ReportDivisor: 1},
+	{Metric: UsageMetricStorageBytes, Unit: "bytes", Aggregation: UsageAggregationMax, ReportUnit: "GB", ReportDivisor: 1e9},
+}
+
+// UsageMetrics returns the definitions of every metered metric
+func UsageMetrics() []UsageMetricDefinition {
+	return append([]UsageMetricDefinition(nil), usageMetrics...)
+}
+
+// LookupUsageMetric returns the definition of a metric
+func LookupUsageMetric(metric UsageMetric) (UsageMetricDefinition, bool) {
+	for _, definition := range usageMetrics {
+		if definition.Metric == metric {
+			return definition, true
+		}
+	}
+	return UsageMetricDefinition{}, false
+}
+
+// UsageEvent is usage recorded by a feature for an organization
+type UsageEvent struct {
+	OrganizationID int32
+	// AccountID is the account that caused the usage, if any
+	AccountID *int32
+	Metric    UsageMetric
+	// Quantity is in the metric's unit; gauges record their current total
+	Quantity int64
+	// Source is the feature that recorded the event, e.g. documents.ocr
+	Source string
+	// IdempotencyKey makes retries safe; a repeated key is ignored
+	IdempotencyKey string
+	// OccurredAt defaults to now
+	OccurredAt time.Time
+}
+
+// UsageRollup is an organization's usage of a metric in one hour
+type UsageRollup struct {
+	ID               int32
+	OrganizationID   int32
+	Metric           UsageMetric
+	PeriodStart      time.Time
+	PeriodEnd        time.Time
+	Quantity         int64
+	EventCount       int32
+	ReportedQuantity int64
+	ReportedAt       *time.Time
+}
+
+// UsageTotal is the usage of a metric over a period
+type UsageTotal struct {
+	Metric      UsageMetric      `json:"metric"`
+	Unit        string           `json:"unit"`
+	Aggregation UsageAggregation `json:"aggregation"`
+	Quantity    int64            `json:"quantity"`
+	EventCount  int64            `json:"event_count"`
+}
+
+// UsageSummary is an organization's usage per metric in [From, To).
+// It covers rolled up hours only, so recent usage appears after the next rollup.
+type UsageSummary struct {
+	OrganizationID int32         `json:"organization_id"`
+	From           time.Time     `json:"from"`
+	To             time.Time     `json:"to"`
+	Metrics        []*UsageTotal `json:"metrics"`
+}
+
+// UsageReport is usage reported to the billing provider for a metered price
+type UsageReport struct {
+	ExternalCustomerID string
+	MeterSlug          string
+	Metric             UsageMetric
+	// Quantity is in the metric's report unit
+	Quantity    float64
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+	// IdempotencyKey lets the provider drop a report sent twice
+	IdempotencyKey string
+}
@@ -1,9 +1,12 @@
 package billing
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,24 +15,46 @@ import (
 	billingServices "github.com/moasq/go-b2b-starter/internal/modules/billing/app/services"
 	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
 	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	polarpkg "github.com/moasq/go-b2b-starter/internal/platform/polar"
 	"github.com/moasq/go-b2b-starter/pkg/httperr"
 )
 
+// maxWebhookBodyBytes bounds the webhook body read before the signature is checked
+const maxWebhookBodyBytes = 1 << 20
+
 type Handler struct {
-	billingService billingServices.BillingService
-	logger         logger.Logger
+	billingService     billingServices.BillingService
+	userBillingService billingServices.UserBillingService
+	usageService       billingServices.UsageMeteringService
+	seatService        billingServices.SeatService
+	catalogService     billingServices.PlanCatalogService
+	providerConfig     *billingServices.ProviderConfig
+	logger             logger.Logger
 }
 
-func NewHandler(billingService billingServices.BillingService, log logger.Logger) *Handler {
+func NewHandler(
+	billingService billingServices.BillingService,
+	userBillingService billingServices.UserBillingService,
+	usageService billingServices.UsageMeteringService,
+	seatService billingServices.SeatService,
+	catalogService billingServices.PlanCatalogService,
+	providerConfig *billingServices.ProviderConfig,
+	log logger.Logger,
+) *Handler {
 	return &Handler{
-		billingService: billingService,
-		logger:         log,
+		billingService:     billingService,
+		userBillingService: userBillingService,
+		usageService:       usageService,
+		seatService:        seatService,
+		catalogService:     catalogService,
+		providerConfig:     providerConfig,
+		logger:             log,
 	}
 }
 
 // GetBillingStatus godoc
 // @Summary Get current billing and quota status
-// @Description Retrieve the current subscription billing status and invoice quota information for the organization
+// @Description Retrieve the current subscription billing status and invoice quota information for the organization. TrialEndsAt is set while the organization is on a free trial.
 // @Tags subscriptions
 // @Accept json
 // @Produce json
@@ -188,3 +213,269 @@ func (h *Handler) VerifyPayment(c *gin.Context) {
 
 	c.JSON(http.StatusOK, billingStatus)
 }
+
+// ListPlans godoc
+// @Summary List plans with localized prices
+// @Description Lists the active plans with prices in the organization's currency. The currency is negotiated from the currency query parameter, the organization's billing settings, then Accept-Language.
+// @Tags subscriptions
+// @Produce json
+// @Param currency query string false "ISO 4217 currency code, e.g. EUR"
+// @Param locale query string false "BCP 47 locale used to format amounts, e.g. de-DE"
+// @Param Accept-Language header string false "Preferred languages"
+// @Success 200 {object} billingServices.PlanCatalog "Plan catalog"
+// @Failure 400 {object} httperr.HTTPError "Missing organization context"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/plans [get]
+func (h *Handler) ListPlans(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	h.listPlans(c, reqCtx.OrganizationID)
+}
+
+// ListPublicPlans godoc
+// @Summary List plans with localized prices (public)
+// @Description Lists the active plans for pricing pages. The currency is negotiated from the currency query parameter, then Accept-Language.
+// @Tags subscriptions
+// @Produce json
+// @Param currency query string false "ISO 4217 currency code, e.g. EUR"
+// @Param locale query string false "BCP 47 locale used to format amounts, e.g. de-DE"
+// @Param Accept-Language header string false "Preferred languages"
+// @Success 200 {object} billingServices.PlanCatalog "Plan catalog"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/public/plans [get]
+func (h *Handler) ListPublicPlans(c *gin.Context) {
+	h.listPlans(c, 0)
+}
+
+func (h *Handler) listPlans(c *gin.Context, organizationID int32) {
+	catalog, err := h.catalogService.ListPlans(c.Request.Context(), &billingServices.PlanCatalogRequest{
+		OrganizationID: organizationID,
+		Currency:       c.Query("currency"),
+		Locale:         c.Query("locale"),
+		AcceptLanguage: c.GetHeader("Accept-Language"),
+	})
+	if err != nil {
+		h.logger.Error("failed to list plans", map[string]any{
+			"organization_id": organizationID,
+			"error":           err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"plans_unavailable",
+			"Failed to retrieve plans",
+		))
+		return
+	}
+
+	// Responses differ per language; keep shared caches from mixing them up
+	c.Header("Vary", "Accept-Language")
+	c.JSON(http.StatusOK, catalog)
+}
+
+// GetBillingSettings godoc
+// @Summary Get billing display settings
+// @Description Returns the organization's preferred currency and locale for prices and amounts. Empty values are negotiated per request.
+// @Tags subscriptions
+// @Produce json
+// @Success 200 {object} domain.BillingSettings "Billing settings"
+// @Failure 400 {object} httperr.HTTPError "Missing organization context"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/settings [get]
+func (h *Handler) GetBillingSettings(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	settings, err := h.catalogService.GetSettings(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.logger.Error("failed to get billing settings", map[string]any{
+			"organization_id": reqCtx.OrganizationID,
+			"error":           err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"billing_settings_failed",
+			"Failed to retrieve billing settings",
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateBillingSettings godoc
+// @Summary Update billing display settings
+// @Description Sets the organization's preferred currency (ISO 4217) and locale (BCP 47). Send an empty value to negotiate it per request again.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param request body billingServices.UpdateBillingSettingsRequest true "Currency and locale"
+// @Success 200 {object} domain.BillingSettings "Updated billing settings"
+// @Failure 400 {object} httperr.HTTPError "Invalid currency or locale"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/settings [put]
+func (h *Handler) UpdateBillingSettings(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	var req billingServices.UpdateBillingSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			fmt.Sprintf("Invalid request: %v", err),
+		))
+		return
+	}
+
+	settings, err := h.catalogService.UpdateSettings(c.Request.Context(), reqCtx.OrganizationID, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidCurrency) || errors.Is(err, domain.ErrInvalidLocale) {
+			c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+				http.StatusBadRequest,
+				"invalid_settings",
+				err.Error(),
+			))
+			return
+		}
+
+		h.logger.Error("failed to update billing settings", map[string]any{
+			"organization_id": reqCtx.OrganizationID,
+			"error":           err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"billing_settings_failed",
+			"Failed to update billing settings",
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// HandlePolarWebhook godoc
+// @Summary Receive billing provider webhook
+// @Description Receives subscription, customer and meter events signed with the Standard Webhooks scheme (webhook-id, webhook-timestamp and webhook-signature headers) and applies them to the local subscription state.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Success 200 {object} map[string]string "Event processed"
+// @Failure 400 {object} httperr.HTTPError "Malformed event"
+// @Failure 401 {object} httperr.HTTPError "Invalid or stale signature"
+// @Failure 500 {object} httperr.HTTPError "Event could not be processed, the provider will retry"
+// @Failure 503 {object} httperr.HTTPError "Webhook secret not configured"
+// @Router /api/webhooks/polar [post]
+func (h *Handler) HandlePolarWebhook(c *gin.Context) {
+	if h.providerConfig.WebhookSecret == "" {
+		h.logger.Error("[Webhook] Rejected event: WEBHOOK_SECRET is not configured", nil)
+		c.JSON(http.StatusServiceUnavailable, httperr.NewHTTPError(
+			http.StatusServiceUnavailable,
+			"webhooks_not_configured",
+			"Webhook secret is not configured",
+		))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodyBytes))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			"Failed to read webhook body",
+		))
+		return
+	}
+
+	webhookID := c.GetHeader("webhook-id")
+	timestamp := c.GetHeader("webhook-timestamp")
+	if err := h.verifyWebhookTimestamp(timestamp); err != nil {
+		h.logger.Warn("[Webhook] Rejected event with stale timestamp", map[string]any{
+			"webhook_id": webhookID,
+			"error":      err.Error(),
+		})
+		c.JSON(http.StatusUnauthorized, httperr.NewHTTPError(
+			http.StatusUnauthorized,
+			"invalid_signature",
+			err.Error(),
+		))
+		return
+	}
+
+	if err := polarpkg.VerifyWebhookSignature(h.providerConfig.WebhookSecret, webhookID, timestamp, body, c.GetHeader("webhook-signature")); err != nil {
+		h.logger.Warn("[Webhook] Rejected event with invalid signature", map[string]any{
+			"webhook_id": webhookID,
+			"error":      err.Error(),
+		})
+		c.JSON(http.StatusUnauthorized, httperr.NewHTTPError(
+			http.StatusUnauthorized,
+			"invalid_signature",
+			"Webhook signature verification failed",
+		))
+		return
+	}
+
+	var event struct {
+		Type string         `json:"type"`
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(body, &event); err != nil || event.Type == "" {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_event",
+			"Webhook body must be an event with a type and data",
+		))
+		return
+	}
+
+	if err := h.billingService.ProcessWebhookEvent(c.Request.Context(), event.Type, event.Data); err != nil {
+		h.logger.Error("[Webhook] Failed to process event", map[string]any{
+			"webhook_id": webhookID,
+			"event_type": event.Type,
+			"error":      err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"webhook_processing_failed",
+			"Failed to process webhook event",
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"status": "processed"})
+}
+
+// verifyWebhookTimestamp rejects events signed outside the configured tolerance,
+// so a captured request cannot be replayed later.
+func (h *Handler) verifyWebhookTimestamp(timestamp string) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("webhook timestamp is missing or invalid")
+	}
+	age := time.Since(time.Unix(seconds, 0))
+	if age > h.providerConfig.WebhookTolerance || age < -h.providerConfig.WebhookTolerance {
+		return fmt.Errorf("webhook timestamp is outside the allowed tolerance")
+	}
+	return nil
+}
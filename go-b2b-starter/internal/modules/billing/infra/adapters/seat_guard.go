@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"context"
+	"errors"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+)
+
+// SeatGuardAdapter adapts the SeatService to the paywall.SeatGuard interface.
+//
+// This adapter lets the organizations module enforce seat limits without
+// depending on the billing module.
+type SeatGuardAdapter struct {
+	service services.SeatService
+}
+
+func NewSeatGuardAdapter(service services.SeatService) paywall.SeatGuard {
+	return &SeatGuardAdapter{service: service}
+}
+
+// CheckSeatAvailable implements paywall.SeatGuard.
+//
+// It translates the billing seat limit error to paywall.ErrSeatLimitReached.
+func (a *SeatGuardAdapter) CheckSeatAvailable(ctx context.Context, organizationID int32) (bool, error) {
+	limited, err := a.service.CheckSeatAvailable(ctx, organizationID)
+	if errors.Is(err, domain.ErrSeatLimitReached) {
+		return true, paywall.ErrSeatLimitReached
+	}
+	return limited, err
+}
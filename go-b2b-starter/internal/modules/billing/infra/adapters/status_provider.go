@@ -5,6 +5,7 @@ import (
 	"context"
 
 	"github.com/moasq/go-b2b-starter/internal/modules/billing/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
 	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
 )
 
@@ -40,7 +41,10 @@ func (a *StatusProviderAdapter) GetSubscriptionStatus(ctx context.Context, organ
 
 	// Determine status string from reason
 	if billingStatus.HasActiveSubscription {
-		status.Status = paywall.StatusActive
+		status.Status = activeStatus(billingStatus)
+		if billingStatus.TrialEndsAt != nil {
+			status.ExpiresAt = *billingStatus.TrialEndsAt
+		}
 	} else if billingStatus.Reason == "no active subscription found" {
 		status.Status = paywall.StatusNone
 	} else {
@@ -72,7 +76,10 @@ func (a *StatusProviderAdapter) RefreshSubscriptionStatus(ctx context.Context, o
 
 	// Determine status string from reason
 	if billingStatus.HasActiveSubscription {
-		status.Status = paywall.StatusActive
+		status.Status = activeStatus(billingStatus)
+		if billingStatus.TrialEndsAt != nil {
+			status.ExpiresAt = *billingStatus.TrialEndsAt
+		}
 	} else if billingStatus.Reason == "no active subscription found" {
 		status.Status = paywall.StatusNone
 	} else {
@@ -83,6 +90,14 @@ func (a *StatusProviderAdapter) RefreshSubscriptionStatus(ctx context.Context, o
 	return status, nil
 }
 
+// activeStatus distinguishes trials from paid subscriptions that grant access.
+func activeStatus(billingStatus *domain.BillingStatus) string {
+	if billingStatus.SubscriptionStatus == paywall.StatusTrialing {
+		return paywall.StatusTrialing
+	}
+	return paywall.StatusActive
+}
+
 // parseStatusFromReason attempts to extract a subscription status from the reason string.
 func parseStatusFromReason(reason string) string {
 	// Check for common status patterns in reason
@@ -95,6 +110,8 @@ func parseStatusFromReason(reason string) string {
 		return paywall.StatusUnpaid
 	case containsStatus(reason, "trialing"):
 		return paywall.StatusTrialing
+	case containsStatus(reason, domain.TrialExpiredStatus):
+		return paywall.StatusExpired
 	default:
 		return paywall.StatusNone
 	}
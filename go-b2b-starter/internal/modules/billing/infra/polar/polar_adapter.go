@@ -55,6 +55,9 @@ func (p *polarAdapter) GetSubscription(ctx context.Context, externalCustomerID s
 			CurrentPeriodStart string `json:"current_period_start"`
 			CurrentPeriodEnd   string `json:"current_period_end"`
 			CanceledAt         *string `json:"canceled_at"`
+			Seats              *int32  `json:"seats"`
+			TrialStart         *string `json:"trial_start"`
+			TrialEnd           *string `json:"trial_end"`
 			Customer           struct {
 				ID       string            `json:"id"`
 				Metadata map[string]string `json:"metadata"`
@@ -87,6 +90,18 @@ func (p *polarAdapter) GetSubscription(ctx context.Context, externalCustomerID s
 		canceledAt = &t
 	}
 
+	var trialStart, trialEnd *time.Time
+	if polarSub.TrialStart != nil {
+		if t, err := parseTime(*polarSub.TrialStart); err == nil {
+			trialStart = &t
+		}
+	}
+	if polarSub.TrialEnd != nil {
+		if t, err := parseTime(*polarSub.TrialEnd); err == nil {
+			trialEnd = &t
+		}
+	}
+
 	// Parse quota limit from product metadata
 	invoiceCountMax := int32(0)
 	if val, ok := polarSub.Product.Metadata["invoice_count"]; ok {
@@ -95,6 +110,12 @@ func (p *polarAdapter) GetSubscription(ctx context.Context, externalCustomerID s
 		}
 	}
 
+	// Seat-based subscriptions carry their seat quantity
+	var seats int32
+	if polarSub.Seats != nil {
+		seats = *polarSub.Seats
+	}
+
 	// Log subscription sync
 	p.logger.Info("polar subscription sync completed", loggerdomain.Fields{
 		"customer_id":       externalCustomerID,
@@ -102,6 +123,7 @@ func (p *polarAdapter) GetSubscription(ctx context.Context, externalCustomerID s
 		"invoice_count_max": invoiceCountMax,
 		"status":            polarSub.Status,
 		"product_name":      polarSub.Product.Name,
+		"seats":             seats,
 	})
 
 	// Create domain subscription (organizationID will be set by caller)
@@ -114,6 +136,9 @@ func (p *polarAdapter) GetSubscription(ctx context.Context, externalCustomerID s
 		CurrentPeriodStart: currentPeriodStart,
 		CurrentPeriodEnd:   currentPeriodEnd,
 		CanceledAt:         canceledAt,
+		Seats:              seats,
+		TrialStart:         trialStart,
+		TrialEnd:           trialEnd,
 		Metadata: map[string]any{
 			"invoice_count_max":    invoiceCountMax,
 			"product_metadata":     polarSub.Product.Metadata,
@@ -375,6 +400,158 @@ func (p *polarAdapter) IngestMeterEvent(ctx context.Context, externalCustomerID
 	return nil
 }
 
+// ReportUsage ingests rolled up usage as a Polar event named after the meter.
+// The meter should aggregate the "quantity" metadata property (sum, or max for
+// gauges such as storage). The idempotency key is sent as the event's
+// external_id, so Polar drops a report that was already ingested.
+func (p *polarAdapter) ReportUsage(ctx context.Context, report *domain.UsageReport) error {
+	endpoint := "/v1/events/ingest"
+
+	body := map[string]any{
+		"events": []map[string]any{
+			{
+				"name":                 report.MeterSlug,
+				"external_customer_id": report.ExternalCustomerID,
+				"external_id":          report.IdempotencyKey,
+				"timestamp":            report.PeriodStart.UTC().Format(time.RFC3339),
+				"metadata": map[string]any{
+					"quantity":     report.Quantity,
+					"metric":       string(report.Metric),
+					"period_start": report.PeriodStart.UTC().Format(time.RFC3339),
+					"period_end":   report.PeriodEnd.UTC().Format(time.RFC3339),
+				},
+			},
+		},
+	}
+
+	resp, err := p.client.Post(ctx, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to call Polar events API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("polar events API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	p.logger.Debug("usage reported to polar", loggerdomain.Fields{
+		"customer_id": report.ExternalCustomerID,
+		"meter_slug":  report.MeterSlug,
+		"quantity":    report.Quantity,
+	})
+
+	return nil
+}
+
+// UpdateSubscriptionSeats changes the seat quantity of a seat-based
+// subscription. Polar prorates the change on the next invoice.
+func (p *polarAdapter) UpdateSubscriptionSeats(ctx context.Context, subscriptionID string, seats int32) error {
+	endpoint := fmt.Sprintf("/v1/subscriptions/%s", subscriptionID)
+
+	resp, err := p.client.Patch(ctx, endpoint, map[string]any{
+		"seats": seats,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to call Polar subscriptions API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("polar subscriptions API returned status %d: %s", resp.StatusCode, string(bodyBytes))
+	}
+
+	p.logger.Info("subscription seats updated", loggerdomain.Fields{
+		"subscription_id": subscriptionID,
+		"seats":           seats,
+	})
+
+	return nil
+}
+
+// ListPlans retrieves the active products and their prices from Polar.
+// Each price carries its own currency, so a product can be offered in several currencies.
+func (p *polarAdapter) ListPlans(ctx context.Context) ([]*domain.Plan, error) {
+	const pageSize = 100
+
+	var plans []*domain.Plan
+	for page := 1; ; page++ {
+		endpoint := fmt.Sprintf("/v1/products/?is_archived=false&limit=%d&page=%d", pageSize, page)
+
+		resp, err := p.client.Get(ctx, endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed to call Polar products API: %w", err)
+		}
+
+		var result struct {
+			Items []struct {
+				ID                string         `json:"id"`
+				Name              string         `json:"name"`
+				Description       string         `json:"description"`
+				IsRecurring       bool           `json:"is_recurring"`
+				RecurringInterval string         `json:"recurring_interval"`
+				Metadata          map[string]any `json:"metadata"`
+				Prices            []struct {
+					ID            string `json:"id"`
+					AmountType    string `json:"amount_type"`
+					IsArchived    bool   `json:"is_archived"`
+					PriceAmount   int64  `json:"price_amount"`
+					PresetAmount  *int64 `json:"preset_amount"`
+					PriceCurrency string `json:"price_currency"`
+				} `json:"prices"`
+			} `json:"items"`
+			Pagination struct {
+				MaxPage int `json:"max_page"`
+			} `json:"pagination"`
+		}
+
+		if err := polarpkg.DecodeJSON(resp, &result); err != nil {
+			return nil, fmt.Errorf("failed to decode products response: %w", err)
+		}
+
+		for _, item := range result.Items {
+			plan := &domain.Plan{
+				ProductID:   item.ID,
+				Name:        item.Name,
+				Description: item.Description,
+				IsRecurring: item.IsRecurring,
+				Interval:    item.RecurringInterval,
+				Metadata:    make(map[string]string, len(item.Metadata)),
+			}
+			for key, value := range item.Metadata {
+				plan.Metadata[key] = fmt.Sprint(value)
+			}
+			for _, price := range item.Prices {
+				if price.IsArchived {
+					continue
+				}
+				amount := price.PriceAmount
+				if price.AmountType == "custom" && price.PresetAmount != nil {
+					amount = *price.PresetAmount
+				}
+				plan.Prices = append(plan.Prices, &domain.Price{
+					PriceID:    price.ID,
+					AmountType: price.AmountType,
+					Amount:     amount,
+					Currency:   strings.ToUpper(price.PriceCurrency),
+				})
+			}
+			plans = append(plans, plan)
+		}
+
+		if page >= result.Pagination.MaxPage {
+			break
+		}
+	}
+
+	p.logger.Info("polar plans retrieved", loggerdomain.Fields{
+		"plans": len(plans),
+	})
+
+	return plans, nil
+}
+
 func parseTime(s string) (time.Time, error) {
 	// Parse ISO 8601 timestamp
 	return time.Parse(time.RFC3339, s)
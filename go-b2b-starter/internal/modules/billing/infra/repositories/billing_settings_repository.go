@@ -0,0 +1,56 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+)
+
+// billingSettingsRepository implements domain.BillingSettingsRepository using SQLC internally.
+type billingSettingsRepository struct {
+	store sqlc.Store
+}
+
+// NewBillingSettingsRepository creates a new BillingSettingsRepository implementation.
+func NewBillingSettingsRepository(store sqlc.Store) domain.BillingSettingsRepository {
+	return &billingSettingsRepository{store: store}
+}
+
+func (r *billingSettingsRepository) GetSettings(ctx context.Context, organizationID int32) (*domain.BillingSettings, error) {
+	result, err := r.store.GetBillingSettings(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrBillingSettingsNotFound
+		}
+		return nil, fmt.Errorf("failed to get billing settings: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *billingSettingsRepository) UpsertSettings(ctx context.Context, settings *domain.BillingSettings) (*domain.BillingSettings, error) {
+	params := sqlc.UpsertBillingSettingsParams{
+		OrganizationID: settings.OrganizationID,
+		Currency:       helpers.ToPgText(settings.Currency),
+		Locale:         helpers.ToPgText(settings.Locale),
+	}
+
+	result, err := r.store.UpsertBillingSettings(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert billing settings: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *billingSettingsRepository) mapToDomain(s *sqlc.SubscriptionBillingBillingSetting) *domain.BillingSettings {
+	return &domain.BillingSettings{
+		OrganizationID: s.OrganizationID,
+		Currency:       helpers.FromPgText(s.Currency),
+		Locale:         helpers.FromPgText(s.Locale),
+	}
+}
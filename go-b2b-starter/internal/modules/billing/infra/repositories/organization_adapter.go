@@ -45,3 +45,16 @@ func (a *organizationAdapter) GetOrganizationIDByStytchOrgID(ctx context.Context
 
 	return org.ID, nil
 }
+
+func (a *organizationAdapter) GetBillingOrganizationID(ctx context.Context, organizationID int32) (int32, bool, error) {
+	org, err := a.orgStore.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	if org.Environment == "production" || !org.ParentOrganizationID.Valid {
+		return org.ID, false, nil
+	}
+
+	return org.ParentOrganizationID.Int32, true, nil
+}
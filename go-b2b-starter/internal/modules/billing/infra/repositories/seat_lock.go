@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+)
+
+// seatLock implements paywall.SeatLock on the caller's transaction.
+type seatLock struct{}
+
+// NewSeatLock creates a new paywall.SeatLock implementation.
+func NewSeatLock() paywall.SeatLock {
+	return &seatLock{}
+}
+
+func (l *seatLock) CheckSeatsLocked(ctx context.Context, q sqlc.Querier, organizationID int32) error {
+	allocation, err := q.LockSeatAllocation(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to lock seat allocation: %w", err)
+	}
+	if allocation.PurchasedSeats == 0 || allocation.AutoExpand {
+		return nil
+	}
+
+	used, err := q.CountBillableSeats(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("failed to count used seats: %w", err)
+	}
+	if used >= allocation.PurchasedSeats {
+		return fmt.Errorf("%w: %d of %d seats used", paywall.ErrSeatLimitReached, used, allocation.PurchasedSeats)
+	}
+	return nil
+}
@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+)
+
+// seatRepository implements domain.SeatRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+//
+// Sync times are stored in UTC, as they are compared with times from Go.
+type seatRepository struct {
+	store sqlc.Store
+}
+
+// NewSeatRepository creates a new SeatRepository implementation.
+func NewSeatRepository(store sqlc.Store) domain.SeatRepository {
+	return &seatRepository{store: store}
+}
+
+func (r *seatRepository) GetAllocation(ctx context.Context, organizationID int32) (*domain.SeatAllocation, error) {
+	result, err := r.store.GetSeatAllocation(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return &domain.SeatAllocation{OrganizationID: organizationID}, nil
+		}
+		return nil, fmt.Errorf("failed to get seat allocation: %w", err)
+	}
+	return r.mapToDomain(&result), nil
+}
+
+func (r *seatRepository) SetPurchasedSeats(ctx context.Context, organizationID, seats int32) (*domain.SeatAllocation, error) {
+	result, err := r.store.UpsertPurchasedSeats(ctx, sqlc.UpsertPurchasedSeatsParams{
+		OrganizationID: organizationID,
+		PurchasedSeats: seats,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set purchased seats: %w", err)
+	}
+	return r.mapToDomain(&result), nil
+}
+
+func (r *seatRepository) SetAutoExpand(ctx context.Context, organizationID int32, autoExpand bool) (*domain.SeatAllocation, error) {
+	result, err := r.store.SetSeatAutoExpand(ctx, sqlc.SetSeatAutoExpandParams{
+		OrganizationID: organizationID,
+		AutoExpand:     autoExpand,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set seat auto-expand: %w", err)
+	}
+	return r.mapToDomain(&result), nil
+}
+
+func (r *seatRepository) CountUsedSeats(ctx context.Context, organizationID int32) (int32, error) {
+	count, err := r.store.CountBillableSeats(ctx, organizationID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count used seats: %w", err)
+	}
+	return count, nil
+}
+
+func (r *seatRepository) RequestSync(ctx context.Context, organizationID int32, dueAt time.Time) error {
+	err := r.store.RequestSeatSync(ctx, sqlc.RequestSeatSyncParams{
+		OrganizationID: organizationID,
+		DueAt:          toUTCTimestamp(dueAt),
+		RequestedAt:    toUTCTimestamp(time.Now()),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to request seat sync: %w", err)
+	}
+	return nil
+}
+
+func (r *seatRepository) RequestSyncForSubscribed(ctx context.Context, dueAt time.Time) (int64, error) {
+	scheduled, err := r.store.RequestSeatSyncForSubscribed(ctx, sqlc.RequestSeatSyncForSubscribedParams{
+		DueAt:       toUTCTimestamp(dueAt),
+		RequestedAt: toUTCTimestamp(time.Now()),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to request seat sync for subscribed organizations: %w", err)
+	}
+	return scheduled, nil
+}
+
+func (r *seatRepository) ListDue(ctx context.Context, dueBefore time.Time, limit int32) ([]*domain.SeatAllocation, error) {
+	results, err := r.store.ListDueSeatAllocations(ctx, sqlc.ListDueSeatAllocationsParams{
+		DueBefore: toUTCTimestamp(dueBefore),
+		RowLimit:  limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due seat allocations: %w", err)
+	}
+
+	allocations := make([]*domain.SeatAllocation, len(results))
+	for i := range results {
+		allocations[i] = r.mapToDomain(&results[i])
+	}
+	return allocations, nil
+}
+
+func (r *seatRepository) MarkSynced(ctx context.Context, organizationID, usedSeats, purchasedSeats int32, countedAt time.Time) (*domain.SeatAllocation, error) {
+	result, err := r.store.MarkSeatAllocationSynced(ctx, sqlc.MarkSeatAllocationSyncedParams{
+		UsedSeats:      usedSeats,
+		PurchasedSeats: purchasedSeats,
+		CountedAt:      toUTCTimestamp(countedAt),
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark seat allocation synced: %w", err)
+	}
+	return r.mapToDomain(&result), nil
+}
+
+func (r *seatRepository) MarkFailed(ctx context.Context, organizationID int32, syncErr string, retryAt time.Time) error {
+	err := r.store.MarkSeatAllocationFailed(ctx, sqlc.MarkSeatAllocationFailedParams{
+		SyncError:      syncErr,
+		RetryAt:        toUTCTimestamp(retryAt),
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark seat allocation failed: %w", err)
+	}
+	return nil
+}
+
+func (r *seatRepository) mapToDomain(a *sqlc.SubscriptionBillingSeatAllocation) *domain.SeatAllocation {
+	allocation := &domain.SeatAllocation{
+		OrganizationID: a.OrganizationID,
+		PurchasedSeats: a.PurchasedSeats,
+		UsedSeats:      a.UsedSeats,
+		AutoExpand:     a.AutoExpand,
+		SyncError:      helpers.FromPgText(a.SyncError),
+	}
+
+	// Handle nullable sync times
+	if a.SyncDueAt.Valid {
+		allocation.SyncDueAt = &a.SyncDueAt.Time
+	}
+	if a.SyncedAt.Valid {
+		allocation.SyncedAt = &a.SyncedAt.Time
+	}
+
+	return allocation
+}
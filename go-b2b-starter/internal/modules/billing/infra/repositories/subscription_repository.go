@@ -57,6 +57,8 @@ func (r *subscriptionRepository) UpsertSubscription(ctx context.Context, subscri
 		CancelAtPeriodEnd:  helpers.ToPgBool(subscription.CancelAtPeriodEnd),
 		CanceledAt:         toPgTimestampPtr(subscription.CanceledAt),
 		Metadata:           metadataJSON,
+		TrialStart:         toPgTimestampPtr(subscription.TrialStart),
+		TrialEnd:           toPgTimestampPtr(subscription.TrialEnd),
 	}
 
 	result, err := r.store.UpsertSubscription(ctx, params)
@@ -155,6 +157,12 @@ func (r *subscriptionRepository) mapToDomainSubscription(s *sqlc.SubscriptionBil
 	if s.CanceledAt.Valid {
 		subscription.CanceledAt = &s.CanceledAt.Time
 	}
+	if s.TrialStart.Valid {
+		subscription.TrialStart = &s.TrialStart.Time
+	}
+	if s.TrialEnd.Valid {
+		subscription.TrialEnd = &s.TrialEnd.Time
+	}
 
 	return subscription
 }
@@ -195,6 +203,9 @@ func (r *subscriptionRepository) mapToDomainQuotaStatus(qs *sqlc.GetQuotaStatusR
 	if qs.MaxSeats.Valid {
 		status.MaxSeats = qs.MaxSeats.Int32
 	}
+	if qs.TrialEnd.Valid {
+		status.TrialEnd = &qs.TrialEnd.Time
+	}
 
 	return status
 }
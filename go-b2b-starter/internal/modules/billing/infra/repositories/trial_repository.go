@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+)
+
+// trialRepository implements domain.TrialRepository using SQLC internally.
+// Trials are rows of the subscriptions table, mapped like any subscription.
+//
+// Trial times are stored in UTC, as they are compared with times from Go.
+type trialRepository struct {
+	store         sqlc.Store
+	subscriptions *subscriptionRepository
+}
+
+// NewTrialRepository creates a new TrialRepository implementation.
+func NewTrialRepository(store sqlc.Store) domain.TrialRepository {
+	return &trialRepository{
+		store:         store,
+		subscriptions: &subscriptionRepository{store: store},
+	}
+}
+
+func (r *trialRepository) StartTrial(ctx context.Context, trial *domain.Subscription) (*domain.Subscription, error) {
+	if trial.TrialStart == nil || trial.TrialEnd == nil {
+		return nil, fmt.Errorf("trial period is required")
+	}
+
+	result, err := r.store.StartTrial(ctx, sqlc.StartTrialParams{
+		OrganizationID:     trial.OrganizationID,
+		ExternalCustomerID: trial.ExternalCustomerID,
+		SubscriptionID:     trial.SubscriptionID,
+		ProductID:          trial.ProductID,
+		ProductName:        helpers.ToPgText(trial.ProductName),
+		PlanName:           helpers.ToPgText(trial.PlanName),
+		TrialStart:         toUTCTimestamp(*trial.TrialStart),
+		TrialEnd:           toUTCTimestamp(*trial.TrialEnd),
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrSubscriptionExists
+		}
+		return nil, fmt.Errorf("failed to start trial: %w", err)
+	}
+	return r.subscriptions.mapToDomainSubscription(&result), nil
+}
+
+func (r *trialRepository) ListEndingBetween(ctx context.Context, after, before time.Time, limit int32) ([]*domain.Subscription, error) {
+	results, err := r.store.ListTrialsEndingBetween(ctx, sqlc.ListTrialsEndingBetweenParams{
+		EndsAfter:  toUTCTimestamp(after),
+		EndsBefore: toUTCTimestamp(before),
+		RowLimit:   limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ending trials: %w", err)
+	}
+	return r.mapAll(results), nil
+}
+
+func (r *trialRepository) MarkReminderSent(ctx context.Context, organizationID int32, sentAt time.Time) error {
+	err := r.store.MarkTrialReminderSent(ctx, sqlc.MarkTrialReminderSentParams{
+		SentAt:         toUTCTimestamp(sentAt),
+		OrganizationID: organizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark trial reminder sent: %w", err)
+	}
+	return nil
+}
+
+func (r *trialRepository) ListReminderRecipients(ctx context.Context, organizationID int32) ([]string, error) {
+	emails, err := r.store.ListTrialReminderRecipients(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trial reminder recipients: %w", err)
+	}
+	return emails, nil
+}
+
+func (r *trialRepository) ListExpired(ctx context.Context, endedBefore time.Time, limit int32) ([]*domain.Subscription, error) {
+	results, err := r.store.ListExpiredTrials(ctx, sqlc.ListExpiredTrialsParams{
+		ProductID:   domain.TrialProductID,
+		EndedBefore: toUTCTimestamp(endedBefore),
+		RowLimit:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired trials: %w", err)
+	}
+	return r.mapAll(results), nil
+}
+
+func (r *trialRepository) Expire(ctx context.Context, organizationID int32) (*domain.Subscription, error) {
+	result, err := r.store.ExpireTrial(ctx, sqlc.ExpireTrialParams{
+		OrganizationID: organizationID,
+		ProductID:      domain.TrialProductID,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrTrialNotActive
+		}
+		return nil, fmt.Errorf("failed to expire trial: %w", err)
+	}
+	return r.subscriptions.mapToDomainSubscription(&result), nil
+}
+
+func (r *trialRepository) mapAll(results []sqlc.SubscriptionBillingSubscription) []*domain.Subscription {
+	trials := make([]*domain.Subscription, len(results))
+	for i := range results {
+		trials[i] = r.subscriptions.mapToDomainSubscription(&results[i])
+	}
+	return trials
+}
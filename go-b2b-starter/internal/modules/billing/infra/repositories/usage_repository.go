@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+)
+
+// usageRepository implements domain.UsageRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+//
+// Times are stored in UTC so Go and Postgres agree on hour boundaries.
+type usageRepository struct {
+	store sqlc.Store
+}
+
+// NewUsageRepository creates a new UsageRepository implementation.
+func NewUsageRepository(store sqlc.Store) domain.UsageRepository {
+	return &usageRepository{store: store}
+}
+
+func (r *usageRepository) InsertEvent(ctx context.Context, event *domain.UsageEvent) (bool, error) {
+	params := sqlc.InsertUsageEventParams{
+		OrganizationID: event.OrganizationID,
+		Metric:         string(event.Metric),
+		Quantity:       event.Quantity,
+		Source:         event.Source,
+		OccurredAt:     toUTCTimestamp(event.OccurredAt),
+	}
+	if event.AccountID != nil {
+		params.AccountID = pgtype.Int4{Int32: *event.AccountID, Valid: true}
+	}
+	if event.IdempotencyKey != "" {
+		params.IdempotencyKey = pgtype.Text{String: event.IdempotencyKey, Valid: true}
+	}
+
+	inserted, err := r.store.InsertUsageEvent(ctx, params)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert usage event: %w", err)
+	}
+	return inserted > 0, nil
+}
+
+func (r *usageRepository) Rollup(ctx context.Context, since time.Time, gaugeMetrics []domain.UsageMetric) (int64, error) {
+	changed, err := r.store.RollupUsageEvents(ctx, sqlc.RollupUsageEventsParams{
+		GaugeMetrics: metricNames(gaugeMetrics),
+		Since:        toUTCTimestamp(since),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up usage events: %w", err)
+	}
+	return changed, nil
+}
+
+func (r *usageRepository) ListUnreported(ctx context.Context, metrics []domain.UsageMetric, closedBefore time.Time, afterID, limit int32) ([]*domain.UsageRollup, error) {
+	results, err := r.store.ListUnreportedUsageRollups(ctx, sqlc.ListUnreportedUsageRollupsParams{
+		Metrics:      metricNames(metrics),
+		ClosedBefore: toUTCTimestamp(closedBefore),
+		AfterID:      afterID,
+		RowLimit:     limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unreported usage: %w", err)
+	}
+	return r.mapRollups(results), nil
+}
+
+func (r *usageRepository) MarkReported(ctx context.Context, rollupID int32, reportedQuantity int64) error {
+	err := r.store.MarkUsageRollupReported(ctx, sqlc.MarkUsageRollupReportedParams{
+		ReportedQuantity: reportedQuantity,
+		ID:               rollupID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark usage reported: %w", err)
+	}
+	return nil
+}
+
+func (r *usageRepository) ListRollups(ctx context.Context, organizationID int32, from, to time.Time) ([]*domain.UsageRollup, error) {
+	results, err := r.store.ListUsageRollups(ctx, sqlc.ListUsageRollupsParams{
+		OrganizationID: organizationID,
+		PeriodFrom:     toUTCTimestamp(from),
+		PeriodTo:       toUTCTimestamp(to),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list usage rollups: %w", err)
+	}
+	return r.mapRollups(results), nil
+}
+
+func (r *usageRepository) DeleteEventsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	deleted, err := r.store.DeleteUsageEventsBefore(ctx, toUTCTimestamp(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete usage events: %w", err)
+	}
+	return deleted, nil
+}
+
+func (r *usageRepository) mapRollups(results []sqlc.SubscriptionBillingUsageRollup) []*domain.UsageRollup {
+	rollups := make([]*domain.UsageRollup, len(results))
+	for i, result := range results {
+		rollup := &domain.UsageRollup{
+			ID:               result.ID,
+			OrganizationID:   result.OrganizationID,
+			Metric:           domain.UsageMetric(result.Metric),
+			PeriodStart:      result.PeriodStart.Time,
+			PeriodEnd:        result.PeriodEnd.Time,
+			Quantity:         result.Quantity,
+			EventCount:       result.EventCount,
+			ReportedQuantity: result.ReportedQuantity,
+		}
+		if result.ReportedAt.Valid {
+			reportedAt := result.ReportedAt.Time
+			rollup.ReportedAt = &reportedAt
+		}
+		rollups[i] = rollup
+	}
+	return rollups
+}
+
+func metricNames(metrics []domain.UsageMetric) []string {
+	names := make([]string, len(metrics))
+	for i, metric := range metrics {
+		names[i] = string(metric)
+	}
+	return names
+}
+
+func toUTCTimestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t.UTC(), Valid: true}
+}
@@ -0,0 +1,53 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+)
+
+// userAdapter resolves accounts billed in user scope. An account is known to
+// the billing provider by its public ID, never by its sequential ID.
+type userAdapter struct {
+	store sqlc.Store
+}
+
+func NewUserAdapter(store sqlc.Store) domain.UserAdapter {
+	return &userAdapter{store: store}
+}
+
+func (a *userAdapter) GetExternalCustomerID(ctx context.Context, accountID int32) (string, error) {
+	account, err := a.store.GetBillingCustomerByAccountID(ctx, accountID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get account: %w", err)
+	}
+
+	return domain.UserCustomerID(helpers.FromPgUUID(account.PublicID).String()), nil
+}
+
+func (a *userAdapter) GetAccountByExternalCustomerID(ctx context.Context, externalCustomerID string) (int32, int32, error) {
+	if !domain.IsUserCustomerID(externalCustomerID) {
+		return 0, 0, fmt.Errorf("customer %q is not an individual account", externalCustomerID)
+	}
+
+	publicID, err := uuid.Parse(externalCustomerID[len(domain.UserCustomerPrefix):])
+	if err != nil {
+		return 0, 0, fmt.Errorf("customer %q has an invalid account ID", externalCustomerID)
+	}
+
+	account, err := a.store.GetBillingCustomerByPublicID(ctx, helpers.ToPgUUID(publicID))
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return 0, 0, fmt.Errorf("no account for customer %q", externalCustomerID)
+		}
+		return 0, 0, fmt.Errorf("failed to get account by customer ID: %w", err)
+	}
+
+	return account.ID, account.OrganizationID, nil
+}
@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+)
+
+// userSubscriptionRepository implements domain.UserSubscriptionRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type userSubscriptionRepository struct {
+	store sqlc.Store
+}
+
+// NewUserSubscriptionRepository creates a new UserSubscriptionRepository implementation.
+func NewUserSubscriptionRepository(store sqlc.Store) domain.UserSubscriptionRepository {
+	return &userSubscriptionRepository{store: store}
+}
+
+func (r *userSubscriptionRepository) GetByAccountID(ctx context.Context, accountID int32) (*domain.UserSubscription, error) {
+	result, err := r.store.GetUserSubscriptionByAccountID(ctx, accountID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get user subscription: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *userSubscriptionRepository) Upsert(ctx context.Context, subscription *domain.UserSubscription) (*domain.UserSubscription, error) {
+	metadata := subscription.Metadata
+	if metadata == nil {
+		metadata = map[string]any{}
+	}
+	metadataJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	result, err := r.store.UpsertUserSubscription(ctx, sqlc.UpsertUserSubscriptionParams{
+		AccountID:          subscription.AccountID,
+		OrganizationID:     subscription.OrganizationID,
+		ExternalCustomerID: subscription.ExternalCustomerID,
+		SubscriptionID:     subscription.SubscriptionID,
+		SubscriptionStatus: subscription.SubscriptionStatus,
+		ProductID:          subscription.ProductID,
+		ProductName:        helpers.ToPgText(subscription.ProductName),
+		PlanName:           helpers.ToPgText(subscription.PlanName),
+		CurrentPeriodStart: toPgTimestamp(subscription.CurrentPeriodStart),
+		CurrentPeriodEnd:   toPgTimestamp(subscription.CurrentPeriodEnd),
+		CancelAtPeriodEnd:  subscription.CancelAtPeriodEnd,
+		CanceledAt:         toPgTimestampPtr(subscription.CanceledAt),
+		Metadata:           metadataJSON,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert user subscription: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+// mapToDomain converts SQLC user subscription to domain entity
+func (r *userSubscriptionRepository) mapToDomain(s *sqlc.SubscriptionBillingUserSubscription) *domain.UserSubscription {
+	var metadata map[string]any
+	if len(s.Metadata) > 0 {
+		json.Unmarshal(s.Metadata, &metadata)
+	}
+
+	subscription := &domain.UserSubscription{
+		ID:                 s.ID,
+		AccountID:          s.AccountID,
+		OrganizationID:     s.OrganizationID,
+		ExternalCustomerID: s.ExternalCustomerID,
+		SubscriptionID:     s.SubscriptionID,
+		SubscriptionStatus: s.SubscriptionStatus,
+		ProductID:          s.ProductID,
+		ProductName:        helpers.FromPgText(s.ProductName),
+		PlanName:           helpers.FromPgText(s.PlanName),
+		CurrentPeriodStart: s.CurrentPeriodStart.Time,
+		CurrentPeriodEnd:   s.CurrentPeriodEnd.Time,
+		CancelAtPeriodEnd:  s.CancelAtPeriodEnd,
+		Metadata:           metadata,
+		CreatedAt:          s.CreatedAt.Time,
+		UpdatedAt:          s.UpdatedAt.Time,
+	}
+	if s.CanceledAt.Valid {
+		canceledAt := s.CanceledAt.Time
+		subscription.CanceledAt = &canceledAt
+	}
+
+	return subscription
+}
@@ -0,0 +1,123 @@
+package sandbox
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+)
+
+// Checkout outcomes encoded in sandbox checkout session IDs
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomePending   = "pending"
+	OutcomeExpired   = "expired"
+	OutcomeFailed    = "failed"
+)
+
+// Sandbox product IDs. They contain no underscores so they can be embedded in session IDs.
+const (
+	ProductStarter = "sandbox-starter"
+	ProductPro     = "sandbox-pro"
+)
+
+const checkoutSessionPrefix = "cs_sandbox_"
+
+// Plans returns the fixed sandbox catalog. Metadata mirrors what the Polar
+// products carry so quota parsing behaves the same as in production.
+// Pro is seat-based: its "seats" metadata is the seat quantity a new
+// subscription starts with.
+func Plans() []*domain.Plan {
+	return []*domain.Plan{
+		{
+			ProductID:   ProductStarter,
+			Name:        "Starter (sandbox)",
+			Description: "Sandbox plan for local development",
+			IsRecurring: true,
+			Interval:    "month",
+			Prices: []*domain.Price{
+				{PriceID: ProductStarter + "-usd", AmountType: "fixed", Amount: 2900, Currency: "USD"},
+				{PriceID: ProductStarter + "-eur", AmountType: "fixed", Amount: 2700, Currency: "EUR"},
+			},
+			Metadata: map[string]string{
+				"invoice_count": "100",
+				"max_seats":     "5",
+			},
+		},
+		{
+			ProductID:   ProductPro,
+			Name:        "Pro (sandbox)",
+			Description: "Sandbox plan for local development",
+			IsRecurring: true,
+			Interval:    "month",
+			Prices: []*domain.Price{
+				{PriceID: ProductPro + "-usd", AmountType: "fixed", Amount: 9900, Currency: "USD"},
+				{PriceID: ProductPro + "-eur", AmountType: "fixed", Amount: 9200, Currency: "EUR"},
+			},
+			Metadata: map[string]string{
+				"invoice_count": "1000",
+				"max_seats":     "25",
+				"seats":         "5",
+			},
+		},
+	}
+}
+
+// FindPlan returns the sandbox plan with the given product ID.
+func FindPlan(productID string) (*domain.Plan, bool) {
+	for _, plan := range Plans() {
+		if plan.ProductID == productID {
+			return plan, true
+		}
+	}
+	return nil, false
+}
+
+// CheckoutSessionID builds a deterministic checkout session ID.
+// The outcome, product and customer are encoded in the ID, so verifying the
+// session needs no stored state: cs_sandbox_<outcome>_<product>_<external customer ID>.
+func CheckoutSessionID(outcome, productID, externalCustomerID string) (string, error) {
+	switch outcome {
+	case OutcomeSucceeded, OutcomePending, OutcomeExpired, OutcomeFailed:
+	default:
+		return "", fmt.Errorf("unknown checkout outcome %q", outcome)
+	}
+	if _, ok := FindPlan(productID); !ok {
+		return "", fmt.Errorf("unknown sandbox product %q", productID)
+	}
+	if externalCustomerID == "" {
+		return "", fmt.Errorf("external customer ID is required")
+	}
+	return checkoutSessionPrefix + outcome + "_" + productID + "_" + externalCustomerID, nil
+}
+
+// parseCheckoutSessionID reverses CheckoutSessionID.
+func parseCheckoutSessionID(sessionID string) (outcome, productID, externalCustomerID string, ok bool) {
+	rest, found := strings.CutPrefix(sessionID, checkoutSessionPrefix)
+	if !found {
+		return "", "", "", false
+	}
+	parts := strings.SplitN(rest, "_", 3)
+	if len(parts) != 3 || parts[2] == "" {
+		return "", "", "", false
+	}
+	if _, known := FindPlan(parts[1]); !known {
+		return "", "", "", false
+	}
+	return parts[0], parts[1], parts[2], true
+}
+
+// SubscriptionID derives the sandbox subscription ID for a customer and product,
+// so checkouts and simulated webhooks refer to the same subscription.
+func SubscriptionID(externalCustomerID, productID string) string {
+	sum := sha256.Sum256([]byte(externalCustomerID + "|" + productID))
+	return "sub_sandbox_" + hex.EncodeToString(sum[:8])
+}
+
+// CustomerID derives the sandbox provider-side customer ID.
+func CustomerID(externalCustomerID string) string {
+	sum := sha256.Sum256([]byte(externalCustomerID))
+	return "cus_sandbox_" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,138 @@
+package sandbox
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+)
+
+// Event is a webhook event shaped like the ones Polar delivers:
+// the event type plus the affected object under "data".
+type Event struct {
+	Type      string         `json:"type"`
+	Timestamp time.Time      `json:"timestamp"`
+	Data      map[string]any `json:"data"`
+}
+
+// NewSubscription returns the sandbox subscription a customer gets for a product.
+// The period starts at the beginning of the UTC day of start and lasts one month.
+func NewSubscription(externalCustomerID, productID string, start time.Time) (*domain.Subscription, error) {
+	plan, ok := FindPlan(productID)
+	if !ok {
+		return nil, fmt.Errorf("unknown sandbox product %q", productID)
+	}
+
+	periodStart := start.UTC().Truncate(24 * time.Hour)
+
+	invoiceCountMax := int32(0)
+	if count, err := strconv.ParseInt(plan.Metadata["invoice_count"], 10, 32); err == nil {
+		invoiceCountMax = int32(count)
+	}
+
+	seats := int32(0)
+	if count, err := strconv.ParseInt(plan.Metadata["seats"], 10, 32); err == nil {
+		seats = int32(count)
+	}
+
+	return &domain.Subscription{
+		ExternalCustomerID: externalCustomerID,
+		SubscriptionID:     SubscriptionID(externalCustomerID, productID),
+		SubscriptionStatus: "active",
+		ProductID:          plan.ProductID,
+		ProductName:        plan.Name,
+		CurrentPeriodStart: periodStart,
+		CurrentPeriodEnd:   periodStart.AddDate(0, 1, 0),
+		Seats:              seats,
+		Metadata: map[string]any{
+			"invoice_count_max": invoiceCountMax,
+			"product_metadata":  plan.Metadata,
+			"customer_metadata": map[string]string{"organization_id": externalCustomerID},
+		},
+	}, nil
+}
+
+// SubscriptionEvent builds a subscription.created, subscription.updated or
+// subscription.canceled event for the subscription.
+func SubscriptionEvent(eventType string, sub *domain.Subscription, at time.Time) (*Event, error) {
+	switch eventType {
+	case "subscription.created", "subscription.updated", "subscription.canceled":
+	default:
+		return nil, fmt.Errorf("unsupported subscription event %q", eventType)
+	}
+
+	plan, ok := FindPlan(sub.ProductID)
+	if !ok {
+		return nil, fmt.Errorf("unknown sandbox product %q", sub.ProductID)
+	}
+
+	var canceledAt any
+	if sub.CanceledAt != nil {
+		canceledAt = sub.CanceledAt.UTC().Format(time.RFC3339)
+	}
+
+	var seats any
+	if sub.Seats > 0 {
+		seats = sub.Seats
+	}
+
+	return &Event{
+		Type:      eventType,
+		Timestamp: at.UTC(),
+		Data: map[string]any{
+			"id":                   sub.SubscriptionID,
+			"status":               sub.SubscriptionStatus,
+			"current_period_start": sub.CurrentPeriodStart.UTC().Format(time.RFC3339),
+			"current_period_end":   sub.CurrentPeriodEnd.UTC().Format(time.RFC3339),
+			"cancel_at_period_end": sub.CancelAtPeriodEnd,
+			"canceled_at":          canceledAt,
+			"seats":                seats,
+			"customer_id":          CustomerID(sub.ExternalCustomerID),
+			"product_id":           plan.ProductID,
+			"customer":             customerObject(sub.ExternalCustomerID, nil),
+			"product": map[string]any{
+				"id":       plan.ProductID,
+				"name":     plan.Name,
+				"metadata": plan.Metadata,
+			},
+		},
+	}, nil
+}
+
+// CustomerUpdatedEvent builds a customer.updated event carrying customer metadata,
+// e.g. {"invoice_count": "42"} to set the remaining invoice quota.
+func CustomerUpdatedEvent(externalCustomerID string, metadata map[string]string, at time.Time) *Event {
+	return &Event{
+		Type:      "customer.updated",
+		Timestamp: at.UTC(),
+		Data:      customerObject(externalCustomerID, metadata),
+	}
+}
+
+// MeterGrantEvent builds a meter.grant.updated event with the customer's available balance.
+func MeterGrantEvent(externalCustomerID, meterSlug string, available int32, at time.Time) *Event {
+	return &Event{
+		Type:      "meter.grant.updated",
+		Timestamp: at.UTC(),
+		Data: map[string]any{
+			"meter":    map[string]any{"slug": meterSlug},
+			"customer": customerObject(externalCustomerID, nil),
+			"balance":  map[string]any{"available": available},
+		},
+	}
+}
+
+// customerObject mirrors a Polar customer. The organization_id metadata is what
+// checkout sets, and how customer.updated events are mapped to an organization.
+func customerObject(externalCustomerID string, metadata map[string]string) map[string]any {
+	merged := map[string]string{"organization_id": externalCustomerID}
+	for key, value := range metadata {
+		merged[key] = value
+	}
+	return map[string]any{
+		"id":          CustomerID(externalCustomerID),
+		"external_id": externalCustomerID,
+		"metadata":    merged,
+	}
+}
@@ -0,0 +1,190 @@
+// Package sandbox provides an offline BillingProvider for local development.
+//
+// Checkouts are deterministic: the session ID encodes the outcome, product and
+// customer (see CheckoutSessionID), so no provider account or network access is
+// needed. Subscription state lives in memory and is lost on restart; the local
+// database stays the source of truth, as with Polar.
+package sandbox
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	loggerdomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// Ensure sandboxAdapter implements domain.BillingProvider at compile time
+var _ domain.BillingProvider = (*sandboxAdapter)(nil)
+
+type sandboxAdapter struct {
+	logger logger.Logger
+	now    func() time.Time
+
+	mu            sync.Mutex
+	subscriptions map[string]*domain.Subscription // by external customer ID
+	usage         map[string]int32                // meter events by external customer ID and slug
+	usageReports  map[string]float64              // reported usage by external customer ID and slug
+	reportKeys    map[string]struct{}             // idempotency keys of reported usage
+}
+
+func NewSandboxAdapter(log logger.Logger) domain.BillingProvider {
+	return &sandboxAdapter{
+		logger:        log,
+		now:           time.Now,
+		subscriptions: make(map[string]*domain.Subscription),
+		usage:         make(map[string]int32),
+		usageReports:  make(map[string]float64),
+		reportKeys:    make(map[string]struct{}),
+	}
+}
+
+func (s *sandboxAdapter) GetSubscription(ctx context.Context, externalCustomerID string) (*domain.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subscriptions[externalCustomerID]
+	if !ok {
+		return nil, domain.ErrSubscriptionNotFound
+	}
+
+	copied := *sub
+	return &copied, nil
+}
+
+// GetCheckoutSession resolves a sandbox session ID. A succeeded checkout
+// starts an active subscription for the customer, as a real payment would.
+func (s *sandboxAdapter) GetCheckoutSession(ctx context.Context, sessionID string) (*domain.CheckoutSessionResponse, error) {
+	outcome, productID, externalCustomerID, ok := parseCheckoutSessionID(sessionID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrCheckoutSessionNotFound, sessionID)
+	}
+
+	plan, _ := FindPlan(productID)
+	var amount int64
+	if len(plan.Prices) > 0 {
+		amount = plan.Prices[0].Amount
+	}
+
+	checkoutSession := &domain.CheckoutSessionResponse{
+		ID:         sessionID,
+		Status:     outcome,
+		CustomerID: externalCustomerID,
+		ProductID:  productID,
+		Amount:     amount,
+		CreatedAt:  s.now(),
+	}
+
+	if outcome == OutcomeSucceeded {
+		sub, err := s.startSubscription(externalCustomerID, productID)
+		if err != nil {
+			return nil, err
+		}
+		checkoutSession.SubscriptionID = sub.SubscriptionID
+	}
+
+	s.logger.Info("sandbox checkout session retrieved", loggerdomain.Fields{
+		"session_id":           sessionID,
+		"status":               outcome,
+		"external_customer_id": externalCustomerID,
+		"subscription_id":      checkoutSession.SubscriptionID,
+	})
+
+	return checkoutSession, nil
+}
+
+// GetCheckoutSessionWithPolling returns immediately; sandbox sessions never change state.
+func (s *sandboxAdapter) GetCheckoutSessionWithPolling(ctx context.Context, sessionID string) (*domain.CheckoutSessionResponse, error) {
+	return s.GetCheckoutSession(ctx, sessionID)
+}
+
+func (s *sandboxAdapter) IngestMeterEvent(ctx context.Context, externalCustomerID string, meterSlug string, amount int32) error {
+	s.mu.Lock()
+	key := externalCustomerID + "|" + meterSlug
+	s.usage[key] += amount
+	total := s.usage[key]
+	s.mu.Unlock()
+
+	s.logger.Info("sandbox meter event ingested", loggerdomain.Fields{
+		"customer_id": externalCustomerID,
+		"meter_slug":  meterSlug,
+		"amount":      amount,
+		"total":       total,
+	})
+
+	return nil
+}
+
+// ReportUsage accumulates reported usage per customer and meter. A repeated
+// idempotency key is dropped, as Polar does.
+func (s *sandboxAdapter) ReportUsage(ctx context.Context, report *domain.UsageReport) error {
+	s.mu.Lock()
+	if _, ok := s.reportKeys[report.IdempotencyKey]; ok {
+		s.mu.Unlock()
+		return nil
+	}
+	s.reportKeys[report.IdempotencyKey] = struct{}{}
+	key := report.ExternalCustomerID + "|" + report.MeterSlug
+	s.usageReports[key] += report.Quantity
+	total := s.usageReports[key]
+	s.mu.Unlock()
+
+	s.logger.Info("sandbox usage reported", loggerdomain.Fields{
+		"customer_id":  report.ExternalCustomerID,
+		"meter_slug":   report.MeterSlug,
+		"quantity":     report.Quantity,
+		"period_start": report.PeriodStart,
+		"total":        total,
+	})
+
+	return nil
+}
+
+// UpdateSubscriptionSeats changes the seat quantity of a sandbox subscription.
+func (s *sandboxAdapter) UpdateSubscriptionSeats(ctx context.Context, subscriptionID string, seats int32) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, sub := range s.subscriptions {
+		if sub.SubscriptionID != subscriptionID {
+			continue
+		}
+		if sub.Seats == 0 {
+			return fmt.Errorf("%w: %s", domain.ErrNotSeatBased, subscriptionID)
+		}
+		sub.Seats = seats
+
+		s.logger.Info("sandbox subscription seats updated", loggerdomain.Fields{
+			"subscription_id": subscriptionID,
+			"seats":           seats,
+		})
+		return nil
+	}
+
+	return domain.ErrSubscriptionNotFound
+}
+
+func (s *sandboxAdapter) ListPlans(ctx context.Context) ([]*domain.Plan, error) {
+	return Plans(), nil
+}
+
+// startSubscription records the customer's subscription, keeping an existing
+// one for the same product so repeated verification is idempotent.
+func (s *sandboxAdapter) startSubscription(externalCustomerID, productID string) (*domain.Subscription, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if existing, ok := s.subscriptions[externalCustomerID]; ok && existing.ProductID == productID {
+		return existing, nil
+	}
+
+	sub, err := NewSubscription(externalCustomerID, productID, s.now())
+	if err != nil {
+		return nil, err
+	}
+	s.subscriptions[externalCustomerID] = sub
+	return sub, nil
+}
@@ -3,7 +3,6 @@ package billing
 import (
 	"github.com/gin-gonic/gin"
 
-	"github.com/moasq/go-b2b-starter/internal/modules/auth"
 	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
 )
 
@@ -18,10 +17,58 @@ func (h *Handler) Routes(router *gin.RouterGroup, resolver serverDomain.Middlewa
 	{
 		// Get billing status - requires resource:view permission
 		subscriptions.GET("/status",
-			auth.RequirePermissionFunc("resource", "view"),
+			resolver.Get("perm:resource:view"),
 			h.GetBillingStatus)
+
+		// Metered usage per metric (LLM tokens, OCR pages, storage)
+		subscriptions.GET("/usage",
+			resolver.Get("perm:resource:view"),
+			h.GetUsage)
+
+		// Purchased and used seats of seat-based plans (changes are billed)
+		subscriptions.GET("/seats",
+			resolver.Get("perm:org:view"),
+			h.GetSeats)
+		subscriptions.PUT("/seats",
+			resolver.Get("perm:org:manage"),
+			resolver.Get("recent_auth"),
+			h.UpdateSeats)
+
+		// Plan catalog priced in the organization's currency
+		subscriptions.GET("/plans",
+			resolver.Get("perm:resource:view"),
+			h.ListPlans)
+
+		// Currency and locale used to display prices (changes require a recent sign-in)
+		subscriptions.GET("/settings",
+			resolver.Get("perm:org:view"),
+			h.GetBillingSettings)
+		subscriptions.PUT("/settings",
+			resolver.Get("perm:org:manage"),
+			resolver.Get("recent_auth"),
+			h.UpdateBillingSettings)
 	}
 
+	// User-scoped (B2C) subscriptions of the signed-in user, only when BILLING_SCOPE=user
+	if h.providerConfig.IsUserScope() {
+		me := router.Group("/subscriptions/me")
+		me.Use(
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+		)
+		{
+			me.GET("", h.GetMyBillingStatus)
+			me.POST("/refresh", h.RefreshMyBillingStatus)
+			me.POST("/verify-payment", h.VerifyMyPayment)
+		}
+	}
+
+	// Public plan catalog for pricing pages - no auth, negotiated from the request
+	router.GET("/subscriptions/public/plans", h.ListPublicPlans)
+
+	// Provider webhooks - no auth, authenticated by the Standard Webhooks signature
+	router.POST("/webhooks/polar", h.HandlePolarWebhook)
+
 	// Verify payment endpoint - auth only (session_id identifies org)
 	// This is separate from the main group to avoid requiring org_context middleware
 	// The session_id from the checkout contains the customer_id which maps to the org
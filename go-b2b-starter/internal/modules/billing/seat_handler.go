@@ -0,0 +1,126 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	billingServices "github.com/moasq/go-b2b-starter/internal/modules/billing/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	"github.com/moasq/go-b2b-starter/pkg/httperr"
+)
+
+// GetSeats godoc
+// @Summary Get seat usage
+// @Description Returns the organization's purchased seats and the members using them. Service accounts, API clients and guests do not use a seat. seat_based is false when the plan is not billed per seat.
+// @Tags subscriptions
+// @Produce json
+// @Success 200 {object} domain.SeatStatus "Purchased and used seats"
+// @Failure 400 {object} httperr.HTTPError "Missing organization context"
+// @Failure 404 {object} httperr.HTTPError "Seat-based billing is disabled"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/seats [get]
+func (h *Handler) GetSeats(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	status, err := h.seatService.GetSeats(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.handleSeatError(c, reqCtx.OrganizationID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// UpdateSeats godoc
+// @Summary Update purchased seats
+// @Description Changes the seat quantity of a seat-based subscription on the billing provider, or turns auto-expand on or off. With auto-expand the subscription follows membership instead of blocking new members; seats cannot be set while it is on. Seats cannot drop below the members using one.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param request body billingServices.UpdateSeatsRequest true "Seats and auto-expand"
+// @Success 200 {object} domain.SeatStatus "Updated seats"
+// @Failure 400 {object} httperr.HTTPError "Invalid seat count"
+// @Failure 404 {object} httperr.HTTPError "Seat-based billing is disabled or no subscription"
+// @Failure 409 {object} httperr.HTTPError "The plan is not billed per seat"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/seats [put]
+func (h *Handler) UpdateSeats(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	var req billingServices.UpdateSeatsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			fmt.Sprintf("Invalid request: %v", err),
+		))
+		return
+	}
+
+	status, err := h.seatService.UpdateSeats(c.Request.Context(), reqCtx.OrganizationID, &req)
+	if err != nil {
+		h.handleSeatError(c, reqCtx.OrganizationID, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *Handler) handleSeatError(c *gin.Context, organizationID int32, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidSeatCount):
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_seat_count",
+			err.Error(),
+		))
+	case errors.Is(err, domain.ErrSeatBillingDisabled):
+		c.JSON(http.StatusNotFound, httperr.NewHTTPError(
+			http.StatusNotFound,
+			"seat_billing_disabled",
+			err.Error(),
+		))
+	case errors.Is(err, domain.ErrSubscriptionNotFound):
+		c.JSON(http.StatusNotFound, httperr.NewHTTPError(
+			http.StatusNotFound,
+			"subscription_not_found",
+			err.Error(),
+		))
+	case errors.Is(err, domain.ErrNotSeatBased):
+		c.JSON(http.StatusConflict, httperr.NewHTTPError(
+			http.StatusConflict,
+			"not_seat_based",
+			err.Error(),
+		))
+	default:
+		h.logger.Error("Seat request failed", map[string]any{
+			"organization_id": organizationID,
+			"error":           err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"seats_failed",
+			fmt.Sprintf("Failed to process seat request: %v", err),
+		))
+	}
+}
@@ -0,0 +1,84 @@
+package billing
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/pkg/httperr"
+)
+
+// maxUsageRange bounds the period of a usage query
+const maxUsageRange = 366 * 24 * time.Hour
+
+// GetUsage godoc
+// @Summary Get metered usage
+// @Description Returns the organization's usage per metric (LLM tokens, OCR pages, storage) in [from, to). Usage is rolled up hourly, so the last minutes may be missing. Storage is the peak of the period.
+// @Tags subscriptions
+// @Produce json
+// @Param from query string false "Start (RFC 3339), default the start of the current month (UTC)"
+// @Param to query string false "End (RFC 3339), default now"
+// @Success 200 {object} domain.UsageSummary "Usage per metric"
+// @Failure 400 {object} httperr.HTTPError "Invalid period or missing organization context"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/usage [get]
+func (h *Handler) GetUsage(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	now := time.Now().UTC()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	to := now
+
+	var err error
+	if value := c.Query("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+				http.StatusBadRequest,
+				"invalid_period",
+				fmt.Sprintf("Invalid from: %v", err),
+			))
+			return
+		}
+	}
+	if value := c.Query("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+				http.StatusBadRequest,
+				"invalid_period",
+				fmt.Sprintf("Invalid to: %v", err),
+			))
+			return
+		}
+	}
+	if !from.Before(to) || to.Sub(from) > maxUsageRange {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_period",
+			"from must be before to, at most 366 days apart",
+		))
+		return
+	}
+
+	summary, err := h.usageService.GetUsage(c.Request.Context(), reqCtx.OrganizationID, from, to)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"usage_failed",
+			fmt.Sprintf("Failed to retrieve usage: %v", err),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, summary)
+}
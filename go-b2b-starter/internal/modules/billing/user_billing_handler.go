@@ -0,0 +1,152 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	"github.com/moasq/go-b2b-starter/pkg/httperr"
+)
+
+// GetMyBillingStatus godoc
+// @Summary Get the current user's billing status
+// @Description Retrieve the subscription status of the authenticated user (BILLING_SCOPE=user). external_id is the customer external ID to pass when opening a checkout for the user.
+// @Tags subscriptions
+// @Produce json
+// @Success 200 {object} domain.UserBillingStatus "Current user billing status"
+// @Failure 400 {object} httperr.HTTPError "Missing request context"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/me [get]
+func (h *Handler) GetMyBillingStatus(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Request context is required",
+		))
+		return
+	}
+
+	status, err := h.userBillingService.GetStatus(c.Request.Context(), reqCtx.AccountID)
+	if err != nil {
+		h.handleUserBillingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// RefreshMyBillingStatus godoc
+// @Summary Refresh the current user's billing status
+// @Description Syncs the authenticated user's subscription from the billing provider, for when a webhook was missed
+// @Tags subscriptions
+// @Produce json
+// @Success 200 {object} domain.UserBillingStatus "Refreshed user billing status"
+// @Failure 400 {object} httperr.HTTPError "Missing request context"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/me/refresh [post]
+func (h *Handler) RefreshMyBillingStatus(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Request context is required",
+		))
+		return
+	}
+
+	status, err := h.userBillingService.RefreshStatus(c.Request.Context(), reqCtx.AccountID)
+	if err != nil {
+		h.handleUserBillingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// VerifyMyPayment godoc
+// @Summary Verify the current user's payment from a checkout session
+// @Description Verification on redirect for user-scoped billing. The checkout must have been paid for the user's own customer ID.
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param request body VerifyPaymentRequest true "Checkout session ID"
+// @Success 200 {object} domain.UserBillingStatus "Updated user billing status"
+// @Failure 400 {object} httperr.HTTPError "Invalid request or checkout not completed"
+// @Failure 403 {object} httperr.HTTPError "Checkout session belongs to another customer"
+// @Failure 404 {object} httperr.HTTPError "Checkout session not found"
+// @Failure 500 {object} httperr.HTTPError "Internal server error"
+// @Router /api/subscriptions/me/verify-payment [post]
+func (h *Handler) VerifyMyPayment(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Request context is required",
+		))
+		return
+	}
+
+	var req VerifyPaymentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			fmt.Sprintf("Invalid request: %v", err),
+		))
+		return
+	}
+
+	status, err := h.userBillingService.VerifyPaymentFromCheckout(c.Request.Context(), reqCtx.AccountID, req.SessionID)
+	if err != nil {
+		h.handleUserBillingError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+func (h *Handler) handleUserBillingError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrCheckoutNotSucceeded):
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"payment_not_completed",
+			err.Error(),
+		))
+	case errors.Is(err, domain.ErrCheckoutCustomerMismatch):
+		c.JSON(http.StatusForbidden, httperr.NewHTTPError(
+			http.StatusForbidden,
+			"customer_mismatch",
+			err.Error(),
+		))
+	case errors.Is(err, domain.ErrCheckoutSessionNotFound):
+		c.JSON(http.StatusNotFound, httperr.NewHTTPError(
+			http.StatusNotFound,
+			"session_not_found",
+			err.Error(),
+		))
+	case errors.Is(err, domain.ErrUserBillingDisabled):
+		c.JSON(http.StatusNotFound, httperr.NewHTTPError(
+			http.StatusNotFound,
+			"user_billing_disabled",
+			err.Error(),
+		))
+	default:
+		h.logger.Error("User billing request failed", map[string]any{
+			"error": err.Error(),
+		})
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"user_billing_failed",
+			fmt.Sprintf("Failed to process user billing request: %v", err),
+		))
+	}
+}
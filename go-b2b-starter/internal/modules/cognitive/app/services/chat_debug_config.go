@@ -0,0 +1,42 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// ChatDebugConfig controls the chat debugging endpoints. They expose the
+// assembled prompts, including document content, so they are off by default.
+//
+// All values can be set via environment variables with the CHAT_ prefix.
+type ChatDebugConfig struct {
+	// Enabled registers the retrieval explanation endpoint for org admins
+	Enabled bool `mapstructure:"CHAT_DEBUG_ENABLED"`
+}
+
+// LoadChatDebugConfig loads the chat debug configuration from environment variables and app.env file.
+func LoadChatDebugConfig() (*ChatDebugConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("CHAT_DEBUG_ENABLED", false)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg ChatDebugConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode chat debug config: %w", err)
+	}
+
+	return &cfg, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// chatPlanCeilingDefault is the CHAT_PLAN_CEILINGS key for organizations
+// without an active subscription or on a plan that isn't listed
+const chatPlanCeilingDefault = "default"
+
+// ChatModelConfig controls which model parameters clients may request for a
+// conversation. Parameters a client doesn't request come from the LLM
+// configuration and are not limited here.
+//
+// All values can be set via environment variables with the CHAT_ prefix.
+type ChatModelConfig struct {
+	// AllowedModels is a comma-separated list of models clients may choose,
+	// ordered from least to most capable. Empty disables model choice.
+	AllowedModels string `mapstructure:"CHAT_ALLOWED_MODELS"`
+
+	// MaxTemperature is the highest temperature clients may request (0-2)
+	MaxTemperature float32 `mapstructure:"CHAT_MAX_TEMPERATURE"`
+
+	// MaxTokens is the largest response budget clients may request
+	MaxTokens int32 `mapstructure:"CHAT_MAX_TOKENS"`
+
+	// PlanCeilings caps model choice and response budget per billing plan, as
+	// comma-separated plan=model:max_tokens entries, e.g.
+	// "sandbox-starter=gpt-5-mini:1024,sandbox-pro=gpt-5:4096". A plan may
+	// choose its model and any model listed before it in CHAT_ALLOWED_MODELS.
+	// The "default" entry applies to organizations without an active or
+	// listed plan; without it they get the global limits.
+	PlanCeilings string `mapstructure:"CHAT_PLAN_CEILINGS"`
+
+	// AllowedModelList and PlanCeilingMap are the parsed forms of AllowedModels and PlanCeilings
+	AllowedModelList []string                   `mapstructure:"-"`
+	PlanCeilingMap   map[string]ChatPlanCeiling `mapstructure:"-"`
+}
+
+// ChatPlanCeiling is the most capable model and largest response budget a
+// plan may request
+type ChatPlanCeiling struct {
+	Model     string
+	MaxTokens int32
+}
+
+// LoadChatModelConfig loads the chat model configuration from environment variables and app.env file.
+func LoadChatModelConfig() (*ChatModelConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("CHAT_ALLOWED_MODELS", "")
+	v.SetDefault("CHAT_MAX_TEMPERATURE", 1.0)
+	v.SetDefault("CHAT_MAX_TOKENS", 4096)
+	v.SetDefault("CHAT_PLAN_CEILINGS", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg ChatModelConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode chat model config: %w", err)
+	}
+
+	for _, model := range strings.Split(cfg.AllowedModels, ",") {
+		if model = strings.TrimSpace(model); model != "" && !slices.Contains(cfg.AllowedModelList, model) {
+			cfg.AllowedModelList = append(cfg.AllowedModelList, model)
+		}
+	}
+
+	var err error
+	if cfg.PlanCeilingMap, err = parseChatPlanCeilings(cfg.PlanCeilings); err != nil {
+		return nil, fmt.Errorf("chat model config invalid: CHAT_PLAN_CEILINGS: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the limits and that plan ceilings name allowed models.
+func (c *ChatModelConfig) Validate() error {
+	if c.MaxTemperature < 0 || c.MaxTemperature > 2 {
+		return fmt.Errorf("chat model config invalid: CHAT_MAX_TEMPERATURE must be between 0 and 2")
+	}
+	if c.MaxTokens <= 0 {
+		return fmt.Errorf("chat model config invalid: CHAT_MAX_TOKENS must be positive")
+	}
+	for plan, ceiling := range c.PlanCeilingMap {
+		if !slices.Contains(c.AllowedModelList, ceiling.Model) {
+			return fmt.Errorf("chat model config invalid: CHAT_PLAN_CEILINGS: model %q of plan %q is not in CHAT_ALLOWED_MODELS", ceiling.Model, plan)
+		}
+		if ceiling.MaxTokens > c.MaxTokens {
+			return fmt.Errorf("chat model config invalid: CHAT_PLAN_CEILINGS: max tokens of plan %q exceed CHAT_MAX_TOKENS", plan)
+		}
+	}
+	return nil
+}
+
+// Limits returns the models a plan may choose and its largest response
+// budget. An empty planID is an organization without an active subscription.
+func (c *ChatModelConfig) Limits(planID string) ([]string, int32) {
+	ceiling, ok := c.PlanCeilingMap[planID]
+	if !ok {
+		if ceiling, ok = c.PlanCeilingMap[chatPlanCeilingDefault]; !ok {
+			return c.AllowedModelList, c.MaxTokens
+		}
+	}
+
+	last := slices.Index(c.AllowedModelList, ceiling.Model)
+	return c.AllowedModelList[:last+1], ceiling.MaxTokens
+}
+
+// parseChatPlanCeilings parses comma-separated plan=model:max_tokens entries.
+func parseChatPlanCeilings(list string) (map[string]ChatPlanCeiling, error) {
+	ceilings := make(map[string]ChatPlanCeiling)
+	for _, entry := range strings.Split(list, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		plan, limit, ok := strings.Cut(entry, "=")
+		model, maxTokens, hasTokens := strings.Cut(limit, ":")
+		plan, model = strings.TrimSpace(plan), strings.TrimSpace(model)
+		if !ok || !hasTokens || plan == "" || model == "" {
+			return nil, fmt.Errorf("%q must be plan=model:max_tokens", entry)
+		}
+
+		tokens, err := strconv.ParseInt(strings.TrimSpace(maxTokens), 10, 32)
+		if err != nil || tokens <= 0 {
+			return nil, fmt.Errorf("%q must have positive max tokens", entry)
+		}
+
+		ceilings[plan] = ChatPlanCeiling{Model: model, MaxTokens: int32(tokens)}
+	}
+	return ceilings, nil
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// ChatPromptConfig limits the system prompt and glossary organizations add to
+// their chat prompts.
+//
+// All values can be set via environment variables with the CHAT_ prefix.
+type ChatPromptConfig struct {
+	// SystemPromptMaxChars bounds an organization's system prompt
+	SystemPromptMaxChars int `mapstructure:"CHAT_SYSTEM_PROMPT_MAX_CHARS"`
+
+	// GlossaryMaxTerms bounds how many terms a glossary holds
+	GlossaryMaxTerms int `mapstructure:"CHAT_GLOSSARY_MAX_TERMS"`
+
+	// GlossaryTermMaxChars and GlossaryDefinitionMaxChars bound each glossary entry
+	GlossaryTermMaxChars       int `mapstructure:"CHAT_GLOSSARY_TERM_MAX_CHARS"`
+	GlossaryDefinitionMaxChars int `mapstructure:"CHAT_GLOSSARY_DEFINITION_MAX_CHARS"`
+}
+
+// LoadChatPromptConfig loads the chat prompt configuration from environment variables and app.env file.
+func LoadChatPromptConfig() (*ChatPromptConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("CHAT_SYSTEM_PROMPT_MAX_CHARS", 2000)
+	v.SetDefault("CHAT_GLOSSARY_MAX_TERMS", 50)
+	v.SetDefault("CHAT_GLOSSARY_TERM_MAX_CHARS", 100)
+	v.SetDefault("CHAT_GLOSSARY_DEFINITION_MAX_CHARS", 300)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg ChatPromptConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode chat prompt config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that the limits are usable.
+func (c *ChatPromptConfig) Validate() error {
+	if c.SystemPromptMaxChars <= 0 || c.GlossaryTermMaxChars <= 0 || c.GlossaryDefinitionMaxChars <= 0 {
+		return fmt.Errorf("chat prompt config invalid: CHAT_SYSTEM_PROMPT_MAX_CHARS, CHAT_GLOSSARY_TERM_MAX_CHARS and CHAT_GLOSSARY_DEFINITION_MAX_CHARS must be positive")
+	}
+	if c.GlossaryMaxTerms < 0 {
+		return fmt.Errorf("chat prompt config invalid: CHAT_GLOSSARY_MAX_TERMS must not be negative")
+	}
+	return nil
+}
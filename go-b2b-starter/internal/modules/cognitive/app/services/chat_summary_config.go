@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// ChatSummaryConfig controls how long conversations are compressed. Once the
+// messages sent verbatim exceed the context budget, all but the newest are
+// folded into a rolling summary that is sent in their place.
+//
+// All values can be set via environment variables with the CHAT_ prefix.
+type ChatSummaryConfig struct {
+	// Enabled turns summarization on; when off, older messages simply drop
+	// out of the context history
+	Enabled bool `mapstructure:"CHAT_SUMMARY_ENABLED"`
+
+	// Model writes the summaries; empty uses the LLM configuration's model
+	Model string `mapstructure:"CHAT_SUMMARY_MODEL"`
+
+	// ContextBudgetTokens is the estimated size of the verbatim messages above
+	// which older ones are summarized
+	ContextBudgetTokens int `mapstructure:"CHAT_CONTEXT_BUDGET_TOKENS"`
+
+	// KeepMessages is how many of the newest messages are always sent verbatim
+	KeepMessages int `mapstructure:"CHAT_SUMMARY_KEEP_MESSAGES"`
+
+	// MaxTokens bounds the length of a summary
+	MaxTokens int32 `mapstructure:"CHAT_SUMMARY_MAX_TOKENS"`
+}
+
+// LoadChatSummaryConfig loads the chat summary configuration from environment variables and app.env file.
+func LoadChatSummaryConfig() (*ChatSummaryConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("CHAT_SUMMARY_ENABLED", true)
+	v.SetDefault("CHAT_SUMMARY_MODEL", "")
+	v.SetDefault("CHAT_CONTEXT_BUDGET_TOKENS", 3000)
+	v.SetDefault("CHAT_SUMMARY_KEEP_MESSAGES", 6)
+	v.SetDefault("CHAT_SUMMARY_MAX_TOKENS", 400)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg ChatSummaryConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode chat summary config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the thresholds when summarization is enabled.
+func (c *ChatSummaryConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.ContextBudgetTokens <= 0 || c.MaxTokens <= 0 {
+		return fmt.Errorf("chat summary config invalid: CHAT_CONTEXT_BUDGET_TOKENS and CHAT_SUMMARY_MAX_TOKENS must be positive")
+	}
+	if c.KeepMessages < 1 {
+		return fmt.Errorf("chat summary config invalid: CHAT_SUMMARY_KEEP_MESSAGES must be at least 1")
+	}
+	return nil
+}
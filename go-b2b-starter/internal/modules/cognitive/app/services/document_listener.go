@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
 )
 
 type documentListener struct {
@@ -17,12 +19,25 @@ func NewDocumentListener(
 	}
 }
 
-func (l *documentListener) HandleDocumentUploaded(ctx context.Context, documentID, orgID int32, text string) error {
+func (l *documentListener) HandleDocumentUploaded(ctx context.Context, documentID, orgID int32, text string, chunks []domain.DocumentChunk) error {
 	// Skip if no text to embed
 	if text == "" {
 		return nil
 	}
 
+	// A new version replaces the embeddings of the previous one
+	if err := l.embeddingService.DeleteDocumentEmbeddings(ctx, orgID, documentID); err != nil {
+		return fmt.Errorf("failed to replace document embeddings: %w", err)
+	}
+
+	// Documents split at source boundaries (spreadsheet rows, transcript segments) are embedded chunk by chunk
+	if len(chunks) > 0 {
+		if _, err := l.embeddingService.EmbedChunks(ctx, orgID, documentID, chunks); err != nil {
+			return fmt.Errorf("failed to embed document chunks: %w", err)
+		}
+		return nil
+	}
+
 	// Create embedding for the document
 	_, err := l.embeddingService.EmbedDocument(ctx, orgID, documentID, text)
 	if err != nil {
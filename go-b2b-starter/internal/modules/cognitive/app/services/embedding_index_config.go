@@ -0,0 +1,80 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// Embedding reduction methods for EMBEDDING_DOCUMENTS_REDUCTION.
+const (
+	// EmbeddingReductionNative asks the embedding model for shortened vectors
+	EmbeddingReductionNative = "native"
+	// EmbeddingReductionPCA projects full vectors with a precomputed PCA projection
+	EmbeddingReductionPCA = "pca"
+)
+
+// EmbeddingIndexConfig sets the size of the vectors stored in the document
+// index. Smaller vectors shrink pgvector storage for very large corpora at
+// some cost in retrieval quality.
+//
+// All values can be set via environment variables with the EMBEDDING_DOCUMENTS_ prefix.
+type EmbeddingIndexConfig struct {
+	// Dimensions is the size of stored and query vectors
+	Dimensions int `mapstructure:"EMBEDDING_DOCUMENTS_DIMENSIONS"`
+
+	// Reduction is how vectors are shortened: "native" or "pca"
+	Reduction string `mapstructure:"EMBEDDING_DOCUMENTS_REDUCTION"`
+
+	// PCAPath is the JSON file with the projection used by "pca"
+	PCAPath string `mapstructure:"EMBEDDING_DOCUMENTS_PCA_PATH"`
+}
+
+// LoadEmbeddingIndexConfig loads the document index configuration from environment variables and app.env file.
+func LoadEmbeddingIndexConfig() (*EmbeddingIndexConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("EMBEDDING_DOCUMENTS_DIMENSIONS", 1536)
+	v.SetDefault("EMBEDDING_DOCUMENTS_REDUCTION", EmbeddingReductionNative)
+	v.SetDefault("EMBEDDING_DOCUMENTS_PCA_PATH", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg EmbeddingIndexConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode embedding index config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the dimensions and that "pca" has a projection file.
+func (c *EmbeddingIndexConfig) Validate() error {
+	if c.Dimensions <= 0 {
+		return fmt.Errorf("embedding index config invalid: EMBEDDING_DOCUMENTS_DIMENSIONS must be positive")
+	}
+	switch c.Reduction {
+	case EmbeddingReductionNative:
+	case EmbeddingReductionPCA:
+		if c.PCAPath == "" {
+			return fmt.Errorf("embedding index config invalid: EMBEDDING_DOCUMENTS_PCA_PATH is required for pca reduction")
+		}
+	default:
+		return fmt.Errorf("embedding index config invalid: unknown EMBEDDING_DOCUMENTS_REDUCTION %q", c.Reduction)
+	}
+	return nil
+}
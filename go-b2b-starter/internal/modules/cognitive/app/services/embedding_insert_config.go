@@ -0,0 +1,63 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// EmbeddingInsertConfig controls how chunk embeddings are written when a
+// document is indexed. Chunks are inserted many rows per statement, and a
+// large document is split over several transactions so no single one holds
+// locks or WAL for the whole document.
+//
+// All values can be set via environment variables with the EMBEDDING_INSERT_ prefix.
+type EmbeddingInsertConfig struct {
+	// BatchSize is the number of chunk rows inserted per statement
+	BatchSize int `mapstructure:"EMBEDDING_INSERT_BATCH_SIZE"`
+
+	// TxSize is the number of chunk rows committed per transaction
+	TxSize int `mapstructure:"EMBEDDING_INSERT_TX_SIZE"`
+}
+
+// LoadEmbeddingInsertConfig loads the embedding insert configuration from environment variables and app.env file.
+func LoadEmbeddingInsertConfig() (*EmbeddingInsertConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("EMBEDDING_INSERT_BATCH_SIZE", 100)
+	v.SetDefault("EMBEDDING_INSERT_TX_SIZE", 1000)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg EmbeddingInsertConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode embedding insert config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that a transaction holds at least one full batch.
+func (c *EmbeddingInsertConfig) Validate() error {
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("embedding insert config invalid: EMBEDDING_INSERT_BATCH_SIZE must be positive")
+	}
+	if c.TxSize < c.BatchSize {
+		return fmt.Errorf("embedding insert config invalid: EMBEDDING_INSERT_TX_SIZE must be at least EMBEDDING_INSERT_BATCH_SIZE")
+	}
+	return nil
+}
@@ -19,15 +19,18 @@ const (
 type embeddingService struct {
 	embeddingRepo  domain.EmbeddingRepository
 	textVectorizer domain.TextVectorizer
+	insertConfig   *EmbeddingInsertConfig
 }
 
 func NewEmbeddingService(
 	embeddingRepo domain.EmbeddingRepository,
 	textVectorizer domain.TextVectorizer,
+	insertConfig *EmbeddingInsertConfig,
 ) EmbeddingService {
 	return &embeddingService{
 		embeddingRepo:  embeddingRepo,
 		textVectorizer: textVectorizer,
+		insertConfig:   insertConfig,
 	}
 }
 
@@ -65,6 +68,60 @@ func (s *embeddingService) EmbedDocument(ctx context.Context, orgID, documentID
 	return result, nil
 }
 
+func (s *embeddingService) EmbedChunks(ctx context.Context, orgID, documentID int32, chunks []domain.DocumentChunk) ([]*domain.DocumentEmbedding, error) {
+	results := make([]*domain.DocumentEmbedding, 0, len(chunks))
+	pending := make([]*domain.DocumentEmbedding, 0, min(len(chunks), s.insertConfig.TxSize))
+
+	// flush stores the pending chunks in one transaction
+	flush := func() error {
+		if len(pending) == 0 {
+			return nil
+		}
+		stored, err := s.embeddingRepo.CreateBatch(ctx, orgID, documentID, pending, s.insertConfig.BatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to store embeddings for chunks %d-%d: %w", pending[0].ChunkIndex, pending[len(pending)-1].ChunkIndex, err)
+		}
+		results = append(results, stored...)
+		pending = pending[:0]
+		return nil
+	}
+
+	for i, chunk := range chunks {
+		text := chunk.Text
+		if len(text) > MaxChunkSize {
+			text = text[:MaxChunkSize]
+		}
+
+		embedding, err := s.textVectorizer.Vectorize(ctx, text)
+		if err != nil {
+			return nil, fmt.Errorf("%w: chunk %d: %v", domain.ErrEmbeddingGenerationFailed, i, err)
+		}
+
+		// The whole chunk is kept as the preview so answers can quote it
+		pending = append(pending, &domain.DocumentEmbedding{
+			DocumentID:     documentID,
+			OrganizationID: orgID,
+			Embedding:      embedding,
+			ContentHash:    s.hashContent(text),
+			ContentPreview: text,
+			ChunkIndex:     int32(i),
+			Location:       chunk.Location,
+		})
+
+		if len(pending) >= s.insertConfig.TxSize {
+			if err := flush(); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 func (s *embeddingService) GetDocumentEmbeddings(ctx context.Context, orgID, documentID int32) ([]*domain.DocumentEmbedding, error) {
 	return s.embeddingRepo.GetByDocumentID(ctx, orgID, documentID)
 }
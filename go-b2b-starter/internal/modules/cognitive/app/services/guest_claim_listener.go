@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
+)
+
+type guestClaimListener struct {
+	chatRepo domain.ChatRepository
+}
+
+func NewGuestClaimListener(
+	chatRepo domain.ChatRepository,
+) GuestClaimListener {
+	return &guestClaimListener{
+		chatRepo: chatRepo,
+	}
+}
+
+func (l *guestClaimListener) HandleGuestSessionClaimed(ctx context.Context, guestOrgID, guestAccountID, orgID, accountID int32) error {
+	if _, err := l.chatRepo.ReassignSessions(ctx, guestOrgID, guestAccountID, orgID, accountID); err != nil {
+		return fmt.Errorf("failed to move guest chat sessions: %w", err)
+	}
+
+	return nil
+}
@@ -11,6 +11,9 @@ type EmbeddingService interface {
 	// EmbedDocument generates and stores embeddings for a document
 	EmbedDocument(ctx context.Context, orgID, documentID int32, text string) (*domain.DocumentEmbedding, error)
 
+	// EmbedChunks generates and stores one embedding per chunk, in order
+	EmbedChunks(ctx context.Context, orgID, documentID int32, chunks []domain.DocumentChunk) ([]*domain.DocumentEmbedding, error)
+
 	// GetDocumentEmbeddings retrieves embeddings for a document
 	GetDocumentEmbeddings(ctx context.Context, orgID, documentID int32) ([]*domain.DocumentEmbedding, error)
 
@@ -29,6 +32,24 @@ type RAGService interface {
 	// Chat sends a message and gets a response, optionally using RAG
 	Chat(ctx context.Context, orgID, accountID int32, req *domain.ChatRequest) (*domain.ChatResponse, error)
 
+	// ChatStream is Chat with the answer passed to onChunk as it is generated.
+	// A client that disconnects cancels ctx, which stops the generation; the
+	// partial answer is then saved as a truncated message.
+	ChatStream(ctx context.Context, orgID, accountID int32, req *domain.ChatRequest, onChunk func(content string) error) (*domain.ChatResponse, error)
+
+	// ContinueMessage streams the rest of the session's truncated last answer
+	// and appends it to that message
+	ContinueMessage(ctx context.Context, orgID, sessionID int32, onChunk func(content string) error) (*domain.ChatResponse, error)
+
+	// RegenerateMessage replaces the session's last answer with a new answer to
+	// its last question. req names the session and the retrieval options; its
+	// message is ignored. onChunk may be nil.
+	RegenerateMessage(ctx context.Context, orgID int32, req *domain.ChatRequest, onChunk func(content string) error) (*domain.ChatResponse, error)
+
+	// ExplainRetrieval returns the documents req.Message retrieves and the
+	// prompt chat would send for it, without saving or answering it
+	ExplainRetrieval(ctx context.Context, orgID int32, req *domain.ChatRequest) (*domain.RetrievalExplanation, error)
+
 	// GetSession retrieves a chat session
 	GetSession(ctx context.Context, orgID, sessionID int32) (*domain.ChatSession, error)
 
@@ -43,10 +64,47 @@ type RAGService interface {
 
 	// UpdateSessionTitle updates the title of a chat session
 	UpdateSessionTitle(ctx context.Context, orgID, sessionID int32, title string) (*domain.ChatSession, error)
+
+	// UpdateSessionParameters replaces the model parameters of a chat session.
+	// Empty parameters fall back to the server's defaults.
+	UpdateSessionParameters(ctx context.Context, orgID, sessionID int32, params domain.ModelParameters) (*domain.ChatSession, error)
+}
+
+// PromptSettingsService manages the versioned system prompt, glossary and
+// default language an organization adds to its chat prompts
+type PromptSettingsService interface {
+	// GetSettings returns the newest version; version 0 with no prompt or
+	// glossary when the organization never customized its prompt
+	GetSettings(ctx context.Context, orgID int32) (*domain.PromptSettings, error)
+
+	// UpdateSettings sanitizes and checks the system prompt, glossary and
+	// language and stores them as a new version. Empty values reset the
+	// customization.
+	UpdateSettings(ctx context.Context, orgID, accountID int32, systemPrompt string, glossary []domain.GlossaryTerm, lang string) (*domain.PromptSettings, error)
+
+	// ListVersions lists stored versions, newest first
+	ListVersions(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.PromptSettings, error)
+
+	// RestoreVersion stores a copy of an earlier version as the newest one
+	RestoreVersion(ctx context.Context, orgID, accountID, version int32) (*domain.PromptSettings, error)
 }
 
 // DocumentListener handles document events from the documents module
 type DocumentListener interface {
-	// HandleDocumentUploaded processes the DocumentUploaded event
-	HandleDocumentUploaded(ctx context.Context, documentID, orgID int32, text string) error
+	// HandleDocumentUploaded processes the DocumentUploaded event. When chunks
+	// are given they are embedded instead of the whole text.
+	HandleDocumentUploaded(ctx context.Context, documentID, orgID int32, text string, chunks []domain.DocumentChunk) error
+}
+
+// GuestClaimListener handles guest session claims from the organizations module
+type GuestClaimListener interface {
+	// HandleGuestSessionClaimed moves the guest's chat sessions to the claiming account
+	HandleGuestSessionClaimed(ctx context.Context, guestOrgID, guestAccountID, orgID, accountID int32) error
+}
+
+// MemberOffboardingListener handles member offboarding from the organizations module
+type MemberOffboardingListener interface {
+	// HandleMemberOffboarding transfers the member's chat sessions, or deletes them when
+	// transferToAccountID is 0, and returns how many sessions were handled
+	HandleMemberOffboarding(ctx context.Context, orgID, accountID, transferToAccountID int32) (int64, error)
 }
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
+)
+
+type memberOffboardingListener struct {
+	chatRepo domain.ChatRepository
+}
+
+func NewMemberOffboardingListener(
+	chatRepo domain.ChatRepository,
+) MemberOffboardingListener {
+	return &memberOffboardingListener{
+		chatRepo: chatRepo,
+	}
+}
+
+func (l *memberOffboardingListener) HandleMemberOffboarding(ctx context.Context, orgID, accountID, transferToAccountID int32) (int64, error) {
+	if transferToAccountID == 0 {
+		deleted, err := l.chatRepo.DeleteSessionsByAccount(ctx, orgID, accountID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to delete offboarded member chat sessions: %w", err)
+		}
+		return deleted, nil
+	}
+
+	moved, err := l.chatRepo.ReassignSessions(ctx, orgID, accountID, orgID, transferToAccountID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to transfer offboarded member chat sessions: %w", err)
+	}
+	return moved, nil
+}
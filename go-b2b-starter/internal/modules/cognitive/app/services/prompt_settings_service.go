@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
+)
+
+// promptReservedText are the delimiters and speaker labels of the chat prompt
+// template. Organization text must not contain them, so it cannot close its
+// own section or pass itself off as documents or conversation.
+var promptReservedText = []string{
+	"---",
+	"user question:",
+	"previous conversation:",
+	"current prompt:",
+	"summary of earlier conversation:",
+}
+
+// promptReservedLabels may not start a line of a system prompt, where they
+// would read as a turn of the conversation
+var promptReservedLabels = []string{"user:", "assistant:", "system:"}
+
+type promptSettingsService struct {
+	repo   domain.PromptSettingsRepository
+	config *ChatPromptConfig
+}
+
+func NewPromptSettingsService(repo domain.PromptSettingsRepository, config *ChatPromptConfig) PromptSettingsService {
+	return &promptSettingsService{
+		repo:   repo,
+		config: config,
+	}
+}
+
+func (s *promptSettingsService) GetSettings(ctx context.Context, orgID int32) (*domain.PromptSettings, error) {
+	settings, err := s.repo.GetLatest(ctx, orgID)
+	if errors.Is(err, domain.ErrPromptSettingsNotFound) {
+		return &domain.PromptSettings{OrganizationID: orgID, Glossary: []domain.GlossaryTerm{}}, nil
+	}
+	return settings, err
+}
+
+func (s *promptSettingsService) UpdateSettings(ctx context.Context, orgID, accountID int32, systemPrompt string, glossary []domain.GlossaryTerm, lang string) (*domain.PromptSettings, error) {
+	settings, err := s.sanitize(systemPrompt, glossary, lang)
+	if err != nil {
+		return nil, err
+	}
+
+	settings.OrganizationID = orgID
+	settings.CreatedByAccountID = &accountID
+	return s.repo.CreateVersion(ctx, settings)
+}
+
+func (s *promptSettingsService) ListVersions(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.PromptSettings, error) {
+	return s.repo.ListVersions(ctx, orgID, limit, offset)
+}
+
+func (s *promptSettingsService) RestoreVersion(ctx context.Context, orgID, accountID, version int32) (*domain.PromptSettings, error) {
+	previous, err := s.repo.GetVersion(ctx, orgID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	// Limits may have tightened since the version was stored
+	return s.UpdateSettings(ctx, orgID, accountID, previous.SystemPrompt, previous.Glossary, previous.Language)
+}
+
+// sanitize cleans the system prompt and glossary and checks them against the
+// configured limits and the text reserved for the prompt template. The
+// language is stored in its canonical form.
+func (s *promptSettingsService) sanitize(systemPrompt string, glossary []domain.GlossaryTerm, lang string) (*domain.PromptSettings, error) {
+	systemPrompt = sanitizePromptText(systemPrompt, true)
+	if utf8.RuneCountInString(systemPrompt) > s.config.SystemPromptMaxChars {
+		return nil, fmt.Errorf("%w: at most %d characters", domain.ErrSystemPromptTooLong, s.config.SystemPromptMaxChars)
+	}
+	if err := checkPromptReservedText(systemPrompt); err != nil {
+		return nil, err
+	}
+
+	if len(glossary) > s.config.GlossaryMaxTerms {
+		return nil, fmt.Errorf("%w: at most %d", domain.ErrGlossaryTooLarge, s.config.GlossaryMaxTerms)
+	}
+	terms := make([]domain.GlossaryTerm, 0, len(glossary))
+	for _, entry := range glossary {
+		term := sanitizePromptText(entry.Term, false)
+		definition := sanitizePromptText(entry.Definition, false)
+		if term == "" || definition == "" ||
+			utf8.RuneCountInString(term) > s.config.GlossaryTermMaxChars ||
+			utf8.RuneCountInString(definition) > s.config.GlossaryDefinitionMaxChars {
+			return nil, fmt.Errorf("%w: terms up to %d and definitions up to %d characters",
+				domain.ErrGlossaryTermInvalid, s.config.GlossaryTermMaxChars, s.config.GlossaryDefinitionMaxChars)
+		}
+		if err := checkPromptReservedText(term + "\n" + definition); err != nil {
+			return nil, err
+		}
+		terms = append(terms, domain.GlossaryTerm{Term: term, Definition: definition})
+	}
+
+	if lang = strings.TrimSpace(lang); lang != "" {
+		tag, err := language.Parse(lang)
+		if err != nil || tag == language.Und {
+			return nil, fmt.Errorf("%w: %q", domain.ErrLanguageInvalid, lang)
+		}
+		lang = tag.String()
+	}
+
+	return &domain.PromptSettings{
+		SystemPrompt: systemPrompt,
+		Glossary:     terms,
+		Language:     lang,
+	}, nil
+}
+
+// sanitizePromptText drops invalid UTF-8, control and invisible formatting
+// characters (such as bidirectional overrides) and trims the text. Multiline
+// text keeps its line breaks with trailing spaces removed; other text is
+// collapsed to a single line.
+func sanitizePromptText(text string, multiline bool) string {
+	text = strings.ToValidUTF8(text, "")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	text = strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' || r == '\t':
+			return r
+		case r == '\r':
+			return '\n'
+		case unicode.IsControl(r), unicode.Is(unicode.Cf, r):
+			return -1
+		}
+		return r
+	}, text)
+
+	if !multiline {
+		return strings.Join(strings.Fields(text), " ")
+	}
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRightFunc(line, unicode.IsSpace)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// checkPromptReservedText rejects text containing the prompt template's
+// delimiters, or lines starting with a speaker label.
+func checkPromptReservedText(text string) error {
+	lower := strings.ToLower(text)
+	for _, reserved := range promptReservedText {
+		if strings.Contains(lower, reserved) {
+			return fmt.Errorf("%w: %q", domain.ErrPromptReservedText, reserved)
+		}
+	}
+	for _, line := range strings.Split(lower, "\n") {
+		line = strings.TrimSpace(line)
+		for _, label := range promptReservedLabels {
+			if strings.HasPrefix(line, label) {
+				return fmt.Errorf("%w: lines cannot start with %q", domain.ErrPromptReservedText, label)
+			}
+		}
+	}
+	return nil
+}
@@ -2,8 +2,14 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/language"
+	"golang.org/x/text/language/display"
 
 	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
 )
@@ -16,7 +22,14 @@ const (
 	// SystemPrompt is the default system prompt for RAG
 	SystemPrompt = `You are a helpful assistant that answers questions based on the provided context.
 If the context doesn't contain relevant information, say so clearly.
-Always cite which documents you used to answer the question.`
+Always cite which documents you used to answer the question.
+When a document gives a location, cite it: the cells the answer comes from for a sheet and cell range (for example Sales!C12), or the time range for a recording (for example 00:04:10-00:05:30).`
+	// ContinuePrompt asks for the rest of a truncated answer, which ends the conversation history
+	ContinuePrompt = "Your last answer was cut off. Continue it exactly where it stopped, without repeating any of it."
+	// SummaryPrompt asks for the rolling summary of a conversation's older messages
+	SummaryPrompt = `Summarize the conversation below for an assistant that will continue it.
+Keep facts, decisions, names, numbers and open questions; leave out greetings and small talk.
+Write plain prose without speaker labels.`
 )
 
 type ragService struct {
@@ -24,6 +37,10 @@ type ragService struct {
 	embeddingRepo     domain.EmbeddingRepository
 	textVectorizer    domain.TextVectorizer
 	assistantProvider domain.AssistantProvider
+	planRepo          domain.PlanRepository
+	modelConfig       *ChatModelConfig
+	promptSettings    domain.PromptSettingsRepository
+	summaryConfig     *ChatSummaryConfig
 }
 
 func NewRAGService(
@@ -31,16 +48,34 @@ func NewRAGService(
 	embeddingRepo domain.EmbeddingRepository,
 	textVectorizer domain.TextVectorizer,
 	assistantProvider domain.AssistantProvider,
+	planRepo domain.PlanRepository,
+	modelConfig *ChatModelConfig,
+	promptSettings domain.PromptSettingsRepository,
+	summaryConfig *ChatSummaryConfig,
 ) RAGService {
 	return &ragService{
 		chatRepo:          chatRepo,
 		embeddingRepo:     embeddingRepo,
 		textVectorizer:    textVectorizer,
 		assistantProvider: assistantProvider,
+		planRepo:          planRepo,
+		modelConfig:       modelConfig,
+		promptSettings:    promptSettings,
+		summaryConfig:     summaryConfig,
 	}
 }
 
 func (s *ragService) Chat(ctx context.Context, orgID, accountID int32, req *domain.ChatRequest) (*domain.ChatResponse, error) {
+	return s.chat(ctx, orgID, accountID, req, nil)
+}
+
+func (s *ragService) ChatStream(ctx context.Context, orgID, accountID int32, req *domain.ChatRequest, onChunk func(content string) error) (*domain.ChatResponse, error) {
+	return s.chat(ctx, orgID, accountID, req, onChunk)
+}
+
+// chat saves the user's message and answers it, streaming the answer to
+// onChunk when one is given
+func (s *ragService) chat(ctx context.Context, orgID, accountID int32, req *domain.ChatRequest, onChunk func(content string) error) (*domain.ChatResponse, error) {
 	var session *domain.ChatSession
 	var err error
 
@@ -50,12 +85,30 @@ func (s *ragService) Chat(ctx context.Context, orgID, accountID int32, req *doma
 		if err != nil {
 			return nil, fmt.Errorf("failed to get session: %w", err)
 		}
+
+		// Requested parameters replace the session's; the plan may have changed
+		// since the others were stored, so all of them are checked again
+		params := mergeParameters(session.ModelParameters, req.ModelParameters)
+		if err := s.checkParameters(ctx, orgID, params); err != nil {
+			return nil, err
+		}
+		if !req.ModelParameters.IsEmpty() {
+			session, err = s.chatRepo.UpdateSessionParameters(ctx, orgID, session.ID, params)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update session parameters: %w", err)
+			}
+		}
 	} else {
+		if err := s.checkParameters(ctx, orgID, req.ModelParameters); err != nil {
+			return nil, err
+		}
+
 		// Create new session
 		session = &domain.ChatSession{
-			OrganizationID: orgID,
-			AccountID:      accountID,
-			Title:          generateSessionTitle(req.Message),
+			OrganizationID:  orgID,
+			AccountID:       accountID,
+			Title:           generateSessionTitle(req.Message),
+			ModelParameters: req.ModelParameters,
 		}
 		session, err = s.chatRepo.CreateSession(ctx, session)
 		if err != nil {
@@ -74,45 +127,115 @@ func (s *ragService) Chat(ctx context.Context, orgID, accountID int32, req *doma
 		return nil, fmt.Errorf("failed to save user message: %w", err)
 	}
 
-	// Build context and generate response
-	var referencedDocs []*domain.SimilarDocument
-	var prompt string
+	return s.answer(ctx, orgID, session, req, onChunk)
+}
 
-	if req.UseRAG {
-		// Search for similar documents
-		maxDocs := req.MaxDocuments
-		if maxDocs <= 0 {
-			maxDocs = DefaultMaxDocuments
-		}
+func (s *ragService) ContinueMessage(ctx context.Context, orgID, sessionID int32, onChunk func(content string) error) (*domain.ChatResponse, error) {
+	session, err := s.chatRepo.GetSessionByID(ctx, orgID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if err := s.checkParameters(ctx, orgID, session.ModelParameters); err != nil {
+		return nil, err
+	}
 
-		// Generate embedding for the query and search
-		embedding, err := s.textVectorizer.Vectorize(ctx, req.Message)
-		if err == nil {
-			docs, err := s.embeddingRepo.SearchSimilar(ctx, orgID, embedding, int32(maxDocs))
-			if err == nil {
-				referencedDocs = docs
-			}
-		}
+	history, summary, err := s.conversationHistory(ctx, session, DefaultContextHistory, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session history: %w", err)
+	}
+	if len(history) == 0 || history[0].Role != domain.ChatRoleAssistant || !history[0].Truncated {
+		return nil, domain.ErrNothingToContinue
+	}
+	truncated := history[0]
 
-		// Build RAG prompt
-		prompt = s.buildRAGPrompt(req.Message, referencedDocs)
-	} else {
-		prompt = req.Message
+	instructions, err := s.organizationInstructions(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	prompt := ContinuePrompt
+	if instructions != "" {
+		prompt = fmt.Sprintf("%s\n\n%s", instructions, ContinuePrompt)
+	}
+
+	// The history ends with the truncated answer the model picks up from
+	response, err := s.generate(ctx, s.buildPromptWithHistory(prompt, history, summary), session.ModelParameters, onChunk)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrRAGCompletionFailed, err)
+	}
+
+	// Saved even when the client disconnected, which cancels ctx
+	message, err := s.chatRepo.UpdateMessageContent(context.WithoutCancel(ctx), truncated.ID,
+		truncated.Content+response.Content, truncated.TokensUsed+int32(response.TokensUsed), response.Truncated)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save assistant message: %w", err)
 	}
 
-	// Get conversation history for context
-	contextHistory := req.ContextHistory
-	if contextHistory <= 0 {
-		contextHistory = DefaultContextHistory
+	return &domain.ChatResponse{
+		SessionID:  session.ID,
+		Message:    message,
+		TokensUsed: int32(response.TokensUsed),
+	}, nil
+}
+
+func (s *ragService) RegenerateMessage(ctx context.Context, orgID int32, req *domain.ChatRequest, onChunk func(content string) error) (*domain.ChatResponse, error) {
+	session, err := s.chatRepo.GetSessionByID(ctx, orgID, req.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if err := s.checkParameters(ctx, orgID, session.ModelParameters); err != nil {
+		return nil, err
 	}
 
-	history, _ := s.chatRepo.GetRecentMessages(ctx, session.ID, int32(contextHistory))
+	history, err := s.chatRepo.GetRecentMessages(ctx, session.ID, 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get session history: %w", err)
+	}
 
-	// Build full prompt with history
-	fullPrompt := s.buildPromptWithHistory(prompt, history)
+	// The last answer, complete or truncated, is replaced. A question whose
+	// answer was never saved is answered as it is.
+	var previous *domain.ChatMessage
+	if len(history) > 0 && history[0].Role == domain.ChatRoleAssistant {
+		previous, history = history[0], history[1:]
+	}
+	if len(history) == 0 || history[0].Role != domain.ChatRoleUser {
+		return nil, domain.ErrNothingToRegenerate
+	}
+
+	// Removed first so the new answer is not based on it; if generation fails
+	// the session ends with the question, which can be regenerated again
+	if previous != nil {
+		if err := s.chatRepo.DeleteMessage(ctx, previous.ID); err != nil {
+			return nil, fmt.Errorf("failed to delete previous answer: %w", err)
+		}
+	}
+
+	question := *req
+	question.Message = history[0].Content
+	return s.answer(ctx, orgID, session, &question, onChunk)
+}
+
+// answer generates and saves the answer to req.Message, which is already the
+// session's last message. When a streamed answer stops early, for example
+// because the client disconnected, the partial answer is saved as truncated.
+func (s *ragService) answer(ctx context.Context, orgID int32, session *domain.ChatSession, req *domain.ChatRequest, onChunk func(content string) error) (*domain.ChatResponse, error) {
+	// Search for similar documents; a failed search leaves the answer
+	// without document context
+	var referencedDocs []*domain.SimilarDocument
+	if req.UseRAG {
+		referencedDocs, _ = s.retrieve(ctx, orgID, req.Message, maxDocuments(req.MaxDocuments))
+	}
+
+	// Get conversation history for context, summarizing older messages once
+	// the conversation outgrows the context budget
+	history, summary, _ := s.conversationHistory(ctx, session, contextHistory(req.ContextHistory), true)
+
+	fullPrompt, err := s.assemblePrompt(ctx, orgID, req, referencedDocs, history, summary)
+	if err != nil {
+		return nil, err
+	}
 
 	// Generate response using AI assistant
-	response, err := s.assistantProvider.GenerateResponse(ctx, fullPrompt)
+	response, err := s.generate(ctx, fullPrompt, session.ModelParameters, onChunk)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %v", domain.ErrRAGCompletionFailed, err)
 	}
@@ -123,15 +246,18 @@ func (s *ragService) Chat(ctx context.Context, orgID, accountID int32, req *doma
 		docIDs = append(docIDs, doc.DocumentID)
 	}
 
-	// Save assistant response
+	// Save assistant response with the parameters it was generated with.
+	// It is saved even when the client disconnected, which cancels ctx.
 	assistantMessage := &domain.ChatMessage{
-		SessionID:      session.ID,
-		Role:           domain.ChatRoleAssistant,
-		Content:        response.Content,
-		ReferencedDocs: docIDs,
-		TokensUsed:     int32(response.TokensUsed),
+		SessionID:       session.ID,
+		Role:            domain.ChatRoleAssistant,
+		Content:         response.Content,
+		ReferencedDocs:  docIDs,
+		TokensUsed:      int32(response.TokensUsed),
+		Truncated:       response.Truncated,
+		ModelParameters: response.Parameters,
 	}
-	assistantMessage, err = s.chatRepo.CreateMessage(ctx, assistantMessage)
+	assistantMessage, err = s.chatRepo.CreateMessage(context.WithoutCancel(ctx), assistantMessage)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save assistant message: %w", err)
 	}
@@ -152,6 +278,210 @@ func (s *ragService) Chat(ctx context.Context, orgID, accountID int32, req *doma
 	}, nil
 }
 
+func (s *ragService) ExplainRetrieval(ctx context.Context, orgID int32, req *domain.ChatRequest) (*domain.RetrievalExplanation, error) {
+	// Chat saves the message before reading the history, so it is the
+	// history's newest entry
+	history := []*domain.ChatMessage{{Role: domain.ChatRoleUser, Content: req.Message}}
+	var summary string
+	if req.SessionID > 0 {
+		session, err := s.chatRepo.GetSessionByID(ctx, orgID, req.SessionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get session: %w", err)
+		}
+		// The summary is shown as it stands; it is not brought up to date
+		var previous []*domain.ChatMessage
+		previous, summary, err = s.conversationHistory(ctx, session, contextHistory(req.ContextHistory)-1, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get session history: %w", err)
+		}
+		history = append(history, previous...)
+	}
+
+	filters := domain.RetrievalFilters{
+		OrganizationID: orgID,
+		MaxDocuments:   maxDocuments(req.MaxDocuments),
+	}
+	docs, err := s.retrieve(ctx, orgID, req.Message, filters.MaxDocuments)
+	if err != nil {
+		return nil, err
+	}
+
+	prompt, err := s.assemblePrompt(ctx, orgID, req, docs, history, summary)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]domain.SimilarDocument, 0, len(docs))
+	for _, doc := range docs {
+		if doc != nil {
+			documents = append(documents, *doc)
+		}
+	}
+
+	return &domain.RetrievalExplanation{
+		Query:     req.Message,
+		Filters:   filters,
+		Documents: documents,
+		Prompt:    prompt,
+	}, nil
+}
+
+// retrieve finds the organization's document chunks most similar to query
+func (s *ragService) retrieve(ctx context.Context, orgID int32, query string, limit int) ([]*domain.SimilarDocument, error) {
+	embedding, err := s.textVectorizer.Vectorize(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrEmbeddingGenerationFailed, err)
+	}
+
+	docs, err := s.embeddingRepo.SearchSimilar(ctx, orgID, embedding, int32(limit))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrRAGSearchFailed, err)
+	}
+	return docs, nil
+}
+
+// assemblePrompt builds the prompt for answering req.Message from the
+// retrieved documents, the organization's instructions, the history and the
+// summary of the conversation before it
+func (s *ragService) assemblePrompt(ctx context.Context, orgID int32, req *domain.ChatRequest, docs []*domain.SimilarDocument, history []*domain.ChatMessage, summary string) (string, error) {
+	// The organization's prompt customization applies with and without RAG
+	instructions, err := s.organizationInstructions(ctx, orgID)
+	if err != nil {
+		return "", err
+	}
+
+	var prompt string
+	if req.UseRAG {
+		prompt = s.buildRAGPrompt(req.Message, docs, instructions)
+	} else if instructions != "" {
+		prompt = fmt.Sprintf("%s\n\nUser Question: %s", instructions, req.Message)
+	} else {
+		prompt = req.Message
+	}
+
+	return s.buildPromptWithHistory(prompt, history, summary), nil
+}
+
+// maxDocuments returns the number of documents to retrieve for a requested number
+func maxDocuments(requested int) int {
+	if requested <= 0 {
+		return DefaultMaxDocuments
+	}
+	return requested
+}
+
+// contextHistory returns the number of messages to include for a requested number
+func contextHistory(requested int) int {
+	if requested <= 0 {
+		return DefaultContextHistory
+	}
+	return requested
+}
+
+// conversationHistory returns up to limit of the session's latest messages,
+// newest first, and the summary of the messages before the unsummarized
+// ones. With compress set, once the unsummarized messages exceed the context
+// budget all but the newest few are folded into the session's summary first.
+func (s *ragService) conversationHistory(ctx context.Context, session *domain.ChatSession, limit int, compress bool) ([]*domain.ChatMessage, string, error) {
+	if !s.summaryConfig.Enabled {
+		history, err := s.chatRepo.GetRecentMessages(ctx, session.ID, int32(limit))
+		return history, "", err
+	}
+
+	messages, err := s.chatRepo.GetMessagesAfter(ctx, session.ID, session.SummarizedThroughMessageID)
+	if err != nil {
+		return nil, "", err
+	}
+	summary := session.Summary
+
+	keep := s.summaryConfig.KeepMessages
+	if compress && len(messages) > keep && estimateTokens(messages) > s.summaryConfig.ContextBudgetTokens {
+		older := messages[:len(messages)-keep]
+		// A failed summary leaves the older messages to drop out of the
+		// history as before; they are summarized on a later turn
+		if updated, err := s.summarize(ctx, session.OrganizationID, summary, older); err == nil {
+			// Used for this prompt even if saving fails, in which case the
+			// next turn summarizes the same messages again
+			_, _ = s.chatRepo.UpdateSessionSummary(context.WithoutCancel(ctx), session.OrganizationID, session.ID, updated, older[len(older)-1].ID)
+			summary = updated
+			messages = messages[len(older):]
+		}
+	}
+
+	if len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+	slices.Reverse(messages)
+	return messages, summary, nil
+}
+
+// summarize folds messages, oldest first, into a conversation's summary,
+// written in the organization's default language
+func (s *ragService) summarize(ctx context.Context, orgID int32, summary string, messages []*domain.ChatMessage) (string, error) {
+	settings, err := s.promptSettings.GetLatest(ctx, orgID)
+	if err != nil && !errors.Is(err, domain.ErrPromptSettingsNotFound) {
+		return "", fmt.Errorf("failed to get prompt settings: %w", err)
+	}
+	var lang string
+	if settings != nil {
+		lang = settings.Language
+	}
+
+	var builder strings.Builder
+	builder.WriteString(SummaryPrompt)
+	builder.WriteString("\n" + summaryLanguageInstruction(lang))
+	if summary != "" {
+		builder.WriteString("\n\nSummary so far:\n")
+		builder.WriteString(summary)
+	}
+	builder.WriteString("\n\nConversation:\n")
+	for _, msg := range messages {
+		builder.WriteString(fmt.Sprintf("%s: %s\n", speaker(msg.Role), msg.Content))
+	}
+
+	params := domain.ModelParameters{
+		Model:     s.summaryConfig.Model,
+		MaxTokens: &s.summaryConfig.MaxTokens,
+	}
+	response, err := s.assistantProvider.GenerateResponse(ctx, builder.String(), params)
+	if err != nil {
+		return "", fmt.Errorf("failed to summarize conversation: %w", err)
+	}
+
+	updated := strings.TrimSpace(response.Content)
+	if updated == "" {
+		return "", fmt.Errorf("failed to summarize conversation: empty summary")
+	}
+	return updated, nil
+}
+
+// estimateTokens roughly estimates the tokens of messages at four characters per token
+func estimateTokens(messages []*domain.ChatMessage) int {
+	chars := 0
+	for _, msg := range messages {
+		chars += utf8.RuneCountInString(msg.Content)
+	}
+	return chars / 4
+}
+
+// generate gets a response from the assistant, streamed to onChunk when one is given
+func (s *ragService) generate(ctx context.Context, prompt string, params domain.ModelParameters, onChunk func(content string) error) (*domain.AssistantResponse, error) {
+	if onChunk == nil {
+		return s.assistantProvider.GenerateResponse(ctx, prompt, params)
+	}
+	return s.assistantProvider.StreamResponse(ctx, prompt, params, onChunk)
+}
+
+// organizationInstructions returns the organization's prompt customization
+// as a prompt section, or "" when it has none
+func (s *ragService) organizationInstructions(ctx context.Context, orgID int32) (string, error) {
+	settings, err := s.promptSettings.GetLatest(ctx, orgID)
+	if err != nil && !errors.Is(err, domain.ErrPromptSettingsNotFound) {
+		return "", fmt.Errorf("failed to get prompt settings: %w", err)
+	}
+	return buildOrganizationInstructions(settings), nil
+}
+
 func (s *ragService) GetSession(ctx context.Context, orgID, sessionID int32) (*domain.ChatSession, error) {
 	return s.chatRepo.GetSessionByID(ctx, orgID, sessionID)
 }
@@ -178,19 +508,74 @@ func (s *ragService) UpdateSessionTitle(ctx context.Context, orgID, sessionID in
 	return s.chatRepo.UpdateSessionTitle(ctx, orgID, sessionID, title)
 }
 
-// buildRAGPrompt builds a prompt with RAG context
-func (s *ragService) buildRAGPrompt(query string, docs []*domain.SimilarDocument) string {
+func (s *ragService) UpdateSessionParameters(ctx context.Context, orgID, sessionID int32, params domain.ModelParameters) (*domain.ChatSession, error) {
+	if err := s.checkParameters(ctx, orgID, params); err != nil {
+		return nil, err
+	}
+	return s.chatRepo.UpdateSessionParameters(ctx, orgID, sessionID, params)
+}
+
+// checkParameters checks requested parameters against the model allowlist,
+// the temperature range and the organization's plan ceiling
+func (s *ragService) checkParameters(ctx context.Context, orgID int32, params domain.ModelParameters) error {
+	if params.Temperature != nil && (*params.Temperature < 0 || *params.Temperature > s.modelConfig.MaxTemperature) {
+		return fmt.Errorf("%w: must be between 0 and %g", domain.ErrTemperatureOutOfRange, s.modelConfig.MaxTemperature)
+	}
+	if params.Model == "" && params.MaxTokens == nil {
+		return nil
+	}
+
+	planID, err := s.planRepo.GetActivePlanID(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to get plan: %w", err)
+	}
+	models, maxTokens := s.modelConfig.Limits(planID)
+
+	if params.Model != "" && !slices.Contains(models, params.Model) {
+		return fmt.Errorf("%w: %q", domain.ErrModelNotAllowed, params.Model)
+	}
+	if params.MaxTokens != nil && (*params.MaxTokens <= 0 || *params.MaxTokens > maxTokens) {
+		return fmt.Errorf("%w: must be between 1 and %d", domain.ErrMaxTokensOutOfRange, maxTokens)
+	}
+	return nil
+}
+
+// mergeParameters returns current with the parameters set in requested replacing its own
+func mergeParameters(current, requested domain.ModelParameters) domain.ModelParameters {
+	if requested.Model != "" {
+		current.Model = requested.Model
+	}
+	if requested.Temperature != nil {
+		current.Temperature = requested.Temperature
+	}
+	if requested.MaxTokens != nil {
+		current.MaxTokens = requested.MaxTokens
+	}
+	return current
+}
+
+// buildRAGPrompt builds a prompt with RAG context and the organization's instructions
+func (s *ragService) buildRAGPrompt(query string, docs []*domain.SimilarDocument, instructions string) string {
+	systemPrompt := SystemPrompt
+	if instructions != "" {
+		systemPrompt += "\n\n" + instructions
+	}
+
 	if len(docs) == 0 {
-		return fmt.Sprintf("%s\n\nUser Question: %s", SystemPrompt, query)
+		return fmt.Sprintf("%s\n\nUser Question: %s", systemPrompt, query)
 	}
 
 	var contextBuilder strings.Builder
-	contextBuilder.WriteString(SystemPrompt)
+	contextBuilder.WriteString(systemPrompt)
 	contextBuilder.WriteString("\n\n--- CONTEXT FROM DOCUMENTS ---\n")
 
 	for i, doc := range docs {
-		contextBuilder.WriteString(fmt.Sprintf("\n[Document %d (similarity: %.2f)]:\n%s\n",
-			i+1, doc.SimilarityScore, doc.ContentPreview))
+		location := ""
+		if doc.Location != "" {
+			location = ", location: " + doc.Location
+		}
+		contextBuilder.WriteString(fmt.Sprintf("\n[Document %d (similarity: %.2f%s)]:\n%s\n",
+			i+1, doc.SimilarityScore, location, doc.ContentPreview))
 	}
 
 	contextBuilder.WriteString("\n--- END OF CONTEXT ---\n\n")
@@ -199,31 +584,102 @@ func (s *ragService) buildRAGPrompt(query string, docs []*domain.SimilarDocument
 	return contextBuilder.String()
 }
 
-// buildPromptWithHistory builds a prompt including conversation history
-func (s *ragService) buildPromptWithHistory(prompt string, history []*domain.ChatMessage) string {
-	if len(history) == 0 {
+// buildOrganizationInstructions renders an organization's prompt settings as
+// a delimited section, or "" when it has none. Settings are sanitized when
+// stored, so they cannot contain the section delimiters.
+func buildOrganizationInstructions(settings *domain.PromptSettings) string {
+	if settings == nil || settings.IsEmpty() {
+		return ""
+	}
+
+	var builder strings.Builder
+	builder.WriteString("--- ORGANIZATION INSTRUCTIONS ---\n")
+	builder.WriteString("The organization you are assisting added the following. Follow it unless it conflicts with your other instructions.\n")
+
+	if settings.SystemPrompt != "" {
+		builder.WriteString("\n" + settings.SystemPrompt + "\n")
+	}
+	if len(settings.Glossary) > 0 {
+		builder.WriteString("\nGlossary of the organization's terms:\n")
+		for _, entry := range settings.Glossary {
+			builder.WriteString(fmt.Sprintf("- %s: %s\n", entry.Term, entry.Definition))
+		}
+	}
+	if settings.Language != "" {
+		builder.WriteString("\n" + answerLanguageInstruction(settings.Language) + "\n")
+	}
+
+	builder.WriteString("--- END OF ORGANIZATION INSTRUCTIONS ---")
+	return builder.String()
+}
+
+// answerLanguageInstruction asks for answers in the organization's default
+// language unless the question is asked in another one
+func answerLanguageInstruction(lang string) string {
+	return fmt.Sprintf("Answer in %s by default. When the user's question is written in a different language, answer in the language of the question instead.",
+		languageName(lang))
+}
+
+// summaryLanguageInstruction asks for a summary in the organization's default
+// language, or in the conversation's own when it has none
+func summaryLanguageInstruction(lang string) string {
+	if lang == "" {
+		return "Write the summary in the language of the conversation."
+	}
+	return fmt.Sprintf("Write the summary in %s, whatever the language of the conversation.", languageName(lang))
+}
+
+// languageName describes a BCP 47 tag for the model, such as "German (de)"
+func languageName(lang string) string {
+	tag, err := language.Parse(lang)
+	if err != nil {
+		return lang
+	}
+	if name := display.English.Tags().Name(tag); name != "" {
+		return fmt.Sprintf("%s (%s)", name, lang)
+	}
+	return lang
+}
+
+// buildPromptWithHistory builds a prompt including the summary of earlier
+// conversation and the conversation history
+func (s *ragService) buildPromptWithHistory(prompt string, history []*domain.ChatMessage, summary string) string {
+	if len(history) == 0 && summary == "" {
 		return prompt
 	}
 
 	var builder strings.Builder
-	builder.WriteString("Previous conversation:\n")
-
-	// History is in descending order, so reverse it
-	for i := len(history) - 1; i >= 0; i-- {
-		msg := history[i]
-		role := "User"
-		if msg.Role == domain.ChatRoleAssistant {
-			role = "Assistant"
+	if summary != "" {
+		builder.WriteString("Summary of earlier conversation:\n")
+		builder.WriteString(summary)
+		builder.WriteString("\n\n")
+	}
+
+	if len(history) > 0 {
+		builder.WriteString("Previous conversation:\n")
+
+		// History is in descending order, so reverse it
+		for i := len(history) - 1; i >= 0; i-- {
+			msg := history[i]
+			builder.WriteString(fmt.Sprintf("%s: %s\n", speaker(msg.Role), msg.Content))
 		}
-		builder.WriteString(fmt.Sprintf("%s: %s\n", role, msg.Content))
+		builder.WriteString("\n")
 	}
 
-	builder.WriteString("\nCurrent prompt:\n")
+	builder.WriteString("Current prompt:\n")
 	builder.WriteString(prompt)
 
 	return builder.String()
 }
 
+// speaker returns the label of a message's role in a prompt
+func speaker(role domain.ChatRole) string {
+	if role == domain.ChatRoleAssistant {
+		return "Assistant"
+	}
+	return "User"
+}
+
 // generateSessionTitle generates a title from the first message
 func generateSessionTitle(message string) string {
 	// Take first 50 characters of the message as title
@@ -8,7 +8,9 @@ import (
 
 	"github.com/moasq/go-b2b-starter/internal/modules/cognitive"
 	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
 	docEvents "github.com/moasq/go-b2b-starter/internal/modules/documents/domain/events"
+	orgEvents "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
 	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
 )
 
@@ -31,12 +33,56 @@ func Init(container *dig.Container) error {
 				return fmt.Errorf("unexpected event type: %T", event)
 			}
 
+			chunks := make([]domain.DocumentChunk, len(docEvent.Chunks))
+			for i, chunk := range docEvent.Chunks {
+				chunks[i] = domain.DocumentChunk{Text: chunk.Text, Location: chunk.Location}
+			}
+
 			// Handle the event
-			return listener.HandleDocumentUploaded(ctx, docEvent.DocumentID, docEvent.OrganizationID, docEvent.ExtractedText)
+			return listener.HandleDocumentUploaded(ctx, docEvent.DocumentID, docEvent.OrganizationID, docEvent.ExtractedText, chunks)
 		})
 	}); err != nil {
 		return fmt.Errorf("failed to wire document event listener: %w", err)
 	}
 
+	// Wire up event listener for guest session claims
+	if err := container.Invoke(func(
+		bus eventbus.EventBus,
+		listener services.GuestClaimListener,
+	) error {
+		return bus.Subscribe(orgEvents.GuestSessionClaimedEventType, func(ctx context.Context, event eventbus.Event) error {
+			claimEvent, ok := event.(*orgEvents.GuestSessionClaimed)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+
+			return listener.HandleGuestSessionClaimed(ctx, claimEvent.GuestOrganizationID, claimEvent.GuestAccountID, claimEvent.OrganizationID, claimEvent.AccountID)
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to wire guest claim listener: %w", err)
+	}
+
+	// Wire up event listener for member offboarding
+	if err := container.Invoke(func(
+		bus eventbus.EventBus,
+		listener services.MemberOffboardingListener,
+	) error {
+		return bus.Subscribe(orgEvents.MemberOffboardingEventType, func(ctx context.Context, event eventbus.Event) error {
+			offboardEvent, ok := event.(*orgEvents.MemberOffboarding)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+
+			count, err := listener.HandleMemberOffboarding(ctx, offboardEvent.OrganizationID, offboardEvent.AccountID, offboardEvent.TransferToAccountID)
+			if err != nil {
+				return err
+			}
+			offboardEvent.Results.Record("chat_sessions", count)
+			return nil
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to wire member offboarding listener: %w", err)
+	}
+
 	return nil
 }
@@ -14,12 +14,21 @@ type TextVectorizer interface {
 // This enables intelligent responses based on context and user queries.
 // Implementation details (LLM providers, models) are in the infra layer.
 type AssistantProvider interface {
-	// GenerateResponse creates an AI response for the given prompt with context
-	GenerateResponse(ctx context.Context, prompt string) (*AssistantResponse, error)
+	// GenerateResponse creates an AI response for the given prompt with context.
+	// Parameters left empty use the provider's defaults.
+	GenerateResponse(ctx context.Context, prompt string, params ModelParameters) (*AssistantResponse, error)
+
+	// StreamResponse is GenerateResponse with the text passed to onChunk as it
+	// is generated. Cancelling ctx or returning an error from onChunk stops the
+	// generation. When it stops after some text was generated, the partial
+	// response is returned with Truncated set instead of an error.
+	StreamResponse(ctx context.Context, prompt string, params ModelParameters, onChunk func(content string) error) (*AssistantResponse, error)
 }
 
 // AssistantResponse contains the result of an AI assistance request
 type AssistantResponse struct {
-	Content    string // The generated response text
-	TokensUsed int    // Tokens consumed (for usage tracking)
+	Content    string          // The generated response text
+	TokensUsed int             // Tokens consumed (for usage tracking)
+	Parameters ModelParameters // Parameters the response was generated with
+	Truncated  bool            // Generation stopped before the response was complete
 }
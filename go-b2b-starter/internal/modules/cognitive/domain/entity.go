@@ -22,24 +22,51 @@ type DocumentEmbedding struct {
 	ContentHash    string    `json:"content_hash,omitempty"`
 	ContentPreview string    `json:"content_preview,omitempty"`
 	ChunkIndex     int32     `json:"chunk_index"`
+	Location       string    `json:"location,omitempty"` // Where the chunk sits in the source, e.g. Sales!A2:D40 or 00:01:05-00:03:00
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
 }
 
+// DocumentChunk is a piece of a document embedded on its own, with the
+// location it was cut from
+type DocumentChunk struct {
+	Text     string `json:"text"`
+	Location string `json:"location,omitempty"`
+}
+
 // SimilarDocument represents a document found through similarity search
 type SimilarDocument struct {
 	DocumentEmbedding
 	SimilarityScore float64 `json:"similarity_score"`
 }
 
+// ModelParameters selects the model and sampling parameters for a chat.
+// Empty fields fall back to the server's LLM configuration.
+type ModelParameters struct {
+	Model       string   `json:"model,omitempty"`
+	Temperature *float32 `json:"temperature,omitempty"`
+	MaxTokens   *int32   `json:"max_tokens,omitempty"`
+}
+
+// IsEmpty reports whether no parameter is set
+func (p ModelParameters) IsEmpty() bool {
+	return p.Model == "" && p.Temperature == nil && p.MaxTokens == nil
+}
+
 // ChatSession represents a conversation session
 type ChatSession struct {
-	ID             int32     `json:"id"`
-	OrganizationID int32     `json:"organization_id"`
-	AccountID      int32     `json:"account_id"`
-	Title          string    `json:"title,omitempty"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
+	ID              int32     `json:"id"`
+	OrganizationID  int32     `json:"organization_id"`
+	AccountID       int32     `json:"account_id"`
+	Title           string    `json:"title,omitempty"`
+	ModelParameters           // Requested for the session's replies
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+
+	// Summary condenses the messages up to SummarizedThroughMessageID and is
+	// sent in their place once the conversation outgrows the context budget
+	Summary                    string `json:"summary,omitempty"`
+	SummarizedThroughMessageID int32  `json:"summarized_through_message_id,omitempty"`
 }
 
 func (s *ChatSession) GetID() int32 {
@@ -59,13 +86,15 @@ func (s *ChatSession) Validate() error {
 
 // ChatMessage represents a message within a chat session
 type ChatMessage struct {
-	ID             int32     `json:"id"`
-	SessionID      int32     `json:"session_id"`
-	Role           ChatRole  `json:"role"`
-	Content        string    `json:"content"`
-	ReferencedDocs []int32   `json:"referenced_docs,omitempty"`
-	TokensUsed     int32     `json:"tokens_used,omitempty"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID              int32     `json:"id"`
+	SessionID       int32     `json:"session_id"`
+	Role            ChatRole  `json:"role"`
+	Content         string    `json:"content"`
+	ReferencedDocs  []int32   `json:"referenced_docs,omitempty"`
+	TokensUsed      int32     `json:"tokens_used,omitempty"`
+	Truncated       bool      `json:"truncated"` // Generation stopped before the answer was complete
+	ModelParameters           // Effective for assistant messages, recorded for reproducibility
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 func (m *ChatMessage) GetID() int32 {
@@ -102,11 +131,12 @@ type RAGContext struct {
 
 // ChatRequest represents a request to send a chat message
 type ChatRequest struct {
-	SessionID      int32  `json:"session_id,omitempty"` // Optional - create new session if not provided
-	Message        string `json:"message"`
-	UseRAG         bool   `json:"use_rag,omitempty"` // Whether to use RAG for context
-	MaxDocuments   int    `json:"max_documents,omitempty"`
-	ContextHistory int    `json:"context_history,omitempty"` // Number of previous messages to include
+	SessionID       int32  `json:"session_id,omitempty"` // Optional - create new session if not provided
+	Message         string `json:"message"`
+	UseRAG          bool   `json:"use_rag,omitempty"` // Whether to use RAG for context
+	MaxDocuments    int    `json:"max_documents,omitempty"`
+	ContextHistory  int    `json:"context_history,omitempty"` // Number of previous messages to include
+	ModelParameters        // Optional - stored on the session and used for this and later replies
 }
 
 // ChatResponse represents a response from the chat service
@@ -117,6 +147,47 @@ type ChatResponse struct {
 	TokensUsed       int32             `json:"tokens_used,omitempty"`
 }
 
+// RetrievalExplanation shows what a chat message would retrieve and the
+// prompt it would be answered with, for tuning RAG
+type RetrievalExplanation struct {
+	Query     string            `json:"query"`
+	Filters   RetrievalFilters  `json:"filters"`
+	Documents []SimilarDocument `json:"documents"` // Most similar first
+	Reranked  bool              `json:"reranked"`  // Whether a reranker reordered the documents; none is configured
+	Prompt    string            `json:"prompt"`    // The prompt sent to the model, including conversation history
+}
+
+// RetrievalFilters are the constraints the vector search was run with
+type RetrievalFilters struct {
+	OrganizationID int32 `json:"organization_id"`
+	MaxDocuments   int   `json:"max_documents"`
+}
+
+// GlossaryTerm explains an organization-specific term to the assistant
+type GlossaryTerm struct {
+	Term       string `json:"term"`
+	Definition string `json:"definition"`
+}
+
+// PromptSettings is a version of an organization's custom system prompt,
+// glossary and default language. The newest version is added to the prompt
+// of every chat message.
+type PromptSettings struct {
+	ID                 int32          `json:"id,omitempty"`
+	OrganizationID     int32          `json:"organization_id"`
+	Version            int32          `json:"version"` // 0 until the organization customizes its prompt
+	SystemPrompt       string         `json:"system_prompt"`
+	Glossary           []GlossaryTerm `json:"glossary"`
+	Language           string         `json:"language"` // BCP 47 tag; empty answers in each question's language
+	CreatedByAccountID *int32         `json:"created_by_account_id,omitempty"`
+	CreatedAt          time.Time      `json:"created_at,omitempty"`
+}
+
+// IsEmpty reports whether the settings add nothing to the prompt
+func (s *PromptSettings) IsEmpty() bool {
+	return s.SystemPrompt == "" && len(s.Glossary) == 0 && s.Language == ""
+}
+
 // EmbeddingStats represents embedding statistics
 type EmbeddingStats struct {
 	TotalEmbeddings int64 `json:"total_embeddings"`
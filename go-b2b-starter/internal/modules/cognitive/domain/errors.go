@@ -19,6 +19,21 @@ var (
 	ErrMessageSessionRequired = errors.New("message session ID is required")
 	ErrMessageContentRequired = errors.New("message content is required")
 	ErrMessageRoleRequired    = errors.New("message role is required")
+	ErrNothingToContinue      = errors.New("the session's last message is not a truncated answer")
+	ErrNothingToRegenerate    = errors.New("the session has no question to answer")
+
+	// Model parameter errors
+	ErrModelNotAllowed       = errors.New("model is not allowed for this organization")
+	ErrTemperatureOutOfRange = errors.New("temperature is out of the allowed range")
+	ErrMaxTokensOutOfRange   = errors.New("max tokens is out of the allowed range")
+
+	// Prompt settings errors
+	ErrPromptSettingsNotFound = errors.New("prompt settings not found")
+	ErrSystemPromptTooLong    = errors.New("system prompt is too long")
+	ErrGlossaryTooLarge       = errors.New("glossary has too many terms")
+	ErrGlossaryTermInvalid    = errors.New("glossary terms need a term and a definition within the length limits")
+	ErrPromptReservedText     = errors.New("prompt settings contain text reserved for the chat prompt template")
+	ErrLanguageInvalid        = errors.New("language is not a valid BCP 47 tag")
 
 	// RAG errors
 	ErrRAGContextEmpty      = errors.New("no relevant documents found for RAG context")
@@ -7,6 +7,10 @@ type EmbeddingRepository interface {
 	// Create creates a new document embedding
 	Create(ctx context.Context, embedding *DocumentEmbedding) (*DocumentEmbedding, error)
 
+	// CreateBatch stores embeddings of one document in a single transaction,
+	// inserting up to batchSize rows per statement
+	CreateBatch(ctx context.Context, orgID, documentID int32, embeddings []*DocumentEmbedding, batchSize int) ([]*DocumentEmbedding, error)
+
 	// GetByID retrieves an embedding by ID
 	GetByID(ctx context.Context, orgID, embeddingID int32) (*DocumentEmbedding, error)
 
@@ -30,12 +34,45 @@ type ChatRepository interface {
 	GetSessionByID(ctx context.Context, orgID, sessionID int32) (*ChatSession, error)
 	ListSessionsByAccount(ctx context.Context, orgID, accountID int32, limit, offset int32) ([]*ChatSession, error)
 	UpdateSessionTitle(ctx context.Context, orgID, sessionID int32, title string) (*ChatSession, error)
+	UpdateSessionParameters(ctx context.Context, orgID, sessionID int32, params ModelParameters) (*ChatSession, error)
+	// UpdateSessionSummary stores a summary of the messages up to throughMessageID
+	UpdateSessionSummary(ctx context.Context, orgID, sessionID int32, summary string, throughMessageID int32) (*ChatSession, error)
 	DeleteSession(ctx context.Context, orgID, sessionID int32) error
+	// ReassignSessions moves every session of an account to another account, returning the count moved
+	ReassignSessions(ctx context.Context, fromOrgID, fromAccountID, toOrgID, toAccountID int32) (int64, error)
+	// DeleteSessionsByAccount deletes every session of an account with its messages, returning the count deleted
+	DeleteSessionsByAccount(ctx context.Context, orgID, accountID int32) (int64, error)
 
 	// Messages
 	CreateMessage(ctx context.Context, message *ChatMessage) (*ChatMessage, error)
 	GetMessagesBySession(ctx context.Context, sessionID int32) ([]*ChatMessage, error)
 	GetRecentMessages(ctx context.Context, sessionID int32, limit int32) ([]*ChatMessage, error)
+	// GetMessagesAfter returns the messages newer than afterMessageID, oldest first
+	GetMessagesAfter(ctx context.Context, sessionID, afterMessageID int32) ([]*ChatMessage, error)
 	CountMessagesBySession(ctx context.Context, sessionID int32) (int64, error)
+	// UpdateMessageContent replaces a message's content, e.g. when a truncated answer is continued
+	UpdateMessageContent(ctx context.Context, messageID int32, content string, tokensUsed int32, truncated bool) (*ChatMessage, error)
 	DeleteMessage(ctx context.Context, messageID int32) error
 }
+
+// PlanRepository reads the billing plan an organization is on
+type PlanRepository interface {
+	// GetActivePlanID returns the product ID of the organization's active or
+	// trialing subscription, or "" when it has none
+	GetActivePlanID(ctx context.Context, orgID int32) (string, error)
+}
+
+// PromptSettingsRepository stores the versions of organizations' prompt settings
+type PromptSettingsRepository interface {
+	// CreateVersion stores settings as the organization's next version
+	CreateVersion(ctx context.Context, settings *PromptSettings) (*PromptSettings, error)
+
+	// GetLatest returns the newest version, or ErrPromptSettingsNotFound
+	GetLatest(ctx context.Context, orgID int32) (*PromptSettings, error)
+
+	// GetVersion returns one version, or ErrPromptSettingsNotFound
+	GetVersion(ctx context.Context, orgID, version int32) (*PromptSettings, error)
+
+	// ListVersions returns versions newest first
+	ListVersions(ctx context.Context, orgID int32, limit, offset int32) ([]*PromptSettings, error)
+}
@@ -1,7 +1,9 @@
 package cognitive
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 
@@ -31,11 +33,58 @@ type ChatRequest struct {
 	UseRAG         bool   `json:"use_rag,omitempty"`
 	MaxDocuments   int    `json:"max_documents,omitempty"`
 	ContextHistory int    `json:"context_history,omitempty"`
+
+	// Optional model parameters, kept for later messages of the session
+	Model       string   `json:"model,omitempty"`
+	Temperature *float32 `json:"temperature,omitempty"`
+	MaxTokens   *int32   `json:"max_tokens,omitempty"`
+}
+
+// toDomain converts the request body to a domain chat request
+func (r *ChatRequest) toDomain() *domain.ChatRequest {
+	return &domain.ChatRequest{
+		SessionID:      r.SessionID,
+		Message:        r.Message,
+		UseRAG:         r.UseRAG,
+		MaxDocuments:   r.MaxDocuments,
+		ContextHistory: r.ContextHistory,
+		ModelParameters: domain.ModelParameters{
+			Model:       r.Model,
+			Temperature: r.Temperature,
+			MaxTokens:   r.MaxTokens,
+		},
+	}
+}
+
+// ExplainRetrievalRequest represents the JSON request body for explaining
+// what a chat message would retrieve
+type ExplainRetrievalRequest struct {
+	Message        string `json:"message" binding:"required"`
+	SessionID      int32  `json:"session_id,omitempty"`
+	MaxDocuments   int    `json:"max_documents,omitempty"`
+	ContextHistory int    `json:"context_history,omitempty"`
+}
+
+// RegenerateRequest represents the optional JSON request body for
+// regenerating a session's last answer
+type RegenerateRequest struct {
+	UseRAG         bool `json:"use_rag,omitempty"`
+	MaxDocuments   int  `json:"max_documents,omitempty"`
+	ContextHistory int  `json:"context_history,omitempty"`
+}
+
+// SessionParametersRequest represents the JSON request body for replacing a
+// session's model parameters. Omitted or null fields use the server defaults.
+type SessionParametersRequest struct {
+	Model       string   `json:"model"`
+	Temperature *float32 `json:"temperature"`
+	MaxTokens   *int32   `json:"max_tokens"`
 }
 
 // Chat sends a message and gets a response
 // @Summary Chat with AI
-// @Description Sends a message to the AI and gets a response, optionally using RAG
+// @Description Sends a message to the AI and gets a response, optionally using RAG.
+// @Description A model, temperature or max_tokens given here is stored on the session and used for its later messages.
 // @Tags Cognitive
 // @Accept json
 // @Produce json
@@ -65,18 +114,242 @@ func (h *Handler) Chat(c *gin.Context) {
 		return
 	}
 
-	// Create domain request
+	response, err := h.ragService.Chat(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, req.toDomain())
+	if err != nil {
+		if isParameterError(err) {
+			c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+				http.StatusBadRequest,
+				"invalid_parameters",
+				err.Error(),
+			))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"chat_failed",
+			"Failed to process chat: "+err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// ChatStream sends a message and streams the response as it is generated
+// @Summary Chat with AI (streaming)
+// @Description Like Chat, but the response is sent as server-sent events: a "chunk" event with {"content": "..."} for each piece of the answer, then a "done" event with the saved ChatResponse, or an "error" event.
+// @Description If the client disconnects, generation stops and the partial answer is saved with "truncated": true. It can be continued or regenerated later.
+// @Tags Cognitive
+// @Accept json
+// @Produce text/event-stream
+// @Param request body ChatRequest true "Chat request"
+// @Success 200 {object} domain.ChatResponse "Sent as the data of the done event"
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_cognitive/chat/stream [post]
+func (h *Handler) ChatStream(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	var req ChatRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			"Invalid JSON format: "+err.Error(),
+		))
+		return
+	}
+
+	h.streamChat(c, func(onChunk func(string) error) (*domain.ChatResponse, error) {
+		return h.ragService.ChatStream(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, req.toDomain(), onChunk)
+	})
+}
+
+// ContinueMessage streams the rest of a session's truncated last answer
+// @Summary Continue a truncated answer
+// @Description Generates the rest of the session's last answer when it is truncated and appends it to that message. Streamed as server-sent events like ChatStream.
+// @Tags Cognitive
+// @Produce text/event-stream
+// @Param id path int true "Session ID"
+// @Success 200 {object} domain.ChatResponse "Sent as the data of the done event"
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 409 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_cognitive/sessions/{id}/continue [post]
+func (h *Handler) ContinueMessage(c *gin.Context) {
+	idParam := c.Param("id")
+	var sessionID int32
+	if _, err := fmt.Sscanf(idParam, "%d", &sessionID); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_id",
+			"Session ID must be a valid number",
+		))
+		return
+	}
+
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	h.streamChat(c, func(onChunk func(string) error) (*domain.ChatResponse, error) {
+		return h.ragService.ContinueMessage(c.Request.Context(), reqCtx.OrganizationID, sessionID, onChunk)
+	})
+}
+
+// RegenerateMessage replaces a session's last answer with a new one
+// @Summary Regenerate the last answer
+// @Description Replaces the session's last answer, complete or truncated, with a new answer to its last question. A question left without an answer is answered. Streamed as server-sent events like ChatStream.
+// @Tags Cognitive
+// @Accept json
+// @Produce text/event-stream
+// @Param id path int true "Session ID"
+// @Param request body RegenerateRequest false "Retrieval options"
+// @Success 200 {object} domain.ChatResponse "Sent as the data of the done event"
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 409 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_cognitive/sessions/{id}/regenerate [post]
+func (h *Handler) RegenerateMessage(c *gin.Context) {
+	idParam := c.Param("id")
+	var sessionID int32
+	if _, err := fmt.Sscanf(idParam, "%d", &sessionID); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_id",
+			"Session ID must be a valid number",
+		))
+		return
+	}
+
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	// The body is optional
+	var req RegenerateRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			"Invalid JSON format: "+err.Error(),
+		))
+		return
+	}
+
+	chatReq := &domain.ChatRequest{
+		SessionID:      sessionID,
+		UseRAG:         req.UseRAG,
+		MaxDocuments:   req.MaxDocuments,
+		ContextHistory: req.ContextHistory,
+	}
+
+	h.streamChat(c, func(onChunk func(string) error) (*domain.ChatResponse, error) {
+		return h.ragService.RegenerateMessage(c.Request.Context(), reqCtx.OrganizationID, chatReq, onChunk)
+	})
+}
+
+// ExplainRetrieval shows what a message would retrieve and the prompt it would be answered with
+// @Summary Explain RAG retrieval
+// @Description Runs the vector search for a message and returns the retrieved chunks with their similarity scores, the filters applied and the prompt chat would send with use_rag, including the session's history when session_id is given. Nothing is saved and the model is not called.
+// @Description No reranker is configured, so documents keep their similarity order and reranked is false.
+// @Description Only available when CHAT_DEBUG_ENABLED is set.
+// @Tags Cognitive
+// @Accept json
+// @Produce json
+// @Param request body ExplainRetrievalRequest true "Message to explain"
+// @Success 200 {object} domain.RetrievalExplanation
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_cognitive/debug/retrieval [post]
+func (h *Handler) ExplainRetrieval(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	var req ExplainRetrievalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			"Invalid JSON format: "+err.Error(),
+		))
+		return
+	}
+
 	chatReq := &domain.ChatRequest{
 		SessionID:      req.SessionID,
 		Message:        req.Message,
-		UseRAG:         req.UseRAG,
+		UseRAG:         true,
 		MaxDocuments:   req.MaxDocuments,
 		ContextHistory: req.ContextHistory,
 	}
 
-	response, err := h.ragService.Chat(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, chatReq)
+	explanation, err := h.ragService.ExplainRetrieval(c.Request.Context(), reqCtx.OrganizationID, chatReq)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"explain_failed",
+			"Failed to explain retrieval: "+err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, explanation)
+}
+
+// streamChat runs generate and sends its answer as server-sent events. Errors
+// before the first chunk get a regular JSON error response. A client that
+// disconnects cancels the request context, which stops the generation.
+func (h *Handler) streamChat(c *gin.Context, generate func(onChunk func(string) error) (*domain.ChatResponse, error)) {
+	response, err := generate(func(content string) error {
+		if !c.Writer.Written() {
+			c.Header("Cache-Control", "no-cache")
+			c.Header("X-Accel-Buffering", "no")
+		}
+		c.SSEvent("chunk", gin.H{"content": content})
+		c.Writer.Flush()
+		return c.Request.Context().Err()
+	})
+	if err != nil {
+		if !c.Writer.Written() {
+			status, code := http.StatusInternalServerError, "chat_failed"
+			switch {
+			case isParameterError(err):
+				status, code = http.StatusBadRequest, "invalid_parameters"
+			case errors.Is(err, domain.ErrNothingToContinue), errors.Is(err, domain.ErrNothingToRegenerate):
+				status, code = http.StatusConflict, "nothing_to_generate"
+			}
+			c.JSON(status, httperr.NewHTTPError(status, code, "Failed to process chat: "+err.Error()))
+			return
+		}
+		c.SSEvent("error", httperr.NewHTTPError(
 			http.StatusInternalServerError,
 			"chat_failed",
 			"Failed to process chat: "+err.Error(),
@@ -84,7 +357,7 @@ func (h *Handler) Chat(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	c.SSEvent("done", response)
 }
 
 // ListSessions lists chat sessions for the current user
@@ -173,3 +446,81 @@ func (h *Handler) GetSessionHistory(c *gin.Context) {
 
 	c.JSON(http.StatusOK, messages)
 }
+
+// UpdateSessionParameters replaces the model parameters of a session
+// @Summary Set session model parameters
+// @Description Replaces the model, temperature and max_tokens used for a session's replies. Omitted or null fields use the server defaults. The model must be allowed for the organization's plan.
+// @Tags Cognitive
+// @Accept json
+// @Produce json
+// @Param id path int true "Session ID"
+// @Param request body SessionParametersRequest true "Model parameters"
+// @Success 200 {object} domain.ChatSession
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_cognitive/sessions/{id}/parameters [put]
+func (h *Handler) UpdateSessionParameters(c *gin.Context) {
+	idParam := c.Param("id")
+	var sessionID int32
+	if _, err := fmt.Sscanf(idParam, "%d", &sessionID); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_id",
+			"Session ID must be a valid number",
+		))
+		return
+	}
+
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	var req SessionParametersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			"Invalid JSON format: "+err.Error(),
+		))
+		return
+	}
+
+	params := domain.ModelParameters{
+		Model:       req.Model,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+	}
+
+	session, err := h.ragService.UpdateSessionParameters(c.Request.Context(), reqCtx.OrganizationID, sessionID, params)
+	if err != nil {
+		if isParameterError(err) {
+			c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+				http.StatusBadRequest,
+				"invalid_parameters",
+				err.Error(),
+			))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"update_failed",
+			"Failed to update session parameters: "+err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, session)
+}
+
+// isParameterError reports whether err rejects requested model parameters
+func isParameterError(err error) bool {
+	return errors.Is(err, domain.ErrModelNotAllowed) ||
+		errors.Is(err, domain.ErrTemperatureOutOfRange) ||
+		errors.Is(err, domain.ErrMaxTokensOutOfRange)
+}
@@ -2,6 +2,7 @@ package ai
 
 import (
 	"context"
+	"strings"
 
 	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
 	llmdomain "github.com/moasq/go-b2b-starter/internal/platform/llm/domain"
@@ -16,14 +17,67 @@ func NewAssistantProvider(llmClient llmdomain.LLMClient) domain.AssistantProvide
 	return &openAIAssistantProvider{llmClient: llmClient}
 }
 
-func (p *openAIAssistantProvider) GenerateResponse(ctx context.Context, prompt string) (*domain.AssistantResponse, error) {
-	req := llmdomain.CompletionRequest{Prompt: prompt}
-	resp, err := p.llmClient.Complete(ctx, req)
+func (p *openAIAssistantProvider) GenerateResponse(ctx context.Context, prompt string, params domain.ModelParameters) (*domain.AssistantResponse, error) {
+	resp, err := p.llmClient.Complete(ctx, completionRequest(prompt, params))
 	if err != nil {
 		return nil, err
 	}
+
+	return assistantResponse(resp), nil
+}
+
+func (p *openAIAssistantProvider) StreamResponse(ctx context.Context, prompt string, params domain.ModelParameters, onChunk func(content string) error) (*domain.AssistantResponse, error) {
+	var received strings.Builder
+	resp, err := p.llmClient.CompleteStream(ctx, completionRequest(prompt, params), func(chunk llmdomain.StreamChunk) error {
+		if chunk.Content == "" {
+			return nil
+		}
+		received.WriteString(chunk.Content)
+		return onChunk(chunk.Content)
+	})
+	if err != nil {
+		if received.Len() == 0 {
+			return nil, err
+		}
+
+		// Keep what was generated before the stream stopped. Token usage is
+		// estimated the way the LLM client estimates it for streams.
+		content := received.String()
+		return &domain.AssistantResponse{
+			Content:    content,
+			TokensUsed: len(strings.Fields(content)),
+			Parameters: params,
+			Truncated:  true,
+		}, nil
+	}
+
+	return assistantResponse(resp), nil
+}
+
+// completionRequest builds the LLM request for a prompt and the requested parameters
+func completionRequest(prompt string, params domain.ModelParameters) llmdomain.CompletionRequest {
+	req := llmdomain.CompletionRequest{
+		Prompt:      prompt,
+		Model:       params.Model,
+		Temperature: params.Temperature,
+	}
+	if params.MaxTokens != nil {
+		maxTokens := int(*params.MaxTokens)
+		req.MaxTokens = &maxTokens
+	}
+	return req
+}
+
+// assistantResponse maps a completed LLM response with the parameters it was generated with
+func assistantResponse(resp *llmdomain.CompletionResponse) *domain.AssistantResponse {
+	maxTokens := int32(resp.MaxTokens)
 	return &domain.AssistantResponse{
 		Content:    resp.Text,
 		TokensUsed: resp.TokensUsed,
-	}, nil
+		Parameters: domain.ModelParameters{
+			Model:       resp.Model,
+			Temperature: resp.Temperature,
+			MaxTokens:   &maxTokens,
+		},
+	}
 }
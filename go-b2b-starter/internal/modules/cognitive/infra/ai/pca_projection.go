@@ -0,0 +1,73 @@
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+)
+
+// PCAProjection reduces embeddings to their leading principal components.
+// It is computed offline from a sample of full-size embeddings and stored as
+// JSON:
+//
+//	{"mean": [...], "components": [[...], ...]}
+//
+// mean has the full embedding size; each component is a unit vector of the
+// same size, strongest first. Projected vectors are normalized so cosine
+// similarity still applies.
+type PCAProjection struct {
+	Mean       []float64   `json:"mean"`
+	Components [][]float64 `json:"components"`
+}
+
+// LoadPCAProjection reads a projection from path and checks that it yields
+// vectors of the given number of dimensions.
+func LoadPCAProjection(path string, dimensions int) (*PCAProjection, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PCA projection: %w", err)
+	}
+
+	var projection PCAProjection
+	if err := json.Unmarshal(data, &projection); err != nil {
+		return nil, fmt.Errorf("failed to decode PCA projection: %w", err)
+	}
+
+	if len(projection.Components) != dimensions {
+		return nil, fmt.Errorf("PCA projection has %d components, want %d", len(projection.Components), dimensions)
+	}
+	for i, component := range projection.Components {
+		if len(component) != len(projection.Mean) {
+			return nil, fmt.Errorf("PCA projection component %d has %d values, want %d", i, len(component), len(projection.Mean))
+		}
+	}
+
+	return &projection, nil
+}
+
+// Project reduces a full-size embedding to the projection's dimensions
+func (p *PCAProjection) Project(embedding []float64) ([]float64, error) {
+	if len(embedding) != len(p.Mean) {
+		return nil, fmt.Errorf("embedding has %d dimensions, PCA projection expects %d", len(embedding), len(p.Mean))
+	}
+
+	projected := make([]float64, len(p.Components))
+	var norm float64
+	for i, component := range p.Components {
+		var sum float64
+		for j, value := range embedding {
+			sum += (value - p.Mean[j]) * component[j]
+		}
+		projected[i] = sum
+		norm += sum * sum
+	}
+
+	norm = math.Sqrt(norm)
+	if norm > 0 {
+		for i := range projected {
+			projected[i] /= norm
+		}
+	}
+	return projected, nil
+}
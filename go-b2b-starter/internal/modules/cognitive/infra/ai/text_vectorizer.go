@@ -10,13 +10,38 @@ import (
 const embeddingModel = "text-embedding-3-small"
 
 type openAITextVectorizer struct {
-	llmClient llmdomain.LLMClient
+	llmClient  llmdomain.LLMClient
+	dimensions int
+	projection *PCAProjection
 }
 
-func NewTextVectorizer(llmClient llmdomain.LLMClient) domain.TextVectorizer {
-	return &openAITextVectorizer{llmClient: llmClient}
+// NewTextVectorizer creates a TextVectorizer backed by OpenAI. Vectors have
+// the given number of dimensions, shortened by the model, or by projection
+// when one is given.
+func NewTextVectorizer(llmClient llmdomain.LLMClient, dimensions int, projection *PCAProjection) domain.TextVectorizer {
+	return &openAITextVectorizer{
+		llmClient:  llmClient,
+		dimensions: dimensions,
+		projection: projection,
+	}
 }
 
 func (v *openAITextVectorizer) Vectorize(ctx context.Context, text string) ([]float64, error) {
-	return v.llmClient.GenerateEmbedding(ctx, text, embeddingModel)
+	if v.projection != nil {
+		embedding, err := v.llmClient.GenerateEmbedding(ctx, text, embeddingModel)
+		if err != nil {
+			return nil, err
+		}
+		return v.projection.Project(embedding)
+	}
+
+	resp, err := v.llmClient.CreateEmbedding(ctx, llmdomain.EmbeddingRequest{
+		Text:       text,
+		Model:      embeddingModel,
+		Dimensions: v.dimensions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Embedding, nil
 }
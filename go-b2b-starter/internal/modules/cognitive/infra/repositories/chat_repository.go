@@ -27,6 +27,9 @@ func (r *chatRepository) CreateSession(ctx context.Context, session *domain.Chat
 		OrganizationID: session.OrganizationID,
 		AccountID:      session.AccountID,
 		Title:          helpers.ToPgText(session.Title),
+		Model:          helpers.ToPgText(session.Model),
+		Temperature:    helpers.ToPgFloat4Ptr(session.Temperature),
+		MaxTokens:      helpers.ToPgInt4Ptr(session.MaxTokens),
 	}
 
 	result, err := r.store.CreateChatSession(ctx, params)
@@ -87,6 +90,39 @@ func (r *chatRepository) UpdateSessionTitle(ctx context.Context, orgID, sessionI
 	return r.mapSessionToDomain(&result), nil
 }
 
+func (r *chatRepository) UpdateSessionParameters(ctx context.Context, orgID, sessionID int32, params domain.ModelParameters) (*domain.ChatSession, error) {
+	sqlcParams := sqlc.UpdateChatSessionParametersParams{
+		ID:             sessionID,
+		OrganizationID: orgID,
+		Model:          helpers.ToPgText(params.Model),
+		Temperature:    helpers.ToPgFloat4Ptr(params.Temperature),
+		MaxTokens:      helpers.ToPgInt4Ptr(params.MaxTokens),
+	}
+
+	result, err := r.store.UpdateChatSessionParameters(ctx, sqlcParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update chat session parameters: %w", err)
+	}
+
+	return r.mapSessionToDomain(&result), nil
+}
+
+func (r *chatRepository) UpdateSessionSummary(ctx context.Context, orgID, sessionID int32, summary string, throughMessageID int32) (*domain.ChatSession, error) {
+	params := sqlc.UpdateChatSessionSummaryParams{
+		ID:                         sessionID,
+		OrganizationID:             orgID,
+		Summary:                    helpers.ToPgText(summary),
+		SummarizedThroughMessageID: throughMessageID,
+	}
+
+	result, err := r.store.UpdateChatSessionSummary(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update chat session summary: %w", err)
+	}
+
+	return r.mapSessionToDomain(&result), nil
+}
+
 func (r *chatRepository) DeleteSession(ctx context.Context, orgID, sessionID int32) error {
 	params := sqlc.DeleteChatSessionParams{
 		ID:             sessionID,
@@ -100,6 +136,36 @@ func (r *chatRepository) DeleteSession(ctx context.Context, orgID, sessionID int
 	return nil
 }
 
+func (r *chatRepository) ReassignSessions(ctx context.Context, fromOrgID, fromAccountID, toOrgID, toAccountID int32) (int64, error) {
+	params := sqlc.ReassignChatSessionsParams{
+		OrganizationID:   fromOrgID,
+		AccountID:        fromAccountID,
+		OrganizationID_2: toOrgID,
+		AccountID_2:      toAccountID,
+	}
+
+	moved, err := r.store.ReassignChatSessions(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to reassign chat sessions: %w", err)
+	}
+
+	return moved, nil
+}
+
+func (r *chatRepository) DeleteSessionsByAccount(ctx context.Context, orgID, accountID int32) (int64, error) {
+	params := sqlc.DeleteChatSessionsByAccountParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	}
+
+	deleted, err := r.store.DeleteChatSessionsByAccount(ctx, params)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete chat sessions: %w", err)
+	}
+
+	return deleted, nil
+}
+
 // Messages
 
 func (r *chatRepository) CreateMessage(ctx context.Context, message *domain.ChatMessage) (*domain.ChatMessage, error) {
@@ -109,6 +175,10 @@ func (r *chatRepository) CreateMessage(ctx context.Context, message *domain.Chat
 		Content:        message.Content,
 		ReferencedDocs: message.ReferencedDocs,
 		TokensUsed:     helpers.ToPgInt4(message.TokensUsed),
+		Model:          helpers.ToPgText(message.Model),
+		Temperature:    helpers.ToPgFloat4Ptr(message.Temperature),
+		MaxTokens:      helpers.ToPgInt4Ptr(message.MaxTokens),
+		Truncated:      message.Truncated,
 	}
 
 	result, err := r.store.CreateChatMessage(ctx, params)
@@ -152,6 +222,25 @@ func (r *chatRepository) GetRecentMessages(ctx context.Context, sessionID int32,
 	return messages, nil
 }
 
+func (r *chatRepository) GetMessagesAfter(ctx context.Context, sessionID, afterMessageID int32) ([]*domain.ChatMessage, error) {
+	params := sqlc.GetChatMessagesAfterParams{
+		SessionID:      sessionID,
+		AfterMessageID: afterMessageID,
+	}
+
+	results, err := r.store.GetChatMessagesAfter(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chat messages: %w", err)
+	}
+
+	messages := make([]*domain.ChatMessage, len(results))
+	for i, result := range results {
+		messages[i] = r.mapMessageToDomain(&result)
+	}
+
+	return messages, nil
+}
+
 func (r *chatRepository) CountMessagesBySession(ctx context.Context, sessionID int32) (int64, error) {
 	count, err := r.store.CountChatMessagesBySession(ctx, sessionID)
 	if err != nil {
@@ -161,6 +250,22 @@ func (r *chatRepository) CountMessagesBySession(ctx context.Context, sessionID i
 	return count, nil
 }
 
+func (r *chatRepository) UpdateMessageContent(ctx context.Context, messageID int32, content string, tokensUsed int32, truncated bool) (*domain.ChatMessage, error) {
+	params := sqlc.UpdateChatMessageContentParams{
+		ID:         messageID,
+		Content:    content,
+		TokensUsed: helpers.ToPgInt4(tokensUsed),
+		Truncated:  truncated,
+	}
+
+	result, err := r.store.UpdateChatMessageContent(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update chat message: %w", err)
+	}
+
+	return r.mapMessageToDomain(&result), nil
+}
+
 func (r *chatRepository) DeleteMessage(ctx context.Context, messageID int32) error {
 	if err := r.store.DeleteChatMessage(ctx, messageID); err != nil {
 		return fmt.Errorf("failed to delete chat message: %w", err)
@@ -177,8 +282,15 @@ func (r *chatRepository) mapSessionToDomain(s *sqlc.CognitiveChatSession) *domai
 		OrganizationID: s.OrganizationID,
 		AccountID:      s.AccountID,
 		Title:          helpers.FromPgText(s.Title),
-		CreatedAt:      s.CreatedAt.Time,
-		UpdatedAt:      s.UpdatedAt.Time,
+		ModelParameters: domain.ModelParameters{
+			Model:       helpers.FromPgText(s.Model),
+			Temperature: helpers.FromPgFloat4Ptr(s.Temperature),
+			MaxTokens:   helpers.FromPgInt4Ptr(s.MaxTokens),
+		},
+		CreatedAt:                  s.CreatedAt.Time,
+		UpdatedAt:                  s.UpdatedAt.Time,
+		Summary:                    helpers.FromPgText(s.Summary),
+		SummarizedThroughMessageID: s.SummarizedThroughMessageID,
 	}
 }
 
@@ -192,6 +304,12 @@ func (r *chatRepository) mapMessageToDomain(m *sqlc.CognitiveChatMessage) *domai
 		Content:        m.Content,
 		ReferencedDocs: m.ReferencedDocs,
 		TokensUsed:     helpers.FromPgInt4(m.TokensUsed),
-		CreatedAt:      m.CreatedAt.Time,
+		Truncated:      m.Truncated,
+		ModelParameters: domain.ModelParameters{
+			Model:       helpers.FromPgText(m.Model),
+			Temperature: helpers.FromPgFloat4Ptr(m.Temperature),
+			MaxTokens:   helpers.FromPgInt4Ptr(m.MaxTokens),
+		},
+		CreatedAt: m.CreatedAt.Time,
 	}
 }
@@ -28,6 +28,7 @@ func (r *embeddingRepository) Create(ctx context.Context, embedding *domain.Docu
 		ContentHash:    helpers.ToPgText(embedding.ContentHash),
 		ContentPreview: helpers.ToPgText(embedding.ContentPreview),
 		ChunkIndex:     helpers.ToPgInt4(embedding.ChunkIndex),
+		Location:       helpers.ToPgText(embedding.Location),
 	}
 
 	result, err := r.store.CreateDocumentEmbedding(ctx, params)
@@ -38,6 +39,51 @@ func (r *embeddingRepository) Create(ctx context.Context, embedding *domain.Docu
 	return r.mapToDomain(&result), nil
 }
 
+func (r *embeddingRepository) CreateBatch(ctx context.Context, orgID, documentID int32, embeddings []*domain.DocumentEmbedding, batchSize int) ([]*domain.DocumentEmbedding, error) {
+	if batchSize <= 0 {
+		batchSize = len(embeddings)
+	}
+
+	created := make([]*domain.DocumentEmbedding, 0, len(embeddings))
+	err := r.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		for start := 0; start < len(embeddings); start += batchSize {
+			end := min(start+batchSize, len(embeddings))
+			batch := embeddings[start:end]
+
+			params := sqlc.CreateDocumentEmbeddingsParams{
+				DocumentID:      documentID,
+				OrganizationID:  orgID,
+				Embeddings:      make([]string, len(batch)),
+				ContentHashes:   make([]string, len(batch)),
+				ContentPreviews: make([]string, len(batch)),
+				ChunkIndexes:    make([]int32, len(batch)),
+				Locations:       make([]string, len(batch)),
+			}
+			for i, e := range batch {
+				params.Embeddings[i] = helpers.ToVector(e.Embedding).String()
+				params.ContentHashes[i] = e.ContentHash
+				params.ContentPreviews[i] = e.ContentPreview
+				params.ChunkIndexes[i] = e.ChunkIndex
+				params.Locations[i] = e.Location
+			}
+
+			results, err := q.CreateDocumentEmbeddings(ctx, params)
+			if err != nil {
+				return fmt.Errorf("failed to create document embeddings %d-%d: %w", start, end-1, err)
+			}
+			for i := range results {
+				created = append(created, r.mapToDomain(&results[i]))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
 func (r *embeddingRepository) GetByID(ctx context.Context, orgID, embeddingID int32) (*domain.DocumentEmbedding, error) {
 	params := sqlc.GetDocumentEmbeddingByIDParams{
 		ID:             embeddingID,
@@ -93,6 +139,7 @@ func (r *embeddingRepository) SearchSimilar(ctx context.Context, orgID int32, em
 				ContentHash:    helpers.FromPgText(result.ContentHash),
 				ContentPreview: helpers.FromPgText(result.ContentPreview),
 				ChunkIndex:     helpers.FromPgInt4(result.ChunkIndex),
+				Location:       helpers.FromPgText(result.Location),
 				CreatedAt:      result.CreatedAt.Time,
 				UpdatedAt:      result.UpdatedAt.Time,
 			},
@@ -136,6 +183,7 @@ func (r *embeddingRepository) mapToDomain(e *sqlc.CognitiveDocumentEmbedding) *d
 		ContentHash:    helpers.FromPgText(e.ContentHash),
 		ContentPreview: helpers.FromPgText(e.ContentPreview),
 		ChunkIndex:     helpers.FromPgInt4(e.ChunkIndex),
+		Location:       helpers.FromPgText(e.Location),
 		CreatedAt:      e.CreatedAt.Time,
 		UpdatedAt:      e.UpdatedAt.Time,
 	}
@@ -0,0 +1,38 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
+)
+
+// planRepository implements domain.PlanRepository using SQLC internally.
+// It reads the subscriptions the billing module keeps in sync.
+type planRepository struct {
+	store sqlc.Store
+}
+
+// NewPlanRepository creates a new PlanRepository implementation.
+func NewPlanRepository(store sqlc.Store) domain.PlanRepository {
+	return &planRepository{store: store}
+}
+
+func (r *planRepository) GetActivePlanID(ctx context.Context, orgID int32) (string, error) {
+	result, err := r.store.GetSubscriptionByOrgID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to get subscription: %w", err)
+	}
+
+	switch result.SubscriptionStatus {
+	case "active", "trialing":
+		return result.ProductID, nil
+	default:
+		return "", nil
+	}
+}
@@ -0,0 +1,120 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
+)
+
+// promptSettingsRepository implements domain.PromptSettingsRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type promptSettingsRepository struct {
+	store sqlc.Store
+}
+
+// NewPromptSettingsRepository creates a new PromptSettingsRepository implementation.
+func NewPromptSettingsRepository(store sqlc.Store) domain.PromptSettingsRepository {
+	return &promptSettingsRepository{store: store}
+}
+
+func (r *promptSettingsRepository) CreateVersion(ctx context.Context, settings *domain.PromptSettings) (*domain.PromptSettings, error) {
+	glossary := settings.Glossary
+	if glossary == nil {
+		glossary = []domain.GlossaryTerm{}
+	}
+	glossaryJSON, err := json.Marshal(glossary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode glossary: %w", err)
+	}
+
+	params := sqlc.CreatePromptSettingsVersionParams{
+		OrganizationID:     settings.OrganizationID,
+		SystemPrompt:       helpers.ToPgText(settings.SystemPrompt),
+		Glossary:           glossaryJSON,
+		CreatedByAccountID: helpers.ToPgInt4Ptr(settings.CreatedByAccountID),
+		Language:           helpers.ToPgText(settings.Language),
+	}
+
+	result, err := r.store.CreatePromptSettingsVersion(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create prompt settings version: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *promptSettingsRepository) GetLatest(ctx context.Context, orgID int32) (*domain.PromptSettings, error) {
+	result, err := r.store.GetLatestPromptSettings(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrPromptSettingsNotFound
+		}
+		return nil, fmt.Errorf("failed to get prompt settings: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *promptSettingsRepository) GetVersion(ctx context.Context, orgID, version int32) (*domain.PromptSettings, error) {
+	params := sqlc.GetPromptSettingsVersionParams{
+		OrganizationID: orgID,
+		VersionNumber:  version,
+	}
+
+	result, err := r.store.GetPromptSettingsVersion(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrPromptSettingsNotFound
+		}
+		return nil, fmt.Errorf("failed to get prompt settings version: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *promptSettingsRepository) ListVersions(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.PromptSettings, error) {
+	params := sqlc.ListPromptSettingsVersionsParams{
+		OrganizationID: orgID,
+		Limit:          limit,
+		Offset:         offset,
+	}
+
+	results, err := r.store.ListPromptSettingsVersions(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list prompt settings versions: %w", err)
+	}
+
+	versions := make([]*domain.PromptSettings, len(results))
+	for i, result := range results {
+		if versions[i], err = r.mapToDomain(&result); err != nil {
+			return nil, err
+		}
+	}
+
+	return versions, nil
+}
+
+// mapToDomain maps SQLC prompt settings type to domain type.
+// This is the translation boundary - SQLC types never escape this function.
+func (r *promptSettingsRepository) mapToDomain(s *sqlc.CognitivePromptSetting) (*domain.PromptSettings, error) {
+	var glossary []domain.GlossaryTerm
+	if err := json.Unmarshal(s.Glossary, &glossary); err != nil {
+		return nil, fmt.Errorf("failed to decode glossary: %w", err)
+	}
+
+	return &domain.PromptSettings{
+		ID:                 s.ID,
+		OrganizationID:     s.OrganizationID,
+		Version:            s.VersionNumber,
+		SystemPrompt:       helpers.FromPgText(s.SystemPrompt),
+		Glossary:           glossary,
+		Language:           helpers.FromPgText(s.Language),
+		CreatedByAccountID: helpers.FromPgInt4Ptr(s.CreatedByAccountID),
+		CreatedAt:          s.CreatedAt.Time,
+	}, nil
+}
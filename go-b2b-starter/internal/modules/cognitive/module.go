@@ -23,11 +23,24 @@ func NewModule(container *dig.Container) *Module {
 // RegisterDependencies registers all cognitive module dependencies
 // Note: Repository implementations are registered in internal/db/inject.go
 func (m *Module) RegisterDependencies() error {
-	// Register AI adapters (infra layer)
+	// Register AI adapters (infra layer) with the size of the document index's vectors
+	if err := m.container.Provide(services.LoadEmbeddingIndexConfig); err != nil {
+		return err
+	}
+
 	if err := m.container.Provide(func(
 		llmClient llmdomain.LLMClient,
-	) domain.TextVectorizer {
-		return ai.NewTextVectorizer(llmClient)
+		indexConfig *services.EmbeddingIndexConfig,
+	) (domain.TextVectorizer, error) {
+		var projection *ai.PCAProjection
+		if indexConfig.Reduction == services.EmbeddingReductionPCA {
+			var err error
+			projection, err = ai.LoadPCAProjection(indexConfig.PCAPath, indexConfig.Dimensions)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return ai.NewTextVectorizer(llmClient, indexConfig.Dimensions, projection), nil
 	}); err != nil {
 		return err
 	}
@@ -40,24 +53,59 @@ func (m *Module) RegisterDependencies() error {
 		return err
 	}
 
-	// Register embedding service
+	// Register embedding service with the batch sizes for indexing chunks
+	if err := m.container.Provide(services.LoadEmbeddingInsertConfig); err != nil {
+		return err
+	}
+
 	if err := m.container.Provide(func(
 		embeddingRepo domain.EmbeddingRepository,
 		textVectorizer domain.TextVectorizer,
+		insertConfig *services.EmbeddingInsertConfig,
 	) services.EmbeddingService {
-		return services.NewEmbeddingService(embeddingRepo, textVectorizer)
+		return services.NewEmbeddingService(embeddingRepo, textVectorizer, insertConfig)
 	}); err != nil {
 		return err
 	}
 
-	// Register RAG service
+	// Register RAG service with the limits on client-requested model parameters
+	if err := m.container.Provide(services.LoadChatModelConfig); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.LoadChatSummaryConfig); err != nil {
+		return err
+	}
+
 	if err := m.container.Provide(func(
 		chatRepo domain.ChatRepository,
 		embeddingRepo domain.EmbeddingRepository,
 		textVectorizer domain.TextVectorizer,
 		assistantProvider domain.AssistantProvider,
+		planRepo domain.PlanRepository,
+		modelConfig *services.ChatModelConfig,
+		promptSettingsRepo domain.PromptSettingsRepository,
+		summaryConfig *services.ChatSummaryConfig,
 	) services.RAGService {
-		return services.NewRAGService(chatRepo, embeddingRepo, textVectorizer, assistantProvider)
+		return services.NewRAGService(chatRepo, embeddingRepo, textVectorizer, assistantProvider, planRepo, modelConfig, promptSettingsRepo, summaryConfig)
+	}); err != nil {
+		return err
+	}
+
+	// Register prompt settings service
+	if err := m.container.Provide(services.LoadChatPromptConfig); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.LoadChatDebugConfig); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		promptSettingsRepo domain.PromptSettingsRepository,
+		config *services.ChatPromptConfig,
+	) services.PromptSettingsService {
+		return services.NewPromptSettingsService(promptSettingsRepo, config)
 	}); err != nil {
 		return err
 	}
@@ -71,5 +119,23 @@ func (m *Module) RegisterDependencies() error {
 		return err
 	}
 
+	// Register guest claim listener
+	if err := m.container.Provide(func(
+		chatRepo domain.ChatRepository,
+	) services.GuestClaimListener {
+		return services.NewGuestClaimListener(chatRepo)
+	}); err != nil {
+		return err
+	}
+
+	// Register member offboarding listener
+	if err := m.container.Provide(func(
+		chatRepo domain.ChatRepository,
+	) services.MemberOffboardingListener {
+		return services.NewMemberOffboardingListener(chatRepo)
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
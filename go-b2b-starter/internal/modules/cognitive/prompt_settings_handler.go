@@ -0,0 +1,233 @@
+package cognitive
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/domain"
+	"github.com/moasq/go-b2b-starter/pkg/httperr"
+)
+
+type PromptSettingsHandler struct {
+	service services.PromptSettingsService
+}
+
+func NewPromptSettingsHandler(service services.PromptSettingsService) *PromptSettingsHandler {
+	return &PromptSettingsHandler{
+		service: service,
+	}
+}
+
+// PromptSettingsRequest represents the JSON request body for updating an
+// organization's prompt settings. All fields replace the current values.
+type PromptSettingsRequest struct {
+	SystemPrompt string                `json:"system_prompt"`
+	Glossary     []domain.GlossaryTerm `json:"glossary"`
+	Language     string                `json:"language" binding:"max=35"`
+}
+
+// GetSettings retrieves the organization's current prompt settings
+// @Summary Get prompt settings
+// @Description Retrieves the organization's custom system prompt, glossary and default language. Version 0 means none have been saved.
+// @Tags Cognitive
+// @Produce json
+// @Success 200 {object} domain.PromptSettings
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_cognitive/prompt-settings [get]
+func (h *PromptSettingsHandler) GetSettings(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	settings, err := h.service.GetSettings(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"fetch_failed",
+			"Failed to fetch prompt settings: "+err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// UpdateSettings saves a new version of the organization's prompt settings
+// @Summary Update prompt settings
+// @Description Saves the organization's custom system prompt, glossary and default language as a new version. They are added to every chat prompt of the organization.
+// @Description Text is sanitized; text containing the prompt template's delimiters or speaker labels is rejected.
+// @Description The language is a BCP 47 tag such as "de" or "pt-BR". Answers and conversation summaries use it, except that questions asked in another language are answered in theirs.
+// @Tags Cognitive
+// @Accept json
+// @Produce json
+// @Param request body PromptSettingsRequest true "Prompt settings"
+// @Success 200 {object} domain.PromptSettings
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_cognitive/prompt-settings [put]
+func (h *PromptSettingsHandler) UpdateSettings(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	var req PromptSettingsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			"Invalid JSON format: "+err.Error(),
+		))
+		return
+	}
+
+	settings, err := h.service.UpdateSettings(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, req.SystemPrompt, req.Glossary, req.Language)
+	if err != nil {
+		if isPromptSettingsError(err) {
+			c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+				http.StatusBadRequest,
+				"invalid_prompt_settings",
+				err.Error(),
+			))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"update_failed",
+			"Failed to update prompt settings: "+err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// ListVersions lists previous versions of the organization's prompt settings
+// @Summary List prompt settings versions
+// @Description Lists saved versions of the organization's prompt settings, newest first
+// @Tags Cognitive
+// @Produce json
+// @Param limit query int false "Limit" default(10)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_cognitive/prompt-settings/versions [get]
+func (h *PromptSettingsHandler) ListVersions(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	// Parse query parameters
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+
+	versions, err := h.service.ListVersions(c.Request.Context(), reqCtx.OrganizationID, int32(limit), int32(offset))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"list_failed",
+			"Failed to list prompt settings versions: "+err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"versions": versions,
+		"limit":    limit,
+		"offset":   offset,
+	})
+}
+
+// RestoreVersion makes a previous version the current prompt settings
+// @Summary Restore prompt settings version
+// @Description Saves a copy of a previous version as the organization's current prompt settings. The copy must satisfy the current limits.
+// @Tags Cognitive
+// @Produce json
+// @Param version path int true "Version number"
+// @Success 200 {object} domain.PromptSettings
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 404 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_cognitive/prompt-settings/versions/{version}/restore [post]
+func (h *PromptSettingsHandler) RestoreVersion(c *gin.Context) {
+	versionParam := c.Param("version")
+	var version int32
+	if _, err := fmt.Sscanf(versionParam, "%d", &version); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_version",
+			"Version must be a valid number",
+		))
+		return
+	}
+
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	settings, err := h.service.RestoreVersion(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, version)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPromptSettingsNotFound):
+			c.JSON(http.StatusNotFound, httperr.NewHTTPError(
+				http.StatusNotFound,
+				"not_found",
+				"Prompt settings version not found",
+			))
+		case isPromptSettingsError(err):
+			c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+				http.StatusBadRequest,
+				"invalid_prompt_settings",
+				err.Error(),
+			))
+		default:
+			c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+				http.StatusInternalServerError,
+				"restore_failed",
+				"Failed to restore prompt settings: "+err.Error(),
+			))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, settings)
+}
+
+// isPromptSettingsError reports whether err rejects submitted prompt settings
+func isPromptSettingsError(err error) bool {
+	return errors.Is(err, domain.ErrSystemPromptTooLong) ||
+		errors.Is(err, domain.ErrGlossaryTooLarge) ||
+		errors.Is(err, domain.ErrGlossaryTermInvalid) ||
+		errors.Is(err, domain.ErrPromptReservedText) ||
+		errors.Is(err, domain.ErrLanguageInvalid)
+}
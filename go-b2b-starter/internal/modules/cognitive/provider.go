@@ -18,6 +18,10 @@ func (p *Provider) RegisterDependencies() error {
 		return err
 	}
 
+	if err := p.container.Provide(NewPromptSettingsHandler); err != nil {
+		return err
+	}
+
 	// Register routes
 	if err := p.container.Provide(NewRoutes); err != nil {
 		return err
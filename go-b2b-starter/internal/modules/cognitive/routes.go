@@ -3,43 +3,92 @@ package cognitive
 import (
 	"github.com/gin-gonic/gin"
 
-	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/cognitive/app/services"
 	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
 )
 
 type Routes struct {
-	handler *Handler
+	handler               *Handler
+	promptSettingsHandler *PromptSettingsHandler
+	debugConfig           *services.ChatDebugConfig
 }
 
-func NewRoutes(handler *Handler) *Routes {
+func NewRoutes(handler *Handler, promptSettingsHandler *PromptSettingsHandler, debugConfig *services.ChatDebugConfig) *Routes {
 	return &Routes{
-		handler: handler,
+		handler:               handler,
+		promptSettingsHandler: promptSettingsHandler,
+		debugConfig:           debugConfig,
 	}
 }
 
 func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
 	cognitiveGroup := router.Group("/example_cognitive")
+	// Guests may try the chat demo; see GuestVerifier in the auth module
 	cognitiveGroup.Use(
-		resolver.Get("auth"),
+		resolver.Get("guest_auth"),
 		resolver.Get("org_context"),
 		resolver.Get("subscription"),
 	)
 	{
 		// Chat endpoint
 		cognitiveGroup.POST("/chat",
-			auth.RequirePermissionFunc("resource", "create"),
+			resolver.Get("perm:resource:create"),
 			r.handler.Chat)
 
+		cognitiveGroup.POST("/chat/stream",
+			resolver.Get("perm:resource:create"),
+			r.handler.ChatStream)
+
 		// Chat sessions
 		sessionsGroup := cognitiveGroup.Group("/sessions")
 		{
 			sessionsGroup.GET("",
-				auth.RequirePermissionFunc("resource", "view"),
+				resolver.Get("perm:resource:view"),
 				r.handler.ListSessions)
 
 			sessionsGroup.GET("/:id/messages",
-				auth.RequirePermissionFunc("resource", "view"),
+				resolver.Get("perm:resource:view"),
 				r.handler.GetSessionHistory)
+
+			sessionsGroup.PUT("/:id/parameters",
+				resolver.Get("perm:resource:edit"),
+				r.handler.UpdateSessionParameters)
+
+			sessionsGroup.POST("/:id/continue",
+				resolver.Get("perm:resource:create"),
+				r.handler.ContinueMessage)
+
+			sessionsGroup.POST("/:id/regenerate",
+				resolver.Get("perm:resource:create"),
+				r.handler.RegenerateMessage)
+		}
+
+		// Organization prompt customization
+		promptSettingsGroup := cognitiveGroup.Group("/prompt-settings")
+		{
+			promptSettingsGroup.GET("",
+				resolver.Get("perm:org:manage"),
+				r.promptSettingsHandler.GetSettings)
+
+			promptSettingsGroup.PUT("",
+				resolver.Get("perm:org:manage"),
+				r.promptSettingsHandler.UpdateSettings)
+
+			promptSettingsGroup.GET("/versions",
+				resolver.Get("perm:org:manage"),
+				r.promptSettingsHandler.ListVersions)
+
+			promptSettingsGroup.POST("/versions/:version/restore",
+				resolver.Get("perm:org:manage"),
+				r.promptSettingsHandler.RestoreVersion)
+		}
+
+		// RAG tuning - exposes document content and prompts, so only
+		// registered when CHAT_DEBUG_ENABLED is set
+		if r.debugConfig.Enabled {
+			cognitiveGroup.POST("/debug/retrieval",
+				resolver.Get("perm:org:manage"),
+				r.handler.ExplainRetrieval)
 		}
 	}
 }
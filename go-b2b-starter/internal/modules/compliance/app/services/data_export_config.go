@@ -0,0 +1,94 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DataExportConfig configures members' "download my data" exports.
+//
+// All values can be set via environment variables with the DATA_EXPORT_ prefix.
+type DataExportConfig struct {
+	// DownloadURL is the public endpoint the emailed download token is
+	// appended to
+	DownloadURL string `mapstructure:"DATA_EXPORT_DOWNLOAD_URL"`
+
+	// LinkTTL is how long the download link works. The archive is deleted
+	// once it expires.
+	LinkTTL time.Duration `mapstructure:"DATA_EXPORT_LINK_TTL"`
+
+	// MaxArchiveBytes caps the files included in an archive. Files past the
+	// cap are listed in the archive but left out of it.
+	MaxArchiveBytes int64 `mapstructure:"DATA_EXPORT_MAX_ARCHIVE_BYTES"`
+
+	// Timeout bounds how long building one archive may take. Exports still
+	// running after it, for example because of a restart, count as failed.
+	Timeout time.Duration `mapstructure:"DATA_EXPORT_TIMEOUT"`
+
+	// MinInterval is the time a member has to wait between exports
+	MinInterval time.Duration `mapstructure:"DATA_EXPORT_MIN_INTERVAL"`
+
+	// CleanupInterval is the time between deletions of expired archives.
+	// Zero disables the scheduled cleanup.
+	CleanupInterval time.Duration `mapstructure:"DATA_EXPORT_CLEANUP_INTERVAL"`
+}
+
+// LoadDataExportConfig loads the data export configuration from environment variables and app.env file.
+func LoadDataExportConfig() (*DataExportConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("DATA_EXPORT_DOWNLOAD_URL", "http://localhost:8080/api/data-exports/download")
+	v.SetDefault("DATA_EXPORT_LINK_TTL", "168h")
+	v.SetDefault("DATA_EXPORT_MAX_ARCHIVE_BYTES", 100<<20)
+	v.SetDefault("DATA_EXPORT_TIMEOUT", "30m")
+	v.SetDefault("DATA_EXPORT_MIN_INTERVAL", "24h")
+	v.SetDefault("DATA_EXPORT_CLEANUP_INTERVAL", "1h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg DataExportConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode data export config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the download link, archive size and scheduling settings.
+func (c *DataExportConfig) Validate() error {
+	if c.DownloadURL == "" {
+		return fmt.Errorf("data export config invalid: DATA_EXPORT_DOWNLOAD_URL is required")
+	}
+	if c.LinkTTL < time.Hour {
+		return fmt.Errorf("data export config invalid: DATA_EXPORT_LINK_TTL must be at least 1h")
+	}
+	if c.MaxArchiveBytes < 1 {
+		return fmt.Errorf("data export config invalid: DATA_EXPORT_MAX_ARCHIVE_BYTES must be positive")
+	}
+	if c.Timeout < time.Minute {
+		return fmt.Errorf("data export config invalid: DATA_EXPORT_TIMEOUT must be at least 1m")
+	}
+	if c.MinInterval < 0 {
+		return fmt.Errorf("data export config invalid: DATA_EXPORT_MIN_INTERVAL must not be negative")
+	}
+	if c.CleanupInterval < 0 {
+		return fmt.Errorf("data export config invalid: DATA_EXPORT_CLEANUP_INTERVAL must not be negative")
+	}
+	return nil
+}
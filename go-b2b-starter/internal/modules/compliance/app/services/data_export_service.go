@@ -0,0 +1,588 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
+	filemanager "github.com/moasq/go-b2b-starter/internal/modules/files"
+	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	orgDomain "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+const (
+	// dataExportFormat identifies the archive layout in export.json
+	dataExportFormat = "member-data-export/v1"
+
+	// dataExportTokenBytes is the entropy of emailed download tokens
+	dataExportTokenBytes = 32
+
+	// dataExportListLimit caps the exports ListExports returns
+	dataExportListLimit int32 = 20
+
+	// dataExportCleanupBatchSize caps the expired archives deleted per run
+	dataExportCleanupBatchSize int32 = 100
+
+	// dataExportFinalizeTimeout bounds marking an export failed and deleting
+	// its archive after the build itself ran out of time
+	dataExportFinalizeTimeout = 30 * time.Second
+
+	// dataExportFailureReason is shown to the member; details are logged
+	dataExportFailureReason = "the archive could not be built, please request a new export"
+)
+
+// buildDataExportJob is the background archive build after an export request
+var buildDataExportJob = jobsDomain.Definition{
+	Name:        "compliance.data_export",
+	Kind:        jobsDomain.KindQueued,
+	Description: "Builds members' personal data export archives",
+}
+
+// DataExportService implements "download my data" for members. A request
+// starts a background job that gathers the member's profile, the documents
+// and versions they uploaded with their files, and their chat history into a
+// ZIP archive. The archive is stored through the files module and the member
+// is emailed a download link that works for DATA_EXPORT_LINK_TTL; after that
+// the archive is deleted.
+type DataExportService interface {
+	// RequestExport starts building an archive for the member. It returns
+	// ErrDataExportInProgress while another export runs and
+	// ErrDataExportTooSoon within DATA_EXPORT_MIN_INTERVAL of the last one.
+	RequestExport(ctx context.Context, orgID, accountID int32) (*domain.DataExport, error)
+
+	// ListExports returns the member's recent exports newest first
+	ListExports(ctx context.Context, orgID, accountID int32) ([]*domain.DataExport, error)
+
+	GetExport(ctx context.Context, orgID, accountID, id int32) (*domain.DataExport, error)
+
+	// OpenDownload returns the archive of the export the emailed token belongs
+	// to. The caller must close it. Unknown and expired tokens return
+	// ErrDataExportExpired.
+	OpenDownload(ctx context.Context, token string) (io.ReadCloser, *domain.DataExport, error)
+
+	// Run deletes expired archives every DATA_EXPORT_CLEANUP_INTERVAL until
+	// ctx is cancelled
+	Run(ctx context.Context)
+
+	// DeleteExpired deletes one batch of archives whose download link expired
+	DeleteExpired(ctx context.Context) error
+}
+
+type dataExportService struct {
+	exports  domain.DataExportRepository
+	data     domain.AccountDataRepository
+	accounts orgDomain.AccountRepository
+	orgs     orgDomain.OrganizationRepository
+	files    filedomain.FileService
+	sender   emailDomain.Sender
+	config   *DataExportConfig
+	tracker  jobsDomain.Tracker
+	job      jobsDomain.Definition
+	logger   loggerDomain.Logger
+}
+
+func NewDataExportService(
+	exports domain.DataExportRepository,
+	data domain.AccountDataRepository,
+	accounts orgDomain.AccountRepository,
+	orgs orgDomain.OrganizationRepository,
+	files filedomain.FileService,
+	sender emailDomain.Sender,
+	config *DataExportConfig,
+	tracker jobsDomain.Tracker,
+	logger loggerDomain.Logger,
+) DataExportService {
+	s := &dataExportService{
+		exports:  exports,
+		data:     data,
+		accounts: accounts,
+		orgs:     orgs,
+		files:    files,
+		sender:   sender,
+		config:   config,
+		tracker:  tracker,
+		job: jobsDomain.Definition{
+			Name:        "compliance.data_export_cleanup",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Deletes data export archives whose download link expired",
+			Schedule:    "every " + config.CleanupInterval.String(),
+		},
+		logger: logger.Named("compliance"),
+	}
+	tracker.Register(buildDataExportJob)
+	tracker.Register(s.job)
+	return s
+}
+
+func (s *dataExportService) RequestExport(ctx context.Context, orgID, accountID int32) (*domain.DataExport, error) {
+	// Exports interrupted by a restart would otherwise block new ones forever
+	if _, err := s.exports.FailStale(ctx, accountID, time.Now().Add(-s.config.Timeout)); err != nil {
+		return nil, err
+	}
+
+	latest, err := s.exports.GetLatest(ctx, orgID, accountID)
+	switch {
+	case err == nil:
+		if latest.Status == domain.DataExportStatusRunning {
+			return nil, domain.ErrDataExportInProgress
+		}
+		if time.Since(latest.CreatedAt) < s.config.MinInterval {
+			return nil, domain.ErrDataExportTooSoon
+		}
+	case !errors.Is(err, domain.ErrDataExportNotFound):
+		return nil, err
+	}
+
+	export, err := s.exports.Create(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("data_export.requested", export, loggerDomain.Fields{})
+	s.buildInBackground(export)
+
+	return export, nil
+}
+
+func (s *dataExportService) ListExports(ctx context.Context, orgID, accountID int32) ([]*domain.DataExport, error) {
+	return s.exports.List(ctx, orgID, accountID, dataExportListLimit)
+}
+
+func (s *dataExportService) GetExport(ctx context.Context, orgID, accountID, id int32) (*domain.DataExport, error) {
+	return s.exports.GetByID(ctx, orgID, accountID, id)
+}
+
+func (s *dataExportService) OpenDownload(ctx context.Context, token string) (io.ReadCloser, *domain.DataExport, error) {
+	if token == "" {
+		return nil, nil, domain.ErrDataExportExpired
+	}
+
+	export, err := s.exports.GetByTokenHash(ctx, hashDownloadToken(token))
+	if err != nil {
+		if errors.Is(err, domain.ErrDataExportNotFound) {
+			return nil, nil, domain.ErrDataExportExpired
+		}
+		return nil, nil, err
+	}
+	if export.Status != domain.DataExportStatusCompleted || export.FileAssetID == nil ||
+		export.ExpiresAt == nil || time.Now().UTC().After(*export.ExpiresAt) {
+		return nil, nil, domain.ErrDataExportExpired
+	}
+
+	content, _, err := s.files.DownloadFile(ctx, *export.FileAssetID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open data export archive: %w", err)
+	}
+
+	if err := s.exports.RecordDownload(ctx, export.ID); err != nil {
+		s.logger.Error("failed to record data export download", loggerDomain.Fields{
+			"data_export_id": export.ID,
+			"error":          err.Error(),
+		})
+	}
+	s.audit("data_export.downloaded", export, loggerDomain.Fields{})
+
+	return content, export, nil
+}
+
+func (s *dataExportService) buildInBackground(export *domain.DataExport) {
+	go func() {
+		// Don't use request context as it will be cancelled when request completes
+		buildCtx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+		defer cancel()
+
+		err := s.tracker.Track(buildCtx, buildDataExportJob, func(ctx context.Context) error {
+			return s.build(ctx, export)
+		})
+		if err == nil {
+			return
+		}
+
+		s.logger.Error("data export failed", loggerDomain.Fields{
+			"data_export_id":  export.ID,
+			"organization_id": export.OrganizationID,
+			"account_id":      export.AccountID,
+			"error":           err.Error(),
+		})
+
+		// The build context may be the reason it failed
+		failCtx, cancelFail := context.WithTimeout(context.Background(), dataExportFinalizeTimeout)
+		defer cancelFail()
+		if err := s.exports.Fail(failCtx, export.ID, dataExportFailureReason); err != nil {
+			s.logger.Error("failed to mark data export as failed", loggerDomain.Fields{
+				"data_export_id": export.ID,
+				"error":          err.Error(),
+			})
+		}
+	}()
+}
+
+// build gathers the member's data into an archive, stores it, completes the
+// export and emails the download link
+func (s *dataExportService) build(ctx context.Context, export *domain.DataExport) error {
+	account, err := s.accounts.GetByID(ctx, export.OrganizationID, export.AccountID)
+	if err != nil {
+		return fmt.Errorf("failed to get account: %w", err)
+	}
+	org, err := s.orgs.GetByID(ctx, export.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	archive, err := os.CreateTemp("", "data-export-*.zip")
+	if err != nil {
+		return fmt.Errorf("failed to create archive file: %w", err)
+	}
+	defer func() {
+		archive.Close()
+		os.Remove(archive.Name())
+	}()
+
+	summary, err := s.writeArchive(ctx, archive, account, org)
+	if err != nil {
+		return err
+	}
+
+	size, err := archive.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to measure archive: %w", err)
+	}
+	if _, err := archive.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind archive: %w", err)
+	}
+
+	asset, err := s.files.StoreArchive(ctx, &filedomain.FileUploadRequest{
+		Filename:    fmt.Sprintf("data-export-%d.zip", export.ID),
+		Size:        size,
+		ContentType: "application/zip",
+		Context:     filemanager.ContextDataExport,
+		Metadata: map[string]any{
+			"data_export_id": export.ID,
+			"account_id":     export.AccountID,
+		},
+	}, archive)
+	if err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+
+	token, tokenHash, err := generateDownloadToken()
+	if err != nil {
+		s.deleteArchive(export, asset.ID)
+		return err
+	}
+
+	expiresAt := time.Now().UTC().Add(s.config.LinkTTL)
+	export.FileAssetID = &asset.ID
+	export.FileSize = size
+	export.DownloadTokenHash = tokenHash
+	export.Summary = *summary
+	export.ExpiresAt = &expiresAt
+
+	completed, err := s.exports.Complete(ctx, export)
+	if err != nil {
+		// Also covers exports failed as stale while this one was building
+		s.deleteArchive(export, asset.ID)
+		return err
+	}
+
+	s.audit("data_export.completed", completed, loggerDomain.Fields{
+		"file_size":     completed.FileSize,
+		"skipped_files": len(completed.Summary.SkippedFiles),
+		"expires_at":    expiresAt.Format(time.RFC3339),
+	})
+
+	msg := &emailDomain.Message{
+		To:      []string{account.Email},
+		Subject: "Your data export is ready",
+		Body: fmt.Sprintf(
+			"The export of your personal data in %s is ready.\n\n"+
+				"Download it before %s:\n%s\n\n"+
+				"Anyone with this link can download your data, so don't share it.\n\n"+
+				"If you did not request this export, contact your organization administrator.\n",
+			org.Name, expiresAt.Format(time.RFC1123), tokenLink(s.config.DownloadURL, token)),
+	}
+	if err := s.sender.Send(ctx, msg); err != nil {
+		s.logger.Error("failed to send data export email", loggerDomain.Fields{
+			"data_export_id": completed.ID,
+			"error":          err.Error(),
+		})
+	}
+
+	return nil
+}
+
+// exportManifest is export.json, describing the archive
+type exportManifest struct {
+	Format       string                   `json:"format"`
+	ExportedAt   time.Time                `json:"exported_at"`
+	Organization exportOrganization       `json:"organization"`
+	AccountID    int32                    `json:"account_id"`
+	Summary      domain.DataExportSummary `json:"summary"`
+}
+
+type exportOrganization struct {
+	ID   int32  `json:"id"`
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// exportProfile is profile.json
+type exportProfile struct {
+	Account      *orgDomain.Account `json:"account"`
+	Organization exportOrganization `json:"organization"`
+	AvatarPath   string             `json:"avatar_archive_path,omitempty"`
+}
+
+// exportDocuments is documents.json
+type exportDocuments struct {
+	Documents []domain.ExportedDocument        `json:"documents"`
+	Versions  []domain.ExportedDocumentVersion `json:"versions"`
+}
+
+// writeArchive writes the member's data as a ZIP archive. Files are added
+// while they fit DATA_EXPORT_MAX_ARCHIVE_BYTES; the rest are listed in the
+// summary as skipped.
+func (s *dataExportService) writeArchive(ctx context.Context, w io.Writer, account *orgDomain.Account, org *orgDomain.Organization) (*domain.DataExportSummary, error) {
+	documents, err := s.data.ListUploadedDocuments(ctx, org.ID, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	versions, err := s.data.ListUploadedVersions(ctx, org.ID, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	chats, err := s.data.ListChatHistory(ctx, org.ID, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.DataExportSummary{
+		Documents:        len(documents),
+		DocumentVersions: len(versions),
+		ChatSessions:     len(chats),
+	}
+	for _, chat := range chats {
+		summary.ChatMessages += len(chat.Messages)
+	}
+
+	zw := zip.NewWriter(w)
+	budget := s.config.MaxArchiveBytes
+	orgInfo := exportOrganization{ID: org.ID, Name: org.Name, Slug: org.Slug}
+
+	profile := exportProfile{Account: account, Organization: orgInfo}
+	if account.AvatarFileID != nil {
+		avatar, err := s.files.GetFile(ctx, *account.AvatarFileID)
+		if err != nil {
+			summary.SkippedFiles = append(summary.SkippedFiles, domain.SkippedFile{
+				FileID: *account.AvatarFileID,
+				Reason: "file could not be read",
+			})
+		} else {
+			profile.AvatarPath, err = s.addFile(ctx, zw, avatar.ID, avatar.OriginalFilename, avatar.Size,
+				"files/avatar/"+filedomain.SanitizeFilename(avatar.OriginalFilename), &budget, summary)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for i := range versions {
+		version := &versions[i]
+		path := fmt.Sprintf("files/documents/%d/v%d-%s",
+			version.DocumentID, version.VersionNumber, filedomain.SanitizeFilename(version.FileName))
+		version.ArchivePath, err = s.addFile(ctx, zw, version.FileAssetID, version.FileName, version.FileSize, path, &budget, summary)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := writeJSON(zw, "profile.json", profile); err != nil {
+		return nil, err
+	}
+	if err := writeJSON(zw, "documents.json", exportDocuments{Documents: documents, Versions: versions}); err != nil {
+		return nil, err
+	}
+	if err := writeJSON(zw, "chat_history.json", chats); err != nil {
+		return nil, err
+	}
+	if err := writeJSON(zw, "export.json", exportManifest{
+		Format:       dataExportFormat,
+		ExportedAt:   time.Now().UTC(),
+		Organization: orgInfo,
+		AccountID:    account.ID,
+		Summary:      *summary,
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return summary, nil
+}
+
+// addFile copies a stored file into the archive at path and returns the
+// path, or records the file as skipped and returns "" when it would exceed
+// the size budget or cannot be read. Only archive write errors are returned.
+func (s *dataExportService) addFile(ctx context.Context, zw *zip.Writer, fileID int32, fileName string, size int64, path string, budget *int64, summary *domain.DataExportSummary) (string, error) {
+	skip := func(reason string) (string, error) {
+		summary.SkippedFiles = append(summary.SkippedFiles, domain.SkippedFile{
+			FileID:   fileID,
+			FileName: fileName,
+			Reason:   reason,
+		})
+		return "", nil
+	}
+
+	if size > *budget {
+		return skip("archive size limit reached")
+	}
+
+	content, _, err := s.files.DownloadFile(ctx, fileID)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		s.logger.Warn("data export file could not be read", loggerDomain.Fields{
+			"file_id": fileID,
+			"error":   err.Error(),
+		})
+		return skip("file could not be read")
+	}
+	defer content.Close()
+
+	entry, err := zw.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to add %s to archive: %w", path, err)
+	}
+	written, err := io.Copy(entry, content)
+	if err != nil {
+		return "", fmt.Errorf("failed to add %s to archive: %w", path, err)
+	}
+
+	*budget -= written
+	summary.Files++
+	return path, nil
+}
+
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	entry, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("failed to add %s to archive: %w", name, err)
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func (s *dataExportService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.CleanupInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("data export cleanup scheduler started", loggerDomain.Fields{
+		"interval": s.config.CleanupInterval.String(),
+		"link_ttl": s.config.LinkTTL.String(),
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.DeleteExpired); err != nil {
+			s.logger.Error("data export cleanup run failed", loggerDomain.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *dataExportService) DeleteExpired(ctx context.Context) error {
+	exports, err := s.exports.ListExpired(ctx, dataExportCleanupBatchSize)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, export := range exports {
+		if export.FileAssetID != nil {
+			if err := s.files.DeleteFile(ctx, *export.FileAssetID); err != nil {
+				errs = append(errs, fmt.Errorf("data export %d: %w", export.ID, err))
+				continue
+			}
+		}
+		if err := s.exports.Expire(ctx, export.ID); err != nil {
+			errs = append(errs, fmt.Errorf("data export %d: %w", export.ID, err))
+			continue
+		}
+		s.audit("data_export.expired", export, loggerDomain.Fields{})
+	}
+
+	return errors.Join(errs...)
+}
+
+// deleteArchive removes the stored archive of an export that could not be completed
+func (s *dataExportService) deleteArchive(export *domain.DataExport, fileID int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), dataExportFinalizeTimeout)
+	defer cancel()
+
+	if err := s.files.DeleteFile(ctx, fileID); err != nil {
+		s.logger.Error("failed to delete data export archive", loggerDomain.Fields{
+			"data_export_id": export.ID,
+			"file_id":        fileID,
+			"error":          err.Error(),
+		})
+	}
+}
+
+// audit writes an audit log entry for the data export lifecycle.
+func (s *dataExportService) audit(event string, export *domain.DataExport, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = export.OrganizationID
+	fields["account_id"] = export.AccountID
+	fields["data_export_id"] = export.ID
+	s.logger.Info("data export audit", fields)
+}
+
+// tokenLink appends the token to the download URL.
+func tokenLink(base, token string) string {
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + "token=" + url.QueryEscape(token)
+}
+
+// generateDownloadToken returns a random download token and its stored hash.
+func generateDownloadToken() (string, string, error) {
+	buf := make([]byte, dataExportTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate download token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashDownloadToken(token), nil
+}
+
+func hashDownloadToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
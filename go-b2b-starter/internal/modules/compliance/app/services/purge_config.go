@@ -0,0 +1,66 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// PurgeConfig configures tenant data purges and report access.
+//
+// All values can be set via environment variables with the COMPLIANCE_ prefix.
+type PurgeConfig struct {
+	// DeleteAuthOrganization also deletes the organization in the auth provider
+	DeleteAuthOrganization bool `mapstructure:"COMPLIANCE_DELETE_AUTH_ORGANIZATION"`
+
+	// StagingPurgeInterval is the time between purges of expired staging
+	// organizations. Zero disables the scheduled purge.
+	StagingPurgeInterval time.Duration `mapstructure:"COMPLIANCE_STAGING_PURGE_INTERVAL"`
+
+	// StagingPurgeBatchSize caps the staging organizations purged per run
+	StagingPurgeBatchSize int `mapstructure:"COMPLIANCE_STAGING_PURGE_BATCH_SIZE"`
+}
+
+// LoadPurgeConfig loads the purge configuration from environment variables and app.env file.
+func LoadPurgeConfig() (*PurgeConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("COMPLIANCE_DELETE_AUTH_ORGANIZATION", true)
+	v.SetDefault("COMPLIANCE_STAGING_PURGE_INTERVAL", "1h")
+	v.SetDefault("COMPLIANCE_STAGING_PURGE_BATCH_SIZE", 50)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg PurgeConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode purge config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the scheduled staging purge settings.
+func (c *PurgeConfig) Validate() error {
+	if c.StagingPurgeInterval < 0 {
+		return fmt.Errorf("purge config invalid: COMPLIANCE_STAGING_PURGE_INTERVAL must not be negative")
+	}
+	if c.StagingPurgeInterval > 0 && c.StagingPurgeBatchSize < 1 {
+		return fmt.Errorf("purge config invalid: COMPLIANCE_STAGING_PURGE_BATCH_SIZE must be at least 1")
+	}
+	return nil
+}
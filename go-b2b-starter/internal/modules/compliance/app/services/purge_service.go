@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
+	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	orgDomain "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// organizationsTable is the row whose deletion cascades to every tenant table
+const organizationsTable = "organizations.organizations"
+
+// vectorIndexTables hold embeddings and are reported as vector indexes
+var vectorIndexTables = map[string]bool{
+	"cognitive.document_embeddings": true,
+	"public.resource_embeddings":    true,
+}
+
+// PurgeRequest asks for all data of one organization to be purged
+type PurgeRequest struct {
+	Reason      domain.PurgeReason `json:"reason" binding:"required"`
+	RequestedBy string             `json:"requested_by" binding:"required"`
+
+	// ConfirmSlug must repeat the organization slug to guard against purging
+	// the wrong tenant by ID typo
+	ConfirmSlug string `json:"confirm_slug" binding:"required"`
+}
+
+// PurgeService deletes a tenant's data and keeps a verification report of it
+type PurgeService interface {
+	// PurgeOrganization deletes the organization's rows, stored files, vector
+	// embeddings and auth provider organization, counts what remains and
+	// stores the resulting report
+	PurgeOrganization(ctx context.Context, orgID int32, req *PurgeRequest) (*domain.PurgeReport, error)
+
+	GetReport(ctx context.Context, id int32) (*domain.PurgeReport, error)
+
+	// ListReports returns reports newest first and the total match count.
+	// orgID 0 lists every organization.
+	ListReports(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.PurgeReport, int64, error)
+}
+
+type purgeService struct {
+	data     domain.TenantDataRepository
+	reports  domain.PurgeReportRepository
+	orgs     orgDomain.OrganizationRepository
+	authOrgs orgDomain.AuthOrganizationRepository
+	files    filedomain.FileService
+	storage  filedomain.R2Repository
+	config   *PurgeConfig
+	logger   loggerDomain.Logger
+}
+
+func NewPurgeService(
+	data domain.TenantDataRepository,
+	reports domain.PurgeReportRepository,
+	orgs orgDomain.OrganizationRepository,
+	authOrgs orgDomain.AuthOrganizationRepository,
+	files filedomain.FileService,
+	storage filedomain.R2Repository,
+	config *PurgeConfig,
+	logger loggerDomain.Logger,
+) PurgeService {
+	return &purgeService{
+		data:     data,
+		reports:  reports,
+		orgs:     orgs,
+		authOrgs: authOrgs,
+		files:    files,
+		storage:  storage,
+		config:   config,
+		logger:   logger.Named("compliance"),
+	}
+}
+
+func (s *purgeService) PurgeOrganization(ctx context.Context, orgID int32, req *PurgeRequest) (*domain.PurgeReport, error) {
+	if !req.Reason.IsValid() {
+		return nil, domain.ErrInvalidReason
+	}
+	requestedBy := strings.TrimSpace(req.RequestedBy)
+	if requestedBy == "" {
+		return nil, domain.ErrRequesterRequired
+	}
+
+	// Once started, a purge must run to the end even if the caller goes away
+	ctx = context.WithoutCancel(ctx)
+	started := time.Now()
+
+	before, err := s.data.CountRows(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if rowCount(before, organizationsTable) == 0 {
+		return nil, domain.ErrOrganizationNotFound
+	}
+
+	org, err := s.orgs.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if req.ConfirmSlug != org.Slug {
+		return nil, domain.ErrConfirmationMismatch
+	}
+
+	// Staging organizations reference their parent without a cascade, so
+	// they are purged (each with its own report) before the parent
+	children, err := s.purgeStagingChildren(ctx, orgID, req.Reason, requestedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Files are only reachable through tenant rows, so list them before the cascade
+	files, err := s.data.ListFiles(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("tenant_purge.started", orgID, loggerDomain.Fields{
+		"reason":           string(req.Reason),
+		"requested_by":     requestedBy,
+		"files":            len(files),
+		"staging_children": children,
+	})
+
+	// Deleting the organization cascades to every tenant table
+	if err := s.orgs.Delete(ctx, orgID); err != nil {
+		s.audit("tenant_purge.failed", orgID, loggerDomain.Fields{
+			"requested_by": requestedBy,
+			"error":        err.Error(),
+		})
+		return nil, err
+	}
+	rowsDeletedAt := time.Now()
+
+	report := &domain.PurgeReport{
+		OrganizationID:   orgID,
+		OrganizationName: org.Name,
+		Reason:           req.Reason,
+		RequestedBy:      requestedBy,
+		StartedAt:        started,
+	}
+	report.StorageObjects = s.purgeFiles(ctx, files, report)
+	report.AuthProvider = s.deleteAuthOrganization(ctx, org)
+
+	after, err := s.data.CountRows(ctx, orgID)
+	if err != nil {
+		report.VerificationErrors = append(report.VerificationErrors, fmt.Sprintf("recount tenant tables: %v", err))
+	}
+	report.Tables, report.VectorIndexes = tableResults(before, after, err == nil, rowsDeletedAt)
+
+	report.Status = verifyReport(report)
+	report.CompletedAt = time.Now()
+
+	saved, err := s.reports.Create(ctx, report)
+	if err != nil {
+		s.audit("tenant_purge.report_failed", orgID, loggerDomain.Fields{
+			"requested_by": requestedBy,
+			"status":       string(report.Status),
+			"error":        err.Error(),
+		})
+		return nil, err
+	}
+
+	s.audit("tenant_purge.completed", orgID, loggerDomain.Fields{
+		"report_id":     saved.ID,
+		"reason":        string(saved.Reason),
+		"requested_by":  requestedBy,
+		"status":        string(saved.Status),
+		"rows_deleted":  deletedRows(saved.Tables),
+		"vectors":       deletedRows(saved.VectorIndexes),
+		"files_deleted": saved.StorageObjects.Deleted,
+	})
+
+	return saved, nil
+}
+
+func (s *purgeService) GetReport(ctx context.Context, id int32) (*domain.PurgeReport, error) {
+	return s.reports.GetByID(ctx, id)
+}
+
+func (s *purgeService) ListReports(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.PurgeReport, int64, error) {
+	total, err := s.reports.Count(ctx, orgID)
+	if err != nil {
+		return nil, 0, err
+	}
+	reports, err := s.reports.List(ctx, orgID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	return reports, total, nil
+}
+
+// purgeStagingChildren purges the staging organizations of orgID and returns
+// how many were purged
+func (s *purgeService) purgeStagingChildren(ctx context.Context, orgID int32, reason domain.PurgeReason, requestedBy string) (int, error) {
+	childIDs, err := s.data.ListStagingChildren(ctx, orgID)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, childID := range childIDs {
+		child, err := s.orgs.GetByID(ctx, childID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get staging organization %d: %w", childID, err)
+		}
+		if _, err := s.PurgeOrganization(ctx, childID, &PurgeRequest{
+			Reason:      reason,
+			RequestedBy: requestedBy,
+			ConfirmSlug: child.Slug,
+		}); err != nil {
+			return 0, fmt.Errorf("failed to purge staging organization %d: %w", childID, err)
+		}
+	}
+	return len(childIDs), nil
+}
+
+// purgeFiles deletes each stored file and its metadata, then checks object
+// storage itself so the report does not rely on our own bookkeeping
+func (s *purgeService) purgeFiles(ctx context.Context, files []domain.StoredFile, report *domain.PurgeReport) domain.StorageResult {
+	result := domain.StorageResult{Found: int64(len(files))}
+	ids := make([]int32, 0, len(files))
+
+	for _, file := range files {
+		ids = append(ids, file.ID)
+		if err := s.files.DeleteFile(ctx, file.ID); err != nil {
+			result.Failures = append(result.Failures, storageFailure(file, err))
+			continue
+		}
+		deletedAt := time.Now()
+		result.Deleted++
+		result.DeletedAt = &deletedAt
+	}
+
+	for _, file := range files {
+		exists, err := s.storage.ObjectExists(ctx, file.StoragePath)
+		if err != nil {
+			result.Failures = append(result.Failures, storageFailure(file, fmt.Errorf("verify deletion: %w", err)))
+			continue
+		}
+		if exists {
+			result.RemainingObjects++
+		}
+	}
+
+	remaining, err := s.data.CountFiles(ctx, ids)
+	if err != nil {
+		report.VerificationErrors = append(report.VerificationErrors, fmt.Sprintf("recount file metadata: %v", err))
+	}
+	result.RemainingMetadata = remaining
+
+	return result
+}
+
+// deleteAuthOrganization removes the organization from the auth provider so
+// former members can no longer sign in to it
+func (s *purgeService) deleteAuthOrganization(ctx context.Context, org *orgDomain.Organization) domain.ExternalResult {
+	result := domain.ExternalResult{ID: org.StytchOrgID}
+	if !s.config.DeleteAuthOrganization || org.StytchOrgID == "" {
+		result.Skipped = true
+		return result
+	}
+
+	if err := s.authOrgs.DeleteOrganization(ctx, org.StytchOrgID); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	deletedAt := time.Now()
+	result.Deleted = true
+	result.DeletedAt = &deletedAt
+	return result
+}
+
+// audit writes an audit log entry for the tenant purge lifecycle.
+func (s *purgeService) audit(event string, orgID int32, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = orgID
+	s.logger.Info("tenant purge audit", fields)
+}
+
+// tableResults compares row counts before and after the purge and splits
+// them into plain tables and vector indexes. Without a recount every row is
+// reported as remaining so the report cannot claim a verified purge.
+func tableResults(before, after []domain.TableCount, recounted bool, deletedAt time.Time) ([]domain.TableResult, []domain.TableResult) {
+	tables := make([]domain.TableResult, 0, len(before))
+	vectors := make([]domain.TableResult, 0, len(vectorIndexTables))
+
+	for _, count := range before {
+		result := domain.TableResult{Table: count.Table, Before: count.Rows, Remaining: count.Rows}
+		if recounted {
+			result.Remaining = rowCount(after, count.Table)
+			result.Deleted = max(count.Rows-result.Remaining, 0)
+		}
+		if result.Deleted > 0 {
+			result.DeletedAt = &deletedAt
+		}
+
+		if vectorIndexTables[count.Table] {
+			vectors = append(vectors, result)
+		} else {
+			tables = append(tables, result)
+		}
+	}
+	return tables, vectors
+}
+
+// verifyReport marks the purge verified only when nothing of the tenant remains
+func verifyReport(report *domain.PurgeReport) domain.ReportStatus {
+	if len(report.VerificationErrors) > 0 {
+		return domain.ReportStatusIncomplete
+	}
+	if remainingRows(report.Tables) > 0 || remainingRows(report.VectorIndexes) > 0 {
+		return domain.ReportStatusIncomplete
+	}
+
+	storage := report.StorageObjects
+	if len(storage.Failures) > 0 || storage.RemainingObjects > 0 || storage.RemainingMetadata > 0 {
+		return domain.ReportStatusIncomplete
+	}
+	if report.AuthProvider.Error != "" {
+		return domain.ReportStatusIncomplete
+	}
+	return domain.ReportStatusVerified
+}
+
+func rowCount(counts []domain.TableCount, table string) int64 {
+	for _, count := range counts {
+		if count.Table == table {
+			return count.Rows
+		}
+	}
+	return 0
+}
+
+func deletedRows(results []domain.TableResult) int64 {
+	var total int64
+	for _, result := range results {
+		total += result.Deleted
+	}
+	return total
+}
+
+func remainingRows(results []domain.TableResult) int64 {
+	var total int64
+	for _, result := range results {
+		total += result.Remaining
+	}
+	return total
+}
+
+func storageFailure(file domain.StoredFile, err error) domain.StorageFailure {
+	return domain.StorageFailure{
+		FileID:      file.ID,
+		Bucket:      file.Bucket,
+		StoragePath: file.StoragePath,
+		Error:       err.Error(),
+	}
+}
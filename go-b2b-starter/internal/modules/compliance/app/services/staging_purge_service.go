@@ -0,0 +1,124 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
+	orgDomain "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// stagingPurgeRequester is recorded as requested_by on scheduled purge reports
+const stagingPurgeRequester = "system:staging-expiry"
+
+// StagingPurgeService purges staging and sandbox organizations once they expire.
+type StagingPurgeService interface {
+	// Run purges expired staging organizations every configured interval
+	// until ctx is cancelled
+	Run(ctx context.Context)
+
+	// PurgeExpired purges one batch of expired staging organizations
+	PurgeExpired(ctx context.Context) error
+}
+
+type stagingPurgeService struct {
+	purges  PurgeService
+	data    domain.TenantDataRepository
+	orgs    orgDomain.OrganizationRepository
+	config  *PurgeConfig
+	tracker jobsDomain.Tracker
+	job     jobsDomain.Definition
+	logger  loggerDomain.Logger
+}
+
+func NewStagingPurgeService(
+	purges PurgeService,
+	data domain.TenantDataRepository,
+	orgs orgDomain.OrganizationRepository,
+	config *PurgeConfig,
+	tracker jobsDomain.Tracker,
+	logger loggerDomain.Logger,
+) StagingPurgeService {
+	s := &stagingPurgeService{
+		purges:  purges,
+		data:    data,
+		orgs:    orgs,
+		config:  config,
+		tracker: tracker,
+		job: jobsDomain.Definition{
+			Name:        "compliance.staging_purge",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Purges expired staging and sandbox organizations",
+			Schedule:    "every " + config.StagingPurgeInterval.String(),
+		},
+		logger: logger.Named("compliance"),
+	}
+	tracker.Register(s.job)
+	return s
+}
+
+func (s *stagingPurgeService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.StagingPurgeInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("staging purge scheduler started", loggerDomain.Fields{
+		"interval":   s.config.StagingPurgeInterval.String(),
+		"batch_size": s.config.StagingPurgeBatchSize,
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.PurgeExpired); err != nil {
+			s.logger.Error("staging purge run failed", loggerDomain.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *stagingPurgeService) PurgeExpired(ctx context.Context) error {
+	orgIDs, err := s.data.ListExpiredStaging(ctx, int32(s.config.StagingPurgeBatchSize))
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, orgID := range orgIDs {
+		org, err := s.orgs.GetByID(ctx, orgID)
+		if errors.Is(err, orgDomain.ErrOrganizationNotFound) {
+			// Purged by another instance since it was listed
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("organization %d: %w", orgID, err))
+			continue
+		}
+
+		report, err := s.purges.PurgeOrganization(ctx, orgID, &PurgeRequest{
+			Reason:      domain.PurgeReasonStagingExpiry,
+			RequestedBy: stagingPurgeRequester,
+			ConfirmSlug: org.Slug,
+		})
+		if errors.Is(err, domain.ErrOrganizationNotFound) {
+			continue
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("organization %d: %w", orgID, err))
+			continue
+		}
+
+		s.logger.Info("expired staging organization purged", loggerDomain.Fields{
+			"organization_id": orgID,
+			"report_id":       report.ID,
+			"status":          string(report.Status),
+		})
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,167 @@
+package compliance
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// DataExportHandler serves members' "download my data" exports
+type DataExportHandler struct {
+	service services.DataExportService
+	logger  logger.Logger
+}
+
+func NewDataExportHandler(service services.DataExportService, logger logger.Logger) *DataExportHandler {
+	return &DataExportHandler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RequestExport godoc
+// @Summary Request my data export
+// @Description Starts building a ZIP archive of the signed-in member's personal data: their profile and avatar, the documents and versions they uploaded with their files, and their chat history. When it is ready the member is emailed a download link that works for DATA_EXPORT_LINK_TTL. One export can run at a time, and a new one can be requested DATA_EXPORT_MIN_INTERVAL after the last.
+// @Tags auth
+// @Produce json
+// @Success 202 {object} domain.DataExport "Export started"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "An export is already in progress"
+// @Failure 429 {object} map[string]string "An export was requested recently"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/data-exports [post]
+func (h *DataExportHandler) RequestExport(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	export, err := h.service.RequestExport(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrDataExportInProgress):
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		case errors.Is(err, domain.ErrDataExportTooSoon):
+			response.Error(c, http.StatusTooManyRequests, err.Error(), err)
+		default:
+			h.logger.Error("failed to request data export", map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": reqCtx.AccountID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to request data export", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, export)
+}
+
+// ListExports godoc
+// @Summary List my data exports
+// @Description Returns the signed-in member's recent data exports newest first, with their status, archive size and what they hold.
+// @Tags auth
+// @Produce json
+// @Success 200 {array} domain.DataExport "Data exports"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/data-exports [get]
+func (h *DataExportHandler) ListExports(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	exports, err := h.service.ListExports(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID)
+	if err != nil {
+		h.logger.Error("failed to list data exports", map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": reqCtx.AccountID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list data exports", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, exports)
+}
+
+// GetExport godoc
+// @Summary Get my data export
+// @Description Returns one of the signed-in member's data exports, for polling its status after requesting it.
+// @Tags auth
+// @Produce json
+// @Param id path int true "Data export ID"
+// @Success 200 {object} domain.DataExport "Data export"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Data export not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/data-exports/{id} [get]
+func (h *DataExportHandler) GetExport(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var id int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid data export id", err)
+		return
+	}
+
+	export, err := h.service.GetExport(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrDataExportNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		h.logger.Error("failed to get data export", map[string]interface{}{"org_id": reqCtx.OrganizationID, "data_export_id": id, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to get data export", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, export)
+}
+
+// DownloadExport godoc
+// @Summary Download a data export
+// @Description Downloads the ZIP archive of a data export with the token from the emailed link. The route is public so the link works from any browser; the token is the only credential, so it is random and the link expires after DATA_EXPORT_LINK_TTL.
+// @Tags auth
+// @Produce application/zip
+// @Param token query string true "Download token from the emailed link"
+// @Success 200 {file} file "data-export-{id}.zip"
+// @Failure 410 {object} map[string]string "Link invalid or expired"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /data-exports/download [get]
+func (h *DataExportHandler) DownloadExport(c *gin.Context) {
+	content, export, err := h.service.OpenDownload(c.Request.Context(), c.Query("token"))
+	if err != nil {
+		if errors.Is(err, domain.ErrDataExportExpired) {
+			response.Error(c, http.StatusGone, err.Error(), err)
+			return
+		}
+		h.logger.Error("failed to open data export", map[string]interface{}{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to download data export", err)
+		return
+	}
+	defer content.Close()
+
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Length", strconv.FormatInt(export.FileSize, 10))
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="data-export-%d.zip"`, export.ID))
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, content); err != nil {
+		h.logger.Error("failed to stream data export", map[string]interface{}{"data_export_id": export.ID, "error": err.Error()})
+	}
+}
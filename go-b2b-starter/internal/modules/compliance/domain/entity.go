@@ -0,0 +1,210 @@
+package domain
+
+import "time"
+
+// PurgeReason records why a tenant's data was purged
+type PurgeReason string
+
+const (
+	PurgeReasonGDPRErasure          PurgeReason = "gdpr_erasure"
+	PurgeReasonOrganizationDeletion PurgeReason = "organization_deletion"
+
+	// PurgeReasonStagingExpiry is used by the scheduled purge of expired
+	// staging and sandbox organizations
+	PurgeReasonStagingExpiry PurgeReason = "staging_expiry"
+)
+
+// IsValid reports whether the reason is known
+func (r PurgeReason) IsValid() bool {
+	return r == PurgeReasonGDPRErasure || r == PurgeReasonOrganizationDeletion || r == PurgeReasonStagingExpiry
+}
+
+// ReportStatus is the verification outcome of a purge
+type ReportStatus string
+
+const (
+	// ReportStatusVerified means nothing belonging to the tenant remains
+	ReportStatusVerified ReportStatus = "verified"
+
+	// ReportStatusIncomplete means some rows, objects or the auth provider
+	// organization remain and need manual follow-up
+	ReportStatusIncomplete ReportStatus = "incomplete"
+)
+
+// PurgeReport is the verification report for one tenant purge. It is kept
+// after the organization is gone so compliance admins can prove the erasure.
+type PurgeReport struct {
+	ID               int32          `json:"id"`
+	OrganizationID   int32          `json:"organization_id"`
+	OrganizationName string         `json:"organization_name"`
+	Reason           PurgeReason    `json:"reason"`
+	RequestedBy      string         `json:"requested_by"`
+	Status           ReportStatus   `json:"status"`
+	Tables           []TableResult  `json:"tables"`
+	VectorIndexes    []TableResult  `json:"vector_indexes"`
+	StorageObjects   StorageResult  `json:"storage_objects"`
+	AuthProvider     ExternalResult `json:"auth_provider"`
+
+	// VerificationErrors lists checks that could not run; the report is
+	// incomplete until they are repeated by hand
+	VerificationErrors []string `json:"verification_errors,omitempty"`
+
+	StartedAt   time.Time `json:"started_at"`
+	CompletedAt time.Time `json:"completed_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TableResult is the row count of one table before and after the purge
+type TableResult struct {
+	Table     string     `json:"table"`
+	Before    int64      `json:"before"`
+	Deleted   int64      `json:"deleted"`
+	Remaining int64      `json:"remaining"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// StorageResult covers the tenant's files in object storage and their
+// file_manager.file_assets metadata rows
+type StorageResult struct {
+	Found             int64            `json:"found"`
+	Deleted           int64            `json:"deleted"`
+	RemainingObjects  int64            `json:"remaining_objects"`
+	RemainingMetadata int64            `json:"remaining_metadata"`
+	Failures          []StorageFailure `json:"failures,omitempty"`
+	DeletedAt         *time.Time       `json:"deleted_at,omitempty"`
+}
+
+// StorageFailure is a file that could not be deleted or verified
+type StorageFailure struct {
+	FileID      int32  `json:"file_id"`
+	Bucket      string `json:"bucket"`
+	StoragePath string `json:"storage_path"`
+	Error       string `json:"error"`
+}
+
+// ExternalResult is the outcome of deleting the tenant in an external system
+type ExternalResult struct {
+	ID        string     `json:"id,omitempty"`
+	Deleted   bool       `json:"deleted"`
+	Skipped   bool       `json:"skipped,omitempty"`
+	Error     string     `json:"error,omitempty"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+}
+
+// TableCount is the number of rows a table holds for one organization
+type TableCount struct {
+	Table string
+	Rows  int64
+}
+
+// StoredFile is a file in object storage that belongs to an organization
+type StoredFile struct {
+	ID          int32
+	Bucket      string
+	StoragePath string
+}
+
+// DataExportStatus is the state of a member's data export
+type DataExportStatus string
+
+const (
+	DataExportStatusRunning   DataExportStatus = "running"
+	DataExportStatusCompleted DataExportStatus = "completed"
+	DataExportStatusFailed    DataExportStatus = "failed"
+
+	// DataExportStatusExpired means the download link expired and the
+	// archive was deleted
+	DataExportStatusExpired DataExportStatus = "expired"
+)
+
+// DataExport is a member's "download my data" request. A background job
+// builds a ZIP archive of the member's personal data and emails them a
+// download link that works until ExpiresAt.
+type DataExport struct {
+	ID             int32             `json:"id"`
+	OrganizationID int32             `json:"organization_id"`
+	AccountID      int32             `json:"account_id"`
+	Status         DataExportStatus  `json:"status"`
+	FileSize       int64             `json:"file_size,omitempty"`
+	DownloadCount  int32             `json:"download_count"`
+	Summary        DataExportSummary `json:"summary"`
+	Error          string            `json:"error,omitempty"`
+	StartedAt      time.Time         `json:"started_at"`
+	CompletedAt    *time.Time        `json:"completed_at,omitempty"`
+	ExpiresAt      *time.Time        `json:"expires_at,omitempty"`
+	CreatedAt      time.Time         `json:"created_at"`
+
+	// FileAssetID is the stored archive, set while the export is completed
+	FileAssetID *int32 `json:"-"`
+
+	// DownloadTokenHash is the SHA-256 hex of the emailed download token.
+	// The token itself is never stored.
+	DownloadTokenHash string `json:"-"`
+}
+
+// DataExportSummary counts what an export archive holds
+type DataExportSummary struct {
+	Documents        int `json:"documents"`
+	DocumentVersions int `json:"document_versions"`
+	ChatSessions     int `json:"chat_sessions"`
+	ChatMessages     int `json:"chat_messages"`
+	Files            int `json:"files"`
+
+	// SkippedFiles lists the files listed in the archive but left out of it
+	SkippedFiles []SkippedFile `json:"skipped_files,omitempty"`
+}
+
+// SkippedFile is a file left out of an export archive, for example because
+// it would push the archive past DATA_EXPORT_MAX_ARCHIVE_BYTES
+type SkippedFile struct {
+	FileID   int32  `json:"file_id"`
+	FileName string `json:"file_name"`
+	Reason   string `json:"reason"`
+}
+
+// ExportedDocument is a document the member uploaded
+type ExportedDocument struct {
+	ID          int32          `json:"id"`
+	Title       string         `json:"title"`
+	FileName    string         `json:"file_name"`
+	ContentType string         `json:"content_type"`
+	FileSize    int64          `json:"file_size"`
+	Status      string         `json:"status"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// ExportedDocumentVersion is a document version the member uploaded. Its
+// file is included in the archive under ArchivePath.
+type ExportedDocumentVersion struct {
+	ID            int32     `json:"id"`
+	DocumentID    int32     `json:"document_id"`
+	DocumentTitle string    `json:"document_title"`
+	VersionNumber int32     `json:"version_number"`
+	FileName      string    `json:"file_name"`
+	ContentType   string    `json:"content_type"`
+	FileSize      int64     `json:"file_size"`
+	ArchivePath   string    `json:"archive_path,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+
+	FileAssetID int32 `json:"-"`
+}
+
+// ExportedChatSession is one of the member's chat conversations
+type ExportedChatSession struct {
+	ID        int32                 `json:"id"`
+	Title     string                `json:"title,omitempty"`
+	Messages  []ExportedChatMessage `json:"messages"`
+	CreatedAt time.Time             `json:"created_at"`
+	UpdatedAt time.Time             `json:"updated_at"`
+}
+
+// ExportedChatMessage is a question of the member or an answer they received
+type ExportedChatMessage struct {
+	ID                  int32     `json:"id"`
+	Role                string    `json:"role"`
+	Content             string    `json:"content"`
+	ReferencedDocuments []int32   `json:"referenced_documents,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "errors"
+
+// Domain errors for tenant data purges and member data exports
+var (
+	// Not found errors
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrReportNotFound       = errors.New("purge report not found")
+	ErrDataExportNotFound   = errors.New("data export not found")
+
+	// Validation errors
+	ErrInvalidReason        = errors.New("reason must be gdpr_erasure, organization_deletion or staging_expiry")
+	ErrRequesterRequired    = errors.New("requested_by is required")
+	ErrConfirmationMismatch = errors.New("confirm_slug does not match the organization slug")
+
+	// Data export errors
+	ErrDataExportInProgress = errors.New("a data export is already in progress")
+	ErrDataExportTooSoon    = errors.New("a data export was requested recently, try again later")
+	ErrDataExportExpired    = errors.New("data export download link is invalid or expired")
+)
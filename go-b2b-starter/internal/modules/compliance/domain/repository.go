@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// TenantDataRepository inventories what the database holds for an organization
+type TenantDataRepository interface {
+	// CountRows returns the rows every tenant table holds for the organization,
+	// including the organization row itself and its vector indexes
+	CountRows(ctx context.Context, orgID int32) ([]TableCount, error)
+
+	// ListFiles returns the stored files referenced by the organization's data
+	ListFiles(ctx context.Context, orgID int32) ([]StoredFile, error)
+
+	// CountFiles returns how many of the given file metadata rows still exist
+	CountFiles(ctx context.Context, fileIDs []int32) (int64, error)
+
+	// ListExpiredStaging returns up to limit staging and sandbox organizations
+	// past their expiry, oldest first
+	ListExpiredStaging(ctx context.Context, limit int32) ([]int32, error)
+
+	// ListStagingChildren returns the staging and sandbox organizations of a
+	// production organization
+	ListStagingChildren(ctx context.Context, parentID int32) ([]int32, error)
+}
+
+// PurgeReportRepository stores purge verification reports
+type PurgeReportRepository interface {
+	Create(ctx context.Context, report *PurgeReport) (*PurgeReport, error)
+	GetByID(ctx context.Context, id int32) (*PurgeReport, error)
+
+	// List returns reports newest first. orgID 0 lists every organization.
+	List(ctx context.Context, orgID int32, limit, offset int32) ([]*PurgeReport, error)
+	Count(ctx context.Context, orgID int32) (int64, error)
+}
+
+// DataExportRepository stores members' data exports
+type DataExportRepository interface {
+	// Create starts a running export. It returns ErrDataExportInProgress when
+	// the account already has one running.
+	Create(ctx context.Context, orgID, accountID int32) (*DataExport, error)
+
+	GetByID(ctx context.Context, orgID, accountID, id int32) (*DataExport, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*DataExport, error)
+
+	// GetLatest returns the account's newest export that did not fail
+	GetLatest(ctx context.Context, orgID, accountID int32) (*DataExport, error)
+
+	// List returns the account's exports newest first
+	List(ctx context.Context, orgID, accountID, limit int32) ([]*DataExport, error)
+
+	// Complete stores the archive, download token hash, summary and expiry of
+	// a running export
+	Complete(ctx context.Context, export *DataExport) (*DataExport, error)
+
+	// Fail marks a running export as failed
+	Fail(ctx context.Context, id int32, reason string) error
+
+	// FailStale marks the account's running exports that started before the
+	// given time as failed
+	FailStale(ctx context.Context, accountID int32, before time.Time) (int64, error)
+
+	// ListExpired returns up to limit completed exports past their expiry
+	ListExpired(ctx context.Context, limit int32) ([]*DataExport, error)
+
+	// Expire marks an export as expired and forgets its archive and token
+	Expire(ctx context.Context, id int32) error
+
+	RecordDownload(ctx context.Context, id int32) error
+}
+
+// AccountDataRepository reads the personal data of one member for their data export
+type AccountDataRepository interface {
+	// ListUploadedDocuments returns the documents the account uploaded
+	ListUploadedDocuments(ctx context.Context, orgID, accountID int32) ([]ExportedDocument, error)
+
+	// ListUploadedVersions returns the document versions the account uploaded
+	ListUploadedVersions(ctx context.Context, orgID, accountID int32) ([]ExportedDocumentVersion, error)
+
+	// ListChatHistory returns the account's chat sessions with their messages
+	ListChatHistory(ctx context.Context, orgID, accountID int32) ([]ExportedChatSession, error)
+}
@@ -0,0 +1,195 @@
+package compliance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	listingshared "github.com/moasq/go-b2b-starter/pkg/pagination"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// ListReportsParams filters and pages the purge reports
+type ListReportsParams struct {
+	OrganizationID int32 `form:"organization_id"`
+	listingshared.ListableParams
+}
+
+// Handler serves tenant purges and their verification reports. It is an
+// operator endpoint behind the "operator" middleware, since the reports
+// outlive the organizations they describe.
+type Handler struct {
+	service services.PurgeService
+	logger  logger.Logger
+}
+
+func NewHandler(service services.PurgeService, logger logger.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// PurgeOrganization godoc
+// @Summary Purge an organization's data
+// @Description Deletes every row, stored file and vector embedding of the organization and its auth provider organization, verifies nothing remains and stores a verification report. Irreversible.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param org_id path int true "Organization ID"
+// @Param request body services.PurgeRequest true "Reason, requester and slug confirmation"
+// @Success 201 {object} domain.PurgeReport "Verification report"
+// @Failure 400 {object} map[string]string "Invalid request or slug mismatch"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Organization not found or endpoint disabled"
+// @Router /admin/compliance/organizations/{org_id}/purge [post]
+func (h *Handler) PurgeOrganization(c *gin.Context) {
+	var orgID int32
+	if _, err := fmt.Sscanf(c.Param("org_id"), "%d", &orgID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid organization id", err)
+		return
+	}
+
+	var req services.PurgeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	report, err := h.service.PurgeOrganization(c.Request.Context(), orgID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrOrganizationNotFound):
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case errors.Is(err, domain.ErrInvalidReason),
+			errors.Is(err, domain.ErrRequesterRequired),
+			errors.Is(err, domain.ErrConfirmationMismatch):
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		default:
+			h.logger.Error("tenant purge failed", logger.Fields{
+				"organization_id": orgID,
+				"error":           err.Error(),
+			})
+			response.Error(c, http.StatusInternalServerError, "failed to purge organization", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, report)
+}
+
+// ListReports godoc
+// @Summary List purge reports
+// @Description Returns purge verification reports newest first, optionally for one organization ID.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param organization_id query int false "Organization ID"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 10, max 100)"
+// @Success 200 {object} listingshared.PagePagination[domain.PurgeReport] "Reports"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Router /admin/compliance/purge-reports [get]
+func (h *Handler) ListReports(c *gin.Context) {
+	var params ListReportsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+	if err := params.Validate(); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	offset, err := listingshared.PageToOffset(params.Page, params.Limit)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	reports, total, err := h.service.ListReports(c.Request.Context(), params.OrganizationID, int32(params.Limit), int32(offset))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to list purge reports", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, listingshared.NewPagePagination(int(total), params.Page, params.Limit, reports))
+}
+
+// GetReport godoc
+// @Summary Get a purge report
+// @Description Returns one purge verification report.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param id path int true "Report ID"
+// @Success 200 {object} domain.PurgeReport "Report"
+// @Failure 400 {object} map[string]string "Invalid report ID"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Report not found or endpoint disabled"
+// @Router /admin/compliance/purge-reports/{id} [get]
+func (h *Handler) GetReport(c *gin.Context) {
+	report, ok := h.loadReport(c)
+	if !ok {
+		return
+	}
+
+	response.Success(c, http.StatusOK, report)
+}
+
+// DownloadReport godoc
+// @Summary Download a purge report
+// @Description Downloads one purge verification report as a JSON file for compliance records.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param id path int true "Report ID"
+// @Success 200 {file} file "purge-report-{id}.json"
+// @Failure 400 {object} map[string]string "Invalid report ID"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Report not found or endpoint disabled"
+// @Router /admin/compliance/purge-reports/{id}/download [get]
+func (h *Handler) DownloadReport(c *gin.Context) {
+	report, ok := h.loadReport(c)
+	if !ok {
+		return
+	}
+
+	body, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to encode purge report", err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="purge-report-%d.json"`, report.ID))
+	c.Data(http.StatusOK, "application/json", body)
+}
+
+// loadReport resolves the :id path parameter and writes the error response if needed
+func (h *Handler) loadReport(c *gin.Context) (*domain.PurgeReport, bool) {
+	var id int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid report id", err)
+		return nil, false
+	}
+
+	report, err := h.service.GetReport(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrReportNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+			return nil, false
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to get purge report", err)
+		return nil, false
+	}
+	return report, true
+}
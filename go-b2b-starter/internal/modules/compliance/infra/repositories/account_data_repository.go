@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
+)
+
+// accountDataRepository implements domain.AccountDataRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type accountDataRepository struct {
+	store sqlc.Store
+}
+
+// NewAccountDataRepository creates a new AccountDataRepository implementation.
+func NewAccountDataRepository(store sqlc.Store) domain.AccountDataRepository {
+	return &accountDataRepository{store: store}
+}
+
+func (r *accountDataRepository) ListUploadedDocuments(ctx context.Context, orgID, accountID int32) ([]domain.ExportedDocument, error) {
+	results, err := r.store.ListAccountUploadedDocuments(ctx, sqlc.ListAccountUploadedDocumentsParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list uploaded documents: %w", err)
+	}
+
+	documents := make([]domain.ExportedDocument, len(results))
+	for i, result := range results {
+		documents[i] = domain.ExportedDocument{
+			ID:          result.ID,
+			Title:       result.Title,
+			FileName:    result.FileName,
+			ContentType: result.ContentType,
+			FileSize:    result.FileSize,
+			Status:      result.Status,
+			Metadata:    helpers.FromJSONB(result.Metadata),
+			CreatedAt:   result.CreatedAt.Time,
+			UpdatedAt:   result.UpdatedAt.Time,
+		}
+	}
+	return documents, nil
+}
+
+func (r *accountDataRepository) ListUploadedVersions(ctx context.Context, orgID, accountID int32) ([]domain.ExportedDocumentVersion, error) {
+	results, err := r.store.ListAccountUploadedDocumentVersions(ctx, sqlc.ListAccountUploadedDocumentVersionsParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list uploaded document versions: %w", err)
+	}
+
+	versions := make([]domain.ExportedDocumentVersion, len(results))
+	for i, result := range results {
+		versions[i] = domain.ExportedDocumentVersion{
+			ID:            result.ID,
+			DocumentID:    result.DocumentID,
+			DocumentTitle: result.Title,
+			VersionNumber: result.VersionNumber,
+			FileName:      result.FileName,
+			ContentType:   result.ContentType,
+			FileSize:      result.FileSize,
+			CreatedAt:     result.CreatedAt.Time,
+			FileAssetID:   result.FileAssetID,
+		}
+	}
+	return versions, nil
+}
+
+func (r *accountDataRepository) ListChatHistory(ctx context.Context, orgID, accountID int32) ([]domain.ExportedChatSession, error) {
+	sessions, err := r.store.ListAccountChatSessions(ctx, sqlc.ListAccountChatSessionsParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat sessions: %w", err)
+	}
+	messages, err := r.store.ListAccountChatMessages(ctx, sqlc.ListAccountChatMessagesParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat messages: %w", err)
+	}
+
+	bySession := make(map[int32][]domain.ExportedChatMessage, len(sessions))
+	for _, message := range messages {
+		bySession[message.SessionID] = append(bySession[message.SessionID], domain.ExportedChatMessage{
+			ID:                  message.ID,
+			Role:                message.Role,
+			Content:             message.Content,
+			ReferencedDocuments: message.ReferencedDocs,
+			CreatedAt:           message.CreatedAt.Time,
+		})
+	}
+
+	history := make([]domain.ExportedChatSession, len(sessions))
+	for i, session := range sessions {
+		history[i] = domain.ExportedChatSession{
+			ID:        session.ID,
+			Title:     helpers.FromPgText(session.Title),
+			Messages:  bySession[session.ID],
+			CreatedAt: session.CreatedAt.Time,
+			UpdatedAt: session.UpdatedAt.Time,
+		}
+		if history[i].Messages == nil {
+			history[i].Messages = []domain.ExportedChatMessage{}
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
+)
+
+// dataExportRepository implements domain.DataExportRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type dataExportRepository struct {
+	store sqlc.Store
+}
+
+// NewDataExportRepository creates a new DataExportRepository implementation.
+func NewDataExportRepository(store sqlc.Store) domain.DataExportRepository {
+	return &dataExportRepository{store: store}
+}
+
+func (r *dataExportRepository) Create(ctx context.Context, orgID, accountID int32) (*domain.DataExport, error) {
+	result, err := r.store.CreateDataExport(ctx, sqlc.CreateDataExportParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+			return nil, domain.ErrDataExportInProgress
+		}
+		return nil, fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *dataExportRepository) GetByID(ctx context.Context, orgID, accountID, id int32) (*domain.DataExport, error) {
+	result, err := r.store.GetDataExport(ctx, sqlc.GetDataExportParams{
+		ID:             id,
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *dataExportRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.DataExport, error) {
+	result, err := r.store.GetDataExportByTokenHash(ctx, helpers.ToPgText(tokenHash))
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get data export by token: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *dataExportRepository) GetLatest(ctx context.Context, orgID, accountID int32) (*domain.DataExport, error) {
+	result, err := r.store.GetLatestDataExport(ctx, sqlc.GetLatestDataExportParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("failed to get latest data export: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *dataExportRepository) List(ctx context.Context, orgID, accountID, limit int32) ([]*domain.DataExport, error) {
+	results, err := r.store.ListDataExports(ctx, sqlc.ListDataExportsParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+		RowLimit:       limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data exports: %w", err)
+	}
+
+	return r.mapAllToDomain(results)
+}
+
+func (r *dataExportRepository) Complete(ctx context.Context, export *domain.DataExport) (*domain.DataExport, error) {
+	summary, err := json.Marshal(export.Summary)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data export summary: %w", err)
+	}
+
+	params := sqlc.CompleteDataExportParams{
+		ID:                export.ID,
+		FileAssetID:       helpers.ToPgInt4Ptr(export.FileAssetID),
+		FileSize:          pgtype.Int8{Int64: export.FileSize, Valid: true},
+		DownloadTokenHash: helpers.ToPgText(export.DownloadTokenHash),
+		Summary:           summary,
+	}
+	if export.ExpiresAt != nil {
+		params.ExpiresAt = pgtype.Timestamp{Time: *export.ExpiresAt, Valid: true}
+	}
+
+	result, err := r.store.CompleteDataExport(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDataExportNotFound
+		}
+		return nil, fmt.Errorf("failed to complete data export: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *dataExportRepository) Fail(ctx context.Context, id int32, reason string) error {
+	if err := r.store.FailDataExport(ctx, sqlc.FailDataExportParams{
+		ID:    id,
+		Error: helpers.ToPgText(reason),
+	}); err != nil {
+		return fmt.Errorf("failed to fail data export: %w", err)
+	}
+	return nil
+}
+
+func (r *dataExportRepository) FailStale(ctx context.Context, accountID int32, before time.Time) (int64, error) {
+	failed, err := r.store.FailStaleDataExports(ctx, sqlc.FailStaleDataExportsParams{
+		AccountID:   accountID,
+		StaleBefore: pgtype.Timestamp{Time: before, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale data exports: %w", err)
+	}
+	return failed, nil
+}
+
+func (r *dataExportRepository) ListExpired(ctx context.Context, limit int32) ([]*domain.DataExport, error) {
+	results, err := r.store.ListExpiredDataExports(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired data exports: %w", err)
+	}
+
+	return r.mapAllToDomain(results)
+}
+
+func (r *dataExportRepository) Expire(ctx context.Context, id int32) error {
+	if err := r.store.ExpireDataExport(ctx, id); err != nil {
+		return fmt.Errorf("failed to expire data export: %w", err)
+	}
+	return nil
+}
+
+func (r *dataExportRepository) RecordDownload(ctx context.Context, id int32) error {
+	if err := r.store.IncrementDataExportDownloads(ctx, id); err != nil {
+		return fmt.Errorf("failed to record data export download: %w", err)
+	}
+	return nil
+}
+
+func (r *dataExportRepository) mapAllToDomain(results []sqlc.ComplianceDataExport) ([]*domain.DataExport, error) {
+	exports := make([]*domain.DataExport, len(results))
+	for i := range results {
+		export, err := r.mapToDomain(&results[i])
+		if err != nil {
+			return nil, err
+		}
+		exports[i] = export
+	}
+	return exports, nil
+}
+
+func (r *dataExportRepository) mapToDomain(export *sqlc.ComplianceDataExport) (*domain.DataExport, error) {
+	var summary domain.DataExportSummary
+	if err := json.Unmarshal(export.Summary, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode data export %d summary: %w", export.ID, err)
+	}
+
+	mapped := &domain.DataExport{
+		ID:                export.ID,
+		OrganizationID:    export.OrganizationID,
+		AccountID:         export.AccountID,
+		Status:            domain.DataExportStatus(export.Status),
+		FileSize:          export.FileSize.Int64,
+		DownloadCount:     export.DownloadCount,
+		Summary:           summary,
+		Error:             export.Error.String,
+		StartedAt:         export.StartedAt.Time,
+		CreatedAt:         export.CreatedAt.Time,
+		FileAssetID:       helpers.FromPgInt4Ptr(export.FileAssetID),
+		DownloadTokenHash: export.DownloadTokenHash.String,
+	}
+	if export.CompletedAt.Valid {
+		completedAt := export.CompletedAt.Time
+		mapped.CompletedAt = &completedAt
+	}
+	if export.ExpiresAt.Valid {
+		expiresAt := export.ExpiresAt.Time
+		mapped.ExpiresAt = &expiresAt
+	}
+
+	return mapped, nil
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
+)
+
+// reportDetails is the JSONB payload of compliance.purge_reports.details
+type reportDetails struct {
+	Tables             []domain.TableResult  `json:"tables"`
+	VectorIndexes      []domain.TableResult  `json:"vector_indexes"`
+	StorageObjects     domain.StorageResult  `json:"storage_objects"`
+	AuthProvider       domain.ExternalResult `json:"auth_provider"`
+	VerificationErrors []string              `json:"verification_errors,omitempty"`
+}
+
+// purgeReportRepository implements domain.PurgeReportRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type purgeReportRepository struct {
+	store sqlc.Store
+}
+
+// NewPurgeReportRepository creates a new PurgeReportRepository implementation.
+func NewPurgeReportRepository(store sqlc.Store) domain.PurgeReportRepository {
+	return &purgeReportRepository{store: store}
+}
+
+func (r *purgeReportRepository) Create(ctx context.Context, report *domain.PurgeReport) (*domain.PurgeReport, error) {
+	details, err := json.Marshal(reportDetails{
+		Tables:             report.Tables,
+		VectorIndexes:      report.VectorIndexes,
+		StorageObjects:     report.StorageObjects,
+		AuthProvider:       report.AuthProvider,
+		VerificationErrors: report.VerificationErrors,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode purge report details: %w", err)
+	}
+
+	result, err := r.store.CreatePurgeReport(ctx, sqlc.CreatePurgeReportParams{
+		OrganizationID:   report.OrganizationID,
+		OrganizationName: report.OrganizationName,
+		Reason:           string(report.Reason),
+		RequestedBy:      report.RequestedBy,
+		Status:           string(report.Status),
+		Details:          details,
+		StartedAt:        pgtype.Timestamp{Time: report.StartedAt, Valid: true},
+		CompletedAt:      pgtype.Timestamp{Time: report.CompletedAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create purge report: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *purgeReportRepository) GetByID(ctx context.Context, id int32) (*domain.PurgeReport, error) {
+	result, err := r.store.GetPurgeReport(ctx, id)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrReportNotFound
+		}
+		return nil, fmt.Errorf("failed to get purge report: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *purgeReportRepository) List(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.PurgeReport, error) {
+	results, err := r.store.ListPurgeReports(ctx, sqlc.ListPurgeReportsParams{
+		OrganizationID: optionalID(orgID),
+		RowLimit:       limit,
+		RowOffset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list purge reports: %w", err)
+	}
+
+	reports := make([]*domain.PurgeReport, len(results))
+	for i := range results {
+		report, err := r.mapToDomain(&results[i])
+		if err != nil {
+			return nil, err
+		}
+		reports[i] = report
+	}
+	return reports, nil
+}
+
+func (r *purgeReportRepository) Count(ctx context.Context, orgID int32) (int64, error) {
+	count, err := r.store.CountPurgeReports(ctx, optionalID(orgID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count purge reports: %w", err)
+	}
+	return count, nil
+}
+
+func (r *purgeReportRepository) mapToDomain(report *sqlc.CompliancePurgeReport) (*domain.PurgeReport, error) {
+	var details reportDetails
+	if err := json.Unmarshal(report.Details, &details); err != nil {
+		return nil, fmt.Errorf("failed to decode purge report %d details: %w", report.ID, err)
+	}
+
+	return &domain.PurgeReport{
+		ID:                 report.ID,
+		OrganizationID:     report.OrganizationID,
+		OrganizationName:   report.OrganizationName,
+		Reason:             domain.PurgeReason(report.Reason),
+		RequestedBy:        report.RequestedBy,
+		Status:             domain.ReportStatus(report.Status),
+		Tables:             details.Tables,
+		VectorIndexes:      details.VectorIndexes,
+		StorageObjects:     details.StorageObjects,
+		AuthProvider:       details.AuthProvider,
+		VerificationErrors: details.VerificationErrors,
+		StartedAt:          report.StartedAt.Time,
+		CompletedAt:        report.CompletedAt.Time,
+		CreatedAt:          report.CreatedAt.Time,
+	}, nil
+}
+
+// optionalID maps a zero organization ID (no filter) to NULL
+func optionalID(id int32) pgtype.Int4 {
+	if id == 0 {
+		return pgtype.Int4{Valid: false}
+	}
+	return helpers.ToPgInt4(id)
+}
@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/domain"
+)
+
+// tenantDataRepository implements domain.TenantDataRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type tenantDataRepository struct {
+	store sqlc.Store
+}
+
+// NewTenantDataRepository creates a new TenantDataRepository implementation.
+func NewTenantDataRepository(store sqlc.Store) domain.TenantDataRepository {
+	return &tenantDataRepository{store: store}
+}
+
+func (r *tenantDataRepository) CountRows(ctx context.Context, orgID int32) ([]domain.TableCount, error) {
+	results, err := r.store.CountOrganizationRows(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count organization rows: %w", err)
+	}
+
+	counts := make([]domain.TableCount, len(results))
+	for i, result := range results {
+		counts[i] = domain.TableCount{Table: result.TableName, Rows: result.RowCount}
+	}
+	return counts, nil
+}
+
+func (r *tenantDataRepository) ListFiles(ctx context.Context, orgID int32) ([]domain.StoredFile, error) {
+	results, err := r.store.ListOrganizationFileAssets(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization files: %w", err)
+	}
+
+	files := make([]domain.StoredFile, len(results))
+	for i, result := range results {
+		files[i] = domain.StoredFile{
+			ID:          result.ID,
+			Bucket:      result.BucketName,
+			StoragePath: result.StoragePath,
+		}
+	}
+	return files, nil
+}
+
+func (r *tenantDataRepository) CountFiles(ctx context.Context, fileIDs []int32) (int64, error) {
+	if len(fileIDs) == 0 {
+		return 0, nil
+	}
+
+	count, err := r.store.CountFileAssetsByIDs(ctx, fileIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count file assets: %w", err)
+	}
+	return count, nil
+}
+
+func (r *tenantDataRepository) ListExpiredStaging(ctx context.Context, limit int32) ([]int32, error) {
+	ids, err := r.store.ListExpiredStagingOrganizations(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired staging organizations: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *tenantDataRepository) ListStagingChildren(ctx context.Context, parentID int32) ([]int32, error) {
+	ids, err := r.store.ListStagingOrganizationIDs(ctx, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staging organizations: %w", err)
+	}
+	return ids, nil
+}
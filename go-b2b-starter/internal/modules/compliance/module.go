@@ -0,0 +1,79 @@
+package compliance
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/compliance/app/services"
+)
+
+// Module provides compliance module dependencies
+type Module struct {
+	container *dig.Container
+}
+
+func NewModule(container *dig.Container) *Module {
+	return &Module{
+		container: container,
+	}
+}
+
+// RegisterDependencies registers all compliance module dependencies
+// Note: Repository implementations are registered in internal/db/inject.go
+func (m *Module) RegisterDependencies() error {
+	// Register purge config
+	if err := m.container.Provide(services.LoadPurgeConfig); err != nil {
+		return err
+	}
+
+	// Register purge service
+	if err := m.container.Provide(services.NewPurgeService); err != nil {
+		return err
+	}
+
+	// Register scheduled purge of expired staging organizations
+	if err := m.container.Provide(services.NewStagingPurgeService); err != nil {
+		return err
+	}
+
+	// Register data export config
+	if err := m.container.Provide(services.LoadDataExportConfig); err != nil {
+		return err
+	}
+
+	// Register member data exports
+	if err := m.container.Provide(services.NewDataExportService); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// StartScheduler starts the background purge of expired staging organizations
+// unless COMPLIANCE_STAGING_PURGE_INTERVAL is zero, and the deletion of expired
+// data export archives unless DATA_EXPORT_CLEANUP_INTERVAL is zero.
+func (m *Module) StartScheduler() error {
+	var stagingEnabled, exportCleanupEnabled bool
+	if err := m.container.Invoke(func(cfg *services.PurgeConfig, exportCfg *services.DataExportConfig) {
+		stagingEnabled = cfg.StagingPurgeInterval > 0
+		exportCleanupEnabled = exportCfg.CleanupInterval > 0
+	}); err != nil {
+		return err
+	}
+
+	if stagingEnabled {
+		if err := m.container.Invoke(func(service services.StagingPurgeService) {
+			go service.Run(context.Background())
+		}); err != nil {
+			return err
+		}
+	}
+
+	if !exportCleanupEnabled {
+		return nil
+	}
+	return m.container.Invoke(func(service services.DataExportService) {
+		go service.Run(context.Background())
+	})
+}
@@ -0,0 +1,58 @@
+package compliance
+
+import (
+	"github.com/gin-gonic/gin"
+
+	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
+)
+
+type Routes struct {
+	handler           *Handler
+	dataExportHandler *DataExportHandler
+}
+
+func NewRoutes(handler *Handler, dataExportHandler *DataExportHandler) *Routes {
+	return &Routes{
+		handler:           handler,
+		dataExportHandler: dataExportHandler,
+	}
+}
+
+func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	// Operator endpoints - X-Admin-Token instead of organization auth
+	adminGroup := router.Group("/admin/compliance")
+	adminGroup.Use(resolver.Get("operator"))
+	{
+		// POST /api/admin/compliance/organizations/{org_id}/purge
+		adminGroup.POST("/organizations/:org_id/purge", r.handler.PurgeOrganization)
+
+		// GET /api/admin/compliance/purge-reports
+		adminGroup.GET("/purge-reports", r.handler.ListReports)
+
+		// GET /api/admin/compliance/purge-reports/{id}
+		adminGroup.GET("/purge-reports/:id", r.handler.GetReport)
+
+		// GET /api/admin/compliance/purge-reports/{id}/download
+		adminGroup.GET("/purge-reports/:id/download", r.handler.DownloadReport)
+	}
+
+	// "Download my data" - members export their own personal data
+	dataExportGroup := router.Group("/me/data-exports")
+	dataExportGroup.Use(
+		resolver.Get("auth"),
+		resolver.Get("org_context"),
+	)
+	{
+		dataExportGroup.POST("", r.dataExportHandler.RequestExport)
+		dataExportGroup.GET("", r.dataExportHandler.ListExports)
+		dataExportGroup.GET("/:id", r.dataExportHandler.GetExport)
+	}
+
+	// Public endpoint - the emailed download link carries a random token instead of a session
+	router.GET("/data-exports/download", r.dataExportHandler.DownloadExport)
+}
+
+// Routes returns a RouteRegistrar function compatible with the server interface
+func (r *Routes) Routes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	r.RegisterRoutes(router, resolver)
+}
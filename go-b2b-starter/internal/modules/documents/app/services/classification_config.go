@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+)
+
+// classificationLabelPattern restricts document and entity type names
+var classificationLabelPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,99}$`)
+
+// ClassificationConfig controls the document classification stage that runs
+// after text extraction.
+//
+// All values can be set via environment variables with the DOCUMENT_CLASSIFICATION_ prefix.
+type ClassificationConfig struct {
+	// Enabled turns on classification of extracted documents
+	Enabled bool `mapstructure:"DOCUMENT_CLASSIFICATION_ENABLED"`
+
+	// DocumentTypes is a comma-separated taxonomy of document types.
+	// "other" is always added as the catch-all.
+	DocumentTypes string `mapstructure:"DOCUMENT_CLASSIFICATION_TYPES"`
+
+	// EntityTypes is a comma-separated list of named entity types to extract
+	EntityTypes string `mapstructure:"DOCUMENT_CLASSIFICATION_ENTITY_TYPES"`
+
+	// MinConfidence marks classifications below it for review and drops
+	// entities below it
+	MinConfidence float64 `mapstructure:"DOCUMENT_CLASSIFICATION_MIN_CONFIDENCE"`
+
+	// MaxInputChars bounds how much of the extracted text is sent to the model
+	MaxInputChars int `mapstructure:"DOCUMENT_CLASSIFICATION_MAX_INPUT_CHARS"`
+
+	// MaxTokens bounds the model's response
+	MaxTokens int `mapstructure:"DOCUMENT_CLASSIFICATION_MAX_TOKENS"`
+
+	// MaxEntities bounds how many entities are kept per document; 0 turns
+	// entity extraction off
+	MaxEntities int `mapstructure:"DOCUMENT_CLASSIFICATION_MAX_ENTITIES"`
+
+	// documentTypes and entityTypes are the parsed forms of DocumentTypes and EntityTypes
+	documentTypes []string
+	entityTypes   []string
+}
+
+// LoadClassificationConfig loads the classification configuration from environment variables and app.env file.
+func LoadClassificationConfig() (*ClassificationConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("DOCUMENT_CLASSIFICATION_ENABLED", false)
+	v.SetDefault("DOCUMENT_CLASSIFICATION_TYPES", "invoice,contract,report,receipt,letter")
+	v.SetDefault("DOCUMENT_CLASSIFICATION_ENTITY_TYPES", "person,organization,date,amount,address,reference_number")
+	v.SetDefault("DOCUMENT_CLASSIFICATION_MIN_CONFIDENCE", 0.5)
+	v.SetDefault("DOCUMENT_CLASSIFICATION_MAX_INPUT_CHARS", 12000)
+	v.SetDefault("DOCUMENT_CLASSIFICATION_MAX_TOKENS", 1000)
+	v.SetDefault("DOCUMENT_CLASSIFICATION_MAX_ENTITIES", 50)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg ClassificationConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode classification config: %w", err)
+	}
+
+	var err error
+	if cfg.documentTypes, err = parseClassificationLabels(cfg.DocumentTypes); err != nil {
+		return nil, fmt.Errorf("classification config invalid: DOCUMENT_CLASSIFICATION_TYPES: %w", err)
+	}
+	if !slices.Contains(cfg.documentTypes, domain.DocumentTypeOther) {
+		cfg.documentTypes = append(cfg.documentTypes, domain.DocumentTypeOther)
+	}
+	if cfg.entityTypes, err = parseClassificationLabels(cfg.EntityTypes); err != nil {
+		return nil, fmt.Errorf("classification config invalid: DOCUMENT_CLASSIFICATION_ENTITY_TYPES: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the taxonomy and limits of an enabled classification stage.
+func (c *ClassificationConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.documentTypes) < 2 {
+		return fmt.Errorf("classification config invalid: DOCUMENT_CLASSIFICATION_TYPES must list at least one type")
+	}
+	if c.MinConfidence < 0 || c.MinConfidence > 1 {
+		return fmt.Errorf("classification config invalid: DOCUMENT_CLASSIFICATION_MIN_CONFIDENCE must be between 0 and 1")
+	}
+	if c.MaxInputChars <= 0 || c.MaxTokens <= 0 {
+		return fmt.Errorf("classification config invalid: DOCUMENT_CLASSIFICATION_MAX_INPUT_CHARS and DOCUMENT_CLASSIFICATION_MAX_TOKENS must be positive")
+	}
+	if c.MaxEntities < 0 {
+		return fmt.Errorf("classification config invalid: DOCUMENT_CLASSIFICATION_MAX_ENTITIES must not be negative")
+	}
+	return nil
+}
+
+// DocumentTypeList returns the taxonomy, ending with "other" unless it was listed.
+func (c *ClassificationConfig) DocumentTypeList() []string {
+	return c.documentTypes
+}
+
+// EntityTypeList returns the entity types to extract; empty when entity
+// extraction is off.
+func (c *ClassificationConfig) EntityTypeList() []string {
+	if c.MaxEntities == 0 {
+		return nil
+	}
+	return c.entityTypes
+}
+
+// parseClassificationLabels splits a comma-separated list of lowercase type names.
+func parseClassificationLabels(list string) ([]string, error) {
+	var labels []string
+	for _, label := range strings.Split(list, ",") {
+		label = strings.ToLower(strings.TrimSpace(label))
+		if label == "" || slices.Contains(labels, label) {
+			continue
+		}
+		if !classificationLabelPattern.MatchString(label) {
+			return nil, fmt.Errorf("%q must be lowercase letters, digits and underscores", label)
+		}
+		labels = append(labels, label)
+	}
+	return labels, nil
+}
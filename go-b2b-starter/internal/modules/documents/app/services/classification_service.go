@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+	llmdomain "github.com/moasq/go-b2b-starter/internal/platform/llm/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	loggerdomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// classificationSchemaName names the structured output schema sent to the model
+const classificationSchemaName = "document_classification"
+
+// ClassificationService classifies documents into the configured taxonomy and
+// extracts named entities from their text.
+type ClassificationService interface {
+	// HandleDocumentUploaded classifies a document once its text is extracted.
+	// Manual overrides are kept. Does nothing when classification is disabled.
+	HandleDocumentUploaded(ctx context.Context, orgID, docID int32, title, text string) error
+
+	// GetClassification retrieves a document's classification
+	GetClassification(ctx context.Context, orgID, docID int32) (*domain.DocumentClassification, error)
+
+	// Reclassify classifies the document's current text again, replacing a manual override
+	Reclassify(ctx context.Context, orgID, docID int32) (*domain.DocumentClassification, error)
+
+	// OverrideClassification sets the document type and, when given, the entities by hand
+	OverrideClassification(ctx context.Context, orgID, docID, accountID int32, req *OverrideClassificationRequest) (*domain.DocumentClassification, error)
+}
+
+// OverrideClassificationRequest represents a manual classification.
+// Entities replace the current ones when set; an empty list clears them.
+type OverrideClassificationRequest struct {
+	DocumentType string          `json:"document_type" binding:"required"`
+	Entities     []domain.Entity `json:"entities,omitempty"`
+}
+
+// classificationResult is the structured output of the model
+type classificationResult struct {
+	DocumentType string          `json:"document_type"`
+	Confidence   float64         `json:"confidence"`
+	Entities     []domain.Entity `json:"entities"`
+}
+
+type classificationService struct {
+	docRepo            domain.DocumentRepository
+	classificationRepo domain.DocumentClassificationRepository
+	llmService         llmdomain.LLMService
+	config             *ClassificationConfig
+	logger             logger.Logger
+}
+
+func NewClassificationService(
+	docRepo domain.DocumentRepository,
+	classificationRepo domain.DocumentClassificationRepository,
+	llmService llmdomain.LLMService,
+	config *ClassificationConfig,
+	logger logger.Logger,
+) ClassificationService {
+	return &classificationService{
+		docRepo:            docRepo,
+		classificationRepo: classificationRepo,
+		llmService:         llmService,
+		config:             config,
+		logger:             logger,
+	}
+}
+
+func (s *classificationService) HandleDocumentUploaded(ctx context.Context, orgID, docID int32, title, text string) error {
+	if !s.config.Enabled || strings.TrimSpace(text) == "" {
+		return nil
+	}
+
+	classification, err := s.classify(ctx, orgID, docID, title, text)
+	if err != nil {
+		return err
+	}
+
+	stored, err := s.classificationRepo.SaveModelResult(ctx, classification, false)
+	if err != nil {
+		return err
+	}
+	if !stored {
+		s.logger.Info("kept manual document classification", loggerdomain.Fields{
+			"document_id":     docID,
+			"organization_id": orgID,
+			"document_type":   classification.DocumentType,
+		})
+	}
+
+	return nil
+}
+
+func (s *classificationService) GetClassification(ctx context.Context, orgID, docID int32) (*domain.DocumentClassification, error) {
+	classification, err := s.classificationRepo.Get(ctx, orgID, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.markForReview(classification)
+	return classification, nil
+}
+
+func (s *classificationService) Reclassify(ctx context.Context, orgID, docID int32) (*domain.DocumentClassification, error) {
+	if !s.config.Enabled {
+		return nil, domain.ErrClassificationDisabled
+	}
+
+	doc, err := s.docRepo.GetByID(ctx, orgID, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+	if !doc.IsProcessed() || !doc.HasText() {
+		return nil, domain.ErrDocumentNotProcessed
+	}
+
+	classification, err := s.classify(ctx, orgID, docID, doc.Title, doc.ExtractedText)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.classificationRepo.SaveModelResult(ctx, classification, true); err != nil {
+		return nil, err
+	}
+
+	return s.GetClassification(ctx, orgID, docID)
+}
+
+func (s *classificationService) OverrideClassification(ctx context.Context, orgID, docID, accountID int32, req *OverrideClassificationRequest) (*domain.DocumentClassification, error) {
+	documentType := strings.ToLower(strings.TrimSpace(req.DocumentType))
+	if !slices.Contains(s.config.documentTypes, documentType) {
+		return nil, domain.ErrInvalidDocumentType
+	}
+
+	// Scoped to the caller's organization, so another tenant's document is not found
+	if _, err := s.docRepo.GetByID(ctx, orgID, docID); err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+
+	entities := req.Entities
+	if entities == nil {
+		// Keep the current entities
+		current, err := s.classificationRepo.Get(ctx, orgID, docID)
+		if err == nil {
+			entities = current.Entities
+		}
+	} else {
+		for i := range entities {
+			entities[i].Type = strings.ToLower(strings.TrimSpace(entities[i].Type))
+			entities[i].Value = strings.TrimSpace(entities[i].Value)
+			entities[i].Confidence = 1
+			if !slices.Contains(s.config.entityTypes, entities[i].Type) {
+				return nil, fmt.Errorf("%w: %q", domain.ErrInvalidEntityType, entities[i].Type)
+			}
+			if entities[i].Value == "" {
+				return nil, domain.ErrInvalidEntityValue
+			}
+		}
+	}
+
+	overriddenBy := &accountID
+	if accountID == 0 {
+		overriddenBy = nil
+	}
+
+	classification, err := s.classificationRepo.Override(ctx, &domain.DocumentClassification{
+		DocumentID:     docID,
+		OrganizationID: orgID,
+		DocumentType:   documentType,
+		Entities:       entities,
+		OverriddenBy:   overriddenBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return classification, nil
+}
+
+// classify asks the model for the document type and entities of a document's text.
+func (s *classificationService) classify(ctx context.Context, orgID, docID int32, title, text string) (*domain.DocumentClassification, error) {
+	maxTokens := s.config.MaxTokens
+	temperature := float32(0)
+
+	response, err := s.llmService.Complete(ctx, llmdomain.CompletionRequest{
+		Prompt:      s.buildPrompt(title, text),
+		MaxTokens:   &maxTokens,
+		Temperature: &temperature,
+		Schema: &llmdomain.ResponseSchema{
+			Name:   classificationSchemaName,
+			Schema: s.buildSchema(),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrClassificationFailed, err)
+	}
+
+	var result classificationResult
+	if err := json.Unmarshal([]byte(response.Text), &result); err != nil {
+		return nil, fmt.Errorf("%w: invalid model response: %v", domain.ErrClassificationFailed, err)
+	}
+
+	documentType := strings.ToLower(strings.TrimSpace(result.DocumentType))
+	if !slices.Contains(s.config.documentTypes, documentType) {
+		documentType = domain.DocumentTypeOther
+	}
+
+	return &domain.DocumentClassification{
+		DocumentID:     docID,
+		OrganizationID: orgID,
+		DocumentType:   documentType,
+		Confidence:     clampConfidence(result.Confidence),
+		Entities:       s.filterEntities(result.Entities),
+		Source:         domain.ClassificationSourceModel,
+		Model:          response.Model,
+	}, nil
+}
+
+// filterEntities keeps confident entities of configured types, once each, up to MaxEntities.
+func (s *classificationService) filterEntities(entities []domain.Entity) []domain.Entity {
+	entityTypes := s.config.EntityTypeList()
+	kept := []domain.Entity{}
+	seen := make(map[string]bool)
+
+	for _, entity := range entities {
+		if len(kept) >= s.config.MaxEntities {
+			break
+		}
+		entity.Type = strings.ToLower(strings.TrimSpace(entity.Type))
+		entity.Value = strings.TrimSpace(entity.Value)
+		entity.Confidence = clampConfidence(entity.Confidence)
+		if entity.Value == "" || entity.Confidence < s.config.MinConfidence || !slices.Contains(entityTypes, entity.Type) {
+			continue
+		}
+
+		key := entity.Type + "\x00" + strings.ToLower(entity.Value)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		kept = append(kept, entity)
+	}
+
+	return kept
+}
+
+func (s *classificationService) markForReview(classification *domain.DocumentClassification) {
+	classification.NeedsReview = !classification.IsOverridden() && classification.Confidence < s.config.MinConfidence
+}
+
+// buildPrompt lists the taxonomy and entity types and quotes the start of the text.
+func (s *classificationService) buildPrompt(title, text string) string {
+	if runes := []rune(text); len(runes) > s.config.MaxInputChars {
+		text = string(runes[:s.config.MaxInputChars])
+	}
+
+	var prompt strings.Builder
+	prompt.WriteString("Classify the document below and extract its named entities.\n\n")
+	prompt.WriteString("Document types: " + strings.Join(s.config.documentTypes, ", ") + "\n")
+	prompt.WriteString("Pick the single best type; use the catch-all type when none of the others fit. ")
+	prompt.WriteString("Give your confidence in the type from 0 to 1.\n")
+	if entityTypes := s.config.EntityTypeList(); len(entityTypes) > 0 {
+		prompt.WriteString("\nEntity types: " + strings.Join(entityTypes, ", ") + "\n")
+		prompt.WriteString("List each entity once, with its value exactly as written and your confidence from 0 to 1. ")
+		prompt.WriteString("Leave the list empty when there are none.\n")
+	}
+	prompt.WriteString("\n--- DOCUMENT ---\n")
+	if title != "" {
+		prompt.WriteString("Title: " + title + "\n\n")
+	}
+	prompt.WriteString(text)
+	prompt.WriteString("\n--- END OF DOCUMENT ---")
+
+	return prompt.String()
+}
+
+// buildSchema describes classificationResult for structured output.
+func (s *classificationService) buildSchema() map[string]any {
+	confidence := map[string]any{"type": "number", "minimum": 0, "maximum": 1}
+
+	properties := map[string]any{
+		"document_type": map[string]any{"type": "string", "enum": s.config.documentTypes},
+		"confidence":    confidence,
+	}
+	required := []string{"document_type", "confidence"}
+
+	if entityTypes := s.config.EntityTypeList(); len(entityTypes) > 0 {
+		properties["entities"] = map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type":                 "object",
+				"additionalProperties": false,
+				"required":             []string{"type", "value", "confidence"},
+				"properties": map[string]any{
+					"type":       map[string]any{"type": "string", "enum": entityTypes},
+					"value":      map[string]any{"type": "string"},
+					"confidence": confidence,
+				},
+			},
+		}
+		required = append(required, "entities")
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             required,
+		"properties":           properties,
+	}
+}
+
+func clampConfidence(confidence float64) float64 {
+	return min(max(confidence, 0), 1)
+}
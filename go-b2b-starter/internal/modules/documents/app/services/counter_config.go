@@ -0,0 +1,63 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// CounterConfig controls the scheduled reconciliation of the per-organization
+// document counters.
+//
+// All values can be set via environment variables with the DOCUMENT_COUNTERS_ prefix.
+type CounterConfig struct {
+	// ReconcileInterval is the time between recounts of every organization's
+	// counters. Zero disables the scheduled reconciliation.
+	ReconcileInterval time.Duration `mapstructure:"DOCUMENT_COUNTERS_RECONCILE_INTERVAL"`
+
+	// ReconcileBatchSize is how many organizations are listed at a time
+	ReconcileBatchSize int `mapstructure:"DOCUMENT_COUNTERS_RECONCILE_BATCH_SIZE"`
+}
+
+// LoadCounterConfig loads the counter configuration from environment variables and app.env file.
+func LoadCounterConfig() (*CounterConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("DOCUMENT_COUNTERS_RECONCILE_INTERVAL", "24h")
+	v.SetDefault("DOCUMENT_COUNTERS_RECONCILE_BATCH_SIZE", 500)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg CounterConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode counter config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the reconciliation interval and batch size.
+func (c *CounterConfig) Validate() error {
+	if c.ReconcileInterval < 0 {
+		return fmt.Errorf("counter config invalid: DOCUMENT_COUNTERS_RECONCILE_INTERVAL must not be negative")
+	}
+	if c.ReconcileInterval > 0 && c.ReconcileBatchSize < 1 {
+		return fmt.Errorf("counter config invalid: DOCUMENT_COUNTERS_RECONCILE_BATCH_SIZE must be at least 1")
+	}
+	return nil
+}
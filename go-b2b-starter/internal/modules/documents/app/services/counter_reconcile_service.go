@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	loggerdomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// CounterReconcileService recounts the maintained document counters so that
+// drift, e.g. from rows changed with the counter triggers disabled, does not
+// last past the next run.
+type CounterReconcileService interface {
+	// Run reconciles every organization's counters every configured interval
+	// until ctx is cancelled
+	Run(ctx context.Context)
+
+	// ReconcileAll reconciles the counters of every organization
+	ReconcileAll(ctx context.Context) error
+}
+
+type counterReconcileService struct {
+	counterRepo domain.DocumentCounterRepository
+	config      *CounterConfig
+	tracker     jobsDomain.Tracker
+	job         jobsDomain.Definition
+	logger      logger.Logger
+}
+
+func NewCounterReconcileService(
+	counterRepo domain.DocumentCounterRepository,
+	config *CounterConfig,
+	tracker jobsDomain.Tracker,
+	logger logger.Logger,
+) CounterReconcileService {
+	s := &counterReconcileService{
+		counterRepo: counterRepo,
+		config:      config,
+		tracker:     tracker,
+		job: jobsDomain.Definition{
+			Name:        "documents.counter_reconcile",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Recounts per-organization document counters and corrects drift",
+			Schedule:    "every " + config.ReconcileInterval.String(),
+		},
+		logger: logger.Named("documents"),
+	}
+	tracker.Register(s.job)
+	return s
+}
+
+func (s *counterReconcileService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.ReconcileInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("document counter reconciliation started", loggerdomain.Fields{
+		"interval":   s.config.ReconcileInterval.String(),
+		"batch_size": s.config.ReconcileBatchSize,
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.ReconcileAll); err != nil {
+			s.logger.Error("document counter reconciliation failed", loggerdomain.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *counterReconcileService) ReconcileAll(ctx context.Context) error {
+	var errs []error
+	var afterID int32
+	for {
+		orgIDs, err := s.counterRepo.ListOrganizationIDs(ctx, afterID, int32(s.config.ReconcileBatchSize))
+		if err != nil {
+			return errors.Join(append(errs, err)...)
+		}
+		if len(orgIDs) == 0 {
+			return errors.Join(errs...)
+		}
+
+		for _, orgID := range orgIDs {
+			drifted, err := s.counterRepo.Reconcile(ctx, orgID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("organization %d: %w", orgID, err))
+				continue
+			}
+			if drifted {
+				s.logger.Warn("document counters drifted and were corrected", loggerdomain.Fields{
+					"organization_id": orgID,
+				})
+			}
+		}
+		afterID = orgIDs[len(orgIDs)-1]
+	}
+}
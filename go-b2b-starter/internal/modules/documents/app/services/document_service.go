@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -13,38 +14,66 @@ import (
 	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
 	filemanager "github.com/moasq/go-b2b-starter/internal/modules/files"
 	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
 	"github.com/moasq/go-b2b-starter/internal/platform/logger"
 	loggerdomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
 	ocrdomain "github.com/moasq/go-b2b-starter/internal/platform/ocr/domain"
+	transcriptiondomain "github.com/moasq/go-b2b-starter/internal/platform/transcription/domain"
 )
 
+// processDocumentJob is the background text extraction after an upload
+var processDocumentJob = jobsDomain.Definition{
+	Name:        "documents.process",
+	Kind:        jobsDomain.KindQueued,
+	Description: "Extracts text from uploaded documents",
+}
+
 type documentService struct {
 	docRepo     domain.DocumentRepository
+	versionRepo domain.DocumentVersionRepository
+	counterRepo domain.DocumentCounterRepository
 	fileService filedomain.FileService
 	ocrService  ocrdomain.OCRService
+	transcriber transcriptiondomain.TranscriptionService
 	eventBus    eventbus.EventBus
+	jobs        jobsDomain.Tracker
+	spreadsheet *SpreadsheetConfig
+	transcript  *TranscriptConfig
 	logger      logger.Logger
 }
 
 func NewDocumentService(
 	docRepo domain.DocumentRepository,
+	versionRepo domain.DocumentVersionRepository,
+	counterRepo domain.DocumentCounterRepository,
 	fileService filedomain.FileService,
 	ocrService ocrdomain.OCRService,
+	transcriber transcriptiondomain.TranscriptionService,
 	eventBus eventbus.EventBus,
+	jobs jobsDomain.Tracker,
+	spreadsheet *SpreadsheetConfig,
+	transcript *TranscriptConfig,
 	logger logger.Logger,
 ) DocumentService {
+	jobs.Register(processDocumentJob)
 	return &documentService{
 		docRepo:     docRepo,
+		versionRepo: versionRepo,
+		counterRepo: counterRepo,
 		fileService: fileService,
 		ocrService:  ocrService,
+		transcriber: transcriber,
 		eventBus:    eventBus,
+		jobs:        jobs,
+		spreadsheet: spreadsheet,
+		transcript:  transcript,
 		logger:      logger,
 	}
 }
 
 func (s *documentService) UploadDocument(ctx context.Context, orgID int32, req *UploadDocumentRequest, content io.Reader) (*domain.Document, error) {
-	// Validate content type (only PDFs allowed)
-	if !strings.Contains(strings.ToLower(req.ContentType), "pdf") {
+	// Validate file type (only PDF, spreadsheets, audio and video allowed)
+	if documentFileKind(req.FileName, req.ContentType) == "" {
 		return nil, domain.ErrInvalidFileType
 	}
 
@@ -72,6 +101,7 @@ func (s *documentService) UploadDocument(ctx context.Context, orgID int32, req *
 		FileSize:       req.FileSize,
 		Status:         domain.DocumentStatusPending,
 		Metadata:       req.Metadata,
+		UploadedBy:     uploader(req.UploadedBy),
 	}
 
 	createdDoc, err := s.docRepo.Create(ctx, doc)
@@ -79,23 +109,113 @@ func (s *documentService) UploadDocument(ctx context.Context, orgID int32, req *
 		return nil, fmt.Errorf("failed to create document: %w", err)
 	}
 
+	// The original upload is version 1
+	if _, err := s.versionRepo.Create(ctx, newDocumentVersion(createdDoc, createdDoc.UploadedBy)); err != nil {
+		return nil, err
+	}
+
 	// Process document asynchronously (extract text)
+	s.processInBackground(orgID, createdDoc.ID, req.UploadedBy)
+
+	return createdDoc, nil
+}
+
+func (s *documentService) UploadDocumentVersion(ctx context.Context, orgID, docID int32, req *UploadDocumentRequest, content io.Reader) (*domain.DocumentVersion, error) {
+	// Validate file type (only PDF, spreadsheets, audio and video allowed)
+	if documentFileKind(req.FileName, req.ContentType) == "" {
+		return nil, domain.ErrInvalidFileType
+	}
+
+	doc, err := s.docRepo.GetByID(ctx, orgID, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+
+	fileReq := &filedomain.FileUploadRequest{
+		Filename:    req.FileName,
+		Size:        req.FileSize,
+		ContentType: req.ContentType,
+		Context:     filemanager.ContextGeneral,
+		Metadata:    req.Metadata,
+	}
+
+	fileAsset, err := s.fileService.UploadFile(ctx, fileReq, content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrFileUploadFailed, err)
+	}
+
+	doc.FileAssetID = fileAsset.ID
+	doc.FileName = req.FileName
+	doc.ContentType = req.ContentType
+	doc.FileSize = req.FileSize
+
+	version, err := s.versionRepo.Create(ctx, newDocumentVersion(doc, uploader(req.UploadedBy)))
+	if err != nil {
+		return nil, err
+	}
+
+	// The document always shows its latest version
+	if _, err := s.docRepo.UpdateFile(ctx, doc); err != nil {
+		return nil, err
+	}
+
+	s.processInBackground(orgID, docID, req.UploadedBy)
+
+	return version, nil
+}
+
+func (s *documentService) ListDocumentVersions(ctx context.Context, orgID, docID int32) ([]*domain.DocumentVersion, error) {
+	// Scoped to the caller's organization, so another tenant's document is not found
+	if _, err := s.docRepo.GetByID(ctx, orgID, docID); err != nil {
+		return nil, fmt.Errorf("failed to get document: %w", err)
+	}
+
+	return s.versionRepo.List(ctx, orgID, docID)
+}
+
+// newDocumentVersion describes the version holding the document's current file.
+func newDocumentVersion(doc *domain.Document, uploadedBy *int32) *domain.DocumentVersion {
+	return &domain.DocumentVersion{
+		DocumentID:     doc.ID,
+		OrganizationID: doc.OrganizationID,
+		FileAssetID:    doc.FileAssetID,
+		FileName:       doc.FileName,
+		ContentType:    doc.ContentType,
+		FileSize:       doc.FileSize,
+		Status:         domain.DocumentStatusPending,
+		UploadedBy:     uploadedBy,
+	}
+}
+
+// uploader returns the account stored as a document's uploader, nil if unknown.
+func uploader(accountID int32) *int32 {
+	if accountID == 0 {
+		return nil
+	}
+	return &accountID
+}
+
+// processInBackground extracts the document's text after the request returns.
+// uploadedBy is reported on document.uploaded.
+func (s *documentService) processInBackground(orgID, docID, uploadedBy int32) {
 	go func() {
 		// Create a new context with timeout for background processing
 		// Don't use request context as it will be cancelled when request completes
 		processCtx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 		defer cancel()
 
-		if _, err := s.ProcessDocument(processCtx, orgID, createdDoc.ID); err != nil {
+		err := s.jobs.Track(processCtx, processDocumentJob, func(ctx context.Context) error {
+			_, err := s.processDocument(ctx, orgID, docID, uploadedBy)
+			return err
+		})
+		if err != nil {
 			s.logger.Error("background document processing failed", loggerdomain.Fields{
-				"document_id":     createdDoc.ID,
+				"document_id":     docID,
 				"organization_id": orgID,
 				"error":           err.Error(),
 			})
 		}
 	}()
-
-	return createdDoc, nil
 }
 
 func (s *documentService) GetDocument(ctx context.Context, orgID, docID int32) (*domain.Document, error) {
@@ -109,7 +229,6 @@ func (s *documentService) GetDocument(ctx context.Context, orgID, docID int32) (
 
 func (s *documentService) ListDocuments(ctx context.Context, orgID int32, req *ListDocumentsRequest) (*ListDocumentsResponse, error) {
 	var docs []*domain.Document
-	var total int64
 	var err error
 
 	if req.Status != nil {
@@ -117,19 +236,24 @@ func (s *documentService) ListDocuments(ctx context.Context, orgID int32, req *L
 		if err != nil {
 			return nil, fmt.Errorf("failed to list documents by status: %w", err)
 		}
-		total, err = s.docRepo.CountByStatus(ctx, orgID, *req.Status)
 	} else {
 		docs, err = s.docRepo.List(ctx, orgID, req.Limit, req.Offset)
 		if err != nil {
 			return nil, fmt.Errorf("failed to list documents: %w", err)
 		}
-		total, err = s.docRepo.Count(ctx, orgID)
 	}
 
+	// Totals come from the maintained counters rather than counting rows
+	counters, err := s.counterRepo.Get(ctx, orgID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count documents: %w", err)
 	}
 
+	total := counters.DocumentCount
+	if req.Status != nil {
+		total = counters.CountByStatus(*req.Status)
+	}
+
 	return &ListDocumentsResponse{
 		Documents: docs,
 		Total:     total,
@@ -168,9 +292,19 @@ func (s *documentService) DeleteDocument(ctx context.Context, orgID, docID int32
 		return fmt.Errorf("failed to get document: %w", err)
 	}
 
-	// Delete the file asset
-	if err := s.fileService.DeleteFile(ctx, doc.FileAssetID); err != nil {
-		// Continue with document deletion even if file deletion fails
+	// Delete the file assets of every version
+	fileAssetIDs := []int32{doc.FileAssetID}
+	if versions, err := s.versionRepo.List(ctx, orgID, docID); err == nil {
+		for _, version := range versions {
+			if version.FileAssetID != doc.FileAssetID {
+				fileAssetIDs = append(fileAssetIDs, version.FileAssetID)
+			}
+		}
+	}
+	for _, fileAssetID := range fileAssetIDs {
+		if err := s.fileService.DeleteFile(ctx, fileAssetID); err != nil {
+			// Continue with document deletion even if file deletion fails
+		}
 	}
 
 	// Delete the document record
@@ -182,65 +316,70 @@ func (s *documentService) DeleteDocument(ctx context.Context, orgID, docID int32
 }
 
 func (s *documentService) GetDocumentStats(ctx context.Context, orgID int32) (*domain.DocumentStats, error) {
-	total, err := s.docRepo.Count(ctx, orgID)
+	counters, err := s.counterRepo.Get(ctx, orgID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count documents: %w", err)
 	}
 
-	pending, err := s.docRepo.CountByStatus(ctx, orgID, domain.DocumentStatusPending)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count pending documents: %w", err)
-	}
-
-	processed, err := s.docRepo.CountByStatus(ctx, orgID, domain.DocumentStatusProcessed)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count processed documents: %w", err)
-	}
-
-	failed, err := s.docRepo.CountByStatus(ctx, orgID, domain.DocumentStatusFailed)
-	if err != nil {
-		return nil, fmt.Errorf("failed to count failed documents: %w", err)
-	}
-
 	return &domain.DocumentStats{
-		TotalCount:     total,
-		PendingCount:   pending,
-		ProcessedCount: processed,
-		FailedCount:    failed,
+		TotalCount:      counters.DocumentCount,
+		PendingCount:    counters.PendingCount,
+		ProcessingCount: counters.ProcessingCount,
+		ProcessedCount:  counters.ProcessedCount,
+		FailedCount:     counters.FailedCount,
+		StorageBytes:    counters.StorageBytes,
+		ChunkCount:      counters.ChunkCount,
 	}, nil
 }
 
 func (s *documentService) ProcessDocument(ctx context.Context, orgID, docID int32) (*domain.Document, error) {
+	return s.processDocument(ctx, orgID, docID, 0)
+}
+
+// processDocument extracts the document's text and publishes document.uploaded
+// on behalf of uploadedBy, 0 when no member uploaded it just now.
+func (s *documentService) processDocument(ctx context.Context, orgID, docID, uploadedBy int32) (*domain.Document, error) {
 	// Update status to processing
 	doc, err := s.docRepo.UpdateStatus(ctx, orgID, docID, domain.DocumentStatusProcessing)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update document status: %w", err)
 	}
+	fileAssetID := doc.FileAssetID
 
 	// Download file content
 	content, _, err := s.fileService.DownloadFile(ctx, doc.FileAssetID)
 	if err != nil {
-		s.markDocumentFailed(ctx, orgID, docID, err.Error())
+		s.markDocumentFailed(ctx, orgID, docID, fileAssetID, err.Error())
 		return nil, fmt.Errorf("%w: %v", domain.ErrFileDownloadFailed, err)
 	}
 	defer content.Close()
 
-	// Extract text from PDF
-	extractedText, err := s.extractTextFromPDF(content)
+	// Extract text from the PDF or spreadsheet, or transcribe the recording
+	extractedText, chunks, err := s.extractText(ctx, doc, content)
 	if err != nil {
-		s.markDocumentFailed(ctx, orgID, docID, err.Error())
+		s.markDocumentFailed(ctx, orgID, docID, fileAssetID, err.Error())
 		return nil, fmt.Errorf("%w: %v", domain.ErrTextExtractionFailed, err)
 	}
 
 	// Update document with extracted text
 	doc, err = s.docRepo.UpdateExtractedText(ctx, orgID, docID, extractedText)
 	if err != nil {
-		s.markDocumentFailed(ctx, orgID, docID, err.Error())
+		s.markDocumentFailed(ctx, orgID, docID, fileAssetID, err.Error())
 		return nil, fmt.Errorf("failed to update extracted text: %w", err)
 	}
 
+	// Keep the version's text for comparing revisions
+	if err := s.versionRepo.UpdateText(ctx, docID, fileAssetID, extractedText, domain.DocumentStatusProcessed); err != nil {
+		s.logger.Error("failed to store document version text", loggerdomain.Fields{
+			"document_id": docID,
+			"error":       err.Error(),
+		})
+	}
+
 	// Publish event for cognitive module to pick up
 	event := events.NewDocumentUploaded(docID, orgID, doc.FileAssetID, doc.Title, extractedText)
+	event.AccountID = uploadedBy
+	event.Chunks = chunks
 	if err := s.eventBus.Publish(ctx, event); err != nil {
 		// Don't fail the operation just because event publishing failed
 	}
@@ -248,15 +387,100 @@ func (s *documentService) ProcessDocument(ctx context.Context, orgID, docID int3
 	return doc, nil
 }
 
-// markDocumentFailed marks a document as failed and publishes failure event
-func (s *documentService) markDocumentFailed(ctx context.Context, orgID, docID int32, errMsg string) {
+// markDocumentFailed marks a document and the version holding the file as failed and publishes failure event
+func (s *documentService) markDocumentFailed(ctx context.Context, orgID, docID, fileAssetID int32, errMsg string) {
 	s.docRepo.UpdateStatus(ctx, orgID, docID, domain.DocumentStatusFailed)
+	s.versionRepo.UpdateText(ctx, docID, fileAssetID, "", domain.DocumentStatusFailed)
 
 	// Publish failure event
 	event := events.NewDocumentFailed(docID, orgID, errMsg)
 	s.eventBus.Publish(ctx, event)
 }
 
+// extractText extracts a document's text by file kind. Spreadsheets and
+// transcripts are also split into chunks of rows or segments; PDFs are
+// embedded as a whole and return no chunks.
+func (s *documentService) extractText(ctx context.Context, doc *domain.Document, content io.Reader) (string, []events.DocumentChunk, error) {
+	switch documentFileKind(doc.FileName, doc.ContentType) {
+	case fileKindXLSX, fileKindCSV:
+		return s.extractTextFromSpreadsheet(doc, content)
+	case fileKindMedia:
+		return s.transcribeMedia(ctx, doc, content)
+	default:
+		text, err := s.extractTextFromPDF(content)
+		return text, nil, err
+	}
+}
+
+// extractTextFromSpreadsheet reads the cells of an XLSX or CSV file and splits them into chunks of rows
+func (s *documentService) extractTextFromSpreadsheet(doc *domain.Document, content io.Reader) (string, []events.DocumentChunk, error) {
+	kind := documentFileKind(doc.FileName, doc.ContentType)
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read spreadsheet content: %w", err)
+	}
+
+	var sheets []*spreadsheetSheet
+	var truncated bool
+	if kind == fileKindXLSX {
+		sheets, truncated, err = parseXLSX(data, s.spreadsheet.MaxRows)
+	} else {
+		sheetName := strings.TrimSuffix(doc.FileName, filepath.Ext(doc.FileName))
+		sheets, truncated, err = parseCSV(data, sheetName, s.spreadsheet.MaxRows)
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	text, chunks := renderSpreadsheet(sheets, truncated, s.spreadsheet.ChunkSize)
+	if truncated {
+		s.logger.Warn("spreadsheet row limit reached", loggerdomain.Fields{
+			"document_id": doc.ID,
+			"max_rows":    s.spreadsheet.MaxRows,
+		})
+	}
+
+	s.logger.Info("Successfully extracted spreadsheet text", loggerdomain.Fields{
+		"document_id": doc.ID,
+		"sheets":      len(sheets),
+		"chunks":      len(chunks),
+		"chars":       len(text),
+	})
+
+	return text, chunks, nil
+}
+
+// transcribeMedia transcribes an audio or video file and splits the timestamped
+// segments into chunks
+func (s *documentService) transcribeMedia(ctx context.Context, doc *domain.Document, content io.Reader) (string, []events.DocumentChunk, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read media content: %w", err)
+	}
+
+	transcript, err := s.transcriber.Transcribe(ctx, data, doc.FileName, doc.ContentType)
+	if err != nil {
+		s.logger.Error("transcription failed", loggerdomain.Fields{
+			"document_id": doc.ID,
+			"error":       err.Error(),
+		})
+		return "", nil, fmt.Errorf("transcription failed: %w", err)
+	}
+
+	text, chunks := renderTranscript(transcript, s.transcript.ChunkSize, s.transcript.ChunkDuration)
+
+	s.logger.Info("Successfully transcribed media", loggerdomain.Fields{
+		"document_id": doc.ID,
+		"language":    transcript.Language,
+		"duration":    transcript.Duration,
+		"segments":    len(transcript.Segments),
+		"chunks":      len(chunks),
+	})
+
+	return text, chunks, nil
+}
+
 // extractTextFromPDF extracts text from a PDF file using OCR service
 func (s *documentService) extractTextFromPDF(content io.Reader) (string, error) {
 	// Read all content into memory
@@ -0,0 +1,44 @@
+package services
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// File kinds the documents module extracts text from
+const (
+	fileKindPDF   = "pdf"
+	fileKindXLSX  = "xlsx"
+	fileKindCSV   = "csv"
+	fileKindMedia = "media" // audio or video, transcribed
+)
+
+// mediaExtensions are the audio and video formats that are transcribed
+var mediaExtensions = []string{".flac", ".m4a", ".mp3", ".mp4", ".mpeg", ".mpga", ".oga", ".ogg", ".wav", ".webm"}
+
+// documentFileKind tells PDF, spreadsheet and media uploads apart by file
+// extension, falling back to the content type. Empty when the file is not supported.
+func documentFileKind(fileName, contentType string) string {
+	switch ext := strings.ToLower(filepath.Ext(fileName)); {
+	case ext == ".pdf":
+		return fileKindPDF
+	case ext == ".xlsx":
+		return fileKindXLSX
+	case ext == ".csv":
+		return fileKindCSV
+	case slices.Contains(mediaExtensions, ext):
+		return fileKindMedia
+	}
+
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.Contains(contentType, "pdf"):
+		return fileKindPDF
+	case strings.Contains(contentType, "spreadsheetml.sheet"):
+		return fileKindXLSX
+	case strings.Contains(contentType, "csv"):
+		return fileKindCSV
+	}
+	return ""
+}
@@ -29,6 +29,12 @@ type DocumentService interface {
 
 	// ProcessDocument processes a document (extract text, etc.)
 	ProcessDocument(ctx context.Context, orgID, docID int32) (*domain.Document, error)
+
+	// UploadDocumentVersion uploads a new revision of a document and extracts its text
+	UploadDocumentVersion(ctx context.Context, orgID, docID int32, req *UploadDocumentRequest, content io.Reader) (*domain.DocumentVersion, error)
+
+	// ListDocumentVersions lists a document's versions, newest first
+	ListDocumentVersions(ctx context.Context, orgID, docID int32) ([]*domain.DocumentVersion, error)
 }
 
 // UploadDocumentRequest represents a request to upload a document
@@ -38,6 +44,8 @@ type UploadDocumentRequest struct {
 	ContentType string                 `json:"content_type"`
 	FileSize    int64                  `json:"file_size"`
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	// UploadedBy is the uploading member's account, reported on document.uploaded; 0 if unknown
+	UploadedBy int32 `json:"-"`
 }
 
 // ListDocumentsRequest represents a request to list documents
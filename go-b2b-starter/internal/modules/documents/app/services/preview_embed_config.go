@@ -0,0 +1,123 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// minPreviewEmbedSecretLength is the minimum length of the embed token signing secret
+const minPreviewEmbedSecretLength = 32
+
+// PreviewEmbedConfig controls embedding document previews in customer iframes.
+//
+// All values can be set via environment variables with the PREVIEW_EMBED_ prefix.
+type PreviewEmbedConfig struct {
+	// Enabled turns on embed token issuance and the public preview endpoint
+	Enabled bool `mapstructure:"PREVIEW_EMBED_ENABLED"`
+
+	// Secret signs embed tokens (HS256); at least 32 characters
+	Secret string `mapstructure:"PREVIEW_EMBED_SECRET"`
+
+	// TTL is the lifetime of an embed token and of the cookie it is exchanged for
+	TTL time.Duration `mapstructure:"PREVIEW_EMBED_TTL"`
+
+	// MaxTTL caps the lifetime a request may ask for
+	MaxTTL time.Duration `mapstructure:"PREVIEW_EMBED_MAX_TTL"`
+
+	// AllowedOrigins is a comma-separated list of origins (scheme://host[:port])
+	// that may frame previews
+	AllowedOrigins string `mapstructure:"PREVIEW_EMBED_ALLOWED_ORIGINS"`
+
+	// origins is the parsed form of AllowedOrigins
+	origins map[string]bool
+}
+
+// LoadPreviewEmbedConfig loads the preview embed configuration from environment variables and app.env file.
+func LoadPreviewEmbedConfig() (*PreviewEmbedConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("PREVIEW_EMBED_ENABLED", false)
+	v.SetDefault("PREVIEW_EMBED_SECRET", "")
+	v.SetDefault("PREVIEW_EMBED_TTL", "5m")
+	v.SetDefault("PREVIEW_EMBED_MAX_TTL", "1h")
+	v.SetDefault("PREVIEW_EMBED_ALLOWED_ORIGINS", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg PreviewEmbedConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode preview embed config: %w", err)
+	}
+
+	cfg.origins = make(map[string]bool)
+	for _, origin := range strings.Split(cfg.AllowedOrigins, ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			continue
+		}
+		normalized, err := NormalizeOrigin(origin)
+		if err != nil {
+			return nil, fmt.Errorf("preview embed config invalid: PREVIEW_EMBED_ALLOWED_ORIGINS: %w", err)
+		}
+		cfg.origins[normalized] = true
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that an enabled config can sign tokens for at least one origin.
+func (c *PreviewEmbedConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.Secret) < minPreviewEmbedSecretLength {
+		return fmt.Errorf("preview embed config invalid: PREVIEW_EMBED_SECRET must be at least %d characters", minPreviewEmbedSecretLength)
+	}
+	if c.TTL <= 0 || c.MaxTTL < c.TTL {
+		return fmt.Errorf("preview embed config invalid: PREVIEW_EMBED_TTL must be positive and at most PREVIEW_EMBED_MAX_TTL")
+	}
+	if len(c.origins) == 0 {
+		return fmt.Errorf("preview embed config invalid: PREVIEW_EMBED_ALLOWED_ORIGINS is required")
+	}
+	return nil
+}
+
+// IsOriginAllowed reports whether origin may frame previews.
+func (c *PreviewEmbedConfig) IsOriginAllowed(origin string) bool {
+	normalized, err := NormalizeOrigin(origin)
+	return err == nil && c.origins[normalized]
+}
+
+// NormalizeOrigin reduces an origin to lower-case scheme://host[:port] and
+// rejects anything else (paths, wildcards, non-HTTP schemes).
+func NormalizeOrigin(origin string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(origin))
+	if err != nil {
+		return "", fmt.Errorf("invalid origin %q", origin)
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || strings.Contains(u.Host, "*") {
+		return "", fmt.Errorf("invalid origin %q: must be scheme://host[:port]", origin)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" || u.User != nil {
+		return "", fmt.Errorf("invalid origin %q: must be scheme://host[:port]", origin)
+	}
+	return strings.ToLower(u.Scheme + "://" + u.Host), nil
+}
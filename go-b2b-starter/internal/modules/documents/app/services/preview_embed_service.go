@@ -0,0 +1,212 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	loggerdomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// previewTokenType marks tokens issued by PreviewEmbedService
+const previewTokenType = "document_preview"
+
+// PreviewPath is the public preview endpoint a token is valid for
+const PreviewPath = "/api/embed/documents/%d/preview"
+
+// previewClaims are the claims of a preview embed token. The token grants
+// read access to one document, framed by one origin, until it expires.
+type previewClaims struct {
+	jwt.RegisteredClaims
+	TokenType      string `json:"typ"`
+	OrganizationID int32  `json:"org"`
+	IssuedBy       int32  `json:"acct"`
+}
+
+// PreviewEmbedService issues and verifies tokens for embedding document
+// previews in customer iframes.
+type PreviewEmbedService interface {
+	// IssueEmbedToken issues a token letting req.Origin frame the document's
+	// preview. The caller's permission on the document is checked by the route.
+	IssueEmbedToken(ctx context.Context, orgID, accountID, docID int32, req *IssueEmbedTokenRequest) (*EmbedToken, error)
+
+	// OpenPreview verifies token for docID and opens the document content.
+	// fetchDest is the request's Sec-Fetch-Dest header; a top-level navigation
+	// ("document") is refused so a leaked link cannot be opened outside the
+	// frame. The caller closes Content.
+	OpenPreview(ctx context.Context, docID int32, token, fetchDest string) (*EmbeddedPreview, error)
+}
+
+// IssueEmbedTokenRequest represents the request to embed a document preview
+type IssueEmbedTokenRequest struct {
+	// Origin is the page origin (scheme://host[:port]) that frames the preview
+	Origin string `json:"origin" binding:"required"`
+
+	// TTLSeconds overrides the default token lifetime, up to the configured maximum
+	TTLSeconds int `json:"ttl_seconds" binding:"min=0"`
+}
+
+// EmbedToken is an issued preview token
+type EmbedToken struct {
+	Token string `json:"token"`
+
+	// PreviewURL is the iframe src, relative to the API host
+	PreviewURL string    `json:"preview_url"`
+	Origin     string    `json:"origin"`
+	ExpiresAt  time.Time `json:"expires_at"`
+}
+
+// EmbeddedPreview is a verified preview ready to be served
+type EmbeddedPreview struct {
+	Document  *domain.Document
+	Content   io.ReadCloser
+	Origin    string
+	ExpiresAt time.Time
+}
+
+type previewEmbedService struct {
+	docRepo     domain.DocumentRepository
+	fileService filedomain.FileService
+	config      *PreviewEmbedConfig
+	logger      logger.Logger
+}
+
+func NewPreviewEmbedService(
+	docRepo domain.DocumentRepository,
+	fileService filedomain.FileService,
+	config *PreviewEmbedConfig,
+	logger logger.Logger,
+) PreviewEmbedService {
+	return &previewEmbedService{
+		docRepo:     docRepo,
+		fileService: fileService,
+		config:      config,
+		logger:      logger,
+	}
+}
+
+func (s *previewEmbedService) IssueEmbedToken(ctx context.Context, orgID, accountID, docID int32, req *IssueEmbedTokenRequest) (*EmbedToken, error) {
+	if !s.config.Enabled {
+		return nil, domain.ErrPreviewEmbedDisabled
+	}
+
+	origin, err := NormalizeOrigin(req.Origin)
+	if err != nil || !s.config.IsOriginAllowed(origin) {
+		return nil, domain.ErrPreviewOriginNotAllowed
+	}
+
+	// Scoped to the caller's organization, so another tenant's document is not found
+	if _, err := s.docRepo.GetByID(ctx, orgID, docID); err != nil {
+		return nil, err
+	}
+
+	ttl := s.config.TTL
+	if req.TTLSeconds > 0 {
+		ttl = min(time.Duration(req.TTLSeconds)*time.Second, s.config.MaxTTL)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(ttl)
+	claims := previewClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   strconv.Itoa(int(docID)),
+			Audience:  jwt.ClaimStrings{origin},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		TokenType:      previewTokenType,
+		OrganizationID: orgID,
+		IssuedBy:       accountID,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.config.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign preview token: %w", err)
+	}
+
+	s.logger.Info("document preview embed token issued", loggerdomain.Fields{
+		"audit":           true,
+		"event":           "document.preview_embed_issued",
+		"organization_id": orgID,
+		"account_id":      accountID,
+		"document_id":     docID,
+		"origin":          origin,
+		"token_id":        claims.ID,
+		"expires_at":      expiresAt,
+	})
+
+	return &EmbedToken{
+		Token:      token,
+		PreviewURL: fmt.Sprintf(PreviewPath, docID) + "?token=" + url.QueryEscape(token),
+		Origin:     origin,
+		ExpiresAt:  expiresAt,
+	}, nil
+}
+
+func (s *previewEmbedService) OpenPreview(ctx context.Context, docID int32, token, fetchDest string) (*EmbeddedPreview, error) {
+	if !s.config.Enabled {
+		return nil, domain.ErrPreviewEmbedDisabled
+	}
+	if fetchDest == "document" {
+		return nil, domain.ErrPreviewOriginNotAllowed
+	}
+
+	var claims previewClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		return []byte(s.config.Secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, domain.ErrPreviewTokenExpired
+		}
+		return nil, domain.ErrInvalidPreviewToken
+	}
+
+	if claims.TokenType != previewTokenType || claims.Subject != strconv.Itoa(int(docID)) || len(claims.Audience) != 1 {
+		return nil, domain.ErrInvalidPreviewToken
+	}
+
+	// Tokens outliving the current maximum are rejected
+	if claims.IssuedAt == nil || claims.ExpiresAt.Sub(claims.IssuedAt.Time) > s.config.MaxTTL {
+		return nil, domain.ErrPreviewTokenExpired
+	}
+
+	// Removing an origin from the allowlist revokes its outstanding tokens
+	origin := claims.Audience[0]
+	if !s.config.IsOriginAllowed(origin) {
+		return nil, domain.ErrPreviewOriginNotAllowed
+	}
+
+	// A document deleted after issuance is no longer served
+	doc, err := s.docRepo.GetByID(ctx, claims.OrganizationID, docID)
+	if err != nil {
+		return nil, err
+	}
+
+	content, _, err := s.fileService.DownloadFile(ctx, doc.FileAssetID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrFileDownloadFailed, err)
+	}
+
+	return &EmbeddedPreview{
+		Document:  doc,
+		Content:   content,
+		Origin:    origin,
+		ExpiresAt: claims.ExpiresAt.Time,
+	}, nil
+}
@@ -0,0 +1,566 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain/events"
+)
+
+// maxXLSXPartSize bounds the uncompressed size of each XML part read from a
+// workbook, so a small upload cannot expand without limit
+const maxXLSXPartSize = 50 << 20
+
+var (
+	// plainSheetNamePattern matches sheet names that need no quotes in a cell reference
+	plainSheetNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+	// cellReferencePattern splits a cell reference such as AB12 into column and row
+	cellReferencePattern = regexp.MustCompile(`^\$?([A-Za-z]{1,3})\$?([0-9]+)$`)
+
+	// formatLiteralPattern removes quoted text, escapes and bracketed sections
+	// (colors, conditions, locales) from a number format before looking for date codes
+	formatLiteralPattern = regexp.MustCompile(`"[^"]*"|\\.|\[[^\]]*\]`)
+
+	// errXLSXPartNotFound is returned for parts the workbook does not have
+	errXLSXPartNotFound = errors.New("not found")
+)
+
+// spreadsheetSheet holds the non-empty rows read from one sheet
+type spreadsheetSheet struct {
+	name string
+	rows []spreadsheetRow
+}
+
+// spreadsheetRow holds the non-empty cells of a row
+type spreadsheetRow struct {
+	number int // 1-based, as shown by spreadsheet applications
+	cells  []spreadsheetCell
+}
+
+type spreadsheetCell struct {
+	column int // 0-based; 0 is column A
+	value  string
+}
+
+// spreadsheetReader collects rows up to a limit shared by all sheets of a file
+type spreadsheetReader struct {
+	remainingRows int
+	truncated     bool
+}
+
+// addRow appends the row when it has a value and the row limit allows it.
+// It returns false once the limit is reached.
+func (r *spreadsheetReader) addRow(sheet *spreadsheetSheet, number int, cells []spreadsheetCell) bool {
+	if len(cells) == 0 {
+		return true
+	}
+	if r.remainingRows == 0 {
+		r.truncated = true
+		return false
+	}
+	r.remainingRows--
+	sheet.rows = append(sheet.rows, spreadsheetRow{number: number, cells: cells})
+	return true
+}
+
+// parseCSV reads a CSV file as a single sheet. The delimiter is a comma,
+// semicolon or tab, whichever the first line uses most.
+func parseCSV(data []byte, sheetName string, maxRows int) ([]*spreadsheetSheet, bool, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	delimiter := ','
+	for _, candidate := range []rune{';', '\t'} {
+		if bytes.Count(firstLine, []byte(string(candidate))) > bytes.Count(firstLine, []byte(string(delimiter))) {
+			delimiter = candidate
+		}
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = delimiter
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	sheet := &spreadsheetSheet{name: sheetName}
+	rows := &spreadsheetReader{remainingRows: maxRows}
+	number, endLine := 0, 0
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to read CSV: %w", err)
+		}
+
+		// Blank lines are skipped by the reader but are rows in a spreadsheet;
+		// a quoted field spanning lines stays one row
+		startLine, _ := reader.FieldPos(0)
+		number += startLine - endLine
+		lastLine, _ := reader.FieldPos(len(record) - 1)
+		endLine = lastLine + strings.Count(record[len(record)-1], "\n")
+
+		var cells []spreadsheetCell
+		for column, field := range record {
+			if value := normalizeCellValue(field); value != "" {
+				cells = append(cells, spreadsheetCell{column: column, value: value})
+			}
+		}
+		if !rows.addRow(sheet, number, cells) {
+			break
+		}
+	}
+
+	return []*spreadsheetSheet{sheet}, rows.truncated, nil
+}
+
+// XML parts of an XLSX workbook. Only what text extraction needs is decoded.
+type (
+	xlsxWorkbook struct {
+		Properties struct {
+			Date1904 bool `xml:"date1904,attr"`
+		} `xml:"workbookPr"`
+		Sheets []struct {
+			Name  string     `xml:"name,attr"`
+			Attrs []xml.Attr `xml:",any,attr"`
+		} `xml:"sheets>sheet"`
+	}
+
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+
+	xlsxSharedStrings struct {
+		Items []xlsxRichText `xml:"si"`
+	}
+
+	xlsxStyles struct {
+		NumberFormats []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellFormats []struct {
+			NumberFormatID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+
+	xlsxRow struct {
+		Number int        `xml:"r,attr"`
+		Cells  []xlsxCell `xml:"c"`
+	}
+
+	xlsxCell struct {
+		Reference string       `xml:"r,attr"`
+		Type      string       `xml:"t,attr"`
+		Style     int          `xml:"s,attr"`
+		Value     string       `xml:"v"`
+		Inline    xlsxRichText `xml:"is"`
+	}
+
+	// xlsxRichText is a string that is either plain or split into formatted runs
+	xlsxRichText struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+)
+
+func (t xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var text strings.Builder
+	for _, run := range t.Runs {
+		text.WriteString(run.Text)
+	}
+	return text.String()
+}
+
+// xlsxFile reads the parts of an XLSX workbook
+type xlsxFile struct {
+	parts         map[string]*zip.File
+	sharedStrings []string
+	dateStyles    map[int]bool
+	date1904      bool
+}
+
+// parseXLSX reads the cell values of every sheet in an XLSX workbook, in
+// workbook order. Formulas contribute their cached results.
+func parseXLSX(data []byte, maxRows int) ([]*spreadsheetSheet, bool, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to open XLSX: %w", err)
+	}
+
+	file := &xlsxFile{parts: make(map[string]*zip.File, len(archive.File))}
+	for _, part := range archive.File {
+		file.parts[part.Name] = part
+	}
+
+	var workbook xlsxWorkbook
+	if err := file.decode("xl/workbook.xml", &workbook); err != nil {
+		return nil, false, err
+	}
+	file.date1904 = workbook.Properties.Date1904
+
+	var relationships xlsxRelationships
+	if err := file.decode("xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return nil, false, err
+	}
+	targets := make(map[string]string, len(relationships.Relationships))
+	for _, relationship := range relationships.Relationships {
+		if strings.HasPrefix(relationship.Target, "/") {
+			targets[relationship.ID] = strings.TrimPrefix(relationship.Target, "/")
+		} else {
+			targets[relationship.ID] = path.Join("xl", relationship.Target)
+		}
+	}
+
+	if err := file.loadSharedStrings(); err != nil {
+		return nil, false, err
+	}
+	if err := file.loadDateStyles(); err != nil {
+		return nil, false, err
+	}
+
+	rows := &spreadsheetReader{remainingRows: maxRows}
+	sheets := make([]*spreadsheetSheet, 0, len(workbook.Sheets))
+	for _, entry := range workbook.Sheets {
+		var target string
+		for _, attr := range entry.Attrs {
+			if attr.Name.Local == "id" && strings.Contains(attr.Name.Space, "relationships") {
+				target = targets[attr.Value]
+			}
+		}
+		if target == "" {
+			return nil, false, fmt.Errorf("XLSX sheet %q has no worksheet part", entry.Name)
+		}
+
+		sheet := &spreadsheetSheet{name: entry.Name}
+		if err := file.readSheet(target, sheet, rows); err != nil {
+			return nil, false, fmt.Errorf("failed to read XLSX sheet %q: %w", entry.Name, err)
+		}
+		sheets = append(sheets, sheet)
+		if rows.truncated {
+			break
+		}
+	}
+
+	return sheets, rows.truncated, nil
+}
+
+// open opens a workbook part, bounded by maxXLSXPartSize.
+func (f *xlsxFile) open(name string) (io.ReadCloser, error) {
+	part, ok := f.parts[name]
+	if !ok {
+		return nil, fmt.Errorf("XLSX part %s: %w", name, errXLSXPartNotFound)
+	}
+	if part.UncompressedSize64 > maxXLSXPartSize {
+		return nil, fmt.Errorf("XLSX part %s is larger than %d bytes", name, maxXLSXPartSize)
+	}
+
+	reader, err := part.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XLSX part %s: %w", name, err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.LimitReader(reader, maxXLSXPartSize), reader}, nil
+}
+
+func (f *xlsxFile) decode(name string, v any) error {
+	reader, err := f.open(name)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	if err := xml.NewDecoder(reader).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse XLSX part %s: %w", name, err)
+	}
+	return nil
+}
+
+// loadSharedStrings reads the string table that text cells point into. Workbooks
+// with only numbers or inline strings have none.
+func (f *xlsxFile) loadSharedStrings() error {
+	var table xlsxSharedStrings
+	if err := f.decode("xl/sharedStrings.xml", &table); err != nil {
+		if errors.Is(err, errXLSXPartNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	f.sharedStrings = make([]string, len(table.Items))
+	for i, item := range table.Items {
+		f.sharedStrings[i] = item.String()
+	}
+	return nil
+}
+
+// loadDateStyles finds the cell styles whose number format shows a date or time.
+func (f *xlsxFile) loadDateStyles() error {
+	var styles xlsxStyles
+	if err := f.decode("xl/styles.xml", &styles); err != nil {
+		if errors.Is(err, errXLSXPartNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	customFormats := make(map[int]string, len(styles.NumberFormats))
+	for _, format := range styles.NumberFormats {
+		customFormats[format.ID] = format.Code
+	}
+
+	f.dateStyles = make(map[int]bool)
+	for i, cellFormat := range styles.CellFormats {
+		id := cellFormat.NumberFormatID
+		if code, ok := customFormats[id]; ok {
+			f.dateStyles[i] = isDateFormatCode(code)
+		} else {
+			// Built-in date and time formats
+			f.dateStyles[i] = (id >= 14 && id <= 22) || (id >= 45 && id <= 47)
+		}
+	}
+	return nil
+}
+
+// readSheet streams a worksheet's rows so that rows past the limit are never decoded.
+func (f *xlsxFile) readSheet(name string, sheet *spreadsheetSheet, rows *spreadsheetReader) error {
+	reader, err := f.open(name)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	decoder := xml.NewDecoder(reader)
+	number := 0
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+
+		var row xlsxRow
+		if err := decoder.DecodeElement(&row, &start); err != nil {
+			return err
+		}
+		number++
+		if row.Number > 0 {
+			number = row.Number
+		}
+
+		var cells []spreadsheetCell
+		column := -1
+		for _, cell := range row.Cells {
+			column++
+			if match := cellReferencePattern.FindStringSubmatch(cell.Reference); match != nil {
+				column = columnIndex(match[1])
+			}
+			if value := normalizeCellValue(f.cellValue(cell)); value != "" {
+				cells = append(cells, spreadsheetCell{column: column, value: value})
+			}
+		}
+		if !rows.addRow(sheet, number, cells) {
+			return nil
+		}
+	}
+}
+
+// cellValue formats a cell the way it reads in the sheet, apart from number formatting.
+func (f *xlsxFile) cellValue(cell xlsxCell) string {
+	switch cell.Type {
+	case "s":
+		index, err := strconv.Atoi(strings.TrimSpace(cell.Value))
+		if err != nil || index < 0 || index >= len(f.sharedStrings) {
+			return ""
+		}
+		return f.sharedStrings[index]
+	case "inlineStr":
+		return cell.Inline.String()
+	case "b":
+		if strings.TrimSpace(cell.Value) == "1" {
+			return "TRUE"
+		}
+		return "FALSE"
+	case "", "n":
+		if f.dateStyles[cell.Style] {
+			if serial, err := strconv.ParseFloat(strings.TrimSpace(cell.Value), 64); err == nil {
+				return formatSerialDate(serial, f.date1904)
+			}
+		}
+		return cell.Value
+	default:
+		// str (formula text), e (error such as #DIV/0!) and d (ISO 8601 date)
+		return cell.Value
+	}
+}
+
+// isDateFormatCode reports whether a custom number format shows a date or time.
+func isDateFormatCode(code string) bool {
+	code = strings.ToLower(formatLiteralPattern.ReplaceAllString(code, ""))
+	return strings.ContainsAny(code, "dmyhs")
+}
+
+// formatSerialDate converts a spreadsheet date serial to ISO 8601. Serials
+// below 1 are times of day.
+func formatSerialDate(serial float64, date1904 bool) string {
+	epoch := time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC)
+	if date1904 {
+		epoch = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+	}
+
+	days := int(serial)
+	seconds := int((serial-float64(days))*86400 + 0.5)
+	value := epoch.AddDate(0, 0, days).Add(time.Duration(seconds) * time.Second)
+
+	switch {
+	case days == 0 && !date1904:
+		return value.Format("15:04:05")
+	case seconds == 0:
+		return value.Format("2006-01-02")
+	default:
+		return value.Format("2006-01-02 15:04:05")
+	}
+}
+
+// columnIndex converts column letters to a 0-based index: A is 0, AA is 26.
+func columnIndex(letters string) int {
+	index := 0
+	for _, letter := range strings.ToUpper(letters) {
+		index = index*26 + int(letter-'A'+1)
+	}
+	return index - 1
+}
+
+// columnName converts a 0-based column index to its letters.
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+// sheetReference prefixes a cell range with its sheet, quoting the sheet name when needed.
+func sheetReference(sheetName, cellRange string) string {
+	if !plainSheetNamePattern.MatchString(sheetName) {
+		sheetName = "'" + strings.ReplaceAll(sheetName, "'", "''") + "'"
+	}
+	return sheetName + "!" + cellRange
+}
+
+// normalizeCellValue collapses whitespace, including line breaks inside cells.
+func normalizeCellValue(value string) string {
+	return strings.Join(strings.Fields(value), " ")
+}
+
+// renderSpreadsheet writes each sheet as text, one line per row with every
+// value labelled by its column header and cell reference. The first non-empty
+// row of a sheet is its header. Chunks hold whole rows of one sheet, start
+// with the sheet name and column headers, and carry the cell range they cover.
+func renderSpreadsheet(sheets []*spreadsheetSheet, truncated bool, chunkSize int) (string, []events.DocumentChunk) {
+	var text strings.Builder
+	var chunks []events.DocumentChunk
+
+	for _, sheet := range sheets {
+		if len(sheet.rows) == 0 {
+			continue
+		}
+
+		header := sheet.rows[0]
+		headers := make(map[int]string, len(header.cells))
+		firstColumn, lastColumn := header.cells[0].column, header.cells[0].column
+		var columns []string
+		for _, cell := range header.cells {
+			headers[cell.column] = cell.value
+			columns = append(columns, columnName(cell.column)+" "+cell.value)
+		}
+		for _, row := range sheet.rows {
+			firstColumn = min(firstColumn, row.cells[0].column)
+			lastColumn = max(lastColumn, row.cells[len(row.cells)-1].column)
+		}
+
+		preamble := "Sheet: " + sheet.name + "\nColumns: " + strings.Join(columns, " | ") + "\n"
+		if text.Len() > 0 {
+			text.WriteString("\n")
+		}
+		text.WriteString(preamble)
+
+		data := sheet.rows[1:]
+		if len(data) == 0 {
+			// A sheet holding only a header row is still searchable
+			data = sheet.rows
+		}
+
+		var chunk strings.Builder
+		chunkStart := 0
+		flush := func(end int) {
+			cellRange := fmt.Sprintf("%s%d:%s%d", columnName(firstColumn), data[chunkStart].number, columnName(lastColumn), data[end].number)
+			chunks = append(chunks, events.DocumentChunk{
+				Text:     preamble + chunk.String(),
+				Location: sheetReference(sheet.name, cellRange),
+			})
+			chunk.Reset()
+			chunkStart = end + 1
+		}
+
+		for i, row := range data {
+			line := renderSpreadsheetRow(row, headers)
+			text.WriteString(line)
+
+			if chunk.Len() > 0 && len(preamble)+chunk.Len()+len(line) > chunkSize {
+				flush(i - 1)
+			}
+			chunk.WriteString(line)
+		}
+		flush(len(data) - 1)
+	}
+
+	if truncated {
+		text.WriteString("\n(Further rows were not read: the row limit was reached.)\n")
+	}
+
+	return text.String(), chunks
+}
+
+// renderSpreadsheetRow writes a row as "Row 12: Region (A12): EMEA | Revenue (C12): 1200".
+func renderSpreadsheetRow(row spreadsheetRow, headers map[int]string) string {
+	values := make([]string, len(row.cells))
+	for i, cell := range row.cells {
+		reference := columnName(cell.column) + strconv.Itoa(row.number)
+		if label := headers[cell.column]; label != "" {
+			values[i] = fmt.Sprintf("%s (%s): %s", label, reference, cell.value)
+		} else {
+			values[i] = reference + ": " + cell.value
+		}
+	}
+	return fmt.Sprintf("Row %d: %s\n", row.number, strings.Join(values, " | "))
+}
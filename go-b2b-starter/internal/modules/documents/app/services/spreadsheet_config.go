@@ -0,0 +1,62 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// SpreadsheetConfig controls text extraction from XLSX and CSV documents.
+//
+// All values can be set via environment variables with the DOCUMENT_SPREADSHEET_ prefix.
+type SpreadsheetConfig struct {
+	// MaxRows bounds how many non-empty rows are read from a file, across all
+	// its sheets; later rows are skipped
+	MaxRows int `mapstructure:"DOCUMENT_SPREADSHEET_MAX_ROWS"`
+
+	// ChunkSize is the target number of characters per embedded chunk. Each
+	// chunk repeats the sheet's column headers and holds whole rows.
+	ChunkSize int `mapstructure:"DOCUMENT_SPREADSHEET_CHUNK_SIZE"`
+}
+
+// LoadSpreadsheetConfig loads the spreadsheet configuration from environment variables and app.env file.
+func LoadSpreadsheetConfig() (*SpreadsheetConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("DOCUMENT_SPREADSHEET_MAX_ROWS", 10000)
+	v.SetDefault("DOCUMENT_SPREADSHEET_CHUNK_SIZE", 1500)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg SpreadsheetConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode spreadsheet config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the row and chunk limits.
+func (c *SpreadsheetConfig) Validate() error {
+	if c.MaxRows <= 0 {
+		return fmt.Errorf("spreadsheet config invalid: DOCUMENT_SPREADSHEET_MAX_ROWS must be positive")
+	}
+	if c.ChunkSize <= 0 {
+		return fmt.Errorf("spreadsheet config invalid: DOCUMENT_SPREADSHEET_CHUNK_SIZE must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package services
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+)
+
+// pageSeparator splits extracted text into pages (the OCR service joins pages with a form feed)
+const pageSeparator = "\f"
+
+// paragraphBreak splits a page into passages at blank lines
+var paragraphBreak = regexp.MustCompile(`\n\s*\n`)
+
+// passage is a paragraph of extracted text with its location.
+type passage struct {
+	text      string
+	key       string // text with whitespace collapsed, so OCR spacing noise doesn't count as a change
+	page      int
+	paragraph int
+}
+
+// splitPassages breaks extracted text into passages anchored to pages.
+func splitPassages(text string) []passage {
+	var passages []passage
+	for i, page := range strings.Split(text, pageSeparator) {
+		paragraph := 0
+		for _, part := range paragraphBreak.Split(page, -1) {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			paragraph++
+			passages = append(passages, passage{
+				text:      part,
+				key:       strings.Join(strings.Fields(part), " "),
+				page:      i + 1,
+				paragraph: paragraph,
+			})
+		}
+	}
+	return passages
+}
+
+// diffPassages returns the passages removed from `from` and added in `to`, in
+// document order, plus how many are unchanged. ok is false when the changed
+// region exceeds maxPassages on either side.
+//
+// Common leading and trailing passages are skipped first; the rest is
+// matched with a longest-common-subsequence table.
+func diffPassages(from, to []passage, maxPassages int) (changes []domain.TextChange, unchanged int, ok bool) {
+	prefix := 0
+	for prefix < len(from) && prefix < len(to) && from[prefix].key == to[prefix].key {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(from)-prefix && suffix < len(to)-prefix &&
+		from[len(from)-1-suffix].key == to[len(to)-1-suffix].key {
+		suffix++
+	}
+
+	a := from[prefix : len(from)-suffix]
+	b := to[prefix : len(to)-suffix]
+	if len(a) > maxPassages || len(b) > maxPassages {
+		return nil, 0, false
+	}
+
+	// lcs[i][j] is the longest common subsequence of a[i:] and b[j:]
+	width := len(b) + 1
+	lcs := make([]int32, (len(a)+1)*width)
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i].key == b[j].key {
+				lcs[i*width+j] = lcs[(i+1)*width+j+1] + 1
+			} else {
+				lcs[i*width+j] = max(lcs[(i+1)*width+j], lcs[i*width+j+1])
+			}
+		}
+	}
+
+	unchanged = prefix + suffix
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i].key == b[j].key:
+			unchanged++
+			i++
+			j++
+		case j == len(b) || (i < len(a) && lcs[(i+1)*width+j] >= lcs[i*width+j+1]):
+			changes = append(changes, textChange(domain.TextChangeRemoved, a[i]))
+			i++
+		default:
+			changes = append(changes, textChange(domain.TextChangeAdded, b[j]))
+			j++
+		}
+	}
+	return changes, unchanged, true
+}
+
+func textChange(op string, p passage) domain.TextChange {
+	return domain.TextChange{
+		Op:        op,
+		Text:      p.text,
+		Page:      p.page,
+		Paragraph: p.paragraph,
+	}
+}
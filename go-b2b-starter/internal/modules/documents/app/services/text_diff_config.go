@@ -0,0 +1,62 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// TextDiffConfig controls diffs of extracted text between document versions.
+//
+// All values can be set via environment variables with the DOCUMENT_DIFF_ prefix.
+type TextDiffConfig struct {
+	// CacheTTL is how long a computed diff is kept in Redis; 0 disables caching
+	CacheTTL time.Duration `mapstructure:"DOCUMENT_DIFF_CACHE_TTL"`
+
+	// MaxPassages bounds the changed region (in passages per version) a diff
+	// may compare, which bounds its memory to about MaxPassages² × 4 bytes
+	MaxPassages int `mapstructure:"DOCUMENT_DIFF_MAX_PASSAGES"`
+}
+
+// LoadTextDiffConfig loads the text diff configuration from environment variables and app.env file.
+func LoadTextDiffConfig() (*TextDiffConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("DOCUMENT_DIFF_CACHE_TTL", "24h")
+	v.SetDefault("DOCUMENT_DIFF_MAX_PASSAGES", 2000)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg TextDiffConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode text diff config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the cache TTL and passage limit.
+func (c *TextDiffConfig) Validate() error {
+	if c.CacheTTL < 0 {
+		return fmt.Errorf("text diff config invalid: DOCUMENT_DIFF_CACHE_TTL must not be negative")
+	}
+	if c.MaxPassages <= 0 {
+		return fmt.Errorf("text diff config invalid: DOCUMENT_DIFF_MAX_PASSAGES must be positive")
+	}
+	return nil
+}
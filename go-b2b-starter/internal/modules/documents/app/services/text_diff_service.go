@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	loggerdomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+// textDiffCacheKeyPattern caches a diff per organization, document and version pair
+const textDiffCacheKeyPattern = "documents:diff:%d:%d:%d:%d"
+
+// TextDiffService compares the extracted text of two versions of a document.
+type TextDiffService interface {
+	// DiffVersions returns the passages removed and added going from version
+	// fromVersion to toVersion. Diffs are computed on first request and cached.
+	DiffVersions(ctx context.Context, orgID, docID, fromVersion, toVersion int32) (*domain.TextDiff, error)
+}
+
+type textDiffService struct {
+	versionRepo domain.DocumentVersionRepository
+	redis       redis.Client
+	config      *TextDiffConfig
+	logger      logger.Logger
+}
+
+func NewTextDiffService(
+	versionRepo domain.DocumentVersionRepository,
+	redisClient redis.Client,
+	config *TextDiffConfig,
+	logger logger.Logger,
+) TextDiffService {
+	return &textDiffService{
+		versionRepo: versionRepo,
+		redis:       redisClient,
+		config:      config,
+		logger:      logger,
+	}
+}
+
+func (s *textDiffService) DiffVersions(ctx context.Context, orgID, docID, fromVersion, toVersion int32) (*domain.TextDiff, error) {
+	if fromVersion == toVersion {
+		return nil, domain.ErrSameDocumentVersion
+	}
+
+	// Both versions must exist and be extracted before a cached diff is served,
+	// so a deleted document's diff is never returned
+	versions, err := s.versionRepo.List(ctx, orgID, docID)
+	if err != nil {
+		return nil, err
+	}
+	for _, number := range []int32{fromVersion, toVersion} {
+		version := findVersion(versions, number)
+		if version == nil {
+			return nil, domain.ErrDocumentVersionNotFound
+		}
+		if !version.IsProcessed() {
+			return nil, domain.ErrDocumentVersionNotProcessed
+		}
+	}
+
+	cacheKey := fmt.Sprintf(textDiffCacheKeyPattern, orgID, docID, fromVersion, toVersion)
+	if diff := s.readCache(ctx, cacheKey); diff != nil {
+		return diff, nil
+	}
+
+	from, err := s.versionRepo.Get(ctx, orgID, docID, fromVersion)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.versionRepo.Get(ctx, orgID, docID, toVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, unchanged, ok := diffPassages(splitPassages(from.ExtractedText), splitPassages(to.ExtractedText), s.config.MaxPassages)
+	if !ok {
+		return nil, domain.ErrTextDiffTooLarge
+	}
+
+	diff := &domain.TextDiff{
+		DocumentID:  docID,
+		FromVersion: fromVersion,
+		ToVersion:   toVersion,
+		Unchanged:   unchanged,
+		Changes:     changes,
+		ComputedAt:  time.Now().UTC(),
+	}
+	if diff.Changes == nil {
+		diff.Changes = []domain.TextChange{}
+	}
+	for _, change := range changes {
+		if change.Op == domain.TextChangeAdded {
+			diff.Added++
+		} else {
+			diff.Removed++
+		}
+	}
+
+	s.writeCache(ctx, cacheKey, diff)
+	return diff, nil
+}
+
+func findVersion(versions []*domain.DocumentVersion, number int32) *domain.DocumentVersion {
+	for _, version := range versions {
+		if version.VersionNumber == number {
+			return version
+		}
+	}
+	return nil
+}
+
+// readCache returns a cached diff. Redis failures count as a miss.
+func (s *textDiffService) readCache(ctx context.Context, key string) *domain.TextDiff {
+	if s.config.CacheTTL <= 0 {
+		return nil
+	}
+	cached, err := s.redis.Get(ctx, key)
+	if err != nil || cached == "" {
+		return nil
+	}
+	var diff domain.TextDiff
+	if err := json.Unmarshal([]byte(cached), &diff); err != nil {
+		return nil
+	}
+	return &diff
+}
+
+func (s *textDiffService) writeCache(ctx context.Context, key string, diff *domain.TextDiff) {
+	if s.config.CacheTTL <= 0 {
+		return
+	}
+	data, err := json.Marshal(diff)
+	if err != nil {
+		return
+	}
+	if err := s.redis.Set(ctx, key, string(data), s.config.CacheTTL); err != nil {
+		s.logger.Warn("failed to cache document text diff", loggerdomain.Fields{
+			"document_id": diff.DocumentID,
+			"error":       err.Error(),
+		})
+	}
+}
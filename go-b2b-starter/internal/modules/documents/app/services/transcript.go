@@ -0,0 +1,59 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain/events"
+	transcriptiondomain "github.com/moasq/go-b2b-starter/internal/platform/transcription/domain"
+)
+
+// renderTranscript writes one line per segment, prefixed with when it was
+// spoken: "[00:01:05 - 00:01:12] Revenue was up twelve percent." Chunks hold
+// whole segments and carry the time range they cover, such as 00:01:05-00:03:00.
+// A transcript without segments is returned as plain text and no chunks.
+func renderTranscript(transcript *transcriptiondomain.TranscriptionResponse, chunkSize int, chunkDuration time.Duration) (string, []events.DocumentChunk) {
+	if len(transcript.Segments) == 0 {
+		return transcript.Text, nil
+	}
+
+	var text, chunk strings.Builder
+	var chunks []events.DocumentChunk
+	var chunkStart, chunkEnd float64
+
+	flush := func() {
+		chunks = append(chunks, events.DocumentChunk{
+			Text:     chunk.String(),
+			Location: formatTimestamp(chunkStart) + "-" + formatTimestamp(chunkEnd),
+		})
+		chunk.Reset()
+	}
+
+	for _, segment := range transcript.Segments {
+		line := fmt.Sprintf("[%s - %s] %s\n", formatTimestamp(segment.Start), formatTimestamp(segment.End), segment.Text)
+		text.WriteString(line)
+
+		if chunk.Len() > 0 {
+			tooLong := chunk.Len()+len(line) > chunkSize
+			tooSpread := time.Duration((segment.End-chunkStart)*float64(time.Second)) > chunkDuration
+			if tooLong || tooSpread {
+				flush()
+			}
+		}
+		if chunk.Len() == 0 {
+			chunkStart = segment.Start
+		}
+		chunk.WriteString(line)
+		chunkEnd = segment.End
+	}
+	flush()
+
+	return text.String(), chunks
+}
+
+// formatTimestamp formats an offset in seconds as HH:MM:SS.
+func formatTimestamp(seconds float64) string {
+	total := int(max(seconds, 0))
+	return fmt.Sprintf("%02d:%02d:%02d", total/3600, total/60%60, total%60)
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// TranscriptConfig controls how transcripts of audio and video documents are
+// split into chunks for embedding.
+//
+// All values can be set via environment variables with the DOCUMENT_TRANSCRIPT_ prefix.
+type TranscriptConfig struct {
+	// ChunkSize is the target number of characters per embedded chunk. Chunks
+	// hold whole segments.
+	ChunkSize int `mapstructure:"DOCUMENT_TRANSCRIPT_CHUNK_SIZE"`
+
+	// ChunkDuration bounds the stretch of the recording a chunk covers, so
+	// citations point close to where something was said
+	ChunkDuration time.Duration `mapstructure:"DOCUMENT_TRANSCRIPT_CHUNK_DURATION"`
+}
+
+// LoadTranscriptConfig loads the transcript configuration from environment variables and app.env file.
+func LoadTranscriptConfig() (*TranscriptConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("DOCUMENT_TRANSCRIPT_CHUNK_SIZE", 1500)
+	v.SetDefault("DOCUMENT_TRANSCRIPT_CHUNK_DURATION", "2m")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg TranscriptConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode transcript config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the chunk limits.
+func (c *TranscriptConfig) Validate() error {
+	if c.ChunkSize <= 0 {
+		return fmt.Errorf("transcript config invalid: DOCUMENT_TRANSCRIPT_CHUNK_SIZE must be positive")
+	}
+	if c.ChunkDuration <= 0 {
+		return fmt.Errorf("transcript config invalid: DOCUMENT_TRANSCRIPT_CHUNK_DURATION must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+	"github.com/moasq/go-b2b-starter/pkg/httperr"
+)
+
+// GetDocumentClassification returns the type and named entities of a document
+// @Summary Get document classification
+// @Description Returns the document type, confidence and extracted entities set by the classification stage or a manual override. needs_review is set when the model's confidence is below DOCUMENT_CLASSIFICATION_MIN_CONFIDENCE.
+// @Tags Documents
+// @Produce json
+// @Param id path int true "Document ID"
+// @Success 200 {object} domain.DocumentClassification
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 404 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_documents/{id}/classification [get]
+func (h *Handler) GetDocumentClassification(c *gin.Context) {
+	docID, reqCtx, ok := classificationRequest(c)
+	if !ok {
+		return
+	}
+
+	classification, err := h.classificationService.GetClassification(c.Request.Context(), reqCtx.OrganizationID, docID)
+	if err != nil {
+		writeClassificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, classification)
+}
+
+// OverrideDocumentClassification sets the type and entities of a document by hand
+// @Summary Override document classification
+// @Description Sets the document type and, when given, the entities. Overrides have confidence 1 and are kept when the document is reprocessed; reclassify to replace them.
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Param id path int true "Document ID"
+// @Param request body services.OverrideClassificationRequest true "Classification"
+// @Success 200 {object} domain.DocumentClassification
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 404 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_documents/{id}/classification [put]
+func (h *Handler) OverrideDocumentClassification(c *gin.Context) {
+	docID, reqCtx, ok := classificationRequest(c)
+	if !ok {
+		return
+	}
+
+	var req services.OverrideClassificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			"Invalid request body: "+err.Error(),
+		))
+		return
+	}
+
+	classification, err := h.classificationService.OverrideClassification(c.Request.Context(), reqCtx.OrganizationID, docID, reqCtx.AccountID, &req)
+	if err != nil {
+		writeClassificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, classification)
+}
+
+// ReclassifyDocument runs the classification stage again
+// @Summary Reclassify document
+// @Description Classifies the document's current text again and extracts its entities, replacing a manual override.
+// @Tags Documents
+// @Produce json
+// @Param id path int true "Document ID"
+// @Success 200 {object} domain.DocumentClassification
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 403 {object} httperr.HTTPError
+// @Failure 404 {object} httperr.HTTPError
+// @Failure 409 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_documents/{id}/classify [post]
+func (h *Handler) ReclassifyDocument(c *gin.Context) {
+	docID, reqCtx, ok := classificationRequest(c)
+	if !ok {
+		return
+	}
+
+	classification, err := h.classificationService.Reclassify(c.Request.Context(), reqCtx.OrganizationID, docID)
+	if err != nil {
+		writeClassificationError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, classification)
+}
+
+// classificationRequest reads the document ID and organization context, writing the error response when either is missing.
+func classificationRequest(c *gin.Context) (int32, *auth.RequestContext, bool) {
+	idParam := c.Param("id")
+	var docID int32
+	if _, err := fmt.Sscanf(idParam, "%d", &docID); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_id",
+			"Document ID must be a valid number",
+		))
+		return 0, nil, false
+	}
+
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return 0, nil, false
+	}
+
+	return docID, reqCtx, true
+}
+
+func writeClassificationError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, domain.ErrInvalidDocumentType), errors.Is(err, domain.ErrInvalidEntityType), errors.Is(err, domain.ErrInvalidEntityValue):
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(http.StatusBadRequest, "invalid_classification", err.Error()))
+	case errors.Is(err, domain.ErrClassificationDisabled):
+		c.JSON(http.StatusForbidden, httperr.NewHTTPError(http.StatusForbidden, "classification_disabled", err.Error()))
+	case errors.Is(err, domain.ErrDocumentNotFound), errors.Is(err, domain.ErrDocumentClassificationNotFound):
+		c.JSON(http.StatusNotFound, httperr.NewHTTPError(http.StatusNotFound, "not_found", err.Error()))
+	case errors.Is(err, domain.ErrDocumentNotProcessed):
+		c.JSON(http.StatusConflict, httperr.NewHTTPError(http.StatusConflict, "not_processed", err.Error()))
+	default:
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"classification_failed",
+			"Failed to classify document: "+err.Error(),
+		))
+	}
+}
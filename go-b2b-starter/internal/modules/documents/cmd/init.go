@@ -1,12 +1,44 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+
 	"go.uber.org/dig"
 
 	"github.com/moasq/go-b2b-starter/internal/modules/documents"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/app/services"
+	docEvents "github.com/moasq/go-b2b-starter/internal/modules/documents/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
 )
 
 func Init(container *dig.Container) error {
 	module := documents.NewModule(container)
-	return module.RegisterDependencies()
+	if err := module.RegisterDependencies(); err != nil {
+		return err
+	}
+
+	// Classify documents once their text is extracted
+	if err := container.Invoke(func(
+		bus eventbus.EventBus,
+		config *services.ClassificationConfig,
+		classifier services.ClassificationService,
+	) error {
+		if !config.Enabled {
+			return nil
+		}
+
+		return bus.Subscribe(docEvents.DocumentUploadedEventType, func(ctx context.Context, event eventbus.Event) error {
+			docEvent, ok := event.(*docEvents.DocumentUploaded)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+
+			return classifier.HandleDocumentUploaded(ctx, docEvent.OrganizationID, docEvent.DocumentID, docEvent.Title, docEvent.ExtractedText)
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to wire document classification listener: %w", err)
+	}
+
+	return module.StartScheduler()
 }
@@ -26,8 +26,10 @@ type Document struct {
 	ExtractedText  string                 `json:"extracted_text,omitempty"`
 	Status         DocumentStatus         `json:"status"`
 	Metadata       map[string]interface{} `json:"metadata,omitempty"`
-	CreatedAt      time.Time              `json:"created_at"`
-	UpdatedAt      time.Time              `json:"updated_at"`
+	// UploadedBy is the account of the member who uploaded the document, nil if unknown
+	UploadedBy *int32    `json:"uploaded_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
 }
 
 func (d *Document) GetID() int32 {
@@ -63,6 +65,97 @@ func (d *Document) HasText() bool {
 	return d.ExtractedText != ""
 }
 
+// DocumentVersion is one uploaded revision of a document.
+// Version 1 is the original upload; the document always shows the latest.
+type DocumentVersion struct {
+	ID             int32          `json:"id"`
+	DocumentID     int32          `json:"document_id"`
+	OrganizationID int32          `json:"organization_id"`
+	VersionNumber  int32          `json:"version_number"`
+	FileAssetID    int32          `json:"file_asset_id"`
+	FileName       string         `json:"file_name"`
+	ContentType    string         `json:"content_type"`
+	FileSize       int64          `json:"file_size"`
+	ExtractedText  string         `json:"extracted_text,omitempty"`
+	Status         DocumentStatus `json:"status"`
+	// UploadedBy is the account of the member who uploaded the version, nil if unknown
+	UploadedBy *int32    `json:"uploaded_by,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (v *DocumentVersion) IsProcessed() bool {
+	return v.Status == DocumentStatusProcessed
+}
+
+// Text diff operations
+const (
+	TextChangeAdded   = "added"
+	TextChangeRemoved = "removed"
+)
+
+// TextChange is a passage added in or removed from the newer version.
+// Page and Paragraph locate it (both 1-based) in the version it belongs to:
+// the older one for removals, the newer one for additions.
+type TextChange struct {
+	Op        string `json:"op"`
+	Text      string `json:"text"`
+	Page      int    `json:"page"`
+	Paragraph int    `json:"paragraph"`
+}
+
+// TextDiff compares the extracted text of two versions of a document passage by passage.
+type TextDiff struct {
+	DocumentID  int32        `json:"document_id"`
+	FromVersion int32        `json:"from_version"`
+	ToVersion   int32        `json:"to_version"`
+	Added       int          `json:"added"`
+	Removed     int          `json:"removed"`
+	Unchanged   int          `json:"unchanged"`
+	Changes     []TextChange `json:"changes"`
+	ComputedAt  time.Time    `json:"computed_at"`
+}
+
+// Classification sources
+const (
+	ClassificationSourceModel  = "model"
+	ClassificationSourceManual = "manual"
+)
+
+// DocumentTypeOther is the catch-all document type, part of every taxonomy
+const DocumentTypeOther = "other"
+
+// Entity is a named entity found in a document's text, such as a party,
+// date or amount. Confidence is from 0 to 1; manually set entities have 1.
+type Entity struct {
+	Type       string  `json:"type"`
+	Value      string  `json:"value"`
+	Confidence float64 `json:"confidence"`
+}
+
+// DocumentClassification is a document's type and named entities, set by the
+// classification stage after text extraction or overridden manually.
+// A manual override is kept when the document is reprocessed.
+type DocumentClassification struct {
+	DocumentID     int32    `json:"document_id"`
+	OrganizationID int32    `json:"organization_id"`
+	DocumentType   string   `json:"document_type"`
+	Confidence     float64  `json:"confidence"`
+	Entities       []Entity `json:"entities"`
+	Source         string   `json:"source"`
+	Model          string   `json:"model,omitempty"`
+	OverriddenBy   *int32   `json:"overridden_by,omitempty"`
+
+	// NeedsReview is set when the model's confidence is below the configured minimum
+	NeedsReview bool `json:"needs_review"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (c *DocumentClassification) IsOverridden() bool {
+	return c.Source == ClassificationSourceManual
+}
+
 // DocumentUploadRequest represents a request to upload a new document
 type DocumentUploadRequest struct {
 	OrganizationID int32                  `json:"organization_id"`
@@ -80,8 +173,42 @@ type DocumentFilter struct {
 
 // DocumentStats represents document statistics
 type DocumentStats struct {
-	TotalCount     int64 `json:"total_count"`
-	PendingCount   int64 `json:"pending_count"`
-	ProcessedCount int64 `json:"processed_count"`
-	FailedCount    int64 `json:"failed_count"`
+	TotalCount      int64 `json:"total_count"`
+	PendingCount    int64 `json:"pending_count"`
+	ProcessingCount int64 `json:"processing_count"`
+	ProcessedCount  int64 `json:"processed_count"`
+	FailedCount     int64 `json:"failed_count"`
+	StorageBytes    int64 `json:"storage_bytes"`
+	ChunkCount      int64 `json:"chunk_count"`
+}
+
+// DocumentCounters are an organization's maintained document totals. The
+// database updates them with every document, version and embedding write,
+// and a scheduled job recounts them to correct drift.
+type DocumentCounters struct {
+	OrganizationID  int32      `json:"organization_id"`
+	DocumentCount   int64      `json:"document_count"`
+	PendingCount    int64      `json:"pending_count"`
+	ProcessingCount int64      `json:"processing_count"`
+	ProcessedCount  int64      `json:"processed_count"`
+	FailedCount     int64      `json:"failed_count"`
+	StorageBytes    int64      `json:"storage_bytes"`
+	ChunkCount      int64      `json:"chunk_count"`
+	ReconciledAt    *time.Time `json:"reconciled_at,omitempty"`
+}
+
+// CountByStatus returns the number of documents with the status
+func (c *DocumentCounters) CountByStatus(status DocumentStatus) int64 {
+	switch status {
+	case DocumentStatusPending:
+		return c.PendingCount
+	case DocumentStatusProcessing:
+		return c.ProcessingCount
+	case DocumentStatusProcessed:
+		return c.ProcessedCount
+	case DocumentStatusFailed:
+		return c.FailedCount
+	default:
+		return 0
+	}
 }
@@ -19,8 +19,29 @@ var (
 	ErrTextExtractionFailed     = errors.New("text extraction from document failed")
 
 	// File errors
-	ErrInvalidFileType     = errors.New("invalid file type: only PDF files are allowed")
+	ErrInvalidFileType     = errors.New("invalid file type: only PDF, XLSX, CSV, audio and video files are allowed")
 	ErrFileTooLarge        = errors.New("file size exceeds maximum allowed limit")
 	ErrFileUploadFailed    = errors.New("failed to upload file")
 	ErrFileDownloadFailed  = errors.New("failed to download file")
+
+	// Version errors
+	ErrDocumentVersionNotFound     = errors.New("document version not found")
+	ErrDocumentVersionNotProcessed = errors.New("document version text has not been extracted yet")
+	ErrSameDocumentVersion         = errors.New("cannot compare a document version with itself")
+	ErrTextDiffTooLarge            = errors.New("document versions are too large to compare")
+
+	// Classification errors
+	ErrClassificationDisabled         = errors.New("document classification is disabled")
+	ErrDocumentClassificationNotFound = errors.New("document has not been classified")
+	ErrDocumentNotProcessed           = errors.New("document text has not been extracted yet")
+	ErrInvalidDocumentType            = errors.New("document type is not in the taxonomy")
+	ErrInvalidEntityType              = errors.New("entity type is not configured")
+	ErrInvalidEntityValue             = errors.New("entity value is required")
+	ErrClassificationFailed           = errors.New("document classification failed")
+
+	// Preview embed errors
+	ErrPreviewEmbedDisabled    = errors.New("preview embedding is disabled")
+	ErrPreviewOriginNotAllowed = errors.New("origin is not allowed to embed previews")
+	ErrInvalidPreviewToken     = errors.New("invalid preview token")
+	ErrPreviewTokenExpired     = errors.New("preview token has expired")
 )
@@ -21,6 +21,21 @@ type DocumentUploaded struct {
 	FileAssetID    int32  `json:"file_asset_id"`
 	Title          string `json:"title"`
 	ExtractedText  string `json:"extracted_text"`
+
+	// AccountID is the member who uploaded the document or version. It is 0
+	// when the document was processed again or uploaded by an import.
+	AccountID int32 `json:"account_id,omitempty"`
+
+	// Chunks splits the text at source boundaries, such as spreadsheet rows or
+	// transcript segments.
+	// Empty when the text is embedded as a whole.
+	Chunks []DocumentChunk `json:"chunks,omitempty"`
+}
+
+// DocumentChunk is a piece of extracted text and where it came from in the file
+type DocumentChunk struct {
+	Text     string `json:"text"`
+	Location string `json:"location,omitempty"` // e.g. Sales!A2:D40 or 00:01:05-00:03:00
 }
 
 func NewDocumentUploaded(documentID, organizationID, fileAssetID int32, title, extractedText string) *DocumentUploaded {
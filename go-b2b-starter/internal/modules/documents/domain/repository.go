@@ -28,6 +28,9 @@ type DocumentRepository interface {
 	// Update updates document metadata
 	Update(ctx context.Context, doc *Document) (*Document, error)
 
+	// UpdateFile points the document at a new version's file and resets it to pending
+	UpdateFile(ctx context.Context, doc *Document) (*Document, error)
+
 	// Delete removes a document
 	Delete(ctx context.Context, orgID, docID int32) error
 
@@ -37,3 +40,44 @@ type DocumentRepository interface {
 	// CountByStatus returns the count of documents with a specific status
 	CountByStatus(ctx context.Context, orgID int32, status DocumentStatus) (int64, error)
 }
+
+// DocumentVersionRepository defines the interface for document version data operations
+type DocumentVersionRepository interface {
+	// Create adds the next version of a document
+	Create(ctx context.Context, version *DocumentVersion) (*DocumentVersion, error)
+
+	// Get retrieves one version, including its extracted text
+	Get(ctx context.Context, orgID, docID, versionNumber int32) (*DocumentVersion, error)
+
+	// List retrieves all versions of a document, newest first, without their text
+	List(ctx context.Context, orgID, docID int32) ([]*DocumentVersion, error)
+
+	// UpdateText stores the extracted text and status of the version holding the file
+	UpdateText(ctx context.Context, docID, fileAssetID int32, text string, status DocumentStatus) error
+}
+
+// DocumentClassificationRepository defines the interface for document classification data operations
+type DocumentClassificationRepository interface {
+	// Get retrieves a document's classification
+	Get(ctx context.Context, orgID, docID int32) (*DocumentClassification, error)
+
+	// SaveModelResult stores a classification made by the model. A manual
+	// override is kept unless replaceManual is set; the result reports
+	// whether the classification was stored.
+	SaveModelResult(ctx context.Context, classification *DocumentClassification, replaceManual bool) (bool, error)
+
+	// Override stores a manual classification
+	Override(ctx context.Context, classification *DocumentClassification) (*DocumentClassification, error)
+}
+
+// DocumentCounterRepository defines the interface for the maintained per-organization document counters
+type DocumentCounterRepository interface {
+	// Get retrieves an organization's counters; all zero if it has none yet
+	Get(ctx context.Context, orgID int32) (*DocumentCounters, error)
+
+	// ListOrganizationIDs retrieves up to limit organization IDs greater than afterID, in order
+	ListOrganizationIDs(ctx context.Context, afterID, limit int32) ([]int32, error)
+
+	// Reconcile recounts an organization's counters and reports whether they had drifted
+	Reconcile(ctx context.Context, orgID int32) (bool, error)
+}
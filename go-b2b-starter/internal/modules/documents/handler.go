@@ -14,20 +14,33 @@ import (
 )
 
 type Handler struct {
-	service services.DocumentService
+	service               services.DocumentService
+	embedService          services.PreviewEmbedService
+	diffService           services.TextDiffService
+	classificationService services.ClassificationService
 }
 
-func NewHandler(service services.DocumentService) *Handler {
-	return &Handler{service: service}
+func NewHandler(
+	service services.DocumentService,
+	embedService services.PreviewEmbedService,
+	diffService services.TextDiffService,
+	classificationService services.ClassificationService,
+) *Handler {
+	return &Handler{
+		service:               service,
+		embedService:          embedService,
+		diffService:           diffService,
+		classificationService: classificationService,
+	}
 }
 
-// UploadDocument uploads a new PDF document
-// @Summary Upload PDF document
-// @Description Uploads a PDF document, extracts text, and creates embeddings
+// UploadDocument uploads a new PDF, spreadsheet or recording
+// @Summary Upload document
+// @Description Uploads a PDF, XLSX or CSV document or an audio/video recording, extracts or transcribes its text, and creates embeddings. Spreadsheets are embedded in chunks of rows that carry their cell range; recordings in chunks of timestamped segments that carry their time range.
 // @Tags Documents
 // @Accept multipart/form-data
 // @Produce json
-// @Param file formData file true "PDF file to upload"
+// @Param file formData file true "PDF, XLSX, CSV, audio or video file to upload"
 // @Param title formData string true "Document title"
 // @Success 201 {object} domain.Document
 // @Failure 400 {object} httperr.HTTPError
@@ -68,6 +81,7 @@ func (h *Handler) UploadDocument(c *gin.Context) {
 		FileName:    header.Filename,
 		ContentType: header.Header.Get("Content-Type"),
 		FileSize:    header.Size,
+		UploadedBy:  reqCtx.AccountID,
 	}
 
 	// Upload document
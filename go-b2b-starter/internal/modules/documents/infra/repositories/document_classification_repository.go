@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+)
+
+// documentClassificationRepository implements domain.DocumentClassificationRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type documentClassificationRepository struct {
+	store sqlc.Store
+}
+
+// NewDocumentClassificationRepository creates a new DocumentClassificationRepository implementation.
+func NewDocumentClassificationRepository(store sqlc.Store) domain.DocumentClassificationRepository {
+	return &documentClassificationRepository{store: store}
+}
+
+func (r *documentClassificationRepository) Get(ctx context.Context, orgID, docID int32) (*domain.DocumentClassification, error) {
+	params := sqlc.GetDocumentClassificationParams{
+		DocumentID:     docID,
+		OrganizationID: orgID,
+	}
+
+	result, err := r.store.GetDocumentClassification(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDocumentClassificationNotFound
+		}
+		return nil, fmt.Errorf("failed to get document classification: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *documentClassificationRepository) SaveModelResult(ctx context.Context, classification *domain.DocumentClassification, replaceManual bool) (bool, error) {
+	entities, err := marshalEntities(classification.Entities)
+	if err != nil {
+		return false, err
+	}
+
+	params := sqlc.UpsertModelDocumentClassificationParams{
+		DocumentID:     classification.DocumentID,
+		OrganizationID: classification.OrganizationID,
+		DocumentType:   classification.DocumentType,
+		Confidence:     classification.Confidence,
+		Entities:       entities,
+		Model:          helpers.ToPgText(classification.Model),
+		ReplaceManual:  replaceManual,
+	}
+
+	stored, err := r.store.UpsertModelDocumentClassification(ctx, params)
+	if err != nil {
+		return false, fmt.Errorf("failed to save document classification: %w", err)
+	}
+
+	return stored > 0, nil
+}
+
+func (r *documentClassificationRepository) Override(ctx context.Context, classification *domain.DocumentClassification) (*domain.DocumentClassification, error) {
+	entities, err := marshalEntities(classification.Entities)
+	if err != nil {
+		return nil, err
+	}
+
+	params := sqlc.OverrideDocumentClassificationParams{
+		DocumentID:     classification.DocumentID,
+		OrganizationID: classification.OrganizationID,
+		DocumentType:   classification.DocumentType,
+		Entities:       entities,
+		OverriddenBy:   helpers.ToPgInt4Ptr(classification.OverriddenBy),
+	}
+
+	result, err := r.store.OverrideDocumentClassification(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to override document classification: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func marshalEntities(entities []domain.Entity) ([]byte, error) {
+	if entities == nil {
+		entities = []domain.Entity{}
+	}
+	data, err := json.Marshal(entities)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal entities: %w", err)
+	}
+	return data, nil
+}
+
+// mapToDomain converts SQLC document classification type to domain type.
+// This is the translation boundary - SQLC types never escape this function.
+func (r *documentClassificationRepository) mapToDomain(classification *sqlc.DocumentsDocumentClassification) (*domain.DocumentClassification, error) {
+	entities := []domain.Entity{}
+	if len(classification.Entities) > 0 {
+		if err := json.Unmarshal(classification.Entities, &entities); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal entities: %w", err)
+		}
+	}
+
+	return &domain.DocumentClassification{
+		DocumentID:     classification.DocumentID,
+		OrganizationID: classification.OrganizationID,
+		DocumentType:   classification.DocumentType,
+		Confidence:     classification.Confidence,
+		Entities:       entities,
+		Source:         classification.Source,
+		Model:          helpers.FromPgText(classification.Model),
+		OverriddenBy:   int4Ptr(classification.OverriddenBy),
+		CreatedAt:      classification.CreatedAt.Time,
+		UpdatedAt:      classification.UpdatedAt.Time,
+	}, nil
+}
+
+func int4Ptr(i pgtype.Int4) *int32 {
+	if !i.Valid {
+		return nil
+	}
+	value := i.Int32
+	return &value
+}
@@ -0,0 +1,76 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+)
+
+// documentCounterRepository implements domain.DocumentCounterRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type documentCounterRepository struct {
+	store sqlc.Store
+}
+
+// NewDocumentCounterRepository creates a new DocumentCounterRepository implementation.
+func NewDocumentCounterRepository(store sqlc.Store) domain.DocumentCounterRepository {
+	return &documentCounterRepository{store: store}
+}
+
+func (r *documentCounterRepository) Get(ctx context.Context, orgID int32) (*domain.DocumentCounters, error) {
+	result, err := r.store.GetDocumentCounters(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			// Created by the first counted write or reconciliation
+			return &domain.DocumentCounters{OrganizationID: orgID}, nil
+		}
+		return nil, fmt.Errorf("failed to get document counters: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *documentCounterRepository) ListOrganizationIDs(ctx context.Context, afterID, limit int32) ([]int32, error) {
+	params := sqlc.ListCountedOrganizationIDsParams{
+		AfterID:  afterID,
+		RowLimit: limit,
+	}
+
+	ids, err := r.store.ListCountedOrganizationIDs(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	return ids, nil
+}
+
+func (r *documentCounterRepository) Reconcile(ctx context.Context, orgID int32) (bool, error) {
+	drifted, err := r.store.ReconcileDocumentCounters(ctx, orgID)
+	if err != nil {
+		return false, fmt.Errorf("failed to reconcile document counters: %w", err)
+	}
+
+	return drifted, nil
+}
+
+// mapToDomain maps SQLC type to domain type.
+// This is the translation boundary - SQLC types never escape this function.
+func (r *documentCounterRepository) mapToDomain(c *sqlc.DocumentsDocumentCounter) *domain.DocumentCounters {
+	counters := &domain.DocumentCounters{
+		OrganizationID:  c.OrganizationID,
+		DocumentCount:   c.DocumentCount,
+		PendingCount:    c.PendingCount,
+		ProcessingCount: c.ProcessingCount,
+		ProcessedCount:  c.ProcessedCount,
+		FailedCount:     c.FailedCount,
+		StorageBytes:    c.StorageBytes,
+		ChunkCount:      c.ChunkCount,
+	}
+	if c.ReconciledAt.Valid {
+		counters.ReconciledAt = &c.ReconciledAt.Time
+	}
+	return counters
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/moasq/go-b2b-starter/internal/db/helpers"
@@ -31,6 +32,7 @@ func (r *documentRepository) Create(ctx context.Context, doc *domain.Document) (
 		ExtractedText:  helpers.ToPgText(doc.ExtractedText),
 		Status:         string(doc.Status),
 		Metadata:       helpers.ToJSONB(doc.Metadata),
+		UploadedBy:     helpers.ToPgInt4Ptr(doc.UploadedBy),
 	}
 
 	result, err := r.store.CreateDocument(ctx, params)
@@ -49,6 +51,9 @@ func (r *documentRepository) GetByID(ctx context.Context, orgID, docID int32) (*
 
 	result, err := r.store.GetDocumentByID(ctx, params)
 	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDocumentNotFound
+		}
 		return nil, fmt.Errorf("failed to get document: %w", err)
 	}
 
@@ -156,6 +161,24 @@ func (r *documentRepository) Update(ctx context.Context, doc *domain.Document) (
 	return r.mapToDomain(&result), nil
 }
 
+func (r *documentRepository) UpdateFile(ctx context.Context, doc *domain.Document) (*domain.Document, error) {
+	params := sqlc.UpdateDocumentFileParams{
+		ID:             doc.ID,
+		OrganizationID: doc.OrganizationID,
+		FileAssetID:    doc.FileAssetID,
+		FileName:       doc.FileName,
+		ContentType:    doc.ContentType,
+		FileSize:       doc.FileSize,
+	}
+
+	result, err := r.store.UpdateDocumentFile(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update document file: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
 func (r *documentRepository) Delete(ctx context.Context, orgID, docID int32) error {
 	params := sqlc.DeleteDocumentParams{
 		ID:             docID,
@@ -206,6 +229,7 @@ func (r *documentRepository) mapToDomain(doc *sqlc.DocumentsDocument) *domain.Do
 		ExtractedText:  helpers.FromPgText(doc.ExtractedText),
 		Status:         domain.DocumentStatus(doc.Status),
 		Metadata:       helpers.FromJSONB(doc.Metadata),
+		UploadedBy:     helpers.FromPgInt4Ptr(doc.UploadedBy),
 		CreatedAt:      doc.CreatedAt.Time,
 		UpdatedAt:      doc.UpdatedAt.Time,
 	}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+)
+
+// documentVersionRepository implements domain.DocumentVersionRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type documentVersionRepository struct {
+	store sqlc.Store
+}
+
+// NewDocumentVersionRepository creates a new DocumentVersionRepository implementation.
+func NewDocumentVersionRepository(store sqlc.Store) domain.DocumentVersionRepository {
+	return &documentVersionRepository{store: store}
+}
+
+func (r *documentVersionRepository) Create(ctx context.Context, version *domain.DocumentVersion) (*domain.DocumentVersion, error) {
+	params := sqlc.CreateDocumentVersionParams{
+		DocumentID:     version.DocumentID,
+		OrganizationID: version.OrganizationID,
+		FileAssetID:    version.FileAssetID,
+		FileName:       version.FileName,
+		ContentType:    version.ContentType,
+		FileSize:       version.FileSize,
+		Status:         string(version.Status),
+		UploadedBy:     helpers.ToPgInt4Ptr(version.UploadedBy),
+	}
+
+	result, err := r.store.CreateDocumentVersion(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create document version: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *documentVersionRepository) Get(ctx context.Context, orgID, docID, versionNumber int32) (*domain.DocumentVersion, error) {
+	params := sqlc.GetDocumentVersionParams{
+		DocumentID:     docID,
+		OrganizationID: orgID,
+		VersionNumber:  versionNumber,
+	}
+
+	result, err := r.store.GetDocumentVersion(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDocumentVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to get document version: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *documentVersionRepository) List(ctx context.Context, orgID, docID int32) ([]*domain.DocumentVersion, error) {
+	params := sqlc.ListDocumentVersionsParams{
+		DocumentID:     docID,
+		OrganizationID: orgID,
+	}
+
+	results, err := r.store.ListDocumentVersions(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document versions: %w", err)
+	}
+
+	versions := make([]*domain.DocumentVersion, len(results))
+	for i, result := range results {
+		versions[i] = &domain.DocumentVersion{
+			ID:             result.ID,
+			DocumentID:     result.DocumentID,
+			OrganizationID: result.OrganizationID,
+			VersionNumber:  result.VersionNumber,
+			FileAssetID:    result.FileAssetID,
+			FileName:       result.FileName,
+			ContentType:    result.ContentType,
+			FileSize:       result.FileSize,
+			Status:         domain.DocumentStatus(result.Status),
+			CreatedAt:      result.CreatedAt.Time,
+		}
+	}
+
+	return versions, nil
+}
+
+func (r *documentVersionRepository) UpdateText(ctx context.Context, docID, fileAssetID int32, text string, status domain.DocumentStatus) error {
+	params := sqlc.UpdateDocumentVersionTextParams{
+		DocumentID:    docID,
+		FileAssetID:   fileAssetID,
+		ExtractedText: helpers.ToPgText(text),
+		Status:        string(status),
+	}
+
+	if err := r.store.UpdateDocumentVersionText(ctx, params); err != nil {
+		return fmt.Errorf("failed to update document version text: %w", err)
+	}
+
+	return nil
+}
+
+// mapToDomain converts SQLC document version type to domain type.
+// This is the translation boundary - SQLC types never escape this function.
+func (r *documentVersionRepository) mapToDomain(version *sqlc.DocumentsDocumentVersion) *domain.DocumentVersion {
+	return &domain.DocumentVersion{
+		ID:             version.ID,
+		DocumentID:     version.DocumentID,
+		OrganizationID: version.OrganizationID,
+		VersionNumber:  version.VersionNumber,
+		FileAssetID:    version.FileAssetID,
+		FileName:       version.FileName,
+		ContentType:    version.ContentType,
+		FileSize:       version.FileSize,
+		ExtractedText:  helpers.FromPgText(version.ExtractedText),
+		Status:         domain.DocumentStatus(version.Status),
+		UploadedBy:     helpers.FromPgInt4Ptr(version.UploadedBy),
+		CreatedAt:      version.CreatedAt.Time,
+	}
+}
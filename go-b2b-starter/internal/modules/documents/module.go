@@ -1,14 +1,20 @@
 package documents
 
 import (
+	"context"
+
 	"go.uber.org/dig"
 
 	"github.com/moasq/go-b2b-starter/internal/modules/documents/app/services"
 	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
 	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
 	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	llmdomain "github.com/moasq/go-b2b-starter/internal/platform/llm/domain"
 	"github.com/moasq/go-b2b-starter/internal/platform/logger"
 	ocrdomain "github.com/moasq/go-b2b-starter/internal/platform/ocr/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+	transcriptiondomain "github.com/moasq/go-b2b-starter/internal/platform/transcription/domain"
 )
 
 // Module provides documents module dependencies
@@ -25,18 +31,117 @@ func NewModule(container *dig.Container) *Module {
 // RegisterDependencies registers all documents module dependencies
 // Note: Repository implementations are registered in internal/db/inject.go
 func (m *Module) RegisterDependencies() error {
+	// Register spreadsheet text extraction
+	if err := m.container.Provide(services.LoadSpreadsheetConfig); err != nil {
+		return err
+	}
+
+	// Register transcript chunking for audio and video documents
+	if err := m.container.Provide(services.LoadTranscriptConfig); err != nil {
+		return err
+	}
+
 	// Register document service
 	if err := m.container.Provide(func(
 		docRepo domain.DocumentRepository,
+		versionRepo domain.DocumentVersionRepository,
+		counterRepo domain.DocumentCounterRepository,
 		fileService filedomain.FileService,
 		ocrService ocrdomain.OCRService,
+		transcriptionService transcriptiondomain.TranscriptionService,
 		eventBus eventbus.EventBus,
+		jobs jobsDomain.Tracker,
+		spreadsheetConfig *services.SpreadsheetConfig,
+		transcriptConfig *services.TranscriptConfig,
 		logger logger.Logger,
 	) services.DocumentService {
-		return services.NewDocumentService(docRepo, fileService, ocrService, eventBus, logger)
+		return services.NewDocumentService(docRepo, versionRepo, counterRepo, fileService, ocrService, transcriptionService, eventBus, jobs, spreadsheetConfig, transcriptConfig, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register reconciliation of the maintained document counters
+	if err := m.container.Provide(services.LoadCounterConfig); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		counterRepo domain.DocumentCounterRepository,
+		config *services.CounterConfig,
+		jobs jobsDomain.Tracker,
+		logger logger.Logger,
+	) services.CounterReconcileService {
+		return services.NewCounterReconcileService(counterRepo, config, jobs, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register text diffs between document versions
+	if err := m.container.Provide(services.LoadTextDiffConfig); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		versionRepo domain.DocumentVersionRepository,
+		redisClient redis.Client,
+		config *services.TextDiffConfig,
+		logger logger.Logger,
+	) services.TextDiffService {
+		return services.NewTextDiffService(versionRepo, redisClient, config, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register classification and entity extraction after text extraction
+	if err := m.container.Provide(services.LoadClassificationConfig); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		docRepo domain.DocumentRepository,
+		classificationRepo domain.DocumentClassificationRepository,
+		llmService llmdomain.LLMService,
+		config *services.ClassificationConfig,
+		logger logger.Logger,
+	) services.ClassificationService {
+		return services.NewClassificationService(docRepo, classificationRepo, llmService, config, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register preview embedding for customer iframes
+	if err := m.container.Provide(services.LoadPreviewEmbedConfig); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		docRepo domain.DocumentRepository,
+		fileService filedomain.FileService,
+		config *services.PreviewEmbedConfig,
+		logger logger.Logger,
+	) services.PreviewEmbedService {
+		return services.NewPreviewEmbedService(docRepo, fileService, config, logger)
 	}); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// StartScheduler starts the background reconciliation of the document
+// counters unless DOCUMENT_COUNTERS_RECONCILE_INTERVAL is zero.
+func (m *Module) StartScheduler() error {
+	var enabled bool
+	if err := m.container.Invoke(func(cfg *services.CounterConfig) {
+		enabled = cfg.ReconcileInterval > 0
+	}); err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	return m.container.Invoke(func(service services.CounterReconcileService) {
+		go service.Run(context.Background())
+	})
+}
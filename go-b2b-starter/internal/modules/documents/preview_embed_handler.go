@@ -0,0 +1,168 @@
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+	"github.com/moasq/go-b2b-starter/pkg/httperr"
+)
+
+// previewCookieName holds the preview token after the first framed request,
+// so the viewer's follow-up requests need no token in the URL
+const previewCookieName = "document_preview"
+
+// CreatePreviewEmbed issues a token for embedding a document preview
+// @Summary Create document preview embed
+// @Description Issues a short-lived signed token that lets one allowed origin frame this document's preview. Use preview_url as the iframe src.
+// @Tags Documents
+// @Accept json
+// @Produce json
+// @Param id path int true "Document ID"
+// @Param request body services.IssueEmbedTokenRequest true "Embedding origin"
+// @Success 201 {object} services.EmbedToken
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 403 {object} httperr.HTTPError
+// @Failure 404 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_documents/{id}/embed [post]
+func (h *Handler) CreatePreviewEmbed(c *gin.Context) {
+	idParam := c.Param("id")
+	var docID int32
+	if _, err := fmt.Sscanf(idParam, "%d", &docID); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_id",
+			"Document ID must be a valid number",
+		))
+		return
+	}
+
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	var req services.IssueEmbedTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_request",
+			"Invalid request payload: "+err.Error(),
+		))
+		return
+	}
+
+	token, err := h.embedService.IssueEmbedToken(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, docID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPreviewEmbedDisabled), errors.Is(err, domain.ErrPreviewOriginNotAllowed):
+			c.JSON(http.StatusForbidden, httperr.NewHTTPError(http.StatusForbidden, "embed_not_allowed", err.Error()))
+		case errors.Is(err, domain.ErrDocumentNotFound):
+			c.JSON(http.StatusNotFound, httperr.NewHTTPError(http.StatusNotFound, "not_found", err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+				http.StatusInternalServerError,
+				"embed_failed",
+				"Failed to create preview embed: "+err.Error(),
+			))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// ServeEmbeddedPreview serves a document preview inside a customer iframe
+// @Summary Serve embedded document preview
+// @Description Public endpoint for iframes. Authenticated by the token query parameter, which is exchanged for a cookie scoped to this document. Only the origin the token was issued for may frame the response.
+// @Tags Documents
+// @Produce application/pdf
+// @Param id path int true "Document ID"
+// @Param token query string false "Preview token (or the document_preview cookie)"
+// @Success 200 {file} file
+// @Failure 401 {object} httperr.HTTPError
+// @Failure 403 {object} httperr.HTTPError
+// @Failure 404 {object} httperr.HTTPError
+// @Router /embed/documents/{id}/preview [get]
+func (h *Handler) ServeEmbeddedPreview(c *gin.Context) {
+	idParam := c.Param("id")
+	var docID int32
+	if _, err := fmt.Sscanf(idParam, "%d", &docID); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_id",
+			"Document ID must be a valid number",
+		))
+		return
+	}
+
+	token := c.Query("token")
+	fromQuery := token != ""
+	if !fromQuery {
+		token, _ = c.Cookie(previewCookieName)
+	}
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, httperr.NewHTTPError(http.StatusUnauthorized, "missing_token", "Preview token is required"))
+		return
+	}
+
+	preview, err := h.embedService.OpenPreview(c.Request.Context(), docID, token, c.GetHeader("Sec-Fetch-Dest"))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrPreviewEmbedDisabled), errors.Is(err, domain.ErrDocumentNotFound):
+			c.JSON(http.StatusNotFound, httperr.NewHTTPError(http.StatusNotFound, "not_found", "Preview not found"))
+		case errors.Is(err, domain.ErrInvalidPreviewToken), errors.Is(err, domain.ErrPreviewTokenExpired):
+			c.JSON(http.StatusUnauthorized, httperr.NewHTTPError(http.StatusUnauthorized, "invalid_token", err.Error()))
+		case errors.Is(err, domain.ErrPreviewOriginNotAllowed):
+			c.JSON(http.StatusForbidden, httperr.NewHTTPError(http.StatusForbidden, "embed_not_allowed", err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+				http.StatusInternalServerError,
+				"preview_failed",
+				"Failed to load preview",
+			))
+		}
+		return
+	}
+	defer preview.Content.Close()
+
+	if fromQuery {
+		// Partitioned (CHIPS) so third-party cookie blocking still lets the
+		// frame keep it; the path limits it to this document
+		http.SetCookie(c.Writer, &http.Cookie{
+			Name:        previewCookieName,
+			Value:       token,
+			Path:        fmt.Sprintf(services.PreviewPath, docID),
+			MaxAge:      max(int(time.Until(preview.ExpiresAt).Seconds()), 1),
+			HttpOnly:    true,
+			Secure:      true,
+			SameSite:    http.SameSiteNoneMode,
+			Partitioned: true,
+		})
+	}
+
+	// Replace the global anti-framing headers with the token's origin
+	c.Header("X-Frame-Options", "")
+	c.Header("Content-Security-Policy", "frame-ancestors "+preview.Origin)
+	c.Header("Cross-Origin-Resource-Policy", "cross-origin")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("Cache-Control", "private, no-store")
+
+	doc := preview.Document
+	c.DataFromReader(http.StatusOK, doc.FileSize, doc.ContentType, preview.Content, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("inline", map[string]string{"filename": doc.FileName}),
+	})
+}
@@ -3,7 +3,6 @@ package documents
 import (
 	"github.com/gin-gonic/gin"
 
-	"github.com/moasq/go-b2b-starter/internal/modules/auth"
 	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
 )
 
@@ -27,19 +26,51 @@ func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.M
 	{
 		// Upload document
 		docsGroup.POST("/upload",
-			auth.RequirePermissionFunc("resource", "create"),
+			resolver.Get("perm:resource:create"),
 			r.handler.UploadDocument)
 
 		// List documents
 		docsGroup.GET("",
-			auth.RequirePermissionFunc("resource", "view"),
+			resolver.Get("perm:resource:view"),
 			r.handler.ListDocuments)
 
 		// Delete document
 		docsGroup.DELETE("/:id",
-			auth.RequirePermissionFunc("resource", "delete"),
+			resolver.Get("perm:resource:delete"),
 			r.handler.DeleteDocument)
+
+		// Upload and list versions
+		docsGroup.POST("/:id/versions",
+			resolver.Get("perm:resource:edit"),
+			r.handler.UploadDocumentVersion)
+		docsGroup.GET("/:id/versions",
+			resolver.Get("perm:resource:view"),
+			r.handler.ListDocumentVersions)
+
+		// Compare the text of two versions
+		docsGroup.GET("/:id/diff",
+			resolver.Get("perm:resource:view"),
+			r.handler.DiffDocumentVersions)
+
+		// Document type and named entities
+		docsGroup.GET("/:id/classification",
+			resolver.Get("perm:resource:view"),
+			r.handler.GetDocumentClassification)
+		docsGroup.PUT("/:id/classification",
+			resolver.Get("perm:resource:edit"),
+			r.handler.OverrideDocumentClassification)
+		docsGroup.POST("/:id/classify",
+			resolver.Get("perm:resource:edit"),
+			r.handler.ReclassifyDocument)
+
+		// Issue a token for embedding the preview in a customer iframe
+		docsGroup.POST("/:id/embed",
+			resolver.Get("perm:resource:view"),
+			r.handler.CreatePreviewEmbed)
 	}
+
+	// Embedded preview - no auth, authenticated by the preview token or cookie
+	router.GET("/embed/documents/:id/preview", r.handler.ServeEmbeddedPreview)
 }
 
 // Routes returns a RouteRegistrar function compatible with the server interface
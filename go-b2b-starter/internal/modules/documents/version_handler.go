@@ -0,0 +1,210 @@
+package documents
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+	"github.com/moasq/go-b2b-starter/pkg/httperr"
+)
+
+// UploadDocumentVersion uploads a new revision of a document
+// @Summary Upload document version
+// @Description Uploads a new revision of a PDF, XLSX, CSV, audio or video document. The document shows the new version and its text is extracted again; earlier versions are kept for comparison.
+// @Tags Documents
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Document ID"
+// @Param file formData file true "PDF, XLSX, CSV, audio or video file of the new version"
+// @Success 201 {object} domain.DocumentVersion
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 404 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_documents/{id}/versions [post]
+func (h *Handler) UploadDocumentVersion(c *gin.Context) {
+	idParam := c.Param("id")
+	var docID int32
+	if _, err := fmt.Sscanf(idParam, "%d", &docID); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_id",
+			"Document ID must be a valid number",
+		))
+		return
+	}
+
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_file",
+			"Failed to read file: "+err.Error(),
+		))
+		return
+	}
+	defer file.Close()
+
+	req := &services.UploadDocumentRequest{
+		FileName:    header.Filename,
+		ContentType: header.Header.Get("Content-Type"),
+		FileSize:    header.Size,
+		UploadedBy:  reqCtx.AccountID,
+	}
+
+	version, err := h.service.UploadDocumentVersion(c.Request.Context(), reqCtx.OrganizationID, docID, req, file)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidFileType):
+			c.JSON(http.StatusBadRequest, httperr.NewHTTPError(http.StatusBadRequest, "invalid_file", err.Error()))
+		case errors.Is(err, domain.ErrDocumentNotFound):
+			c.JSON(http.StatusNotFound, httperr.NewHTTPError(http.StatusNotFound, "not_found", err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+				http.StatusInternalServerError,
+				"upload_failed",
+				"Failed to upload document version: "+err.Error(),
+			))
+		}
+		return
+	}
+
+	c.JSON(http.StatusCreated, version)
+}
+
+// ListDocumentVersions lists the versions of a document
+// @Summary List document versions
+// @Description Lists the uploaded versions of a document, newest first. Extracted text is not included.
+// @Tags Documents
+// @Produce json
+// @Param id path int true "Document ID"
+// @Success 200 {array} domain.DocumentVersion
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 404 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_documents/{id}/versions [get]
+func (h *Handler) ListDocumentVersions(c *gin.Context) {
+	idParam := c.Param("id")
+	var docID int32
+	if _, err := fmt.Sscanf(idParam, "%d", &docID); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_id",
+			"Document ID must be a valid number",
+		))
+		return
+	}
+
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	versions, err := h.service.ListDocumentVersions(c.Request.Context(), reqCtx.OrganizationID, docID)
+	if err != nil {
+		if errors.Is(err, domain.ErrDocumentNotFound) {
+			c.JSON(http.StatusNotFound, httperr.NewHTTPError(http.StatusNotFound, "not_found", err.Error()))
+			return
+		}
+		c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+			http.StatusInternalServerError,
+			"list_failed",
+			"Failed to list document versions: "+err.Error(),
+		))
+		return
+	}
+
+	c.JSON(http.StatusOK, versions)
+}
+
+// DiffDocumentVersions compares the text of two document versions
+// @Summary Diff document versions
+// @Description Returns the passages removed and added between two versions of a document, each anchored to its page and paragraph. Passages are paragraphs of the extracted text; whitespace differences are ignored. Diffs are computed on first request and cached.
+// @Tags Documents
+// @Produce json
+// @Param id path int true "Document ID"
+// @Param from query int true "Older version number"
+// @Param to query int true "Newer version number"
+// @Success 200 {object} domain.TextDiff
+// @Failure 400 {object} httperr.HTTPError
+// @Failure 404 {object} httperr.HTTPError
+// @Failure 409 {object} httperr.HTTPError
+// @Failure 422 {object} httperr.HTTPError
+// @Failure 500 {object} httperr.HTTPError
+// @Router /example_documents/{id}/diff [get]
+func (h *Handler) DiffDocumentVersions(c *gin.Context) {
+	idParam := c.Param("id")
+	var docID int32
+	if _, err := fmt.Sscanf(idParam, "%d", &docID); err != nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_id",
+			"Document ID must be a valid number",
+		))
+		return
+	}
+
+	from, fromErr := strconv.ParseInt(c.Query("from"), 10, 32)
+	to, toErr := strconv.ParseInt(c.Query("to"), 10, 32)
+	if fromErr != nil || toErr != nil || from < 1 || to < 1 {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"invalid_versions",
+			"from and to must be version numbers",
+		))
+		return
+	}
+
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		c.JSON(http.StatusBadRequest, httperr.NewHTTPError(
+			http.StatusBadRequest,
+			"missing_context",
+			"Organization context is required",
+		))
+		return
+	}
+
+	diff, err := h.diffService.DiffVersions(c.Request.Context(), reqCtx.OrganizationID, docID, int32(from), int32(to))
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrSameDocumentVersion):
+			c.JSON(http.StatusBadRequest, httperr.NewHTTPError(http.StatusBadRequest, "invalid_versions", err.Error()))
+		case errors.Is(err, domain.ErrDocumentVersionNotFound):
+			c.JSON(http.StatusNotFound, httperr.NewHTTPError(http.StatusNotFound, "not_found", err.Error()))
+		case errors.Is(err, domain.ErrDocumentVersionNotProcessed):
+			c.JSON(http.StatusConflict, httperr.NewHTTPError(http.StatusConflict, "not_processed", err.Error()))
+		case errors.Is(err, domain.ErrTextDiffTooLarge):
+			c.JSON(http.StatusUnprocessableEntity, httperr.NewHTTPError(http.StatusUnprocessableEntity, "diff_too_large", err.Error()))
+		default:
+			c.JSON(http.StatusInternalServerError, httperr.NewHTTPError(
+				http.StatusInternalServerError,
+				"diff_failed",
+				"Failed to diff document versions: "+err.Error(),
+			))
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, diff)
+}
@@ -10,16 +10,20 @@ type FileCategory string
 const (
 	CategoryDocument FileCategory = "document"
 	CategoryImage    FileCategory = "image"
+	CategoryMedia    FileCategory = "media"
 	CategoryArchive  FileCategory = "archive"
 )
 
 // Supported file types
-// SECURITY: Restricted to invoice-safe formats only (PDF and common image formats)
-// Removed: Office documents (.doc, .docx, .xls, .xlsx), text files (.txt, .csv),
+// SECURITY: Restricted to invoice-safe formats only (PDF, spreadsheets, common image formats
+// and the audio/video formats accepted for transcription)
+// Spreadsheets are read as data only; macro-enabled and legacy formats stay excluded.
+// Removed: Office documents (.doc, .docx, .xls, .xlsm), text files (.txt),
 //          archives (.zip, .rar, etc.), and risky image formats (.svg, .gif)
 var (
-	DocumentTypes = []string{".pdf"}
+	DocumentTypes = []string{".pdf", ".xlsx", ".csv"}
 	ImageTypes    = []string{".jpg", ".jpeg", ".png"}
+	MediaTypes    = []string{".flac", ".m4a", ".mp3", ".mp4", ".mpeg", ".mpga", ".oga", ".ogg", ".wav", ".webm"}
 	ArchiveTypes  = []string{} // Archives disabled for security
 )
 
@@ -35,14 +39,17 @@ const (
 	ContextGeneral            FileContext = "general"
 	ContextPaymentInstruction FileContext = "payment_instruction"
 	ContextPaymentBatch       FileContext = "payment_batch"
+	ContextSupport            FileContext = "support"
+	ContextDataExport         FileContext = "data_export"
 )
 
 // File size limits (in bytes)
 // SECURITY: Strict limits for invoice processing to minimize attack surface
 const (
-	MaxDocumentSize = 2 * 1024 * 1024 // 2MB - sufficient for most invoice PDFs
-	MaxImageSize    = 1 * 1024 * 1024 // 1MB - sufficient for scanned invoices
-	MaxArchiveSize  = 0               // Archives disabled
+	MaxDocumentSize = 2 * 1024 * 1024  // 2MB - sufficient for most invoice PDFs
+	MaxImageSize    = 1 * 1024 * 1024  // 1MB - sufficient for scanned invoices
+	MaxMediaSize    = 25 * 1024 * 1024 // 25MB - the largest recording the transcription API accepts
+	MaxArchiveSize  = 0                // Archives disabled
 )
 
 // GetFileCategory determines the category based on file extension
@@ -61,6 +68,12 @@ func GetFileCategory(filename string) FileCategory {
 		}
 	}
 	
+	for _, mediaType := range MediaTypes {
+		if ext == mediaType {
+			return CategoryMedia
+		}
+	}
+	
 	for _, archType := range ArchiveTypes {
 		if ext == archType {
 			return CategoryArchive
@@ -75,6 +88,8 @@ func GetMaxFileSize(category FileCategory) int64 {
 	switch category {
 	case CategoryImage:
 		return MaxImageSize
+	case CategoryMedia:
+		return MaxMediaSize
 	case CategoryArchive:
 		return MaxArchiveSize
 	default:
@@ -87,6 +102,7 @@ func IsAllowedFileType(filename string) bool {
 	ext := strings.ToLower(getFileExtension(filename))
 	
 	allTypes := append(DocumentTypes, ImageTypes...)
+	allTypes = append(allTypes, MediaTypes...)
 	allTypes = append(allTypes, ArchiveTypes...)
 	
 	for _, allowedType := range allTypes {
@@ -12,6 +12,10 @@ import (
 
 type FileService interface {
 	UploadFile(ctx context.Context, req *FileUploadRequest, content io.Reader) (*FileAsset, error)
+	// StoreArchive stores a ZIP archive the application generated itself, such
+	// as a data export. Archives are never accepted from uploads, so this skips
+	// the upload checks and must not be given user-provided content.
+	StoreArchive(ctx context.Context, req *FileUploadRequest, content io.Reader) (*FileAsset, error)
 	DownloadFile(ctx context.Context, id int32) (io.ReadCloser, *FileAsset, error)
 	GetFile(ctx context.Context, id int32) (*FileAsset, error)
 	DeleteFile(ctx context.Context, id int32) error
@@ -91,6 +95,35 @@ func (s *fileService) UploadFile(ctx context.Context, req *FileUploadRequest, co
 	return fileAsset, nil
 }
 
+func (s *fileService) StoreArchive(ctx context.Context, req *FileUploadRequest, content io.Reader) (*FileAsset, error) {
+	fileData, err := io.ReadAll(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive content: %w", err)
+	}
+	if int64(len(fileData)) != req.Size {
+		return nil, fmt.Errorf("file size mismatch: declared %d bytes, actual %d bytes",
+			req.Size, len(fileData))
+	}
+
+	fileAsset := &FileAsset{
+		Filename:         SanitizeFilename(req.Filename),
+		OriginalFilename: req.Filename,
+		Size:             req.Size,
+		ContentType:      req.ContentType,
+		Category:         files.CategoryArchive,
+		Context:          req.Context,
+		Metadata:         req.Metadata,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if err := s.repo.Upload(ctx, fileAsset, bytes.NewReader(fileData)); err != nil {
+		return nil, fmt.Errorf("failed to upload archive: %w", err)
+	}
+
+	return fileAsset, nil
+}
+
 func (s *fileService) DownloadFile(ctx context.Context, id int32) (io.ReadCloser, *FileAsset, error) {
 	content, fileAsset, err := s.repo.Download(ctx, id)
 	if err != nil {
@@ -46,9 +46,30 @@ func getAllowedMIMETypes(ext string) ([]string, bool) {
 		".pdf": {
 			"application/pdf",
 		},
+		".xlsx": {
+			"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+		},
+		// CSV has no magic bytes; short or single-column files are detected as plain text
+		".csv": {
+			"text/csv",
+			"text/tab-separated-values",
+			"text/plain; charset=utf-8",
+		},
 		".png": {
 			"image/png",
 		},
+		// Audio and video for transcription; containers are detected by their
+		// audio or video flavour depending on the tracks they hold
+		".flac": {"audio/flac"},
+		".m4a":  {"audio/x-m4a", "audio/mp4", "video/mp4"},
+		".mp3":  {"audio/mpeg"},
+		".mp4":  {"video/mp4", "audio/mp4", "audio/x-m4a"},
+		".mpeg": {"video/mpeg", "audio/mpeg"},
+		".mpga": {"audio/mpeg"},
+		".oga":  {"audio/ogg", "application/ogg"},
+		".ogg":  {"audio/ogg", "application/ogg", "video/ogg"},
+		".wav":  {"audio/wav"},
+		".webm": {"video/webm", "audio/webm"},
 		".jpg": {
 			"image/jpeg",
 		},
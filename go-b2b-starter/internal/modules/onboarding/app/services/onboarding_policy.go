@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// OnboardingPolicy controls the onboarding checklist.
+//
+// All values can be set via environment variables with the ONBOARDING_ prefix.
+type OnboardingPolicy struct {
+	// DisabledSteps lists comma-separated step keys left out of the checklist,
+	// e.g. "connect_billing" for applications without billing
+	DisabledSteps string `mapstructure:"ONBOARDING_DISABLED_STEPS"`
+
+	// DisabledStepSet is the parsed form of DisabledSteps
+	DisabledStepSet map[string]bool `mapstructure:"-"`
+}
+
+// LoadOnboardingPolicy loads the onboarding policy from environment variables and app.env file.
+func LoadOnboardingPolicy() (*OnboardingPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("ONBOARDING_DISABLED_STEPS", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy OnboardingPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode onboarding policy: %w", err)
+	}
+
+	policy.DisabledStepSet = make(map[string]bool)
+	for _, key := range strings.Split(policy.DisabledSteps, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			policy.DisabledStepSet[key] = true
+		}
+	}
+
+	return &policy, nil
+}
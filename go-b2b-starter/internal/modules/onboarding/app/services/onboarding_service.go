@@ -0,0 +1,432 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding/domain/events"
+	orgDomain "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// OnboardingService tracks the onboarding checklist of each organization.
+//
+// Steps complete in one of three ways: a Check that detects completion when
+// progress is read, application code or event listeners calling CompleteStep,
+// or members completing Manual steps through the API. Each completion
+// publishes an onboarding.step_completed event, and the last step done
+// publishes onboarding.completed.
+type OnboardingService interface {
+	// RegisterStep adds an application-specific step to the end of the
+	// checklist. Call it during startup, before serving requests.
+	RegisterStep(step domain.Step) error
+
+	// GetProgress returns the checklist of orgID, completing steps whose
+	// Check now passes
+	GetProgress(ctx context.Context, orgID int32) (*domain.Progress, error)
+
+	// CompleteStep completes a step on behalf of application code or an event
+	// listener. accountID is nil when no member completed it. Completing a
+	// step twice or completing a disabled step does nothing.
+	CompleteStep(ctx context.Context, orgID int32, stepKey string, accountID *int32) error
+
+	// CompleteManualStep completes a Manual step on behalf of a member
+	CompleteManualStep(ctx context.Context, orgID, accountID int32, stepKey string) (*domain.Progress, error)
+
+	// SkipStep skips a pending step that isn't Required on behalf of a member
+	SkipStep(ctx context.Context, orgID, accountID int32, stepKey string) (*domain.Progress, error)
+}
+
+type onboardingService struct {
+	stateRepo domain.StepStateRepository
+	eventBus  eventbus.EventBus
+	policy    *OnboardingPolicy
+	logger    loggerDomain.Logger
+
+	// mu guards the registered steps
+	mu         sync.RWMutex
+	steps      []domain.Step
+	stepsByKey map[string]domain.Step
+}
+
+func NewOnboardingService(
+	stateRepo domain.StepStateRepository,
+	accountRepo orgDomain.AccountRepository,
+	inviteRepo orgDomain.InviteRepository,
+	subscriptions paywall.SubscriptionStatusProvider,
+	eventBus eventbus.EventBus,
+	policy *OnboardingPolicy,
+	logger loggerDomain.Logger,
+) (OnboardingService, error) {
+	s := &onboardingService{
+		stateRepo:  stateRepo,
+		eventBus:   eventBus,
+		policy:     policy,
+		logger:     logger.Named("onboarding"),
+		stepsByKey: make(map[string]domain.Step),
+	}
+
+	for _, step := range builtinSteps(accountRepo, inviteRepo, subscriptions) {
+		if err := s.RegisterStep(step); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// builtinSteps returns the steps every application starts with
+func builtinSteps(
+	accountRepo orgDomain.AccountRepository,
+	inviteRepo orgDomain.InviteRepository,
+	subscriptions paywall.SubscriptionStatusProvider,
+) []domain.Step {
+	return []domain.Step{
+		{
+			Key:         domain.StepVerifyEmail,
+			Title:       "Verify your email",
+			Description: "Confirm your email address to secure your account.",
+			Required:    true,
+			Check: func(ctx context.Context, orgID int32) (bool, error) {
+				accounts, err := accountRepo.ListByOrganization(ctx, orgID)
+				if err != nil {
+					return false, err
+				}
+				for _, account := range accounts {
+					if account.StytchEmailVerified {
+						return true, nil
+					}
+				}
+				return false, nil
+			},
+		},
+		{
+			Key:         domain.StepInviteTeammate,
+			Title:       "Invite a teammate",
+			Description: "Invite a colleague to join your organization.",
+			Check: func(ctx context.Context, orgID int32) (bool, error) {
+				invites, err := inviteRepo.ListByOrganization(ctx, orgID, 1, 0)
+				if err != nil {
+					return false, err
+				}
+				if len(invites) > 0 {
+					return true, nil
+				}
+
+				accounts, err := accountRepo.ListByOrganization(ctx, orgID)
+				if err != nil {
+					return false, err
+				}
+				return len(accounts) > 1, nil
+			},
+		},
+		{
+			// Completed by the document.uploaded listener
+			Key:         domain.StepUploadFirstDocument,
+			Title:       "Upload your first document",
+			Description: "Upload a document to see it processed and searchable.",
+		},
+		{
+			Key:         domain.StepConnectBilling,
+			Title:       "Connect billing",
+			Description: "Choose a plan and add a payment method.",
+			Check: func(ctx context.Context, orgID int32) (bool, error) {
+				status, err := subscriptions.GetSubscriptionStatus(ctx, orgID)
+				if err != nil {
+					if paywall.IsPaymentRequiredError(err) {
+						return false, nil
+					}
+					return false, err
+				}
+				return status.Status != paywall.StatusNone, nil
+			},
+		},
+	}
+}
+
+func (s *onboardingService) RegisterStep(step domain.Step) error {
+	step.Key = strings.TrimSpace(step.Key)
+	if step.Key == "" || strings.TrimSpace(step.Title) == "" {
+		return domain.ErrStepInvalid
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.stepsByKey[step.Key]; ok {
+		return fmt.Errorf("%w: %s", domain.ErrStepDuplicated, step.Key)
+	}
+	s.steps = append(s.steps, step)
+	s.stepsByKey[step.Key] = step
+	return nil
+}
+
+func (s *onboardingService) GetProgress(ctx context.Context, orgID int32) (*domain.Progress, error) {
+	states, err := s.loadStates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	wasComplete := s.buildProgress(orgID, states).Complete
+	changed := false
+	for _, step := range s.enabledSteps() {
+		if step.Check == nil {
+			continue
+		}
+		if state, ok := states[step.Key]; ok && state.Status == domain.StepStatusCompleted {
+			continue
+		}
+
+		done, err := step.Check(ctx, orgID)
+		if err != nil {
+			// A failing check leaves the step as it is rather than failing the checklist
+			s.logger.Warn("onboarding step check failed", loggerDomain.Fields{
+				"organization_id": orgID,
+				"step":            step.Key,
+				"error":           err.Error(),
+			})
+			continue
+		}
+		if !done {
+			continue
+		}
+
+		completed, err := s.complete(ctx, orgID, step.Key, nil)
+		if err != nil {
+			return nil, err
+		}
+		changed = changed || completed
+	}
+
+	if !changed {
+		return s.buildProgress(orgID, states), nil
+	}
+	return s.progressAfterChange(ctx, orgID, wasComplete)
+}
+
+func (s *onboardingService) CompleteStep(ctx context.Context, orgID int32, stepKey string, accountID *int32) error {
+	if s.policy.DisabledStepSet[stepKey] {
+		return nil
+	}
+	if _, err := s.lookup(stepKey); err != nil {
+		return err
+	}
+
+	states, err := s.loadStates(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	if state, ok := states[stepKey]; ok && state.Status == domain.StepStatusCompleted {
+		return nil
+	}
+	wasComplete := s.buildProgress(orgID, states).Complete
+
+	completed, err := s.complete(ctx, orgID, stepKey, accountID)
+	if err != nil {
+		return err
+	}
+	if completed {
+		_, err = s.progressAfterChange(ctx, orgID, wasComplete)
+	}
+	return err
+}
+
+func (s *onboardingService) CompleteManualStep(ctx context.Context, orgID, accountID int32, stepKey string) (*domain.Progress, error) {
+	step, err := s.lookup(stepKey)
+	if err != nil {
+		return nil, err
+	}
+	if !step.Manual {
+		return nil, domain.ErrStepNotManual
+	}
+
+	states, err := s.loadStates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if state, ok := states[stepKey]; ok && state.Status == domain.StepStatusCompleted {
+		return s.buildProgress(orgID, states), nil
+	}
+	wasComplete := s.buildProgress(orgID, states).Complete
+
+	completed, err := s.complete(ctx, orgID, stepKey, &accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !completed {
+		// Completed concurrently
+		return s.GetProgress(ctx, orgID)
+	}
+	return s.progressAfterChange(ctx, orgID, wasComplete)
+}
+
+func (s *onboardingService) SkipStep(ctx context.Context, orgID, accountID int32, stepKey string) (*domain.Progress, error) {
+	step, err := s.lookup(stepKey)
+	if err != nil {
+		return nil, err
+	}
+	if step.Required {
+		return nil, domain.ErrStepRequired
+	}
+
+	_, skipped, err := s.stateRepo.Skip(ctx, orgID, stepKey, &accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !skipped {
+		states, err := s.loadStates(ctx, orgID)
+		if err != nil {
+			return nil, err
+		}
+		if state, ok := states[stepKey]; ok && state.Status == domain.StepStatusCompleted {
+			return nil, domain.ErrStepAlreadyCompleted
+		}
+		// Already skipped
+		return s.buildProgress(orgID, states), nil
+	}
+
+	s.logger.Info("onboarding step skipped", loggerDomain.Fields{
+		"organization_id": orgID,
+		"account_id":      accountID,
+		"step":            stepKey,
+	})
+	// Only pending steps can be skipped, so the checklist wasn't complete before
+	return s.progressAfterChange(ctx, orgID, false)
+}
+
+// complete moves a step to completed and publishes onboarding.step_completed.
+// It reports false when the step already was completed.
+func (s *onboardingService) complete(ctx context.Context, orgID int32, stepKey string, accountID *int32) (bool, error) {
+	_, completed, err := s.stateRepo.Complete(ctx, orgID, stepKey, accountID)
+	if err != nil || !completed {
+		return false, err
+	}
+
+	var completedBy int32
+	if accountID != nil {
+		completedBy = *accountID
+	}
+	s.logger.Info("onboarding step completed", loggerDomain.Fields{
+		"organization_id": orgID,
+		"account_id":      completedBy,
+		"step":            stepKey,
+	})
+	s.publish(ctx, events.NewOnboardingStepCompleted(orgID, stepKey, completedBy))
+	return true, nil
+}
+
+// progressAfterChange reloads the checklist after a step changed state and
+// publishes onboarding.completed when the change left no step pending.
+// wasComplete is whether the checklist was complete before the change, as a
+// skipped step that gets completed later doesn't complete onboarding again.
+func (s *onboardingService) progressAfterChange(ctx context.Context, orgID int32, wasComplete bool) (*domain.Progress, error) {
+	states, err := s.loadStates(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := s.buildProgress(orgID, states)
+	if progress.Complete && !wasComplete {
+		s.logger.Info("onboarding completed", loggerDomain.Fields{"organization_id": orgID})
+		s.publish(ctx, events.NewOnboardingCompleted(orgID, progress.CompletedCount, progress.SkippedCount))
+	}
+	return progress, nil
+}
+
+// publish publishes an onboarding event. The state change is already stored,
+// so delivery problems are logged instead of failing the request.
+func (s *onboardingService) publish(ctx context.Context, event eventbus.Event) {
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Warn("failed to publish onboarding event", loggerDomain.Fields{
+			"event_type": event.EventName(),
+			"error":      err.Error(),
+		})
+	}
+}
+
+// buildProgress combines the enabled steps with the stored states of orgID
+func (s *onboardingService) buildProgress(orgID int32, states map[string]*domain.StepState) *domain.Progress {
+	steps := s.enabledSteps()
+	progress := &domain.Progress{
+		OrganizationID: orgID,
+		Steps:          make([]*domain.StepProgress, 0, len(steps)),
+		TotalCount:     len(steps),
+	}
+
+	for _, step := range steps {
+		item := &domain.StepProgress{
+			Key:         step.Key,
+			Title:       step.Title,
+			Description: step.Description,
+			Required:    step.Required,
+			Manual:      step.Manual,
+			Status:      domain.StepStatusPending,
+		}
+		if state, ok := states[step.Key]; ok {
+			updatedAt := state.UpdatedAt
+			item.Status = state.Status
+			item.AccountID = state.AccountID
+			item.UpdatedAt = &updatedAt
+		}
+
+		switch item.Status {
+		case domain.StepStatusCompleted:
+			progress.CompletedCount++
+		case domain.StepStatusSkipped:
+			progress.SkippedCount++
+		}
+		progress.Steps = append(progress.Steps, item)
+	}
+
+	done := progress.CompletedCount + progress.SkippedCount
+	progress.Complete = done == progress.TotalCount
+	progress.Percent = 100
+	if progress.TotalCount > 0 {
+		progress.Percent = done * 100 / progress.TotalCount
+	}
+	return progress
+}
+
+// loadStates returns the stored states of orgID by step key
+func (s *onboardingService) loadStates(ctx context.Context, orgID int32) (map[string]*domain.StepState, error) {
+	states, err := s.stateRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]*domain.StepState, len(states))
+	for _, state := range states {
+		byKey[state.StepKey] = state
+	}
+	return byKey, nil
+}
+
+// lookup returns an enabled step by key
+func (s *onboardingService) lookup(stepKey string) (domain.Step, error) {
+	s.mu.RLock()
+	step, ok := s.stepsByKey[stepKey]
+	s.mu.RUnlock()
+
+	if !ok || s.policy.DisabledStepSet[stepKey] {
+		return domain.Step{}, domain.ErrStepNotFound
+	}
+	return step, nil
+}
+
+// enabledSteps returns the registered steps without the disabled ones, in checklist order
+func (s *onboardingService) enabledSteps() []domain.Step {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	steps := make([]domain.Step, 0, len(s.steps))
+	for _, step := range s.steps {
+		if !s.policy.DisabledStepSet[step.Key] {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
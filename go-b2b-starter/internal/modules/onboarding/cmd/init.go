@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/dig"
+
+	docEvents "github.com/moasq/go-b2b-starter/internal/modules/documents/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding"
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+)
+
+func Init(container *dig.Container) error {
+	module := onboarding.NewModule(container)
+	if err := module.RegisterDependencies(); err != nil {
+		return fmt.Errorf("failed to register onboarding dependencies: %w", err)
+	}
+
+	// Complete the upload step when an organization's first document is processed
+	if err := container.Invoke(func(
+		bus eventbus.EventBus,
+		service services.OnboardingService,
+	) error {
+		return bus.Subscribe(docEvents.DocumentUploadedEventType, func(ctx context.Context, event eventbus.Event) error {
+			docEvent, ok := event.(*docEvents.DocumentUploaded)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+
+			return service.CompleteStep(ctx, docEvent.OrganizationID, domain.StepUploadFirstDocument, nil)
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to wire onboarding document listener: %w", err)
+	}
+
+	return nil
+}
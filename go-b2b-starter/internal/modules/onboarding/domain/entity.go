@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// Built-in onboarding steps, in checklist order
+const (
+	StepVerifyEmail         = "verify_email"
+	StepInviteTeammate      = "invite_teammate"
+	StepUploadFirstDocument = "upload_first_document"
+	StepConnectBilling      = "connect_billing"
+)
+
+// StepStatus is the state of an onboarding step for one organization.
+//
+// Steps start pending. A pending step can be completed or skipped, and a
+// skipped step can still be completed; completed is final.
+type StepStatus string
+
+const (
+	StepStatusPending   StepStatus = "pending"
+	StepStatusCompleted StepStatus = "completed"
+	StepStatusSkipped   StepStatus = "skipped"
+)
+
+// StepCheck reports whether an organization has done what a step asks for.
+// It is evaluated for steps that are not completed yet whenever progress is read.
+type StepCheck func(ctx context.Context, orgID int32) (bool, error)
+
+// Step is an item of the onboarding checklist. Steps are defined in code; the
+// built-in ones are registered by the onboarding module and applications add
+// their own with OnboardingService.RegisterStep.
+type Step struct {
+	// Key identifies the step, e.g. "verify_email"
+	Key         string `json:"key"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	// Required steps can't be skipped
+	Required bool `json:"required"`
+
+	// Manual steps are completed by members through the API. Other steps are
+	// completed by their Check, by events or by application code.
+	Manual bool `json:"manual"`
+
+	// Check detects completion when progress is read; nil for steps completed
+	// by events or application code
+	Check StepCheck `json:"-"`
+}
+
+// StepState is the stored state of a step that is no longer pending
+type StepState struct {
+	OrganizationID int32      `json:"organization_id"`
+	StepKey        string     `json:"step_key"`
+	Status         StepStatus `json:"status"`
+	AccountID      *int32     `json:"account_id,omitempty"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// StepProgress is a step of the checklist with its state for an organization
+type StepProgress struct {
+	Key         string     `json:"key"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Required    bool       `json:"required"`
+	Manual      bool       `json:"manual"`
+	Status      StepStatus `json:"status"`
+	// AccountID is the member who completed or skipped the step, if it wasn't detected automatically
+	AccountID *int32     `json:"account_id,omitempty"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty"`
+}
+
+// Progress is the onboarding checklist of an organization
+type Progress struct {
+	OrganizationID int32           `json:"organization_id"`
+	Steps          []*StepProgress `json:"steps"`
+	CompletedCount int             `json:"completed_count"`
+	SkippedCount   int             `json:"skipped_count"`
+	TotalCount     int             `json:"total_count"`
+	// Percent counts completed and skipped steps
+	Percent int `json:"percent"`
+	// Complete is true once no step is pending
+	Complete bool `json:"complete"`
+}
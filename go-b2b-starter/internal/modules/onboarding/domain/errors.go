@@ -0,0 +1,18 @@
+package domain
+
+import "errors"
+
+// Domain errors for onboarding
+var (
+	// Not found errors
+	ErrStepNotFound = errors.New("onboarding step not found")
+
+	// Transition errors
+	ErrStepNotManual        = errors.New("onboarding step is completed automatically")
+	ErrStepRequired         = errors.New("required onboarding steps can't be skipped")
+	ErrStepAlreadyCompleted = errors.New("onboarding step is already completed")
+
+	// Registration errors
+	ErrStepInvalid    = errors.New("onboarding step key and title are required")
+	ErrStepDuplicated = errors.New("onboarding step is already registered")
+)
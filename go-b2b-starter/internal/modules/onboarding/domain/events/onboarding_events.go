@@ -0,0 +1,59 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+)
+
+const (
+	OnboardingStepCompletedEventType = "onboarding.step_completed"
+	OnboardingCompletedEventType     = "onboarding.completed"
+)
+
+// OnboardingStepCompleted is published once when an organization completes an onboarding step.
+// AccountID is zero when the step was detected automatically.
+type OnboardingStepCompleted struct {
+	eventbus.BaseEvent
+	OrganizationID int32  `json:"organization_id"`
+	StepKey        string `json:"step_key"`
+	AccountID      int32  `json:"account_id,omitempty"`
+}
+
+func NewOnboardingStepCompleted(organizationID int32, stepKey string, accountID int32) *OnboardingStepCompleted {
+	return &OnboardingStepCompleted{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      OnboardingStepCompletedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		StepKey:        stepKey,
+		AccountID:      accountID,
+	}
+}
+
+// OnboardingCompleted is published when the last pending onboarding step of an
+// organization is completed or skipped
+type OnboardingCompleted struct {
+	eventbus.BaseEvent
+	OrganizationID int32 `json:"organization_id"`
+	CompletedCount int   `json:"completed_count"`
+	SkippedCount   int   `json:"skipped_count"`
+}
+
+func NewOnboardingCompleted(organizationID int32, completedCount, skippedCount int) *OnboardingCompleted {
+	return &OnboardingCompleted{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      OnboardingCompletedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		CompletedCount: completedCount,
+		SkippedCount:   skippedCount,
+	}
+}
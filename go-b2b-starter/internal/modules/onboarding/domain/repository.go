@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// StepStateRepository stores the onboarding steps each organization completed or skipped
+type StepStateRepository interface {
+	// ListByOrganization returns the steps of orgID that are no longer pending
+	ListByOrganization(ctx context.Context, orgID int32) ([]*StepState, error)
+	// Complete marks a pending or skipped step completed. It returns false
+	// when the step already was completed.
+	Complete(ctx context.Context, orgID int32, stepKey string, accountID *int32) (*StepState, bool, error)
+	// Skip marks a pending step skipped. It returns false when the step was
+	// not pending.
+	Skip(ctx context.Context, orgID int32, stepKey string, accountID *int32) (*StepState, bool, error)
+}
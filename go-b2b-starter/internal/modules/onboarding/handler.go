@@ -0,0 +1,130 @@
+package onboarding
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type Handler struct {
+	service services.OnboardingService
+	logger  logger.Logger
+}
+
+func NewHandler(service services.OnboardingService, logger logger.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// GetProgress godoc
+// @Summary Get onboarding progress
+// @Description Returns the onboarding checklist of the current organization with the state of each step. Steps that are detected automatically (such as email verification or billing) are re-checked on every call.
+// @Tags Onboarding
+// @Produce json
+// @Success 200 {object} domain.Progress "Onboarding progress"
+// @Failure 400 {object} map[string]string "Missing organization context"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /onboarding [get]
+func (h *Handler) GetProgress(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	progress, err := h.service.GetProgress(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.logger.Error("failed to get onboarding progress", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to get onboarding progress", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, progress)
+}
+
+// CompleteStep godoc
+// @Summary Complete onboarding step
+// @Description Marks a manual onboarding step completed. Steps that are detected automatically can't be completed through the API.
+// @Tags Onboarding
+// @Produce json
+// @Param key path string true "Step key"
+// @Success 200 {object} domain.Progress "Onboarding progress"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Step not found"
+// @Failure 409 {object} map[string]string "Step is completed automatically"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /onboarding/steps/{key}/complete [post]
+func (h *Handler) CompleteStep(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	progress, err := h.service.CompleteManualStep(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, c.Param("key"))
+	if err != nil {
+		h.handleStepError(c, reqCtx.OrganizationID, "failed to complete onboarding step", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, progress)
+}
+
+// SkipStep godoc
+// @Summary Skip onboarding step
+// @Description Skips a pending onboarding step, so it no longer counts against completion. Required steps can't be skipped. A skipped step that is done later still becomes completed.
+// @Tags Onboarding
+// @Produce json
+// @Param key path string true "Step key"
+// @Success 200 {object} domain.Progress "Onboarding progress"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Step not found"
+// @Failure 409 {object} map[string]string "Step is required or already completed"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /onboarding/steps/{key}/skip [post]
+func (h *Handler) SkipStep(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	progress, err := h.service.SkipStep(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, c.Param("key"))
+	if err != nil {
+		h.handleStepError(c, reqCtx.OrganizationID, "failed to skip onboarding step", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, progress)
+}
+
+// handleStepError maps step transition errors to responses
+func (h *Handler) handleStepError(c *gin.Context, orgID int32, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrStepNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrStepNotManual),
+		errors.Is(err, domain.ErrStepRequired),
+		errors.Is(err, domain.ErrStepAlreadyCompleted):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{"org_id": orgID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
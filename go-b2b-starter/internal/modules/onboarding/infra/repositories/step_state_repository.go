@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding/domain"
+)
+
+// stepStateRepository implements domain.StepStateRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type stepStateRepository struct {
+	store sqlc.Store
+}
+
+// NewStepStateRepository creates a new StepStateRepository implementation.
+func NewStepStateRepository(store sqlc.Store) domain.StepStateRepository {
+	return &stepStateRepository{store: store}
+}
+
+func (r *stepStateRepository) ListByOrganization(ctx context.Context, orgID int32) ([]*domain.StepState, error) {
+	results, err := r.store.ListOnboardingSteps(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list onboarding steps: %w", err)
+	}
+
+	states := make([]*domain.StepState, len(results))
+	for i := range results {
+		states[i] = r.mapToDomain(&results[i])
+	}
+
+	return states, nil
+}
+
+func (r *stepStateRepository) Complete(ctx context.Context, orgID int32, stepKey string, accountID *int32) (*domain.StepState, bool, error) {
+	result, err := r.store.CompleteOnboardingStep(ctx, sqlc.CompleteOnboardingStepParams{
+		OrganizationID: orgID,
+		StepKey:        stepKey,
+		AccountID:      helpers.ToPgInt4Ptr(accountID),
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to complete onboarding step: %w", err)
+	}
+
+	return r.mapToDomain(&result), true, nil
+}
+
+func (r *stepStateRepository) Skip(ctx context.Context, orgID int32, stepKey string, accountID *int32) (*domain.StepState, bool, error) {
+	result, err := r.store.SkipOnboardingStep(ctx, sqlc.SkipOnboardingStepParams{
+		OrganizationID: orgID,
+		StepKey:        stepKey,
+		AccountID:      helpers.ToPgInt4Ptr(accountID),
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to skip onboarding step: %w", err)
+	}
+
+	return r.mapToDomain(&result), true, nil
+}
+
+// mapToDomain converts SQLC step state to domain entity
+func (r *stepStateRepository) mapToDomain(s *sqlc.OnboardingOrganizationStep) *domain.StepState {
+	return &domain.StepState{
+		OrganizationID: s.OrganizationID,
+		StepKey:        s.StepKey,
+		Status:         domain.StepStatus(s.Status),
+		AccountID:      helpers.FromPgInt4Ptr(s.AccountID),
+		UpdatedAt:      s.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,34 @@
+package onboarding
+
+import (
+	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/onboarding/app/services"
+)
+
+// Module provides onboarding module dependencies
+type Module struct {
+	container *dig.Container
+}
+
+func NewModule(container *dig.Container) *Module {
+	return &Module{
+		container: container,
+	}
+}
+
+// RegisterDependencies registers all onboarding module dependencies
+// Note: Repository implementations are registered in internal/db/inject.go
+func (m *Module) RegisterDependencies() error {
+	// Register onboarding policy
+	if err := m.container.Provide(services.LoadOnboardingPolicy); err != nil {
+		return err
+	}
+
+	// Register onboarding service (built-in steps are registered here)
+	if err := m.container.Provide(services.NewOnboardingService); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package onboarding
+
+import (
+	"github.com/gin-gonic/gin"
+
+	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
+)
+
+type Routes struct {
+	handler *Handler
+}
+
+func NewRoutes(handler *Handler) *Routes {
+	return &Routes{
+		handler: handler,
+	}
+}
+
+func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	// Onboarding checklist of the current organization
+	onboardingGroup := router.Group("/onboarding")
+	onboardingGroup.Use(
+		resolver.Get("auth"),
+		resolver.Get("org_context"),
+	)
+	{
+		// GET /api/onboarding
+		onboardingGroup.GET("", resolver.Get("perm:org:view"), r.handler.GetProgress)
+
+		// POST /api/onboarding/steps/{key}/complete
+		onboardingGroup.POST("/steps/:key/complete", resolver.Get("perm:org:manage"), r.handler.CompleteStep)
+
+		// POST /api/onboarding/steps/{key}/skip
+		onboardingGroup.POST("/steps/:key/skip", resolver.Get("perm:org:manage"), r.handler.SkipStep)
+	}
+}
+
+// Routes returns a RouteRegistrar function compatible with the server interface
+func (r *Routes) Routes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	r.RegisterRoutes(router, resolver)
+}
@@ -1,13 +1,17 @@
 package organizations
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+	"github.com/moasq/go-b2b-starter/pkg/httperr"
 	"github.com/moasq/go-b2b-starter/pkg/response"
 	"github.com/moasq/go-b2b-starter/internal/modules/auth"
 	"github.com/moasq/go-b2b-starter/internal/platform/logger"
@@ -48,6 +52,14 @@ func (h *AccountHandler) CreateAccount(c *gin.Context) {
 			response.Error(c, http.StatusNotFound, "organization not found", err)
 			return
 		}
+		if errors.Is(err, domain.ErrUserAlreadyExists) {
+			c.JSON(http.StatusConflict, httperr.NewHTTPError(http.StatusConflict, "user_already_exists", err.Error()))
+			return
+		}
+		if errors.Is(err, paywall.ErrSeatLimitReached) {
+			c.JSON(http.StatusPaymentRequired, httperr.NewHTTPError(http.StatusPaymentRequired, "seat_limit_reached", err.Error()))
+			return
+		}
 		h.logger.Error("failed to create account", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
 		response.Error(c, http.StatusInternalServerError, "failed to create account", err)
 		return
@@ -117,6 +129,35 @@ func (h *AccountHandler) GetAccountByEmail(c *gin.Context) {
 	response.Success(c, http.StatusOK, account)
 }
 
+// GetAccountByPublicID gets an account by its UUIDv7 public ID
+func (h *AccountHandler) GetAccountByPublicID(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	publicID, err := uuid.Parse(c.Param("public_id"))
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid public ID format", err)
+		return
+	}
+
+	account, err := h.orgService.GetAccountByPublicID(c.Request.Context(), reqCtx.OrganizationID, publicID)
+	if err != nil {
+		if err == domain.ErrAccountNotFound {
+			response.Error(c, http.StatusNotFound, "account not found", err)
+			return
+		}
+		h.logger.Error("failed to get account by public ID", map[string]interface{}{"org_id": reqCtx.OrganizationID, "public_id": publicID.String(), "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to get account", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, account)
+}
+
 // ListAccounts lists all accounts in an organization
 func (h *AccountHandler) ListAccounts(c *gin.Context) {
 	reqCtx := auth.GetRequestContext(c)
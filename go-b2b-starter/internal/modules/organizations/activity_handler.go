@@ -0,0 +1,79 @@
+package organizations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type ActivityHandler struct {
+	activityService services.ActivityFeedService
+	logger          logger.Logger
+}
+
+func NewActivityHandler(activityService services.ActivityFeedService, logger logger.Logger) *ActivityHandler {
+	return &ActivityHandler{
+		activityService: activityService,
+		logger:          logger,
+	}
+}
+
+// GetUserActivity godoc
+// @Summary Get user activity
+// @Description Returns when a member was last seen and last signed in, the last time of each activity type and their recent activities newest first, for support and debugging. Activities are sign-ins (login), sign-outs (logout), password changes (password_changed), document uploads (document_uploaded) and organization settings changes (settings_changed).
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param type query string false "Only return recent activities of this type"
+// @Param limit query int false "Maximum recent activities (default 50, at most ACTIVITY_FEED_MAX_PER_ACCOUNT)"
+// @Success 200 {object} domain.AccountActivity "Account activity"
+// @Failure 400 {object} map[string]string "Invalid ID or activity type"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/activity [get]
+func (h *ActivityHandler) GetUserActivity(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var accountID int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &accountID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid account ID format", err)
+		return
+	}
+
+	var req services.GetActivityRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	activity, err := h.activityService.GetActivity(c.Request.Context(), reqCtx.OrganizationID, accountID, &req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAccountNotFound):
+			response.Error(c, http.StatusNotFound, "user not found", err)
+		case errors.Is(err, domain.ErrActivityTypeInvalid):
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		default:
+			h.logger.Error("failed to get user activity", map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": accountID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to get user activity", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, activity)
+}
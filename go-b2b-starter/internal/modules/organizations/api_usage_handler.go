@@ -0,0 +1,57 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type APIUsageHandler struct {
+	usageService services.APIUsageService
+	logger       logger.Logger
+}
+
+func NewAPIUsageHandler(usageService services.APIUsageService, logger logger.Logger) *APIUsageHandler {
+	return &APIUsageHandler{
+		usageService: usageService,
+		logger:       logger,
+	}
+}
+
+// GetUsage godoc
+// @Summary Get API usage
+// @Description Returns the signed-in member's API request count and quota in the current window, when the window resets, and the counts of past windows. Past windows are copied from the live counters every API_USAGE_FLUSH_INTERVAL.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} domain.APIUsageReport "API usage"
+// @Failure 400 {object} map[string]string "Missing organization context"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/usage [get]
+func (h *APIUsageHandler) GetUsage(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	usage, err := h.usageService.GetUsage(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID)
+	if err != nil {
+		h.logger.Error("failed to get api usage", map[string]interface{}{
+			"org_id":     reqCtx.OrganizationID,
+			"account_id": reqCtx.AccountID,
+			"error":      err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "failed to get API usage", err)
+		return
+	}
+
+	c.Header("Cache-Control", "private, no-store")
+	response.Success(c, http.StatusOK, usage)
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// AccountCachePolicy controls the Redis cache in front of account lookups by
+// ID and email, which the auth middleware and most services make on every
+// request.
+//
+// All values can be set via environment variables with the ACCOUNT_CACHE_ prefix.
+type AccountCachePolicy struct {
+	// TTL bounds how long a cached account is served. Writes through the
+	// account, status and activity repositories clear it at once; other
+	// changes, such as deleting the organization, show within the TTL.
+	// 0 turns the cache off.
+	TTL time.Duration `mapstructure:"ACCOUNT_CACHE_TTL"`
+}
+
+// LoadAccountCachePolicy loads the account cache policy from environment variables and app.env file.
+func LoadAccountCachePolicy() (*AccountCachePolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("ACCOUNT_CACHE_TTL", "30s")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy AccountCachePolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode account cache policy: %w", err)
+	}
+
+	if policy.TTL < 0 {
+		return nil, fmt.Errorf("account cache policy invalid: ACCOUNT_CACHE_TTL must not be negative")
+	}
+
+	return &policy, nil
+}
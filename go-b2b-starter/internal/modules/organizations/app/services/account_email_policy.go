@@ -0,0 +1,50 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// AccountEmailPolicy controls how account emails are normalized before they
+// are stored or looked up. Emails are always trimmed and lowercased.
+//
+// All values can be set via environment variables with the ACCOUNT_EMAIL_ prefix.
+type AccountEmailPolicy struct {
+	// CanonicalizePlusAddresses strips "+tag" from the local part, so
+	// "jane+a@example.com" and "jane+b@example.com" are the same account
+	CanonicalizePlusAddresses bool `mapstructure:"ACCOUNT_EMAIL_CANONICALIZE_PLUS"`
+}
+
+// LoadAccountEmailPolicy loads the account email policy from environment variables and app.env file.
+func LoadAccountEmailPolicy() (*AccountEmailPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("ACCOUNT_EMAIL_CANONICALIZE_PLUS", false)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy AccountEmailPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode account email policy: %w", err)
+	}
+
+	return &policy, nil
+}
+
+// Normalizer returns the normalizer applying this policy
+func (p *AccountEmailPolicy) Normalizer() domain.EmailNormalizer {
+	return domain.EmailNormalizer{CanonicalizePlusAddresses: p.CanonicalizePlusAddresses}
+}
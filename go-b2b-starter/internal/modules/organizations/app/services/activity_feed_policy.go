@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ActivityFeedPolicy controls the per-account activity feed support staff use
+// to see what a member did recently.
+//
+// All values can be set via environment variables with the ACTIVITY_FEED_ prefix.
+type ActivityFeedPolicy struct {
+	// MaxPerAccount is how many recent activities are kept per account; older
+	// ones are dropped as new ones are recorded
+	MaxPerAccount int32 `mapstructure:"ACTIVITY_FEED_MAX_PER_ACCOUNT"`
+
+	// Retention is how long activities are kept after they occurred. The last
+	// time of each activity type is kept for as long as the account exists.
+	Retention time.Duration `mapstructure:"ACTIVITY_FEED_RETENTION"`
+
+	// CleanupInterval is how often activities past Retention are deleted. 0 disables cleanup.
+	CleanupInterval time.Duration `mapstructure:"ACTIVITY_FEED_CLEANUP_INTERVAL"`
+}
+
+// LoadActivityFeedPolicy loads the activity feed policy from environment variables and app.env file.
+func LoadActivityFeedPolicy() (*ActivityFeedPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("ACTIVITY_FEED_MAX_PER_ACCOUNT", 200)
+	v.SetDefault("ACTIVITY_FEED_RETENTION", "2160h")
+	v.SetDefault("ACTIVITY_FEED_CLEANUP_INTERVAL", "24h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy ActivityFeedPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode activity feed policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the limits and durations are usable.
+func (p *ActivityFeedPolicy) Validate() error {
+	if p.MaxPerAccount <= 0 || p.MaxPerAccount > 10000 {
+		return fmt.Errorf("activity feed policy invalid: ACTIVITY_FEED_MAX_PER_ACCOUNT must be between 1 and 10000")
+	}
+	if p.Retention < 24*time.Hour {
+		return fmt.Errorf("activity feed policy invalid: ACTIVITY_FEED_RETENTION must be at least 24h")
+	}
+	if p.CleanupInterval < 0 {
+		return fmt.Errorf("activity feed policy invalid: ACTIVITY_FEED_CLEANUP_INTERVAL must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// defaultActivityLimit is how many activities GetActivity returns when no limit is given
+const defaultActivityLimit int32 = 50
+
+// authEventActivities maps the auth events recorded in activity feeds to their activity type
+var authEventActivities = map[string]domain.ActivityType{
+	auth.AuthEventLoginSucceeded:  domain.ActivityLogin,
+	auth.AuthEventLogout:          domain.ActivityLogout,
+	auth.AuthEventPasswordChanged: domain.ActivityPasswordChanged,
+}
+
+// ActivityAuthEventTypes lists the auth event types recorded in activity feeds
+var ActivityAuthEventTypes = []string{
+	auth.AuthEventLoginSucceeded,
+	auth.AuthEventLogout,
+	auth.AuthEventPasswordChanged,
+}
+
+// ActivityFeedService keeps a rolling feed of what each member did recently,
+// such as signing in, uploading documents and changing organization settings,
+// so support staff can see what led up to a reported issue.
+//
+// Activities are recorded from events published on the event bus. Each account
+// keeps its newest ACTIVITY_FEED_MAX_PER_ACCOUNT activities for up to
+// ACTIVITY_FEED_RETENTION, while the last time of each activity type is kept
+// for as long as the account exists.
+type ActivityFeedService interface {
+	// Record adds an activity to the account's feed. Activities without an
+	// account and events that were already recorded are ignored.
+	Record(ctx context.Context, activity *domain.AccountActivityEvent) error
+
+	// RecordAuthEvent records the sign-ins, sign-outs and password changes in
+	// ActivityAuthEventTypes. Events of unknown members are ignored.
+	RecordAuthEvent(ctx context.Context, event *auth.AuthEvent) error
+
+	// GetActivity returns the account's last seen and login times, the last
+	// time of each activity type and its recent activities
+	GetActivity(ctx context.Context, orgID, accountID int32, req *GetActivityRequest) (*domain.AccountActivity, error)
+
+	// Run deletes activities past ACTIVITY_FEED_RETENTION every
+	// ACTIVITY_FEED_CLEANUP_INTERVAL until ctx is cancelled
+	Run(ctx context.Context)
+
+	// DeleteExpired deletes activities past ACTIVITY_FEED_RETENTION
+	DeleteExpired(ctx context.Context) error
+}
+
+// GetActivityRequest filters an account's recent activities
+type GetActivityRequest struct {
+	// Type limits the recent activities to one activity type
+	Type string `form:"type"`
+	// Limit defaults to 50 and is capped at ACTIVITY_FEED_MAX_PER_ACCOUNT
+	Limit int32 `form:"limit"`
+}
+
+type activityFeedService struct {
+	activityRepo domain.ActivityRepository
+	accountRepo  domain.AccountRepository
+	orgRepo      domain.OrganizationRepository
+	policy       *ActivityFeedPolicy
+	tracker      jobsDomain.Tracker
+	job          jobsDomain.Definition
+	logger       loggerDomain.Logger
+}
+
+func NewActivityFeedService(
+	activityRepo domain.ActivityRepository,
+	accountRepo domain.AccountRepository,
+	orgRepo domain.OrganizationRepository,
+	policy *ActivityFeedPolicy,
+	tracker jobsDomain.Tracker,
+	logger loggerDomain.Logger,
+) ActivityFeedService {
+	s := &activityFeedService{
+		activityRepo: activityRepo,
+		accountRepo:  accountRepo,
+		orgRepo:      orgRepo,
+		policy:       policy,
+		tracker:      tracker,
+		job: jobsDomain.Definition{
+			Name:        "organizations.activity_feed_cleanup",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Deletes account activities past the retention period",
+			Schedule:    "every " + policy.CleanupInterval.String(),
+		},
+		logger: logger.Named("organizations"),
+	}
+	tracker.Register(s.job)
+	return s
+}
+
+func (s *activityFeedService) Record(ctx context.Context, activity *domain.AccountActivityEvent) error {
+	if activity.AccountID == 0 || activity.OrganizationID == 0 {
+		return nil
+	}
+	if activity.OccurredAt.IsZero() {
+		activity.OccurredAt = time.Now()
+	}
+
+	_, err := s.activityRepo.RecordEvent(ctx, activity, s.policy.MaxPerAccount)
+	return err
+}
+
+func (s *activityFeedService) RecordAuthEvent(ctx context.Context, event *auth.AuthEvent) error {
+	activityType, ok := authEventActivities[event.EventName()]
+	if !ok || event.OrganizationID == "" || event.Email == "" {
+		return nil
+	}
+
+	org, err := s.orgRepo.GetByStytchID(ctx, event.OrganizationID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrganizationNotFound) {
+			return nil
+		}
+		return err
+	}
+	account, err := s.accountRepo.GetByEmail(ctx, org.ID, event.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	details := map[string]any{}
+	if event.ClientIP != "" {
+		details["client_ip"] = event.ClientIP
+	}
+	if event.UserAgent != "" {
+		details["user_agent"] = event.UserAgent
+	}
+	if event.Reason != "" {
+		details["reason"] = event.Reason
+	}
+
+	return s.Record(ctx, &domain.AccountActivityEvent{
+		EventID:        event.EventID(),
+		OrganizationID: org.ID,
+		AccountID:      account.ID,
+		Type:           activityType,
+		Details:        details,
+		OccurredAt:     event.Timestamp(),
+	})
+}
+
+func (s *activityFeedService) GetActivity(ctx context.Context, orgID, accountID int32, req *GetActivityRequest) (*domain.AccountActivity, error) {
+	filter := domain.AccountActivityFilter{
+		Type:  domain.ActivityType(req.Type),
+		Limit: req.Limit,
+	}
+	if filter.Type != "" && !slices.Contains(domain.ActivityTypes, filter.Type) {
+		return nil, domain.ErrActivityTypeInvalid
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultActivityLimit
+	}
+	if filter.Limit > s.policy.MaxPerAccount {
+		filter.Limit = s.policy.MaxPerAccount
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	lastSeenAt, err := s.activityRepo.LastActiveAt(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	timestamps, err := s.activityRepo.ListTimestamps(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	recent, err := s.activityRepo.ListEvents(ctx, orgID, accountID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AccountActivity{
+		AccountID:   account.ID,
+		LastSeenAt:  lastSeenAt,
+		LastLoginAt: account.LastLoginAt,
+		Timestamps:  timestamps,
+		Recent:      recent,
+	}, nil
+}
+
+func (s *activityFeedService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.policy.CleanupInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("activity feed cleanup scheduler started", loggerDomain.Fields{
+		"interval":  s.policy.CleanupInterval.String(),
+		"retention": s.policy.Retention.String(),
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.DeleteExpired); err != nil {
+			s.logger.Error("activity feed cleanup run failed", loggerDomain.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *activityFeedService) DeleteExpired(ctx context.Context) error {
+	deleted, err := s.activityRepo.DeleteEventsBefore(ctx, time.Now().Add(-s.policy.Retention))
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		s.logger.Info("expired account activities deleted", loggerDomain.Fields{"activities": deleted})
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// APIUsagePolicy controls per-account API request counting and quotas.
+//
+// All values can be set via environment variables with the API_USAGE_ prefix.
+type APIUsagePolicy struct {
+	// Enabled turns request counting on
+	Enabled bool `mapstructure:"API_USAGE_ENABLED"`
+
+	// Window is the length of a quota window. Windows are aligned to the Unix
+	// epoch, so a 24h window resets at midnight UTC.
+	Window time.Duration `mapstructure:"API_USAGE_WINDOW"`
+
+	// Limit is how many requests an account may make per window. 0 only counts.
+	Limit int64 `mapstructure:"API_USAGE_LIMIT"`
+
+	// FlushInterval is how often counts are copied from Redis to the database.
+	// 0 disables flushing, so only the current window is visible.
+	FlushInterval time.Duration `mapstructure:"API_USAGE_FLUSH_INTERVAL"`
+
+	// Retention is how long flushed windows are kept after they end
+	Retention time.Duration `mapstructure:"API_USAGE_RETENTION"`
+
+	// HistoryWindows is how many past windows /me/usage returns
+	HistoryWindows int32 `mapstructure:"API_USAGE_HISTORY_WINDOWS"`
+
+	// FailOpen lets requests through uncounted when Redis is unavailable
+	FailOpen bool `mapstructure:"API_USAGE_FAIL_OPEN"`
+}
+
+// LoadAPIUsagePolicy loads the API usage policy from environment variables and app.env file.
+func LoadAPIUsagePolicy() (*APIUsagePolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("API_USAGE_ENABLED", true)
+	v.SetDefault("API_USAGE_WINDOW", "24h")
+	v.SetDefault("API_USAGE_LIMIT", 0)
+	v.SetDefault("API_USAGE_FLUSH_INTERVAL", "5m")
+	v.SetDefault("API_USAGE_RETENTION", "2160h")
+	v.SetDefault("API_USAGE_HISTORY_WINDOWS", 30)
+	v.SetDefault("API_USAGE_FAIL_OPEN", true)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy APIUsagePolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode api usage policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the window, limit and flush settings are usable.
+func (p *APIUsagePolicy) Validate() error {
+	if p.Window < time.Minute {
+		return fmt.Errorf("api usage policy invalid: API_USAGE_WINDOW must be at least 1m")
+	}
+	if p.Limit < 0 {
+		return fmt.Errorf("api usage policy invalid: API_USAGE_LIMIT must not be negative")
+	}
+	if p.FlushInterval < 0 {
+		return fmt.Errorf("api usage policy invalid: API_USAGE_FLUSH_INTERVAL must not be negative")
+	}
+	if p.Retention < p.Window {
+		return fmt.Errorf("api usage policy invalid: API_USAGE_RETENTION must be at least API_USAGE_WINDOW")
+	}
+	if p.HistoryWindows <= 0 {
+		return fmt.Errorf("api usage policy invalid: API_USAGE_HISTORY_WINDOWS must be positive")
+	}
+	return nil
+}
+
+// windowStart returns the start of the window containing t.
+func (p *APIUsagePolicy) windowStart(t time.Time) time.Time {
+	nanos := t.UnixNano()
+	return time.Unix(0, nanos-nanos%int64(p.Window)).UTC()
+}
@@ -0,0 +1,294 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+const apiUsageKeyPattern = "organizations:api_usage:%d:%d"
+
+// APIQuotaResolver decides how many requests an account may make per window.
+//
+// The default resolver gives every account API_USAGE_LIMIT. Provide another
+// implementation to derive the limit from the organization's plan.
+type APIQuotaResolver interface {
+	// APIQuota returns the account's limit per window. 0 means unlimited.
+	APIQuota(ctx context.Context, orgID, accountID int32) (int64, error)
+}
+
+type policyAPIQuotaResolver struct {
+	policy *APIUsagePolicy
+}
+
+// NewPolicyAPIQuotaResolver creates an APIQuotaResolver that returns
+// API_USAGE_LIMIT for every account.
+func NewPolicyAPIQuotaResolver(policy *APIUsagePolicy) APIQuotaResolver {
+	return &policyAPIQuotaResolver{policy: policy}
+}
+
+func (r *policyAPIQuotaResolver) APIQuota(ctx context.Context, orgID, accountID int32) (int64, error) {
+	return r.policy.Limit, nil
+}
+
+// APIUsageService counts the API requests of each account per quota window
+// and enforces the account's quota.
+//
+// It implements auth.UsageMeter so the auth middleware counts every
+// organization request. Counts live in Redis, shared by all instances, and
+// each instance copies the counts of the windows it served to the database
+// every API_USAGE_FLUSH_INTERVAL so past windows stay visible.
+type APIUsageService interface {
+	auth.UsageMeter
+
+	// GetUsage returns the account's count and quota in the current window
+	// and its last API_USAGE_HISTORY_WINDOWS windows
+	GetUsage(ctx context.Context, orgID, accountID int32) (*domain.APIUsageReport, error)
+
+	// Run flushes counts every API_USAGE_FLUSH_INTERVAL until ctx is
+	// cancelled, then flushes once more
+	Run(ctx context.Context)
+
+	// Flush copies the counts of the windows counted by this instance to the
+	// database and deletes windows past API_USAGE_RETENTION
+	Flush(ctx context.Context) error
+}
+
+// apiUsageWindowKey identifies an account's window that has counts to flush
+type apiUsageWindowKey struct {
+	orgID       int32
+	accountID   int32
+	windowStart time.Time
+}
+
+type apiUsageService struct {
+	usageRepo domain.APIUsageRepository
+	redis     redis.Client
+	quotas    APIQuotaResolver
+	policy    *APIUsagePolicy
+	tracker   jobsDomain.Tracker
+	job       jobsDomain.Definition
+	logger    loggerDomain.Logger
+
+	mu      sync.Mutex
+	pending map[apiUsageWindowKey]struct{}
+}
+
+func NewAPIUsageService(
+	usageRepo domain.APIUsageRepository,
+	redisClient redis.Client,
+	quotas APIQuotaResolver,
+	policy *APIUsagePolicy,
+	tracker jobsDomain.Tracker,
+	logger loggerDomain.Logger,
+) APIUsageService {
+	s := &apiUsageService{
+		usageRepo: usageRepo,
+		redis:     redisClient,
+		quotas:    quotas,
+		policy:    policy,
+		tracker:   tracker,
+		job: jobsDomain.Definition{
+			Name:        "organizations.api_usage_flush",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Copies per-account API request counts from Redis to the database",
+			Schedule:    "every " + policy.FlushInterval.String(),
+		},
+		logger:  logger.Named("organizations"),
+		pending: make(map[apiUsageWindowKey]struct{}),
+	}
+	tracker.Register(s.job)
+	return s
+}
+
+// CountRequest implements auth.UsageMeter. The counter expires a little after
+// its window ends so the last flush can still read the final count.
+func (s *apiUsageService) CountRequest(ctx context.Context, orgID, accountID int32) (*auth.APIUsage, error) {
+	if !s.policy.Enabled {
+		return nil, nil
+	}
+
+	now := time.Now()
+	start := s.policy.windowStart(now)
+	resetAt := start.Add(s.policy.Window)
+
+	key := fmt.Sprintf(apiUsageKeyPattern, accountID, start.Unix())
+	count, _, err := s.redis.Incr(ctx, key, resetAt.Sub(now)+2*s.policy.FlushInterval+time.Minute)
+	if err != nil {
+		if s.policy.FailOpen {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to count api request: %w", err)
+	}
+	s.markPending(apiUsageWindowKey{orgID: orgID, accountID: accountID, windowStart: start})
+
+	limit, err := s.quotas.APIQuota(ctx, orgID, accountID)
+	if err != nil {
+		if !s.policy.FailOpen {
+			return nil, fmt.Errorf("failed to resolve api quota: %w", err)
+		}
+		limit = 0
+	}
+
+	return &auth.APIUsage{Count: count, Limit: limit, ResetAt: resetAt}, nil
+}
+
+func (s *apiUsageService) GetUsage(ctx context.Context, orgID, accountID int32) (*domain.APIUsageReport, error) {
+	start := s.policy.windowStart(time.Now())
+
+	var count int64
+	if s.policy.Enabled {
+		var err error
+		if count, err = s.currentCount(ctx, accountID, start); err != nil {
+			return nil, err
+		}
+	}
+
+	limit, err := s.quotas.APIQuota(ctx, orgID, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve api quota: %w", err)
+	}
+
+	windows, err := s.usageRepo.List(ctx, orgID, accountID, s.policy.HistoryWindows+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// The current window is reported from Redis, which is ahead of its last flush
+	history := make([]*domain.APIUsageWindow, 0, len(windows))
+	for _, window := range windows {
+		if !window.WindowStart.Before(start) || int32(len(history)) == s.policy.HistoryWindows {
+			continue
+		}
+		history = append(history, window)
+	}
+
+	report := &domain.APIUsageReport{
+		WindowStart:  start,
+		ResetAt:      start.Add(s.policy.Window),
+		RequestCount: count,
+		Limit:        limit,
+		History:      history,
+	}
+	if limit > 0 {
+		remaining := max(limit-count, 0)
+		report.Remaining = &remaining
+	}
+	return report, nil
+}
+
+func (s *apiUsageService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.policy.FlushInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("api usage flush started", loggerDomain.Fields{
+		"interval": s.policy.FlushInterval.String(),
+		"window":   s.policy.Window.String(),
+	})
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			// Flush what was counted since the last tick before shutting down
+			flushCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			if err := s.Flush(flushCtx); err != nil {
+				s.logger.Error("final api usage flush failed", loggerDomain.Fields{"error": err.Error()})
+			}
+			cancel()
+			return
+		}
+
+		if err := s.tracker.Track(ctx, s.job, s.Flush); err != nil {
+			s.logger.Error("api usage flush failed", loggerDomain.Fields{"error": err.Error()})
+		}
+	}
+}
+
+// Flush saves the absolute count of each pending window, so flushes from
+// several instances and retries after a failed flush are harmless. Windows
+// that are still open, or failed to save, stay pending for the next flush.
+func (s *apiUsageService) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[apiUsageWindowKey]struct{})
+	s.mu.Unlock()
+
+	now := time.Now()
+	var errs []error
+	var saved int
+	for window := range pending {
+		windowEnd := window.windowStart.Add(s.policy.Window)
+
+		count, err := s.currentCount(ctx, window.accountID, window.windowStart)
+		if err == nil && count > 0 {
+			err = s.usageRepo.Save(ctx, &domain.APIUsageWindow{
+				OrganizationID: window.orgID,
+				AccountID:      window.accountID,
+				WindowStart:    window.windowStart,
+				WindowEnd:      windowEnd,
+				RequestCount:   count,
+			})
+			if err == nil {
+				saved++
+			}
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("account %d: %w", window.accountID, err))
+		}
+		if err != nil || now.Before(windowEnd) {
+			s.markPending(window)
+		}
+	}
+
+	deleted, err := s.usageRepo.DeleteBefore(ctx, now.Add(-s.policy.Retention))
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	if saved > 0 || deleted > 0 {
+		s.logger.Debug("api usage flushed", loggerDomain.Fields{
+			"windows": saved,
+			"deleted": deleted,
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// currentCount reads an account's count in the window starting at start.
+// Windows without requests, or whose counter expired, count 0.
+func (s *apiUsageService) currentCount(ctx context.Context, accountID int32, start time.Time) (int64, error) {
+	key := fmt.Sprintf(apiUsageKeyPattern, accountID, start.Unix())
+	exists, err := s.redis.Exists(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check api usage counter: %w", err)
+	}
+	if !exists {
+		return 0, nil
+	}
+
+	value, err := s.redis.Get(ctx, key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read api usage counter: %w", err)
+	}
+	count, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid api usage counter: %w", err)
+	}
+	return count, nil
+}
+
+func (s *apiUsageService) markPending(window apiUsageWindowKey) {
+	s.mu.Lock()
+	s.pending[window] = struct{}{}
+	s.mu.Unlock()
+}
@@ -0,0 +1,187 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// Bounds for an organization's session max age. 0 leaves session lifetime to the auth provider.
+const (
+	minAuthPolicySessionMaxAgeSeconds = 5 * 60
+	maxAuthPolicySessionMaxAgeSeconds = 30 * 24 * 60 * 60
+)
+
+// AuthPolicyService manages per-organization auth policy overrides.
+//
+// It implements auth.OrganizationAuthPolicyResolver so the auth middleware can
+// enforce an organization's MFA, session age and sign-in method requirements
+// without depending on the organizations domain.
+type AuthPolicyService interface {
+	auth.OrganizationAuthPolicyResolver
+
+	// GetPolicy returns the organization's auth policy, or the defaults if it has not set one
+	GetPolicy(ctx context.Context, orgID int32) (*domain.AuthPolicy, error)
+
+	// UpdatePolicy replaces the organization's auth policy. The policy is
+	// rejected if the caller's own session would not satisfy it.
+	UpdatePolicy(ctx context.Context, orgID, accountID int32, identity *auth.Identity, req *UpdateAuthPolicyRequest) (*domain.AuthPolicy, error)
+
+	// ResetPolicy removes the organization's overrides, falling back to the auth provider's settings
+	ResetPolicy(ctx context.Context, orgID, accountID int32) error
+}
+
+// UpdateAuthPolicyRequest represents the request to update an organization's auth policy
+type UpdateAuthPolicyRequest struct {
+	MFARequired          bool     `json:"mfa_required"`
+	SessionMaxAgeSeconds int32    `json:"session_max_age_seconds"`
+	AllowedAuthMethods   []string `json:"allowed_auth_methods"`
+}
+
+// Validate performs business validation on the auth policy request
+func (r *UpdateAuthPolicyRequest) Validate() error {
+	if r.SessionMaxAgeSeconds != 0 &&
+		(r.SessionMaxAgeSeconds < minAuthPolicySessionMaxAgeSeconds || r.SessionMaxAgeSeconds > maxAuthPolicySessionMaxAgeSeconds) {
+		return domain.ErrAuthPolicyInvalidSessionAge
+	}
+
+	for _, method := range r.AllowedAuthMethods {
+		if !slices.Contains(auth.PrimaryAuthMethods, strings.TrimSpace(method)) {
+			return domain.ErrAuthPolicyInvalidMethod
+		}
+	}
+
+	return nil
+}
+
+type authPolicyService struct {
+	policyRepo domain.AuthPolicyRepository
+	eventBus   eventbus.EventBus
+	logger     loggerDomain.Logger
+}
+
+func NewAuthPolicyService(policyRepo domain.AuthPolicyRepository, eventBus eventbus.EventBus, logger loggerDomain.Logger) AuthPolicyService {
+	return &authPolicyService{
+		policyRepo: policyRepo,
+		eventBus:   eventBus,
+		logger:     logger,
+	}
+}
+
+func (s *authPolicyService) GetPolicy(ctx context.Context, orgID int32) (*domain.AuthPolicy, error) {
+	policy, err := s.policyRepo.GetByOrganization(ctx, orgID)
+	if errors.Is(err, domain.ErrAuthPolicyNotFound) {
+		return &domain.AuthPolicy{
+			OrganizationID:     orgID,
+			AllowedAuthMethods: []string{},
+		}, nil
+	}
+	return policy, err
+}
+
+func (s *authPolicyService) UpdatePolicy(ctx context.Context, orgID, accountID int32, identity *auth.Identity, req *UpdateAuthPolicyRequest) (*domain.AuthPolicy, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	allowedMethods := make([]string, 0, len(req.AllowedAuthMethods))
+	for _, method := range req.AllowedAuthMethods {
+		method = strings.TrimSpace(method)
+		if !slices.Contains(allowedMethods, method) {
+			allowedMethods = append(allowedMethods, method)
+		}
+	}
+
+	policy := &domain.AuthPolicy{
+		OrganizationID:       orgID,
+		MFARequired:          req.MFARequired,
+		SessionMaxAgeSeconds: req.SessionMaxAgeSeconds,
+		AllowedAuthMethods:   allowedMethods,
+		UpdatedByAccountID:   &accountID,
+	}
+
+	// Refuse a policy that would reject the admin's own session on the next request
+	if identity != nil {
+		if err := toAuthPolicy(policy).Check(identity, time.Now()); err != nil {
+			return nil, domain.ErrAuthPolicyLockout
+		}
+	}
+
+	saved, err := s.policyRepo.Upsert(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("auth_policy.updated", loggerDomain.Fields{
+		"organization_id":         orgID,
+		"account_id":              accountID,
+		"mfa_required":            saved.MFARequired,
+		"session_max_age_seconds": saved.SessionMaxAgeSeconds,
+		"allowed_auth_methods":    saved.AllowedAuthMethods,
+	})
+	s.publish(ctx, events.NewSettingsChanged(orgID, accountID, events.SettingAuthPolicy, "updated"))
+
+	return saved, nil
+}
+
+func (s *authPolicyService) ResetPolicy(ctx context.Context, orgID, accountID int32) error {
+	if err := s.policyRepo.Delete(ctx, orgID); err != nil {
+		return err
+	}
+
+	s.audit("auth_policy.reset", loggerDomain.Fields{
+		"organization_id": orgID,
+		"account_id":      accountID,
+	})
+	s.publish(ctx, events.NewSettingsChanged(orgID, accountID, events.SettingAuthPolicy, "reset"))
+
+	return nil
+}
+
+// AuthPolicy implements auth.OrganizationAuthPolicyResolver.
+func (s *authPolicyService) AuthPolicy(ctx context.Context, orgID int32) (*auth.OrganizationAuthPolicy, error) {
+	policy, err := s.policyRepo.GetByOrganization(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAuthPolicyNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load auth policy: %w", err)
+	}
+
+	return toAuthPolicy(policy), nil
+}
+
+// audit writes an audit log entry for auth policy changes.
+func (s *authPolicyService) audit(event string, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	s.logger.Info("auth policy audit", fields)
+}
+
+// publish logs failed subscribers; the policy change is already saved.
+func (s *authPolicyService) publish(ctx context.Context, event eventbus.Event) {
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Warn("failed to publish auth policy event", loggerDomain.Fields{
+			"event": event.EventName(),
+			"error": err.Error(),
+		})
+	}
+}
+
+// toAuthPolicy converts the stored policy to the form the auth middleware enforces.
+func toAuthPolicy(policy *domain.AuthPolicy) *auth.OrganizationAuthPolicy {
+	return &auth.OrganizationAuthPolicy{
+		MFARequired:        policy.MFARequired,
+		SessionMaxAge:      time.Duration(policy.SessionMaxAgeSeconds) * time.Second,
+		AllowedAuthMethods: policy.AllowedAuthMethods,
+	}
+}
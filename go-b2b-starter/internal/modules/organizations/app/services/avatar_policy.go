@@ -0,0 +1,86 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// AvatarPolicy controls the profile pictures members upload for their account.
+//
+// All values can be set via environment variables with the AVATAR_ prefix.
+type AvatarPolicy struct {
+	// MaxUploadBytes is the largest image a member may upload
+	MaxUploadBytes int64 `mapstructure:"AVATAR_MAX_UPLOAD_BYTES"`
+
+	// MaxPixels is the largest width times height accepted, so a small but
+	// huge-dimensioned image cannot exhaust memory while it is decoded
+	MaxPixels int `mapstructure:"AVATAR_MAX_PIXELS"`
+
+	// Size is the width and height in pixels avatars are cropped and resized to
+	Size int `mapstructure:"AVATAR_SIZE"`
+
+	// Quality is the JPEG quality of stored avatars, from 1 to 100
+	Quality int `mapstructure:"AVATAR_JPEG_QUALITY"`
+
+	// BaseURL is the public address of GET /api/avatars; an avatar's URL is
+	// BaseURL followed by its key
+	BaseURL string `mapstructure:"AVATAR_BASE_URL"`
+}
+
+// LoadAvatarPolicy loads the avatar policy from environment variables and app.env file.
+func LoadAvatarPolicy() (*AvatarPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("AVATAR_MAX_UPLOAD_BYTES", 5*1024*1024)
+	v.SetDefault("AVATAR_MAX_PIXELS", 4096*4096)
+	v.SetDefault("AVATAR_SIZE", 256)
+	v.SetDefault("AVATAR_JPEG_QUALITY", 85)
+	v.SetDefault("AVATAR_BASE_URL", "http://localhost:8080/api/avatars")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy AvatarPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode avatar policy: %w", err)
+	}
+
+	policy.BaseURL = strings.TrimSuffix(policy.BaseURL, "/")
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the limits and dimensions are usable.
+func (p *AvatarPolicy) Validate() error {
+	if p.MaxUploadBytes <= 0 {
+		return fmt.Errorf("avatar policy invalid: AVATAR_MAX_UPLOAD_BYTES must be positive")
+	}
+	if p.MaxPixels <= 0 {
+		return fmt.Errorf("avatar policy invalid: AVATAR_MAX_PIXELS must be positive")
+	}
+	if p.Size < 32 || p.Size > 1024 {
+		return fmt.Errorf("avatar policy invalid: AVATAR_SIZE must be between 32 and 1024")
+	}
+	if p.Quality < 1 || p.Quality > 100 {
+		return fmt.Errorf("avatar policy invalid: AVATAR_JPEG_QUALITY must be between 1 and 100")
+	}
+	if p.BaseURL == "" {
+		return fmt.Errorf("avatar policy invalid: AVATAR_BASE_URL is required")
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png" // register the PNG decoder
+	"io"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/files"
+	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// AvatarService manages the profile pictures members upload for their account.
+//
+// Uploads are cropped to a centered square, resized to AVATAR_SIZE and stored
+// as JPEG through the files module. Storage URLs are presigned and expire, so
+// each avatar gets a random key and is served publicly under AVATAR_BASE_URL;
+// a new upload gets a new key, which lets clients cache avatars indefinitely.
+type AvatarService interface {
+	// UploadAvatar replaces the account's avatar and deletes the previous one
+	UploadAvatar(ctx context.Context, orgID, accountID int32, upload *AvatarUpload) (*domain.Account, error)
+
+	// DeleteAvatar removes the account's avatar, if any
+	DeleteAvatar(ctx context.Context, orgID, accountID int32) error
+
+	// OpenAvatar returns the content of the avatar served under key
+	OpenAvatar(ctx context.Context, key string) (io.ReadCloser, *filedomain.FileAsset, error)
+}
+
+// AvatarUpload is an image uploaded as an avatar
+type AvatarUpload struct {
+	Size    int64
+	Content io.Reader
+}
+
+const (
+	avatarKeyBytes = 16
+
+	// avatarCleanupTimeout bounds deleting a replaced avatar file
+	avatarCleanupTimeout = 30 * time.Second
+)
+
+type avatarService struct {
+	accountRepo domain.AccountRepository
+	fileService filedomain.FileService
+	policy      *AvatarPolicy
+	logger      loggerDomain.Logger
+}
+
+func NewAvatarService(
+	accountRepo domain.AccountRepository,
+	fileService filedomain.FileService,
+	policy *AvatarPolicy,
+	logger loggerDomain.Logger,
+) AvatarService {
+	return &avatarService{
+		accountRepo: accountRepo,
+		fileService: fileService,
+		policy:      policy,
+		logger:      logger,
+	}
+}
+
+func (s *avatarService) UploadAvatar(ctx context.Context, orgID, accountID int32, upload *AvatarUpload) (*domain.Account, error) {
+	if upload.Size > s.policy.MaxUploadBytes {
+		return nil, domain.ErrAvatarTooLarge
+	}
+
+	// Fail before processing the image if the account is not in the organization
+	if _, err := s.accountRepo.GetByID(ctx, orgID, accountID); err != nil {
+		return nil, err
+	}
+
+	encoded, err := s.processImage(io.LimitReader(upload.Content, s.policy.MaxUploadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := s.fileService.UploadFile(ctx, &filedomain.FileUploadRequest{
+		Filename:    "avatar.jpg",
+		Size:        int64(len(encoded)),
+		ContentType: "image/jpeg",
+		Context:     files.ContextProfile,
+		Metadata: map[string]any{
+			"organization_id": orgID,
+			"account_id":      accountID,
+		},
+	}, bytes.NewReader(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("failed to store avatar: %w", err)
+	}
+
+	key, err := generateAvatarKey()
+	if err != nil {
+		s.deleteFile(asset.ID)
+		return nil, err
+	}
+
+	previous, err := s.accountRepo.SetAvatar(ctx, orgID, accountID, &domain.AccountAvatar{
+		FileID: asset.ID,
+		Key:    key,
+		URL:    s.policy.BaseURL + "/" + key,
+	})
+	if err != nil {
+		s.deleteFile(asset.ID)
+		return nil, err
+	}
+	if previous != nil {
+		s.deleteFile(*previous)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("account.avatar_updated", orgID, accountID)
+	return account, nil
+}
+
+func (s *avatarService) DeleteAvatar(ctx context.Context, orgID, accountID int32) error {
+	previous, err := s.accountRepo.SetAvatar(ctx, orgID, accountID, nil)
+	if err != nil {
+		return err
+	}
+	if previous == nil {
+		return nil
+	}
+
+	s.deleteFile(*previous)
+	s.audit("account.avatar_deleted", orgID, accountID)
+	return nil
+}
+
+func (s *avatarService) OpenAvatar(ctx context.Context, key string) (io.ReadCloser, *filedomain.FileAsset, error) {
+	fileID, err := s.accountRepo.GetAvatarFileID(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return s.fileService.DownloadFile(ctx, fileID)
+}
+
+// processImage checks that content is a JPEG or PNG image within the limits,
+// then crops it to a centered square, resizes it to the policy size and
+// encodes it as JPEG. Transparent areas become white.
+func (s *avatarService) processImage(content io.Reader) ([]byte, error) {
+	raw, err := io.ReadAll(content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read avatar: %w", err)
+	}
+	if int64(len(raw)) > s.policy.MaxUploadBytes {
+		return nil, domain.ErrAvatarTooLarge
+	}
+
+	// Check the format and dimensions from the header before decoding the pixels
+	config, format, err := image.DecodeConfig(bytes.NewReader(raw))
+	if err != nil || (format != "jpeg" && format != "png") {
+		return nil, domain.ErrAvatarInvalidImage
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > s.policy.MaxPixels {
+		return nil, domain.ErrAvatarInvalidImage
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(raw))
+	if err != nil {
+		return nil, domain.ErrAvatarInvalidImage
+	}
+
+	resized := resizeSquare(cropSquare(src), s.policy.Size)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, resized, &jpeg.Options{Quality: s.policy.Quality}); err != nil {
+		return nil, fmt.Errorf("failed to encode avatar: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// cropSquare copies the largest centered square of src onto a white background
+func cropSquare(src image.Image) *image.RGBA {
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	origin := image.Point{
+		X: bounds.Min.X + (bounds.Dx()-side)/2,
+		Y: bounds.Min.Y + (bounds.Dy()-side)/2,
+	}
+
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(square, square.Bounds(), src, origin, draw.Over)
+	return square
+}
+
+// resizeSquare scales a square image to size by size pixels. Each output pixel
+// averages the source pixels it covers, which keeps downscaled photos smooth;
+// smaller sources are scaled up by repeating pixels.
+func resizeSquare(src *image.RGBA, size int) *image.RGBA {
+	side := src.Bounds().Dx()
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+
+	for y := 0; y < size; y++ {
+		y0, y1 := scaledSpan(y, side, size)
+		for x := 0; x < size; x++ {
+			x0, x1 := scaledSpan(x, side, size)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					px := row[sx*4 : sx*4+4]
+					r += uint64(px[0])
+					g += uint64(px[1])
+					b += uint64(px[2])
+					a += uint64(px[3])
+					n++
+				}
+			}
+
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+// scaledSpan returns the source pixels [from, to) covered by output pixel i,
+// always at least one
+func scaledSpan(i, side, size int) (int, int) {
+	from := i * side / size
+	to := (i + 1) * side / size
+	if to <= from {
+		to = from + 1
+	}
+	return from, to
+}
+
+// deleteFile removes a replaced or orphaned avatar file. Failures are only
+// logged, since the account no longer references the file.
+func (s *avatarService) deleteFile(fileID int32) {
+	ctx, cancel := context.WithTimeout(context.Background(), avatarCleanupTimeout)
+	defer cancel()
+
+	if err := s.fileService.DeleteFile(ctx, fileID); err != nil {
+		s.logger.Warn("failed to delete avatar file", loggerDomain.Fields{
+			"file_id": fileID,
+			"error":   err.Error(),
+		})
+	}
+}
+
+func (s *avatarService) audit(event string, orgID, accountID int32) {
+	s.logger.Info("avatar audit", loggerDomain.Fields{
+		"audit":           true,
+		"event":           event,
+		"organization_id": orgID,
+		"account_id":      accountID,
+	})
+}
+
+func generateAvatarKey() (string, error) {
+	buf := make([]byte, avatarKeyBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate avatar key: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,287 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// CanaryService manages canary credentials (honeytokens) and raises the alarm
+// when one is used.
+//
+// API key canaries are client credentials shaped like real OAuth clients;
+// user canaries are decoy member emails. Both are planted where a leak would
+// expose them and are never used legitimately. It implements
+// auth.CanaryDetector so the auth middleware reports their use on token,
+// login-flow and organization requests.
+type CanaryService interface {
+	auth.CanaryDetector
+
+	// CreateCanary plants a canary; an API key canary's secret is only returned here
+	CreateCanary(ctx context.Context, orgID, createdBy int32, req *CreateCanaryRequest) (*CreatedCanary, error)
+
+	// ListCanaries lists the organization's canaries, newest first
+	ListCanaries(ctx context.Context, orgID int32) ([]*domain.CanaryCredential, error)
+
+	// DeleteCanary stops watching the canary
+	DeleteCanary(ctx context.Context, orgID, deletedBy, id int32) error
+}
+
+// CreateCanaryRequest represents the request to plant a canary credential
+type CreateCanaryRequest struct {
+	// Kind is api_key or user
+	Kind string `json:"kind" binding:"required"`
+	// Name records where the canary is planted, e.g. "CI secrets of repo X"
+	Name string `json:"name" binding:"required,max=255"`
+	// Email of a user canary; it must not belong to any member
+	Email string `json:"email" binding:"omitempty,email,max=255"`
+	// RotateOnTrigger revokes the organization's OAuth clients when the canary is used
+	RotateOnTrigger bool `json:"rotate_on_trigger"`
+}
+
+// CreatedCanary is returned once when a canary is planted. API key canaries
+// come with a client ID and secret shaped like real ones; the secret is not
+// stored since any use of the client ID raises the alarm.
+type CreatedCanary struct {
+	*domain.CanaryCredential
+	ClientID     string `json:"client_id,omitempty"`
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+type canaryService struct {
+	canaryRepo    domain.CanaryCredentialRepository
+	orgRepo       domain.OrganizationRepository
+	accountRepo   domain.AccountRepository
+	clientService OAuthClientService
+	authEvents    auth.AuthEventPublisher
+	eventBus      eventbus.EventBus
+	logger        loggerDomain.Logger
+}
+
+func NewCanaryService(
+	canaryRepo domain.CanaryCredentialRepository,
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	clientService OAuthClientService,
+	authEvents auth.AuthEventPublisher,
+	eventBus eventbus.EventBus,
+	logger loggerDomain.Logger,
+) CanaryService {
+	return &canaryService{
+		canaryRepo:    canaryRepo,
+		orgRepo:       orgRepo,
+		accountRepo:   accountRepo,
+		clientService: clientService,
+		authEvents:    authEvents,
+		eventBus:      eventBus,
+		logger:        logger,
+	}
+}
+
+func (s *canaryService) CreateCanary(ctx context.Context, orgID, createdBy int32, req *CreateCanaryRequest) (*CreatedCanary, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domain.ErrCanaryNameRequired
+	}
+
+	canary := &domain.CanaryCredential{
+		OrganizationID:     orgID,
+		Kind:               req.Kind,
+		Name:               name,
+		RotateOnTrigger:    req.RotateOnTrigger,
+		CreatedByAccountID: &createdBy,
+	}
+	created := &CreatedCanary{}
+
+	switch req.Kind {
+	case domain.CanaryKindAPIKey:
+		clientID, secret, err := generateOAuthClientCredentials()
+		if err != nil {
+			return nil, err
+		}
+		canary.Identifier = clientID
+		created.ClientID = clientID
+		created.ClientSecret = secret
+	case domain.CanaryKindUser:
+		email := strings.ToLower(strings.TrimSpace(req.Email))
+		if email == "" {
+			return nil, domain.ErrCanaryEmailRequired
+		}
+		// Members are detected by email in every organization, so a member's
+		// email would raise false alarms
+		memberships, err := s.accountRepo.ListMembershipsByEmail(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+		if len(memberships) > 0 {
+			return nil, domain.ErrCanaryEmailInUse
+		}
+		if _, err := s.canaryRepo.GetByIdentifier(ctx, email); err == nil {
+			return nil, domain.ErrCanaryEmailInUse
+		} else if !errors.Is(err, domain.ErrCanaryNotFound) {
+			return nil, err
+		}
+		canary.Identifier = email
+	default:
+		return nil, domain.ErrCanaryInvalidKind
+	}
+
+	canary, err := s.canaryRepo.Create(ctx, canary)
+	if err != nil {
+		return nil, err
+	}
+	created.CanaryCredential = canary
+
+	s.audit("canary.created", orgID, createdBy, loggerDomain.Fields{
+		"canary_id":         canary.ID,
+		"kind":              canary.Kind,
+		"name":              canary.Name,
+		"rotate_on_trigger": canary.RotateOnTrigger,
+	})
+
+	return created, nil
+}
+
+func (s *canaryService) ListCanaries(ctx context.Context, orgID int32) ([]*domain.CanaryCredential, error) {
+	return s.canaryRepo.ListByOrganization(ctx, orgID)
+}
+
+func (s *canaryService) DeleteCanary(ctx context.Context, orgID, deletedBy, id int32) error {
+	if err := s.canaryRepo.Delete(ctx, orgID, id); err != nil {
+		return err
+	}
+
+	s.audit("canary.deleted", orgID, deletedBy, loggerDomain.Fields{"canary_id": id})
+	return nil
+}
+
+// DetectCanary implements auth.CanaryDetector. The alert and credential
+// rotation run off the request path, so a canary request takes about as long
+// as one with an unknown credential.
+func (s *canaryService) DetectCanary(ctx context.Context, identifier string, use *auth.CanaryUse) bool {
+	identifier = strings.ToLower(strings.TrimSpace(identifier))
+	if identifier == "" {
+		return false
+	}
+
+	canary, err := s.canaryRepo.GetByIdentifier(ctx, identifier)
+	if err != nil {
+		if !errors.Is(err, domain.ErrCanaryNotFound) {
+			s.logger.Warn("failed to look up canary credential", loggerDomain.Fields{"error": err.Error()})
+		}
+		return false
+	}
+	if use.OrganizationID != 0 && use.OrganizationID != canary.OrganizationID {
+		return false
+	}
+
+	go s.trigger(context.WithoutCancel(ctx), canary, use)
+	return true
+}
+
+// trigger records a canary use, rotates credentials if the canary asks for
+// it, and publishes the alert.
+func (s *canaryService) trigger(ctx context.Context, canary *domain.CanaryCredential, use *auth.CanaryUse) {
+	if updated, err := s.canaryRepo.RecordTrigger(ctx, canary.ID); err != nil {
+		s.logger.Warn("failed to record canary trigger", loggerDomain.Fields{
+			"canary_id": canary.ID,
+			"error":     err.Error(),
+		})
+	} else {
+		canary = updated
+	}
+
+	event := events.NewCanaryTriggered(canary.OrganizationID, canary.ID, canary.Kind, canary.Name, canary.Identifier, canary.TriggerCount)
+	event.ClientIP = use.ClientIP
+	event.UserAgent = use.UserAgent
+	event.Method = use.Method
+	event.Path = use.Path
+
+	if canary.RotateOnTrigger {
+		event.RevokedClientIDs = s.revokeClients(ctx, canary)
+	}
+
+	s.logger.Error("canary credential used", loggerDomain.Fields{
+		"severity":           event.Severity,
+		"organization_id":    canary.OrganizationID,
+		"canary_id":          canary.ID,
+		"kind":               canary.Kind,
+		"name":               canary.Name,
+		"trigger_count":      canary.TriggerCount,
+		"client_ip":          use.ClientIP,
+		"user_agent":         use.UserAgent,
+		"path":               use.Path,
+		"revoked_client_ids": event.RevokedClientIDs,
+	})
+
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Error("failed to publish canary alert", loggerDomain.Fields{
+			"canary_id": canary.ID,
+			"error":     err.Error(),
+		})
+	}
+
+	// Also show the use in the organization's auth audit log
+	org, err := s.orgRepo.GetByID(ctx, canary.OrganizationID)
+	if err != nil {
+		return
+	}
+	authEvent := auth.NewAuthEvent(auth.AuthEventCanaryTriggered, nil).
+		WithRequest(use.ClientIP, use.UserAgent).
+		WithReason(canary.Kind + " canary: " + canary.Name)
+	authEvent.OrganizationID = org.StytchOrgID
+	if canary.Kind == domain.CanaryKindUser {
+		authEvent.Email = canary.Identifier
+	}
+	s.authEvents.Publish(ctx, authEvent)
+}
+
+// revokeClients revokes the organization's OAuth clients and their tokens, so
+// real keys leaked along with the canary stop working. Admins register new
+// clients and deploy their secrets.
+func (s *canaryService) revokeClients(ctx context.Context, canary *domain.CanaryCredential) []string {
+	clients, err := s.clientService.ListClients(ctx, canary.OrganizationID)
+	if err != nil {
+		s.logger.Error("failed to list oauth clients for canary rotation", loggerDomain.Fields{
+			"canary_id": canary.ID,
+			"error":     err.Error(),
+		})
+		return nil
+	}
+
+	var revokedBy int32
+	if canary.CreatedByAccountID != nil {
+		revokedBy = *canary.CreatedByAccountID
+	}
+
+	var revoked []string
+	for _, client := range clients {
+		if client.IsRevoked() {
+			continue
+		}
+		if _, err := s.clientService.RevokeClient(ctx, canary.OrganizationID, revokedBy, client.ID); err != nil {
+			s.logger.Error("failed to revoke oauth client for canary rotation", loggerDomain.Fields{
+				"canary_id": canary.ID,
+				"client_id": client.ClientID,
+				"error":     err.Error(),
+			})
+			continue
+		}
+		revoked = append(revoked, client.ClientID)
+	}
+	return revoked
+}
+
+func (s *canaryService) audit(event string, orgID, accountID int32, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = orgID
+	fields["account_id"] = accountID
+	s.logger.Info("canary audit", fields)
+}
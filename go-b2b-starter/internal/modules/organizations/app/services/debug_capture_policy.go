@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// DebugCapturePolicy controls debug captures: time-boxed recordings of an
+// organization's API traffic that operators start to reproduce an issue.
+//
+// All values can be set via environment variables with the DEBUG_CAPTURE_ prefix.
+type DebugCapturePolicy struct {
+	// DefaultDuration is how long a capture records when no duration is given
+	DefaultDuration time.Duration `mapstructure:"DEBUG_CAPTURE_DEFAULT_DURATION"`
+
+	// MaxDuration is the longest a capture may record
+	MaxDuration time.Duration `mapstructure:"DEBUG_CAPTURE_MAX_DURATION"`
+
+	// Retention is how long records are kept after they are recorded
+	Retention time.Duration `mapstructure:"DEBUG_CAPTURE_RETENTION"`
+
+	// MaxRecords is how many requests a capture records before it stops
+	MaxRecords int32 `mapstructure:"DEBUG_CAPTURE_MAX_RECORDS"`
+
+	// MaxBodyBytes caps how much of each request and response body is kept.
+	// Longer JSON bodies cannot be redacted reliably and are left out.
+	MaxBodyBytes int `mapstructure:"DEBUG_CAPTURE_MAX_BODY_BYTES"`
+
+	// RedactFields lists comma-separated fragments of header, query parameter
+	// and body field names whose values are redacted
+	RedactFields string `mapstructure:"DEBUG_CAPTURE_REDACT_FIELDS"`
+
+	// RedactFieldList is the parsed, lowercased form of RedactFields
+	RedactFieldList []string `mapstructure:"-"`
+
+	// CleanupInterval is how often expired records and ended captures are deleted. 0 disables cleanup.
+	CleanupInterval time.Duration `mapstructure:"DEBUG_CAPTURE_CLEANUP_INTERVAL"`
+}
+
+// LoadDebugCapturePolicy loads the debug capture policy from environment variables and app.env file.
+func LoadDebugCapturePolicy() (*DebugCapturePolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("DEBUG_CAPTURE_DEFAULT_DURATION", "30m")
+	v.SetDefault("DEBUG_CAPTURE_MAX_DURATION", "4h")
+	v.SetDefault("DEBUG_CAPTURE_RETENTION", "72h")
+	v.SetDefault("DEBUG_CAPTURE_MAX_RECORDS", 1000)
+	v.SetDefault("DEBUG_CAPTURE_MAX_BODY_BYTES", 65536)
+	v.SetDefault("DEBUG_CAPTURE_REDACT_FIELDS", "password,passcode,secret,token,authorization,cookie,api_key,apikey,credential,signature,ssn,card_number,cvv")
+	v.SetDefault("DEBUG_CAPTURE_CLEANUP_INTERVAL", "1h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy DebugCapturePolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode debug capture policy: %w", err)
+	}
+
+	policy.RedactFieldList = ParseRedactFields(policy.RedactFields)
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the durations and limits are usable.
+func (p *DebugCapturePolicy) Validate() error {
+	if p.DefaultDuration <= 0 || p.MaxDuration < p.DefaultDuration {
+		return fmt.Errorf("debug capture policy invalid: DEBUG_CAPTURE_DEFAULT_DURATION must be positive and at most DEBUG_CAPTURE_MAX_DURATION")
+	}
+	if p.Retention < time.Hour {
+		return fmt.Errorf("debug capture policy invalid: DEBUG_CAPTURE_RETENTION must be at least 1h")
+	}
+	if p.MaxRecords <= 0 {
+		return fmt.Errorf("debug capture policy invalid: DEBUG_CAPTURE_MAX_RECORDS must be positive")
+	}
+	if p.MaxBodyBytes <= 0 {
+		return fmt.Errorf("debug capture policy invalid: DEBUG_CAPTURE_MAX_BODY_BYTES must be positive")
+	}
+	if p.CleanupInterval < 0 {
+		return fmt.Errorf("debug capture policy invalid: DEBUG_CAPTURE_CLEANUP_INTERVAL must not be negative")
+	}
+	return nil
+}
+
+// ParseRedactFields splits a comma-separated list of field name fragments,
+// lowercasing them and dropping empty entries.
+func ParseRedactFields(fields string) []string {
+	parsed := []string{}
+	for _, field := range strings.Split(fields, ",") {
+		if field = strings.ToLower(strings.TrimSpace(field)); field != "" {
+			parsed = append(parsed, field)
+		}
+	}
+	return parsed
+}
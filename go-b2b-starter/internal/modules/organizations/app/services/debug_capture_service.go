@@ -0,0 +1,455 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// Placeholders stored instead of values that are not kept.
+const (
+	debugCaptureRedacted         = "[REDACTED]"
+	debugCaptureOmittedTruncated = "[OMITTED: body exceeds DEBUG_CAPTURE_MAX_BODY_BYTES]"
+	debugCaptureOmittedType      = "[OMITTED: content type cannot be redacted]"
+	debugCaptureOmittedInvalid   = "[OMITTED: body could not be parsed for redaction]"
+)
+
+// debugCaptureSecretHeaders are redacted whatever DEBUG_CAPTURE_REDACT_FIELDS says.
+var debugCaptureSecretHeaders = map[string]bool{
+	"Authorization":       true,
+	"Proxy-Authorization": true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+}
+
+// DebugCaptureService lets operators record an organization's API traffic for
+// a limited time to reproduce issues a customer reports.
+//
+// It implements auth.DebugCapturer so the auth middleware records the
+// organization's requests while a capture is recording. Header, query
+// parameter and JSON or form body fields whose names contain a fragment of
+// DEBUG_CAPTURE_REDACT_FIELDS (or the capture's own redact fields) are
+// redacted before anything is stored; bodies that cannot be parsed are left
+// out entirely. Records are deleted DEBUG_CAPTURE_RETENTION after they are
+// recorded.
+type DebugCaptureService interface {
+	auth.DebugCapturer
+
+	// StartCapture starts recording the organization's requests
+	StartCapture(ctx context.Context, req *StartDebugCaptureRequest) (*domain.DebugCapture, error)
+
+	// StopCapture stops a recording capture before it expires
+	StopCapture(ctx context.Context, id int32, stoppedBy string) (*domain.DebugCapture, error)
+
+	// ListCaptures lists captures newest first, optionally of one organization
+	ListCaptures(ctx context.Context, req *ListDebugCapturesRequest) ([]*domain.DebugCapture, error)
+
+	// GetCapture returns a capture
+	GetCapture(ctx context.Context, id int32) (*domain.DebugCapture, error)
+
+	// ListRecords returns a page of the capture's records in the order they were recorded
+	ListRecords(ctx context.Context, captureID int32, req *ListDebugCaptureRecordsRequest) ([]*domain.DebugCaptureRecord, error)
+
+	// Run deletes expired records and ended captures every
+	// DEBUG_CAPTURE_CLEANUP_INTERVAL until ctx is cancelled
+	Run(ctx context.Context)
+
+	// DeleteExpired deletes records past DEBUG_CAPTURE_RETENTION and captures
+	// that ended longer than DEBUG_CAPTURE_RETENTION ago
+	DeleteExpired(ctx context.Context) error
+}
+
+// StartDebugCaptureRequest represents the request to start a debug capture
+type StartDebugCaptureRequest struct {
+	OrganizationID int32 `json:"organization_id" binding:"required"`
+	// Reason records why the traffic is recorded, e.g. a support ticket
+	Reason string `json:"reason" binding:"required,max=1000"`
+	// StartedBy identifies the operator starting the capture
+	StartedBy string `json:"started_by" binding:"required,max=255"`
+	// DurationMinutes defaults to DEBUG_CAPTURE_DEFAULT_DURATION
+	DurationMinutes int `json:"duration_minutes" binding:"omitempty,min=1"`
+	// RedactFields are redacted in addition to DEBUG_CAPTURE_REDACT_FIELDS
+	RedactFields []string `json:"redact_fields" binding:"omitempty,max=50,dive,max=100"`
+}
+
+// StopDebugCaptureRequest represents the request to stop a debug capture
+type StopDebugCaptureRequest struct {
+	// StoppedBy identifies the operator stopping the capture
+	StoppedBy string `json:"stopped_by" binding:"required,max=255"`
+}
+
+// ListDebugCapturesRequest filters and pages debug captures
+type ListDebugCapturesRequest struct {
+	OrganizationID int32 `form:"organization_id"`
+	Limit          int32 `form:"limit"`
+	Offset         int32 `form:"offset"`
+}
+
+// ListDebugCaptureRecordsRequest pages the records of a debug capture
+type ListDebugCaptureRecordsRequest struct {
+	Limit  int32 `form:"limit"`
+	Offset int32 `form:"offset"`
+}
+
+type debugCaptureService struct {
+	captureRepo domain.DebugCaptureRepository
+	orgRepo     domain.OrganizationRepository
+	policy      *DebugCapturePolicy
+	tracker     jobsDomain.Tracker
+	job         jobsDomain.Definition
+	logger      loggerDomain.Logger
+}
+
+func NewDebugCaptureService(
+	captureRepo domain.DebugCaptureRepository,
+	orgRepo domain.OrganizationRepository,
+	policy *DebugCapturePolicy,
+	tracker jobsDomain.Tracker,
+	logger loggerDomain.Logger,
+) DebugCaptureService {
+	s := &debugCaptureService{
+		captureRepo: captureRepo,
+		orgRepo:     orgRepo,
+		policy:      policy,
+		tracker:     tracker,
+		job: jobsDomain.Definition{
+			Name:        "organizations.debug_capture_cleanup",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Deletes expired debug capture records and ended captures",
+			Schedule:    "every " + policy.CleanupInterval.String(),
+		},
+		logger: logger.Named("organizations"),
+	}
+	tracker.Register(s.job)
+	return s
+}
+
+func (s *debugCaptureService) StartCapture(ctx context.Context, req *StartDebugCaptureRequest) (*domain.DebugCapture, error) {
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, domain.ErrDebugCaptureReasonRequired
+	}
+
+	duration := s.policy.DefaultDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+	if duration > s.policy.MaxDuration {
+		return nil, domain.ErrDebugCaptureInvalidDuration
+	}
+
+	if _, err := s.orgRepo.GetByID(ctx, req.OrganizationID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.captureRepo.GetActive(ctx, req.OrganizationID); err == nil {
+		return nil, domain.ErrDebugCaptureActive
+	} else if !errors.Is(err, domain.ErrDebugCaptureNotFound) {
+		return nil, err
+	}
+
+	capture, err := s.captureRepo.Create(ctx, &domain.DebugCapture{
+		OrganizationID: req.OrganizationID,
+		Reason:         reason,
+		StartedBy:      req.StartedBy,
+		RedactFields:   ParseRedactFields(strings.Join(req.RedactFields, ",")),
+		MaxRecords:     s.policy.MaxRecords,
+		ExpiresAt:      time.Now().Add(duration),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("debug_capture.started", capture, req.StartedBy)
+	return capture, nil
+}
+
+func (s *debugCaptureService) StopCapture(ctx context.Context, id int32, stoppedBy string) (*domain.DebugCapture, error) {
+	capture, err := s.captureRepo.Stop(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("debug_capture.stopped", capture, stoppedBy)
+	return capture, nil
+}
+
+func (s *debugCaptureService) ListCaptures(ctx context.Context, req *ListDebugCapturesRequest) ([]*domain.DebugCapture, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 50
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	return s.captureRepo.List(ctx, req.OrganizationID, req.Limit, req.Offset)
+}
+
+func (s *debugCaptureService) GetCapture(ctx context.Context, id int32) (*domain.DebugCapture, error) {
+	return s.captureRepo.GetByID(ctx, id)
+}
+
+func (s *debugCaptureService) ListRecords(ctx context.Context, captureID int32, req *ListDebugCaptureRecordsRequest) ([]*domain.DebugCaptureRecord, error) {
+	if _, err := s.captureRepo.GetByID(ctx, captureID); err != nil {
+		return nil, err
+	}
+
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 50
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	return s.captureRepo.ListRecords(ctx, captureID, req.Limit, req.Offset)
+}
+
+// ActiveDebugCapture implements auth.DebugCapturer.
+func (s *debugCaptureService) ActiveDebugCapture(ctx context.Context, orgID int32) *auth.DebugCapture {
+	capture, err := s.captureRepo.GetActive(ctx, orgID)
+	if err != nil {
+		if !errors.Is(err, domain.ErrDebugCaptureNotFound) {
+			s.logger.Warn("failed to look up debug capture", loggerDomain.Fields{
+				"organization_id": orgID,
+				"error":           err.Error(),
+			})
+		}
+		return nil
+	}
+
+	return &auth.DebugCapture{ID: capture.ID, MaxBodyBytes: s.policy.MaxBodyBytes}
+}
+
+// RecordExchange implements auth.DebugCapturer. The exchange is redacted and
+// stored in the background so the response is not held up.
+func (s *debugCaptureService) RecordExchange(ctx context.Context, exchange *auth.CapturedExchange) {
+	if exchange.RequestContext == nil {
+		return
+	}
+
+	go s.record(context.WithoutCancel(ctx), exchange)
+}
+
+// record stores the exchange unless its capture stopped or is full.
+func (s *debugCaptureService) record(ctx context.Context, exchange *auth.CapturedExchange) {
+	reserved, err := s.captureRepo.ReserveRecord(ctx, exchange.CaptureID)
+	if err != nil || !reserved {
+		if err != nil {
+			s.logger.Warn("failed to reserve debug capture record", loggerDomain.Fields{
+				"capture_id": exchange.CaptureID,
+				"error":      err.Error(),
+			})
+		}
+		return
+	}
+
+	capture, err := s.captureRepo.GetByID(ctx, exchange.CaptureID)
+	if err != nil {
+		s.logger.Warn("failed to load debug capture", loggerDomain.Fields{
+			"capture_id": exchange.CaptureID,
+			"error":      err.Error(),
+		})
+		return
+	}
+
+	redactor := &debugCaptureRedactor{fields: append(append([]string{}, s.policy.RedactFieldList...), capture.RedactFields...)}
+
+	record := &domain.DebugCaptureRecord{
+		CaptureID:       capture.ID,
+		OrganizationID:  capture.OrganizationID,
+		Method:          exchange.Method,
+		Path:            exchange.Path,
+		Route:           exchange.Route,
+		Query:           redactor.query(exchange.Query),
+		ClientIP:        exchange.ClientIP,
+		RequestHeaders:  redactor.headers(exchange.RequestHeader),
+		RequestBody:     redactor.body(exchange.RequestHeader, exchange.RequestBody, exchange.RequestBodyTruncated),
+		Status:          int32(exchange.Status),
+		DurationMs:      int32(exchange.Duration.Milliseconds()),
+		ResponseHeaders: redactor.headers(exchange.ResponseHeader),
+		ResponseBody:    redactor.body(exchange.ResponseHeader, exchange.ResponseBody, exchange.ResponseBodyTruncated),
+		ExpiresAt:       time.Now().Add(s.policy.Retention),
+	}
+	if accountID := exchange.RequestContext.AccountID; accountID != 0 {
+		record.AccountID = &accountID
+	}
+
+	if err := s.captureRepo.CreateRecord(ctx, record); err != nil {
+		s.logger.Warn("failed to store debug capture record", loggerDomain.Fields{
+			"capture_id": capture.ID,
+			"error":      err.Error(),
+		})
+	}
+}
+
+func (s *debugCaptureService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.policy.CleanupInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("debug capture cleanup scheduler started", loggerDomain.Fields{
+		"interval":  s.policy.CleanupInterval.String(),
+		"retention": s.policy.Retention.String(),
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.DeleteExpired); err != nil {
+			s.logger.Error("debug capture cleanup run failed", loggerDomain.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *debugCaptureService) DeleteExpired(ctx context.Context) error {
+	now := time.Now()
+
+	records, err := s.captureRepo.DeleteExpiredRecords(ctx, now)
+	if err != nil {
+		return err
+	}
+	captures, err := s.captureRepo.DeleteEnded(ctx, now.Add(-s.policy.Retention))
+	if err != nil {
+		return err
+	}
+
+	if records > 0 || captures > 0 {
+		s.logger.Info("expired debug captures deleted", loggerDomain.Fields{
+			"records":  records,
+			"captures": captures,
+		})
+	}
+	return nil
+}
+
+func (s *debugCaptureService) audit(event string, capture *domain.DebugCapture, actor string) {
+	s.logger.Info("debug capture audit", loggerDomain.Fields{
+		"audit":           true,
+		"event":           event,
+		"organization_id": capture.OrganizationID,
+		"capture_id":      capture.ID,
+		"reason":          capture.Reason,
+		"expires_at":      capture.ExpiresAt,
+		"actor":           actor,
+	})
+}
+
+// debugCaptureRedactor redacts the values of fields whose lowercased names
+// contain any of its fragments.
+type debugCaptureRedactor struct {
+	fields []string
+}
+
+func (r *debugCaptureRedactor) sensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, field := range r.fields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *debugCaptureRedactor) headers(header http.Header) map[string][]string {
+	redacted := make(map[string][]string, len(header))
+	for name, values := range header {
+		if debugCaptureSecretHeaders[name] || r.sensitive(name) {
+			redacted[name] = []string{debugCaptureRedacted}
+			continue
+		}
+		redacted[name] = values
+	}
+	return redacted
+}
+
+// query redacts a raw query string. Unparseable queries are left out.
+func (r *debugCaptureRedactor) query(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		return debugCaptureOmittedInvalid
+	}
+	return r.values(values).Encode()
+}
+
+func (r *debugCaptureRedactor) values(values url.Values) url.Values {
+	for name := range values {
+		if r.sensitive(name) {
+			values[name] = []string{debugCaptureRedacted}
+		}
+	}
+	return values
+}
+
+// body redacts a JSON or form body. Other content types, truncated bodies
+// and bodies that fail to parse are left out, since their secrets cannot be
+// found reliably.
+func (r *debugCaptureRedactor) body(header http.Header, body []byte, truncated bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if truncated {
+		return debugCaptureOmittedTruncated
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return debugCaptureOmittedInvalid
+		}
+		redacted, err := json.Marshal(r.json(value))
+		if err != nil {
+			return debugCaptureOmittedInvalid
+		}
+		return string(redacted)
+	case mediaType == "application/x-www-form-urlencoded":
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return debugCaptureOmittedInvalid
+		}
+		return r.values(values).Encode()
+	default:
+		return debugCaptureOmittedType
+	}
+}
+
+func (r *debugCaptureRedactor) json(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if r.sensitive(key) {
+				v[key] = debugCaptureRedacted
+				continue
+			}
+			v[key] = r.json(field)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = r.json(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Actions taken on the data of dormant organizations. The organizations module
+// only records the action on the organization.dormant event; billing and
+// storage subscribers carry it out.
+const (
+	DormancyActionNone      = "none"
+	DormancyActionDowngrade = "downgrade"
+	DormancyActionArchive   = "archive"
+)
+
+// DormancyPolicy controls activity tracking and dormancy detection.
+//
+// All values can be set via environment variables with the DORMANCY_ prefix.
+type DormancyPolicy struct {
+	// After is how long an account or organization must go without activity
+	// before it becomes dormant
+	After time.Duration `mapstructure:"DORMANCY_AFTER"`
+
+	// CheckInterval is how often dormant accounts and organizations are detected. 0 disables detection.
+	CheckInterval time.Duration `mapstructure:"DORMANCY_CHECK_INTERVAL"`
+
+	// ActivityInterval is how often a request records its account's activity.
+	// Shorter intervals are more accurate and write to the database more often.
+	ActivityInterval time.Duration `mapstructure:"DORMANCY_ACTIVITY_INTERVAL"`
+
+	// BatchSize is how many accounts or organizations are marked dormant per query
+	BatchSize int32 `mapstructure:"DORMANCY_BATCH_SIZE"`
+
+	// OrganizationAction is what should happen to a dormant organization's data:
+	// none, downgrade (to the free plan) or archive
+	OrganizationAction string `mapstructure:"DORMANCY_ORGANIZATION_ACTION"`
+}
+
+// LoadDormancyPolicy loads the dormancy policy from environment variables and app.env file.
+func LoadDormancyPolicy() (*DormancyPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("DORMANCY_AFTER", "2160h")
+	v.SetDefault("DORMANCY_CHECK_INTERVAL", "24h")
+	v.SetDefault("DORMANCY_ACTIVITY_INTERVAL", "1h")
+	v.SetDefault("DORMANCY_BATCH_SIZE", 500)
+	v.SetDefault("DORMANCY_ORGANIZATION_ACTION", DormancyActionNone)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy DormancyPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode dormancy policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the durations, batch size and action are usable.
+func (p *DormancyPolicy) Validate() error {
+	if p.ActivityInterval < time.Minute {
+		return fmt.Errorf("dormancy policy invalid: DORMANCY_ACTIVITY_INTERVAL must be at least 1m")
+	}
+	if p.After < 24*time.Hour || p.After <= p.ActivityInterval {
+		return fmt.Errorf("dormancy policy invalid: DORMANCY_AFTER must be at least 24h and longer than DORMANCY_ACTIVITY_INTERVAL")
+	}
+	if p.CheckInterval < 0 {
+		return fmt.Errorf("dormancy policy invalid: DORMANCY_CHECK_INTERVAL must not be negative")
+	}
+	if p.BatchSize <= 0 {
+		return fmt.Errorf("dormancy policy invalid: DORMANCY_BATCH_SIZE must be positive")
+	}
+	switch p.OrganizationAction {
+	case DormancyActionNone, DormancyActionDowngrade, DormancyActionArchive:
+		return nil
+	default:
+		return fmt.Errorf("dormancy policy invalid: DORMANCY_ORGANIZATION_ACTION must be none, downgrade or archive")
+	}
+}
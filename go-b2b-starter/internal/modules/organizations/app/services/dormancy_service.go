@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+const dormancyActivityKeyPattern = "organizations:activity:%d"
+
+// DormancyService tracks member activity and moves accounts and organizations
+// that stop using the product to the dormant status.
+//
+// It implements auth.ActivityRecorder so the auth middleware records activity
+// on every organization request. Marking an account or organization dormant
+// publishes account.dormant or organization.dormant for win-back emails and
+// the configured organization action; the next request from a dormant member
+// makes both active again and publishes the reactivated events.
+type DormancyService interface {
+	auth.ActivityRecorder
+
+	// Run marks dormant accounts and organizations every
+	// DORMANCY_CHECK_INTERVAL until ctx is cancelled
+	Run(ctx context.Context)
+
+	// MarkDormant marks accounts and organizations without activity for
+	// DORMANCY_AFTER as dormant and publishes their events
+	MarkDormant(ctx context.Context) error
+}
+
+type dormancyService struct {
+	activityRepo domain.ActivityRepository
+	redis        redis.Client
+	eventBus     eventbus.EventBus
+	policy       *DormancyPolicy
+	tracker      jobsDomain.Tracker
+	job          jobsDomain.Definition
+	logger       loggerDomain.Logger
+}
+
+func NewDormancyService(
+	activityRepo domain.ActivityRepository,
+	redisClient redis.Client,
+	eventBus eventbus.EventBus,
+	policy *DormancyPolicy,
+	tracker jobsDomain.Tracker,
+	logger loggerDomain.Logger,
+) DormancyService {
+	s := &dormancyService{
+		activityRepo: activityRepo,
+		redis:        redisClient,
+		eventBus:     eventBus,
+		policy:       policy,
+		tracker:      tracker,
+		job: jobsDomain.Definition{
+			Name:        "organizations.dormancy",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Marks inactive accounts and organizations as dormant",
+			Schedule:    "every " + policy.CheckInterval.String(),
+		},
+		logger: logger.Named("organizations"),
+	}
+	tracker.Register(s.job)
+	return s
+}
+
+// RecordActivity implements auth.ActivityRecorder. Activity is written at most
+// once per DORMANCY_ACTIVITY_INTERVAL per account; Redis errors skip the write
+// rather than hitting the database on every request.
+func (s *dormancyService) RecordActivity(ctx context.Context, orgID, accountID int32) {
+	key := fmt.Sprintf(dormancyActivityKeyPattern, accountID)
+	recent, err := s.redis.Exists(ctx, key)
+	if err != nil || recent {
+		return
+	}
+	if err := s.redis.Set(ctx, key, "1", s.policy.ActivityInterval); err != nil {
+		return
+	}
+
+	if err := s.activityRepo.Record(ctx, orgID, accountID); err != nil {
+		s.logger.Warn("failed to record account activity", loggerDomain.Fields{
+			"organization_id": orgID,
+			"account_id":      accountID,
+			"error":           err.Error(),
+		})
+		return
+	}
+
+	reactivated, err := s.activityRepo.ReactivateAccount(ctx, orgID, accountID)
+	if err != nil {
+		s.logger.Warn("failed to reactivate dormant account", loggerDomain.Fields{
+			"organization_id": orgID,
+			"account_id":      accountID,
+			"error":           err.Error(),
+		})
+	} else if reactivated {
+		s.logger.Info("dormant account reactivated", loggerDomain.Fields{"organization_id": orgID, "account_id": accountID})
+		s.publish(ctx, events.NewAccountReactivated(orgID, accountID))
+	}
+
+	reactivated, err = s.activityRepo.ReactivateOrganization(ctx, orgID)
+	if err != nil {
+		s.logger.Warn("failed to reactivate dormant organization", loggerDomain.Fields{
+			"organization_id": orgID,
+			"account_id":      accountID,
+			"error":           err.Error(),
+		})
+	} else if reactivated {
+		s.logger.Info("dormant organization reactivated", loggerDomain.Fields{"organization_id": orgID, "account_id": accountID})
+		s.publish(ctx, events.NewOrganizationReactivated(orgID, accountID))
+	}
+}
+
+func (s *dormancyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.policy.CheckInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("dormancy scheduler started", loggerDomain.Fields{
+		"interval": s.policy.CheckInterval.String(),
+		"after":    s.policy.After.String(),
+		"action":   s.policy.OrganizationAction,
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.MarkDormant); err != nil {
+			s.logger.Error("dormancy run failed", loggerDomain.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *dormancyService) MarkDormant(ctx context.Context) error {
+	cutoff := time.Now().Add(-s.policy.After)
+
+	var accountCount int
+	for {
+		accounts, err := s.activityRepo.MarkDormantAccounts(ctx, cutoff, s.policy.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, account := range accounts {
+			s.publish(ctx, events.NewAccountDormant(account.OrganizationID, account.AccountID, account.Email, account.FullName, account.LastActiveAt))
+		}
+		accountCount += len(accounts)
+		if int32(len(accounts)) < s.policy.BatchSize {
+			break
+		}
+	}
+
+	var orgCount int
+	for {
+		orgs, err := s.activityRepo.MarkDormantOrganizations(ctx, cutoff, s.policy.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, org := range orgs {
+			s.publish(ctx, events.NewOrganizationDormant(org.OrganizationID, org.Slug, org.Name, org.LastActiveAt, s.policy.OrganizationAction))
+		}
+		orgCount += len(orgs)
+		if int32(len(orgs)) < s.policy.BatchSize {
+			break
+		}
+	}
+
+	if accountCount > 0 || orgCount > 0 {
+		s.logger.Info("dormant accounts and organizations marked", loggerDomain.Fields{
+			"accounts":      accountCount,
+			"organizations": orgCount,
+		})
+	}
+	return nil
+}
+
+// publish logs failed subscribers. The status change is already committed,
+// so a failed win-back email must not undo it.
+func (s *dormancyService) publish(ctx context.Context, event eventbus.Event) {
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Warn("failed to publish dormancy event", loggerDomain.Fields{
+			"event": event.EventName(),
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+)
+
+// ElevationPolicy controls just-in-time privilege elevation.
+//
+// All values can be set via environment variables with the ELEVATION_ prefix.
+type ElevationPolicy struct {
+	// DefaultDuration is used when a request does not specify a duration
+	DefaultDuration time.Duration `mapstructure:"ELEVATION_DEFAULT_DURATION"`
+
+	// MaxDuration is the longest elevation that can be requested
+	MaxDuration time.Duration `mapstructure:"ELEVATION_MAX_DURATION"`
+
+	// AutoApproveRoles lists comma-separated roles granted without an approver
+	// (e.g., "manager"). Auto-approved grants are still audited.
+	AutoApproveRoles string `mapstructure:"ELEVATION_AUTO_APPROVE_ROLES"`
+
+	// autoApprove is the parsed form of AutoApproveRoles
+	autoApprove map[auth.Role]bool
+}
+
+// LoadElevationPolicy loads the elevation policy from environment variables and app.env file.
+func LoadElevationPolicy() (*ElevationPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("ELEVATION_DEFAULT_DURATION", "1h")
+	v.SetDefault("ELEVATION_MAX_DURATION", "8h")
+	v.SetDefault("ELEVATION_AUTO_APPROVE_ROLES", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy ElevationPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode elevation policy: %w", err)
+	}
+
+	policy.autoApprove = make(map[auth.Role]bool)
+	for _, role := range strings.Split(policy.AutoApproveRoles, ",") {
+		role = strings.TrimSpace(role)
+		if role == "" {
+			continue
+		}
+		normalized := auth.NormalizeRole(role)
+		if !normalized.IsValid() {
+			return nil, fmt.Errorf("elevation policy invalid: unknown role %q in ELEVATION_AUTO_APPROVE_ROLES", role)
+		}
+		policy.autoApprove[normalized] = true
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the durations are usable.
+func (p *ElevationPolicy) Validate() error {
+	if p.MaxDuration <= 0 {
+		return fmt.Errorf("elevation policy invalid: ELEVATION_MAX_DURATION must be positive")
+	}
+	if p.DefaultDuration <= 0 || p.DefaultDuration > p.MaxDuration {
+		return fmt.Errorf("elevation policy invalid: ELEVATION_DEFAULT_DURATION must be between 0 and ELEVATION_MAX_DURATION")
+	}
+	return nil
+}
+
+// AutoApproves reports whether the policy grants the role without an approver.
+func (p *ElevationPolicy) AutoApproves(role auth.Role) bool {
+	return p.autoApprove[role]
+}
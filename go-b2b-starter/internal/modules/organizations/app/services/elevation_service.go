@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// AccessElevationService manages just-in-time privilege elevation.
+//
+// A member requests a role for a limited time with a reason; an approver
+// (or the policy) grants it and the grant expires on its own. It implements
+// auth.ElevationResolver so the auth middleware can apply active grants.
+type AccessElevationService interface {
+	auth.ElevationResolver
+
+	// RequestElevation creates an elevation request, auto-approving it if the policy allows
+	RequestElevation(ctx context.Context, orgID, accountID int32, req *RequestElevationRequest) (*domain.AccessElevation, error)
+
+	// ListElevations returns the organization's elevations, optionally filtered by status
+	ListElevations(ctx context.Context, orgID int32, status string) ([]*domain.AccessElevation, error)
+
+	// ApproveElevation grants a pending request; the window starts now
+	ApproveElevation(ctx context.Context, orgID, approverID, elevationID int32, req *DecideElevationRequest) (*domain.AccessElevation, error)
+
+	// DenyElevation rejects a pending request
+	DenyElevation(ctx context.Context, orgID, approverID, elevationID int32, req *DecideElevationRequest) (*domain.AccessElevation, error)
+
+	// RevokeElevation ends an active elevation early
+	RevokeElevation(ctx context.Context, orgID, accountID, elevationID int32) (*domain.AccessElevation, error)
+}
+
+// RequestElevationRequest represents the request to elevate privileges
+type RequestElevationRequest struct {
+	Role            string `json:"role" binding:"required"`
+	Reason          string `json:"reason" binding:"required,max=1000"`
+	DurationMinutes int32  `json:"duration_minutes" binding:"min=0"`
+}
+
+// Validate performs business validation on the elevation request
+func (r *RequestElevationRequest) Validate() error {
+	if !auth.NormalizeRole(strings.TrimSpace(r.Role)).IsValid() {
+		return domain.ErrElevationInvalidRole
+	}
+	if strings.TrimSpace(r.Reason) == "" {
+		return domain.ErrElevationReasonRequired
+	}
+	return nil
+}
+
+// DecideElevationRequest represents an approver's decision note
+type DecideElevationRequest struct {
+	Note string `json:"note" binding:"max=1000"`
+}
+
+const elevationAutoApprovedNote = "auto-approved by policy"
+
+type accessElevationService struct {
+	elevationRepo domain.AccessElevationRepository
+	policy        *ElevationPolicy
+	logger        loggerDomain.Logger
+}
+
+func NewAccessElevationService(
+	elevationRepo domain.AccessElevationRepository,
+	policy *ElevationPolicy,
+	logger loggerDomain.Logger,
+) AccessElevationService {
+	return &accessElevationService{
+		elevationRepo: elevationRepo,
+		policy:        policy,
+		logger:        logger,
+	}
+}
+
+func (s *accessElevationService) RequestElevation(ctx context.Context, orgID, accountID int32, req *RequestElevationRequest) (*domain.AccessElevation, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	duration := s.policy.DefaultDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+	if duration > s.policy.MaxDuration {
+		return nil, domain.ErrElevationInvalidDuration
+	}
+
+	role := auth.NormalizeRole(strings.TrimSpace(req.Role))
+
+	elevation, err := s.elevationRepo.Create(ctx, &domain.AccessElevation{
+		OrganizationID:  orgID,
+		AccountID:       accountID,
+		RequestedRole:   role.String(),
+		Reason:          strings.TrimSpace(req.Reason),
+		DurationMinutes: int32(duration / time.Minute),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("elevation.requested", elevation, loggerDomain.Fields{
+		"reason": elevation.Reason,
+	})
+
+	if !s.policy.AutoApproves(role) {
+		return elevation, nil
+	}
+
+	return s.decide(ctx, elevation, domain.ElevationStatusApproved, nil, elevationAutoApprovedNote)
+}
+
+func (s *accessElevationService) ListElevations(ctx context.Context, orgID int32, status string) ([]*domain.AccessElevation, error) {
+	return s.elevationRepo.ListByOrganization(ctx, orgID, status)
+}
+
+func (s *accessElevationService) ApproveElevation(ctx context.Context, orgID, approverID, elevationID int32, req *DecideElevationRequest) (*domain.AccessElevation, error) {
+	elevation, err := s.pendingForApprover(ctx, orgID, approverID, elevationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decide(ctx, elevation, domain.ElevationStatusApproved, &approverID, strings.TrimSpace(req.Note))
+}
+
+func (s *accessElevationService) DenyElevation(ctx context.Context, orgID, approverID, elevationID int32, req *DecideElevationRequest) (*domain.AccessElevation, error) {
+	elevation, err := s.pendingForApprover(ctx, orgID, approverID, elevationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.decide(ctx, elevation, domain.ElevationStatusDenied, &approverID, strings.TrimSpace(req.Note))
+}
+
+func (s *accessElevationService) RevokeElevation(ctx context.Context, orgID, accountID, elevationID int32) (*domain.AccessElevation, error) {
+	elevation, err := s.elevationRepo.Revoke(ctx, orgID, elevationID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("elevation.revoked", elevation, loggerDomain.Fields{
+		"revoked_by_account_id": accountID,
+	})
+
+	return elevation, nil
+}
+
+// ActiveElevation implements auth.ElevationResolver.
+func (s *accessElevationService) ActiveElevation(ctx context.Context, orgID, accountID int32) (*auth.Elevation, error) {
+	elevation, err := s.elevationRepo.GetActive(ctx, orgID, accountID)
+	if err != nil {
+		if err == domain.ErrElevationNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &auth.Elevation{
+		ID:        elevation.ID,
+		Role:      auth.Role(elevation.RequestedRole),
+		ExpiresAt: *elevation.ExpiresAt,
+	}, nil
+}
+
+// pendingForApprover loads a pending elevation and rejects self-approval.
+func (s *accessElevationService) pendingForApprover(ctx context.Context, orgID, approverID, elevationID int32) (*domain.AccessElevation, error) {
+	elevation, err := s.elevationRepo.GetByID(ctx, orgID, elevationID)
+	if err != nil {
+		return nil, err
+	}
+	if elevation.Status != domain.ElevationStatusPending {
+		return nil, domain.ErrElevationNotPending
+	}
+	if elevation.AccountID == approverID {
+		return nil, domain.ErrElevationSelfApproval
+	}
+	return elevation, nil
+}
+
+// decide records the decision; approvals start the elevation window.
+func (s *accessElevationService) decide(ctx context.Context, elevation *domain.AccessElevation, status string, approverID *int32, note string) (*domain.AccessElevation, error) {
+	decision := *elevation
+	decision.Status = status
+	decision.DecidedByAccountID = approverID
+	decision.DecisionNote = note
+
+	decided, err := s.elevationRepo.Decide(ctx, &decision)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := loggerDomain.Fields{
+		"decision_note": decided.DecisionNote,
+	}
+	if approverID != nil {
+		fields["decided_by_account_id"] = *approverID
+	}
+	if decided.ExpiresAt != nil {
+		fields["expires_at"] = decided.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	s.audit("elevation."+decided.Status, decided, fields)
+
+	return decided, nil
+}
+
+// audit writes an audit log entry for the elevation lifecycle.
+func (s *accessElevationService) audit(event string, elevation *domain.AccessElevation, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = elevation.OrganizationID
+	fields["account_id"] = elevation.AccountID
+	fields["elevation_id"] = elevation.ID
+	fields["role"] = elevation.RequestedRole
+	s.logger.Info("access elevation audit", fields)
+}
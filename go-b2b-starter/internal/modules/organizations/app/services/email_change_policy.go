@@ -0,0 +1,73 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// EmailChangePolicy controls how members change their account email address.
+//
+// All values can be set via environment variables with the EMAIL_CHANGE_ prefix.
+type EmailChangePolicy struct {
+	// TokenTTL is how long the confirmation link sent to the new address stays valid
+	TokenTTL time.Duration `mapstructure:"EMAIL_CHANGE_TOKEN_TTL"`
+
+	// RevertWindow is how long the old address can roll back a confirmed change.
+	// It gives the real owner time to recover from a hijacked session.
+	RevertWindow time.Duration `mapstructure:"EMAIL_CHANGE_REVERT_WINDOW"`
+
+	// ConfirmURL is the frontend page that submits the confirmation token
+	ConfirmURL string `mapstructure:"EMAIL_CHANGE_CONFIRM_URL"`
+
+	// RevertURL is the frontend page that submits the revert token
+	RevertURL string `mapstructure:"EMAIL_CHANGE_REVERT_URL"`
+}
+
+// LoadEmailChangePolicy loads the email change policy from environment variables and app.env file.
+func LoadEmailChangePolicy() (*EmailChangePolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("EMAIL_CHANGE_TOKEN_TTL", "24h")
+	v.SetDefault("EMAIL_CHANGE_REVERT_WINDOW", "168h")
+	v.SetDefault("EMAIL_CHANGE_CONFIRM_URL", "http://localhost:3000/account/email/confirm")
+	v.SetDefault("EMAIL_CHANGE_REVERT_URL", "http://localhost:3000/account/email/revert")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy EmailChangePolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode email change policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the durations and links are usable.
+func (p *EmailChangePolicy) Validate() error {
+	if p.TokenTTL <= 0 {
+		return fmt.Errorf("email change policy invalid: EMAIL_CHANGE_TOKEN_TTL must be positive")
+	}
+	if p.RevertWindow <= 0 {
+		return fmt.Errorf("email change policy invalid: EMAIL_CHANGE_REVERT_WINDOW must be positive")
+	}
+	if p.ConfirmURL == "" || p.RevertURL == "" {
+		return fmt.Errorf("email change policy invalid: EMAIL_CHANGE_CONFIRM_URL and EMAIL_CHANGE_REVERT_URL are required")
+	}
+	return nil
+}
@@ -0,0 +1,421 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// EmailChangeService lets members move their account to a new email address.
+//
+// The new address must be confirmed through an emailed link; until then the
+// old address keeps working. The old address is told about the request and,
+// once the change is confirmed, gets a link that rolls it back for a limited
+// time. Completing or rolling back a change signs the member out everywhere.
+type EmailChangeService interface {
+	// RequestChange emails a confirmation link to the new address, replacing any pending request
+	RequestChange(ctx context.Context, orgID, accountID int32, req *RequestEmailChangeRequest) (*domain.EmailChangeRequest, error)
+
+	// CancelChange cancels the signed-in member's pending request
+	CancelChange(ctx context.Context, orgID, accountID int32) error
+
+	// ConfirmChange switches the account to the new address
+	ConfirmChange(ctx context.Context, req *EmailChangeTokenRequest) (*domain.EmailChangeRequest, error)
+
+	// RevertChange cancels a pending change or rolls back a confirmed one from the old address
+	RevertChange(ctx context.Context, req *EmailChangeTokenRequest) (*domain.EmailChangeRequest, error)
+}
+
+// RequestEmailChangeRequest represents the new address requested by the member
+type RequestEmailChangeRequest struct {
+	NewEmail string `json:"new_email" binding:"required,email"`
+}
+
+// EmailChangeTokenRequest represents a token from a confirmation or revert link
+type EmailChangeTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+const (
+	emailChangeTokenBytes = 32
+
+	// emailChangeNotificationTimeout bounds each background notification
+	emailChangeNotificationTimeout = 30 * time.Second
+)
+
+type emailChangeService struct {
+	changeRepo     domain.EmailChangeRepository
+	orgRepo        domain.OrganizationRepository
+	accountRepo    domain.AccountRepository
+	authMemberRepo domain.AuthMemberRepository
+	secondaryRepo  domain.SecondaryEmailRepository
+	revoker        auth.SessionRevoker
+	denylist       auth.SessionDenylist
+	sender         emailDomain.Sender
+	policy         *EmailChangePolicy
+	logger         loggerDomain.Logger
+}
+
+func NewEmailChangeService(
+	changeRepo domain.EmailChangeRepository,
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	authMemberRepo domain.AuthMemberRepository,
+	secondaryRepo domain.SecondaryEmailRepository,
+	revoker auth.SessionRevoker,
+	denylist auth.SessionDenylist,
+	sender emailDomain.Sender,
+	policy *EmailChangePolicy,
+	logger loggerDomain.Logger,
+) EmailChangeService {
+	return &emailChangeService{
+		changeRepo:     changeRepo,
+		orgRepo:        orgRepo,
+		accountRepo:    accountRepo,
+		authMemberRepo: authMemberRepo,
+		secondaryRepo:  secondaryRepo,
+		revoker:        revoker,
+		denylist:       denylist,
+		sender:         sender,
+		policy:         policy,
+		logger:         logger,
+	}
+}
+
+func (s *emailChangeService) RequestChange(ctx context.Context, orgID, accountID int32, req *RequestEmailChangeRequest) (*domain.EmailChangeRequest, error) {
+	newEmail := strings.ToLower(strings.TrimSpace(req.NewEmail))
+	if newEmail == "" {
+		return nil, domain.ErrAccountEmailRequired
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(account.Email, newEmail) {
+		return nil, domain.ErrEmailChangeSameAddress
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+	if s.emailInUse(ctx, org, account, newEmail) {
+		return nil, domain.ErrUserAlreadyExists
+	}
+
+	confirmToken, confirmHash, err := generateEmailChangeToken()
+	if err != nil {
+		return nil, err
+	}
+	revertToken, revertHash, err := generateEmailChangeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	change, err := s.changeRepo.Create(ctx, &domain.EmailChangeRequest{
+		OrganizationID:   orgID,
+		AccountID:        accountID,
+		OldEmail:         account.Email,
+		NewEmail:         newEmail,
+		ConfirmTokenHash: confirmHash,
+		RevertTokenHash:  revertHash,
+		ExpiresAt:        time.Now().UTC().Add(s.policy.TokenTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("email_change.requested", change, loggerDomain.Fields{
+		"expires_at": change.ExpiresAt.Format(time.RFC3339),
+	})
+
+	s.notify(change, change.NewEmail, "Confirm your new email address", fmt.Sprintf(
+		"A request was made to change the email address of your %s account from %s to this address.\n\n"+
+			"Confirm the change before %s:\n%s\n\n"+
+			"If you did not request this, you can ignore this email.\n",
+		org.Name, change.OldEmail, change.ExpiresAt.Format(time.RFC1123), tokenLink(s.policy.ConfirmURL, confirmToken)))
+
+	s.notify(change, change.OldEmail, "Your email address is being changed", fmt.Sprintf(
+		"A request was made to change the email address of your %s account to %s.\n\n"+
+			"This address stays active until the new one is confirmed.\n\n"+
+			"If you did not request this, cancel it and secure your account:\n%s\n",
+		org.Name, change.NewEmail, tokenLink(s.policy.RevertURL, revertToken)))
+
+	return change, nil
+}
+
+func (s *emailChangeService) CancelChange(ctx context.Context, orgID, accountID int32) error {
+	cancelled, err := s.changeRepo.CancelPending(ctx, orgID, accountID)
+	if err != nil {
+		return err
+	}
+	if !cancelled {
+		return domain.ErrEmailChangeNotFound
+	}
+
+	s.logger.Info("email change audit", loggerDomain.Fields{
+		"audit":           true,
+		"event":           "email_change.cancelled",
+		"organization_id": orgID,
+		"account_id":      accountID,
+	})
+
+	return nil
+}
+
+func (s *emailChangeService) ConfirmChange(ctx context.Context, req *EmailChangeTokenRequest) (*domain.EmailChangeRequest, error) {
+	change, err := s.changeRepo.GetByConfirmTokenHash(ctx, hashEmailChangeToken(strings.TrimSpace(req.Token)))
+	if err != nil {
+		return nil, err
+	}
+	if change.Status != domain.EmailChangeStatusPending {
+		return nil, domain.ErrEmailChangeNotPending
+	}
+	if !time.Now().Before(change.ExpiresAt) {
+		return nil, domain.ErrEmailChangeExpired
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, change.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, change.OrganizationID, change.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve account: %w", err)
+	}
+
+	// The address was changed some other way since the request was made
+	if !strings.EqualFold(account.Email, change.OldEmail) {
+		if _, err := s.changeRepo.Cancel(ctx, change.ID); err != nil && !errors.Is(err, domain.ErrEmailChangeNotPending) {
+			return nil, err
+		}
+		return nil, domain.ErrEmailChangeNotPending
+	}
+	if s.emailInUse(ctx, org, account, change.NewEmail) {
+		return nil, domain.ErrUserAlreadyExists
+	}
+
+	if err := s.switchEmail(ctx, org, account, change.NewEmail); err != nil {
+		return nil, err
+	}
+
+	revertToken, revertHash, err := generateEmailChangeToken()
+	if err != nil {
+		return nil, err
+	}
+
+	confirmed, err := s.changeRepo.Confirm(ctx, change.ID, revertHash, time.Now().UTC().Add(s.policy.RevertWindow))
+	if err != nil {
+		return nil, err
+	}
+
+	revoked := s.revokeSessions(ctx, account)
+
+	s.audit("email_change.confirmed", confirmed, loggerDomain.Fields{
+		"revertible_until": confirmed.RevertibleUntil.Format(time.RFC3339),
+		"sessions_revoked": revoked,
+	})
+
+	s.notify(confirmed, confirmed.OldEmail, "Your email address was changed", fmt.Sprintf(
+		"The email address of your %s account was changed to %s and all sessions were signed out.\n\n"+
+			"If you did not make this change, restore this address before %s:\n%s\n",
+		org.Name, confirmed.NewEmail, confirmed.RevertibleUntil.Format(time.RFC1123), tokenLink(s.policy.RevertURL, revertToken)))
+
+	return confirmed, nil
+}
+
+func (s *emailChangeService) RevertChange(ctx context.Context, req *EmailChangeTokenRequest) (*domain.EmailChangeRequest, error) {
+	change, err := s.changeRepo.GetByRevertTokenHash(ctx, hashEmailChangeToken(strings.TrimSpace(req.Token)))
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case change.Status == domain.EmailChangeStatusPending:
+		// Nothing has changed yet; the old address only stops the request
+		cancelled, err := s.changeRepo.Cancel(ctx, change.ID)
+		if err != nil {
+			return nil, err
+		}
+		s.audit("email_change.cancelled", cancelled, loggerDomain.Fields{"cancelled_by": "old_email"})
+		return cancelled, nil
+	case !change.IsRevertible(time.Now()):
+		return nil, domain.ErrEmailChangeNotRevertible
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, change.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, change.OrganizationID, change.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve account: %w", err)
+	}
+
+	if !strings.EqualFold(account.Email, change.OldEmail) {
+		if err := s.switchEmail(ctx, org, account, change.OldEmail); err != nil {
+			return nil, err
+		}
+	}
+
+	reverted, err := s.changeRepo.Revert(ctx, change.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Whoever confirmed the change may still be signed in
+	revoked := s.revokeSessions(ctx, account)
+
+	s.audit("email_change.reverted", reverted, loggerDomain.Fields{
+		"sessions_revoked": revoked,
+	})
+
+	s.notify(reverted, reverted.OldEmail, "Your email address was restored", fmt.Sprintf(
+		"The email address of your %s account was restored to this address and all sessions were signed out.\n\n"+
+			"Sign in again and review your account security settings.\n",
+		org.Name))
+
+	return reverted, nil
+}
+
+// switchEmail updates the address in the auth provider, then locally.
+// The provider is restored if the local update fails so the two stay in step.
+func (s *emailChangeService) switchEmail(ctx context.Context, org *domain.Organization, account *domain.Account, email string) error {
+	if _, err := s.authMemberRepo.UpdateMember(ctx, &domain.UpdateAuthMemberRequest{
+		OrganizationID: org.StytchOrgID,
+		MemberID:       account.StytchMemberID,
+		EmailAddress:   &email,
+	}); err != nil {
+		return fmt.Errorf("failed to update member email: %w", err)
+	}
+
+	if _, err := s.accountRepo.UpdateEmail(ctx, account.OrganizationID, account.ID, email); err != nil {
+		if _, rollbackErr := s.authMemberRepo.UpdateMember(ctx, &domain.UpdateAuthMemberRequest{
+			OrganizationID: org.StytchOrgID,
+			MemberID:       account.StytchMemberID,
+			EmailAddress:   &account.Email,
+		}); rollbackErr != nil {
+			s.logger.Error("failed to restore member email after local update failed", loggerDomain.Fields{
+				"organization_id": account.OrganizationID,
+				"account_id":      account.ID,
+				"error":           rollbackErr.Error(),
+			})
+		}
+		return err
+	}
+
+	return nil
+}
+
+// emailInUse reports whether another member of the organization already has
+// the address, or it is a secondary address of any account. Members make one
+// of their own secondary addresses primary instead of changing to it.
+func (s *emailChangeService) emailInUse(ctx context.Context, org *domain.Organization, account *domain.Account, email string) bool {
+	if existing, err := s.accountRepo.GetByEmail(ctx, org.ID, email); err == nil && existing.ID != account.ID {
+		return true
+	}
+	if secondary, err := s.secondaryRepo.GetByAddress(ctx, org.ID, email); err == nil && !secondary.VerificationExpired(time.Now()) {
+		return true
+	}
+	if member, err := s.authMemberRepo.GetMemberByEmail(ctx, org.StytchOrgID, email); err == nil && member.MemberID != account.StytchMemberID {
+		return true
+	}
+	return false
+}
+
+// revokeSessions signs the member out everywhere. The email change already
+// happened, so failures are logged rather than returned.
+func (s *emailChangeService) revokeSessions(ctx context.Context, account *domain.Account) bool {
+	if account.StytchMemberID == "" {
+		return false
+	}
+
+	if err := s.denylist.RevokeSubject(ctx, account.StytchMemberID, time.Now()); err != nil {
+		s.logger.Error("failed to denylist member tokens after email change", loggerDomain.Fields{
+			"account_id": account.ID,
+			"error":      err.Error(),
+		})
+		return false
+	}
+	if err := s.revoker.RevokeUserSessions(ctx, account.StytchMemberID); err != nil {
+		s.logger.Error("failed to revoke member sessions after email change", loggerDomain.Fields{
+			"account_id": account.ID,
+			"error":      err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// notify emails one side of the change in the background.
+// A failed notification is logged; the change itself is already stored.
+func (s *emailChangeService) notify(change *domain.EmailChangeRequest, to, subject, body string) {
+	msg := &emailDomain.Message{
+		To:      []string{to},
+		Subject: subject,
+		Body:    body,
+	}
+
+	go func() {
+		// Don't use request context as it will be cancelled when request completes
+		sendCtx, cancel := context.WithTimeout(context.Background(), emailChangeNotificationTimeout)
+		defer cancel()
+
+		if err := s.sender.Send(sendCtx, msg); err != nil {
+			s.logger.Error("failed to send email change notification", loggerDomain.Fields{
+				"email_change_id": change.ID,
+				"subject":         subject,
+				"error":           err.Error(),
+			})
+		}
+	}()
+}
+
+// audit writes an audit log entry for the email change lifecycle.
+func (s *emailChangeService) audit(event string, change *domain.EmailChangeRequest, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = change.OrganizationID
+	fields["account_id"] = change.AccountID
+	fields["email_change_id"] = change.ID
+	s.logger.Info("email change audit", fields)
+}
+
+// tokenLink appends the token to a frontend page URL.
+func tokenLink(base, token string) string {
+	separator := "?"
+	if strings.Contains(base, "?") {
+		separator = "&"
+	}
+	return base + separator + "token=" + url.QueryEscape(token)
+}
+
+// generateEmailChangeToken returns a random link token and its stored hash.
+func generateEmailChangeToken() (string, string, error) {
+	buf := make([]byte, emailChangeTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate email change token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashEmailChangeToken(token), nil
+}
+
+func hashEmailChangeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
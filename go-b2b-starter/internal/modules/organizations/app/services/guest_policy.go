@@ -0,0 +1,78 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// minGuestSecretLength is the minimum length of the guest token signing secret
+const minGuestSecretLength = 32
+
+// GuestSessionPolicy controls anonymous guest sessions for trial experiences.
+//
+// All values can be set via environment variables with the GUEST_ prefix.
+type GuestSessionPolicy struct {
+	// Enabled turns on guest session issuance and verification
+	Enabled bool `mapstructure:"GUEST_SESSIONS_ENABLED"`
+
+	// Secret signs guest tokens (HS256); at least 32 characters
+	Secret string `mapstructure:"GUEST_SESSION_SECRET"`
+
+	// TTL is the lifetime of a guest token
+	TTL time.Duration `mapstructure:"GUEST_SESSION_TTL"`
+
+	// OrganizationID is the auth provider ID of the demo organization guests join
+	OrganizationID string `mapstructure:"GUEST_ORGANIZATION_ID"`
+}
+
+// LoadGuestSessionPolicy loads the guest session policy from environment variables and app.env file.
+func LoadGuestSessionPolicy() (*GuestSessionPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("GUEST_SESSIONS_ENABLED", false)
+	v.SetDefault("GUEST_SESSION_SECRET", "")
+	v.SetDefault("GUEST_SESSION_TTL", "2h")
+	v.SetDefault("GUEST_ORGANIZATION_ID", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy GuestSessionPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode guest session policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that an enabled policy can sign tokens for a demo organization.
+func (p *GuestSessionPolicy) Validate() error {
+	if !p.Enabled {
+		return nil
+	}
+	if len(p.Secret) < minGuestSecretLength {
+		return fmt.Errorf("guest session policy invalid: GUEST_SESSION_SECRET must be at least %d characters", minGuestSecretLength)
+	}
+	if p.TTL <= 0 {
+		return fmt.Errorf("guest session policy invalid: GUEST_SESSION_TTL must be positive")
+	}
+	if p.OrganizationID == "" {
+		return fmt.Errorf("guest session policy invalid: GUEST_ORGANIZATION_ID is required")
+	}
+	return nil
+}
@@ -0,0 +1,374 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// GuestSessionService issues anonymous, device-bound guest sessions so
+// prospects can try the product without registering.
+//
+// Each guest gets a throwaway account in the configured demo organization.
+// After signing up, the new account claims the guest session and the
+// guest's data is moved to it. It implements auth.GuestVerifier so the auth
+// middleware can accept guest tokens on routes that allow them.
+type GuestSessionService interface {
+	auth.GuestVerifier
+
+	// StartGuestSession creates a guest account and returns its token
+	StartGuestSession(ctx context.Context, req *StartGuestSessionRequest) (*GuestSession, error)
+
+	// ClaimGuestSession moves a guest's data into the caller's account and ends the guest session
+	ClaimGuestSession(ctx context.Context, orgID, accountID int32, req *ClaimGuestSessionRequest) error
+
+	// UpgradeGuestSession registers the guest as the owner of a new organization,
+	// moves the guest's data into the new account and ends the guest session
+	UpgradeGuestSession(ctx context.Context, guest *auth.Identity, req *UpgradeGuestSessionRequest) (*BootstrapOrganizationResponse, error)
+}
+
+// StartGuestSessionRequest represents the request to start a guest session
+type StartGuestSessionRequest struct {
+	DeviceID string `json:"device_id" binding:"required,min=8,max=200"`
+}
+
+// ClaimGuestSessionRequest represents the request to claim a guest session after signup
+type ClaimGuestSessionRequest struct {
+	GuestToken string `json:"guest_token" binding:"required"`
+	DeviceID   string `json:"device_id" binding:"required"`
+}
+
+// UpgradeGuestSessionRequest represents the request to turn a guest into a registered owner.
+// The owner signs in with the magic link sent to OwnerEmail.
+type UpgradeGuestSessionRequest struct {
+	OrgDisplayName string `json:"org_display_name" binding:"required"`
+	OwnerEmail     string `json:"owner_email" binding:"required,email"`
+	OwnerName      string `json:"owner_name" binding:"required"`
+}
+
+// GuestSession is returned when a guest session starts
+type GuestSession struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+const (
+	// guestTokenType marks tokens issued by this service
+	guestTokenType = "guest"
+
+	// guestEmailDomain is used for guest account emails; .invalid never resolves
+	guestEmailDomain = "guest.invalid"
+)
+
+// guestPermissions is what guests may do within the demo organization
+var guestPermissions = []auth.Permission{
+	auth.NewPermission("resource", "view"),
+	auth.NewPermission("resource", "create"),
+}
+
+// guestClaims are the claims of a guest token
+type guestClaims struct {
+	jwt.RegisteredClaims
+	TokenType  string `json:"typ"`
+	Email      string `json:"email"`
+	DeviceHash string `json:"dvc"`
+}
+
+type guestSessionService struct {
+	orgRepo       domain.OrganizationRepository
+	accountRepo   domain.AccountRepository
+	memberService MemberService
+	denylist      auth.SessionDenylist
+	claims        auth.TokenClaimsValidator
+	eventBus      eventbus.EventBus
+	policy        *GuestSessionPolicy
+	logger        loggerDomain.Logger
+}
+
+func NewGuestSessionService(
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	memberService MemberService,
+	denylist auth.SessionDenylist,
+	claims auth.TokenClaimsValidator,
+	eventBus eventbus.EventBus,
+	policy *GuestSessionPolicy,
+	logger loggerDomain.Logger,
+) GuestSessionService {
+	return &guestSessionService{
+		orgRepo:       orgRepo,
+		accountRepo:   accountRepo,
+		memberService: memberService,
+		denylist:      denylist,
+		claims:        claims,
+		eventBus:      eventBus,
+		policy:        policy,
+		logger:        logger,
+	}
+}
+
+func (s *guestSessionService) StartGuestSession(ctx context.Context, req *StartGuestSessionRequest) (*GuestSession, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrGuestSessionsDisabled
+	}
+
+	deviceID := strings.TrimSpace(req.DeviceID)
+	if deviceID == "" {
+		return nil, domain.ErrGuestDeviceRequired
+	}
+
+	org, err := s.orgRepo.GetByStytchID(ctx, s.policy.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve guest organization: %w", err)
+	}
+
+	guestID := uuid.New().String()
+	account, err := s.accountRepo.Create(ctx, &domain.Account{
+		OrganizationID: org.ID,
+		Email:          fmt.Sprintf("guest-%s@%s", guestID, guestEmailDomain),
+		FullName:       "Guest",
+		Role:           "member",
+		Status:         "active",
+		Kind:           domain.AccountKindGuest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create guest account: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.policy.TTL)
+	claims := guestClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.claims.Issuer(),
+			Audience:  jwt.ClaimStrings{s.claims.Audience()},
+			Subject:   guestID,
+			ID:        uuid.New().String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		TokenType:  guestTokenType,
+		Email:      account.Email,
+		DeviceHash: hashDeviceID(deviceID),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.policy.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign guest token: %w", err)
+	}
+
+	s.audit("guest_session.started", org.ID, account.ID, loggerDomain.Fields{
+		"guest_id":   guestID,
+		"expires_at": expiresAt.UTC().Format(time.RFC3339),
+	})
+
+	return &GuestSession{
+		Token:     token,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// IsGuestToken implements auth.GuestVerifier.
+func (s *guestSessionService) IsGuestToken(token string) bool {
+	if !s.policy.Enabled {
+		return false
+	}
+
+	var claims guestClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return false
+	}
+	return claims.TokenType == guestTokenType
+}
+
+// VerifyGuestToken implements auth.GuestVerifier.
+func (s *guestSessionService) VerifyGuestToken(ctx context.Context, token, deviceID string) (*auth.Identity, error) {
+	claims, err := s.parseGuestToken(token, deviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.Identity{
+		UserID:         claims.Subject,
+		Email:          claims.Email,
+		EmailVerified:  false,
+		OrganizationID: s.policy.OrganizationID,
+		Roles:          []auth.Role{auth.RoleMember},
+		Permissions:    guestPermissions,
+		TokenID:        claims.ID,
+		Guest:          true,
+		IssuedAt:       claims.IssuedAt.Time,
+		ExpiresAt:      claims.ExpiresAt.Time,
+	}, nil
+}
+
+func (s *guestSessionService) ClaimGuestSession(ctx context.Context, orgID, accountID int32, req *ClaimGuestSessionRequest) error {
+	claims, err := s.parseGuestToken(req.GuestToken, req.DeviceID)
+	if err != nil {
+		// Expired guests can still be claimed; only the signature and device matter
+		if !errors.Is(err, auth.ErrTokenExpired) || claims == nil {
+			return domain.ErrGuestSessionInvalid
+		}
+	}
+
+	guestOrg, err := s.orgRepo.GetByStytchID(ctx, s.policy.OrganizationID)
+	if err != nil {
+		return fmt.Errorf("failed to resolve guest organization: %w", err)
+	}
+
+	guestAccount, err := s.accountRepo.GetByEmail(ctx, guestOrg.ID, claims.Email)
+	if err != nil {
+		return domain.ErrGuestSessionInvalid
+	}
+	if !guestAccount.IsActive() {
+		return domain.ErrGuestSessionClaimed
+	}
+
+	if err := s.transferGuest(ctx, guestOrg.ID, guestAccount, claims.Subject, orgID, accountID); err != nil {
+		return err
+	}
+
+	s.audit("guest_session.claimed", guestOrg.ID, guestAccount.ID, loggerDomain.Fields{
+		"guest_id":              claims.Subject,
+		"claimed_by_org_id":     orgID,
+		"claimed_by_account_id": accountID,
+	})
+
+	return nil
+}
+
+func (s *guestSessionService) UpgradeGuestSession(ctx context.Context, guest *auth.Identity, req *UpgradeGuestSessionRequest) (*BootstrapOrganizationResponse, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrGuestSessionsDisabled
+	}
+	if guest == nil || !guest.Guest {
+		return nil, domain.ErrGuestSessionInvalid
+	}
+
+	guestOrg, err := s.orgRepo.GetByStytchID(ctx, s.policy.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve guest organization: %w", err)
+	}
+
+	guestAccount, err := s.accountRepo.GetByEmail(ctx, guestOrg.ID, guest.Email)
+	if err != nil {
+		return nil, domain.ErrGuestSessionInvalid
+	}
+	if !guestAccount.IsActive() {
+		return nil, domain.ErrGuestSessionClaimed
+	}
+
+	result, err := s.memberService.BootstrapOrganizationWithOwner(ctx, &BootstrapOrganizationRequest{
+		OrgDisplayName: req.OrgDisplayName,
+		OwnerEmail:     req.OwnerEmail,
+		OwnerName:      req.OwnerName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register guest: %w", err)
+	}
+
+	org, err := s.orgRepo.GetBySlug(ctx, result.OrgSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load registered organization: %w", err)
+	}
+	account, err := s.accountRepo.GetByEmail(ctx, org.ID, result.OwnerEmail)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load registered account: %w", err)
+	}
+
+	// The organization exists now; if the transfer fails the new owner can
+	// still claim the guest session after signing in
+	if err := s.transferGuest(ctx, guestOrg.ID, guestAccount, guest.UserID, org.ID, account.ID); err != nil {
+		return nil, err
+	}
+
+	s.audit("guest_session.upgraded", guestOrg.ID, guestAccount.ID, loggerDomain.Fields{
+		"guest_id":              guest.UserID,
+		"claimed_by_org_id":     org.ID,
+		"claimed_by_account_id": account.ID,
+	})
+
+	return result, nil
+}
+
+// transferGuest moves the guest's data to the target account, deactivates
+// the guest account and revokes every token issued to the guest.
+func (s *guestSessionService) transferGuest(ctx context.Context, guestOrgID int32, guestAccount *domain.Account, guestID string, orgID, accountID int32) error {
+	// Subscribers move the guest's data; a failure leaves the guest session claimable
+	event := events.NewGuestSessionClaimed(guestOrgID, guestAccount.ID, orgID, accountID)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		return fmt.Errorf("failed to transfer guest data: %w", err)
+	}
+
+	guestAccount.Status = "inactive"
+	if _, err := s.accountRepo.Update(ctx, guestAccount); err != nil {
+		return fmt.Errorf("failed to deactivate guest account: %w", err)
+	}
+
+	if err := s.denylist.RevokeSubject(ctx, guestID, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke guest session: %w", err)
+	}
+	return nil
+}
+
+// parseGuestToken verifies the signature and device binding of a guest token.
+// On expiry the claims are returned along with auth.ErrTokenExpired.
+func (s *guestSessionService) parseGuestToken(token, deviceID string) (*guestClaims, error) {
+	if !s.policy.Enabled {
+		return nil, auth.ErrInvalidToken
+	}
+
+	var claims guestClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		return []byte(s.policy.Secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil && !errors.Is(err, jwt.ErrTokenExpired) {
+		return nil, auth.ErrInvalidToken
+	}
+	if claims.TokenType != guestTokenType {
+		return nil, auth.ErrInvalidToken
+	}
+	if claimsErr := s.claims.Validate(guestTokenType, claims.Issuer, claims.Audience); claimsErr != nil {
+		return nil, claimsErr
+	}
+
+	deviceHash := hashDeviceID(strings.TrimSpace(deviceID))
+	if deviceID == "" || subtle.ConstantTimeCompare([]byte(deviceHash), []byte(claims.DeviceHash)) != 1 {
+		return nil, auth.ErrInvalidToken
+	}
+
+	if err != nil {
+		return &claims, auth.ErrTokenExpired
+	}
+	return &claims, nil
+}
+
+// audit writes an audit log entry for the guest session lifecycle.
+func (s *guestSessionService) audit(event string, orgID, accountID int32, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = orgID
+	fields["account_id"] = accountID
+	s.logger.Info("guest session audit", fields)
+}
+
+func hashDeviceID(deviceID string) string {
+	sum := sha256.Sum256([]byte(deviceID))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,120 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// minIdentitySecretLength is the minimum length of the identity state signing secret
+const minIdentitySecretLength = 32
+
+// IdentityPolicy controls linking Google and GitHub identities to accounts.
+//
+// A provider is enabled when its client ID is set. All values can be set via
+// environment variables with the IDENTITY_ prefix.
+type IdentityPolicy struct {
+	// Secret signs the OAuth state (HS256); at least 32 characters. Empty
+	// turns identity linking off.
+	Secret string `mapstructure:"IDENTITY_SECRET"`
+
+	// RedirectURL is the frontend page the providers redirect to. It posts
+	// the code and state from its query string back to the API, and must be
+	// registered with each provider.
+	RedirectURL string `mapstructure:"IDENTITY_REDIRECT_URL"`
+
+	// StateTTL is how long a user has to finish at the provider
+	StateTTL time.Duration `mapstructure:"IDENTITY_STATE_TTL"`
+
+	// ProviderTimeout bounds each call to a provider
+	ProviderTimeout time.Duration `mapstructure:"IDENTITY_PROVIDER_TIMEOUT"`
+
+	GoogleClientID     string `mapstructure:"IDENTITY_GOOGLE_CLIENT_ID"`
+	GoogleClientSecret string `mapstructure:"IDENTITY_GOOGLE_CLIENT_SECRET"`
+	GitHubClientID     string `mapstructure:"IDENTITY_GITHUB_CLIENT_ID"`
+	GitHubClientSecret string `mapstructure:"IDENTITY_GITHUB_CLIENT_SECRET"`
+}
+
+// LoadIdentityPolicy loads the identity linking policy from environment variables and app.env file.
+func LoadIdentityPolicy() (*IdentityPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("IDENTITY_SECRET", "")
+	v.SetDefault("IDENTITY_REDIRECT_URL", "http://localhost:3000/auth/identities/callback")
+	v.SetDefault("IDENTITY_STATE_TTL", "10m")
+	v.SetDefault("IDENTITY_PROVIDER_TIMEOUT", "10s")
+	v.SetDefault("IDENTITY_GOOGLE_CLIENT_ID", "")
+	v.SetDefault("IDENTITY_GOOGLE_CLIENT_SECRET", "")
+	v.SetDefault("IDENTITY_GITHUB_CLIENT_ID", "")
+	v.SetDefault("IDENTITY_GITHUB_CLIENT_SECRET", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy IdentityPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode identity policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the OAuth state can be signed and that each
+// enabled provider has a client secret.
+func (p *IdentityPolicy) Validate() error {
+	if !p.Enabled() {
+		return nil
+	}
+	if len(p.Secret) < minIdentitySecretLength {
+		return fmt.Errorf("identity policy invalid: IDENTITY_SECRET must be at least %d characters", minIdentitySecretLength)
+	}
+	if p.RedirectURL == "" {
+		return fmt.Errorf("identity policy invalid: IDENTITY_REDIRECT_URL is required with IDENTITY_SECRET")
+	}
+	if p.StateTTL <= 0 || p.ProviderTimeout <= 0 {
+		return fmt.Errorf("identity policy invalid: IDENTITY_STATE_TTL and IDENTITY_PROVIDER_TIMEOUT must be positive")
+	}
+	if p.GoogleClientID != "" && p.GoogleClientSecret == "" {
+		return fmt.Errorf("identity policy invalid: IDENTITY_GOOGLE_CLIENT_SECRET is required with IDENTITY_GOOGLE_CLIENT_ID")
+	}
+	if p.GitHubClientID != "" && p.GitHubClientSecret == "" {
+		return fmt.Errorf("identity policy invalid: IDENTITY_GITHUB_CLIENT_SECRET is required with IDENTITY_GITHUB_CLIENT_ID")
+	}
+	return nil
+}
+
+// Enabled reports whether identities can be linked.
+func (p *IdentityPolicy) Enabled() bool {
+	return p.Secret != ""
+}
+
+// ProviderEnabled reports whether identities at the provider can be linked.
+func (p *IdentityPolicy) ProviderEnabled(provider domain.IdentityProvider) bool {
+	if !p.Enabled() {
+		return false
+	}
+	switch provider {
+	case domain.IdentityProviderGoogle:
+		return p.GoogleClientID != ""
+	case domain.IdentityProviderGitHub:
+		return p.GitHubClientID != ""
+	default:
+		return false
+	}
+}
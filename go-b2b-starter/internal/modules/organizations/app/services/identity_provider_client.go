@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+const (
+	// OAuth endpoints of the supported identity providers
+	googleAuthorizeURL = "https://accounts.google.com/o/oauth2/v2/auth"
+	googleTokenURL     = "https://oauth2.googleapis.com/token"
+	googleUserInfoURL  = "https://openidconnect.googleapis.com/v1/userinfo"
+	githubAuthorizeURL = "https://github.com/login/oauth/authorize"
+	githubTokenURL     = "https://github.com/login/oauth/access_token"
+	githubUserURL      = "https://api.github.com/user"
+)
+
+// IdentityProviderClient runs the OAuth authorization code flow with an
+// identity provider.
+//
+// Implementations exist for Google and GitHub.
+type IdentityProviderClient interface {
+	// AuthorizationURL returns the provider page that asks the user to sign
+	// in and sends them back to the redirect URL with a code and the state
+	AuthorizationURL(state string) string
+
+	// Exchange trades an authorization code for the user who signed in.
+	// Returns domain.ErrIdentityExchangeFailed if the provider rejects the code.
+	Exchange(ctx context.Context, code string) (*domain.ProviderUser, error)
+}
+
+// NewIdentityProviderClients creates a client for each enabled provider.
+func NewIdentityProviderClients(policy *IdentityPolicy) map[domain.IdentityProvider]IdentityProviderClient {
+	httpClient := &http.Client{Timeout: policy.ProviderTimeout}
+	clients := make(map[domain.IdentityProvider]IdentityProviderClient)
+
+	if policy.ProviderEnabled(domain.IdentityProviderGoogle) {
+		clients[domain.IdentityProviderGoogle] = &oauthIdentityClient{
+			authorizeURL: googleAuthorizeURL,
+			tokenURL:     googleTokenURL,
+			userURL:      googleUserInfoURL,
+			scope:        "openid email",
+			clientID:     policy.GoogleClientID,
+			clientSecret: policy.GoogleClientSecret,
+			redirectURL:  policy.RedirectURL,
+			httpClient:   httpClient,
+			decodeUser:   decodeGoogleUser,
+		}
+	}
+	if policy.ProviderEnabled(domain.IdentityProviderGitHub) {
+		clients[domain.IdentityProviderGitHub] = &oauthIdentityClient{
+			authorizeURL: githubAuthorizeURL,
+			tokenURL:     githubTokenURL,
+			userURL:      githubUserURL,
+			scope:        "read:user",
+			clientID:     policy.GitHubClientID,
+			clientSecret: policy.GitHubClientSecret,
+			redirectURL:  policy.RedirectURL,
+			httpClient:   httpClient,
+			decodeUser:   decodeGitHubUser,
+		}
+	}
+	return clients
+}
+
+// oauthIdentityClient implements IdentityProviderClient for providers that
+// share the authorization code flow and differ only in their user endpoint.
+type oauthIdentityClient struct {
+	authorizeURL string
+	tokenURL     string
+	userURL      string
+	scope        string
+	clientID     string
+	clientSecret string
+	redirectURL  string
+	httpClient   *http.Client
+	decodeUser   func(body io.Reader) (*domain.ProviderUser, error)
+}
+
+type oauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	Error       string `json:"error,omitempty"`
+}
+
+func (c *oauthIdentityClient) AuthorizationURL(state string) string {
+	query := url.Values{}
+	query.Set("client_id", c.clientID)
+	query.Set("redirect_uri", c.redirectURL)
+	query.Set("response_type", "code")
+	query.Set("scope", c.scope)
+	query.Set("state", state)
+	return c.authorizeURL + "?" + query.Encode()
+}
+
+func (c *oauthIdentityClient) Exchange(ctx context.Context, code string) (*domain.ProviderUser, error) {
+	accessToken, err := c.exchangeCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", c.userURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create identity user request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch identity user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("identity provider returned status %d for the user", resp.StatusCode)
+	}
+
+	user, err := c.decodeUser(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if user.ID == "" {
+		return nil, fmt.Errorf("identity provider returned no user ID")
+	}
+	return user, nil
+}
+
+func (c *oauthIdentityClient) exchangeCode(ctx context.Context, code string) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", c.redirectURL)
+	form.Set("client_id", c.clientID)
+	form.Set("client_secret", c.clientSecret)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create identity token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to exchange identity code: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Rejected codes are a 400 (Google) or a 200 with an error (GitHub)
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return "", domain.ErrIdentityExchangeFailed
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("identity provider returned status %d for the code", resp.StatusCode)
+	}
+
+	var result oauthTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode identity token response: %w", err)
+	}
+	if result.Error != "" || result.AccessToken == "" {
+		return "", domain.ErrIdentityExchangeFailed
+	}
+	return result.AccessToken, nil
+}
+
+// decodeGoogleUser reads the OpenID Connect userinfo response. Unverified
+// addresses are dropped.
+func decodeGoogleUser(body io.Reader) (*domain.ProviderUser, error) {
+	var result struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+	}
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode google user: %w", err)
+	}
+
+	user := &domain.ProviderUser{ID: result.Sub}
+	if result.EmailVerified {
+		user.Email = result.Email
+	}
+	return user, nil
+}
+
+// decodeGitHubUser reads the authenticated user. The email is the public
+// profile address and is empty when the user keeps it private.
+func decodeGitHubUser(body io.Reader) (*domain.ProviderUser, error) {
+	var result struct {
+		ID    int64  `json:"id"`
+		Email string `json:"email"`
+	}
+	if err := json.NewDecoder(body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode github user: %w", err)
+	}
+	if result.ID == 0 {
+		return &domain.ProviderUser{}, nil
+	}
+	return &domain.ProviderUser{ID: strconv.FormatInt(result.ID, 10), Email: result.Email}, nil
+}
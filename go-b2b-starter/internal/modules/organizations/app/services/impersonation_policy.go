@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// minImpersonationSecretLength is the minimum length of the impersonation token signing secret
+const minImpersonationSecretLength = 32
+
+// ImpersonationPolicy controls admin impersonation of organization members.
+//
+// All values can be set via environment variables with the IMPERSONATION_ prefix.
+type ImpersonationPolicy struct {
+	// Enabled turns on impersonation token issuance and verification
+	Enabled bool `mapstructure:"IMPERSONATION_ENABLED"`
+
+	// Secret signs impersonation tokens (HS256); at least 32 characters
+	Secret string `mapstructure:"IMPERSONATION_SECRET"`
+
+	// DefaultDuration is used when a request does not specify a duration
+	DefaultDuration time.Duration `mapstructure:"IMPERSONATION_DEFAULT_DURATION"`
+
+	// MaxDuration caps impersonation tokens; longer-lived tokens are rejected
+	// even if they were issued under a more permissive policy
+	MaxDuration time.Duration `mapstructure:"IMPERSONATION_MAX_DURATION"`
+}
+
+// LoadImpersonationPolicy loads the impersonation policy from environment variables and app.env file.
+func LoadImpersonationPolicy() (*ImpersonationPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("IMPERSONATION_ENABLED", false)
+	v.SetDefault("IMPERSONATION_SECRET", "")
+	v.SetDefault("IMPERSONATION_DEFAULT_DURATION", "15m")
+	v.SetDefault("IMPERSONATION_MAX_DURATION", "1h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy ImpersonationPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode impersonation policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that an enabled policy can sign tokens with sane durations.
+func (p *ImpersonationPolicy) Validate() error {
+	if !p.Enabled {
+		return nil
+	}
+	if len(p.Secret) < minImpersonationSecretLength {
+		return fmt.Errorf("impersonation policy invalid: IMPERSONATION_SECRET must be at least %d characters", minImpersonationSecretLength)
+	}
+	if p.DefaultDuration <= 0 || p.MaxDuration <= 0 {
+		return fmt.Errorf("impersonation policy invalid: durations must be positive")
+	}
+	if p.DefaultDuration > p.MaxDuration {
+		return fmt.Errorf("impersonation policy invalid: IMPERSONATION_DEFAULT_DURATION exceeds IMPERSONATION_MAX_DURATION")
+	}
+	return nil
+}
@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// ImpersonationService lets org admins act as a member of their organization
+// to reproduce what the member sees.
+//
+// Impersonation tokens are short-lived, cannot be extended and carry the
+// admin's ID in an impersonated_by claim. Starting and ending an
+// impersonation, and every request made with the token, are audit logged. It
+// implements auth.ImpersonationVerifier so the auth middleware can accept
+// impersonation tokens.
+type ImpersonationService interface {
+	auth.ImpersonationVerifier
+
+	// StartImpersonation issues an impersonation token for the member
+	StartImpersonation(ctx context.Context, orgID, adminAccountID int32, admin *auth.Identity, memberID string, req *StartImpersonationRequest) (*Impersonation, error)
+
+	// EndImpersonation revokes the impersonation token the request was made with
+	EndImpersonation(ctx context.Context, orgID, accountID int32, identity *auth.Identity) error
+}
+
+// StartImpersonationRequest represents the request to impersonate a member
+type StartImpersonationRequest struct {
+	Reason          string `json:"reason" binding:"required,max=1000"`
+	DurationMinutes int32  `json:"duration_minutes" binding:"min=0"`
+}
+
+// Impersonation is returned when an impersonation starts
+type Impersonation struct {
+	ID        string    `json:"id"`
+	Token     string    `json:"token"`
+	MemberID  string    `json:"member_id"`
+	AccountID int32     `json:"account_id"`
+	Email     string    `json:"email"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// impersonationTokenType marks tokens issued by this service
+const impersonationTokenType = "impersonation"
+
+// impersonationClaims are the claims of an impersonation token
+type impersonationClaims struct {
+	jwt.RegisteredClaims
+	TokenType         string `json:"typ"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	OrganizationID    string `json:"org"`
+	Role              string `json:"role"`
+	ImpersonatedBy    string `json:"impersonated_by"`
+	ImpersonatorEmail string `json:"impersonator_email"`
+	Reason            string `json:"reason"`
+}
+
+type impersonationService struct {
+	accountRepo     domain.AccountRepository
+	roleAssignments auth.RoleAssignmentResolver
+	elevations      auth.ElevationResolver
+	denylist        auth.SessionDenylist
+	claims          auth.TokenClaimsValidator
+	policy          *ImpersonationPolicy
+	logger          loggerDomain.Logger
+}
+
+func NewImpersonationService(
+	accountRepo domain.AccountRepository,
+	roleAssignments auth.RoleAssignmentResolver,
+	elevations auth.ElevationResolver,
+	denylist auth.SessionDenylist,
+	claims auth.TokenClaimsValidator,
+	policy *ImpersonationPolicy,
+	logger loggerDomain.Logger,
+) ImpersonationService {
+	return &impersonationService{
+		accountRepo:     accountRepo,
+		roleAssignments: roleAssignments,
+		elevations:      elevations,
+		denylist:        denylist,
+		claims:          claims,
+		policy:          policy,
+		logger:          logger,
+	}
+}
+
+func (s *impersonationService) StartImpersonation(
+	ctx context.Context,
+	orgID, adminAccountID int32,
+	admin *auth.Identity,
+	memberID string,
+	req *StartImpersonationRequest,
+) (*Impersonation, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrImpersonationDisabled
+	}
+	if admin.IsImpersonated() {
+		return nil, domain.ErrImpersonationNested
+	}
+
+	reason := strings.TrimSpace(req.Reason)
+	if reason == "" {
+		return nil, domain.ErrImpersonationReasonRequired
+	}
+
+	duration := s.policy.DefaultDuration
+	if req.DurationMinutes > 0 {
+		duration = time.Duration(req.DurationMinutes) * time.Minute
+	}
+	if duration > s.policy.MaxDuration {
+		return nil, domain.ErrImpersonationInvalidDuration
+	}
+
+	target, err := findAccountByMemberID(ctx, s.accountRepo, orgID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if target.ID == adminAccountID {
+		return nil, domain.ErrImpersonationSelf
+	}
+	if !target.IsActive() || target.StytchMemberID == "" {
+		return nil, domain.ErrImpersonationInvalidTarget
+	}
+
+	// The token carries only the provider role, but the middleware layers the
+	// target's role assignments and elevation onto it on every request, so
+	// check what the admin would actually act with
+	role := auth.NormalizeRole(target.Role)
+	effective, err := s.effectiveIdentity(ctx, orgID, target.ID, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkImpersonationTarget(admin, effective); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(duration)
+	claims := impersonationClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.claims.Issuer(),
+			Audience:  jwt.ClaimStrings{s.claims.Audience()},
+			Subject:   target.StytchMemberID,
+			ID:        uuid.New().String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		TokenType:         impersonationTokenType,
+		Email:             target.Email,
+		EmailVerified:     target.StytchEmailVerified,
+		OrganizationID:    admin.OrganizationID,
+		Role:              role.String(),
+		ImpersonatedBy:    admin.UserID,
+		ImpersonatorEmail: admin.Email,
+		Reason:            reason,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.policy.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign impersonation token: %w", err)
+	}
+
+	s.audit("impersonation.started", orgID, target.ID, loggerDomain.Fields{
+		"impersonation_id":        claims.ID,
+		"member_id":               target.StytchMemberID,
+		"impersonated_by":         admin.UserID,
+		"impersonator_account_id": adminAccountID,
+		"impersonator_email":      admin.Email,
+		"reason":                  reason,
+		"expires_at":              expiresAt.UTC().Format(time.RFC3339),
+	})
+
+	return &Impersonation{
+		ID:        claims.ID,
+		Token:     token,
+		MemberID:  target.StytchMemberID,
+		AccountID: target.ID,
+		Email:     target.Email,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (s *impersonationService) EndImpersonation(ctx context.Context, orgID, accountID int32, identity *auth.Identity) error {
+	if !identity.IsImpersonated() {
+		return domain.ErrImpersonationNotActive
+	}
+
+	if err := s.denylist.RevokeToken(ctx, identity.TokenID, identity.ExpiresAt); err != nil {
+		return fmt.Errorf("failed to revoke impersonation token: %w", err)
+	}
+
+	s.audit("impersonation.ended", orgID, accountID, loggerDomain.Fields{
+		"impersonation_id": identity.TokenID,
+		"member_id":        identity.UserID,
+		"impersonated_by":  identity.ImpersonatedBy(),
+	})
+
+	return nil
+}
+
+// effectiveIdentity returns an identity holding the target's provider role,
+// assigned roles and active elevation.
+func (s *impersonationService) effectiveIdentity(ctx context.Context, orgID, accountID int32, role auth.Role) (*auth.Identity, error) {
+	identity := &auth.Identity{
+		Roles:       []auth.Role{role},
+		Permissions: auth.GetRolePermissions(role),
+	}
+
+	roles, err := s.roleAssignments.AssignedRoles(ctx, orgID, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve role assignments: %w", err)
+	}
+	if len(roles) > 0 {
+		identity = identity.WithRoles(roles...)
+	}
+
+	elevation, err := s.elevations.ActiveElevation(ctx, orgID, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve privilege elevation: %w", err)
+	}
+	if elevation != nil {
+		identity = identity.WithElevation(elevation)
+	}
+
+	return identity, nil
+}
+
+// checkImpersonationTarget refuses targets that can manage the organization,
+// since acting as another admin would hide admin actions behind a second
+// identity, and targets holding permissions the admin does not have.
+func checkImpersonationTarget(admin, target *auth.Identity) error {
+	if target.HasRole(auth.RoleAdmin) || target.HasPermission(auth.PermOrgManage) {
+		return domain.ErrImpersonationTargetAdmin
+	}
+	for _, perm := range target.Permissions {
+		if !admin.HasPermission(perm) {
+			return domain.ErrImpersonationTargetPrivileged
+		}
+	}
+	return nil
+}
+
+// IsImpersonationToken implements auth.ImpersonationVerifier.
+func (s *impersonationService) IsImpersonationToken(token string) bool {
+	if !s.policy.Enabled {
+		return false
+	}
+
+	var claims impersonationClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return false
+	}
+	return claims.TokenType == impersonationTokenType
+}
+
+// VerifyImpersonationToken implements auth.ImpersonationVerifier.
+func (s *impersonationService) VerifyImpersonationToken(ctx context.Context, token string) (*auth.Identity, error) {
+	if !s.policy.Enabled {
+		return nil, auth.ErrInvalidToken
+	}
+
+	var claims impersonationClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		return []byte(s.policy.Secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, auth.ErrTokenExpired
+		}
+		return nil, auth.ErrInvalidToken
+	}
+
+	if claims.TokenType != impersonationTokenType {
+		return nil, auth.ErrInvalidToken
+	}
+	if err := s.claims.Validate(impersonationTokenType, claims.Issuer, claims.Audience); err != nil {
+		return nil, err
+	}
+
+	// Forced expiry: tokens outliving the current maximum are rejected
+	if claims.IssuedAt == nil || claims.ExpiresAt.Sub(claims.IssuedAt.Time) > s.policy.MaxDuration {
+		return nil, auth.ErrTokenExpired
+	}
+	if claims.Subject == "" || claims.ImpersonatedBy == "" || claims.OrganizationID == "" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	// Revoking the admin's sessions also ends their impersonations
+	revoked, err := s.denylist.IsRevoked(ctx, &auth.Identity{
+		UserID:   claims.ImpersonatedBy,
+		IssuedAt: claims.IssuedAt.Time,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check impersonator sessions: %w", err)
+	}
+	if revoked {
+		return nil, auth.ErrSessionRevoked
+	}
+
+	role := auth.Role(claims.Role)
+	return &auth.Identity{
+		UserID:         claims.Subject,
+		Email:          claims.Email,
+		EmailVerified:  claims.EmailVerified,
+		OrganizationID: claims.OrganizationID,
+		Roles:          []auth.Role{role},
+		Permissions:    auth.GetRolePermissions(role),
+		TokenID:        claims.ID,
+		IssuedAt:       claims.IssuedAt.Time,
+		ExpiresAt:      claims.ExpiresAt.Time,
+		Raw: map[string]any{
+			auth.ImpersonatedByClaim:      claims.ImpersonatedBy,
+			auth.ImpersonatorEmailClaim:   claims.ImpersonatorEmail,
+			auth.ImpersonationReasonClaim: claims.Reason,
+		},
+	}, nil
+}
+
+// AuditImpersonatedRequest implements auth.ImpersonationVerifier.
+func (s *impersonationService) AuditImpersonatedRequest(ctx context.Context, identity *auth.Identity, request *auth.ImpersonatedRequest) {
+	var orgID, accountID int32
+	if request.RequestContext != nil {
+		orgID = request.RequestContext.OrganizationID
+		accountID = request.RequestContext.AccountID
+	}
+
+	s.audit("impersonation.request", orgID, accountID, loggerDomain.Fields{
+		"impersonation_id": identity.TokenID,
+		"member_id":        identity.UserID,
+		"provider_org_id":  identity.OrganizationID,
+		"impersonated_by":  identity.ImpersonatedBy(),
+		"method":           request.Method,
+		"path":             request.Path,
+		"route":            request.Route,
+		"client_ip":        request.ClientIP,
+		"status":           request.Status,
+		"duration_ms":      request.Duration.Milliseconds(),
+	})
+}
+
+// audit writes an audit log entry for impersonation.
+func (s *impersonationService) audit(event string, orgID, accountID int32, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = orgID
+	fields["account_id"] = accountID
+	s.logger.Info("impersonation audit", fields)
+}
@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+type stubRoleAssignments []auth.Role
+
+func (s stubRoleAssignments) AssignedRoles(ctx context.Context, orgID, accountID int32) ([]auth.Role, error) {
+	return s, nil
+}
+
+type stubElevations struct {
+	elevation *auth.Elevation
+}
+
+func (s stubElevations) ActiveElevation(ctx context.Context, orgID, accountID int32) (*auth.Elevation, error) {
+	return s.elevation, nil
+}
+
+func TestImpersonationTargetEffectivePermissions(t *testing.T) {
+	admin := &auth.Identity{
+		Roles:       []auth.Role{auth.RoleAdmin},
+		Permissions: auth.GetRolePermissions(auth.RoleAdmin),
+	}
+	// An admin on a custom role that manages the organization but cannot
+	// approve resources
+	limitedAdmin := &auth.Identity{
+		Roles:       []auth.Role{"org_manager"},
+		Permissions: []auth.Permission{auth.PermOrgView, auth.PermOrgManage, auth.PermResourceView, auth.PermResourceCreate},
+	}
+	elevatedToAdmin := &auth.Elevation{ID: 1, Role: auth.RoleAdmin, ExpiresAt: time.Now().Add(time.Hour)}
+	elevatedToManager := &auth.Elevation{ID: 2, Role: auth.RoleManager, ExpiresAt: time.Now().Add(time.Hour)}
+
+	tests := []struct {
+		name      string
+		admin     *auth.Identity
+		role      auth.Role
+		assigned  stubRoleAssignments
+		elevation *auth.Elevation
+		want      error
+	}{
+		{name: "member", admin: admin, role: auth.RoleMember},
+		{name: "provider admin", admin: admin, role: auth.RoleAdmin, want: domain.ErrImpersonationTargetAdmin},
+		{name: "assigned admin", admin: admin, role: auth.RoleMember, assigned: stubRoleAssignments{auth.RoleAdmin}, want: domain.ErrImpersonationTargetAdmin},
+		{name: "elevated to admin", admin: admin, role: auth.RoleMember, elevation: elevatedToAdmin, want: domain.ErrImpersonationTargetAdmin},
+		{name: "member under limited admin", admin: limitedAdmin, role: auth.RoleMember},
+		{name: "manager under limited admin", admin: limitedAdmin, role: auth.RoleManager, want: domain.ErrImpersonationTargetPrivileged},
+		{name: "assigned manager under limited admin", admin: limitedAdmin, role: auth.RoleMember, assigned: stubRoleAssignments{auth.RoleManager}, want: domain.ErrImpersonationTargetPrivileged},
+		{name: "elevated to manager under limited admin", admin: limitedAdmin, role: auth.RoleMember, elevation: elevatedToManager, want: domain.ErrImpersonationTargetPrivileged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &impersonationService{
+				roleAssignments: tt.assigned,
+				elevations:      stubElevations{elevation: tt.elevation},
+			}
+
+			target, err := s.effectiveIdentity(context.Background(), 1, 2, tt.role)
+			if err != nil {
+				t.Fatalf("effectiveIdentity() error = %v", err)
+			}
+			if err := checkImpersonationTarget(tt.admin, target); !errors.Is(err, tt.want) {
+				t.Errorf("checkImpersonationTarget() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// InviteCleanupService deletes invites that can no longer be accepted, so
+// expired and revoked tokens don't pile up in the database.
+type InviteCleanupService interface {
+	// Run deletes stale invites every AUTH_INVITE_CLEANUP_INTERVAL until ctx
+	// is cancelled
+	Run(ctx context.Context)
+
+	// DeleteStale deletes invites that expired or were revoked more than
+	// AUTH_INVITE_RETENTION ago. Accepted invites are kept.
+	DeleteStale(ctx context.Context) error
+}
+
+type inviteCleanupService struct {
+	inviteRepo domain.InviteRepository
+	policy     *InvitePolicy
+	tracker    jobsDomain.Tracker
+	job        jobsDomain.Definition
+	logger     loggerDomain.Logger
+}
+
+func NewInviteCleanupService(
+	inviteRepo domain.InviteRepository,
+	policy *InvitePolicy,
+	tracker jobsDomain.Tracker,
+	logger loggerDomain.Logger,
+) InviteCleanupService {
+	s := &inviteCleanupService{
+		inviteRepo: inviteRepo,
+		policy:     policy,
+		tracker:    tracker,
+		job: jobsDomain.Definition{
+			Name:        "organizations.invite_cleanup",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Deletes expired and revoked organization invites",
+			Schedule:    "every " + policy.CleanupInterval.String(),
+		},
+		logger: logger.Named("organizations"),
+	}
+	tracker.Register(s.job)
+	return s
+}
+
+func (s *inviteCleanupService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.policy.CleanupInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("invite cleanup scheduler started", loggerDomain.Fields{
+		"interval":  s.policy.CleanupInterval.String(),
+		"retention": s.policy.Retention.String(),
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.DeleteStale); err != nil {
+			s.logger.Error("invite cleanup run failed", loggerDomain.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *inviteCleanupService) DeleteStale(ctx context.Context) error {
+	deleted, err := s.inviteRepo.DeleteBefore(ctx, time.Now().Add(-s.policy.Retention))
+	if err != nil {
+		return err
+	}
+
+	if deleted > 0 {
+		s.logger.Info("stale invites deleted", loggerDomain.Fields{"count": deleted})
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// InvitePolicy controls organization invites and invite-only registration.
+//
+// All values can be set via environment variables with the AUTH_INVITE_ prefix.
+type InvitePolicy struct {
+	// InviteOnly closes public signup; new members can only join through an invite
+	InviteOnly bool `mapstructure:"AUTH_INVITE_ONLY"`
+
+	// TTL is how long an invite stays valid when the admin does not pick an expiry
+	TTL time.Duration `mapstructure:"AUTH_INVITE_TTL"`
+
+	// MaxTTL caps the expiry an admin can pick for an invite
+	MaxTTL time.Duration `mapstructure:"AUTH_INVITE_MAX_TTL"`
+
+	// AcceptURL is the frontend page that submits the invite token
+	AcceptURL string `mapstructure:"AUTH_INVITE_ACCEPT_URL"`
+
+	// Retention is how long expired and revoked invites are kept before cleanup
+	Retention time.Duration `mapstructure:"AUTH_INVITE_RETENTION"`
+
+	// CleanupInterval is how often expired and revoked invites are deleted. 0 disables the cleanup.
+	CleanupInterval time.Duration `mapstructure:"AUTH_INVITE_CLEANUP_INTERVAL"`
+}
+
+// LoadInvitePolicy loads the invite policy from environment variables and app.env file.
+func LoadInvitePolicy() (*InvitePolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("AUTH_INVITE_ONLY", false)
+	v.SetDefault("AUTH_INVITE_TTL", "168h")
+	v.SetDefault("AUTH_INVITE_MAX_TTL", "720h")
+	v.SetDefault("AUTH_INVITE_ACCEPT_URL", "http://localhost:3000/invite/accept")
+	v.SetDefault("AUTH_INVITE_RETENTION", "720h")
+	v.SetDefault("AUTH_INVITE_CLEANUP_INTERVAL", "1h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy InvitePolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode invite policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the durations and link are usable.
+func (p *InvitePolicy) Validate() error {
+	if p.TTL <= 0 {
+		return fmt.Errorf("invite policy invalid: AUTH_INVITE_TTL must be positive")
+	}
+	if p.MaxTTL < p.TTL {
+		return fmt.Errorf("invite policy invalid: AUTH_INVITE_MAX_TTL must be at least AUTH_INVITE_TTL")
+	}
+	if p.AcceptURL == "" {
+		return fmt.Errorf("invite policy invalid: AUTH_INVITE_ACCEPT_URL is required")
+	}
+	if p.Retention < 0 {
+		return fmt.Errorf("invite policy invalid: AUTH_INVITE_RETENTION must not be negative")
+	}
+	if p.CleanupInterval < 0 {
+		return fmt.Errorf("invite policy invalid: AUTH_INVITE_CLEANUP_INTERVAL must not be negative")
+	}
+	return nil
+}
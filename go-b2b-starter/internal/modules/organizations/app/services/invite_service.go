@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// InviteService manages organization invites.
+//
+// Admins create invites, optionally pinned to an email address and a role.
+// The token is shown once and, for pinned invites, emailed to the invitee.
+// Accepting an invite registers the invitee as a member of the organization.
+// With AUTH_INVITE_ONLY set, invites are the only way to register.
+type InviteService interface {
+	// CreateInvite creates an invite to orgID on behalf of accountID
+	CreateInvite(ctx context.Context, orgID, accountID int32, req *CreateInviteRequest) (*CreateInviteResponse, error)
+
+	// ListInvites returns the invites of orgID, newest first
+	ListInvites(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.Invite, error)
+
+	// RevokeInvite revokes a pending invite so it can no longer be accepted
+	RevokeInvite(ctx context.Context, orgID, inviteID int32) (*domain.Invite, error)
+
+	// AcceptInvite registers the invitee as a member of the inviting organization
+	AcceptInvite(ctx context.Context, req *AcceptInviteRequest) (*AddMemberResponse, error)
+}
+
+// CreateInviteRequest represents the request to create an invite
+type CreateInviteRequest struct {
+	// Email pins the invite to one address; leave empty for a shareable invite
+	Email string `json:"email" binding:"omitempty,email"`
+
+	// RoleSlug is the role the invitee gets; defaults to "member"
+	RoleSlug string `json:"role_slug"`
+
+	// ExpiresInHours overrides the default invite lifetime
+	ExpiresInHours *int `json:"expires_in_hours,omitempty"`
+}
+
+// CreateInviteResponse returns the invite with its token, which is only shown once
+type CreateInviteResponse struct {
+	Invite *domain.Invite `json:"invite"`
+	Token  string         `json:"token"`
+	Link   string         `json:"link"`
+}
+
+// AcceptInviteRequest represents the invitee's registration details
+type AcceptInviteRequest struct {
+	Token string `json:"token" binding:"required"`
+	Email string `json:"email" binding:"required,email"`
+	Name  string `json:"name" binding:"required"`
+}
+
+const (
+	inviteTokenBytes = 32
+
+	defaultInviteRole = "member"
+
+	// inviteNotificationTimeout bounds each background notification
+	inviteNotificationTimeout = 30 * time.Second
+)
+
+type inviteService struct {
+	inviteRepo    domain.InviteRepository
+	orgRepo       domain.OrganizationRepository
+	authRoleRepo  domain.AuthRoleRepository
+	memberService MemberService
+	sender        emailDomain.Sender
+	policy        *InvitePolicy
+	logger        loggerDomain.Logger
+}
+
+func NewInviteService(
+	inviteRepo domain.InviteRepository,
+	orgRepo domain.OrganizationRepository,
+	authRoleRepo domain.AuthRoleRepository,
+	memberService MemberService,
+	sender emailDomain.Sender,
+	policy *InvitePolicy,
+	logger loggerDomain.Logger,
+) InviteService {
+	return &inviteService{
+		inviteRepo:    inviteRepo,
+		orgRepo:       orgRepo,
+		authRoleRepo:  authRoleRepo,
+		memberService: memberService,
+		sender:        sender,
+		policy:        policy,
+		logger:        logger,
+	}
+}
+
+func (s *inviteService) CreateInvite(ctx context.Context, orgID, accountID int32, req *CreateInviteRequest) (*CreateInviteResponse, error) {
+	ttl := s.policy.TTL
+	if req.ExpiresInHours != nil {
+		ttl = time.Duration(*req.ExpiresInHours) * time.Hour
+		if ttl <= 0 || ttl > s.policy.MaxTTL {
+			return nil, domain.ErrInviteInvalidTTL
+		}
+	}
+
+	roleSlug := strings.ToLower(strings.TrimSpace(req.RoleSlug))
+	if roleSlug == "" {
+		roleSlug = defaultInviteRole
+	}
+	if _, err := s.authRoleRepo.GetRoleBySlug(ctx, roleSlug); err != nil {
+		if errors.Is(err, domain.ErrAuthRoleNotFound) {
+			return nil, domain.ErrInviteRoleInvalid
+		}
+		return nil, fmt.Errorf("failed to resolve invite role: %w", err)
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+
+	token, tokenHash, err := generateInviteToken()
+	if err != nil {
+		return nil, err
+	}
+
+	invite, err := s.inviteRepo.Create(ctx, &domain.Invite{
+		OrganizationID:     orgID,
+		CreatedByAccountID: &accountID,
+		Email:              strings.ToLower(strings.TrimSpace(req.Email)),
+		RoleSlug:           roleSlug,
+		TokenHash:          tokenHash,
+		ExpiresAt:          time.Now().UTC().Add(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("invite.created", invite, loggerDomain.Fields{
+		"account_id": accountID,
+		"pinned":     invite.Email != "",
+		"expires_at": invite.ExpiresAt.Format(time.RFC3339),
+	})
+
+	link := tokenLink(s.policy.AcceptURL, token)
+	if invite.Email != "" {
+		s.notify(invite, fmt.Sprintf("You're invited to join %s", org.Name), fmt.Sprintf(
+			"You have been invited to join %s as %s.\n\n"+
+				"Accept the invite before %s:\n%s\n\n"+
+				"If you were not expecting this, you can ignore this email.\n",
+			org.Name, invite.RoleSlug, invite.ExpiresAt.Format(time.RFC1123), link))
+	}
+
+	return &CreateInviteResponse{
+		Invite: invite,
+		Token:  token,
+		Link:   link,
+	}, nil
+}
+
+func (s *inviteService) ListInvites(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.Invite, error) {
+	invites, err := s.inviteRepo.ListByOrganization(ctx, orgID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, invite := range invites {
+		if invite.IsExpired(now) {
+			invite.Status = domain.InviteStatusExpired
+		}
+	}
+
+	return invites, nil
+}
+
+func (s *inviteService) RevokeInvite(ctx context.Context, orgID, inviteID int32) (*domain.Invite, error) {
+	invite, err := s.inviteRepo.Revoke(ctx, orgID, inviteID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("invite.revoked", invite, loggerDomain.Fields{})
+
+	return invite, nil
+}
+
+func (s *inviteService) AcceptInvite(ctx context.Context, req *AcceptInviteRequest) (*AddMemberResponse, error) {
+	invite, err := s.inviteRepo.GetByTokenHash(ctx, hashInviteToken(strings.TrimSpace(req.Token)))
+	if err != nil {
+		return nil, err
+	}
+	if invite.Status != domain.InviteStatusPending {
+		return nil, domain.ErrInviteNotPending
+	}
+	if invite.IsExpired(time.Now()) {
+		return nil, domain.ErrInviteExpired
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if invite.Email != "" && !strings.EqualFold(invite.Email, email) {
+		return nil, domain.ErrInviteEmailMismatch
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, invite.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+
+	// Claim the invite first so a token can't register two members at once
+	claimed, err := s.inviteRepo.Accept(ctx, invite.ID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := s.memberService.AddMemberDirect(ctx, &AddMemberRequest{
+		OrgID:    org.StytchOrgID,
+		Email:    email,
+		Name:     strings.TrimSpace(req.Name),
+		RoleSlug: claimed.RoleSlug,
+	})
+	if err != nil {
+		if releaseErr := s.inviteRepo.Release(ctx, claimed.ID); releaseErr != nil {
+			s.logger.Error("failed to release invite after registration failed", loggerDomain.Fields{
+				"invite_id": claimed.ID,
+				"error":     releaseErr.Error(),
+			})
+		}
+		return nil, err
+	}
+
+	s.audit("invite.accepted", claimed, loggerDomain.Fields{
+		"member_id": member.MemberID,
+	})
+
+	return member, nil
+}
+
+// notify emails the invite link to a pinned invite's address in the background.
+// A failed notification is logged; the admin still has the link.
+func (s *inviteService) notify(invite *domain.Invite, subject, body string) {
+	msg := &emailDomain.Message{
+		To:      []string{invite.Email},
+		Subject: subject,
+		Body:    body,
+	}
+
+	go func() {
+		// Don't use request context as it will be cancelled when request completes
+		sendCtx, cancel := context.WithTimeout(context.Background(), inviteNotificationTimeout)
+		defer cancel()
+
+		if err := s.sender.Send(sendCtx, msg); err != nil {
+			s.logger.Error("failed to send invite email", loggerDomain.Fields{
+				"invite_id": invite.ID,
+				"error":     err.Error(),
+			})
+		}
+	}()
+}
+
+// audit writes an audit log entry for the invite lifecycle.
+func (s *inviteService) audit(event string, invite *domain.Invite, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = invite.OrganizationID
+	fields["invite_id"] = invite.ID
+	fields["role_slug"] = invite.RoleSlug
+	s.logger.Info("invite audit", fields)
+}
+
+// generateInviteToken returns a random invite token and its stored hash.
+func generateInviteToken() (string, string, error) {
+	buf := make([]byte, inviteTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate invite token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashInviteToken(token), nil
+}
+
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,264 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// LinkedIdentityService lets members link Google and GitHub identities to
+// their account and unlink them.
+//
+// Signing in stays with the auth provider: the API never issues sessions for
+// a linked identity, so the provider's MFA and allowed sign-in methods always
+// apply. Members sign in with Google or GitHub through the provider's own
+// OAuth flow.
+type LinkedIdentityService interface {
+	// ListIdentities returns the identities linked to the account
+	ListIdentities(ctx context.Context, orgID, accountID int32) ([]*domain.LinkedIdentity, error)
+
+	// StartLink returns the provider page where the member signs in with the
+	// identity to link
+	StartLink(ctx context.Context, orgID, accountID int32, provider string) (*IdentityAuthorization, error)
+
+	// CompleteLink links the identity the member signed in with to their account
+	CompleteLink(ctx context.Context, orgID, accountID int32, provider string, req *CompleteIdentityRequest) (*domain.LinkedIdentity, error)
+
+	// Unlink removes the account's identity at the provider
+	Unlink(ctx context.Context, orgID, accountID int32, provider string) error
+}
+
+// CompleteIdentityRequest carries the query parameters the provider
+// redirected to IDENTITY_REDIRECT_URL with
+type CompleteIdentityRequest struct {
+	Code  string `json:"code" binding:"required"`
+	State string `json:"state" binding:"required"`
+}
+
+// IdentityAuthorization is where to send the user to sign in with a provider.
+// The frontend should keep the state and ignore redirects carrying another.
+type IdentityAuthorization struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	State            string    `json:"state"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+const (
+	// identityStateTokenType marks OAuth state issued by this service
+	identityStateTokenType = "identity_state"
+
+	// identityPurposeLink is the purpose of a link OAuth state
+	identityPurposeLink = "link"
+)
+
+// identityStateClaims are the claims of the OAuth state. A link state is
+// bound to the account that started it, so a member cannot be tricked into
+// linking someone else's identity.
+type identityStateClaims struct {
+	jwt.RegisteredClaims
+	TokenType      string `json:"typ"`
+	Purpose        string `json:"purpose"`
+	Provider       string `json:"idp"`
+	OrganizationID int32  `json:"org,omitempty"`
+	AccountID      int32  `json:"acct,omitempty"`
+}
+
+type linkedIdentityService struct {
+	identityRepo domain.IdentityRepository
+	clients      map[domain.IdentityProvider]IdentityProviderClient
+	claims       auth.TokenClaimsValidator
+	policy       *IdentityPolicy
+	logger       loggerDomain.Logger
+}
+
+func NewLinkedIdentityService(
+	identityRepo domain.IdentityRepository,
+	clients map[domain.IdentityProvider]IdentityProviderClient,
+	claims auth.TokenClaimsValidator,
+	policy *IdentityPolicy,
+	logger loggerDomain.Logger,
+) LinkedIdentityService {
+	return &linkedIdentityService{
+		identityRepo: identityRepo,
+		clients:      clients,
+		claims:       claims,
+		policy:       policy,
+		logger:       logger,
+	}
+}
+
+func (s *linkedIdentityService) ListIdentities(ctx context.Context, orgID, accountID int32) ([]*domain.LinkedIdentity, error) {
+	return s.identityRepo.ListByAccount(ctx, orgID, accountID)
+}
+
+func (s *linkedIdentityService) StartLink(ctx context.Context, orgID, accountID int32, provider string) (*IdentityAuthorization, error) {
+	idp, client, err := s.client(provider)
+	if err != nil {
+		return nil, err
+	}
+	return s.authorize(client, identityStateClaims{
+		Purpose:        identityPurposeLink,
+		Provider:       string(idp),
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+}
+
+// CompleteLink is idempotent: completing a link to the identity the account
+// already has returns the existing link.
+func (s *linkedIdentityService) CompleteLink(ctx context.Context, orgID, accountID int32, provider string, req *CompleteIdentityRequest) (*domain.LinkedIdentity, error) {
+	idp, client, err := s.client(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := s.parseState(req.State, identityPurposeLink, idp)
+	if err != nil {
+		return nil, err
+	}
+	if state.OrganizationID != orgID || state.AccountID != accountID {
+		return nil, domain.ErrIdentityInvalidState
+	}
+
+	user, err := client.Exchange(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	linked, err := s.identityRepo.ListByProviderUser(ctx, idp, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, identity := range linked {
+		if identity.OrganizationID != orgID {
+			continue
+		}
+		if identity.AccountID != accountID {
+			return nil, domain.ErrIdentityLinkedElsewhere
+		}
+		return identity, nil
+	}
+
+	identity, err := s.identityRepo.Create(ctx, &domain.LinkedIdentity{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+		Provider:       idp,
+		ProviderUserID: user.ID,
+		Email:          user.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("identity.linked", orgID, accountID, loggerDomain.Fields{
+		"provider":         string(idp),
+		"provider_user_id": user.ID,
+		"provider_email":   user.Email,
+	})
+	return identity, nil
+}
+
+func (s *linkedIdentityService) Unlink(ctx context.Context, orgID, accountID int32, provider string) error {
+	idp, err := domain.ParseIdentityProvider(provider)
+	if err != nil {
+		return err
+	}
+	if err := s.identityRepo.Delete(ctx, orgID, accountID, idp); err != nil {
+		return err
+	}
+
+	s.audit("identity.unlinked", orgID, accountID, loggerDomain.Fields{
+		"provider": string(idp),
+	})
+	return nil
+}
+
+// client returns the named provider's client, or an error if the provider is
+// unknown or not enabled.
+func (s *linkedIdentityService) client(provider string) (domain.IdentityProvider, IdentityProviderClient, error) {
+	idp, err := domain.ParseIdentityProvider(provider)
+	if err != nil {
+		return "", nil, err
+	}
+	client, ok := s.clients[idp]
+	if !ok || !s.policy.ProviderEnabled(idp) {
+		return "", nil, domain.ErrIdentityProviderDisabled
+	}
+	return idp, client, nil
+}
+
+// authorize signs the state and returns the provider page to send the user to.
+func (s *linkedIdentityService) authorize(client IdentityProviderClient, state identityStateClaims) (*IdentityAuthorization, error) {
+	now := time.Now()
+	expiresAt := now.Add(s.policy.StateTTL)
+	state.RegisteredClaims = jwt.RegisteredClaims{
+		Issuer:    s.claims.Issuer(),
+		Audience:  jwt.ClaimStrings{s.claims.Audience()},
+		ID:        uuid.New().String(),
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(expiresAt),
+	}
+	state.TokenType = identityStateTokenType
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, state).SignedString([]byte(s.policy.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign identity state: %w", err)
+	}
+
+	return &IdentityAuthorization{
+		AuthorizationURL: client.AuthorizationURL(signed),
+		State:            signed,
+		ExpiresAt:        expiresAt,
+	}, nil
+}
+
+// parseState verifies the OAuth state and checks it was issued for the
+// purpose and provider. Returns domain.ErrIdentityInvalidState otherwise.
+func (s *linkedIdentityService) parseState(token, purpose string, idp domain.IdentityProvider) (*identityStateClaims, error) {
+	var claims identityStateClaims
+	if err := s.parse(token, &claims); err != nil {
+		return nil, domain.ErrIdentityInvalidState
+	}
+	if claims.TokenType != identityStateTokenType || claims.Purpose != purpose || claims.Provider != string(idp) {
+		return nil, domain.ErrIdentityInvalidState
+	}
+	if err := s.claims.Validate(identityStateTokenType, claims.Issuer, claims.Audience); err != nil {
+		return nil, domain.ErrIdentityInvalidState
+	}
+	return &claims, nil
+}
+
+// parse verifies the signature and lifetime of a token signed by this service.
+func (s *linkedIdentityService) parse(token string, claims jwt.Claims) error {
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		return []byte(s.policy.Secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return auth.ErrTokenExpired
+		}
+		return auth.ErrInvalidToken
+	}
+	return nil
+}
+
+// audit writes an audit log entry for identity linking.
+func (s *linkedIdentityService) audit(event string, orgID, accountID int32, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = orgID
+	fields["account_id"] = accountID
+	s.logger.Info("linked identity audit", fields)
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
 )
 
 // MemberService defines the core authentication and member management operations
@@ -17,6 +20,10 @@ type MemberService interface {
 	// Creates the user if they don't exist, then adds them to the organization with specified roles
 	AddMemberDirect(ctx context.Context, req *AddMemberRequest) (*AddMemberResponse, error)
 
+	// UpdateMemberDirect sets an existing member's name and role in the auth
+	// provider and the local account. The member is found by email.
+	UpdateMemberDirect(ctx context.Context, req *UpdateMemberRequest) (*domain.Account, error)
+
 	// ListOrganizationMembers retrieves all members of an organization
 	// Returns a list of members with their details including roles and status
 	ListOrganizationMembers(ctx context.Context, orgID string) (*ListMembersResponse, error)
@@ -43,6 +50,12 @@ type BootstrapOrganizationRequest struct {
 	// Owner member details
 	OwnerEmail string `json:"owner_email" binding:"required,email"`
 	OwnerName  string `json:"owner_name" binding:"required"`
+
+	// Environment fields (set by StagingService, never from the request body).
+	// Empty Environment means production.
+	Environment          string     `json:"-"`
+	ParentOrganizationID *int32     `json:"-"`
+	ExpiresAt            *time.Time `json:"-"`
 }
 
 // Validate performs business validation on the bootstrap request
@@ -96,6 +109,27 @@ func (r *AddMemberRequest) Validate() error {
 	return nil
 }
 
+// UpdateMemberRequest represents the request to update an existing member
+type UpdateMemberRequest struct {
+	// Organization context (auth provider organization ID)
+	OrgID string `json:"-"`
+
+	Email    string `json:"email"`
+	Name     string `json:"name"`
+	RoleSlug string `json:"role_slug"`
+}
+
+// Validate performs business validation on the update member request
+func (r *UpdateMemberRequest) Validate() error {
+	if strings.TrimSpace(r.Email) == "" {
+		return fmt.Errorf("email cannot be empty")
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+	return nil
+}
+
 // AddMemberResponse represents the response after adding a member
 type AddMemberResponse struct {
 	MemberID   string `json:"member_id"`
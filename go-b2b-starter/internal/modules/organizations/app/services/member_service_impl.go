@@ -7,6 +7,9 @@ import (
 	"strings"
 
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
 	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger"
 )
 
@@ -41,6 +44,8 @@ type memberService struct {
 	authRoleRepo     domain.AuthRoleRepository
 	localOrgRepo     domain.OrganizationRepository
 	localAccountRepo domain.AccountRepository
+	seatGuard        paywall.SeatGuard
+	eventBus         eventbus.EventBus
 	logger           loggerDomain.Logger
 }
 
@@ -50,6 +55,8 @@ func NewMemberService(
 	authRoleRepo domain.AuthRoleRepository,
 	localOrgRepo domain.OrganizationRepository,
 	localAccountRepo domain.AccountRepository,
+	seatGuard paywall.SeatGuard,
+	eventBus eventbus.EventBus,
 	logger loggerDomain.Logger,
 ) MemberService {
 	return &memberService{
@@ -58,6 +65,8 @@ func NewMemberService(
 		authRoleRepo:     authRoleRepo,
 		localOrgRepo:     localOrgRepo,
 		localAccountRepo: localAccountRepo,
+		seatGuard:        seatGuard,
+		eventBus:         eventBus,
 		logger:           logger,
 	}
 }
@@ -130,9 +139,12 @@ func (s *memberService) BootstrapOrganizationWithOwner(
 	})
 
 	localOrg, err := s.localOrgRepo.Create(ctx, &domain.Organization{
-		Slug:   authOrg.Slug,
-		Name:   authOrg.DisplayName,
-		Status: "active",
+		Slug:                 authOrg.Slug,
+		Name:                 authOrg.DisplayName,
+		Status:               "active",
+		Environment:          req.Environment,
+		ParentOrganizationID: req.ParentOrganizationID,
+		ExpiresAt:            req.ExpiresAt,
 	})
 	if err != nil {
 		s.logger.Error("failed to create local organization", loggerDomain.Fields{
@@ -242,6 +254,8 @@ func (s *memberService) BootstrapOrganizationWithOwner(
 	// Success! Disable rollback
 	shouldRollback = false
 
+	s.publishRegistered(ctx, localAccount, member.EmailVerified, events.UserSourceSignup)
+
 	s.logger.Info("organization bootstrap completed", loggerDomain.Fields{
 		"stytch_org_id": authOrg.OrganizationID,
 		"owner_member":  member.MemberID,
@@ -294,6 +308,15 @@ func (s *memberService) AddMemberDirect(
 		return nil, fmt.Errorf("failed to check existing account: %w", err)
 	}
 
+	// Seat-based plans cap membership at the purchased seats
+	seatLimited, err := s.seatGuard.CheckSeatAvailable(ctx, localOrgID)
+	if err != nil {
+		if errors.Is(err, paywall.ErrSeatLimitReached) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to check seat availability: %w", err)
+	}
+
 	createReq := &domain.CreateAuthMemberRequest{
 		OrganizationID: orgID,
 		Email:          req.Email,
@@ -319,14 +342,34 @@ func (s *memberService) AddMemberDirect(
 		return nil, fmt.Errorf("failed to fetch role metadata: %w", err)
 	}
 
-	localAccount, err := s.localAccountRepo.Create(ctx, &domain.Account{
+	account := &domain.Account{
 		OrganizationID: localOrgID,
 		Email:          member.Email,
 		FullName:       member.Name,
 		Role:           mapRoleSlugToAccountRole(roleSlug),
 		Status:         "active",
-	})
+	}
+	var localAccount *domain.Account
+	if seatLimited {
+		localAccount, err = s.localAccountRepo.CreateWithinSeats(ctx, account)
+	} else {
+		localAccount, err = s.localAccountRepo.Create(ctx, account)
+	}
 	if err != nil {
+		// Another member took the last seat since the check; undo the provider member
+		if errors.Is(err, paywall.ErrSeatLimitReached) {
+			if removeErr := s.authMemberRepo.RemoveMembers(ctx, &domain.RemoveAuthMembersRequest{
+				OrganizationID: orgID,
+				MemberIDs:      []string{member.MemberID},
+			}); removeErr != nil {
+				s.logger.Error("failed to remove auth member over the seat limit", loggerDomain.Fields{
+					"org_id":    orgID,
+					"member_id": member.MemberID,
+					"error":     removeErr.Error(),
+				})
+			}
+			return nil, err
+		}
 		return nil, fmt.Errorf("failed to create local account: %w", err)
 	}
 
@@ -342,6 +385,8 @@ func (s *memberService) AddMemberDirect(
 		return nil, fmt.Errorf("failed to map auth member locally: %w", err)
 	}
 
+	s.publishRegistered(ctx, localAccount, member.EmailVerified, events.UserSourceMemberAdded)
+
 	s.logger.Info("member added successfully", loggerDomain.Fields{
 		"org_id":      orgID,
 		"member_id":   member.MemberID,
@@ -358,6 +403,72 @@ func (s *memberService) AddMemberDirect(
 	}, nil
 }
 
+// UpdateMemberDirect updates an existing member's name and role without notifying them.
+func (s *memberService) UpdateMemberDirect(
+	ctx context.Context,
+	req *UpdateMemberRequest,
+) (*domain.Account, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid update member request: %w", err)
+	}
+
+	roleSlug := strings.ToLower(strings.TrimSpace(req.RoleSlug))
+	if roleSlug == "" {
+		roleSlug = "member"
+	}
+
+	orgID := req.OrgID
+	if orgID == "" {
+		return nil, domain.ErrAuthOrganizationIDRequired
+	}
+
+	localOrgID, err := s.resolveLocalOrganizationID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.localAccountRepo.GetByEmail(ctx, localOrgID, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if account.StytchMemberID == "" {
+		return nil, fmt.Errorf("account %d is not linked to an auth member", account.ID)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if _, err := s.authMemberRepo.UpdateMember(ctx, &domain.UpdateAuthMemberRequest{
+		OrganizationID: orgID,
+		MemberID:       account.StytchMemberID,
+		Name:           &name,
+		Roles:          []string{roleSlug},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to update member: %w", err)
+	}
+
+	role, err := s.authRoleRepo.GetRoleBySlug(ctx, roleSlug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch role metadata: %w", err)
+	}
+
+	account.FullName = name
+	account.Role = mapRoleSlugToAccountRole(roleSlug)
+	account.StytchRoleID = role.RoleID
+	account.StytchRoleSlug = roleSlug
+
+	updated, err := s.localAccountRepo.Update(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update local account: %w", err)
+	}
+
+	s.logger.Info("member updated successfully", loggerDomain.Fields{
+		"org_id":    orgID,
+		"member_id": account.StytchMemberID,
+		"role_slug": roleSlug,
+	})
+
+	return updated, nil
+}
+
 // ListOrganizationMembers retrieves all members of an organization.
 func (s *memberService) ListOrganizationMembers(
 	ctx context.Context,
@@ -530,6 +641,28 @@ func (s *memberService) DeleteOrganizationMember(
 	return nil
 }
 
+// publishRegistered publishes user.registered for a new member account.
+// Subscriber failures are logged; the member already exists in the auth
+// provider and locally.
+func (s *memberService) publishRegistered(ctx context.Context, account *domain.Account, emailVerified bool, source string) {
+	event := events.NewUserRegistered(
+		account.OrganizationID,
+		account.ID,
+		account.Email,
+		account.FullName,
+		account.Role,
+		emailVerified,
+		source,
+	)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Warn("failed to publish user event", loggerDomain.Fields{
+			"event":      event.EventName(),
+			"account_id": account.ID,
+			"error":      err.Error(),
+		})
+	}
+}
+
 // Returns true if email is found in any organization, false otherwise
 func (s *memberService) CheckEmailExists(ctx context.Context, email string) (bool, error) {
 	// Validate email format
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// MFARecoveryPolicy controls how members recover from a lost MFA device.
+//
+// All values can be set via environment variables with the MFA_RECOVERY_ prefix.
+type MFARecoveryPolicy struct {
+	// Delay is the waiting period between verifying the email and resetting MFA.
+	// It gives the real owner time to notice and cancel a hijack attempt.
+	Delay time.Duration `mapstructure:"MFA_RECOVERY_DELAY"`
+
+	// CompletionWindow is how long the reset stays available once the delay has passed
+	CompletionWindow time.Duration `mapstructure:"MFA_RECOVERY_COMPLETION_WINDOW"`
+
+	// RequireApproval requires an org admin to approve every recovery request
+	RequireApproval bool `mapstructure:"MFA_RECOVERY_REQUIRE_APPROVAL"`
+}
+
+// LoadMFARecoveryPolicy loads the MFA recovery policy from environment variables and app.env file.
+func LoadMFARecoveryPolicy() (*MFARecoveryPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("MFA_RECOVERY_DELAY", "24h")
+	v.SetDefault("MFA_RECOVERY_COMPLETION_WINDOW", "72h")
+	v.SetDefault("MFA_RECOVERY_REQUIRE_APPROVAL", false)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy MFARecoveryPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode mfa recovery policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the durations are usable.
+func (p *MFARecoveryPolicy) Validate() error {
+	if p.Delay < 0 {
+		return fmt.Errorf("mfa recovery policy invalid: MFA_RECOVERY_DELAY must not be negative")
+	}
+	if p.CompletionWindow <= 0 {
+		return fmt.Errorf("mfa recovery policy invalid: MFA_RECOVERY_COMPLETION_WINDOW must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,320 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// MFARecoveryService lets members who lost their MFA device reset it.
+//
+// Recovery proves control of the member's email with a one-time passcode,
+// then waits out a delay (and optionally an admin approval) before the
+// enrolled factors are removed. The member enrolls a new factor on their
+// next login. Recovery codes are handled by the auth provider directly.
+type MFARecoveryService interface {
+	// StartRecovery emails a verification code. It never reveals whether the member exists.
+	StartRecovery(ctx context.Context, req *StartMFARecoveryRequest) error
+
+	// VerifyRecovery checks the code and opens a delayed recovery request
+	VerifyRecovery(ctx context.Context, req *VerifyMFARecoveryRequest) (*MFARecoveryTicket, error)
+
+	// CompleteRecovery resets the member's MFA once the request is eligible
+	CompleteRecovery(ctx context.Context, req *CompleteMFARecoveryRequest) (*domain.MFARecoveryRequest, error)
+
+	// ListRecoveries returns the organization's recovery requests, optionally filtered by status
+	ListRecoveries(ctx context.Context, orgID int32, status string) ([]*domain.MFARecoveryRequest, error)
+
+	// ApproveRecovery approves a request that requires admin approval
+	ApproveRecovery(ctx context.Context, orgID, approverID, requestID int32) (*domain.MFARecoveryRequest, error)
+
+	// DenyRecovery rejects a request that requires admin approval
+	DenyRecovery(ctx context.Context, orgID, approverID, requestID int32) (*domain.MFARecoveryRequest, error)
+
+	// CancelRecovery lets a signed-in member stop a recovery they did not start
+	CancelRecovery(ctx context.Context, orgID, accountID, requestID int32) (*domain.MFARecoveryRequest, error)
+}
+
+// StartMFARecoveryRequest represents the request to begin MFA recovery
+type StartMFARecoveryRequest struct {
+	OrganizationID string `json:"organization_id" binding:"required"`
+	Email          string `json:"email" binding:"required,email"`
+}
+
+// VerifyMFARecoveryRequest represents the emailed code submitted by the member
+type VerifyMFARecoveryRequest struct {
+	OrganizationID string `json:"organization_id" binding:"required"`
+	Email          string `json:"email" binding:"required,email"`
+	Code           string `json:"code" binding:"required"`
+}
+
+// CompleteMFARecoveryRequest represents the request to finish MFA recovery
+type CompleteMFARecoveryRequest struct {
+	RecoveryID    int32  `json:"recovery_id" binding:"required"`
+	RecoveryToken string `json:"recovery_token" binding:"required"`
+}
+
+// MFARecoveryTicket is returned once the email is verified. The token is
+// shown only once and is required to complete the recovery.
+type MFARecoveryTicket struct {
+	RecoveryID       int32     `json:"recovery_id"`
+	RecoveryToken    string    `json:"recovery_token"`
+	RequiresApproval bool      `json:"requires_approval"`
+	EligibleAt       time.Time `json:"eligible_at"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+const mfaRecoveryTokenBytes = 32
+
+type mfaRecoveryService struct {
+	recoveryRepo   domain.MFARecoveryRepository
+	authMemberRepo domain.AuthMemberRepository
+	orgRepo        domain.OrganizationRepository
+	accountRepo    domain.AccountRepository
+	eventBus       eventbus.EventBus
+	policy         *MFARecoveryPolicy
+	logger         loggerDomain.Logger
+}
+
+func NewMFARecoveryService(
+	recoveryRepo domain.MFARecoveryRepository,
+	authMemberRepo domain.AuthMemberRepository,
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	eventBus eventbus.EventBus,
+	policy *MFARecoveryPolicy,
+	logger loggerDomain.Logger,
+) MFARecoveryService {
+	return &mfaRecoveryService{
+		recoveryRepo:   recoveryRepo,
+		authMemberRepo: authMemberRepo,
+		orgRepo:        orgRepo,
+		accountRepo:    accountRepo,
+		eventBus:       eventBus,
+		policy:         policy,
+		logger:         logger,
+	}
+}
+
+func (s *mfaRecoveryService) StartRecovery(ctx context.Context, req *StartMFARecoveryRequest) error {
+	otpReq := &domain.EmailOTPRequest{
+		OrganizationID: strings.TrimSpace(req.OrganizationID),
+		Email:          strings.TrimSpace(req.Email),
+	}
+	if err := otpReq.Validate(); err != nil {
+		return err
+	}
+
+	// Unknown members and provider failures look the same to the caller
+	if err := s.authMemberRepo.SendEmailOTP(ctx, otpReq); err != nil {
+		s.logger.Warn("mfa recovery code not sent", loggerDomain.Fields{
+			"stytch_org_id": otpReq.OrganizationID,
+			"error":         err.Error(),
+		})
+	}
+
+	return nil
+}
+
+func (s *mfaRecoveryService) VerifyRecovery(ctx context.Context, req *VerifyMFARecoveryRequest) (*MFARecoveryTicket, error) {
+	otpReq := &domain.EmailOTPRequest{
+		OrganizationID: strings.TrimSpace(req.OrganizationID),
+		Email:          strings.TrimSpace(req.Email),
+		Code:           strings.TrimSpace(req.Code),
+	}
+	if err := otpReq.Validate(); err != nil {
+		return nil, err
+	}
+
+	member, err := s.authMemberRepo.AuthenticateEmailOTP(ctx, otpReq)
+	if err != nil {
+		return nil, err
+	}
+
+	org, err := s.orgRepo.GetByStytchID(ctx, member.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+
+	account, err := s.accountRepo.GetByEmail(ctx, org.ID, member.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve account: %w", err)
+	}
+
+	token, tokenHash, err := generateRecoveryToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	eligibleAt := now.Add(s.policy.Delay)
+
+	recovery, err := s.recoveryRepo.Create(ctx, &domain.MFARecoveryRequest{
+		OrganizationID:   org.ID,
+		AccountID:        account.ID,
+		RequiresApproval: s.policy.RequireApproval,
+		TokenHash:        tokenHash,
+		EligibleAt:       eligibleAt,
+		ExpiresAt:        eligibleAt.Add(s.policy.CompletionWindow),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("mfa_recovery.requested", recovery, loggerDomain.Fields{
+		"eligible_at": recovery.EligibleAt.Format(time.RFC3339),
+	})
+
+	event := events.NewMFARecoveryRequested(recovery.ID, recovery.OrganizationID, recovery.AccountID, recovery.RequiresApproval, recovery.EligibleAt)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Don't fail the request just because event publishing failed
+	}
+
+	return &MFARecoveryTicket{
+		RecoveryID:       recovery.ID,
+		RecoveryToken:    token,
+		RequiresApproval: recovery.RequiresApproval,
+		EligibleAt:       recovery.EligibleAt,
+		ExpiresAt:        recovery.ExpiresAt,
+	}, nil
+}
+
+func (s *mfaRecoveryService) CompleteRecovery(ctx context.Context, req *CompleteMFARecoveryRequest) (*domain.MFARecoveryRequest, error) {
+	recovery, err := s.recoveryRepo.GetByID(ctx, req.RecoveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	// A wrong token is indistinguishable from a missing request
+	if subtle.ConstantTimeCompare([]byte(hashRecoveryToken(req.RecoveryToken)), []byte(recovery.TokenHash)) != 1 {
+		return nil, domain.ErrMFARecoveryNotFound
+	}
+
+	if !recovery.IsOpen() {
+		return nil, domain.ErrMFARecoveryNotOpen
+	}
+	if recovery.Status != recovery.ReadyStatus() {
+		return nil, domain.ErrMFARecoveryAwaitingApproval
+	}
+
+	now := time.Now()
+	if now.Before(recovery.EligibleAt) {
+		return nil, domain.ErrMFARecoveryNotYetEligible
+	}
+	if !now.Before(recovery.ExpiresAt) {
+		return nil, domain.ErrMFARecoveryExpired
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, recovery.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, recovery.OrganizationID, recovery.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve account: %w", err)
+	}
+
+	// Resetting is idempotent, so a concurrent completion only repeats it
+	if err := s.authMemberRepo.ResetMFA(ctx, org.StytchOrgID, account.StytchMemberID); err != nil {
+		return nil, fmt.Errorf("failed to reset mfa: %w", err)
+	}
+
+	completed, err := s.recoveryRepo.Complete(ctx, recovery.ID, recovery.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("mfa_recovery.completed", completed, loggerDomain.Fields{})
+
+	event := events.NewMFARecoveryCompleted(completed.ID, completed.OrganizationID, completed.AccountID)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		// Don't fail the request just because event publishing failed
+	}
+
+	return completed, nil
+}
+
+func (s *mfaRecoveryService) ListRecoveries(ctx context.Context, orgID int32, status string) ([]*domain.MFARecoveryRequest, error) {
+	return s.recoveryRepo.ListByOrganization(ctx, orgID, status)
+}
+
+func (s *mfaRecoveryService) ApproveRecovery(ctx context.Context, orgID, approverID, requestID int32) (*domain.MFARecoveryRequest, error) {
+	return s.decide(ctx, orgID, approverID, requestID, domain.MFARecoveryStatusApproved)
+}
+
+func (s *mfaRecoveryService) DenyRecovery(ctx context.Context, orgID, approverID, requestID int32) (*domain.MFARecoveryRequest, error) {
+	return s.decide(ctx, orgID, approverID, requestID, domain.MFARecoveryStatusDenied)
+}
+
+func (s *mfaRecoveryService) CancelRecovery(ctx context.Context, orgID, accountID, requestID int32) (*domain.MFARecoveryRequest, error) {
+	cancelled, err := s.recoveryRepo.Cancel(ctx, orgID, accountID, requestID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("mfa_recovery.cancelled", cancelled, loggerDomain.Fields{})
+
+	return cancelled, nil
+}
+
+// decide records an admin decision; members cannot decide their own requests.
+func (s *mfaRecoveryService) decide(ctx context.Context, orgID, approverID, requestID int32, status string) (*domain.MFARecoveryRequest, error) {
+	recovery, err := s.recoveryRepo.GetByID(ctx, requestID)
+	if err != nil {
+		return nil, err
+	}
+	if recovery.OrganizationID != orgID {
+		return nil, domain.ErrMFARecoveryNotFound
+	}
+	if recovery.AccountID == approverID {
+		return nil, domain.ErrMFARecoverySelfApproval
+	}
+
+	decided, err := s.recoveryRepo.Decide(ctx, orgID, requestID, status, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("mfa_recovery."+decided.Status, decided, loggerDomain.Fields{
+		"decided_by_account_id": approverID,
+	})
+
+	return decided, nil
+}
+
+// audit writes an audit log entry for the recovery lifecycle.
+func (s *mfaRecoveryService) audit(event string, recovery *domain.MFARecoveryRequest, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = recovery.OrganizationID
+	fields["account_id"] = recovery.AccountID
+	fields["recovery_id"] = recovery.ID
+	s.logger.Info("mfa recovery audit", fields)
+}
+
+// generateRecoveryToken returns a random completion token and its stored hash.
+func generateRecoveryToken() (string, string, error) {
+	buf := make([]byte, mfaRecoveryTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate recovery token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashRecoveryToken(token), nil
+}
+
+func hashRecoveryToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,89 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// minUnsubscribeSecretLength is the minimum length of the unsubscribe token signing secret
+const minUnsubscribeSecretLength = 32
+
+// NotificationPolicy controls member notifications and the unsubscribe links
+// in notification emails.
+//
+// All values can be set via environment variables with the NOTIFICATION_ prefix.
+type NotificationPolicy struct {
+	// UnsubscribeSecret signs unsubscribe tokens (HS256); at least 32
+	// characters. Empty leaves unsubscribe links out of emails.
+	UnsubscribeSecret string `mapstructure:"NOTIFICATION_UNSUBSCRIBE_SECRET"`
+
+	// UnsubscribeURL is the frontend page that posts the token from the
+	// link's query string to /api/notifications/unsubscribe
+	UnsubscribeURL string `mapstructure:"NOTIFICATION_UNSUBSCRIBE_URL"`
+
+	// UnsubscribeTTL is how long an unsubscribe link keeps working
+	UnsubscribeTTL time.Duration `mapstructure:"NOTIFICATION_UNSUBSCRIBE_TTL"`
+
+	// MaxInAppResults caps the in-app notifications returned per request
+	MaxInAppResults int32 `mapstructure:"NOTIFICATION_MAX_IN_APP_RESULTS"`
+}
+
+// LoadNotificationPolicy loads the notification policy from environment variables and app.env file.
+func LoadNotificationPolicy() (*NotificationPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("NOTIFICATION_UNSUBSCRIBE_SECRET", "")
+	v.SetDefault("NOTIFICATION_UNSUBSCRIBE_URL", "http://localhost:3000/notifications/unsubscribe")
+	v.SetDefault("NOTIFICATION_UNSUBSCRIBE_TTL", "8760h")
+	v.SetDefault("NOTIFICATION_MAX_IN_APP_RESULTS", 100)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy NotificationPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode notification policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that unsubscribe links can be signed when enabled.
+func (p *NotificationPolicy) Validate() error {
+	if p.MaxInAppResults <= 0 {
+		return fmt.Errorf("notification policy invalid: NOTIFICATION_MAX_IN_APP_RESULTS must be positive")
+	}
+	if !p.UnsubscribeEnabled() {
+		return nil
+	}
+	if len(p.UnsubscribeSecret) < minUnsubscribeSecretLength {
+		return fmt.Errorf("notification policy invalid: NOTIFICATION_UNSUBSCRIBE_SECRET must be at least %d characters", minUnsubscribeSecretLength)
+	}
+	if p.UnsubscribeURL == "" {
+		return fmt.Errorf("notification policy invalid: NOTIFICATION_UNSUBSCRIBE_URL is required with NOTIFICATION_UNSUBSCRIBE_SECRET")
+	}
+	if p.UnsubscribeTTL <= 0 {
+		return fmt.Errorf("notification policy invalid: NOTIFICATION_UNSUBSCRIBE_TTL must be positive")
+	}
+	return nil
+}
+
+// UnsubscribeEnabled reports whether emails carry unsubscribe links.
+func (p *NotificationPolicy) UnsubscribeEnabled() bool {
+	return p.UnsubscribeSecret != ""
+}
@@ -0,0 +1,357 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+const (
+	// unsubscribeTokenType marks tokens in notification unsubscribe links
+	unsubscribeTokenType = "notification_unsubscribe"
+
+	// defaultNotificationLimit is how many in-app notifications are returned when no limit is given
+	defaultNotificationLimit int32 = 50
+)
+
+// unsubscribeClaims are the claims of an unsubscribe token. The token turns
+// off one notification type on the email channel for one account.
+type unsubscribeClaims struct {
+	jwt.RegisteredClaims
+	TokenType        string `json:"typ"`
+	OrganizationID   int32  `json:"org"`
+	NotificationType string `json:"ntf"`
+}
+
+// NotificationService sends notifications to members on the channels they
+// chose and manages those choices.
+//
+// Every notification type in domain.NotificationTypes is sent on all of its
+// channels until the member turns it off, either in their preferences or
+// with the unsubscribe link at the bottom of its emails. Required types are
+// always sent.
+type NotificationService interface {
+	// Notify sends a notification on each channel of its type the member has
+	// not turned off. A failure on one channel does not stop the others.
+	Notify(ctx context.Context, req *NotifyRequest) error
+
+	// ListPreferences returns whether the account receives each notification
+	// type on each of its channels
+	ListPreferences(ctx context.Context, orgID, accountID int32) ([]*domain.NotificationPreference, error)
+
+	// UpdatePreferences turns notification types on or off per channel and
+	// returns all of the account's preferences
+	UpdatePreferences(ctx context.Context, orgID, accountID int32, req *UpdateNotificationPreferencesRequest) ([]*domain.NotificationPreference, error)
+
+	// Unsubscribe turns off the email notification type named by an
+	// unsubscribe token
+	Unsubscribe(ctx context.Context, token string) (*domain.NotificationPreference, error)
+
+	// ListNotifications returns the account's in-app notifications newest first
+	ListNotifications(ctx context.Context, orgID, accountID int32, req *ListNotificationsRequest) ([]*domain.Notification, error)
+
+	// MarkRead marks one of the account's in-app notifications read
+	MarkRead(ctx context.Context, orgID, accountID int32, id int64) error
+}
+
+// NotifyRequest is a notification to send to one member
+type NotifyRequest struct {
+	OrganizationID int32
+	AccountID      int32
+	// Email receives the email notification, along with the member's verified
+	// secondary addresses that have notifications on; empty skips the email
+	// channel
+	Email string
+	Type  domain.NotificationType
+	// Title is the email subject and the in-app title
+	Title string
+	Body  string
+}
+
+// UpdateNotificationPreferencesRequest turns notification types on or off per channel
+type UpdateNotificationPreferencesRequest struct {
+	Preferences []NotificationPreferenceUpdate `json:"preferences" binding:"required,min=1,dive"`
+}
+
+// NotificationPreferenceUpdate turns one notification type on or off on one channel
+type NotificationPreferenceUpdate struct {
+	Type    domain.NotificationType    `json:"type" binding:"required"`
+	Channel domain.NotificationChannel `json:"channel" binding:"required"`
+	Enabled *bool                      `json:"enabled" binding:"required"`
+}
+
+// UnsubscribeRequest carries the token from an unsubscribe link
+type UnsubscribeRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+// ListNotificationsRequest filters an account's in-app notifications
+type ListNotificationsRequest struct {
+	// Unread returns only notifications that were not marked read
+	Unread bool `form:"unread"`
+	// Limit defaults to 50 and is capped at NOTIFICATION_MAX_IN_APP_RESULTS
+	Limit int32 `form:"limit"`
+}
+
+type notificationService struct {
+	notificationRepo domain.NotificationRepository
+	accountRepo      domain.AccountRepository
+	secondaryRepo    domain.SecondaryEmailRepository
+	sender           emailDomain.Sender
+	policy           *NotificationPolicy
+	logger           loggerDomain.Logger
+}
+
+func NewNotificationService(
+	notificationRepo domain.NotificationRepository,
+	accountRepo domain.AccountRepository,
+	secondaryRepo domain.SecondaryEmailRepository,
+	sender emailDomain.Sender,
+	policy *NotificationPolicy,
+	logger loggerDomain.Logger,
+) NotificationService {
+	return &notificationService{
+		notificationRepo: notificationRepo,
+		accountRepo:      accountRepo,
+		secondaryRepo:    secondaryRepo,
+		sender:           sender,
+		policy:           policy,
+		logger:           logger.Named("organizations"),
+	}
+}
+
+func (s *notificationService) Notify(ctx context.Context, req *NotifyRequest) error {
+	info, ok := domain.LookupNotificationType(req.Type)
+	if !ok {
+		return domain.ErrUnknownNotificationType
+	}
+
+	disabled, err := s.disabledChannels(ctx, req.OrganizationID, req.AccountID, info)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	if info.HasChannel(domain.NotificationChannelInApp) && !disabled[domain.NotificationChannelInApp] {
+		_, err := s.notificationRepo.Create(ctx, &domain.Notification{
+			OrganizationID: req.OrganizationID,
+			AccountID:      req.AccountID,
+			Type:           req.Type,
+			Title:          req.Title,
+			Body:           req.Body,
+		})
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if info.HasChannel(domain.NotificationChannelEmail) && !disabled[domain.NotificationChannelEmail] && req.Email != "" {
+		body := req.Body
+		if !info.Required && s.policy.UnsubscribeEnabled() {
+			link, err := s.unsubscribeLink(req.OrganizationID, req.AccountID, req.Type)
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				body += "\n--\nTo stop receiving these emails, unsubscribe:\n" + link + "\n"
+			}
+		}
+
+		to := []string{req.Email}
+		secondary, err := s.secondaryRepo.ListNotified(ctx, req.OrganizationID, req.AccountID)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		to = append(to, secondary...)
+
+		msg := &emailDomain.Message{
+			To:      to,
+			Subject: req.Title,
+			Body:    body,
+		}
+		if err := s.sender.Send(ctx, msg); err != nil {
+			errs = append(errs, fmt.Errorf("failed to send notification email: %w", err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *notificationService) ListPreferences(ctx context.Context, orgID, accountID int32) ([]*domain.NotificationPreference, error) {
+	stored, err := s.notificationRepo.ListPreferences(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	enabled := make(map[domain.NotificationPreference]bool, len(stored))
+	for _, pref := range stored {
+		enabled[domain.NotificationPreference{Type: pref.Type, Channel: pref.Channel}] = pref.Enabled
+	}
+
+	var prefs []*domain.NotificationPreference
+	for _, info := range domain.NotificationTypes {
+		for _, channel := range info.Channels {
+			on, ok := enabled[domain.NotificationPreference{Type: info.Type, Channel: channel}]
+			prefs = append(prefs, &domain.NotificationPreference{
+				Type:     info.Type,
+				Channel:  channel,
+				Enabled:  info.Required || !ok || on,
+				Required: info.Required,
+			})
+		}
+	}
+	return prefs, nil
+}
+
+func (s *notificationService) UpdatePreferences(ctx context.Context, orgID, accountID int32, req *UpdateNotificationPreferencesRequest) ([]*domain.NotificationPreference, error) {
+	// Check every update before storing any, so a bad entry changes nothing
+	for _, update := range req.Preferences {
+		info, ok := domain.LookupNotificationType(update.Type)
+		if !ok {
+			return nil, domain.ErrUnknownNotificationType
+		}
+		if !info.HasChannel(update.Channel) {
+			return nil, domain.ErrNotificationChannel
+		}
+		if info.Required && !*update.Enabled {
+			return nil, domain.ErrNotificationRequired
+		}
+	}
+
+	for _, update := range req.Preferences {
+		err := s.notificationRepo.SavePreference(ctx, orgID, accountID, &domain.NotificationPreference{
+			Type:    update.Type,
+			Channel: update.Channel,
+			Enabled: *update.Enabled,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.ListPreferences(ctx, orgID, accountID)
+}
+
+func (s *notificationService) Unsubscribe(ctx context.Context, token string) (*domain.NotificationPreference, error) {
+	if !s.policy.UnsubscribeEnabled() {
+		return nil, domain.ErrNotificationUnsubscribeOff
+	}
+
+	var claims unsubscribeClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		return []byte(s.policy.UnsubscribeSecret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || claims.TokenType != unsubscribeTokenType {
+		return nil, domain.ErrNotificationUnsubscribeToken
+	}
+
+	accountID, err := strconv.ParseInt(claims.Subject, 10, 32)
+	if err != nil {
+		return nil, domain.ErrNotificationUnsubscribeToken
+	}
+	info, ok := domain.LookupNotificationType(domain.NotificationType(claims.NotificationType))
+	if !ok || info.Required || !info.HasChannel(domain.NotificationChannelEmail) {
+		return nil, domain.ErrNotificationUnsubscribeToken
+	}
+
+	// Links of deleted accounts stop working
+	account, err := s.accountRepo.GetByID(ctx, claims.OrganizationID, int32(accountID))
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return nil, domain.ErrNotificationUnsubscribeToken
+		}
+		return nil, err
+	}
+
+	pref := &domain.NotificationPreference{
+		Type:    info.Type,
+		Channel: domain.NotificationChannelEmail,
+		Enabled: false,
+	}
+	if err := s.notificationRepo.SavePreference(ctx, account.OrganizationID, account.ID, pref); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("notification unsubscribe", loggerDomain.Fields{
+		"audit":             true,
+		"event":             "notification.unsubscribed",
+		"organization_id":   account.OrganizationID,
+		"account_id":        account.ID,
+		"notification_type": info.Type,
+		"token_id":          claims.ID,
+	})
+
+	return pref, nil
+}
+
+func (s *notificationService) ListNotifications(ctx context.Context, orgID, accountID int32, req *ListNotificationsRequest) ([]*domain.Notification, error) {
+	limit := defaultNotificationLimit
+	if req.Limit > 0 {
+		limit = min(req.Limit, s.policy.MaxInAppResults)
+	}
+	return s.notificationRepo.List(ctx, orgID, accountID, req.Unread, limit)
+}
+
+func (s *notificationService) MarkRead(ctx context.Context, orgID, accountID int32, id int64) error {
+	return s.notificationRepo.MarkRead(ctx, orgID, accountID, id)
+}
+
+// disabledChannels returns the channels on which the account turned the type
+// off. Required types are never off.
+func (s *notificationService) disabledChannels(ctx context.Context, orgID, accountID int32, info domain.NotificationTypeInfo) (map[domain.NotificationChannel]bool, error) {
+	disabled := make(map[domain.NotificationChannel]bool)
+	if info.Required {
+		return disabled, nil
+	}
+
+	prefs, err := s.notificationRepo.ListPreferences(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, pref := range prefs {
+		if pref.Type == info.Type && !pref.Enabled {
+			disabled[pref.Channel] = true
+		}
+	}
+	return disabled, nil
+}
+
+// unsubscribeLink returns the link that turns off emails of the type for the account
+func (s *notificationService) unsubscribeLink(orgID, accountID int32, notificationType domain.NotificationType) (string, error) {
+	now := time.Now()
+	claims := unsubscribeClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.New().String(),
+			Subject:   strconv.Itoa(int(accountID)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.policy.UnsubscribeTTL)),
+		},
+		TokenType:        unsubscribeTokenType,
+		OrganizationID:   orgID,
+		NotificationType: string(notificationType),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.policy.UnsubscribeSecret))
+	if err != nil {
+		return "", fmt.Errorf("failed to sign unsubscribe token: %w", err)
+	}
+
+	separator := "?"
+	if strings.Contains(s.policy.UnsubscribeURL, "?") {
+		separator = "&"
+	}
+	return s.policy.UnsubscribeURL + separator + "token=" + url.QueryEscape(token), nil
+}
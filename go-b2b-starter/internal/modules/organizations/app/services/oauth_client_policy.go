@@ -0,0 +1,71 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// minOAuthTokenSecretLength is the minimum length of the client access token signing secret
+const minOAuthTokenSecretLength = 32
+
+// OAuthClientPolicy controls OAuth2 clients using the client credentials grant.
+//
+// All values can be set via environment variables with the OAUTH_ prefix.
+type OAuthClientPolicy struct {
+	// Enabled turns on client registration, the token endpoint and client token verification
+	Enabled bool `mapstructure:"OAUTH_CLIENTS_ENABLED"`
+
+	// Secret signs client access tokens (HS256); at least 32 characters
+	Secret string `mapstructure:"OAUTH_TOKEN_SECRET"`
+
+	// TokenTTL is the lifetime of a client access token
+	TokenTTL time.Duration `mapstructure:"OAUTH_ACCESS_TOKEN_TTL"`
+}
+
+// LoadOAuthClientPolicy loads the OAuth client policy from environment variables and app.env file.
+func LoadOAuthClientPolicy() (*OAuthClientPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("OAUTH_CLIENTS_ENABLED", false)
+	v.SetDefault("OAUTH_TOKEN_SECRET", "")
+	v.SetDefault("OAUTH_ACCESS_TOKEN_TTL", "1h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy OAuthClientPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode oauth client policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that an enabled policy can sign tokens.
+func (p *OAuthClientPolicy) Validate() error {
+	if !p.Enabled {
+		return nil
+	}
+	if len(p.Secret) < minOAuthTokenSecretLength {
+		return fmt.Errorf("oauth client policy invalid: OAUTH_TOKEN_SECRET must be at least %d characters", minOAuthTokenSecretLength)
+	}
+	if p.TokenTTL <= 0 {
+		return fmt.Errorf("oauth client policy invalid: OAUTH_ACCESS_TOKEN_TTL must be positive")
+	}
+	return nil
+}
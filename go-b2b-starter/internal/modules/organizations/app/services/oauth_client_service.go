@@ -0,0 +1,520 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// OAuthClientService manages OAuth2 clients and issues access tokens with the
+// client credentials grant, so backend services can call the API without a user.
+//
+// Each client acts through a service account in its organization, so client
+// tokens pass RequireOrganization like user tokens. Its permissions are the
+// scopes granted at registration. It implements auth.ClientTokenVerifier so
+// the auth middleware can accept client tokens.
+type OAuthClientService interface {
+	auth.ClientTokenVerifier
+
+	// CreateClient registers a client; the secret is only returned here
+	CreateClient(ctx context.Context, orgID, createdBy int32, req *CreateOAuthClientRequest) (*CreatedOAuthClient, error)
+
+	// ListClients lists the organization's clients, newest first
+	ListClients(ctx context.Context, orgID int32) ([]*domain.OAuthClient, error)
+
+	// RevokeClient stops the client from obtaining tokens and revokes its issued tokens
+	RevokeClient(ctx context.Context, orgID, revokedBy, id int32) (*domain.OAuthClient, error)
+
+	// IssueToken authenticates the client and issues an access token for the
+	// requested space-delimited scopes (all granted scopes if empty) and
+	// audiences (this API if empty). Audiences may name downstream services
+	// configured in JWT_SERVICE_AUDIENCES.
+	IssueToken(ctx context.Context, clientID, clientSecret, scope, audience string) (*ClientAccessToken, error)
+
+	// IntrospectToken authenticates the calling client and describes a client
+	// access token (RFC 7662), including tokens issued only for downstream
+	// services. Tokens that are invalid, expired or revoked, or that belong to
+	// another organization, are reported inactive.
+	IntrospectToken(ctx context.Context, clientID, clientSecret, token string) (*TokenIntrospection, error)
+}
+
+// CreateOAuthClientRequest represents the request to register an OAuth client
+type CreateOAuthClientRequest struct {
+	Name   string   `json:"name" binding:"required,max=255"`
+	Scopes []string `json:"scopes" binding:"required"`
+}
+
+// CreatedOAuthClient is returned once when a client is registered
+type CreatedOAuthClient struct {
+	*domain.OAuthClient
+	ClientSecret string `json:"client_secret"`
+}
+
+// ClientAccessToken is the token endpoint response (RFC 6749 section 5.1)
+type ClientAccessToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+	Audience    string `json:"audience"`
+}
+
+// TokenIntrospection is the introspection endpoint response (RFC 7662
+// section 2.2). Only Active is set for inactive tokens.
+type TokenIntrospection struct {
+	Active         bool     `json:"active"`
+	Scope          string   `json:"scope,omitempty"`
+	ClientID       string   `json:"client_id,omitempty"`
+	Username       string   `json:"username,omitempty"`
+	TokenType      string   `json:"token_type,omitempty"`
+	ExpiresAt      int64    `json:"exp,omitempty"`
+	IssuedAt       int64    `json:"iat,omitempty"`
+	Subject        string   `json:"sub,omitempty"`
+	Audience       []string `json:"aud,omitempty"`
+	TokenID        string   `json:"jti,omitempty"`
+	OrganizationID string   `json:"org,omitempty"`
+}
+
+const (
+	// clientTokenType marks tokens issued by this service
+	clientTokenType = "client_credentials"
+
+	// oauthClientEmailDomain is used for service account emails; .invalid never resolves
+	oauthClientEmailDomain = "clients.invalid"
+
+	// Random bytes in generated client IDs and secrets
+	oauthClientIDBytes     = 16
+	oauthClientSecretBytes = 32
+)
+
+// clientTokenClaims are the claims of a client access token
+type clientTokenClaims struct {
+	jwt.RegisteredClaims
+	TokenType      string `json:"typ"`
+	Email          string `json:"email"`
+	OrganizationID string `json:"org"`
+	Scope          string `json:"scope"`
+}
+
+type oauthClientService struct {
+	clientRepo  domain.OAuthClientRepository
+	orgRepo     domain.OrganizationRepository
+	accountRepo domain.AccountRepository
+	denylist    auth.SessionDenylist
+	claims      auth.TokenClaimsValidator
+	policy      *OAuthClientPolicy
+	logger      loggerDomain.Logger
+}
+
+func NewOAuthClientService(
+	clientRepo domain.OAuthClientRepository,
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	denylist auth.SessionDenylist,
+	claims auth.TokenClaimsValidator,
+	policy *OAuthClientPolicy,
+	logger loggerDomain.Logger,
+) OAuthClientService {
+	return &oauthClientService{
+		clientRepo:  clientRepo,
+		orgRepo:     orgRepo,
+		accountRepo: accountRepo,
+		denylist:    denylist,
+		claims:      claims,
+		policy:      policy,
+		logger:      logger,
+	}
+}
+
+func (s *oauthClientService) CreateClient(ctx context.Context, orgID, createdBy int32, req *CreateOAuthClientRequest) (*CreatedOAuthClient, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrOAuthClientsDisabled
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domain.ErrOAuthClientNameRequired
+	}
+
+	scopes, err := normalizeClientScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	clientID, secret, err := generateOAuthClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountRepo.Create(ctx, &domain.Account{
+		OrganizationID: orgID,
+		Email:          clientAccountEmail(clientID),
+		FullName:       "OAuth client: " + name,
+		Role:           "member",
+		Status:         "active",
+		Kind:           domain.AccountKindOAuthClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client service account: %w", err)
+	}
+
+	client, err := s.clientRepo.Create(ctx, &domain.OAuthClient{
+		OrganizationID:     orgID,
+		AccountID:          account.ID,
+		ClientID:           clientID,
+		SecretHash:         hashOAuthClientSecret(secret),
+		Name:               name,
+		Scopes:             scopes,
+		CreatedByAccountID: &createdBy,
+	})
+	if err != nil {
+		if deleteErr := s.accountRepo.Delete(ctx, orgID, account.ID); deleteErr != nil {
+			s.logger.Error("failed to delete orphaned client service account", loggerDomain.Fields{
+				"organization_id": orgID,
+				"account_id":      account.ID,
+				"error":           deleteErr.Error(),
+			})
+		}
+		return nil, err
+	}
+
+	s.audit("oauth_client.created", orgID, createdBy, loggerDomain.Fields{
+		"client_id": client.ClientID,
+		"name":      client.Name,
+		"scopes":    client.Scopes,
+	})
+
+	return &CreatedOAuthClient{
+		OAuthClient:  client,
+		ClientSecret: secret,
+	}, nil
+}
+
+func (s *oauthClientService) ListClients(ctx context.Context, orgID int32) ([]*domain.OAuthClient, error) {
+	return s.clientRepo.ListByOrganization(ctx, orgID)
+}
+
+func (s *oauthClientService) RevokeClient(ctx context.Context, orgID, revokedBy, id int32) (*domain.OAuthClient, error) {
+	client, err := s.clientRepo.Revoke(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Tokens already issued stop working right away
+	if err := s.denylist.RevokeSubject(ctx, client.ClientID, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to revoke client tokens: %w", err)
+	}
+
+	if account, err := s.accountRepo.GetByID(ctx, orgID, client.AccountID); err == nil {
+		account.Status = "inactive"
+		if _, err := s.accountRepo.Update(ctx, account); err != nil {
+			return nil, fmt.Errorf("failed to deactivate client service account: %w", err)
+		}
+	}
+
+	s.audit("oauth_client.revoked", orgID, revokedBy, loggerDomain.Fields{
+		"client_id": client.ClientID,
+		"name":      client.Name,
+	})
+
+	return client, nil
+}
+
+func (s *oauthClientService) IssueToken(ctx context.Context, clientID, clientSecret, scope, audience string) (*ClientAccessToken, error) {
+	client, err := s.authenticateClient(ctx, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := client.Scopes
+	if requested := strings.Fields(scope); len(requested) > 0 {
+		for _, requestedScope := range requested {
+			if !slices.Contains(client.Scopes, requestedScope) {
+				return nil, domain.ErrOAuthInvalidScope
+			}
+		}
+		scopes = requested
+	}
+
+	audiences, err := s.claims.Audiences(strings.Fields(audience))
+	if err != nil {
+		return nil, domain.ErrOAuthInvalidTarget
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, client.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve client organization: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.policy.TokenTTL)
+	claims := clientTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.claims.Issuer(),
+			Audience:  jwt.ClaimStrings(audiences),
+			Subject:   client.ClientID,
+			ID:        uuid.New().String(),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		TokenType:      clientTokenType,
+		Email:          clientAccountEmail(client.ClientID),
+		OrganizationID: org.StytchOrgID,
+		Scope:          strings.Join(scopes, " "),
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.policy.Secret))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign client token: %w", err)
+	}
+
+	if err := s.clientRepo.Touch(ctx, client.ID); err != nil {
+		s.logger.Warn("failed to record oauth client use", loggerDomain.Fields{
+			"client_id": client.ClientID,
+			"error":     err.Error(),
+		})
+	}
+
+	s.audit("oauth_client.token_issued", client.OrganizationID, client.AccountID, loggerDomain.Fields{
+		"client_id":  client.ClientID,
+		"token_id":   claims.ID,
+		"scope":      claims.Scope,
+		"audience":   audiences,
+		"expires_at": expiresAt.UTC().Format(time.RFC3339),
+	})
+
+	return &ClientAccessToken{
+		AccessToken: token,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.policy.TokenTTL / time.Second),
+		Scope:       claims.Scope,
+		Audience:    strings.Join(audiences, " "),
+	}, nil
+}
+
+func (s *oauthClientService) IntrospectToken(ctx context.Context, clientID, clientSecret, token string) (*TokenIntrospection, error) {
+	caller, err := s.authenticateClient(ctx, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	inactive := &TokenIntrospection{Active: false}
+
+	if !s.policy.Enabled {
+		return inactive, nil
+	}
+	claims, err := s.parseClientToken(token)
+	if err != nil {
+		return inactive, nil
+	}
+	// Downstream services introspect tokens issued for them, not for this API
+	if err := s.claims.ValidateIssued(clientTokenType, claims.Issuer, claims.Audience); err != nil {
+		return inactive, nil
+	}
+	identity := claims.identity()
+
+	revoked, err := s.denylist.IsRevoked(ctx, identity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+	if revoked {
+		return inactive, nil
+	}
+
+	// Clients only learn about tokens of their own organization
+	owner, err := s.clientRepo.GetByClientID(ctx, identity.ClientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOAuthClientNotFound) {
+			return inactive, nil
+		}
+		return nil, err
+	}
+	if owner.IsRevoked() || owner.OrganizationID != caller.OrganizationID {
+		return inactive, nil
+	}
+
+	scopes := make([]string, len(identity.Permissions))
+	for i, permission := range identity.Permissions {
+		scopes[i] = permission.String()
+	}
+
+	return &TokenIntrospection{
+		Active:         true,
+		Scope:          strings.Join(scopes, " "),
+		ClientID:       identity.ClientID,
+		Username:       identity.Email,
+		TokenType:      "Bearer",
+		ExpiresAt:      identity.ExpiresAt.Unix(),
+		IssuedAt:       identity.IssuedAt.Unix(),
+		Subject:        identity.UserID,
+		Audience:       claims.Audience,
+		TokenID:        identity.TokenID,
+		OrganizationID: identity.OrganizationID,
+	}, nil
+}
+
+// authenticateClient checks a client's credentials. Unknown clients, wrong
+// secrets and revoked clients all return ErrOAuthInvalidClient.
+func (s *oauthClientService) authenticateClient(ctx context.Context, clientID, clientSecret string) (*domain.OAuthClient, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrOAuthClientsDisabled
+	}
+
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOAuthClientNotFound) {
+			return nil, domain.ErrOAuthInvalidClient
+		}
+		return nil, err
+	}
+
+	secretHash := hashOAuthClientSecret(clientSecret)
+	if subtle.ConstantTimeCompare([]byte(secretHash), []byte(client.SecretHash)) != 1 || client.IsRevoked() {
+		s.audit("oauth_client.authentication_failed", client.OrganizationID, client.AccountID, loggerDomain.Fields{
+			"client_id": client.ClientID,
+			"revoked":   client.IsRevoked(),
+		})
+		return nil, domain.ErrOAuthInvalidClient
+	}
+
+	return client, nil
+}
+
+// IsClientToken implements auth.ClientTokenVerifier.
+func (s *oauthClientService) IsClientToken(token string) bool {
+	if !s.policy.Enabled {
+		return false
+	}
+
+	var claims clientTokenClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(token, &claims); err != nil {
+		return false
+	}
+	return claims.TokenType == clientTokenType
+}
+
+// VerifyClientToken implements auth.ClientTokenVerifier.
+func (s *oauthClientService) VerifyClientToken(ctx context.Context, token string) (*auth.Identity, error) {
+	if !s.policy.Enabled {
+		return nil, auth.ErrInvalidToken
+	}
+
+	claims, err := s.parseClientToken(token)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.claims.Validate(clientTokenType, claims.Issuer, claims.Audience); err != nil {
+		return nil, err
+	}
+
+	return claims.identity(), nil
+}
+
+// parseClientToken verifies the signature, lifetime and type of a client
+// token. The issuer and audience are left to the caller.
+func (s *oauthClientService) parseClientToken(token string) (*clientTokenClaims, error) {
+	var claims clientTokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (any, error) {
+		return []byte(s.policy.Secret), nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			return nil, auth.ErrTokenExpired
+		}
+		return nil, auth.ErrInvalidToken
+	}
+
+	if claims.TokenType != clientTokenType || claims.Subject == "" || claims.IssuedAt == nil {
+		return nil, auth.ErrInvalidToken
+	}
+	return &claims, nil
+}
+
+// identity converts verified client token claims to the identity of the client's service account.
+func (c *clientTokenClaims) identity() *auth.Identity {
+	scopes := strings.Fields(c.Scope)
+	permissions := make([]auth.Permission, len(scopes))
+	for i, scope := range scopes {
+		permissions[i] = auth.Permission(scope)
+	}
+
+	return &auth.Identity{
+		UserID:         c.Subject,
+		ClientID:       c.Subject,
+		Email:          c.Email,
+		OrganizationID: c.OrganizationID,
+		Permissions:    permissions,
+		TokenID:        c.ID,
+		IssuedAt:       c.IssuedAt.Time,
+		ExpiresAt:      c.ExpiresAt.Time,
+	}
+}
+
+// audit writes an audit log entry for the OAuth client lifecycle.
+func (s *oauthClientService) audit(event string, orgID, accountID int32, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = orgID
+	fields["account_id"] = accountID
+	s.logger.Info("oauth client audit", fields)
+}
+
+// normalizeClientScopes validates and de-duplicates requested client scopes.
+// Clients cannot manage the organization, so they cannot register other
+// clients or impersonate members.
+func normalizeClientScopes(requested []string) ([]string, error) {
+	var scopes []string
+	for _, scope := range requested {
+		perm := auth.Permission(strings.ToLower(strings.TrimSpace(scope)))
+		if !slices.Contains(auth.AllPermissions, perm) || perm == auth.PermOrgManage {
+			return nil, domain.ErrOAuthInvalidScope
+		}
+		if !slices.Contains(scopes, perm.String()) {
+			scopes = append(scopes, perm.String())
+		}
+	}
+	if len(scopes) == 0 {
+		return nil, domain.ErrOAuthClientScopeRequired
+	}
+	return scopes, nil
+}
+
+func clientAccountEmail(clientID string) string {
+	return fmt.Sprintf("%s@%s", clientID, oauthClientEmailDomain)
+}
+
+// generateOAuthClientCredentials returns a new client ID and secret. Client
+// IDs are lowercase hex since they are also part of the service account email.
+func generateOAuthClientCredentials() (string, string, error) {
+	buf := make([]byte, oauthClientIDBytes+oauthClientSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate oauth client credentials: %w", err)
+	}
+	clientID := "cli_" + hex.EncodeToString(buf[:oauthClientIDBytes])
+	secret := "cs_" + base64.RawURLEncoding.EncodeToString(buf[oauthClientIDBytes:])
+	return clientID, secret, nil
+}
+
+func hashOAuthClientSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
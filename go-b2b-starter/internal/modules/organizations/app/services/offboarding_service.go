@@ -0,0 +1,190 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// OffboardingService removes a member from an organization in one workflow:
+// their data is transferred to another member or deleted, their sessions are
+// revoked and they are removed from the auth provider.
+type OffboardingService interface {
+	// OffboardMember offboards the member and returns a report of what was done
+	OffboardMember(ctx context.Context, orgID int32, providerOrgID, memberID string, offboardedBy int32, req *OffboardMemberRequest) (*OffboardingReport, error)
+}
+
+// OffboardMemberRequest represents the request to offboard a member.
+// Exactly one of TransferToAccountID and DeleteData must be set.
+type OffboardMemberRequest struct {
+	TransferToAccountID *int32 `json:"transfer_to_account_id,omitempty"`
+	DeleteData          bool   `json:"delete_data"`
+}
+
+// Data actions reported in OffboardingReport
+const (
+	OffboardingDataTransfer = "transfer"
+	OffboardingDataDelete   = "delete"
+)
+
+// OffboardingReport summarizes an offboarding for the admin and the audit log
+type OffboardingReport struct {
+	MemberID               string           `json:"member_id"`
+	AccountID              int32            `json:"account_id"`
+	Email                  string           `json:"email"`
+	DataAction             string           `json:"data_action"`
+	TransferredToAccountID *int32           `json:"transferred_to_account_id,omitempty"`
+	Data                   map[string]int64 `json:"data"`
+	SessionsRevoked        bool             `json:"sessions_revoked"`
+	RemovedFromProvider    bool             `json:"removed_from_provider"`
+	OffboardedBy           int32            `json:"offboarded_by"`
+	CompletedAt            time.Time        `json:"completed_at"`
+}
+
+type offboardingService struct {
+	accountRepo    domain.AccountRepository
+	authMemberRepo domain.AuthMemberRepository
+	revoker        auth.SessionRevoker
+	denylist       auth.SessionDenylist
+	eventBus       eventbus.EventBus
+	logger         loggerDomain.Logger
+}
+
+func NewOffboardingService(
+	accountRepo domain.AccountRepository,
+	authMemberRepo domain.AuthMemberRepository,
+	revoker auth.SessionRevoker,
+	denylist auth.SessionDenylist,
+	eventBus eventbus.EventBus,
+	logger loggerDomain.Logger,
+) OffboardingService {
+	return &offboardingService{
+		accountRepo:    accountRepo,
+		authMemberRepo: authMemberRepo,
+		revoker:        revoker,
+		denylist:       denylist,
+		eventBus:       eventBus,
+		logger:         logger,
+	}
+}
+
+// OffboardMember runs the offboarding steps in order. The account is
+// deactivated first so the member loses access right away; if moving their
+// data fails, the account is reactivated and nothing else changes. The
+// remaining steps are idempotent, so a failed offboarding can be retried.
+func (s *offboardingService) OffboardMember(
+	ctx context.Context,
+	orgID int32,
+	providerOrgID, memberID string,
+	offboardedBy int32,
+	req *OffboardMemberRequest,
+) (*OffboardingReport, error) {
+	if (req.TransferToAccountID == nil) == !req.DeleteData {
+		return nil, domain.ErrOffboardingActionRequired
+	}
+
+	account, err := findAccountByMemberID(ctx, s.accountRepo, orgID, memberID)
+	if err != nil {
+		return nil, err
+	}
+	if account.ID == offboardedBy {
+		return nil, domain.ErrOffboardingSelf
+	}
+
+	report := &OffboardingReport{
+		MemberID:     memberID,
+		AccountID:    account.ID,
+		Email:        account.Email,
+		DataAction:   OffboardingDataDelete,
+		OffboardedBy: offboardedBy,
+	}
+
+	var transferTo int32
+	if req.TransferToAccountID != nil {
+		target, err := s.accountRepo.GetByID(ctx, orgID, *req.TransferToAccountID)
+		if err != nil || target.ID == account.ID || !target.IsActive() {
+			return nil, domain.ErrOffboardingInvalidTarget
+		}
+		transferTo = target.ID
+		report.DataAction = OffboardingDataTransfer
+		report.TransferredToAccountID = &transferTo
+	}
+
+	var rollbacks rollbackStack
+
+	// Step 1: Deactivate the local account
+	if account.IsActive() {
+		status := account.Status
+		account.Status = "inactive"
+		if _, err := s.accountRepo.Update(ctx, account); err != nil {
+			return nil, fmt.Errorf("failed to deactivate account: %w", err)
+		}
+		rollbacks.add(func(ctx context.Context) error {
+			account.Status = status
+			_, err := s.accountRepo.Update(ctx, account)
+			return err
+		})
+	}
+
+	// Step 2: Transfer or delete the member's data in every module that owns some
+	event := events.NewMemberOffboarding(orgID, account.ID, transferTo)
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		rollbacks.execute(ctx, s.logger)
+		return nil, fmt.Errorf("failed to %s member data: %w", report.DataAction, err)
+	}
+	report.Data = event.Results.Counts()
+
+	// Step 3: Revoke the member's sessions and access tokens
+	if err := s.denylist.RevokeSubject(ctx, memberID, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to denylist member tokens: %w", err)
+	}
+	if err := s.revoker.RevokeUserSessions(ctx, memberID); err != nil {
+		return nil, fmt.Errorf("failed to revoke member sessions: %w", err)
+	}
+	report.SessionsRevoked = true
+
+	// Step 4: Remove the member from the auth provider organization
+	if err := s.authMemberRepo.RemoveMembers(ctx, &domain.RemoveAuthMembersRequest{
+		OrganizationID: providerOrgID,
+		MemberIDs:      []string{memberID},
+	}); err != nil {
+		return nil, fmt.Errorf("failed to remove member: %w", err)
+	}
+	report.RemovedFromProvider = true
+	report.CompletedAt = time.Now()
+
+	s.logger.Info("member offboarding audit", loggerDomain.Fields{
+		"audit":           true,
+		"event":           "member.offboarded",
+		"organization_id": orgID,
+		"account_id":      account.ID,
+		"member_id":       memberID,
+		"data_action":     report.DataAction,
+		"transferred_to":  transferTo,
+		"data":            report.Data,
+		"offboarded_by":   offboardedBy,
+	})
+
+	return report, nil
+}
+
+// findAccountByMemberID returns the local account linked to an auth provider member.
+func findAccountByMemberID(ctx context.Context, accountRepo domain.AccountRepository, orgID int32, memberID string) (*domain.Account, error) {
+	accounts, err := accountRepo.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	for _, account := range accounts {
+		if account.StytchMemberID == memberID {
+			return account, nil
+		}
+	}
+	return nil, domain.ErrAccountNotFound
+}
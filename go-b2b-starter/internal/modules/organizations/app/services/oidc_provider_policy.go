@@ -0,0 +1,238 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// minOIDCSigningKeyBits is the smallest RSA key accepted for signing id_tokens
+const minOIDCSigningKeyBits = 2048
+
+// Where the OIDC provider's signing key came from
+const (
+	// OIDCKeySourceFile is a key loaded from OIDC_PROVIDER_SIGNING_KEY_PATH
+	OIDCKeySourceFile = "file"
+	// OIDCKeySourceDev is a development key generated once and kept at OIDC_PROVIDER_DEV_KEY_PATH
+	OIDCKeySourceDev = "dev"
+	// OIDCKeySourceEphemeral is a key generated in memory; tokens stop verifying on restart
+	OIDCKeySourceEphemeral = "ephemeral"
+)
+
+// OIDCProviderPolicy controls OpenID Connect provider mode, in which internal
+// tools sign members in with the authorization code flow and this API issues
+// their id_tokens.
+//
+// All values can be set via environment variables with the OIDC_PROVIDER_ prefix.
+type OIDCProviderPolicy struct {
+	// Enabled turns on client registration and the OpenID Connect endpoints
+	Enabled bool `mapstructure:"OIDC_PROVIDER_ENABLED"`
+
+	// Issuer is the iss claim of issued tokens. Discovery is served at
+	// {Issuer}/.well-known/openid-configuration, so it must be the public
+	// URL of the /oidc routes.
+	Issuer string `mapstructure:"OIDC_PROVIDER_ISSUER"`
+
+	// AuthorizeURL is the frontend consent page clients send members to
+	AuthorizeURL string `mapstructure:"OIDC_PROVIDER_AUTHORIZE_URL"`
+
+	// SigningKeyPath is a PEM file with the RSA private key that signs tokens (RS256).
+	// Required in production.
+	SigningKeyPath string `mapstructure:"OIDC_PROVIDER_SIGNING_KEY_PATH"`
+
+	// DevKeyPath is where a development key is generated (owner-only
+	// permissions) and reused when SigningKeyPath is empty outside production
+	DevKeyPath string `mapstructure:"OIDC_PROVIDER_DEV_KEY_PATH"`
+
+	// EphemeralKey generates a new key in memory on every start instead of
+	// using DevKeyPath. Tokens issued before a restart stop verifying.
+	EphemeralKey bool `mapstructure:"OIDC_PROVIDER_EPHEMERAL_KEY"`
+
+	// CodeTTL is how long an authorization code can be exchanged
+	CodeTTL time.Duration `mapstructure:"OIDC_PROVIDER_CODE_TTL"`
+
+	// TokenTTL is the lifetime of issued id_tokens and access tokens
+	TokenTTL time.Duration `mapstructure:"OIDC_PROVIDER_TOKEN_TTL"`
+
+	// Production is set when ENV=PROD; generated keys are refused
+	Production bool `mapstructure:"-"`
+
+	// SigningKey is the key that signs tokens and KeySource where it came from
+	SigningKey *rsa.PrivateKey `mapstructure:"-"`
+	KeySource  string          `mapstructure:"-"`
+}
+
+// LoadOIDCProviderPolicy loads the OpenID Connect provider policy from environment variables and app.env file.
+func LoadOIDCProviderPolicy() (*OIDCProviderPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("OIDC_PROVIDER_ENABLED", false)
+	v.SetDefault("OIDC_PROVIDER_ISSUER", "http://localhost:8080/api/v1/oidc")
+	v.SetDefault("OIDC_PROVIDER_AUTHORIZE_URL", "http://localhost:3000/oidc/authorize")
+	v.SetDefault("OIDC_PROVIDER_SIGNING_KEY_PATH", "")
+	v.SetDefault("OIDC_PROVIDER_DEV_KEY_PATH", ".keys/oidc_provider_dev.pem")
+	v.SetDefault("OIDC_PROVIDER_EPHEMERAL_KEY", false)
+	v.SetDefault("OIDC_PROVIDER_CODE_TTL", "5m")
+	v.SetDefault("OIDC_PROVIDER_TOKEN_TTL", "1h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy OIDCProviderPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode oidc provider policy: %w", err)
+	}
+	policy.Issuer = strings.TrimSuffix(strings.TrimSpace(policy.Issuer), "/")
+	policy.Production = strings.EqualFold(strings.TrimSpace(v.GetString("ENV")), "PROD")
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	if policy.Enabled {
+		var err error
+		switch {
+		case policy.SigningKeyPath != "":
+			policy.SigningKey, err = loadOIDCSigningKey(policy.SigningKeyPath)
+			policy.KeySource = OIDCKeySourceFile
+		case policy.EphemeralKey:
+			policy.SigningKey, err = rsa.GenerateKey(rand.Reader, minOIDCSigningKeyBits)
+			policy.KeySource = OIDCKeySourceEphemeral
+		default:
+			policy.SigningKey, err = loadOrCreateOIDCDevKey(policy.DevKeyPath)
+			policy.KeySource = OIDCKeySourceDev
+		}
+		if err != nil {
+			return nil, fmt.Errorf("oidc provider policy invalid: %w", err)
+		}
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that an enabled policy has a usable issuer, key and lifetimes.
+func (p *OIDCProviderPolicy) Validate() error {
+	if !p.Enabled {
+		return nil
+	}
+	if u, err := url.Parse(p.Issuer); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("oidc provider policy invalid: OIDC_PROVIDER_ISSUER must be an absolute URL without query or fragment")
+	}
+	if p.AuthorizeURL == "" {
+		return fmt.Errorf("oidc provider policy invalid: OIDC_PROVIDER_AUTHORIZE_URL is required")
+	}
+	if p.SigningKeyPath == "" && p.Production {
+		return fmt.Errorf("oidc provider policy invalid: OIDC_PROVIDER_SIGNING_KEY_PATH is required in production; generated keys are for development only")
+	}
+	if p.SigningKeyPath == "" && !p.EphemeralKey && p.DevKeyPath == "" {
+		return fmt.Errorf("oidc provider policy invalid: OIDC_PROVIDER_DEV_KEY_PATH is required without a signing key")
+	}
+	if p.CodeTTL <= 0 || p.TokenTTL <= 0 {
+		return fmt.Errorf("oidc provider policy invalid: OIDC_PROVIDER_CODE_TTL and OIDC_PROVIDER_TOKEN_TTL must be positive")
+	}
+	return nil
+}
+
+// loadOIDCSigningKey reads an RSA private key in PKCS#1 or PKCS#8 PEM form.
+func loadOIDCSigningKey(path string) (*rsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", path)
+	}
+
+	var key *rsa.PrivateKey
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, parseErr := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if parseErr != nil {
+			err = parseErr
+			break
+		}
+		var ok bool
+		if key, ok = parsed.(*rsa.PrivateKey); !ok {
+			return nil, fmt.Errorf("signing key %s is not an RSA key", path)
+		}
+	default:
+		return nil, fmt.Errorf("signing key %s has unsupported PEM type %q", path, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+
+	if key.N.BitLen() < minOIDCSigningKeyBits {
+		return nil, fmt.Errorf("signing key must be at least %d bits", minOIDCSigningKeyBits)
+	}
+
+	return key, nil
+}
+
+// loadOrCreateOIDCDevKey loads the development signing key at path, generating
+// it on first use so tokens keep verifying across restarts. The key file must
+// only be accessible to its owner.
+func loadOrCreateOIDCDevKey(path string) (*rsa.PrivateKey, error) {
+	info, err := os.Stat(path)
+	if err == nil {
+		if info.Mode().Perm()&0o077 != 0 {
+			return nil, fmt.Errorf("dev signing key %s must only be accessible to its owner (chmod 600)", path)
+		}
+		return loadOIDCSigningKey(path)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to stat dev signing key: %w", err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, minOIDCSigningKeyBits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate dev signing key: %w", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode dev signing key: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create dev signing key directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create dev signing key: %w", err)
+	}
+	if err := pem.Encode(file, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		file.Close()
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write dev signing key: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(path)
+		return nil, fmt.Errorf("failed to write dev signing key: %w", err)
+	}
+
+	return key, nil
+}
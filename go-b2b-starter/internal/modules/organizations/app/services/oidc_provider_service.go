@@ -0,0 +1,752 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// OIDCProviderService lets internal tools use this API as their identity
+// provider ("Sign in with <product>") through the OpenID Connect
+// authorization code flow.
+//
+// Clients belong to an organization and only its members can sign in to
+// them. The frontend consent page calls GetConsent and Authorize on behalf of
+// the signed-in member; the client then exchanges the code for an id_token
+// and an access token that is only good for the userinfo endpoint.
+type OIDCProviderService interface {
+	// Discovery returns the OpenID provider metadata
+	Discovery() (*OIDCDiscovery, error)
+
+	// JWKS returns the public keys that verify issued tokens
+	JWKS() (*OIDCKeySet, error)
+
+	// CreateClient registers a client; the secret is only returned here
+	CreateClient(ctx context.Context, orgID, createdBy int32, req *CreateOIDCClientRequest) (*CreatedOIDCClient, error)
+
+	// ListClients lists the organization's clients, newest first
+	ListClients(ctx context.Context, orgID int32) ([]*domain.OIDCClient, error)
+
+	// RevokeClient stops members from signing in to the client
+	RevokeClient(ctx context.Context, orgID, revokedBy, id int32) (*domain.OIDCClient, error)
+
+	// GetConsent validates an authorization request and describes what the
+	// member is asked to approve
+	GetConsent(ctx context.Context, orgID, accountID int32, req *OIDCAuthorizeRequest) (*OIDCConsentDetails, error)
+
+	// Authorize records the member's decision and returns where to send them
+	// back to, with an authorization code if they approved
+	Authorize(ctx context.Context, orgID, accountID int32, identity *auth.Identity, req *OIDCAuthorizeDecision) (*OIDCAuthorizeResult, error)
+
+	// ExchangeCode authenticates the client and redeems an authorization code
+	ExchangeCode(ctx context.Context, clientID, clientSecret string, req *OIDCTokenRequest) (*OIDCTokenResponse, error)
+
+	// UserInfo returns the claims of the member an access token was issued for
+	UserInfo(ctx context.Context, accessToken string) (*OIDCUserInfo, error)
+}
+
+// CreateOIDCClientRequest represents the request to register an OpenID Connect client
+type CreateOIDCClientRequest struct {
+	Name         string   `json:"name" binding:"required,max=255"`
+	RedirectURIs []string `json:"redirect_uris" binding:"required"`
+}
+
+// CreatedOIDCClient is returned once when a client is registered
+type CreatedOIDCClient struct {
+	*domain.OIDCClient
+	ClientSecret string `json:"client_secret"`
+}
+
+// OIDCAuthorizeRequest carries the parameters of an authentication request
+// (OpenID Connect Core section 3.1.2.1) as the client sent them
+type OIDCAuthorizeRequest struct {
+	ClientID            string `form:"client_id" json:"client_id" binding:"required"`
+	RedirectURI         string `form:"redirect_uri" json:"redirect_uri" binding:"required"`
+	ResponseType        string `form:"response_type" json:"response_type" binding:"required"`
+	Scope               string `form:"scope" json:"scope" binding:"required"`
+	State               string `form:"state" json:"state"`
+	Nonce               string `form:"nonce" json:"nonce"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method"`
+}
+
+// OIDCAuthorizeDecision is the member's answer to an authentication request
+type OIDCAuthorizeDecision struct {
+	OIDCAuthorizeRequest
+	Approved bool `json:"approved"`
+}
+
+// OIDCConsentDetails describes a validated authentication request
+type OIDCConsentDetails struct {
+	ClientID    string   `json:"client_id"`
+	ClientName  string   `json:"client_name"`
+	RedirectURI string   `json:"redirect_uri"`
+	Scopes      []string `json:"scopes"`
+
+	// ConsentGranted is true if the member already approved these scopes,
+	// so the page can approve without asking again
+	ConsentGranted bool `json:"consent_granted"`
+}
+
+// OIDCAuthorizeResult is where the member's browser goes next
+type OIDCAuthorizeResult struct {
+	RedirectURI string `json:"redirect_uri"`
+}
+
+// OIDCTokenRequest is the authorization_code grant of the token endpoint
+type OIDCTokenRequest struct {
+	Code         string
+	RedirectURI  string
+	CodeVerifier string
+}
+
+// OIDCTokenResponse is the token endpoint response (OpenID Connect Core section 3.1.3.3)
+type OIDCTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
+	Scope       string `json:"scope"`
+}
+
+// OIDCUserInfo is the userinfo endpoint response (OpenID Connect Core section 5.3.2)
+type OIDCUserInfo struct {
+	Subject        string `json:"sub"`
+	Email          string `json:"email,omitempty"`
+	EmailVerified  *bool  `json:"email_verified,omitempty"`
+	Name           string `json:"name,omitempty"`
+	OrganizationID string `json:"org"`
+}
+
+// OIDCDiscovery is the OpenID provider metadata (OpenID Connect Discovery section 3)
+type OIDCDiscovery struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// OIDCKeySet is a JSON Web Key Set (RFC 7517 section 5)
+type OIDCKeySet struct {
+	Keys []OIDCKey `json:"keys"`
+}
+
+// OIDCKey is an RSA public JSON Web Key (RFC 7518 section 6.3.1)
+type OIDCKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+const (
+	oidcScopeOpenID  = "openid"
+	oidcScopeProfile = "profile"
+	oidcScopeEmail   = "email"
+
+	// oidcAccessTokenType marks access tokens so an id_token can't be used at userinfo
+	oidcAccessTokenType = "oidc_access"
+
+	// Random bytes in generated client IDs, secrets and codes
+	oidcClientIDBytes     = 16
+	oidcClientSecretBytes = 32
+	oidcCodeBytes         = 32
+)
+
+// oidcSupportedScopes are the scopes clients can request
+var oidcSupportedScopes = []string{oidcScopeOpenID, oidcScopeProfile, oidcScopeEmail}
+
+// oidcIDTokenClaims are the claims of an id_token and, with TokenType and
+// Scope set, of an access token
+type oidcIDTokenClaims struct {
+	jwt.RegisteredClaims
+	TokenType       string           `json:"typ,omitempty"`
+	AuthorizedParty string           `json:"azp"`
+	AuthTime        *jwt.NumericDate `json:"auth_time,omitempty"`
+	Nonce           string           `json:"nonce,omitempty"`
+	Scope           string           `json:"scope,omitempty"`
+	Email           string           `json:"email,omitempty"`
+	EmailVerified   *bool            `json:"email_verified,omitempty"`
+	Name            string           `json:"name,omitempty"`
+	OrganizationID  string           `json:"org"`
+}
+
+type oidcProviderService struct {
+	clientRepo  domain.OIDCClientRepository
+	grantRepo   domain.OIDCGrantRepository
+	orgRepo     domain.OrganizationRepository
+	accountRepo domain.AccountRepository
+	policy      *OIDCProviderPolicy
+	logger      loggerDomain.Logger
+}
+
+func NewOIDCProviderService(
+	clientRepo domain.OIDCClientRepository,
+	grantRepo domain.OIDCGrantRepository,
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	policy *OIDCProviderPolicy,
+	logger loggerDomain.Logger,
+) OIDCProviderService {
+	if policy.Enabled && policy.KeySource != OIDCKeySourceFile {
+		logger.Warn("oidc provider is signing tokens with a generated development key", loggerDomain.Fields{
+			"key_source": policy.KeySource,
+			"key_path":   policy.DevKeyPath,
+			"message":    "Set OIDC_PROVIDER_SIGNING_KEY_PATH to a managed key; ephemeral keys invalidate issued tokens on every restart",
+		})
+	}
+
+	return &oidcProviderService{
+		clientRepo:  clientRepo,
+		grantRepo:   grantRepo,
+		orgRepo:     orgRepo,
+		accountRepo: accountRepo,
+		policy:      policy,
+		logger:      logger,
+	}
+}
+
+func (s *oidcProviderService) Discovery() (*OIDCDiscovery, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrOIDCProviderDisabled
+	}
+
+	return &OIDCDiscovery{
+		Issuer:                            s.policy.Issuer,
+		AuthorizationEndpoint:             s.policy.AuthorizeURL,
+		TokenEndpoint:                     s.policy.Issuer + "/token",
+		UserinfoEndpoint:                  s.policy.Issuer + "/userinfo",
+		JWKSURI:                           s.policy.Issuer + "/jwks",
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{jwt.SigningMethodRS256.Alg()},
+		ScopesSupported:                   oidcSupportedScopes,
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported: []string{
+			"iss", "sub", "aud", "exp", "iat", "auth_time", "nonce", "azp",
+			"email", "email_verified", "name", "org",
+		},
+	}, nil
+}
+
+func (s *oidcProviderService) JWKS() (*OIDCKeySet, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrOIDCProviderDisabled
+	}
+
+	return &OIDCKeySet{Keys: []OIDCKey{oidcPublicKey(&s.policy.SigningKey.PublicKey)}}, nil
+}
+
+func (s *oidcProviderService) CreateClient(ctx context.Context, orgID, createdBy int32, req *CreateOIDCClientRequest) (*CreatedOIDCClient, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrOIDCProviderDisabled
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, domain.ErrOIDCClientNameRequired
+	}
+
+	redirectURIs, err := normalizeOIDCRedirectURIs(req.RedirectURIs)
+	if err != nil {
+		return nil, err
+	}
+
+	clientID, secret, err := generateOIDCClientCredentials()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.clientRepo.Create(ctx, &domain.OIDCClient{
+		OrganizationID:     orgID,
+		ClientID:           clientID,
+		SecretHash:         hashOAuthClientSecret(secret),
+		Name:               name,
+		RedirectURIs:       redirectURIs,
+		CreatedByAccountID: &createdBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("oidc_client.created", orgID, createdBy, loggerDomain.Fields{
+		"client_id":     client.ClientID,
+		"name":          client.Name,
+		"redirect_uris": client.RedirectURIs,
+	})
+
+	return &CreatedOIDCClient{
+		OIDCClient:   client,
+		ClientSecret: secret,
+	}, nil
+}
+
+func (s *oidcProviderService) ListClients(ctx context.Context, orgID int32) ([]*domain.OIDCClient, error) {
+	return s.clientRepo.ListByOrganization(ctx, orgID)
+}
+
+func (s *oidcProviderService) RevokeClient(ctx context.Context, orgID, revokedBy, id int32) (*domain.OIDCClient, error) {
+	client, err := s.clientRepo.Revoke(ctx, orgID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("oidc_client.revoked", orgID, revokedBy, loggerDomain.Fields{
+		"client_id": client.ClientID,
+		"name":      client.Name,
+	})
+
+	return client, nil
+}
+
+func (s *oidcProviderService) GetConsent(ctx context.Context, orgID, accountID int32, req *OIDCAuthorizeRequest) (*OIDCConsentDetails, error) {
+	client, scopes, err := s.validateAuthorizeRequest(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	consent, err := s.grantRepo.GetConsent(ctx, client.ID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	granted := consent != nil
+	for _, scope := range scopes {
+		if granted && !slices.Contains(consent.Scopes, scope) {
+			granted = false
+		}
+	}
+
+	return &OIDCConsentDetails{
+		ClientID:       client.ClientID,
+		ClientName:     client.Name,
+		RedirectURI:    req.RedirectURI,
+		Scopes:         scopes,
+		ConsentGranted: granted,
+	}, nil
+}
+
+func (s *oidcProviderService) Authorize(ctx context.Context, orgID, accountID int32, identity *auth.Identity, req *OIDCAuthorizeDecision) (*OIDCAuthorizeResult, error) {
+	// Only a member signed in as themselves can sign in to a client
+	if identity == nil || identity.Guest || identity.IsMachine() || identity.IsImpersonated() {
+		return nil, domain.ErrOIDCInteractiveLoginRequired
+	}
+
+	client, scopes, err := s.validateAuthorizeRequest(ctx, orgID, &req.OIDCAuthorizeRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	if !req.Approved {
+		s.audit("oidc.authorization_denied", orgID, accountID, loggerDomain.Fields{
+			"client_id": client.ClientID,
+		})
+		return &OIDCAuthorizeResult{
+			RedirectURI: oidcRedirect(req.RedirectURI, url.Values{"error": {"access_denied"}}, req.State),
+		}, nil
+	}
+
+	if _, err := s.grantRepo.SaveConsent(ctx, &domain.OIDCConsent{
+		OIDCClientID:   client.ID,
+		OrganizationID: orgID,
+		AccountID:      accountID,
+		Scopes:         scopes,
+	}); err != nil {
+		return nil, err
+	}
+
+	code, codeHash, err := generateOIDCCode()
+	if err != nil {
+		return nil, err
+	}
+
+	var authTime *time.Time
+	if !identity.AuthenticatedAt.IsZero() {
+		authenticatedAt := identity.AuthenticatedAt
+		authTime = &authenticatedAt
+	}
+
+	if _, err := s.grantRepo.CreateCode(ctx, &domain.OIDCAuthorizationCode{
+		OIDCClientID:   client.ID,
+		OrganizationID: orgID,
+		AccountID:      accountID,
+		CodeHash:       codeHash,
+		Subject:        identity.UserID,
+		EmailVerified:  identity.EmailVerified,
+		RedirectURI:    req.RedirectURI,
+		Scopes:         scopes,
+		Nonce:          req.Nonce,
+		CodeChallenge:  req.CodeChallenge,
+		AuthTime:       authTime,
+		ExpiresAt:      time.Now().UTC().Add(s.policy.CodeTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	s.audit("oidc.authorization_granted", orgID, accountID, loggerDomain.Fields{
+		"client_id": client.ClientID,
+		"scopes":    scopes,
+	})
+
+	return &OIDCAuthorizeResult{
+		RedirectURI: oidcRedirect(req.RedirectURI, url.Values{"code": {code}}, req.State),
+	}, nil
+}
+
+func (s *oidcProviderService) ExchangeCode(ctx context.Context, clientID, clientSecret string, req *OIDCTokenRequest) (*OIDCTokenResponse, error) {
+	client, err := s.authenticateClient(ctx, clientID, clientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.grantRepo.ConsumeCode(ctx, hashOIDCCode(req.Code))
+	if err != nil {
+		return nil, err
+	}
+	if code.OIDCClientID != client.ID || code.RedirectURI != req.RedirectURI {
+		return nil, domain.ErrOIDCInvalidGrant
+	}
+	if code.CodeChallenge != "" && !verifyOIDCCodeChallenge(code.CodeChallenge, req.CodeVerifier) {
+		return nil, domain.ErrOIDCInvalidGrant
+	}
+
+	// Clean up old codes while we're here; a failure only leaves rows behind
+	if _, err := s.grantRepo.DeleteExpiredCodes(ctx); err != nil {
+		s.logger.Warn("failed to delete expired oidc authorization codes", loggerDomain.Fields{
+			"error": err.Error(),
+		})
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, code.OrganizationID, code.AccountID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return nil, domain.ErrOIDCInvalidGrant
+		}
+		return nil, err
+	}
+	if !account.IsActive() {
+		return nil, domain.ErrOIDCInvalidGrant
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, code.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve client organization: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.policy.TokenTTL)
+	claims := oidcIDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.policy.Issuer,
+			Subject:   code.Subject,
+			Audience:  jwt.ClaimStrings{client.ClientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		AuthorizedParty: client.ClientID,
+		OrganizationID:  org.StytchOrgID,
+	}
+	if code.AuthTime != nil {
+		claims.AuthTime = jwt.NewNumericDate(*code.AuthTime)
+	}
+	if slices.Contains(code.Scopes, oidcScopeEmail) {
+		emailVerified := code.EmailVerified
+		claims.Email = account.Email
+		claims.EmailVerified = &emailVerified
+	}
+	if slices.Contains(code.Scopes, oidcScopeProfile) {
+		claims.Name = account.FullName
+	}
+
+	idClaims := claims
+	idClaims.ID = uuid.New().String()
+	idClaims.Nonce = code.Nonce
+	idToken, err := s.sign(idClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	accessClaims := claims
+	accessClaims.ID = uuid.New().String()
+	accessClaims.TokenType = oidcAccessTokenType
+	accessClaims.Scope = strings.Join(code.Scopes, " ")
+	accessToken, err := s.sign(accessClaims)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("oidc.token_issued", code.OrganizationID, code.AccountID, loggerDomain.Fields{
+		"client_id":  client.ClientID,
+		"token_id":   accessClaims.ID,
+		"scope":      accessClaims.Scope,
+		"expires_at": expiresAt.UTC().Format(time.RFC3339),
+	})
+
+	return &OIDCTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(s.policy.TokenTTL / time.Second),
+		IDToken:     idToken,
+		Scope:       accessClaims.Scope,
+	}, nil
+}
+
+func (s *oidcProviderService) UserInfo(ctx context.Context, accessToken string) (*OIDCUserInfo, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrOIDCProviderDisabled
+	}
+
+	var claims oidcIDTokenClaims
+	_, err := jwt.ParseWithClaims(accessToken, &claims, func(t *jwt.Token) (any, error) {
+		return &s.policy.SigningKey.PublicKey, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(s.policy.Issuer),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || claims.TokenType != oidcAccessTokenType || claims.Subject == "" {
+		return nil, domain.ErrOIDCInvalidToken
+	}
+
+	// Tokens stop working as soon as their client is revoked
+	client, err := s.clientRepo.GetByClientID(ctx, claims.AuthorizedParty)
+	if err != nil {
+		if errors.Is(err, domain.ErrOIDCClientNotFound) {
+			return nil, domain.ErrOIDCInvalidToken
+		}
+		return nil, err
+	}
+	if client.IsRevoked() {
+		return nil, domain.ErrOIDCInvalidToken
+	}
+
+	return &OIDCUserInfo{
+		Subject:        claims.Subject,
+		Email:          claims.Email,
+		EmailVerified:  claims.EmailVerified,
+		Name:           claims.Name,
+		OrganizationID: claims.OrganizationID,
+	}, nil
+}
+
+// validateAuthorizeRequest checks an authentication request against the
+// registered client and returns the normalized scopes. Clients of other
+// organizations are reported as not found.
+func (s *oidcProviderService) validateAuthorizeRequest(ctx context.Context, orgID int32, req *OIDCAuthorizeRequest) (*domain.OIDCClient, []string, error) {
+	if !s.policy.Enabled {
+		return nil, nil, domain.ErrOIDCProviderDisabled
+	}
+
+	client, err := s.clientRepo.GetByClientID(ctx, req.ClientID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if client.IsRevoked() || client.OrganizationID != orgID {
+		return nil, nil, domain.ErrOIDCClientNotFound
+	}
+
+	// Redirect URIs must match exactly so codes can't be sent elsewhere
+	if !slices.Contains(client.RedirectURIs, req.RedirectURI) {
+		return nil, nil, domain.ErrOIDCInvalidRedirectURI
+	}
+
+	if req.ResponseType != "code" {
+		return nil, nil, domain.ErrOIDCUnsupportedResponseType
+	}
+
+	var scopes []string
+	for _, scope := range strings.Fields(req.Scope) {
+		if !slices.Contains(oidcSupportedScopes, scope) {
+			return nil, nil, domain.ErrOIDCInvalidScope
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	if !slices.Contains(scopes, oidcScopeOpenID) {
+		return nil, nil, domain.ErrOIDCInvalidScope
+	}
+
+	// PKCE is optional, but only S256 is accepted (RFC 7636 section 4.2)
+	if req.CodeChallenge != "" || req.CodeChallengeMethod != "" {
+		if req.CodeChallengeMethod != "S256" || len(req.CodeChallenge) < 43 || len(req.CodeChallenge) > 128 {
+			return nil, nil, domain.ErrOIDCInvalidCodeChallenge
+		}
+	}
+
+	return client, scopes, nil
+}
+
+// authenticateClient checks a client's credentials. Unknown clients, wrong
+// secrets and revoked clients all return ErrOIDCInvalidClient.
+func (s *oidcProviderService) authenticateClient(ctx context.Context, clientID, clientSecret string) (*domain.OIDCClient, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrOIDCProviderDisabled
+	}
+
+	client, err := s.clientRepo.GetByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOIDCClientNotFound) {
+			return nil, domain.ErrOIDCInvalidClient
+		}
+		return nil, err
+	}
+
+	secretHash := hashOAuthClientSecret(clientSecret)
+	if subtle.ConstantTimeCompare([]byte(secretHash), []byte(client.SecretHash)) != 1 || client.IsRevoked() {
+		s.audit("oidc_client.authentication_failed", client.OrganizationID, 0, loggerDomain.Fields{
+			"client_id": client.ClientID,
+			"revoked":   client.IsRevoked(),
+		})
+		return nil, domain.ErrOIDCInvalidClient
+	}
+
+	return client, nil
+}
+
+// sign signs token claims with the provider key, naming the key in the header.
+func (s *oidcProviderService) sign(claims oidcIDTokenClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = oidcKeyID(&s.policy.SigningKey.PublicKey)
+
+	signed, err := token.SignedString(s.policy.SigningKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign oidc token: %w", err)
+	}
+	return signed, nil
+}
+
+// audit writes an audit log entry for OpenID Connect sign-ins and clients.
+func (s *oidcProviderService) audit(event string, orgID, accountID int32, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = orgID
+	fields["account_id"] = accountID
+	s.logger.Info("oidc provider audit", fields)
+}
+
+// normalizeOIDCRedirectURIs validates and de-duplicates redirect URIs. They
+// must be absolute http(s) URLs without a fragment (OAuth 2.0 section 3.1.2).
+func normalizeOIDCRedirectURIs(requested []string) ([]string, error) {
+	var redirectURIs []string
+	for _, raw := range requested {
+		raw = strings.TrimSpace(raw)
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" || u.Fragment != "" || strings.Contains(raw, "#") {
+			return nil, domain.ErrOIDCInvalidRedirectURI
+		}
+		if !slices.Contains(redirectURIs, raw) {
+			redirectURIs = append(redirectURIs, raw)
+		}
+	}
+	if len(redirectURIs) == 0 {
+		return nil, domain.ErrOIDCRedirectURIRequired
+	}
+	return redirectURIs, nil
+}
+
+// oidcRedirect adds response parameters and the client's state to a redirect URI.
+func oidcRedirect(redirectURI string, params url.Values, state string) string {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+	query := u.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// verifyOIDCCodeChallenge checks a PKCE code verifier against its S256 challenge.
+func verifyOIDCCodeChallenge(challenge, verifier string) bool {
+	if verifier == "" {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(challenge)) == 1
+}
+
+// oidcPublicKey returns the JSON Web Key of the signing key.
+func oidcPublicKey(key *rsa.PublicKey) OIDCKey {
+	return OIDCKey{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: jwt.SigningMethodRS256.Alg(),
+		KeyID:     oidcKeyID(key),
+		Modulus:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+// oidcKeyID is the RFC 7638 thumbprint of the key, so it changes with the key.
+func oidcKeyID(key *rsa.PublicKey) string {
+	thumbprint := fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`,
+		base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		base64.RawURLEncoding.EncodeToString(key.N.Bytes()))
+	sum := sha256.Sum256([]byte(thumbprint))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// generateOIDCClientCredentials returns a new client ID and secret.
+func generateOIDCClientCredentials() (string, string, error) {
+	buf := make([]byte, oidcClientIDBytes+oidcClientSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate oidc client credentials: %w", err)
+	}
+	clientID := "oidc_" + hex.EncodeToString(buf[:oidcClientIDBytes])
+	secret := "ocs_" + base64.RawURLEncoding.EncodeToString(buf[oidcClientIDBytes:])
+	return clientID, secret, nil
+}
+
+// generateOIDCCode returns a new authorization code and its stored hash.
+func generateOIDCCode() (string, string, error) {
+	buf := make([]byte, oidcCodeBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate authorization code: %w", err)
+	}
+	code := base64.RawURLEncoding.EncodeToString(buf)
+	return code, hashOIDCCode(code), nil
+}
+
+func hashOIDCCode(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
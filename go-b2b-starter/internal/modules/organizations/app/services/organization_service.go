@@ -2,20 +2,39 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/google/uuid"
+
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
 )
 
 type organizationService struct {
 	orgRepo     domain.OrganizationRepository
 	accountRepo domain.AccountRepository
+	seatGuard   paywall.SeatGuard
+	eventBus    eventbus.EventBus
+	logger      loggerDomain.Logger
 }
 
-func NewOrganizationService(orgRepo domain.OrganizationRepository, accountRepo domain.AccountRepository) OrganizationService {
+func NewOrganizationService(
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	seatGuard paywall.SeatGuard,
+	eventBus eventbus.EventBus,
+	logger loggerDomain.Logger,
+) OrganizationService {
 	return &organizationService{
 		orgRepo:     orgRepo,
 		accountRepo: accountRepo,
+		seatGuard:   seatGuard,
+		eventBus:    eventBus,
+		logger:      logger,
 	}
 }
 
@@ -54,10 +73,11 @@ func (s *organizationService) CreateOrganization(ctx context.Context, req *Creat
 		Status:         "active",
 	}
 
-	_, err = s.accountRepo.Create(ctx, adminAccount)
+	createdAccount, err := s.accountRepo.Create(ctx, adminAccount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create admin account: %w", err)
 	}
+	s.publishRegistered(ctx, createdAccount, events.UserSourceOrganizationAPI)
 
 	return createdOrg, nil
 }
@@ -142,7 +162,27 @@ func (s *organizationService) CreateAccount(ctx context.Context, orgID int32, re
 		Status:              "active",
 	}
 
-	return s.accountRepo.Create(ctx, account)
+	// Seat-based plans cap membership at the purchased seats
+	seatLimited, err := s.seatGuard.CheckSeatAvailable(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, paywall.ErrSeatLimitReached) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to check seat availability: %w", err)
+	}
+
+	var createdAccount *domain.Account
+	if seatLimited {
+		createdAccount, err = s.accountRepo.CreateWithinSeats(ctx, account)
+	} else {
+		createdAccount, err = s.accountRepo.Create(ctx, account)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishRegistered(ctx, createdAccount, events.UserSourceAccountAPI)
+	return createdAccount, nil
 }
 
 func (s *organizationService) GetAccount(ctx context.Context, orgID, accountID int32) (*domain.Account, error) {
@@ -153,6 +193,10 @@ func (s *organizationService) GetAccountByEmail(ctx context.Context, orgID int32
 	return s.accountRepo.GetByEmail(ctx, orgID, email)
 }
 
+func (s *organizationService) GetAccountByPublicID(ctx context.Context, orgID int32, publicID uuid.UUID) (*domain.Account, error) {
+	return s.accountRepo.GetByPublicID(ctx, orgID, publicID)
+}
+
 func (s *organizationService) ListAccounts(ctx context.Context, orgID int32) ([]*domain.Account, error) {
 	// Verify organization exists
 	_, err := s.orgRepo.GetByID(ctx, orgID)
@@ -180,15 +224,37 @@ func (s *organizationService) UpdateAccount(ctx context.Context, orgID, accountI
 	if req.StytchRoleSlug != "" {
 		account.StytchRoleSlug = req.StytchRoleSlug
 	}
+	wasVerified := account.StytchEmailVerified
 	if req.StytchEmailVerified != nil {
 		account.StytchEmailVerified = *req.StytchEmailVerified
 	}
 
-	return s.accountRepo.Update(ctx, account)
+	updatedAccount, err := s.accountRepo.Update(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	if !wasVerified && updatedAccount.StytchEmailVerified {
+		s.publish(ctx, events.NewUserVerified(orgID, updatedAccount.ID, updatedAccount.Email))
+	}
+	return updatedAccount, nil
 }
 
 func (s *organizationService) DeleteAccount(ctx context.Context, orgID, accountID int32) error {
-	return s.accountRepo.Delete(ctx, orgID, accountID)
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.accountRepo.Delete(ctx, orgID, accountID); err != nil {
+		return err
+	}
+
+	// The account API has no actor, so ActorAccountID stays 0
+	if !domain.IsServiceAccountEmail(account.Email) {
+		s.publish(ctx, events.NewUserDeleted(orgID, account.ID, account.Email, 0))
+	}
+	return nil
 }
 
 func (s *organizationService) UpdateAccountLastLogin(ctx context.Context, orgID, accountID int32) (*domain.Account, error) {
@@ -202,3 +268,28 @@ func (s *organizationService) CheckAccountPermission(ctx context.Context, orgID,
 func (s *organizationService) GetAccountStats(ctx context.Context, accountID int32) (*domain.AccountStats, error) {
 	return s.accountRepo.GetStats(ctx, accountID)
 }
+
+// publishRegistered publishes user.registered for a member account the
+// service created.
+func (s *organizationService) publishRegistered(ctx context.Context, account *domain.Account, source string) {
+	s.publish(ctx, events.NewUserRegistered(
+		account.OrganizationID,
+		account.ID,
+		account.Email,
+		account.FullName,
+		account.Role,
+		account.StytchEmailVerified,
+		source,
+	))
+}
+
+// publish logs failed subscribers. The account change is already committed,
+// so a failed subscriber must not fail the request.
+func (s *organizationService) publish(ctx context.Context, event eventbus.Event) {
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Warn("failed to publish user event", loggerDomain.Fields{
+			"event": event.EventName(),
+			"error": err.Error(),
+		})
+	}
+}
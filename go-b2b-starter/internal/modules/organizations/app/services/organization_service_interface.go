@@ -3,6 +3,8 @@ package services
 import (
 	"context"
 
+	"github.com/google/uuid"
+
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
 )
 
@@ -22,6 +24,7 @@ type OrganizationService interface {
 	CreateAccount(ctx context.Context, orgID int32, req *CreateAccountRequest) (*domain.Account, error)
 	GetAccount(ctx context.Context, orgID, accountID int32) (*domain.Account, error)
 	GetAccountByEmail(ctx context.Context, orgID int32, email string) (*domain.Account, error)
+	GetAccountByPublicID(ctx context.Context, orgID int32, publicID uuid.UUID) (*domain.Account, error)
 	ListAccounts(ctx context.Context, orgID int32) ([]*domain.Account, error)
 	UpdateAccount(ctx context.Context, orgID, accountID int32, req *UpdateAccountRequest) (*domain.Account, error)
 	DeleteAccount(ctx context.Context, orgID, accountID int32) error
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// OrganizationSwitchService lets users who belong to several organizations
+// move between them.
+//
+// Access tokens are scoped to one organization: the auth provider's org claim
+// becomes Identity.OrganizationID and the org_context middleware resolves the
+// database organization and roles from it. Switching therefore issues a new
+// token pair for the target organization instead of changing server state.
+type OrganizationSwitchService interface {
+	// ListMemberships returns the organizations the caller can switch to,
+	// marking the one the request was made in
+	ListMemberships(ctx context.Context, identity *auth.Identity) ([]*domain.Membership, error)
+
+	// SwitchOrganization exchanges the caller's token for a token pair scoped
+	// to the organization
+	SwitchOrganization(ctx context.Context, identity *auth.Identity, token string, req *SwitchOrganizationRequest) (*OrganizationSwitch, error)
+}
+
+// SwitchOrganizationRequest represents the request to switch organizations
+type SwitchOrganizationRequest struct {
+	OrganizationID int32 `json:"organization_id" binding:"required"`
+}
+
+// OrganizationSwitch is the result of switching organizations. When
+// Session.MFARequired is set the client completes MFA for the target
+// organization before it gets tokens.
+type OrganizationSwitch struct {
+	Membership *domain.Membership  `json:"membership"`
+	Session    *auth.SessionTokens `json:"session"`
+}
+
+type organizationSwitchService struct {
+	accountRepo domain.AccountRepository
+	switcher    auth.OrganizationSwitcher
+	logger      loggerDomain.Logger
+}
+
+func NewOrganizationSwitchService(
+	accountRepo domain.AccountRepository,
+	switcher auth.OrganizationSwitcher,
+	logger loggerDomain.Logger,
+) OrganizationSwitchService {
+	return &organizationSwitchService{
+		accountRepo: accountRepo,
+		switcher:    switcher,
+		logger:      logger,
+	}
+}
+
+func (s *organizationSwitchService) ListMemberships(ctx context.Context, identity *auth.Identity) ([]*domain.Membership, error) {
+	if err := s.checkIdentity(identity); err != nil {
+		return nil, err
+	}
+
+	memberships, err := s.accountRepo.ListMembershipsByEmail(ctx, identity.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, membership := range memberships {
+		membership.Current = membership.StytchOrgID != "" && membership.StytchOrgID == identity.OrganizationID
+	}
+	return memberships, nil
+}
+
+func (s *organizationSwitchService) SwitchOrganization(ctx context.Context, identity *auth.Identity, token string, req *SwitchOrganizationRequest) (*OrganizationSwitch, error) {
+	memberships, err := s.ListMemberships(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only organizations the caller is an active member of in our database,
+	// even if the auth provider would accept the exchange
+	var target *domain.Membership
+	for _, membership := range memberships {
+		if membership.OrganizationID == req.OrganizationID {
+			target = membership
+			break
+		}
+	}
+	if target == nil {
+		return nil, domain.ErrSwitchNotMember
+	}
+	if target.StytchOrgID == "" {
+		return nil, domain.ErrSwitchOrganizationNotLinked
+	}
+
+	tokens, err := s.switcher.SwitchOrganization(ctx, token, target.StytchOrgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to switch organization: %w", err)
+	}
+
+	s.logger.Info("organization switched", loggerDomain.Fields{
+		"user_id":         identity.UserID,
+		"organization_id": target.OrganizationID,
+		"account_id":      target.AccountID,
+		"mfa_required":    tokens.MFARequired,
+	})
+
+	return &OrganizationSwitch{
+		Membership: target,
+		Session:    tokens,
+	}, nil
+}
+
+// checkIdentity rejects tokens that don't belong to a signed-in member:
+// guests, OAuth clients, service accounts and impersonations are bound to one
+// organization.
+func (s *organizationSwitchService) checkIdentity(identity *auth.Identity) error {
+	if identity.Guest || identity.IsMachine() || identity.IsImpersonated() {
+		return domain.ErrSwitchNotAllowed
+	}
+	if identity.Email == "" {
+		return auth.ErrMissingEmail
+	}
+	return nil
+}
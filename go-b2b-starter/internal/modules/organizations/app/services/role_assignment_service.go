@@ -0,0 +1,219 @@
+package services
+
+import (
+	"context"
+	"strings"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// RoleAssignmentService assigns database-backed roles to members within
+// their organization and to users globally, on top of the role the auth
+// provider assigns. It implements auth.RoleAssignmentResolver so the auth
+// middleware applies the assignments on every request.
+//
+// Every organization keeps at least one admin: removing the admin role is
+// refused when no other active account of the organization holds it.
+type RoleAssignmentService interface {
+	auth.RoleAssignmentResolver
+
+	// ListAccountRoles returns the provider role and assigned roles of an account
+	ListAccountRoles(ctx context.Context, orgID, accountID int32) (*domain.AccountRoles, error)
+
+	// AssignAccountRole assigns a role to an account of the organization.
+	// Admins can only assign roles whose permissions they hold themselves.
+	AssignAccountRole(ctx context.Context, orgID, actorID int32, actor *auth.Identity, accountID int32, req *AssignRoleRequest) (*domain.RoleAssignment, error)
+
+	// RemoveAccountRole removes a role assigned to an account of the organization
+	RemoveAccountRole(ctx context.Context, orgID, actorID, accountID int32, roleID string) error
+
+	// ListGlobalAssignments returns global assignments, optionally of one email
+	ListGlobalAssignments(ctx context.Context, email string) ([]*domain.RoleAssignment, error)
+
+	// AssignGlobalRole assigns a role to a user in every organization they
+	// have an account in, including ones they join later
+	AssignGlobalRole(ctx context.Context, req *AssignGlobalRoleRequest) (*domain.RoleAssignment, error)
+
+	// RemoveGlobalRole removes a global assignment
+	RemoveGlobalRole(ctx context.Context, id int32) (*domain.RoleAssignment, error)
+}
+
+// AssignRoleRequest names the role to assign to a member
+type AssignRoleRequest struct {
+	RoleID string `json:"role_id" binding:"required"`
+}
+
+// AssignGlobalRoleRequest names the user and role of a global assignment
+type AssignGlobalRoleRequest struct {
+	Email  string `json:"email" binding:"required,email"`
+	RoleID string `json:"role_id" binding:"required"`
+}
+
+// ListGlobalRoleAssignmentsRequest filters global assignments
+type ListGlobalRoleAssignmentsRequest struct {
+	Email string `form:"email"`
+}
+
+type roleAssignmentService struct {
+	assignmentRepo domain.RoleAssignmentRepository
+	accountRepo    domain.AccountRepository
+	logger         loggerDomain.Logger
+}
+
+func NewRoleAssignmentService(
+	assignmentRepo domain.RoleAssignmentRepository,
+	accountRepo domain.AccountRepository,
+	logger loggerDomain.Logger,
+) RoleAssignmentService {
+	return &roleAssignmentService{
+		assignmentRepo: assignmentRepo,
+		accountRepo:    accountRepo,
+		logger:         logger,
+	}
+}
+
+// AssignedRoles implements auth.RoleAssignmentResolver.
+func (s *roleAssignmentService) AssignedRoles(ctx context.Context, orgID, accountID int32) ([]auth.Role, error) {
+	assignments, err := s.assignmentRepo.ListByAccount(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]auth.Role, len(assignments))
+	for i, assignment := range assignments {
+		roles[i] = auth.Role(assignment.RoleID)
+	}
+	return roles, nil
+}
+
+func (s *roleAssignmentService) ListAccountRoles(ctx context.Context, orgID, accountID int32) (*domain.AccountRoles, error) {
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	assignments, err := s.assignmentRepo.ListByAccount(ctx, orgID, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.AccountRoles{
+		AccountID:    account.ID,
+		ProviderRole: auth.NormalizeRole(account.Role).String(),
+		Assignments:  assignments,
+	}, nil
+}
+
+func (s *roleAssignmentService) AssignAccountRole(ctx context.Context, orgID, actorID int32, actor *auth.Identity, accountID int32, req *AssignRoleRequest) (*domain.RoleAssignment, error) {
+	role, err := parseAssignableRole(req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Admins cannot hand out more than they hold, e.g. a custom role with a
+	// permission the admin role lacks
+	held := auth.NewPermissionSet(auth.EffectivePermissions(actor))
+	if !held.ContainsAll(auth.GetRolePermissions(role)...) {
+		return nil, domain.ErrRoleAssignmentNotPermitted
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if domain.IsServiceAccountEmail(account.Email) && !serviceAccountAssignable(role) {
+		return nil, domain.ErrRoleAssignmentNotPermitted
+	}
+
+	assignment, err := s.assignmentRepo.CreateForAccount(ctx, orgID, account.ID, role.String(), &actorID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("role.assigned", assignment, loggerDomain.Fields{
+		"actor_id": actorID,
+	})
+	return assignment, nil
+}
+
+func (s *roleAssignmentService) RemoveAccountRole(ctx context.Context, orgID, actorID, accountID int32, roleID string) error {
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return err
+	}
+
+	assignment, err := s.assignmentRepo.DeleteForAccount(ctx, orgID, account.ID, auth.NormalizeRole(strings.TrimSpace(roleID)).String())
+	if err != nil {
+		return err
+	}
+
+	s.audit("role.removed", assignment, loggerDomain.Fields{
+		"actor_id": actorID,
+	})
+	return nil
+}
+
+func (s *roleAssignmentService) ListGlobalAssignments(ctx context.Context, email string) ([]*domain.RoleAssignment, error) {
+	return s.assignmentRepo.ListGlobal(ctx, strings.TrimSpace(email))
+}
+
+func (s *roleAssignmentService) AssignGlobalRole(ctx context.Context, req *AssignGlobalRoleRequest) (*domain.RoleAssignment, error) {
+	role, err := parseAssignableRole(req.RoleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain.IsServiceAccountEmail(req.Email) && !serviceAccountAssignable(role) {
+		return nil, domain.ErrRoleAssignmentNotPermitted
+	}
+
+	assignment, err := s.assignmentRepo.CreateGlobal(ctx, strings.TrimSpace(req.Email), role.String())
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("role.assigned_globally", assignment, loggerDomain.Fields{})
+	return assignment, nil
+}
+
+func (s *roleAssignmentService) RemoveGlobalRole(ctx context.Context, id int32) (*domain.RoleAssignment, error) {
+	assignment, err := s.assignmentRepo.DeleteGlobal(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("role.removed_globally", assignment, loggerDomain.Fields{})
+	return assignment, nil
+}
+
+// parseAssignableRole normalizes legacy role names and checks the role exists.
+func parseAssignableRole(roleID string) (auth.Role, error) {
+	role := auth.NormalizeRole(strings.TrimSpace(roleID))
+	if !role.IsValid() {
+		return "", domain.ErrRoleAssignmentInvalidRole
+	}
+	return role, nil
+}
+
+// serviceAccountAssignable reports whether a service account may hold the
+// role. Service accounts never manage the organization.
+func serviceAccountAssignable(role auth.Role) bool {
+	return !auth.NewPermissionSet(auth.GetRolePermissions(role)).Contains(auth.PermOrgManage)
+}
+
+// audit writes an audit log entry for a role assignment change.
+func (s *roleAssignmentService) audit(event string, assignment *domain.RoleAssignment, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["assignment_id"] = assignment.ID
+	fields["role"] = assignment.RoleID
+	if assignment.IsGlobal() {
+		fields["email"] = assignment.Email
+	} else {
+		fields["organization_id"] = *assignment.OrganizationID
+		fields["account_id"] = *assignment.AccountID
+	}
+	s.logger.Info("role assignment audit", fields)
+}
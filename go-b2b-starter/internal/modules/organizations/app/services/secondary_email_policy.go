@@ -0,0 +1,70 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// SecondaryEmailPolicy controls the addresses members add to their account
+// besides the primary email.
+//
+// All values can be set via environment variables with the SECONDARY_EMAIL_ prefix.
+type SecondaryEmailPolicy struct {
+	// MaxPerAccount caps the secondary addresses of an account, verified or not
+	MaxPerAccount int `mapstructure:"SECONDARY_EMAIL_MAX_PER_ACCOUNT"`
+
+	// VerifyURL is the frontend page that submits the verification token
+	VerifyURL string `mapstructure:"SECONDARY_EMAIL_VERIFY_URL"`
+
+	// VerifyTTL is how long a verification link stays valid. An address left
+	// unverified after that can be added by another member.
+	VerifyTTL time.Duration `mapstructure:"SECONDARY_EMAIL_VERIFY_TTL"`
+}
+
+// LoadSecondaryEmailPolicy loads the secondary email policy from environment variables and app.env file.
+func LoadSecondaryEmailPolicy() (*SecondaryEmailPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("SECONDARY_EMAIL_MAX_PER_ACCOUNT", 5)
+	v.SetDefault("SECONDARY_EMAIL_VERIFY_URL", "http://localhost:3000/account/emails/verify")
+	v.SetDefault("SECONDARY_EMAIL_VERIFY_TTL", "24h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy SecondaryEmailPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode secondary email policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks the address limit and the verification link.
+func (p *SecondaryEmailPolicy) Validate() error {
+	if p.MaxPerAccount <= 0 {
+		return fmt.Errorf("secondary email policy invalid: SECONDARY_EMAIL_MAX_PER_ACCOUNT must be positive")
+	}
+	if p.VerifyURL == "" {
+		return fmt.Errorf("secondary email policy invalid: SECONDARY_EMAIL_VERIFY_URL is required")
+	}
+	if p.VerifyTTL <= 0 {
+		return fmt.Errorf("secondary email policy invalid: SECONDARY_EMAIL_VERIFY_TTL must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,482 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// SecondaryEmailService lets members keep several addresses on their account,
+// since B2B users frequently move between work addresses.
+//
+// The primary address stays the account email known to the auth provider.
+// Secondary addresses must be verified through an emailed link; verified
+// addresses can then receive notifications and be made the primary address.
+// Signing in stays with the auth provider, which only knows the primary
+// address: to sign in with a secondary address, make it primary.
+type SecondaryEmailService interface {
+	// ListEmails returns the account's primary address and secondary addresses
+	ListEmails(ctx context.Context, orgID, accountID int32) (*AccountEmails, error)
+
+	// AddEmail adds an unverified address to the account and emails it a verification link
+	AddEmail(ctx context.Context, orgID, accountID int32, req *AddSecondaryEmailRequest) (*domain.SecondaryEmail, error)
+
+	// ResendVerification emails a new verification link to an unverified address
+	ResendVerification(ctx context.Context, orgID, accountID, id int32) (*domain.SecondaryEmail, error)
+
+	// VerifyEmail verifies the address a verification link was sent to
+	VerifyEmail(ctx context.Context, req *SecondaryEmailTokenRequest) (*domain.SecondaryEmail, error)
+
+	// RemoveEmail removes a secondary address from the account
+	RemoveEmail(ctx context.Context, orgID, accountID, id int32) error
+
+	// SetNotifications turns email notifications to a verified address on or off
+	SetNotifications(ctx context.Context, orgID, accountID, id int32, req *SetSecondaryEmailNotificationsRequest) (*domain.SecondaryEmail, error)
+
+	// MakePrimary swaps a verified address with the primary address and signs
+	// the member out everywhere
+	MakePrimary(ctx context.Context, orgID, accountID, id int32) (*AccountEmails, error)
+}
+
+// AccountEmails lists every address of an account
+type AccountEmails struct {
+	// Primary is the account email used by the auth provider
+	Primary   string                   `json:"primary"`
+	Secondary []*domain.SecondaryEmail `json:"secondary"`
+}
+
+// AddSecondaryEmailRequest represents the address a member adds to their account
+type AddSecondaryEmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// SetSecondaryEmailNotificationsRequest turns notifications to an address on or off
+type SetSecondaryEmailNotificationsRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
+
+// SecondaryEmailTokenRequest represents the token from a verification link
+type SecondaryEmailTokenRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+const (
+	secondaryEmailTokenBytes = 32
+
+	// secondaryEmailNotificationTimeout bounds each background email
+	secondaryEmailNotificationTimeout = 30 * time.Second
+)
+
+type secondaryEmailService struct {
+	secondaryRepo  domain.SecondaryEmailRepository
+	orgRepo        domain.OrganizationRepository
+	accountRepo    domain.AccountRepository
+	authMemberRepo domain.AuthMemberRepository
+	revoker        auth.SessionRevoker
+	denylist       auth.SessionDenylist
+	sender         emailDomain.Sender
+	normalizer     domain.EmailNormalizer
+	policy         *SecondaryEmailPolicy
+	logger         loggerDomain.Logger
+}
+
+func NewSecondaryEmailService(
+	secondaryRepo domain.SecondaryEmailRepository,
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	authMemberRepo domain.AuthMemberRepository,
+	revoker auth.SessionRevoker,
+	denylist auth.SessionDenylist,
+	sender emailDomain.Sender,
+	normalizer domain.EmailNormalizer,
+	policy *SecondaryEmailPolicy,
+	logger loggerDomain.Logger,
+) SecondaryEmailService {
+	return &secondaryEmailService{
+		secondaryRepo:  secondaryRepo,
+		orgRepo:        orgRepo,
+		accountRepo:    accountRepo,
+		authMemberRepo: authMemberRepo,
+		revoker:        revoker,
+		denylist:       denylist,
+		sender:         sender,
+		normalizer:     normalizer,
+		policy:         policy,
+		logger:         logger,
+	}
+}
+
+func (s *secondaryEmailService) ListEmails(ctx context.Context, orgID, accountID int32) (*AccountEmails, error) {
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	secondary, err := s.secondaryRepo.ListByAccount(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountEmails{Primary: account.Email, Secondary: secondary}, nil
+}
+
+// AddEmail refuses addresses another member of the organization has as
+// primary or secondary address. An address someone else added but did not
+// verify in time is released to the new owner.
+func (s *secondaryEmailService) AddEmail(ctx context.Context, orgID, accountID int32, req *AddSecondaryEmailRequest) (*domain.SecondaryEmail, error) {
+	email := s.normalizer.Normalize(req.Email)
+	if email == "" {
+		return nil, domain.ErrAccountEmailRequired
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Email == email {
+		return nil, domain.ErrSecondaryEmailExists
+	}
+
+	existing, err := s.secondaryRepo.ListByAccount(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, secondary := range existing {
+		if secondary.Email == email {
+			return nil, domain.ErrSecondaryEmailExists
+		}
+	}
+	if len(existing) >= s.policy.MaxPerAccount {
+		return nil, domain.ErrSecondaryEmailLimit
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+	if s.primaryInUse(ctx, org, account, email) {
+		return nil, domain.ErrUserAlreadyExists
+	}
+	if err := s.releaseUnverified(ctx, orgID, email); err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := generateSecondaryEmailToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().UTC().Add(s.policy.VerifyTTL)
+
+	secondary, err := s.secondaryRepo.Create(ctx, &domain.SecondaryEmail{
+		OrganizationID:        orgID,
+		AccountID:             accountID,
+		Email:                 email,
+		VerificationTokenHash: tokenHash,
+		VerificationExpiresAt: &expiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrSecondaryEmailExists) {
+			// Another account added the address since the checks above
+			return nil, domain.ErrUserAlreadyExists
+		}
+		return nil, err
+	}
+
+	s.audit("secondary_email.added", secondary, loggerDomain.Fields{
+		"expires_at": expiresAt.Format(time.RFC3339),
+	})
+
+	s.sendVerification(org, account, secondary, token, expiresAt)
+	s.notify(secondary, account.Email, "An email address was added to your account", fmt.Sprintf(
+		"%s was added as a secondary email address of your %s account. It can be used once it is verified.\n\n"+
+			"If you did not add it, remove it from your account settings and secure your account.\n",
+		secondary.Email, org.Name))
+
+	return secondary, nil
+}
+
+func (s *secondaryEmailService) ResendVerification(ctx context.Context, orgID, accountID, id int32) (*domain.SecondaryEmail, error) {
+	secondary, err := s.secondaryRepo.GetByID(ctx, orgID, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if secondary.IsVerified() {
+		return nil, domain.ErrSecondaryEmailVerified
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	token, tokenHash, err := generateSecondaryEmailToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().UTC().Add(s.policy.VerifyTTL)
+
+	updated, err := s.secondaryRepo.SetVerification(ctx, secondary.ID, tokenHash, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("secondary_email.verification_resent", updated, loggerDomain.Fields{
+		"expires_at": expiresAt.Format(time.RFC3339),
+	})
+
+	s.sendVerification(org, account, updated, token, expiresAt)
+	return updated, nil
+}
+
+func (s *secondaryEmailService) VerifyEmail(ctx context.Context, req *SecondaryEmailTokenRequest) (*domain.SecondaryEmail, error) {
+	secondary, err := s.secondaryRepo.GetByVerificationTokenHash(ctx, hashSecondaryEmailToken(strings.TrimSpace(req.Token)))
+	if err != nil {
+		return nil, err
+	}
+	if secondary.VerificationExpired(time.Now()) {
+		return nil, domain.ErrSecondaryEmailInvalidToken
+	}
+
+	// Another member may have registered with the address since it was added
+	if existing, err := s.accountRepo.GetByEmail(ctx, secondary.OrganizationID, secondary.Email); err == nil && existing.ID != secondary.AccountID {
+		return nil, domain.ErrUserAlreadyExists
+	}
+
+	verified, err := s.secondaryRepo.Verify(ctx, secondary.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("secondary_email.verified", verified, loggerDomain.Fields{})
+	return verified, nil
+}
+
+func (s *secondaryEmailService) RemoveEmail(ctx context.Context, orgID, accountID, id int32) error {
+	secondary, err := s.secondaryRepo.GetByID(ctx, orgID, accountID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.secondaryRepo.Delete(ctx, orgID, accountID, id); err != nil {
+		return err
+	}
+
+	s.audit("secondary_email.removed", secondary, loggerDomain.Fields{})
+	return nil
+}
+
+func (s *secondaryEmailService) SetNotifications(ctx context.Context, orgID, accountID, id int32, req *SetSecondaryEmailNotificationsRequest) (*domain.SecondaryEmail, error) {
+	secondary, err := s.secondaryRepo.GetByID(ctx, orgID, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if *req.Enabled && !secondary.IsVerified() {
+		return nil, domain.ErrSecondaryEmailNotVerified
+	}
+	return s.secondaryRepo.SetNotifications(ctx, orgID, accountID, id, *req.Enabled)
+}
+
+// MakePrimary updates the address in the auth provider first and restores it
+// if the local swap fails, like an email change. The old primary address
+// stays on the account as a verified secondary address.
+func (s *secondaryEmailService) MakePrimary(ctx context.Context, orgID, accountID, id int32) (*AccountEmails, error) {
+	secondary, err := s.secondaryRepo.GetByID(ctx, orgID, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	if !secondary.IsVerified() {
+		return nil, domain.ErrSecondaryEmailNotVerified
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve organization: %w", err)
+	}
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if s.primaryInUse(ctx, org, account, secondary.Email) {
+		return nil, domain.ErrUserAlreadyExists
+	}
+
+	if err := s.updateMemberEmail(ctx, org, account, secondary.Email); err != nil {
+		return nil, err
+	}
+	if _, err := s.secondaryRepo.MakePrimary(ctx, orgID, accountID, id); err != nil {
+		if rollbackErr := s.updateMemberEmail(ctx, org, account, account.Email); rollbackErr != nil {
+			s.logger.Error("failed to restore member email after local update failed", loggerDomain.Fields{
+				"organization_id": orgID,
+				"account_id":      accountID,
+				"error":           rollbackErr.Error(),
+			})
+		}
+		return nil, err
+	}
+
+	revoked := s.revokeSessions(ctx, account)
+
+	s.audit("secondary_email.made_primary", secondary, loggerDomain.Fields{
+		"previous_email":   account.Email,
+		"sessions_revoked": revoked,
+	})
+
+	s.notify(secondary, account.Email, "Your primary email address was changed", fmt.Sprintf(
+		"The primary email address of your %s account was changed to %s and all sessions were signed out. "+
+			"This address remains on the account as a secondary address.\n\n"+
+			"If you did not make this change, sign in and review your account security settings.\n",
+		org.Name, secondary.Email))
+
+	return s.ListEmails(ctx, orgID, accountID)
+}
+
+// primaryInUse reports whether another member of the organization has the
+// address as their primary email.
+func (s *secondaryEmailService) primaryInUse(ctx context.Context, org *domain.Organization, account *domain.Account, email string) bool {
+	if existing, err := s.accountRepo.GetByEmail(ctx, org.ID, email); err == nil && existing.ID != account.ID {
+		return true
+	}
+	if org.StytchOrgID == "" {
+		return false
+	}
+	if member, err := s.authMemberRepo.GetMemberByEmail(ctx, org.StytchOrgID, email); err == nil && member.MemberID != account.StytchMemberID {
+		return true
+	}
+	return false
+}
+
+// releaseUnverified removes another account's unverified, expired claim on
+// the address. Returns domain.ErrUserAlreadyExists if the address is still
+// claimed.
+func (s *secondaryEmailService) releaseUnverified(ctx context.Context, orgID int32, email string) error {
+	existing, err := s.secondaryRepo.GetByAddress(ctx, orgID, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrSecondaryEmailNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !existing.VerificationExpired(time.Now()) {
+		return domain.ErrUserAlreadyExists
+	}
+
+	deleted, err := s.secondaryRepo.DeleteExpired(ctx, existing.ID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return domain.ErrUserAlreadyExists
+	}
+	s.audit("secondary_email.released", existing, loggerDomain.Fields{})
+	return nil
+}
+
+// updateMemberEmail sets the member's address in the auth provider. Accounts
+// without a provider member have nothing to update.
+func (s *secondaryEmailService) updateMemberEmail(ctx context.Context, org *domain.Organization, account *domain.Account, email string) error {
+	if account.StytchMemberID == "" || org.StytchOrgID == "" {
+		return nil
+	}
+	if _, err := s.authMemberRepo.UpdateMember(ctx, &domain.UpdateAuthMemberRequest{
+		OrganizationID: org.StytchOrgID,
+		MemberID:       account.StytchMemberID,
+		EmailAddress:   &email,
+	}); err != nil {
+		return fmt.Errorf("failed to update member email: %w", err)
+	}
+	return nil
+}
+
+// revokeSessions signs the member out everywhere. The primary address already
+// changed, so failures are logged rather than returned.
+func (s *secondaryEmailService) revokeSessions(ctx context.Context, account *domain.Account) bool {
+	if account.StytchMemberID == "" {
+		return false
+	}
+
+	if err := s.denylist.RevokeSubject(ctx, account.StytchMemberID, time.Now()); err != nil {
+		s.logger.Error("failed to denylist member tokens after primary email change", loggerDomain.Fields{
+			"account_id": account.ID,
+			"error":      err.Error(),
+		})
+		return false
+	}
+	if err := s.revoker.RevokeUserSessions(ctx, account.StytchMemberID); err != nil {
+		s.logger.Error("failed to revoke member sessions after primary email change", loggerDomain.Fields{
+			"account_id": account.ID,
+			"error":      err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// sendVerification emails the verification link to the new address.
+func (s *secondaryEmailService) sendVerification(org *domain.Organization, account *domain.Account, secondary *domain.SecondaryEmail, token string, expiresAt time.Time) {
+	s.notify(secondary, secondary.Email, "Verify your email address", fmt.Sprintf(
+		"%s added this address to their %s account.\n\n"+
+			"Verify it before %s:\n%s\n\n"+
+			"If you do not know about this, you can ignore this email.\n",
+		account.Email, org.Name, expiresAt.Format(time.RFC1123), tokenLink(s.policy.VerifyURL, token)))
+}
+
+// notify sends an email about the address in the background.
+// A failed send is logged; the change itself is already stored.
+func (s *secondaryEmailService) notify(secondary *domain.SecondaryEmail, to, subject, body string) {
+	msg := &emailDomain.Message{
+		To:      []string{to},
+		Subject: subject,
+		Body:    body,
+	}
+
+	go func() {
+		// Don't use request context as it will be cancelled when request completes
+		sendCtx, cancel := context.WithTimeout(context.Background(), secondaryEmailNotificationTimeout)
+		defer cancel()
+
+		if err := s.sender.Send(sendCtx, msg); err != nil {
+			s.logger.Error("failed to send secondary email notification", loggerDomain.Fields{
+				"secondary_email_id": secondary.ID,
+				"subject":            subject,
+				"error":              err.Error(),
+			})
+		}
+	}()
+}
+
+// audit writes an audit log entry for the secondary email lifecycle.
+func (s *secondaryEmailService) audit(event string, secondary *domain.SecondaryEmail, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = secondary.OrganizationID
+	fields["account_id"] = secondary.AccountID
+	fields["secondary_email_id"] = secondary.ID
+	s.logger.Info("secondary email audit", fields)
+}
+
+// generateSecondaryEmailToken returns a random link token and its stored hash.
+func generateSecondaryEmailToken() (string, string, error) {
+	buf := make([]byte, secondaryEmailTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate secondary email token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashSecondaryEmailToken(token), nil
+}
+
+func hashSecondaryEmailToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
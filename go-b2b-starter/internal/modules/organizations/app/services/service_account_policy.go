@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// ServiceAccountPolicy controls service accounts and their API keys.
+//
+// All values can be set via environment variables with the SERVICE_ACCOUNT prefix.
+type ServiceAccountPolicy struct {
+	// Enabled turns on the service account endpoints and API key verification
+	Enabled bool `mapstructure:"SERVICE_ACCOUNTS_ENABLED"`
+
+	// MaxKeys is the number of active API keys a service account may have, so
+	// a key can be rotated without downtime
+	MaxKeys int `mapstructure:"SERVICE_ACCOUNT_MAX_KEYS"`
+
+	// KeyMaxTTL is the longest lifetime of an API key. Keys without an
+	// expiry get this lifetime. 0 allows keys that never expire.
+	KeyMaxTTL time.Duration `mapstructure:"SERVICE_ACCOUNT_KEY_MAX_TTL"`
+}
+
+// LoadServiceAccountPolicy loads the service account policy from environment variables and app.env file.
+func LoadServiceAccountPolicy() (*ServiceAccountPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("SERVICE_ACCOUNTS_ENABLED", true)
+	v.SetDefault("SERVICE_ACCOUNT_MAX_KEYS", 5)
+	v.SetDefault("SERVICE_ACCOUNT_KEY_MAX_TTL", "8760h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy ServiceAccountPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode service account policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks the key limits.
+func (p *ServiceAccountPolicy) Validate() error {
+	if p.MaxKeys < 1 {
+		return fmt.Errorf("service account policy invalid: SERVICE_ACCOUNT_MAX_KEYS must be at least 1")
+	}
+	if p.KeyMaxTTL < 0 {
+		return fmt.Errorf("service account policy invalid: SERVICE_ACCOUNT_KEY_MAX_TTL must not be negative")
+	}
+	return nil
+}
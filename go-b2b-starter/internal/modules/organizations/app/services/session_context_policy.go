@@ -0,0 +1,116 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// SessionContextPolicy controls the session context returned by GET /me/context.
+//
+// All values can be set via environment variables.
+type SessionContextPolicy struct {
+	// CacheTTL is how long the organization, account and entitlements of a
+	// member are cached in Redis. 0 disables the cache.
+	CacheTTL time.Duration `mapstructure:"SESSION_CONTEXT_CACHE_TTL"`
+
+	// FeatureFlags lists comma-separated feature flags enabled for every organization
+	FeatureFlags string `mapstructure:"FEATURE_FLAGS"`
+
+	// FeatureFlagList is the parsed form of FeatureFlags
+	FeatureFlagList []string `mapstructure:"-"`
+
+	// FeatureFlagTags lists comma-separated flag=tag pairs that enable a
+	// feature flag for users carrying the tag, e.g. "new_editor=beta,new_editor=vip".
+	FeatureFlagTags string `mapstructure:"FEATURE_FLAG_TAGS"`
+
+	// FeatureFlagTargets is the parsed form of FeatureFlagTags
+	FeatureFlagTargets []FeatureFlagTarget `mapstructure:"-"`
+}
+
+// FeatureFlagTarget enables a feature flag for the users carrying a tag
+type FeatureFlagTarget struct {
+	Flag string
+	Tag  string
+}
+
+// LoadSessionContextPolicy loads the session context policy from environment variables and app.env file.
+func LoadSessionContextPolicy() (*SessionContextPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("SESSION_CONTEXT_CACHE_TTL", "30s")
+	v.SetDefault("FEATURE_FLAGS", "")
+	v.SetDefault("FEATURE_FLAG_TAGS", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy SessionContextPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode session context policy: %w", err)
+	}
+
+	policy.FeatureFlagList = []string{}
+	for _, flag := range strings.Split(policy.FeatureFlags, ",") {
+		if flag = strings.TrimSpace(flag); flag != "" {
+			policy.FeatureFlagList = append(policy.FeatureFlagList, flag)
+		}
+	}
+
+	policy.FeatureFlagTargets = []FeatureFlagTarget{}
+	for _, pair := range strings.Split(policy.FeatureFlagTags, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		flag, tag, ok := strings.Cut(pair, "=")
+		flag = strings.TrimSpace(flag)
+		if !ok || flag == "" {
+			return nil, fmt.Errorf("session context policy invalid: FEATURE_FLAG_TAGS entry %q must be flag=tag", pair)
+		}
+		tag, err := domain.NormalizeTagName(tag)
+		if err != nil {
+			return nil, fmt.Errorf("session context policy invalid: FEATURE_FLAG_TAGS entry %q: %w", pair, err)
+		}
+		policy.FeatureFlagTargets = append(policy.FeatureFlagTargets, FeatureFlagTarget{Flag: flag, Tag: tag})
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the cache TTL is usable.
+func (p *SessionContextPolicy) Validate() error {
+	if p.CacheTTL < 0 {
+		return fmt.Errorf("session context policy invalid: SESSION_CONTEXT_CACHE_TTL must not be negative")
+	}
+	return nil
+}
+
+// FeatureFlagsFor returns the flags enabled for every organization followed
+// by the flags targeted at any of the tags, each once.
+func (p *SessionContextPolicy) FeatureFlagsFor(tags []string) []string {
+	flags := slices.Clone(p.FeatureFlagList)
+	for _, target := range p.FeatureFlagTargets {
+		if slices.Contains(tags, target.Tag) && !slices.Contains(flags, target.Flag) {
+			flags = append(flags, target.Flag)
+		}
+	}
+	return flags
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+// sessionContextCacheKeyPattern caches the database part of a session context per account
+const sessionContextCacheKeyPattern = "organizations:session_context:%d:%d"
+
+// SessionContextService assembles what a frontend needs to render for the
+// signed-in user in one call, so SPAs don't decode tokens or duplicate
+// authorization logic.
+type SessionContextService interface {
+	// GetContext returns the session context of the request. The organization,
+	// account, entitlements and the account's tags are cached; identity and
+	// permissions come from the current token.
+	GetContext(ctx context.Context, reqCtx *auth.RequestContext) (*SessionContext, error)
+}
+
+// SessionContext is the response of GET /me/context
+type SessionContext struct {
+	Identity     *SessionIdentity            `json:"identity"`
+	Permissions  []string                    `json:"permissions"`
+	Organization *SessionOrganization        `json:"organization"`
+	Account      *SessionAccount             `json:"account"`
+	Entitlements *paywall.SubscriptionStatus `json:"entitlements"`
+	FeatureFlags []string                    `json:"feature_flags"`
+	Elevation    *auth.Elevation             `json:"elevation,omitempty"`
+}
+
+// SessionIdentity is the caller's identity without provider-specific claims
+type SessionIdentity struct {
+	UserID          string      `json:"user_id"`
+	Email           string      `json:"email"`
+	EmailVerified   bool        `json:"email_verified"`
+	Roles           []auth.Role `json:"roles"`
+	AuthMethods     []string    `json:"auth_methods,omitempty"`
+	AuthenticatedAt *time.Time  `json:"authenticated_at,omitempty"`
+	ExpiresAt       time.Time   `json:"expires_at"`
+	Guest           bool        `json:"guest,omitempty"`
+	ClientID        string      `json:"client_id,omitempty"`
+	ImpersonatedBy  string      `json:"impersonated_by,omitempty"`
+}
+
+// SessionOrganization is the active organization
+type SessionOrganization struct {
+	ID          int32  `json:"id"`
+	Slug        string `json:"slug"`
+	Name        string `json:"name"`
+	Status      string `json:"status"`
+	Environment string `json:"environment"`
+}
+
+// SessionAccount is the caller's account in the active organization
+type SessionAccount struct {
+	ID       int32  `json:"id"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	Role     string `json:"role"`
+	Status   string `json:"status"`
+}
+
+// cachedSessionContext is the database part of a session context
+type cachedSessionContext struct {
+	Organization *SessionOrganization        `json:"organization"`
+	Account      *SessionAccount             `json:"account"`
+	Entitlements *paywall.SubscriptionStatus `json:"entitlements"`
+	// Tags are only loaded when FEATURE_FLAG_TAGS targets flags at tags
+	Tags []string `json:"tags,omitempty"`
+}
+
+type sessionContextService struct {
+	orgRepo       domain.OrganizationRepository
+	accountRepo   domain.AccountRepository
+	tagRepo       domain.TagRepository
+	subscriptions paywall.SubscriptionStatusProvider
+	redis         redis.Client
+	policy        *SessionContextPolicy
+	logger        loggerDomain.Logger
+}
+
+func NewSessionContextService(
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	tagRepo domain.TagRepository,
+	subscriptions paywall.SubscriptionStatusProvider,
+	redisClient redis.Client,
+	policy *SessionContextPolicy,
+	logger loggerDomain.Logger,
+) SessionContextService {
+	return &sessionContextService{
+		orgRepo:       orgRepo,
+		accountRepo:   accountRepo,
+		tagRepo:       tagRepo,
+		subscriptions: subscriptions,
+		redis:         redisClient,
+		policy:        policy,
+		logger:        logger,
+	}
+}
+
+func (s *sessionContextService) GetContext(ctx context.Context, reqCtx *auth.RequestContext) (*SessionContext, error) {
+	cached, err := s.loadCached(ctx, reqCtx.OrganizationID, reqCtx.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	identity := reqCtx.Identity
+	sessionIdentity := &SessionIdentity{
+		UserID:         identity.UserID,
+		Email:          identity.Email,
+		EmailVerified:  identity.EmailVerified,
+		Roles:          identity.Roles,
+		AuthMethods:    identity.AuthMethods,
+		ExpiresAt:      identity.ExpiresAt,
+		Guest:          identity.Guest,
+		ClientID:       identity.ClientID,
+		ImpersonatedBy: identity.ImpersonatedBy(),
+	}
+	if !identity.AuthenticatedAt.IsZero() {
+		sessionIdentity.AuthenticatedAt = &identity.AuthenticatedAt
+	}
+
+	return &SessionContext{
+		Identity:     sessionIdentity,
+		Permissions:  auth.PermissionsToStrings(auth.EffectivePermissions(identity)),
+		Organization: cached.Organization,
+		Account:      cached.Account,
+		Entitlements: cached.Entitlements,
+		FeatureFlags: s.policy.FeatureFlagsFor(cached.Tags),
+		Elevation:    reqCtx.Elevation,
+	}, nil
+}
+
+// loadCached returns the organization, account and entitlements from the
+// cache, or loads and caches them. Redis failures count as a miss.
+func (s *sessionContextService) loadCached(ctx context.Context, orgID, accountID int32) (*cachedSessionContext, error) {
+	key := fmt.Sprintf(sessionContextCacheKeyPattern, orgID, accountID)
+	if s.policy.CacheTTL > 0 {
+		if value, err := s.redis.Get(ctx, key); err == nil && value != "" {
+			var cached cachedSessionContext
+			if json.Unmarshal([]byte(value), &cached) == nil {
+				return &cached, nil
+			}
+		}
+	}
+
+	org, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load organization: %w", err)
+	}
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+
+	cached := &cachedSessionContext{
+		Organization: &SessionOrganization{
+			ID:          org.ID,
+			Slug:        org.Slug,
+			Name:        org.Name,
+			Status:      org.Status,
+			Environment: org.Environment,
+		},
+		Account: &SessionAccount{
+			ID:       account.ID,
+			Email:    account.Email,
+			FullName: account.FullName,
+			Role:     account.Role,
+			Status:   account.Status,
+		},
+	}
+
+	if len(s.policy.FeatureFlagTargets) > 0 {
+		cached.Tags, err = s.tagRepo.ListAccountTags(ctx, orgID, accountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load account tags: %w", err)
+		}
+	}
+
+	// Billing problems leave entitlements out instead of failing the whole context,
+	// and the result is not cached so they show up once billing recovers
+	cacheable := true
+	cached.Entitlements, err = s.subscriptions.GetSubscriptionStatus(ctx, orgID)
+	if err != nil {
+		s.logger.Warn("failed to load entitlements for session context", loggerDomain.Fields{
+			"organization_id": orgID,
+			"error":           err.Error(),
+		})
+		cacheable = false
+	}
+
+	if cacheable && s.policy.CacheTTL > 0 {
+		if value, err := json.Marshal(cached); err == nil {
+			_ = s.redis.Set(ctx, key, string(value), s.policy.CacheTTL)
+		}
+	}
+
+	return cached, nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// StagingPolicy controls staging and sandbox organizations, which let a
+// customer test integrations against production without polluting real data.
+//
+// All values can be set via environment variables with the STAGING_ prefix.
+type StagingPolicy struct {
+	// Enabled allows production organizations to create staging organizations
+	Enabled bool `mapstructure:"STAGING_ORGANIZATIONS_ENABLED"`
+
+	// TTL is how long a staging organization lives before it is purged
+	TTL time.Duration `mapstructure:"STAGING_ORGANIZATION_TTL"`
+
+	// MaxPerParent caps the live staging organizations of one production organization
+	MaxPerParent int `mapstructure:"STAGING_ORGANIZATIONS_PER_PARENT"`
+}
+
+// LoadStagingPolicy loads the staging policy from environment variables and app.env file.
+func LoadStagingPolicy() (*StagingPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("STAGING_ORGANIZATIONS_ENABLED", true)
+	v.SetDefault("STAGING_ORGANIZATION_TTL", "720h")
+	v.SetDefault("STAGING_ORGANIZATIONS_PER_PARENT", 3)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy StagingPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode staging policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that an enabled policy expires and bounds staging organizations.
+func (p *StagingPolicy) Validate() error {
+	if !p.Enabled {
+		return nil
+	}
+	if p.TTL <= 0 {
+		return fmt.Errorf("staging policy invalid: STAGING_ORGANIZATION_TTL must be positive")
+	}
+	if p.MaxPerParent < 1 {
+		return fmt.Errorf("staging policy invalid: STAGING_ORGANIZATIONS_PER_PARENT must be at least 1")
+	}
+	return nil
+}
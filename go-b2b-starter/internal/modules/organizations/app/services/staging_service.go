@@ -0,0 +1,142 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// StagingService manages staging and sandbox organizations.
+//
+// A staging organization is a separate tenant owned by a production
+// organization. Its data is left out of warehouse analytics, it shares the
+// parent's subscription without consuming quota, and the compliance module
+// purges it once it expires.
+type StagingService interface {
+	// CreateStagingOrganization creates a staging organization under orgID with
+	// the calling account as its owner
+	CreateStagingOrganization(ctx context.Context, orgID, accountID int32, req *CreateStagingOrganizationRequest) (*domain.Organization, error)
+
+	// ListStagingOrganizations returns the staging organizations of orgID
+	ListStagingOrganizations(ctx context.Context, orgID int32) ([]*domain.Organization, error)
+}
+
+// CreateStagingOrganizationRequest represents the request to create a staging organization
+type CreateStagingOrganizationRequest struct {
+	DisplayName string `json:"display_name" binding:"required,max=255"`
+
+	// Environment is "staging" or "sandbox"; defaults to "staging"
+	Environment string `json:"environment"`
+}
+
+// Validate performs business validation on the staging organization request
+func (r *CreateStagingOrganizationRequest) Validate() error {
+	if strings.TrimSpace(r.DisplayName) == "" {
+		return fmt.Errorf("display name cannot be empty")
+	}
+	switch r.Environment {
+	case "", domain.EnvironmentStaging, domain.EnvironmentSandbox:
+		return nil
+	default:
+		return domain.ErrStagingInvalidEnvironment
+	}
+}
+
+type stagingService struct {
+	orgRepo       domain.OrganizationRepository
+	accountRepo   domain.AccountRepository
+	memberService MemberService
+	policy        *StagingPolicy
+	logger        loggerDomain.Logger
+}
+
+func NewStagingService(
+	orgRepo domain.OrganizationRepository,
+	accountRepo domain.AccountRepository,
+	memberService MemberService,
+	policy *StagingPolicy,
+	logger loggerDomain.Logger,
+) StagingService {
+	return &stagingService{
+		orgRepo:       orgRepo,
+		accountRepo:   accountRepo,
+		memberService: memberService,
+		policy:        policy,
+		logger:        logger,
+	}
+}
+
+func (s *stagingService) CreateStagingOrganization(ctx context.Context, orgID, accountID int32, req *CreateStagingOrganizationRequest) (*domain.Organization, error) {
+	if !s.policy.Enabled {
+		return nil, domain.ErrStagingOrganizationsDisabled
+	}
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	parent, err := s.orgRepo.GetByID(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !parent.IsProduction() {
+		return nil, domain.ErrStagingParentNotProduction
+	}
+
+	count, err := s.orgRepo.CountStaging(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= int64(s.policy.MaxPerParent) {
+		return nil, domain.ErrStagingLimitReached
+	}
+
+	owner, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	environment := req.Environment
+	if environment == "" {
+		environment = domain.EnvironmentStaging
+	}
+	expiresAt := time.Now().Add(s.policy.TTL).UTC()
+
+	// The staging organization is a full tenant in the auth provider so
+	// integrations can authenticate against it exactly as in production
+	bootstrap, err := s.memberService.BootstrapOrganizationWithOwner(ctx, &BootstrapOrganizationRequest{
+		OrgDisplayName:       strings.TrimSpace(req.DisplayName),
+		OwnerEmail:           owner.Email,
+		OwnerName:            owner.FullName,
+		Environment:          environment,
+		ParentOrganizationID: &parent.ID,
+		ExpiresAt:            &expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create staging organization: %w", err)
+	}
+
+	org, err := s.orgRepo.GetBySlug(ctx, bootstrap.OrgSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("staging organization created", loggerDomain.Fields{
+		"audit":                  true,
+		"event":                  "organization.staging_created",
+		"organization_id":        org.ID,
+		"parent_organization_id": parent.ID,
+		"account_id":             accountID,
+		"environment":            environment,
+		"expires_at":             expiresAt,
+	})
+
+	return org, nil
+}
+
+func (s *stagingService) ListStagingOrganizations(ctx context.Context, orgID int32) ([]*domain.Organization, error) {
+	return s.orgRepo.ListStaging(ctx, orgID)
+}
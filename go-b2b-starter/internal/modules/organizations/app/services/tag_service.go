@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+// TagService lets organization admins attach tags such as "beta" or "vip" to
+// users. Tags filter the admin user list and target feature flags (see
+// FEATURE_FLAG_TAGS).
+type TagService interface {
+	// ListTags returns the organization's tags with how many users carry each
+	ListTags(ctx context.Context, orgID int32) ([]*domain.Tag, error)
+
+	// DeleteTag removes a tag from the organization and from every user
+	DeleteTag(ctx context.Context, orgID, actorID int32, name string) error
+
+	// ListUserTags returns the names of the account's tags
+	ListUserTags(ctx context.Context, orgID, accountID int32) ([]string, error)
+
+	// AddUserTag attaches a tag to the account, creating the tag on first use,
+	// and returns the account's tags
+	AddUserTag(ctx context.Context, orgID, accountID, actorID int32, name string) ([]string, error)
+
+	// RemoveUserTag detaches a tag from the account and returns the account's tags
+	RemoveUserTag(ctx context.Context, orgID, accountID, actorID int32, name string) ([]string, error)
+}
+
+// AddUserTagRequest names the tag to attach to a user
+type AddUserTagRequest struct {
+	Tag string `json:"tag" binding:"required"`
+}
+
+type tagService struct {
+	tagRepo     domain.TagRepository
+	accountRepo domain.AccountRepository
+	redis       redis.Client
+	logger      loggerDomain.Logger
+}
+
+func NewTagService(
+	tagRepo domain.TagRepository,
+	accountRepo domain.AccountRepository,
+	redisClient redis.Client,
+	logger loggerDomain.Logger,
+) TagService {
+	return &tagService{
+		tagRepo:     tagRepo,
+		accountRepo: accountRepo,
+		redis:       redisClient,
+		logger:      logger,
+	}
+}
+
+func (s *tagService) ListTags(ctx context.Context, orgID int32) ([]*domain.Tag, error) {
+	return s.tagRepo.List(ctx, orgID)
+}
+
+// DeleteTag does not clear cached session contexts; feature flags targeted
+// at the tag turn off within SESSION_CONTEXT_CACHE_TTL.
+func (s *tagService) DeleteTag(ctx context.Context, orgID, actorID int32, name string) error {
+	name, err := domain.NormalizeTagName(name)
+	if err != nil {
+		return err
+	}
+	if err := s.tagRepo.Delete(ctx, orgID, name); err != nil {
+		return err
+	}
+
+	s.audit("tag.deleted", loggerDomain.Fields{
+		"organization_id": orgID,
+		"actor_id":        actorID,
+		"tag":             name,
+	})
+	return nil
+}
+
+func (s *tagService) ListUserTags(ctx context.Context, orgID, accountID int32) ([]string, error) {
+	if _, err := s.accountRepo.GetByID(ctx, orgID, accountID); err != nil {
+		return nil, err
+	}
+	return s.tagRepo.ListAccountTags(ctx, orgID, accountID)
+}
+
+// AddUserTag refuses to create a tag once the organization has
+// domain.MaxOrganizationTags; existing tags can always be attached.
+func (s *tagService) AddUserTag(ctx context.Context, orgID, accountID, actorID int32, name string) ([]string, error) {
+	name, err := domain.NormalizeTagName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := s.tagRepo.List(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	if !containsTag(tags, name) && len(tags) >= domain.MaxOrganizationTags {
+		return nil, domain.ErrTagLimit
+	}
+
+	if err := s.tagRepo.AddAccountTag(ctx, orgID, account.ID, name, actorID); err != nil {
+		return nil, err
+	}
+	s.clearSessionContext(ctx, orgID, account.ID)
+
+	s.audit("user.tag_added", loggerDomain.Fields{
+		"organization_id": orgID,
+		"account_id":      account.ID,
+		"actor_id":        actorID,
+		"tag":             name,
+	})
+	return s.tagRepo.ListAccountTags(ctx, orgID, account.ID)
+}
+
+func (s *tagService) RemoveUserTag(ctx context.Context, orgID, accountID, actorID int32, name string) ([]string, error) {
+	name, err := domain.NormalizeTagName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tagRepo.RemoveAccountTag(ctx, orgID, account.ID, name); err != nil {
+		return nil, err
+	}
+	s.clearSessionContext(ctx, orgID, account.ID)
+
+	s.audit("user.tag_removed", loggerDomain.Fields{
+		"organization_id": orgID,
+		"account_id":      account.ID,
+		"actor_id":        actorID,
+		"tag":             name,
+	})
+	return s.tagRepo.ListAccountTags(ctx, orgID, account.ID)
+}
+
+// clearSessionContext drops the account's cached session context so feature
+// flags targeted at its tags change on the next GET /me/context. A failure
+// only delays the change until the cache expires.
+func (s *tagService) clearSessionContext(ctx context.Context, orgID, accountID int32) {
+	if err := s.redis.Delete(ctx, fmt.Sprintf(sessionContextCacheKeyPattern, orgID, accountID)); err != nil {
+		s.logger.Warn("failed to clear cached session context", loggerDomain.Fields{
+			"organization_id": orgID,
+			"account_id":      accountID,
+			"error":           err.Error(),
+		})
+	}
+}
+
+func (s *tagService) audit(event string, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	s.logger.Info("tag audit", fields)
+}
+
+func containsTag(tags []*domain.Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
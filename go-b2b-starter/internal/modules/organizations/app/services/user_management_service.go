@@ -0,0 +1,538 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+const (
+	// userNotificationTimeout bounds each background notification to a managed user
+	userNotificationTimeout = 30 * time.Second
+
+	// statusHistoryLimit caps the status changes returned for an account
+	statusHistoryLimit = 100
+)
+
+// UserManagementService gives organization admins control over the
+// organization's users: finding them, suspending and reactivating them,
+// forcing a password reset, lifting a login lockout and deleting them.
+type UserManagementService interface {
+	// ListUsers returns a page of the organization's accounts matching the request filters
+	ListUsers(ctx context.Context, orgID int32, req *ListUsersRequest) (*ListUsersResponse, error)
+
+	// GetUser returns an account with its login lockout state
+	GetUser(ctx context.Context, orgID, accountID int32) (*UserDetails, error)
+
+	// SuspendUser blocks an active account from the API, revokes its sessions
+	// and notifies the member of the reason, which is required. actorID is 0
+	// when no member suspends the account, as in imports.
+	SuspendUser(ctx context.Context, orgID int32, providerOrgID string, accountID, actorID int32, reason string) (*domain.Account, error)
+
+	// ReactivateUser lifts a suspension and notifies the member. The reason is
+	// optional. On seat-based plans the member needs a free seat.
+	ReactivateUser(ctx context.Context, orgID, accountID, actorID int32, reason string) (*domain.Account, error)
+
+	// ListStatusHistory returns the account's suspensions and reactivations newest first
+	ListStatusHistory(ctx context.Context, orgID, accountID int32) ([]*domain.AccountStatusChange, error)
+
+	// ResetPassword deletes the member's password, revokes their sessions and
+	// emails them a link to set a new one
+	ResetPassword(ctx context.Context, orgID int32, providerOrgID string, accountID, actorID int32) error
+
+	// UnlockUser lifts the login lockout of the account's email
+	UnlockUser(ctx context.Context, orgID, accountID, actorID int32) error
+
+	// DeleteUser revokes the member's sessions, removes them from the auth
+	// provider and deactivates the account
+	DeleteUser(ctx context.Context, orgID int32, providerOrgID string, accountID, actorID int32) error
+
+	// GetUserMetadata returns the application-defined attributes of an account
+	GetUserMetadata(ctx context.Context, orgID, accountID int32) (domain.AccountMetadata, error)
+
+	// PatchUserMetadata merges the patch into the account's metadata: keys
+	// replace existing values and keys set to null are removed
+	PatchUserMetadata(ctx context.Context, orgID, accountID, actorID int32, patch domain.AccountMetadata) (domain.AccountMetadata, error)
+}
+
+// ListUsersRequest filters, sorts and pages the organization's accounts
+type ListUsersRequest struct {
+	// Query is matched against the email and full name
+	Query string `form:"query"`
+	// Email is matched against the email only
+	Email         string    `form:"email"`
+	Status        string    `form:"status" binding:"omitempty,oneof=active inactive suspended dormant"`
+	Role          string    `form:"role"`
+	EmailVerified *bool     `form:"email_verified"`
+	CreatedAfter  time.Time `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedBefore time.Time `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`
+	Tag           string    `form:"tag"`
+	Type          string    `form:"type" binding:"omitempty,oneof=human service"`
+	Sort          string    `form:"sort" binding:"omitempty,oneof=created_desc created_asc email_asc email_desc name_asc last_login_desc"`
+	Limit         int32     `form:"limit"`
+	Offset        int32     `form:"offset"`
+}
+
+// UserStatusRequest carries the reason for suspending or reactivating an account
+type UserStatusRequest struct {
+	Reason string `json:"reason"`
+}
+
+// ListUsersResponse is a page of accounts with the total number of matches
+type ListUsersResponse struct {
+	Users  []*domain.Account `json:"users"`
+	Total  int64             `json:"total"`
+	Limit  int32             `json:"limit"`
+	Offset int32             `json:"offset"`
+}
+
+// UserDetails is an account as seen by an organization admin
+type UserDetails struct {
+	*domain.Account
+
+	// LockedUntil is set while the email is locked out of sign-in flows
+	LockedUntil *time.Time `json:"locked_until,omitempty"`
+}
+
+type userManagementService struct {
+	accountRepo    domain.AccountRepository
+	statusRepo     domain.AccountStatusRepository
+	authMemberRepo domain.AuthMemberRepository
+	lockouts       auth.LoginLockoutService
+	revoker        auth.SessionRevoker
+	denylist       auth.SessionDenylist
+	notifications  NotificationService
+	seatGuard      paywall.SeatGuard
+	eventBus       eventbus.EventBus
+	logger         loggerDomain.Logger
+}
+
+func NewUserManagementService(
+	accountRepo domain.AccountRepository,
+	statusRepo domain.AccountStatusRepository,
+	authMemberRepo domain.AuthMemberRepository,
+	lockouts auth.LoginLockoutService,
+	revoker auth.SessionRevoker,
+	denylist auth.SessionDenylist,
+	notifications NotificationService,
+	seatGuard paywall.SeatGuard,
+	eventBus eventbus.EventBus,
+	logger loggerDomain.Logger,
+) UserManagementService {
+	return &userManagementService{
+		accountRepo:    accountRepo,
+		statusRepo:     statusRepo,
+		authMemberRepo: authMemberRepo,
+		lockouts:       lockouts,
+		revoker:        revoker,
+		denylist:       denylist,
+		notifications:  notifications,
+		seatGuard:      seatGuard,
+		eventBus:       eventBus,
+		logger:         logger,
+	}
+}
+
+func (s *userManagementService) ListUsers(ctx context.Context, orgID int32, req *ListUsersRequest) (*ListUsersResponse, error) {
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 50
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+	if !req.CreatedAfter.IsZero() && !req.CreatedBefore.IsZero() && !req.CreatedAfter.Before(req.CreatedBefore) {
+		return nil, domain.ErrUserCreatedRange
+	}
+	if req.Tag != "" {
+		tag, err := domain.NormalizeTagName(req.Tag)
+		if err != nil {
+			return nil, err
+		}
+		req.Tag = tag
+	}
+
+	filter := domain.AccountFilter{
+		Query:         req.Query,
+		Email:         req.Email,
+		Status:        req.Status,
+		Role:          req.Role,
+		EmailVerified: req.EmailVerified,
+		CreatedAfter:  req.CreatedAfter,
+		CreatedBefore: req.CreatedBefore,
+		Tag:           req.Tag,
+		Sort:          domain.AccountSort(req.Sort),
+	}
+	if req.Type != "" {
+		service := req.Type == "service"
+		filter.Service = &service
+	}
+
+	users, err := s.accountRepo.ListFiltered(ctx, orgID, filter, req.Limit, req.Offset)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.accountRepo.CountFiltered(ctx, orgID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ListUsersResponse{
+		Users:  users,
+		Total:  total,
+		Limit:  req.Limit,
+		Offset: req.Offset,
+	}, nil
+}
+
+func (s *userManagementService) GetUser(ctx context.Context, orgID, accountID int32) (*UserDetails, error) {
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &UserDetails{Account: account}
+	lockedFor, err := s.lockouts.LockedFor(ctx, account.Email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check login lockout: %w", err)
+	}
+	if lockedFor > 0 {
+		lockedUntil := time.Now().Add(lockedFor)
+		details.LockedUntil = &lockedUntil
+	}
+
+	return details, nil
+}
+
+// SuspendUser sets the suspended status first, so the account is rejected
+// by the organization middleware even if revoking its sessions fails. The
+// status and its history entry are written in one statement.
+func (s *userManagementService) SuspendUser(ctx context.Context, orgID int32, providerOrgID string, accountID, actorID int32, reason string) (*domain.Account, error) {
+	reason, err := statusChangeReason(reason)
+	if err != nil {
+		return nil, err
+	}
+	if reason == "" {
+		return nil, domain.ErrUserSuspensionReason
+	}
+
+	account, err := s.managedAccount(ctx, orgID, accountID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsActive() {
+		return nil, domain.ErrUserNotActive
+	}
+
+	var actor *int32
+	if actorID != 0 {
+		actor = &actorID
+	}
+	change, err := s.statusRepo.ChangeStatus(ctx, &domain.AccountStatusChange{
+		OrganizationID: orgID,
+		AccountID:      account.ID,
+		FromStatus:     account.Status,
+		ToStatus:       domain.StatusSuspended,
+		Reason:         reason,
+		ActorAccountID: actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.revokeSessions(ctx, account); err != nil {
+		return nil, err
+	}
+
+	s.notifyStatusChange(account, change, "Your account was suspended", fmt.Sprintf(
+		"Your %s account was suspended by an administrator and you can no longer sign in.\n\n"+
+			"Reason: %s\n\n"+
+			"Contact your organization administrator if you think this is a mistake.\n",
+		s.organizationName(ctx, account), reason))
+
+	s.auditStatusChange("user.suspended", account, change)
+	if !domain.IsServiceAccountEmail(account.Email) {
+		s.publish(ctx, events.NewUserSuspended(orgID, account.ID, account.Email, reason, actorID))
+	}
+	return s.accountRepo.GetByID(ctx, orgID, account.ID)
+}
+
+func (s *userManagementService) ReactivateUser(ctx context.Context, orgID, accountID, actorID int32, reason string) (*domain.Account, error) {
+	reason, err := statusChangeReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if !account.IsSuspended() {
+		return nil, domain.ErrUserNotSuspended
+	}
+
+	statusChange := &domain.AccountStatusChange{
+		OrganizationID: orgID,
+		AccountID:      account.ID,
+		FromStatus:     domain.StatusSuspended,
+		ToStatus:       domain.StatusActive,
+		Reason:         reason,
+		ActorAccountID: &actorID,
+	}
+
+	// A suspended member released its seat; only human accounts take one
+	seatLimited := false
+	if account.Kind == domain.AccountKindHuman {
+		seatLimited, err = s.seatGuard.CheckSeatAvailable(ctx, orgID)
+		if err != nil {
+			if errors.Is(err, paywall.ErrSeatLimitReached) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to check seat availability: %w", err)
+		}
+	}
+
+	var change *domain.AccountStatusChange
+	if seatLimited {
+		change, err = s.statusRepo.ChangeStatusWithinSeats(ctx, statusChange)
+	} else {
+		change, err = s.statusRepo.ChangeStatus(ctx, statusChange)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	body := fmt.Sprintf("Your %s account was reactivated and you can sign in again.\n", s.organizationName(ctx, account))
+	if reason != "" {
+		body += fmt.Sprintf("\nNote from your administrator: %s\n", reason)
+	}
+	s.notifyStatusChange(account, change, "Your account was reactivated", body)
+
+	s.auditStatusChange("user.reactivated", account, change)
+	if !domain.IsServiceAccountEmail(account.Email) {
+		s.publish(ctx, events.NewUserReactivated(orgID, account.ID, account.Email, reason, actorID))
+	}
+	return s.accountRepo.GetByID(ctx, orgID, account.ID)
+}
+
+func (s *userManagementService) ListStatusHistory(ctx context.Context, orgID, accountID int32) ([]*domain.AccountStatusChange, error) {
+	if _, err := s.accountRepo.GetByID(ctx, orgID, accountID); err != nil {
+		return nil, err
+	}
+	return s.statusRepo.ListChanges(ctx, orgID, accountID, statusHistoryLimit)
+}
+
+func (s *userManagementService) ResetPassword(ctx context.Context, orgID int32, providerOrgID string, accountID, actorID int32) error {
+	account, err := s.managedAccount(ctx, orgID, accountID, actorID)
+	if err != nil {
+		return err
+	}
+	if account.StytchMemberID == "" {
+		return domain.ErrUserNoAuthMember
+	}
+
+	if err := s.authMemberRepo.ResetPassword(ctx, providerOrgID, account.StytchMemberID); err != nil {
+		return fmt.Errorf("failed to reset password: %w", err)
+	}
+	if err := s.revokeSessions(ctx, account); err != nil {
+		return err
+	}
+
+	s.audit("user.password_reset", orgID, account, actorID)
+	s.publish(ctx, events.NewPasswordChanged(orgID, account.ID, account.Email, actorID, true))
+	return nil
+}
+
+func (s *userManagementService) UnlockUser(ctx context.Context, orgID, accountID, actorID int32) error {
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.lockouts.Unlock(ctx, account.Email); err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+
+	s.audit("user.unlocked", orgID, account, actorID)
+	return nil
+}
+
+// DeleteUser runs its steps in an order that can be retried: sessions are
+// revoked before the member is removed, and the account is deactivated last.
+func (s *userManagementService) DeleteUser(ctx context.Context, orgID int32, providerOrgID string, accountID, actorID int32) error {
+	account, err := s.managedAccount(ctx, orgID, accountID, actorID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.revokeSessions(ctx, account); err != nil {
+		return err
+	}
+	if account.StytchMemberID != "" {
+		if err := s.authMemberRepo.RemoveMembers(ctx, &domain.RemoveAuthMembersRequest{
+			OrganizationID: providerOrgID,
+			MemberIDs:      []string{account.StytchMemberID},
+		}); err != nil {
+			return fmt.Errorf("failed to remove member: %w", err)
+		}
+	}
+	if err := s.accountRepo.Delete(ctx, orgID, account.ID); err != nil {
+		return fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	s.audit("user.deleted", orgID, account, actorID)
+	if !domain.IsServiceAccountEmail(account.Email) {
+		s.publish(ctx, events.NewUserDeleted(orgID, account.ID, account.Email, actorID))
+	}
+	return nil
+}
+
+func (s *userManagementService) GetUserMetadata(ctx context.Context, orgID, accountID int32) (domain.AccountMetadata, error) {
+	return s.accountRepo.GetMetadata(ctx, orgID, accountID)
+}
+
+// PatchUserMetadata checks the size of the merged metadata before storing
+// the patch. The store merges again in one statement, so concurrent patches
+// to different keys are not lost.
+func (s *userManagementService) PatchUserMetadata(ctx context.Context, orgID, accountID, actorID int32, patch domain.AccountMetadata) (domain.AccountMetadata, error) {
+	if len(patch) == 0 {
+		return s.accountRepo.GetMetadata(ctx, orgID, accountID)
+	}
+	if err := patch.ValidateKeys(); err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := account.Metadata.Merge(patch).ValidateSize(); err != nil {
+		return nil, err
+	}
+
+	metadata, err := s.accountRepo.PatchMetadata(ctx, orgID, accountID, patch)
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("user.metadata_updated", orgID, account, actorID)
+	return metadata, nil
+}
+
+// managedAccount loads an account an admin is about to act on. Admins cannot
+// lock themselves out, so their own account is refused.
+func (s *userManagementService) managedAccount(ctx context.Context, orgID, accountID, actorID int32) (*domain.Account, error) {
+	if accountID == actorID {
+		return nil, domain.ErrUserManagementSelf
+	}
+	return s.accountRepo.GetByID(ctx, orgID, accountID)
+}
+
+// revokeSessions ends the member's provider sessions and denylists the
+// access tokens already issued to them.
+func (s *userManagementService) revokeSessions(ctx context.Context, account *domain.Account) error {
+	if account.StytchMemberID == "" {
+		return nil
+	}
+	if err := s.denylist.RevokeSubject(ctx, account.StytchMemberID, time.Now()); err != nil {
+		return fmt.Errorf("failed to denylist member tokens: %w", err)
+	}
+	if err := s.revoker.RevokeUserSessions(ctx, account.StytchMemberID); err != nil {
+		return fmt.Errorf("failed to revoke member sessions: %w", err)
+	}
+	return nil
+}
+
+// organizationName names the account's organization in notifications
+func (s *userManagementService) organizationName(ctx context.Context, account *domain.Account) string {
+	org, err := s.accountRepo.GetOrganization(ctx, account.ID)
+	if err != nil {
+		return "organization"
+	}
+	return org.Name
+}
+
+// notifyStatusChange notifies the member about a status change in the
+// background. A failed notification is logged; the change itself is already stored.
+func (s *userManagementService) notifyStatusChange(account *domain.Account, change *domain.AccountStatusChange, subject, body string) {
+	req := &NotifyRequest{
+		OrganizationID: change.OrganizationID,
+		AccountID:      account.ID,
+		Email:          account.Email,
+		Type:           domain.NotificationAccountStatus,
+		Title:          subject,
+		Body:           body,
+	}
+
+	go func() {
+		// Don't use request context as it will be cancelled when request completes
+		sendCtx, cancel := context.WithTimeout(context.Background(), userNotificationTimeout)
+		defer cancel()
+
+		if err := s.notifications.Notify(sendCtx, req); err != nil {
+			s.logger.Error("failed to send account status notification", loggerDomain.Fields{
+				"account_id":       account.ID,
+				"status_change_id": change.ID,
+				"error":            err.Error(),
+			})
+		}
+	}()
+}
+
+// statusChangeReason trims the reason and checks its length
+func statusChangeReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > domain.MaxStatusChangeReasonLength {
+		return "", domain.ErrUserReasonTooLong
+	}
+	return reason, nil
+}
+
+func (s *userManagementService) auditStatusChange(event string, account *domain.Account, change *domain.AccountStatusChange) {
+	var actorID int32
+	if change.ActorAccountID != nil {
+		actorID = *change.ActorAccountID
+	}
+	s.logger.Info("user management audit", loggerDomain.Fields{
+		"audit":            true,
+		"event":            event,
+		"organization_id":  change.OrganizationID,
+		"account_id":       account.ID,
+		"member_id":        account.StytchMemberID,
+		"actor_id":         actorID,
+		"status_change_id": change.ID,
+		"from_status":      change.FromStatus,
+		"reason":           change.Reason,
+	})
+}
+
+func (s *userManagementService) audit(event string, orgID int32, account *domain.Account, actorID int32) {
+	s.logger.Info("user management audit", loggerDomain.Fields{
+		"audit":           true,
+		"event":           event,
+		"organization_id": orgID,
+		"account_id":      account.ID,
+		"member_id":       account.StytchMemberID,
+		"actor_id":        actorID,
+	})
+}
+
+// publish logs failed subscribers. The change is already committed, so a
+// failed subscriber must not fail the admin's request.
+func (s *userManagementService) publish(ctx context.Context, event eventbus.Event) {
+	if err := s.eventBus.Publish(ctx, event); err != nil {
+		s.logger.Warn("failed to publish user event", loggerDomain.Fields{
+			"event": event.EventName(),
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+)
+
+// UserStatsPolicy controls the signup and engagement statistics operators
+// view on an internal admin dashboard.
+//
+// All values can be set via environment variables with the USER_STATS_ prefix.
+type UserStatsPolicy struct {
+	// DefaultDays is how many days of signups are returned when none are asked for
+	DefaultDays int32 `mapstructure:"USER_STATS_DEFAULT_DAYS"`
+
+	// MaxDays is the most days of signups returned at once
+	MaxDays int32 `mapstructure:"USER_STATS_MAX_DAYS"`
+}
+
+// LoadUserStatsPolicy loads the user stats policy from environment variables and app.env file.
+func LoadUserStatsPolicy() (*UserStatsPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("USER_STATS_DEFAULT_DAYS", 30)
+	v.SetDefault("USER_STATS_MAX_DAYS", 365)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy UserStatsPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode user stats policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks that the default range fits within the maximum.
+func (p *UserStatsPolicy) Validate() error {
+	if p.MaxDays <= 0 {
+		return fmt.Errorf("user stats policy invalid: USER_STATS_MAX_DAYS must be positive")
+	}
+	if p.DefaultDays <= 0 || p.DefaultDays > p.MaxDays {
+		return fmt.Errorf("user stats policy invalid: USER_STATS_DEFAULT_DAYS must be between 1 and USER_STATS_MAX_DAYS")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// UserStatsService reports signup and engagement statistics for an internal
+// admin dashboard. Without an organization ID the statistics cover every
+// production organization; staging and sandbox organizations are left out.
+type UserStatsService interface {
+	// GetStats returns account totals, active users and the verification rate
+	GetStats(ctx context.Context, req *UserStatsRequest) (*domain.UserStats, error)
+
+	// ListSignups returns the accounts created per day, oldest first
+	ListSignups(ctx context.Context, req *ListSignupsRequest) ([]*domain.DailySignups, error)
+}
+
+// UserStatsRequest optionally narrows the statistics to one organization
+type UserStatsRequest struct {
+	OrganizationID int32 `form:"organization_id"`
+}
+
+// ListSignupsRequest selects the days of signups to return
+type ListSignupsRequest struct {
+	OrganizationID int32 `form:"organization_id"`
+	// Days up to and including today; defaults to USER_STATS_DEFAULT_DAYS
+	Days int32 `form:"days"`
+}
+
+type userStatsService struct {
+	statsRepo domain.UserStatsRepository
+	orgRepo   domain.OrganizationRepository
+	policy    *UserStatsPolicy
+}
+
+func NewUserStatsService(
+	statsRepo domain.UserStatsRepository,
+	orgRepo domain.OrganizationRepository,
+	policy *UserStatsPolicy,
+) UserStatsService {
+	return &userStatsService{
+		statsRepo: statsRepo,
+		orgRepo:   orgRepo,
+		policy:    policy,
+	}
+}
+
+func (s *userStatsService) GetStats(ctx context.Context, req *UserStatsRequest) (*domain.UserStats, error) {
+	if err := s.checkOrganization(ctx, req.OrganizationID); err != nil {
+		return nil, err
+	}
+	return s.statsRepo.GetStats(ctx, req.OrganizationID)
+}
+
+func (s *userStatsService) ListSignups(ctx context.Context, req *ListSignupsRequest) ([]*domain.DailySignups, error) {
+	days := req.Days
+	if days == 0 {
+		days = s.policy.DefaultDays
+	}
+	if days < 0 || days > s.policy.MaxDays {
+		return nil, fmt.Errorf("%w: days must be between 1 and %d", domain.ErrUserStatsInvalidDays, s.policy.MaxDays)
+	}
+
+	if err := s.checkOrganization(ctx, req.OrganizationID); err != nil {
+		return nil, err
+	}
+	return s.statsRepo.ListDailySignups(ctx, req.OrganizationID, days)
+}
+
+// checkOrganization returns ErrOrganizationNotFound for an unknown organization
+// instead of reporting zeros. 0 selects every production organization.
+func (s *userStatsService) checkOrganization(ctx context.Context, orgID int32) error {
+	if orgID == 0 {
+		return nil
+	}
+	_, err := s.orgRepo.GetByID(ctx, orgID)
+	return err
+}
@@ -0,0 +1,130 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type AuthPolicyHandler struct {
+	policyService services.AuthPolicyService
+	logger        logger.Logger
+}
+
+func NewAuthPolicyHandler(policyService services.AuthPolicyService, logger logger.Logger) *AuthPolicyHandler {
+	return &AuthPolicyHandler{
+		policyService: policyService,
+		logger:        logger,
+	}
+}
+
+// GetPolicy godoc
+// @Summary Get organization auth policy
+// @Description Returns the organization's session requirements. Without overrides, MFA and sign-in methods are left to the auth provider and session_max_age_seconds is 0.
+// @Tags Organizations
+// @Produce json
+// @Success 200 {object} domain.AuthPolicy "Auth policy"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/auth-policy [get]
+func (h *AuthPolicyHandler) GetPolicy(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	policy, err := h.policyService.GetPolicy(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.logger.Error("failed to get auth policy", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to get auth policy", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, policy)
+}
+
+// UpdatePolicy godoc
+// @Summary Update organization auth policy
+// @Description Requires MFA, limits how long after signing in a session may be used, and restricts sign-in methods for the organization's members. These are enforced on top of the auth provider's own settings. The update is rejected if the caller's current session would not satisfy the new policy.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param request body services.UpdateAuthPolicyRequest true "Auth policy"
+// @Success 200 {object} domain.AuthPolicy "Updated auth policy"
+// @Failure 400 {object} map[string]string "Invalid policy"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 409 {object} map[string]string "Policy would lock out the caller"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/auth-policy [put]
+func (h *AuthPolicyHandler) UpdatePolicy(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.UpdateAuthPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	policy, err := h.policyService.UpdatePolicy(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, auth.GetIdentity(c), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrAuthPolicyInvalidMethod, domain.ErrAuthPolicyInvalidSessionAge:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrAuthPolicyLockout:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		default:
+			h.logger.Error("failed to update auth policy", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to update auth policy", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, policy)
+}
+
+// ResetPolicy godoc
+// @Summary Reset organization auth policy
+// @Description Removes the organization's auth policy overrides so only the auth provider's settings apply.
+// @Tags Organizations
+// @Produce json
+// @Success 204 "Policy reset"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "No policy set"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/auth-policy [delete]
+func (h *AuthPolicyHandler) ResetPolicy(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	if err := h.policyService.ResetPolicy(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID); err != nil {
+		if err == domain.ErrAuthPolicyNotFound {
+			response.Error(c, http.StatusNotFound, "auth policy not found", err)
+			return
+		}
+		h.logger.Error("failed to reset auth policy", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to reset auth policy", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,162 @@
+package organizations
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// avatarFormOverhead allows for the multipart headers around the image
+const avatarFormOverhead = 64 << 10
+
+type AvatarHandler struct {
+	avatarService  services.AvatarService
+	maxUploadBytes int64
+	logger         logger.Logger
+}
+
+func NewAvatarHandler(avatarService services.AvatarService, policy *services.AvatarPolicy, logger logger.Logger) *AvatarHandler {
+	return &AvatarHandler{
+		avatarService:  avatarService,
+		maxUploadBytes: policy.MaxUploadBytes,
+		logger:         logger,
+	}
+}
+
+// UploadAvatar godoc
+// @Summary Upload my avatar
+// @Description Replaces the signed-in member's avatar. The JPEG or PNG image is cropped to a centered square, resized to AVATAR_SIZE and stored as JPEG; the account's avatar_url then points at the public avatar route. The previous avatar is deleted.
+// @Tags auth
+// @Accept multipart/form-data
+// @Produce json
+// @Param avatar formData file true "JPEG or PNG image"
+// @Success 200 {object} domain.Account "Account with the new avatar_url"
+// @Failure 400 {object} map[string]string "Missing file or not a JPEG or PNG image within AVATAR_MAX_PIXELS"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Account not found"
+// @Failure 413 {object} map[string]string "Image exceeds AVATAR_MAX_UPLOAD_BYTES"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/avatar [put]
+func (h *AvatarHandler) UploadAvatar(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxUploadBytes+avatarFormOverhead)
+
+	header, err := c.FormFile("avatar")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(c, http.StatusRequestEntityTooLarge, domain.ErrAvatarTooLarge.Error(), err)
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "avatar file is required", err)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, "failed to read avatar", err)
+		return
+	}
+	defer file.Close()
+
+	account, err := h.avatarService.UploadAvatar(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &services.AvatarUpload{
+		Size:    header.Size,
+		Content: file,
+	})
+	if err != nil {
+		h.handleError(c, reqCtx, "failed to upload avatar", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, account)
+}
+
+// DeleteAvatar godoc
+// @Summary Delete my avatar
+// @Description Removes the signed-in member's avatar and deletes its file.
+// @Tags auth
+// @Success 204 "Avatar removed"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Account not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/avatar [delete]
+func (h *AvatarHandler) DeleteAvatar(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	if err := h.avatarService.DeleteAvatar(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID); err != nil {
+		h.handleError(c, reqCtx, "failed to delete avatar", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAvatar godoc
+// @Summary Get an avatar
+// @Description Serves an avatar image by the key in an account's avatar_url. The route is public so avatars can be used in img tags; keys are random and change with every upload, so responses are cached indefinitely.
+// @Tags auth
+// @Produce image/jpeg
+// @Param key path string true "Avatar key"
+// @Success 200 {file} file "Avatar image"
+// @Failure 404 {object} map[string]string "Avatar not found"
+// @Router /avatars/{key} [get]
+func (h *AvatarHandler) GetAvatar(c *gin.Context) {
+	content, asset, err := h.avatarService.OpenAvatar(c.Request.Context(), c.Param("key"))
+	if err != nil {
+		if errors.Is(err, domain.ErrAvatarNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		h.logger.Error("failed to open avatar", map[string]interface{}{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to get avatar", err)
+		return
+	}
+	defer content.Close()
+
+	c.Header("Content-Type", asset.ContentType)
+	c.Header("Content-Length", strconv.FormatInt(asset.Size, 10))
+	c.Header("Cache-Control", "public, max-age=31536000, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, content); err != nil {
+		h.logger.Error("failed to stream avatar", map[string]interface{}{"file_id": asset.ID, "error": err.Error()})
+	}
+}
+
+func (h *AvatarHandler) handleError(c *gin.Context, reqCtx *auth.RequestContext, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAccountNotFound):
+		response.Error(c, http.StatusNotFound, "account not found", err)
+	case errors.Is(err, domain.ErrAvatarTooLarge):
+		response.Error(c, http.StatusRequestEntityTooLarge, err.Error(), err)
+	case errors.Is(err, domain.ErrAvatarInvalidImage):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{
+			"org_id":     reqCtx.OrganizationID,
+			"account_id": reqCtx.AccountID,
+			"error":      err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
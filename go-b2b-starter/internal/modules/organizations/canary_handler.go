@@ -0,0 +1,141 @@
+package organizations
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type CanaryHandler struct {
+	canaryService services.CanaryService
+	logger        logger.Logger
+}
+
+func NewCanaryHandler(canaryService services.CanaryService, logger logger.Logger) *CanaryHandler {
+	return &CanaryHandler{
+		canaryService: canaryService,
+		logger:        logger,
+	}
+}
+
+// ListCanaries godoc
+// @Summary List canary credentials
+// @Description Lists the organization's canary API keys and users with how often and when they were last used. API key secrets are never returned.
+// @Tags Organizations
+// @Produce json
+// @Success 200 {array} domain.CanaryCredential "Canary credentials"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/canaries [get]
+func (h *CanaryHandler) ListCanaries(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	canaries, err := h.canaryService.ListCanaries(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.logger.Error("failed to list canary credentials", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list canary credentials", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, canaries)
+}
+
+// CreateCanary godoc
+// @Summary Plant canary credential
+// @Description Creates a canary (honeytoken) to plant where a leak would expose it. An api_key canary returns a client ID and secret shaped like a real OAuth client, only in this response; a user canary watches an email that no member uses. Any use publishes a high-severity canary.triggered event and, with rotate_on_trigger, revokes the organization's OAuth clients.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param request body services.CreateCanaryRequest true "Canary kind, name and email"
+// @Success 201 {object} services.CreatedCanary "Canary with API key credentials"
+// @Failure 400 {object} map[string]string "Invalid kind, name or email"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 409 {object} map[string]string "Email belongs to a member or canary"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/canaries [post]
+func (h *CanaryHandler) CreateCanary(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.CreateCanaryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	canary, err := h.canaryService.CreateCanary(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrCanaryInvalidKind, domain.ErrCanaryNameRequired, domain.ErrCanaryEmailRequired:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrCanaryEmailInUse:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		default:
+			h.logger.Error("failed to create canary credential", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to create canary credential", err)
+		}
+		return
+	}
+
+	// The response may carry the canary's client secret
+	c.Header("Cache-Control", "no-store")
+	response.Success(c, http.StatusCreated, canary)
+}
+
+// DeleteCanary godoc
+// @Summary Delete canary credential
+// @Description Stops watching the canary. Uses after deletion fail like any unknown credential without raising an alert.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Canary ID"
+// @Success 204 "Canary deleted"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Canary not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/canaries/{id} [delete]
+func (h *CanaryHandler) DeleteCanary(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var canaryID int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &canaryID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid canary ID format", err)
+		return
+	}
+
+	if err := h.canaryService.DeleteCanary(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, canaryID); err != nil {
+		if err == domain.ErrCanaryNotFound {
+			response.Error(c, http.StatusNotFound, "canary credential not found", err)
+			return
+		}
+		h.logger.Error("failed to delete canary credential", map[string]interface{}{"org_id": reqCtx.OrganizationID, "canary_id": canaryID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to delete canary credential", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,12 +1,83 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
+
 	"go.uber.org/dig"
 
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	docEvents "github.com/moasq/go-b2b-starter/internal/modules/documents/domain/events"
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain/events"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
 )
 
 func Init(container *dig.Container) error {
 	module := organizations.NewModule(container)
-	return module.RegisterDependencies()
-}
\ No newline at end of file
+	if err := module.RegisterDependencies(); err != nil {
+		return err
+	}
+
+	// Record sign-ins, document uploads and settings changes in account activity feeds
+	if err := container.Invoke(func(
+		bus eventbus.EventBus,
+		service services.ActivityFeedService,
+	) error {
+		for _, eventType := range services.ActivityAuthEventTypes {
+			if err := bus.Subscribe(eventType, func(ctx context.Context, event eventbus.Event) error {
+				authEvent, ok := event.(*auth.AuthEvent)
+				if !ok {
+					return fmt.Errorf("unexpected event type: %T", event)
+				}
+				return service.RecordAuthEvent(ctx, authEvent)
+			}); err != nil {
+				return err
+			}
+		}
+
+		if err := bus.Subscribe(docEvents.DocumentUploadedEventType, func(ctx context.Context, event eventbus.Event) error {
+			docEvent, ok := event.(*docEvents.DocumentUploaded)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+			return service.Record(ctx, &domain.AccountActivityEvent{
+				EventID:        docEvent.EventID(),
+				OrganizationID: docEvent.OrganizationID,
+				AccountID:      docEvent.AccountID,
+				Type:           domain.ActivityDocumentUploaded,
+				Details: map[string]any{
+					"document_id": docEvent.DocumentID,
+					"title":       docEvent.Title,
+				},
+				OccurredAt: docEvent.Timestamp(),
+			})
+		}); err != nil {
+			return err
+		}
+
+		return bus.Subscribe(events.SettingsChangedEventType, func(ctx context.Context, event eventbus.Event) error {
+			settingsEvent, ok := event.(*events.SettingsChanged)
+			if !ok {
+				return fmt.Errorf("unexpected event type: %T", event)
+			}
+			return service.Record(ctx, &domain.AccountActivityEvent{
+				EventID:        settingsEvent.EventID(),
+				OrganizationID: settingsEvent.OrganizationID,
+				AccountID:      settingsEvent.AccountID,
+				Type:           domain.ActivitySettingsChanged,
+				Details: map[string]any{
+					"setting": settingsEvent.Setting,
+					"change":  settingsEvent.Change,
+				},
+				OccurredAt: settingsEvent.Timestamp(),
+			})
+		})
+	}); err != nil {
+		return fmt.Errorf("failed to wire account activity feed: %w", err)
+	}
+
+	return module.StartScheduler()
+}
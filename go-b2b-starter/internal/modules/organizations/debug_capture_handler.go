@@ -0,0 +1,215 @@
+package organizations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// DebugCaptureHandler serves debug captures of organizations' API traffic. It
+// is an operator endpoint behind the "operator" middleware, since
+// support staff record a customer's traffic without being a member.
+type DebugCaptureHandler struct {
+	captureService services.DebugCaptureService
+	logger         logger.Logger
+}
+
+func NewDebugCaptureHandler(captureService services.DebugCaptureService, logger logger.Logger) *DebugCaptureHandler {
+	return &DebugCaptureHandler{
+		captureService: captureService,
+		logger:         logger,
+	}
+}
+
+// StartCapture godoc
+// @Summary Start a debug capture
+// @Description Records the organization's API requests and responses for a limited time, redacting sensitive headers, query parameters and body fields. An organization has at most one capture recording at a time.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param request body services.StartDebugCaptureRequest true "Organization, reason and duration"
+// @Success 201 {object} domain.DebugCapture "Started capture"
+// @Failure 400 {object} map[string]string "Invalid request or duration"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Organization not found or endpoint disabled"
+// @Failure 409 {object} map[string]string "Organization already has a capture recording"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/debug-captures [post]
+func (h *DebugCaptureHandler) StartCapture(c *gin.Context) {
+	var req services.StartDebugCaptureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	capture, err := h.captureService.StartCapture(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, "failed to start debug capture", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, capture)
+}
+
+// ListCaptures godoc
+// @Summary List debug captures
+// @Description Returns debug captures newest first, optionally for one organization ID.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param organization_id query int false "Organization ID"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} domain.DebugCapture "Captures"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/debug-captures [get]
+func (h *DebugCaptureHandler) ListCaptures(c *gin.Context) {
+	var req services.ListDebugCapturesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	captures, err := h.captureService.ListCaptures(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, "failed to list debug captures", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, captures)
+}
+
+// GetCapture godoc
+// @Summary Get a debug capture
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param id path int true "Capture ID"
+// @Success 200 {object} domain.DebugCapture "Capture"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Capture not found or endpoint disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/debug-captures/{id} [get]
+func (h *DebugCaptureHandler) GetCapture(c *gin.Context) {
+	id, ok := h.captureID(c)
+	if !ok {
+		return
+	}
+
+	capture, err := h.captureService.GetCapture(c.Request.Context(), id)
+	if err != nil {
+		h.handleError(c, "failed to get debug capture", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, capture)
+}
+
+// StopCapture godoc
+// @Summary Stop a debug capture
+// @Description Stops recording before the capture expires. Records already stored are kept until their retention ends.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param id path int true "Capture ID"
+// @Param request body services.StopDebugCaptureRequest true "Operator stopping the capture"
+// @Success 200 {object} domain.DebugCapture "Stopped capture"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Capture not recording or endpoint disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/debug-captures/{id}/stop [post]
+func (h *DebugCaptureHandler) StopCapture(c *gin.Context) {
+	id, ok := h.captureID(c)
+	if !ok {
+		return
+	}
+
+	var req services.StopDebugCaptureRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	capture, err := h.captureService.StopCapture(c.Request.Context(), id, req.StoppedBy)
+	if err != nil {
+		h.handleError(c, "failed to stop debug capture", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, capture)
+}
+
+// ListRecords godoc
+// @Summary List debug capture records
+// @Description Returns a page of the redacted request/response pairs recorded by the capture, oldest first.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param id path int true "Capture ID"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} domain.DebugCaptureRecord "Records"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Capture not found or endpoint disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/debug-captures/{id}/records [get]
+func (h *DebugCaptureHandler) ListRecords(c *gin.Context) {
+	id, ok := h.captureID(c)
+	if !ok {
+		return
+	}
+
+	var req services.ListDebugCaptureRecordsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	records, err := h.captureService.ListRecords(c.Request.Context(), id, &req)
+	if err != nil {
+		h.handleError(c, "failed to list debug capture records", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, records)
+}
+
+// captureID returns the capture ID in the path. It writes the error response
+// and returns false when it is invalid.
+func (h *DebugCaptureHandler) captureID(c *gin.Context) (int32, bool) {
+	var id int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid capture ID format", err)
+		return 0, false
+	}
+	return id, true
+}
+
+func (h *DebugCaptureHandler) handleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrDebugCaptureNotFound), errors.Is(err, domain.ErrOrganizationNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrDebugCaptureActive):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	case errors.Is(err, domain.ErrDebugCaptureReasonRequired), errors.Is(err, domain.ErrDebugCaptureInvalidDuration):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
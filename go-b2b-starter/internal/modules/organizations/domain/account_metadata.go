@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// Limits on account metadata, so it stays small enough to load with every account
+const (
+	MaxAccountMetadataBytes     = 16 << 10
+	MaxAccountMetadataKeyLength = 64
+)
+
+// AccountMetadata holds application-defined attributes of an account, stored
+// as a JSON object. Values are decoded JSON: strings, json.Number, bools, nil,
+// []any and map[string]any. Use the typed accessors to read them.
+type AccountMetadata map[string]any
+
+// DecodeAccountMetadata parses a stored JSON object. Numbers are kept as
+// json.Number so large integers are not rounded.
+func DecodeAccountMetadata(data []byte) (AccountMetadata, error) {
+	metadata := AccountMetadata{}
+	if len(data) == 0 {
+		return metadata, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&metadata); err != nil {
+		return nil, fmt.Errorf("failed to decode account metadata: %w", err)
+	}
+	return metadata, nil
+}
+
+// String returns the value of key if it is a string
+func (m AccountMetadata) String(key string) (string, bool) {
+	value, ok := m[key].(string)
+	return value, ok
+}
+
+// Bool returns the value of key if it is a boolean
+func (m AccountMetadata) Bool(key string) (bool, bool) {
+	value, ok := m[key].(bool)
+	return value, ok
+}
+
+// Float64 returns the value of key if it is a number
+func (m AccountMetadata) Float64(key string) (float64, bool) {
+	switch value := m[key].(type) {
+	case json.Number:
+		f, err := value.Float64()
+		return f, err == nil
+	case float64:
+		return value, true
+	case int:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	}
+	return 0, false
+}
+
+// Int64 returns the value of key if it is a whole number that fits an int64
+func (m AccountMetadata) Int64(key string) (int64, bool) {
+	switch value := m[key].(type) {
+	case json.Number:
+		i, err := value.Int64()
+		return i, err == nil
+	case float64:
+		if value != math.Trunc(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(value), true
+	case int:
+		return int64(value), true
+	case int64:
+		return value, true
+	}
+	return 0, false
+}
+
+// Strings returns the value of key if it is an array of strings
+func (m AccountMetadata) Strings(key string) ([]string, bool) {
+	switch value := m[key].(type) {
+	case []string:
+		return value, true
+	case []any:
+		strs := make([]string, len(value))
+		for i, item := range value {
+			s, ok := item.(string)
+			if !ok {
+				return nil, false
+			}
+			strs[i] = s
+		}
+		return strs, true
+	}
+	return nil, false
+}
+
+// Decode unmarshals the value of key into v, for structured values. It
+// returns false if the key is not set.
+func (m AccountMetadata) Decode(key string, v any) (bool, error) {
+	value, ok := m[key]
+	if !ok {
+		return false, nil
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return true, fmt.Errorf("failed to encode metadata %q: %w", key, err)
+	}
+	if err := json.Unmarshal(encoded, v); err != nil {
+		return true, fmt.Errorf("failed to decode metadata %q: %w", key, err)
+	}
+	return true, nil
+}
+
+// Merge returns a copy of the metadata with the patch applied: top-level keys
+// of the patch replace existing values and keys set to nil are removed. This
+// is what AccountRepository.PatchMetadata stores.
+func (m AccountMetadata) Merge(patch AccountMetadata) AccountMetadata {
+	merged := make(AccountMetadata, len(m)+len(patch))
+	for key, value := range m {
+		merged[key] = value
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(merged, key)
+			continue
+		}
+		merged[key] = value
+	}
+	return merged
+}
+
+// ValidateKeys checks that every key is non-empty and at most MaxAccountMetadataKeyLength long
+func (m AccountMetadata) ValidateKeys() error {
+	for key := range m {
+		if key == "" || len(key) > MaxAccountMetadataKeyLength {
+			return fmt.Errorf("%w: %q", ErrAccountMetadataInvalidKey, key)
+		}
+	}
+	return nil
+}
+
+// ValidateSize checks that the encoded metadata is at most MaxAccountMetadataBytes
+func (m AccountMetadata) ValidateSize() error {
+	encoded, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("failed to encode account metadata: %w", err)
+	}
+	if len(encoded) > MaxAccountMetadataBytes {
+		return ErrAccountMetadataTooLarge
+	}
+	return nil
+}
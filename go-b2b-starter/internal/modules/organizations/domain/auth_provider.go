@@ -52,6 +52,7 @@ type UpdateAuthMemberRequest struct {
 	OrganizationID string         `json:"organization_id"`
 	MemberID       string         `json:"member_id"`
 	Name           *string        `json:"name,omitempty"`
+	EmailAddress   *string        `json:"email_address,omitempty"`
 	Roles          []string       `json:"roles,omitempty"`
 	TrustedMeta    map[string]any `json:"trusted_metadata,omitempty"`
 	UntrustedMeta  map[string]any `json:"untrusted_metadata,omitempty"`
@@ -84,6 +85,13 @@ type SendMagicLinkRequest struct {
 	SignupRedirectURL string `json:"signup_redirect_url"`
 }
 
+// EmailOTPRequest represents the payload required to send or verify an email one-time passcode.
+type EmailOTPRequest struct {
+	OrganizationID string `json:"organization_id"`
+	Email          string `json:"email"`
+	Code           string `json:"code,omitempty"`
+}
+
 // Validate validates the CreateAuthMemberRequest.
 func (r *CreateAuthMemberRequest) Validate() error {
 	if r.OrganizationID == "" {
@@ -115,6 +123,20 @@ func (r *SendMagicLinkRequest) Validate() error {
 	return nil
 }
 
+// Validate ensures the EmailOTPRequest contains core identifiers.
+func (r *EmailOTPRequest) Validate() error {
+	if r.OrganizationID == "" {
+		return ErrAuthOrganizationIDRequired
+	}
+	if r.Email == "" {
+		return ErrAuthEmailRequired
+	}
+	if _, err := mail.ParseAddress(r.Email); err != nil {
+		return ErrAuthInvalidEmail
+	}
+	return nil
+}
+
 // Validate validates the UpdateAuthMemberRequest.
 func (r *UpdateAuthMemberRequest) Validate() error {
 	if r.OrganizationID == "" {
@@ -180,6 +202,14 @@ type AuthMemberRepository interface {
 	RemoveMembers(ctx context.Context, req *RemoveAuthMembersRequest) error
 	AssignRoles(ctx context.Context, req *AssignAuthRolesRequest) error
 	SendMagicLink(ctx context.Context, req *SendMagicLinkRequest) error
+	// SendEmailOTP emails a one-time passcode to an existing member
+	SendEmailOTP(ctx context.Context, req *EmailOTPRequest) error
+	// AuthenticateEmailOTP verifies an email passcode and returns the member it belongs to
+	AuthenticateEmailOTP(ctx context.Context, req *EmailOTPRequest) (*AuthMember, error)
+	// ResetMFA removes the member's enrolled MFA factors so they can enroll new ones
+	ResetMFA(ctx context.Context, organizationID, memberID string) error
+	// ResetPassword deletes the member's password and emails them a link to set a new one
+	ResetPassword(ctx context.Context, organizationID, memberID string) error
 }
 
 // AuthRoleRepository defines auth provider RBAC operations.
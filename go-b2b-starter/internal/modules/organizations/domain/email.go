@@ -0,0 +1,34 @@
+package domain
+
+import "strings"
+
+// EmailNormalizer puts account emails in the form they are stored and looked
+// up in, so addresses differing only by case or surrounding whitespace belong
+// to the same account.
+type EmailNormalizer struct {
+	// CanonicalizePlusAddresses drops a "+tag" suffix from the local part, so
+	// "jane+work@example.com" is stored as "jane@example.com". Mail is then
+	// sent to the canonical address, which only reaches the member on
+	// providers that support plus addressing.
+	CanonicalizePlusAddresses bool
+}
+
+// Normalize trims and lowercases email and, when enabled, removes its plus
+// tag. Addresses without an "@" are only trimmed and lowercased so
+// validation can still reject them.
+func (n EmailNormalizer) Normalize(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !n.CanonicalizePlusAddresses {
+		return email
+	}
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	local, domainPart := email[:at], email[at:]
+	if plus := strings.Index(local, "+"); plus > 0 {
+		local = local[:plus]
+	}
+	return local + domainPart
+}
@@ -1,6 +1,17 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Organization environments
+const (
+	EnvironmentProduction = "production"
+	EnvironmentStaging    = "staging"
+	EnvironmentSandbox    = "sandbox"
+)
 
 // Organization represents an organization (tenant) in the system
 type Organization struct {
@@ -13,11 +24,35 @@ type Organization struct {
 	StytchConnectionName string    `json:"stytch_connection_name"`
 	CreatedAt            time.Time `json:"created_at"`
 	UpdatedAt            time.Time `json:"updated_at"`
+
+	// Environment is production, staging or sandbox. Staging and sandbox
+	// organizations belong to a production parent, are left out of analytics
+	// and billing, and are purged at ExpiresAt.
+	Environment          string     `json:"environment"`
+	ParentOrganizationID *int32     `json:"parent_organization_id,omitempty"`
+	ExpiresAt            *time.Time `json:"expires_at,omitempty"`
 }
 
+// IsProduction reports whether the organization holds real customer data
+func (o *Organization) IsProduction() bool {
+	return o.Environment == "" || o.Environment == EnvironmentProduction
+}
+
+// Account kinds. Only human accounts take a seat; AccountRepository.Create
+// stores AccountKindHuman when Account.Kind is empty.
+const (
+	AccountKindHuman          = "human"
+	AccountKindServiceAccount = "service_account"
+	AccountKindOAuthClient    = "oauth_client"
+	AccountKindGuest          = "guest"
+)
+
 // Account represents a user account within an organization
 type Account struct {
-	ID                  int32      `json:"id"`
+	ID int32 `json:"id"`
+	// PublicID is the UUIDv7 identifier to hand out to clients and other
+	// systems; unlike ID it reveals nothing about how many accounts exist
+	PublicID            uuid.UUID  `json:"public_id"`
 	OrganizationID      int32      `json:"organization_id"`
 	Email               string     `json:"email"`
 	FullName            string     `json:"full_name"`
@@ -28,8 +63,489 @@ type Account struct {
 	Role                string     `json:"role"`
 	Status              string     `json:"status"`
 	LastLoginAt         *time.Time `json:"last_login_at,omitempty"`
+	Kind                string     `json:"kind"`
 	CreatedAt           time.Time  `json:"created_at"`
 	UpdatedAt           time.Time  `json:"updated_at"`
+
+	// Metadata holds application-defined attributes. It is read-only here:
+	// Update leaves it alone and AccountRepository.PatchMetadata changes it.
+	Metadata AccountMetadata `json:"metadata"`
+
+	// AvatarURL is the profile picture clients display. Like AvatarFileID, it
+	// is changed by AccountRepository.SetAvatar only.
+	AvatarURL    string `json:"avatar_url,omitempty"`
+	AvatarFileID *int32 `json:"avatar_file_id,omitempty"`
+}
+
+// AccountAvatar is an uploaded avatar: the stored file, the random key it is
+// served under and the URL clients display
+type AccountAvatar struct {
+	FileID int32
+	Key    string
+	URL    string
+}
+
+// IPAllowlistEntry is a network allowed to access the API on behalf of an organization
+type IPAllowlistEntry struct {
+	ID                 int32     `json:"id"`
+	OrganizationID     int32     `json:"organization_id"`
+	CIDR               string    `json:"cidr"`
+	Description        string    `json:"description"`
+	CreatedByAccountID *int32    `json:"created_by_account_id,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// AuthPolicy is an organization's own session requirements for its members,
+// enforced by the auth middleware on top of the auth provider's settings
+type AuthPolicy struct {
+	OrganizationID       int32     `json:"organization_id"`
+	MFARequired          bool      `json:"mfa_required"`
+	SessionMaxAgeSeconds int32     `json:"session_max_age_seconds"`
+	AllowedAuthMethods   []string  `json:"allowed_auth_methods"`
+	UpdatedByAccountID   *int32    `json:"updated_by_account_id,omitempty"`
+	CreatedAt            time.Time `json:"created_at,omitempty"`
+	UpdatedAt            time.Time `json:"updated_at,omitempty"`
+}
+
+// Access elevation statuses
+const (
+	ElevationStatusPending  = "pending"
+	ElevationStatusApproved = "approved"
+	ElevationStatusDenied   = "denied"
+	ElevationStatusRevoked  = "revoked"
+)
+
+// AccessElevation is a request for, or grant of, a time-boxed elevated role
+type AccessElevation struct {
+	ID                 int32      `json:"id"`
+	OrganizationID     int32      `json:"organization_id"`
+	AccountID          int32      `json:"account_id"`
+	RequestedRole      string     `json:"requested_role"`
+	Reason             string     `json:"reason"`
+	DurationMinutes    int32      `json:"duration_minutes"`
+	Status             string     `json:"status"`
+	DecidedByAccountID *int32     `json:"decided_by_account_id,omitempty"`
+	DecisionNote       string     `json:"decision_note,omitempty"`
+	DecidedAt          *time.Time `json:"decided_at,omitempty"`
+	ExpiresAt          *time.Time `json:"expires_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// IsActive checks if the elevation is approved and not yet expired
+func (e *AccessElevation) IsActive(now time.Time) bool {
+	return e.Status == ElevationStatusApproved && e.ExpiresAt != nil && now.Before(*e.ExpiresAt)
+}
+
+// MFA recovery statuses
+const (
+	MFARecoveryStatusPending   = "pending"
+	MFARecoveryStatusApproved  = "approved"
+	MFARecoveryStatusDenied    = "denied"
+	MFARecoveryStatusCancelled = "cancelled"
+	MFARecoveryStatusCompleted = "completed"
+)
+
+// MFARecoveryRequest is a delayed MFA reset for a member who lost their authenticator
+type MFARecoveryRequest struct {
+	ID                 int32      `json:"id"`
+	OrganizationID     int32      `json:"organization_id"`
+	AccountID          int32      `json:"account_id"`
+	Status             string     `json:"status"`
+	RequiresApproval   bool       `json:"requires_approval"`
+	TokenHash          string     `json:"-"`
+	DecidedByAccountID *int32     `json:"decided_by_account_id,omitempty"`
+	DecidedAt          *time.Time `json:"decided_at,omitempty"`
+	EligibleAt         time.Time  `json:"eligible_at"`
+	ExpiresAt          time.Time  `json:"expires_at"`
+	CompletedAt        *time.Time `json:"completed_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// ReadyStatus returns the status the request must have before it can be completed
+func (r *MFARecoveryRequest) ReadyStatus() string {
+	if r.RequiresApproval {
+		return MFARecoveryStatusApproved
+	}
+	return MFARecoveryStatusPending
+}
+
+// IsOpen checks if the request can still be approved, cancelled or completed
+func (r *MFARecoveryRequest) IsOpen() bool {
+	return r.Status == MFARecoveryStatusPending || r.Status == MFARecoveryStatusApproved
+}
+
+// Email change statuses
+const (
+	EmailChangeStatusPending   = "pending"
+	EmailChangeStatusConfirmed = "confirmed"
+	EmailChangeStatusCancelled = "cancelled"
+	EmailChangeStatusReverted  = "reverted"
+)
+
+// EmailChangeRequest is a member's request to move their account to a new email address.
+// The old address stays active until the new one is confirmed, and can roll the
+// change back until RevertibleUntil.
+type EmailChangeRequest struct {
+	ID               int32      `json:"id"`
+	OrganizationID   int32      `json:"organization_id"`
+	AccountID        int32      `json:"account_id"`
+	OldEmail         string     `json:"old_email"`
+	NewEmail         string     `json:"new_email"`
+	Status           string     `json:"status"`
+	ConfirmTokenHash string     `json:"-"`
+	RevertTokenHash  string     `json:"-"`
+	ExpiresAt        time.Time  `json:"expires_at"`
+	ConfirmedAt      *time.Time `json:"confirmed_at,omitempty"`
+	RevertibleUntil  *time.Time `json:"revertible_until,omitempty"`
+	RevertedAt       *time.Time `json:"reverted_at,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// IsRevertible checks if a confirmed change is still within its rollback window
+func (r *EmailChangeRequest) IsRevertible(now time.Time) bool {
+	return r.Status == EmailChangeStatusConfirmed && r.RevertibleUntil != nil && now.Before(*r.RevertibleUntil)
+}
+
+// Invite statuses. A pending invite past ExpiresAt is reported as expired.
+const (
+	InviteStatusPending  = "pending"
+	InviteStatusAccepted = "accepted"
+	InviteStatusRevoked  = "revoked"
+	InviteStatusExpired  = "expired"
+)
+
+// Invite lets someone register as a member of an organization with the given role.
+// When Email is set only that address can accept it; otherwise anyone holding
+// the token can, once.
+type Invite struct {
+	ID                 int32      `json:"id"`
+	OrganizationID     int32      `json:"organization_id"`
+	CreatedByAccountID *int32     `json:"created_by_account_id,omitempty"`
+	Email              string     `json:"email,omitempty"`
+	RoleSlug           string     `json:"role_slug"`
+	Status             string     `json:"status"`
+	TokenHash          string     `json:"-"`
+	ExpiresAt          time.Time  `json:"expires_at"`
+	AcceptedAt         *time.Time `json:"accepted_at,omitempty"`
+	AcceptedEmail      string     `json:"accepted_email,omitempty"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// IsExpired checks if a pending invite can no longer be accepted
+func (i *Invite) IsExpired(now time.Time) bool {
+	return i.Status == InviteStatusPending && !now.Before(i.ExpiresAt)
+}
+
+// OAuthClient is a machine-to-machine client using the client credentials grant.
+// It acts through its own service account and only gets the scopes it was granted.
+type OAuthClient struct {
+	ID                 int32      `json:"id"`
+	OrganizationID     int32      `json:"organization_id"`
+	AccountID          int32      `json:"account_id"`
+	ClientID           string     `json:"client_id"`
+	SecretHash         string     `json:"-"`
+	Name               string     `json:"name"`
+	Scopes             []string   `json:"scopes"`
+	CreatedByAccountID *int32     `json:"created_by_account_id,omitempty"`
+	LastUsedAt         *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// IsRevoked checks if the client can no longer obtain tokens
+func (c *OAuthClient) IsRevoked() bool {
+	return c.RevokedAt != nil
+}
+
+// Canary credential kinds
+const (
+	CanaryKindAPIKey = "api_key"
+	CanaryKindUser   = "user"
+)
+
+// CanaryCredential is a decoy API key or user planted where a leak would expose
+// it. It is never used legitimately, so any use is treated as a compromise.
+type CanaryCredential struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	Kind           string `json:"kind"`
+	// Identifier is the client ID of an api_key canary or the email of a user canary
+	Identifier         string     `json:"identifier"`
+	Name               string     `json:"name"`
+	RotateOnTrigger    bool       `json:"rotate_on_trigger"`
+	CreatedByAccountID *int32     `json:"created_by_account_id,omitempty"`
+	TriggerCount       int32      `json:"trigger_count"`
+	LastTriggeredAt    *time.Time `json:"last_triggered_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+// OIDCClient is a relying party that signs members of its organization in
+// with the OpenID Connect authorization code flow.
+type OIDCClient struct {
+	ID                 int32      `json:"id"`
+	OrganizationID     int32      `json:"organization_id"`
+	ClientID           string     `json:"client_id"`
+	SecretHash         string     `json:"-"`
+	Name               string     `json:"name"`
+	RedirectURIs       []string   `json:"redirect_uris"`
+	CreatedByAccountID *int32     `json:"created_by_account_id,omitempty"`
+	RevokedAt          *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// IsRevoked checks if members can no longer sign in to the client
+func (c *OIDCClient) IsRevoked() bool {
+	return c.RevokedAt != nil
+}
+
+// OIDCAuthorizationCode is a single-use code issued to a client's redirect URI
+// after a member approved the sign-in.
+type OIDCAuthorizationCode struct {
+	ID             int32
+	OIDCClientID   int32
+	OrganizationID int32
+	AccountID      int32
+	CodeHash       string
+	Subject        string
+	EmailVerified  bool
+	RedirectURI    string
+	Scopes         []string
+	Nonce          string
+	CodeChallenge  string
+	AuthTime       *time.Time
+	ExpiresAt      time.Time
+	UsedAt         *time.Time
+	CreatedAt      time.Time
+}
+
+// OIDCConsent records the scopes a member approved for a client
+type OIDCConsent struct {
+	ID             int32     `json:"id"`
+	OIDCClientID   int32     `json:"oidc_client_id"`
+	OrganizationID int32     `json:"organization_id"`
+	AccountID      int32     `json:"account_id"`
+	Scopes         []string  `json:"scopes"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// Membership is an active account of a user in an active organization
+type Membership struct {
+	OrganizationID int32  `json:"organization_id"`
+	Slug           string `json:"slug"`
+	Name           string `json:"name"`
+	Environment    string `json:"environment"`
+	StytchOrgID    string `json:"-"`
+	AccountID      int32  `json:"account_id"`
+	Role           string `json:"role"`
+
+	// Current is true for the organization the request was made in
+	Current bool `json:"current"`
+}
+
+// Dormancy statuses. Accounts and organizations without activity for
+// DORMANCY_AFTER become dormant, and active again on their next request.
+const (
+	StatusActive  = "active"
+	StatusDormant = "dormant"
+)
+
+// StatusSuspended is the status of an account an organization admin
+// suspended. Suspended accounts cannot sign in until reactivated.
+const StatusSuspended = "suspended"
+
+// MaxStatusChangeReasonLength bounds the reason recorded with a suspension
+const MaxStatusChangeReasonLength = 1000
+
+// AccountStatusChange records an admin suspending or reactivating an account
+type AccountStatusChange struct {
+	ID             int64  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	FromStatus     string `json:"from_status"`
+	ToStatus       string `json:"to_status"`
+	Reason         string `json:"reason,omitempty"`
+	// ActorAccountID is the admin who made the change; nil once their account is deleted
+	ActorAccountID *int32    `json:"actor_account_id,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// APIUsageWindow is how many API requests an account made in one quota window
+type APIUsageWindow struct {
+	OrganizationID int32     `json:"organization_id"`
+	AccountID      int32     `json:"account_id"`
+	WindowStart    time.Time `json:"window_start"`
+	WindowEnd      time.Time `json:"window_end"`
+	RequestCount   int64     `json:"request_count"`
+}
+
+// APIUsageReport is an account's API usage in the current quota window and
+// the windows before it
+type APIUsageReport struct {
+	WindowStart  time.Time `json:"window_start"`
+	ResetAt      time.Time `json:"reset_at"`
+	RequestCount int64     `json:"request_count"`
+	// Limit is the account's quota per window; 0 means requests are only counted
+	Limit int64 `json:"limit"`
+	// Remaining is nil when there is no quota
+	Remaining *int64            `json:"remaining,omitempty"`
+	History   []*APIUsageWindow `json:"history"`
+}
+
+// AccountFilter narrows an organization's account list. Empty fields match every account.
+type AccountFilter struct {
+	// Query is matched against the email and full name
+	Query string
+	// Email is matched against the email only
+	Email         string
+	Status        string
+	Role          string
+	EmailVerified *bool
+	// CreatedAfter and CreatedBefore bound the creation time; the zero time is no bound
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	// Tag matches accounts carrying the tag
+	Tag string
+	// Service matches the accounts of service accounts and OAuth clients when
+	// true and the accounts of people when false
+	Service *bool
+	Sort    AccountSort
+}
+
+// AccountSort orders an organization's account list. Ties are newest first.
+type AccountSort string
+
+const (
+	AccountSortCreatedDesc   AccountSort = "created_desc"
+	AccountSortCreatedAsc    AccountSort = "created_asc"
+	AccountSortEmailAsc      AccountSort = "email_asc"
+	AccountSortEmailDesc     AccountSort = "email_desc"
+	AccountSortNameAsc       AccountSort = "name_asc"
+	AccountSortLastLoginDesc AccountSort = "last_login_desc"
+)
+
+// DormantAccount is an account that was just moved to the dormant status
+type DormantAccount struct {
+	AccountID      int32     `json:"account_id"`
+	OrganizationID int32     `json:"organization_id"`
+	Email          string    `json:"email"`
+	FullName       string    `json:"full_name"`
+	LastActiveAt   time.Time `json:"last_active_at"`
+}
+
+// DormantOrganization is an organization that was just moved to the dormant status
+type DormantOrganization struct {
+	OrganizationID int32     `json:"organization_id"`
+	Slug           string    `json:"slug"`
+	Name           string    `json:"name"`
+	LastActiveAt   time.Time `json:"last_active_at"`
+}
+
+// ActivityType is a kind of activity recorded in an account's activity feed
+type ActivityType string
+
+const (
+	ActivityLogin            ActivityType = "login"
+	ActivityLogout           ActivityType = "logout"
+	ActivityPasswordChanged  ActivityType = "password_changed"
+	ActivityDocumentUploaded ActivityType = "document_uploaded"
+	ActivitySettingsChanged  ActivityType = "settings_changed"
+)
+
+// ActivityTypes lists every activity type
+var ActivityTypes = []ActivityType{
+	ActivityLogin,
+	ActivityLogout,
+	ActivityPasswordChanged,
+	ActivityDocumentUploaded,
+	ActivitySettingsChanged,
+}
+
+// AccountActivityEvent is one entry of an account's activity feed, recorded
+// from an event published on the event bus
+type AccountActivityEvent struct {
+	ID             int64        `json:"id"`
+	EventID        string       `json:"event_id"`
+	OrganizationID int32        `json:"organization_id"`
+	AccountID      int32        `json:"account_id"`
+	Type           ActivityType `json:"type"`
+	// Details say what the activity was about, e.g. the document or setting
+	Details    map[string]any `json:"details"`
+	OccurredAt time.Time      `json:"occurred_at"`
+}
+
+// ActivityTimestamp is the latest time and number of occurrences of one kind
+// of activity of an account. Unlike the feed, it is never trimmed.
+type ActivityTimestamp struct {
+	Type           ActivityType `json:"type"`
+	LastOccurredAt time.Time    `json:"last_occurred_at"`
+	Occurrences    int32        `json:"occurrences"`
+}
+
+// AccountActivity summarizes what an account did recently, for support and debugging
+type AccountActivity struct {
+	AccountID int32 `json:"account_id"`
+	// LastSeenAt is the last authenticated request, accurate to DORMANCY_ACTIVITY_INTERVAL
+	LastSeenAt  *time.Time              `json:"last_seen_at"`
+	LastLoginAt *time.Time              `json:"last_login_at"`
+	Timestamps  []*ActivityTimestamp    `json:"timestamps"`
+	Recent      []*AccountActivityEvent `json:"recent"`
+}
+
+// AccountActivityFilter narrows an account's activity feed. An empty Type matches every activity.
+type AccountActivityFilter struct {
+	Type  ActivityType
+	Limit int32
+}
+
+// DebugCapture is a time-boxed recording of an organization's API traffic,
+// started by an operator to reproduce an issue a customer reports.
+type DebugCapture struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	Reason         string `json:"reason"`
+	StartedBy      string `json:"started_by"`
+	// RedactFields are redacted in addition to the configured field names
+	RedactFields []string   `json:"redact_fields"`
+	MaxRecords   int32      `json:"max_records"`
+	RecordCount  int32      `json:"record_count"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	StoppedAt    *time.Time `json:"stopped_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// IsRecording reports whether the capture still records requests at now.
+func (c *DebugCapture) IsRecording(now time.Time) bool {
+	return c.StoppedAt == nil && now.Before(c.ExpiresAt)
+}
+
+// DebugCaptureRecord is a request/response pair recorded by a debug capture.
+// Sensitive headers, query parameters and body fields are redacted.
+type DebugCaptureRecord struct {
+	ID              int64               `json:"id"`
+	CaptureID       int32               `json:"capture_id"`
+	OrganizationID  int32               `json:"organization_id"`
+	AccountID       *int32              `json:"account_id,omitempty"`
+	Method          string              `json:"method"`
+	Path            string              `json:"path"`
+	Route           string              `json:"route"`
+	Query           string              `json:"query"`
+	ClientIP        string              `json:"client_ip"`
+	RequestHeaders  map[string][]string `json:"request_headers"`
+	RequestBody     string              `json:"request_body"`
+	Status          int32               `json:"status"`
+	DurationMs      int32               `json:"duration_ms"`
+	ResponseHeaders map[string][]string `json:"response_headers"`
+	ResponseBody    string              `json:"response_body"`
+	CreatedAt       time.Time           `json:"created_at"`
+	ExpiresAt       time.Time           `json:"expires_at"`
 }
 
 // OrganizationContext provides context for operations within an organization
@@ -77,6 +593,17 @@ func (a *Account) Validate() error {
 	return nil
 }
 
+// IsActive reports whether the account can sign in. Dormant accounts are
+// active accounts that have not been used for a while.
+func (a *Account) IsActive() bool {
+	return a.Status == StatusActive || a.Status == StatusDormant
+}
+
+// IsSuspended reports whether an organization admin suspended the account.
+func (a *Account) IsSuspended() bool {
+	return a.Status == StatusSuspended
+}
+
 // IsOwner checks if the account has admin role (legacy function name, kept for compatibility)
 func (a *Account) IsOwner() bool {
 	return a.Role == "admin"
@@ -4,12 +4,12 @@ import "errors"
 
 // Organization errors
 var (
-	ErrOrganizationNotFound      = errors.New("organization not found")
-	ErrOrganizationNameRequired  = errors.New("organization name is required")
-	ErrOrganizationSlugRequired  = errors.New("organization slug is required")
-	ErrOrganizationSlugTooShort  = errors.New("organization slug must be at least 3 characters")
-	ErrOrganizationSlugTaken     = errors.New("organization slug is already taken")
-	ErrOrganizationInactive      = errors.New("organization is inactive")
+	ErrOrganizationNotFound     = errors.New("organization not found")
+	ErrOrganizationNameRequired = errors.New("organization name is required")
+	ErrOrganizationSlugRequired = errors.New("organization slug is required")
+	ErrOrganizationSlugTooShort = errors.New("organization slug must be at least 3 characters")
+	ErrOrganizationSlugTaken    = errors.New("organization slug is already taken")
+	ErrOrganizationInactive     = errors.New("organization is inactive")
 )
 
 // Account errors
@@ -18,11 +18,253 @@ var (
 	ErrAccountEmailRequired        = errors.New("account email is required")
 	ErrAccountFullNameRequired     = errors.New("account full name is required")
 	ErrAccountOrganizationRequired = errors.New("account organization is required")
-	ErrAccountEmailTaken           = errors.New("account email is already taken")
+	ErrUserAlreadyExists           = errors.New("user already exists in the organization")
 	ErrAccountInactive             = errors.New("account is inactive")
 	ErrAccountInsufficientRole     = errors.New("account does not have sufficient permissions")
 )
 
+// Activity feed errors
+var ErrActivityTypeInvalid = errors.New("unknown activity type")
+
+// IP allowlist errors
+var (
+	ErrIPAllowlistEntryNotFound = errors.New("ip allowlist entry not found")
+	ErrIPAllowlistEntryExists   = errors.New("ip allowlist entry already exists")
+	ErrIPAllowlistInvalidCIDR   = errors.New("invalid CIDR or IP address")
+)
+
+// Auth policy errors
+var (
+	ErrAuthPolicyNotFound          = errors.New("auth policy not found")
+	ErrAuthPolicyInvalidMethod     = errors.New("unsupported sign-in method")
+	ErrAuthPolicyInvalidSessionAge = errors.New("session max age is out of range")
+	ErrAuthPolicyLockout           = errors.New("your current session would not satisfy this policy")
+)
+
+// Access elevation errors
+var (
+	ErrElevationNotFound        = errors.New("access elevation not found")
+	ErrElevationNotPending      = errors.New("access elevation is not pending")
+	ErrElevationNotActive       = errors.New("access elevation is not active")
+	ErrElevationInvalidRole     = errors.New("invalid elevation role")
+	ErrElevationReasonRequired  = errors.New("elevation reason is required")
+	ErrElevationInvalidDuration = errors.New("invalid elevation duration")
+	ErrElevationSelfApproval    = errors.New("cannot decide your own elevation request")
+)
+
+// MFA recovery errors
+var (
+	ErrMFARecoveryNotFound         = errors.New("mfa recovery request not found")
+	ErrMFARecoveryInProgress       = errors.New("an mfa recovery request is already in progress")
+	ErrMFARecoveryNotOpen          = errors.New("mfa recovery request is no longer open")
+	ErrMFARecoveryAwaitingApproval = errors.New("mfa recovery request is awaiting admin approval")
+	ErrMFARecoveryNotYetEligible   = errors.New("mfa recovery request is still in its waiting period")
+	ErrMFARecoveryExpired          = errors.New("mfa recovery request has expired")
+	ErrMFARecoveryInvalidCode      = errors.New("invalid or expired verification code")
+	ErrMFARecoverySelfApproval     = errors.New("cannot decide your own mfa recovery request")
+)
+
+// Email change errors
+var (
+	ErrEmailChangeNotFound      = errors.New("email change request not found")
+	ErrEmailChangeNotPending    = errors.New("email change request is no longer pending")
+	ErrEmailChangeExpired       = errors.New("email change request has expired")
+	ErrEmailChangeSameAddress   = errors.New("new email must differ from the current email")
+	ErrEmailChangeNotRevertible = errors.New("email change can no longer be reverted")
+)
+
+// Invite errors
+var (
+	ErrInviteNotFound         = errors.New("invite not found")
+	ErrInviteNotPending       = errors.New("invite has already been used or revoked")
+	ErrInviteExpired          = errors.New("invite has expired")
+	ErrInviteEmailMismatch    = errors.New("invite was issued to a different email address")
+	ErrInviteRoleInvalid      = errors.New("invalid invite role")
+	ErrInviteInvalidTTL       = errors.New("invalid invite expiry")
+	ErrRegistrationInviteOnly = errors.New("registration requires an invite")
+)
+
+// Organization switch errors
+var (
+	ErrSwitchNotAllowed            = errors.New("only signed-in members can switch organizations")
+	ErrSwitchNotMember             = errors.New("you are not an active member of this organization")
+	ErrSwitchOrganizationNotLinked = errors.New("organization is not linked to the auth provider")
+)
+
+// Staging organization errors
+var (
+	ErrStagingOrganizationsDisabled = errors.New("staging organizations are disabled")
+	ErrStagingParentNotProduction   = errors.New("staging organizations can only be created from a production organization")
+	ErrStagingLimitReached          = errors.New("staging organization limit reached")
+	ErrStagingInvalidEnvironment    = errors.New("environment must be staging or sandbox")
+)
+
+// Guest session errors
+var (
+	ErrGuestSessionsDisabled = errors.New("guest sessions are disabled")
+	ErrGuestDeviceRequired   = errors.New("device ID is required")
+	ErrGuestSessionInvalid   = errors.New("invalid or expired guest session")
+	ErrGuestSessionClaimed   = errors.New("guest session has already been claimed")
+)
+
+// Impersonation errors
+var (
+	ErrImpersonationDisabled         = errors.New("impersonation is disabled")
+	ErrImpersonationSelf             = errors.New("cannot impersonate yourself")
+	ErrImpersonationNested           = errors.New("cannot start an impersonation while impersonating")
+	ErrImpersonationTargetAdmin      = errors.New("org admins cannot be impersonated")
+	ErrImpersonationTargetPrivileged = errors.New("cannot impersonate a member with permissions you do not have")
+	ErrImpersonationInvalidTarget    = errors.New("only active members with a login can be impersonated")
+	ErrImpersonationReasonRequired   = errors.New("impersonation reason is required")
+	ErrImpersonationInvalidDuration  = errors.New("invalid impersonation duration")
+	ErrImpersonationNotActive        = errors.New("request is not made with an impersonation token")
+)
+
+// OAuth client errors
+var (
+	ErrOAuthClientsDisabled     = errors.New("oauth clients are disabled")
+	ErrOAuthClientNotFound      = errors.New("oauth client not found")
+	ErrOAuthClientNameRequired  = errors.New("client name is required")
+	ErrOAuthClientScopeRequired = errors.New("at least one scope is required")
+	ErrOAuthInvalidScope        = errors.New("invalid scope")
+	ErrOAuthInvalidTarget       = errors.New("invalid audience")
+	ErrOAuthInvalidClient       = errors.New("invalid client credentials")
+)
+
+// Canary credential errors
+var (
+	ErrCanaryNotFound      = errors.New("canary credential not found")
+	ErrCanaryInvalidKind   = errors.New("canary kind must be api_key or user")
+	ErrCanaryNameRequired  = errors.New("canary name is required")
+	ErrCanaryEmailRequired = errors.New("a valid email is required for user canaries")
+	ErrCanaryEmailInUse    = errors.New("email already belongs to a member or canary")
+)
+
+// OpenID Connect provider errors
+var (
+	ErrOIDCProviderDisabled         = errors.New("openid connect provider is disabled")
+	ErrOIDCClientNotFound           = errors.New("openid connect client not found")
+	ErrOIDCClientNameRequired       = errors.New("client name is required")
+	ErrOIDCRedirectURIRequired      = errors.New("at least one redirect uri is required")
+	ErrOIDCInvalidRedirectURI       = errors.New("invalid redirect uri")
+	ErrOIDCUnsupportedResponseType  = errors.New("only the code response type is supported")
+	ErrOIDCInvalidScope             = errors.New("invalid scope")
+	ErrOIDCInvalidCodeChallenge     = errors.New("invalid code challenge")
+	ErrOIDCInvalidClient            = errors.New("invalid client credentials")
+	ErrOIDCInvalidGrant             = errors.New("invalid authorization code")
+	ErrOIDCInvalidToken             = errors.New("invalid access token")
+	ErrOIDCInteractiveLoginRequired = errors.New("sign in as a member to continue")
+)
+
+// Offboarding errors
+var (
+	ErrOffboardingSelf           = errors.New("cannot offboard yourself")
+	ErrOffboardingActionRequired = errors.New("either transfer_to_account_id or delete_data is required")
+	ErrOffboardingInvalidTarget  = errors.New("data can only be transferred to another active member of the organization")
+)
+
+// User management errors
+var (
+	ErrUserManagementSelf   = errors.New("cannot suspend, reset or delete your own account")
+	ErrUserNotActive        = errors.New("only active accounts can be suspended")
+	ErrUserNotSuspended     = errors.New("account is not suspended")
+	ErrUserNoAuthMember     = errors.New("account is not linked to an auth provider member")
+	ErrUserCreatedRange     = errors.New("created_after must be before created_before")
+	ErrUserSuspensionReason = errors.New("a suspension reason is required")
+	ErrUserReasonTooLong    = errors.New("reason must be at most 1000 characters")
+	ErrAccountStatusChanged = errors.New("account status was changed by another request")
+)
+
+// Avatar errors
+var (
+	ErrAvatarNotFound     = errors.New("avatar not found")
+	ErrAvatarTooLarge     = errors.New("avatar exceeds AVATAR_MAX_UPLOAD_BYTES")
+	ErrAvatarInvalidImage = errors.New("avatar must be a JPEG or PNG image within AVATAR_MAX_PIXELS")
+)
+
+// Account metadata errors
+var (
+	ErrAccountMetadataInvalidKey = errors.New("metadata keys must be 1 to 64 characters")
+	ErrAccountMetadataTooLarge   = errors.New("metadata must be at most 16 KiB")
+)
+
+// Debug capture errors
+var (
+	ErrDebugCaptureNotFound        = errors.New("debug capture not found")
+	ErrDebugCaptureActive          = errors.New("organization already has a debug capture recording")
+	ErrDebugCaptureReasonRequired  = errors.New("a reason is required to start a debug capture")
+	ErrDebugCaptureInvalidDuration = errors.New("debug capture duration exceeds the maximum")
+)
+
+// Notification errors
+var (
+	ErrNotificationNotFound         = errors.New("notification not found")
+	ErrUnknownNotificationType      = errors.New("unknown notification type")
+	ErrNotificationChannel          = errors.New("notification type is not sent on this channel")
+	ErrNotificationRequired         = errors.New("notification type cannot be turned off")
+	ErrNotificationUnsubscribeToken = errors.New("unsubscribe link is invalid or expired")
+	ErrNotificationUnsubscribeOff   = errors.New("unsubscribe links are not enabled")
+)
+
+// Tag errors
+var (
+	ErrTagNotFound        = errors.New("tag not found")
+	ErrTagInvalidName     = errors.New("tag names are 1-50 lowercase letters, digits, hyphens or underscores, starting with a letter or digit")
+	ErrTagLimit           = errors.New("organization has reached its tag limit")
+	ErrAccountTagNotFound = errors.New("user does not have this tag")
+)
+
+// Identity linking errors
+var (
+	ErrIdentityProviderUnknown  = errors.New("identity provider must be google or github")
+	ErrIdentityProviderDisabled = errors.New("identity provider is not enabled")
+	ErrIdentityInvalidState     = errors.New("identity link state is invalid or expired")
+	ErrIdentityExchangeFailed   = errors.New("identity provider rejected the authorization code")
+	ErrIdentityAlreadyLinked    = errors.New("account already has an identity linked at this provider")
+	ErrIdentityLinkedElsewhere  = errors.New("identity is already linked to another account")
+	ErrIdentityNotLinked        = errors.New("identity is not linked to an account")
+)
+
+// Secondary email errors
+var (
+	ErrSecondaryEmailNotFound     = errors.New("secondary email not found")
+	ErrSecondaryEmailExists       = errors.New("address is already one of your emails")
+	ErrSecondaryEmailLimit        = errors.New("account has reached its secondary email limit")
+	ErrSecondaryEmailNotVerified  = errors.New("secondary email is not verified")
+	ErrSecondaryEmailVerified     = errors.New("secondary email is already verified")
+	ErrSecondaryEmailInvalidToken = errors.New("invalid or expired verification link")
+)
+
+// Role assignment errors
+var (
+	ErrRoleAssignmentNotFound     = errors.New("role assignment not found")
+	ErrRoleAssignmentExists       = errors.New("role is already assigned")
+	ErrRoleAssignmentInvalidRole  = errors.New("unknown role")
+	ErrRoleAssignmentNotPermitted = errors.New("cannot assign a role with permissions you do not have")
+	ErrRoleAssignmentLastAdmin    = errors.New("an organization must keep at least one admin")
+)
+
+// Service account errors
+var (
+	ErrServiceAccountsDisabled        = errors.New("service accounts are disabled")
+	ErrServiceAccountNotFound         = errors.New("service account not found")
+	ErrServiceAccountNameRequired     = errors.New("service account name is required")
+	ErrServiceAccountNameTaken        = errors.New("organization already has a service account with this name")
+	ErrServiceAccountInvalidRole      = errors.New("unknown role")
+	ErrServiceAccountRoleNotPermitted = errors.New("service accounts cannot have roles that manage the organization or that you do not have")
+	ErrServiceAccountInvalidOwner     = errors.New("owner must be a member of the organization")
+	ErrServiceAccountDisabled         = errors.New("service account is disabled")
+	ErrServiceAccountKeyNotFound      = errors.New("api key not found")
+	ErrServiceAccountKeyNameRequired  = errors.New("api key name is required")
+	ErrServiceAccountKeyLimit         = errors.New("service account has reached its api key limit")
+	ErrServiceAccountKeyInvalidExpiry = errors.New("api key expiry exceeds the maximum lifetime")
+)
+
+// User stats errors
+var (
+	ErrUserStatsInvalidDays = errors.New("days is out of range")
+)
+
 // Permission errors
 var (
 	ErrPermissionDenied = errors.New("permission denied")
@@ -115,4 +357,4 @@ func NewAccountError(errorType, message string, accountID, orgID *int32, cause e
 		OrganizationID: orgID,
 		Cause:          cause,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,52 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+)
+
+const CanaryTriggeredEventType = "canary.triggered"
+
+// CanarySeverityHigh is the severity of every canary use: the credential is
+// never used legitimately, so its use means it leaked.
+const CanarySeverityHigh = "high"
+
+// CanaryTriggered is published each time a canary credential is used.
+// Subscribers page the organization's security contacts or on-call.
+type CanaryTriggered struct {
+	eventbus.BaseEvent
+	OrganizationID int32  `json:"organization_id"`
+	CanaryID       int32  `json:"canary_id"`
+	Kind           string `json:"kind"`
+	Name           string `json:"name"`
+	Identifier     string `json:"identifier"`
+	Severity       string `json:"severity"`
+	TriggerCount   int32  `json:"trigger_count"`
+	ClientIP       string `json:"client_ip"`
+	UserAgent      string `json:"user_agent"`
+	Method         string `json:"method"`
+	Path           string `json:"path"`
+	// RevokedClientIDs lists the OAuth clients revoked because the canary
+	// rotates credentials on trigger
+	RevokedClientIDs []string `json:"revoked_client_ids,omitempty"`
+}
+
+func NewCanaryTriggered(organizationID, canaryID int32, kind, name, identifier string, triggerCount int32) *CanaryTriggered {
+	return &CanaryTriggered{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      CanaryTriggeredEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		CanaryID:       canaryID,
+		Kind:           kind,
+		Name:           name,
+		Identifier:     identifier,
+		Severity:       CanarySeverityHigh,
+		TriggerCount:   triggerCount,
+	}
+}
@@ -0,0 +1,113 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+)
+
+const (
+	AccountDormantEventType          = "account.dormant"
+	AccountReactivatedEventType      = "account.reactivated"
+	OrganizationDormantEventType     = "organization.dormant"
+	OrganizationReactivatedEventType = "organization.reactivated"
+)
+
+// AccountDormant is published when an account without activity for
+// DORMANCY_AFTER becomes dormant. Subscribers start win-back email sequences.
+type AccountDormant struct {
+	eventbus.BaseEvent
+	OrganizationID int32     `json:"organization_id"`
+	AccountID      int32     `json:"account_id"`
+	Email          string    `json:"email"`
+	FullName       string    `json:"full_name"`
+	LastActiveAt   time.Time `json:"last_active_at"`
+}
+
+func NewAccountDormant(organizationID, accountID int32, email, fullName string, lastActiveAt time.Time) *AccountDormant {
+	return &AccountDormant{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      AccountDormantEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+		Email:          email,
+		FullName:       fullName,
+		LastActiveAt:   lastActiveAt,
+	}
+}
+
+// AccountReactivated is published when a dormant account makes a request again.
+// Subscribers stop win-back sequences and can count the conversion.
+type AccountReactivated struct {
+	eventbus.BaseEvent
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+}
+
+func NewAccountReactivated(organizationID, accountID int32) *AccountReactivated {
+	return &AccountReactivated{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      AccountReactivatedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+	}
+}
+
+// OrganizationDormant is published when none of an organization's accounts was
+// active for DORMANCY_AFTER. Action is the configured DORMANCY_ORGANIZATION_ACTION:
+// billing subscribers downgrade the subscription on "downgrade" and storage
+// subscribers move the organization's data to cold storage on "archive".
+type OrganizationDormant struct {
+	eventbus.BaseEvent
+	OrganizationID int32     `json:"organization_id"`
+	Slug           string    `json:"slug"`
+	Name           string    `json:"name"`
+	LastActiveAt   time.Time `json:"last_active_at"`
+	Action         string    `json:"action"`
+}
+
+func NewOrganizationDormant(organizationID int32, slug, name string, lastActiveAt time.Time, action string) *OrganizationDormant {
+	return &OrganizationDormant{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      OrganizationDormantEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		Slug:           slug,
+		Name:           name,
+		LastActiveAt:   lastActiveAt,
+		Action:         action,
+	}
+}
+
+// OrganizationReactivated is published when a member of a dormant organization
+// makes a request again. Subscribers undo the dormancy action.
+type OrganizationReactivated struct {
+	eventbus.BaseEvent
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+}
+
+func NewOrganizationReactivated(organizationID, accountID int32) *OrganizationReactivated {
+	return &OrganizationReactivated{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      OrganizationReactivatedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+	}
+}
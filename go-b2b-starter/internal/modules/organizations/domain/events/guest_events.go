@@ -0,0 +1,37 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+)
+
+const (
+	GuestSessionClaimedEventType = "guest_session.claimed"
+)
+
+// GuestSessionClaimed is published when a registered account claims a guest session.
+// Modules that store per-account data move it from the guest account to the new one.
+type GuestSessionClaimed struct {
+	eventbus.BaseEvent
+	GuestOrganizationID int32 `json:"guest_organization_id"`
+	GuestAccountID      int32 `json:"guest_account_id"`
+	OrganizationID      int32 `json:"organization_id"`
+	AccountID           int32 `json:"account_id"`
+}
+
+func NewGuestSessionClaimed(guestOrganizationID, guestAccountID, organizationID, accountID int32) *GuestSessionClaimed {
+	return &GuestSessionClaimed{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      GuestSessionClaimedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		GuestOrganizationID: guestOrganizationID,
+		GuestAccountID:      guestAccountID,
+		OrganizationID:      organizationID,
+		AccountID:           accountID,
+	}
+}
@@ -0,0 +1,62 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+)
+
+const (
+	MFARecoveryRequestedEventType = "mfa_recovery.requested"
+	MFARecoveryCompletedEventType = "mfa_recovery.completed"
+)
+
+// MFARecoveryRequested is published when a member starts recovering a lost MFA device.
+// Subscribers can notify the member and organization admins.
+type MFARecoveryRequested struct {
+	eventbus.BaseEvent
+	RequestID        int32     `json:"request_id"`
+	OrganizationID   int32     `json:"organization_id"`
+	AccountID        int32     `json:"account_id"`
+	RequiresApproval bool      `json:"requires_approval"`
+	EligibleAt       time.Time `json:"eligible_at"`
+}
+
+func NewMFARecoveryRequested(requestID, organizationID, accountID int32, requiresApproval bool, eligibleAt time.Time) *MFARecoveryRequested {
+	return &MFARecoveryRequested{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      MFARecoveryRequestedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		RequestID:        requestID,
+		OrganizationID:   organizationID,
+		AccountID:        accountID,
+		RequiresApproval: requiresApproval,
+		EligibleAt:       eligibleAt,
+	}
+}
+
+// MFARecoveryCompleted is published when a member's MFA factors have been reset
+type MFARecoveryCompleted struct {
+	eventbus.BaseEvent
+	RequestID      int32 `json:"request_id"`
+	OrganizationID int32 `json:"organization_id"`
+	AccountID      int32 `json:"account_id"`
+}
+
+func NewMFARecoveryCompleted(requestID, organizationID, accountID int32) *MFARecoveryCompleted {
+	return &MFARecoveryCompleted{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      MFARecoveryCompletedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		RequestID:      requestID,
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+	}
+}
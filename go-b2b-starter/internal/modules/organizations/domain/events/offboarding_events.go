@@ -0,0 +1,71 @@
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+)
+
+const (
+	MemberOffboardingEventType = "member.offboarding"
+)
+
+// MemberOffboarding is published while a member is offboarded, before they are
+// removed from the auth provider. Modules that store per-account data move it
+// to TransferToAccountID, or delete it when TransferToAccountID is 0, and record
+// how many records they handled in Results for the offboarding report.
+type MemberOffboarding struct {
+	eventbus.BaseEvent
+	OrganizationID      int32               `json:"organization_id"`
+	AccountID           int32               `json:"account_id"`
+	TransferToAccountID int32               `json:"transfer_to_account_id,omitempty"`
+	Results             *OffboardingResults `json:"-"`
+}
+
+func NewMemberOffboarding(organizationID, accountID, transferToAccountID int32) *MemberOffboarding {
+	return &MemberOffboarding{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      MemberOffboardingEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID:      organizationID,
+		AccountID:           accountID,
+		TransferToAccountID: transferToAccountID,
+		Results:             &OffboardingResults{counts: make(map[string]int64)},
+	}
+}
+
+// DeleteData reports whether the member's data is deleted rather than transferred.
+func (e *MemberOffboarding) DeleteData() bool {
+	return e.TransferToAccountID == 0
+}
+
+// OffboardingResults collects per-resource record counts from event subscribers.
+// Subscribers run concurrently, so access is synchronized.
+type OffboardingResults struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+// Record adds the number of records of a resource that were transferred or deleted.
+func (r *OffboardingResults) Record(resource string, count int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[resource] += count
+}
+
+// Counts returns a copy of the recorded counts.
+func (r *OffboardingResults) Counts() map[string]int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counts := make(map[string]int64, len(r.counts))
+	for resource, count := range r.counts {
+		counts[resource] = count
+	}
+	return counts
+}
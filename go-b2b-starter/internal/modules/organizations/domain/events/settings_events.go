@@ -0,0 +1,43 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+)
+
+const (
+	SettingsChangedEventType = "settings.changed"
+)
+
+// Organization settings that publish SettingsChanged
+const (
+	SettingAuthPolicy  = "auth_policy"
+	SettingIPAllowlist = "ip_allowlist"
+)
+
+// SettingsChanged is published when a member changes an organization setting.
+// Change says what happened to it, e.g. "updated" or "entry_added".
+type SettingsChanged struct {
+	eventbus.BaseEvent
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	Setting        string `json:"setting"`
+	Change         string `json:"change"`
+}
+
+func NewSettingsChanged(organizationID, accountID int32, setting, change string) *SettingsChanged {
+	return &SettingsChanged{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      SettingsChangedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+		Setting:        setting,
+		Change:         change,
+	}
+}
@@ -0,0 +1,194 @@
+package events
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+)
+
+const (
+	UserRegisteredEventType  = "user.registered"
+	UserVerifiedEventType    = "user.verified"
+	UserSuspendedEventType   = "user.suspended"
+	UserReactivatedEventType = "user.reactivated"
+	UserDeletedEventType     = "user.deleted"
+	PasswordChangedEventType = "user.password_changed"
+)
+
+// Sources of a UserRegistered event
+const (
+	UserSourceSignup          = "signup"
+	UserSourceMemberAdded     = "member_added"
+	UserSourceOrganizationAPI = "organization_api"
+	UserSourceAccountAPI      = "account_api"
+)
+
+// UserRegistered is published when a member account is created: by signing
+// up with a new organization, by an admin adding the member, or through the
+// organization and account APIs, as recorded in Source.
+// Billing subscribers count seats and notification subscribers send welcome
+// emails. Service accounts, OAuth clients and guests are not users and do not
+// publish it.
+type UserRegistered struct {
+	eventbus.BaseEvent
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	Email          string `json:"email"`
+	FullName       string `json:"full_name"`
+	Role           string `json:"role"`
+	EmailVerified  bool   `json:"email_verified"`
+	Source         string `json:"source"`
+}
+
+func NewUserRegistered(organizationID, accountID int32, email, fullName, role string, emailVerified bool, source string) *UserRegistered {
+	return &UserRegistered{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      UserRegisteredEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+		Email:          email,
+		FullName:       fullName,
+		Role:           role,
+		EmailVerified:  emailVerified,
+		Source:         source,
+	}
+}
+
+// UserVerified is published when an account's email becomes verified after
+// it was registered. Subscribers unlock features held back for unverified users.
+type UserVerified struct {
+	eventbus.BaseEvent
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	Email          string `json:"email"`
+}
+
+func NewUserVerified(organizationID, accountID int32, email string) *UserVerified {
+	return &UserVerified{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      UserVerifiedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+		Email:          email,
+	}
+}
+
+// UserSuspended is published after an admin suspended an account and its
+// sessions were revoked. Subscribers stop background work for the user, such
+// as scheduled cognitive jobs, and billing can release the seat.
+type UserSuspended struct {
+	eventbus.BaseEvent
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	Email          string `json:"email"`
+	Reason         string `json:"reason"`
+	ActorAccountID int32  `json:"actor_account_id"`
+}
+
+func NewUserSuspended(organizationID, accountID int32, email, reason string, actorAccountID int32) *UserSuspended {
+	return &UserSuspended{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      UserSuspendedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+		Email:          email,
+		Reason:         reason,
+		ActorAccountID: actorAccountID,
+	}
+}
+
+// UserReactivated is published after an admin lifted a suspension. The
+// member signs in again to get a session, and billing counts the seat again.
+// Reason is empty when the admin gave none.
+type UserReactivated struct {
+	eventbus.BaseEvent
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	Email          string `json:"email"`
+	Reason         string `json:"reason,omitempty"`
+	ActorAccountID int32  `json:"actor_account_id"`
+}
+
+func NewUserReactivated(organizationID, accountID int32, email, reason string, actorAccountID int32) *UserReactivated {
+	return &UserReactivated{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      UserReactivatedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+		Email:          email,
+		Reason:         reason,
+		ActorAccountID: actorAccountID,
+	}
+}
+
+// UserDeleted is published after an account was deleted. The account no
+// longer exists, so subscribers must not load it; they clean up what they
+// stored for AccountID. ActorAccountID is 0 when the actor is unknown.
+type UserDeleted struct {
+	eventbus.BaseEvent
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	Email          string `json:"email"`
+	ActorAccountID int32  `json:"actor_account_id,omitempty"`
+}
+
+func NewUserDeleted(organizationID, accountID int32, email string, actorAccountID int32) *UserDeleted {
+	return &UserDeleted{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      UserDeletedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+		Email:          email,
+		ActorAccountID: actorAccountID,
+	}
+}
+
+// PasswordChanged is published when an admin forced a password reset and the
+// account's sessions were revoked. Notification subscribers tell the user.
+// Reset is true for admin resets; passwords the user changes on the auth
+// provider's pages are reported as auth.password_changed instead.
+type PasswordChanged struct {
+	eventbus.BaseEvent
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	Email          string `json:"email"`
+	ActorAccountID int32  `json:"actor_account_id"`
+	Reset          bool   `json:"reset"`
+}
+
+func NewPasswordChanged(organizationID, accountID int32, email string, actorAccountID int32, reset bool) *PasswordChanged {
+	return &PasswordChanged{
+		BaseEvent: eventbus.BaseEvent{
+			ID:        uuid.New().String(),
+			Name:      PasswordChangedEventType,
+			CreatedAt: time.Now(),
+			Meta:      make(map[string]interface{}),
+		},
+		OrganizationID: organizationID,
+		AccountID:      accountID,
+		Email:          email,
+		ActorAccountID: actorAccountID,
+		Reset:          reset,
+	}
+}
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// IdentityProvider is a social sign-in provider that identities can be linked at
+type IdentityProvider string
+
+const (
+	IdentityProviderGoogle IdentityProvider = "google"
+	IdentityProviderGitHub IdentityProvider = "github"
+)
+
+// ParseIdentityProvider returns the provider with the given name, ignoring case
+func ParseIdentityProvider(name string) (IdentityProvider, error) {
+	switch provider := IdentityProvider(strings.ToLower(strings.TrimSpace(name))); provider {
+	case IdentityProviderGoogle, IdentityProviderGitHub:
+		return provider, nil
+	default:
+		return "", fmt.Errorf("%w: %q", ErrIdentityProviderUnknown, name)
+	}
+}
+
+// LinkedIdentity is a Google or GitHub identity linked to an account, so a
+// member who registered with a password has their social accounts on record.
+// An account has at most one identity per provider, and an identity belongs
+// to at most one account per organization.
+type LinkedIdentity struct {
+	ID             int32            `json:"id"`
+	OrganizationID int32            `json:"organization_id"`
+	AccountID      int32            `json:"account_id"`
+	Provider       IdentityProvider `json:"provider"`
+	ProviderUserID string           `json:"provider_user_id"`
+	// Email is what the provider reported when the identity was linked; it
+	// may differ from the account email
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ProviderUser is the user an identity provider authenticated
+type ProviderUser struct {
+	ID    string
+	Email string
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// NotificationChannel is a way of delivering notifications to a member
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail NotificationChannel = "email"
+	NotificationChannelInApp NotificationChannel = "in_app"
+)
+
+// NotificationType is a kind of notification members choose whether to receive
+type NotificationType string
+
+const (
+	// NotificationAccountStatus tells a member an admin suspended or reactivated their account
+	NotificationAccountStatus NotificationType = "account_status"
+)
+
+// NotificationTypeInfo describes a notification type and the channels it is sent on
+type NotificationTypeInfo struct {
+	Type        NotificationType      `json:"type"`
+	Description string                `json:"description"`
+	Channels    []NotificationChannel `json:"channels"`
+	// Required types are always sent; members cannot turn them off
+	Required bool `json:"required"`
+}
+
+// NotificationTypes lists every notification type members can manage. A type
+// must be listed here before it can be sent.
+var NotificationTypes = []NotificationTypeInfo{
+	{
+		Type:        NotificationAccountStatus,
+		Description: "An administrator suspended or reactivated your account",
+		Channels:    []NotificationChannel{NotificationChannelEmail, NotificationChannelInApp},
+	},
+}
+
+// LookupNotificationType returns the description of a notification type
+func LookupNotificationType(notificationType NotificationType) (NotificationTypeInfo, bool) {
+	for _, info := range NotificationTypes {
+		if info.Type == notificationType {
+			return info, true
+		}
+	}
+	return NotificationTypeInfo{}, false
+}
+
+// HasChannel reports whether the type is sent on the channel
+func (i NotificationTypeInfo) HasChannel(channel NotificationChannel) bool {
+	return slices.Contains(i.Channels, channel)
+}
+
+// NotificationPreference is whether an account receives a notification type on a channel
+type NotificationPreference struct {
+	Type    NotificationType    `json:"type"`
+	Channel NotificationChannel `json:"channel"`
+	Enabled bool                `json:"enabled"`
+	// Required is set for types that cannot be turned off
+	Required bool `json:"required,omitempty"`
+}
+
+// Notification is a notification in a member's in-app inbox
+type Notification struct {
+	ID             int64            `json:"id"`
+	OrganizationID int32            `json:"organization_id"`
+	AccountID      int32            `json:"account_id"`
+	Type           NotificationType `json:"type"`
+	Title          string           `json:"title"`
+	Body           string           `json:"body,omitempty"`
+	// ReadAt is nil while the notification is unread
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
@@ -1,6 +1,11 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
 
 // OrganizationRepository defines the interface for organization data operations
 type OrganizationRepository interface {
@@ -13,22 +18,309 @@ type OrganizationRepository interface {
 	UpdateStytchInfo(ctx context.Context, id int32, stytchOrgID, stytchConnectionID, stytchConnectionName string) (*Organization, error)
 	Delete(ctx context.Context, id int32) error
 	List(ctx context.Context, limit, offset int32) ([]*Organization, error)
+	// ListStaging returns the staging and sandbox organizations of a production organization, newest first
+	ListStaging(ctx context.Context, parentID int32) ([]*Organization, error)
+	CountStaging(ctx context.Context, parentID int32) (int64, error)
 	GetStats(ctx context.Context, id int32) (*OrganizationStats, error)
 }
 
-// AccountRepository defines the interface for account data operations
+// AccountRepository defines the interface for account data operations.
+// Emails are normalized (see EmailNormalizer) before they are stored or
+// compared, so lookups do not depend on the case the caller used.
 type AccountRepository interface {
+	// Create returns ErrUserAlreadyExists if the organization already has an
+	// account with the same normalized email or auth provider member
 	Create(ctx context.Context, account *Account) (*Account, error)
+	// CreateWithinSeats is Create for a member that takes a seat. The seats
+	// are counted and the account inserted in one transaction holding the
+	// organization's seat allocation lock; it returns
+	// paywall.ErrSeatLimitReached when every purchased seat is in use.
+	CreateWithinSeats(ctx context.Context, account *Account) (*Account, error)
 	GetByID(ctx context.Context, orgID, accountID int32) (*Account, error)
 	GetByEmail(ctx context.Context, orgID int32, email string) (*Account, error)
+	// GetByPublicID returns ErrAccountNotFound if no account of the organization has the public ID
+	GetByPublicID(ctx context.Context, orgID int32, publicID uuid.UUID) (*Account, error)
 	ListByOrganization(ctx context.Context, orgID int32) ([]*Account, error)
+	// ListFiltered returns a page of the organization's accounts matching the filter, newest first
+	ListFiltered(ctx context.Context, orgID int32, filter AccountFilter, limit, offset int32) ([]*Account, error)
+	CountFiltered(ctx context.Context, orgID int32, filter AccountFilter) (int64, error)
 	Update(ctx context.Context, account *Account) (*Account, error)
+	// UpdateStytchInfo returns ErrUserAlreadyExists if another account is linked to the member
 	UpdateStytchInfo(ctx context.Context, orgID, accountID int32, stytchMemberID, stytchRoleID, stytchRoleSlug string, stytchEmailVerified bool) (*Account, error)
 	UpdateLastLogin(ctx context.Context, orgID, accountID int32) (*Account, error)
+	// UpdateEmail returns ErrUserAlreadyExists if another account already uses the address
+	UpdateEmail(ctx context.Context, orgID, accountID int32, email string) (*Account, error)
 	Delete(ctx context.Context, orgID, accountID int32) error
+	// GetMetadata returns ErrAccountNotFound if the account does not exist
+	GetMetadata(ctx context.Context, orgID, accountID int32) (AccountMetadata, error)
+	// PatchMetadata merges the patch into the stored metadata in one statement (see
+	// AccountMetadata.Merge) and returns the result
+	PatchMetadata(ctx context.Context, orgID, accountID int32, patch AccountMetadata) (AccountMetadata, error)
+	// SetAvatar replaces the account avatar, or removes it when avatar is nil,
+	// and returns the file of the previous avatar for the caller to delete
+	SetAvatar(ctx context.Context, orgID, accountID int32, avatar *AccountAvatar) (*int32, error)
+	// GetAvatarFileID returns the file of the avatar served under key, or ErrAvatarNotFound
+	GetAvatarFileID(ctx context.Context, key string) (int32, error)
 	GetOrganization(ctx context.Context, accountID int32) (*Organization, error)
 	CheckPermission(ctx context.Context, orgID, accountID int32) (*AccountPermission, error)
 	GetStats(ctx context.Context, accountID int32) (*AccountStats, error)
+	// ListMembershipsByEmail returns the active organizations a user has an active account in
+	ListMembershipsByEmail(ctx context.Context, email string) ([]*Membership, error)
+}
+
+// ActivityRepository records account activity, keeps each account's activity
+// feed and moves inactive accounts and organizations to the dormant status
+type ActivityRepository interface {
+	Record(ctx context.Context, orgID, accountID int32) error
+	// LastActiveAt returns the account's last recorded request, or nil if there is none
+	LastActiveAt(ctx context.Context, orgID, accountID int32) (*time.Time, error)
+	// RecordEvent adds an activity to the account's feed and deletes all but
+	// its newest keep activities. It returns false if the event was already recorded.
+	RecordEvent(ctx context.Context, event *AccountActivityEvent, keep int32) (bool, error)
+	// ListEvents returns the account's activities newest first
+	ListEvents(ctx context.Context, orgID, accountID int32, filter AccountActivityFilter) ([]*AccountActivityEvent, error)
+	// ListTimestamps returns the latest time of each kind of activity of the account
+	ListTimestamps(ctx context.Context, orgID, accountID int32) ([]*ActivityTimestamp, error)
+	// DeleteEventsBefore deletes feed activities that occurred before cutoff
+	DeleteEventsBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	// ReactivateAccount returns true if the account was dormant
+	ReactivateAccount(ctx context.Context, orgID, accountID int32) (bool, error)
+	// ReactivateOrganization returns true if the organization was dormant
+	ReactivateOrganization(ctx context.Context, orgID int32) (bool, error)
+	// MarkDormantAccounts moves up to limit active accounts without activity since cutoff to dormant
+	MarkDormantAccounts(ctx context.Context, cutoff time.Time, limit int32) ([]*DormantAccount, error)
+	// MarkDormantOrganizations moves up to limit active organizations without activity since cutoff to dormant
+	MarkDormantOrganizations(ctx context.Context, cutoff time.Time, limit int32) ([]*DormantOrganization, error)
+}
+
+// AccountStatusRepository changes account statuses together with their history
+type AccountStatusRepository interface {
+	// ChangeStatus moves the account from change.FromStatus to change.ToStatus
+	// and records the change. It returns ErrAccountStatusChanged if the
+	// account is no longer in FromStatus.
+	ChangeStatus(ctx context.Context, change *AccountStatusChange) (*AccountStatusChange, error)
+	// ChangeStatusWithinSeats is ChangeStatus for a change that gives the
+	// account a seat again, such as a reactivation. The seats are counted
+	// and the status changed in one transaction holding the organization's
+	// seat allocation lock; it returns paywall.ErrSeatLimitReached when every
+	// purchased seat is in use.
+	ChangeStatusWithinSeats(ctx context.Context, change *AccountStatusChange) (*AccountStatusChange, error)
+	// ListChanges returns the account's status changes newest first
+	ListChanges(ctx context.Context, orgID, accountID, limit int32) ([]*AccountStatusChange, error)
+}
+
+// APIUsageRepository stores the API request counts of accounts per quota window
+type APIUsageRepository interface {
+	// Save stores the window's count, keeping the stored count if it is higher
+	Save(ctx context.Context, usage *APIUsageWindow) error
+	// List returns the account's usage windows newest first
+	List(ctx context.Context, orgID, accountID, limit int32) ([]*APIUsageWindow, error)
+	// DeleteBefore deletes windows that ended before cutoff
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// NotificationRepository stores members' notification preferences and in-app notifications
+type NotificationRepository interface {
+	// ListPreferences returns the account's stored choices; types and
+	// channels without one are enabled
+	ListPreferences(ctx context.Context, orgID, accountID int32) ([]*NotificationPreference, error)
+	SavePreference(ctx context.Context, orgID, accountID int32, pref *NotificationPreference) error
+	Create(ctx context.Context, notification *Notification) (*Notification, error)
+	// List returns the account's in-app notifications newest first
+	List(ctx context.Context, orgID, accountID int32, unreadOnly bool, limit int32) ([]*Notification, error)
+	// MarkRead returns ErrNotificationNotFound if the account has no such notification
+	MarkRead(ctx context.Context, orgID, accountID int32, id int64) error
+}
+
+// TagRepository stores organization tags and the accounts they are attached to
+type TagRepository interface {
+	// List returns the organization's tags by name with their account counts
+	List(ctx context.Context, orgID int32) ([]*Tag, error)
+	// Delete returns ErrTagNotFound if the organization has no such tag
+	Delete(ctx context.Context, orgID int32, name string) error
+	// ListAccountTags returns the names of the account's tags in order
+	ListAccountTags(ctx context.Context, orgID, accountID int32) ([]string, error)
+	// AddAccountTag attaches the tag, creating it if needed; attaching it again is a no-op
+	AddAccountTag(ctx context.Context, orgID, accountID int32, name string, actorID int32) error
+	// RemoveAccountTag returns ErrAccountTagNotFound if the account does not have the tag
+	RemoveAccountTag(ctx context.Context, orgID, accountID int32, name string) error
+}
+
+// IdentityRepository stores the social identities linked to accounts
+type IdentityRepository interface {
+	// Create returns ErrIdentityAlreadyLinked if the account already has an
+	// identity at the provider or the identity is linked to another account
+	// of the organization
+	Create(ctx context.Context, identity *LinkedIdentity) (*LinkedIdentity, error)
+	// ListByAccount returns the account's identities by provider
+	ListByAccount(ctx context.Context, orgID, accountID int32) ([]*LinkedIdentity, error)
+	// ListByProviderUser returns every account the provider user is linked to, across organizations
+	ListByProviderUser(ctx context.Context, provider IdentityProvider, providerUserID string) ([]*LinkedIdentity, error)
+	// Delete returns ErrIdentityNotLinked if the account has no identity at the provider
+	Delete(ctx context.Context, orgID, accountID int32, provider IdentityProvider) error
+}
+
+// SecondaryEmailRepository stores the addresses of accounts besides their
+// primary email. Addresses are normalized before they are stored or looked up.
+type SecondaryEmailRepository interface {
+	// Create returns ErrSecondaryEmailExists if the organization already has the address
+	Create(ctx context.Context, email *SecondaryEmail) (*SecondaryEmail, error)
+	// ListByAccount returns the account's addresses, oldest first
+	ListByAccount(ctx context.Context, orgID, accountID int32) ([]*SecondaryEmail, error)
+	// GetByID returns ErrSecondaryEmailNotFound unless the address belongs to the account
+	GetByID(ctx context.Context, orgID, accountID, id int32) (*SecondaryEmail, error)
+	// GetByAddress returns the organization's secondary address of any account
+	GetByAddress(ctx context.Context, orgID int32, email string) (*SecondaryEmail, error)
+	// GetByVerificationTokenHash returns ErrSecondaryEmailInvalidToken if no address awaits the token
+	GetByVerificationTokenHash(ctx context.Context, tokenHash string) (*SecondaryEmail, error)
+	// ListNotified returns the verified addresses of the account that receive notifications
+	ListNotified(ctx context.Context, orgID, accountID int32) ([]string, error)
+	// SetVerification replaces the verification link of an unverified address.
+	// Returns ErrSecondaryEmailVerified once the address is verified.
+	SetVerification(ctx context.Context, id int32, tokenHash string, expiresAt time.Time) (*SecondaryEmail, error)
+	// Verify returns ErrSecondaryEmailVerified if the address was already verified
+	Verify(ctx context.Context, id int32) (*SecondaryEmail, error)
+	SetNotifications(ctx context.Context, orgID, accountID, id int32, enabled bool) (*SecondaryEmail, error)
+	// MakePrimary swaps a verified address with the account's primary email in
+	// one transaction and returns the row, which now holds the old primary address
+	MakePrimary(ctx context.Context, orgID, accountID, id int32) (*SecondaryEmail, error)
+	Delete(ctx context.Context, orgID, accountID, id int32) error
+	// DeleteExpired removes an unverified address whose link expired and
+	// reports whether it did
+	DeleteExpired(ctx context.Context, id int32) (bool, error)
+}
+
+// ServiceAccountRepository stores service accounts and their API keys. Keys
+// are stored as SHA-256 hashes. A service account is deleted with its account.
+type ServiceAccountRepository interface {
+	// Create returns ErrServiceAccountNameTaken if the organization already
+	// has a service account with the name
+	Create(ctx context.Context, serviceAccount *ServiceAccount) (*ServiceAccount, error)
+	GetByID(ctx context.Context, orgID, id int32) (*ServiceAccount, error)
+	ListByOrganization(ctx context.Context, orgID int32) ([]*ServiceAccount, error)
+	// Update changes the name, description and owner; returns
+	// ErrServiceAccountNameTaken like Create
+	Update(ctx context.Context, serviceAccount *ServiceAccount) (*ServiceAccount, error)
+	// SetDisabled disables or enables the service account
+	SetDisabled(ctx context.Context, orgID, id int32, disabled bool) (*ServiceAccount, error)
+	CreateKey(ctx context.Context, key *ServiceAccountKey) (*ServiceAccountKey, error)
+	// ListKeys returns the service account's keys, newest first
+	ListKeys(ctx context.Context, orgID, serviceAccountID int32) ([]*ServiceAccountKey, error)
+	// CountActiveKeys counts the keys that are neither revoked nor expired
+	CountActiveKeys(ctx context.Context, serviceAccountID int32) (int64, error)
+	// RevokeKey returns ErrServiceAccountKeyNotFound if the key is missing or already revoked
+	RevokeKey(ctx context.Context, orgID, serviceAccountID, id int32) (*ServiceAccountKey, error)
+	// GetCredential returns ErrServiceAccountKeyNotFound if no unrevoked key has the hash
+	GetCredential(ctx context.Context, keyHash string) (*ServiceAccountCredential, error)
+	// TouchKey records a use of the key
+	TouchKey(ctx context.Context, id int32) error
+}
+
+// RoleAssignmentRepository stores the roles assigned to accounts within
+// their organization and to users globally. Global assignments are keyed by
+// normalized email.
+type RoleAssignmentRepository interface {
+	// CreateForAccount returns ErrRoleAssignmentExists if the account already
+	// has the role, or ErrRoleAssignmentInvalidRole if the role does not exist
+	CreateForAccount(ctx context.Context, orgID, accountID int32, roleID string, assignedBy *int32) (*RoleAssignment, error)
+	// CreateGlobal returns ErrRoleAssignmentExists if the email already has the role,
+	// or ErrRoleAssignmentInvalidRole if the role does not exist
+	CreateGlobal(ctx context.Context, email, roleID string) (*RoleAssignment, error)
+	// ListByAccount returns the account's assignments, global ones first
+	ListByAccount(ctx context.Context, orgID, accountID int32) ([]*RoleAssignment, error)
+	// ListGlobal returns global assignments by email; an empty email returns all
+	ListGlobal(ctx context.Context, email string) ([]*RoleAssignment, error)
+	// DeleteForAccount returns ErrRoleAssignmentNotFound if the account does not
+	// have the role, or ErrRoleAssignmentLastAdmin if the organization would be
+	// left without an admin
+	DeleteForAccount(ctx context.Context, orgID, accountID int32, roleID string) (*RoleAssignment, error)
+	// DeleteGlobal returns ErrRoleAssignmentNotFound if there is no such global
+	// assignment, or ErrRoleAssignmentLastAdmin if an organization of the user
+	// would be left without an admin
+	DeleteGlobal(ctx context.Context, id int32) (*RoleAssignment, error)
+}
+
+// UserStatsRepository aggregates accounts for admin dashboards. An orgID of 0
+// covers every production organization.
+type UserStatsRepository interface {
+	GetStats(ctx context.Context, orgID int32) (*UserStats, error)
+	// ListDailySignups returns one entry per day up to today, oldest first
+	ListDailySignups(ctx context.Context, orgID, days int32) ([]*DailySignups, error)
+}
+
+// IPAllowlistRepository defines the interface for organization IP allowlist data operations
+type IPAllowlistRepository interface {
+	Create(ctx context.Context, entry *IPAllowlistEntry) (*IPAllowlistEntry, error)
+	ListByOrganization(ctx context.Context, orgID int32) ([]*IPAllowlistEntry, error)
+	Delete(ctx context.Context, orgID, entryID int32) error
+}
+
+// AuthPolicyRepository defines the interface for organization auth policy data operations
+type AuthPolicyRepository interface {
+	GetByOrganization(ctx context.Context, orgID int32) (*AuthPolicy, error)
+	Upsert(ctx context.Context, policy *AuthPolicy) (*AuthPolicy, error)
+	Delete(ctx context.Context, orgID int32) error
+}
+
+// AccessElevationRepository defines the interface for just-in-time elevation data operations
+type AccessElevationRepository interface {
+	Create(ctx context.Context, elevation *AccessElevation) (*AccessElevation, error)
+	GetByID(ctx context.Context, orgID, elevationID int32) (*AccessElevation, error)
+	// ListByOrganization returns elevations newest first; an empty status returns all
+	ListByOrganization(ctx context.Context, orgID int32, status string) ([]*AccessElevation, error)
+	// GetActive returns the account's active elevation or ErrElevationNotFound
+	GetActive(ctx context.Context, orgID, accountID int32) (*AccessElevation, error)
+	// Decide approves or denies a pending elevation, starting the window on approval;
+	// returns ErrElevationNotPending otherwise
+	Decide(ctx context.Context, elevation *AccessElevation) (*AccessElevation, error)
+	// Revoke ends an approved elevation early; returns ErrElevationNotActive otherwise
+	Revoke(ctx context.Context, orgID, elevationID int32) (*AccessElevation, error)
+}
+
+// MFARecoveryRepository defines the interface for MFA recovery request data operations
+type MFARecoveryRepository interface {
+	// Create returns ErrMFARecoveryInProgress if the account already has an open request
+	Create(ctx context.Context, req *MFARecoveryRequest) (*MFARecoveryRequest, error)
+	GetByID(ctx context.Context, requestID int32) (*MFARecoveryRequest, error)
+	// ListByOrganization returns requests newest first; an empty status returns all
+	ListByOrganization(ctx context.Context, orgID int32, status string) ([]*MFARecoveryRequest, error)
+	// Decide approves or denies a pending request that requires approval
+	Decide(ctx context.Context, orgID, requestID int32, status string, deciderID int32) (*MFARecoveryRequest, error)
+	// Cancel closes an open request on behalf of the account it belongs to
+	Cancel(ctx context.Context, orgID, accountID, requestID int32) (*MFARecoveryRequest, error)
+	// Complete marks the request completed if it still has the expected status
+	Complete(ctx context.Context, requestID int32, expectedStatus string) (*MFARecoveryRequest, error)
+}
+
+// EmailChangeRepository defines the interface for account email change data operations
+type EmailChangeRepository interface {
+	// Create replaces any pending request for the account with the new one
+	Create(ctx context.Context, req *EmailChangeRequest) (*EmailChangeRequest, error)
+	GetByConfirmTokenHash(ctx context.Context, tokenHash string) (*EmailChangeRequest, error)
+	GetByRevertTokenHash(ctx context.Context, tokenHash string) (*EmailChangeRequest, error)
+	// CancelPending cancels the account's pending request and reports whether there was one
+	CancelPending(ctx context.Context, orgID, accountID int32) (bool, error)
+	// Cancel cancels a pending request; returns ErrEmailChangeNotPending otherwise
+	Cancel(ctx context.Context, requestID int32) (*EmailChangeRequest, error)
+	// Confirm marks a pending request confirmed and stores the rotated revert token
+	Confirm(ctx context.Context, requestID int32, revertTokenHash string, revertibleUntil time.Time) (*EmailChangeRequest, error)
+	// Revert marks a confirmed request reverted; returns ErrEmailChangeNotRevertible otherwise
+	Revert(ctx context.Context, requestID int32) (*EmailChangeRequest, error)
+}
+
+// InviteRepository defines the interface for organization invite data operations
+type InviteRepository interface {
+	Create(ctx context.Context, invite *Invite) (*Invite, error)
+	GetByTokenHash(ctx context.Context, tokenHash string) (*Invite, error)
+	ListByOrganization(ctx context.Context, orgID int32, limit, offset int32) ([]*Invite, error)
+	// Accept claims a pending, unexpired invite for the email; returns ErrInviteNotPending otherwise
+	Accept(ctx context.Context, inviteID int32, email string) (*Invite, error)
+	// Release returns a claimed invite to pending after registration failed
+	Release(ctx context.Context, inviteID int32) error
+	// Revoke revokes a pending invite; returns ErrInviteNotPending otherwise
+	Revoke(ctx context.Context, orgID, inviteID int32) (*Invite, error)
+	// DeleteBefore deletes invites that expired or were revoked before cutoff; accepted invites are kept
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
 }
 
 // OrganizationStats represents organization statistics
@@ -52,3 +344,67 @@ type AccountPermission struct {
 	Status    string `json:"status"`
 	OrgStatus string `json:"org_status"`
 }
+
+// OAuthClientRepository defines the interface for OAuth client data operations
+type OAuthClientRepository interface {
+	Create(ctx context.Context, client *OAuthClient) (*OAuthClient, error)
+	GetByID(ctx context.Context, orgID, id int32) (*OAuthClient, error)
+	GetByClientID(ctx context.Context, clientID string) (*OAuthClient, error)
+	ListByOrganization(ctx context.Context, orgID int32) ([]*OAuthClient, error)
+	// Revoke marks the client revoked; returns ErrOAuthClientNotFound if it is missing or already revoked
+	Revoke(ctx context.Context, orgID, id int32) (*OAuthClient, error)
+	// Touch records that the client obtained a token
+	Touch(ctx context.Context, id int32) error
+}
+
+// CanaryCredentialRepository defines the interface for canary credential data operations
+type CanaryCredentialRepository interface {
+	Create(ctx context.Context, canary *CanaryCredential) (*CanaryCredential, error)
+	// GetByIdentifier returns ErrCanaryNotFound if no canary has the client ID or email
+	GetByIdentifier(ctx context.Context, identifier string) (*CanaryCredential, error)
+	ListByOrganization(ctx context.Context, orgID int32) ([]*CanaryCredential, error)
+	Delete(ctx context.Context, orgID, id int32) error
+	// RecordTrigger counts a use of the canary
+	RecordTrigger(ctx context.Context, id int32) (*CanaryCredential, error)
+}
+
+// DebugCaptureRepository defines the interface for debug capture data operations
+type DebugCaptureRepository interface {
+	Create(ctx context.Context, capture *DebugCapture) (*DebugCapture, error)
+	GetByID(ctx context.Context, id int32) (*DebugCapture, error)
+	// GetActive returns ErrDebugCaptureNotFound if the organization has no capture recording
+	GetActive(ctx context.Context, orgID int32) (*DebugCapture, error)
+	// List returns captures newest first; orgID 0 lists the captures of every organization
+	List(ctx context.Context, orgID, limit, offset int32) ([]*DebugCapture, error)
+	// Stop ends a capture early; returns ErrDebugCaptureNotFound if it is missing or no longer recording
+	Stop(ctx context.Context, id int32) (*DebugCapture, error)
+	// ReserveRecord counts a record against the capture's limit, reporting false if it stopped or is full
+	ReserveRecord(ctx context.Context, id int32) (bool, error)
+	CreateRecord(ctx context.Context, record *DebugCaptureRecord) error
+	ListRecords(ctx context.Context, captureID, limit, offset int32) ([]*DebugCaptureRecord, error)
+	// DeleteExpiredRecords drops records past their retention, returning the count deleted
+	DeleteExpiredRecords(ctx context.Context, before time.Time) (int64, error)
+	// DeleteEnded drops captures that ended before the cutoff, returning the count deleted
+	DeleteEnded(ctx context.Context, before time.Time) (int64, error)
+}
+
+// OIDCClientRepository defines the interface for OpenID Connect client data operations
+type OIDCClientRepository interface {
+	Create(ctx context.Context, client *OIDCClient) (*OIDCClient, error)
+	GetByClientID(ctx context.Context, clientID string) (*OIDCClient, error)
+	ListByOrganization(ctx context.Context, orgID int32) ([]*OIDCClient, error)
+	// Revoke marks the client revoked; returns ErrOIDCClientNotFound if it is missing or already revoked
+	Revoke(ctx context.Context, orgID, id int32) (*OIDCClient, error)
+}
+
+// OIDCGrantRepository defines the interface for OpenID Connect authorization codes and consents
+type OIDCGrantRepository interface {
+	CreateCode(ctx context.Context, code *OIDCAuthorizationCode) (*OIDCAuthorizationCode, error)
+	// ConsumeCode marks a code used; returns ErrOIDCInvalidGrant if it was spent, expired or never issued
+	ConsumeCode(ctx context.Context, codeHash string) (*OIDCAuthorizationCode, error)
+	// DeleteExpiredCodes drops codes that expired over an hour ago, returning the count deleted
+	DeleteExpiredCodes(ctx context.Context) (int64, error)
+	// GetConsent returns nil if the member never approved the client
+	GetConsent(ctx context.Context, oidcClientID, accountID int32) (*OIDCConsent, error)
+	SaveConsent(ctx context.Context, consent *OIDCConsent) (*OIDCConsent, error)
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "time"
+
+// AdminRoleID is the role organizations must always have a holder of
+const AdminRoleID = "admin"
+
+// RoleAssignment grants a role on top of the role the auth provider assigns.
+// An assignment within an organization belongs to one account; a global
+// assignment belongs to an email and applies in every organization the user
+// has an account in.
+type RoleAssignment struct {
+	ID     int32  `json:"id"`
+	RoleID string `json:"role_id"`
+	// OrganizationID and AccountID are set for assignments within an organization
+	OrganizationID *int32 `json:"organization_id,omitempty"`
+	AccountID      *int32 `json:"account_id,omitempty"`
+	// Email is set for global assignments
+	Email string `json:"email,omitempty"`
+	// AssignedByAccountID is the admin who made an organization assignment;
+	// empty for assignments made by operators
+	AssignedByAccountID *int32    `json:"assigned_by_account_id,omitempty"`
+	CreatedAt           time.Time `json:"created_at"`
+}
+
+// IsGlobal reports whether the assignment applies in every organization
+func (a *RoleAssignment) IsGlobal() bool {
+	return a.OrganizationID == nil
+}
+
+// AccountRoles are the roles an account holds in its organization
+type AccountRoles struct {
+	AccountID int32 `json:"account_id"`
+	// ProviderRole is the role the auth provider assigns
+	ProviderRole string `json:"provider_role"`
+	// Assignments are the assigned roles, global ones first
+	Assignments []*RoleAssignment `json:"assignments"`
+}
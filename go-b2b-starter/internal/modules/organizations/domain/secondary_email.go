@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// SecondaryEmail is an address of an account besides its primary email, for
+// members who move between work addresses. Once verified it can receive
+// notifications and can be made the primary email.
+// An address belongs to at most one account per organization.
+type SecondaryEmail struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	AccountID      int32  `json:"account_id"`
+	Email          string `json:"email"`
+	// Notifications sends the account's email notifications to this address
+	// as well as the primary one
+	Notifications bool       `json:"notifications"`
+	VerifiedAt    *time.Time `json:"verified_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+
+	// VerificationTokenHash and VerificationExpiresAt describe the pending
+	// verification link of an unverified address
+	VerificationTokenHash string     `json:"-"`
+	VerificationExpiresAt *time.Time `json:"-"`
+}
+
+// IsVerified reports whether the owner confirmed the address
+func (e *SecondaryEmail) IsVerified() bool {
+	return e.VerifiedAt != nil
+}
+
+// VerificationExpired reports whether the address is unverified and its link
+// stopped working at now
+func (e *SecondaryEmail) VerificationExpired(now time.Time) bool {
+	return !e.IsVerified() && (e.VerificationExpiresAt == nil || !now.Before(*e.VerificationExpiresAt))
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxTagNameLength is the longest tag name
+	MaxTagNameLength = 50
+
+	// MaxOrganizationTags caps how many tags an organization can define
+	MaxOrganizationTags = 100
+)
+
+var tagNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// Tag is an admin-defined label such as "beta" or "vip" that groups users
+// of an organization for support and feature rollouts
+type Tag struct {
+	ID             int32  `json:"id"`
+	OrganizationID int32  `json:"organization_id"`
+	Name           string `json:"name"`
+	// AccountCount is how many accounts carry the tag
+	AccountCount       int64     `json:"account_count"`
+	CreatedByAccountID *int32    `json:"created_by_account_id,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// NormalizeTagName trims and lowercases a tag name and checks that it is 1
+// to MaxTagNameLength letters, digits, hyphens or underscores, starting with
+// a letter or digit.
+func NormalizeTagName(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if len(name) > MaxTagNameLength || !tagNamePattern.MatchString(name) {
+		return "", fmt.Errorf("%w: %q", ErrTagInvalidName, name)
+	}
+	return name, nil
+}
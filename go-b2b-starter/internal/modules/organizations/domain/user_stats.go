@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// UserStats summarizes the accounts of production organizations, or of one
+// organization, for an internal admin dashboard. Deleted accounts are left
+// out.
+type UserStats struct {
+	// OrganizationID is set when the stats cover a single organization
+	OrganizationID    *int32 `json:"organization_id,omitempty"`
+	TotalAccounts     int64  `json:"total_accounts"`
+	ActiveAccounts    int64  `json:"active_accounts"`
+	DormantAccounts   int64  `json:"dormant_accounts"`
+	SuspendedAccounts int64  `json:"suspended_accounts"`
+	VerifiedAccounts  int64  `json:"verified_accounts"`
+	// VerificationRate is the share of accounts with a verified email, from 0 to 1
+	VerificationRate float64 `json:"verification_rate"`
+	// DailyActiveUsers, WeeklyActiveUsers and MonthlyActiveUsers count
+	// accounts that signed in or made an authenticated request in the last
+	// 1, 7 and 30 days
+	DailyActiveUsers   int64     `json:"daily_active_users"`
+	WeeklyActiveUsers  int64     `json:"weekly_active_users"`
+	MonthlyActiveUsers int64     `json:"monthly_active_users"`
+	GeneratedAt        time.Time `json:"generated_at"`
+}
+
+// DailySignups counts the accounts created on one day
+type DailySignups struct {
+	// Day is the date in YYYY-MM-DD form
+	Day     string `json:"day"`
+	Signups int64  `json:"signups"`
+	// Verified is how many of the day's signups verified their email so far
+	Verified         int64   `json:"verified"`
+	VerificationRate float64 `json:"verification_rate"`
+}
+
+// VerificationRate returns verified as a share of total, or 0 without accounts
+func VerificationRate(verified, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(verified) / float64(total)
+}
@@ -0,0 +1,231 @@
+package organizations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type ElevationHandler struct {
+	elevationService services.AccessElevationService
+	logger           logger.Logger
+}
+
+func NewElevationHandler(elevationService services.AccessElevationService, logger logger.Logger) *ElevationHandler {
+	return &ElevationHandler{
+		elevationService: elevationService,
+		logger:           logger,
+	}
+}
+
+// RequestElevation godoc
+// @Summary Request elevated access
+// @Description Requests a role for a limited time. The request is pending until an org admin approves it, unless the elevation policy auto-approves the role. Once approved the role applies to every request until it expires.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param request body services.RequestElevationRequest true "Elevation request"
+// @Success 201 {object} domain.AccessElevation "Elevation request"
+// @Failure 400 {object} map[string]string "Invalid role, reason or duration"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /elevations [post]
+func (h *ElevationHandler) RequestElevation(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.RequestElevationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	elevation, err := h.elevationService.RequestElevation(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrElevationInvalidRole, domain.ErrElevationReasonRequired, domain.ErrElevationInvalidDuration:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		default:
+			h.logger.Error("failed to request elevation", map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": reqCtx.AccountID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to request elevation", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, elevation)
+}
+
+// ListElevations godoc
+// @Summary List elevation requests
+// @Description Lists the organization's elevation requests and grants, newest first.
+// @Tags Organizations
+// @Produce json
+// @Param status query string false "Filter by status (pending, approved, denied, revoked)"
+// @Success 200 {array} domain.AccessElevation "Elevations"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /elevations [get]
+func (h *ElevationHandler) ListElevations(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	elevations, err := h.elevationService.ListElevations(c.Request.Context(), reqCtx.OrganizationID, c.Query("status"))
+	if err != nil {
+		h.logger.Error("failed to list elevations", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list elevations", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, elevations)
+}
+
+// ApproveElevation godoc
+// @Summary Approve elevation request
+// @Description Approves a pending elevation request. The elevation window starts now. Requesters cannot approve their own requests.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Elevation ID"
+// @Param request body services.DecideElevationRequest false "Decision note"
+// @Success 200 {object} domain.AccessElevation "Approved elevation"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 403 {object} map[string]string "Forbidden or self-approval"
+// @Failure 404 {object} map[string]string "Elevation not found"
+// @Failure 409 {object} map[string]string "Elevation is not pending"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /elevations/{id}/approve [post]
+func (h *ElevationHandler) ApproveElevation(c *gin.Context) {
+	h.decide(c, h.elevationService.ApproveElevation)
+}
+
+// DenyElevation godoc
+// @Summary Deny elevation request
+// @Description Denies a pending elevation request. Requesters cannot decide their own requests.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Elevation ID"
+// @Param request body services.DecideElevationRequest false "Decision note"
+// @Success 200 {object} domain.AccessElevation "Denied elevation"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 403 {object} map[string]string "Forbidden or self-approval"
+// @Failure 404 {object} map[string]string "Elevation not found"
+// @Failure 409 {object} map[string]string "Elevation is not pending"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /elevations/{id}/deny [post]
+func (h *ElevationHandler) DenyElevation(c *gin.Context) {
+	h.decide(c, h.elevationService.DenyElevation)
+}
+
+// RevokeElevation godoc
+// @Summary Revoke elevated access
+// @Description Ends an approved elevation before it expires.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Elevation ID"
+// @Success 200 {object} domain.AccessElevation "Revoked elevation"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 409 {object} map[string]string "Elevation is not active"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /elevations/{id}/revoke [post]
+func (h *ElevationHandler) RevokeElevation(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	elevationID, ok := parseElevationID(c)
+	if !ok {
+		return
+	}
+
+	elevation, err := h.elevationService.RevokeElevation(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, elevationID)
+	if err != nil {
+		if err == domain.ErrElevationNotActive {
+			response.Error(c, http.StatusConflict, err.Error(), err)
+			return
+		}
+		h.logger.Error("failed to revoke elevation", map[string]interface{}{"org_id": reqCtx.OrganizationID, "elevation_id": elevationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to revoke elevation", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, elevation)
+}
+
+type elevationDecision func(ctx context.Context, orgID, approverID, elevationID int32, req *services.DecideElevationRequest) (*domain.AccessElevation, error)
+
+// decide handles approve and deny, which share validation and error mapping.
+func (h *ElevationHandler) decide(c *gin.Context, decision elevationDecision) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	elevationID, ok := parseElevationID(c)
+	if !ok {
+		return
+	}
+
+	var req services.DecideElevationRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+			return
+		}
+	}
+
+	elevation, err := decision(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, elevationID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrElevationNotFound:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case domain.ErrElevationNotPending:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		case domain.ErrElevationSelfApproval:
+			response.Error(c, http.StatusForbidden, err.Error(), err)
+		default:
+			h.logger.Error("failed to decide elevation", map[string]interface{}{"org_id": reqCtx.OrganizationID, "elevation_id": elevationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to decide elevation", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, elevation)
+}
+
+func parseElevationID(c *gin.Context) (int32, bool) {
+	var elevationID int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &elevationID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid elevation ID format", err)
+		return 0, false
+	}
+	return elevationID, true
+}
@@ -0,0 +1,180 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type EmailChangeHandler struct {
+	changeService services.EmailChangeService
+	logger        logger.Logger
+}
+
+func NewEmailChangeHandler(changeService services.EmailChangeService, logger logger.Logger) *EmailChangeHandler {
+	return &EmailChangeHandler{
+		changeService: changeService,
+		logger:        logger,
+	}
+}
+
+// RequestChange godoc
+// @Summary Request an email change
+// @Description Emails a confirmation link to the new address and notifies the current one. The current address stays active until the change is confirmed. A new request replaces any pending one.
+// @Tags Accounts
+// @Accept json
+// @Produce json
+// @Param request body services.RequestEmailChangeRequest true "New email address"
+// @Success 202 {object} domain.EmailChangeRequest "Pending email change"
+// @Failure 400 {object} map[string]string "Invalid request or same address"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Email already in use"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /accounts/me/email-change [post]
+func (h *EmailChangeHandler) RequestChange(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.RequestEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	change, err := h.changeService.RequestChange(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrAccountEmailRequired, domain.ErrEmailChangeSameAddress:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrUserAlreadyExists:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		default:
+			h.logger.Error("failed to request email change", map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": reqCtx.AccountID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to request email change", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, change)
+}
+
+// CancelChange godoc
+// @Summary Cancel a pending email change
+// @Description Cancels the signed-in member's pending email change.
+// @Tags Accounts
+// @Produce json
+// @Success 200 {object} map[string]string "Email change cancelled"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "No pending email change"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /accounts/me/email-change [delete]
+func (h *EmailChangeHandler) CancelChange(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	if err := h.changeService.CancelChange(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID); err != nil {
+		if err == domain.ErrEmailChangeNotFound {
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		h.logger.Error("failed to cancel email change", map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": reqCtx.AccountID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to cancel email change", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, gin.H{"message": "email change cancelled"})
+}
+
+// ConfirmChange godoc
+// @Summary Confirm an email change
+// @Description Switches the account to the new address using the token emailed to it. All of the member's sessions are revoked, and the old address receives a link to roll the change back.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body services.EmailChangeTokenRequest true "Confirmation token"
+// @Success 200 {object} domain.EmailChangeRequest "Confirmed email change"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "Email change not found"
+// @Failure 409 {object} map[string]string "No longer pending or email already in use"
+// @Failure 410 {object} map[string]string "Confirmation link expired"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/email-change/confirm [post]
+func (h *EmailChangeHandler) ConfirmChange(c *gin.Context) {
+	var req services.EmailChangeTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	change, err := h.changeService.ConfirmChange(c.Request.Context(), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrEmailChangeNotFound:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case domain.ErrEmailChangeNotPending, domain.ErrUserAlreadyExists:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		case domain.ErrEmailChangeExpired:
+			response.Error(c, http.StatusGone, err.Error(), err)
+		default:
+			h.logger.Error("failed to confirm email change", map[string]interface{}{"error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to confirm email change", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, change)
+}
+
+// RevertChange godoc
+// @Summary Revert an email change
+// @Description Uses the token emailed to the old address. A pending change is cancelled; a confirmed change is rolled back while its revert window is open. All of the member's sessions are revoked on rollback.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body services.EmailChangeTokenRequest true "Revert token"
+// @Success 200 {object} domain.EmailChangeRequest "Cancelled or reverted email change"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "Email change not found"
+// @Failure 409 {object} map[string]string "Old email already in use"
+// @Failure 410 {object} map[string]string "Revert window has passed"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/email-change/revert [post]
+func (h *EmailChangeHandler) RevertChange(c *gin.Context) {
+	var req services.EmailChangeTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	change, err := h.changeService.RevertChange(c.Request.Context(), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrEmailChangeNotFound:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case domain.ErrUserAlreadyExists:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		case domain.ErrEmailChangeNotRevertible, domain.ErrEmailChangeNotPending:
+			response.Error(c, http.StatusGone, err.Error(), err)
+		default:
+			h.logger.Error("failed to revert email change", map[string]interface{}{"error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to revert email change", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, change)
+}
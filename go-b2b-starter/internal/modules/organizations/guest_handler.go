@@ -0,0 +1,160 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type GuestHandler struct {
+	guestService services.GuestSessionService
+	invitePolicy *services.InvitePolicy
+	logger       logger.Logger
+}
+
+func NewGuestHandler(guestService services.GuestSessionService, invitePolicy *services.InvitePolicy, logger logger.Logger) *GuestHandler {
+	return &GuestHandler{
+		guestService: guestService,
+		invitePolicy: invitePolicy,
+		logger:       logger,
+	}
+}
+
+// StartGuestSession godoc
+// @Summary Start guest session
+// @Description Issues a short-lived anonymous session bound to the given device so prospects can try the demo without registering. Send the token as a Bearer token together with the X-Device-ID header.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body services.StartGuestSessionRequest true "Device ID"
+// @Success 201 {object} services.GuestSession "Guest session"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 404 {object} map[string]string "Guest sessions are disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/guest [post]
+func (h *GuestHandler) StartGuestSession(c *gin.Context) {
+	var req services.StartGuestSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	session, err := h.guestService.StartGuestSession(c.Request.Context(), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrGuestSessionsDisabled:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case domain.ErrGuestDeviceRequired:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		default:
+			h.logger.Error("failed to start guest session", map[string]interface{}{"error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to start guest session", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, session)
+}
+
+// ClaimGuestSession godoc
+// @Summary Claim guest session
+// @Description Moves the data created during a guest session into the signed-in account and ends the guest session. Call it right after signup.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body services.ClaimGuestSessionRequest true "Guest token and device ID"
+// @Success 204 "Guest session claimed"
+// @Failure 400 {object} map[string]string "Invalid or expired guest session"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Guest session already claimed"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/guest/claim [post]
+func (h *GuestHandler) ClaimGuestSession(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.ClaimGuestSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	if err := h.guestService.ClaimGuestSession(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req); err != nil {
+		switch err {
+		case domain.ErrGuestSessionInvalid:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrGuestSessionClaimed:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		default:
+			h.logger.Error("failed to claim guest session", map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": reqCtx.AccountID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to claim guest session", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusNoContent, nil)
+}
+
+// UpgradeGuestSession godoc
+// @Summary Upgrade guest session
+// @Description Registers the guest as the owner of a new organization, moves the data created during the guest session into the new account and ends the guest session. The owner receives a magic link to sign in. Send the guest token as a Bearer token together with the X-Device-ID header.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer guest token"
+// @Param X-Device-ID header string true "Device the guest token was issued for"
+// @Param request body services.UpgradeGuestSessionRequest true "Organization and owner details"
+// @Success 201 {object} services.BootstrapOrganizationResponse "Registered organization"
+// @Failure 400 {object} map[string]string "Invalid request or guest session"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Registration requires an invite"
+// @Failure 404 {object} map[string]string "Guest sessions are disabled"
+// @Failure 409 {object} map[string]string "Guest session already claimed"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/guest/upgrade [post]
+func (h *GuestHandler) UpgradeGuestSession(c *gin.Context) {
+	if h.invitePolicy.InviteOnly {
+		response.Error(c, http.StatusForbidden, domain.ErrRegistrationInviteOnly.Error(), domain.ErrRegistrationInviteOnly)
+		return
+	}
+
+	identity := auth.GetIdentity(c)
+	if identity == nil || !identity.Guest {
+		response.Error(c, http.StatusBadRequest, domain.ErrGuestSessionInvalid.Error(), domain.ErrGuestSessionInvalid)
+		return
+	}
+
+	var req services.UpgradeGuestSessionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	result, err := h.guestService.UpgradeGuestSession(c.Request.Context(), identity, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrGuestSessionsDisabled:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case domain.ErrGuestSessionInvalid:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrGuestSessionClaimed:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		default:
+			h.logger.Error("failed to upgrade guest session", map[string]interface{}{"guest_id": identity.UserID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to upgrade guest session", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, result)
+}
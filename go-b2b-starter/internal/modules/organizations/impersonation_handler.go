@@ -0,0 +1,113 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type ImpersonationHandler struct {
+	impersonationService services.ImpersonationService
+	logger               logger.Logger
+}
+
+func NewImpersonationHandler(impersonationService services.ImpersonationService, logger logger.Logger) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+		logger:               logger,
+	}
+}
+
+// StartImpersonation godoc
+// @Summary Impersonate organization member
+// @Description Issues a short-lived token to act as the member, for support and debugging. The token carries an impersonated_by claim, cannot be extended, and every request made with it is audit logged. Org admins cannot be impersonated.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param member_id path string true "Member ID"
+// @Param request body services.StartImpersonationRequest true "Reason and duration"
+// @Success 201 {object} services.Impersonation "Impersonation token"
+// @Failure 400 {object} map[string]string "Invalid reason, duration or target"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Member not found or impersonation disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/members/{member_id}/impersonate [post]
+func (h *ImpersonationHandler) StartImpersonation(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	memberID := c.Param("member_id")
+	if memberID == "" {
+		response.Error(c, http.StatusBadRequest, "member_id is required", nil)
+		return
+	}
+
+	var req services.StartImpersonationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	impersonation, err := h.impersonationService.StartImpersonation(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, reqCtx.Identity, memberID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrImpersonationReasonRequired, domain.ErrImpersonationInvalidDuration, domain.ErrImpersonationInvalidTarget:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrImpersonationSelf, domain.ErrImpersonationNested, domain.ErrImpersonationTargetAdmin, domain.ErrImpersonationTargetPrivileged:
+			response.Error(c, http.StatusForbidden, err.Error(), err)
+		case domain.ErrImpersonationDisabled:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case domain.ErrAccountNotFound:
+			response.Error(c, http.StatusNotFound, "member not found", err)
+		default:
+			h.logger.Error("failed to start impersonation", map[string]interface{}{"org_id": reqCtx.OrganizationID, "member_id": memberID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to start impersonation", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, impersonation)
+}
+
+// EndImpersonation godoc
+// @Summary End impersonation
+// @Description Revokes the impersonation token used to call this endpoint. Call it when done instead of waiting for the token to expire.
+// @Tags auth
+// @Produce json
+// @Success 204 "Impersonation ended"
+// @Failure 400 {object} map[string]string "Not an impersonation token"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/impersonation/end [post]
+func (h *ImpersonationHandler) EndImpersonation(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	if err := h.impersonationService.EndImpersonation(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, reqCtx.Identity); err != nil {
+		switch err {
+		case domain.ErrImpersonationNotActive:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		default:
+			h.logger.Error("failed to end impersonation", map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": reqCtx.AccountID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to end impersonation", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusNoContent, nil)
+}
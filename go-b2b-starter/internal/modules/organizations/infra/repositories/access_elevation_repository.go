@@ -0,0 +1,162 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// accessElevationRepository implements domain.AccessElevationRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type accessElevationRepository struct {
+	store sqlc.Store
+}
+
+// NewAccessElevationRepository creates a new AccessElevationRepository implementation.
+func NewAccessElevationRepository(store sqlc.Store) domain.AccessElevationRepository {
+	return &accessElevationRepository{store: store}
+}
+
+func (r *accessElevationRepository) Create(ctx context.Context, elevation *domain.AccessElevation) (*domain.AccessElevation, error) {
+	params := sqlc.CreateAccessElevationParams{
+		OrganizationID:  elevation.OrganizationID,
+		AccountID:       elevation.AccountID,
+		RequestedRole:   elevation.RequestedRole,
+		Reason:          elevation.Reason,
+		DurationMinutes: elevation.DurationMinutes,
+	}
+
+	result, err := r.store.CreateAccessElevation(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create access elevation: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *accessElevationRepository) GetByID(ctx context.Context, orgID, elevationID int32) (*domain.AccessElevation, error) {
+	params := sqlc.GetAccessElevationByIDParams{
+		ID:             elevationID,
+		OrganizationID: orgID,
+	}
+
+	result, err := r.store.GetAccessElevationByID(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrElevationNotFound
+		}
+		return nil, fmt.Errorf("failed to get access elevation: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *accessElevationRepository) ListByOrganization(ctx context.Context, orgID int32, status string) ([]*domain.AccessElevation, error) {
+	params := sqlc.ListAccessElevationsByOrganizationParams{
+		OrganizationID: orgID,
+		Status:         helpers.ToPgText(status),
+	}
+
+	results, err := r.store.ListAccessElevationsByOrganization(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list access elevations: %w", err)
+	}
+
+	elevations := make([]*domain.AccessElevation, len(results))
+	for i, result := range results {
+		elevations[i] = r.mapToDomain(&result)
+	}
+
+	return elevations, nil
+}
+
+func (r *accessElevationRepository) GetActive(ctx context.Context, orgID, accountID int32) (*domain.AccessElevation, error) {
+	params := sqlc.GetActiveAccessElevationParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	}
+
+	result, err := r.store.GetActiveAccessElevation(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrElevationNotFound
+		}
+		return nil, fmt.Errorf("failed to get active access elevation: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *accessElevationRepository) Decide(ctx context.Context, elevation *domain.AccessElevation) (*domain.AccessElevation, error) {
+	params := sqlc.DecideAccessElevationParams{
+		Status:             elevation.Status,
+		DecidedByAccountID: helpers.ToPgInt4Ptr(elevation.DecidedByAccountID),
+		DecisionNote:       elevation.DecisionNote,
+		ID:                 elevation.ID,
+		OrganizationID:     elevation.OrganizationID,
+	}
+
+	result, err := r.store.DecideAccessElevation(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrElevationNotPending
+		}
+		return nil, fmt.Errorf("failed to decide access elevation: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *accessElevationRepository) Revoke(ctx context.Context, orgID, elevationID int32) (*domain.AccessElevation, error) {
+	params := sqlc.RevokeAccessElevationParams{
+		ID:             elevationID,
+		OrganizationID: orgID,
+	}
+
+	result, err := r.store.RevokeAccessElevation(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrElevationNotActive
+		}
+		return nil, fmt.Errorf("failed to revoke access elevation: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+// mapToDomain converts SQLC access elevation to domain entity
+func (r *accessElevationRepository) mapToDomain(sqlcElevation *sqlc.OrganizationsAccessElevation) *domain.AccessElevation {
+	elevation := &domain.AccessElevation{
+		ID:              sqlcElevation.ID,
+		OrganizationID:  sqlcElevation.OrganizationID,
+		AccountID:       sqlcElevation.AccountID,
+		RequestedRole:   sqlcElevation.RequestedRole,
+		Reason:          sqlcElevation.Reason,
+		DurationMinutes: sqlcElevation.DurationMinutes,
+		Status:          sqlcElevation.Status,
+		DecisionNote:    sqlcElevation.DecisionNote,
+		CreatedAt:       sqlcElevation.CreatedAt.Time,
+		UpdatedAt:       sqlcElevation.UpdatedAt.Time,
+	}
+
+	if sqlcElevation.DecidedByAccountID.Valid {
+		decidedBy := sqlcElevation.DecidedByAccountID.Int32
+		elevation.DecidedByAccountID = &decidedBy
+	}
+
+	if sqlcElevation.DecidedAt.Valid {
+		decidedAt := sqlcElevation.DecidedAt.Time
+		elevation.DecidedAt = &decidedAt
+	}
+
+	if sqlcElevation.ExpiresAt.Valid {
+		expiresAt := sqlcElevation.ExpiresAt.Time
+		elevation.ExpiresAt = &expiresAt
+	}
+
+	return elevation
+}
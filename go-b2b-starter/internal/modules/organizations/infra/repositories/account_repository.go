@@ -3,29 +3,73 @@ package repositories
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
 
 	"github.com/moasq/go-b2b-starter/internal/db/helpers"
 	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+)
+
+// likeEscaper escapes LIKE wildcards so the filter query is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Unique keys of organizations.accounts that identify a user
+const (
+	accountEmailIndex       = "idx_accounts_org_email_lower"
+	accountMemberConstraint = "accounts_organization_id_stytch_member_id_key"
 )
 
 // accountRepository implements domain.AccountRepository using SQLC internally.
 // SQLC types are never exposed outside this package.
 type accountRepository struct {
-	store sqlc.Store
+	store      sqlc.Store
+	normalizer domain.EmailNormalizer
+	seats      paywall.SeatLock
 }
 
 // NewAccountRepository creates a new AccountRepository implementation.
-func NewAccountRepository(store sqlc.Store) domain.AccountRepository {
-	return &accountRepository{store: store}
+// Emails are normalized with normalizer before they are stored or looked up,
+// and CreateWithinSeats checks the seats with seats.
+func NewAccountRepository(store sqlc.Store, normalizer domain.EmailNormalizer, seats paywall.SeatLock) domain.AccountRepository {
+	return &accountRepository{store: store, normalizer: normalizer, seats: seats}
 }
 
 func (r *accountRepository) Create(ctx context.Context, account *domain.Account) (*domain.Account, error) {
+	return r.create(ctx, r.store, account)
+}
+
+func (r *accountRepository) CreateWithinSeats(ctx context.Context, account *domain.Account) (*domain.Account, error) {
+	var created *domain.Account
+	err := r.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		if err := r.seats.CheckSeatsLocked(ctx, q, account.OrganizationID); err != nil {
+			return err
+		}
+
+		var err error
+		created, err = r.create(ctx, q, account)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *accountRepository) create(ctx context.Context, q sqlc.Querier, account *domain.Account) (*domain.Account, error) {
+	kind := account.Kind
+	if kind == "" {
+		kind = domain.AccountKindHuman
+	}
+
 	params := sqlc.CreateAccountParams{
 		OrganizationID:      account.OrganizationID,
-		Email:               account.Email,
+		Email:               r.normalizer.Normalize(account.Email),
 		FullName:            account.FullName,
 		StytchMemberID:      helpers.ToPgText(account.StytchMemberID),
 		StytchRoleID:        helpers.ToPgText(account.StytchRoleID),
@@ -33,10 +77,14 @@ func (r *accountRepository) Create(ctx context.Context, account *domain.Account)
 		StytchEmailVerified: account.StytchEmailVerified,
 		Role:                account.Role,
 		Status:              account.Status,
+		Kind:                kind,
 	}
 
-	result, err := r.store.CreateAccount(ctx, params)
+	result, err := q.CreateAccount(ctx, params)
 	if err != nil {
+		if isDuplicateUser(err) {
+			return nil, domain.ErrUserAlreadyExists
+		}
 		return nil, fmt.Errorf("failed to create account: %w", err)
 	}
 
@@ -62,7 +110,7 @@ func (r *accountRepository) GetByID(ctx context.Context, orgID, accountID int32)
 
 func (r *accountRepository) GetByEmail(ctx context.Context, orgID int32, email string) (*domain.Account, error) {
 	params := sqlc.GetAccountByEmailParams{
-		Email:          email,
+		Email:          r.normalizer.Normalize(email),
 		OrganizationID: orgID,
 	}
 
@@ -77,6 +125,21 @@ func (r *accountRepository) GetByEmail(ctx context.Context, orgID int32, email s
 	return r.mapToDomain(&result), nil
 }
 
+func (r *accountRepository) GetByPublicID(ctx context.Context, orgID int32, publicID uuid.UUID) (*domain.Account, error) {
+	result, err := r.store.GetAccountByPublicID(ctx, sqlc.GetAccountByPublicIDParams{
+		PublicID:       helpers.ToPgUUID(publicID),
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get account by public ID: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
 func (r *accountRepository) ListByOrganization(ctx context.Context, orgID int32) ([]*domain.Account, error) {
 	results, err := r.store.ListAccountsByOrganization(ctx, orgID)
 	if err != nil {
@@ -91,6 +154,53 @@ func (r *accountRepository) ListByOrganization(ctx context.Context, orgID int32)
 	return accounts, nil
 }
 
+func (r *accountRepository) ListFiltered(ctx context.Context, orgID int32, filter domain.AccountFilter, limit, offset int32) ([]*domain.Account, error) {
+	results, err := r.store.ListAccountsFiltered(ctx, sqlc.ListAccountsFilteredParams{
+		OrganizationID: orgID,
+		Pattern:        helpers.ToPgText(likeEscaper.Replace(filter.Query)),
+		EmailPattern:   helpers.ToPgText(likeEscaper.Replace(filter.Email)),
+		Status:         helpers.ToPgText(filter.Status),
+		Role:           helpers.ToPgText(filter.Role),
+		EmailVerified:  helpers.ToPgBoolPtr(filter.EmailVerified),
+		CreatedAfter:   toPgTimestamp(filter.CreatedAfter),
+		CreatedBefore:  toPgTimestamp(filter.CreatedBefore),
+		Tag:            helpers.ToPgText(filter.Tag),
+		Service:        helpers.ToPgBoolPtr(filter.Service),
+		Sort:           string(filter.Sort),
+		RowLimit:       limit,
+		RowOffset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list filtered accounts: %w", err)
+	}
+
+	accounts := make([]*domain.Account, len(results))
+	for i, result := range results {
+		accounts[i] = r.mapToDomain(&result)
+	}
+
+	return accounts, nil
+}
+
+func (r *accountRepository) CountFiltered(ctx context.Context, orgID int32, filter domain.AccountFilter) (int64, error) {
+	count, err := r.store.CountAccountsFiltered(ctx, sqlc.CountAccountsFilteredParams{
+		OrganizationID: orgID,
+		Pattern:        helpers.ToPgText(likeEscaper.Replace(filter.Query)),
+		EmailPattern:   helpers.ToPgText(likeEscaper.Replace(filter.Email)),
+		Status:         helpers.ToPgText(filter.Status),
+		Role:           helpers.ToPgText(filter.Role),
+		EmailVerified:  helpers.ToPgBoolPtr(filter.EmailVerified),
+		CreatedAfter:   toPgTimestamp(filter.CreatedAfter),
+		CreatedBefore:  toPgTimestamp(filter.CreatedBefore),
+		Tag:            helpers.ToPgText(filter.Tag),
+		Service:        helpers.ToPgBoolPtr(filter.Service),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count filtered accounts: %w", err)
+	}
+	return count, nil
+}
+
 func (r *accountRepository) Update(ctx context.Context, account *domain.Account) (*domain.Account, error) {
 	params := sqlc.UpdateAccountParams{
 		ID:                  account.ID,
@@ -129,6 +239,9 @@ func (r *accountRepository) UpdateStytchInfo(ctx context.Context, orgID, account
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrAccountNotFound
 		}
+		if isDuplicateUser(err) {
+			return nil, domain.ErrUserAlreadyExists
+		}
 		return nil, fmt.Errorf("failed to update account Stytch info: %w", err)
 	}
 
@@ -152,6 +265,100 @@ func (r *accountRepository) UpdateLastLogin(ctx context.Context, orgID, accountI
 	return r.mapToDomain(&result), nil
 }
 
+func (r *accountRepository) UpdateEmail(ctx context.Context, orgID, accountID int32, email string) (*domain.Account, error) {
+	params := sqlc.UpdateAccountEmailParams{
+		ID:             accountID,
+		OrganizationID: orgID,
+		Email:          r.normalizer.Normalize(email),
+	}
+
+	result, err := r.store.UpdateAccountEmail(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrAccountNotFound
+		}
+		if isDuplicateUser(err) {
+			return nil, domain.ErrUserAlreadyExists
+		}
+		return nil, fmt.Errorf("failed to update account email: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *accountRepository) GetMetadata(ctx context.Context, orgID, accountID int32) (domain.AccountMetadata, error) {
+	result, err := r.store.GetAccountMetadata(ctx, sqlc.GetAccountMetadataParams{
+		ID:             accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get account metadata: %w", err)
+	}
+
+	return domain.DecodeAccountMetadata(result)
+}
+
+func (r *accountRepository) PatchMetadata(ctx context.Context, orgID, accountID int32, patch domain.AccountMetadata) (domain.AccountMetadata, error) {
+	encoded, err := json.Marshal(patch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode account metadata patch: %w", err)
+	}
+
+	result, err := r.store.PatchAccountMetadata(ctx, sqlc.PatchAccountMetadataParams{
+		Patch:          encoded,
+		ID:             accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to patch account metadata: %w", err)
+	}
+
+	return domain.DecodeAccountMetadata(result)
+}
+
+func (r *accountRepository) SetAvatar(ctx context.Context, orgID, accountID int32, avatar *domain.AccountAvatar) (*int32, error) {
+	params := sqlc.SetAccountAvatarParams{
+		ID:             accountID,
+		OrganizationID: orgID,
+	}
+	if avatar != nil {
+		params.AvatarFileID = helpers.ToPgInt4(avatar.FileID)
+		params.AvatarKey = helpers.ToPgText(avatar.Key)
+		params.AvatarUrl = helpers.ToPgText(avatar.URL)
+	}
+
+	previous, err := r.store.SetAccountAvatar(ctx, params)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to set account avatar: %w", err)
+	}
+
+	return helpers.FromPgInt4Ptr(previous), nil
+}
+
+func (r *accountRepository) GetAvatarFileID(ctx context.Context, key string) (int32, error) {
+	fileID, err := r.store.GetAvatarFileIDByKey(ctx, key)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, domain.ErrAvatarNotFound
+		}
+		return 0, fmt.Errorf("failed to get avatar: %w", err)
+	}
+	if !fileID.Valid {
+		return 0, domain.ErrAvatarNotFound
+	}
+
+	return fileID.Int32, nil
+}
+
 func (r *accountRepository) Delete(ctx context.Context, orgID, accountID int32) error {
 	params := sqlc.DeleteAccountParams{
 		ID:             accountID,
@@ -188,6 +395,28 @@ func (r *accountRepository) GetOrganization(ctx context.Context, accountID int32
 	}, nil
 }
 
+func (r *accountRepository) ListMembershipsByEmail(ctx context.Context, email string) ([]*domain.Membership, error) {
+	results, err := r.store.ListMembershipsByEmail(ctx, r.normalizer.Normalize(email))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships by email: %w", err)
+	}
+
+	memberships := make([]*domain.Membership, len(results))
+	for i, result := range results {
+		memberships[i] = &domain.Membership{
+			OrganizationID: result.OrganizationID,
+			Slug:           result.Slug,
+			Name:           result.Name,
+			Environment:    result.Environment,
+			StytchOrgID:    helpers.FromPgText(result.StytchOrgID),
+			AccountID:      result.AccountID,
+			Role:           result.Role,
+		}
+	}
+
+	return memberships, nil
+}
+
 func (r *accountRepository) CheckPermission(ctx context.Context, orgID, accountID int32) (*domain.AccountPermission, error) {
 	params := sqlc.CheckAccountPermissionParams{
 		ID:             accountID,
@@ -252,6 +481,7 @@ func (r *accountRepository) GetStats(ctx context.Context, accountID int32) (*dom
 func (r *accountRepository) mapToDomain(sqlcAccount *sqlc.OrganizationsAccount) *domain.Account {
 	account := &domain.Account{
 		ID:                  sqlcAccount.ID,
+		PublicID:            helpers.FromPgUUID(sqlcAccount.PublicID),
 		OrganizationID:      sqlcAccount.OrganizationID,
 		Email:               sqlcAccount.Email,
 		FullName:            sqlcAccount.FullName,
@@ -261,8 +491,11 @@ func (r *accountRepository) mapToDomain(sqlcAccount *sqlc.OrganizationsAccount)
 		StytchEmailVerified: sqlcAccount.StytchEmailVerified,
 		Role:                sqlcAccount.Role,
 		Status:              sqlcAccount.Status,
+		Kind:                sqlcAccount.Kind,
 		CreatedAt:           sqlcAccount.CreatedAt.Time,
 		UpdatedAt:           sqlcAccount.UpdatedAt.Time,
+		AvatarURL:           helpers.FromPgText(sqlcAccount.AvatarUrl),
+		AvatarFileID:        helpers.FromPgInt4Ptr(sqlcAccount.AvatarFileID),
 	}
 
 	// Handle nullable LastLoginAt
@@ -270,5 +503,25 @@ func (r *accountRepository) mapToDomain(sqlcAccount *sqlc.OrganizationsAccount)
 		account.LastLoginAt = &sqlcAccount.LastLoginAt.Time
 	}
 
+	// The column is a JSON object by constraint; metadata that fails to decode is left empty
+	account.Metadata, _ = domain.DecodeAccountMetadata(sqlcAccount.Metadata)
+	if account.Metadata == nil {
+		account.Metadata = domain.AccountMetadata{}
+	}
+
 	return account
 }
+
+// isDuplicateUser reports whether err violates the organization's unique email
+// or auth member key. Other unique keys, such as the public ID, are not a
+// duplicate user and are returned as internal errors.
+func isDuplicateUser(err error) bool {
+	if sqlc.ErrorCode(err) != sqlc.UniqueViolation {
+		return false
+	}
+	switch sqlc.ConstraintName(err) {
+	case accountEmailIndex, accountMemberConstraint:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+)
+
+// accountStatusRepository implements domain.AccountStatusRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type accountStatusRepository struct {
+	store sqlc.Store
+	seats paywall.SeatLock
+}
+
+// NewAccountStatusRepository creates a new AccountStatusRepository implementation.
+// ChangeStatusWithinSeats checks the seats with seats.
+func NewAccountStatusRepository(store sqlc.Store, seats paywall.SeatLock) domain.AccountStatusRepository {
+	return &accountStatusRepository{store: store, seats: seats}
+}
+
+func (r *accountStatusRepository) ChangeStatus(ctx context.Context, change *domain.AccountStatusChange) (*domain.AccountStatusChange, error) {
+	return r.changeStatus(ctx, r.store, change)
+}
+
+func (r *accountStatusRepository) ChangeStatusWithinSeats(ctx context.Context, change *domain.AccountStatusChange) (*domain.AccountStatusChange, error) {
+	var recorded *domain.AccountStatusChange
+	err := r.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		if err := r.seats.CheckSeatsLocked(ctx, q, change.OrganizationID); err != nil {
+			return err
+		}
+
+		var err error
+		recorded, err = r.changeStatus(ctx, q, change)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recorded, nil
+}
+
+func (r *accountStatusRepository) changeStatus(ctx context.Context, q sqlc.Querier, change *domain.AccountStatusChange) (*domain.AccountStatusChange, error) {
+	result, err := q.ChangeAccountStatus(ctx, sqlc.ChangeAccountStatusParams{
+		ToStatus:       change.ToStatus,
+		AccountID:      change.AccountID,
+		OrganizationID: change.OrganizationID,
+		FromStatus:     change.FromStatus,
+		Reason:         change.Reason,
+		ActorAccountID: helpers.ToPgInt4Ptr(change.ActorAccountID),
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrAccountStatusChanged
+		}
+		return nil, fmt.Errorf("failed to change account status: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *accountStatusRepository) ListChanges(ctx context.Context, orgID, accountID, limit int32) ([]*domain.AccountStatusChange, error) {
+	results, err := r.store.ListAccountStatusChanges(ctx, sqlc.ListAccountStatusChangesParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+		RowLimit:       limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account status changes: %w", err)
+	}
+
+	changes := make([]*domain.AccountStatusChange, len(results))
+	for i, result := range results {
+		changes[i] = r.mapToDomain(&result)
+	}
+	return changes, nil
+}
+
+func (r *accountStatusRepository) mapToDomain(change *sqlc.OrganizationsAccountStatusChange) *domain.AccountStatusChange {
+	return &domain.AccountStatusChange{
+		ID:             change.ID,
+		OrganizationID: change.OrganizationID,
+		AccountID:      change.AccountID,
+		FromStatus:     change.FromStatus,
+		ToStatus:       change.ToStatus,
+		Reason:         change.Reason,
+		ActorAccountID: helpers.FromPgInt4Ptr(change.ActorAccountID),
+		CreatedAt:      change.CreatedAt.Time,
+	}
+}
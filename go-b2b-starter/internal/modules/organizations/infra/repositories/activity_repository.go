@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// activityRepository implements domain.ActivityRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type activityRepository struct {
+	store sqlc.Store
+}
+
+// NewActivityRepository creates a new ActivityRepository implementation.
+func NewActivityRepository(store sqlc.Store) domain.ActivityRepository {
+	return &activityRepository{store: store}
+}
+
+func (r *activityRepository) Record(ctx context.Context, orgID, accountID int32) error {
+	if err := r.store.RecordAccountActivity(ctx, sqlc.RecordAccountActivityParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	}); err != nil {
+		return fmt.Errorf("failed to record account activity: %w", err)
+	}
+	return nil
+}
+
+func (r *activityRepository) LastActiveAt(ctx context.Context, orgID, accountID int32) (*time.Time, error) {
+	lastActiveAt, err := r.store.GetAccountLastActive(ctx, sqlc.GetAccountLastActiveParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get account activity: %w", err)
+	}
+	return &lastActiveAt.Time, nil
+}
+
+func (r *activityRepository) RecordEvent(ctx context.Context, event *domain.AccountActivityEvent, keep int32) (bool, error) {
+	details := event.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return false, fmt.Errorf("failed to encode activity details: %w", err)
+	}
+
+	rows, err := r.store.CreateAccountActivityEvent(ctx, sqlc.CreateAccountActivityEventParams{
+		EventID:        event.EventID,
+		OrganizationID: event.OrganizationID,
+		AccountID:      event.AccountID,
+		ActivityType:   string(event.Type),
+		Details:        encoded,
+		OccurredAt:     toPgTimestamp(event.OccurredAt),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to record account activity event: %w", err)
+	}
+	if rows == 0 {
+		return false, nil
+	}
+
+	if err := r.store.TouchAccountActivityTimestamp(ctx, sqlc.TouchAccountActivityTimestampParams{
+		AccountID:      event.AccountID,
+		OrganizationID: event.OrganizationID,
+		ActivityType:   string(event.Type),
+		LastOccurredAt: toPgTimestamp(event.OccurredAt),
+	}); err != nil {
+		return true, fmt.Errorf("failed to record account activity timestamp: %w", err)
+	}
+
+	if _, err := r.store.TrimAccountActivityFeed(ctx, sqlc.TrimAccountActivityFeedParams{
+		AccountID: event.AccountID,
+		Keep:      keep,
+	}); err != nil {
+		return true, fmt.Errorf("failed to trim account activity feed: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *activityRepository) ListEvents(ctx context.Context, orgID, accountID int32, filter domain.AccountActivityFilter) ([]*domain.AccountActivityEvent, error) {
+	params := sqlc.ListAccountActivityEventsParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+		RowLimit:       filter.Limit,
+	}
+	if filter.Type != "" {
+		params.ActivityType = helpers.ToPgText(string(filter.Type))
+	}
+
+	results, err := r.store.ListAccountActivityEvents(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account activity: %w", err)
+	}
+
+	events := make([]*domain.AccountActivityEvent, len(results))
+	for i, result := range results {
+		details := map[string]any{}
+		if len(result.Details) > 0 {
+			if err := json.Unmarshal(result.Details, &details); err != nil {
+				return nil, fmt.Errorf("failed to decode activity details: %w", err)
+			}
+		}
+
+		events[i] = &domain.AccountActivityEvent{
+			ID:             result.ID,
+			EventID:        result.EventID,
+			OrganizationID: result.OrganizationID,
+			AccountID:      result.AccountID,
+			Type:           domain.ActivityType(result.ActivityType),
+			Details:        details,
+			OccurredAt:     result.OccurredAt.Time,
+		}
+	}
+	return events, nil
+}
+
+func (r *activityRepository) ListTimestamps(ctx context.Context, orgID, accountID int32) ([]*domain.ActivityTimestamp, error) {
+	results, err := r.store.ListAccountActivityTimestamps(ctx, sqlc.ListAccountActivityTimestampsParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account activity timestamps: %w", err)
+	}
+
+	timestamps := make([]*domain.ActivityTimestamp, len(results))
+	for i, result := range results {
+		timestamps[i] = &domain.ActivityTimestamp{
+			Type:           domain.ActivityType(result.ActivityType),
+			LastOccurredAt: result.LastOccurredAt.Time,
+			Occurrences:    result.Occurrences,
+		}
+	}
+	return timestamps, nil
+}
+
+func (r *activityRepository) DeleteEventsBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	rows, err := r.store.DeleteAccountActivityEventsBefore(ctx, toPgTimestamp(cutoff))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete account activity: %w", err)
+	}
+	return rows, nil
+}
+
+func (r *activityRepository) ReactivateAccount(ctx context.Context, orgID, accountID int32) (bool, error) {
+	rows, err := r.store.ReactivateDormantAccount(ctx, sqlc.ReactivateDormantAccountParams{
+		ID:             accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to reactivate account: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *activityRepository) ReactivateOrganization(ctx context.Context, orgID int32) (bool, error) {
+	rows, err := r.store.ReactivateDormantOrganization(ctx, orgID)
+	if err != nil {
+		return false, fmt.Errorf("failed to reactivate organization: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (r *activityRepository) MarkDormantAccounts(ctx context.Context, cutoff time.Time, limit int32) ([]*domain.DormantAccount, error) {
+	results, err := r.store.MarkDormantAccounts(ctx, sqlc.MarkDormantAccountsParams{
+		Cutoff:   toPgTimestamp(cutoff),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark dormant accounts: %w", err)
+	}
+
+	accounts := make([]*domain.DormantAccount, len(results))
+	for i, result := range results {
+		accounts[i] = &domain.DormantAccount{
+			AccountID:      result.ID,
+			OrganizationID: result.OrganizationID,
+			Email:          result.Email,
+			FullName:       result.FullName,
+			LastActiveAt:   result.LastActiveAt.Time,
+		}
+	}
+	return accounts, nil
+}
+
+func (r *activityRepository) MarkDormantOrganizations(ctx context.Context, cutoff time.Time, limit int32) ([]*domain.DormantOrganization, error) {
+	results, err := r.store.MarkDormantOrganizations(ctx, sqlc.MarkDormantOrganizationsParams{
+		Cutoff:   toPgTimestamp(cutoff),
+		RowLimit: limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark dormant organizations: %w", err)
+	}
+
+	orgs := make([]*domain.DormantOrganization, len(results))
+	for i, result := range results {
+		orgs[i] = &domain.DormantOrganization{
+			OrganizationID: result.ID,
+			Slug:           result.Slug,
+			Name:           result.Name,
+			LastActiveAt:   result.LastActiveAt.Time,
+		}
+	}
+	return orgs, nil
+}
@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// apiUsageRepository implements domain.APIUsageRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type apiUsageRepository struct {
+	store sqlc.Store
+}
+
+// NewAPIUsageRepository creates a new APIUsageRepository implementation.
+func NewAPIUsageRepository(store sqlc.Store) domain.APIUsageRepository {
+	return &apiUsageRepository{store: store}
+}
+
+func (r *apiUsageRepository) Save(ctx context.Context, usage *domain.APIUsageWindow) error {
+	err := r.store.UpsertAPIUsage(ctx, sqlc.UpsertAPIUsageParams{
+		OrganizationID: usage.OrganizationID,
+		AccountID:      usage.AccountID,
+		WindowStart:    pgtype.Timestamp{Time: usage.WindowStart, Valid: true},
+		WindowEnd:      pgtype.Timestamp{Time: usage.WindowEnd, Valid: true},
+		RequestCount:   usage.RequestCount,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save api usage: %w", err)
+	}
+	return nil
+}
+
+func (r *apiUsageRepository) List(ctx context.Context, orgID, accountID, limit int32) ([]*domain.APIUsageWindow, error) {
+	results, err := r.store.ListAPIUsage(ctx, sqlc.ListAPIUsageParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+		RowLimit:       limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api usage: %w", err)
+	}
+
+	windows := make([]*domain.APIUsageWindow, len(results))
+	for i, result := range results {
+		windows[i] = &domain.APIUsageWindow{
+			OrganizationID: result.OrganizationID,
+			AccountID:      result.AccountID,
+			WindowStart:    result.WindowStart.Time,
+			WindowEnd:      result.WindowEnd.Time,
+			RequestCount:   result.RequestCount,
+		}
+	}
+	return windows, nil
+}
+
+func (r *apiUsageRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	deleted, err := r.store.DeleteAPIUsageBefore(ctx, pgtype.Timestamp{Time: cutoff, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete api usage: %w", err)
+	}
+	return deleted, nil
+}
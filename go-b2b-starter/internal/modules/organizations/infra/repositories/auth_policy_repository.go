@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// authPolicyRepository implements domain.AuthPolicyRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type authPolicyRepository struct {
+	store sqlc.Store
+}
+
+// NewAuthPolicyRepository creates a new AuthPolicyRepository implementation.
+func NewAuthPolicyRepository(store sqlc.Store) domain.AuthPolicyRepository {
+	return &authPolicyRepository{store: store}
+}
+
+func (r *authPolicyRepository) GetByOrganization(ctx context.Context, orgID int32) (*domain.AuthPolicy, error) {
+	result, err := r.store.GetOrganizationAuthPolicy(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrAuthPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to get auth policy: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *authPolicyRepository) Upsert(ctx context.Context, policy *domain.AuthPolicy) (*domain.AuthPolicy, error) {
+	allowedMethods := policy.AllowedAuthMethods
+	if allowedMethods == nil {
+		allowedMethods = []string{}
+	}
+
+	params := sqlc.UpsertOrganizationAuthPolicyParams{
+		OrganizationID:       policy.OrganizationID,
+		MfaRequired:          policy.MFARequired,
+		SessionMaxAgeSeconds: policy.SessionMaxAgeSeconds,
+		AllowedAuthMethods:   allowedMethods,
+		UpdatedByAccountID:   helpers.ToPgInt4Ptr(policy.UpdatedByAccountID),
+	}
+
+	result, err := r.store.UpsertOrganizationAuthPolicy(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save auth policy: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *authPolicyRepository) Delete(ctx context.Context, orgID int32) error {
+	rows, err := r.store.DeleteOrganizationAuthPolicy(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to delete auth policy: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrAuthPolicyNotFound
+	}
+
+	return nil
+}
+
+// mapToDomain converts SQLC auth policy to domain entity
+func (r *authPolicyRepository) mapToDomain(sqlcPolicy *sqlc.OrganizationsAuthPolicy) *domain.AuthPolicy {
+	policy := &domain.AuthPolicy{
+		OrganizationID:       sqlcPolicy.OrganizationID,
+		MFARequired:          sqlcPolicy.MfaRequired,
+		SessionMaxAgeSeconds: sqlcPolicy.SessionMaxAgeSeconds,
+		AllowedAuthMethods:   sqlcPolicy.AllowedAuthMethods,
+		CreatedAt:            sqlcPolicy.CreatedAt.Time,
+		UpdatedAt:            sqlcPolicy.UpdatedAt.Time,
+	}
+
+	if policy.AllowedAuthMethods == nil {
+		policy.AllowedAuthMethods = []string{}
+	}
+
+	if sqlcPolicy.UpdatedByAccountID.Valid {
+		updatedBy := sqlcPolicy.UpdatedByAccountID.Int32
+		policy.UpdatedByAccountID = &updatedBy
+	}
+
+	return policy
+}
@@ -0,0 +1,333 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+const (
+	// Redis keys of cached accounts: the account by ID, the ID of the account
+	// with a normalized email, and the account's cache generation
+	accountCacheKeyPattern           = "organizations:account:%d:%d"
+	accountEmailCacheKeyPattern      = "organizations:account_email:%d:%s"
+	accountGenerationCacheKeyPattern = "organizations:account_generation:%d:%d"
+)
+
+// cachedAccountRepository serves GetByID and GetByEmail from Redis, falling
+// back to the database on a miss or a Redis failure. Writes clear the
+// account's entry.
+//
+// Email entries only point at an account ID and are checked against the
+// cached account's email, so clearing the ID entry also stops stale email
+// lookups after an email change.
+//
+// Clearing an account also replaces its generation. Each entry records the
+// generation read before the account was loaded, and entries of an older
+// generation are misses, so a lookup that loaded the account just before a
+// change cannot cache the old row after the change cleared it.
+type cachedAccountRepository struct {
+	domain.AccountRepository
+	redis      redis.Client
+	normalizer domain.EmailNormalizer
+	ttl        time.Duration
+}
+
+// NewCachedAccountRepository wraps repo with a Redis read-through cache for
+// GetByID and GetByEmail. It returns repo unchanged if ttl is not positive.
+func NewCachedAccountRepository(repo domain.AccountRepository, redisClient redis.Client, normalizer domain.EmailNormalizer, ttl time.Duration) domain.AccountRepository {
+	if ttl <= 0 {
+		return repo
+	}
+	return &cachedAccountRepository{
+		AccountRepository: repo,
+		redis:             redisClient,
+		normalizer:        normalizer,
+		ttl:               ttl,
+	}
+}
+
+func (r *cachedAccountRepository) GetByID(ctx context.Context, orgID, accountID int32) (*domain.Account, error) {
+	generation, cacheable := r.generation(ctx, orgID, accountID)
+	if cacheable {
+		if account, ok := r.readAccount(ctx, orgID, accountID, generation); ok {
+			return account, nil
+		}
+	}
+
+	account, err := r.AccountRepository.GetByID(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if cacheable {
+		r.writeAccount(ctx, account, generation)
+	}
+	return account, nil
+}
+
+func (r *cachedAccountRepository) GetByEmail(ctx context.Context, orgID int32, email string) (*domain.Account, error) {
+	email = r.normalizer.Normalize(email)
+
+	if cached, err := r.redis.Get(ctx, fmt.Sprintf(accountEmailCacheKeyPattern, orgID, email)); err == nil {
+		if accountID, err := strconv.ParseInt(cached, 10, 32); err == nil {
+			if generation, ok := r.generation(ctx, orgID, int32(accountID)); ok {
+				if account, ok := r.readAccount(ctx, orgID, int32(accountID), generation); ok && account.Email == email {
+					return account, nil
+				}
+			}
+		}
+	}
+
+	account, err := r.AccountRepository.GetByEmail(ctx, orgID, email)
+	if err != nil {
+		return nil, err
+	}
+
+	// The generation must be read before the account is loaded, which needs
+	// its ID, so the entry is loaded again through GetByID
+	cached, err := r.GetByID(ctx, orgID, account.ID)
+	if err != nil || cached.Email != email {
+		return account, nil
+	}
+	return cached, nil
+}
+
+func (r *cachedAccountRepository) Update(ctx context.Context, account *domain.Account) (*domain.Account, error) {
+	updated, err := r.AccountRepository.Update(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	clearCachedAccount(ctx, r.redis, account.OrganizationID, account.ID, r.ttl)
+	return updated, nil
+}
+
+func (r *cachedAccountRepository) UpdateStytchInfo(ctx context.Context, orgID, accountID int32, stytchMemberID, stytchRoleID, stytchRoleSlug string, stytchEmailVerified bool) (*domain.Account, error) {
+	updated, err := r.AccountRepository.UpdateStytchInfo(ctx, orgID, accountID, stytchMemberID, stytchRoleID, stytchRoleSlug, stytchEmailVerified)
+	if err != nil {
+		return nil, err
+	}
+	clearCachedAccount(ctx, r.redis, orgID, accountID, r.ttl)
+	return updated, nil
+}
+
+func (r *cachedAccountRepository) UpdateLastLogin(ctx context.Context, orgID, accountID int32) (*domain.Account, error) {
+	updated, err := r.AccountRepository.UpdateLastLogin(ctx, orgID, accountID)
+	if err != nil {
+		return nil, err
+	}
+	clearCachedAccount(ctx, r.redis, orgID, accountID, r.ttl)
+	return updated, nil
+}
+
+func (r *cachedAccountRepository) UpdateEmail(ctx context.Context, orgID, accountID int32, email string) (*domain.Account, error) {
+	updated, err := r.AccountRepository.UpdateEmail(ctx, orgID, accountID, email)
+	if err != nil {
+		return nil, err
+	}
+	clearCachedAccount(ctx, r.redis, orgID, accountID, r.ttl)
+	return updated, nil
+}
+
+func (r *cachedAccountRepository) Delete(ctx context.Context, orgID, accountID int32) error {
+	if err := r.AccountRepository.Delete(ctx, orgID, accountID); err != nil {
+		return err
+	}
+	clearCachedAccount(ctx, r.redis, orgID, accountID, r.ttl)
+	return nil
+}
+
+func (r *cachedAccountRepository) PatchMetadata(ctx context.Context, orgID, accountID int32, patch domain.AccountMetadata) (domain.AccountMetadata, error) {
+	metadata, err := r.AccountRepository.PatchMetadata(ctx, orgID, accountID, patch)
+	if err != nil {
+		return nil, err
+	}
+	clearCachedAccount(ctx, r.redis, orgID, accountID, r.ttl)
+	return metadata, nil
+}
+
+func (r *cachedAccountRepository) SetAvatar(ctx context.Context, orgID, accountID int32, avatar *domain.AccountAvatar) (*int32, error) {
+	previous, err := r.AccountRepository.SetAvatar(ctx, orgID, accountID, avatar)
+	if err != nil {
+		return nil, err
+	}
+	clearCachedAccount(ctx, r.redis, orgID, accountID, r.ttl)
+	return previous, nil
+}
+
+// cachedAccount is a cache entry: the account and the generation it was
+// loaded under
+type cachedAccount struct {
+	Generation string          `json:"generation"`
+	Account    *domain.Account `json:"account"`
+}
+
+// generation returns the account's cache generation, starting a new one if
+// it has none. The account may only be cached under the returned generation
+// if ok; when Redis fails the lookup goes to the database.
+func (r *cachedAccountRepository) generation(ctx context.Context, orgID, accountID int32) (string, bool) {
+	if generation, err := r.redis.Get(ctx, fmt.Sprintf(accountGenerationCacheKeyPattern, orgID, accountID)); err == nil && generation != "" {
+		return generation, true
+	}
+	// A new generation only makes existing entries misses, so it is safe to
+	// start one when the read failed rather than found nothing
+	generation, err := newAccountGeneration(ctx, r.redis, orgID, accountID, r.ttl)
+	return generation, err == nil
+}
+
+// readAccount decodes a cached account of the given generation. Redis
+// failures count as a miss so the database stays the fallback. Numbers in the
+// metadata are decoded as json.Number, as they are when read from the
+// database.
+func (r *cachedAccountRepository) readAccount(ctx context.Context, orgID, accountID int32, generation string) (*domain.Account, bool) {
+	cached, err := r.redis.Get(ctx, fmt.Sprintf(accountCacheKeyPattern, orgID, accountID))
+	if err != nil || cached == "" {
+		return nil, false
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(cached))
+	decoder.UseNumber()
+	var entry cachedAccount
+	if err := decoder.Decode(&entry); err != nil || entry.Account == nil || entry.Generation != generation {
+		return nil, false
+	}
+	return entry.Account, true
+}
+
+func (r *cachedAccountRepository) writeAccount(ctx context.Context, account *domain.Account, generation string) {
+	data, err := json.Marshal(cachedAccount{Generation: generation, Account: account})
+	if err != nil {
+		return
+	}
+	_ = r.redis.Set(ctx, fmt.Sprintf(accountCacheKeyPattern, account.OrganizationID, account.ID), string(data), r.ttl)
+	_ = r.redis.Set(ctx, fmt.Sprintf(accountEmailCacheKeyPattern, account.OrganizationID, account.Email), strconv.Itoa(int(account.ID)), r.ttl)
+}
+
+// newAccountGeneration replaces the account's cache generation. It is kept
+// for twice the entry ttl so it outlives the entries cached under it.
+func newAccountGeneration(ctx context.Context, redisClient redis.Client, orgID, accountID int32, ttl time.Duration) (string, error) {
+	generation := uuid.New().String()
+	if err := redisClient.Set(ctx, fmt.Sprintf(accountGenerationCacheKeyPattern, orgID, accountID), generation, 2*ttl); err != nil {
+		return "", err
+	}
+	return generation, nil
+}
+
+// clearCachedAccount starts a new cache generation for the account, so
+// entries loaded before the change are not served or written back, and drops
+// its entry. A failure leaves the entry to expire.
+func clearCachedAccount(ctx context.Context, redisClient redis.Client, orgID, accountID int32, ttl time.Duration) {
+	_, _ = newAccountGeneration(ctx, redisClient, orgID, accountID, ttl)
+	_ = redisClient.Delete(ctx, fmt.Sprintf(accountCacheKeyPattern, orgID, accountID))
+}
+
+// cacheClearingAccountStatusRepository clears the cached account whenever its
+// status changes, so suspensions and reactivations apply at once.
+type cacheClearingAccountStatusRepository struct {
+	domain.AccountStatusRepository
+	redis redis.Client
+	ttl   time.Duration
+}
+
+// NewCacheClearingAccountStatusRepository wraps repo to clear accounts cached
+// by NewCachedAccountRepository when their status changes. It returns repo
+// unchanged if ttl is not positive (the cache is off).
+func NewCacheClearingAccountStatusRepository(repo domain.AccountStatusRepository, redisClient redis.Client, ttl time.Duration) domain.AccountStatusRepository {
+	if ttl <= 0 {
+		return repo
+	}
+	return &cacheClearingAccountStatusRepository{AccountStatusRepository: repo, redis: redisClient, ttl: ttl}
+}
+
+func (r *cacheClearingAccountStatusRepository) ChangeStatus(ctx context.Context, change *domain.AccountStatusChange) (*domain.AccountStatusChange, error) {
+	recorded, err := r.AccountStatusRepository.ChangeStatus(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+	clearCachedAccount(ctx, r.redis, change.OrganizationID, change.AccountID, r.ttl)
+	return recorded, nil
+}
+
+func (r *cacheClearingAccountStatusRepository) ChangeStatusWithinSeats(ctx context.Context, change *domain.AccountStatusChange) (*domain.AccountStatusChange, error) {
+	recorded, err := r.AccountStatusRepository.ChangeStatusWithinSeats(ctx, change)
+	if err != nil {
+		return nil, err
+	}
+	clearCachedAccount(ctx, r.redis, change.OrganizationID, change.AccountID, r.ttl)
+	return recorded, nil
+}
+
+// cacheClearingActivityRepository clears cached accounts moved to or out of
+// the dormant status.
+type cacheClearingActivityRepository struct {
+	domain.ActivityRepository
+	redis redis.Client
+	ttl   time.Duration
+}
+
+// NewCacheClearingActivityRepository wraps repo to clear accounts cached by
+// NewCachedAccountRepository when they become dormant or active again. It
+// returns repo unchanged if ttl is not positive (the cache is off).
+func NewCacheClearingActivityRepository(repo domain.ActivityRepository, redisClient redis.Client, ttl time.Duration) domain.ActivityRepository {
+	if ttl <= 0 {
+		return repo
+	}
+	return &cacheClearingActivityRepository{ActivityRepository: repo, redis: redisClient, ttl: ttl}
+}
+
+func (r *cacheClearingActivityRepository) ReactivateAccount(ctx context.Context, orgID, accountID int32) (bool, error) {
+	reactivated, err := r.ActivityRepository.ReactivateAccount(ctx, orgID, accountID)
+	if err != nil {
+		return false, err
+	}
+	if reactivated {
+		clearCachedAccount(ctx, r.redis, orgID, accountID, r.ttl)
+	}
+	return reactivated, nil
+}
+
+func (r *cacheClearingActivityRepository) MarkDormantAccounts(ctx context.Context, cutoff time.Time, limit int32) ([]*domain.DormantAccount, error) {
+	accounts, err := r.ActivityRepository.MarkDormantAccounts(ctx, cutoff, limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, account := range accounts {
+		clearCachedAccount(ctx, r.redis, account.OrganizationID, account.AccountID, r.ttl)
+	}
+	return accounts, nil
+}
+
+// cacheClearingSecondaryEmailRepository clears cached accounts whose primary
+// email was swapped with a secondary address.
+type cacheClearingSecondaryEmailRepository struct {
+	domain.SecondaryEmailRepository
+	redis redis.Client
+	ttl   time.Duration
+}
+
+// NewCacheClearingSecondaryEmailRepository wraps repo to clear accounts cached
+// by NewCachedAccountRepository when a secondary address becomes their
+// primary email. It returns repo unchanged if ttl is not positive (the cache
+// is off).
+func NewCacheClearingSecondaryEmailRepository(repo domain.SecondaryEmailRepository, redisClient redis.Client, ttl time.Duration) domain.SecondaryEmailRepository {
+	if ttl <= 0 {
+		return repo
+	}
+	return &cacheClearingSecondaryEmailRepository{SecondaryEmailRepository: repo, redis: redisClient, ttl: ttl}
+}
+
+func (r *cacheClearingSecondaryEmailRepository) MakePrimary(ctx context.Context, orgID, accountID, id int32) (*domain.SecondaryEmail, error) {
+	swapped, err := r.SecondaryEmailRepository.MakePrimary(ctx, orgID, accountID, id)
+	if err != nil {
+		return nil, err
+	}
+	clearCachedAccount(ctx, r.redis, orgID, accountID, r.ttl)
+	return swapped, nil
+}
@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// canaryCredentialRepository implements domain.CanaryCredentialRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type canaryCredentialRepository struct {
+	store sqlc.Store
+}
+
+// NewCanaryCredentialRepository creates a new CanaryCredentialRepository implementation.
+func NewCanaryCredentialRepository(store sqlc.Store) domain.CanaryCredentialRepository {
+	return &canaryCredentialRepository{store: store}
+}
+
+func (r *canaryCredentialRepository) Create(ctx context.Context, canary *domain.CanaryCredential) (*domain.CanaryCredential, error) {
+	params := sqlc.CreateCanaryCredentialParams{
+		OrganizationID:     canary.OrganizationID,
+		Kind:               canary.Kind,
+		Identifier:         canary.Identifier,
+		Name:               canary.Name,
+		RotateOnTrigger:    canary.RotateOnTrigger,
+		CreatedByAccountID: helpers.ToPgInt4Ptr(canary.CreatedByAccountID),
+	}
+
+	result, err := r.store.CreateCanaryCredential(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create canary credential: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *canaryCredentialRepository) GetByIdentifier(ctx context.Context, identifier string) (*domain.CanaryCredential, error) {
+	result, err := r.store.GetCanaryCredentialByIdentifier(ctx, identifier)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrCanaryNotFound
+		}
+		return nil, fmt.Errorf("failed to get canary credential: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *canaryCredentialRepository) ListByOrganization(ctx context.Context, orgID int32) ([]*domain.CanaryCredential, error) {
+	results, err := r.store.ListCanaryCredentialsByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list canary credentials: %w", err)
+	}
+
+	canaries := make([]*domain.CanaryCredential, len(results))
+	for i, result := range results {
+		canaries[i] = r.mapToDomain(&result)
+	}
+	return canaries, nil
+}
+
+func (r *canaryCredentialRepository) Delete(ctx context.Context, orgID, id int32) error {
+	rows, err := r.store.DeleteCanaryCredential(ctx, sqlc.DeleteCanaryCredentialParams{
+		OrganizationID: orgID,
+		ID:             id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete canary credential: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrCanaryNotFound
+	}
+
+	return nil
+}
+
+func (r *canaryCredentialRepository) RecordTrigger(ctx context.Context, id int32) (*domain.CanaryCredential, error) {
+	result, err := r.store.TriggerCanaryCredential(ctx, id)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrCanaryNotFound
+		}
+		return nil, fmt.Errorf("failed to record canary trigger: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+// mapToDomain converts SQLC canary credential to domain entity
+func (r *canaryCredentialRepository) mapToDomain(sqlcCanary *sqlc.OrganizationsCanaryCredential) *domain.CanaryCredential {
+	canary := &domain.CanaryCredential{
+		ID:              sqlcCanary.ID,
+		OrganizationID:  sqlcCanary.OrganizationID,
+		Kind:            sqlcCanary.Kind,
+		Identifier:      sqlcCanary.Identifier,
+		Name:            sqlcCanary.Name,
+		RotateOnTrigger: sqlcCanary.RotateOnTrigger,
+		TriggerCount:    sqlcCanary.TriggerCount,
+		CreatedAt:       sqlcCanary.CreatedAt.Time,
+	}
+
+	if sqlcCanary.CreatedByAccountID.Valid {
+		createdBy := sqlcCanary.CreatedByAccountID.Int32
+		canary.CreatedByAccountID = &createdBy
+	}
+
+	if sqlcCanary.LastTriggeredAt.Valid {
+		lastTriggeredAt := sqlcCanary.LastTriggeredAt.Time
+		canary.LastTriggeredAt = &lastTriggeredAt
+	}
+
+	return canary
+}
@@ -0,0 +1,230 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// debugCaptureRepository implements domain.DebugCaptureRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type debugCaptureRepository struct {
+	store sqlc.Store
+}
+
+// NewDebugCaptureRepository creates a new DebugCaptureRepository implementation.
+func NewDebugCaptureRepository(store sqlc.Store) domain.DebugCaptureRepository {
+	return &debugCaptureRepository{store: store}
+}
+
+func (r *debugCaptureRepository) Create(ctx context.Context, capture *domain.DebugCapture) (*domain.DebugCapture, error) {
+	redactFields := capture.RedactFields
+	if redactFields == nil {
+		redactFields = []string{}
+	}
+
+	result, err := r.store.CreateDebugCapture(ctx, sqlc.CreateDebugCaptureParams{
+		OrganizationID: capture.OrganizationID,
+		Reason:         capture.Reason,
+		StartedBy:      capture.StartedBy,
+		RedactFields:   redactFields,
+		MaxRecords:     capture.MaxRecords,
+		ExpiresAt:      pgtype.Timestamp{Time: capture.ExpiresAt, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create debug capture: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *debugCaptureRepository) GetByID(ctx context.Context, id int32) (*domain.DebugCapture, error) {
+	result, err := r.store.GetDebugCapture(ctx, id)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDebugCaptureNotFound
+		}
+		return nil, fmt.Errorf("failed to get debug capture: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *debugCaptureRepository) GetActive(ctx context.Context, orgID int32) (*domain.DebugCapture, error) {
+	result, err := r.store.GetActiveDebugCapture(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDebugCaptureNotFound
+		}
+		return nil, fmt.Errorf("failed to get active debug capture: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *debugCaptureRepository) List(ctx context.Context, orgID, limit, offset int32) ([]*domain.DebugCapture, error) {
+	params := sqlc.ListDebugCapturesParams{
+		RowLimit:  limit,
+		RowOffset: offset,
+	}
+	if orgID != 0 {
+		params.OrganizationID = helpers.ToPgInt4(orgID)
+	}
+
+	results, err := r.store.ListDebugCaptures(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list debug captures: %w", err)
+	}
+
+	captures := make([]*domain.DebugCapture, len(results))
+	for i, result := range results {
+		captures[i] = r.mapToDomain(&result)
+	}
+	return captures, nil
+}
+
+func (r *debugCaptureRepository) Stop(ctx context.Context, id int32) (*domain.DebugCapture, error) {
+	result, err := r.store.StopDebugCapture(ctx, id)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrDebugCaptureNotFound
+		}
+		return nil, fmt.Errorf("failed to stop debug capture: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *debugCaptureRepository) ReserveRecord(ctx context.Context, id int32) (bool, error) {
+	rows, err := r.store.ReserveDebugCaptureRecord(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve debug capture record: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *debugCaptureRepository) CreateRecord(ctx context.Context, record *domain.DebugCaptureRecord) error {
+	requestHeaders, err := json.Marshal(record.RequestHeaders)
+	if err != nil {
+		return fmt.Errorf("failed to encode request headers: %w", err)
+	}
+	responseHeaders, err := json.Marshal(record.ResponseHeaders)
+	if err != nil {
+		return fmt.Errorf("failed to encode response headers: %w", err)
+	}
+
+	if err := r.store.CreateDebugCaptureRecord(ctx, sqlc.CreateDebugCaptureRecordParams{
+		CaptureID:       record.CaptureID,
+		OrganizationID:  record.OrganizationID,
+		AccountID:       helpers.ToPgInt4Ptr(record.AccountID),
+		Method:          record.Method,
+		Path:            record.Path,
+		Route:           record.Route,
+		Query:           record.Query,
+		ClientIp:        record.ClientIP,
+		RequestHeaders:  requestHeaders,
+		RequestBody:     record.RequestBody,
+		Status:          record.Status,
+		DurationMs:      record.DurationMs,
+		ResponseHeaders: responseHeaders,
+		ResponseBody:    record.ResponseBody,
+		ExpiresAt:       pgtype.Timestamp{Time: record.ExpiresAt, Valid: true},
+	}); err != nil {
+		return fmt.Errorf("failed to create debug capture record: %w", err)
+	}
+
+	return nil
+}
+
+func (r *debugCaptureRepository) ListRecords(ctx context.Context, captureID, limit, offset int32) ([]*domain.DebugCaptureRecord, error) {
+	results, err := r.store.ListDebugCaptureRecords(ctx, sqlc.ListDebugCaptureRecordsParams{
+		CaptureID: captureID,
+		RowLimit:  limit,
+		RowOffset: offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list debug capture records: %w", err)
+	}
+
+	records := make([]*domain.DebugCaptureRecord, len(results))
+	for i, result := range results {
+		records[i] = r.mapRecordToDomain(&result)
+	}
+	return records, nil
+}
+
+func (r *debugCaptureRepository) DeleteExpiredRecords(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := r.store.DeleteExpiredDebugCaptureRecords(ctx, pgtype.Timestamp{Time: before, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired debug capture records: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func (r *debugCaptureRepository) DeleteEnded(ctx context.Context, before time.Time) (int64, error) {
+	deleted, err := r.store.DeleteEndedDebugCaptures(ctx, pgtype.Timestamp{Time: before, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete ended debug captures: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// mapToDomain converts SQLC debug capture to domain entity
+func (r *debugCaptureRepository) mapToDomain(sqlcCapture *sqlc.OrganizationsDebugCapture) *domain.DebugCapture {
+	capture := &domain.DebugCapture{
+		ID:             sqlcCapture.ID,
+		OrganizationID: sqlcCapture.OrganizationID,
+		Reason:         sqlcCapture.Reason,
+		StartedBy:      sqlcCapture.StartedBy,
+		RedactFields:   sqlcCapture.RedactFields,
+		MaxRecords:     sqlcCapture.MaxRecords,
+		RecordCount:    sqlcCapture.RecordCount,
+		ExpiresAt:      sqlcCapture.ExpiresAt.Time,
+		CreatedAt:      sqlcCapture.CreatedAt.Time,
+	}
+
+	if sqlcCapture.StoppedAt.Valid {
+		stoppedAt := sqlcCapture.StoppedAt.Time
+		capture.StoppedAt = &stoppedAt
+	}
+
+	return capture
+}
+
+// mapRecordToDomain converts SQLC debug capture record to domain entity.
+// Headers that fail to decode are left empty.
+func (r *debugCaptureRepository) mapRecordToDomain(sqlcRecord *sqlc.OrganizationsDebugCaptureRecord) *domain.DebugCaptureRecord {
+	record := &domain.DebugCaptureRecord{
+		ID:             sqlcRecord.ID,
+		CaptureID:      sqlcRecord.CaptureID,
+		OrganizationID: sqlcRecord.OrganizationID,
+		AccountID:      helpers.FromPgInt4Ptr(sqlcRecord.AccountID),
+		Method:         sqlcRecord.Method,
+		Path:           sqlcRecord.Path,
+		Route:          sqlcRecord.Route,
+		Query:          sqlcRecord.Query,
+		ClientIP:       sqlcRecord.ClientIp,
+		RequestBody:    sqlcRecord.RequestBody,
+		Status:         sqlcRecord.Status,
+		DurationMs:     sqlcRecord.DurationMs,
+		ResponseBody:   sqlcRecord.ResponseBody,
+		CreatedAt:      sqlcRecord.CreatedAt.Time,
+		ExpiresAt:      sqlcRecord.ExpiresAt.Time,
+	}
+
+	_ = json.Unmarshal(sqlcRecord.RequestHeaders, &record.RequestHeaders)
+	_ = json.Unmarshal(sqlcRecord.ResponseHeaders, &record.ResponseHeaders)
+
+	return record
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// emailChangeRepository implements domain.EmailChangeRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type emailChangeRepository struct {
+	store sqlc.Store
+}
+
+// NewEmailChangeRepository creates a new EmailChangeRepository implementation.
+func NewEmailChangeRepository(store sqlc.Store) domain.EmailChangeRepository {
+	return &emailChangeRepository{store: store}
+}
+
+func (r *emailChangeRepository) Create(ctx context.Context, req *domain.EmailChangeRequest) (*domain.EmailChangeRequest, error) {
+	if _, err := r.CancelPending(ctx, req.OrganizationID, req.AccountID); err != nil {
+		return nil, err
+	}
+
+	params := sqlc.CreateEmailChangeRequestParams{
+		OrganizationID:   req.OrganizationID,
+		AccountID:        req.AccountID,
+		OldEmail:         req.OldEmail,
+		NewEmail:         req.NewEmail,
+		ConfirmTokenHash: req.ConfirmTokenHash,
+		RevertTokenHash:  req.RevertTokenHash,
+		ExpiresAt:        toPgTimestamp(req.ExpiresAt),
+	}
+
+	result, err := r.store.CreateEmailChangeRequest(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create email change request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *emailChangeRepository) GetByConfirmTokenHash(ctx context.Context, tokenHash string) (*domain.EmailChangeRequest, error) {
+	result, err := r.store.GetEmailChangeRequestByConfirmTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrEmailChangeNotFound
+		}
+		return nil, fmt.Errorf("failed to get email change request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *emailChangeRepository) GetByRevertTokenHash(ctx context.Context, tokenHash string) (*domain.EmailChangeRequest, error) {
+	result, err := r.store.GetEmailChangeRequestByRevertTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrEmailChangeNotFound
+		}
+		return nil, fmt.Errorf("failed to get email change request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *emailChangeRepository) CancelPending(ctx context.Context, orgID, accountID int32) (bool, error) {
+	params := sqlc.CancelPendingEmailChangeRequestsParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	}
+
+	rows, err := r.store.CancelPendingEmailChangeRequests(ctx, params)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel pending email change requests: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+func (r *emailChangeRepository) Cancel(ctx context.Context, requestID int32) (*domain.EmailChangeRequest, error) {
+	result, err := r.store.CancelEmailChangeRequest(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrEmailChangeNotPending
+		}
+		return nil, fmt.Errorf("failed to cancel email change request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *emailChangeRepository) Confirm(ctx context.Context, requestID int32, revertTokenHash string, revertibleUntil time.Time) (*domain.EmailChangeRequest, error) {
+	params := sqlc.ConfirmEmailChangeRequestParams{
+		ID:              requestID,
+		RevertTokenHash: revertTokenHash,
+		RevertibleUntil: toPgTimestamp(revertibleUntil),
+	}
+
+	result, err := r.store.ConfirmEmailChangeRequest(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrEmailChangeNotPending
+		}
+		return nil, fmt.Errorf("failed to confirm email change request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *emailChangeRepository) Revert(ctx context.Context, requestID int32) (*domain.EmailChangeRequest, error) {
+	result, err := r.store.RevertEmailChangeRequest(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrEmailChangeNotRevertible
+		}
+		return nil, fmt.Errorf("failed to revert email change request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+// mapToDomain converts SQLC email change request to domain entity
+func (r *emailChangeRepository) mapToDomain(sqlcRequest *sqlc.OrganizationsEmailChangeRequest) *domain.EmailChangeRequest {
+	req := &domain.EmailChangeRequest{
+		ID:               sqlcRequest.ID,
+		OrganizationID:   sqlcRequest.OrganizationID,
+		AccountID:        sqlcRequest.AccountID,
+		OldEmail:         sqlcRequest.OldEmail,
+		NewEmail:         sqlcRequest.NewEmail,
+		Status:           sqlcRequest.Status,
+		ConfirmTokenHash: sqlcRequest.ConfirmTokenHash,
+		RevertTokenHash:  sqlcRequest.RevertTokenHash,
+		ExpiresAt:        sqlcRequest.ExpiresAt.Time,
+		CreatedAt:        sqlcRequest.CreatedAt.Time,
+		UpdatedAt:        sqlcRequest.UpdatedAt.Time,
+	}
+
+	if sqlcRequest.ConfirmedAt.Valid {
+		confirmedAt := sqlcRequest.ConfirmedAt.Time
+		req.ConfirmedAt = &confirmedAt
+	}
+
+	if sqlcRequest.RevertibleUntil.Valid {
+		revertibleUntil := sqlcRequest.RevertibleUntil.Time
+		req.RevertibleUntil = &revertibleUntil
+	}
+
+	if sqlcRequest.RevertedAt.Valid {
+		revertedAt := sqlcRequest.RevertedAt.Time
+		req.RevertedAt = &revertedAt
+	}
+
+	return req
+}
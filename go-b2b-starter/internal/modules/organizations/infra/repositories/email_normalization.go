@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// CheckStoredEmails verifies that stored member emails are in the form
+// normalizer looks them up in. With CanonicalizePlusAddresses set, an address
+// stored with its "+tag" would no longer be found and the same member could
+// register again without the tag, so the stored addresses must be rewritten
+// before the option is turned on.
+func CheckStoredEmails(ctx context.Context, store sqlc.Store, normalizer domain.EmailNormalizer) error {
+	if !normalizer.CanonicalizePlusAddresses {
+		return nil
+	}
+
+	count, err := store.CountPlusAddressedEmails(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check stored emails: %w", err)
+	}
+	if count > 0 {
+		return fmt.Errorf("ACCOUNT_EMAIL_CANONICALIZE_PLUS is set but %d stored member emails still have a +tag; "+
+			"remove the tags (resolving accounts that become duplicates) or turn the option off", count)
+	}
+	return nil
+}
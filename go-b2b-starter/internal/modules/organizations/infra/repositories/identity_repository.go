@@ -0,0 +1,95 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// identityRepository implements domain.IdentityRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type identityRepository struct {
+	store sqlc.Store
+}
+
+// NewIdentityRepository creates a new IdentityRepository implementation.
+func NewIdentityRepository(store sqlc.Store) domain.IdentityRepository {
+	return &identityRepository{store: store}
+}
+
+func (r *identityRepository) Create(ctx context.Context, identity *domain.LinkedIdentity) (*domain.LinkedIdentity, error) {
+	result, err := r.store.CreateIdentity(ctx, sqlc.CreateIdentityParams{
+		OrganizationID: identity.OrganizationID,
+		AccountID:      identity.AccountID,
+		Provider:       string(identity.Provider),
+		ProviderUserID: identity.ProviderUserID,
+		Email:          helpers.ToPgText(identity.Email),
+	})
+	if err != nil {
+		if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+			return nil, domain.ErrIdentityAlreadyLinked
+		}
+		return nil, fmt.Errorf("failed to create identity: %w", err)
+	}
+	return toLinkedIdentity(result), nil
+}
+
+func (r *identityRepository) ListByAccount(ctx context.Context, orgID, accountID int32) ([]*domain.LinkedIdentity, error) {
+	results, err := r.store.ListAccountIdentities(ctx, sqlc.ListAccountIdentitiesParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account identities: %w", err)
+	}
+	return toLinkedIdentities(results), nil
+}
+
+func (r *identityRepository) ListByProviderUser(ctx context.Context, provider domain.IdentityProvider, providerUserID string) ([]*domain.LinkedIdentity, error) {
+	results, err := r.store.ListIdentitiesByProviderUser(ctx, sqlc.ListIdentitiesByProviderUserParams{
+		Provider:       string(provider),
+		ProviderUserID: providerUserID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider user identities: %w", err)
+	}
+	return toLinkedIdentities(results), nil
+}
+
+func (r *identityRepository) Delete(ctx context.Context, orgID, accountID int32, provider domain.IdentityProvider) error {
+	rows, err := r.store.DeleteIdentity(ctx, sqlc.DeleteIdentityParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+		Provider:       string(provider),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete identity: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrIdentityNotLinked
+	}
+	return nil
+}
+
+func toLinkedIdentities(results []sqlc.OrganizationsIdentity) []*domain.LinkedIdentity {
+	identities := make([]*domain.LinkedIdentity, len(results))
+	for i, result := range results {
+		identities[i] = toLinkedIdentity(result)
+	}
+	return identities
+}
+
+func toLinkedIdentity(result sqlc.OrganizationsIdentity) *domain.LinkedIdentity {
+	return &domain.LinkedIdentity{
+		ID:             result.ID,
+		OrganizationID: result.OrganizationID,
+		AccountID:      result.AccountID,
+		Provider:       domain.IdentityProvider(result.Provider),
+		ProviderUserID: result.ProviderUserID,
+		Email:          helpers.FromPgText(result.Email),
+		CreatedAt:      result.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,159 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// inviteRepository implements domain.InviteRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type inviteRepository struct {
+	store sqlc.Store
+}
+
+// NewInviteRepository creates a new InviteRepository implementation.
+func NewInviteRepository(store sqlc.Store) domain.InviteRepository {
+	return &inviteRepository{store: store}
+}
+
+func (r *inviteRepository) Create(ctx context.Context, invite *domain.Invite) (*domain.Invite, error) {
+	params := sqlc.CreateInviteParams{
+		OrganizationID:     invite.OrganizationID,
+		CreatedByAccountID: helpers.ToPgInt4Ptr(invite.CreatedByAccountID),
+		Email:              helpers.ToPgText(invite.Email),
+		RoleSlug:           invite.RoleSlug,
+		TokenHash:          invite.TokenHash,
+		ExpiresAt:          toPgTimestamp(invite.ExpiresAt),
+	}
+
+	result, err := r.store.CreateInvite(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invite: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *inviteRepository) GetByTokenHash(ctx context.Context, tokenHash string) (*domain.Invite, error) {
+	result, err := r.store.GetInviteByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrInviteNotFound
+		}
+		return nil, fmt.Errorf("failed to get invite: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *inviteRepository) ListByOrganization(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.Invite, error) {
+	params := sqlc.ListInvitesByOrganizationParams{
+		OrganizationID: orgID,
+		Limit:          limit,
+		Offset:         offset,
+	}
+
+	results, err := r.store.ListInvitesByOrganization(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list invites: %w", err)
+	}
+
+	invites := make([]*domain.Invite, len(results))
+	for i, result := range results {
+		invites[i] = r.mapToDomain(&result)
+	}
+
+	return invites, nil
+}
+
+func (r *inviteRepository) Accept(ctx context.Context, inviteID int32, email string) (*domain.Invite, error) {
+	params := sqlc.AcceptInviteParams{
+		ID:            inviteID,
+		AcceptedEmail: helpers.ToPgText(email),
+	}
+
+	result, err := r.store.AcceptInvite(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrInviteNotPending
+		}
+		return nil, fmt.Errorf("failed to accept invite: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *inviteRepository) Release(ctx context.Context, inviteID int32) error {
+	if err := r.store.ReleaseInvite(ctx, inviteID); err != nil {
+		return fmt.Errorf("failed to release invite: %w", err)
+	}
+
+	return nil
+}
+
+func (r *inviteRepository) Revoke(ctx context.Context, orgID, inviteID int32) (*domain.Invite, error) {
+	params := sqlc.RevokeInviteParams{
+		ID:             inviteID,
+		OrganizationID: orgID,
+	}
+
+	result, err := r.store.RevokeInvite(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrInviteNotPending
+		}
+		return nil, fmt.Errorf("failed to revoke invite: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *inviteRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	deleted, err := r.store.DeleteInvitesBefore(ctx, pgtype.Timestamp{Time: cutoff, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete invites: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// mapToDomain converts SQLC invite to domain entity
+func (r *inviteRepository) mapToDomain(sqlcInvite *sqlc.OrganizationsInvite) *domain.Invite {
+	invite := &domain.Invite{
+		ID:             sqlcInvite.ID,
+		OrganizationID: sqlcInvite.OrganizationID,
+		Email:          helpers.FromPgText(sqlcInvite.Email),
+		RoleSlug:       sqlcInvite.RoleSlug,
+		Status:         sqlcInvite.Status,
+		TokenHash:      sqlcInvite.TokenHash,
+		ExpiresAt:      sqlcInvite.ExpiresAt.Time,
+		AcceptedEmail:  helpers.FromPgText(sqlcInvite.AcceptedEmail),
+		CreatedAt:      sqlcInvite.CreatedAt.Time,
+		UpdatedAt:      sqlcInvite.UpdatedAt.Time,
+	}
+
+	if sqlcInvite.CreatedByAccountID.Valid {
+		createdBy := sqlcInvite.CreatedByAccountID.Int32
+		invite.CreatedByAccountID = &createdBy
+	}
+
+	if sqlcInvite.AcceptedAt.Valid {
+		acceptedAt := sqlcInvite.AcceptedAt.Time
+		invite.AcceptedAt = &acceptedAt
+	}
+
+	if sqlcInvite.RevokedAt.Valid {
+		revokedAt := sqlcInvite.RevokedAt.Time
+		invite.RevokedAt = &revokedAt
+	}
+
+	return invite
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// ipAllowlistRepository implements domain.IPAllowlistRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type ipAllowlistRepository struct {
+	store sqlc.Store
+}
+
+// NewIPAllowlistRepository creates a new IPAllowlistRepository implementation.
+func NewIPAllowlistRepository(store sqlc.Store) domain.IPAllowlistRepository {
+	return &ipAllowlistRepository{store: store}
+}
+
+func (r *ipAllowlistRepository) Create(ctx context.Context, entry *domain.IPAllowlistEntry) (*domain.IPAllowlistEntry, error) {
+	params := sqlc.CreateIPAllowlistEntryParams{
+		OrganizationID:     entry.OrganizationID,
+		Cidr:               entry.CIDR,
+		Description:        entry.Description,
+		CreatedByAccountID: helpers.ToPgInt4Ptr(entry.CreatedByAccountID),
+	}
+
+	result, err := r.store.CreateIPAllowlistEntry(ctx, params)
+	if err != nil {
+		if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+			return nil, domain.ErrIPAllowlistEntryExists
+		}
+		return nil, fmt.Errorf("failed to create ip allowlist entry: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *ipAllowlistRepository) ListByOrganization(ctx context.Context, orgID int32) ([]*domain.IPAllowlistEntry, error) {
+	results, err := r.store.ListIPAllowlistEntriesByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ip allowlist entries: %w", err)
+	}
+
+	entries := make([]*domain.IPAllowlistEntry, len(results))
+	for i, result := range results {
+		entries[i] = r.mapToDomain(&result)
+	}
+
+	return entries, nil
+}
+
+func (r *ipAllowlistRepository) Delete(ctx context.Context, orgID, entryID int32) error {
+	params := sqlc.DeleteIPAllowlistEntryParams{
+		ID:             entryID,
+		OrganizationID: orgID,
+	}
+
+	rows, err := r.store.DeleteIPAllowlistEntry(ctx, params)
+	if err != nil {
+		return fmt.Errorf("failed to delete ip allowlist entry: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrIPAllowlistEntryNotFound
+	}
+
+	return nil
+}
+
+// mapToDomain converts SQLC IP allowlist entry to domain entity
+func (r *ipAllowlistRepository) mapToDomain(sqlcEntry *sqlc.OrganizationsIpAllowlistEntry) *domain.IPAllowlistEntry {
+	entry := &domain.IPAllowlistEntry{
+		ID:             sqlcEntry.ID,
+		OrganizationID: sqlcEntry.OrganizationID,
+		CIDR:           sqlcEntry.Cidr,
+		Description:    sqlcEntry.Description,
+		CreatedAt:      sqlcEntry.CreatedAt.Time,
+	}
+
+	if sqlcEntry.CreatedByAccountID.Valid {
+		createdBy := sqlcEntry.CreatedByAccountID.Int32
+		entry.CreatedByAccountID = &createdBy
+	}
+
+	return entry
+}
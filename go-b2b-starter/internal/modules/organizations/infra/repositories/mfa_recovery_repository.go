@@ -0,0 +1,171 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// mfaRecoveryRepository implements domain.MFARecoveryRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type mfaRecoveryRepository struct {
+	store sqlc.Store
+}
+
+// NewMFARecoveryRepository creates a new MFARecoveryRepository implementation.
+func NewMFARecoveryRepository(store sqlc.Store) domain.MFARecoveryRepository {
+	return &mfaRecoveryRepository{store: store}
+}
+
+func (r *mfaRecoveryRepository) Create(ctx context.Context, req *domain.MFARecoveryRequest) (*domain.MFARecoveryRequest, error) {
+	params := sqlc.CreateMFARecoveryRequestParams{
+		OrganizationID:   req.OrganizationID,
+		AccountID:        req.AccountID,
+		RequiresApproval: req.RequiresApproval,
+		TokenHash:        req.TokenHash,
+		EligibleAt:       toPgTimestamp(req.EligibleAt),
+		ExpiresAt:        toPgTimestamp(req.ExpiresAt),
+	}
+
+	result, err := r.store.CreateMFARecoveryRequest(ctx, params)
+	if err != nil {
+		if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+			return nil, domain.ErrMFARecoveryInProgress
+		}
+		return nil, fmt.Errorf("failed to create mfa recovery request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *mfaRecoveryRepository) GetByID(ctx context.Context, requestID int32) (*domain.MFARecoveryRequest, error) {
+	result, err := r.store.GetMFARecoveryRequestByID(ctx, requestID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrMFARecoveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get mfa recovery request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *mfaRecoveryRepository) ListByOrganization(ctx context.Context, orgID int32, status string) ([]*domain.MFARecoveryRequest, error) {
+	params := sqlc.ListMFARecoveryRequestsByOrganizationParams{
+		OrganizationID: orgID,
+		Status:         helpers.ToPgText(status),
+	}
+
+	results, err := r.store.ListMFARecoveryRequestsByOrganization(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list mfa recovery requests: %w", err)
+	}
+
+	requests := make([]*domain.MFARecoveryRequest, len(results))
+	for i, result := range results {
+		requests[i] = r.mapToDomain(&result)
+	}
+
+	return requests, nil
+}
+
+func (r *mfaRecoveryRepository) Decide(ctx context.Context, orgID, requestID int32, status string, deciderID int32) (*domain.MFARecoveryRequest, error) {
+	params := sqlc.DecideMFARecoveryRequestParams{
+		ID:                 requestID,
+		OrganizationID:     orgID,
+		Status:             status,
+		DecidedByAccountID: helpers.ToPgInt4(deciderID),
+	}
+
+	result, err := r.store.DecideMFARecoveryRequest(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrMFARecoveryNotOpen
+		}
+		return nil, fmt.Errorf("failed to decide mfa recovery request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *mfaRecoveryRepository) Cancel(ctx context.Context, orgID, accountID, requestID int32) (*domain.MFARecoveryRequest, error) {
+	params := sqlc.CancelMFARecoveryRequestParams{
+		ID:             requestID,
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	}
+
+	result, err := r.store.CancelMFARecoveryRequest(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrMFARecoveryNotOpen
+		}
+		return nil, fmt.Errorf("failed to cancel mfa recovery request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *mfaRecoveryRepository) Complete(ctx context.Context, requestID int32, expectedStatus string) (*domain.MFARecoveryRequest, error) {
+	params := sqlc.CompleteMFARecoveryRequestParams{
+		ID:     requestID,
+		Status: expectedStatus,
+	}
+
+	result, err := r.store.CompleteMFARecoveryRequest(ctx, params)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrMFARecoveryNotOpen
+		}
+		return nil, fmt.Errorf("failed to complete mfa recovery request: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+// mapToDomain converts SQLC mfa recovery request to domain entity
+func (r *mfaRecoveryRepository) mapToDomain(sqlcRequest *sqlc.OrganizationsMfaRecoveryRequest) *domain.MFARecoveryRequest {
+	req := &domain.MFARecoveryRequest{
+		ID:               sqlcRequest.ID,
+		OrganizationID:   sqlcRequest.OrganizationID,
+		AccountID:        sqlcRequest.AccountID,
+		Status:           sqlcRequest.Status,
+		RequiresApproval: sqlcRequest.RequiresApproval,
+		TokenHash:        sqlcRequest.TokenHash,
+		EligibleAt:       sqlcRequest.EligibleAt.Time,
+		ExpiresAt:        sqlcRequest.ExpiresAt.Time,
+		CreatedAt:        sqlcRequest.CreatedAt.Time,
+		UpdatedAt:        sqlcRequest.UpdatedAt.Time,
+	}
+
+	if sqlcRequest.DecidedByAccountID.Valid {
+		decidedBy := sqlcRequest.DecidedByAccountID.Int32
+		req.DecidedByAccountID = &decidedBy
+	}
+
+	if sqlcRequest.DecidedAt.Valid {
+		decidedAt := sqlcRequest.DecidedAt.Time
+		req.DecidedAt = &decidedAt
+	}
+
+	if sqlcRequest.CompletedAt.Valid {
+		completedAt := sqlcRequest.CompletedAt.Time
+		req.CompletedAt = &completedAt
+	}
+
+	return req
+}
+
+func toPgTimestamp(t time.Time) pgtype.Timestamp {
+	if t.IsZero() {
+		return pgtype.Timestamp{Valid: false}
+	}
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// notificationRepository implements domain.NotificationRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type notificationRepository struct {
+	store sqlc.Store
+}
+
+// NewNotificationRepository creates a new NotificationRepository implementation.
+func NewNotificationRepository(store sqlc.Store) domain.NotificationRepository {
+	return &notificationRepository{store: store}
+}
+
+func (r *notificationRepository) ListPreferences(ctx context.Context, orgID, accountID int32) ([]*domain.NotificationPreference, error) {
+	results, err := r.store.ListNotificationPreferences(ctx, sqlc.ListNotificationPreferencesParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	prefs := make([]*domain.NotificationPreference, len(results))
+	for i, result := range results {
+		prefs[i] = &domain.NotificationPreference{
+			Type:    domain.NotificationType(result.NotificationType),
+			Channel: domain.NotificationChannel(result.Channel),
+			Enabled: result.Enabled,
+		}
+	}
+	return prefs, nil
+}
+
+func (r *notificationRepository) SavePreference(ctx context.Context, orgID, accountID int32, pref *domain.NotificationPreference) error {
+	err := r.store.UpsertNotificationPreference(ctx, sqlc.UpsertNotificationPreferenceParams{
+		OrganizationID:   orgID,
+		AccountID:        accountID,
+		NotificationType: string(pref.Type),
+		Channel:          string(pref.Channel),
+		Enabled:          pref.Enabled,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to save notification preference: %w", err)
+	}
+	return nil
+}
+
+func (r *notificationRepository) Create(ctx context.Context, notification *domain.Notification) (*domain.Notification, error) {
+	result, err := r.store.CreateNotification(ctx, sqlc.CreateNotificationParams{
+		OrganizationID:   notification.OrganizationID,
+		AccountID:        notification.AccountID,
+		NotificationType: string(notification.Type),
+		Title:            notification.Title,
+		Body:             notification.Body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *notificationRepository) List(ctx context.Context, orgID, accountID int32, unreadOnly bool, limit int32) ([]*domain.Notification, error) {
+	results, err := r.store.ListNotifications(ctx, sqlc.ListNotificationsParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+		UnreadOnly:     unreadOnly,
+		RowLimit:       limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	notifications := make([]*domain.Notification, len(results))
+	for i, result := range results {
+		notifications[i] = r.mapToDomain(&result)
+	}
+	return notifications, nil
+}
+
+func (r *notificationRepository) MarkRead(ctx context.Context, orgID, accountID int32, id int64) error {
+	updated, err := r.store.MarkNotificationRead(ctx, sqlc.MarkNotificationReadParams{
+		ID:             id,
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to mark notification read: %w", err)
+	}
+	if updated == 0 {
+		return domain.ErrNotificationNotFound
+	}
+	return nil
+}
+
+func (r *notificationRepository) mapToDomain(notification *sqlc.OrganizationsNotification) *domain.Notification {
+	result := &domain.Notification{
+		ID:             notification.ID,
+		OrganizationID: notification.OrganizationID,
+		AccountID:      notification.AccountID,
+		Type:           domain.NotificationType(notification.NotificationType),
+		Title:          notification.Title,
+		Body:           notification.Body,
+		CreatedAt:      notification.CreatedAt.Time,
+	}
+
+	if notification.ReadAt.Valid {
+		readAt := notification.ReadAt.Time
+		result.ReadAt = &readAt
+	}
+
+	return result
+}
@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// oauthClientRepository implements domain.OAuthClientRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type oauthClientRepository struct {
+	store sqlc.Store
+}
+
+// NewOAuthClientRepository creates a new OAuthClientRepository implementation.
+func NewOAuthClientRepository(store sqlc.Store) domain.OAuthClientRepository {
+	return &oauthClientRepository{store: store}
+}
+
+func (r *oauthClientRepository) Create(ctx context.Context, client *domain.OAuthClient) (*domain.OAuthClient, error) {
+	params := sqlc.CreateOAuthClientParams{
+		OrganizationID:     client.OrganizationID,
+		AccountID:          client.AccountID,
+		ClientID:           client.ClientID,
+		SecretHash:         client.SecretHash,
+		Name:               client.Name,
+		Scopes:             client.Scopes,
+		CreatedByAccountID: helpers.ToPgInt4Ptr(client.CreatedByAccountID),
+	}
+
+	result, err := r.store.CreateOAuthClient(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oauth client: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *oauthClientRepository) GetByID(ctx context.Context, orgID, id int32) (*domain.OAuthClient, error) {
+	result, err := r.store.GetOAuthClientByID(ctx, sqlc.GetOAuthClientByIDParams{
+		OrganizationID: orgID,
+		ID:             id,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrOAuthClientNotFound
+		}
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *oauthClientRepository) GetByClientID(ctx context.Context, clientID string) (*domain.OAuthClient, error) {
+	result, err := r.store.GetOAuthClientByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrOAuthClientNotFound
+		}
+		return nil, fmt.Errorf("failed to get oauth client: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *oauthClientRepository) ListByOrganization(ctx context.Context, orgID int32) ([]*domain.OAuthClient, error) {
+	results, err := r.store.ListOAuthClientsByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oauth clients: %w", err)
+	}
+
+	clients := make([]*domain.OAuthClient, len(results))
+	for i, result := range results {
+		clients[i] = r.mapToDomain(&result)
+	}
+
+	return clients, nil
+}
+
+func (r *oauthClientRepository) Revoke(ctx context.Context, orgID, id int32) (*domain.OAuthClient, error) {
+	result, err := r.store.RevokeOAuthClient(ctx, sqlc.RevokeOAuthClientParams{
+		OrganizationID: orgID,
+		ID:             id,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrOAuthClientNotFound
+		}
+		return nil, fmt.Errorf("failed to revoke oauth client: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *oauthClientRepository) Touch(ctx context.Context, id int32) error {
+	if err := r.store.TouchOAuthClient(ctx, id); err != nil {
+		return fmt.Errorf("failed to update oauth client last use: %w", err)
+	}
+	return nil
+}
+
+// mapToDomain converts SQLC OAuth client to domain entity
+func (r *oauthClientRepository) mapToDomain(sqlcClient *sqlc.OrganizationsOauthClient) *domain.OAuthClient {
+	client := &domain.OAuthClient{
+		ID:             sqlcClient.ID,
+		OrganizationID: sqlcClient.OrganizationID,
+		AccountID:      sqlcClient.AccountID,
+		ClientID:       sqlcClient.ClientID,
+		SecretHash:     sqlcClient.SecretHash,
+		Name:           sqlcClient.Name,
+		Scopes:         sqlcClient.Scopes,
+		CreatedAt:      sqlcClient.CreatedAt.Time,
+		UpdatedAt:      sqlcClient.UpdatedAt.Time,
+	}
+
+	if sqlcClient.CreatedByAccountID.Valid {
+		createdBy := sqlcClient.CreatedByAccountID.Int32
+		client.CreatedByAccountID = &createdBy
+	}
+
+	if sqlcClient.LastUsedAt.Valid {
+		lastUsedAt := sqlcClient.LastUsedAt.Time
+		client.LastUsedAt = &lastUsedAt
+	}
+
+	if sqlcClient.RevokedAt.Valid {
+		revokedAt := sqlcClient.RevokedAt.Time
+		client.RevokedAt = &revokedAt
+	}
+
+	return client
+}
@@ -0,0 +1,107 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// oidcClientRepository implements domain.OIDCClientRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type oidcClientRepository struct {
+	store sqlc.Store
+}
+
+// NewOIDCClientRepository creates a new OIDCClientRepository implementation.
+func NewOIDCClientRepository(store sqlc.Store) domain.OIDCClientRepository {
+	return &oidcClientRepository{store: store}
+}
+
+func (r *oidcClientRepository) Create(ctx context.Context, client *domain.OIDCClient) (*domain.OIDCClient, error) {
+	params := sqlc.CreateOIDCClientParams{
+		OrganizationID:     client.OrganizationID,
+		ClientID:           client.ClientID,
+		SecretHash:         client.SecretHash,
+		Name:               client.Name,
+		RedirectUris:       client.RedirectURIs,
+		CreatedByAccountID: helpers.ToPgInt4Ptr(client.CreatedByAccountID),
+	}
+
+	result, err := r.store.CreateOIDCClient(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oidc client: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *oidcClientRepository) GetByClientID(ctx context.Context, clientID string) (*domain.OIDCClient, error) {
+	result, err := r.store.GetOIDCClientByClientID(ctx, clientID)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrOIDCClientNotFound
+		}
+		return nil, fmt.Errorf("failed to get oidc client: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *oidcClientRepository) ListByOrganization(ctx context.Context, orgID int32) ([]*domain.OIDCClient, error) {
+	results, err := r.store.ListOIDCClientsByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list oidc clients: %w", err)
+	}
+
+	clients := make([]*domain.OIDCClient, len(results))
+	for i, result := range results {
+		clients[i] = r.mapToDomain(&result)
+	}
+
+	return clients, nil
+}
+
+func (r *oidcClientRepository) Revoke(ctx context.Context, orgID, id int32) (*domain.OIDCClient, error) {
+	result, err := r.store.RevokeOIDCClient(ctx, sqlc.RevokeOIDCClientParams{
+		OrganizationID: orgID,
+		ID:             id,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrOIDCClientNotFound
+		}
+		return nil, fmt.Errorf("failed to revoke oidc client: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+// mapToDomain converts SQLC OIDC client to domain entity
+func (r *oidcClientRepository) mapToDomain(sqlcClient *sqlc.OrganizationsOidcClient) *domain.OIDCClient {
+	client := &domain.OIDCClient{
+		ID:             sqlcClient.ID,
+		OrganizationID: sqlcClient.OrganizationID,
+		ClientID:       sqlcClient.ClientID,
+		SecretHash:     sqlcClient.SecretHash,
+		Name:           sqlcClient.Name,
+		RedirectURIs:   sqlcClient.RedirectUris,
+		CreatedAt:      sqlcClient.CreatedAt.Time,
+		UpdatedAt:      sqlcClient.UpdatedAt.Time,
+	}
+
+	if sqlcClient.CreatedByAccountID.Valid {
+		createdBy := sqlcClient.CreatedByAccountID.Int32
+		client.CreatedByAccountID = &createdBy
+	}
+
+	if sqlcClient.RevokedAt.Valid {
+		revokedAt := sqlcClient.RevokedAt.Time
+		client.RevokedAt = &revokedAt
+	}
+
+	return client
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// oidcGrantRepository implements domain.OIDCGrantRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type oidcGrantRepository struct {
+	store sqlc.Store
+}
+
+// NewOIDCGrantRepository creates a new OIDCGrantRepository implementation.
+func NewOIDCGrantRepository(store sqlc.Store) domain.OIDCGrantRepository {
+	return &oidcGrantRepository{store: store}
+}
+
+func (r *oidcGrantRepository) CreateCode(ctx context.Context, code *domain.OIDCAuthorizationCode) (*domain.OIDCAuthorizationCode, error) {
+	params := sqlc.CreateOIDCAuthorizationCodeParams{
+		OidcClientID:   code.OIDCClientID,
+		OrganizationID: code.OrganizationID,
+		AccountID:      code.AccountID,
+		CodeHash:       code.CodeHash,
+		Subject:        code.Subject,
+		EmailVerified:  code.EmailVerified,
+		RedirectUri:    code.RedirectURI,
+		Scopes:         code.Scopes,
+		Nonce:          helpers.ToPgText(code.Nonce),
+		CodeChallenge:  helpers.ToPgText(code.CodeChallenge),
+		ExpiresAt:      toPgTimestamp(code.ExpiresAt),
+	}
+	if code.AuthTime != nil {
+		params.AuthTime = toPgTimestamp(*code.AuthTime)
+	}
+
+	result, err := r.store.CreateOIDCAuthorizationCode(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create oidc authorization code: %w", err)
+	}
+
+	return r.mapCodeToDomain(&result), nil
+}
+
+func (r *oidcGrantRepository) ConsumeCode(ctx context.Context, codeHash string) (*domain.OIDCAuthorizationCode, error) {
+	result, err := r.store.ConsumeOIDCAuthorizationCode(ctx, codeHash)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrOIDCInvalidGrant
+		}
+		return nil, fmt.Errorf("failed to consume oidc authorization code: %w", err)
+	}
+
+	return r.mapCodeToDomain(&result), nil
+}
+
+func (r *oidcGrantRepository) DeleteExpiredCodes(ctx context.Context) (int64, error) {
+	deleted, err := r.store.DeleteExpiredOIDCAuthorizationCodes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired oidc authorization codes: %w", err)
+	}
+
+	return deleted, nil
+}
+
+func (r *oidcGrantRepository) GetConsent(ctx context.Context, oidcClientID, accountID int32) (*domain.OIDCConsent, error) {
+	result, err := r.store.GetOIDCConsent(ctx, sqlc.GetOIDCConsentParams{
+		OidcClientID: oidcClientID,
+		AccountID:    accountID,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get oidc consent: %w", err)
+	}
+
+	return r.mapConsentToDomain(&result), nil
+}
+
+func (r *oidcGrantRepository) SaveConsent(ctx context.Context, consent *domain.OIDCConsent) (*domain.OIDCConsent, error) {
+	result, err := r.store.UpsertOIDCConsent(ctx, sqlc.UpsertOIDCConsentParams{
+		OidcClientID:   consent.OIDCClientID,
+		OrganizationID: consent.OrganizationID,
+		AccountID:      consent.AccountID,
+		Scopes:         consent.Scopes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save oidc consent: %w", err)
+	}
+
+	return r.mapConsentToDomain(&result), nil
+}
+
+// mapCodeToDomain converts SQLC authorization code to domain entity
+func (r *oidcGrantRepository) mapCodeToDomain(sqlcCode *sqlc.OrganizationsOidcAuthorizationCode) *domain.OIDCAuthorizationCode {
+	code := &domain.OIDCAuthorizationCode{
+		ID:             sqlcCode.ID,
+		OIDCClientID:   sqlcCode.OidcClientID,
+		OrganizationID: sqlcCode.OrganizationID,
+		AccountID:      sqlcCode.AccountID,
+		CodeHash:       sqlcCode.CodeHash,
+		Subject:        sqlcCode.Subject,
+		EmailVerified:  sqlcCode.EmailVerified,
+		RedirectURI:    sqlcCode.RedirectUri,
+		Scopes:         sqlcCode.Scopes,
+		Nonce:          helpers.FromPgText(sqlcCode.Nonce),
+		CodeChallenge:  helpers.FromPgText(sqlcCode.CodeChallenge),
+		ExpiresAt:      sqlcCode.ExpiresAt.Time,
+		CreatedAt:      sqlcCode.CreatedAt.Time,
+	}
+
+	if sqlcCode.AuthTime.Valid {
+		authTime := sqlcCode.AuthTime.Time
+		code.AuthTime = &authTime
+	}
+
+	if sqlcCode.UsedAt.Valid {
+		usedAt := sqlcCode.UsedAt.Time
+		code.UsedAt = &usedAt
+	}
+
+	return code
+}
+
+// mapConsentToDomain converts SQLC consent to domain entity
+func (r *oidcGrantRepository) mapConsentToDomain(sqlcConsent *sqlc.OrganizationsOidcConsent) *domain.OIDCConsent {
+	return &domain.OIDCConsent{
+		ID:             sqlcConsent.ID,
+		OIDCClientID:   sqlcConsent.OidcClientID,
+		OrganizationID: sqlcConsent.OrganizationID,
+		AccountID:      sqlcConsent.AccountID,
+		Scopes:         sqlcConsent.Scopes,
+		CreatedAt:      sqlcConsent.CreatedAt.Time,
+		UpdatedAt:      sqlcConsent.UpdatedAt.Time,
+	}
+}
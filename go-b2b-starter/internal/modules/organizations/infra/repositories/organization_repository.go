@@ -6,6 +6,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/jackc/pgx/v5/pgtype"
+
 	"github.com/moasq/go-b2b-starter/internal/db/helpers"
 	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
@@ -14,19 +16,31 @@ import (
 // organizationRepository implements domain.OrganizationRepository using SQLC internally.
 // SQLC types are never exposed outside this package.
 type organizationRepository struct {
-	store sqlc.Store
+	store      sqlc.Store
+	normalizer domain.EmailNormalizer
 }
 
 // NewOrganizationRepository creates a new OrganizationRepository implementation.
-func NewOrganizationRepository(store sqlc.Store) domain.OrganizationRepository {
-	return &organizationRepository{store: store}
+// Member emails are normalized with normalizer before they are looked up.
+func NewOrganizationRepository(store sqlc.Store, normalizer domain.EmailNormalizer) domain.OrganizationRepository {
+	return &organizationRepository{store: store, normalizer: normalizer}
 }
 
 func (r *organizationRepository) Create(ctx context.Context, org *domain.Organization) (*domain.Organization, error) {
+	environment := org.Environment
+	if environment == "" {
+		environment = domain.EnvironmentProduction
+	}
+
 	params := sqlc.CreateOrganizationParams{
-		Slug:   org.Slug,
-		Name:   org.Name,
-		Status: org.Status,
+		Slug:                 org.Slug,
+		Name:                 org.Name,
+		Status:               org.Status,
+		Environment:          environment,
+		ParentOrganizationID: helpers.ToPgInt4Ptr(org.ParentOrganizationID),
+	}
+	if org.ExpiresAt != nil {
+		params.ExpiresAt = pgtype.Timestamp{Time: *org.ExpiresAt, Valid: true}
 	}
 
 	result, err := r.store.CreateOrganization(ctx, params)
@@ -74,7 +88,7 @@ func (r *organizationRepository) GetByStytchID(ctx context.Context, stytchOrgID
 }
 
 func (r *organizationRepository) GetByUserEmail(ctx context.Context, email string) (*domain.Organization, error) {
-	result, err := r.store.GetOrganizationByUserEmail(ctx, email)
+	result, err := r.store.GetOrganizationByUserEmail(ctx, r.normalizer.Normalize(email))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, domain.ErrOrganizationNotFound
@@ -144,6 +158,28 @@ func (r *organizationRepository) List(ctx context.Context, limit, offset int32)
 	return organizations, nil
 }
 
+func (r *organizationRepository) ListStaging(ctx context.Context, parentID int32) ([]*domain.Organization, error) {
+	results, err := r.store.ListStagingOrganizations(ctx, parentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list staging organizations: %w", err)
+	}
+
+	organizations := make([]*domain.Organization, len(results))
+	for i, result := range results {
+		organizations[i] = r.mapToDomain(&result)
+	}
+
+	return organizations, nil
+}
+
+func (r *organizationRepository) CountStaging(ctx context.Context, parentID int32) (int64, error) {
+	count, err := r.store.CountStagingOrganizations(ctx, parentID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count staging organizations: %w", err)
+	}
+	return count, nil
+}
+
 func (r *organizationRepository) Delete(ctx context.Context, id int32) error {
 	if err := r.store.DeleteOrganization(ctx, id); err != nil {
 		return fmt.Errorf("failed to delete organization: %w", err)
@@ -191,6 +227,18 @@ func (r *organizationRepository) mapToDomain(sqlcOrg *sqlc.OrganizationsOrganiza
 		Status:    sqlcOrg.Status,
 		CreatedAt: sqlcOrg.CreatedAt.Time,
 		UpdatedAt: sqlcOrg.UpdatedAt.Time,
+
+		Environment: sqlcOrg.Environment,
+	}
+
+	// Map environment fields
+	if sqlcOrg.ParentOrganizationID.Valid {
+		parentID := sqlcOrg.ParentOrganizationID.Int32
+		org.ParentOrganizationID = &parentID
+	}
+	if sqlcOrg.ExpiresAt.Valid {
+		expiresAt := sqlcOrg.ExpiresAt.Time
+		org.ExpiresAt = &expiresAt
 	}
 
 	// Map Stytch fields
@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// roleAssignmentRepository implements domain.RoleAssignmentRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type roleAssignmentRepository struct {
+	store      sqlc.Store
+	normalizer domain.EmailNormalizer
+}
+
+// NewRoleAssignmentRepository creates a new RoleAssignmentRepository implementation.
+// Emails of global assignments are normalized with normalizer, like account emails.
+func NewRoleAssignmentRepository(store sqlc.Store, normalizer domain.EmailNormalizer) domain.RoleAssignmentRepository {
+	return &roleAssignmentRepository{store: store, normalizer: normalizer}
+}
+
+func (r *roleAssignmentRepository) CreateForAccount(ctx context.Context, orgID, accountID int32, roleID string, assignedBy *int32) (*domain.RoleAssignment, error) {
+	result, err := r.store.CreateOrganizationRoleAssignment(ctx, sqlc.CreateOrganizationRoleAssignmentParams{
+		RoleID:              roleID,
+		OrganizationID:      orgID,
+		AccountID:           accountID,
+		AssignedByAccountID: helpers.ToPgInt4Ptr(assignedBy),
+	})
+	if err != nil {
+		return nil, r.createError(err)
+	}
+	return toRoleAssignment(result), nil
+}
+
+func (r *roleAssignmentRepository) CreateGlobal(ctx context.Context, email, roleID string) (*domain.RoleAssignment, error) {
+	result, err := r.store.CreateGlobalRoleAssignment(ctx, sqlc.CreateGlobalRoleAssignmentParams{
+		RoleID: roleID,
+		Email:  r.normalizer.Normalize(email),
+	})
+	if err != nil {
+		return nil, r.createError(err)
+	}
+	return toRoleAssignment(result), nil
+}
+
+func (r *roleAssignmentRepository) ListByAccount(ctx context.Context, orgID, accountID int32) ([]*domain.RoleAssignment, error) {
+	results, err := r.store.ListAccountRoleAssignments(ctx, sqlc.ListAccountRoleAssignmentsParams{
+		OrganizationID: orgID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list role assignments: %w", err)
+	}
+	return toRoleAssignments(results), nil
+}
+
+func (r *roleAssignmentRepository) ListGlobal(ctx context.Context, email string) ([]*domain.RoleAssignment, error) {
+	if email != "" {
+		email = r.normalizer.Normalize(email)
+	}
+	results, err := r.store.ListGlobalRoleAssignments(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list global role assignments: %w", err)
+	}
+	return toRoleAssignments(results), nil
+}
+
+func (r *roleAssignmentRepository) DeleteForAccount(ctx context.Context, orgID, accountID int32, roleID string) (*domain.RoleAssignment, error) {
+	var deleted sqlc.RbacRoleAssignment
+	err := r.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		if err := q.LockRoleAssignments(ctx); err != nil {
+			return fmt.Errorf("failed to lock role assignments: %w", err)
+		}
+
+		var adminsBefore int64
+		if roleID == domain.AdminRoleID {
+			count, err := q.CountOrganizationAdmins(ctx, orgID)
+			if err != nil {
+				return fmt.Errorf("failed to count organization admins: %w", err)
+			}
+			adminsBefore = count
+		}
+
+		var err error
+		deleted, err = q.DeleteOrganizationRoleAssignment(ctx, sqlc.DeleteOrganizationRoleAssignmentParams{
+			OrganizationID: orgID,
+			AccountID:      accountID,
+			RoleID:         roleID,
+		})
+		if err != nil {
+			if errors.Is(err, sqlc.ErrRecordNotFound) {
+				return domain.ErrRoleAssignmentNotFound
+			}
+			return fmt.Errorf("failed to delete role assignment: %w", err)
+		}
+
+		if roleID == domain.AdminRoleID && adminsBefore > 0 {
+			adminsAfter, err := q.CountOrganizationAdmins(ctx, orgID)
+			if err != nil {
+				return fmt.Errorf("failed to count organization admins: %w", err)
+			}
+			if adminsAfter == 0 {
+				return domain.ErrRoleAssignmentLastAdmin
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toRoleAssignment(deleted), nil
+}
+
+func (r *roleAssignmentRepository) DeleteGlobal(ctx context.Context, id int32) (*domain.RoleAssignment, error) {
+	var deleted sqlc.RbacRoleAssignment
+	err := r.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		if err := q.LockRoleAssignments(ctx); err != nil {
+			return fmt.Errorf("failed to lock role assignments: %w", err)
+		}
+
+		var err error
+		deleted, err = q.DeleteGlobalRoleAssignment(ctx, id)
+		if err != nil {
+			if errors.Is(err, sqlc.ErrRecordNotFound) {
+				return domain.ErrRoleAssignmentNotFound
+			}
+			return fmt.Errorf("failed to delete global role assignment: %w", err)
+		}
+
+		// Until now the user was an admin in each organization they have an
+		// active account in, so any of them without an admin lost its last one
+		if deleted.RoleID == domain.AdminRoleID {
+			orgIDs, err := q.ListOrganizationsWithoutAdmins(ctx, deleted.Email.String)
+			if err != nil {
+				return fmt.Errorf("failed to check organization admins: %w", err)
+			}
+			if len(orgIDs) > 0 {
+				return fmt.Errorf("%w (organizations %v)", domain.ErrRoleAssignmentLastAdmin, orgIDs)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toRoleAssignment(deleted), nil
+}
+
+func (r *roleAssignmentRepository) createError(err error) error {
+	switch sqlc.ErrorCode(err) {
+	case sqlc.UniqueViolation:
+		return domain.ErrRoleAssignmentExists
+	case sqlc.ForeignKeyViolation:
+		return domain.ErrRoleAssignmentInvalidRole
+	}
+	return fmt.Errorf("failed to create role assignment: %w", err)
+}
+
+func toRoleAssignments(results []sqlc.RbacRoleAssignment) []*domain.RoleAssignment {
+	assignments := make([]*domain.RoleAssignment, len(results))
+	for i, result := range results {
+		assignments[i] = toRoleAssignment(result)
+	}
+	return assignments
+}
+
+func toRoleAssignment(result sqlc.RbacRoleAssignment) *domain.RoleAssignment {
+	return &domain.RoleAssignment{
+		ID:                  result.ID,
+		RoleID:              result.RoleID,
+		OrganizationID:      helpers.FromPgInt4Ptr(result.OrganizationID),
+		AccountID:           helpers.FromPgInt4Ptr(result.AccountID),
+		Email:               helpers.FromPgText(result.Email),
+		AssignedByAccountID: helpers.FromPgInt4Ptr(result.AssignedByAccountID),
+		CreatedAt:           result.CreatedAt.Time,
+	}
+}
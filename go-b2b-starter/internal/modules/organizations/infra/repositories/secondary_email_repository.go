@@ -0,0 +1,260 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// secondaryEmailRepository implements domain.SecondaryEmailRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type secondaryEmailRepository struct {
+	store      sqlc.Store
+	normalizer domain.EmailNormalizer
+}
+
+// NewSecondaryEmailRepository creates a new SecondaryEmailRepository implementation.
+// Addresses are normalized with normalizer, like account emails.
+func NewSecondaryEmailRepository(store sqlc.Store, normalizer domain.EmailNormalizer) domain.SecondaryEmailRepository {
+	return &secondaryEmailRepository{store: store, normalizer: normalizer}
+}
+
+func (r *secondaryEmailRepository) Create(ctx context.Context, email *domain.SecondaryEmail) (*domain.SecondaryEmail, error) {
+	var expiresAt time.Time
+	if email.VerificationExpiresAt != nil {
+		expiresAt = *email.VerificationExpiresAt
+	}
+
+	result, err := r.store.CreateSecondaryEmail(ctx, sqlc.CreateSecondaryEmailParams{
+		OrganizationID:        email.OrganizationID,
+		AccountID:             email.AccountID,
+		Email:                 r.normalizer.Normalize(email.Email),
+		VerificationTokenHash: email.VerificationTokenHash,
+		VerificationExpiresAt: toPgTimestamp(expiresAt),
+	})
+	if err != nil {
+		if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+			return nil, domain.ErrSecondaryEmailExists
+		}
+		return nil, fmt.Errorf("failed to create secondary email: %w", err)
+	}
+	return toSecondaryEmail(result), nil
+}
+
+func (r *secondaryEmailRepository) ListByAccount(ctx context.Context, orgID, accountID int32) ([]*domain.SecondaryEmail, error) {
+	results, err := r.store.ListAccountSecondaryEmails(ctx, sqlc.ListAccountSecondaryEmailsParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list secondary emails: %w", err)
+	}
+	return toSecondaryEmails(results), nil
+}
+
+func (r *secondaryEmailRepository) GetByID(ctx context.Context, orgID, accountID, id int32) (*domain.SecondaryEmail, error) {
+	result, err := r.store.GetSecondaryEmail(ctx, sqlc.GetSecondaryEmailParams{
+		ID:             id,
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrSecondaryEmailNotFound
+		}
+		return nil, fmt.Errorf("failed to get secondary email: %w", err)
+	}
+	return toSecondaryEmail(result), nil
+}
+
+func (r *secondaryEmailRepository) GetByAddress(ctx context.Context, orgID int32, email string) (*domain.SecondaryEmail, error) {
+	result, err := r.store.GetSecondaryEmailByAddress(ctx, sqlc.GetSecondaryEmailByAddressParams{
+		OrganizationID: orgID,
+		Email:          r.normalizer.Normalize(email),
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrSecondaryEmailNotFound
+		}
+		return nil, fmt.Errorf("failed to get secondary email by address: %w", err)
+	}
+	return toSecondaryEmail(result), nil
+}
+
+func (r *secondaryEmailRepository) GetByVerificationTokenHash(ctx context.Context, tokenHash string) (*domain.SecondaryEmail, error) {
+	result, err := r.store.GetSecondaryEmailByVerificationToken(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrSecondaryEmailInvalidToken
+		}
+		return nil, fmt.Errorf("failed to get secondary email by verification token: %w", err)
+	}
+	return toSecondaryEmail(result), nil
+}
+
+func (r *secondaryEmailRepository) ListNotified(ctx context.Context, orgID, accountID int32) ([]string, error) {
+	emails, err := r.store.ListNotifiedSecondaryEmails(ctx, sqlc.ListNotifiedSecondaryEmailsParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notified secondary emails: %w", err)
+	}
+	return emails, nil
+}
+
+func (r *secondaryEmailRepository) SetVerification(ctx context.Context, id int32, tokenHash string, expiresAt time.Time) (*domain.SecondaryEmail, error) {
+	result, err := r.store.SetSecondaryEmailVerification(ctx, sqlc.SetSecondaryEmailVerificationParams{
+		VerificationTokenHash: tokenHash,
+		VerificationExpiresAt: toPgTimestamp(expiresAt),
+		ID:                    id,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrSecondaryEmailVerified
+		}
+		return nil, fmt.Errorf("failed to replace secondary email verification: %w", err)
+	}
+	return toSecondaryEmail(result), nil
+}
+
+func (r *secondaryEmailRepository) Verify(ctx context.Context, id int32) (*domain.SecondaryEmail, error) {
+	result, err := r.store.VerifySecondaryEmail(ctx, id)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrSecondaryEmailVerified
+		}
+		return nil, fmt.Errorf("failed to verify secondary email: %w", err)
+	}
+	return toSecondaryEmail(result), nil
+}
+
+func (r *secondaryEmailRepository) SetNotifications(ctx context.Context, orgID, accountID, id int32, enabled bool) (*domain.SecondaryEmail, error) {
+	result, err := r.store.SetSecondaryEmailNotifications(ctx, sqlc.SetSecondaryEmailNotificationsParams{
+		Notifications:  enabled,
+		ID:             id,
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrSecondaryEmailNotFound
+		}
+		return nil, fmt.Errorf("failed to update secondary email notifications: %w", err)
+	}
+	return toSecondaryEmail(result), nil
+}
+
+func (r *secondaryEmailRepository) MakePrimary(ctx context.Context, orgID, accountID, id int32) (*domain.SecondaryEmail, error) {
+	var swapped sqlc.OrganizationsSecondaryEmail
+	err := r.store.ExecTx(ctx, func(q *sqlc.Queries) error {
+		secondary, err := q.LockSecondaryEmail(ctx, sqlc.LockSecondaryEmailParams{
+			ID:             id,
+			AccountID:      accountID,
+			OrganizationID: orgID,
+		})
+		if err != nil {
+			if errors.Is(err, sqlc.ErrRecordNotFound) {
+				return domain.ErrSecondaryEmailNotFound
+			}
+			return fmt.Errorf("failed to lock secondary email: %w", err)
+		}
+		if !secondary.VerifiedAt.Valid {
+			return domain.ErrSecondaryEmailNotVerified
+		}
+
+		account, err := q.GetAccountByID(ctx, sqlc.GetAccountByIDParams{
+			ID:             accountID,
+			OrganizationID: orgID,
+		})
+		if err != nil {
+			if errors.Is(err, sqlc.ErrRecordNotFound) {
+				return domain.ErrAccountNotFound
+			}
+			return fmt.Errorf("failed to get account: %w", err)
+		}
+
+		if _, err := q.UpdateAccountEmail(ctx, sqlc.UpdateAccountEmailParams{
+			ID:             accountID,
+			OrganizationID: orgID,
+			Email:          secondary.Email,
+		}); err != nil {
+			if isDuplicateUser(err) {
+				return domain.ErrUserAlreadyExists
+			}
+			return fmt.Errorf("failed to update account email: %w", err)
+		}
+
+		swapped, err = q.ReplaceSecondaryEmailAddress(ctx, sqlc.ReplaceSecondaryEmailAddressParams{
+			Email: r.normalizer.Normalize(account.Email),
+			ID:    secondary.ID,
+		})
+		if err != nil {
+			if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+				return domain.ErrSecondaryEmailExists
+			}
+			return fmt.Errorf("failed to store previous primary email: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toSecondaryEmail(swapped), nil
+}
+
+func (r *secondaryEmailRepository) Delete(ctx context.Context, orgID, accountID, id int32) error {
+	rows, err := r.store.DeleteSecondaryEmail(ctx, sqlc.DeleteSecondaryEmailParams{
+		ID:             id,
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete secondary email: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrSecondaryEmailNotFound
+	}
+	return nil
+}
+
+func (r *secondaryEmailRepository) DeleteExpired(ctx context.Context, id int32) (bool, error) {
+	rows, err := r.store.DeleteExpiredSecondaryEmail(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete expired secondary email: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func toSecondaryEmails(results []sqlc.OrganizationsSecondaryEmail) []*domain.SecondaryEmail {
+	emails := make([]*domain.SecondaryEmail, len(results))
+	for i, result := range results {
+		emails[i] = toSecondaryEmail(result)
+	}
+	return emails
+}
+
+func toSecondaryEmail(result sqlc.OrganizationsSecondaryEmail) *domain.SecondaryEmail {
+	email := &domain.SecondaryEmail{
+		ID:             result.ID,
+		OrganizationID: result.OrganizationID,
+		AccountID:      result.AccountID,
+		Email:          result.Email,
+		Notifications:  result.Notifications,
+		CreatedAt:      result.CreatedAt.Time,
+	}
+	if result.VerifiedAt.Valid {
+		email.VerifiedAt = &result.VerifiedAt.Time
+	}
+	if result.VerificationTokenHash.Valid {
+		email.VerificationTokenHash = result.VerificationTokenHash.String
+	}
+	if result.VerificationExpiresAt.Valid {
+		email.VerificationExpiresAt = &result.VerificationExpiresAt.Time
+	}
+	return email
+}
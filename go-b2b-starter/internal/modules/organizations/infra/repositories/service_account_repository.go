@@ -0,0 +1,247 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// serviceAccountRepository implements domain.ServiceAccountRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type serviceAccountRepository struct {
+	store sqlc.Store
+}
+
+// NewServiceAccountRepository creates a new ServiceAccountRepository implementation.
+func NewServiceAccountRepository(store sqlc.Store) domain.ServiceAccountRepository {
+	return &serviceAccountRepository{store: store}
+}
+
+func (r *serviceAccountRepository) Create(ctx context.Context, serviceAccount *domain.ServiceAccount) (*domain.ServiceAccount, error) {
+	result, err := r.store.CreateServiceAccount(ctx, sqlc.CreateServiceAccountParams{
+		OrganizationID:     serviceAccount.OrganizationID,
+		AccountID:          serviceAccount.AccountID,
+		Username:           serviceAccount.Username,
+		Name:               serviceAccount.Name,
+		Description:        serviceAccount.Description,
+		OwnerAccountID:     helpers.ToPgInt4Ptr(serviceAccount.OwnerAccountID),
+		CreatedByAccountID: helpers.ToPgInt4Ptr(serviceAccount.CreatedByAccountID),
+	})
+	if err != nil {
+		if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+			return nil, domain.ErrServiceAccountNameTaken
+		}
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *serviceAccountRepository) GetByID(ctx context.Context, orgID, id int32) (*domain.ServiceAccount, error) {
+	result, err := r.store.GetServiceAccountByID(ctx, sqlc.GetServiceAccountByIDParams{
+		OrganizationID: orgID,
+		ID:             id,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrServiceAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *serviceAccountRepository) ListByOrganization(ctx context.Context, orgID int32) ([]*domain.ServiceAccount, error) {
+	results, err := r.store.ListServiceAccountsByOrganization(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	serviceAccounts := make([]*domain.ServiceAccount, len(results))
+	for i, result := range results {
+		serviceAccounts[i] = r.mapToDomain(&result)
+	}
+
+	return serviceAccounts, nil
+}
+
+func (r *serviceAccountRepository) Update(ctx context.Context, serviceAccount *domain.ServiceAccount) (*domain.ServiceAccount, error) {
+	result, err := r.store.UpdateServiceAccount(ctx, sqlc.UpdateServiceAccountParams{
+		Name:           serviceAccount.Name,
+		Description:    serviceAccount.Description,
+		OwnerAccountID: helpers.ToPgInt4Ptr(serviceAccount.OwnerAccountID),
+		OrganizationID: serviceAccount.OrganizationID,
+		ID:             serviceAccount.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrServiceAccountNotFound
+		}
+		if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+			return nil, domain.ErrServiceAccountNameTaken
+		}
+		return nil, fmt.Errorf("failed to update service account: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *serviceAccountRepository) SetDisabled(ctx context.Context, orgID, id int32, disabled bool) (*domain.ServiceAccount, error) {
+	result, err := r.store.SetServiceAccountDisabled(ctx, sqlc.SetServiceAccountDisabledParams{
+		Disabled:       disabled,
+		OrganizationID: orgID,
+		ID:             id,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrServiceAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to update service account status: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *serviceAccountRepository) CreateKey(ctx context.Context, key *domain.ServiceAccountKey) (*domain.ServiceAccountKey, error) {
+	result, err := r.store.CreateServiceAccountKey(ctx, sqlc.CreateServiceAccountKeyParams{
+		OrganizationID:     key.OrganizationID,
+		ServiceAccountID:   key.ServiceAccountID,
+		Name:               key.Name,
+		KeyPrefix:          key.Prefix,
+		KeyHash:            key.KeyHash,
+		ExpiresAt:          toPgTimestampPtr(key.ExpiresAt),
+		CreatedByAccountID: helpers.ToPgInt4Ptr(key.CreatedByAccountID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return r.mapKeyToDomain(&result), nil
+}
+
+func (r *serviceAccountRepository) ListKeys(ctx context.Context, orgID, serviceAccountID int32) ([]*domain.ServiceAccountKey, error) {
+	results, err := r.store.ListServiceAccountKeys(ctx, sqlc.ListServiceAccountKeysParams{
+		OrganizationID:   orgID,
+		ServiceAccountID: serviceAccountID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	keys := make([]*domain.ServiceAccountKey, len(results))
+	for i, result := range results {
+		keys[i] = r.mapKeyToDomain(&result)
+	}
+
+	return keys, nil
+}
+
+func (r *serviceAccountRepository) CountActiveKeys(ctx context.Context, serviceAccountID int32) (int64, error) {
+	count, err := r.store.CountActiveServiceAccountKeys(ctx, serviceAccountID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count api keys: %w", err)
+	}
+	return count, nil
+}
+
+func (r *serviceAccountRepository) RevokeKey(ctx context.Context, orgID, serviceAccountID, id int32) (*domain.ServiceAccountKey, error) {
+	result, err := r.store.RevokeServiceAccountKey(ctx, sqlc.RevokeServiceAccountKeyParams{
+		OrganizationID:   orgID,
+		ServiceAccountID: serviceAccountID,
+		ID:               id,
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrServiceAccountKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return r.mapKeyToDomain(&result), nil
+}
+
+func (r *serviceAccountRepository) GetCredential(ctx context.Context, keyHash string) (*domain.ServiceAccountCredential, error) {
+	result, err := r.store.GetServiceAccountKeyCredential(ctx, keyHash)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrServiceAccountKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return &domain.ServiceAccountCredential{
+		KeyID:            result.ID,
+		OrganizationID:   result.OrganizationID,
+		ServiceAccountID: result.ServiceAccountID,
+		ExpiresAt:        fromPgTimestampPtr(result.ExpiresAt),
+		Username:         result.Username,
+		Disabled:         result.DisabledAt.Valid,
+		Email:            result.Email,
+		Role:             result.Role,
+		ProviderOrgID:    helpers.FromPgText(result.StytchOrgID),
+	}, nil
+}
+
+func (r *serviceAccountRepository) TouchKey(ctx context.Context, id int32) error {
+	if err := r.store.TouchServiceAccountKey(ctx, id); err != nil {
+		return fmt.Errorf("failed to update api key last use: %w", err)
+	}
+	return nil
+}
+
+// mapToDomain converts SQLC service account to domain entity
+func (r *serviceAccountRepository) mapToDomain(sqlcAccount *sqlc.OrganizationsServiceAccount) *domain.ServiceAccount {
+	return &domain.ServiceAccount{
+		ID:                 sqlcAccount.ID,
+		OrganizationID:     sqlcAccount.OrganizationID,
+		AccountID:          sqlcAccount.AccountID,
+		Username:           sqlcAccount.Username,
+		Name:               sqlcAccount.Name,
+		Description:        sqlcAccount.Description,
+		OwnerAccountID:     helpers.FromPgInt4Ptr(sqlcAccount.OwnerAccountID),
+		CreatedByAccountID: helpers.FromPgInt4Ptr(sqlcAccount.CreatedByAccountID),
+		DisabledAt:         fromPgTimestampPtr(sqlcAccount.DisabledAt),
+		CreatedAt:          sqlcAccount.CreatedAt.Time,
+		UpdatedAt:          sqlcAccount.UpdatedAt.Time,
+	}
+}
+
+// mapKeyToDomain converts SQLC service account key to domain entity
+func (r *serviceAccountRepository) mapKeyToDomain(sqlcKey *sqlc.OrganizationsServiceAccountKey) *domain.ServiceAccountKey {
+	return &domain.ServiceAccountKey{
+		ID:                 sqlcKey.ID,
+		OrganizationID:     sqlcKey.OrganizationID,
+		ServiceAccountID:   sqlcKey.ServiceAccountID,
+		Name:               sqlcKey.Name,
+		Prefix:             sqlcKey.KeyPrefix,
+		KeyHash:            sqlcKey.KeyHash,
+		ExpiresAt:          fromPgTimestampPtr(sqlcKey.ExpiresAt),
+		CreatedByAccountID: helpers.FromPgInt4Ptr(sqlcKey.CreatedByAccountID),
+		LastUsedAt:         fromPgTimestampPtr(sqlcKey.LastUsedAt),
+		RevokedAt:          fromPgTimestampPtr(sqlcKey.RevokedAt),
+		CreatedAt:          sqlcKey.CreatedAt.Time,
+	}
+}
+
+func toPgTimestampPtr(t *time.Time) pgtype.Timestamp {
+	if t == nil {
+		return pgtype.Timestamp{Valid: false}
+	}
+	return pgtype.Timestamp{Time: *t, Valid: true}
+}
+
+func fromPgTimestampPtr(t pgtype.Timestamp) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	value := t.Time
+	return &value
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -12,6 +13,8 @@ import (
 	"github.com/stytchauth/stytch-go/v16/stytch/b2b/magiclinks/email"
 	"github.com/stytchauth/stytch-go/v16/stytch/b2b/organizations"
 	"github.com/stytchauth/stytch-go/v16/stytch/b2b/organizations/members"
+	otpemail "github.com/stytchauth/stytch-go/v16/stytch/b2b/otp/email"
+	passwordemail "github.com/stytchauth/stytch-go/v16/stytch/b2b/passwords/email"
 )
 
 type stytchMemberRepository struct {
@@ -68,6 +71,9 @@ func (r *stytchMemberRepository) UpdateMember(ctx context.Context, req *domain.U
 	if req.Name != nil {
 		params.Name = *req.Name
 	}
+	if req.EmailAddress != nil {
+		params.EmailAddress = *req.EmailAddress
+	}
 	if len(req.Roles) > 0 {
 		rolesCopy := append([]string(nil), req.Roles...)
 		params.Roles = &rolesCopy
@@ -245,6 +251,136 @@ func (r *stytchMemberRepository) SendMagicLink(ctx context.Context, req *domain.
 	return nil
 }
 
+func (r *stytchMemberRepository) SendEmailOTP(ctx context.Context, req *domain.EmailOTPRequest) error {
+	if err := req.Validate(); err != nil {
+		return fmt.Errorf("invalid email otp request: %w", err)
+	}
+
+	// LoginOrSignup would create a member for unknown emails; only send to existing members
+	if _, err := r.GetMemberByEmail(ctx, req.OrganizationID, req.Email); err != nil {
+		return err
+	}
+
+	if _, err := r.client.API().OTPs.Email.LoginOrSignup(ctx, &otpemail.LoginOrSignupParams{
+		OrganizationID: req.OrganizationID,
+		EmailAddress:   req.Email,
+	}); err != nil {
+		return fmt.Errorf("stytch send email otp: %w", stytchcfg.MapError(err))
+	}
+
+	return nil
+}
+
+func (r *stytchMemberRepository) AuthenticateEmailOTP(ctx context.Context, req *domain.EmailOTPRequest) (*domain.AuthMember, error) {
+	if err := req.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid email otp request: %w", err)
+	}
+	if req.Code == "" {
+		return nil, domain.ErrMFARecoveryInvalidCode
+	}
+
+	resp, err := r.client.API().OTPs.Email.Authenticate(ctx, &otpemail.AuthenticateParams{
+		OrganizationID: req.OrganizationID,
+		EmailAddress:   req.Email,
+		Code:           req.Code,
+	})
+	if err != nil {
+		mapped := stytchcfg.MapError(err)
+		if errors.Is(mapped, stytchcfg.ErrBadRequest) || errors.Is(mapped, stytchcfg.ErrUnauthorized) || errors.Is(mapped, stytchcfg.ErrNotFound) {
+			return nil, domain.ErrMFARecoveryInvalidCode
+		}
+		return nil, fmt.Errorf("stytch authenticate email otp: %w", mapped)
+	}
+
+	return mapToAuthMember(resp.Member), nil
+}
+
+func (r *stytchMemberRepository) ResetMFA(ctx context.Context, organizationID, memberID string) error {
+	if organizationID == "" {
+		return domain.ErrAuthOrganizationIDRequired
+	}
+	if memberID == "" {
+		return domain.ErrAuthMemberIDRequired
+	}
+
+	resp, err := r.client.API().Organizations.Members.Get(ctx, &members.GetParams{
+		OrganizationID: organizationID,
+		MemberID:       memberID,
+	})
+	if err != nil {
+		return fmt.Errorf("stytch get member: %w", stytchcfg.MapError(err))
+	}
+
+	if resp.Member.TOTPRegistrationID != "" {
+		if _, err := r.client.API().Organizations.Members.DeleteTOTP(ctx, &members.DeleteTOTPParams{
+			OrganizationID: organizationID,
+			MemberID:       memberID,
+		}); err != nil {
+			return fmt.Errorf("stytch delete member totp: %w", stytchcfg.MapError(err))
+		}
+	}
+
+	if resp.Member.MFAPhoneNumber != "" {
+		if _, err := r.client.API().Organizations.Members.DeleteMFAPhoneNumber(ctx, &members.DeleteMFAPhoneNumberParams{
+			OrganizationID: organizationID,
+			MemberID:       memberID,
+		}); err != nil {
+			return fmt.Errorf("stytch delete member mfa phone number: %w", stytchcfg.MapError(err))
+		}
+	}
+
+	r.logger.Info("reset member mfa factors", loggerDomain.Fields{
+		"org_id":    organizationID,
+		"member_id": memberID,
+	})
+
+	return nil
+}
+
+func (r *stytchMemberRepository) ResetPassword(ctx context.Context, organizationID, memberID string) error {
+	if organizationID == "" {
+		return domain.ErrAuthOrganizationIDRequired
+	}
+	if memberID == "" {
+		return domain.ErrAuthMemberIDRequired
+	}
+
+	resp, err := r.client.API().Organizations.Members.Get(ctx, &members.GetParams{
+		OrganizationID: organizationID,
+		MemberID:       memberID,
+	})
+	if err != nil {
+		return fmt.Errorf("stytch get member: %w", stytchcfg.MapError(err))
+	}
+
+	if resp.Member.MemberPasswordID != "" {
+		if _, err := r.client.API().Organizations.Members.DeletePassword(ctx, &members.DeletePasswordParams{
+			OrganizationID:   organizationID,
+			MemberPasswordID: resp.Member.MemberPasswordID,
+		}); err != nil {
+			return fmt.Errorf("stytch delete member password: %w", stytchcfg.MapError(err))
+		}
+	}
+
+	params := &passwordemail.ResetStartParams{
+		OrganizationID: organizationID,
+		EmailAddress:   resp.Member.EmailAddress,
+	}
+	if loginRedirect := strings.TrimSpace(r.config.LoginRedirectURL); loginRedirect != "" {
+		params.LoginRedirectURL = loginRedirect
+	}
+	if _, err := r.client.API().Passwords.Email.ResetStart(ctx, params); err != nil {
+		return fmt.Errorf("stytch start password reset: %w", stytchcfg.MapError(err))
+	}
+
+	r.logger.Info("reset member password", loggerDomain.Fields{
+		"org_id":    organizationID,
+		"member_id": memberID,
+	})
+
+	return nil
+}
+
 func mapToAuthMember(src organizations.Member) *domain.AuthMember {
 	var createdAt, updatedAt time.Time
 	if src.CreatedAt != nil {
@@ -0,0 +1,94 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// tagRepository implements domain.TagRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type tagRepository struct {
+	store sqlc.Store
+}
+
+// NewTagRepository creates a new TagRepository implementation.
+func NewTagRepository(store sqlc.Store) domain.TagRepository {
+	return &tagRepository{store: store}
+}
+
+func (r *tagRepository) List(ctx context.Context, orgID int32) ([]*domain.Tag, error) {
+	results, err := r.store.ListTags(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	tags := make([]*domain.Tag, len(results))
+	for i, result := range results {
+		tags[i] = &domain.Tag{
+			ID:                 result.ID,
+			OrganizationID:     result.OrganizationID,
+			Name:               result.Name,
+			AccountCount:       result.AccountCount,
+			CreatedByAccountID: helpers.FromPgInt4Ptr(result.CreatedByAccountID),
+			CreatedAt:          result.CreatedAt.Time,
+		}
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) Delete(ctx context.Context, orgID int32, name string) error {
+	rows, err := r.store.DeleteTag(ctx, sqlc.DeleteTagParams{
+		OrganizationID: orgID,
+		Name:           name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete tag: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrTagNotFound
+	}
+	return nil
+}
+
+func (r *tagRepository) ListAccountTags(ctx context.Context, orgID, accountID int32) ([]string, error) {
+	tags, err := r.store.ListAccountTags(ctx, sqlc.ListAccountTagsParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list account tags: %w", err)
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) AddAccountTag(ctx context.Context, orgID, accountID int32, name string, actorID int32) error {
+	err := r.store.AddAccountTag(ctx, sqlc.AddAccountTagParams{
+		OrganizationID: orgID,
+		Name:           name,
+		ActorAccountID: actorID,
+		AccountID:      accountID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add account tag: %w", err)
+	}
+	return nil
+}
+
+func (r *tagRepository) RemoveAccountTag(ctx context.Context, orgID, accountID int32, name string) error {
+	rows, err := r.store.RemoveAccountTag(ctx, sqlc.RemoveAccountTagParams{
+		AccountID:      accountID,
+		OrganizationID: orgID,
+		Name:           name,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove account tag: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrAccountTagNotFound
+	}
+	return nil
+}
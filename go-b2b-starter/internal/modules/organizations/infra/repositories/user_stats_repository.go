@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+)
+
+// userStatsRepository implements domain.UserStatsRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type userStatsRepository struct {
+	store sqlc.Store
+}
+
+// NewUserStatsRepository creates a new UserStatsRepository implementation.
+func NewUserStatsRepository(store sqlc.Store) domain.UserStatsRepository {
+	return &userStatsRepository{store: store}
+}
+
+func (r *userStatsRepository) GetStats(ctx context.Context, orgID int32) (*domain.UserStats, error) {
+	result, err := r.store.GetUserStats(ctx, organizationFilter(orgID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user stats: %w", err)
+	}
+
+	stats := &domain.UserStats{
+		TotalAccounts:      result.TotalAccounts,
+		ActiveAccounts:     result.ActiveAccounts,
+		DormantAccounts:    result.DormantAccounts,
+		SuspendedAccounts:  result.SuspendedAccounts,
+		VerifiedAccounts:   result.VerifiedAccounts,
+		VerificationRate:   domain.VerificationRate(result.VerifiedAccounts, result.TotalAccounts),
+		DailyActiveUsers:   result.DailyActiveUsers,
+		WeeklyActiveUsers:  result.WeeklyActiveUsers,
+		MonthlyActiveUsers: result.MonthlyActiveUsers,
+		GeneratedAt:        time.Now().UTC(),
+	}
+	if orgID != 0 {
+		stats.OrganizationID = &orgID
+	}
+	return stats, nil
+}
+
+func (r *userStatsRepository) ListDailySignups(ctx context.Context, orgID, days int32) ([]*domain.DailySignups, error) {
+	results, err := r.store.ListDailySignups(ctx, sqlc.ListDailySignupsParams{
+		Days:           days,
+		OrganizationID: organizationFilter(orgID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list daily signups: %w", err)
+	}
+
+	signups := make([]*domain.DailySignups, len(results))
+	for i, result := range results {
+		signups[i] = &domain.DailySignups{
+			Day:              result.Day.Time.Format(time.DateOnly),
+			Signups:          result.Signups,
+			Verified:         result.Verified,
+			VerificationRate: domain.VerificationRate(result.Verified, result.Signups),
+		}
+	}
+	return signups, nil
+}
+
+// organizationFilter leaves the organization out of the query when orgID is 0
+func organizationFilter(orgID int32) pgtype.Int4 {
+	if orgID == 0 {
+		return pgtype.Int4{}
+	}
+	return helpers.ToPgInt4(orgID)
+}
@@ -0,0 +1,196 @@
+package organizations
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type InviteHandler struct {
+	inviteService services.InviteService
+	logger        logger.Logger
+}
+
+func NewInviteHandler(inviteService services.InviteService, logger logger.Logger) *InviteHandler {
+	return &InviteHandler{
+		inviteService: inviteService,
+		logger:        logger,
+	}
+}
+
+// CreateInvite godoc
+// @Summary Create invite
+// @Description Creates an invite to the current organization, optionally pinned to an email address and a role (defaults to member). The token and link are only returned once; pinned invites are also emailed to the invitee.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param request body services.CreateInviteRequest true "Invite"
+// @Success 201 {object} services.CreateInviteResponse "Created invite with its token"
+// @Failure 400 {object} map[string]string "Invalid request, role or expiry"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/invites [post]
+func (h *InviteHandler) CreateInvite(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.CreateInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	result, err := h.inviteService.CreateInvite(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrInviteRoleInvalid, domain.ErrInviteInvalidTTL:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		default:
+			h.logger.Error("failed to create invite", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to create invite", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, result)
+}
+
+// ListInvites godoc
+// @Summary List invites
+// @Description Returns the invites of the current organization, newest first. Pending invites past their expiry are reported as expired.
+// @Tags Organizations
+// @Produce json
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} domain.Invite "Invites"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/invites [get]
+func (h *InviteHandler) ListInvites(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	offset, _ := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	invites, err := h.inviteService.ListInvites(c.Request.Context(), reqCtx.OrganizationID, int32(limit), int32(offset))
+	if err != nil {
+		h.logger.Error("failed to list invites", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list invites", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, invites)
+}
+
+// RevokeInvite godoc
+// @Summary Revoke invite
+// @Description Revokes a pending invite so its token can no longer be used.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Invite ID"
+// @Success 200 {object} domain.Invite "Revoked invite"
+// @Failure 400 {object} map[string]string "Invalid invite ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 409 {object} map[string]string "Invite is not pending"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/invites/{id}/revoke [post]
+func (h *InviteHandler) RevokeInvite(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var inviteID int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &inviteID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid invite ID format", err)
+		return
+	}
+
+	invite, err := h.inviteService.RevokeInvite(c.Request.Context(), reqCtx.OrganizationID, inviteID)
+	if err != nil {
+		if err == domain.ErrInviteNotPending {
+			response.Error(c, http.StatusConflict, err.Error(), err)
+			return
+		}
+		h.logger.Error("failed to revoke invite", map[string]interface{}{"org_id": reqCtx.OrganizationID, "invite_id": inviteID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to revoke invite", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, invite)
+}
+
+// AcceptInvite godoc
+// @Summary Accept invite
+// @Description Registers the invitee as a member of the inviting organization with the invite's role. Invites pinned to an email can only be accepted with that address. The member signs in through the usual login flow afterwards.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body services.AcceptInviteRequest true "Invite token and member details"
+// @Success 201 {object} services.AddMemberResponse "Registered member"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 402 {object} map[string]string "All purchased seats are in use"
+// @Failure 403 {object} map[string]string "Invite was issued to a different email"
+// @Failure 404 {object} map[string]string "Invite not found"
+// @Failure 409 {object} map[string]string "Invite already used or member already exists"
+// @Failure 410 {object} map[string]string "Invite has expired"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/invites/accept [post]
+func (h *InviteHandler) AcceptInvite(c *gin.Context) {
+	var req services.AcceptInviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	member, err := h.inviteService.AcceptInvite(c.Request.Context(), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrInviteNotFound:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case domain.ErrInviteNotPending, domain.ErrAuthMemberAlreadyExists:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		case domain.ErrInviteExpired:
+			response.Error(c, http.StatusGone, err.Error(), err)
+		case domain.ErrInviteEmailMismatch:
+			response.Error(c, http.StatusForbidden, err.Error(), err)
+		case paywall.ErrSeatLimitReached:
+			response.Error(c, http.StatusPaymentRequired, err.Error(), err)
+		default:
+			h.logger.Error("failed to accept invite", map[string]interface{}{"error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to accept invite", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, member)
+}
@@ -0,0 +1,145 @@
+package organizations
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type IPAllowlistHandler struct {
+	allowlistService services.IPAllowlistService
+	logger           logger.Logger
+}
+
+func NewIPAllowlistHandler(allowlistService services.IPAllowlistService, logger logger.Logger) *IPAllowlistHandler {
+	return &IPAllowlistHandler{
+		allowlistService: allowlistService,
+		logger:           logger,
+	}
+}
+
+// ListEntries godoc
+// @Summary List organization IP allowlist
+// @Description Returns the CIDR ranges allowed to access the API for the current organization. An empty list means access is not restricted.
+// @Tags Organizations
+// @Produce json
+// @Success 200 {array} domain.IPAllowlistEntry "Allowlist entries"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/ip-allowlist [get]
+func (h *IPAllowlistHandler) ListEntries(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	entries, err := h.allowlistService.ListEntries(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.logger.Error("failed to list ip allowlist", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list ip allowlist", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, entries)
+}
+
+// AddEntry godoc
+// @Summary Add IP allowlist entry
+// @Description Adds a CIDR range or single IP address to the organization's allowlist. Once the list is non-empty, members can only access the API from allowed addresses; the organization owner can always bypass (audited).
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param request body services.AddIPAllowlistEntryRequest true "Allowlist entry"
+// @Success 201 {object} domain.IPAllowlistEntry "Created entry"
+// @Failure 400 {object} map[string]string "Invalid CIDR"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 409 {object} map[string]string "Entry already exists"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/ip-allowlist [post]
+func (h *IPAllowlistHandler) AddEntry(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.AddIPAllowlistEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	entry, err := h.allowlistService.AddEntry(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrIPAllowlistInvalidCIDR:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrIPAllowlistEntryExists:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		default:
+			h.logger.Error("failed to add ip allowlist entry", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to add ip allowlist entry", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, entry)
+}
+
+// RemoveEntry godoc
+// @Summary Remove IP allowlist entry
+// @Description Removes an entry from the organization's IP allowlist. Removing the last entry lifts the restriction.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Entry ID"
+// @Success 204 "Entry removed"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Entry not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/ip-allowlist/{id} [delete]
+func (h *IPAllowlistHandler) RemoveEntry(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	entryIDParam := c.Param("id")
+	var entryID int32
+	if _, err := fmt.Sscanf(entryIDParam, "%d", &entryID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid entry ID format", err)
+		return
+	}
+
+	if err := h.allowlistService.RemoveEntry(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, entryID); err != nil {
+		if err == domain.ErrIPAllowlistEntryNotFound {
+			response.Error(c, http.StatusNotFound, "ip allowlist entry not found", err)
+			return
+		}
+		h.logger.Error("failed to remove ip allowlist entry", map[string]interface{}{"org_id": reqCtx.OrganizationID, "entry_id": entryID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to remove ip allowlist entry", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,170 @@
+package organizations
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type LinkedIdentityHandler struct {
+	identityService services.LinkedIdentityService
+	logger          logger.Logger
+}
+
+func NewLinkedIdentityHandler(identityService services.LinkedIdentityService, logger logger.Logger) *LinkedIdentityHandler {
+	return &LinkedIdentityHandler{
+		identityService: identityService,
+		logger:          logger,
+	}
+}
+
+// ListIdentities godoc
+// @Summary List my linked identities
+// @Description Returns the Google and GitHub identities linked to the signed-in member's account.
+// @Tags auth
+// @Produce json
+// @Success 200 {array} domain.LinkedIdentity "Linked identities"
+// @Failure 400 {object} map[string]string "Missing organization context"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/identities [get]
+func (h *LinkedIdentityHandler) ListIdentities(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	identities, err := h.identityService.ListIdentities(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID)
+	if err != nil {
+		h.handleError(c, "failed to list identities", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, identities)
+}
+
+// StartLink godoc
+// @Summary Start linking an identity
+// @Description Returns the provider page where the signed-in member signs in with the Google or GitHub identity to link. The provider redirects to IDENTITY_REDIRECT_URL, which posts the code and state to /me/identities/{provider}/callback. Requires a recent sign-in.
+// @Tags auth
+// @Produce json
+// @Param provider path string true "google or github"
+// @Success 200 {object} services.IdentityAuthorization "Where to send the member"
+// @Failure 400 {object} map[string]string "Unknown provider"
+// @Failure 401 {object} map[string]string "Unauthorized or sign-in too old"
+// @Failure 404 {object} map[string]string "Provider not enabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/identities/{provider}/link [post]
+func (h *LinkedIdentityHandler) StartLink(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	authorization, err := h.identityService.StartLink(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, c.Param("provider"))
+	if err != nil {
+		h.handleError(c, "failed to start identity link", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, authorization)
+}
+
+// CompleteLink godoc
+// @Summary Finish linking an identity
+// @Description Links the identity the member signed in with at the provider to their account. The state must come from a link started by the same member. An account has one identity per provider, and an identity can be linked to one account per organization.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param provider path string true "google or github"
+// @Param request body services.CompleteIdentityRequest true "Code and state from the provider redirect"
+// @Success 200 {object} domain.LinkedIdentity "Linked identity"
+// @Failure 400 {object} map[string]string "Invalid request, state or code"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Provider not enabled"
+// @Failure 409 {object} map[string]string "Provider already linked or identity linked to another account"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/identities/{provider}/callback [post]
+func (h *LinkedIdentityHandler) CompleteLink(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	var req services.CompleteIdentityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	identity, err := h.identityService.CompleteLink(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, c.Param("provider"), &req)
+	if err != nil {
+		h.handleError(c, "failed to link identity", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, identity)
+}
+
+// Unlink godoc
+// @Summary Unlink an identity
+// @Description Removes the signed-in member's identity at the provider. Requires a recent sign-in.
+// @Tags auth
+// @Produce json
+// @Param provider path string true "google or github"
+// @Success 204 "Identity unlinked"
+// @Failure 400 {object} map[string]string "Unknown provider"
+// @Failure 401 {object} map[string]string "Unauthorized or sign-in too old"
+// @Failure 404 {object} map[string]string "No identity linked at the provider"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/identities/{provider} [delete]
+func (h *LinkedIdentityHandler) Unlink(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.identityService.Unlink(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, c.Param("provider")); err != nil {
+		h.handleError(c, "failed to unlink identity", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *LinkedIdentityHandler) requestContext(c *gin.Context) (*auth.RequestContext, bool) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return nil, false
+	}
+	return reqCtx, true
+}
+
+func (h *LinkedIdentityHandler) handleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrIdentityProviderUnknown), errors.Is(err, domain.ErrIdentityInvalidState),
+		errors.Is(err, domain.ErrIdentityExchangeFailed):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, domain.ErrIdentityProviderDisabled), errors.Is(err, domain.ErrIdentityNotLinked):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrIdentityAlreadyLinked), errors.Is(err, domain.ErrIdentityLinkedElsewhere):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	default:
+		fields := map[string]interface{}{"provider": c.Param("provider"), "error": err.Error()}
+		if reqCtx := auth.GetRequestContext(c); reqCtx != nil {
+			fields["org_id"] = reqCtx.OrganizationID
+			fields["account_id"] = reqCtx.AccountID
+		}
+		h.logger.Error(message, fields)
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
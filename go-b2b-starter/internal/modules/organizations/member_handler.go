@@ -1,28 +1,35 @@
 package organizations
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/pkg/httperr"
 	"github.com/moasq/go-b2b-starter/pkg/response"
 	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
 	"github.com/moasq/go-b2b-starter/internal/platform/logger"
 )
 
 type MemberHandler struct {
 	memberService services.MemberService
+	invitePolicy  *services.InvitePolicy
 	logger        logger.Logger
 }
 
 func NewMemberHandler(
 	memberService services.MemberService,
+	invitePolicy *services.InvitePolicy,
 	logger logger.Logger,
 ) *MemberHandler {
 	return &MemberHandler{
 		memberService: memberService,
+		invitePolicy:  invitePolicy,
 		logger:        logger,
 	}
 }
@@ -36,9 +43,15 @@ func NewMemberHandler(
 // @Param request body services.BootstrapOrganizationRequest true "Organization bootstrap request (passwordless - no password required)"
 // @Success 201 {object} services.BootstrapOrganizationResponse
 // @Failure 400 {object} map[string]any "Invalid request payload"
+// @Failure 403 {object} map[string]any "Registration requires an invite"
 // @Failure 500 {object} map[string]any "Failed to bootstrap organization"
 // @Router /auth/signup [post]
 func (h *MemberHandler) BootstrapOrganization(c *gin.Context) {
+	if h.invitePolicy.InviteOnly {
+		response.Error(c, http.StatusForbidden, domain.ErrRegistrationInviteOnly.Error(), domain.ErrRegistrationInviteOnly)
+		return
+	}
+
 	var req services.BootstrapOrganizationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		h.logger.Error("invalid bootstrap request payload", map[string]any{
@@ -80,6 +93,8 @@ func (h *MemberHandler) BootstrapOrganization(c *gin.Context) {
 // @Param role_slug body string false "Role slug (defaults to 'member')"
 // @Success 201 {object} services.AddMemberResponse
 // @Failure 400 {object} map[string]any "Invalid request payload or missing organization context"
+// @Failure 402 {object} httperr.HTTPError "All purchased seats are in use (code seat_limit_reached)"
+// @Failure 409 {object} httperr.HTTPError "A member with this email already exists (code user_already_exists)"
 // @Failure 500 {object} map[string]any "Failed to add member"
 // @Router /auth/members [post]
 func (h *MemberHandler) AddMember(c *gin.Context) {
@@ -106,6 +121,14 @@ func (h *MemberHandler) AddMember(c *gin.Context) {
 
 	result, err := h.memberService.AddMemberDirect(c.Request.Context(), &req)
 	if err != nil {
+		if errors.Is(err, domain.ErrAuthMemberAlreadyExists) || errors.Is(err, domain.ErrUserAlreadyExists) {
+			c.JSON(http.StatusConflict, httperr.NewHTTPError(http.StatusConflict, "user_already_exists", err.Error()))
+			return
+		}
+		if errors.Is(err, paywall.ErrSeatLimitReached) {
+			c.JSON(http.StatusPaymentRequired, httperr.NewHTTPError(http.StatusPaymentRequired, "seat_limit_reached", err.Error()))
+			return
+		}
 		h.logger.Error("failed to add member", map[string]any{
 			"org_id": reqCtx.ProviderOrgID,
 			"email":  req.Email,
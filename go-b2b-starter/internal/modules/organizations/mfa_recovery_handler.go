@@ -0,0 +1,280 @@
+package organizations
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type MFARecoveryHandler struct {
+	recoveryService services.MFARecoveryService
+	logger          logger.Logger
+}
+
+func NewMFARecoveryHandler(recoveryService services.MFARecoveryService, logger logger.Logger) *MFARecoveryHandler {
+	return &MFARecoveryHandler{
+		recoveryService: recoveryService,
+		logger:          logger,
+	}
+}
+
+// StartRecovery godoc
+// @Summary Start MFA recovery
+// @Description Emails a one-time verification code to a member who lost their MFA device. The response is the same whether or not the member exists.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body services.StartMFARecoveryRequest true "Organization and email"
+// @Success 202 {object} map[string]string "Code sent if the member exists"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Router /auth/mfa-recovery/start [post]
+func (h *MFARecoveryHandler) StartRecovery(c *gin.Context) {
+	var req services.StartMFARecoveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	if err := h.recoveryService.StartRecovery(c.Request.Context(), &req); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, gin.H{"message": "if the account exists, a verification code has been sent"})
+}
+
+// VerifyRecovery godoc
+// @Summary Verify MFA recovery code
+// @Description Verifies the emailed code and opens a recovery request. MFA can be reset once the waiting period has passed (and an admin has approved, if required). The recovery token is returned only once.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body services.VerifyMFARecoveryRequest true "Organization, email and code"
+// @Success 201 {object} services.MFARecoveryTicket "Recovery ticket"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Invalid or expired code"
+// @Failure 409 {object} map[string]string "Recovery already in progress"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/mfa-recovery/verify [post]
+func (h *MFARecoveryHandler) VerifyRecovery(c *gin.Context) {
+	var req services.VerifyMFARecoveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	ticket, err := h.recoveryService.VerifyRecovery(c.Request.Context(), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrAuthOrganizationIDRequired, domain.ErrAuthEmailRequired, domain.ErrAuthInvalidEmail:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrMFARecoveryInvalidCode:
+			response.Error(c, http.StatusUnauthorized, err.Error(), err)
+		case domain.ErrMFARecoveryInProgress:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		default:
+			h.logger.Error("failed to verify mfa recovery", map[string]interface{}{"stytch_org_id": req.OrganizationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to verify mfa recovery", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, ticket)
+}
+
+// CompleteRecovery godoc
+// @Summary Complete MFA recovery
+// @Description Removes the member's enrolled MFA factors so a new device can be enrolled at the next login.
+// @Tags Auth
+// @Accept json
+// @Produce json
+// @Param request body services.CompleteMFARecoveryRequest true "Recovery ID and token"
+// @Success 200 {object} domain.MFARecoveryRequest "Completed recovery"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 403 {object} map[string]string "Awaiting approval or waiting period not over"
+// @Failure 404 {object} map[string]string "Recovery not found"
+// @Failure 409 {object} map[string]string "Recovery is no longer open"
+// @Failure 410 {object} map[string]string "Recovery expired"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/mfa-recovery/complete [post]
+func (h *MFARecoveryHandler) CompleteRecovery(c *gin.Context) {
+	var req services.CompleteMFARecoveryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	recovery, err := h.recoveryService.CompleteRecovery(c.Request.Context(), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrMFARecoveryNotFound:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case domain.ErrMFARecoveryNotOpen:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		case domain.ErrMFARecoveryAwaitingApproval, domain.ErrMFARecoveryNotYetEligible:
+			response.Error(c, http.StatusForbidden, err.Error(), err)
+		case domain.ErrMFARecoveryExpired:
+			response.Error(c, http.StatusGone, err.Error(), err)
+		default:
+			h.logger.Error("failed to complete mfa recovery", map[string]interface{}{"recovery_id": req.RecoveryID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to complete mfa recovery", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, recovery)
+}
+
+// ListRecoveries godoc
+// @Summary List MFA recovery requests
+// @Description Lists the organization's MFA recovery requests, newest first.
+// @Tags Organizations
+// @Produce json
+// @Param status query string false "Filter by status (pending, approved, denied, cancelled, completed)"
+// @Success 200 {array} domain.MFARecoveryRequest "Recovery requests"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /mfa-recoveries [get]
+func (h *MFARecoveryHandler) ListRecoveries(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	recoveries, err := h.recoveryService.ListRecoveries(c.Request.Context(), reqCtx.OrganizationID, c.Query("status"))
+	if err != nil {
+		h.logger.Error("failed to list mfa recoveries", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list mfa recoveries", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, recoveries)
+}
+
+// ApproveRecovery godoc
+// @Summary Approve MFA recovery request
+// @Description Approves a recovery request when the policy requires admin approval. Members cannot approve their own requests.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Recovery ID"
+// @Success 200 {object} domain.MFARecoveryRequest "Approved recovery"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 403 {object} map[string]string "Forbidden or self-approval"
+// @Failure 404 {object} map[string]string "Recovery not found"
+// @Failure 409 {object} map[string]string "Recovery is not awaiting approval"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /mfa-recoveries/{id}/approve [post]
+func (h *MFARecoveryHandler) ApproveRecovery(c *gin.Context) {
+	h.decide(c, h.recoveryService.ApproveRecovery)
+}
+
+// DenyRecovery godoc
+// @Summary Deny MFA recovery request
+// @Description Denies a recovery request when the policy requires admin approval. Members cannot decide their own requests.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Recovery ID"
+// @Success 200 {object} domain.MFARecoveryRequest "Denied recovery"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 403 {object} map[string]string "Forbidden or self-approval"
+// @Failure 404 {object} map[string]string "Recovery not found"
+// @Failure 409 {object} map[string]string "Recovery is not awaiting approval"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /mfa-recoveries/{id}/deny [post]
+func (h *MFARecoveryHandler) DenyRecovery(c *gin.Context) {
+	h.decide(c, h.recoveryService.DenyRecovery)
+}
+
+// CancelRecovery godoc
+// @Summary Cancel MFA recovery request
+// @Description Cancels an open recovery request for the signed-in member, e.g. one they did not start.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Recovery ID"
+// @Success 200 {object} domain.MFARecoveryRequest "Cancelled recovery"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 409 {object} map[string]string "Recovery is no longer open"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /mfa-recoveries/{id}/cancel [post]
+func (h *MFARecoveryHandler) CancelRecovery(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	recoveryID, ok := parseRecoveryID(c)
+	if !ok {
+		return
+	}
+
+	recovery, err := h.recoveryService.CancelRecovery(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, recoveryID)
+	if err != nil {
+		if err == domain.ErrMFARecoveryNotOpen {
+			response.Error(c, http.StatusConflict, err.Error(), err)
+			return
+		}
+		h.logger.Error("failed to cancel mfa recovery", map[string]interface{}{"org_id": reqCtx.OrganizationID, "recovery_id": recoveryID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to cancel mfa recovery", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, recovery)
+}
+
+type recoveryDecision func(ctx context.Context, orgID, approverID, requestID int32) (*domain.MFARecoveryRequest, error)
+
+// decide handles approve and deny, which share validation and error mapping.
+func (h *MFARecoveryHandler) decide(c *gin.Context, decision recoveryDecision) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	recoveryID, ok := parseRecoveryID(c)
+	if !ok {
+		return
+	}
+
+	recovery, err := decision(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, recoveryID)
+	if err != nil {
+		switch err {
+		case domain.ErrMFARecoveryNotFound:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case domain.ErrMFARecoveryNotOpen:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		case domain.ErrMFARecoverySelfApproval:
+			response.Error(c, http.StatusForbidden, err.Error(), err)
+		default:
+			h.logger.Error("failed to decide mfa recovery", map[string]interface{}{"org_id": reqCtx.OrganizationID, "recovery_id": recoveryID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to decide mfa recovery", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, recovery)
+}
+
+func parseRecoveryID(c *gin.Context) (int32, bool) {
+	var recoveryID int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &recoveryID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid recovery ID format", err)
+		return 0, false
+	}
+	return recoveryID, true
+}
@@ -1,12 +1,19 @@
 package organizations
 
 import (
+	"context"
+
 	"go.uber.org/dig"
 
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
 	"github.com/moasq/go-b2b-starter/internal/modules/organizations/infra/repositories"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
 	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
 	stytchcfg "github.com/moasq/go-b2b-starter/internal/platform/stytch"
 )
 
@@ -55,22 +62,29 @@ func (m *Module) RegisterDependencies() error {
 	}
 
 	// Register organization service
+	// The seat guard is provided by the billing module
 	if err := m.container.Provide(func(
 		orgRepo domain.OrganizationRepository,
 		accountRepo domain.AccountRepository,
+		seatGuard paywall.SeatGuard,
+		eventBus eventbus.EventBus,
+		logger loggerDomain.Logger,
 	) services.OrganizationService {
-		return services.NewOrganizationService(orgRepo, accountRepo)
+		return services.NewOrganizationService(orgRepo, accountRepo, seatGuard, eventBus, logger)
 	}); err != nil {
 		return err
 	}
 
 	// Register member service (for auth member operations)
+	// The seat guard is provided by the billing module
 	if err := m.container.Provide(func(
 		authOrgRepo domain.AuthOrganizationRepository,
 		authMemberRepo domain.AuthMemberRepository,
 		authRoleRepo domain.AuthRoleRepository,
 		localOrgRepo domain.OrganizationRepository,
 		localAccountRepo domain.AccountRepository,
+		seatGuard paywall.SeatGuard,
+		eventBus eventbus.EventBus,
 		logger loggerDomain.Logger,
 	) services.MemberService {
 		return services.NewMemberService(
@@ -79,11 +93,480 @@ func (m *Module) RegisterDependencies() error {
 			authRoleRepo,
 			localOrgRepo,
 			localAccountRepo,
+			seatGuard,
+			eventBus,
 			logger,
 		)
 	}); err != nil {
 		return err
 	}
 
+	// Register IP allowlist service and expose it to the auth middleware
+	if err := m.container.Provide(func(
+		allowlistRepo domain.IPAllowlistRepository,
+		accountRepo domain.AccountRepository,
+		eventBus eventbus.EventBus,
+		logger loggerDomain.Logger,
+	) services.IPAllowlistService {
+		return services.NewIPAllowlistService(allowlistRepo, accountRepo, eventBus, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(allowlistService services.IPAllowlistService) auth.NetworkPolicy {
+		return allowlistService
+	}); err != nil {
+		return err
+	}
+
+	// Register auth policy service and expose it to the auth middleware
+	if err := m.container.Provide(func(
+		policyRepo domain.AuthPolicyRepository,
+		eventBus eventbus.EventBus,
+		logger loggerDomain.Logger,
+	) services.AuthPolicyService {
+		return services.NewAuthPolicyService(policyRepo, eventBus, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(policyService services.AuthPolicyService) auth.OrganizationAuthPolicyResolver {
+		return policyService
+	}); err != nil {
+		return err
+	}
+
+	// Register just-in-time elevation service and expose it to the auth middleware
+	if err := m.container.Provide(services.LoadElevationPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		elevationRepo domain.AccessElevationRepository,
+		policy *services.ElevationPolicy,
+		logger loggerDomain.Logger,
+	) services.AccessElevationService {
+		return services.NewAccessElevationService(elevationRepo, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(elevationService services.AccessElevationService) auth.ElevationResolver {
+		return elevationService
+	}); err != nil {
+		return err
+	}
+
+	// Register MFA recovery service
+	if err := m.container.Provide(services.LoadMFARecoveryPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		recoveryRepo domain.MFARecoveryRepository,
+		authMemberRepo domain.AuthMemberRepository,
+		orgRepo domain.OrganizationRepository,
+		accountRepo domain.AccountRepository,
+		eventBus eventbus.EventBus,
+		policy *services.MFARecoveryPolicy,
+		logger loggerDomain.Logger,
+	) services.MFARecoveryService {
+		return services.NewMFARecoveryService(recoveryRepo, authMemberRepo, orgRepo, accountRepo, eventBus, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register guest session service and expose it to the auth middleware
+	if err := m.container.Provide(services.LoadGuestSessionPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		orgRepo domain.OrganizationRepository,
+		accountRepo domain.AccountRepository,
+		memberService services.MemberService,
+		denylist auth.SessionDenylist,
+		claims auth.TokenClaimsValidator,
+		eventBus eventbus.EventBus,
+		policy *services.GuestSessionPolicy,
+		logger loggerDomain.Logger,
+	) services.GuestSessionService {
+		return services.NewGuestSessionService(orgRepo, accountRepo, memberService, denylist, claims, eventBus, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(guestService services.GuestSessionService) auth.GuestVerifier {
+		return guestService
+	}); err != nil {
+		return err
+	}
+
+	// Register impersonation service and expose it to the auth middleware
+	if err := m.container.Provide(services.LoadImpersonationPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		accountRepo domain.AccountRepository,
+		roleAssignments auth.RoleAssignmentResolver,
+		elevations auth.ElevationResolver,
+		denylist auth.SessionDenylist,
+		claims auth.TokenClaimsValidator,
+		policy *services.ImpersonationPolicy,
+		logger loggerDomain.Logger,
+	) services.ImpersonationService {
+		return services.NewImpersonationService(accountRepo, roleAssignments, elevations, denylist, claims, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(impersonationService services.ImpersonationService) auth.ImpersonationVerifier {
+		return impersonationService
+	}); err != nil {
+		return err
+	}
+
+	// Register OAuth client service and expose it to the auth middleware
+	if err := m.container.Provide(services.LoadOAuthClientPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		clientRepo domain.OAuthClientRepository,
+		orgRepo domain.OrganizationRepository,
+		accountRepo domain.AccountRepository,
+		denylist auth.SessionDenylist,
+		claims auth.TokenClaimsValidator,
+		policy *services.OAuthClientPolicy,
+		logger loggerDomain.Logger,
+	) services.OAuthClientService {
+		return services.NewOAuthClientService(clientRepo, orgRepo, accountRepo, denylist, claims, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(clientService services.OAuthClientService) auth.ClientTokenVerifier {
+		return clientService
+	}); err != nil {
+		return err
+	}
+
+	// Register linked Google/GitHub identities and expose their session tokens to the auth middleware
+	if err := m.container.Provide(services.LoadIdentityPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		identityRepo domain.IdentityRepository,
+		orgRepo domain.OrganizationRepository,
+		claims auth.TokenClaimsValidator,
+		policy *services.IdentityPolicy,
+		logger loggerDomain.Logger,
+	) services.LinkedIdentityService {
+		clients := services.NewIdentityProviderClients(policy)
+		return services.NewLinkedIdentityService(identityRepo, clients, claims, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register canary credential service and expose detection to the auth middleware
+	if err := m.container.Provide(services.NewCanaryService); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(canaryService services.CanaryService) auth.CanaryDetector {
+		return canaryService
+	}); err != nil {
+		return err
+	}
+
+	// Register member offboarding service
+	if err := m.container.Provide(func(
+		accountRepo domain.AccountRepository,
+		authMemberRepo domain.AuthMemberRepository,
+		revoker auth.SessionRevoker,
+		denylist auth.SessionDenylist,
+		eventBus eventbus.EventBus,
+		logger loggerDomain.Logger,
+	) services.OffboardingService {
+		return services.NewOffboardingService(accountRepo, authMemberRepo, revoker, denylist, eventBus, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register member notifications, sent on the channels each member chose
+	if err := m.container.Provide(services.LoadNotificationPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewNotificationService); err != nil {
+		return err
+	}
+
+	// Register admin user management service
+	if err := m.container.Provide(services.NewUserManagementService); err != nil {
+		return err
+	}
+
+	// Register user tags for support and feature-flag targeting
+	if err := m.container.Provide(services.NewTagService); err != nil {
+		return err
+	}
+
+	// Register signup and engagement statistics for the admin dashboard
+	if err := m.container.Provide(services.LoadUserStatsPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewUserStatsService); err != nil {
+		return err
+	}
+
+	// Register role assignments and expose them to the auth middleware
+	if err := m.container.Provide(services.NewRoleAssignmentService); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(assignmentService services.RoleAssignmentService) auth.RoleAssignmentResolver {
+		return assignmentService
+	}); err != nil {
+		return err
+	}
+
+	// Register service accounts and expose their API keys to the auth middleware
+	if err := m.container.Provide(services.LoadServiceAccountPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewServiceAccountService); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(serviceAccountService services.ServiceAccountService) auth.ServiceAccountKeyVerifier {
+		return serviceAccountService
+	}); err != nil {
+		return err
+	}
+
+	// Register staging organization service
+	if err := m.container.Provide(services.LoadStagingPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		orgRepo domain.OrganizationRepository,
+		accountRepo domain.AccountRepository,
+		memberService services.MemberService,
+		policy *services.StagingPolicy,
+		logger loggerDomain.Logger,
+	) services.StagingService {
+		return services.NewStagingService(orgRepo, accountRepo, memberService, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register email change service
+	if err := m.container.Provide(services.LoadEmailChangePolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		changeRepo domain.EmailChangeRepository,
+		orgRepo domain.OrganizationRepository,
+		accountRepo domain.AccountRepository,
+		authMemberRepo domain.AuthMemberRepository,
+		secondaryRepo domain.SecondaryEmailRepository,
+		revoker auth.SessionRevoker,
+		denylist auth.SessionDenylist,
+		sender emailDomain.Sender,
+		policy *services.EmailChangePolicy,
+		logger loggerDomain.Logger,
+	) services.EmailChangeService {
+		return services.NewEmailChangeService(changeRepo, orgRepo, accountRepo, authMemberRepo, secondaryRepo, revoker, denylist, sender, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register secondary email addresses
+	if err := m.container.Provide(services.LoadSecondaryEmailPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewSecondaryEmailService); err != nil {
+		return err
+	}
+
+	// Register invite service
+	if err := m.container.Provide(services.LoadInvitePolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		inviteRepo domain.InviteRepository,
+		orgRepo domain.OrganizationRepository,
+		authRoleRepo domain.AuthRoleRepository,
+		memberService services.MemberService,
+		sender emailDomain.Sender,
+		policy *services.InvitePolicy,
+		logger loggerDomain.Logger,
+	) services.InviteService {
+		return services.NewInviteService(inviteRepo, orgRepo, authRoleRepo, memberService, sender, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register scheduled cleanup of expired and revoked invites
+	if err := m.container.Provide(services.NewInviteCleanupService); err != nil {
+		return err
+	}
+
+	// Register dormancy detection and expose activity tracking to the auth middleware
+	if err := m.container.Provide(services.LoadDormancyPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewDormancyService); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(dormancyService services.DormancyService) auth.ActivityRecorder {
+		return dormancyService
+	}); err != nil {
+		return err
+	}
+
+	// Register API usage counting and expose quota enforcement to the auth middleware
+	if err := m.container.Provide(services.LoadAPIUsagePolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewPolicyAPIQuotaResolver); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewAPIUsageService); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(usageService services.APIUsageService) auth.UsageMeter {
+		return usageService
+	}); err != nil {
+		return err
+	}
+
+	// Register debug captures and expose recording to the auth middleware
+	if err := m.container.Provide(services.LoadDebugCapturePolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewDebugCaptureService); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(captureService services.DebugCaptureService) auth.DebugCapturer {
+		return captureService
+	}); err != nil {
+		return err
+	}
+
+	// Register the per-account activity feed, recorded from event bus events
+	if err := m.container.Provide(services.LoadActivityFeedPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewActivityFeedService); err != nil {
+		return err
+	}
+
+	// Register avatar uploads (PUT /me/avatar), stored through the files module
+	if err := m.container.Provide(services.LoadAvatarPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(services.NewAvatarService); err != nil {
+		return err
+	}
+
+	// Register session context service (GET /me/context)
+	if err := m.container.Provide(services.LoadSessionContextPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		orgRepo domain.OrganizationRepository,
+		accountRepo domain.AccountRepository,
+		tagRepo domain.TagRepository,
+		subscriptions paywall.SubscriptionStatusProvider,
+		redisClient redis.Client,
+		policy *services.SessionContextPolicy,
+		logger loggerDomain.Logger,
+	) services.SessionContextService {
+		return services.NewSessionContextService(orgRepo, accountRepo, tagRepo, subscriptions, redisClient, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	// Register organization switching for members of several organizations
+	if err := m.container.Provide(services.NewOrganizationSwitchService); err != nil {
+		return err
+	}
+
+	// Register OpenID Connect provider service
+	if err := m.container.Provide(services.LoadOIDCProviderPolicy); err != nil {
+		return err
+	}
+
+	if err := m.container.Provide(func(
+		clientRepo domain.OIDCClientRepository,
+		grantRepo domain.OIDCGrantRepository,
+		orgRepo domain.OrganizationRepository,
+		accountRepo domain.AccountRepository,
+		policy *services.OIDCProviderPolicy,
+		logger loggerDomain.Logger,
+	) services.OIDCProviderService {
+		return services.NewOIDCProviderService(clientRepo, grantRepo, orgRepo, accountRepo, policy, logger)
+	}); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// StartScheduler starts the background cleanup of expired and revoked invites
+// unless AUTH_INVITE_CLEANUP_INTERVAL is zero, dormancy detection unless
+// DORMANCY_CHECK_INTERVAL is zero, the cleanup of expired debug captures
+// unless DEBUG_CAPTURE_CLEANUP_INTERVAL is zero, the cleanup of expired
+// account activities unless ACTIVITY_FEED_CLEANUP_INTERVAL is zero, and the
+// flushing of API usage counts unless usage tracking is off or
+// API_USAGE_FLUSH_INTERVAL is zero.
+func (m *Module) StartScheduler() error {
+	return m.container.Invoke(func(
+		invitePolicy *services.InvitePolicy,
+		inviteCleanup services.InviteCleanupService,
+		dormancyPolicy *services.DormancyPolicy,
+		dormancy services.DormancyService,
+		capturePolicy *services.DebugCapturePolicy,
+		captures services.DebugCaptureService,
+		activityPolicy *services.ActivityFeedPolicy,
+		activityFeed services.ActivityFeedService,
+		usagePolicy *services.APIUsagePolicy,
+		usage services.APIUsageService,
+	) {
+		if invitePolicy.CleanupInterval > 0 {
+			go inviteCleanup.Run(context.Background())
+		}
+		if dormancyPolicy.CheckInterval > 0 {
+			go dormancy.Run(context.Background())
+		}
+		if capturePolicy.CleanupInterval > 0 {
+			go captures.Run(context.Background())
+		}
+		if activityPolicy.CleanupInterval > 0 {
+			go activityFeed.Run(context.Background())
+		}
+		if usagePolicy.Enabled && usagePolicy.FlushInterval > 0 {
+			go usage.Run(context.Background())
+		}
+	})
+}
@@ -0,0 +1,213 @@
+package organizations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type NotificationHandler struct {
+	notificationService services.NotificationService
+	logger              logger.Logger
+}
+
+func NewNotificationHandler(notificationService services.NotificationService, logger logger.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+		logger:              logger,
+	}
+}
+
+// GetPreferences godoc
+// @Summary Get my notification preferences
+// @Description Returns whether the signed-in member receives each notification type on each of its channels (email, in_app). Types are on until turned off; required types cannot be turned off.
+// @Tags auth
+// @Produce json
+// @Success 200 {array} domain.NotificationPreference "Notification preferences"
+// @Failure 400 {object} map[string]string "Missing organization context"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/notification-preferences [get]
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	prefs, err := h.notificationService.ListPreferences(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID)
+	if err != nil {
+		h.handleError(c, reqCtx, "failed to get notification preferences", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, prefs)
+}
+
+// UpdatePreferences godoc
+// @Summary Update my notification preferences
+// @Description Turns notification types on or off per channel for the signed-in member and returns all of their preferences. Nothing is changed if any entry names an unknown type, a channel the type is not sent on, or turns off a required type.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.UpdateNotificationPreferencesRequest true "Preferences to change"
+// @Success 200 {array} domain.NotificationPreference "Notification preferences"
+// @Failure 400 {object} map[string]string "Invalid request, unknown type or channel, or required type"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/notification-preferences [put]
+func (h *NotificationHandler) UpdatePreferences(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateNotificationPreferencesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	prefs, err := h.notificationService.UpdatePreferences(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		h.handleError(c, reqCtx, "failed to update notification preferences", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, prefs)
+}
+
+// ListNotifications godoc
+// @Summary List my notifications
+// @Description Returns the signed-in member's in-app notifications, newest first.
+// @Tags auth
+// @Produce json
+// @Param unread query bool false "Only notifications not marked read"
+// @Param limit query int false "Maximum notifications (default 50, capped at NOTIFICATION_MAX_IN_APP_RESULTS)"
+// @Success 200 {array} domain.Notification "In-app notifications"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/notifications [get]
+func (h *NotificationHandler) ListNotifications(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	var req services.ListNotificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	notifications, err := h.notificationService.ListNotifications(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		h.handleError(c, reqCtx, "failed to list notifications", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, notifications)
+}
+
+// MarkRead godoc
+// @Summary Mark a notification read
+// @Description Marks one of the signed-in member's in-app notifications read. Marking it again keeps the first read time.
+// @Tags auth
+// @Produce json
+// @Param id path int true "Notification ID"
+// @Success 204 "Marked read"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Notification not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/notifications/{id}/read [post]
+func (h *NotificationHandler) MarkRead(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	var id int64
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid notification ID format", err)
+		return
+	}
+
+	if err := h.notificationService.MarkRead(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id); err != nil {
+		h.handleError(c, reqCtx, "failed to mark notification read", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Unsubscribe godoc
+// @Summary Unsubscribe from notification emails
+// @Description Turns off the email notification type named by the token from an unsubscribe link. No sign-in is needed; the page at NOTIFICATION_UNSUBSCRIBE_URL posts the token from its query string. Members turn the type back on in their notification preferences.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.UnsubscribeRequest true "Unsubscribe token"
+// @Success 200 {object} domain.NotificationPreference "Preference that was turned off"
+// @Failure 400 {object} map[string]string "Invalid or expired token"
+// @Failure 404 {object} map[string]string "Unsubscribe links are not enabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /notifications/unsubscribe [post]
+func (h *NotificationHandler) Unsubscribe(c *gin.Context) {
+	var req services.UnsubscribeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	pref, err := h.notificationService.Unsubscribe(c.Request.Context(), req.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotificationUnsubscribeToken):
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case errors.Is(err, domain.ErrNotificationUnsubscribeOff):
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		default:
+			h.logger.Error("failed to unsubscribe", map[string]interface{}{"error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to unsubscribe", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, pref)
+}
+
+func (h *NotificationHandler) requestContext(c *gin.Context) (*auth.RequestContext, bool) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return nil, false
+	}
+	return reqCtx, true
+}
+
+func (h *NotificationHandler) handleError(c *gin.Context, reqCtx *auth.RequestContext, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrNotificationNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrUnknownNotificationType), errors.Is(err, domain.ErrNotificationChannel),
+		errors.Is(err, domain.ErrNotificationRequired):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{
+			"org_id":     reqCtx.OrganizationID,
+			"account_id": reqCtx.AccountID,
+			"error":      err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
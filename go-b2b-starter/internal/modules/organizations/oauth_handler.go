@@ -0,0 +1,289 @@
+package organizations
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// clientCredentialsGrant is the only grant type supported by the token endpoint
+const clientCredentialsGrant = "client_credentials"
+
+type OAuthHandler struct {
+	clientService services.OAuthClientService
+	logger        logger.Logger
+}
+
+func NewOAuthHandler(clientService services.OAuthClientService, logger logger.Logger) *OAuthHandler {
+	return &OAuthHandler{
+		clientService: clientService,
+		logger:        logger,
+	}
+}
+
+// Token godoc
+// @Summary Issue client access token
+// @Description OAuth2 token endpoint for the client credentials grant (RFC 6749 section 4.4). Authenticate with HTTP Basic or client_id/client_secret form fields. The access token is accepted as a Bearer token by the API, scoped to the client's organization and scopes. Request an audience to get a token for a downstream service instead.
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "Must be client_credentials"
+// @Param scope formData string false "Space-delimited scopes; defaults to all granted scopes"
+// @Param audience formData string false "Space-delimited audiences (this API or services in JWT_SERVICE_AUDIENCES); defaults to this API"
+// @Param client_id formData string false "Client ID when not using HTTP Basic"
+// @Param client_secret formData string false "Client secret when not using HTTP Basic"
+// @Success 200 {object} services.ClientAccessToken "Access token"
+// @Failure 400 {object} map[string]string "invalid_request, unsupported_grant_type, invalid_scope or invalid_target"
+// @Failure 401 {object} map[string]string "invalid_client"
+// @Failure 500 {object} map[string]string "server_error"
+// @Router /oauth/token [post]
+func (h *OAuthHandler) Token(c *gin.Context) {
+	// Token responses must not be cached (RFC 6749 section 5.1)
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	if c.PostForm("grant_type") != clientCredentialsGrant {
+		tokenError(c, http.StatusBadRequest, "unsupported_grant_type", "only the client_credentials grant is supported")
+		return
+	}
+
+	clientID, clientSecret, basic, ok := clientCredentials(c)
+	if !ok {
+		return
+	}
+
+	token, err := h.clientService.IssueToken(c.Request.Context(), clientID, clientSecret, c.PostForm("scope"), c.PostForm("audience"))
+	if err != nil {
+		switch err {
+		case domain.ErrOAuthInvalidClient:
+			if basic {
+				c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+			}
+			tokenError(c, http.StatusUnauthorized, "invalid_client", err.Error())
+		case domain.ErrOAuthInvalidScope:
+			tokenError(c, http.StatusBadRequest, "invalid_scope", "requested scope exceeds the scopes granted to the client")
+		case domain.ErrOAuthInvalidTarget:
+			tokenError(c, http.StatusBadRequest, "invalid_target", "requested audience is not this API or a configured service")
+		case domain.ErrOAuthClientsDisabled:
+			tokenError(c, http.StatusBadRequest, "unsupported_grant_type", err.Error())
+		default:
+			h.logger.Error("failed to issue client token", map[string]interface{}{"client_id": clientID, "error": err.Error()})
+			tokenError(c, http.StatusInternalServerError, "server_error", "failed to issue token")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+// Introspect godoc
+// @Summary Introspect client access token
+// @Description OAuth2 token introspection (RFC 7662) for gateways and internal services. The caller authenticates as a registered client with HTTP Basic or client_id/client_secret form fields. Tokens that are invalid, expired, revoked or issued in another organization return only {"active": false}.
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token formData string true "Access token to introspect"
+// @Param token_type_hint formData string false "Ignored; only access tokens are issued"
+// @Param client_id formData string false "Client ID when not using HTTP Basic"
+// @Param client_secret formData string false "Client secret when not using HTTP Basic"
+// @Success 200 {object} services.TokenIntrospection "Token state"
+// @Failure 400 {object} map[string]string "invalid_request"
+// @Failure 401 {object} map[string]string "invalid_client"
+// @Failure 404 {object} map[string]string "OAuth clients disabled"
+// @Failure 500 {object} map[string]string "server_error"
+// @Router /oauth/introspect [post]
+func (h *OAuthHandler) Introspect(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	clientID, clientSecret, basic, ok := clientCredentials(c)
+	if !ok {
+		return
+	}
+
+	token := c.PostForm("token")
+	if token == "" {
+		tokenError(c, http.StatusBadRequest, "invalid_request", "token is required")
+		return
+	}
+
+	introspection, err := h.clientService.IntrospectToken(c.Request.Context(), clientID, clientSecret, token)
+	if err != nil {
+		switch err {
+		case domain.ErrOAuthInvalidClient:
+			if basic {
+				c.Header("WWW-Authenticate", `Basic realm="oauth"`)
+			}
+			tokenError(c, http.StatusUnauthorized, "invalid_client", err.Error())
+		case domain.ErrOAuthClientsDisabled:
+			tokenError(c, http.StatusNotFound, "invalid_request", err.Error())
+		default:
+			h.logger.Error("failed to introspect token", map[string]interface{}{"client_id": clientID, "error": err.Error()})
+			tokenError(c, http.StatusInternalServerError, "server_error", "failed to introspect token")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, introspection)
+}
+
+// clientCredentials reads the calling client's credentials from HTTP Basic or
+// the client_id and client_secret form fields. On failure it writes the error
+// response and returns ok false.
+func clientCredentials(c *gin.Context) (clientID, clientSecret string, basic, ok bool) {
+	clientID, clientSecret, basic = c.Request.BasicAuth()
+	if basic {
+		// Basic credentials are form-urlencoded before encoding (RFC 6749 section 2.3.1)
+		var idErr, secretErr error
+		clientID, idErr = url.QueryUnescape(clientID)
+		clientSecret, secretErr = url.QueryUnescape(clientSecret)
+		if idErr != nil || secretErr != nil {
+			tokenError(c, http.StatusBadRequest, "invalid_request", "malformed client credentials")
+			return "", "", basic, false
+		}
+		if c.PostForm("client_id") != "" || c.PostForm("client_secret") != "" {
+			tokenError(c, http.StatusBadRequest, "invalid_request", "use only one client authentication method")
+			return "", "", basic, false
+		}
+	} else {
+		clientID = c.PostForm("client_id")
+		clientSecret = c.PostForm("client_secret")
+	}
+	if clientID == "" || clientSecret == "" {
+		tokenError(c, http.StatusBadRequest, "invalid_request", "client_id and client_secret are required")
+		return "", "", basic, false
+	}
+	return clientID, clientSecret, basic, true
+}
+
+// tokenError writes an OAuth2 error response (RFC 6749 section 5.2)
+func tokenError(c *gin.Context, status int, code, description string) {
+	c.JSON(status, gin.H{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+// CreateClient godoc
+// @Summary Register OAuth client
+// @Description Registers a client for the client credentials grant. Scopes are permissions the client's tokens carry; org:manage cannot be granted. The client secret is only returned in this response.
+// @Tags oauth
+// @Accept json
+// @Produce json
+// @Param request body services.CreateOAuthClientRequest true "Client name and scopes"
+// @Success 201 {object} services.CreatedOAuthClient "Registered client with secret"
+// @Failure 400 {object} map[string]string "Invalid name or scopes"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "OAuth clients disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /oauth/clients [post]
+func (h *OAuthHandler) CreateClient(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.CreateOAuthClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	client, err := h.clientService.CreateClient(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrOAuthClientNameRequired, domain.ErrOAuthClientScopeRequired, domain.ErrOAuthInvalidScope:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrOAuthClientsDisabled:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		default:
+			h.logger.Error("failed to create oauth client", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to create oauth client", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, client)
+}
+
+// ListClients godoc
+// @Summary List OAuth clients
+// @Description Lists the organization's OAuth clients, including revoked ones. Secrets are never returned.
+// @Tags oauth
+// @Produce json
+// @Success 200 {array} domain.OAuthClient "OAuth clients"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /oauth/clients [get]
+func (h *OAuthHandler) ListClients(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	clients, err := h.clientService.ListClients(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.logger.Error("failed to list oauth clients", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list oauth clients", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, clients)
+}
+
+// RevokeClient godoc
+// @Summary Revoke OAuth client
+// @Description Revokes the client. It can no longer obtain tokens and tokens already issued stop working.
+// @Tags oauth
+// @Produce json
+// @Param id path int true "Client record ID"
+// @Success 200 {object} domain.OAuthClient "Revoked client"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Client not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /oauth/clients/{id} [delete]
+func (h *OAuthHandler) RevokeClient(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	clientIDParam := c.Param("id")
+	var id int32
+	if _, err := fmt.Sscanf(clientIDParam, "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid client ID format", err)
+		return
+	}
+
+	client, err := h.clientService.RevokeClient(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id)
+	if err != nil {
+		switch err {
+		case domain.ErrOAuthClientNotFound:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		default:
+			h.logger.Error("failed to revoke oauth client", map[string]interface{}{"org_id": reqCtx.OrganizationID, "id": id, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to revoke oauth client", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, client)
+}
@@ -0,0 +1,79 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type OffboardingHandler struct {
+	offboardingService services.OffboardingService
+	logger             logger.Logger
+}
+
+func NewOffboardingHandler(offboardingService services.OffboardingService, logger logger.Logger) *OffboardingHandler {
+	return &OffboardingHandler{
+		offboardingService: offboardingService,
+		logger:             logger,
+	}
+}
+
+// OffboardMember godoc
+// @Summary Offboard organization member
+// @Description Removes a member from the organization in one workflow: their data is transferred to another member (transfer_to_account_id) or deleted (delete_data), their sessions are revoked and they are removed from the auth provider. Returns an offboarding report. Failed offboardings can be retried.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param member_id path string true "Member ID"
+// @Param request body services.OffboardMemberRequest true "Data handling"
+// @Success 200 {object} services.OffboardingReport "Offboarding report"
+// @Failure 400 {object} map[string]string "Invalid request or transfer target"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Member not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/members/{member_id}/offboard [post]
+func (h *OffboardingHandler) OffboardMember(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	memberID := c.Param("member_id")
+	if memberID == "" {
+		response.Error(c, http.StatusBadRequest, "member_id is required", nil)
+		return
+	}
+
+	var req services.OffboardMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	report, err := h.offboardingService.OffboardMember(c.Request.Context(), reqCtx.OrganizationID, reqCtx.ProviderOrgID, memberID, reqCtx.AccountID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrOffboardingActionRequired, domain.ErrOffboardingInvalidTarget:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrOffboardingSelf:
+			response.Error(c, http.StatusForbidden, err.Error(), err)
+		case domain.ErrAccountNotFound:
+			response.Error(c, http.StatusNotFound, "member not found", err)
+		default:
+			h.logger.Error("failed to offboard member", map[string]interface{}{"org_id": reqCtx.OrganizationID, "member_id": memberID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to offboard member", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, report)
+}
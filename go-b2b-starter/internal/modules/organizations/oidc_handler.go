@@ -0,0 +1,379 @@
+package organizations
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// authorizationCodeGrant is the only grant type supported by the OpenID Connect token endpoint
+const authorizationCodeGrant = "authorization_code"
+
+type OIDCHandler struct {
+	providerService services.OIDCProviderService
+	logger          logger.Logger
+}
+
+func NewOIDCHandler(providerService services.OIDCProviderService, logger logger.Logger) *OIDCHandler {
+	return &OIDCHandler{
+		providerService: providerService,
+		logger:          logger,
+	}
+}
+
+// Discovery godoc
+// @Summary OpenID provider configuration
+// @Description OpenID Connect discovery document for clients that sign members in with this API.
+// @Tags oidc
+// @Produce json
+// @Success 200 {object} services.OIDCDiscovery "Provider metadata"
+// @Failure 404 {object} map[string]string "OpenID Connect provider disabled"
+// @Router /oidc/.well-known/openid-configuration [get]
+func (h *OIDCHandler) Discovery(c *gin.Context) {
+	discovery, err := h.providerService.Discovery()
+	if err != nil {
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, discovery)
+}
+
+// JWKS godoc
+// @Summary OpenID provider signing keys
+// @Description JSON Web Key Set with the public keys that verify id_tokens and access tokens.
+// @Tags oidc
+// @Produce json
+// @Success 200 {object} services.OIDCKeySet "Signing keys"
+// @Failure 404 {object} map[string]string "OpenID Connect provider disabled"
+// @Router /oidc/jwks [get]
+func (h *OIDCHandler) JWKS(c *gin.Context) {
+	keys, err := h.providerService.JWKS()
+	if err != nil {
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+		return
+	}
+
+	c.JSON(http.StatusOK, keys)
+}
+
+// GetAuthorization godoc
+// @Summary Describe authentication request
+// @Description Called by the consent page with the parameters the client sent the member with. Validates them against the registered client and returns what the member is asked to approve. consent_granted is true if the member already approved these scopes.
+// @Tags oidc
+// @Produce json
+// @Param client_id query string true "Client ID"
+// @Param redirect_uri query string true "Registered redirect URI"
+// @Param response_type query string true "Must be code"
+// @Param scope query string true "Space-delimited scopes; must include openid"
+// @Param state query string false "Opaque client state"
+// @Param nonce query string false "Nonce echoed in the id_token"
+// @Param code_challenge query string false "PKCE code challenge"
+// @Param code_challenge_method query string false "Must be S256 when a challenge is sent"
+// @Success 200 {object} services.OIDCConsentDetails "Consent details"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Client not found or provider disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /oidc/authorize [get]
+func (h *OIDCHandler) GetAuthorization(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.OIDCAuthorizeRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request parameters", err)
+		return
+	}
+
+	consent, err := h.providerService.GetConsent(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		h.authorizeError(c, reqCtx, req.ClientID, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, consent)
+}
+
+// Authorize godoc
+// @Summary Approve or deny authentication request
+// @Description Records the signed-in member's decision and returns the client redirect URI to send the browser to, with an authorization code if approved or error=access_denied if not. Guest, client and impersonation sessions cannot sign in to clients.
+// @Tags oidc
+// @Accept json
+// @Produce json
+// @Param request body services.OIDCAuthorizeDecision true "Authentication request and decision"
+// @Success 200 {object} services.OIDCAuthorizeResult "Redirect URI"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Not signed in as a member"
+// @Failure 404 {object} map[string]string "Client not found or provider disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /oidc/authorize [post]
+func (h *OIDCHandler) Authorize(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.OIDCAuthorizeDecision
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	result, err := h.providerService.Authorize(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, reqCtx.Identity, &req)
+	if err != nil {
+		h.authorizeError(c, reqCtx, req.ClientID, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, result)
+}
+
+// authorizeError maps errors of the consent endpoints to responses
+func (h *OIDCHandler) authorizeError(c *gin.Context, reqCtx *auth.RequestContext, clientID string, err error) {
+	switch err {
+	case domain.ErrOIDCInvalidRedirectURI, domain.ErrOIDCUnsupportedResponseType, domain.ErrOIDCInvalidScope, domain.ErrOIDCInvalidCodeChallenge:
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	case domain.ErrOIDCInteractiveLoginRequired:
+		response.Error(c, http.StatusForbidden, err.Error(), err)
+	case domain.ErrOIDCClientNotFound, domain.ErrOIDCProviderDisabled:
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	default:
+		h.logger.Error("failed to authorize oidc client", map[string]interface{}{"org_id": reqCtx.OrganizationID, "client_id": clientID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to authorize client", err)
+	}
+}
+
+// Token godoc
+// @Summary Exchange authorization code
+// @Description OpenID Connect token endpoint for the authorization code grant. Authenticate with HTTP Basic or client_id/client_secret form fields. Returns an id_token and an access token for the userinfo endpoint; the access token is not accepted by the rest of the API.
+// @Tags oidc
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "Must be authorization_code"
+// @Param code formData string true "Authorization code"
+// @Param redirect_uri formData string true "Redirect URI the code was issued to"
+// @Param code_verifier formData string false "PKCE code verifier; required if a challenge was sent"
+// @Param client_id formData string false "Client ID when not using HTTP Basic"
+// @Param client_secret formData string false "Client secret when not using HTTP Basic"
+// @Success 200 {object} services.OIDCTokenResponse "Tokens"
+// @Failure 400 {object} map[string]string "invalid_request, unsupported_grant_type or invalid_grant"
+// @Failure 401 {object} map[string]string "invalid_client"
+// @Failure 500 {object} map[string]string "server_error"
+// @Router /oidc/token [post]
+func (h *OIDCHandler) Token(c *gin.Context) {
+	// Token responses must not be cached (RFC 6749 section 5.1)
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+
+	if c.PostForm("grant_type") != authorizationCodeGrant {
+		tokenError(c, http.StatusBadRequest, "unsupported_grant_type", "only the authorization_code grant is supported")
+		return
+	}
+
+	clientID, clientSecret, basic, ok := clientCredentials(c)
+	if !ok {
+		return
+	}
+
+	req := services.OIDCTokenRequest{
+		Code:         c.PostForm("code"),
+		RedirectURI:  c.PostForm("redirect_uri"),
+		CodeVerifier: c.PostForm("code_verifier"),
+	}
+	if req.Code == "" || req.RedirectURI == "" {
+		tokenError(c, http.StatusBadRequest, "invalid_request", "code and redirect_uri are required")
+		return
+	}
+
+	tokens, err := h.providerService.ExchangeCode(c.Request.Context(), clientID, clientSecret, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrOIDCInvalidClient:
+			if basic {
+				c.Header("WWW-Authenticate", `Basic realm="oidc"`)
+			}
+			tokenError(c, http.StatusUnauthorized, "invalid_client", err.Error())
+		case domain.ErrOIDCInvalidGrant:
+			tokenError(c, http.StatusBadRequest, "invalid_grant", err.Error())
+		case domain.ErrOIDCProviderDisabled:
+			tokenError(c, http.StatusBadRequest, "unsupported_grant_type", err.Error())
+		default:
+			h.logger.Error("failed to exchange oidc authorization code", map[string]interface{}{"client_id": clientID, "error": err.Error()})
+			tokenError(c, http.StatusInternalServerError, "server_error", "failed to issue token")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// UserInfo godoc
+// @Summary OpenID Connect userinfo
+// @Description Returns the claims of the member the access token was issued for, limited to the approved scopes.
+// @Tags oidc
+// @Produce json
+// @Param Authorization header string true "Bearer access token from the token endpoint"
+// @Success 200 {object} services.OIDCUserInfo "Member claims"
+// @Failure 401 {object} map[string]string "invalid_token"
+// @Failure 404 {object} map[string]string "OpenID Connect provider disabled"
+// @Failure 500 {object} map[string]string "server_error"
+// @Router /oidc/userinfo [get]
+func (h *OIDCHandler) UserInfo(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+
+	fields := strings.Fields(c.GetHeader("Authorization"))
+	if len(fields) != 2 || !strings.EqualFold(fields[0], "bearer") {
+		c.Header("WWW-Authenticate", `Bearer realm="oidc"`)
+		tokenError(c, http.StatusUnauthorized, "invalid_token", "bearer access token is required")
+		return
+	}
+
+	userInfo, err := h.providerService.UserInfo(c.Request.Context(), fields[1])
+	if err != nil {
+		switch err {
+		case domain.ErrOIDCInvalidToken:
+			// Bearer token errors are reported in the challenge (RFC 6750 section 3)
+			c.Header("WWW-Authenticate", `Bearer realm="oidc", error="invalid_token"`)
+			tokenError(c, http.StatusUnauthorized, "invalid_token", err.Error())
+		case domain.ErrOIDCProviderDisabled:
+			tokenError(c, http.StatusNotFound, "invalid_request", err.Error())
+		default:
+			h.logger.Error("failed to load oidc userinfo", map[string]interface{}{"error": err.Error()})
+			tokenError(c, http.StatusInternalServerError, "server_error", "failed to load userinfo")
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, userInfo)
+}
+
+// CreateClient godoc
+// @Summary Register OpenID Connect client
+// @Description Registers an internal tool that signs members of this organization in with the authorization code flow. Redirect URIs must be absolute http(s) URLs without a fragment and are matched exactly. The client secret is only returned in this response.
+// @Tags oidc
+// @Accept json
+// @Produce json
+// @Param request body services.CreateOIDCClientRequest true "Client name and redirect URIs"
+// @Success 201 {object} services.CreatedOIDCClient "Registered client with secret"
+// @Failure 400 {object} map[string]string "Invalid name or redirect URIs"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "OpenID Connect provider disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /oidc/clients [post]
+func (h *OIDCHandler) CreateClient(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.CreateOIDCClientRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	client, err := h.providerService.CreateClient(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrOIDCClientNameRequired, domain.ErrOIDCRedirectURIRequired, domain.ErrOIDCInvalidRedirectURI:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		case domain.ErrOIDCProviderDisabled:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		default:
+			h.logger.Error("failed to create oidc client", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to create oidc client", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, client)
+}
+
+// ListClients godoc
+// @Summary List OpenID Connect clients
+// @Description Lists the organization's OpenID Connect clients, including revoked ones. Secrets are never returned.
+// @Tags oidc
+// @Produce json
+// @Success 200 {array} domain.OIDCClient "OpenID Connect clients"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /oidc/clients [get]
+func (h *OIDCHandler) ListClients(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	clients, err := h.providerService.ListClients(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.logger.Error("failed to list oidc clients", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list oidc clients", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, clients)
+}
+
+// RevokeClient godoc
+// @Summary Revoke OpenID Connect client
+// @Description Revokes the client. Members can no longer sign in to it, and its access tokens stop working at the userinfo endpoint.
+// @Tags oidc
+// @Produce json
+// @Param id path int true "Client record ID"
+// @Success 200 {object} domain.OIDCClient "Revoked client"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Client not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /oidc/clients/{id} [delete]
+func (h *OIDCHandler) RevokeClient(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var id int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid client ID format", err)
+		return
+	}
+
+	client, err := h.providerService.RevokeClient(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id)
+	if err != nil {
+		switch err {
+		case domain.ErrOIDCClientNotFound:
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		default:
+			h.logger.Error("failed to revoke oidc client", map[string]interface{}{"org_id": reqCtx.OrganizationID, "id": id, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to revoke oidc client", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, client)
+}
@@ -0,0 +1,111 @@
+package organizations
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type OrganizationSwitchHandler struct {
+	switchService services.OrganizationSwitchService
+	logger        logger.Logger
+}
+
+func NewOrganizationSwitchHandler(switchService services.OrganizationSwitchService, logger logger.Logger) *OrganizationSwitchHandler {
+	return &OrganizationSwitchHandler{
+		switchService: switchService,
+		logger:        logger,
+	}
+}
+
+// ListMemberships godoc
+// @Summary List organization memberships
+// @Description Lists the active organizations the signed-in user has an active account in, marking the one the token is scoped to. Use it to build an organization switcher.
+// @Tags organizations
+// @Produce json
+// @Success 200 {array} domain.Membership "Memberships"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Guest, client or impersonation token"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/memberships [get]
+func (h *OrganizationSwitchHandler) ListMemberships(c *gin.Context) {
+	identity := auth.GetIdentity(c)
+	if identity == nil {
+		response.Error(c, http.StatusUnauthorized, "authentication required", nil)
+		return
+	}
+
+	memberships, err := h.switchService.ListMemberships(c.Request.Context(), identity)
+	if err != nil {
+		h.handleError(c, identity, "failed to list memberships", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, memberships)
+}
+
+// SwitchOrganization godoc
+// @Summary Switch organization
+// @Description Exchanges the caller's token for a token pair scoped to another organization they are an active member of. Use the returned access token for later requests; the org_context middleware resolves the organization and roles from it. When the target organization requires MFA the session lacks, no tokens are returned and mfa_required is set with an intermediate session token.
+// @Tags organizations
+// @Accept json
+// @Produce json
+// @Param request body services.SwitchOrganizationRequest true "Target organization"
+// @Success 200 {object} services.OrganizationSwitch "Token pair for the organization"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Not a member, or a guest, client or impersonation token"
+// @Failure 409 {object} map[string]string "Organization not linked to the auth provider"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Failure 501 {object} map[string]string "Auth provider cannot switch organizations"
+// @Router /organizations/switch [post]
+func (h *OrganizationSwitchHandler) SwitchOrganization(c *gin.Context) {
+	identity := auth.GetIdentity(c)
+	if identity == nil {
+		response.Error(c, http.StatusUnauthorized, "authentication required", nil)
+		return
+	}
+
+	token, err := auth.GetBearerToken(c)
+	if err != nil {
+		response.Error(c, http.StatusUnauthorized, "missing or invalid authorization header", err)
+		return
+	}
+
+	var req services.SwitchOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	result, err := h.switchService.SwitchOrganization(c.Request.Context(), identity, token, &req)
+	if err != nil {
+		h.handleError(c, identity, "failed to switch organization", err)
+		return
+	}
+
+	// The response carries session tokens
+	c.Header("Cache-Control", "no-store")
+	response.Success(c, http.StatusOK, result)
+}
+
+func (h *OrganizationSwitchHandler) handleError(c *gin.Context, identity *auth.Identity, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrSwitchNotAllowed), errors.Is(err, domain.ErrSwitchNotMember), errors.Is(err, auth.ErrMissingEmail):
+		response.Error(c, http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, domain.ErrSwitchOrganizationNotLinked):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	case errors.Is(err, auth.ErrOrganizationSwitchUnsupported):
+		response.Error(c, http.StatusNotImplemented, auth.ErrOrganizationSwitchUnsupported.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{"user_id": identity.UserID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
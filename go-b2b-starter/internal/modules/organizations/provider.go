@@ -42,9 +42,255 @@ func (p *Provider) RegisterDependencies() error {
 	// Register member handler (for auth/member routes)
 	if err := p.container.Provide(func(
 		memberService services.MemberService,
+		invitePolicy *services.InvitePolicy,
 		logger logger.Logger,
 	) *MemberHandler {
-		return NewMemberHandler(memberService, logger)
+		return NewMemberHandler(memberService, invitePolicy, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		allowlistService services.IPAllowlistService,
+		logger logger.Logger,
+	) *IPAllowlistHandler {
+		return NewIPAllowlistHandler(allowlistService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		policyService services.AuthPolicyService,
+		logger logger.Logger,
+	) *AuthPolicyHandler {
+		return NewAuthPolicyHandler(policyService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		elevationService services.AccessElevationService,
+		logger logger.Logger,
+	) *ElevationHandler {
+		return NewElevationHandler(elevationService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		recoveryService services.MFARecoveryService,
+		logger logger.Logger,
+	) *MFARecoveryHandler {
+		return NewMFARecoveryHandler(recoveryService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		guestService services.GuestSessionService,
+		invitePolicy *services.InvitePolicy,
+		logger logger.Logger,
+	) *GuestHandler {
+		return NewGuestHandler(guestService, invitePolicy, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		offboardingService services.OffboardingService,
+		logger logger.Logger,
+	) *OffboardingHandler {
+		return NewOffboardingHandler(offboardingService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		changeService services.EmailChangeService,
+		logger logger.Logger,
+	) *EmailChangeHandler {
+		return NewEmailChangeHandler(changeService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		impersonationService services.ImpersonationService,
+		logger logger.Logger,
+	) *ImpersonationHandler {
+		return NewImpersonationHandler(impersonationService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		clientService services.OAuthClientService,
+		logger logger.Logger,
+	) *OAuthHandler {
+		return NewOAuthHandler(clientService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		stagingService services.StagingService,
+		logger logger.Logger,
+	) *StagingHandler {
+		return NewStagingHandler(stagingService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		inviteService services.InviteService,
+		logger logger.Logger,
+	) *InviteHandler {
+		return NewInviteHandler(inviteService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		providerService services.OIDCProviderService,
+		logger logger.Logger,
+	) *OIDCHandler {
+		return NewOIDCHandler(providerService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		contextService services.SessionContextService,
+		logger logger.Logger,
+	) *SessionContextHandler {
+		return NewSessionContextHandler(contextService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		switchService services.OrganizationSwitchService,
+		logger logger.Logger,
+	) *OrganizationSwitchHandler {
+		return NewOrganizationSwitchHandler(switchService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		canaryService services.CanaryService,
+		logger logger.Logger,
+	) *CanaryHandler {
+		return NewCanaryHandler(canaryService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		userService services.UserManagementService,
+		logger logger.Logger,
+	) *UserHandler {
+		return NewUserHandler(userService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		captureService services.DebugCaptureService,
+		logger logger.Logger,
+	) *DebugCaptureHandler {
+		return NewDebugCaptureHandler(captureService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		avatarService services.AvatarService,
+		policy *services.AvatarPolicy,
+		logger logger.Logger,
+	) *AvatarHandler {
+		return NewAvatarHandler(avatarService, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		activityService services.ActivityFeedService,
+		logger logger.Logger,
+	) *ActivityHandler {
+		return NewActivityHandler(activityService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		usageService services.APIUsageService,
+		logger logger.Logger,
+	) *APIUsageHandler {
+		return NewAPIUsageHandler(usageService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		notificationService services.NotificationService,
+		logger logger.Logger,
+	) *NotificationHandler {
+		return NewNotificationHandler(notificationService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		tagService services.TagService,
+		logger logger.Logger,
+	) *TagHandler {
+		return NewTagHandler(tagService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		identityService services.LinkedIdentityService,
+		logger logger.Logger,
+	) *LinkedIdentityHandler {
+		return NewLinkedIdentityHandler(identityService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		statsService services.UserStatsService,
+		logger logger.Logger,
+	) *UserStatsHandler {
+		return NewUserStatsHandler(statsService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		secondaryEmailService services.SecondaryEmailService,
+		logger logger.Logger,
+	) *SecondaryEmailHandler {
+		return NewSecondaryEmailHandler(secondaryEmailService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		assignmentService services.RoleAssignmentService,
+		logger logger.Logger,
+	) *RoleAssignmentHandler {
+		return NewRoleAssignmentHandler(assignmentService, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := p.container.Provide(func(
+		serviceAccountService services.ServiceAccountService,
+		logger logger.Logger,
+	) *ServiceAccountHandler {
+		return NewServiceAccountHandler(serviceAccountService, logger)
 	}); err != nil {
 		return err
 	}
@@ -54,8 +300,35 @@ func (p *Provider) RegisterDependencies() error {
 		organizationHandler *OrganizationHandler,
 		accountHandler *AccountHandler,
 		memberHandler *MemberHandler,
+		ipAllowlistHandler *IPAllowlistHandler,
+		elevationHandler *ElevationHandler,
+		mfaRecoveryHandler *MFARecoveryHandler,
+		guestHandler *GuestHandler,
+		offboardingHandler *OffboardingHandler,
+		emailChangeHandler *EmailChangeHandler,
+		impersonationHandler *ImpersonationHandler,
+		oauthHandler *OAuthHandler,
+		stagingHandler *StagingHandler,
+		inviteHandler *InviteHandler,
+		oidcHandler *OIDCHandler,
+		authPolicyHandler *AuthPolicyHandler,
+		sessionContextHandler *SessionContextHandler,
+		switchHandler *OrganizationSwitchHandler,
+		canaryHandler *CanaryHandler,
+		userHandler *UserHandler,
+		debugCaptureHandler *DebugCaptureHandler,
+		avatarHandler *AvatarHandler,
+		activityHandler *ActivityHandler,
+		apiUsageHandler *APIUsageHandler,
+		notificationHandler *NotificationHandler,
+		tagHandler *TagHandler,
+		identityHandler *LinkedIdentityHandler,
+		userStatsHandler *UserStatsHandler,
+		secondaryEmailHandler *SecondaryEmailHandler,
+		roleAssignmentHandler *RoleAssignmentHandler,
+		serviceAccountHandler *ServiceAccountHandler,
 	) *Routes {
-		return NewRoutes(organizationHandler, accountHandler, memberHandler)
+		return NewRoutes(organizationHandler, accountHandler, memberHandler, ipAllowlistHandler, elevationHandler, mfaRecoveryHandler, guestHandler, offboardingHandler, emailChangeHandler, impersonationHandler, oauthHandler, stagingHandler, inviteHandler, oidcHandler, authPolicyHandler, sessionContextHandler, switchHandler, canaryHandler, userHandler, debugCaptureHandler, avatarHandler, activityHandler, apiUsageHandler, notificationHandler, tagHandler, identityHandler, userStatsHandler, secondaryEmailHandler, roleAssignmentHandler, serviceAccountHandler)
 	}); err != nil {
 		return err
 	}
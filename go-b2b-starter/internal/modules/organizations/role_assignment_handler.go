@@ -0,0 +1,256 @@
+package organizations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// RoleAssignmentHandler assigns roles to members. Organization admins manage
+// the roles of their organization's accounts; global assignments span
+// organizations, so they are operator endpoints behind the "operator"
+// middleware like the role admin API.
+type RoleAssignmentHandler struct {
+	assignmentService services.RoleAssignmentService
+	logger            logger.Logger
+}
+
+func NewRoleAssignmentHandler(assignmentService services.RoleAssignmentService, logger logger.Logger) *RoleAssignmentHandler {
+	return &RoleAssignmentHandler{
+		assignmentService: assignmentService,
+		logger:            logger,
+	}
+}
+
+// ListUserRoles godoc
+// @Summary List user roles
+// @Description Returns the role the auth provider assigns to an account and the roles assigned to it, global assignments first.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 200 {object} domain.AccountRoles "Roles"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/roles [get]
+func (h *RoleAssignmentHandler) ListUserRoles(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.assignmentService.ListAccountRoles(c.Request.Context(), reqCtx.OrganizationID, accountID)
+	if err != nil {
+		h.handleError(c, "failed to list user roles", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, roles)
+}
+
+// AssignUserRole godoc
+// @Summary Assign a role to a user
+// @Description Assigns a role to an account of the organization on top of the role the auth provider assigns. It applies from the member's next request. Admins can only assign roles whose permissions they hold.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param request body services.AssignRoleRequest true "Role to assign"
+// @Success 201 {object} domain.RoleAssignment "Assignment"
+// @Failure 400 {object} map[string]string "Invalid ID or unknown role"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden or role grants permissions you do not have"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Role already assigned"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/roles [post]
+func (h *RoleAssignmentHandler) AssignUserRole(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req services.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	assignment, err := h.assignmentService.AssignAccountRole(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, reqCtx.Identity, accountID, &req)
+	if err != nil {
+		h.handleError(c, "failed to assign role", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, assignment)
+}
+
+// RemoveUserRole godoc
+// @Summary Remove a role from a user
+// @Description Removes a role assigned to an account of the organization. The role the auth provider assigns and global assignments are not affected. The organization must keep at least one admin.
+// @Tags Organizations
+// @Param id path int true "Account ID"
+// @Param role_id path string true "Role ID"
+// @Success 204 "Role removed"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found or role not assigned"
+// @Failure 409 {object} map[string]string "Last admin of the organization"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/roles/{role_id} [delete]
+func (h *RoleAssignmentHandler) RemoveUserRole(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	if err := h.assignmentService.RemoveAccountRole(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, accountID, c.Param("role_id")); err != nil {
+		h.handleError(c, "failed to remove role", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListGlobalAssignments godoc
+// @Summary List global role assignments
+// @Description Returns the roles assigned to users in every organization they have an account in, by email.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param email query string false "Only assignments of this email"
+// @Success 200 {array} domain.RoleAssignment "Assignments"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/rbac/assignments [get]
+func (h *RoleAssignmentHandler) ListGlobalAssignments(c *gin.Context) {
+	var req services.ListGlobalRoleAssignmentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	assignments, err := h.assignmentService.ListGlobalAssignments(c.Request.Context(), req.Email)
+	if err != nil {
+		h.handleError(c, "failed to list role assignments", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, assignments)
+}
+
+// AssignGlobalRole godoc
+// @Summary Assign a role globally
+// @Description Assigns a role to a user in every organization they have an account in, including organizations they join later.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param request body services.AssignGlobalRoleRequest true "User email and role"
+// @Success 201 {object} domain.RoleAssignment "Assignment"
+// @Failure 400 {object} map[string]string "Invalid email or unknown role"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Failure 409 {object} map[string]string "Role already assigned"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/rbac/assignments [post]
+func (h *RoleAssignmentHandler) AssignGlobalRole(c *gin.Context) {
+	var req services.AssignGlobalRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	assignment, err := h.assignmentService.AssignGlobalRole(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, "failed to assign role", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, assignment)
+}
+
+// RemoveGlobalRole godoc
+// @Summary Remove a global role assignment
+// @Description Removes a global assignment. Refused when an organization the user has an active account in would be left without an admin.
+// @Tags admin
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param id path int true "Assignment ID"
+// @Success 204 "Assignment removed"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Assignment not found or endpoint disabled"
+// @Failure 409 {object} map[string]string "Last admin of an organization"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/rbac/assignments/{id} [delete]
+func (h *RoleAssignmentHandler) RemoveGlobalRole(c *gin.Context) {
+	var id int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid assignment ID format", err)
+		return
+	}
+
+	if _, err := h.assignmentService.RemoveGlobalRole(c.Request.Context(), id); err != nil {
+		h.handleError(c, "failed to remove role assignment", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RoleAssignmentHandler) requestContext(c *gin.Context) (*auth.RequestContext, bool) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return nil, false
+	}
+	return reqCtx, true
+}
+
+// target returns the request context and the account ID in the path. It
+// writes the error response and returns false when either is missing.
+func (h *RoleAssignmentHandler) target(c *gin.Context) (*auth.RequestContext, int32, bool) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return nil, 0, false
+	}
+
+	var accountID int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &accountID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid account ID format", err)
+		return nil, 0, false
+	}
+
+	return reqCtx, accountID, true
+}
+
+func (h *RoleAssignmentHandler) handleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAccountNotFound):
+		response.Error(c, http.StatusNotFound, "user not found", err)
+	case errors.Is(err, domain.ErrRoleAssignmentNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrRoleAssignmentInvalidRole):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, domain.ErrRoleAssignmentNotPermitted):
+		response.Error(c, http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, domain.ErrRoleAssignmentExists), errors.Is(err, domain.ErrRoleAssignmentLastAdmin):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -3,25 +3,105 @@ package organizations
 import (
 	"github.com/gin-gonic/gin"
 
-	"github.com/moasq/go-b2b-starter/internal/modules/auth"
 	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
 )
 
 type Routes struct {
-	organizationHandler *OrganizationHandler
-	accountHandler      *AccountHandler
-	memberHandler       *MemberHandler
+	organizationHandler   *OrganizationHandler
+	accountHandler        *AccountHandler
+	memberHandler         *MemberHandler
+	ipAllowlistHandler    *IPAllowlistHandler
+	elevationHandler      *ElevationHandler
+	mfaRecoveryHandler    *MFARecoveryHandler
+	guestHandler          *GuestHandler
+	offboardingHandler    *OffboardingHandler
+	emailChangeHandler    *EmailChangeHandler
+	impersonationHandler  *ImpersonationHandler
+	oauthHandler          *OAuthHandler
+	stagingHandler        *StagingHandler
+	inviteHandler         *InviteHandler
+	oidcHandler           *OIDCHandler
+	authPolicyHandler     *AuthPolicyHandler
+	sessionContextHandler *SessionContextHandler
+	switchHandler         *OrganizationSwitchHandler
+	canaryHandler         *CanaryHandler
+	userHandler           *UserHandler
+	debugCaptureHandler   *DebugCaptureHandler
+	avatarHandler         *AvatarHandler
+	activityHandler       *ActivityHandler
+	apiUsageHandler       *APIUsageHandler
+	notificationHandler   *NotificationHandler
+	tagHandler            *TagHandler
+	identityHandler       *LinkedIdentityHandler
+	userStatsHandler      *UserStatsHandler
+	secondaryEmailHandler *SecondaryEmailHandler
+	roleAssignmentHandler *RoleAssignmentHandler
+	serviceAccountHandler *ServiceAccountHandler
 }
 
 func NewRoutes(
 	organizationHandler *OrganizationHandler,
 	accountHandler *AccountHandler,
 	memberHandler *MemberHandler,
+	ipAllowlistHandler *IPAllowlistHandler,
+	elevationHandler *ElevationHandler,
+	mfaRecoveryHandler *MFARecoveryHandler,
+	guestHandler *GuestHandler,
+	offboardingHandler *OffboardingHandler,
+	emailChangeHandler *EmailChangeHandler,
+	impersonationHandler *ImpersonationHandler,
+	oauthHandler *OAuthHandler,
+	stagingHandler *StagingHandler,
+	inviteHandler *InviteHandler,
+	oidcHandler *OIDCHandler,
+	authPolicyHandler *AuthPolicyHandler,
+	sessionContextHandler *SessionContextHandler,
+	switchHandler *OrganizationSwitchHandler,
+	canaryHandler *CanaryHandler,
+	userHandler *UserHandler,
+	debugCaptureHandler *DebugCaptureHandler,
+	avatarHandler *AvatarHandler,
+	activityHandler *ActivityHandler,
+	apiUsageHandler *APIUsageHandler,
+	notificationHandler *NotificationHandler,
+	tagHandler *TagHandler,
+	identityHandler *LinkedIdentityHandler,
+	userStatsHandler *UserStatsHandler,
+	secondaryEmailHandler *SecondaryEmailHandler,
+	roleAssignmentHandler *RoleAssignmentHandler,
+	serviceAccountHandler *ServiceAccountHandler,
 ) *Routes {
 	return &Routes{
-		organizationHandler: organizationHandler,
-		accountHandler:      accountHandler,
-		memberHandler:       memberHandler,
+		organizationHandler:   organizationHandler,
+		accountHandler:        accountHandler,
+		memberHandler:         memberHandler,
+		ipAllowlistHandler:    ipAllowlistHandler,
+		elevationHandler:      elevationHandler,
+		mfaRecoveryHandler:    mfaRecoveryHandler,
+		guestHandler:          guestHandler,
+		offboardingHandler:    offboardingHandler,
+		emailChangeHandler:    emailChangeHandler,
+		impersonationHandler:  impersonationHandler,
+		oauthHandler:          oauthHandler,
+		stagingHandler:        stagingHandler,
+		inviteHandler:         inviteHandler,
+		oidcHandler:           oidcHandler,
+		authPolicyHandler:     authPolicyHandler,
+		sessionContextHandler: sessionContextHandler,
+		switchHandler:         switchHandler,
+		canaryHandler:         canaryHandler,
+		userHandler:           userHandler,
+		debugCaptureHandler:   debugCaptureHandler,
+		avatarHandler:         avatarHandler,
+		activityHandler:       activityHandler,
+		apiUsageHandler:       apiUsageHandler,
+		notificationHandler:   notificationHandler,
+		tagHandler:            tagHandler,
+		identityHandler:       identityHandler,
+		userStatsHandler:      userStatsHandler,
+		secondaryEmailHandler: secondaryEmailHandler,
+		roleAssignmentHandler: roleAssignmentHandler,
+		serviceAccountHandler: serviceAccountHandler,
 	}
 }
 
@@ -30,11 +110,46 @@ func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.M
 	// Auth routes - member management and authentication
 	authGroup := router.Group("/auth")
 	{
+		// Public login-flow endpoints are throttled per client IP and per email,
+		// and challenged with a CAPTCHA after repeated failed attempts. Sign-in
+		// lookups and recoveries for canary users raise an alert.
+
 		// Public endpoint - Organization signup (no authentication required)
-		authGroup.POST("/signup", r.memberHandler.BootstrapOrganization)
+		authGroup.POST("/signup", resolver.Get("login_rate_limit"), resolver.Get("captcha_signup"), r.memberHandler.BootstrapOrganization)
+
+		// Public endpoint - Register as a member through an invite (open even when signup is invite-only)
+		authGroup.POST("/invites/accept", resolver.Get("login_rate_limit"), resolver.Get("captcha_signup"), r.inviteHandler.AcceptInvite)
 
 		// Public endpoint - Check if email exists (no authentication required)
-		authGroup.GET("/check-email", r.memberHandler.CheckEmail)
+		authGroup.GET("/check-email", resolver.Get("canary"), resolver.Get("login_rate_limit"), resolver.Get("captcha"), r.memberHandler.CheckEmail)
+
+		// Public endpoints - MFA recovery for members who lost their device
+		authGroup.POST("/mfa-recovery/start", resolver.Get("canary"), resolver.Get("login_rate_limit"), resolver.Get("email_throttle"), resolver.Get("captcha"), r.mfaRecoveryHandler.StartRecovery)
+		authGroup.POST("/mfa-recovery/verify", resolver.Get("login_rate_limit"), resolver.Get("captcha"), r.mfaRecoveryHandler.VerifyRecovery)
+		authGroup.POST("/mfa-recovery/complete", resolver.Get("login_rate_limit"), resolver.Get("captcha"), r.mfaRecoveryHandler.CompleteRecovery)
+
+		// Public endpoints - Email change links sent to the new and old addresses
+		authGroup.POST("/email-change/confirm", resolver.Get("login_rate_limit"), r.emailChangeHandler.ConfirmChange)
+		authGroup.POST("/email-change/revert", resolver.Get("login_rate_limit"), r.emailChangeHandler.RevertChange)
+
+		// Public endpoint - Verification links sent to secondary email addresses
+		authGroup.POST("/emails/verify", resolver.Get("login_rate_limit"), r.secondaryEmailHandler.VerifyEmail)
+
+		// Public endpoint - Start an anonymous guest session for trials
+		authGroup.POST("/guest", resolver.Get("login_rate_limit"), resolver.Get("captcha"), r.guestHandler.StartGuestSession)
+
+		// Guest endpoint - Register the guest as an organization owner and move its data
+		authGroup.POST("/guest/upgrade",
+			resolver.Get("login_rate_limit"),
+			resolver.Get("captcha_signup"),
+			resolver.Get("guest_auth"),
+			r.guestHandler.UpgradeGuestSession)
+
+		// Protected endpoint - Claim guest data after signup (guest tokens are not accepted)
+		authGroup.POST("/guest/claim",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			r.guestHandler.ClaimGuestSession)
 
 		// Protected endpoint - Add member (requires JWT authentication)
 		authGroup.POST("/members",
@@ -46,7 +161,7 @@ func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.M
 		authGroup.GET("/members",
 			resolver.Get("auth"),
 			resolver.Get("org_context"),
-			auth.RequirePermissionFunc("org", "manage"),
+			resolver.Get("perm:org:manage"),
 			r.memberHandler.ListMembers)
 
 		// Protected endpoint - Get current user profile (requires JWT authentication only)
@@ -55,12 +170,113 @@ func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.M
 			resolver.Get("org_context"),
 			r.memberHandler.GetProfile)
 
-		// Protected endpoint - Delete organization member (requires JWT authentication and org:manage permission)
+		// Protected endpoint - Delete organization member (requires JWT authentication, org:manage permission and a recent sign-in)
 		authGroup.DELETE("/members/:member_id",
 			resolver.Get("auth"),
 			resolver.Get("org_context"),
-			auth.RequirePermissionFunc("org", "manage"),
+			resolver.Get("perm:org:manage"),
+			resolver.Get("recent_auth"),
 			r.memberHandler.DeleteMember)
+
+		// Protected endpoint - Offboard member with data transfer and session revocation (requires org:manage permission and a recent sign-in)
+		authGroup.POST("/members/:member_id/offboard",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			resolver.Get("perm:org:manage"),
+			resolver.Get("recent_auth"),
+			r.offboardingHandler.OffboardMember)
+
+		// Protected endpoint - Impersonate a member with a short-lived, audited token (requires org:manage permission and a recent sign-in)
+		authGroup.POST("/members/:member_id/impersonate",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			resolver.Get("perm:org:manage"),
+			resolver.Get("recent_auth"),
+			r.impersonationHandler.StartImpersonation)
+
+		// Protected endpoint - End the impersonation the request is made with
+		authGroup.POST("/impersonation/end",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			r.impersonationHandler.EndImpersonation)
+	}
+
+	// OAuth routes - client credentials grant for backend services
+	oauthGroup := router.Group("/oauth")
+	{
+		// Public endpoint - Token endpoint authenticates the client itself
+		oauthGroup.POST("/token", resolver.Get("canary"), resolver.Get("login_rate_limit"), r.oauthHandler.Token)
+		// Public endpoint - Introspection authenticates the calling client the same way. It
+		// is called per request by gateways, so it is left out of the login rate limit.
+		oauthGroup.POST("/introspect", resolver.Get("canary"), r.oauthHandler.Introspect)
+
+		// Protected endpoints - Client registration (requires org:manage permission;
+		// creating and revoking credentials also requires a recent sign-in)
+		oauthGroup.POST("/clients",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			resolver.Get("perm:org:manage"),
+			resolver.Get("recent_auth"),
+			r.oauthHandler.CreateClient)
+		oauthGroup.GET("/clients",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			resolver.Get("perm:org:manage"),
+			r.oauthHandler.ListClients)
+		oauthGroup.DELETE("/clients/:id",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			resolver.Get("perm:org:manage"),
+			resolver.Get("recent_auth"),
+			r.oauthHandler.RevokeClient)
+	}
+
+	// OpenID Connect routes - this API as identity provider for internal tools
+	oidcGroup := router.Group("/oidc")
+	{
+		// Public endpoints - Discovery, signing keys and the endpoints clients call directly
+		oidcGroup.GET("/.well-known/openid-configuration", r.oidcHandler.Discovery)
+		oidcGroup.GET("/jwks", r.oidcHandler.JWKS)
+		oidcGroup.POST("/token", resolver.Get("canary"), resolver.Get("login_rate_limit"), r.oidcHandler.Token)
+		oidcGroup.GET("/userinfo", r.oidcHandler.UserInfo)
+
+		// Protected endpoints - The consent page acts for the signed-in member
+		oidcGroup.GET("/authorize",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			r.oidcHandler.GetAuthorization)
+		oidcGroup.POST("/authorize",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			r.oidcHandler.Authorize)
+
+		// Protected endpoints - Client registration (requires org:manage permission;
+		// creating and revoking credentials also requires a recent sign-in)
+		oidcGroup.POST("/clients",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			resolver.Get("perm:org:manage"),
+			resolver.Get("recent_auth"),
+			r.oidcHandler.CreateClient)
+		oidcGroup.GET("/clients",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			resolver.Get("perm:org:manage"),
+			r.oidcHandler.ListClients)
+		oidcGroup.DELETE("/clients/:id",
+			resolver.Get("auth"),
+			resolver.Get("org_context"),
+			resolver.Get("perm:org:manage"),
+			resolver.Get("recent_auth"),
+			r.oidcHandler.RevokeClient)
+	}
+
+	// Organization switching - require JWT authentication only, so members can
+	// leave an organization whose policies their current token does not satisfy
+	switchGroup := router.Group("/organizations")
+	{
+		switchGroup.GET("/memberships", resolver.Get("auth"), r.switchHandler.ListMemberships)
+		switchGroup.POST("/switch", resolver.Get("auth"), r.switchHandler.SwitchOrganization)
 	}
 
 	// Organization routes - require JWT authentication
@@ -71,9 +287,135 @@ func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.M
 	)
 	{
 		// Current organization endpoints
-		orgGroup.GET("", auth.RequirePermissionFunc("org", "view"), r.organizationHandler.GetOrganization)
-		orgGroup.PUT("", auth.RequirePermissionFunc("org", "manage"), r.organizationHandler.UpdateOrganization)
-		orgGroup.GET("/stats", auth.RequirePermissionFunc("org", "view"), r.organizationHandler.GetOrganizationStats)
+		orgGroup.GET("", resolver.Get("perm:org:view"), r.organizationHandler.GetOrganization)
+		orgGroup.PUT("", resolver.Get("perm:org:manage"), r.organizationHandler.UpdateOrganization)
+		orgGroup.GET("/stats", resolver.Get("perm:org:view"), r.organizationHandler.GetOrganizationStats)
+
+		// IP allowlist management (changes require a recent sign-in)
+		orgGroup.GET("/ip-allowlist", resolver.Get("perm:org:manage"), r.ipAllowlistHandler.ListEntries)
+		orgGroup.POST("/ip-allowlist", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.ipAllowlistHandler.AddEntry)
+		orgGroup.DELETE("/ip-allowlist/:id", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.ipAllowlistHandler.RemoveEntry)
+
+		// Auth policy overrides - MFA, session age and sign-in methods (changes require a recent sign-in)
+		orgGroup.GET("/auth-policy", resolver.Get("perm:org:manage"), r.authPolicyHandler.GetPolicy)
+		orgGroup.PUT("/auth-policy", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.authPolicyHandler.UpdatePolicy)
+		orgGroup.DELETE("/auth-policy", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.authPolicyHandler.ResetPolicy)
+
+		// Canary credentials - decoy API keys and users that alert when used (changes require a recent sign-in)
+		orgGroup.GET("/canaries", resolver.Get("perm:org:manage"), r.canaryHandler.ListCanaries)
+		orgGroup.POST("/canaries", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.canaryHandler.CreateCanary)
+		orgGroup.DELETE("/canaries/:id", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.canaryHandler.DeleteCanary)
+
+		// Admin user management (changes require a recent sign-in)
+		orgGroup.GET("/users", resolver.Get("perm:org:manage"), r.userHandler.ListUsers)
+		orgGroup.GET("/users/:id", resolver.Get("perm:org:manage"), r.userHandler.GetUser)
+		orgGroup.POST("/users/:id/suspend", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.userHandler.SuspendUser)
+		orgGroup.POST("/users/:id/reactivate", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.userHandler.ReactivateUser)
+		orgGroup.GET("/users/:id/status-history", resolver.Get("perm:org:manage"), r.userHandler.GetUserStatusHistory)
+		orgGroup.POST("/users/:id/password-reset", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.userHandler.ResetUserPassword)
+		orgGroup.POST("/users/:id/unlock", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.userHandler.UnlockUser)
+		orgGroup.GET("/users/:id/metadata", resolver.Get("perm:org:manage"), r.userHandler.GetUserMetadata)
+		orgGroup.PATCH("/users/:id/metadata", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.userHandler.PatchUserMetadata)
+		orgGroup.GET("/users/:id/activity", resolver.Get("perm:org:manage"), r.activityHandler.GetUserActivity)
+		orgGroup.GET("/users/:id/tags", resolver.Get("perm:org:manage"), r.tagHandler.ListUserTags)
+		orgGroup.POST("/users/:id/tags", resolver.Get("perm:org:manage"), r.tagHandler.AddUserTag)
+		orgGroup.DELETE("/users/:id/tags/:tag", resolver.Get("perm:org:manage"), r.tagHandler.RemoveUserTag)
+		orgGroup.GET("/users/:id/roles", resolver.Get("perm:org:manage"), r.roleAssignmentHandler.ListUserRoles)
+		orgGroup.POST("/users/:id/roles", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.roleAssignmentHandler.AssignUserRole)
+		orgGroup.DELETE("/users/:id/roles/:role_id", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.roleAssignmentHandler.RemoveUserRole)
+		orgGroup.DELETE("/users/:id", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.userHandler.DeleteUser)
+
+		// Service accounts - non-human users with API keys (changes require a recent sign-in)
+		orgGroup.GET("/service-accounts", resolver.Get("perm:org:manage"), r.serviceAccountHandler.ListServiceAccounts)
+		orgGroup.POST("/service-accounts", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.serviceAccountHandler.CreateServiceAccount)
+		orgGroup.GET("/service-accounts/:id", resolver.Get("perm:org:manage"), r.serviceAccountHandler.GetServiceAccount)
+		orgGroup.PUT("/service-accounts/:id", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.serviceAccountHandler.UpdateServiceAccount)
+		orgGroup.DELETE("/service-accounts/:id", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.serviceAccountHandler.DeleteServiceAccount)
+		orgGroup.POST("/service-accounts/:id/disable", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.serviceAccountHandler.DisableServiceAccount)
+		orgGroup.POST("/service-accounts/:id/enable", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.serviceAccountHandler.EnableServiceAccount)
+		orgGroup.GET("/service-accounts/:id/keys", resolver.Get("perm:org:manage"), r.serviceAccountHandler.ListKeys)
+		orgGroup.POST("/service-accounts/:id/keys", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.serviceAccountHandler.CreateKey)
+		orgGroup.DELETE("/service-accounts/:id/keys/:key_id", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.serviceAccountHandler.RevokeKey)
+
+		// User tags (segments) for support and feature-flag targeting
+		orgGroup.GET("/tags", resolver.Get("perm:org:manage"), r.tagHandler.ListTags)
+		orgGroup.DELETE("/tags/:name", resolver.Get("perm:org:manage"), r.tagHandler.DeleteTag)
+
+		// Staging and sandbox organizations for testing integrations
+		orgGroup.GET("/staging", resolver.Get("perm:org:manage"), r.stagingHandler.ListStagingOrganizations)
+		orgGroup.POST("/staging", resolver.Get("perm:org:manage"), r.stagingHandler.CreateStagingOrganization)
+
+		// Invites for new members (the only way to register when signup is invite-only)
+		orgGroup.GET("/invites", resolver.Get("perm:org:manage"), r.inviteHandler.ListInvites)
+		orgGroup.POST("/invites", resolver.Get("perm:org:manage"), r.inviteHandler.CreateInvite)
+		orgGroup.POST("/invites/:id/revoke", resolver.Get("perm:org:manage"), r.inviteHandler.RevokeInvite)
+	}
+
+	// Session context - everything the frontend needs about the signed-in user
+	meGroup := router.Group("/me")
+	meGroup.Use(
+		resolver.Get("auth"),
+		resolver.Get("org_context"),
+	)
+	{
+		meGroup.GET("/context", r.sessionContextHandler.GetContext)
+		meGroup.PUT("/avatar", r.avatarHandler.UploadAvatar)
+		meGroup.DELETE("/avatar", r.avatarHandler.DeleteAvatar)
+		meGroup.GET("/usage", r.apiUsageHandler.GetUsage)
+		meGroup.GET("/notification-preferences", r.notificationHandler.GetPreferences)
+		meGroup.PUT("/notification-preferences", r.notificationHandler.UpdatePreferences)
+		meGroup.GET("/notifications", r.notificationHandler.ListNotifications)
+		meGroup.POST("/notifications/:id/read", r.notificationHandler.MarkRead)
+
+		// Linked Google/GitHub identities (linking starts and unlinking need a recent sign-in)
+		meGroup.GET("/identities", r.identityHandler.ListIdentities)
+		meGroup.POST("/identities/:provider/link", resolver.Get("recent_auth"), r.identityHandler.StartLink)
+		meGroup.POST("/identities/:provider/callback", r.identityHandler.CompleteLink)
+		meGroup.DELETE("/identities/:provider", resolver.Get("recent_auth"), r.identityHandler.Unlink)
+
+		// Secondary email addresses (adding, removing and making one primary need a recent sign-in;
+		// verification emails are throttled)
+		meGroup.GET("/emails", r.secondaryEmailHandler.ListEmails)
+		meGroup.POST("/emails", resolver.Get("recent_auth"), resolver.Get("email_throttle"), r.secondaryEmailHandler.AddEmail)
+		meGroup.DELETE("/emails/:id", resolver.Get("recent_auth"), r.secondaryEmailHandler.RemoveEmail)
+		meGroup.POST("/emails/:id/resend", resolver.Get("email_throttle"), r.secondaryEmailHandler.ResendVerification)
+		meGroup.POST("/emails/:id/primary", resolver.Get("recent_auth"), r.secondaryEmailHandler.MakePrimary)
+		meGroup.PUT("/emails/:id/notifications", r.secondaryEmailHandler.SetNotifications)
+	}
+
+	// Public endpoint - Unsubscribe links in notification emails carry a signed token
+	router.POST("/notifications/unsubscribe", r.notificationHandler.Unsubscribe)
+
+	// Public endpoint - Avatars are served by their random key so they work in img tags
+	router.GET("/avatars/:key", r.avatarHandler.GetAvatar)
+
+	// Just-in-time elevation routes - require JWT authentication
+	elevationGroup := router.Group("/elevations")
+	elevationGroup.Use(
+		resolver.Get("auth"),
+		resolver.Get("org_context"),
+	)
+	{
+		// Any member may request elevation; org admins decide
+		elevationGroup.POST("", r.elevationHandler.RequestElevation)
+		elevationGroup.GET("", resolver.Get("perm:org:manage"), r.elevationHandler.ListElevations)
+		elevationGroup.POST("/:id/approve", resolver.Get("perm:org:manage"), resolver.Get("recent_auth"), r.elevationHandler.ApproveElevation)
+		elevationGroup.POST("/:id/deny", resolver.Get("perm:org:manage"), r.elevationHandler.DenyElevation)
+		elevationGroup.POST("/:id/revoke", resolver.Get("perm:org:manage"), r.elevationHandler.RevokeElevation)
+	}
+
+	// MFA recovery review routes - require JWT authentication
+	mfaRecoveryGroup := router.Group("/mfa-recoveries")
+	mfaRecoveryGroup.Use(
+		resolver.Get("auth"),
+		resolver.Get("org_context"),
+	)
+	{
+		// Members cancel their own requests; org admins review and decide
+		mfaRecoveryGroup.POST("/:id/cancel", r.mfaRecoveryHandler.CancelRecovery)
+		mfaRecoveryGroup.GET("", resolver.Get("perm:org:manage"), r.mfaRecoveryHandler.ListRecoveries)
+		mfaRecoveryGroup.POST("/:id/approve", resolver.Get("perm:org:manage"), r.mfaRecoveryHandler.ApproveRecovery)
+		mfaRecoveryGroup.POST("/:id/deny", resolver.Get("perm:org:manage"), r.mfaRecoveryHandler.DenyRecovery)
 	}
 
 	// Account routes - require JWT authentication
@@ -83,16 +425,49 @@ func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.M
 		resolver.Get("org_context"),
 	)
 	{
+		// Email change for the signed-in member (the verification email is throttled)
+		accountGroup.POST("/me/email-change", resolver.Get("email_throttle"), r.emailChangeHandler.RequestChange)
+		accountGroup.DELETE("/me/email-change", r.emailChangeHandler.CancelChange)
+
 		// Account management
-		accountGroup.POST("", auth.RequirePermissionFunc("org", "manage"), r.accountHandler.CreateAccount)
-		accountGroup.GET("", auth.RequirePermissionFunc("org", "view"), r.accountHandler.ListAccounts)
-		accountGroup.GET("/by-email", auth.RequirePermissionFunc("org", "view"), r.accountHandler.GetAccountByEmail)
-		accountGroup.GET("/:id", auth.RequirePermissionFunc("org", "view"), r.accountHandler.GetAccount)
-		accountGroup.PUT("/:id", auth.RequirePermissionFunc("org", "manage"), r.accountHandler.UpdateAccount)
-		accountGroup.DELETE("/:id", auth.RequirePermissionFunc("org", "manage"), r.accountHandler.DeleteAccount)
-		accountGroup.POST("/:id/last-login", auth.RequirePermissionFunc("org", "view"), r.accountHandler.UpdateAccountLastLogin)
-		accountGroup.GET("/:id/permissions", auth.RequirePermissionFunc("org", "view"), r.accountHandler.CheckAccountPermission)
-		accountGroup.GET("/:id/stats", auth.RequirePermissionFunc("org", "view"), r.accountHandler.GetAccountStats)
+		accountGroup.POST("", resolver.Get("perm:org:manage"), r.accountHandler.CreateAccount)
+		accountGroup.GET("", resolver.Get("perm:org:view"), r.accountHandler.ListAccounts)
+		accountGroup.GET("/by-email", resolver.Get("perm:org:view"), r.accountHandler.GetAccountByEmail)
+		accountGroup.GET("/by-public-id/:public_id", resolver.Get("perm:org:view"), r.accountHandler.GetAccountByPublicID)
+		accountGroup.GET("/:id", resolver.Get("perm:org:view"), r.accountHandler.GetAccount)
+		accountGroup.PUT("/:id", resolver.Get("perm:org:manage"), r.accountHandler.UpdateAccount)
+		accountGroup.DELETE("/:id", resolver.Get("perm:org:manage"), r.accountHandler.DeleteAccount)
+		accountGroup.POST("/:id/last-login", resolver.Get("perm:org:view"), r.accountHandler.UpdateAccountLastLogin)
+		accountGroup.GET("/:id/permissions", resolver.Get("perm:org:view"), r.accountHandler.CheckAccountPermission)
+		accountGroup.GET("/:id/stats", resolver.Get("perm:org:view"), r.accountHandler.GetAccountStats)
+	}
+
+	// Debug captures - operator endpoints with X-Admin-Token instead of organization auth
+	debugCaptureGroup := router.Group("/admin/debug-captures")
+	debugCaptureGroup.Use(resolver.Get("operator"))
+	{
+		debugCaptureGroup.POST("", r.debugCaptureHandler.StartCapture)
+		debugCaptureGroup.GET("", r.debugCaptureHandler.ListCaptures)
+		debugCaptureGroup.GET("/:id", r.debugCaptureHandler.GetCapture)
+		debugCaptureGroup.POST("/:id/stop", r.debugCaptureHandler.StopCapture)
+		debugCaptureGroup.GET("/:id/records", r.debugCaptureHandler.ListRecords)
+	}
+
+	// User statistics - operator endpoints for an internal admin dashboard with X-Admin-Token
+	userStatsGroup := router.Group("/admin/user-stats")
+	userStatsGroup.Use(resolver.Get("operator"))
+	{
+		userStatsGroup.GET("", r.userStatsHandler.GetStats)
+		userStatsGroup.GET("/signups", r.userStatsHandler.ListSignups)
+	}
+
+	// Global role assignments - operator endpoints with the ADMIN_API_TOKEN X-Admin-Token
+	roleAssignmentGroup := router.Group("/admin/rbac/assignments")
+	roleAssignmentGroup.Use(resolver.Get("operator"))
+	{
+		roleAssignmentGroup.GET("", r.roleAssignmentHandler.ListGlobalAssignments)
+		roleAssignmentGroup.POST("", r.roleAssignmentHandler.AssignGlobalRole)
+		roleAssignmentGroup.DELETE("/:id", r.roleAssignmentHandler.RemoveGlobalRole)
 	}
 }
 
@@ -0,0 +1,280 @@
+package organizations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type SecondaryEmailHandler struct {
+	secondaryEmailService services.SecondaryEmailService
+	logger                logger.Logger
+}
+
+func NewSecondaryEmailHandler(secondaryEmailService services.SecondaryEmailService, logger logger.Logger) *SecondaryEmailHandler {
+	return &SecondaryEmailHandler{
+		secondaryEmailService: secondaryEmailService,
+		logger:                logger,
+	}
+}
+
+// ListEmails godoc
+// @Summary List my email addresses
+// @Description Returns the signed-in member's primary email and their secondary addresses, verified or not, oldest first.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} services.AccountEmails "Email addresses"
+// @Failure 400 {object} map[string]string "Missing organization context"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/emails [get]
+func (h *SecondaryEmailHandler) ListEmails(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	emails, err := h.secondaryEmailService.ListEmails(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID)
+	if err != nil {
+		h.handleError(c, "failed to list email addresses", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, emails)
+}
+
+// AddEmail godoc
+// @Summary Add a secondary email address
+// @Description Adds an address to the signed-in member's account and emails it a verification link to SECONDARY_EMAIL_VERIFY_URL. The primary address is told about it. Requires a recent sign-in.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.AddSecondaryEmailRequest true "Address to add"
+// @Success 201 {object} domain.SecondaryEmail "Unverified address"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized or sign-in not recent enough"
+// @Failure 409 {object} map[string]string "Address already on the account, taken, or limit reached"
+// @Failure 429 {object} map[string]string "Too many emails requested"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/emails [post]
+func (h *SecondaryEmailHandler) AddEmail(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	var req services.AddSecondaryEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	secondary, err := h.secondaryEmailService.AddEmail(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		h.handleError(c, "failed to add email address", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, secondary)
+}
+
+// ResendVerification godoc
+// @Summary Resend a verification link
+// @Description Emails a new verification link to one of the signed-in member's unverified addresses. Earlier links stop working.
+// @Tags auth
+// @Produce json
+// @Param id path int true "Secondary email ID"
+// @Success 200 {object} domain.SecondaryEmail "Unverified address"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Address not found"
+// @Failure 409 {object} map[string]string "Address already verified"
+// @Failure 429 {object} map[string]string "Too many emails requested"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/emails/{id}/resend [post]
+func (h *SecondaryEmailHandler) ResendVerification(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	secondary, err := h.secondaryEmailService.ResendVerification(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id)
+	if err != nil {
+		h.handleError(c, "failed to resend verification", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, secondary)
+}
+
+// RemoveEmail godoc
+// @Summary Remove a secondary email address
+// @Description Removes one of the signed-in member's secondary addresses. Requires a recent sign-in.
+// @Tags auth
+// @Produce json
+// @Param id path int true "Secondary email ID"
+// @Success 204 "Address removed"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized or sign-in not recent enough"
+// @Failure 404 {object} map[string]string "Address not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/emails/{id} [delete]
+func (h *SecondaryEmailHandler) RemoveEmail(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	if err := h.secondaryEmailService.RemoveEmail(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id); err != nil {
+		h.handleError(c, "failed to remove email address", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SetNotifications godoc
+// @Summary Turn notifications to an address on or off
+// @Description Sends the signed-in member's email notifications to a verified secondary address as well as the primary one, or stops doing so. Notification preferences apply to every address.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param id path int true "Secondary email ID"
+// @Param request body services.SetSecondaryEmailNotificationsRequest true "Whether the address receives notifications"
+// @Success 200 {object} domain.SecondaryEmail "Address"
+// @Failure 400 {object} map[string]string "Invalid ID or request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Address not found"
+// @Failure 409 {object} map[string]string "Address not verified"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/emails/{id}/notifications [put]
+func (h *SecondaryEmailHandler) SetNotifications(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req services.SetSecondaryEmailNotificationsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	secondary, err := h.secondaryEmailService.SetNotifications(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id, &req)
+	if err != nil {
+		h.handleError(c, "failed to update email notifications", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, secondary)
+}
+
+// MakePrimary godoc
+// @Summary Make a secondary address primary
+// @Description Swaps a verified secondary address with the signed-in member's primary email in the auth provider and locally. The old primary address stays on the account as a verified secondary address and is told about the change. Signs the member out everywhere. Requires a recent sign-in.
+// @Tags auth
+// @Produce json
+// @Param id path int true "Secondary email ID"
+// @Success 200 {object} services.AccountEmails "Email addresses"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized or sign-in not recent enough"
+// @Failure 404 {object} map[string]string "Address not found"
+// @Failure 409 {object} map[string]string "Address not verified or taken"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/emails/{id}/primary [post]
+func (h *SecondaryEmailHandler) MakePrimary(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	emails, err := h.secondaryEmailService.MakePrimary(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id)
+	if err != nil {
+		h.handleError(c, "failed to make email address primary", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, emails)
+}
+
+// VerifyEmail godoc
+// @Summary Verify a secondary email address
+// @Description Verifies the address a verification link was sent to. No sign-in is needed; the page at SECONDARY_EMAIL_VERIFY_URL posts the token from its query string.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body services.SecondaryEmailTokenRequest true "Verification token"
+// @Success 200 {object} domain.SecondaryEmail "Verified address"
+// @Failure 400 {object} map[string]string "Invalid or expired token"
+// @Failure 409 {object} map[string]string "Address already verified or taken"
+// @Failure 429 {object} map[string]string "Too many attempts"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /auth/emails/verify [post]
+func (h *SecondaryEmailHandler) VerifyEmail(c *gin.Context) {
+	var req services.SecondaryEmailTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	secondary, err := h.secondaryEmailService.VerifyEmail(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, "failed to verify email address", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, secondary)
+}
+
+func (h *SecondaryEmailHandler) requestContext(c *gin.Context) (*auth.RequestContext, bool) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return nil, false
+	}
+	return reqCtx, true
+}
+
+// target returns the request context and the secondary email ID in the
+// path. It writes the error response and returns false when either is missing.
+func (h *SecondaryEmailHandler) target(c *gin.Context) (*auth.RequestContext, int32, bool) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return nil, 0, false
+	}
+
+	var id int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid secondary email ID format", err)
+		return nil, 0, false
+	}
+
+	return reqCtx, id, true
+}
+
+func (h *SecondaryEmailHandler) handleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAccountEmailRequired), errors.Is(err, domain.ErrSecondaryEmailInvalidToken):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, domain.ErrSecondaryEmailNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrSecondaryEmailExists), errors.Is(err, domain.ErrSecondaryEmailLimit),
+		errors.Is(err, domain.ErrSecondaryEmailNotVerified), errors.Is(err, domain.ErrSecondaryEmailVerified),
+		errors.Is(err, domain.ErrUserAlreadyExists):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	case errors.Is(err, domain.ErrAccountInactive):
+		response.Error(c, http.StatusForbidden, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,384 @@
+package organizations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// ServiceAccountHandler manages the organization's service accounts and
+// their API keys. Only organization admins can use it.
+type ServiceAccountHandler struct {
+	serviceAccountService services.ServiceAccountService
+	logger                logger.Logger
+}
+
+func NewServiceAccountHandler(serviceAccountService services.ServiceAccountService, logger logger.Logger) *ServiceAccountHandler {
+	return &ServiceAccountHandler{
+		serviceAccountService: serviceAccountService,
+		logger:                logger,
+	}
+}
+
+// ListServiceAccounts godoc
+// @Summary List service accounts
+// @Description Returns the organization's service accounts by name.
+// @Tags Organizations
+// @Produce json
+// @Success 200 {array} domain.ServiceAccount "Service accounts"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts [get]
+func (h *ServiceAccountHandler) ListServiceAccounts(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	serviceAccounts, err := h.serviceAccountService.ListServiceAccounts(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.handleError(c, "failed to list service accounts", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, serviceAccounts)
+}
+
+// CreateServiceAccount godoc
+// @Summary Create a service account
+// @Description Creates a non-human user of the organization that authenticates with API keys only. It has no password. Its role defaults to member, cannot manage the organization and cannot grant permissions you do not have.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param request body services.CreateServiceAccountRequest true "Service account"
+// @Success 201 {object} domain.ServiceAccount "Service account"
+// @Failure 400 {object} map[string]string "Invalid name, role or owner"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden or role not permitted"
+// @Failure 404 {object} map[string]string "Service accounts disabled"
+// @Failure 409 {object} map[string]string "Name already used"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts [post]
+func (h *ServiceAccountHandler) CreateServiceAccount(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	var req services.CreateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	serviceAccount, err := h.serviceAccountService.CreateServiceAccount(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, reqCtx.Identity, &req)
+	if err != nil {
+		h.handleError(c, "failed to create service account", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, serviceAccount)
+}
+
+// GetServiceAccount godoc
+// @Summary Get a service account
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Service account ID"
+// @Success 200 {object} domain.ServiceAccount "Service account"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Service account not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts/{id} [get]
+func (h *ServiceAccountHandler) GetServiceAccount(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	serviceAccount, err := h.serviceAccountService.GetServiceAccount(c.Request.Context(), reqCtx.OrganizationID, id)
+	if err != nil {
+		h.handleError(c, "failed to get service account", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, serviceAccount)
+}
+
+// UpdateServiceAccount godoc
+// @Summary Update a service account
+// @Description Changes the name, description, owner or role. Omitted fields are left unchanged. A new role applies from the next request.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Service account ID"
+// @Param request body services.UpdateServiceAccountRequest true "Changes"
+// @Success 200 {object} domain.ServiceAccount "Service account"
+// @Failure 400 {object} map[string]string "Invalid ID, name, role or owner"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden or role not permitted"
+// @Failure 404 {object} map[string]string "Service account not found"
+// @Failure 409 {object} map[string]string "Name already used"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts/{id} [put]
+func (h *ServiceAccountHandler) UpdateServiceAccount(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req services.UpdateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	serviceAccount, err := h.serviceAccountService.UpdateServiceAccount(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, reqCtx.Identity, id, &req)
+	if err != nil {
+		h.handleError(c, "failed to update service account", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, serviceAccount)
+}
+
+// DeleteServiceAccount godoc
+// @Summary Delete a service account
+// @Description Deletes the service account with its account and API keys.
+// @Tags Organizations
+// @Param id path int true "Service account ID"
+// @Success 204 "Service account deleted"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Service account not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts/{id} [delete]
+func (h *ServiceAccountHandler) DeleteServiceAccount(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	if err := h.serviceAccountService.DeleteServiceAccount(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id); err != nil {
+		h.handleError(c, "failed to delete service account", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DisableServiceAccount godoc
+// @Summary Disable a service account
+// @Description Rejects the service account's API keys until it is enabled again. Keys are kept.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Service account ID"
+// @Success 200 {object} domain.ServiceAccount "Service account"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Service account not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts/{id}/disable [post]
+func (h *ServiceAccountHandler) DisableServiceAccount(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	serviceAccount, err := h.serviceAccountService.DisableServiceAccount(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id)
+	if err != nil {
+		h.handleError(c, "failed to disable service account", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, serviceAccount)
+}
+
+// EnableServiceAccount godoc
+// @Summary Enable a service account
+// @Description Accepts the service account's unrevoked, unexpired API keys again.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Service account ID"
+// @Success 200 {object} domain.ServiceAccount "Service account"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Service account not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts/{id}/enable [post]
+func (h *ServiceAccountHandler) EnableServiceAccount(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	serviceAccount, err := h.serviceAccountService.EnableServiceAccount(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id)
+	if err != nil {
+		h.handleError(c, "failed to enable service account", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, serviceAccount)
+}
+
+// ListKeys godoc
+// @Summary List API keys of a service account
+// @Description Returns the service account's keys, newest first, including revoked and expired ones. Keys themselves are never returned.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Service account ID"
+// @Success 200 {array} domain.ServiceAccountKey "API keys"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Service account not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts/{id}/keys [get]
+func (h *ServiceAccountHandler) ListKeys(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.serviceAccountService.ListKeys(c.Request.Context(), reqCtx.OrganizationID, id)
+	if err != nil {
+		h.handleError(c, "failed to list api keys", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, keys)
+}
+
+// CreateKey godoc
+// @Summary Create an API key for a service account
+// @Description Creates an API key the service account sends as a Bearer token. The key is only returned in this response. Keys expire after SERVICE_ACCOUNT_KEY_MAX_TTL unless a shorter expiry is requested.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Service account ID"
+// @Param request body services.CreateServiceAccountKeyRequest true "Key name and expiry"
+// @Success 201 {object} services.CreatedServiceAccountKey "API key"
+// @Failure 400 {object} map[string]string "Invalid ID, name or expiry"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Service account not found"
+// @Failure 409 {object} map[string]string "Service account disabled or key limit reached"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts/{id}/keys [post]
+func (h *ServiceAccountHandler) CreateKey(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req services.CreateServiceAccountKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	key, err := h.serviceAccountService.CreateKey(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id, &req)
+	if err != nil {
+		h.handleError(c, "failed to create api key", err)
+		return
+	}
+
+	response.Success(c, http.StatusCreated, key)
+}
+
+// RevokeKey godoc
+// @Summary Revoke an API key of a service account
+// @Tags Organizations
+// @Param id path int true "Service account ID"
+// @Param key_id path int true "API key ID"
+// @Success 204 "API key revoked"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Service account or key not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/service-accounts/{id}/keys/{key_id} [delete]
+func (h *ServiceAccountHandler) RevokeKey(c *gin.Context) {
+	reqCtx, id, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var keyID int32
+	if _, err := fmt.Sscanf(c.Param("key_id"), "%d", &keyID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid key ID format", err)
+		return
+	}
+
+	if _, err := h.serviceAccountService.RevokeKey(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, id, keyID); err != nil {
+		h.handleError(c, "failed to revoke api key", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ServiceAccountHandler) requestContext(c *gin.Context) (*auth.RequestContext, bool) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return nil, false
+	}
+	return reqCtx, true
+}
+
+// target returns the request context and the service account ID in the
+// path. It writes the error response and returns false when either is missing.
+func (h *ServiceAccountHandler) target(c *gin.Context) (*auth.RequestContext, int32, bool) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return nil, 0, false
+	}
+
+	var id int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid service account ID format", err)
+		return nil, 0, false
+	}
+
+	return reqCtx, id, true
+}
+
+func (h *ServiceAccountHandler) handleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrServiceAccountsDisabled),
+		errors.Is(err, domain.ErrServiceAccountNotFound),
+		errors.Is(err, domain.ErrServiceAccountKeyNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrServiceAccountNameRequired),
+		errors.Is(err, domain.ErrServiceAccountInvalidRole),
+		errors.Is(err, domain.ErrServiceAccountInvalidOwner),
+		errors.Is(err, domain.ErrServiceAccountKeyNameRequired),
+		errors.Is(err, domain.ErrServiceAccountKeyInvalidExpiry):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, domain.ErrServiceAccountRoleNotPermitted):
+		response.Error(c, http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, domain.ErrServiceAccountNameTaken),
+		errors.Is(err, domain.ErrServiceAccountDisabled),
+		errors.Is(err, domain.ErrServiceAccountKeyLimit):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,54 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type SessionContextHandler struct {
+	contextService services.SessionContextService
+	logger         logger.Logger
+}
+
+func NewSessionContextHandler(contextService services.SessionContextService, logger logger.Logger) *SessionContextHandler {
+	return &SessionContextHandler{
+		contextService: contextService,
+		logger:         logger,
+	}
+}
+
+// GetContext godoc
+// @Summary Get session context
+// @Description Returns everything a frontend needs about the signed-in user in one call: identity, effective permissions (wildcards and roles resolved), active organization, account, entitlements and feature flags. The organization, account and entitlements are cached for SESSION_CONTEXT_CACHE_TTL.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} services.SessionContext "Session context"
+// @Failure 400 {object} map[string]string "Missing organization context"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /me/context [get]
+func (h *SessionContextHandler) GetContext(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil || reqCtx.Identity == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	sessionContext, err := h.contextService.GetContext(c.Request.Context(), reqCtx)
+	if err != nil {
+		h.logger.Error("failed to get session context", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to get session context", err)
+		return
+	}
+
+	// The context is specific to the caller's token
+	c.Header("Cache-Control", "private, no-store")
+	response.Success(c, http.StatusOK, sessionContext)
+}
@@ -0,0 +1,105 @@
+package organizations
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type StagingHandler struct {
+	stagingService services.StagingService
+	logger         logger.Logger
+}
+
+func NewStagingHandler(stagingService services.StagingService, logger logger.Logger) *StagingHandler {
+	return &StagingHandler{
+		stagingService: stagingService,
+		logger:         logger,
+	}
+}
+
+// ListStagingOrganizations godoc
+// @Summary List staging organizations
+// @Description Returns the staging and sandbox organizations created from the current production organization, newest first.
+// @Tags Organizations
+// @Produce json
+// @Success 200 {array} domain.Organization "Staging organizations"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/staging [get]
+func (h *StagingHandler) ListStagingOrganizations(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	orgs, err := h.stagingService.ListStagingOrganizations(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.logger.Error("failed to list staging organizations", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list staging organizations", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, orgs)
+}
+
+// CreateStagingOrganization godoc
+// @Summary Create staging organization
+// @Description Creates a staging or sandbox organization owned by the current production organization, with the caller as its admin. Its data is excluded from analytics, it uses the parent's subscription without consuming quota, and it is purged automatically when it expires.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param request body services.CreateStagingOrganizationRequest true "Staging organization"
+// @Success 201 {object} domain.Organization "Created organization"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden or staging disabled"
+// @Failure 409 {object} map[string]string "Staging organization limit reached"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/staging [post]
+func (h *StagingHandler) CreateStagingOrganization(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.CreateStagingOrganizationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	org, err := h.stagingService.CreateStagingOrganization(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrStagingOrganizationsDisabled, domain.ErrStagingParentNotProduction:
+			response.Error(c, http.StatusForbidden, err.Error(), err)
+		case domain.ErrStagingLimitReached:
+			response.Error(c, http.StatusConflict, err.Error(), err)
+		case domain.ErrStagingInvalidEnvironment:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		default:
+			h.logger.Error("failed to create staging organization", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to create staging organization", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, org)
+}
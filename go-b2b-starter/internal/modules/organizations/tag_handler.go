@@ -0,0 +1,216 @@
+package organizations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type TagHandler struct {
+	tagService services.TagService
+	logger     logger.Logger
+}
+
+func NewTagHandler(tagService services.TagService, logger logger.Logger) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+		logger:     logger,
+	}
+}
+
+// ListTags godoc
+// @Summary List user tags
+// @Description Returns the organization's user tags by name with how many users carry each.
+// @Tags Organizations
+// @Produce json
+// @Success 200 {array} domain.Tag "Tags"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/tags [get]
+func (h *TagHandler) ListTags(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.tagService.ListTags(c.Request.Context(), reqCtx.OrganizationID)
+	if err != nil {
+		h.handleError(c, reqCtx, 0, "failed to list tags", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, tags)
+}
+
+// DeleteTag godoc
+// @Summary Delete user tag
+// @Description Deletes a tag from the organization and removes it from every user. Feature flags targeted at the tag turn off once cached session contexts expire.
+// @Tags Organizations
+// @Produce json
+// @Param name path string true "Tag name"
+// @Success 204 "Tag deleted"
+// @Failure 400 {object} map[string]string "Invalid tag name"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "Tag not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/tags/{name} [delete]
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return
+	}
+
+	if err := h.tagService.DeleteTag(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, c.Param("name")); err != nil {
+		h.handleError(c, reqCtx, 0, "failed to delete tag", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListUserTags godoc
+// @Summary List a user's tags
+// @Description Returns the names of the tags attached to an account of the organization.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 200 {array} string "Tag names"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/tags [get]
+func (h *TagHandler) ListUserTags(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.tagService.ListUserTags(c.Request.Context(), reqCtx.OrganizationID, accountID)
+	if err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to list user tags", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, tags)
+}
+
+// AddUserTag godoc
+// @Summary Tag a user
+// @Description Attaches a tag to an account and returns the account's tags. Names are lowercased; a tag that does not exist yet is created, up to 100 per organization. Tagging a user again is a no-op.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param request body services.AddUserTagRequest true "Tag to attach (1-50 letters, digits, hyphens or underscores)"
+// @Success 200 {array} string "Tag names"
+// @Failure 400 {object} map[string]string "Invalid ID or tag name"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Tag limit reached"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/tags [post]
+func (h *TagHandler) AddUserTag(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req services.AddUserTagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	tags, err := h.tagService.AddUserTag(c.Request.Context(), reqCtx.OrganizationID, accountID, reqCtx.AccountID, req.Tag)
+	if err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to tag user", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, tags)
+}
+
+// RemoveUserTag godoc
+// @Summary Untag a user
+// @Description Detaches a tag from an account and returns the account's tags. The tag stays in the organization.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param tag path string true "Tag name"
+// @Success 200 {array} string "Tag names"
+// @Failure 400 {object} map[string]string "Invalid ID or tag name"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found or does not have the tag"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/tags/{tag} [delete]
+func (h *TagHandler) RemoveUserTag(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	tags, err := h.tagService.RemoveUserTag(c.Request.Context(), reqCtx.OrganizationID, accountID, reqCtx.AccountID, c.Param("tag"))
+	if err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to untag user", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, tags)
+}
+
+func (h *TagHandler) requestContext(c *gin.Context) (*auth.RequestContext, bool) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return nil, false
+	}
+	return reqCtx, true
+}
+
+// target returns the request context and the account ID in the path. It
+// writes the error response and returns false when either is missing.
+func (h *TagHandler) target(c *gin.Context) (*auth.RequestContext, int32, bool) {
+	reqCtx, ok := h.requestContext(c)
+	if !ok {
+		return nil, 0, false
+	}
+
+	var accountID int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &accountID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid account ID format", err)
+		return nil, 0, false
+	}
+
+	return reqCtx, accountID, true
+}
+
+func (h *TagHandler) handleError(c *gin.Context, reqCtx *auth.RequestContext, accountID int32, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAccountNotFound):
+		response.Error(c, http.StatusNotFound, "user not found", err)
+	case errors.Is(err, domain.ErrTagNotFound), errors.Is(err, domain.ErrAccountTagNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrTagInvalidName):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, domain.ErrTagLimit):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": accountID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
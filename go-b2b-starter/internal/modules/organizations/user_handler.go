@@ -0,0 +1,396 @@
+package organizations
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/paywall"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type UserHandler struct {
+	userService services.UserManagementService
+	logger      logger.Logger
+}
+
+func NewUserHandler(userService services.UserManagementService, logger logger.Logger) *UserHandler {
+	return &UserHandler{
+		userService: userService,
+		logger:      logger,
+	}
+}
+
+// ListUsers godoc
+// @Summary List and search users
+// @Description Returns a page of the organization's accounts, newest first unless sort is given. query matches email and full name and email matches the email only; tag keeps accounts carrying that tag and the other filters narrow the list.
+// @Tags Organizations
+// @Produce json
+// @Param query query string false "Text matched against email and full name"
+// @Param email query string false "Text matched against email"
+// @Param status query string false "Account status" Enums(active, inactive, suspended, dormant)
+// @Param role query string false "Account role"
+// @Param email_verified query bool false "Whether the auth provider reports the email as verified"
+// @Param created_after query string false "Created at or after (RFC 3339)"
+// @Param created_before query string false "Created before (RFC 3339)"
+// @Param tag query string false "Tag the account carries"
+// @Param type query string false "People or service accounts and OAuth clients" Enums(human, service)
+// @Param sort query string false "Order" Enums(created_desc, created_asc, email_asc, email_desc, name_asc, last_login_desc) default(created_desc)
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} services.ListUsersResponse "Users"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users [get]
+func (h *UserHandler) ListUsers(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.ListUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	users, err := h.userService.ListUsers(c.Request.Context(), reqCtx.OrganizationID, &req)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserCreatedRange) || errors.Is(err, domain.ErrTagInvalidName) {
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		h.logger.Error("failed to list users", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list users", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, users)
+}
+
+// GetUser godoc
+// @Summary Get user
+// @Description Returns an account of the organization and, while its email is locked out of sign-in, when the lockout ends.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 200 {object} services.UserDetails "User"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id} [get]
+func (h *UserHandler) GetUser(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.userService.GetUser(c.Request.Context(), reqCtx.OrganizationID, accountID)
+	if err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to get user", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, user)
+}
+
+// SuspendUser godoc
+// @Summary Suspend user
+// @Description Suspends an active account: its sessions are revoked, its requests are rejected until it is reactivated and the member is notified of the reason by email and in the app, unless they turned off account_status notifications. The change is kept in the account's status history. Admins cannot suspend themselves.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param request body services.UserStatusRequest true "Why the account is suspended (required, at most 1000 characters)"
+// @Success 200 {object} domain.Account "Suspended account"
+// @Failure 400 {object} map[string]string "Invalid ID or missing reason"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Account is not active"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/suspend [post]
+func (h *UserHandler) SuspendUser(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req services.UserStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	account, err := h.userService.SuspendUser(c.Request.Context(), reqCtx.OrganizationID, reqCtx.ProviderOrgID, accountID, reqCtx.AccountID, req.Reason)
+	if err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to suspend user", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, account)
+}
+
+// ReactivateUser godoc
+// @Summary Reactivate user
+// @Description Lifts a suspension and notifies the member like a suspension does. The member signs in again to get a new session. The body is optional; a reason is recorded in the status history and included in the notification.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param request body services.UserStatusRequest false "Why the account is reactivated"
+// @Success 200 {object} domain.Account "Reactivated account"
+// @Failure 400 {object} map[string]string "Invalid ID or reason"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 402 {object} map[string]string "Every purchased seat is in use"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Account is not suspended"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/reactivate [post]
+func (h *UserHandler) ReactivateUser(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var req services.UserStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	account, err := h.userService.ReactivateUser(c.Request.Context(), reqCtx.OrganizationID, accountID, reqCtx.AccountID, req.Reason)
+	if err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to reactivate user", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, account)
+}
+
+// GetUserStatusHistory godoc
+// @Summary Get user status history
+// @Description Returns the account's suspensions and reactivations newest first, with who made each change and why. At most 100 changes are returned.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 200 {array} domain.AccountStatusChange "Status changes"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/status-history [get]
+func (h *UserHandler) GetUserStatusHistory(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	changes, err := h.userService.ListStatusHistory(c.Request.Context(), reqCtx.OrganizationID, accountID)
+	if err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to get user status history", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, changes)
+}
+
+// ResetUserPassword godoc
+// @Summary Force password reset
+// @Description Deletes the member's password, revokes their sessions and emails them a link to set a new password.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 204 "Password reset started"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 409 {object} map[string]string "Account has no auth provider member"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/password-reset [post]
+func (h *UserHandler) ResetUserPassword(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.ResetPassword(c.Request.Context(), reqCtx.OrganizationID, reqCtx.ProviderOrgID, accountID, reqCtx.AccountID); err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to reset password", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UnlockUser godoc
+// @Summary Unlock user
+// @Description Lifts the login lockout of the account's email and clears its failed attempts.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 204 "Account unlocked"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/unlock [post]
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.UnlockUser(c.Request.Context(), reqCtx.OrganizationID, accountID, reqCtx.AccountID); err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to unlock user", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// DeleteUser godoc
+// @Summary Delete user
+// @Description Revokes the member's sessions, removes them from the auth provider and deactivates the account. Their data is kept; use offboarding to transfer or delete it. Failed deletions can be retried.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 204 "User deleted"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id} [delete]
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	if err := h.userService.DeleteUser(c.Request.Context(), reqCtx.OrganizationID, reqCtx.ProviderOrgID, accountID, reqCtx.AccountID); err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to delete user", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetUserMetadata godoc
+// @Summary Get user metadata
+// @Description Returns the application-defined attributes of an account as a JSON object.
+// @Tags Organizations
+// @Produce json
+// @Param id path int true "Account ID"
+// @Success 200 {object} map[string]interface{} "Metadata"
+// @Failure 400 {object} map[string]string "Invalid ID"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/metadata [get]
+func (h *UserHandler) GetUserMetadata(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	metadata, err := h.userService.GetUserMetadata(c.Request.Context(), reqCtx.OrganizationID, accountID)
+	if err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to get user metadata", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, metadata)
+}
+
+// PatchUserMetadata godoc
+// @Summary Update user metadata
+// @Description Merges a JSON object into the account's metadata. Top-level keys replace existing values and keys set to null are removed. Keys are 1 to 64 characters and the merged metadata is at most 16 KiB.
+// @Tags Organizations
+// @Accept json
+// @Produce json
+// @Param id path int true "Account ID"
+// @Param request body map[string]interface{} true "Keys to set, or null to remove"
+// @Success 200 {object} map[string]interface{} "Merged metadata"
+// @Failure 400 {object} map[string]string "Invalid ID, key or size"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 403 {object} map[string]string "Forbidden"
+// @Failure 404 {object} map[string]string "User not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /organizations/users/{id}/metadata [patch]
+func (h *UserHandler) PatchUserMetadata(c *gin.Context) {
+	reqCtx, accountID, ok := h.target(c)
+	if !ok {
+		return
+	}
+
+	var patch domain.AccountMetadata
+	if err := c.ShouldBindJSON(&patch); err != nil {
+		response.Error(c, http.StatusBadRequest, "metadata must be a JSON object", err)
+		return
+	}
+
+	metadata, err := h.userService.PatchUserMetadata(c.Request.Context(), reqCtx.OrganizationID, accountID, reqCtx.AccountID, patch)
+	if err != nil {
+		h.handleError(c, reqCtx, accountID, "failed to update user metadata", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, metadata)
+}
+
+// target returns the request context and the account ID in the path. It
+// writes the error response and returns false when either is missing.
+func (h *UserHandler) target(c *gin.Context) (*auth.RequestContext, int32, bool) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return nil, 0, false
+	}
+
+	var accountID int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &accountID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid account ID format", err)
+		return nil, 0, false
+	}
+
+	return reqCtx, accountID, true
+}
+
+func (h *UserHandler) handleError(c *gin.Context, reqCtx *auth.RequestContext, accountID int32, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrAccountNotFound):
+		response.Error(c, http.StatusNotFound, "user not found", err)
+	case errors.Is(err, domain.ErrUserManagementSelf):
+		response.Error(c, http.StatusForbidden, err.Error(), err)
+	case errors.Is(err, domain.ErrUserNotActive), errors.Is(err, domain.ErrUserNotSuspended), errors.Is(err, domain.ErrUserNoAuthMember),
+		errors.Is(err, domain.ErrAccountStatusChanged):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	case errors.Is(err, domain.ErrAccountMetadataInvalidKey), errors.Is(err, domain.ErrAccountMetadataTooLarge),
+		errors.Is(err, domain.ErrUserSuspensionReason), errors.Is(err, domain.ErrUserReasonTooLong):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, paywall.ErrSeatLimitReached):
+		response.Error(c, http.StatusPaymentRequired, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": accountID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
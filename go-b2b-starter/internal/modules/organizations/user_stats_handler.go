@@ -0,0 +1,99 @@
+package organizations
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// UserStatsHandler serves signup and engagement statistics for an internal
+// admin dashboard. It is an operator endpoint behind the "operator"
+// middleware, since the statistics span organizations.
+type UserStatsHandler struct {
+	statsService services.UserStatsService
+	logger       logger.Logger
+}
+
+func NewUserStatsHandler(statsService services.UserStatsService, logger logger.Logger) *UserStatsHandler {
+	return &UserStatsHandler{
+		statsService: statsService,
+		logger:       logger,
+	}
+}
+
+// GetStats godoc
+// @Summary Get user statistics
+// @Description Returns account totals by status, daily, weekly and monthly active users, and the share of accounts with a verified email. Without an organization ID the statistics cover every production organization. Deleted accounts are left out; suspended accounts are the ones locked by an admin.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param organization_id query int false "Organization ID"
+// @Success 200 {object} domain.UserStats "User statistics"
+// @Failure 400 {object} map[string]string "Invalid query parameters"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Organization not found or endpoint disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/user-stats [get]
+func (h *UserStatsHandler) GetStats(c *gin.Context) {
+	var req services.UserStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	stats, err := h.statsService.GetStats(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, "failed to get user stats", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, stats)
+}
+
+// ListSignups godoc
+// @Summary List signups per day
+// @Description Returns the accounts created on each day up to today, oldest first, with how many of them verified their email. Days without signups are included. Without an organization ID the counts cover every production organization.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param organization_id query int false "Organization ID"
+// @Param days query int false "Days up to and including today (default USER_STATS_DEFAULT_DAYS, at most USER_STATS_MAX_DAYS)"
+// @Success 200 {array} domain.DailySignups "Signups per day"
+// @Failure 400 {object} map[string]string "Invalid query parameters or days out of range"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Organization not found or endpoint disabled"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /admin/user-stats/signups [get]
+func (h *UserStatsHandler) ListSignups(c *gin.Context) {
+	var req services.ListSignupsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+
+	signups, err := h.statsService.ListSignups(c.Request.Context(), &req)
+	if err != nil {
+		h.handleError(c, "failed to list signups", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, signups)
+}
+
+func (h *UserStatsHandler) handleError(c *gin.Context, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrUserStatsInvalidDays):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	default:
+		h.logger.Error(message, map[string]interface{}{"error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
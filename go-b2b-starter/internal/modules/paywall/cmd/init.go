@@ -36,7 +36,8 @@ func InitMiddleware(container *dig.Container) error {
 // # Usage
 //
 //	config := &paywall.MiddlewareConfig{
-//	    UpgradeURL: "/settings/billing",
+//	    UpgradeURL:    "/settings/billing",
+//	    AllowTrialing: true,
 //	}
 //	if err := paywallCmd.InitMiddlewareWithConfig(container, config); err != nil {
 //	    panic(err)
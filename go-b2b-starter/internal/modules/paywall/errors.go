@@ -28,6 +28,11 @@ var (
 	// HTTP status: 402 Payment Required
 	ErrPaymentFailed = errors.New("subscription payment failed")
 
+	// ErrSeatLimitReached is returned by SeatGuard when every purchased seat
+	// is in use and the organization has not enabled auto-expand.
+	// HTTP status: 402 Payment Required
+	ErrSeatLimitReached = errors.New("all purchased seats are in use")
+
 	// ErrMissingOrganization is returned when organization ID is not in context.
 	// This means RequireOrganization middleware hasn't run.
 	// HTTP status: 500 Internal Server Error (misconfigured middleware)
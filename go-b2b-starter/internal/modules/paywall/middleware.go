@@ -126,8 +126,9 @@ func (m *Middleware) RequireActiveSubscription() gin.HandlerFunc {
 			// If refresh fails or still inactive, continue with original status
 		}
 
-		// Check if subscription is active (after potential refresh)
-		if !status.IsActive {
+		// Check if subscription is active (after potential refresh);
+		// trials pass unless AllowTrialing is off
+		if !status.IsActive || (status.IsTrialing() && !m.config.AllowTrialing) {
 			response := m.buildErrorResponse(status)
 			m.config.ErrorHandler(c, http.StatusPaymentRequired, response)
 			c.Abort()
@@ -158,6 +159,12 @@ func (m *Middleware) buildErrorResponse(status *SubscriptionStatus) *ErrorRespon
 	case StatusUnpaid:
 		response.Error = "payment_required"
 		response.Message = "Your subscription is unpaid. Please update your payment method."
+	case StatusExpired:
+		response.Error = "trial_expired"
+		response.Message = "Your free trial has ended. Please subscribe to continue."
+	case StatusTrialing:
+		response.Error = "subscription_required"
+		response.Message = "A paid subscription is required to access this feature"
 	default:
 		response.Error = "subscription_inactive"
 		response.Message = "An active subscription is required to access this feature"
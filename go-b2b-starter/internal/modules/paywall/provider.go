@@ -44,7 +44,8 @@ func SetupMiddleware(container *dig.Container) error {
 // # Usage
 //
 //	config := &subscription.MiddlewareConfig{
-//	    UpgradeURL: "/settings/billing",
+//	    UpgradeURL:    "/settings/billing",
+//	    AllowTrialing: true,
 //	}
 //	if err := subscription.SetupMiddlewareWithConfig(container, config); err != nil {
 //	    return err
@@ -0,0 +1,42 @@
+package paywall
+
+import (
+	"context"
+
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+)
+
+// SeatGuard limits organization membership to the seats purchased on a
+// seat-based subscription.
+//
+// The billing module implements this interface; the organizations module
+// checks it before adding a member, so it never depends on billing directly.
+//
+// Implementations should:
+//   - Read from local database only (for speed)
+//   - Return false and nil when the plan is not seat-based
+//   - Return ErrSeatLimitReached when every seat is in use
+type SeatGuard interface {
+	// CheckSeatAvailable reports whether one more member fits in the
+	// organization's purchased seats, and whether membership is limited by
+	// seats at all. Members added at the same time can each pass the check,
+	// so when limited is true the caller adds the member with a write that
+	// counts the seats again under the organization's seat allocation lock.
+	// The organizationID is the database primary key (int32).
+	CheckSeatAvailable(ctx context.Context, organizationID int32) (limited bool, err error)
+}
+
+// SeatLock enforces the seat limit inside the transaction that gives a member
+// a seat, where SeatGuard can only check beforehand.
+//
+// The billing module implements this interface; the organizations
+// repositories call it within their own transaction before adding a member
+// or reactivating one, so the seat count and the write are atomic.
+type SeatLock interface {
+	// CheckSeatsLocked locks the organization's seat allocation until q's
+	// transaction ends and returns ErrSeatLimitReached if every purchased
+	// seat is in use, so members added at the same time are counted one
+	// after the other. Organizations without a seat-based plan, or with
+	// auto-expand on, are never full.
+	CheckSeatsLocked(ctx context.Context, q sqlc.Querier, organizationID int32) error
+}
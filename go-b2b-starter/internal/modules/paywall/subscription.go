@@ -143,7 +143,8 @@ const (
 	StatusPastDue  = "past_due"
 	StatusCanceled = "canceled"
 	StatusUnpaid   = "unpaid"
-	StatusNone     = "none" // No subscription exists
+	StatusExpired  = "expired" // Free trial ended without a subscription
+	StatusNone     = "none"    // No subscription exists
 )
 
 // IsActiveStatus returns true if the given status represents an active subscription.
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	billingDomain "github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	docDomain "github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	orgDomain "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/portability/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// exportPageSize is how many documents are read per query during an export
+const exportPageSize = 100
+
+// ExportService builds organization bundles for import into another instance
+type ExportService interface {
+	// ExportOrganization returns the organization's settings, users and
+	// documents manifest. Deactivated users are left out.
+	ExportOrganization(ctx context.Context, orgID int32) (*domain.Bundle, error)
+}
+
+type exportService struct {
+	orgs            orgDomain.OrganizationRepository
+	accounts        orgDomain.AccountRepository
+	policies        orgDomain.AuthPolicyRepository
+	allowlist       orgDomain.IPAllowlistRepository
+	billingSettings billingDomain.BillingSettingsRepository
+	documents       docDomain.DocumentRepository
+	files           filedomain.FileService
+	logger          loggerDomain.Logger
+}
+
+func NewExportService(
+	orgs orgDomain.OrganizationRepository,
+	accounts orgDomain.AccountRepository,
+	policies orgDomain.AuthPolicyRepository,
+	allowlist orgDomain.IPAllowlistRepository,
+	billingSettings billingDomain.BillingSettingsRepository,
+	documents docDomain.DocumentRepository,
+	files filedomain.FileService,
+	logger loggerDomain.Logger,
+) ExportService {
+	return &exportService{
+		orgs:            orgs,
+		accounts:        accounts,
+		policies:        policies,
+		allowlist:       allowlist,
+		billingSettings: billingSettings,
+		documents:       documents,
+		files:           files,
+		logger:          logger.Named("portability"),
+	}
+}
+
+func (s *exportService) ExportOrganization(ctx context.Context, orgID int32) (*domain.Bundle, error) {
+	org, err := s.orgs.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, orgDomain.ErrOrganizationNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	bundle := &domain.Bundle{
+		Format:     domain.BundleFormat,
+		Version:    domain.BundleVersion,
+		ExportedAt: time.Now().UTC(),
+		Organization: domain.BundleOrganization{
+			Slug: org.Slug,
+			Name: org.Name,
+		},
+		Settings: domain.BundleSettings{
+			IPAllowlist: []domain.BundleIPAllowlistEntry{},
+		},
+		Users:     []domain.BundleUser{},
+		Documents: []domain.BundleDocument{},
+	}
+
+	if err := s.exportSettings(ctx, orgID, &bundle.Settings); err != nil {
+		return nil, err
+	}
+	if err := s.exportUsers(ctx, orgID, bundle); err != nil {
+		return nil, err
+	}
+	if err := s.exportDocuments(ctx, orgID, bundle); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("organization export audit", loggerDomain.Fields{
+		"audit":           true,
+		"event":           "organization_export.created",
+		"organization_id": orgID,
+		"users":           len(bundle.Users),
+		"documents":       len(bundle.Documents),
+	})
+
+	return bundle, nil
+}
+
+func (s *exportService) exportSettings(ctx context.Context, orgID int32, settings *domain.BundleSettings) error {
+	policy, err := s.policies.GetByOrganization(ctx, orgID)
+	switch {
+	case err == nil:
+		settings.AuthPolicy = &domain.BundleAuthPolicy{
+			MFARequired:          policy.MFARequired,
+			SessionMaxAgeSeconds: policy.SessionMaxAgeSeconds,
+			AllowedAuthMethods:   policy.AllowedAuthMethods,
+		}
+	case !errors.Is(err, orgDomain.ErrAuthPolicyNotFound):
+		return err
+	}
+
+	entries, err := s.allowlist.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		settings.IPAllowlist = append(settings.IPAllowlist, domain.BundleIPAllowlistEntry{
+			CIDR:        entry.CIDR,
+			Description: entry.Description,
+		})
+	}
+
+	billing, err := s.billingSettings.GetSettings(ctx, orgID)
+	switch {
+	case err == nil:
+		settings.Billing = &domain.BundleBillingSettings{
+			Currency: billing.Currency,
+			Locale:   billing.Locale,
+		}
+	case !errors.Is(err, billingDomain.ErrBillingSettingsNotFound):
+		return err
+	}
+
+	return nil
+}
+
+func (s *exportService) exportUsers(ctx context.Context, orgID int32, bundle *domain.Bundle) error {
+	accounts, err := s.accounts.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return err
+	}
+
+	for _, account := range accounts {
+		// Inactive accounts belong to deleted users
+		if account.Status == "inactive" {
+			continue
+		}
+
+		role := account.StytchRoleSlug
+		if role == "" {
+			role = account.Role
+		}
+		bundle.Users = append(bundle.Users, domain.BundleUser{
+			Email:    account.Email,
+			FullName: account.FullName,
+			Role:     role,
+			Status:   account.Status,
+		})
+	}
+	return nil
+}
+
+func (s *exportService) exportDocuments(ctx context.Context, orgID int32, bundle *domain.Bundle) error {
+	for offset := int32(0); ; offset += exportPageSize {
+		docs, err := s.documents.List(ctx, orgID, exportPageSize, offset)
+		if err != nil {
+			return err
+		}
+
+		for _, doc := range docs {
+			file, err := s.files.GetFile(ctx, doc.FileAssetID)
+			if err != nil {
+				return fmt.Errorf("failed to get file of document %d: %w", doc.ID, err)
+			}
+			bundle.Documents = append(bundle.Documents, domain.BundleDocument{
+				Title:       doc.Title,
+				FileName:    doc.FileName,
+				ContentType: doc.ContentType,
+				FileSize:    doc.FileSize,
+				StoragePath: file.StoragePath,
+				Metadata:    doc.Metadata,
+			})
+		}
+
+		if len(docs) < exportPageSize {
+			return nil
+		}
+	}
+}
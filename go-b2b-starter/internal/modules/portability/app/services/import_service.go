@@ -0,0 +1,705 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+
+	billingDomain "github.com/moasq/go-b2b-starter/internal/modules/billing/domain"
+	docServices "github.com/moasq/go-b2b-starter/internal/modules/documents/app/services"
+	docDomain "github.com/moasq/go-b2b-starter/internal/modules/documents/domain"
+	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	orgServices "github.com/moasq/go-b2b-starter/internal/modules/organizations/app/services"
+	orgDomain "github.com/moasq/go-b2b-starter/internal/modules/organizations/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/portability/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/pkg/money"
+)
+
+// ImportRequest asks for a bundle to be imported into an existing organization
+type ImportRequest struct {
+	Bundle   *domain.Bundle        `json:"bundle" binding:"required"`
+	Strategy domain.ImportStrategy `json:"strategy" binding:"required"`
+
+	// DryRun only validates the bundle and returns the plan
+	DryRun bool `json:"dry_run"`
+
+	// RequestedBy is the operator or ticket reference recorded on the import
+	RequestedBy string `json:"requested_by"`
+}
+
+// ImportService imports organization bundles exported from another instance
+type ImportService interface {
+	// PlanImport checks the bundle against the organization and returns what
+	// an import would create, update, skip or reject. Nothing is written.
+	PlanImport(ctx context.Context, orgID int32, req *ImportRequest) (*domain.ImportPlan, error)
+
+	// StartImport plans the import and, if the plan is valid, applies it in
+	// the background. It returns ErrImportRejected if the plan is not valid;
+	// PlanImport shows why.
+	StartImport(ctx context.Context, orgID int32, req *ImportRequest) (*domain.OrganizationImport, error)
+
+	GetImport(ctx context.Context, id int32) (*domain.OrganizationImport, error)
+
+	// ListImports returns imports newest first and the total match count.
+	// orgID 0 lists every organization.
+	ListImports(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.OrganizationImport, int64, error)
+}
+
+type importService struct {
+	imports         domain.ImportRepository
+	orgs            orgDomain.OrganizationRepository
+	accounts        orgDomain.AccountRepository
+	policies        orgDomain.AuthPolicyRepository
+	allowlist       orgDomain.IPAllowlistRepository
+	roles           orgDomain.AuthRoleRepository
+	members         orgServices.MemberService
+	userManagement  orgServices.UserManagementService
+	normalizer      orgDomain.EmailNormalizer
+	billingSettings billingDomain.BillingSettingsRepository
+	documentRepo    docDomain.DocumentRepository
+	documents       docServices.DocumentService
+	storage         filedomain.R2Repository
+	config          *PortabilityConfig
+	logger          loggerDomain.Logger
+}
+
+func NewImportService(
+	imports domain.ImportRepository,
+	orgs orgDomain.OrganizationRepository,
+	accounts orgDomain.AccountRepository,
+	policies orgDomain.AuthPolicyRepository,
+	allowlist orgDomain.IPAllowlistRepository,
+	roles orgDomain.AuthRoleRepository,
+	members orgServices.MemberService,
+	userManagement orgServices.UserManagementService,
+	normalizer orgDomain.EmailNormalizer,
+	billingSettings billingDomain.BillingSettingsRepository,
+	documentRepo docDomain.DocumentRepository,
+	documents docServices.DocumentService,
+	storage filedomain.R2Repository,
+	config *PortabilityConfig,
+	logger loggerDomain.Logger,
+) ImportService {
+	return &importService{
+		imports:         imports,
+		orgs:            orgs,
+		accounts:        accounts,
+		policies:        policies,
+		allowlist:       allowlist,
+		roles:           roles,
+		members:         members,
+		userManagement:  userManagement,
+		normalizer:      normalizer,
+		billingSettings: billingSettings,
+		documentRepo:    documentRepo,
+		documents:       documents,
+		storage:         storage,
+		config:          config,
+		logger:          logger.Named("portability"),
+	}
+}
+
+func (s *importService) PlanImport(ctx context.Context, orgID int32, req *ImportRequest) (*domain.ImportPlan, error) {
+	org, err := s.checkRequest(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := s.plan(ctx, org, req)
+	if err != nil {
+		return nil, err
+	}
+	return p.plan, nil
+}
+
+func (s *importService) StartImport(ctx context.Context, orgID int32, req *ImportRequest) (*domain.OrganizationImport, error) {
+	org, err := s.checkRequest(ctx, orgID, req)
+	if err != nil {
+		return nil, err
+	}
+	requestedBy := strings.TrimSpace(req.RequestedBy)
+	if requestedBy == "" {
+		return nil, domain.ErrRequesterRequired
+	}
+
+	// An import that stopped reporting progress was interrupted and would
+	// otherwise block the organization forever
+	if failed, err := s.imports.FailStale(ctx, orgID, time.Now().Add(-s.config.StaleAfter)); err != nil {
+		return nil, err
+	} else if failed > 0 {
+		s.audit("organization_import.interrupted", orgID, loggerDomain.Fields{"imports": failed})
+	}
+
+	p, err := s.plan(ctx, org, req)
+	if err != nil {
+		return nil, err
+	}
+	if !p.plan.Valid {
+		return nil, domain.ErrImportRejected
+	}
+
+	imp, err := s.imports.Create(ctx, &domain.OrganizationImport{
+		OrganizationID:     orgID,
+		SourceOrganization: req.Bundle.Organization.Slug,
+		Strategy:           req.Strategy,
+		RequestedBy:        requestedBy,
+		TotalItems:         p.plan.TotalItems,
+		Sections:           p.initialResults(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.audit("organization_import.started", orgID, loggerDomain.Fields{
+		"import_id":           imp.ID,
+		"source_organization": imp.SourceOrganization,
+		"strategy":            string(imp.Strategy),
+		"requested_by":        requestedBy,
+		"total_items":         imp.TotalItems,
+	})
+
+	// The import outlives the request that started it
+	go s.run(context.WithoutCancel(ctx), imp, p.steps)
+
+	return imp, nil
+}
+
+func (s *importService) GetImport(ctx context.Context, id int32) (*domain.OrganizationImport, error) {
+	return s.imports.GetByID(ctx, id)
+}
+
+func (s *importService) ListImports(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.OrganizationImport, int64, error) {
+	imports, err := s.imports.List(ctx, orgID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := s.imports.Count(ctx, orgID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return imports, total, nil
+}
+
+// checkRequest validates the parts of the request that do not depend on the
+// organization's data and loads the organization
+func (s *importService) checkRequest(ctx context.Context, orgID int32, req *ImportRequest) (*orgDomain.Organization, error) {
+	if !req.Strategy.IsValid() {
+		return nil, domain.ErrInvalidStrategy
+	}
+	if req.Bundle == nil || !req.Bundle.IsSupported() {
+		return nil, domain.ErrUnsupportedBundle
+	}
+
+	org, err := s.orgs.GetByID(ctx, orgID)
+	if err != nil {
+		if errors.Is(err, orgDomain.ErrOrganizationNotFound) {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	return org, nil
+}
+
+// run applies the planned steps, recording progress after each one. A step
+// that fails is recorded and the import carries on with the next.
+func (s *importService) run(ctx context.Context, imp *domain.OrganizationImport, steps []importStep) {
+	defer func() {
+		if r := recover(); r != nil {
+			imp.Status = domain.ImportStatusFailed
+			imp.Error = fmt.Sprintf("import stopped unexpectedly: %v", r)
+			s.complete(ctx, imp)
+		}
+	}()
+
+	for i, step := range steps {
+		result := sectionResult(imp, step.section)
+		if err := step.apply(ctx); err != nil {
+			result.Failed++
+			imp.Failures = append(imp.Failures, domain.ImportIssue{
+				Section: step.section,
+				Item:    step.item,
+				Message: err.Error(),
+			})
+		} else if step.update {
+			result.Updated++
+		} else {
+			result.Created++
+		}
+
+		imp.ProcessedItems = int32(i + 1)
+		if err := s.imports.UpdateProgress(ctx, imp); err != nil {
+			s.logger.Warn("failed to record organization import progress", loggerDomain.Fields{
+				"import_id": imp.ID,
+				"error":     err.Error(),
+			})
+		}
+	}
+
+	imp.Status = domain.ImportStatusCompleted
+	s.complete(ctx, imp)
+}
+
+func (s *importService) complete(ctx context.Context, imp *domain.OrganizationImport) {
+	if _, err := s.imports.Complete(ctx, imp); err != nil {
+		s.logger.Error("failed to complete organization import", loggerDomain.Fields{
+			"import_id": imp.ID,
+			"error":     err.Error(),
+		})
+	}
+
+	s.audit("organization_import."+string(imp.Status), imp.OrganizationID, loggerDomain.Fields{
+		"import_id":       imp.ID,
+		"processed_items": imp.ProcessedItems,
+		"total_items":     imp.TotalItems,
+		"failures":        len(imp.Failures),
+		"error":           imp.Error,
+	})
+}
+
+func (s *importService) audit(event string, orgID int32, fields loggerDomain.Fields) {
+	fields["audit"] = true
+	fields["event"] = event
+	fields["organization_id"] = orgID
+	s.logger.Info("organization import audit", fields)
+}
+
+// sectionResult returns the import's counts for section
+func sectionResult(imp *domain.OrganizationImport, section string) *domain.SectionResult {
+	for i := range imp.Sections {
+		if imp.Sections[i].Section == section {
+			return &imp.Sections[i]
+		}
+	}
+	imp.Sections = append(imp.Sections, domain.SectionResult{Section: section})
+	return &imp.Sections[len(imp.Sections)-1]
+}
+
+// importStep is one bundle item the import creates or updates
+type importStep struct {
+	section string
+	item    string
+	update  bool
+	apply   func(ctx context.Context) error
+}
+
+// importPlanner builds the plan and the steps that carry it out
+type importPlanner struct {
+	plan  *domain.ImportPlan
+	steps []importStep
+}
+
+func newImportPlanner(orgID int32, req *ImportRequest) *importPlanner {
+	plan := &domain.ImportPlan{
+		OrganizationID:     orgID,
+		SourceOrganization: req.Bundle.Organization.Slug,
+		Strategy:           req.Strategy,
+		Sections:           make([]domain.SectionPlan, len(domain.Sections)),
+	}
+	for i, section := range domain.Sections {
+		plan.Sections[i].Section = section
+	}
+	return &importPlanner{plan: plan}
+}
+
+func (p *importPlanner) section(name string) *domain.SectionPlan {
+	for i := range p.plan.Sections {
+		if p.plan.Sections[i].Section == name {
+			return &p.plan.Sections[i]
+		}
+	}
+	panic("unknown bundle section " + name)
+}
+
+// create plans a new item
+func (p *importPlanner) create(section, item string, apply func(ctx context.Context) error) {
+	p.section(section).Create++
+	p.steps = append(p.steps, importStep{section: section, item: item, apply: apply})
+}
+
+// existing plans an item that already exists with different values,
+// according to the strategy
+func (p *importPlanner) existing(section, item string, overwrite func(ctx context.Context) error) {
+	switch p.plan.Strategy {
+	case domain.ImportStrategyOverwrite:
+		p.section(section).Update++
+		p.steps = append(p.steps, importStep{section: section, item: item, update: true, apply: overwrite})
+	case domain.ImportStrategyFail:
+		p.section(section).Conflict++
+		p.issue(section, item, "already exists")
+	default:
+		p.section(section).Skip++
+	}
+}
+
+// unchanged plans an item that already exists with the same values
+func (p *importPlanner) unchanged(section string) {
+	p.section(section).Skip++
+}
+
+// invalid records an item that cannot be imported
+func (p *importPlanner) invalid(section, item string, err error) {
+	p.section(section).Invalid++
+	p.issue(section, item, err.Error())
+}
+
+func (p *importPlanner) issue(section, item, message string) {
+	p.plan.Issues = append(p.plan.Issues, domain.ImportIssue{Section: section, Item: item, Message: message})
+}
+
+// initialResults returns the per-section results before any step ran,
+// with the planned skips already counted
+func (p *importPlanner) initialResults() []domain.SectionResult {
+	results := make([]domain.SectionResult, len(p.plan.Sections))
+	for i, section := range p.plan.Sections {
+		results[i] = domain.SectionResult{Section: section.Section, Skipped: section.Skip}
+	}
+	return results
+}
+
+// plan compares every bundle item with the organization's data
+func (s *importService) plan(ctx context.Context, org *orgDomain.Organization, req *ImportRequest) (*importPlanner, error) {
+	p := newImportPlanner(org.ID, req)
+	bundle := req.Bundle
+
+	if err := s.planAuthPolicy(ctx, p, org.ID, bundle.Settings.AuthPolicy); err != nil {
+		return nil, err
+	}
+	if err := s.planIPAllowlist(ctx, p, org.ID, bundle.Settings.IPAllowlist); err != nil {
+		return nil, err
+	}
+	if err := s.planBillingSettings(ctx, p, org.ID, bundle.Settings.Billing); err != nil {
+		return nil, err
+	}
+	if err := s.planUsers(ctx, p, org, bundle.Users); err != nil {
+		return nil, err
+	}
+	if err := s.planDocuments(ctx, p, org.ID, bundle.Documents); err != nil {
+		return nil, err
+	}
+
+	p.plan.TotalItems = int32(len(p.steps))
+	p.plan.Valid = len(p.plan.Issues) == 0
+	return p, nil
+}
+
+func (s *importService) planAuthPolicy(ctx context.Context, p *importPlanner, orgID int32, policy *domain.BundleAuthPolicy) error {
+	if policy == nil {
+		return nil
+	}
+	const item = "auth_policy"
+
+	req := &orgServices.UpdateAuthPolicyRequest{
+		MFARequired:          policy.MFARequired,
+		SessionMaxAgeSeconds: policy.SessionMaxAgeSeconds,
+		AllowedAuthMethods:   policy.AllowedAuthMethods,
+	}
+	if err := req.Validate(); err != nil {
+		p.invalid(domain.SectionAuthPolicy, item, err)
+		return nil
+	}
+
+	apply := func(ctx context.Context) error {
+		methods := policy.AllowedAuthMethods
+		if methods == nil {
+			methods = []string{}
+		}
+		_, err := s.policies.Upsert(ctx, &orgDomain.AuthPolicy{
+			OrganizationID:       orgID,
+			MFARequired:          policy.MFARequired,
+			SessionMaxAgeSeconds: policy.SessionMaxAgeSeconds,
+			AllowedAuthMethods:   methods,
+		})
+		return err
+	}
+
+	current, err := s.policies.GetByOrganization(ctx, orgID)
+	switch {
+	case errors.Is(err, orgDomain.ErrAuthPolicyNotFound):
+		p.create(domain.SectionAuthPolicy, item, apply)
+	case err != nil:
+		return err
+	case current.MFARequired == policy.MFARequired &&
+		current.SessionMaxAgeSeconds == policy.SessionMaxAgeSeconds &&
+		slices.Equal(current.AllowedAuthMethods, policy.AllowedAuthMethods):
+		p.unchanged(domain.SectionAuthPolicy)
+	default:
+		p.existing(domain.SectionAuthPolicy, item, apply)
+	}
+	return nil
+}
+
+func (s *importService) planIPAllowlist(ctx context.Context, p *importPlanner, orgID int32, entries []domain.BundleIPAllowlistEntry) error {
+	current, err := s.allowlist.ListByOrganization(ctx, orgID)
+	if err != nil {
+		return err
+	}
+	byCIDR := make(map[string]*orgDomain.IPAllowlistEntry, len(current))
+	for _, entry := range current {
+		byCIDR[entry.CIDR] = entry
+	}
+
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		cidr, err := orgServices.NormalizeAllowlistCIDR(entry.CIDR)
+		if err != nil {
+			p.invalid(domain.SectionIPAllowlist, entry.CIDR, err)
+			continue
+		}
+		if seen[cidr] {
+			p.invalid(domain.SectionIPAllowlist, entry.CIDR, errors.New("appears more than once in the bundle"))
+			continue
+		}
+		seen[cidr] = true
+
+		description := strings.TrimSpace(entry.Description)
+		create := func(ctx context.Context) error {
+			_, err := s.allowlist.Create(ctx, &orgDomain.IPAllowlistEntry{
+				OrganizationID: orgID,
+				CIDR:           cidr,
+				Description:    description,
+			})
+			return err
+		}
+
+		existing, ok := byCIDR[cidr]
+		switch {
+		case !ok:
+			p.create(domain.SectionIPAllowlist, cidr, create)
+		case existing.Description == description:
+			p.unchanged(domain.SectionIPAllowlist)
+		default:
+			// Entries have no update, so the description is replaced by
+			// recreating the entry
+			p.existing(domain.SectionIPAllowlist, cidr, func(ctx context.Context) error {
+				if err := s.allowlist.Delete(ctx, orgID, existing.ID); err != nil {
+					return err
+				}
+				return create(ctx)
+			})
+		}
+	}
+	return nil
+}
+
+func (s *importService) planBillingSettings(ctx context.Context, p *importPlanner, orgID int32, billing *domain.BundleBillingSettings) error {
+	if billing == nil {
+		return nil
+	}
+	const item = "billing_settings"
+
+	settings := &billingDomain.BillingSettings{OrganizationID: orgID}
+	if billing.Currency != "" {
+		currency, err := money.NormalizeCurrency(billing.Currency)
+		if err != nil {
+			p.invalid(domain.SectionBillingSettings, item, billingDomain.ErrInvalidCurrency)
+			return nil
+		}
+		settings.Currency = currency
+	}
+	if billing.Locale != "" {
+		locale, err := money.NormalizeLocale(billing.Locale)
+		if err != nil {
+			p.invalid(domain.SectionBillingSettings, item, billingDomain.ErrInvalidLocale)
+			return nil
+		}
+		settings.Locale = locale
+	}
+
+	apply := func(ctx context.Context) error {
+		_, err := s.billingSettings.UpsertSettings(ctx, settings)
+		return err
+	}
+
+	current, err := s.billingSettings.GetSettings(ctx, orgID)
+	switch {
+	case errors.Is(err, billingDomain.ErrBillingSettingsNotFound):
+		p.create(domain.SectionBillingSettings, item, apply)
+	case err != nil:
+		return err
+	case current.Currency == settings.Currency && current.Locale == settings.Locale:
+		p.unchanged(domain.SectionBillingSettings)
+	default:
+		p.existing(domain.SectionBillingSettings, item, apply)
+	}
+	return nil
+}
+
+func (s *importService) planUsers(ctx context.Context, p *importPlanner, org *orgDomain.Organization, users []domain.BundleUser) error {
+	knownRoles := map[string]bool{}
+	seen := make(map[string]bool, len(users))
+
+	for _, user := range users {
+		email := s.normalizer.Normalize(user.Email)
+		name := strings.TrimSpace(user.FullName)
+		role := strings.ToLower(strings.TrimSpace(user.Role))
+		if role == "" {
+			role = "member"
+		}
+
+		if _, err := mail.ParseAddress(email); err != nil {
+			p.invalid(domain.SectionUsers, user.Email, errors.New("invalid email address"))
+			continue
+		}
+		if name == "" {
+			p.invalid(domain.SectionUsers, email, errors.New("full_name is required"))
+			continue
+		}
+		if seen[email] {
+			p.invalid(domain.SectionUsers, email, errors.New("appears more than once in the bundle"))
+			continue
+		}
+		seen[email] = true
+		if org.StytchOrgID == "" {
+			p.invalid(domain.SectionUsers, email, errors.New("organization is not linked to an auth provider organization"))
+			continue
+		}
+
+		known, checked := knownRoles[role]
+		if !checked {
+			_, err := s.roles.GetRoleBySlug(ctx, role)
+			known = err == nil
+			knownRoles[role] = known
+		}
+		if !known {
+			p.invalid(domain.SectionUsers, email, fmt.Errorf("role %q does not exist", role))
+			continue
+		}
+
+		current, err := s.accounts.GetByEmail(ctx, org.ID, email)
+		switch {
+		case errors.Is(err, orgDomain.ErrAccountNotFound):
+			suspended := user.Status == orgDomain.StatusSuspended
+			p.create(domain.SectionUsers, email, func(ctx context.Context) error {
+				return s.createUser(ctx, org, email, name, role, suspended)
+			})
+		case err != nil:
+			return err
+		case current.FullName == name && current.StytchRoleSlug == role:
+			p.unchanged(domain.SectionUsers)
+		default:
+			p.existing(domain.SectionUsers, email, func(ctx context.Context) error {
+				_, err := s.members.UpdateMemberDirect(ctx, &orgServices.UpdateMemberRequest{
+					OrgID:    org.StytchOrgID,
+					Email:    email,
+					Name:     name,
+					RoleSlug: role,
+				})
+				return err
+			})
+		}
+	}
+	return nil
+}
+
+// importSuspensionReason is recorded when a user suspended in the source
+// organization is suspended again after being added
+const importSuspensionReason = "Suspended in the organization the import came from"
+
+// createUser adds the member without an invitation. Users suspended in the
+// source organization are suspended like any other member, so their sessions
+// are revoked and the suspension is audited and frees their seat.
+func (s *importService) createUser(ctx context.Context, org *orgDomain.Organization, email, name, role string, suspended bool) error {
+	if _, err := s.members.AddMemberDirect(ctx, &orgServices.AddMemberRequest{
+		OrgID:    org.StytchOrgID,
+		Email:    email,
+		Name:     name,
+		RoleSlug: role,
+	}); err != nil {
+		return err
+	}
+	if !suspended {
+		return nil
+	}
+
+	account, err := s.accounts.GetByEmail(ctx, org.ID, email)
+	if err != nil {
+		return err
+	}
+	_, err = s.userManagement.SuspendUser(ctx, org.ID, org.StytchOrgID, account.ID, 0, importSuspensionReason)
+	return err
+}
+
+func (s *importService) planDocuments(ctx context.Context, p *importPlanner, orgID int32, documents []domain.BundleDocument) error {
+	current := map[string]*docDomain.Document{}
+	for offset := int32(0); ; offset += exportPageSize {
+		docs, err := s.documentRepo.List(ctx, orgID, exportPageSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			current[documentKey(doc.Title, doc.FileName)] = doc
+		}
+		if len(docs) < exportPageSize {
+			break
+		}
+	}
+
+	seen := make(map[string]bool, len(documents))
+	for _, document := range documents {
+		title := strings.TrimSpace(document.Title)
+		if title == "" || document.FileName == "" || document.StoragePath == "" {
+			p.invalid(domain.SectionDocuments, document.Title, errors.New("title, file_name and storage_path are required"))
+			continue
+		}
+		key := documentKey(title, document.FileName)
+		if seen[key] {
+			p.invalid(domain.SectionDocuments, title, errors.New("appears more than once in the bundle"))
+			continue
+		}
+		seen[key] = true
+
+		// The files are copied to this instance's storage before the import
+		exists, err := s.storage.ObjectExists(ctx, document.StoragePath)
+		if err != nil {
+			return fmt.Errorf("failed to check document file %s: %w", document.StoragePath, err)
+		}
+		if !exists {
+			p.invalid(domain.SectionDocuments, title, fmt.Errorf("file %s is not in storage", document.StoragePath))
+			continue
+		}
+
+		upload := &docServices.UploadDocumentRequest{
+			Title:       title,
+			FileName:    document.FileName,
+			ContentType: document.ContentType,
+			FileSize:    document.FileSize,
+			Metadata:    document.Metadata,
+		}
+
+		existing, ok := current[key]
+		if !ok {
+			p.create(domain.SectionDocuments, title, func(ctx context.Context) error {
+				content, err := s.storage.DownloadObject(ctx, document.StoragePath)
+				if err != nil {
+					return err
+				}
+				defer content.Close()
+				_, err = s.documents.UploadDocument(ctx, orgID, upload, content)
+				return err
+			})
+			continue
+		}
+
+		// Overwriting a document adds the bundle's file as a new version
+		p.existing(domain.SectionDocuments, title, func(ctx context.Context) error {
+			content, err := s.storage.DownloadObject(ctx, document.StoragePath)
+			if err != nil {
+				return err
+			}
+			defer content.Close()
+			_, err = s.documents.UploadDocumentVersion(ctx, orgID, existing.ID, upload, content)
+			return err
+		})
+	}
+	return nil
+}
+
+// documentKey identifies a document by title and file name
+func documentKey(title, fileName string) string {
+	return title + "\x00" + fileName
+}
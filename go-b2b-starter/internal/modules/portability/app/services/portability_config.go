@@ -0,0 +1,63 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// PortabilityConfig configures organization exports and imports.
+//
+// All values can be set via environment variables with the PORTABILITY_ prefix.
+type PortabilityConfig struct {
+	// MaxBundleBytes caps the size of an import request body
+	MaxBundleBytes int64 `mapstructure:"PORTABILITY_MAX_BUNDLE_BYTES"`
+
+	// StaleAfter is how long a running import may go without progress before
+	// it is treated as interrupted (for example by a restart) so the
+	// organization can be imported into again
+	StaleAfter time.Duration `mapstructure:"PORTABILITY_STALE_AFTER"`
+}
+
+// LoadPortabilityConfig loads the portability configuration from environment variables and app.env file.
+func LoadPortabilityConfig() (*PortabilityConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("PORTABILITY_MAX_BUNDLE_BYTES", 32<<20)
+	v.SetDefault("PORTABILITY_STALE_AFTER", "30m")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg PortabilityConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode portability config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the bundle size limit and stale import timeout.
+func (c *PortabilityConfig) Validate() error {
+	if c.MaxBundleBytes < 1 {
+		return fmt.Errorf("portability config invalid: PORTABILITY_MAX_BUNDLE_BYTES must be positive")
+	}
+	if c.StaleAfter < time.Minute {
+		return fmt.Errorf("portability config invalid: PORTABILITY_STALE_AFTER must be at least 1m")
+	}
+	return nil
+}
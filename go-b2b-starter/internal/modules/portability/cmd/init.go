@@ -0,0 +1,12 @@
+package cmd
+
+import (
+	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/portability"
+)
+
+func Init(container *dig.Container) error {
+	module := portability.NewModule(container)
+	return module.RegisterDependencies()
+}
@@ -0,0 +1,99 @@
+package domain
+
+import "time"
+
+// Bundle format identifiers. A bundle with another format or a newer version
+// is rejected rather than partially imported.
+const (
+	BundleFormat  = "go-b2b-starter.organization"
+	BundleVersion = 1
+)
+
+// Bundle sections, in the order they are imported
+const (
+	SectionAuthPolicy      = "auth_policy"
+	SectionIPAllowlist     = "ip_allowlist"
+	SectionBillingSettings = "billing_settings"
+	SectionUsers           = "users"
+	SectionDocuments       = "documents"
+)
+
+// Sections lists the bundle sections in import order
+var Sections = []string{
+	SectionAuthPolicy,
+	SectionIPAllowlist,
+	SectionBillingSettings,
+	SectionUsers,
+	SectionDocuments,
+}
+
+// Bundle is an organization exported from one instance of the starter to be
+// imported into another. Documents are a manifest: their files are copied
+// between object stores separately, under the same storage paths.
+type Bundle struct {
+	Format       string             `json:"format"`
+	Version      int                `json:"version"`
+	ExportedAt   time.Time          `json:"exported_at"`
+	Organization BundleOrganization `json:"organization"`
+	Settings     BundleSettings     `json:"settings"`
+	Users        []BundleUser       `json:"users"`
+	Documents    []BundleDocument   `json:"documents"`
+}
+
+// IsSupported reports whether this instance can import the bundle
+func (b *Bundle) IsSupported() bool {
+	return b.Format == BundleFormat && b.Version >= 1 && b.Version <= BundleVersion
+}
+
+// BundleOrganization identifies the exported organization
+type BundleOrganization struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+}
+
+// BundleSettings are the organization's own settings. Nil settings were not
+// set in the source organization and are left alone on import.
+type BundleSettings struct {
+	AuthPolicy  *BundleAuthPolicy        `json:"auth_policy,omitempty"`
+	IPAllowlist []BundleIPAllowlistEntry `json:"ip_allowlist"`
+	Billing     *BundleBillingSettings   `json:"billing,omitempty"`
+}
+
+// BundleAuthPolicy is the organization's session requirements for its members
+type BundleAuthPolicy struct {
+	MFARequired          bool     `json:"mfa_required"`
+	SessionMaxAgeSeconds int32    `json:"session_max_age_seconds"`
+	AllowedAuthMethods   []string `json:"allowed_auth_methods"`
+}
+
+// BundleIPAllowlistEntry is a network allowed to access the API
+type BundleIPAllowlistEntry struct {
+	CIDR        string `json:"cidr"`
+	Description string `json:"description"`
+}
+
+// BundleBillingSettings are the organization's currency and locale preferences
+type BundleBillingSettings struct {
+	Currency string `json:"currency"`
+	Locale   string `json:"locale"`
+}
+
+// BundleUser is a member of the organization. Users are matched by email.
+type BundleUser struct {
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+	// Role is the auth provider role slug
+	Role   string `json:"role"`
+	Status string `json:"status"`
+}
+
+// BundleDocument is a document's current version. Documents are matched by
+// title and file name.
+type BundleDocument struct {
+	Title       string                 `json:"title"`
+	FileName    string                 `json:"file_name"`
+	ContentType string                 `json:"content_type"`
+	FileSize    int64                  `json:"file_size"`
+	StoragePath string                 `json:"storage_path"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+}
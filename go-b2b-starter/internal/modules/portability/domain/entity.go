@@ -0,0 +1,105 @@
+package domain
+
+import "time"
+
+// ImportStrategy decides what happens to bundle items that already exist in
+// the target organization
+type ImportStrategy string
+
+const (
+	// ImportStrategySkip keeps the existing item
+	ImportStrategySkip ImportStrategy = "skip"
+
+	// ImportStrategyOverwrite replaces the existing item with the bundle's
+	ImportStrategyOverwrite ImportStrategy = "overwrite"
+
+	// ImportStrategyFail rejects the whole import if any item exists
+	ImportStrategyFail ImportStrategy = "fail"
+)
+
+// IsValid reports whether the strategy is known
+func (s ImportStrategy) IsValid() bool {
+	return s == ImportStrategySkip || s == ImportStrategyOverwrite || s == ImportStrategyFail
+}
+
+// ImportStatus is the progress of an import
+type ImportStatus string
+
+const (
+	ImportStatusRunning   ImportStatus = "running"
+	ImportStatusCompleted ImportStatus = "completed"
+
+	// ImportStatusFailed means the import stopped before processing every
+	// item; Error says why
+	ImportStatusFailed ImportStatus = "failed"
+)
+
+// ImportPlan is what an import would do, computed before anything is
+// written. Dry runs return it as is.
+type ImportPlan struct {
+	OrganizationID     int32          `json:"organization_id"`
+	SourceOrganization string         `json:"source_organization"`
+	Strategy           ImportStrategy `json:"strategy"`
+
+	// Valid is false when the import would be rejected: the bundle has
+	// invalid items, or items conflict under the fail strategy
+	Valid bool `json:"valid"`
+
+	// TotalItems is the number of items that would be created or updated
+	TotalItems int32         `json:"total_items"`
+	Sections   []SectionPlan `json:"sections"`
+	Issues     []ImportIssue `json:"issues,omitempty"`
+}
+
+// SectionPlan counts what an import would do with one bundle section
+type SectionPlan struct {
+	Section string `json:"section"`
+	Create  int32  `json:"create"`
+	Update  int32  `json:"update"`
+	Skip    int32  `json:"skip"`
+
+	// Conflict counts existing items under the fail strategy
+	Conflict int32 `json:"conflict"`
+	Invalid  int32 `json:"invalid"`
+}
+
+// ImportIssue is a bundle item that is invalid, conflicts or failed to import
+type ImportIssue struct {
+	Section string `json:"section"`
+	// Item identifies the item: email, title, CIDR or setting name
+	Item    string `json:"item"`
+	Message string `json:"message"`
+}
+
+// OrganizationImport is one import of a bundle into an organization and its
+// progress
+type OrganizationImport struct {
+	ID                 int32           `json:"id"`
+	OrganizationID     int32           `json:"organization_id"`
+	SourceOrganization string          `json:"source_organization"`
+	Strategy           ImportStrategy  `json:"strategy"`
+	RequestedBy        string          `json:"requested_by"`
+	Status             ImportStatus    `json:"status"`
+	TotalItems         int32           `json:"total_items"`
+	ProcessedItems     int32           `json:"processed_items"`
+	Sections           []SectionResult `json:"sections"`
+
+	// Failures lists items that could not be imported; the rest of the
+	// import carries on without them
+	Failures []ImportIssue `json:"failures,omitempty"`
+	Error    string        `json:"error,omitempty"`
+
+	StartedAt   time.Time  `json:"started_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+// SectionResult counts what an import did with one bundle section
+type SectionResult struct {
+	Section string `json:"section"`
+	Created int32  `json:"created"`
+	Updated int32  `json:"updated"`
+	Skipped int32  `json:"skipped"`
+	Failed  int32  `json:"failed"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import "errors"
+
+// Domain errors for organization exports and imports
+var (
+	// Not found errors
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrImportNotFound       = errors.New("organization import not found")
+
+	// Validation errors
+	ErrInvalidStrategy   = errors.New("strategy must be skip, overwrite or fail")
+	ErrRequesterRequired = errors.New("requested_by is required")
+	ErrUnsupportedBundle = errors.New("bundle format or version is not supported")
+	ErrImportRejected    = errors.New("bundle has invalid or conflicting items")
+
+	// State errors
+	ErrImportRunning = errors.New("organization already has an import running")
+)
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ImportRepository stores organization imports and their progress
+type ImportRepository interface {
+	// Create returns ErrImportRunning if the organization has an import running
+	Create(ctx context.Context, imp *OrganizationImport) (*OrganizationImport, error)
+	GetByID(ctx context.Context, id int32) (*OrganizationImport, error)
+
+	// List returns imports newest first. orgID 0 lists every organization.
+	List(ctx context.Context, orgID int32, limit, offset int32) ([]*OrganizationImport, error)
+	Count(ctx context.Context, orgID int32) (int64, error)
+
+	// UpdateProgress stores the processed item count and results of a running import
+	UpdateProgress(ctx context.Context, imp *OrganizationImport) error
+
+	// Complete stores the final status, results and error of an import
+	Complete(ctx context.Context, imp *OrganizationImport) (*OrganizationImport, error)
+
+	// FailStale marks the organization's running imports without progress
+	// since before as failed, and returns how many there were
+	FailStale(ctx context.Context, orgID int32, before time.Time) (int64, error)
+}
@@ -0,0 +1,243 @@
+package portability
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/portability/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/portability/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	listingshared "github.com/moasq/go-b2b-starter/pkg/pagination"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// ListImportsParams filters and pages the organization imports
+type ListImportsParams struct {
+	OrganizationID int32 `form:"organization_id"`
+	listingshared.ListableParams
+}
+
+// Handler serves organization exports and imports. It is an operator endpoint
+// behind the "operator" middleware, since moving a tenant between
+// instances is done by staff rather than by the organization's members.
+type Handler struct {
+	export         services.ExportService
+	imports        services.ImportService
+	maxBundleBytes int64
+	logger         logger.Logger
+}
+
+func NewHandler(export services.ExportService, imports services.ImportService, cfg *services.PortabilityConfig, logger logger.Logger) *Handler {
+	return &Handler{
+		export:         export,
+		imports:        imports,
+		maxBundleBytes: cfg.MaxBundleBytes,
+		logger:         logger,
+	}
+}
+
+// ExportOrganization godoc
+// @Summary Export an organization
+// @Description Downloads a versioned bundle of the organization's settings, users and documents manifest. Document files stay in object storage and are referenced by their storage paths.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param org_id path int true "Organization ID"
+// @Success 200 {file} file "organization-{slug}-export.json"
+// @Failure 400 {object} map[string]string "Invalid organization ID"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Organization not found or endpoint disabled"
+// @Router /admin/portability/organizations/{org_id}/export [get]
+func (h *Handler) ExportOrganization(c *gin.Context) {
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+
+	bundle, err := h.export.ExportOrganization(c.Request.Context(), orgID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrganizationNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		h.logger.Error("organization export failed", logger.Fields{
+			"organization_id": orgID,
+			"error":           err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, "failed to export organization", err)
+		return
+	}
+
+	body, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to encode organization export", err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="organization-%s-export.json"`, bundle.Organization.Slug))
+	c.Data(http.StatusOK, "application/json", body)
+}
+
+// ImportOrganization godoc
+// @Summary Import an organization bundle
+// @Description Imports an exported bundle into an existing organization. Items that already exist are skipped, overwritten or rejected according to the strategy. With dry_run the plan is returned and nothing is changed; otherwise the import runs in the background and its progress is available under /admin/portability/imports/{id}.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param org_id path int true "Target organization ID"
+// @Param request body services.ImportRequest true "Bundle, strategy and requester"
+// @Success 200 {object} domain.ImportPlan "Dry run plan"
+// @Success 202 {object} domain.OrganizationImport "Started import"
+// @Failure 400 {object} map[string]string "Invalid request, strategy or bundle"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Organization not found or endpoint disabled"
+// @Failure 409 {object} map[string]string "Organization already has an import running"
+// @Failure 413 {object} map[string]string "Bundle exceeds PORTABILITY_MAX_BUNDLE_BYTES"
+// @Failure 422 {object} map[string]string "Bundle has invalid or conflicting items"
+// @Router /admin/portability/organizations/{org_id}/imports [post]
+func (h *Handler) ImportOrganization(c *gin.Context) {
+	orgID, ok := h.organizationID(c)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxBundleBytes)
+
+	var req services.ImportRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			response.Error(c, http.StatusRequestEntityTooLarge, "bundle is too large", err)
+			return
+		}
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	if req.DryRun {
+		plan, err := h.imports.PlanImport(c.Request.Context(), orgID, &req)
+		if err != nil {
+			h.handleImportError(c, orgID, "failed to plan organization import", err)
+			return
+		}
+
+		response.Success(c, http.StatusOK, plan)
+		return
+	}
+
+	imp, err := h.imports.StartImport(c.Request.Context(), orgID, &req)
+	if err != nil {
+		h.handleImportError(c, orgID, "failed to start organization import", err)
+		return
+	}
+
+	response.Success(c, http.StatusAccepted, imp)
+}
+
+// ListImports godoc
+// @Summary List organization imports
+// @Description Returns organization imports newest first, optionally for one organization ID.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param organization_id query int false "Organization ID"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 10, max 100)"
+// @Success 200 {object} listingshared.PagePagination[domain.OrganizationImport] "Imports"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Router /admin/portability/imports [get]
+func (h *Handler) ListImports(c *gin.Context) {
+	var params ListImportsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+	if err := params.Validate(); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	offset, err := listingshared.PageToOffset(params.Page, params.Limit)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	imports, total, err := h.imports.ListImports(c.Request.Context(), params.OrganizationID, int32(params.Limit), int32(offset))
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to list organization imports", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, listingshared.NewPagePagination(int(total), params.Page, params.Limit, imports))
+}
+
+// GetImport godoc
+// @Summary Get an organization import
+// @Description Returns one organization import with its progress, per-section results and failed items.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param id path int true "Import ID"
+// @Success 200 {object} domain.OrganizationImport "Import"
+// @Failure 400 {object} map[string]string "Invalid import ID"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Import not found or endpoint disabled"
+// @Router /admin/portability/imports/{id} [get]
+func (h *Handler) GetImport(c *gin.Context) {
+	var id int32
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid import id", err)
+		return
+	}
+
+	imp, err := h.imports.GetImport(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrImportNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to get organization import", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, imp)
+}
+
+// organizationID resolves the :org_id path parameter and writes the error response if needed
+func (h *Handler) organizationID(c *gin.Context) (int32, bool) {
+	var orgID int32
+	if _, err := fmt.Sscanf(c.Param("org_id"), "%d", &orgID); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid organization id", err)
+		return 0, false
+	}
+	return orgID, true
+}
+
+func (h *Handler) handleImportError(c *gin.Context, orgID int32, message string, err error) {
+	switch {
+	case errors.Is(err, domain.ErrOrganizationNotFound):
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	case errors.Is(err, domain.ErrInvalidStrategy),
+		errors.Is(err, domain.ErrRequesterRequired),
+		errors.Is(err, domain.ErrUnsupportedBundle):
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+	case errors.Is(err, domain.ErrImportRejected):
+		response.Error(c, http.StatusUnprocessableEntity, err.Error()+"; run with dry_run to see the issues", err)
+	case errors.Is(err, domain.ErrImportRunning):
+		response.Error(c, http.StatusConflict, err.Error(), err)
+	default:
+		h.logger.Error(message, logger.Fields{
+			"organization_id": orgID,
+			"error":           err.Error(),
+		})
+		response.Error(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,196 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/portability/domain"
+)
+
+// importResult is the JSONB payload of portability.organization_imports.result
+type importResult struct {
+	Sections []domain.SectionResult `json:"sections"`
+	Failures []domain.ImportIssue   `json:"failures,omitempty"`
+}
+
+// importRepository implements domain.ImportRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type importRepository struct {
+	store sqlc.Store
+}
+
+// NewImportRepository creates a new ImportRepository implementation.
+func NewImportRepository(store sqlc.Store) domain.ImportRepository {
+	return &importRepository{store: store}
+}
+
+func (r *importRepository) Create(ctx context.Context, imp *domain.OrganizationImport) (*domain.OrganizationImport, error) {
+	result, err := encodeResult(imp)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := r.store.CreateOrganizationImport(ctx, sqlc.CreateOrganizationImportParams{
+		OrganizationID:     imp.OrganizationID,
+		SourceOrganization: imp.SourceOrganization,
+		Strategy:           string(imp.Strategy),
+		RequestedBy:        imp.RequestedBy,
+		TotalItems:         imp.TotalItems,
+		Result:             result,
+	})
+	if err != nil {
+		if sqlc.ErrorCode(err) == sqlc.UniqueViolation {
+			return nil, domain.ErrImportRunning
+		}
+		return nil, fmt.Errorf("failed to create organization import: %w", err)
+	}
+
+	return r.mapToDomain(&created)
+}
+
+func (r *importRepository) GetByID(ctx context.Context, id int32) (*domain.OrganizationImport, error) {
+	result, err := r.store.GetOrganizationImport(ctx, id)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrImportNotFound
+		}
+		return nil, fmt.Errorf("failed to get organization import: %w", err)
+	}
+
+	return r.mapToDomain(&result)
+}
+
+func (r *importRepository) List(ctx context.Context, orgID int32, limit, offset int32) ([]*domain.OrganizationImport, error) {
+	results, err := r.store.ListOrganizationImports(ctx, sqlc.ListOrganizationImportsParams{
+		OrganizationID: optionalID(orgID),
+		RowLimit:       limit,
+		RowOffset:      offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list organization imports: %w", err)
+	}
+
+	imports := make([]*domain.OrganizationImport, len(results))
+	for i := range results {
+		imp, err := r.mapToDomain(&results[i])
+		if err != nil {
+			return nil, err
+		}
+		imports[i] = imp
+	}
+	return imports, nil
+}
+
+func (r *importRepository) Count(ctx context.Context, orgID int32) (int64, error) {
+	count, err := r.store.CountOrganizationImports(ctx, optionalID(orgID))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count organization imports: %w", err)
+	}
+	return count, nil
+}
+
+func (r *importRepository) UpdateProgress(ctx context.Context, imp *domain.OrganizationImport) error {
+	result, err := encodeResult(imp)
+	if err != nil {
+		return err
+	}
+
+	if err := r.store.UpdateOrganizationImportProgress(ctx, sqlc.UpdateOrganizationImportProgressParams{
+		ID:             imp.ID,
+		ProcessedItems: imp.ProcessedItems,
+		Result:         result,
+	}); err != nil {
+		return fmt.Errorf("failed to update organization import progress: %w", err)
+	}
+	return nil
+}
+
+func (r *importRepository) Complete(ctx context.Context, imp *domain.OrganizationImport) (*domain.OrganizationImport, error) {
+	result, err := encodeResult(imp)
+	if err != nil {
+		return nil, err
+	}
+
+	completed, err := r.store.CompleteOrganizationImport(ctx, sqlc.CompleteOrganizationImportParams{
+		ID:             imp.ID,
+		Status:         string(imp.Status),
+		ProcessedItems: imp.ProcessedItems,
+		Result:         result,
+		Error:          helpers.ToPgText(imp.Error),
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrImportNotFound
+		}
+		return nil, fmt.Errorf("failed to complete organization import: %w", err)
+	}
+
+	return r.mapToDomain(&completed)
+}
+
+func (r *importRepository) FailStale(ctx context.Context, orgID int32, before time.Time) (int64, error) {
+	failed, err := r.store.FailStaleOrganizationImports(ctx, sqlc.FailStaleOrganizationImportsParams{
+		OrganizationID: orgID,
+		StaleBefore:    pgtype.Timestamp{Time: before, Valid: true},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail stale organization imports: %w", err)
+	}
+	return failed, nil
+}
+
+func (r *importRepository) mapToDomain(imp *sqlc.PortabilityOrganizationImport) (*domain.OrganizationImport, error) {
+	var result importResult
+	if err := json.Unmarshal(imp.Result, &result); err != nil {
+		return nil, fmt.Errorf("failed to decode organization import %d result: %w", imp.ID, err)
+	}
+
+	mapped := &domain.OrganizationImport{
+		ID:                 imp.ID,
+		OrganizationID:     imp.OrganizationID,
+		SourceOrganization: imp.SourceOrganization,
+		Strategy:           domain.ImportStrategy(imp.Strategy),
+		RequestedBy:        imp.RequestedBy,
+		Status:             domain.ImportStatus(imp.Status),
+		TotalItems:         imp.TotalItems,
+		ProcessedItems:     imp.ProcessedItems,
+		Sections:           result.Sections,
+		Failures:           result.Failures,
+		Error:              imp.Error.String,
+		StartedAt:          imp.StartedAt.Time,
+		UpdatedAt:          imp.UpdatedAt.Time,
+		CreatedAt:          imp.CreatedAt.Time,
+	}
+	if imp.CompletedAt.Valid {
+		completedAt := imp.CompletedAt.Time
+		mapped.CompletedAt = &completedAt
+	}
+
+	return mapped, nil
+}
+
+func encodeResult(imp *domain.OrganizationImport) ([]byte, error) {
+	result, err := json.Marshal(importResult{
+		Sections: imp.Sections,
+		Failures: imp.Failures,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode organization import result: %w", err)
+	}
+	return result, nil
+}
+
+// optionalID maps a zero organization ID (no filter) to NULL
+func optionalID(id int32) pgtype.Int4 {
+	if id == 0 {
+		return pgtype.Int4{Valid: false}
+	}
+	return helpers.ToPgInt4(id)
+}
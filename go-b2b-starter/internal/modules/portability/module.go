@@ -0,0 +1,39 @@
+package portability
+
+import (
+	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/portability/app/services"
+)
+
+// Module provides portability module dependencies
+type Module struct {
+	container *dig.Container
+}
+
+func NewModule(container *dig.Container) *Module {
+	return &Module{
+		container: container,
+	}
+}
+
+// RegisterDependencies registers all portability module dependencies
+// Note: Repository implementations are registered in internal/db/inject.go
+func (m *Module) RegisterDependencies() error {
+	// Register portability config
+	if err := m.container.Provide(services.LoadPortabilityConfig); err != nil {
+		return err
+	}
+
+	// Register export service
+	if err := m.container.Provide(services.NewExportService); err != nil {
+		return err
+	}
+
+	// Register import service
+	if err := m.container.Provide(services.NewImportService); err != nil {
+		return err
+	}
+
+	return nil
+}
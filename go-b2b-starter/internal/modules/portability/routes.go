@@ -0,0 +1,41 @@
+package portability
+
+import (
+	"github.com/gin-gonic/gin"
+
+	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
+)
+
+type Routes struct {
+	handler *Handler
+}
+
+func NewRoutes(handler *Handler) *Routes {
+	return &Routes{
+		handler: handler,
+	}
+}
+
+func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	// Operator endpoints - X-Admin-Token instead of organization auth
+	adminGroup := router.Group("/admin/portability")
+	adminGroup.Use(resolver.Get("operator"))
+	{
+		// GET /api/admin/portability/organizations/{org_id}/export
+		adminGroup.GET("/organizations/:org_id/export", r.handler.ExportOrganization)
+
+		// POST /api/admin/portability/organizations/{org_id}/imports
+		adminGroup.POST("/organizations/:org_id/imports", r.handler.ImportOrganization)
+
+		// GET /api/admin/portability/imports
+		adminGroup.GET("/imports", r.handler.ListImports)
+
+		// GET /api/admin/portability/imports/{id}
+		adminGroup.GET("/imports/:id", r.handler.GetImport)
+	}
+}
+
+// Routes returns a RouteRegistrar function compatible with the server interface
+func (r *Routes) Routes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	r.RegisterRoutes(router, resolver)
+}
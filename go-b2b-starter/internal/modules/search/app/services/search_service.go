@@ -0,0 +1,167 @@
+package services
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/search/domain"
+)
+
+const (
+	minQueryLength = 2
+	maxQueryLength = 100
+
+	// DefaultSearchLimit and MaxSearchLimit bound the number of merged results
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 50
+)
+
+// SearchService federates search across users, documents and conversations
+// for command-palette style lookups.
+type SearchService interface {
+	// Search runs the query against every requested type the identity may see
+	// and returns the hits merged by relevance
+	Search(ctx context.Context, orgID, accountID int32, identity *auth.Identity, req *SearchRequest) (*SearchResponse, error)
+}
+
+// SearchRequest represents a global search request
+type SearchRequest struct {
+	Query string `json:"query"`
+
+	// Types restricts the search; empty means every type
+	Types []domain.ResultType `json:"types,omitempty"`
+
+	Limit int32 `json:"limit"`
+}
+
+// SearchResponse holds the merged results and the types that were searched
+type SearchResponse struct {
+	Query   string              `json:"query"`
+	Types   []domain.ResultType `json:"types"`
+	Results []*domain.Result    `json:"results"`
+	Total   int                 `json:"total"`
+}
+
+type searchService struct {
+	repo domain.SearchRepository
+}
+
+func NewSearchService(repo domain.SearchRepository) SearchService {
+	return &searchService{
+		repo: repo,
+	}
+}
+
+func (s *searchService) Search(ctx context.Context, orgID, accountID int32, identity *auth.Identity, req *SearchRequest) (*SearchResponse, error) {
+	query := strings.TrimSpace(req.Query)
+	if utf8.RuneCountInString(query) < minQueryLength {
+		return nil, domain.ErrQueryTooShort
+	}
+	if utf8.RuneCountInString(query) > maxQueryLength {
+		return nil, domain.ErrQueryTooLong
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit > MaxSearchLimit {
+		limit = MaxSearchLimit
+	}
+
+	requested := req.Types
+	if len(requested) == 0 {
+		requested = domain.AllResultTypes
+	}
+	for _, t := range requested {
+		if !t.IsValid() {
+			return nil, domain.ErrInvalidResultType
+		}
+	}
+
+	// Types the caller may not see are skipped rather than rejected, so a
+	// palette can always ask for everything
+	types := make([]domain.ResultType, 0, len(requested))
+	for _, t := range domain.AllResultTypes {
+		if containsType(requested, t) && canSearch(identity, t) {
+			types = append(types, t)
+		}
+	}
+
+	results := make([]*domain.Result, 0)
+	for _, t := range types {
+		var hits []*domain.Result
+		var err error
+		switch t {
+		case domain.ResultTypeUser:
+			hits, err = s.repo.SearchUsers(ctx, orgID, query, limit)
+		case domain.ResultTypeDocument:
+			hits, err = s.repo.SearchDocuments(ctx, orgID, query, limit)
+		case domain.ResultTypeConversation:
+			hits, err = s.repo.SearchConversations(ctx, orgID, accountID, query, limit)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, normalizeScores(hits)...)
+	}
+
+	// Merge by normalized score; ties keep the type priority order
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	if len(results) > int(limit) {
+		results = results[:limit]
+	}
+
+	return &SearchResponse{
+		Query:   query,
+		Types:   types,
+		Results: results,
+		Total:   len(results),
+	}, nil
+}
+
+// canSearch reports whether the identity may see results of the given type.
+// Listing members is an admin capability; documents and conversations need
+// resource:view, and conversations are limited to the caller's own.
+func canSearch(identity *auth.Identity, t domain.ResultType) bool {
+	switch t {
+	case domain.ResultTypeUser:
+		return identity.HasResourcePermission("org", "manage")
+	case domain.ResultTypeDocument, domain.ResultTypeConversation:
+		return identity.HasResourcePermission("resource", "view")
+	}
+	return false
+}
+
+// normalizeScores scales raw ranks so the best hit of each type scores 1.
+// Ranks are not comparable across types (full-text rank vs. match quality),
+// so normalizing lets the top hits of every type surface together.
+func normalizeScores(results []*domain.Result) []*domain.Result {
+	var best float64
+	for _, r := range results {
+		if r.Rank > best {
+			best = r.Rank
+		}
+	}
+	for _, r := range results {
+		r.Score = 1
+		if best > 0 {
+			r.Score = r.Rank / best
+		}
+	}
+	return results
+}
+
+func containsType(types []domain.ResultType, t domain.ResultType) bool {
+	for _, candidate := range types {
+		if candidate == t {
+			return true
+		}
+	}
+	return false
+}
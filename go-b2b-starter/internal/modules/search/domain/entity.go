@@ -0,0 +1,41 @@
+package domain
+
+// ResultType identifies what kind of record a search result points to
+type ResultType string
+
+const (
+	ResultTypeUser         ResultType = "user"
+	ResultTypeDocument     ResultType = "document"
+	ResultTypeConversation ResultType = "conversation"
+)
+
+// AllResultTypes lists every searchable type in display priority order
+var AllResultTypes = []ResultType{
+	ResultTypeUser,
+	ResultTypeDocument,
+	ResultTypeConversation,
+}
+
+// IsValid checks if the result type is valid
+func (t ResultType) IsValid() bool {
+	switch t {
+	case ResultTypeUser, ResultTypeDocument, ResultTypeConversation:
+		return true
+	}
+	return false
+}
+
+// Result is a single type-tagged search hit
+type Result struct {
+	Type     ResultType `json:"type"`
+	ID       int32      `json:"id"`
+	Title    string     `json:"title"`
+	Subtitle string     `json:"subtitle,omitempty"`
+	Snippet  string     `json:"snippet,omitempty"`
+
+	// Score is the relevance within the result's type, normalized to (0, 1]
+	Score float64 `json:"score"`
+
+	// Rank is the raw relevance reported by the store
+	Rank float64 `json:"-"`
+}
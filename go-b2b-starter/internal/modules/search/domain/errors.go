@@ -0,0 +1,10 @@
+package domain
+
+import "errors"
+
+// Domain errors for search
+var (
+	ErrQueryTooShort     = errors.New("search query must be at least 2 characters")
+	ErrQueryTooLong      = errors.New("search query must be at most 100 characters")
+	ErrInvalidResultType = errors.New("invalid search result type")
+)
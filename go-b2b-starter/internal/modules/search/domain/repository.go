@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// SearchRepository runs the per-type search queries
+type SearchRepository interface {
+	// SearchUsers matches active accounts of an organization by name or email
+	SearchUsers(ctx context.Context, orgID int32, query string, limit int32) ([]*Result, error)
+
+	// SearchDocuments matches documents by full text and title
+	SearchDocuments(ctx context.Context, orgID int32, query string, limit int32) ([]*Result, error)
+
+	// SearchConversations matches the account's own chat sessions by title
+	SearchConversations(ctx context.Context, orgID, accountID int32, query string, limit int32) ([]*Result, error)
+}
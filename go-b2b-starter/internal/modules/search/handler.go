@@ -0,0 +1,82 @@
+package search
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/search/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/search/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+type Handler struct {
+	service services.SearchService
+	logger  logger.Logger
+}
+
+func NewHandler(service services.SearchService, logger logger.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// Search godoc
+// @Summary Global search
+// @Description Searches users (admins only), documents (full text) and the caller's conversations, returning type-tagged results merged by relevance. Types the caller may not see are skipped.
+// @Tags Search
+// @Produce json
+// @Param q query string true "Search text (2-100 characters)"
+// @Param types query string false "Comma-separated result types: user, document, conversation"
+// @Param limit query int false "Maximum results (default 20, max 50)"
+// @Success 200 {object} services.SearchResponse "Search results"
+// @Failure 400 {object} map[string]string "Invalid query"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /search [get]
+func (h *Handler) Search(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	req := &services.SearchRequest{
+		Query: c.Query("q"),
+	}
+
+	if types := c.Query("types"); types != "" {
+		for _, t := range strings.Split(types, ",") {
+			req.Types = append(req.Types, domain.ResultType(strings.TrimSpace(t)))
+		}
+	}
+
+	if limitStr := c.Query("limit"); limitStr != "" {
+		limit, err := strconv.ParseInt(limitStr, 10, 32)
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "invalid limit", err)
+			return
+		}
+		req.Limit = int32(limit)
+	}
+
+	result, err := h.service.Search(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, reqCtx.Identity, req)
+	if err != nil {
+		switch err {
+		case domain.ErrQueryTooShort, domain.ErrQueryTooLong, domain.ErrInvalidResultType:
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		default:
+			h.logger.Error("failed to search", map[string]interface{}{"org_id": reqCtx.OrganizationID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to search", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusOK, result)
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/search/domain"
+)
+
+// likeEscaper escapes LIKE wildcards so the query is matched literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// searchRepository implements domain.SearchRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type searchRepository struct {
+	store sqlc.Store
+}
+
+// NewSearchRepository creates a new SearchRepository implementation.
+func NewSearchRepository(store sqlc.Store) domain.SearchRepository {
+	return &searchRepository{store: store}
+}
+
+func (r *searchRepository) SearchUsers(ctx context.Context, orgID int32, query string, limit int32) ([]*domain.Result, error) {
+	rows, err := r.store.SearchAccounts(ctx, sqlc.SearchAccountsParams{
+		Query:          query,
+		Pattern:        likeEscaper.Replace(query),
+		OrganizationID: orgID,
+		ResultLimit:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search users: %w", err)
+	}
+
+	results := make([]*domain.Result, len(rows))
+	for i, row := range rows {
+		results[i] = &domain.Result{
+			Type:     domain.ResultTypeUser,
+			ID:       row.ID,
+			Title:    row.FullName,
+			Subtitle: row.Email,
+			Rank:     row.Rank,
+		}
+	}
+	return results, nil
+}
+
+func (r *searchRepository) SearchDocuments(ctx context.Context, orgID int32, query string, limit int32) ([]*domain.Result, error) {
+	rows, err := r.store.SearchDocuments(ctx, sqlc.SearchDocumentsParams{
+		Query:          query,
+		Pattern:        likeEscaper.Replace(query),
+		OrganizationID: orgID,
+		ResultLimit:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search documents: %w", err)
+	}
+
+	results := make([]*domain.Result, len(rows))
+	for i, row := range rows {
+		results[i] = &domain.Result{
+			Type:     domain.ResultTypeDocument,
+			ID:       row.ID,
+			Title:    row.Title,
+			Subtitle: row.FileName,
+			Snippet:  row.Snippet,
+			Rank:     row.Rank,
+		}
+	}
+	return results, nil
+}
+
+func (r *searchRepository) SearchConversations(ctx context.Context, orgID, accountID int32, query string, limit int32) ([]*domain.Result, error) {
+	rows, err := r.store.SearchChatSessions(ctx, sqlc.SearchChatSessionsParams{
+		Query:          query,
+		Pattern:        likeEscaper.Replace(query),
+		OrganizationID: orgID,
+		AccountID:      accountID,
+		ResultLimit:    limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search conversations: %w", err)
+	}
+
+	results := make([]*domain.Result, len(rows))
+	for i, row := range rows {
+		results[i] = &domain.Result{
+			Type:  domain.ResultTypeConversation,
+			ID:    row.ID,
+			Title: helpers.FromPgText(row.Title),
+			Rank:  row.Rank,
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,34 @@
+package search
+
+import (
+	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/search/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/search/domain"
+)
+
+// Module provides search module dependencies
+type Module struct {
+	container *dig.Container
+}
+
+func NewModule(container *dig.Container) *Module {
+	return &Module{
+		container: container,
+	}
+}
+
+// RegisterDependencies registers all search module dependencies
+// Note: Repository implementations are registered in internal/db/inject.go
+func (m *Module) RegisterDependencies() error {
+	// Register search service
+	if err := m.container.Provide(func(
+		repo domain.SearchRepository,
+	) services.SearchService {
+		return services.NewSearchService(repo)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package search
+
+import (
+	"github.com/gin-gonic/gin"
+
+	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
+)
+
+type Routes struct {
+	handler *Handler
+}
+
+func NewRoutes(handler *Handler) *Routes {
+	return &Routes{
+		handler: handler,
+	}
+}
+
+func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	// Per-type permissions are applied by the search service
+	router.GET("/search",
+		resolver.Get("auth"),
+		resolver.Get("org_context"),
+		resolver.Get("subscription"),
+		r.handler.Search)
+}
+
+// Routes returns a RouteRegistrar function compatible with the server interface
+func (r *Routes) Routes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	r.RegisterRoutes(router, resolver)
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"fmt"
+	"net/mail"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// SupportPolicy configures ticket submission and notifications.
+//
+// All values can be set via environment variables with the SUPPORT_ prefix.
+type SupportPolicy struct {
+	// InboxEmail receives a notification for every new ticket
+	InboxEmail string `mapstructure:"SUPPORT_INBOX_EMAIL"`
+
+	// AnonymousEnabled lets visitors submit tickets without signing in
+	AnonymousEnabled bool `mapstructure:"SUPPORT_ANONYMOUS_ENABLED"`
+
+	// AnonymousLimit is how many tickets one client IP may submit anonymously per AnonymousWindow
+	AnonymousLimit int64 `mapstructure:"SUPPORT_ANONYMOUS_LIMIT"`
+
+	// AnonymousWindow is the counting window for AnonymousLimit
+	AnonymousWindow time.Duration `mapstructure:"SUPPORT_ANONYMOUS_WINDOW"`
+
+	// MaxAttachments is the maximum number of files per ticket
+	MaxAttachments int `mapstructure:"SUPPORT_MAX_ATTACHMENTS"`
+}
+
+// LoadSupportPolicy loads the support policy from environment variables and app.env file.
+func LoadSupportPolicy() (*SupportPolicy, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("SUPPORT_INBOX_EMAIL", "")
+	v.SetDefault("SUPPORT_ANONYMOUS_ENABLED", true)
+	v.SetDefault("SUPPORT_ANONYMOUS_LIMIT", 5)
+	v.SetDefault("SUPPORT_ANONYMOUS_WINDOW", "1h")
+	v.SetDefault("SUPPORT_MAX_ATTACHMENTS", 3)
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var policy SupportPolicy
+	if err := v.Unmarshal(&policy); err != nil {
+		return nil, fmt.Errorf("unable to decode support policy: %w", err)
+	}
+
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// Validate checks the inbox address and the anonymous submission limits.
+func (p *SupportPolicy) Validate() error {
+	if p.InboxEmail != "" {
+		if _, err := mail.ParseAddress(p.InboxEmail); err != nil {
+			return fmt.Errorf("support policy invalid: SUPPORT_INBOX_EMAIL must be a valid address: %w", err)
+		}
+	}
+	if p.AnonymousEnabled && (p.AnonymousLimit <= 0 || p.AnonymousWindow <= 0) {
+		return fmt.Errorf("support policy invalid: SUPPORT_ANONYMOUS_LIMIT and SUPPORT_ANONYMOUS_WINDOW must be positive")
+	}
+	if p.MaxAttachments < 0 {
+		return fmt.Errorf("support policy invalid: SUPPORT_MAX_ATTACHMENTS must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,310 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	filemanager "github.com/moasq/go-b2b-starter/internal/modules/files"
+	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/support/domain"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+const (
+	// supportRateLimitKeyPattern counts anonymous submissions per client IP
+	supportRateLimitKeyPattern = "support:ratelimit:ip:%s"
+
+	// ticketAccessTokenBytes is the entropy of the status access token
+	ticketAccessTokenBytes = 32
+
+	// notificationTimeout bounds sending the inbox notification
+	notificationTimeout = 30 * time.Second
+)
+
+// TicketService handles support tickets from signed-in members and anonymous visitors.
+type TicketService interface {
+	// SubmitTicket stores a ticket with its attachments and notifies the support inbox
+	SubmitTicket(ctx context.Context, req *SubmitTicketRequest, attachments []*AttachmentUpload) (*SubmittedTicket, error)
+
+	// ListTickets lists the tickets an account submitted
+	ListTickets(ctx context.Context, orgID, accountID int32, limit, offset int32) ([]*domain.Ticket, error)
+
+	// GetTicket returns a ticket the account submitted
+	GetTicket(ctx context.Context, orgID, accountID int32, reference string) (*domain.Ticket, error)
+
+	// GetTicketStatus returns a ticket for the holder of its access token
+	GetTicketStatus(ctx context.Context, reference, accessToken string) (*domain.Ticket, error)
+}
+
+// SubmitTicketRequest represents a support ticket submission.
+// OrganizationID and AccountID are zero for anonymous visitors.
+type SubmitTicketRequest struct {
+	Name           string `form:"name" binding:"max=255"`
+	Email          string `form:"email" binding:"omitempty,email,max=255"`
+	Subject        string `form:"subject" binding:"required,min=3,max=200"`
+	Message        string `form:"message" binding:"required,min=10,max=10000"`
+	OrganizationID int32  `form:"-"`
+	AccountID      int32  `form:"-"`
+	ClientIP       string `form:"-"`
+}
+
+// AttachmentUpload is a file submitted with a ticket
+type AttachmentUpload struct {
+	FileName    string
+	ContentType string
+	Size        int64
+	Content     io.Reader
+}
+
+// SubmittedTicket is returned when a ticket is created. The access token is
+// only returned here and lets the requester check the status without signing in.
+type SubmittedTicket struct {
+	Reference   string              `json:"reference"`
+	AccessToken string              `json:"access_token"`
+	Status      domain.TicketStatus `json:"status"`
+	CreatedAt   time.Time           `json:"created_at"`
+}
+
+type ticketService struct {
+	repo        domain.TicketRepository
+	fileService filedomain.FileService
+	sender      emailDomain.Sender
+	redis       redis.Client
+	policy      *SupportPolicy
+	logger      loggerDomain.Logger
+}
+
+func NewTicketService(
+	repo domain.TicketRepository,
+	fileService filedomain.FileService,
+	sender emailDomain.Sender,
+	redisClient redis.Client,
+	policy *SupportPolicy,
+	logger loggerDomain.Logger,
+) TicketService {
+	return &ticketService{
+		repo:        repo,
+		fileService: fileService,
+		sender:      sender,
+		redis:       redisClient,
+		policy:      policy,
+		logger:      logger,
+	}
+}
+
+func (s *ticketService) SubmitTicket(ctx context.Context, req *SubmitTicketRequest, attachments []*AttachmentUpload) (*SubmittedTicket, error) {
+	anonymous := req.AccountID == 0
+	if anonymous {
+		if !s.policy.AnonymousEnabled {
+			return nil, domain.ErrAnonymousTicketsDisabled
+		}
+		if strings.TrimSpace(req.Email) == "" {
+			return nil, domain.ErrEmailRequired
+		}
+		if err := s.checkRateLimit(ctx, req.ClientIP); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(attachments) > s.policy.MaxAttachments {
+		return nil, domain.ErrTooManyAttachments
+	}
+
+	// Upload attachments first so a rejected file fails the submission
+	uploaded := make([]*filedomain.FileAsset, 0, len(attachments))
+	for _, attachment := range attachments {
+		asset, err := s.fileService.UploadFile(ctx, &filedomain.FileUploadRequest{
+			Filename:    attachment.FileName,
+			Size:        attachment.Size,
+			ContentType: attachment.ContentType,
+			Context:     filemanager.ContextSupport,
+		}, attachment.Content)
+		if err != nil {
+			s.deleteFiles(uploaded)
+			return nil, fmt.Errorf("%w: %s: %v", domain.ErrAttachmentRejected, attachment.FileName, err)
+		}
+		uploaded = append(uploaded, asset)
+	}
+
+	token, tokenHash, err := generateAccessToken()
+	if err != nil {
+		s.deleteFiles(uploaded)
+		return nil, err
+	}
+
+	ticket, err := s.repo.Create(ctx, &domain.Ticket{
+		Reference:       uuid.New().String(),
+		OrganizationID:  req.OrganizationID,
+		AccountID:       req.AccountID,
+		Name:            strings.TrimSpace(req.Name),
+		Email:           strings.TrimSpace(req.Email),
+		Subject:         strings.TrimSpace(req.Subject),
+		Message:         req.Message,
+		AccessTokenHash: tokenHash,
+	})
+	if err != nil {
+		s.deleteFiles(uploaded)
+		return nil, err
+	}
+
+	for i, asset := range uploaded {
+		if err := s.repo.AddAttachment(ctx, ticket.ID, asset.ID); err != nil {
+			// The ticket is kept; unlinked files are cleaned up
+			s.deleteFiles(uploaded[i:])
+			s.logger.Error("failed to attach file to support ticket", loggerDomain.Fields{
+				"ticket_reference": ticket.Reference,
+				"file_asset_id":    asset.ID,
+				"error":            err.Error(),
+			})
+			break
+		}
+		ticket.Attachments = append(ticket.Attachments, &domain.Attachment{
+			FileAssetID: asset.ID,
+			FileName:    asset.OriginalFilename,
+			FileSize:    asset.Size,
+			ContentType: asset.ContentType,
+		})
+	}
+
+	s.notifyInbox(ticket)
+
+	s.logger.Info("support ticket submitted", loggerDomain.Fields{
+		"ticket_reference": ticket.Reference,
+		"organization_id":  ticket.OrganizationID,
+		"account_id":       ticket.AccountID,
+		"anonymous":        anonymous,
+		"attachments":      len(ticket.Attachments),
+	})
+
+	return &SubmittedTicket{
+		Reference:   ticket.Reference,
+		AccessToken: token,
+		Status:      ticket.Status,
+		CreatedAt:   ticket.CreatedAt,
+	}, nil
+}
+
+func (s *ticketService) ListTickets(ctx context.Context, orgID, accountID int32, limit, offset int32) ([]*domain.Ticket, error) {
+	return s.repo.ListByAccount(ctx, orgID, accountID, limit, offset)
+}
+
+func (s *ticketService) GetTicket(ctx context.Context, orgID, accountID int32, reference string) (*domain.Ticket, error) {
+	ticket, err := s.repo.GetByReference(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	if ticket.OrganizationID != orgID || ticket.AccountID != accountID {
+		return nil, domain.ErrTicketNotFound
+	}
+	return ticket, nil
+}
+
+func (s *ticketService) GetTicketStatus(ctx context.Context, reference, accessToken string) (*domain.Ticket, error) {
+	ticket, err := s.repo.GetByReference(ctx, reference)
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashAccessToken(accessToken)), []byte(ticket.AccessTokenHash)) != 1 {
+		return nil, domain.ErrTicketNotFound
+	}
+	return ticket, nil
+}
+
+// checkRateLimit counts an anonymous submission against the client IP.
+func (s *ticketService) checkRateLimit(ctx context.Context, clientIP string) error {
+	count, _, err := s.redis.Incr(ctx, fmt.Sprintf(supportRateLimitKeyPattern, clientIP), s.policy.AnonymousWindow)
+	if err != nil {
+		return fmt.Errorf("failed to count support tickets: %w", err)
+	}
+	if count > s.policy.AnonymousLimit {
+		return domain.ErrTicketRateLimited
+	}
+	return nil
+}
+
+// notifyInbox emails the support inbox in the background.
+// A failed notification is logged; the ticket is already stored.
+func (s *ticketService) notifyInbox(ticket *domain.Ticket) {
+	if s.policy.InboxEmail == "" {
+		return
+	}
+
+	msg := &emailDomain.Message{
+		To:      []string{s.policy.InboxEmail},
+		ReplyTo: ticket.Email,
+		Subject: fmt.Sprintf("[Support %s] %s", ticket.Reference, ticket.Subject),
+		Body:    renderNotification(ticket),
+	}
+
+	go func() {
+		// Don't use request context as it will be cancelled when request completes
+		sendCtx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+		defer cancel()
+
+		if err := s.sender.Send(sendCtx, msg); err != nil {
+			s.logger.Error("failed to notify support inbox", loggerDomain.Fields{
+				"ticket_reference": ticket.Reference,
+				"error":            err.Error(),
+			})
+		}
+	}()
+}
+
+// deleteFiles removes uploaded attachments that could not be linked to a ticket.
+func (s *ticketService) deleteFiles(assets []*filedomain.FileAsset) {
+	for _, asset := range assets {
+		if err := s.fileService.DeleteFile(context.Background(), asset.ID); err != nil {
+			s.logger.Error("failed to delete support attachment", loggerDomain.Fields{
+				"file_asset_id": asset.ID,
+				"error":         err.Error(),
+			})
+		}
+	}
+}
+
+func renderNotification(ticket *domain.Ticket) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Reference: %s\n", ticket.Reference)
+	fmt.Fprintf(&b, "From: %s <%s>\n", ticket.Name, ticket.Email)
+	if ticket.IsAnonymous() {
+		b.WriteString("Account: anonymous visitor\n")
+	} else {
+		fmt.Fprintf(&b, "Account: %d (organization %d)\n", ticket.AccountID, ticket.OrganizationID)
+	}
+	fmt.Fprintf(&b, "Subject: %s\n\n%s\n", ticket.Subject, ticket.Message)
+
+	if len(ticket.Attachments) > 0 {
+		b.WriteString("\nAttachments:\n")
+		for _, attachment := range ticket.Attachments {
+			fmt.Fprintf(&b, "- %s (%d bytes, file %d)\n", attachment.FileName, attachment.FileSize, attachment.FileAssetID)
+		}
+	}
+	return b.String()
+}
+
+// generateAccessToken returns a random status access token and its stored hash.
+func generateAccessToken() (string, string, error) {
+	buf := make([]byte, ticketAccessTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate ticket access token: %w", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashAccessToken(token), nil
+}
+
+func hashAccessToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
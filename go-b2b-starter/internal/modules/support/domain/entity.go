@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// TicketStatus is the lifecycle state of a support ticket
+type TicketStatus string
+
+const (
+	TicketStatusOpen       TicketStatus = "open"
+	TicketStatusInProgress TicketStatus = "in_progress"
+	TicketStatusResolved   TicketStatus = "resolved"
+	TicketStatusClosed     TicketStatus = "closed"
+)
+
+// Ticket is a support or contact request.
+// OrganizationID and AccountID are zero for tickets from anonymous visitors.
+type Ticket struct {
+	ID              int32         `json:"-"`
+	Reference       string        `json:"reference"`
+	OrganizationID  int32         `json:"organization_id,omitempty"`
+	AccountID       int32         `json:"account_id,omitempty"`
+	Name            string        `json:"name,omitempty"`
+	Email           string        `json:"email"`
+	Subject         string        `json:"subject"`
+	Message         string        `json:"message"`
+	Status          TicketStatus  `json:"status"`
+	AccessTokenHash string        `json:"-"`
+	Attachments     []*Attachment `json:"attachments"`
+	CreatedAt       time.Time     `json:"created_at"`
+	UpdatedAt       time.Time     `json:"updated_at"`
+}
+
+// IsAnonymous reports whether the ticket was submitted without signing in
+func (t *Ticket) IsAnonymous() bool {
+	return t.AccountID == 0
+}
+
+// Attachment is a file attached to a ticket, stored by the files module
+type Attachment struct {
+	FileAssetID int32  `json:"file_asset_id"`
+	FileName    string `json:"file_name"`
+	FileSize    int64  `json:"file_size"`
+	ContentType string `json:"content_type"`
+}
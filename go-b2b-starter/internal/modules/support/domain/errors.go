@@ -0,0 +1,16 @@
+package domain
+
+import "errors"
+
+// Domain errors for support tickets
+var (
+	// Not found errors
+	ErrTicketNotFound = errors.New("support ticket not found")
+
+	// Submission errors
+	ErrEmailRequired            = errors.New("email is required for anonymous support tickets")
+	ErrAnonymousTicketsDisabled = errors.New("anonymous support tickets are disabled")
+	ErrTicketRateLimited        = errors.New("too many support tickets, please try again later")
+	ErrTooManyAttachments       = errors.New("too many attachments")
+	ErrAttachmentRejected       = errors.New("attachment rejected")
+)
@@ -0,0 +1,18 @@
+package domain
+
+import "context"
+
+// TicketRepository defines the interface for support ticket persistence
+type TicketRepository interface {
+	// Create stores a new ticket
+	Create(ctx context.Context, ticket *Ticket) (*Ticket, error)
+
+	// AddAttachment links a stored file to a ticket
+	AddAttachment(ctx context.Context, ticketID, fileAssetID int32) error
+
+	// GetByReference returns a ticket with its attachments
+	GetByReference(ctx context.Context, reference string) (*Ticket, error)
+
+	// ListByAccount lists the tickets an account submitted, newest first
+	ListByAccount(ctx context.Context, orgID, accountID int32, limit, offset int32) ([]*Ticket, error)
+}
@@ -0,0 +1,241 @@
+package support
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/auth"
+	"github.com/moasq/go-b2b-starter/internal/modules/support/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/support/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/logger"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// TicketTokenHeader carries the access token for anonymous status lookups
+const TicketTokenHeader = "X-Ticket-Token"
+
+type Handler struct {
+	service services.TicketService
+	logger  logger.Logger
+}
+
+func NewHandler(service services.TicketService, logger logger.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// SubmitTicket godoc
+// @Summary Submit support ticket
+// @Description Submits a support ticket as the signed-in member. The email defaults to the member's email. Attachments are stored by the files module.
+// @Tags Support
+// @Accept multipart/form-data
+// @Produce json
+// @Param subject formData string true "Subject"
+// @Param message formData string true "Message"
+// @Param name formData string false "Name"
+// @Param email formData string false "Reply-to email"
+// @Param attachments formData file false "Attachments (PDF, JPG or PNG)"
+// @Success 201 {object} services.SubmittedTicket "Ticket submitted"
+// @Failure 400 {object} map[string]string "Invalid request or attachment"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /support/tickets [post]
+func (h *Handler) SubmitTicket(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	var req services.SubmitTicketRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+	req.OrganizationID = reqCtx.OrganizationID
+	req.AccountID = reqCtx.AccountID
+	if req.Email == "" {
+		req.Email = reqCtx.Identity.Email
+	}
+
+	h.submit(c, &req)
+}
+
+// SubmitPublicTicket godoc
+// @Summary Submit support ticket anonymously
+// @Description Contact form for visitors who are not signed in. Submissions are rate limited per client IP. Keep the returned access token to check the ticket status.
+// @Tags Support
+// @Accept multipart/form-data
+// @Produce json
+// @Param subject formData string true "Subject"
+// @Param message formData string true "Message"
+// @Param email formData string true "Reply-to email"
+// @Param name formData string false "Name"
+// @Param attachments formData file false "Attachments (PDF, JPG or PNG)"
+// @Success 201 {object} services.SubmittedTicket "Ticket submitted"
+// @Failure 400 {object} map[string]string "Invalid request or attachment"
+// @Failure 404 {object} map[string]string "Anonymous tickets are disabled"
+// @Failure 429 {object} map[string]string "Too many tickets"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /support/public/tickets [post]
+func (h *Handler) SubmitPublicTicket(c *gin.Context) {
+	var req services.SubmitTicketRequest
+	if err := c.ShouldBind(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+	req.ClientIP = c.ClientIP()
+
+	h.submit(c, &req)
+}
+
+func (h *Handler) submit(c *gin.Context, req *services.SubmitTicketRequest) {
+	var files []*multipart.FileHeader
+	if form, err := c.MultipartForm(); err == nil {
+		files = form.File["attachments"]
+	}
+
+	attachments := make([]*services.AttachmentUpload, 0, len(files))
+	for _, header := range files {
+		file, err := header.Open()
+		if err != nil {
+			response.Error(c, http.StatusBadRequest, "failed to read attachment", err)
+			return
+		}
+		defer file.Close()
+
+		attachments = append(attachments, &services.AttachmentUpload{
+			FileName:    header.Filename,
+			ContentType: header.Header.Get("Content-Type"),
+			Size:        header.Size,
+			Content:     file,
+		})
+	}
+
+	ticket, err := h.service.SubmitTicket(c.Request.Context(), req, attachments)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrAnonymousTicketsDisabled):
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+		case errors.Is(err, domain.ErrTicketRateLimited):
+			response.Error(c, http.StatusTooManyRequests, err.Error(), err)
+		case errors.Is(err, domain.ErrEmailRequired),
+			errors.Is(err, domain.ErrTooManyAttachments),
+			errors.Is(err, domain.ErrAttachmentRejected):
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+		default:
+			h.logger.Error("failed to submit support ticket", map[string]interface{}{"org_id": req.OrganizationID, "account_id": req.AccountID, "error": err.Error()})
+			response.Error(c, http.StatusInternalServerError, "failed to submit support ticket", err)
+		}
+		return
+	}
+
+	response.Success(c, http.StatusCreated, ticket)
+}
+
+// ListTickets godoc
+// @Summary List my support tickets
+// @Description Lists the support tickets the signed-in member submitted, newest first
+// @Tags Support
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {array} domain.Ticket "Tickets"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /support/tickets [get]
+func (h *Handler) ListTickets(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		response.Error(c, http.StatusBadRequest, "limit must be between 1 and 100", err)
+		return
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		response.Error(c, http.StatusBadRequest, "offset must not be negative", err)
+		return
+	}
+
+	tickets, err := h.service.ListTickets(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, int32(limit), int32(offset))
+	if err != nil {
+		h.logger.Error("failed to list support tickets", map[string]interface{}{"org_id": reqCtx.OrganizationID, "account_id": reqCtx.AccountID, "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to list support tickets", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, tickets)
+}
+
+// GetTicket godoc
+// @Summary Get my support ticket
+// @Description Returns a support ticket the signed-in member submitted, including its status and attachments
+// @Tags Support
+// @Produce json
+// @Param reference path string true "Ticket reference"
+// @Success 200 {object} domain.Ticket "Ticket"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Ticket not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /support/tickets/{reference} [get]
+func (h *Handler) GetTicket(c *gin.Context) {
+	reqCtx := auth.GetRequestContext(c)
+	if reqCtx == nil {
+		h.logger.Error("missing request context", nil)
+		response.Error(c, http.StatusBadRequest, "organization context is required", nil)
+		return
+	}
+
+	ticket, err := h.service.GetTicket(c.Request.Context(), reqCtx.OrganizationID, reqCtx.AccountID, c.Param("reference"))
+	if err != nil {
+		h.respondTicketError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, ticket)
+}
+
+// GetPublicTicketStatus godoc
+// @Summary Get support ticket status
+// @Description Returns a support ticket for the holder of the access token returned at submission
+// @Tags Support
+// @Produce json
+// @Param reference path string true "Ticket reference"
+// @Param X-Ticket-Token header string true "Ticket access token"
+// @Success 200 {object} domain.Ticket "Ticket"
+// @Failure 404 {object} map[string]string "Ticket not found"
+// @Failure 500 {object} map[string]string "Internal error"
+// @Router /support/public/tickets/{reference} [get]
+func (h *Handler) GetPublicTicketStatus(c *gin.Context) {
+	ticket, err := h.service.GetTicketStatus(c.Request.Context(), c.Param("reference"), c.GetHeader(TicketTokenHeader))
+	if err != nil {
+		h.respondTicketError(c, err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, ticket)
+}
+
+func (h *Handler) respondTicketError(c *gin.Context, err error) {
+	switch err {
+	case domain.ErrTicketNotFound:
+		response.Error(c, http.StatusNotFound, err.Error(), err)
+	default:
+		h.logger.Error("failed to get support ticket", map[string]interface{}{"reference": c.Param("reference"), "error": err.Error()})
+		response.Error(c, http.StatusInternalServerError, "failed to get support ticket", err)
+	}
+}
@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/support/domain"
+)
+
+// ticketRepository implements domain.TicketRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type ticketRepository struct {
+	store sqlc.Store
+}
+
+// NewTicketRepository creates a new TicketRepository implementation.
+func NewTicketRepository(store sqlc.Store) domain.TicketRepository {
+	return &ticketRepository{store: store}
+}
+
+func (r *ticketRepository) Create(ctx context.Context, ticket *domain.Ticket) (*domain.Ticket, error) {
+	params := sqlc.CreateSupportTicketParams{
+		Reference:       ticket.Reference,
+		OrganizationID:  optionalID(ticket.OrganizationID),
+		AccountID:       optionalID(ticket.AccountID),
+		Name:            ticket.Name,
+		Email:           ticket.Email,
+		Subject:         ticket.Subject,
+		Message:         ticket.Message,
+		AccessTokenHash: ticket.AccessTokenHash,
+	}
+
+	result, err := r.store.CreateSupportTicket(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create support ticket: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *ticketRepository) AddAttachment(ctx context.Context, ticketID, fileAssetID int32) error {
+	params := sqlc.AddSupportTicketAttachmentParams{
+		TicketID:    ticketID,
+		FileAssetID: fileAssetID,
+	}
+
+	if err := r.store.AddSupportTicketAttachment(ctx, params); err != nil {
+		return fmt.Errorf("failed to add support ticket attachment: %w", err)
+	}
+	return nil
+}
+
+func (r *ticketRepository) GetByReference(ctx context.Context, reference string) (*domain.Ticket, error) {
+	result, err := r.store.GetSupportTicketByReference(ctx, reference)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrTicketNotFound
+		}
+		return nil, fmt.Errorf("failed to get support ticket: %w", err)
+	}
+
+	ticket := r.mapToDomain(&result)
+
+	rows, err := r.store.ListSupportTicketAttachments(ctx, result.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list support ticket attachments: %w", err)
+	}
+	for _, row := range rows {
+		ticket.Attachments = append(ticket.Attachments, &domain.Attachment{
+			FileAssetID: row.ID,
+			FileName:    row.OriginalFileName,
+			FileSize:    row.FileSize,
+			ContentType: row.MimeType,
+		})
+	}
+
+	return ticket, nil
+}
+
+func (r *ticketRepository) ListByAccount(ctx context.Context, orgID, accountID int32, limit, offset int32) ([]*domain.Ticket, error) {
+	params := sqlc.ListSupportTicketsByAccountParams{
+		OrganizationID: helpers.ToPgInt4(orgID),
+		AccountID:      helpers.ToPgInt4(accountID),
+		Limit:          limit,
+		Offset:         offset,
+	}
+
+	results, err := r.store.ListSupportTicketsByAccount(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list support tickets: %w", err)
+	}
+
+	tickets := make([]*domain.Ticket, len(results))
+	for i, result := range results {
+		tickets[i] = r.mapToDomain(&result)
+	}
+
+	return tickets, nil
+}
+
+func (r *ticketRepository) mapToDomain(sqlcTicket *sqlc.SupportTicket) *domain.Ticket {
+	return &domain.Ticket{
+		ID:              sqlcTicket.ID,
+		Reference:       sqlcTicket.Reference,
+		OrganizationID:  helpers.FromPgInt4(sqlcTicket.OrganizationID),
+		AccountID:       helpers.FromPgInt4(sqlcTicket.AccountID),
+		Name:            sqlcTicket.Name,
+		Email:           sqlcTicket.Email,
+		Subject:         sqlcTicket.Subject,
+		Message:         sqlcTicket.Message,
+		Status:          domain.TicketStatus(sqlcTicket.Status),
+		AccessTokenHash: sqlcTicket.AccessTokenHash,
+		Attachments:     []*domain.Attachment{},
+		CreatedAt:       sqlcTicket.CreatedAt.Time,
+		UpdatedAt:       sqlcTicket.UpdatedAt.Time,
+	}
+}
+
+// optionalID maps a zero ID (anonymous ticket) to NULL
+func optionalID(id int32) pgtype.Int4 {
+	if id == 0 {
+		return pgtype.Int4{Valid: false}
+	}
+	return helpers.ToPgInt4(id)
+}
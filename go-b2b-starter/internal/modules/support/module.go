@@ -0,0 +1,48 @@
+package support
+
+import (
+	"go.uber.org/dig"
+
+	filedomain "github.com/moasq/go-b2b-starter/internal/modules/files/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/support/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/support/domain"
+	emailDomain "github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/redis"
+)
+
+// Module provides support module dependencies
+type Module struct {
+	container *dig.Container
+}
+
+func NewModule(container *dig.Container) *Module {
+	return &Module{
+		container: container,
+	}
+}
+
+// RegisterDependencies registers all support module dependencies
+// Note: Repository implementations are registered in internal/db/inject.go
+func (m *Module) RegisterDependencies() error {
+	// Register support policy
+	if err := m.container.Provide(services.LoadSupportPolicy); err != nil {
+		return err
+	}
+
+	// Register ticket service
+	if err := m.container.Provide(func(
+		repo domain.TicketRepository,
+		fileService filedomain.FileService,
+		sender emailDomain.Sender,
+		redisClient redis.Client,
+		policy *services.SupportPolicy,
+		logger loggerDomain.Logger,
+	) services.TicketService {
+		return services.NewTicketService(repo, fileService, sender, redisClient, policy, logger)
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
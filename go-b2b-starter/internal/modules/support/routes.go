@@ -0,0 +1,51 @@
+package support
+
+import (
+	"github.com/gin-gonic/gin"
+
+	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
+)
+
+type Routes struct {
+	handler *Handler
+}
+
+func NewRoutes(handler *Handler) *Routes {
+	return &Routes{
+		handler: handler,
+	}
+}
+
+func (r *Routes) RegisterRoutes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	// Tickets from signed-in members
+	ticketsGroup := router.Group("/support/tickets")
+	ticketsGroup.Use(
+		resolver.Get("auth"),
+		resolver.Get("org_context"),
+	)
+	{
+		// POST /api/support/tickets
+		ticketsGroup.POST("", r.handler.SubmitTicket)
+
+		// GET /api/support/tickets
+		ticketsGroup.GET("", r.handler.ListTickets)
+
+		// GET /api/support/tickets/{reference}
+		ticketsGroup.GET("/:reference", r.handler.GetTicket)
+	}
+
+	// Public contact form - no authentication, rate limited per client IP by the service
+	publicGroup := router.Group("/support/public/tickets")
+	{
+		// POST /api/support/public/tickets
+		publicGroup.POST("", r.handler.SubmitPublicTicket)
+
+		// GET /api/support/public/tickets/{reference} (X-Ticket-Token header)
+		publicGroup.GET("/:reference", r.handler.GetPublicTicketStatus)
+	}
+}
+
+// Routes returns a RouteRegistrar function compatible with the server interface
+func (r *Routes) Routes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	r.RegisterRoutes(router, resolver)
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/domain"
+)
+
+// Exported dataset names
+const (
+	DatasetChatUsage          = "chat_usage"
+	DatasetDocumentProcessing = "document_processing"
+	DatasetSubscriptions      = "subscriptions"
+)
+
+// pseudonymLength is the number of hex characters kept from the HMAC
+const pseudonymLength = 32
+
+// dataset is one exported table. Bump version whenever columns change;
+// a new version is written under a new prefix and restarts from the beginning.
+type dataset struct {
+	name    string
+	version int32
+	columns []*domain.Column
+
+	// fetch reads the next batch after the cursor; an empty batch means
+	// the dataset is exported up to until
+	fetch func(ctx context.Context, after domain.Cursor, until time.Time, limit int32) (*batch, error)
+}
+
+// batch is a page of encoded rows with the cursors of its first and last row
+type batch struct {
+	rows  [][]any
+	first domain.Cursor
+	last  domain.Cursor
+}
+
+func (s *exportService) buildDatasets() []*dataset {
+	return []*dataset{
+		{
+			name:    DatasetChatUsage,
+			version: 1,
+			columns: []*domain.Column{
+				requiredColumn("message_key", domain.ColumnTypeString, "Pseudonymized chat message ID"),
+				requiredColumn("session_key", domain.ColumnTypeString, "Pseudonymized chat session ID"),
+				requiredColumn("organization_key", domain.ColumnTypeString, "Pseudonymized organization ID"),
+				requiredColumn("account_key", domain.ColumnTypeString, "Pseudonymized account ID"),
+				requiredColumn("role", domain.ColumnTypeString, "user or assistant"),
+				requiredColumn("tokens_used", domain.ColumnTypeInteger, "LLM tokens used for the message"),
+				requiredColumn("referenced_doc_count", domain.ColumnTypeInteger, "Number of documents used as context"),
+				requiredColumn("created_at", domain.ColumnTypeTimestamp, "Message time (UTC)"),
+			},
+			fetch: s.fetchChatUsage,
+		},
+		{
+			name:    DatasetDocumentProcessing,
+			version: 1,
+			columns: []*domain.Column{
+				requiredColumn("document_key", domain.ColumnTypeString, "Pseudonymized document ID"),
+				requiredColumn("organization_key", domain.ColumnTypeString, "Pseudonymized organization ID"),
+				requiredColumn("status", domain.ColumnTypeString, "Processing status"),
+				requiredColumn("content_type", domain.ColumnTypeString, "MIME type"),
+				requiredColumn("file_size_bytes", domain.ColumnTypeInteger, "File size in bytes"),
+				requiredColumn("created_at", domain.ColumnTypeTimestamp, "Upload time (UTC)"),
+				requiredColumn("updated_at", domain.ColumnTypeTimestamp, "Time of this state (UTC)"),
+			},
+			fetch: s.fetchDocumentProcessing,
+		},
+		{
+			name:    DatasetSubscriptions,
+			version: 1,
+			columns: []*domain.Column{
+				requiredColumn("subscription_key", domain.ColumnTypeString, "Pseudonymized subscription ID"),
+				requiredColumn("organization_key", domain.ColumnTypeString, "Pseudonymized organization ID"),
+				requiredColumn("status", domain.ColumnTypeString, "Subscription status"),
+				requiredColumn("product_id", domain.ColumnTypeString, "Billing provider product ID"),
+				nullableColumn("plan_name", domain.ColumnTypeString, "Plan name"),
+				nullableColumn("current_period_start", domain.ColumnTypeTimestamp, "Current billing period start (UTC)"),
+				nullableColumn("current_period_end", domain.ColumnTypeTimestamp, "Current billing period end (UTC)"),
+				requiredColumn("cancel_at_period_end", domain.ColumnTypeBoolean, "Cancels at the end of the period"),
+				nullableColumn("canceled_at", domain.ColumnTypeTimestamp, "Cancellation time (UTC)"),
+				requiredColumn("changed_at", domain.ColumnTypeTimestamp, "Time of this state (UTC)"),
+			},
+			fetch: s.fetchSubscriptions,
+		},
+	}
+}
+
+func (s *exportService) fetchChatUsage(ctx context.Context, after domain.Cursor, until time.Time, limit int32) (*batch, error) {
+	facts, err := s.facts.ListChatMessageFacts(ctx, after, until, limit)
+	if err != nil || len(facts) == 0 {
+		return &batch{}, err
+	}
+
+	rows := make([][]any, len(facts))
+	for i, f := range facts {
+		rows[i] = []any{
+			s.pseudonym("message", f.ID),
+			s.pseudonym("session", f.SessionID),
+			s.pseudonym("organization", f.OrganizationID),
+			s.pseudonym("account", f.AccountID),
+			f.Role,
+			int64(f.TokensUsed),
+			int64(f.ReferencedDocCount),
+			f.CreatedAt,
+		}
+	}
+
+	first, last := facts[0], facts[len(facts)-1]
+	return &batch{
+		rows:  rows,
+		first: domain.Cursor{At: first.CreatedAt, ID: first.ID},
+		last:  domain.Cursor{At: last.CreatedAt, ID: last.ID},
+	}, nil
+}
+
+func (s *exportService) fetchDocumentProcessing(ctx context.Context, after domain.Cursor, until time.Time, limit int32) (*batch, error) {
+	facts, err := s.facts.ListDocumentFacts(ctx, after, until, limit)
+	if err != nil || len(facts) == 0 {
+		return &batch{}, err
+	}
+
+	rows := make([][]any, len(facts))
+	for i, f := range facts {
+		rows[i] = []any{
+			s.pseudonym("document", f.ID),
+			s.pseudonym("organization", f.OrganizationID),
+			f.Status,
+			f.ContentType,
+			f.FileSize,
+			f.CreatedAt,
+			f.UpdatedAt,
+		}
+	}
+
+	first, last := facts[0], facts[len(facts)-1]
+	return &batch{
+		rows:  rows,
+		first: domain.Cursor{At: first.UpdatedAt, ID: first.ID},
+		last:  domain.Cursor{At: last.UpdatedAt, ID: last.ID},
+	}, nil
+}
+
+func (s *exportService) fetchSubscriptions(ctx context.Context, after domain.Cursor, until time.Time, limit int32) (*batch, error) {
+	facts, err := s.facts.ListSubscriptionFacts(ctx, after, until, limit)
+	if err != nil || len(facts) == 0 {
+		return &batch{}, err
+	}
+
+	rows := make([][]any, len(facts))
+	for i, f := range facts {
+		rows[i] = []any{
+			s.pseudonym("subscription", f.ID),
+			s.pseudonym("organization", f.OrganizationID),
+			f.Status,
+			f.ProductID,
+			nullableString(f.PlanName),
+			nullableTime(f.CurrentPeriodStart),
+			nullableTime(f.CurrentPeriodEnd),
+			f.CancelAtPeriodEnd,
+			nullableTime(f.CanceledAt),
+			f.ChangedAt,
+		}
+	}
+
+	first, last := facts[0], facts[len(facts)-1]
+	return &batch{
+		rows:  rows,
+		first: domain.Cursor{At: first.ChangedAt, ID: first.ID},
+		last:  domain.Cursor{At: last.ChangedAt, ID: last.ID},
+	}, nil
+}
+
+// pseudonym replaces an internal ID with a keyed hash. The same ID of the same
+// kind always maps to the same value, so datasets can still be joined.
+func (s *exportService) pseudonym(kind string, id int32) string {
+	mac := hmac.New(sha256.New, []byte(s.config.HashKey))
+	mac.Write([]byte(kind + ":" + strconv.FormatInt(int64(id), 10)))
+	return hex.EncodeToString(mac.Sum(nil))[:pseudonymLength]
+}
+
+func requiredColumn(name, columnType, description string) *domain.Column {
+	return &domain.Column{Name: name, Type: columnType, Mode: domain.ColumnModeRequired, Description: description}
+}
+
+func nullableColumn(name, columnType, description string) *domain.Column {
+	return &domain.Column{Name: name, Type: columnType, Mode: domain.ColumnModeNullable, Description: description}
+}
+
+func nullableString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullableTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/domain"
+)
+
+// Encoder serializes a batch of rows into one exported file.
+// Row values are string, int64, bool, time.Time or nil for NULL.
+type Encoder interface {
+	// Extension is the file name extension, e.g. "csv.gz"
+	Extension() string
+
+	// ContentType is stored with the object
+	ContentType() string
+
+	// Encode writes the rows in column order
+	Encode(columns []*domain.Column, rows [][]any) ([]byte, error)
+}
+
+// NewEncoder returns the encoder for a WAREHOUSE_EXPORT_FORMAT value.
+func NewEncoder(format string) (Encoder, error) {
+	switch format {
+	case FormatCSV:
+		return csvEncoder{}, nil
+	case FormatJSONL:
+		return jsonlEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// csvEncoder writes gzip-compressed CSV with a header row. NULL is an empty field.
+type csvEncoder struct{}
+
+func (csvEncoder) Extension() string   { return "csv.gz" }
+func (csvEncoder) ContentType() string { return "application/gzip" }
+
+func (csvEncoder) Encode(columns []*domain.Column, rows [][]any) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	w := csv.NewWriter(gz)
+
+	record := make([]string, len(columns))
+	for i, column := range columns {
+		record[i] = column.Name
+	}
+	if err := w.Write(record); err != nil {
+		return nil, fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	for _, row := range rows {
+		for i, value := range row {
+			record[i] = formatCSVValue(value)
+		}
+		if err := w.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write csv row: %w", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write csv: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress csv: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func formatCSVValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// jsonlEncoder writes gzip-compressed newline-delimited JSON objects keyed by column name.
+type jsonlEncoder struct{}
+
+func (jsonlEncoder) Extension() string   { return "jsonl.gz" }
+func (jsonlEncoder) ContentType() string { return "application/gzip" }
+
+func (jsonlEncoder) Encode(columns []*domain.Column, rows [][]any) ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(gz)
+
+	for _, row := range rows {
+		object := make(map[string]any, len(columns))
+		for i, column := range columns {
+			if t, ok := row[i].(time.Time); ok {
+				object[column.Name] = t.UTC()
+				continue
+			}
+			object[column.Name] = row[i]
+		}
+		if err := enc.Encode(object); err != nil {
+			return nil, fmt.Errorf("failed to write json row: %w", err)
+		}
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to compress json: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Supported values for WAREHOUSE_EXPORT_SINK
+const (
+	SinkS3    = "s3"
+	SinkLocal = "local"
+)
+
+// Supported values for WAREHOUSE_EXPORT_FORMAT
+const (
+	FormatCSV   = "csv"
+	FormatJSONL = "jsonl"
+)
+
+// minHashKeyLength is the minimum length of the pseudonymization key
+const minHashKeyLength = 32
+
+// ExportConfig configures the scheduled data warehouse export.
+//
+// All values can be set via environment variables with the WAREHOUSE_EXPORT_ prefix.
+type ExportConfig struct {
+	// Enabled starts the export scheduler
+	Enabled bool `mapstructure:"WAREHOUSE_EXPORT_ENABLED"`
+
+	// Interval is the time between export runs
+	Interval time.Duration `mapstructure:"WAREHOUSE_EXPORT_INTERVAL"`
+
+	// BatchSize is the maximum number of rows per exported file
+	BatchSize int32 `mapstructure:"WAREHOUSE_EXPORT_BATCH_SIZE"`
+
+	// Lag holds back rows newer than now minus Lag, so rows from transactions
+	// that commit late are not skipped by the watermark
+	Lag time.Duration `mapstructure:"WAREHOUSE_EXPORT_LAG"`
+
+	// Lease is how long one instance holds a dataset before another may take over
+	Lease time.Duration `mapstructure:"WAREHOUSE_EXPORT_LEASE"`
+
+	// Format is the file format: csv or jsonl (both gzip-compressed)
+	Format string `mapstructure:"WAREHOUSE_EXPORT_FORMAT"`
+
+	// Sink is where files are written: s3 or local
+	Sink string `mapstructure:"WAREHOUSE_EXPORT_SINK"`
+
+	// Prefix is prepended to every object key
+	Prefix string `mapstructure:"WAREHOUSE_EXPORT_PREFIX"`
+
+	// LocalDir is the output directory of the local sink
+	LocalDir string `mapstructure:"WAREHOUSE_EXPORT_LOCAL_DIR"`
+
+	// S3 sink settings. Endpoint is only needed for S3-compatible stores.
+	S3Bucket          string `mapstructure:"WAREHOUSE_EXPORT_S3_BUCKET"`
+	S3Region          string `mapstructure:"WAREHOUSE_EXPORT_S3_REGION"`
+	S3Endpoint        string `mapstructure:"WAREHOUSE_EXPORT_S3_ENDPOINT"`
+	S3AccessKeyID     string `mapstructure:"WAREHOUSE_EXPORT_S3_ACCESS_KEY_ID"`
+	S3SecretAccessKey string `mapstructure:"WAREHOUSE_EXPORT_S3_SECRET_ACCESS_KEY"`
+
+	// HashKey is the HMAC key used to pseudonymize organization, account and
+	// row IDs. Keep it stable: changing it breaks joins with earlier exports.
+	HashKey string `mapstructure:"WAREHOUSE_EXPORT_HASH_KEY"`
+}
+
+// LoadExportConfig loads the export configuration from environment variables and app.env file.
+func LoadExportConfig() (*ExportConfig, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("WAREHOUSE_EXPORT_ENABLED", false)
+	v.SetDefault("WAREHOUSE_EXPORT_INTERVAL", "1h")
+	v.SetDefault("WAREHOUSE_EXPORT_BATCH_SIZE", 5000)
+	v.SetDefault("WAREHOUSE_EXPORT_LAG", "5m")
+	v.SetDefault("WAREHOUSE_EXPORT_LEASE", "30m")
+	v.SetDefault("WAREHOUSE_EXPORT_FORMAT", FormatCSV)
+	v.SetDefault("WAREHOUSE_EXPORT_SINK", SinkS3)
+	v.SetDefault("WAREHOUSE_EXPORT_PREFIX", "warehouse")
+	v.SetDefault("WAREHOUSE_EXPORT_LOCAL_DIR", "./exports")
+	v.SetDefault("WAREHOUSE_EXPORT_S3_BUCKET", "")
+	v.SetDefault("WAREHOUSE_EXPORT_S3_REGION", "us-east-1")
+	v.SetDefault("WAREHOUSE_EXPORT_S3_ENDPOINT", "")
+	v.SetDefault("WAREHOUSE_EXPORT_S3_ACCESS_KEY_ID", "")
+	v.SetDefault("WAREHOUSE_EXPORT_S3_SECRET_ACCESS_KEY", "")
+	v.SetDefault("WAREHOUSE_EXPORT_HASH_KEY", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg ExportConfig
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode warehouse export config: %w", err)
+	}
+
+	cfg.Format = strings.ToLower(strings.TrimSpace(cfg.Format))
+	cfg.Sink = strings.ToLower(strings.TrimSpace(cfg.Sink))
+	cfg.Prefix = strings.Trim(cfg.Prefix, "/")
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks the configuration. Settings are only enforced when the export is enabled.
+func (c *ExportConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Interval <= 0 || c.Lease <= 0 || c.Lag < 0 {
+		return fmt.Errorf("warehouse export config invalid: WAREHOUSE_EXPORT_INTERVAL and WAREHOUSE_EXPORT_LEASE must be positive and WAREHOUSE_EXPORT_LAG must not be negative")
+	}
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("warehouse export config invalid: WAREHOUSE_EXPORT_BATCH_SIZE must be positive")
+	}
+	switch c.Format {
+	case FormatCSV, FormatJSONL:
+	default:
+		return fmt.Errorf("warehouse export config invalid: unsupported WAREHOUSE_EXPORT_FORMAT %q (expected csv or jsonl)", c.Format)
+	}
+	switch c.Sink {
+	case SinkS3:
+		if c.S3Bucket == "" {
+			return fmt.Errorf("warehouse export config invalid: WAREHOUSE_EXPORT_S3_BUCKET is required for the s3 sink")
+		}
+	case SinkLocal:
+		if c.LocalDir == "" {
+			return fmt.Errorf("warehouse export config invalid: WAREHOUSE_EXPORT_LOCAL_DIR is required for the local sink")
+		}
+	default:
+		return fmt.Errorf("warehouse export config invalid: unsupported WAREHOUSE_EXPORT_SINK %q (expected s3 or local)", c.Sink)
+	}
+	if len(c.HashKey) < minHashKeyLength {
+		return fmt.Errorf("warehouse export config invalid: WAREHOUSE_EXPORT_HASH_KEY must be at least %d characters", minHashKeyLength)
+	}
+	return nil
+}
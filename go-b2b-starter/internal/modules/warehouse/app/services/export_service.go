@@ -0,0 +1,236 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/domain"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// releaseTimeout bounds releasing a dataset lease after a run
+const releaseTimeout = 10 * time.Second
+
+// ExportService ships anonymized facts to the configured sink in incremental batches.
+type ExportService interface {
+	// Run exports all datasets every configured interval until ctx is cancelled
+	Run(ctx context.Context)
+
+	// ExportAll exports new rows of every dataset once
+	ExportAll(ctx context.Context) error
+
+	// ExportDataset exports new rows of one dataset and returns how many were exported
+	ExportDataset(ctx context.Context, name string) (int64, error)
+}
+
+type exportService struct {
+	facts      domain.FactRepository
+	watermarks domain.WatermarkRepository
+	sink       domain.ObjectSink
+	encoder    Encoder
+	config     *ExportConfig
+	tracker    jobsDomain.Tracker
+	job        jobsDomain.Definition
+	logger     loggerDomain.Logger
+	datasets   []*dataset
+}
+
+func NewExportService(
+	facts domain.FactRepository,
+	watermarks domain.WatermarkRepository,
+	sink domain.ObjectSink,
+	encoder Encoder,
+	config *ExportConfig,
+	tracker jobsDomain.Tracker,
+	logger loggerDomain.Logger,
+) ExportService {
+	s := &exportService{
+		facts:      facts,
+		watermarks: watermarks,
+		sink:       sink,
+		encoder:    encoder,
+		config:     config,
+		tracker:    tracker,
+		job: jobsDomain.Definition{
+			Name:        "warehouse.export",
+			Kind:        jobsDomain.KindScheduled,
+			Description: "Exports anonymized facts to the warehouse sink",
+			Schedule:    "every " + config.Interval.String(),
+		},
+		logger: logger,
+	}
+	s.datasets = s.buildDatasets()
+	tracker.Register(s.job)
+	return s
+}
+
+func (s *exportService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	s.logger.Info("warehouse export scheduler started", loggerDomain.Fields{
+		"interval": s.config.Interval.String(),
+		"sink":     s.config.Sink,
+		"format":   s.config.Format,
+	})
+
+	for {
+		if err := s.tracker.Track(ctx, s.job, s.ExportAll); err != nil {
+			s.logger.Error("warehouse export run failed", loggerDomain.Fields{"error": err.Error()})
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *exportService) ExportAll(ctx context.Context) error {
+	var errs []error
+	for _, ds := range s.datasets {
+		if _, err := s.ExportDataset(ctx, ds.name); err != nil {
+			if errors.Is(err, domain.ErrExportInProgress) {
+				s.logger.Info("skipping dataset exported by another instance", loggerDomain.Fields{"dataset": ds.name})
+				continue
+			}
+			errs = append(errs, fmt.Errorf("%s: %w", ds.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *exportService) ExportDataset(ctx context.Context, name string) (int64, error) {
+	ds := s.findDataset(name)
+	if ds == nil {
+		return 0, domain.ErrUnknownDataset
+	}
+
+	started := time.Now().UTC()
+	watermark, err := s.watermarks.Claim(ctx, ds.name, ds.version, started.Add(s.config.Lease))
+	if err != nil {
+		return 0, err
+	}
+	defer s.release(ds.name)
+
+	cursor := watermark.Cursor
+	if watermark.SchemaVersion != ds.version {
+		// A new schema version is exported in full under its own prefix
+		s.logger.Info("dataset schema version changed, restarting export", loggerDomain.Fields{
+			"dataset":      ds.name,
+			"from_version": watermark.SchemaVersion,
+			"to_version":   ds.version,
+		})
+		cursor = domain.Cursor{At: time.Unix(0, 0).UTC()}
+	}
+
+	if err := s.writeSchema(ctx, ds); err != nil {
+		return 0, err
+	}
+
+	// Stop well before the lease expires so two instances never export the same rows
+	deadline := started.Add(s.config.Lease / 2)
+	until := started.Add(-s.config.Lag)
+
+	var exported int64
+	for time.Now().Before(deadline) {
+		page, err := ds.fetch(ctx, cursor, until, s.config.BatchSize)
+		if err != nil {
+			return exported, err
+		}
+		if len(page.rows) == 0 {
+			break
+		}
+
+		body, err := s.encoder.Encode(ds.columns, page.rows)
+		if err != nil {
+			return exported, err
+		}
+
+		// The key is derived from the first row, so a batch retried after
+		// a failed watermark update overwrites its earlier upload
+		key := s.objectKey(ds, page.first)
+		if err := s.sink.Put(ctx, key, body, s.encoder.ContentType()); err != nil {
+			return exported, err
+		}
+		if err := s.watermarks.Advance(ctx, ds.name, ds.version, page.last, int64(len(page.rows)), key); err != nil {
+			return exported, err
+		}
+
+		exported += int64(len(page.rows))
+		cursor = page.last
+
+		if int32(len(page.rows)) < s.config.BatchSize {
+			break
+		}
+	}
+
+	s.logger.Info("warehouse dataset exported", loggerDomain.Fields{
+		"dataset":        ds.name,
+		"schema_version": ds.version,
+		"rows":           exported,
+		"watermark_at":   cursor.At,
+		"watermark_id":   cursor.ID,
+	})
+
+	return exported, nil
+}
+
+// writeSchema stores the schema manifest next to the dataset files.
+// The manifest uses BigQuery schema fields and is rewritten on every run.
+func (s *exportService) writeSchema(ctx context.Context, ds *dataset) error {
+	body, err := json.MarshalIndent(&domain.Schema{
+		Dataset: ds.name,
+		Version: ds.version,
+		Format:  s.config.Format,
+		Columns: ds.columns,
+	}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode schema: %w", err)
+	}
+
+	return s.sink.Put(ctx, path.Join(s.datasetPrefix(ds), "_schema.json"), body, "application/json")
+}
+
+// objectKey returns <prefix>/<dataset>/v<version>/dt=<date>/part-<time>-<id>.<ext>
+// for a batch starting at the given row.
+func (s *exportService) objectKey(ds *dataset, first domain.Cursor) string {
+	return path.Join(
+		s.datasetPrefix(ds),
+		"dt="+first.At.UTC().Format("2006-01-02"),
+		fmt.Sprintf("part-%d-%d.%s", first.At.UnixMicro(), first.ID, s.encoder.Extension()),
+	)
+}
+
+func (s *exportService) datasetPrefix(ds *dataset) string {
+	return path.Join(s.config.Prefix, ds.name, fmt.Sprintf("v%d", ds.version))
+}
+
+// release gives up the dataset lease. Uses a fresh context so the lease is
+// released even when the run was cancelled.
+func (s *exportService) release(name string) {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	if err := s.watermarks.Release(ctx, name); err != nil {
+		s.logger.Error("failed to release warehouse export lease", loggerDomain.Fields{
+			"dataset": name,
+			"error":   err.Error(),
+		})
+	}
+}
+
+func (s *exportService) findDataset(name string) *dataset {
+	for _, ds := range s.datasets {
+		if ds.name == name {
+			return ds
+		}
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse"
+)
+
+func Init(container *dig.Container) error {
+	module := warehouse.NewModule(container)
+	if err := module.RegisterDependencies(); err != nil {
+		return err
+	}
+	return module.StartScheduler()
+}
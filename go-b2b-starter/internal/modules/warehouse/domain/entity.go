@@ -0,0 +1,89 @@
+package domain
+
+import "time"
+
+// Column types used in exported schemas. The names follow BigQuery so the
+// schema manifest can be passed to `bq load --schema` as is.
+const (
+	ColumnTypeString    = "STRING"
+	ColumnTypeInteger   = "INT64"
+	ColumnTypeBoolean   = "BOOL"
+	ColumnTypeTimestamp = "TIMESTAMP"
+)
+
+// Column modes used in exported schemas
+const (
+	ColumnModeRequired = "REQUIRED"
+	ColumnModeNullable = "NULLABLE"
+)
+
+// Column describes one column of an exported dataset
+type Column struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Mode        string `json:"mode"`
+	Description string `json:"description,omitempty"`
+}
+
+// Schema describes the files exported for one version of a dataset.
+// A change to the columns must bump the version.
+type Schema struct {
+	Dataset string    `json:"dataset"`
+	Version int32     `json:"version"`
+	Format  string    `json:"format"`
+	Columns []*Column `json:"columns"`
+}
+
+// Cursor is a keyset position in a dataset: rows are exported in
+// (At, ID) order and the cursor points at the last exported row.
+type Cursor struct {
+	At time.Time `json:"at"`
+	ID int32     `json:"id"`
+}
+
+// Watermark tracks the export progress of a dataset
+type Watermark struct {
+	Dataset        string     `json:"dataset"`
+	SchemaVersion  int32      `json:"schema_version"`
+	Cursor         Cursor     `json:"cursor"`
+	RowsExported   int64      `json:"rows_exported"`
+	LastObjectKey  string     `json:"last_object_key,omitempty"`
+	LastExportedAt *time.Time `json:"last_exported_at,omitempty"`
+}
+
+// ChatMessageFact is a usage fact for one chat message. Message content is never exported.
+type ChatMessageFact struct {
+	ID                 int32
+	SessionID          int32
+	OrganizationID     int32
+	AccountID          int32
+	Role               string
+	TokensUsed         int32
+	ReferencedDocCount int32
+	CreatedAt          time.Time
+}
+
+// DocumentFact is a processing fact for one document. Titles, file names and text are never exported.
+type DocumentFact struct {
+	ID             int32
+	OrganizationID int32
+	Status         string
+	ContentType    string
+	FileSize       int64
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// SubscriptionFact is a billing fact for one subscription. Provider customer IDs and metadata are never exported.
+type SubscriptionFact struct {
+	ID                 int32
+	OrganizationID     int32
+	Status             string
+	ProductID          string
+	PlanName           string
+	CurrentPeriodStart *time.Time
+	CurrentPeriodEnd   *time.Time
+	CancelAtPeriodEnd  bool
+	CanceledAt         *time.Time
+	ChangedAt          time.Time
+}
@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+// Domain errors for warehouse exports
+var (
+	// ErrExportInProgress is returned when another instance holds the dataset lease
+	ErrExportInProgress = errors.New("dataset export is already in progress")
+
+	// ErrUnknownDataset is returned for a dataset that is not registered
+	ErrUnknownDataset = errors.New("unknown export dataset")
+)
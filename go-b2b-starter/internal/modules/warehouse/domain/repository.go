@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// FactRepository reads exportable facts in keyset order.
+// Each method returns up to limit rows after the cursor and no later than until.
+type FactRepository interface {
+	// ListChatMessageFacts lists chat messages ordered by (created_at, id)
+	ListChatMessageFacts(ctx context.Context, after Cursor, until time.Time, limit int32) ([]*ChatMessageFact, error)
+
+	// ListDocumentFacts lists documents ordered by (updated_at, id)
+	ListDocumentFacts(ctx context.Context, after Cursor, until time.Time, limit int32) ([]*DocumentFact, error)
+
+	// ListSubscriptionFacts lists subscriptions ordered by their last change
+	ListSubscriptionFacts(ctx context.Context, after Cursor, until time.Time, limit int32) ([]*SubscriptionFact, error)
+}
+
+// WatermarkRepository persists export progress per dataset
+type WatermarkRepository interface {
+	// Claim takes the dataset lease until leaseUntil and returns the current watermark.
+	// The watermark is created on first use. Returns ErrExportInProgress when
+	// another instance holds an unexpired lease.
+	Claim(ctx context.Context, dataset string, schemaVersion int32, leaseUntil time.Time) (*Watermark, error)
+
+	// Advance records an exported batch
+	Advance(ctx context.Context, dataset string, schemaVersion int32, cursor Cursor, rows int64, objectKey string) error
+
+	// Release gives up the dataset lease
+	Release(ctx context.Context, dataset string) error
+}
+
+// ObjectSink stores exported files
+type ObjectSink interface {
+	// Put writes an object, replacing any existing object with the same key
+	Put(ctx context.Context, key string, body []byte, contentType string) error
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/domain"
+)
+
+// factRepository implements domain.FactRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type factRepository struct {
+	store sqlc.Store
+}
+
+// NewFactRepository creates a new FactRepository implementation.
+func NewFactRepository(store sqlc.Store) domain.FactRepository {
+	return &factRepository{store: store}
+}
+
+func (r *factRepository) ListChatMessageFacts(ctx context.Context, after domain.Cursor, until time.Time, limit int32) ([]*domain.ChatMessageFact, error) {
+	params := sqlc.ListChatMessageFactsParams{
+		AfterAt:    toPgTimestamp(after.At),
+		AfterID:    after.ID,
+		UntilAt:    toPgTimestamp(until),
+		BatchLimit: limit,
+	}
+
+	rows, err := r.store.ListChatMessageFacts(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list chat message facts: %w", err)
+	}
+
+	facts := make([]*domain.ChatMessageFact, len(rows))
+	for i, row := range rows {
+		facts[i] = &domain.ChatMessageFact{
+			ID:                 row.ID,
+			SessionID:          row.SessionID,
+			OrganizationID:     row.OrganizationID,
+			AccountID:          row.AccountID,
+			Role:               row.Role,
+			TokensUsed:         row.TokensUsed,
+			ReferencedDocCount: row.ReferencedDocCount,
+			CreatedAt:          row.CreatedAt.Time,
+		}
+	}
+	return facts, nil
+}
+
+func (r *factRepository) ListDocumentFacts(ctx context.Context, after domain.Cursor, until time.Time, limit int32) ([]*domain.DocumentFact, error) {
+	params := sqlc.ListDocumentFactsParams{
+		AfterAt:    toPgTimestamp(after.At),
+		AfterID:    after.ID,
+		UntilAt:    toPgTimestamp(until),
+		BatchLimit: limit,
+	}
+
+	rows, err := r.store.ListDocumentFacts(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document facts: %w", err)
+	}
+
+	facts := make([]*domain.DocumentFact, len(rows))
+	for i, row := range rows {
+		facts[i] = &domain.DocumentFact{
+			ID:             row.ID,
+			OrganizationID: row.OrganizationID,
+			Status:         row.Status,
+			ContentType:    row.ContentType,
+			FileSize:       row.FileSize,
+			CreatedAt:      row.CreatedAt.Time,
+			UpdatedAt:      row.UpdatedAt.Time,
+		}
+	}
+	return facts, nil
+}
+
+func (r *factRepository) ListSubscriptionFacts(ctx context.Context, after domain.Cursor, until time.Time, limit int32) ([]*domain.SubscriptionFact, error) {
+	params := sqlc.ListSubscriptionFactsParams{
+		AfterAt:    toPgTimestamp(after.At),
+		AfterID:    after.ID,
+		UntilAt:    toPgTimestamp(until),
+		BatchLimit: limit,
+	}
+
+	rows, err := r.store.ListSubscriptionFacts(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscription facts: %w", err)
+	}
+
+	facts := make([]*domain.SubscriptionFact, len(rows))
+	for i, row := range rows {
+		facts[i] = &domain.SubscriptionFact{
+			ID:                 row.ID,
+			OrganizationID:     row.OrganizationID,
+			Status:             row.SubscriptionStatus,
+			ProductID:          row.ProductID,
+			PlanName:           helpers.FromPgText(row.PlanName),
+			CurrentPeriodStart: fromPgTimestampPtr(row.CurrentPeriodStart),
+			CurrentPeriodEnd:   fromPgTimestampPtr(row.CurrentPeriodEnd),
+			CancelAtPeriodEnd:  helpers.FromPgBool(row.CancelAtPeriodEnd),
+			CanceledAt:         fromPgTimestampPtr(row.CanceledAt),
+			ChangedAt:          row.ChangedAt.Time,
+		}
+	}
+	return facts, nil
+}
+
+func toPgTimestamp(t time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: t, Valid: true}
+}
+
+func fromPgTimestampPtr(t pgtype.Timestamp) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
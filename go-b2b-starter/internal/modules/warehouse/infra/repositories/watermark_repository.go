@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/domain"
+)
+
+// watermarkRepository implements domain.WatermarkRepository using SQLC internally.
+type watermarkRepository struct {
+	store sqlc.Store
+}
+
+// NewWatermarkRepository creates a new WatermarkRepository implementation.
+func NewWatermarkRepository(store sqlc.Store) domain.WatermarkRepository {
+	return &watermarkRepository{store: store}
+}
+
+func (r *watermarkRepository) Claim(ctx context.Context, dataset string, schemaVersion int32, leaseUntil time.Time) (*domain.Watermark, error) {
+	params := sqlc.ClaimExportWatermarkParams{
+		Dataset:       dataset,
+		SchemaVersion: schemaVersion,
+		LeaseUntil:    toPgTimestamp(leaseUntil),
+	}
+
+	result, err := r.store.ClaimExportWatermark(ctx, params)
+	if err != nil {
+		// The upsert returns no row while another instance holds the lease
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrExportInProgress
+		}
+		return nil, fmt.Errorf("failed to claim export watermark: %w", err)
+	}
+
+	return &domain.Watermark{
+		Dataset:       result.Dataset,
+		SchemaVersion: result.SchemaVersion,
+		Cursor: domain.Cursor{
+			At: result.WatermarkAt.Time,
+			ID: result.WatermarkID,
+		},
+		RowsExported:   result.RowsExported,
+		LastObjectKey:  helpers.FromPgText(result.LastObjectKey),
+		LastExportedAt: fromPgTimestampPtr(result.LastExportedAt),
+	}, nil
+}
+
+func (r *watermarkRepository) Advance(ctx context.Context, dataset string, schemaVersion int32, cursor domain.Cursor, rows int64, objectKey string) error {
+	params := sqlc.AdvanceExportWatermarkParams{
+		SchemaVersion: schemaVersion,
+		WatermarkAt:   toPgTimestamp(cursor.At),
+		WatermarkID:   cursor.ID,
+		ExportedRows:  rows,
+		LastObjectKey: helpers.ToPgText(objectKey),
+		Dataset:       dataset,
+	}
+
+	if err := r.store.AdvanceExportWatermark(ctx, params); err != nil {
+		return fmt.Errorf("failed to advance export watermark: %w", err)
+	}
+	return nil
+}
+
+func (r *watermarkRepository) Release(ctx context.Context, dataset string) error {
+	if err := r.store.ReleaseExportWatermark(ctx, dataset); err != nil {
+		return fmt.Errorf("failed to release export watermark: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package sinks
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/domain"
+)
+
+type localSink struct {
+	dir string
+}
+
+// NewLocalSink creates an ObjectSink that writes below a local directory.
+// Intended for development and for warehouses that load from a mounted volume.
+func NewLocalSink(dir string) domain.ObjectSink {
+	return &localSink{dir: dir}
+}
+
+func (s *localSink) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	// Write to a temporary file first so readers never see a partial object
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, body, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	return nil
+}
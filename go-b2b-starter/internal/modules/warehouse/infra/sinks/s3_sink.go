@@ -0,0 +1,76 @@
+package sinks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/domain"
+)
+
+// S3Options configures the S3 sink. Endpoint is only needed for
+// S3-compatible stores such as R2 or MinIO; without static credentials the
+// default AWS credential chain (environment, shared config, instance role) is used.
+type S3Options struct {
+	Bucket          string
+	Region          string
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+type s3Sink struct {
+	client *s3.Client
+	bucket string
+}
+
+// NewS3Sink creates an ObjectSink that writes to an S3 bucket.
+func NewS3Sink(opts S3Options) (domain.ObjectSink, error) {
+	loadOpts := []func(*config.LoadOptions) error{
+		config.WithRegion(opts.Region),
+	}
+	if opts.AccessKeyID != "" {
+		loadOpts = append(loadOpts, config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(
+			opts.AccessKeyID,
+			opts.SecretAccessKey,
+			"",
+		)))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(context.Background(), loadOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load S3 config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if opts.Endpoint != "" {
+			o.BaseEndpoint = aws.String(opts.Endpoint)
+			// S3-compatible stores generally only support path-style URLs
+			o.UsePathStyle = true
+		}
+	})
+
+	return &s3Sink{
+		client: client,
+		bucket: opts.Bucket,
+	}, nil
+}
+
+func (s *s3Sink) Put(ctx context.Context, key string, body []byte, contentType string) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(s.bucket),
+		Key:           aws.String(key),
+		Body:          bytes.NewReader(body),
+		ContentLength: aws.Int64(int64(len(body))),
+		ContentType:   aws.String(contentType),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s to S3: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package warehouse
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/app/services"
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/domain"
+	"github.com/moasq/go-b2b-starter/internal/modules/warehouse/infra/sinks"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// Module provides warehouse export dependencies
+type Module struct {
+	container *dig.Container
+}
+
+func NewModule(container *dig.Container) *Module {
+	return &Module{
+		container: container,
+	}
+}
+
+// RegisterDependencies registers all warehouse module dependencies
+// Note: Repository implementations are registered in internal/db/inject.go
+func (m *Module) RegisterDependencies() error {
+	// Register export config
+	if err := m.container.Provide(services.LoadExportConfig); err != nil {
+		return err
+	}
+
+	// Register object sink (S3 or local directory)
+	if err := m.container.Provide(func(cfg *services.ExportConfig) (domain.ObjectSink, error) {
+		if cfg.Sink == services.SinkLocal {
+			return sinks.NewLocalSink(cfg.LocalDir), nil
+		}
+		return sinks.NewS3Sink(sinks.S3Options{
+			Bucket:          cfg.S3Bucket,
+			Region:          cfg.S3Region,
+			Endpoint:        cfg.S3Endpoint,
+			AccessKeyID:     cfg.S3AccessKeyID,
+			SecretAccessKey: cfg.S3SecretAccessKey,
+		})
+	}); err != nil {
+		return err
+	}
+
+	// Register export service
+	if err := m.container.Provide(func(
+		facts domain.FactRepository,
+		watermarks domain.WatermarkRepository,
+		sink domain.ObjectSink,
+		cfg *services.ExportConfig,
+		tracker jobsDomain.Tracker,
+		logger loggerDomain.Logger,
+	) (services.ExportService, error) {
+		encoder, err := services.NewEncoder(cfg.Format)
+		if err != nil {
+			return nil, err
+		}
+		return services.NewExportService(facts, watermarks, sink, encoder, cfg, tracker, logger), nil
+	}); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// StartScheduler starts the background export loop when the export is enabled.
+// The sink and service are only built when enabled.
+func (m *Module) StartScheduler() error {
+	var enabled bool
+	if err := m.container.Invoke(func(cfg *services.ExportConfig) {
+		enabled = cfg.Enabled
+	}); err != nil {
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	return m.container.Invoke(func(service services.ExportService) {
+		go service.Run(context.Background())
+	})
+}
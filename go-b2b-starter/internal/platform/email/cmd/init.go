@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/email/infra"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// Init provides the email sender: SMTP when EMAIL_SMTP_HOST is set,
+// otherwise a sender that only logs messages (for local development).
+func Init(container *dig.Container) error {
+	return container.Provide(func(logger loggerDomain.Logger) (domain.Sender, error) {
+		config, err := infra.LoadConfig()
+		if err != nil {
+			return nil, err
+		}
+		if config.SMTPHost == "" {
+			return infra.NewLogSender(logger), nil
+		}
+		return infra.NewSMTPSender(config), nil
+	})
+}
@@ -0,0 +1,9 @@
+package domain
+
+// Message is a plain-text email
+type Message struct {
+	To      []string // Recipient addresses
+	ReplyTo string   // Optional Reply-To address
+	Subject string
+	Body    string // Plain-text body
+}
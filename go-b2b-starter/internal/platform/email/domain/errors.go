@@ -0,0 +1,9 @@
+package domain
+
+import "errors"
+
+var (
+	ErrNoRecipients   = errors.New("email has no recipients")
+	ErrInvalidAddress = errors.New("invalid email address")
+	ErrSendFailed     = errors.New("failed to send email")
+)
@@ -0,0 +1,8 @@
+package domain
+
+import "context"
+
+// Sender delivers transactional emails
+type Sender interface {
+	Send(ctx context.Context, msg *Message) error
+}
@@ -0,0 +1,68 @@
+package infra
+
+import (
+	"fmt"
+	"net/mail"
+
+	"github.com/spf13/viper"
+)
+
+// Config configures outgoing email.
+//
+// All values can be set via environment variables with the EMAIL_ prefix.
+// Leave EMAIL_SMTP_HOST empty to log emails instead of sending them.
+type Config struct {
+	SMTPHost     string `mapstructure:"EMAIL_SMTP_HOST"`
+	SMTPPort     int    `mapstructure:"EMAIL_SMTP_PORT"`
+	SMTPUsername string `mapstructure:"EMAIL_SMTP_USERNAME"`
+	SMTPPassword string `mapstructure:"EMAIL_SMTP_PASSWORD"`
+	From         string `mapstructure:"EMAIL_FROM"`
+}
+
+// LoadConfig loads the email configuration from environment variables and app.env file.
+func LoadConfig() (*Config, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("EMAIL_SMTP_HOST", "")
+	v.SetDefault("EMAIL_SMTP_PORT", 587)
+	v.SetDefault("EMAIL_SMTP_USERNAME", "")
+	v.SetDefault("EMAIL_SMTP_PASSWORD", "")
+	v.SetDefault("EMAIL_FROM", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode email config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that an SMTP configuration has a port and sender address.
+func (c *Config) Validate() error {
+	if c.SMTPHost == "" {
+		return nil
+	}
+	if c.SMTPPort <= 0 {
+		return fmt.Errorf("email config invalid: EMAIL_SMTP_PORT must be positive")
+	}
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("email config invalid: EMAIL_FROM must be a valid address: %w", err)
+	}
+	return nil
+}
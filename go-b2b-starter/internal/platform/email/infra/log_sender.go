@@ -0,0 +1,32 @@
+package infra
+
+import (
+	"context"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// LogSender logs emails instead of sending them.
+// It is used for local development when no SMTP server is configured.
+type LogSender struct {
+	logger loggerDomain.Logger
+}
+
+func NewLogSender(logger loggerDomain.Logger) domain.Sender {
+	return &LogSender{logger: logger}
+}
+
+func (s *LogSender) Send(ctx context.Context, msg *domain.Message) error {
+	if len(msg.To) == 0 {
+		return domain.ErrNoRecipients
+	}
+
+	s.logger.Info("email not sent (EMAIL_SMTP_HOST is not set)", map[string]any{
+		"to":       msg.To,
+		"reply_to": msg.ReplyTo,
+		"subject":  msg.Subject,
+		"body":     msg.Body,
+	})
+	return nil
+}
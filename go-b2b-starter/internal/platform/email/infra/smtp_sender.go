@@ -0,0 +1,160 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/email/domain"
+)
+
+// smtpDialTimeout bounds connecting to the SMTP server when the context has no deadline
+const smtpDialTimeout = 10 * time.Second
+
+type smtpSender struct {
+	config *Config
+}
+
+// NewSMTPSender creates a Sender that delivers through an SMTP server,
+// upgrading to TLS with STARTTLS when the server supports it.
+func NewSMTPSender(config *Config) domain.Sender {
+	return &smtpSender{config: config}
+}
+
+func (s *smtpSender) Send(ctx context.Context, msg *domain.Message) error {
+	from, err := mail.ParseAddress(s.config.From)
+	if err != nil {
+		return fmt.Errorf("%w: from: %v", domain.ErrInvalidAddress, err)
+	}
+
+	recipients, err := parseRecipients(msg.To)
+	if err != nil {
+		return err
+	}
+
+	body, err := buildMessage(from, recipients, msg)
+	if err != nil {
+		return err
+	}
+
+	addr := net.JoinHostPort(s.config.SMTPHost, strconv.Itoa(s.config.SMTPPort))
+	dialer := &net.Dialer{Timeout: smtpDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("%w: dial %s: %v", domain.ErrSendFailed, addr, err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, s.config.SMTPHost)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("%w: %v", domain.ErrSendFailed, err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		tlsConfig := &tls.Config{ServerName: s.config.SMTPHost, MinVersion: tls.VersionTLS12}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("%w: starttls: %v", domain.ErrSendFailed, err)
+		}
+	}
+
+	if s.config.SMTPUsername != "" {
+		auth := smtp.PlainAuth("", s.config.SMTPUsername, s.config.SMTPPassword, s.config.SMTPHost)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("%w: auth: %v", domain.ErrSendFailed, err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrSendFailed, err)
+	}
+	for _, rcpt := range recipients {
+		if err := client.Rcpt(rcpt.Address); err != nil {
+			return fmt.Errorf("%w: rcpt %s: %v", domain.ErrSendFailed, rcpt.Address, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrSendFailed, err)
+	}
+	if _, err := w.Write(body); err != nil {
+		w.Close()
+		return fmt.Errorf("%w: %v", domain.ErrSendFailed, err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrSendFailed, err)
+	}
+
+	return client.Quit()
+}
+
+func parseRecipients(to []string) ([]*mail.Address, error) {
+	if len(to) == 0 {
+		return nil, domain.ErrNoRecipients
+	}
+
+	recipients := make([]*mail.Address, 0, len(to))
+	for _, addr := range to {
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", domain.ErrInvalidAddress, addr)
+		}
+		recipients = append(recipients, parsed)
+	}
+	return recipients, nil
+}
+
+// buildMessage renders the RFC 5322 message. Header values are encoded so
+// user-supplied text (e.g. a subject) cannot inject extra headers.
+func buildMessage(from *mail.Address, to []*mail.Address, msg *domain.Message) ([]byte, error) {
+	toHeader := make([]string, len(to))
+	for i, addr := range to {
+		toHeader[i] = addr.String()
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from.String())
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(toHeader, ", "))
+	if msg.ReplyTo != "" {
+		replyTo, err := mail.ParseAddress(msg.ReplyTo)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reply-to: %q", domain.ErrInvalidAddress, msg.ReplyTo)
+		}
+		fmt.Fprintf(&buf, "Reply-To: %s\r\n", replyTo.String())
+	}
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", singleLine(msg.Subject)))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Message-ID: <%s@%s>\r\n", uuid.New().String(), domainOf(from.Address))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return buf.Bytes(), nil
+}
+
+func singleLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func domainOf(address string) string {
+	if idx := strings.LastIndex(address, "@"); idx != -1 {
+		return address[idx+1:]
+	}
+	return "localhost"
+}
@@ -4,13 +4,15 @@ import (
 	"go.uber.org/dig"
 	
 	"github.com/moasq/go-b2b-starter/internal/platform/eventbus"
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
 	"github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
 )
 
 // ProvideEventBus creates and configures the event bus with middleware
 func ProvideEventBus(container *dig.Container) error {
-	return container.Provide(func(logger domain.Logger) eventbus.EventBus {
+	return container.Provide(func(logger domain.Logger, tracker jobsDomain.Tracker) eventbus.EventBus {
 		middleware := []eventbus.EventMiddleware{
+			eventbus.JobTrackingMiddleware(tracker),
 			eventbus.RecoveryMiddleware(logger),
 			eventbus.LoggingMiddleware(logger),
 			eventbus.MetricsMiddleware(),
@@ -6,9 +6,28 @@ import (
 	"runtime/debug"
 	"time"
 
+	jobsDomain "github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
 	"github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
 )
 
+// JobTrackingMiddleware records each handler execution in the job run history
+// as a queued job named "event.<event name>". Place it first so panics are
+// already turned into errors by RecoveryMiddleware.
+func JobTrackingMiddleware(tracker jobsDomain.Tracker) EventMiddleware {
+	return func(next EventHandler[Event]) EventHandler[Event] {
+		return func(ctx context.Context, event Event) error {
+			job := jobsDomain.Definition{
+				Name:        "event." + event.EventName(),
+				Kind:        jobsDomain.KindQueued,
+				Description: "Handles " + event.EventName() + " events",
+			}
+			return tracker.Track(ctx, job, func(ctx context.Context) error {
+				return next(ctx, event)
+			})
+		}
+	}
+}
+
 // LoggingMiddleware adds logging to event handling
 func LoggingMiddleware(logger domain.Logger) EventMiddleware {
 	return func(next EventHandler[Event]) EventHandler[Event] {
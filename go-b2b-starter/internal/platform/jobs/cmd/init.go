@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+
+	"go.uber.org/dig"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/jobs"
+	"github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// Init provides the job tracker, run history service and admin handler, and
+// starts the retention cleanup when the history is enabled.
+//
+// The db module must be initialized first (for domain.RunRepository).
+func Init(container *dig.Container) error {
+	if err := container.Provide(jobs.LoadConfig); err != nil {
+		return err
+	}
+
+	if err := container.Provide(func(cfg *jobs.Config, repo domain.RunRepository, logger loggerDomain.Logger) domain.Tracker {
+		return jobs.NewTracker(cfg, repo, logger)
+	}); err != nil {
+		return err
+	}
+
+	if err := container.Provide(jobs.NewHistoryService); err != nil {
+		return err
+	}
+
+	if err := container.Provide(jobs.NewHandler); err != nil {
+		return err
+	}
+
+	return container.Invoke(func(cfg *jobs.Config, service jobs.HistoryService) {
+		if cfg.HistoryEnabled {
+			go service.Run(context.Background())
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Config controls the job run history.
+type Config struct {
+	// HistoryEnabled records every job run in jobs.job_runs
+	HistoryEnabled bool `mapstructure:"JOBS_HISTORY_ENABLED"`
+
+	// Retention is how long runs are kept
+	Retention time.Duration `mapstructure:"JOBS_HISTORY_RETENTION"`
+
+	// CleanupInterval is how often runs older than Retention are deleted
+	CleanupInterval time.Duration `mapstructure:"JOBS_HISTORY_CLEANUP_INTERVAL"`
+}
+
+// LoadConfig loads the job history configuration from environment variables and app.env file.
+func LoadConfig() (*Config, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("JOBS_HISTORY_ENABLED", true)
+	v.SetDefault("JOBS_HISTORY_RETENTION", "720h")
+	v.SetDefault("JOBS_HISTORY_CLEANUP_INTERVAL", "1h")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode jobs config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that enabled history has a usable retention policy.
+func (c *Config) Validate() error {
+	if !c.HistoryEnabled {
+		return nil
+	}
+	if c.Retention <= 0 {
+		return fmt.Errorf("jobs config invalid: JOBS_HISTORY_RETENTION must be positive")
+	}
+	if c.CleanupInterval <= 0 {
+		return fmt.Errorf("jobs config invalid: JOBS_HISTORY_CLEANUP_INTERVAL must be positive")
+	}
+	return nil
+}
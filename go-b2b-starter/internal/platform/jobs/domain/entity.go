@@ -0,0 +1,69 @@
+package domain
+
+import "time"
+
+// Kind tells how a job is triggered
+type Kind string
+
+const (
+	// KindScheduled jobs run on a timer (e.g. the warehouse export)
+	KindScheduled Kind = "scheduled"
+	// KindQueued jobs run in response to work items such as events
+	KindQueued Kind = "queued"
+)
+
+// Status is the outcome of a run
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+// IsValid reports whether s is a known status
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusRunning, StatusSucceeded, StatusFailed:
+		return true
+	}
+	return false
+}
+
+// Definition describes a background job
+type Definition struct {
+	Name        string `json:"name"`                  // Dotted name, e.g. "warehouse.export"
+	Kind        Kind   `json:"kind"`                  // scheduled or queued
+	Description string `json:"description,omitempty"` // What the job does
+	Schedule    string `json:"schedule,omitempty"`    // When a scheduled job runs, e.g. "every 1h"
+}
+
+// Run is one execution of a job
+type Run struct {
+	ID         int64      `json:"id"`
+	Job        string     `json:"job"`
+	Kind       Kind       `json:"kind"`
+	Status     Status     `json:"status"`
+	Instance   string     `json:"instance"` // Host that ran the job
+	Error      string     `json:"error,omitempty"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	DurationMs *int64     `json:"duration_ms,omitempty"`
+}
+
+// JobStats summarizes the stored runs of one job
+type JobStats struct {
+	Job             string
+	LastSucceededAt *time.Time
+	LastFailedAt    *time.Time
+	TotalRuns       int64
+	FailedRuns      int64
+}
+
+// RunFilter selects runs; empty fields match everything
+type RunFilter struct {
+	Job    string
+	Status Status
+	Limit  int32
+	Offset int32
+}
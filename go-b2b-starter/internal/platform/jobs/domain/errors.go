@@ -0,0 +1,8 @@
+package domain
+
+import "errors"
+
+var (
+	ErrRunNotFound   = errors.New("job run not found")
+	ErrInvalidStatus = errors.New("status must be running, succeeded or failed")
+)
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// RunRepository stores the job run history
+type RunRepository interface {
+	// Start records a run in the running state
+	Start(ctx context.Context, job string, kind Kind, instance string) (*Run, error)
+
+	// Finish records the outcome of a run
+	Finish(ctx context.Context, id int64, status Status, errMsg string, duration time.Duration) (*Run, error)
+
+	GetByID(ctx context.Context, id int64) (*Run, error)
+
+	// List returns runs matching the filter, newest first
+	List(ctx context.Context, filter RunFilter) ([]*Run, error)
+
+	Count(ctx context.Context, filter RunFilter) (int64, error)
+
+	// ListLatest returns the most recent run of each job
+	ListLatest(ctx context.Context) ([]*Run, error)
+
+	// ListStats returns run counts and last outcomes per job
+	ListStats(ctx context.Context) ([]*JobStats, error)
+
+	// DeleteBefore removes runs started before the cutoff and returns how many were removed
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
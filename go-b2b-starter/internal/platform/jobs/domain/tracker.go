@@ -0,0 +1,19 @@
+package domain
+
+import "context"
+
+// Tracker records background job executions in the run history.
+//
+// Wrap each execution in Track; modules only depend on this interface.
+type Tracker interface {
+	// Register lists a job in the catalog before its first run
+	Register(def Definition)
+
+	// Track runs fn and records its start, end, outcome and duration.
+	// The error from fn is returned unchanged; failing to record a run is
+	// logged and never fails the job.
+	Track(ctx context.Context, def Definition, fn func(ctx context.Context) error) error
+
+	// Definitions returns the registered jobs sorted by name
+	Definitions() []Definition
+}
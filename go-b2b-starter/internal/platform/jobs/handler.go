@@ -0,0 +1,152 @@
+package jobs
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
+	listingshared "github.com/moasq/go-b2b-starter/pkg/pagination"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// ListRunsParams filters and pages the run history
+type ListRunsParams struct {
+	Job    string `form:"job"`
+	Status string `form:"status"`
+	listingshared.ListableParams
+}
+
+// JobsResponse lists the job catalog
+type JobsResponse struct {
+	Jobs []*JobSummary `json:"jobs"`
+}
+
+// Handler serves the job run history. It is an operator endpoint behind the
+// "operator" middleware, since jobs run for the whole deployment and not for
+// one organization.
+type Handler struct {
+	service HistoryService
+}
+
+func NewHandler(service HistoryService) *Handler {
+	return &Handler{
+		service: service,
+	}
+}
+
+// Routes registers the run history endpoints
+func (h *Handler) Routes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	group := router.Group("/admin/jobs")
+	group.Use(resolver.Get("operator"))
+	{
+		group.GET("", h.ListJobs)
+		group.GET("/runs", h.ListRuns)
+		group.GET("/runs/:id", h.GetRun)
+	}
+}
+
+// ListJobs godoc
+// @Summary List background jobs
+// @Description Returns every scheduled and queued job with its last run, last success and failure times, and run counts.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Success 200 {object} JobsResponse "Job catalog"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Router /admin/jobs [get]
+func (h *Handler) ListJobs(c *gin.Context) {
+	jobs, err := h.service.ListJobs(c.Request.Context())
+	if err != nil {
+		response.Error(c, http.StatusInternalServerError, "failed to list jobs", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, JobsResponse{Jobs: jobs})
+}
+
+// ListRuns godoc
+// @Summary List job runs
+// @Description Returns job runs newest first, optionally filtered by job name and status.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param job query string false "Job name, e.g. warehouse.export"
+// @Param status query string false "running, succeeded or failed"
+// @Param page query int false "Page number (default 1)"
+// @Param limit query int false "Page size (default 10, max 100)"
+// @Success 200 {object} listingshared.PagePagination[domain.Run] "Runs"
+// @Failure 400 {object} map[string]string "Invalid filter"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Router /admin/jobs/runs [get]
+func (h *Handler) ListRuns(c *gin.Context) {
+	var params ListRunsParams
+	if err := c.ShouldBindQuery(&params); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid query parameters", err)
+		return
+	}
+	if err := params.Validate(); err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	offset, err := listingshared.PageToOffset(params.Page, params.Limit)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	runs, total, err := h.service.ListRuns(c.Request.Context(), domain.RunFilter{
+		Job:    params.Job,
+		Status: domain.Status(params.Status),
+		Limit:  int32(params.Limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidStatus) {
+			response.Error(c, http.StatusBadRequest, err.Error(), err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to list job runs", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, listingshared.NewPagePagination(int(total), params.Page, params.Limit, runs))
+}
+
+// GetRun godoc
+// @Summary Get a job run
+// @Description Returns one job run including its error message.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param id path int true "Run ID"
+// @Success 200 {object} domain.Run "Run"
+// @Failure 400 {object} map[string]string "Invalid run ID"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Run not found or endpoint disabled"
+// @Router /admin/jobs/runs/{id} [get]
+func (h *Handler) GetRun(c *gin.Context) {
+	var id int64
+	if _, err := fmt.Sscanf(c.Param("id"), "%d", &id); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid run id", err)
+		return
+	}
+
+	run, err := h.service.GetRun(c.Request.Context(), id)
+	if err != nil {
+		if errors.Is(err, domain.ErrRunNotFound) {
+			response.Error(c, http.StatusNotFound, err.Error(), err)
+			return
+		}
+		response.Error(c, http.StatusInternalServerError, "failed to get job run", err)
+		return
+	}
+
+	response.Success(c, http.StatusOK, run)
+}
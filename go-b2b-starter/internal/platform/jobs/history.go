@@ -0,0 +1,166 @@
+package jobs
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// cleanupJob deletes runs older than JOBS_HISTORY_RETENTION
+var cleanupJob = domain.Definition{
+	Name:        "jobs.history_cleanup",
+	Kind:        domain.KindScheduled,
+	Description: "Deletes job runs older than JOBS_HISTORY_RETENTION",
+}
+
+// JobSummary is a job from the catalog with its latest outcomes
+type JobSummary struct {
+	domain.Definition
+	LastRun         *domain.Run `json:"last_run,omitempty"`
+	LastSucceededAt *time.Time  `json:"last_succeeded_at,omitempty"`
+	LastFailedAt    *time.Time  `json:"last_failed_at,omitempty"`
+	TotalRuns       int64       `json:"total_runs"`
+	FailedRuns      int64       `json:"failed_runs"`
+}
+
+// HistoryService answers questions about past job runs and enforces retention
+type HistoryService interface {
+	// ListJobs returns every known job: those registered in this process and
+	// those with stored runs (e.g. from other instances)
+	ListJobs(ctx context.Context) ([]*JobSummary, error)
+
+	// ListRuns returns a page of runs, newest first, and the total match count
+	ListRuns(ctx context.Context, filter domain.RunFilter) ([]*domain.Run, int64, error)
+
+	GetRun(ctx context.Context, id int64) (*domain.Run, error)
+
+	// PurgeExpired deletes runs older than the retention period
+	PurgeExpired(ctx context.Context) (int64, error)
+
+	// Run deletes expired runs every cleanup interval until ctx is done
+	Run(ctx context.Context)
+}
+
+type historyService struct {
+	repo    domain.RunRepository
+	tracker domain.Tracker
+	config  *Config
+	logger  loggerDomain.Logger
+}
+
+func NewHistoryService(repo domain.RunRepository, tracker domain.Tracker, config *Config, logger loggerDomain.Logger) HistoryService {
+	def := cleanupJob
+	def.Schedule = "every " + config.CleanupInterval.String()
+	tracker.Register(def)
+
+	return &historyService{
+		repo:    repo,
+		tracker: tracker,
+		config:  config,
+		logger:  logger.Named("jobs"),
+	}
+}
+
+func (s *historyService) ListJobs(ctx context.Context) ([]*JobSummary, error) {
+	summaries := make(map[string]*JobSummary)
+	for _, def := range s.tracker.Definitions() {
+		summaries[def.Name] = &JobSummary{Definition: def}
+	}
+
+	if s.config.HistoryEnabled {
+		latest, err := s.repo.ListLatest(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, run := range latest {
+			summary, ok := summaries[run.Job]
+			if !ok {
+				summary = &JobSummary{Definition: domain.Definition{Name: run.Job, Kind: run.Kind}}
+				summaries[run.Job] = summary
+			}
+			summary.LastRun = run
+		}
+
+		stats, err := s.repo.ListStats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, stat := range stats {
+			if summary, ok := summaries[stat.Job]; ok {
+				summary.LastSucceededAt = stat.LastSucceededAt
+				summary.LastFailedAt = stat.LastFailedAt
+				summary.TotalRuns = stat.TotalRuns
+				summary.FailedRuns = stat.FailedRuns
+			}
+		}
+	}
+
+	result := make([]*JobSummary, 0, len(summaries))
+	for _, summary := range summaries {
+		result = append(result, summary)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (s *historyService) ListRuns(ctx context.Context, filter domain.RunFilter) ([]*domain.Run, int64, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, 0, domain.ErrInvalidStatus
+	}
+	if !s.config.HistoryEnabled {
+		return []*domain.Run{}, 0, nil
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	runs, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return runs, total, nil
+}
+
+func (s *historyService) GetRun(ctx context.Context, id int64) (*domain.Run, error) {
+	if !s.config.HistoryEnabled {
+		return nil, domain.ErrRunNotFound
+	}
+	return s.repo.GetByID(ctx, id)
+}
+
+func (s *historyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteBefore(ctx, time.Now().Add(-s.config.Retention))
+}
+
+func (s *historyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.CleanupInterval)
+	defer ticker.Stop()
+
+	s.logger.Info("job history cleanup started", loggerDomain.Fields{
+		"interval":  s.config.CleanupInterval.String(),
+		"retention": s.config.Retention.String(),
+	})
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		err := s.tracker.Track(ctx, cleanupJob, func(ctx context.Context) error {
+			deleted, err := s.PurgeExpired(ctx)
+			if err == nil && deleted > 0 {
+				s.logger.Info("deleted expired job runs", loggerDomain.Fields{"deleted": deleted})
+			}
+			return err
+		})
+		if err != nil {
+			s.logger.Error("job history cleanup failed", loggerDomain.Fields{"error": err.Error()})
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/moasq/go-b2b-starter/internal/db/helpers"
+	sqlc "github.com/moasq/go-b2b-starter/internal/db/postgres/sqlc/gen"
+	"github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+)
+
+// runRepository implements domain.RunRepository using SQLC internally.
+// SQLC types are never exposed outside this package.
+type runRepository struct {
+	store sqlc.Store
+}
+
+// NewRunRepository creates a new RunRepository implementation.
+func NewRunRepository(store sqlc.Store) domain.RunRepository {
+	return &runRepository{store: store}
+}
+
+func (r *runRepository) Start(ctx context.Context, job string, kind domain.Kind, instance string) (*domain.Run, error) {
+	result, err := r.store.CreateJobRun(ctx, sqlc.CreateJobRunParams{
+		JobName:  job,
+		Kind:     string(kind),
+		Instance: instance,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create job run: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *runRepository) Finish(ctx context.Context, id int64, status domain.Status, errMsg string, duration time.Duration) (*domain.Run, error) {
+	result, err := r.store.FinishJobRun(ctx, sqlc.FinishJobRunParams{
+		ID:         id,
+		Status:     string(status),
+		Error:      helpers.ToPgText(errMsg),
+		DurationMs: pgtype.Int8{Int64: duration.Milliseconds(), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrRunNotFound
+		}
+		return nil, fmt.Errorf("failed to finish job run: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *runRepository) GetByID(ctx context.Context, id int64) (*domain.Run, error) {
+	result, err := r.store.GetJobRun(ctx, id)
+	if err != nil {
+		if errors.Is(err, sqlc.ErrRecordNotFound) {
+			return nil, domain.ErrRunNotFound
+		}
+		return nil, fmt.Errorf("failed to get job run: %w", err)
+	}
+
+	return r.mapToDomain(&result), nil
+}
+
+func (r *runRepository) List(ctx context.Context, filter domain.RunFilter) ([]*domain.Run, error) {
+	results, err := r.store.ListJobRuns(ctx, sqlc.ListJobRunsParams{
+		JobName:   helpers.ToPgText(filter.Job),
+		Status:    helpers.ToPgText(string(filter.Status)),
+		RowLimit:  filter.Limit,
+		RowOffset: filter.Offset,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+
+	return r.mapAllToDomain(results), nil
+}
+
+func (r *runRepository) Count(ctx context.Context, filter domain.RunFilter) (int64, error) {
+	count, err := r.store.CountJobRuns(ctx, sqlc.CountJobRunsParams{
+		JobName: helpers.ToPgText(filter.Job),
+		Status:  helpers.ToPgText(string(filter.Status)),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count job runs: %w", err)
+	}
+	return count, nil
+}
+
+func (r *runRepository) ListLatest(ctx context.Context) ([]*domain.Run, error) {
+	results, err := r.store.ListLatestJobRuns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list latest job runs: %w", err)
+	}
+
+	return r.mapAllToDomain(results), nil
+}
+
+func (r *runRepository) ListStats(ctx context.Context) ([]*domain.JobStats, error) {
+	results, err := r.store.ListJobRunStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job run stats: %w", err)
+	}
+
+	stats := make([]*domain.JobStats, len(results))
+	for i, result := range results {
+		stats[i] = &domain.JobStats{
+			Job:             result.JobName,
+			LastSucceededAt: timePtr(result.LastSucceededAt),
+			LastFailedAt:    timePtr(result.LastFailedAt),
+			TotalRuns:       result.TotalRuns,
+			FailedRuns:      result.FailedRuns,
+		}
+	}
+	return stats, nil
+}
+
+func (r *runRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	deleted, err := r.store.DeleteJobRunsBefore(ctx, pgtype.Timestamp{Time: cutoff, Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete job runs: %w", err)
+	}
+	return deleted, nil
+}
+
+func (r *runRepository) mapAllToDomain(results []sqlc.JobsJobRun) []*domain.Run {
+	runs := make([]*domain.Run, len(results))
+	for i := range results {
+		runs[i] = r.mapToDomain(&results[i])
+	}
+	return runs
+}
+
+func (r *runRepository) mapToDomain(run *sqlc.JobsJobRun) *domain.Run {
+	result := &domain.Run{
+		ID:         run.ID,
+		Job:        run.JobName,
+		Kind:       domain.Kind(run.Kind),
+		Status:     domain.Status(run.Status),
+		Instance:   run.Instance,
+		Error:      helpers.FromPgText(run.Error),
+		StartedAt:  run.StartedAt.Time,
+		FinishedAt: timePtr(run.FinishedAt),
+	}
+	if run.DurationMs.Valid {
+		duration := run.DurationMs.Int64
+		result.DurationMs = &duration
+	}
+	return result
+}
+
+func timePtr(t pgtype.Timestamp) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	value := t.Time
+	return &value
+}
@@ -0,0 +1,121 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/jobs/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// maxErrorLength caps stored error messages
+const maxErrorLength = 2000
+
+// tracker implements domain.Tracker. When the history is disabled it only
+// keeps the job catalog and runs jobs unrecorded.
+type tracker struct {
+	repo     domain.RunRepository
+	instance string
+	logger   loggerDomain.Logger
+
+	mu          sync.RWMutex
+	definitions map[string]domain.Definition
+}
+
+func NewTracker(cfg *Config, repo domain.RunRepository, logger loggerDomain.Logger) domain.Tracker {
+	instance, err := os.Hostname()
+	if err != nil || instance == "" {
+		instance = "unknown"
+	}
+	if !cfg.HistoryEnabled {
+		repo = nil
+	}
+
+	return &tracker{
+		repo:        repo,
+		instance:    instance,
+		logger:      logger.Named("jobs"),
+		definitions: make(map[string]domain.Definition),
+	}
+}
+
+func (t *tracker) Register(def domain.Definition) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.definitions[def.Name] = def
+}
+
+func (t *tracker) Definitions() []domain.Definition {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	defs := make([]domain.Definition, 0, len(t.definitions))
+	for _, def := range t.definitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool { return defs[i].Name < defs[j].Name })
+	return defs
+}
+
+func (t *tracker) Track(ctx context.Context, def domain.Definition, fn func(ctx context.Context) error) (err error) {
+	t.registerIfMissing(def)
+	if t.repo == nil {
+		return fn(ctx)
+	}
+
+	// Recording must succeed even when the job's context is cancelled
+	recordCtx := context.WithoutCancel(ctx)
+
+	run, startErr := t.repo.Start(recordCtx, def.Name, def.Kind, t.instance)
+	if startErr != nil {
+		t.logger.Warn("failed to record job start", loggerDomain.Fields{
+			"job":   def.Name,
+			"error": startErr.Error(),
+		})
+		return fn(ctx)
+	}
+
+	started := time.Now()
+	defer func() {
+		// A panicking job is recorded as failed before the panic continues
+		if r := recover(); r != nil {
+			t.finish(recordCtx, run, started, fmt.Errorf("panic: %v", r))
+			panic(r)
+		}
+		t.finish(recordCtx, run, started, err)
+	}()
+
+	return fn(ctx)
+}
+
+func (t *tracker) finish(ctx context.Context, run *domain.Run, started time.Time, jobErr error) {
+	status, errMsg := domain.StatusSucceeded, ""
+	if jobErr != nil {
+		status, errMsg = domain.StatusFailed, jobErr.Error()
+		if len(errMsg) > maxErrorLength {
+			errMsg = errMsg[:maxErrorLength]
+		}
+	}
+
+	if _, err := t.repo.Finish(ctx, run.ID, status, errMsg, time.Since(started)); err != nil {
+		t.logger.Warn("failed to record job outcome", loggerDomain.Fields{
+			"job":    run.Job,
+			"run_id": run.ID,
+			"status": string(status),
+			"error":  err.Error(),
+		})
+	}
+}
+
+func (t *tracker) registerIfMissing(def domain.Definition) {
+	t.mu.RLock()
+	_, ok := t.definitions[def.Name]
+	t.mu.RUnlock()
+	if !ok {
+		t.Register(def)
+	}
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"go.uber.org/dig"
 
 	"github.com/moasq/go-b2b-starter/internal/platform/llm/domain"
@@ -12,7 +14,15 @@ func Init(container *dig.Container) error {
 	// Register LLMClient (which includes LLMService)
 	if err := container.Provide(func(logger loggerDomain.Logger) (domain.LLMClient, error) {
 		config := infra.NewLLMConfig()
-		return infra.NewOpenAIClient(config, logger)
+		switch config.Provider {
+		case infra.ProviderOpenAI:
+			return infra.NewOpenAIClient(config, logger)
+		case infra.ProviderFake:
+			logger.Warn("using fake LLM provider; completions and embeddings are synthetic")
+			return infra.NewFakeClient(logger), nil
+		default:
+			return nil, fmt.Errorf("unknown LLM_PROVIDER %q (expected %q or %q)", config.Provider, infra.ProviderOpenAI, infra.ProviderFake)
+		}
 	}); err != nil {
 		return err
 	}
@@ -21,4 +31,4 @@ func Init(container *dig.Container) error {
 	return container.Provide(func(client domain.LLMClient) domain.LLMService {
 		return client
 	})
-}
\ No newline at end of file
+}
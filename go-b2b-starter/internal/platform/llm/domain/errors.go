@@ -7,4 +7,5 @@ var (
 	ErrProviderNotFound = errors.New("LLM provider not found")
 	ErrAPIError         = errors.New("LLM API error")
 	ErrTimeout          = errors.New("LLM request timeout")
+	ErrInvalidSchema    = errors.New("response schema requires a name and a schema")
 )
\ No newline at end of file
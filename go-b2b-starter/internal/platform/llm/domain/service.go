@@ -6,17 +6,40 @@ type CompletionRequest struct {
 	Prompt      string
 	MaxTokens   *int
 	Temperature *float32
+
+	// Model, when set, replaces the configured model for this request
+	Model string
+
+	// Schema, when set, constrains the completion text to JSON matching it
+	Schema *ResponseSchema
+}
+
+// ResponseSchema is a JSON Schema for structured output. Providers enforce it
+// strictly: every property must be listed in "required" and objects must set
+// "additionalProperties": false.
+type ResponseSchema struct {
+	Name   string
+	Schema map[string]any
 }
 
 type CompletionResponse struct {
 	Text       string
 	TokensUsed int
 	Model      string
+
+	// MaxTokens and Temperature are the parameters the completion was
+	// requested with. Temperature is nil when the model does not take one.
+	MaxTokens   int
+	Temperature *float32
 }
 
 type EmbeddingRequest struct {
 	Text  string
 	Model string
+
+	// Dimensions, when set, asks for an embedding shortened to this many
+	// dimensions. Only models that support shortening accept it.
+	Dimensions int
 }
 
 type EmbeddingResponse struct {
@@ -38,4 +61,5 @@ type LLMService interface {
 type LLMClient interface {
 	LLMService
 	GenerateEmbedding(ctx context.Context, text string, model string) ([]float64, error)
+	CreateEmbedding(ctx context.Context, request EmbeddingRequest) (*EmbeddingResponse, error)
 }
\ No newline at end of file
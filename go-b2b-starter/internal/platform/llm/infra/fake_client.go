@@ -0,0 +1,310 @@
+package infra
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/llm/domain"
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+const (
+	// FakeModel is reported as the model of every fake completion and embedding
+	FakeModel = "fake"
+
+	// FakeEmbeddingDimensions matches the full size of text-embedding-3-small
+	FakeEmbeddingDimensions = 1536
+
+	// fakePreviewChars bounds how much of the top document is quoted in answers
+	fakePreviewChars = 240
+)
+
+var (
+	fakeTokenPattern    = regexp.MustCompile(`[\p{L}\p{N}]+`)
+	fakeDocumentPattern = regexp.MustCompile(`\[Document \d+[^\]]*\]:\n`)
+
+	// fakeWordPattern keeps underscores so enum values like "purchase_order" match
+	fakeWordPattern = regexp.MustCompile(`[\p{L}\p{N}_]+`)
+)
+
+// FakeClient is a deterministic, offline LLMClient for local development and CI.
+//
+// Completions are template answers that echo the question and quote the top
+// document from a RAG prompt. Structured completions are built from the
+// schema: enums pick the value the prompt mentions most. Embeddings hash each word into a fixed-size
+// vector, so texts sharing words are close and similarity search still ranks
+// relevant documents first. The same input always produces the same output.
+type FakeClient struct {
+	logger loggerDomain.Logger
+}
+
+func NewFakeClient(logger loggerDomain.Logger) domain.LLMClient {
+	return &FakeClient{logger: logger}
+}
+
+func (c *FakeClient) Complete(ctx context.Context, request domain.CompletionRequest) (*domain.CompletionResponse, error) {
+	if request.Prompt == "" {
+		return nil, domain.ErrInvalidPrompt
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	if request.Schema != nil {
+		if request.Schema.Name == "" || request.Schema.Schema == nil {
+			return nil, domain.ErrInvalidSchema
+		}
+		data, err := json.Marshal(fakeValue(request.Schema.Schema, fakeWordCounts(request.Prompt)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to build structured completion: %w", err)
+		}
+		return &domain.CompletionResponse{
+			Text:        string(data),
+			TokensUsed:  countTokens(request.Prompt) + countTokens(string(data)),
+			Model:       FakeModel,
+			Temperature: request.Temperature,
+		}, nil
+	}
+
+	text := fakeAnswer(request.Prompt)
+	var maxTokens int
+	if request.MaxTokens != nil && *request.MaxTokens > 0 {
+		maxTokens = *request.MaxTokens
+		if words := strings.Fields(text); len(words) > maxTokens {
+			text = strings.Join(words[:maxTokens], " ")
+		}
+	}
+
+	return &domain.CompletionResponse{
+		Text:        text,
+		TokensUsed:  countTokens(request.Prompt) + countTokens(text),
+		Model:       FakeModel,
+		MaxTokens:   maxTokens,
+		Temperature: request.Temperature,
+	}, nil
+}
+
+func (c *FakeClient) CompleteStream(ctx context.Context, request domain.CompletionRequest, callback func(domain.StreamChunk) error) (*domain.CompletionResponse, error) {
+	response, err := c.Complete(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	words := strings.Fields(response.Text)
+	for i, word := range words {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if i < len(words)-1 {
+			word += " "
+		}
+		if err := callback(domain.StreamChunk{Content: word}); err != nil {
+			return nil, err
+		}
+	}
+	if err := callback(domain.StreamChunk{Done: true}); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GenerateEmbedding returns a normalized bag-of-words vector using the hashing trick.
+func (c *FakeClient) GenerateEmbedding(ctx context.Context, text string, model string) ([]float64, error) {
+	resp, err := c.CreateEmbedding(ctx, domain.EmbeddingRequest{Text: text, Model: model})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Embedding, nil
+}
+
+// CreateEmbedding returns a normalized bag-of-words vector using the hashing
+// trick, with Dimensions buckets when the request sets it.
+func (c *FakeClient) CreateEmbedding(ctx context.Context, request domain.EmbeddingRequest) (*domain.EmbeddingResponse, error) {
+	text := request.Text
+	if text == "" {
+		return nil, fmt.Errorf("text cannot be empty")
+	}
+
+	dimensions := uint64(FakeEmbeddingDimensions)
+	if request.Dimensions > 0 {
+		dimensions = uint64(request.Dimensions)
+	}
+	embedding := make([]float64, dimensions)
+
+	tokens := fakeTokenPattern.FindAllString(strings.ToLower(text), -1)
+	if len(tokens) == 0 {
+		tokens = []string{text}
+	}
+	for _, token := range tokens {
+		h := fnv.New64a()
+		h.Write([]byte(token))
+		sum := h.Sum64()
+
+		// The top bit picks the sign so unrelated words tend to cancel out
+		sign := 1.0
+		if sum>>63 == 1 {
+			sign = -1.0
+		}
+		embedding[sum%dimensions] += sign
+	}
+
+	var norm float64
+	for _, v := range embedding {
+		norm += v * v
+	}
+	norm = math.Sqrt(norm)
+	if norm > 0 {
+		for i := range embedding {
+			embedding[i] /= norm
+		}
+	}
+
+	return &domain.EmbeddingResponse{
+		Embedding:  embedding,
+		TokensUsed: len(tokens),
+		Model:      FakeModel,
+	}, nil
+}
+
+// fakeAnswer builds the template answer for a prompt. RAG prompts end with
+// "User Question: ..." and carry "[Document N (...)]:" context blocks.
+func fakeAnswer(prompt string) string {
+	question := prompt
+	if idx := strings.LastIndex(prompt, "User Question:"); idx >= 0 {
+		question = prompt[idx+len("User Question:"):]
+	} else if lines := strings.Split(strings.TrimSpace(prompt), "\n"); len(lines) > 0 {
+		question = lines[len(lines)-1]
+	}
+	question = strings.Join(strings.Fields(question), " ")
+
+	documents := fakeDocumentPattern.FindAllStringIndex(prompt, -1)
+	if len(documents) == 0 {
+		return fmt.Sprintf("[offline answer] You asked: %q. No document context was provided.", question)
+	}
+
+	preview := prompt[documents[0][1]:]
+	if len(documents) > 1 {
+		preview = prompt[documents[0][1]:documents[1][0]]
+	} else if end := strings.Index(preview, "--- END OF CONTEXT ---"); end >= 0 {
+		preview = preview[:end]
+	}
+	preview = strings.Join(strings.Fields(preview), " ")
+	if runes := []rune(preview); len(runes) > fakePreviewChars {
+		preview = string(runes[:fakePreviewChars]) + "..."
+	}
+
+	return fmt.Sprintf("[offline answer] You asked: %q. Based on %d document(s), the most relevant one says: %s",
+		question, len(documents), preview)
+}
+
+// fakeValue builds the smallest value matching a JSON Schema: objects fill
+// every property, arrays are empty, numbers sit midway between their bounds
+// and enums pick the value with the most whole-word mentions in the prompt,
+// the first one on a tie. Nullable types are null.
+func fakeValue(schema map[string]any, words map[string]int) any {
+	if values, ok := schema["enum"].([]any); ok && len(values) > 0 {
+		best, bestCount := values[0], -1
+		for _, value := range values {
+			if count := words[strings.ToLower(fmt.Sprint(value))]; count > bestCount {
+				best, bestCount = value, count
+			}
+		}
+		return best
+	}
+	if values, ok := schema["enum"].([]string); ok && len(values) > 0 {
+		generic := make([]any, len(values))
+		for i, value := range values {
+			generic[i] = value
+		}
+		return fakeValue(map[string]any{"enum": generic}, words)
+	}
+
+	switch schemaType := schema["type"].(type) {
+	case string:
+		return fakeTypedValue(schemaType, schema, words)
+	case []any:
+		for _, t := range schemaType {
+			if t == "null" {
+				return nil
+			}
+		}
+		if len(schemaType) > 0 {
+			return fakeTypedValue(fmt.Sprint(schemaType[0]), schema, words)
+		}
+	case []string:
+		if slices.Contains(schemaType, "null") {
+			return nil
+		}
+		if len(schemaType) > 0 {
+			return fakeTypedValue(schemaType[0], schema, words)
+		}
+	}
+	return nil
+}
+
+func fakeTypedValue(schemaType string, schema map[string]any, words map[string]int) any {
+	switch schemaType {
+	case "object":
+		object := map[string]any{}
+		properties, _ := schema["properties"].(map[string]any)
+		for name, property := range properties {
+			if propertySchema, ok := property.(map[string]any); ok {
+				object[name] = fakeValue(propertySchema, words)
+			}
+		}
+		return object
+	case "array":
+		return []any{}
+	case "number", "integer":
+		minimum, hasMin := toFloat(schema["minimum"])
+		maximum, hasMax := toFloat(schema["maximum"])
+		if hasMin && hasMax {
+			value := (minimum + maximum) / 2
+			if schemaType == "integer" {
+				return math.Floor(value)
+			}
+			return value
+		}
+		if hasMin {
+			return minimum
+		}
+		return 0
+	case "boolean":
+		return false
+	default:
+		return ""
+	}
+}
+
+func toFloat(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	}
+	return 0, false
+}
+
+// fakeWordCounts counts the lowercase words of a prompt.
+func fakeWordCounts(prompt string) map[string]int {
+	counts := map[string]int{}
+	for _, word := range fakeWordPattern.FindAllString(strings.ToLower(prompt), -1) {
+		counts[word]++
+	}
+	return counts
+}
+
+// countTokens approximates token usage by counting words.
+func countTokens(text string) int {
+	return len(strings.Fields(text))
+}
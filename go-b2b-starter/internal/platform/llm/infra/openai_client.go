@@ -20,7 +20,15 @@ import (
 	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
 )
 
+// Supported values for LLM_PROVIDER.
+const (
+	ProviderOpenAI = "openai"
+	ProviderFake   = "fake"
+)
+
 type Config struct {
+	// Provider is "openai" or "fake" (deterministic, offline)
+	Provider    string
 	APIKey      string
 	Model       string
 	MaxTokens   int
@@ -132,6 +140,19 @@ type openAIRequest struct {
 	Temperature *float32        `json:"temperature,omitempty"`
 	Stop        []string        `json:"stop,omitempty"`
 	Stream      bool            `json:"stream,omitempty"`
+
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type       string            `json:"type"` // "json_schema"
+	JSONSchema *openAIJSONSchema `json:"json_schema,omitempty"`
+}
+
+type openAIJSONSchema struct {
+	Name   string         `json:"name"`
+	Schema map[string]any `json:"schema"`
+	Strict bool           `json:"strict"`
 }
 
 type ToolCall struct {
@@ -193,6 +214,7 @@ func NewLLMConfig() Config {
 	debugMode, _ := strconv.ParseBool(getEnvOrDefault("LLM_DEBUG_MODE", "false"))
 
 	return Config{
+		Provider:    strings.ToLower(getEnvOrDefault("LLM_PROVIDER", ProviderOpenAI)),
 		APIKey:      os.Getenv("OPENAI_API_KEY"),
 		Model:       getEnvOrDefault("OPENAI_MODEL", "gpt-5-mini"),
 		MaxTokens:   maxTokens,
@@ -261,6 +283,14 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 	if request.Prompt == "" {
 		return nil, domain.ErrInvalidPrompt
 	}
+	if request.Schema != nil && (request.Schema.Name == "" || request.Schema.Schema == nil) {
+		return nil, domain.ErrInvalidSchema
+	}
+
+	model := c.config.Model
+	if request.Model != "" {
+		model = request.Model
+	}
 
 	maxTokens := c.config.MaxTokens
 	if request.MaxTokens != nil {
@@ -268,7 +298,7 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 	}
 
 	// Right-size tokens for field extraction - avoid excessive budgets
-	if strings.HasPrefix(c.config.Model, "gpt-5") {
+	if strings.HasPrefix(model, "gpt-5") {
 		// For GPT-5, use smaller budgets unless explicitly requested
 		if maxTokens == c.config.MaxTokens && maxTokens > 200 {
 			maxTokens = 128 // Reasonable default for most extraction tasks
@@ -284,7 +314,7 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 	}
 
 	openAIReq := openAIRequest{
-		Model: c.config.Model,
+		Model: model,
 		Messages: []openAIMessage{
 			{
 				Role:    "user",
@@ -296,12 +326,22 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 	}
 
 	// Only set temperature for models that support it (GPT-5 models don't accept custom temperature)
-	if supportsTemperature(c.config.Model) {
+	if supportsTemperature(model) {
 		openAIReq.Temperature = &temperature
 	}
 
-	// Only set stop sequences for models that support them (GPT-5 models don't accept stop parameter)
-	if supportsStop(c.config.Model) {
+	if request.Schema != nil {
+		// Structured output; stop sequences would cut the JSON short
+		openAIReq.ResponseFormat = &openAIResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &openAIJSONSchema{
+				Name:   request.Schema.Name,
+				Schema: request.Schema.Schema,
+				Strict: true,
+			},
+		}
+	} else if supportsStop(model) {
+		// Only set stop sequences for models that support them (GPT-5 models don't accept stop parameter)
 		openAIReq.Stop = []string{"\n\n", "\n---"}
 	}
 
@@ -309,27 +349,30 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 	if c.config.DebugMode {
 		logData := map[string]any{
 			"endpoint":              "https://api.openai.com/v1/chat/completions",
-			"model":                 c.config.Model,
+			"model":                 model,
 			"input_length":          len(request.Prompt),
 			"max_tokens":           maxTokens,
-			"supports_temperature":  supportsTemperature(c.config.Model),
-			"supports_stop":         supportsStop(c.config.Model),
+			"supports_temperature":  supportsTemperature(model),
+			"supports_stop":         supportsStop(model),
 		}
-		if supportsTemperature(c.config.Model) {
+		if supportsTemperature(model) {
 			logData["temperature"] = temperature
 		}
-		if supportsStop(c.config.Model) {
-			logData["stop_sequences"] = []string{"\n\n", "\n---"}
+		if len(openAIReq.Stop) > 0 {
+			logData["stop_sequences"] = openAIReq.Stop
+		}
+		if request.Schema != nil {
+			logData["response_schema"] = request.Schema.Name
 		}
 		c.logger.Info("Starting OpenAI request", logData)
 
-		debugMsg := fmt.Sprintf("[DEBUG] Starting OpenAI request - Model: %s | MaxTokens: %d", c.config.Model, maxTokens)
-		if supportsTemperature(c.config.Model) {
+		debugMsg := fmt.Sprintf("[DEBUG] Starting OpenAI request - Model: %s | MaxTokens: %d", model, maxTokens)
+		if supportsTemperature(model) {
 			debugMsg += fmt.Sprintf(" | Temperature: %.1f", temperature)
 		} else {
 			debugMsg += " | Temperature: OMITTED"
 		}
-		if supportsStop(c.config.Model) {
+		if len(openAIReq.Stop) > 0 {
 			debugMsg += " | Stop: [\\n\\n, \\n---]"
 		} else {
 			debugMsg += " | Stop: OMITTED"
@@ -344,7 +387,7 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 	if c.circuitBreaker != nil && !c.circuitBreaker.CanExecute() {
 		stats := c.circuitBreaker.GetStats()
 		c.logger.Warn("Circuit breaker is open, request blocked", map[string]any{
-			"model":         c.config.Model,
+			"model":         model,
 			"breaker_state": stats["state"],
 			"failures":      stats["failures"],
 			"successes":     stats["successes"],
@@ -356,7 +399,7 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 	for i := 0; i <= c.config.MaxRetries; i++ {
 		// Create fresh context per attempt - THIS FIXES THE MAIN BUG
 		callTimeout := time.Duration(c.config.TimeoutSec) * time.Second
-		if strings.HasPrefix(c.config.Model, "gpt-5") {
+		if strings.HasPrefix(model, "gpt-5") {
 			callTimeout += 30 * time.Second // Extra time for reasoning models
 		}
 		callCtx, cancel := context.WithTimeout(ctx, callTimeout)
@@ -385,7 +428,7 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 		// Don't retry permanent errors
 		if isPerm {
 			c.logger.Error("Permanent error detected, not retrying", map[string]any{
-				"model":       c.config.Model,
+				"model":       model,
 				"error":       err.Error(),
 				"error_type":  "permanent",
 				"attempt":     i + 1,
@@ -397,7 +440,7 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 			c.logger.Warn("OpenAI request failed, retrying", map[string]any{
 				"attempt":     i + 1,
 				"max_retries": c.config.MaxRetries,
-				"model":       c.config.Model,
+				"model":       model,
 				"error":       err.Error(),
 				"error_type":  map[bool]string{true: "temporary", false: "unknown"}[isTemp],
 				"will_retry":  true,
@@ -413,14 +456,16 @@ func (c *OpenAIClient) Complete(ctx context.Context, request domain.CompletionRe
 	if err != nil {
 		c.logger.Error("OpenAI request failed after all retries", map[string]any{
 			"error":       err.Error(),
-			"model":       c.config.Model,
+			"model":       model,
 			"endpoint":    "https://api.openai.com/v1/chat/completions",
 			"max_retries": c.config.MaxRetries,
 		})
-		fmt.Println("[ERROR] OpenAI request failed after all retries:", err.Error(), "Model:", c.config.Model)
+		fmt.Println("[ERROR] OpenAI request failed after all retries:", err.Error(), "Model:", model)
 		return nil, err
 	}
 
+	response.MaxTokens = maxTokens
+	response.Temperature = openAIReq.Temperature
 	return response, nil
 }
 
@@ -428,6 +473,14 @@ func (c *OpenAIClient) CompleteStream(ctx context.Context, request domain.Comple
 	if request.Prompt == "" {
 		return nil, domain.ErrInvalidPrompt
 	}
+	if request.Schema != nil && (request.Schema.Name == "" || request.Schema.Schema == nil) {
+		return nil, domain.ErrInvalidSchema
+	}
+
+	model := c.config.Model
+	if request.Model != "" {
+		model = request.Model
+	}
 
 	maxTokens := c.config.MaxTokens
 	if request.MaxTokens != nil {
@@ -435,7 +488,7 @@ func (c *OpenAIClient) CompleteStream(ctx context.Context, request domain.Comple
 	}
 
 	// Right-size tokens for field extraction - avoid excessive budgets
-	if strings.HasPrefix(c.config.Model, "gpt-5") {
+	if strings.HasPrefix(model, "gpt-5") {
 		// For GPT-5, use smaller budgets unless explicitly requested
 		if maxTokens == c.config.MaxTokens && maxTokens > 200 {
 			maxTokens = 128 // Reasonable default for most extraction tasks
@@ -451,7 +504,7 @@ func (c *OpenAIClient) CompleteStream(ctx context.Context, request domain.Comple
 	}
 
 	openAIReq := openAIRequest{
-		Model: c.config.Model,
+		Model: model,
 		Messages: []openAIMessage{
 			{
 				Role:    "user",
@@ -463,18 +516,27 @@ func (c *OpenAIClient) CompleteStream(ctx context.Context, request domain.Comple
 	}
 
 	// Only set temperature for models that support it
-	if supportsTemperature(c.config.Model) {
+	if supportsTemperature(model) {
 		openAIReq.Temperature = &temperature
 	}
 
-	// Only set stop sequences for models that support them
-	if supportsStop(c.config.Model) {
+	if request.Schema != nil {
+		openAIReq.ResponseFormat = &openAIResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &openAIJSONSchema{
+				Name:   request.Schema.Name,
+				Schema: request.Schema.Schema,
+				Strict: true,
+			},
+		}
+	} else if supportsStop(model) {
+		// Only set stop sequences for models that support them
 		openAIReq.Stop = []string{"\n\n", "\n---"}
 	}
 
 	if c.config.DebugMode {
 		c.logger.Info("Starting OpenAI streaming request", map[string]any{
-			"model":      c.config.Model,
+			"model":      model,
 			"max_tokens": maxTokens,
 			"stream":     true,
 		})
@@ -483,26 +545,41 @@ func (c *OpenAIClient) CompleteStream(ctx context.Context, request domain.Comple
 	var response *domain.CompletionResponse
 	var err error
 
+	// A retry after chunks reached the caller would repeat them
+	streamed := false
+	streamCallback := callback
+	if callback != nil {
+		streamCallback = func(chunk domain.StreamChunk) error {
+			streamed = true
+			return callback(chunk)
+		}
+	}
+
 	// Retry with fresh context per attempt
 	for i := 0; i <= c.config.MaxRetries; i++ {
 		callTimeout := time.Duration(c.config.TimeoutSec) * time.Second
-		if strings.HasPrefix(c.config.Model, "gpt-5") {
+		if strings.HasPrefix(model, "gpt-5") {
 			callTimeout += 30 * time.Second
 		}
 		callCtx, cancel := context.WithTimeout(ctx, callTimeout)
 		
-		response, err = c.makeStreamRequest(callCtx, openAIReq, callback)
+		response, err = c.makeStreamRequest(callCtx, openAIReq, streamCallback)
 		cancel()
 		
 		if err == nil {
 			break
 		}
 
+		// A cancelled caller (e.g. a disconnected client) must stop token spend
+		if ctx.Err() != nil || streamed {
+			break
+		}
+
 		if i < c.config.MaxRetries {
 			c.logger.Warn("OpenAI streaming request failed, retrying", map[string]any{
 				"attempt":     i + 1,
 				"max_retries": c.config.MaxRetries,
-				"model":       c.config.Model,
+				"model":       model,
 				"error":       err.Error(),
 			})
 			
@@ -515,12 +592,14 @@ func (c *OpenAIClient) CompleteStream(ctx context.Context, request domain.Comple
 	if err != nil {
 		c.logger.Error("OpenAI streaming request failed after all retries", map[string]any{
 			"error":       err.Error(),
-			"model":       c.config.Model,
+			"model":       model,
 			"max_retries": c.config.MaxRetries,
 		})
 		return nil, err
 	}
 
+	response.MaxTokens = maxTokens
+	response.Temperature = openAIReq.Temperature
 	return response, nil
 }
 
@@ -657,10 +736,22 @@ func supportsStop(model string) bool {
 
 // GenerateEmbedding generates a vector embedding for the given text using OpenAI embeddings API
 func (c *OpenAIClient) GenerateEmbedding(ctx context.Context, text string, model string) ([]float64, error) {
+	resp, err := c.CreateEmbedding(ctx, domain.EmbeddingRequest{Text: text, Model: model})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Embedding, nil
+}
+
+// CreateEmbedding generates a vector embedding using OpenAI embeddings API,
+// shortened by the API when the request sets Dimensions
+func (c *OpenAIClient) CreateEmbedding(ctx context.Context, request domain.EmbeddingRequest) (*domain.EmbeddingResponse, error) {
+	text := request.Text
 	if text == "" {
 		return nil, fmt.Errorf("text cannot be empty")
 	}
 
+	model := request.Model
 	if model == "" {
 		model = "text-embedding-3-small" // Default embedding model
 	}
@@ -669,6 +760,9 @@ func (c *OpenAIClient) GenerateEmbedding(ctx context.Context, text string, model
 		"model": model,
 		"input": text,
 	}
+	if request.Dimensions > 0 {
+		embeddingReq["dimensions"] = request.Dimensions
+	}
 
 	jsonData, err := json.Marshal(embeddingReq)
 	if err != nil {
@@ -735,7 +829,11 @@ func (c *OpenAIClient) GenerateEmbedding(ctx context.Context, text string, model
 		})
 	}
 
-	return embedding, nil
+	return &domain.EmbeddingResponse{
+		Embedding:  embedding,
+		TokensUsed: embeddingResp.Usage.TotalTokens,
+		Model:      model,
+	}, nil
 }
 
 type streamResponse struct {
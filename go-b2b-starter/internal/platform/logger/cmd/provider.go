@@ -6,5 +6,10 @@ import (
 )
 
 func ProvideDependencies(container *dig.Container) {
-	container.Provide(logger.New)
+	container.Provide(logger.LoadConfig)
+	container.Provide(func(cfg *logger.Config) *logger.Levels {
+		return logger.NewLevels(cfg.DefaultLevel, cfg.ModuleLevelMap)
+	})
+	container.Provide(logger.NewFromConfig)
+	container.Provide(logger.NewLevelHandler)
 }
@@ -0,0 +1,114 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+)
+
+// Config controls log output and levels.
+//
+// LOG_FORMAT defaults to pretty console output in development and JSON when
+// ENV=PROD. LOG_MODULE_LEVELS overrides LOG_LEVEL per module namespace, e.g.
+// "billing=debug,auth.middleware=warn".
+type Config struct {
+	// Env is the deployment environment (DEV or PROD)
+	Env string `mapstructure:"ENV"`
+
+	// Level is the default minimum level (debug, info, warn, error)
+	Level string `mapstructure:"LOG_LEVEL"`
+
+	// Format is "console" or "json"; empty picks by environment
+	Format string `mapstructure:"LOG_FORMAT"`
+
+	// ModuleLevels lists comma-separated module=level overrides
+	ModuleLevels string `mapstructure:"LOG_MODULE_LEVELS"`
+
+	// DefaultLevel is the parsed form of Level
+	DefaultLevel domain.Level `mapstructure:"-"`
+
+	// OutputFormat is the parsed form of Format
+	OutputFormat domain.Format `mapstructure:"-"`
+
+	// ModuleLevelMap is the parsed form of ModuleLevels
+	ModuleLevelMap map[string]domain.Level `mapstructure:"-"`
+}
+
+// LoadConfig loads the logging configuration from environment variables and app.env file.
+func LoadConfig() (*Config, error) {
+	v := viper.New()
+	v.SetConfigName("app")
+	v.SetConfigType("env")
+	v.AddConfigPath(".")
+	v.AutomaticEnv()
+
+	// Set defaults
+	v.SetDefault("ENV", "DEV")
+	v.SetDefault("LOG_LEVEL", "info")
+	v.SetDefault("LOG_FORMAT", "")
+	v.SetDefault("LOG_MODULE_LEVELS", "")
+
+	// Try to read config file (ignore if not found)
+	if err := v.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, fmt.Errorf("failed to read config: %w", err)
+		}
+	}
+
+	var cfg Config
+	if err := v.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unable to decode logger config: %w", err)
+	}
+
+	if err := cfg.parse(); err != nil {
+		return nil, err
+	}
+
+	return &cfg, nil
+}
+
+// parse validates the raw values and fills the parsed fields.
+func (c *Config) parse() error {
+	level, err := domain.ParseLevel(c.Level)
+	if err != nil {
+		return fmt.Errorf("logger config invalid: LOG_LEVEL: %w", err)
+	}
+	c.DefaultLevel = level
+
+	switch strings.ToLower(strings.TrimSpace(c.Format)) {
+	case "":
+		c.OutputFormat = domain.PrettyFormat
+		if strings.EqualFold(c.Env, "PROD") {
+			c.OutputFormat = domain.JSONFormat
+		}
+	case "console", "pretty":
+		c.OutputFormat = domain.PrettyFormat
+	case "json":
+		c.OutputFormat = domain.JSONFormat
+	default:
+		return fmt.Errorf("logger config invalid: LOG_FORMAT must be console or json, got %q", c.Format)
+	}
+
+	c.ModuleLevelMap = make(map[string]domain.Level)
+	for _, item := range strings.Split(c.ModuleLevels, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		module, name, ok := strings.Cut(item, "=")
+		module = strings.TrimSpace(module)
+		if !ok || module == "" {
+			return fmt.Errorf("logger config invalid: LOG_MODULE_LEVELS entry %q must be module=level", item)
+		}
+		level, err := domain.ParseLevel(name)
+		if err != nil {
+			return fmt.Errorf("logger config invalid: LOG_MODULE_LEVELS %s: %w", module, err)
+		}
+		c.ModuleLevelMap[module] = level
+	}
+
+	return nil
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// String returns the lowercase name of the level.
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case FatalLevel:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseLevel parses a level name (debug, info, warn, error, fatal).
+func ParseLevel(name string) (Level, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "debug":
+		return DebugLevel, nil
+	case "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	case "fatal":
+		return FatalLevel, nil
+	default:
+		return InfoLevel, fmt.Errorf("unknown log level %q", name)
+	}
+}
+
+// Levels holds the default minimum level and overrides per module namespace.
+//
+// Module namespaces are dotted ("billing.webhooks"); a logger uses the most
+// specific override that matches its namespace ("billing.webhooks", then
+// "billing") and falls back to the default. Levels is shared by all loggers
+// created from the same factory, so changes apply immediately without restart.
+type Levels struct {
+	mu       sync.RWMutex
+	defLevel Level
+	modules  map[string]Level
+}
+
+// NewLevels creates Levels with the default level and module overrides.
+func NewLevels(defLevel Level, modules map[string]Level) *Levels {
+	overrides := make(map[string]Level, len(modules))
+	for module, level := range modules {
+		overrides[module] = level
+	}
+	return &Levels{
+		defLevel: defLevel,
+		modules:  overrides,
+	}
+}
+
+// Enabled reports whether a message at level should be logged for the module.
+func (l *Levels) Enabled(module string, level Level) bool {
+	return level >= l.Level(module)
+}
+
+// Level returns the effective minimum level for the module.
+func (l *Levels) Level(module string) Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	for module != "" {
+		if level, ok := l.modules[module]; ok {
+			return level
+		}
+		i := strings.LastIndex(module, ".")
+		if i < 0 {
+			break
+		}
+		module = module[:i]
+	}
+	return l.defLevel
+}
+
+// SetDefault changes the level of modules without an override.
+func (l *Levels) SetDefault(level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.defLevel = level
+}
+
+// SetModule overrides the level of the module and its sub-namespaces.
+func (l *Levels) SetModule(module string, level Level) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.modules[module] = level
+}
+
+// ResetModule removes the module's override.
+func (l *Levels) ResetModule(module string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.modules, module)
+}
+
+// Snapshot returns the default level and a copy of the module overrides.
+func (l *Levels) Snapshot() (Level, map[string]Level) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	modules := make(map[string]Level, len(l.modules))
+	for module, level := range l.modules {
+		modules[module] = level
+	}
+	return l.defLevel, modules
+}
@@ -18,6 +18,16 @@ const (
 	BothOutput
 )
 
+// Format selects how console output is rendered. File output is always JSON.
+type Format int
+
+const (
+	// PrettyFormat renders human-readable, colored lines (development)
+	PrettyFormat Format = iota
+	// JSONFormat writes one JSON object per line (production log collectors)
+	JSONFormat
+)
+
 type Fields = map[string]interface{}
 
 type Logger interface {
@@ -27,4 +37,9 @@ type Logger interface {
 	Error(msg string, fields ...Fields)
 	Fatal(msg string, fields ...Fields)
 	WithFields(fields Fields) Logger
+
+	// Named returns a logger for a module namespace. Names nest with dots
+	// ("billing" then "webhooks" gives "billing.webhooks"); the namespace is
+	// written as the "module" field and selects per-module levels.
+	Named(module string) Logger
 }
@@ -5,7 +5,11 @@ type Option func(*Options)
 type Options struct {
 	Level       Level
 	Output      OutputType
+	Format      Format
 	FileOptions FileOptions
+
+	// Levels overrides Level with runtime-adjustable, per-module levels
+	Levels *Levels
 }
 
 type FileOptions struct {
@@ -28,6 +32,18 @@ func WithOutput(output OutputType) Option {
 	}
 }
 
+func WithFormat(format Format) Option {
+	return func(o *Options) {
+		o.Format = format
+	}
+}
+
+func WithLevels(levels *Levels) Option {
+	return func(o *Options) {
+		o.Levels = levels
+	}
+}
+
 func WithFileOptions(fileOpts FileOptions) Option {
 	return func(o *Options) {
 		o.FileOptions = fileOpts
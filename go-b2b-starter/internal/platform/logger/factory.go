@@ -5,6 +5,7 @@ import (
 	zerolog "github.com/moasq/go-b2b-starter/internal/platform/logger/internal/zerologger"
 )
 
+// New creates a logger. Without WithLevels, the level is fixed at Level.
 func New(opts ...domain.Option) domain.Logger {
 	options := &domain.Options{
 		Level:  domain.InfoLevel,
@@ -23,12 +24,24 @@ func New(opts ...domain.Option) domain.Logger {
 	return zerolog.NewLogger(options)
 }
 
+// NewFromConfig creates a logger with the configured format and shared,
+// runtime-adjustable levels.
+func NewFromConfig(cfg *Config, levels *domain.Levels) domain.Logger {
+	return New(
+		domain.WithLevel(cfg.DefaultLevel),
+		domain.WithFormat(cfg.OutputFormat),
+		domain.WithLevels(levels),
+	)
+}
+
 // Re-export types and constants for ease of use
 type (
 	Logger = domain.Logger
 	Fields = domain.Fields
 	Level  = domain.Level
 	Option = domain.Option
+	Format = domain.Format
+	Levels = domain.Levels
 )
 
 var (
@@ -42,7 +55,15 @@ var (
 	FileOutput    = domain.FileOutput
 	BothOutput    = domain.BothOutput
 
+	PrettyFormat = domain.PrettyFormat
+	JSONFormat   = domain.JSONFormat
+
+	NewLevels  = domain.NewLevels
+	ParseLevel = domain.ParseLevel
+
 	WithLevel       = domain.WithLevel
 	WithOutput      = domain.WithOutput
+	WithFormat      = domain.WithFormat
+	WithLevels      = domain.WithLevels
 	WithFileOptions = domain.WithFileOptions
 )
@@ -0,0 +1,141 @@
+package logger
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	serverDomain "github.com/moasq/go-b2b-starter/internal/platform/server/domain"
+	"github.com/moasq/go-b2b-starter/pkg/response"
+)
+
+// LevelsResponse describes the current log levels
+type LevelsResponse struct {
+	Level   string            `json:"level"`
+	Modules map[string]string `json:"modules"`
+}
+
+// SetLevelRequest changes the default level, or a module's level when Module is set
+type SetLevelRequest struct {
+	Module string `json:"module"`
+	Level  string `json:"level" binding:"required"`
+}
+
+// LevelHandler changes log levels at runtime. It is an operator endpoint
+// behind the "operator" middleware rather than user authentication, since levels
+// apply to the whole process and not to one organization.
+type LevelHandler struct {
+	levels *domain.Levels
+	logger Logger
+}
+
+func NewLevelHandler(levels *domain.Levels, logger Logger) *LevelHandler {
+	return &LevelHandler{
+		levels: levels,
+		logger: logger.Named("logger"),
+	}
+}
+
+// Routes registers the log level endpoints
+func (h *LevelHandler) Routes(router *gin.RouterGroup, resolver serverDomain.MiddlewareResolver) {
+	group := router.Group("/admin/log-levels")
+	group.Use(resolver.Get("operator"))
+	{
+		group.GET("", h.GetLevels)
+		group.PUT("", h.SetLevel)
+		group.DELETE("/:module", h.ResetModule)
+	}
+}
+
+// GetLevels godoc
+// @Summary Get log levels
+// @Description Returns the default log level and per-module overrides.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Success 200 {object} LevelsResponse "Current levels"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Router /admin/log-levels [get]
+func (h *LevelHandler) GetLevels(c *gin.Context) {
+	response.Success(c, http.StatusOK, h.snapshot())
+}
+
+// SetLevel godoc
+// @Summary Set log level
+// @Description Changes the default log level, or the level of a module namespace and its sub-namespaces when module is set. Takes effect immediately and lasts until restart.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param request body SetLevelRequest true "Module and level"
+// @Success 200 {object} LevelsResponse "Updated levels"
+// @Failure 400 {object} map[string]string "Invalid level"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Router /admin/log-levels [put]
+func (h *LevelHandler) SetLevel(c *gin.Context) {
+	var req SetLevelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.Error(c, http.StatusBadRequest, "invalid request payload", err)
+		return
+	}
+
+	level, err := domain.ParseLevel(req.Level)
+	if err != nil {
+		response.Error(c, http.StatusBadRequest, err.Error(), err)
+		return
+	}
+
+	module := strings.TrimSpace(req.Module)
+	if module == "" {
+		h.levels.SetDefault(level)
+	} else {
+		h.levels.SetModule(module, level)
+	}
+
+	h.logger.Warn("log level changed", Fields{
+		"target_module": module,
+		"level":         level.String(),
+		"client_ip":     c.ClientIP(),
+	})
+
+	response.Success(c, http.StatusOK, h.snapshot())
+}
+
+// ResetModule godoc
+// @Summary Reset module log level
+// @Description Removes a module's override so it uses the default level again.
+// @Tags admin
+// @Produce json
+// @Param X-Admin-Token header string true "ADMIN_API_TOKEN"
+// @Param module path string true "Module namespace"
+// @Success 200 {object} LevelsResponse "Updated levels"
+// @Failure 401 {object} map[string]string "Invalid admin token"
+// @Failure 404 {object} map[string]string "Endpoint disabled"
+// @Router /admin/log-levels/{module} [delete]
+func (h *LevelHandler) ResetModule(c *gin.Context) {
+	module := c.Param("module")
+	h.levels.ResetModule(module)
+
+	h.logger.Warn("log level override removed", Fields{
+		"target_module": module,
+		"client_ip":     c.ClientIP(),
+	})
+
+	response.Success(c, http.StatusOK, h.snapshot())
+}
+
+func (h *LevelHandler) snapshot() *LevelsResponse {
+	defLevel, modules := h.levels.Snapshot()
+	resp := &LevelsResponse{
+		Level:   defLevel.String(),
+		Modules: make(map[string]string, len(modules)),
+	}
+	for module, level := range modules {
+		resp.Modules[module] = level.String()
+	}
+	return resp
+}
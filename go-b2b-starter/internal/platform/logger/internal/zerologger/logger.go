@@ -11,7 +11,9 @@ import (
 )
 
 type zerologLogger struct {
-	zl zerolog.Logger
+	zl     zerolog.Logger
+	levels *logger.Levels
+	module string
 }
 
 func newZerologLogger(opts *logger.Options) logger.Logger {
@@ -19,7 +21,7 @@ func newZerologLogger(opts *logger.Options) logger.Logger {
 
 	switch opts.Output {
 	case logger.ConsoleOutput:
-		output = zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
+		output = consoleWriter(opts.Format)
 	case logger.FileOutput:
 		output = &lumberjack.Logger{
 			Filename:   opts.FileOptions.Filename,
@@ -29,7 +31,6 @@ func newZerologLogger(opts *logger.Options) logger.Logger {
 			Compress:   opts.FileOptions.Compress,
 		}
 	case logger.BothOutput:
-		consoleWriter := zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
 		fileWriter := &lumberjack.Logger{
 			Filename:   opts.FileOptions.Filename,
 			MaxSize:    opts.FileOptions.MaxSize,
@@ -37,7 +38,7 @@ func newZerologLogger(opts *logger.Options) logger.Logger {
 			MaxAge:     opts.FileOptions.MaxAge,
 			Compress:   opts.FileOptions.Compress,
 		}
-		output = zerolog.MultiLevelWriter(consoleWriter, fileWriter)
+		output = zerolog.MultiLevelWriter(consoleWriter(opts.Format), fileWriter)
 	default:
 		output = os.Stdout
 	}
@@ -46,37 +47,71 @@ func newZerologLogger(opts *logger.Options) logger.Logger {
 
 	zl := zerolog.New(output).With().Timestamp().Logger()
 
-	// Set the log level
-	zl = zl.Level(convertLogLevel(opts.Level))
+	// Levels are checked per module on each call, so zerolog passes everything
+	levels := opts.Levels
+	if levels == nil {
+		levels = logger.NewLevels(opts.Level, nil)
+	}
+	zl = zl.Level(zerolog.DebugLevel)
+
+	return &zerologLogger{zl: zl, levels: levels}
+}
 
-	return &zerologLogger{zl: zl}
+// consoleWriter returns stdout rendered in the given format.
+func consoleWriter(format logger.Format) io.Writer {
+	if format == logger.JSONFormat {
+		return os.Stdout
+	}
+	return zerolog.ConsoleWriter{Out: os.Stdout, TimeFormat: time.RFC3339}
 }
 
 func (l *zerologLogger) Debug(msg string, fields ...logger.Fields) {
-	l.log(l.zl.Debug(), msg, fields...)
+	l.log(logger.DebugLevel, msg, fields...)
 }
 
 func (l *zerologLogger) Info(msg string, fields ...logger.Fields) {
-	l.log(l.zl.Info(), msg, fields...)
+	l.log(logger.InfoLevel, msg, fields...)
 }
 
 func (l *zerologLogger) Warn(msg string, fields ...logger.Fields) {
-	l.log(l.zl.Warn(), msg, fields...)
+	l.log(logger.WarnLevel, msg, fields...)
 }
 
 func (l *zerologLogger) Error(msg string, fields ...logger.Fields) {
-	l.log(l.zl.Error(), msg, fields...)
+	l.log(logger.ErrorLevel, msg, fields...)
 }
 
 func (l *zerologLogger) Fatal(msg string, fields ...logger.Fields) {
-	l.log(l.zl.Fatal(), msg, fields...)
+	l.log(logger.FatalLevel, msg, fields...)
 }
 
 func (l *zerologLogger) WithFields(fields logger.Fields) logger.Logger {
-	return &zerologLogger{zl: l.zl.With().Fields(fields).Logger()}
+	return &zerologLogger{zl: l.zl.With().Fields(fields).Logger(), levels: l.levels, module: l.module}
 }
 
-func (l *zerologLogger) log(event *zerolog.Event, msg string, fields ...logger.Fields) {
+func (l *zerologLogger) Named(module string) logger.Logger {
+	if l.module != "" {
+		module = l.module + "." + module
+	}
+	return &zerologLogger{zl: l.zl, levels: l.levels, module: module}
+}
+
+func (l *zerologLogger) log(level logger.Level, msg string, fields ...logger.Fields) {
+	// Fatal always logs since it exits the process
+	if level != logger.FatalLevel && !l.levels.Enabled(l.module, level) {
+		return
+	}
+
+	// WithLevel does not exit on fatal, so Fatal keeps its own event
+	var event *zerolog.Event
+	if level == logger.FatalLevel {
+		event = l.zl.Fatal()
+	} else {
+		event = l.zl.WithLevel(convertLogLevel(level))
+	}
+	if l.module != "" {
+		event.Str("module", l.module)
+	}
 	if len(fields) > 0 {
 		event.Fields(fields[0])
 	}
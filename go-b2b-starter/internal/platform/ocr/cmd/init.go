@@ -1,16 +1,26 @@
 package cmd
 
 import (
+	"fmt"
+
 	"go.uber.org/dig"
 
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
 	"github.com/moasq/go-b2b-starter/internal/platform/ocr/domain"
 	"github.com/moasq/go-b2b-starter/internal/platform/ocr/infra"
-	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
 )
 
 func Init(container *dig.Container) error {
 	return container.Provide(func(logger loggerDomain.Logger) (domain.OCRService, error) {
 		config := infra.NewOCRConfig()
-		return infra.NewMistralOCRClient(config, logger)
+		switch config.Provider {
+		case infra.ProviderMistral:
+			return infra.NewMistralOCRClient(config, logger)
+		case infra.ProviderFake:
+			logger.Warn("using fake OCR provider; extracted text is canned")
+			return infra.NewFakeOCRClient(logger), nil
+		default:
+			return nil, fmt.Errorf("unknown OCR_PROVIDER %q (expected %q or %q)", config.Provider, infra.ProviderMistral, infra.ProviderFake)
+		}
 	})
-}
\ No newline at end of file
+}
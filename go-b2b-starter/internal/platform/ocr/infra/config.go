@@ -4,9 +4,18 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+)
+
+// Supported values for OCR_PROVIDER.
+const (
+	ProviderMistral = "mistral"
+	ProviderFake    = "fake"
 )
 
 type Config struct {
+	// Provider is "mistral" or "fake" (deterministic, offline)
+	Provider      string
 	MistralAPIKey string
 	APIEndpoint   string
 	TimeoutSec    int
@@ -26,6 +35,7 @@ func NewOCRConfig() Config {
 	timeoutSec, _ := strconv.Atoi(getEnvOrDefault("OCR_TIMEOUT_SEC", "120"))
 
 	return Config{
+		Provider:      strings.ToLower(getEnvOrDefault("OCR_PROVIDER", ProviderMistral)),
 		MistralAPIKey: os.Getenv("MISTRAL_API_KEY"),
 		APIEndpoint:   getEnvOrDefault("MISTRAL_OCR_ENDPOINT", "https://api.mistral.ai/v1/ocr"),
 		TimeoutSec:    timeoutSec,
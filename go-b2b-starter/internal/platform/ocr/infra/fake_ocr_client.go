@@ -0,0 +1,112 @@
+package infra
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/ocr/domain"
+)
+
+// fakeConfidence is above the documents module's review threshold
+const fakeConfidence = 0.95
+
+// pdfPagePattern matches page objects (but not the /Pages tree) in a PDF
+var pdfPagePattern = regexp.MustCompile(`/Type\s*/Page[^s]`)
+
+// FakeOCRClient is a deterministic, offline OCRService for local development and CI.
+//
+// It returns a canned invoice for PDFs and a canned receipt for images. The
+// document number is derived from the file contents, so the same file always
+// yields the same text and different files yield different text.
+type FakeOCRClient struct {
+	logger loggerDomain.Logger
+}
+
+func NewFakeOCRClient(logger loggerDomain.Logger) domain.OCRService {
+	return &FakeOCRClient{logger: logger}
+}
+
+func (f *FakeOCRClient) ExtractText(ctx context.Context, base64File string, mimeType string) (*domain.OCRResponse, error) {
+	data, err := base64.StdEncoding.DecodeString(base64File)
+	if err != nil {
+		return nil, fmt.Errorf("%w: file is not valid base64", domain.ErrInvalidInput)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrInvalidInput)
+	}
+
+	sum := sha256.Sum256(data)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sum[:3]))
+
+	var text string
+	pages := 1
+
+	switch {
+	case mimeType == "application/pdf":
+		if count := len(pdfPagePattern.FindAll(data, -1)); count > 0 {
+			pages = count
+		}
+		text = fmt.Sprintf(fakeInvoiceText, fingerprint)
+	case strings.HasPrefix(mimeType, "image/"):
+		text = fmt.Sprintf(fakeReceiptText, fingerprint)
+	default:
+		return nil, domain.ErrUnsupportedFile
+	}
+
+	f.logger.Info("fake OCR extraction completed", map[string]any{
+		"mime_type":   mimeType,
+		"pages":       pages,
+		"text_length": len(text),
+		"fingerprint": fingerprint,
+	})
+
+	return &domain.OCRResponse{
+		Text:       text,
+		Pages:      pages,
+		Confidence: fakeConfidence,
+	}, nil
+}
+
+const fakeInvoiceText = `# INVOICE
+
+Invoice Number: INV-%s
+Date: January 15, 2024
+
+Bill To:
+ABC Company
+123 Main Street
+City, State 12345
+
+| Description | Qty | Unit Price | Total |
+|-------------|-----|------------|-------|
+| Professional Services | 10 | $150.00 | $1,500.00 |
+| Consulting | 5 | $200.00 | $1,000.00 |
+
+Subtotal: $2,500.00
+Tax: $250.00
+Total: $2,750.00
+
+Payment Terms: Net 30
+Due Date: February 15, 2024`
+
+const fakeReceiptText = `# RECEIPT
+
+Store: Tech Solutions Inc.
+Date: 2024-01-10
+Receipt #: R-%s
+
+Items:
+- Software License    $299.99
+- Support Package     $99.99
+
+Subtotal: $399.98
+Tax: $32.00
+Total: $431.98
+
+Thank you for your business!`
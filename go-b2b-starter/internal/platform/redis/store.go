@@ -10,4 +10,7 @@ type Client interface {
 	Get(ctx context.Context, key string) (string, error)
 	Delete(ctx context.Context, key string) error
 	Exists(ctx context.Context, key string) (bool, error)
+	// Incr increments a counter and starts its ttl on the first increment,
+	// returning the new value and the time left until the counter resets
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, time.Duration, error)
 }
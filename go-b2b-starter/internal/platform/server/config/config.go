@@ -54,6 +54,11 @@ type Config struct {
 	SecurityLogPath  string `mapstructure:"SECURITY_LOG_PATH"`
 	LogRetentionDays int    `mapstructure:"LOG_RETENTION_DAYS"`
 
+	// Response compression
+	CompressionEnabled   bool     `mapstructure:"COMPRESSION_ENABLED"`
+	CompressionMinSize   int      `mapstructure:"COMPRESSION_MIN_SIZE"`  // Smaller bodies are sent as-is
+	CompressionEncodings []string `mapstructure:"COMPRESSION_ENCODINGS"` // Server preference order
+
 	// Processing Settings
 	ExtractionTimeoutSeconds int `mapstructure:"EXTRACTION_TIMEOUT_SECONDS"`
 	
@@ -104,6 +109,9 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault("DISABLE_PATH_TRAVERSAL", false)
 	viper.SetDefault("SECURITY_LOG_PATH", "logs/security.log")
 	viper.SetDefault("LOG_RETENTION_DAYS", 30)
+	viper.SetDefault("COMPRESSION_ENABLED", true)
+	viper.SetDefault("COMPRESSION_MIN_SIZE", 1024)
+	viper.SetDefault("COMPRESSION_ENCODINGS", []string{"zstd", "gzip"})
 	viper.SetDefault("EXTRACTION_TIMEOUT_SECONDS", 60)
 	viper.SetDefault("DUPLICATE_SIMILARITY_THRESHOLD", 0.85)
 	viper.SetDefault("DUPLICATE_SEARCH_LIMIT", 10)
@@ -116,6 +124,10 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	if err := validateCompressionConfig(cfg); err != nil {
+		return nil, err
+	}
+
 	// Validate production configuration
 	if cfg.Env == PROD {
 		if err := validateProductionConfig(cfg); err != nil {
@@ -126,6 +138,23 @@ func LoadConfig() (*Config, error) {
 	return cfg, nil
 }
 
+func validateCompressionConfig(cfg *Config) error {
+	if !cfg.CompressionEnabled {
+		return nil
+	}
+	if cfg.CompressionMinSize < 0 {
+		return fmt.Errorf("invalid compression configuration: COMPRESSION_MIN_SIZE must not be negative")
+	}
+	for i, encoding := range cfg.CompressionEncodings {
+		encoding = strings.ToLower(strings.TrimSpace(encoding))
+		if encoding != "zstd" && encoding != "gzip" {
+			return fmt.Errorf("invalid compression configuration: unsupported encoding %q (use zstd or gzip)", encoding)
+		}
+		cfg.CompressionEncodings[i] = encoding
+	}
+	return nil
+}
+
 func validateProductionConfig(cfg *Config) error {
 	var errors []string
 
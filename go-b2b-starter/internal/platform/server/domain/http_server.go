@@ -143,10 +143,11 @@ func (s *HTTPServer) Get(name string) gin.HandlerFunc {
 	if middleware, exists := s.namedMiddlewares[name]; exists {
 		return middleware()
 	}
-	// Return a no-op middleware if not found
+	// Fail closed: an unknown name is usually a mistyped guard (e.g. "perm:org:mange"),
+	// and letting requests through would silently skip the check.
+	s.logger.Errorw("Middleware not found", "name", name)
 	return func(c *gin.Context) {
-		s.logger.Warnw("Middleware not found", "name", name)
-		c.Next()
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
 	}
 }
 
@@ -19,6 +19,17 @@ func (s *HTTPServer) setupMiddleware() {
 		middleware.RequestSanitization(s.config.GetSanitizationConfig()),
 		middleware.Recovery(s.logger),
 		middleware.RequestSizeLimit(int64(s.config.MaxRequestSize)),
+	)
+
+	// Compress before the timeout middleware so timeout responses go through it too
+	if s.config.CompressionEnabled {
+		s.router.Use(middleware.Compression(middleware.CompressionConfig{
+			Encodings: s.config.CompressionEncodings,
+			MinSize:   s.config.CompressionMinSize,
+		}))
+	}
+
+	s.router.Use(
 		middleware.Timeout(requestTimeout),
 		middleware.RateLimiter(s.config.RateLimitPerSecond),
 		middleware.CORS(s.config.AllowedOrigins),
@@ -0,0 +1,282 @@
+package middleware
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Supported response encodings
+const (
+	EncodingZstd = "zstd"
+	EncodingGzip = "gzip"
+)
+
+// CompressionConfig controls response compression.
+type CompressionConfig struct {
+	// Encodings lists supported encodings in server preference order
+	Encodings []string
+
+	// MinSize is the smallest body worth compressing, in bytes
+	MinSize int
+
+	// ContentTypes lists compressible media types; entries ending in "/"
+	// match a whole type (e.g. "text/")
+	ContentTypes []string
+}
+
+// DefaultCompressibleTypes are text payloads that compress well. Images, PDFs
+// and archives are already compressed, and event streams must not be buffered.
+var DefaultCompressibleTypes = []string{
+	"application/json",
+	"application/problem+json",
+	"application/x-ndjson",
+	"application/xml",
+	"application/javascript",
+	"image/svg+xml",
+	"text/",
+}
+
+var (
+	gzipWriterPool = sync.Pool{New: func() any {
+		w, _ := gzip.NewWriterLevel(io.Discard, gzip.DefaultCompression)
+		return w
+	}}
+	zstdWriterPool = sync.Pool{New: func() any {
+		w, _ := zstd.NewWriter(io.Discard, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return w
+	}}
+)
+
+// Compression compresses responses with the best encoding the client accepts
+// (Accept-Encoding, honoring q-values). Bodies are buffered up to MinSize
+// before deciding, so small responses and non-compressible content types are
+// sent unchanged. Responses that already set Content-Encoding are untouched.
+func Compression(cfg CompressionConfig) gin.HandlerFunc {
+	if len(cfg.ContentTypes) == 0 {
+		cfg.ContentTypes = DefaultCompressibleTypes
+	}
+
+	return func(c *gin.Context) {
+		// HEAD has no body and upgraded connections are not HTTP responses
+		if c.Request.Method == http.MethodHead || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		encoding := NegotiateEncoding(c.GetHeader("Accept-Encoding"), cfg.Encodings)
+		if encoding == "" {
+			c.Next()
+			return
+		}
+
+		cw := &compressWriter{
+			ResponseWriter: c.Writer,
+			encoding:       encoding,
+			cfg:            &cfg,
+		}
+		c.Writer = cw
+		defer func() {
+			cw.close()
+			c.Writer = cw.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// NegotiateEncoding picks the supported encoding with the highest q-value in
+// the Accept-Encoding header, preferring earlier entries of supported on ties.
+// Returns "" when the response should not be encoded.
+func NegotiateEncoding(header string, supported []string) string {
+	if header == "" {
+		return ""
+	}
+
+	weights := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		params := strings.Split(part, ";")
+		name := strings.ToLower(strings.TrimSpace(params[0]))
+		if name == "" {
+			continue
+		}
+		weight := 1.0
+		for _, param := range params[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					weight = parsed
+				}
+			}
+		}
+		weights[name] = weight
+	}
+
+	best, bestWeight := "", 0.0
+	for _, encoding := range supported {
+		weight, ok := weights[encoding]
+		if !ok {
+			weight, ok = weights["*"]
+		}
+		if ok && weight > bestWeight {
+			best, bestWeight = encoding, weight
+		}
+	}
+	return best
+}
+
+// compressWriter buffers the start of the body to decide whether to compress.
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding string
+	cfg      *CompressionConfig
+
+	buf     []byte
+	decided bool
+	encoder io.WriteCloser
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if w.decided {
+		if w.encoder != nil {
+			return w.encoder.Write(data)
+		}
+		return w.ResponseWriter.Write(data)
+	}
+
+	w.buf = append(w.buf, data...)
+	if len(w.buf) < w.cfg.MinSize {
+		return len(data), nil
+	}
+	if err := w.decide(); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow commits the headers, so a body that has not started yet is
+// sent unencoded.
+func (w *compressWriter) WriteHeaderNow() {
+	if !w.decided && len(w.buf) == 0 {
+		w.decided = true
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush sends buffered data for streaming responses.
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		if err := w.decide(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	w.decided = true
+	return w.ResponseWriter.Hijack()
+}
+
+// decide chooses compression from the buffered body and headers, then writes the buffer.
+func (w *compressWriter) decide() error {
+	w.decided = true
+
+	header := w.Header()
+	// Partial content ranges refer to the unencoded body
+	if header.Get("Content-Encoding") == "" && header.Get("Content-Range") == "" && w.compressible(header.Get("Content-Type")) {
+		header.Add("Vary", "Accept-Encoding")
+		if len(w.buf) >= w.cfg.MinSize && bodyAllowed(w.ResponseWriter.Status()) {
+			header.Del("Content-Length")
+			header.Set("Content-Encoding", w.encoding)
+			w.encoder = w.newEncoder()
+		}
+	}
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	if w.encoder != nil {
+		_, err := w.encoder.Write(buf)
+		return err
+	}
+	_, err := w.ResponseWriter.Write(buf)
+	return err
+}
+
+// close writes any buffered body and finishes the encoded stream.
+func (w *compressWriter) close() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.encoder == nil {
+		return
+	}
+	_ = w.encoder.Close()
+
+	switch encoder := w.encoder.(type) {
+	case *gzip.Writer:
+		encoder.Reset(io.Discard)
+		gzipWriterPool.Put(encoder)
+	case *zstd.Encoder:
+		encoder.Reset(io.Discard)
+		zstdWriterPool.Put(encoder)
+	}
+	w.encoder = nil
+}
+
+func (w *compressWriter) newEncoder() io.WriteCloser {
+	if w.encoding == EncodingZstd {
+		encoder := zstdWriterPool.Get().(*zstd.Encoder)
+		encoder.Reset(w.ResponseWriter)
+		return encoder
+	}
+	encoder := gzipWriterPool.Get().(*gzip.Writer)
+	encoder.Reset(w.ResponseWriter)
+	return encoder
+}
+
+func (w *compressWriter) compressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	if mediaType == "" || mediaType == "text/event-stream" {
+		return false
+	}
+	for _, allowed := range w.cfg.ContentTypes {
+		if strings.HasSuffix(allowed, "/") {
+			if strings.HasPrefix(mediaType, allowed) {
+				return true
+			}
+		} else if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// bodyAllowed reports whether a response with the status can carry a body.
+func bodyAllowed(status int) bool {
+	switch {
+	case status >= 100 && status <= 199:
+		return false
+	case status == http.StatusNoContent, status == http.StatusNotModified:
+		return false
+	}
+	return true
+}
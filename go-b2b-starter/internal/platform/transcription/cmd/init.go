@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+
+	"go.uber.org/dig"
+
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/transcription/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/transcription/infra"
+)
+
+func Init(container *dig.Container) error {
+	return container.Provide(func(logger loggerDomain.Logger) (domain.TranscriptionService, error) {
+		config := infra.NewTranscriptionConfig()
+		switch config.Provider {
+		case infra.ProviderWhisper:
+			return infra.NewWhisperClient(config, logger)
+		case infra.ProviderFake:
+			logger.Warn("using fake transcription provider; transcripts are canned")
+			return infra.NewFakeTranscriptionClient(logger), nil
+		default:
+			return nil, fmt.Errorf("unknown TRANSCRIPTION_PROVIDER %q (expected %q or %q)", config.Provider, infra.ProviderWhisper, infra.ProviderFake)
+		}
+	})
+}
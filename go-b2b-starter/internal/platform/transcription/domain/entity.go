@@ -0,0 +1,16 @@
+package domain
+
+// TranscriptionResponse represents the transcript of an audio or video recording
+type TranscriptionResponse struct {
+	Text     string    `json:"text"`     // Full transcript
+	Language string    `json:"language"` // Detected or requested language
+	Duration float64   `json:"duration"` // Length of the recording in seconds
+	Segments []Segment `json:"segments"` // Timestamped stretches of speech, in order
+}
+
+// Segment is a stretch of speech and when it was spoken
+type Segment struct {
+	Start float64 `json:"start"` // Offset from the start of the recording in seconds
+	End   float64 `json:"end"`   // Offset in seconds
+	Text  string  `json:"text"`
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidInput    = errors.New("invalid transcription input")
+	ErrUnsupportedFile = errors.New("unsupported audio or video format")
+	ErrFileTooLarge    = errors.New("recording is too large to transcribe")
+	ErrQuotaExceeded   = errors.New("transcription quota exceeded")
+	ErrAuthFailed      = errors.New("transcription authentication failed")
+)
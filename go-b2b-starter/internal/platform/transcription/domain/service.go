@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// TranscriptionService converts speech in audio and video files to timestamped text.
+// The file name's extension tells the provider the container format.
+type TranscriptionService interface {
+	Transcribe(ctx context.Context, file []byte, fileName string, mimeType string) (*TranscriptionResponse, error)
+}
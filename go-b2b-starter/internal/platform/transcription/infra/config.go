@@ -0,0 +1,58 @@
+package infra
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Supported values for TRANSCRIPTION_PROVIDER.
+const (
+	ProviderWhisper = "whisper"
+	ProviderFake    = "fake"
+)
+
+type Config struct {
+	// Provider is "whisper" or "fake" (deterministic, offline)
+	Provider    string
+	APIKey      string
+	APIEndpoint string
+	Model       string
+	// Language is an optional ISO-639-1 hint; empty lets the model detect it
+	Language   string
+	TimeoutSec int
+}
+
+func (c Config) Validate() error {
+	if c.APIKey == "" {
+		return fmt.Errorf("OpenAI API key is required")
+	}
+	if c.APIEndpoint == "" {
+		return fmt.Errorf("API endpoint is required")
+	}
+	if c.Model == "" {
+		return fmt.Errorf("model is required")
+	}
+	return nil
+}
+
+func NewTranscriptionConfig() Config {
+	timeoutSec, _ := strconv.Atoi(getEnvOrDefault("TRANSCRIPTION_TIMEOUT_SEC", "300"))
+
+	return Config{
+		Provider:    strings.ToLower(getEnvOrDefault("TRANSCRIPTION_PROVIDER", ProviderWhisper)),
+		APIKey:      os.Getenv("OPENAI_API_KEY"),
+		APIEndpoint: getEnvOrDefault("WHISPER_ENDPOINT", "https://api.openai.com/v1/audio/transcriptions"),
+		Model:       getEnvOrDefault("WHISPER_MODEL", "whisper-1"),
+		Language:    strings.ToLower(os.Getenv("TRANSCRIPTION_LANGUAGE")),
+		TimeoutSec:  timeoutSec,
+	}
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
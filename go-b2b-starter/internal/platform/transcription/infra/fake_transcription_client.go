@@ -0,0 +1,77 @@
+package infra
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/transcription/domain"
+)
+
+// fakeSegmentSeconds is the length of each canned segment
+const fakeSegmentSeconds = 7.5
+
+// FakeTranscriptionClient is a deterministic, offline TranscriptionService for local development and CI.
+//
+// It returns a canned meeting transcript with evenly spaced segments. The
+// meeting reference is derived from the file contents, so the same file always
+// yields the same transcript and different files yield different ones.
+type FakeTranscriptionClient struct {
+	logger loggerDomain.Logger
+}
+
+func NewFakeTranscriptionClient(logger loggerDomain.Logger) domain.TranscriptionService {
+	return &FakeTranscriptionClient{logger: logger}
+}
+
+func (f *FakeTranscriptionClient) Transcribe(ctx context.Context, file []byte, fileName string, mimeType string) (*domain.TranscriptionResponse, error) {
+	if len(file) == 0 {
+		return nil, fmt.Errorf("%w: file is empty", domain.ErrInvalidInput)
+	}
+
+	sum := sha256.Sum256(file)
+	fingerprint := strings.ToUpper(hex.EncodeToString(sum[:3]))
+
+	segments := make([]domain.Segment, len(fakeTranscriptLines))
+	texts := make([]string, len(fakeTranscriptLines))
+	for i, line := range fakeTranscriptLines {
+		if strings.Contains(line, "%s") {
+			line = fmt.Sprintf(line, fingerprint)
+		}
+		segments[i] = domain.Segment{
+			Start: float64(i) * fakeSegmentSeconds,
+			End:   float64(i+1) * fakeSegmentSeconds,
+			Text:  line,
+		}
+		texts[i] = line
+	}
+
+	response := &domain.TranscriptionResponse{
+		Text:     strings.Join(texts, " "),
+		Language: "english",
+		Duration: float64(len(segments)) * fakeSegmentSeconds,
+		Segments: segments,
+	}
+
+	f.logger.Info("fake transcription completed", map[string]any{
+		"mime_type":   mimeType,
+		"segments":    len(response.Segments),
+		"text_length": len(response.Text),
+		"fingerprint": fingerprint,
+	})
+
+	return response, nil
+}
+
+var fakeTranscriptLines = []string{
+	"Welcome everyone to the quarterly review, meeting reference MTG-%s.",
+	"First item: revenue for the quarter came in at two point four million dollars.",
+	"That is up twelve percent on the previous quarter, mostly from the EMEA region.",
+	"Second item: the Acme Corp contract renewal is due on March first.",
+	"Legal has reviewed the terms and we expect to sign next week.",
+	"Action items: finance to send the updated forecast by Friday.",
+	"Thanks everyone, we will meet again next month.",
+}
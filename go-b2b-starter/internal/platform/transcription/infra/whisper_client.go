@@ -0,0 +1,204 @@
+package infra
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	loggerDomain "github.com/moasq/go-b2b-starter/internal/platform/logger/domain"
+	"github.com/moasq/go-b2b-starter/internal/platform/transcription/domain"
+)
+
+// whisperMaxFileSize is the largest file the transcription API accepts
+const whisperMaxFileSize = 25 << 20
+
+// whisperFormats are the container formats the transcription API accepts, by extension
+var whisperFormats = []string{".flac", ".m4a", ".mp3", ".mp4", ".mpeg", ".mpga", ".oga", ".ogg", ".wav", ".webm"}
+
+type WhisperClient struct {
+	config Config
+	client *http.Client
+	logger loggerDomain.Logger
+}
+
+// Whisper API response structures (verbose_json)
+type WhisperResponse struct {
+	Text     string           `json:"text"`
+	Language string           `json:"language"`
+	Duration float64          `json:"duration"`
+	Segments []WhisperSegment `json:"segments"`
+}
+
+type WhisperSegment struct {
+	ID    int     `json:"id"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
+
+type WhisperErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+func NewWhisperClient(config Config, logger loggerDomain.Logger) (domain.TranscriptionService, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	client := &http.Client{
+		Timeout: time.Duration(config.TimeoutSec) * time.Second,
+	}
+
+	return &WhisperClient{
+		config: config,
+		client: client,
+		logger: logger,
+	}, nil
+}
+
+func (w *WhisperClient) Transcribe(ctx context.Context, file []byte, fileName string, mimeType string) (*domain.TranscriptionResponse, error) {
+	w.logger.Info("Starting Whisper transcription", map[string]any{
+		"mime_type": mimeType,
+		"bytes":     len(file),
+	})
+
+	if err := w.validateInput(file, fileName); err != nil {
+		return nil, err
+	}
+
+	whisperResponse, err := w.callWhisperAPI(ctx, file, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	response := w.convertResponse(whisperResponse)
+
+	w.logger.Info("Whisper transcription completed", map[string]any{
+		"language":    response.Language,
+		"duration":    response.Duration,
+		"segments":    len(response.Segments),
+		"text_length": len(response.Text),
+	})
+
+	return response, nil
+}
+
+func (w *WhisperClient) validateInput(file []byte, fileName string) error {
+	if len(file) == 0 {
+		return domain.ErrInvalidInput
+	}
+	if len(file) > whisperMaxFileSize {
+		return domain.ErrFileTooLarge
+	}
+	if !slices.Contains(whisperFormats, strings.ToLower(filepath.Ext(fileName))) {
+		return domain.ErrUnsupportedFile
+	}
+	return nil
+}
+
+func (w *WhisperClient) callWhisperAPI(ctx context.Context, file []byte, fileName string) (*WhisperResponse, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	// The API reads the format from the file name's extension
+	part, err := form.CreateFormFile("file", filepath.Base(fileName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	if _, err := part.Write(file); err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	fields := [][2]string{
+		{"model", w.config.Model},
+		{"response_format", "verbose_json"},
+		{"timestamp_granularities[]", "segment"},
+	}
+	if w.config.Language != "" {
+		fields = append(fields, [2]string{"language", w.config.Language})
+	}
+	for _, field := range fields {
+		if err := form.WriteField(field[0], field[1]); err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", w.config.APIEndpoint, &body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+w.config.APIKey)
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized:
+		return nil, domain.ErrAuthFailed
+	case http.StatusTooManyRequests:
+		return nil, domain.ErrQuotaExceeded
+	case http.StatusRequestEntityTooLarge:
+		return nil, domain.ErrFileTooLarge
+	case http.StatusBadRequest:
+		var apiErr WhisperErrorResponse
+		if json.Unmarshal(respBody, &apiErr) == nil && apiErr.Error.Message != "" {
+			return nil, fmt.Errorf("%w: %s", domain.ErrInvalidInput, apiErr.Error.Message)
+		}
+		return nil, domain.ErrInvalidInput
+	default:
+		return nil, fmt.Errorf("API error (status %d): %s", resp.StatusCode, resp.Status)
+	}
+
+	var response WhisperResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &response, nil
+}
+
+func (w *WhisperClient) convertResponse(whisperResponse *WhisperResponse) *domain.TranscriptionResponse {
+	segments := make([]domain.Segment, 0, len(whisperResponse.Segments))
+	for _, segment := range whisperResponse.Segments {
+		text := strings.TrimSpace(segment.Text)
+		if text == "" {
+			continue
+		}
+		segments = append(segments, domain.Segment{
+			Start: segment.Start,
+			End:   segment.End,
+			Text:  text,
+		})
+	}
+
+	return &domain.TranscriptionResponse{
+		Text:     strings.TrimSpace(whisperResponse.Text),
+		Language: whisperResponse.Language,
+		Duration: whisperResponse.Duration,
+		Segments: segments,
+	}
+}
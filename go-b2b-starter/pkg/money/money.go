@@ -0,0 +1,103 @@
+package money
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// symbolAfterLanguages write the currency symbol after the amount, e.g. "1.234,50 €"
+var symbolAfterLanguages = map[string]bool{
+	"bg": true, "cs": true, "da": true, "de": true, "el": true, "es": true,
+	"et": true, "fi": true, "fr": true, "hr": true, "hu": true, "it": true,
+	"lt": true, "lv": true, "nb": true, "nn": true, "no": true, "pl": true,
+	"ro": true, "ru": true, "sk": true, "sl": true, "sv": true, "uk": true,
+}
+
+// NormalizeCurrency validates an ISO 4217 code and returns it in upper case.
+// Example: "eur" -> "EUR"
+func NormalizeCurrency(code string) (string, error) {
+	unit, err := currency.ParseISO(strings.TrimSpace(code))
+	if err != nil {
+		return "", fmt.Errorf("invalid currency code %q", code)
+	}
+	return unit.String(), nil
+}
+
+// NormalizeLocale validates a BCP 47 language tag and returns its canonical form.
+// Example: "de_de" -> "de-DE"
+func NormalizeLocale(locale string) (string, error) {
+	tag, err := language.Parse(strings.TrimSpace(locale))
+	if err != nil {
+		return "", fmt.Errorf("invalid locale %q", locale)
+	}
+	return tag.String(), nil
+}
+
+// CurrencyForLocale returns the currency used in the locale's region, if it has one.
+// Example: "en-GB" -> "GBP", "fr" -> "EUR"
+func CurrencyForLocale(locale string) (string, bool) {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		return "", false
+	}
+	unit, confidence := currency.FromTag(tag)
+	if confidence == language.No {
+		return "", false
+	}
+	return unit.String(), true
+}
+
+// MinorUnits returns the number of decimal places of the currency.
+// Example: "USD" -> 2, "JPY" -> 0
+func MinorUnits(code string) int {
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return 2
+	}
+	scale, _ := currency.Standard.Rounding(unit)
+	return scale
+}
+
+// Format renders an amount in minor units (cents for USD) for display in the locale.
+// Amounts are rendered the same way in the API, invoices and emails.
+//
+//	Format(123450, "USD", "en-US") // "$1,234.50"
+//	Format(123450, "EUR", "de-DE") // "1.234,50 €"
+//	Format(1234, "JPY", "ja-JP")   // "￥1,234"
+func Format(amount int64, code, locale string) string {
+	tag, err := language.Parse(locale)
+	if err != nil {
+		tag = language.English
+	}
+	unit, err := currency.ParseISO(code)
+	if err != nil {
+		return fmt.Sprintf("%d %s", amount, code)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	scale := MinorUnits(unit.String())
+	value := float64(amount)
+	for i := 0; i < scale; i++ {
+		value /= 10
+	}
+
+	p := message.NewPrinter(tag)
+	digits := p.Sprint(number.Decimal(value, number.Scale(scale)))
+	symbol := p.Sprint(currency.NarrowSymbol(unit))
+
+	base, _ := tag.Base()
+	if symbolAfterLanguages[base.String()] {
+		return digits + " " + symbol
+	}
+	return sign + symbol + digits
+}